@@ -0,0 +1,45 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/urfave/cli"
+	ini "gopkg.in/ini.v1"
+)
+
+var (
+	subcmdReplication = cli.Command{
+		Name:  "replication",
+		Usage: "Manage the replication of the repositories to a standby instance",
+		Subcommands: []cli.Command{
+			microcmdReplicationPromote,
+		},
+	}
+
+	microcmdReplicationPromote = cli.Command{
+		Name:        "promote",
+		Usage:       "Promote this standby instance to a primary instance",
+		Description: "Accept the pushes of all the users on this standby instance. Gitea must be restarted afterwards, and the primary instance must not replicate to it anymore.",
+		Action:      runReplicationPromote,
+	}
+)
+
+func runReplicationPromote(c *cli.Context) error {
+	setting.LoadFromExisting()
+	if !setting.Replication.Standby {
+		return errors.New("this instance is not a standby, replication.STANDBY is false")
+	}
+
+	setting.CreateOrAppendToCustomConf(func(cfg *ini.File) {
+		cfg.Section("replication").Key("STANDBY").SetValue("false")
+	})
+	fmt.Printf("This instance is now a primary instance, restart Gitea to accept the pushes of all the users.\n")
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
@@ -64,8 +65,57 @@ const (
 	ActionPublishRelease                                  // 24
 	ActionPullReviewDismissed                             // 25
 	ActionPullRequestReadyForReview                       // 26
+	ActionPublishAdvisory                                 // 27
+	ActionWorkflowRunSuccess                              // 28
+	ActionWorkflowRunFailure                              // 29
 )
 
+// actionTypeGroups are the groups of action types the feeds can be filtered by
+var actionTypeGroups = map[string][]ActionType{
+	"repo":     {ActionCreateRepo, ActionRenameRepo, ActionTransferRepo, ActionStarRepo, ActionWatchRepo},
+	"commit":   {ActionCommitRepo, ActionMirrorSyncPush},
+	"branch":   {ActionDeleteBranch, ActionMirrorSyncCreate, ActionMirrorSyncDelete},
+	"tag":      {ActionPushTag, ActionDeleteTag, ActionMirrorSyncCreate, ActionMirrorSyncDelete},
+	"issue":    {ActionCreateIssue, ActionCommentIssue, ActionCloseIssue, ActionReopenIssue},
+	"pull":     {ActionCreatePullRequest, ActionMergePullRequest, ActionClosePullRequest, ActionReopenPullRequest, ActionApprovePullRequest, ActionRejectPullRequest, ActionCommentPull, ActionPullReviewDismissed, ActionPullRequestReadyForReview},
+	"release":  {ActionPublishRelease},
+	"advisory": {ActionPublishAdvisory},
+	"workflow": {ActionWorkflowRunSuccess, ActionWorkflowRunFailure},
+}
+
+// ErrUnknownActionTypeGroup represents a "UnknownActionTypeGroup" kind of error.
+type ErrUnknownActionTypeGroup struct {
+	Name string
+}
+
+// IsErrUnknownActionTypeGroup checks if an error is a ErrUnknownActionTypeGroup.
+func IsErrUnknownActionTypeGroup(err error) bool {
+	_, ok := err.(ErrUnknownActionTypeGroup)
+	return ok
+}
+
+func (err ErrUnknownActionTypeGroup) Error() string {
+	return fmt.Sprintf("unknown action type group [name: %s]", err.Name)
+}
+
+// ParseActionTypeGroups returns the action types of a comma separated list of groups of action types,
+// e.g. "release,tag", or nil if the list is empty
+func ParseActionTypeGroups(groups string) ([]ActionType, error) {
+	var opTypes []ActionType
+	for _, name := range strings.Split(groups, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		types, ok := actionTypeGroups[name]
+		if !ok {
+			return nil, ErrUnknownActionTypeGroup{Name: name}
+		}
+		opTypes = append(opTypes, types...)
+	}
+	return opTypes, nil
+}
+
 // Action represents user operation type and other information to
 // repository. It implemented interface base.Actioner so that can be
 // used in template render.
@@ -331,12 +381,16 @@ type GetFeedsOptions struct {
 	OnlyPerformedBy bool                   // only actions performed by requested user
 	IncludeDeleted  bool                   // include deleted actions
 	Date            string                 // the day we want activity for: YYYY-MM-DD
+	Since           int64                  // the unix time we want activity since, all the activity if 0
+	OpTypes         []ActionType           // the types of actions we want activity for, all the types if empty
+	RepoIDs         []int64                // the repos we want activity for among the requested ones, all of them if empty
+	Instance        bool                   // the activity of the whole instance, each action once
 }
 
 // GetFeeds returns actions according to the provided options
 func GetFeeds(ctx context.Context, opts GetFeedsOptions) (ActionList, error) {
-	if opts.RequestedUser == nil && opts.RequestedTeam == nil && opts.RequestedRepo == nil {
-		return nil, fmt.Errorf("need at least one of these filters: RequestedUser, RequestedTeam, RequestedRepo")
+	if opts.RequestedUser == nil && opts.RequestedTeam == nil && opts.RequestedRepo == nil && !opts.Instance {
+		return nil, fmt.Errorf("need at least one of these filters: RequestedUser, RequestedTeam, RequestedRepo, Instance")
 	}
 
 	cond, err := activityQueryCondition(opts)
@@ -348,7 +402,13 @@ func GetFeeds(ctx context.Context, opts GetFeedsOptions) (ActionList, error) {
 		Select("`action`.*"). // this line will avoid select other joined table's columns
 		Join("INNER", "repository", "`repository`.id = `action`.repo_id")
 
-	opts.SetDefaultValues()
+	// the page size isn't capped by MAX_RESPONSE_ITEMS, the RSS and Atom feeds cap it by FEED_MAX_PAGING_NUM
+	if opts.PageSize <= 0 {
+		opts.PageSize = setting.API.DefaultPagingNum
+	}
+	if opts.Page <= 0 {
+		opts.Page = 1
+	}
 	sess = db.SetSessionPagination(sess, &opts)
 
 	actions := make([]*Action, 0, opts.PageSize)
@@ -361,7 +421,63 @@ func GetFeeds(ctx context.Context, opts GetFeedsOptions) (ActionList, error) {
 		return nil, fmt.Errorf("LoadAttributes: %v", err)
 	}
 
-	return actions, nil
+	return filterConfidentialActions(ctx, actions, opts.Actor)
+}
+
+// isIssueAction returns whether the action is about an issue, its content then starts with the index of the issue
+func (a *Action) isIssueAction() bool {
+	switch a.OpType {
+	case ActionCreateIssue, ActionCommentIssue, ActionCloseIssue, ActionReopenIssue:
+		return true
+	}
+	return false
+}
+
+// filterConfidentialActions leaves out the actions about the confidential issues the actor can't see
+func filterConfidentialActions(ctx context.Context, actions ActionList, actor *user_model.User) (ActionList, error) {
+	repoIDs := make(map[int64]struct{})
+	for _, a := range actions {
+		if a.isIssueAction() {
+			repoIDs[a.RepoID] = struct{}{}
+		}
+	}
+	if len(repoIDs) == 0 {
+		return actions, nil
+	}
+
+	confidentialIssues := make([]*issues_model.Issue, 0, 5)
+	if err := db.GetEngine(ctx).Where(builder.Eq{"is_confidential": true}).
+		And(builder.In("repo_id", container.KeysInt64(repoIDs))).
+		Find(&confidentialIssues); err != nil {
+		return nil, err
+	}
+	if len(confidentialIssues) == 0 {
+		return actions, nil
+	}
+
+	type issueKey struct{ repoID, index int64 }
+	hidden := make(map[issueKey]bool, len(confidentialIssues))
+	for _, issue := range confidentialIssues {
+		isHidden, err := issue.IsHiddenFrom(ctx, actor)
+		if err != nil {
+			return nil, err
+		}
+		if isHidden {
+			hidden[issueKey{issue.RepoID, issue.Index}] = true
+		}
+	}
+
+	visible := make(ActionList, 0, len(actions))
+	for _, a := range actions {
+		if a.isIssueAction() {
+			index, _ := strconv.ParseInt(a.GetIssueInfos()[0], 10, 64)
+			if hidden[issueKey{a.RepoID, index}] {
+				continue
+			}
+		}
+		visible = append(visible, a)
+	}
+	return visible, nil
 }
 
 // ActivityReadable return whether doer can read activities of user
@@ -406,6 +522,10 @@ func activityQueryCondition(opts GetFeedsOptions) (builder.Cond, error) {
 		cond = cond.And(builder.Eq{"repo_id": opts.RequestedRepo.ID})
 	}
 
+	if len(opts.RepoIDs) > 0 {
+		cond = cond.And(builder.In("repo_id", opts.RepoIDs))
+	}
+
 	if opts.RequestedTeam != nil {
 		env := organization.OrgFromUser(opts.RequestedUser).AccessibleTeamReposEnv(opts.RequestedTeam)
 		teamRepoIDs, err := env.RepoIDs(1, opts.RequestedUser.NumRepos)
@@ -423,6 +543,11 @@ func activityQueryCondition(opts GetFeedsOptions) (builder.Cond, error) {
 		}
 	}
 
+	if opts.Instance {
+		// an action is copied for every user who sees it in its feed, the copy of the performer is always recorded
+		cond = cond.And(builder.Expr("`action`.user_id = `action`.act_user_id"))
+	}
+
 	if !opts.IncludePrivate {
 		cond = cond.And(builder.Eq{"`action`.is_private": false})
 	}
@@ -430,6 +555,10 @@ func activityQueryCondition(opts GetFeedsOptions) (builder.Cond, error) {
 		cond = cond.And(builder.Eq{"is_deleted": false})
 	}
 
+	if len(opts.OpTypes) > 0 {
+		cond = cond.And(builder.In("op_type", opts.OpTypes))
+	}
+
 	if opts.Date != "" {
 		dateLow, err := time.ParseInLocation("2006-01-02", opts.Date, setting.DefaultUILocation)
 		if err != nil {
@@ -442,6 +571,10 @@ func activityQueryCondition(opts GetFeedsOptions) (builder.Cond, error) {
 		}
 	}
 
+	if opts.Since > 0 {
+		cond = cond.And(builder.Gte{"`action`.created_unix": opts.Since})
+	}
+
 	return cond, nil
 }
 
@@ -537,7 +670,8 @@ func notifyWatchers(ctx context.Context, actions ...*Action) error {
 			act.Repo.Units = nil
 
 			switch act.OpType {
-			case ActionCommitRepo, ActionPushTag, ActionDeleteTag, ActionPublishRelease, ActionDeleteBranch:
+			case ActionCommitRepo, ActionPushTag, ActionDeleteTag, ActionPublishRelease, ActionDeleteBranch, ActionPublishAdvisory,
+				ActionWorkflowRunSuccess, ActionWorkflowRunFailure:
 				if !permCode[i] {
 					continue
 				}
@@ -136,6 +136,53 @@ func TestGetFeeds2(t *testing.T) {
 	assert.Len(t, actions, 0)
 }
 
+func TestGetFeedsForInstance(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	// public actions & no login
+	actions, err := activities_model.GetFeeds(db.DefaultContext, activities_model.GetFeedsOptions{
+		Instance: true,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, actions, 2) {
+		assert.EqualValues(t, 7, actions[0].ID)
+		assert.EqualValues(t, 3, actions[1].ID)
+	}
+
+	// the copies of an action recorded for the other users are left out
+	actions, err = activities_model.GetFeeds(db.DefaultContext, activities_model.GetFeedsOptions{
+		Instance:       true,
+		Actor:          admin,
+		IncludePrivate: true,
+	})
+	assert.NoError(t, err)
+	for _, act := range actions {
+		assert.EqualValues(t, act.ActUserID, act.UserID)
+	}
+	assert.Len(t, actions, 6)
+}
+
+func TestGetFeedsSince(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	actions, err := activities_model.GetFeeds(db.DefaultContext, activities_model.GetFeedsOptions{
+		Instance: true,
+		Since:    1603011540,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, actions, 1) {
+		assert.EqualValues(t, 7, actions[0].ID)
+	}
+
+	actions, err = activities_model.GetFeeds(db.DefaultContext, activities_model.GetFeedsOptions{
+		Instance: true,
+		Since:    1603011541,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, actions)
+}
+
 func TestActivityReadable(t *testing.T) {
 	tt := []struct {
 		desc   string
@@ -272,3 +319,73 @@ func TestConsistencyUpdateAction(t *testing.T) {
 	assert.NoError(t, db.GetEngine(db.DefaultContext).Where("id = ?", id).Find(&actions))
 	unittest.CheckConsistencyFor(t, &activities_model.Action{})
 }
+
+func TestGetFeedsOpTypes(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 10})
+
+	opTypes, err := activities_model.ParseActionTypeGroups("repo")
+	assert.NoError(t, err)
+	actions, err := activities_model.GetFeeds(db.DefaultContext, activities_model.GetFeedsOptions{
+		RequestedUser:  user,
+		Actor:          user,
+		IncludePrivate: true,
+		OpTypes:        opTypes,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 3)
+
+	opTypes, err = activities_model.ParseActionTypeGroups("release, tag")
+	assert.NoError(t, err)
+	actions, err = activities_model.GetFeeds(db.DefaultContext, activities_model.GetFeedsOptions{
+		RequestedUser:  user,
+		Actor:          user,
+		IncludePrivate: true,
+		OpTypes:        opTypes,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 0)
+}
+
+func TestGetFeedsRepoIDs(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	org := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	actions, err := activities_model.GetFeeds(db.DefaultContext, activities_model.GetFeedsOptions{
+		RequestedUser:  org,
+		Actor:          user,
+		IncludePrivate: true,
+		RepoIDs:        []int64{3, 5},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, actions, 1) {
+		assert.EqualValues(t, 2, actions[0].ID)
+	}
+
+	actions, err = activities_model.GetFeeds(db.DefaultContext, activities_model.GetFeedsOptions{
+		RequestedUser:  org,
+		Actor:          user,
+		IncludePrivate: true,
+		RepoIDs:        []int64{5},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 0)
+}
+
+func TestParseActionTypeGroups(t *testing.T) {
+	opTypes, err := activities_model.ParseActionTypeGroups("")
+	assert.NoError(t, err)
+	assert.Empty(t, opTypes)
+
+	opTypes, err = activities_model.ParseActionTypeGroups("commit,Release")
+	assert.NoError(t, err)
+	assert.Equal(t, []activities_model.ActionType{activities_model.ActionCommitRepo, activities_model.ActionMirrorSyncPush, activities_model.ActionPublishRelease}, opTypes)
+
+	opTypes, err = activities_model.ParseActionTypeGroups("workflow")
+	assert.NoError(t, err)
+	assert.Equal(t, []activities_model.ActionType{activities_model.ActionWorkflowRunSuccess, activities_model.ActionWorkflowRunFailure}, opTypes)
+
+	_, err = activities_model.ParseActionTypeGroups("release,unknown")
+	assert.True(t, activities_model.IsErrUnknownActionTypeGroup(err))
+}
@@ -274,6 +274,11 @@ func createOrUpdateIssueNotifications(ctx context.Context, issueID, commentID, n
 		if !issue.IsPull && !access_model.CheckRepoUnitUser(ctx, issue.Repo, user, unit.TypeIssues) {
 			continue
 		}
+		if hidden, err := issue.IsHiddenFrom(ctx, user); err != nil {
+			return err
+		} else if hidden {
+			continue
+		}
 
 		if notificationExists(notifications, issue.ID, userID) {
 			if err = updateIssueNotification(ctx, userID, issue.ID, commentID, notificationAuthorID); err != nil {
@@ -0,0 +1,221 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package activities
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// OrgInsightDay is the duration of the period covered by an OrgInsight, in seconds
+const OrgInsightDay = 24 * 60 * 60
+
+// IssueAgeBuckets are the upper bounds, in days, of the age buckets of the open issues,
+// the last bucket holds the issues older than the last bound
+var IssueAgeBuckets = []int64{7, 30, 90, 365}
+
+// OrgInsight holds the engineering metrics of the repositories of an organization for one UTC day,
+// it is computed by a cron task once the day is over.
+type OrgInsight struct {
+	ID    int64              `xorm:"pk autoincr"`
+	OrgID int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Day   timeutil.TimeStamp `xorm:"UNIQUE(s) INDEX NOT NULL"`
+
+	PullsOpened  int64 `xorm:"NOT NULL DEFAULT 0"`
+	PullsMerged  int64 `xorm:"NOT NULL DEFAULT 0"`
+	MergeTimeSum int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	// PullsReviewed counts the pull requests which got their first review on that day
+	PullsReviewed    int64 `xorm:"NOT NULL DEFAULT 0"`
+	ReviewLatencySum int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	IssuesOpened int64 `xorm:"NOT NULL DEFAULT 0"`
+	IssuesClosed int64 `xorm:"NOT NULL DEFAULT 0"`
+	// the issues still open at the end of the day, by age bucket
+	IssuesOpenWeek    int64 `xorm:"NOT NULL DEFAULT 0"`
+	IssuesOpenMonth   int64 `xorm:"NOT NULL DEFAULT 0"`
+	IssuesOpenQuarter int64 `xorm:"NOT NULL DEFAULT 0"`
+	IssuesOpenYear    int64 `xorm:"NOT NULL DEFAULT 0"`
+	IssuesOpenOlder   int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	StatusesSucceeded int64 `xorm:"NOT NULL DEFAULT 0"`
+	StatusesFailed    int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(OrgInsight))
+}
+
+// OrgInsightList is a list of daily insights
+type OrgInsightList []*OrgInsight
+
+// Sum adds up the daily insights, the open issues are the ones of the last day
+func (l OrgInsightList) Sum() *OrgInsight {
+	sum := &OrgInsight{}
+	for _, in := range l {
+		sum.PullsOpened += in.PullsOpened
+		sum.PullsMerged += in.PullsMerged
+		sum.MergeTimeSum += in.MergeTimeSum
+		sum.PullsReviewed += in.PullsReviewed
+		sum.ReviewLatencySum += in.ReviewLatencySum
+		sum.IssuesOpened += in.IssuesOpened
+		sum.IssuesClosed += in.IssuesClosed
+		sum.StatusesSucceeded += in.StatusesSucceeded
+		sum.StatusesFailed += in.StatusesFailed
+	}
+	if len(l) > 0 {
+		last := l[len(l)-1]
+		sum.OrgID = last.OrgID
+		sum.Day = last.Day
+		sum.IssuesOpenWeek = last.IssuesOpenWeek
+		sum.IssuesOpenMonth = last.IssuesOpenMonth
+		sum.IssuesOpenQuarter = last.IssuesOpenQuarter
+		sum.IssuesOpenYear = last.IssuesOpenYear
+		sum.IssuesOpenOlder = last.IssuesOpenOlder
+	}
+	return sum
+}
+
+// InsightDayStart returns the start of the UTC day containing the timestamp
+func InsightDayStart(t timeutil.TimeStamp) timeutil.TimeStamp {
+	return t - t%OrgInsightDay
+}
+
+type countSum struct {
+	N     int64
+	Total int64
+}
+
+// CountOrgInsight computes the insight of the organization for the UTC day starting at day
+func CountOrgInsight(ctx context.Context, orgID int64, day timeutil.TimeStamp) (*OrgInsight, error) {
+	e := db.GetEngine(ctx)
+	end := day + OrgInsightDay
+	repoIDs := builder.Select("id").From("repository").Where(builder.Eq{"owner_id": orgID})
+	in := &OrgInsight{OrgID: orgID, Day: day}
+	var err error
+
+	if in.PullsOpened, err = e.Table("issue").
+		Where(builder.In("repo_id", repoIDs)).
+		And(builder.Eq{"is_pull": true}).
+		And(builder.Gte{"created_unix": day}.And(builder.Lt{"created_unix": end})).
+		Count(); err != nil {
+		return nil, err
+	}
+
+	var merged countSum
+	if _, err = e.Table("pull_request").
+		Join("INNER", "issue", "issue.id = pull_request.issue_id").
+		Where(builder.In("pull_request.base_repo_id", repoIDs)).
+		And(builder.Eq{"pull_request.has_merged": true}).
+		And(builder.Gte{"pull_request.merged_unix": day}.And(builder.Lt{"pull_request.merged_unix": end})).
+		Select("COUNT(*) AS n, COALESCE(SUM(pull_request.merged_unix - issue.created_unix), 0) AS total").
+		Get(&merged); err != nil {
+		return nil, err
+	}
+	in.PullsMerged, in.MergeTimeSum = merged.N, merged.Total
+
+	// the latency of a pull request is the time until its first review by someone else than its poster
+	var reviewed countSum
+	if _, err = e.SQL("SELECT COUNT(*) AS n, COALESCE(SUM(first_review_unix - created_unix), 0) AS total FROM ("+
+		"SELECT issue.created_unix AS created_unix, MIN(review.created_unix) AS first_review_unix FROM review "+
+		"INNER JOIN issue ON issue.id = review.issue_id "+
+		"WHERE issue.repo_id IN (SELECT id FROM repository WHERE owner_id = ?) "+
+		"AND review.type IN (?, ?, ?) AND review.reviewer_id <> issue.poster_id AND review.original_author_id = 0 "+
+		"GROUP BY issue.id, issue.created_unix) first_reviews "+
+		"WHERE first_review_unix >= ? AND first_review_unix < ?",
+		orgID, issues_model.ReviewTypeApprove, issues_model.ReviewTypeComment, issues_model.ReviewTypeReject, day, end).
+		Get(&reviewed); err != nil {
+		return nil, err
+	}
+	in.PullsReviewed, in.ReviewLatencySum = reviewed.N, reviewed.Total
+
+	issueCond := builder.In("repo_id", repoIDs).And(builder.Eq{"is_pull": false})
+	if in.IssuesOpened, err = e.Table("issue").
+		Where(issueCond).
+		And(builder.Gte{"created_unix": day}.And(builder.Lt{"created_unix": end})).
+		Count(); err != nil {
+		return nil, err
+	}
+	if in.IssuesClosed, err = e.Table("issue").
+		Where(issueCond).
+		And(builder.Eq{"is_closed": true}).
+		And(builder.Gte{"closed_unix": day}.And(builder.Lt{"closed_unix": end})).
+		Count(); err != nil {
+		return nil, err
+	}
+
+	openAtEnd := issueCond.And(builder.Eq{"is_closed": false}.Or(builder.Gte{"closed_unix": end}))
+	buckets := []*int64{&in.IssuesOpenWeek, &in.IssuesOpenMonth, &in.IssuesOpenQuarter, &in.IssuesOpenYear, &in.IssuesOpenOlder}
+	newest := end
+	for i, count := range buckets {
+		cond := openAtEnd.And(builder.Lt{"created_unix": newest})
+		if i < len(IssueAgeBuckets) {
+			newest = end - timeutil.TimeStamp(IssueAgeBuckets[i]*OrgInsightDay)
+			cond = cond.And(builder.Gte{"created_unix": newest})
+		}
+		if *count, err = e.Table("issue").Where(cond).Count(); err != nil {
+			return nil, err
+		}
+	}
+
+	statusCond := builder.In("repo_id", repoIDs).And(builder.Gte{"created_unix": day}.And(builder.Lt{"created_unix": end}))
+	if in.StatusesSucceeded, err = e.Table("commit_status").
+		Where(statusCond.And(builder.Eq{"state": api.CommitStatusSuccess})).
+		Count(); err != nil {
+		return nil, err
+	}
+	if in.StatusesFailed, err = e.Table("commit_status").
+		Where(statusCond.And(builder.In("state", api.CommitStatusFailure, api.CommitStatusError))).
+		Count(); err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+// SaveOrgInsight stores the insight, replacing the one of the same day if any
+func SaveOrgInsight(ctx context.Context, in *OrgInsight) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Delete(&OrgInsight{OrgID: in.OrgID, Day: in.Day}); err != nil {
+			return err
+		}
+		in.ID = 0
+		return db.Insert(ctx, in)
+	}, ctx)
+}
+
+// GetLatestOrgInsightDay returns the last day computed for the organization, or 0 if there is none
+func GetLatestOrgInsightDay(ctx context.Context, orgID int64) (timeutil.TimeStamp, error) {
+	in := &OrgInsight{}
+	has, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Desc("day").Get(in)
+	if err != nil || !has {
+		return 0, err
+	}
+	return in.Day, nil
+}
+
+// FindOrgInsights returns the insights of the organization for the days starting in [since, before), oldest first
+func FindOrgInsights(ctx context.Context, orgID int64, since, before timeutil.TimeStamp) (OrgInsightList, error) {
+	insights := make(OrgInsightList, 0, 30)
+	return insights, db.GetEngine(ctx).
+		Where("org_id = ?", orgID).
+		And(builder.Gte{"day": since}.And(builder.Lt{"day": before})).
+		Asc("day").
+		Find(&insights)
+}
+
+// DeleteOrgInsights deletes all the insights of the organization
+func DeleteOrgInsights(ctx context.Context, orgID int64) error {
+	_, err := db.GetEngine(ctx).Delete(&OrgInsight{OrgID: orgID})
+	return err
+}
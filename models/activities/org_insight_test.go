@@ -0,0 +1,96 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package activities_test
+
+import (
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountOrgInsight(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// 2022-10-20 00:00 UTC
+	day := timeutil.TimeStamp(1666224000)
+	assert.Equal(t, day, activities_model.InsightDayStart(day+12345))
+	daysAgo := func(n int64) timeutil.TimeStamp {
+		return day - timeutil.TimeStamp(n*activities_model.OrgInsightDay)
+	}
+
+	insert := func(beans ...interface{}) {
+		_, err := db.GetEngine(db.DefaultContext).NoAutoTime().Insert(beans...)
+		assert.NoError(t, err)
+	}
+	pull := &issues_model.Issue{RepoID: 3, Index: 100, PosterID: 2, IsPull: true, CreatedUnix: day + 3600}
+	insert(pull)
+	insert(
+		&issues_model.PullRequest{IssueID: pull.ID, Index: 100, BaseRepoID: 3, HeadRepoID: 3, HasMerged: true, MergedUnix: day + 7200},
+		// pending reviews and reviews of the poster don't count
+		&issues_model.Review{IssueID: pull.ID, ReviewerID: 1, Type: issues_model.ReviewTypePending, CreatedUnix: day + 3700},
+		&issues_model.Review{IssueID: pull.ID, ReviewerID: 2, Type: issues_model.ReviewTypeComment, CreatedUnix: day + 4000},
+		&issues_model.Review{IssueID: pull.ID, ReviewerID: 1, Type: issues_model.ReviewTypeApprove, CreatedUnix: day + 5400},
+		&issues_model.Review{IssueID: pull.ID, ReviewerID: 4, Type: issues_model.ReviewTypeReject, CreatedUnix: day + 6000},
+		&issues_model.Issue{RepoID: 3, Index: 101, PosterID: 2, CreatedUnix: day + 100},
+		&issues_model.Issue{RepoID: 3, Index: 102, PosterID: 2, CreatedUnix: daysAgo(40), IsClosed: true, ClosedUnix: day + 200},
+		&issues_model.Issue{RepoID: 5, Index: 100, PosterID: 2, CreatedUnix: daysAgo(100)},
+		&issues_model.Issue{RepoID: 32, Index: 100, PosterID: 2, CreatedUnix: daysAgo(10), IsClosed: true, ClosedUnix: day + activities_model.OrgInsightDay + 10},
+		// another owner
+		&issues_model.Issue{RepoID: 1, Index: 100, PosterID: 2, CreatedUnix: day + 10},
+		&git_model.CommitStatus{RepoID: 3, Index: 1, SHA: "a", State: api.CommitStatusSuccess, CreatedUnix: day + 10},
+		&git_model.CommitStatus{RepoID: 3, Index: 2, SHA: "a", State: api.CommitStatusFailure, CreatedUnix: day + 20},
+		&git_model.CommitStatus{RepoID: 3, Index: 3, SHA: "a", State: api.CommitStatusError, CreatedUnix: day + 30},
+		&git_model.CommitStatus{RepoID: 3, Index: 4, SHA: "a", State: api.CommitStatusPending, CreatedUnix: day + 40},
+		&git_model.CommitStatus{RepoID: 1, Index: 100, SHA: "a", State: api.CommitStatusSuccess, CreatedUnix: day + 50},
+	)
+
+	in, err := activities_model.CountOrgInsight(db.DefaultContext, 3, day)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, in.PullsOpened)
+	assert.EqualValues(t, 1, in.PullsMerged)
+	assert.EqualValues(t, 3600, in.MergeTimeSum)
+	assert.EqualValues(t, 1, in.PullsReviewed)
+	assert.EqualValues(t, 1800, in.ReviewLatencySum)
+	assert.EqualValues(t, 1, in.IssuesOpened)
+	assert.EqualValues(t, 1, in.IssuesClosed)
+	assert.EqualValues(t, 1, in.IssuesOpenWeek)
+	assert.EqualValues(t, 1, in.IssuesOpenMonth)
+	assert.EqualValues(t, 0, in.IssuesOpenQuarter)
+	assert.EqualValues(t, 1, in.IssuesOpenYear)
+	// the open issues of the fixtures
+	assert.EqualValues(t, 4, in.IssuesOpenOlder)
+	assert.EqualValues(t, 1, in.StatusesSucceeded)
+	assert.EqualValues(t, 2, in.StatusesFailed)
+
+	assert.NoError(t, activities_model.SaveOrgInsight(db.DefaultContext, in))
+	// saving the same day again replaces it
+	assert.NoError(t, activities_model.SaveOrgInsight(db.DefaultContext, &activities_model.OrgInsight{OrgID: 3, Day: day, PullsOpened: 2}))
+	assert.NoError(t, activities_model.SaveOrgInsight(db.DefaultContext, &activities_model.OrgInsight{OrgID: 3, Day: daysAgo(1), PullsOpened: 3, IssuesOpenOlder: 1}))
+	assert.EqualValues(t, 2, unittest.GetCount(t, &activities_model.OrgInsight{OrgID: 3}))
+
+	latest, err := activities_model.GetLatestOrgInsightDay(db.DefaultContext, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, day, latest)
+
+	insights, err := activities_model.FindOrgInsights(db.DefaultContext, 3, daysAgo(1), day+1)
+	assert.NoError(t, err)
+	if assert.Len(t, insights, 2) {
+		assert.Equal(t, daysAgo(1), insights[0].Day)
+		sum := insights.Sum()
+		assert.EqualValues(t, 5, sum.PullsOpened)
+		assert.EqualValues(t, 0, sum.IssuesOpenOlder, "the open issues are the ones of the last day")
+	}
+
+	assert.NoError(t, activities_model.DeleteOrgInsights(db.DefaultContext, 3))
+	unittest.AssertNotExistsBean(t, &activities_model.OrgInsight{OrgID: 3})
+}
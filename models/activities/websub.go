@@ -0,0 +1,111 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package activities
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// WebSubSubscription is the subscription of a callback to the updates of an activity feed through the WebSub hub,
+// the topic is the URL of the feed, see https://www.w3.org/TR/websub/
+type WebSubSubscription struct {
+	ID          int64              `xorm:"pk autoincr"`
+	Topic       string             `xorm:"UNIQUE(s) VARCHAR(512) NOT NULL"`
+	Callback    string             `xorm:"UNIQUE(s) VARCHAR(512) NOT NULL"`
+	Secret      string             `xorm:"TEXT"`
+	ExpiresUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(WebSubSubscription))
+}
+
+// UpsertWebSubSubscription creates the subscription of a callback to a topic or renews its lease and secret
+func UpsertWebSubSubscription(ctx context.Context, sub *WebSubSubscription) error {
+	return db.WithTx(func(ctx context.Context) error {
+		e := db.GetEngine(ctx)
+		existing := &WebSubSubscription{}
+		has, err := e.Where("topic = ? AND callback = ?", sub.Topic, sub.Callback).Get(existing)
+		if err != nil {
+			return err
+		}
+		if !has {
+			_, err = e.Insert(sub)
+			return err
+		}
+		sub.ID = existing.ID
+		_, err = e.ID(sub.ID).Cols("secret", "expires_unix").Update(sub)
+		return err
+	}, ctx)
+}
+
+// DeleteWebSubSubscription deletes the subscription of a callback to a topic
+func DeleteWebSubSubscription(ctx context.Context, topic, callback string) error {
+	_, err := db.GetEngine(ctx).Where("topic = ? AND callback = ?", topic, callback).Delete(new(WebSubSubscription))
+	return err
+}
+
+// FindWebSubSubscriptions returns the active subscriptions to the given feeds, whatever the parameters of their topic
+func FindWebSubSubscriptions(ctx context.Context, feedURLs ...string) ([]*WebSubSubscription, error) {
+	subs := make([]*WebSubSubscription, 0, len(feedURLs))
+	if len(feedURLs) == 0 {
+		return subs, nil
+	}
+	cond := builder.NewCond()
+	for _, feedURL := range feedURLs {
+		cond = cond.Or(builder.Eq{"topic": feedURL}, builder.Like{"topic", feedURL + "?%"})
+	}
+	if err := db.GetEngine(ctx).
+		Where(cond).
+		And(builder.Gt{"expires_unix": timeutil.TimeStampNow()}).
+		Find(&subs); err != nil {
+		return nil, err
+	}
+
+	// the wildcards of LIKE may match other feeds
+	feeds := make(map[string]bool, len(feedURLs))
+	for _, feedURL := range feedURLs {
+		feeds[feedURL] = true
+	}
+	filtered := subs[:0]
+	for _, sub := range subs {
+		if feedURL, _, _ := strings.Cut(sub.Topic, "?"); feeds[feedURL] {
+			filtered = append(filtered, sub)
+		}
+	}
+	return filtered, nil
+}
+
+// DeleteExpiredWebSubSubscriptions deletes the subscriptions whose lease has expired
+func DeleteExpiredWebSubSubscriptions(ctx context.Context) error {
+	_, err := db.GetEngine(ctx).Where(builder.Lte{"expires_unix": timeutil.TimeStampNow()}).Delete(new(WebSubSubscription))
+	return err
+}
+
+// FindPublicActionsAfter returns at most limit public actions with an ID greater than afterID, oldest first
+func FindPublicActionsAfter(ctx context.Context, afterID int64, limit int) ([]*Action, error) {
+	actions := make([]*Action, 0, limit)
+	return actions, db.GetEngine(ctx).
+		Where(builder.Gt{"id": afterID}).
+		And(builder.Eq{"is_private": false, "is_deleted": false}).
+		Asc("id").
+		Limit(limit).
+		Find(&actions)
+}
+
+// GetMaxActionID returns the ID of the last recorded action
+func GetMaxActionID(ctx context.Context) (int64, error) {
+	var maxID int64
+	_, err := db.GetEngine(ctx).Table("action").Select("MAX(id)").Get(&maxID)
+	return maxID, err
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package activities_test
+
+import (
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebSubSubscriptions(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	subscribe := func(topic, callback string, expires timeutil.TimeStamp) {
+		assert.NoError(t, activities_model.UpsertWebSubSubscription(db.DefaultContext, &activities_model.WebSubSubscription{
+			Topic:       topic,
+			Callback:    callback,
+			ExpiresUnix: expires,
+		}))
+	}
+	future := timeutil.TimeStampNow().Add(3600)
+	subscribe("https://try.gitea.io/user2.rss", "https://reader.example.com/a", 1)
+	subscribe("https://try.gitea.io/user2.rss?types=release", "https://reader.example.com/b", future)
+	subscribe("https://try.gitea.io/user_2.atom", "https://reader.example.com/c", future)
+	// renewing the lease keeps a single subscription
+	subscribe("https://try.gitea.io/user2.rss", "https://reader.example.com/a", future)
+	unittest.AssertCount(t, &activities_model.WebSubSubscription{}, 3)
+
+	subs, err := activities_model.FindWebSubSubscriptions(db.DefaultContext, "https://try.gitea.io/user2.rss", "https://try.gitea.io/user2.atom")
+	assert.NoError(t, err)
+	callbacks := make([]string, 0, len(subs))
+	for _, sub := range subs {
+		callbacks = append(callbacks, sub.Callback)
+	}
+	assert.ElementsMatch(t, []string{"https://reader.example.com/a", "https://reader.example.com/b"}, callbacks)
+
+	assert.NoError(t, activities_model.DeleteWebSubSubscription(db.DefaultContext, "https://try.gitea.io/user2.rss", "https://reader.example.com/a"))
+	subscribe("https://try.gitea.io/user2.rss", "https://reader.example.com/d", 1)
+	assert.NoError(t, activities_model.DeleteExpiredWebSubSubscriptions(db.DefaultContext))
+	unittest.AssertCount(t, &activities_model.WebSubSubscription{}, 2)
+}
@@ -0,0 +1,271 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package advisory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+var (
+	// ErrAdvisoryNotExist indicates an advisory not exist error
+	ErrAdvisoryNotExist = errors.New("Advisory does not exist")
+	// ErrInvalidCVEID indicates that the CVE id of an advisory is malformed
+	ErrInvalidCVEID = errors.New("CVE id is invalid")
+	// ErrInvalidCWEID indicates that a CWE id of an advisory is malformed
+	ErrInvalidCWEID = errors.New("CWE id is invalid")
+)
+
+var (
+	cveIDPattern = regexp.MustCompile(`^CVE-\d{4}-\d{4,}$`)
+	cweIDPattern = regexp.MustCompile(`^CWE-\d+$`)
+)
+
+func init() {
+	db.RegisterModel(new(Advisory))
+}
+
+// State represents the state of an advisory in the disclosure workflow
+type State string
+
+// Advisory states
+const (
+	// StateTriage is the state of a privately reported vulnerability which has not been accepted yet
+	StateTriage    State = "triage"
+	StateDraft     State = "draft"
+	StatePublished State = "published"
+	StateClosed    State = "closed"
+)
+
+// Severity represents the severity of a vulnerability
+type Severity string
+
+// Advisory severities
+const (
+	SeverityLow      Severity = "low"
+	SeverityModerate Severity = "moderate"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// Severities lists the known severities from the least to the most severe
+var Severities = []Severity{SeverityLow, SeverityModerate, SeverityHigh, SeverityCritical}
+
+// IsValid returns true if the severity is known
+func (s Severity) IsValid() bool {
+	for _, severity := range Severities {
+		if s == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// Advisory represents a security advisory of a repository
+type Advisory struct {
+	ID                 int64                  `xorm:"pk autoincr"`
+	RepoID             int64                  `xorm:"INDEX NOT NULL"`
+	Repo               *repo_model.Repository `xorm:"-"`
+	Title              string                 `xorm:"NOT NULL"`
+	Description        string                 `xorm:"TEXT"`
+	Severity           Severity               `xorm:"VARCHAR(20) NOT NULL"`
+	CVEID              string                 `xorm:"VARCHAR(32)"`
+	CWEIDs             []string               `xorm:"TEXT JSON"`
+	Ecosystem          string                 `xorm:"VARCHAR(32)"`
+	PackageName        string
+	VulnerableVersions string
+	PatchedVersions    string
+	State              State            `xorm:"VARCHAR(20) INDEX NOT NULL"`
+	ReporterID         int64            `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Reporter           *user_model.User `xorm:"-"`
+	PublisherID        int64            `xorm:"NOT NULL DEFAULT 0"`
+	Publisher          *user_model.User `xorm:"-"`
+	// ForkID is the id of the temporary private fork used to prepare the fix
+	ForkID int64                  `xorm:"NOT NULL DEFAULT 0"`
+	Fork   *repo_model.Repository `xorm:"-"`
+
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+	PublishedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+}
+
+// TableName sets the table name
+func (Advisory) TableName() string {
+	return "repository_advisory"
+}
+
+// IsPublished returns true if the advisory has been disclosed
+func (a *Advisory) IsPublished() bool {
+	return a.State == StatePublished
+}
+
+// IsOpen returns true if the advisory is still being worked on
+func (a *Advisory) IsOpen() bool {
+	return a.State == StateTriage || a.State == StateDraft
+}
+
+// Link returns the relative url of the advisory
+func (a *Advisory) Link() string {
+	return fmt.Sprintf("%s/security/advisories/%d", a.Repo.Link(), a.ID)
+}
+
+// HTMLURL returns the absolute url of the advisory
+func (a *Advisory) HTMLURL() string {
+	return fmt.Sprintf("%s/security/advisories/%d", a.Repo.HTMLURL(), a.ID)
+}
+
+// Validate checks the CVE-style metadata of the advisory
+func (a *Advisory) Validate() error {
+	if !a.Severity.IsValid() {
+		return fmt.Errorf("unknown severity %q", a.Severity)
+	}
+	if a.CVEID != "" && !cveIDPattern.MatchString(a.CVEID) {
+		return ErrInvalidCVEID
+	}
+	for _, cwe := range a.CWEIDs {
+		if !cweIDPattern.MatchString(cwe) {
+			return ErrInvalidCWEID
+		}
+	}
+	return nil
+}
+
+// LoadAttributes loads the repository, the reporter, the publisher and the fork of the advisory
+func (a *Advisory) LoadAttributes(ctx context.Context) (err error) {
+	if a.Repo == nil {
+		if a.Repo, err = repo_model.GetRepositoryByIDCtx(ctx, a.RepoID); err != nil {
+			return err
+		}
+	}
+	if a.Reporter == nil {
+		if a.Reporter, err = getPossibleUser(ctx, a.ReporterID); err != nil {
+			return err
+		}
+	}
+	if a.Publisher == nil && a.PublisherID > 0 {
+		if a.Publisher, err = getPossibleUser(ctx, a.PublisherID); err != nil {
+			return err
+		}
+	}
+	if a.Fork == nil && a.ForkID > 0 {
+		a.Fork, err = repo_model.GetRepositoryByIDCtx(ctx, a.ForkID)
+		if repo_model.IsErrRepoNotExist(err) {
+			err = nil
+		}
+	}
+	return err
+}
+
+func getPossibleUser(ctx context.Context, id int64) (*user_model.User, error) {
+	u, err := user_model.GetUserByIDCtx(ctx, id)
+	if user_model.IsErrUserNotExist(err) {
+		return user_model.NewGhostUser(), nil
+	}
+	return u, err
+}
+
+// CreateAdvisory inserts a new advisory
+func CreateAdvisory(ctx context.Context, a *Advisory) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	return db.Insert(ctx, a)
+}
+
+// GetAdvisoryByID returns the advisory of the repository with the given id
+func GetAdvisoryByID(ctx context.Context, repoID, id int64) (*Advisory, error) {
+	a := &Advisory{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(a)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrAdvisoryNotExist
+	}
+	return a, nil
+}
+
+// UpdateAdvisory updates the given columns of the advisory
+func UpdateAdvisory(ctx context.Context, a *Advisory, cols ...string) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(a.ID).Cols(cols...).Update(a)
+	return err
+}
+
+// FindAdvisoriesOptions represents the options to search advisories
+type FindAdvisoriesOptions struct {
+	db.ListOptions
+	ID     int64
+	RepoID int64
+	State  State
+	// IncludeUnpublished shows all the advisories of the repository, otherwise
+	// only published advisories and the reports of the doer are shown
+	IncludeUnpublished bool
+	DoerID             int64
+	Ecosystem          string
+	PackageName        string
+	// PublicOnly limits the advisories to the repositories which are readable anonymously
+	PublicOnly bool
+	// OrderByPublished sorts the advisories by their publication, the latest first, instead of their creation
+	OrderByPublished bool
+}
+
+func (opts *FindAdvisoriesOptions) toConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.ID > 0 {
+		cond = cond.And(builder.Eq{"id": opts.ID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.State != "" {
+		cond = cond.And(builder.Eq{"state": opts.State})
+	}
+	if opts.Ecosystem != "" {
+		cond = cond.And(builder.Eq{"ecosystem": opts.Ecosystem})
+	}
+	if opts.PackageName != "" {
+		cond = cond.And(builder.Eq{"package_name": opts.PackageName})
+	}
+	if opts.PublicOnly {
+		cond = cond.And(builder.In("repo_id", builder.Select("id").From("repository").
+			Where(repo_model.AccessibleRepositoryCondition(nil, unit.TypeCode))))
+	}
+	if !opts.IncludeUnpublished {
+		visible := builder.NewCond().Or(builder.Eq{"state": StatePublished})
+		if opts.DoerID > 0 {
+			visible = visible.Or(builder.Eq{"reporter_id": opts.DoerID})
+		}
+		cond = cond.And(visible)
+	}
+	return cond
+}
+
+// FindAdvisories returns the advisories matching the options, newest first
+func FindAdvisories(ctx context.Context, opts *FindAdvisoriesOptions) ([]*Advisory, int64, error) {
+	sess := db.GetEngine(ctx).Where(opts.toConds())
+	if opts.OrderByPublished {
+		sess = sess.Desc("published_unix")
+	}
+	sess = sess.Desc("id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, opts)
+	}
+	advisories := make([]*Advisory, 0, setting.UI.IssuePagingNum)
+	count, err := sess.FindAndCount(&advisories)
+	return advisories, count, err
+}
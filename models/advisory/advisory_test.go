@@ -0,0 +1,73 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package advisory_test
+
+import (
+	"testing"
+
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdvisoryValidate(t *testing.T) {
+	adv := &advisory_model.Advisory{Severity: advisory_model.SeverityHigh, CVEID: "CVE-2022-1234", CWEIDs: []string{"CWE-79"}}
+	assert.NoError(t, adv.Validate())
+
+	adv.Severity = "urgent"
+	assert.Error(t, adv.Validate())
+
+	adv.Severity = advisory_model.SeverityLow
+	adv.CVEID = "2022-1234"
+	assert.ErrorIs(t, adv.Validate(), advisory_model.ErrInvalidCVEID)
+
+	adv.CVEID = ""
+	adv.CWEIDs = []string{"XSS"}
+	assert.ErrorIs(t, adv.Validate(), advisory_model.ErrInvalidCWEID)
+}
+
+func TestFindAdvisories(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	create := func(state advisory_model.State, reporterID int64) *advisory_model.Advisory {
+		adv := &advisory_model.Advisory{RepoID: 1, Title: string(state), Severity: advisory_model.SeverityModerate, State: state, ReporterID: reporterID}
+		assert.NoError(t, advisory_model.CreateAdvisory(db.DefaultContext, adv))
+		return adv
+	}
+	published := create(advisory_model.StatePublished, 2)
+	report := create(advisory_model.StateTriage, 4)
+	create(advisory_model.StateDraft, 2)
+
+	find := func(opts *advisory_model.FindAdvisoriesOptions) []int64 {
+		opts.RepoID = 1
+		advisories, count, err := advisory_model.FindAdvisories(db.DefaultContext, opts)
+		assert.NoError(t, err)
+		assert.EqualValues(t, len(advisories), count)
+		ids := make([]int64, 0, len(advisories))
+		for _, adv := range advisories {
+			ids = append(ids, adv.ID)
+		}
+		return ids
+	}
+
+	// anonymous users only see published advisories
+	assert.Equal(t, []int64{published.ID}, find(&advisory_model.FindAdvisoriesOptions{}))
+	// reporters additionally see their own reports
+	assert.Equal(t, []int64{report.ID, published.ID}, find(&advisory_model.FindAdvisoriesOptions{DoerID: 4}))
+	// collaborators see everything
+	assert.Len(t, find(&advisory_model.FindAdvisoriesOptions{IncludeUnpublished: true}), 3)
+	assert.Equal(t, []int64{report.ID}, find(&advisory_model.FindAdvisoriesOptions{IncludeUnpublished: true, State: advisory_model.StateTriage}))
+
+	// an advisory created before another one but published after it
+	published.PublishedUnix = 100
+	assert.NoError(t, advisory_model.UpdateAdvisory(db.DefaultContext, published, "published_unix"))
+	latest := create(advisory_model.StatePublished, 2)
+	latest.PublishedUnix = 50
+	assert.NoError(t, advisory_model.UpdateAdvisory(db.DefaultContext, latest, "published_unix"))
+	assert.Equal(t, []int64{latest.ID, published.ID}, find(&advisory_model.FindAdvisoriesOptions{}))
+	assert.Equal(t, []int64{published.ID, latest.ID}, find(&advisory_model.FindAdvisoriesOptions{OrderByPublished: true}))
+}
@@ -0,0 +1,230 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package attestation
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// ErrAttestationNotExist represents a "AttestationNotExist" kind of error.
+type ErrAttestationNotExist struct {
+	ID int64
+}
+
+// IsErrAttestationNotExist checks if an error is a ErrAttestationNotExist.
+func IsErrAttestationNotExist(err error) bool {
+	_, ok := err.(ErrAttestationNotExist)
+	return ok
+}
+
+func (err ErrAttestationNotExist) Error() string {
+	return fmt.Sprintf("attestation does not exist [id: %d]", err.ID)
+}
+
+// ErrAttestationRequired represents a "AttestationRequired" kind of error.
+type ErrAttestationRequired struct {
+	Subject string
+}
+
+// IsErrAttestationRequired checks if an error is a ErrAttestationRequired.
+func IsErrAttestationRequired(err error) bool {
+	_, ok := err.(ErrAttestationRequired)
+	return ok
+}
+
+func (err ErrAttestationRequired) Error() string {
+	return fmt.Sprintf("an attestation is required [subject: %s]", err.Subject)
+}
+
+// SubjectType is the kind of the subject of an attestation
+type SubjectType string
+
+const (
+	// SubjectTypeCommit is the type of the attestations of a commit of a repository
+	SubjectTypeCommit SubjectType = "commit"
+	// SubjectTypeTag is the type of the attestations of a tag of a repository, which may not exist yet
+	SubjectTypeTag SubjectType = "tag"
+	// SubjectTypePackage is the type of the attestations of a package version
+	SubjectTypePackage SubjectType = "package"
+)
+
+func init() {
+	db.RegisterModel(new(Attestation))
+	db.RegisterModel(new(Digest))
+}
+
+// Attestation represents an uploaded in-toto attestation, e.g. the SLSA provenance of a build, of a commit or a tag of a
+// repository or of a package version
+type Attestation struct {
+	ID          int64       `xorm:"pk autoincr"`
+	OwnerID     int64       `xorm:"INDEX NOT NULL"`
+	RepoID      int64       `xorm:"INDEX NOT NULL DEFAULT 0"`
+	PackageID   int64       `xorm:"INDEX NOT NULL DEFAULT 0"`
+	SubjectType SubjectType `xorm:"VARCHAR(20) NOT NULL"`
+	// Subject is the commit SHA, the tag name or the package version the attestation is about
+	Subject       string `xorm:"INDEX NOT NULL"`
+	PredicateType string `xorm:"INDEX NOT NULL DEFAULT ''"`
+	// Content is the uploaded in-toto statement or the DSSE envelope signing it
+	Content     string             `xorm:"LONGTEXT"`
+	UploaderID  int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+
+	Digests  []string         `xorm:"-"`
+	Uploader *user_model.User `xorm:"-"`
+}
+
+// LoadUploader loads the user who uploaded an attestation, the ghost user if it was deleted
+func (a *Attestation) LoadUploader(ctx context.Context) (err error) {
+	if a.Uploader != nil {
+		return nil
+	}
+	a.Uploader, err = user_model.GetUserByIDCtx(ctx, a.UploaderID)
+	if user_model.IsErrUserNotExist(err) {
+		a.Uploader = user_model.NewGhostUser()
+		return nil
+	}
+	return err
+}
+
+// Digest represents a digest of a subject of an attestation written as "algorithm:value"
+type Digest struct {
+	ID            int64  `xorm:"pk autoincr"`
+	AttestationID int64  `xorm:"INDEX NOT NULL"`
+	RepoID        int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	PackageID     int64  `xorm:"INDEX(s) NOT NULL DEFAULT 0"`
+	Digest        string `xorm:"INDEX(s) NOT NULL"`
+}
+
+// TableName sets the table name of the attestation digest
+func (Digest) TableName() string {
+	return "attestation_digest"
+}
+
+// CreateAttestation stores an attestation with the digests of its subjects
+func CreateAttestation(ctx context.Context, a *Attestation) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if err := db.Insert(ctx, a); err != nil {
+			return err
+		}
+		if len(a.Digests) == 0 {
+			return nil
+		}
+		digests := make([]*Digest, 0, len(a.Digests))
+		for _, d := range a.Digests {
+			digests = append(digests, &Digest{AttestationID: a.ID, RepoID: a.RepoID, PackageID: a.PackageID, Digest: d})
+		}
+		_, err := db.GetEngine(ctx).Insert(&digests)
+		return err
+	}, ctx)
+}
+
+// GetAttestationByID returns an attestation with the digests of its subjects
+func GetAttestationByID(ctx context.Context, id int64) (*Attestation, error) {
+	a := new(Attestation)
+	has, err := db.GetEngine(ctx).ID(id).Get(a)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrAttestationNotExist{ID: id}
+	}
+	return a, LoadDigests(ctx, []*Attestation{a})
+}
+
+// FindAttestationsOptions represents the options to find the attestations of a repository or a package
+type FindAttestationsOptions struct {
+	db.ListOptions
+	RepoID        int64
+	PackageID     int64
+	SubjectType   SubjectType
+	Subject       string
+	PredicateType string
+}
+
+func (opts *FindAttestationsOptions) toConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.PackageID > 0 {
+		cond = cond.And(builder.Eq{"package_id": opts.PackageID})
+	}
+	if opts.SubjectType != "" {
+		cond = cond.And(builder.Eq{"subject_type": opts.SubjectType})
+	}
+	if opts.Subject != "" {
+		cond = cond.And(builder.Eq{"subject": opts.Subject})
+	}
+	if opts.PredicateType != "" {
+		cond = cond.And(builder.Eq{"predicate_type": opts.PredicateType})
+	}
+	return cond
+}
+
+// FindAttestations returns the attestations matching the options, the latest first, and their total count
+func FindAttestations(ctx context.Context, opts *FindAttestationsOptions) ([]*Attestation, int64, error) {
+	sess := db.GetEngine(ctx).Where(opts.toConds()).Desc("id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, opts)
+	}
+	attestations := make([]*Attestation, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&attestations)
+	if err != nil {
+		return nil, 0, err
+	}
+	return attestations, count, LoadDigests(ctx, attestations)
+}
+
+// LoadDigests loads the digests of the subjects of attestations
+func LoadDigests(ctx context.Context, attestations []*Attestation) error {
+	if len(attestations) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(attestations))
+	byID := make(map[int64]*Attestation, len(attestations))
+	for _, a := range attestations {
+		a.Digests = make([]string, 0, 2)
+		ids = append(ids, a.ID)
+		byID[a.ID] = a
+	}
+	digests := make([]*Digest, 0, len(attestations))
+	if err := db.GetEngine(ctx).In("attestation_id", ids).Asc("digest").Find(&digests); err != nil {
+		return err
+	}
+	for _, d := range digests {
+		byID[d.AttestationID].Digests = append(byID[d.AttestationID].Digests, d.Digest)
+	}
+	return nil
+}
+
+// DeleteAttestation deletes an attestation of a repository or a package
+func DeleteAttestation(ctx context.Context, a *Attestation) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(a.ID).Delete(new(Attestation)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).Where("attestation_id=?", a.ID).Delete(new(Digest))
+		return err
+	}, ctx)
+}
+
+// HasReleaseAttestation returns whether an attestation of the tag of a release or of the commit it points to exists
+func HasReleaseAttestation(ctx context.Context, repoID int64, tagName, commitSHA string) (bool, error) {
+	return db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID}.And(
+		builder.Eq{"subject_type": SubjectTypeTag, "subject": tagName}.
+			Or(builder.Eq{"subject_type": SubjectTypeCommit, "subject": commitSHA}),
+	)).Exist(new(Attestation))
+}
+
+// HasPackageAttestation returns whether an attestation of a version of a package has a subject with a digest
+func HasPackageAttestation(ctx context.Context, packageID int64, digest string) (bool, error) {
+	return db.GetEngine(ctx).Where("package_id=? AND digest=?", packageID, digest).Exist(new(Digest))
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package attestation_test
+
+import (
+	"testing"
+
+	attestation_model "code.gitea.io/gitea/models/attestation"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDigest = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+func TestCreateAttestation(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	a := &attestation_model.Attestation{
+		OwnerID:       2,
+		RepoID:        1,
+		SubjectType:   attestation_model.SubjectTypeCommit,
+		Subject:       "65f1bf27bc3bf70f64657658635e66094edbcb4d",
+		PredicateType: "https://slsa.dev/provenance/v1",
+		Content:       "{}",
+		UploaderID:    2,
+		Digests:       []string{"gitcommit:65f1bf27bc3bf70f64657658635e66094edbcb4d"},
+	}
+	assert.NoError(t, attestation_model.CreateAttestation(db.DefaultContext, a))
+	unittest.AssertExistsAndLoadBean(t, &attestation_model.Digest{AttestationID: a.ID, RepoID: 1})
+
+	a, err := attestation_model.GetAttestationByID(db.DefaultContext, a.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gitcommit:65f1bf27bc3bf70f64657658635e66094edbcb4d"}, a.Digests)
+
+	attestations, count, err := attestation_model.FindAttestations(db.DefaultContext, &attestation_model.FindAttestationsOptions{RepoID: 1})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	if assert.Len(t, attestations, 2) {
+		assert.Equal(t, a.ID, attestations[0].ID)
+		assert.Equal(t, []string{testDigest}, attestations[1].Digests)
+	}
+
+	attestations, _, err = attestation_model.FindAttestations(db.DefaultContext, &attestation_model.FindAttestationsOptions{
+		RepoID:        1,
+		PredicateType: "https://slsa.dev/provenance/v0.2",
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, attestations, 1) {
+		assert.EqualValues(t, 1, attestations[0].ID)
+	}
+
+	assert.NoError(t, attestation_model.DeleteAttestation(db.DefaultContext, a))
+	unittest.AssertNotExistsBean(t, &attestation_model.Attestation{ID: a.ID})
+	unittest.AssertNotExistsBean(t, &attestation_model.Digest{AttestationID: a.ID})
+
+	_, err = attestation_model.GetAttestationByID(db.DefaultContext, a.ID)
+	assert.True(t, attestation_model.IsErrAttestationNotExist(err))
+}
+
+func TestHasAttestation(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	has, err := attestation_model.HasReleaseAttestation(db.DefaultContext, 1, "v1.1", "65f1bf27bc3bf70f64657658635e66094edbcb4d")
+	assert.NoError(t, err)
+	assert.True(t, has)
+	has, err = attestation_model.HasReleaseAttestation(db.DefaultContext, 1, "v2.0", "65f1bf27bc3bf70f64657658635e66094edbcb4d")
+	assert.NoError(t, err)
+	assert.False(t, has)
+	has, err = attestation_model.HasReleaseAttestation(db.DefaultContext, 2, "v1.1", "")
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	assert.NoError(t, attestation_model.CreateAttestation(db.DefaultContext, &attestation_model.Attestation{
+		OwnerID:     2,
+		PackageID:   1,
+		SubjectType: attestation_model.SubjectTypePackage,
+		Subject:     testDigest,
+		Digests:     []string{testDigest},
+	}))
+	has, err = attestation_model.HasPackageAttestation(db.DefaultContext, 1, testDigest)
+	assert.NoError(t, err)
+	assert.True(t, has)
+	has, err = attestation_model.HasPackageAttestation(db.DefaultContext, 2, testDigest)
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
@@ -0,0 +1,197 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// userCodeAlphabet are the characters of the user codes, without vowels to avoid forming words and
+// without the characters which are easily confused, see section 6.1 of RFC 8628
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+// userCodeLength is the number of characters of a user code, it is shown as two groups of four characters
+const userCodeLength = 8
+
+// OAuth2DeviceCode is a pending authorization of a device, see RFC 8628. The device polls the access token endpoint with
+// the device code while the user approves the request by entering the user code in the browser.
+type OAuth2DeviceCode struct {
+	ID             int64  `xorm:"pk autoincr"`
+	ApplicationID  int64  `xorm:"INDEX"`
+	DeviceCode     string `xorm:"INDEX unique"`
+	UserCode       string `xorm:"INDEX unique"`
+	Scope          string `xorm:"TEXT"`
+	GrantID        int64
+	IsDenied       bool `xorm:"NOT NULL DEFAULT false"`
+	LastPolledUnix timeutil.TimeStamp
+	ValidUntil     timeutil.TimeStamp `xorm:"index"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(OAuth2DeviceCode))
+}
+
+// TableName sets the table name to `oauth2_device_code`
+func (code *OAuth2DeviceCode) TableName() string {
+	return "oauth2_device_code"
+}
+
+// FormattedUserCode returns the user code as it is shown to the user
+func (code *OAuth2DeviceCode) FormattedUserCode() string {
+	if len(code.UserCode) != userCodeLength {
+		return code.UserCode
+	}
+	return code.UserCode[:userCodeLength/2] + "-" + code.UserCode[userCodeLength/2:]
+}
+
+// IsExpired returns whether the device code can't be used anymore
+func (code *OAuth2DeviceCode) IsExpired() bool {
+	return code.ValidUntil <= timeutil.TimeStampNow()
+}
+
+// IsApproved returns whether the user approved the request of the device
+func (code *OAuth2DeviceCode) IsApproved() bool {
+	return code.GrantID > 0
+}
+
+// Approve records the grant given by the user to the device
+func (code *OAuth2DeviceCode) Approve(ctx context.Context, grantID int64) error {
+	code.GrantID = grantID
+	_, err := db.GetEngine(ctx).ID(code.ID).Cols("grant_id").Update(code)
+	return err
+}
+
+// Deny records that the user refused the request of the device
+func (code *OAuth2DeviceCode) Deny(ctx context.Context) error {
+	code.IsDenied = true
+	_, err := db.GetEngine(ctx).ID(code.ID).Cols("is_denied").Update(code)
+	return err
+}
+
+// UpdatePolled records a token request of the device and returns whether it polled faster than the given interval
+func (code *OAuth2DeviceCode) UpdatePolled(ctx context.Context, interval int64) (tooFast bool, err error) {
+	now := timeutil.TimeStampNow()
+	tooFast = code.LastPolledUnix > 0 && now < code.LastPolledUnix.Add(interval)
+	code.LastPolledUnix = now
+	_, err = db.GetEngine(ctx).ID(code.ID).Cols("last_polled_unix").Update(code)
+	return tooFast, err
+}
+
+// Invalidate deletes the device code from the database to invalidate it
+func (code *OAuth2DeviceCode) Invalidate(ctx context.Context) error {
+	_, err := db.GetEngine(ctx).ID(code.ID).NoAutoCondition().Delete(code)
+	return err
+}
+
+func generateUserCode() (string, error) {
+	var sb strings.Builder
+	max := big.NewInt(int64(len(userCodeAlphabet)))
+	for i := 0; i < userCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(userCodeAlphabet[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// NormalizeUserCode returns the user code as it is stored from the input of a user, which may be lower case or
+// contain dashes and spaces
+func NormalizeUserCode(input string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToUpper(input) {
+		if r >= 'A' && r <= 'Z' {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// GenerateDeviceCode generates a new device code for the application and saves it to the database.
+// The expired device codes are cleaned up at the same time.
+func (app *OAuth2Application) GenerateDeviceCode(ctx context.Context, scope string, expiresIn int64) (*OAuth2DeviceCode, error) {
+	if _, err := db.GetEngine(ctx).Where("valid_until <= ?", timeutil.TimeStampNow()).Delete(new(OAuth2DeviceCode)); err != nil {
+		return nil, err
+	}
+
+	rBytes, err := util.CryptoRandomBytes(32)
+	if err != nil {
+		return nil, err
+	}
+	code := &OAuth2DeviceCode{
+		ApplicationID: app.ID,
+		// Add a prefix to the base32, like the authorization codes
+		DeviceCode: "gtd_" + base32Lower.EncodeToString(rBytes),
+		Scope:      scope,
+		ValidUntil: timeutil.TimeStampNow().Add(expiresIn),
+	}
+	// the user codes are short, retry in the unlikely case of a collision with a pending one
+	for i := 0; i < 5; i++ {
+		if code.UserCode, err = generateUserCode(); err != nil {
+			return nil, err
+		}
+		has, err := db.GetEngine(ctx).Exist(&OAuth2DeviceCode{UserCode: code.UserCode})
+		if err != nil {
+			return nil, err
+		}
+		if !has {
+			return code, db.Insert(ctx, code)
+		}
+	}
+	return nil, errors.New("cannot generate a unique user code")
+}
+
+// GetOAuth2DeviceCodeByDeviceCode returns the device code of an application by its device code
+func GetOAuth2DeviceCodeByDeviceCode(ctx context.Context, appID int64, deviceCode string) (*OAuth2DeviceCode, error) {
+	code := new(OAuth2DeviceCode)
+	if has, err := db.GetEngine(ctx).Where("application_id = ? AND device_code = ?", appID, deviceCode).Get(code); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrOAuth2DeviceCodeNotExist{}
+	}
+	return code, nil
+}
+
+// GetOAuth2DeviceCodeByUserCode returns the pending device code matching the user code entered by a user
+func GetOAuth2DeviceCodeByUserCode(ctx context.Context, userCode string) (*OAuth2DeviceCode, error) {
+	userCode = NormalizeUserCode(userCode)
+	if len(userCode) != userCodeLength {
+		return nil, ErrOAuth2DeviceCodeNotExist{UserCode: userCode}
+	}
+	code := new(OAuth2DeviceCode)
+	if has, err := db.GetEngine(ctx).Where("user_code = ?", userCode).Get(code); err != nil {
+		return nil, err
+	} else if !has || code.IsExpired() || code.IsApproved() || code.IsDenied {
+		return nil, ErrOAuth2DeviceCodeNotExist{UserCode: userCode}
+	}
+	return code, nil
+}
+
+// ErrOAuth2DeviceCodeNotExist represents a "OAuth2DeviceCodeNotExist" kind of error.
+type ErrOAuth2DeviceCodeNotExist struct {
+	UserCode string
+}
+
+// IsErrOAuth2DeviceCodeNotExist checks if an error is a ErrOAuth2DeviceCodeNotExist.
+func IsErrOAuth2DeviceCodeNotExist(err error) bool {
+	_, ok := err.(ErrOAuth2DeviceCodeNotExist)
+	return ok
+}
+
+// Error returns the error message
+func (err ErrOAuth2DeviceCodeNotExist) Error() string {
+	return fmt.Sprintf("device code does not exist [user_code: %s]", err.UserCode)
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeUserCode(t *testing.T) {
+	assert.Equal(t, "BCDFGHJK", auth_model.NormalizeUserCode("bcdf-ghjk"))
+	assert.Equal(t, "BCDFGHJK", auth_model.NormalizeUserCode(" BCDF GHJK "))
+}
+
+func TestOAuth2Application_GenerateDeviceCode(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	app := unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2Application{ID: 1})
+
+	code, err := app.GenerateDeviceCode(db.DefaultContext, "openid", 600)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(code.DeviceCode, "gtd_"))
+	assert.Len(t, code.UserCode, 8)
+	assert.Equal(t, code.UserCode[:4]+"-"+code.UserCode[4:], code.FormattedUserCode())
+	assert.False(t, code.IsExpired())
+	unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2DeviceCode{DeviceCode: code.DeviceCode})
+
+	found, err := auth_model.GetOAuth2DeviceCodeByDeviceCode(db.DefaultContext, app.ID, code.DeviceCode)
+	assert.NoError(t, err)
+	assert.Equal(t, code.ID, found.ID)
+	_, err = auth_model.GetOAuth2DeviceCodeByDeviceCode(db.DefaultContext, app.ID+1, code.DeviceCode)
+	assert.True(t, auth_model.IsErrOAuth2DeviceCodeNotExist(err))
+
+	// a new code cleans up the expired ones
+	expired, err := app.GenerateDeviceCode(db.DefaultContext, "", -1)
+	assert.NoError(t, err)
+	assert.True(t, expired.IsExpired())
+	_, err = app.GenerateDeviceCode(db.DefaultContext, "", 600)
+	assert.NoError(t, err)
+	unittest.AssertNotExistsBean(t, &auth_model.OAuth2DeviceCode{ID: expired.ID})
+}
+
+func TestGetOAuth2DeviceCodeByUserCode(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	app := unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2Application{ID: 1})
+	code, err := app.GenerateDeviceCode(db.DefaultContext, "openid", 600)
+	assert.NoError(t, err)
+
+	found, err := auth_model.GetOAuth2DeviceCodeByUserCode(db.DefaultContext, strings.ToLower(code.FormattedUserCode()))
+	assert.NoError(t, err)
+	assert.Equal(t, code.ID, found.ID)
+
+	_, err = auth_model.GetOAuth2DeviceCodeByUserCode(db.DefaultContext, "BCD")
+	assert.True(t, auth_model.IsErrOAuth2DeviceCodeNotExist(err))
+
+	// an approved code can't be entered again
+	assert.NoError(t, found.Approve(db.DefaultContext, 1))
+	assert.True(t, found.IsApproved())
+	_, err = auth_model.GetOAuth2DeviceCodeByUserCode(db.DefaultContext, code.UserCode)
+	assert.True(t, auth_model.IsErrOAuth2DeviceCodeNotExist(err))
+}
+
+func TestOAuth2DeviceCode_UpdatePolled(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	app := unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2Application{ID: 1})
+	code, err := app.GenerateDeviceCode(db.DefaultContext, "", 600)
+	assert.NoError(t, err)
+
+	tooFast, err := code.UpdatePolled(db.DefaultContext, 5)
+	assert.NoError(t, err)
+	assert.False(t, tooFast)
+	tooFast, err = code.UpdatePolled(db.DefaultContext, 5)
+	assert.NoError(t, err)
+	assert.True(t, tooFast)
+
+	assert.NoError(t, code.Deny(db.DefaultContext))
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2DeviceCode{ID: code.ID}).IsDenied)
+	assert.NoError(t, code.Invalidate(db.DefaultContext))
+	unittest.AssertNotExistsBean(t, &auth_model.OAuth2DeviceCode{ID: code.ID})
+}
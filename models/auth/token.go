@@ -48,8 +48,40 @@ func (err ErrAccessTokenEmpty) Error() string {
 	return "access token is empty"
 }
 
+// ErrAccessTokenNameExist represents a "AccessTokenNameExist" kind of error.
+type ErrAccessTokenNameExist struct {
+	Name string
+}
+
+// IsErrAccessTokenNameExist checks if an error is a ErrAccessTokenNameExist.
+func IsErrAccessTokenNameExist(err error) bool {
+	_, ok := err.(ErrAccessTokenNameExist)
+	return ok
+}
+
+func (err ErrAccessTokenNameExist) Error() string {
+	return fmt.Sprintf("access token name already exists [name: %s]", err.Name)
+}
+
 var successfulAccessTokenCache *lru.Cache
 
+// AccessTokenScope restricts what an access token can do on behalf of its owner
+type AccessTokenScope string
+
+const (
+	// AccessTokenScopeAll allows everything the owner of the token can do
+	AccessTokenScopeAll AccessTokenScope = ""
+	// AccessTokenScopeRead only allows the API requests and git operations which don't change anything
+	AccessTokenScopeRead AccessTokenScope = "read"
+	// AccessTokenScopeFeed only allows reading the RSS and Atom feeds, e.g. by a feed reader
+	AccessTokenScopeFeed AccessTokenScope = "feed"
+)
+
+// IsValid returns whether the scope is known
+func (s AccessTokenScope) IsValid() bool {
+	return s == AccessTokenScopeAll || s == AccessTokenScopeRead || s == AccessTokenScopeFeed
+}
+
 // AccessToken represents a personal access token.
 type AccessToken struct {
 	ID             int64 `xorm:"pk autoincr"`
@@ -58,7 +90,8 @@ type AccessToken struct {
 	Token          string `xorm:"-"`
 	TokenHash      string `xorm:"UNIQUE"` // sha256 of token
 	TokenSalt      string
-	TokenLastEight string `xorm:"token_last_eight"`
+	TokenLastEight string           `xorm:"token_last_eight"`
+	Scope          AccessTokenScope `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
 
 	CreatedUnix       timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix       timeutil.TimeStamp `xorm:"INDEX updated"`
@@ -0,0 +1,261 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package codescanning
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// ErrAlertNotExist indicates a code scanning alert not exist error
+var ErrAlertNotExist = errors.New("Code scanning alert does not exist")
+
+func init() {
+	db.RegisterModel(new(Alert))
+	db.RegisterModel(new(AlertInstance))
+}
+
+// AlertState represents the state of a code scanning alert
+type AlertState int
+
+const (
+	// AlertStateOpen the alert was found in the latest analysis and has not been dismissed
+	AlertStateOpen AlertState = iota
+	// AlertStateDismissed the alert was dismissed by a user
+	AlertStateDismissed
+	// AlertStateFixed the alert was not found anymore in an analysis of the default branch
+	AlertStateFixed
+)
+
+var alertStateNames = map[AlertState]string{
+	AlertStateOpen:      "open",
+	AlertStateDismissed: "dismissed",
+	AlertStateFixed:     "fixed",
+}
+
+// String returns the name of the state
+func (s AlertState) String() string {
+	return alertStateNames[s]
+}
+
+// AlertStateFromString returns the state with the given name
+func AlertStateFromString(name string) (AlertState, bool) {
+	for state, n := range alertStateNames {
+		if n == name {
+			return state, true
+		}
+	}
+	return AlertStateOpen, false
+}
+
+// Alert represents a finding of a code scanning tool, deduplicated across analyses by its fingerprint
+type Alert struct {
+	ID               int64  `xorm:"pk autoincr"`
+	RepoID           int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Fingerprint      string `xorm:"VARCHAR(64) UNIQUE(s) NOT NULL"`
+	ToolName         string `xorm:"NOT NULL DEFAULT ''"`
+	Category         string `xorm:"NOT NULL DEFAULT ''"`
+	RuleID           string `xorm:"NOT NULL DEFAULT ''"`
+	RuleDescription  string `xorm:"TEXT"`
+	Severity         string `xorm:"NOT NULL DEFAULT ''"`
+	Message          string `xorm:"TEXT"`
+	Path             string `xorm:"TEXT"`
+	StartLine        int
+	EndLine          int
+	State            AlertState         `xorm:"INDEX NOT NULL DEFAULT 0"`
+	FirstSeenSHA     string             `xorm:"VARCHAR(40)"`
+	LastSeenSHA      string             `xorm:"VARCHAR(40)"`
+	DismissedByID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	DismissedBy      *user_model.User   `xorm:"-"`
+	DismissedReason  string             `xorm:"NOT NULL DEFAULT ''"`
+	DismissedComment string             `xorm:"TEXT"`
+	DismissedUnix    timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	FixedUnix        timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix      timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix      timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the table name of the alert
+func (Alert) TableName() string {
+	return "code_scanning_alert"
+}
+
+// LoadDismissedBy loads the user who dismissed the alert
+func (a *Alert) LoadDismissedBy(ctx context.Context) (err error) {
+	if a.DismissedByID == 0 || a.DismissedBy != nil {
+		return nil
+	}
+	a.DismissedBy, err = user_model.GetUserByIDCtx(ctx, a.DismissedByID)
+	if user_model.IsErrUserNotExist(err) {
+		a.DismissedBy = user_model.NewGhostUser()
+		err = nil
+	}
+	return err
+}
+
+// AlertInstance represents the occurrence of an alert in the analysis of a commit
+type AlertInstance struct {
+	ID         int64  `xorm:"pk autoincr"`
+	AlertID    int64  `xorm:"INDEX NOT NULL"`
+	AnalysisID int64  `xorm:"INDEX NOT NULL"`
+	RepoID     int64  `xorm:"INDEX(s) NOT NULL"`
+	CommitSHA  string `xorm:"VARCHAR(40) INDEX(s) NOT NULL"`
+	Path       string `xorm:"TEXT"`
+	StartLine  int
+	EndLine    int
+	Message    string `xorm:"TEXT"`
+	Alert      *Alert `xorm:"-"`
+}
+
+// TableName sets the table name of the alert instance
+func (AlertInstance) TableName() string {
+	return "code_scanning_alert_instance"
+}
+
+// GetAlertByID returns the alert of the repository with the given id
+func GetAlertByID(ctx context.Context, repoID, id int64) (*Alert, error) {
+	alert := &Alert{ID: id, RepoID: repoID}
+	has, err := db.GetEngine(ctx).Get(alert)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrAlertNotExist
+	}
+	return alert, nil
+}
+
+// GetAlertByFingerprint returns the alert of the repository with the given fingerprint
+func GetAlertByFingerprint(ctx context.Context, repoID int64, fingerprint string) (*Alert, error) {
+	alert := &Alert{RepoID: repoID, Fingerprint: fingerprint}
+	has, err := db.GetEngine(ctx).Get(alert)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrAlertNotExist
+	}
+	return alert, nil
+}
+
+// InsertAlert inserts an alert
+func InsertAlert(ctx context.Context, alert *Alert) error {
+	return db.Insert(ctx, alert)
+}
+
+// UpdateAlertCols updates the given columns of an alert
+func UpdateAlertCols(ctx context.Context, alert *Alert, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(alert.ID).Cols(cols...).Update(alert)
+	return err
+}
+
+// InsertAlertInstances inserts the occurrences of alerts in an analysis
+func InsertAlertInstances(ctx context.Context, instances []*AlertInstance) error {
+	if len(instances) == 0 {
+		return nil
+	}
+	_, err := db.GetEngine(ctx).Insert(&instances)
+	return err
+}
+
+// FixMissingAlerts marks the open alerts of a tool which were not seen in an analysis as fixed
+func FixMissingAlerts(ctx context.Context, repoID int64, toolName, category string, seenAlertIDs []int64) error {
+	cond := builder.Eq{
+		"repo_id":   repoID,
+		"tool_name": toolName,
+		"category":  category,
+		"state":     AlertStateOpen,
+	}.And(builder.NotIn("id", seenAlertIDs))
+	_, err := db.GetEngine(ctx).Where(cond).Cols("state", "fixed_unix").Update(&Alert{
+		State:     AlertStateFixed,
+		FixedUnix: timeutil.TimeStampNow(),
+	})
+	return err
+}
+
+// FindAlertsOptions represents the options to find alerts of a repository
+type FindAlertsOptions struct {
+	db.ListOptions
+	RepoID    int64
+	State     *AlertState
+	CommitSHA string
+	ToolName  string
+	Severity  string
+}
+
+func (opts *FindAlertsOptions) toConds() builder.Cond {
+	cond := builder.NewCond()
+	cond = cond.And(builder.Eq{"code_scanning_alert.repo_id": opts.RepoID})
+	if opts.State != nil {
+		cond = cond.And(builder.Eq{"code_scanning_alert.state": *opts.State})
+	}
+	if opts.CommitSHA != "" {
+		cond = cond.And(builder.In("code_scanning_alert.id", builder.Select("alert_id").
+			From("code_scanning_alert_instance").
+			Where(builder.Eq{"repo_id": opts.RepoID, "commit_sha": opts.CommitSHA})))
+	}
+	if opts.ToolName != "" {
+		cond = cond.And(builder.Eq{"code_scanning_alert.tool_name": opts.ToolName})
+	}
+	if opts.Severity != "" {
+		cond = cond.And(builder.Eq{"code_scanning_alert.severity": opts.Severity})
+	}
+	return cond
+}
+
+// FindAlerts returns the alerts matching the options and their total count
+func FindAlerts(ctx context.Context, opts *FindAlertsOptions) ([]*Alert, int64, error) {
+	sess := db.GetEngine(ctx).Where(opts.toConds()).Desc("code_scanning_alert.id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, opts)
+	}
+	alerts := make([]*Alert, 0, 10)
+	count, err := sess.FindAndCount(&alerts)
+	return alerts, count, err
+}
+
+// FindCommitAlertInstances returns the occurrences of not dismissed alerts in the analyses of a commit with their alert loaded
+func FindCommitAlertInstances(ctx context.Context, repoID int64, commitSHA string) ([]*AlertInstance, error) {
+	instances := make([]*AlertInstance, 0, 10)
+	if err := db.GetEngine(ctx).
+		Where("repo_id = ? AND commit_sha = ?", repoID, commitSHA).
+		Asc("path", "start_line").
+		Find(&instances); err != nil {
+		return nil, err
+	}
+
+	alertIDs := make([]int64, 0, len(instances))
+	for _, instance := range instances {
+		alertIDs = append(alertIDs, instance.AlertID)
+	}
+	alerts := make(map[int64]*Alert, len(alertIDs))
+	if err := db.GetEngine(ctx).In("id", alertIDs).Find(&alerts); err != nil {
+		return nil, err
+	}
+
+	result := instances[:0]
+	for _, instance := range instances {
+		if alert, ok := alerts[instance.AlertID]; ok && alert.State != AlertStateDismissed {
+			instance.Alert = alert
+			result = append(result, instance)
+		}
+	}
+	return result, nil
+}
+
+// CountNewAlerts returns the number of not dismissed alerts found in the head commit but not in the base commit
+func CountNewAlerts(ctx context.Context, repoID int64, headSHA, baseSHA string) (int64, error) {
+	return db.GetEngine(ctx).
+		Where(builder.Eq{"repo_id": repoID}.And(builder.Neq{"state": AlertStateDismissed})).
+		And(builder.In("id", builder.Select("alert_id").From("code_scanning_alert_instance").
+			Where(builder.Eq{"repo_id": repoID, "commit_sha": headSHA}))).
+		And(builder.NotIn("id", builder.Select("alert_id").From("code_scanning_alert_instance").
+			Where(builder.Eq{"repo_id": repoID, "commit_sha": baseSHA}))).
+		Count(new(Alert))
+}
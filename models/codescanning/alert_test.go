@@ -0,0 +1,90 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package codescanning_test
+
+import (
+	"testing"
+
+	codescanning_model "code.gitea.io/gitea/models/codescanning"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	baseSHA = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+	headSHA = "985f0301dba5e7b34be866819cd15ad3d8f508ee"
+)
+
+func insertAlert(t *testing.T, fingerprint string, shas ...string) *codescanning_model.Alert {
+	alert := &codescanning_model.Alert{
+		RepoID:      1,
+		Fingerprint: fingerprint,
+		ToolName:    "gosec",
+		RuleID:      "G101",
+		Severity:    "error",
+		Path:        "main.go",
+	}
+	assert.NoError(t, codescanning_model.InsertAlert(db.DefaultContext, alert))
+	for _, sha := range shas {
+		assert.NoError(t, codescanning_model.InsertAlertInstances(db.DefaultContext, []*codescanning_model.AlertInstance{
+			{AlertID: alert.ID, RepoID: 1, CommitSHA: sha, Path: "main.go", StartLine: 3},
+		}))
+	}
+	return alert
+}
+
+func TestCountNewAlerts(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	insertAlert(t, "old", baseSHA, headSHA)
+	introduced := insertAlert(t, "new", headSHA)
+
+	count, err := codescanning_model.CountNewAlerts(db.DefaultContext, 1, headSHA, baseSHA)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	instances, err := codescanning_model.FindCommitAlertInstances(db.DefaultContext, 1, headSHA)
+	assert.NoError(t, err)
+	assert.Len(t, instances, 2)
+
+	introduced.State = codescanning_model.AlertStateDismissed
+	assert.NoError(t, codescanning_model.UpdateAlertCols(db.DefaultContext, introduced, "state"))
+	count, err = codescanning_model.CountNewAlerts(db.DefaultContext, 1, headSHA, baseSHA)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+
+	instances, err = codescanning_model.FindCommitAlertInstances(db.DefaultContext, 1, headSHA)
+	assert.NoError(t, err)
+	assert.Len(t, instances, 1)
+}
+
+func TestFixMissingAlerts(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	kept := insertAlert(t, "kept", headSHA)
+	missing := insertAlert(t, "missing", baseSHA)
+
+	assert.NoError(t, codescanning_model.FixMissingAlerts(db.DefaultContext, 1, "gosec", "", []int64{kept.ID}))
+
+	alert, err := codescanning_model.GetAlertByID(db.DefaultContext, 1, missing.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, codescanning_model.AlertStateFixed, alert.State)
+	assert.NotZero(t, alert.FixedUnix)
+
+	alert, err = codescanning_model.GetAlertByFingerprint(db.DefaultContext, 1, "kept")
+	assert.NoError(t, err)
+	assert.Equal(t, codescanning_model.AlertStateOpen, alert.State)
+
+	state := codescanning_model.AlertStateFixed
+	alerts, count, err := codescanning_model.FindAlerts(db.DefaultContext, &codescanning_model.FindAlertsOptions{RepoID: 1, State: &state})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	assert.Len(t, alerts, 1)
+
+	_, err = codescanning_model.GetAlertByID(db.DefaultContext, 2, missing.ID)
+	assert.ErrorIs(t, err, codescanning_model.ErrAlertNotExist)
+}
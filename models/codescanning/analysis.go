@@ -0,0 +1,55 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package codescanning
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+func init() {
+	db.RegisterModel(new(Analysis))
+}
+
+// Analysis represents an uploaded SARIF report of one tool for a commit
+type Analysis struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"INDEX(s) NOT NULL"`
+	CommitSHA   string             `xorm:"VARCHAR(40) INDEX(s) NOT NULL"`
+	Ref         string             `xorm:"NOT NULL DEFAULT ''"`
+	ToolName    string             `xorm:"NOT NULL DEFAULT ''"`
+	ToolVersion string             `xorm:"NOT NULL DEFAULT ''"`
+	Category    string             `xorm:"NOT NULL DEFAULT ''"`
+	NumResults  int                `xorm:"NOT NULL DEFAULT 0"`
+	UploaderID  int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName sets the table name of the analysis
+func (Analysis) TableName() string {
+	return "code_scanning_analysis"
+}
+
+// InsertAnalysis inserts an analysis
+func InsertAnalysis(ctx context.Context, analysis *Analysis) error {
+	return db.Insert(ctx, analysis)
+}
+
+// HasAnalysisForCommit returns true if any report has been uploaded for the commit
+func HasAnalysisForCommit(ctx context.Context, repoID int64, commitSHA string) (bool, error) {
+	return db.GetEngine(ctx).Where("repo_id = ? AND commit_sha = ?", repoID, commitSHA).Exist(new(Analysis))
+}
+
+// GetLatestAnalyzedCommit returns the commit of the latest analysis uploaded for the ref, or "" if there is none
+func GetLatestAnalyzedCommit(ctx context.Context, repoID int64, ref string) (string, error) {
+	analysis := new(Analysis)
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND ref = ?", repoID, ref).Desc("id").Get(analysis)
+	if err != nil || !has {
+		return "", err
+	}
+	return analysis.CommitSHA, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package coverage
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+func init() {
+	db.RegisterModel(new(Report))
+	db.RegisterModel(new(File))
+}
+
+// Report represents an uploaded coverage report of a commit
+type Report struct {
+	ID           int64              `xorm:"pk autoincr"`
+	RepoID       int64              `xorm:"INDEX(s) NOT NULL"`
+	CommitSHA    string             `xorm:"VARCHAR(40) INDEX(s) NOT NULL"`
+	Flag         string             `xorm:"NOT NULL DEFAULT ''"`
+	Format       string             `xorm:"NOT NULL DEFAULT ''"`
+	LinesCovered int64              `xorm:"NOT NULL DEFAULT 0"`
+	LinesValid   int64              `xorm:"NOT NULL DEFAULT 0"`
+	UploaderID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName sets the table name of the coverage report
+func (Report) TableName() string {
+	return "coverage_report"
+}
+
+// File represents the line coverage of a source file in a report
+type File struct {
+	ID             int64  `xorm:"pk autoincr"`
+	ReportID       int64  `xorm:"INDEX NOT NULL"`
+	RepoID         int64  `xorm:"INDEX(s) NOT NULL"`
+	CommitSHA      string `xorm:"VARCHAR(40) INDEX(s) NOT NULL"`
+	Path           string `xorm:"TEXT"`
+	CoveredLines   []int  `xorm:"JSON TEXT"`
+	UncoveredLines []int  `xorm:"JSON TEXT"`
+}
+
+// TableName sets the table name of the coverage file
+func (File) TableName() string {
+	return "coverage_file"
+}
+
+// InsertReport inserts a report with its files, replacing the report of the commit with the same flag
+func InsertReport(ctx context.Context, report *Report, files []*File) error {
+	ctx, committer, err := db.TxContext()
+	if err != nil {
+		return err
+	}
+	defer committer.Close()
+
+	old := make([]*Report, 0, 1)
+	if err := db.GetEngine(ctx).Where("repo_id = ? AND commit_sha = ? AND flag = ?", report.RepoID, report.CommitSHA, report.Flag).Find(&old); err != nil {
+		return err
+	}
+	for _, r := range old {
+		if _, err := db.GetEngine(ctx).Delete(&File{ReportID: r.ID}); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).ID(r.ID).Delete(new(Report)); err != nil {
+			return err
+		}
+	}
+
+	if err := db.Insert(ctx, report); err != nil {
+		return err
+	}
+	for _, file := range files {
+		file.ReportID = report.ID
+		file.RepoID = report.RepoID
+		file.CommitSHA = report.CommitSHA
+	}
+	if len(files) > 0 {
+		if _, err := db.GetEngine(ctx).Insert(&files); err != nil {
+			return err
+		}
+	}
+	return committer.Commit()
+}
+
+// GetCommitReports returns the coverage reports uploaded for a commit
+func GetCommitReports(ctx context.Context, repoID int64, commitSHA string) ([]*Report, error) {
+	reports := make([]*Report, 0, 2)
+	return reports, db.GetEngine(ctx).Where("repo_id = ? AND commit_sha = ?", repoID, commitSHA).Asc("id").Find(&reports)
+}
+
+// GetCommitFiles returns the file coverage of all reports uploaded for a commit
+func GetCommitFiles(ctx context.Context, repoID int64, commitSHA string) ([]*File, error) {
+	files := make([]*File, 0, 10)
+	return files, db.GetEngine(ctx).Where("repo_id = ? AND commit_sha = ?", repoID, commitSHA).Asc("id").Find(&files)
+}
@@ -0,0 +1,52 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package coverage_test
+
+import (
+	"testing"
+
+	coverage_model "code.gitea.io/gitea/models/coverage"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertReport(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	const sha = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+	insert := func(flag string, covered, uncovered []int) {
+		assert.NoError(t, coverage_model.InsertReport(db.DefaultContext, &coverage_model.Report{
+			RepoID:    1,
+			CommitSHA: sha,
+			Flag:      flag,
+			Format:    "lcov",
+		}, []*coverage_model.File{{Path: "main.go", CoveredLines: covered, UncoveredLines: uncovered}}))
+	}
+	insert("unit", []int{1}, []int{2, 3})
+	insert("integration", []int{2}, []int{1, 3})
+	// uploading a report with an existing flag replaces it
+	insert("unit", []int{1, 3}, []int{2})
+
+	reports, err := coverage_model.GetCommitReports(db.DefaultContext, 1, sha)
+	assert.NoError(t, err)
+	if assert.Len(t, reports, 2) {
+		assert.Equal(t, "integration", reports[0].Flag)
+		assert.Equal(t, "unit", reports[1].Flag)
+	}
+
+	files, err := coverage_model.GetCommitFiles(db.DefaultContext, 1, sha)
+	assert.NoError(t, err)
+	if assert.Len(t, files, 2) {
+		assert.Equal(t, []int{2}, files[0].CoveredLines)
+		assert.Equal(t, []int{1, 3}, files[1].CoveredLines)
+		assert.Equal(t, reports[1].ID, files[1].ReportID)
+	}
+
+	files, err = coverage_model.GetCommitFiles(db.DefaultContext, 2, sha)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
@@ -0,0 +1,287 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package deployment
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+var (
+	// ErrDeploymentNotExist indicates a deployment not exist error
+	ErrDeploymentNotExist = errors.New("Deployment does not exist")
+	// ErrDeploymentActive indicates that an active deployment can not be deleted
+	ErrDeploymentActive = errors.New("Deployment is still active")
+)
+
+func init() {
+	db.RegisterModel(new(Deployment))
+	db.RegisterModel(new(Status))
+}
+
+// State represents the state of a deployment
+type State string
+
+// Deployment states
+const (
+	StatePending    State = "pending"
+	StateQueued     State = "queued"
+	StateInProgress State = "in_progress"
+	StateSuccess    State = "success"
+	StateFailure    State = "failure"
+	StateError      State = "error"
+	StateInactive   State = "inactive"
+)
+
+// IsValid returns true if the state is known
+func (s State) IsValid() bool {
+	switch s {
+	case StatePending, StateQueued, StateInProgress, StateSuccess, StateFailure, StateError, StateInactive:
+		return true
+	}
+	return false
+}
+
+// IsActive returns true if the deployment is running or currently deployed
+func (s State) IsActive() bool {
+	switch s {
+	case StatePending, StateQueued, StateInProgress, StateSuccess:
+		return true
+	}
+	return false
+}
+
+// Deployment represents the deployment of a commit to an environment
+type Deployment struct {
+	ID             int64            `xorm:"pk autoincr"`
+	RepoID         int64            `xorm:"INDEX NOT NULL"`
+	Environment    string           `xorm:"INDEX NOT NULL"`
+	Ref            string           `xorm:"NOT NULL DEFAULT ''"`
+	SHA            string           `xorm:"VARCHAR(64) INDEX NOT NULL"`
+	Description    string           `xorm:"TEXT"`
+	State          State            `xorm:"VARCHAR(20) NOT NULL"`
+	EnvironmentURL string           `xorm:"TEXT"`
+	CreatorID      int64            `xorm:"NOT NULL DEFAULT 0"`
+	Creator        *user_model.User `xorm:"-"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the table name
+func (Deployment) TableName() string {
+	return "deployment"
+}
+
+// LoadCreator loads the creator of the deployment
+func (d *Deployment) LoadCreator(ctx context.Context) (err error) {
+	if d.Creator == nil {
+		d.Creator, err = user_model.GetUserByIDCtx(ctx, d.CreatorID)
+		if user_model.IsErrUserNotExist(err) {
+			d.Creator = user_model.NewGhostUser()
+			err = nil
+		}
+	}
+	return err
+}
+
+// Status represents a state transition of a deployment
+type Status struct {
+	ID             int64            `xorm:"pk autoincr"`
+	RepoID         int64            `xorm:"INDEX NOT NULL"`
+	DeploymentID   int64            `xorm:"INDEX NOT NULL"`
+	State          State            `xorm:"VARCHAR(20) NOT NULL"`
+	Description    string           `xorm:"TEXT"`
+	LogURL         string           `xorm:"TEXT"`
+	EnvironmentURL string           `xorm:"TEXT"`
+	CreatorID      int64            `xorm:"NOT NULL DEFAULT 0"`
+	Creator        *user_model.User `xorm:"-"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName sets the table name
+func (Status) TableName() string {
+	return "deployment_status"
+}
+
+// LoadCreator loads the creator of the status
+func (s *Status) LoadCreator(ctx context.Context) (err error) {
+	if s.Creator == nil {
+		s.Creator, err = user_model.GetUserByIDCtx(ctx, s.CreatorID)
+		if user_model.IsErrUserNotExist(err) {
+			s.Creator = user_model.NewGhostUser()
+			err = nil
+		}
+	}
+	return err
+}
+
+// CreateDeployment inserts a deployment together with its initial pending status
+func CreateDeployment(ctx context.Context, d *Deployment) error {
+	return db.WithTx(func(ctx context.Context) error {
+		d.State = StatePending
+		if err := db.Insert(ctx, d); err != nil {
+			return err
+		}
+		return db.Insert(ctx, &Status{
+			RepoID:       d.RepoID,
+			DeploymentID: d.ID,
+			State:        StatePending,
+			CreatorID:    d.CreatorID,
+		})
+	}, ctx)
+}
+
+// GetDeploymentByID returns the deployment of the repository with the given id
+func GetDeploymentByID(ctx context.Context, repoID, id int64) (*Deployment, error) {
+	d := &Deployment{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrDeploymentNotExist
+	}
+	return d, nil
+}
+
+// DeleteDeployment deletes a deployment which is not active anymore together with its statuses
+func DeleteDeployment(ctx context.Context, d *Deployment) error {
+	if d.State.IsActive() {
+		return ErrDeploymentActive
+	}
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Delete(&Status{DeploymentID: d.ID}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(d.ID).Delete(new(Deployment))
+		return err
+	}, ctx)
+}
+
+// FindDeploymentsOptions represents the options to search deployments
+type FindDeploymentsOptions struct {
+	db.ListOptions
+	RepoID      int64
+	SHA         string
+	Ref         string
+	Environment string
+}
+
+func (opts *FindDeploymentsOptions) toConds() builder.Cond {
+	cond := builder.NewCond().And(builder.Eq{"repo_id": opts.RepoID})
+	if opts.SHA != "" {
+		cond = cond.And(builder.Eq{"sha": opts.SHA})
+	}
+	if opts.Ref != "" {
+		cond = cond.And(builder.Eq{"ref": opts.Ref})
+	}
+	if opts.Environment != "" {
+		cond = cond.And(builder.Eq{"environment": opts.Environment})
+	}
+	return cond
+}
+
+// FindDeployments returns the deployments matching the options, newest first
+func FindDeployments(ctx context.Context, opts *FindDeploymentsOptions) ([]*Deployment, int64, error) {
+	sess := db.GetEngine(ctx).Where(opts.toConds()).Desc("id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, opts)
+	}
+	deployments := make([]*Deployment, 0, 10)
+	count, err := sess.FindAndCount(&deployments)
+	return deployments, count, err
+}
+
+// GetLatestDeployments returns the latest deployment of every environment of the repository,
+// limited to the deployments of a commit if sha is not empty
+func GetLatestDeployments(ctx context.Context, repoID int64, sha string) ([]*Deployment, error) {
+	cond := builder.Eq{"repo_id": repoID}
+	if sha != "" {
+		cond["sha"] = sha
+	}
+	deployments := make([]*Deployment, 0, 5)
+	return deployments, db.GetEngine(ctx).
+		In("id", builder.Select("max(id)").From("deployment").Where(cond).GroupBy("environment")).
+		Asc("environment").
+		Find(&deployments)
+}
+
+// CreateStatus adds a state transition to the deployment. A successful deployment deactivates
+// the previously successful deployments of the same environment.
+func CreateStatus(ctx context.Context, d *Deployment, status *Status) error {
+	return db.WithTx(func(ctx context.Context) error {
+		status.RepoID = d.RepoID
+		status.DeploymentID = d.ID
+		if err := db.Insert(ctx, status); err != nil {
+			return err
+		}
+
+		d.State = status.State
+		cols := []string{"state"}
+		if status.EnvironmentURL != "" {
+			d.EnvironmentURL = status.EnvironmentURL
+			cols = append(cols, "environment_url")
+		}
+		if _, err := db.GetEngine(ctx).ID(d.ID).Cols(cols...).Update(d); err != nil {
+			return err
+		}
+
+		if status.State != StateSuccess {
+			return nil
+		}
+		previous := make([]*Deployment, 0, 1)
+		if err := db.GetEngine(ctx).
+			Where(builder.Eq{"repo_id": d.RepoID, "environment": d.Environment, "state": StateSuccess}).
+			And(builder.Neq{"id": d.ID}).
+			Find(&previous); err != nil {
+			return err
+		}
+		for _, p := range previous {
+			if err := db.Insert(ctx, &Status{
+				RepoID:       p.RepoID,
+				DeploymentID: p.ID,
+				State:        StateInactive,
+				CreatorID:    status.CreatorID,
+			}); err != nil {
+				return err
+			}
+			p.State = StateInactive
+			if _, err := db.GetEngine(ctx).ID(p.ID).Cols("state").Update(p); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, ctx)
+}
+
+// GetStatuses returns the state transitions of a deployment, newest first
+func GetStatuses(ctx context.Context, deploymentID int64, opts db.ListOptions) ([]*Status, int64, error) {
+	sess := db.GetEngine(ctx).Where("deployment_id = ?", deploymentID).Desc("id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	statuses := make([]*Status, 0, 5)
+	count, err := sess.FindAndCount(&statuses)
+	return statuses, count, err
+}
+
+// GetStatusByID returns the status of the deployment with the given id
+func GetStatusByID(ctx context.Context, deploymentID, id int64) (*Status, error) {
+	s := &Status{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND deployment_id = ?", id, deploymentID).Get(s)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrDeploymentNotExist
+	}
+	return s, nil
+}
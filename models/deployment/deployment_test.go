@@ -0,0 +1,57 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package deployment_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	deployment_model "code.gitea.io/gitea/models/deployment"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentStatuses(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	create := func(sha string) *deployment_model.Deployment {
+		d := &deployment_model.Deployment{RepoID: 1, Environment: "production", Ref: "refs/heads/master", SHA: sha, CreatorID: 2}
+		assert.NoError(t, deployment_model.CreateDeployment(db.DefaultContext, d))
+		assert.Equal(t, deployment_model.StatePending, d.State)
+		return d
+	}
+	first := create("65f1bf27bc3bf70f64657658635e66094edbcb4d")
+	assert.NoError(t, deployment_model.CreateStatus(db.DefaultContext, first, &deployment_model.Status{State: deployment_model.StateSuccess, EnvironmentURL: "https://example.com", CreatorID: 2}))
+	assert.EqualValues(t, "https://example.com", first.EnvironmentURL)
+
+	second := create("985f0301dba5e7b34be866819cd15ad3d8f508ee")
+	assert.NoError(t, deployment_model.CreateStatus(db.DefaultContext, second, &deployment_model.Status{State: deployment_model.StateSuccess, CreatorID: 2}))
+
+	// the previous successful deployment of the environment became inactive
+	first, err := deployment_model.GetDeploymentByID(db.DefaultContext, 1, first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, deployment_model.StateInactive, first.State)
+	statuses, count, err := deployment_model.GetStatuses(db.DefaultContext, first.ID, db.ListOptions{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	assert.Equal(t, deployment_model.StateInactive, statuses[0].State)
+
+	latest, err := deployment_model.GetLatestDeployments(db.DefaultContext, 1, "")
+	assert.NoError(t, err)
+	if assert.Len(t, latest, 1) {
+		assert.Equal(t, second.ID, latest[0].ID)
+	}
+
+	assert.ErrorIs(t, deployment_model.DeleteDeployment(db.DefaultContext, second), deployment_model.ErrDeploymentActive)
+	assert.NoError(t, deployment_model.DeleteDeployment(db.DefaultContext, first))
+	_, err = deployment_model.GetDeploymentByID(db.DefaultContext, 1, first.ID)
+	assert.ErrorIs(t, err, deployment_model.ErrDeploymentNotExist)
+
+	deployments, count, err := deployment_model.FindDeployments(db.DefaultContext, &deployment_model.FindDeploymentsOptions{RepoID: 1, Environment: "production"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	assert.Len(t, deployments, 1)
+}
@@ -416,6 +416,23 @@ func (err ErrCommitIDDoesNotMatch) Error() string {
 	return fmt.Sprintf("file CommitID does not match [given: %s, expected: %s]", err.GivenCommitID, err.CurrentCommitID)
 }
 
+// ErrCherryPickConflict represents a "CherryPickConflict" kind of error.
+type ErrCherryPickConflict struct {
+	SHA             string
+	Branch          string
+	ConflictedFiles []string
+}
+
+// IsErrCherryPickConflict checks if an error is a ErrCherryPickConflict.
+func IsErrCherryPickConflict(err error) bool {
+	_, ok := err.(ErrCherryPickConflict)
+	return ok
+}
+
+func (err ErrCherryPickConflict) Error() string {
+	return fmt.Sprintf("commit conflicts with the branch [sha: %s, branch: %s, files: %v]", err.SHA, err.Branch, err.ConflictedFiles)
+}
+
 // ErrSHAOrCommitIDNotProvided represents a "SHAOrCommitIDNotProvided" kind of error.
 type ErrSHAOrCommitIDNotProvided struct{}
 
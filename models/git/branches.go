@@ -47,7 +47,11 @@ type ProtectedBranch struct {
 	BlockOnRejectedReviews        bool     `xorm:"NOT NULL DEFAULT false"`
 	BlockOnOfficialReviewRequests bool     `xorm:"NOT NULL DEFAULT false"`
 	BlockOnOutdatedBranch         bool     `xorm:"NOT NULL DEFAULT false"`
+	BlockOnCodeScanningAlerts     bool     `xorm:"NOT NULL DEFAULT false"`
+	MinimumDiffCoverage           int64    `xorm:"NOT NULL DEFAULT 0"`
 	DismissStaleApprovals         bool     `xorm:"NOT NULL DEFAULT false"`
+	IgnoreLastCommittersApprovals int64    `xorm:"NOT NULL DEFAULT 0"`
+	DismissPusherApprovals        bool     `xorm:"NOT NULL DEFAULT false"`
 	RequireSignedCommits          bool     `xorm:"NOT NULL DEFAULT false"`
 	ProtectedFilePatterns         string   `xorm:"TEXT"`
 	UnprotectedFilePatterns       string   `xorm:"TEXT"`
@@ -185,6 +189,51 @@ func getFilePatterns(filePatterns string) []glob.Glob {
 	return extarr
 }
 
+// StatusCheckAnyOfSeparator separates the alternative patterns of a required status check,
+// the check passes when any of its alternatives passes
+const StatusCheckAnyOfSeparator = "|"
+
+// IsStatusCheckPattern returns true if a required status check isn't a single context name
+func IsStatusCheckPattern(check string) bool {
+	return strings.ContainsAny(check, "*?[{\\"+StatusCheckAnyOfSeparator)
+}
+
+// CompileStatusCheck returns the glob patterns of the alternatives of a required status check
+func CompileStatusCheck(check string) ([]glob.Glob, error) {
+	alternatives := make([]glob.Glob, 0, 2)
+	for _, expr := range strings.Split(check, StatusCheckAnyOfSeparator) {
+		expr = strings.TrimSpace(expr)
+		if expr == "" {
+			continue
+		}
+		g, err := glob.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid status check pattern %q: %w", expr, err)
+		}
+		alternatives = append(alternatives, g)
+	}
+	return alternatives, nil
+}
+
+// IsStatusCheckRequired returns true if a status context is matched by one of the required status checks
+func (protectBranch *ProtectedBranch) IsStatusCheckRequired(context string) bool {
+	for _, check := range protectBranch.StatusCheckContexts {
+		if check == context {
+			return true
+		}
+		alternatives, err := CompileStatusCheck(check)
+		if err != nil {
+			continue
+		}
+		for _, g := range alternatives {
+			if g.Match(context) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // MergeBlockedByProtectedFiles returns true if merge is blocked by protected files change
 func (protectBranch *ProtectedBranch) MergeBlockedByProtectedFiles(changedProtectedFiles []string) bool {
 	glob := protectBranch.GetProtectedFilePatterns()
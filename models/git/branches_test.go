@@ -161,3 +161,26 @@ func TestOnlyGetDeletedBranchOnCorrectRepo(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, deletedBranch)
 }
+
+func TestProtectedBranchIsStatusCheckRequired(t *testing.T) {
+	protectBranch := &git_model.ProtectedBranch{
+		StatusCheckContexts: []string{"lint", "ci/build-*", "ci/legacy | ci/test-{linux,macos}", "bad[pattern"},
+	}
+	assert.True(t, protectBranch.IsStatusCheckRequired("lint"))
+	assert.True(t, protectBranch.IsStatusCheckRequired("ci/build-windows"))
+	assert.True(t, protectBranch.IsStatusCheckRequired("ci/legacy"))
+	assert.True(t, protectBranch.IsStatusCheckRequired("ci/test-macos"))
+	assert.True(t, protectBranch.IsStatusCheckRequired("bad[pattern"))
+	assert.False(t, protectBranch.IsStatusCheckRequired("ci/test-windows"))
+	assert.False(t, protectBranch.IsStatusCheckRequired("docs"))
+
+	assert.False(t, git_model.IsStatusCheckPattern("lint"))
+	assert.True(t, git_model.IsStatusCheckPattern("ci/build-*"))
+	assert.True(t, git_model.IsStatusCheckPattern("ci/legacy|lint"))
+
+	_, err := git_model.CompileStatusCheck("bad[pattern")
+	assert.Error(t, err)
+	alternatives, err := git_model.CompileStatusCheck("ci/legacy | ci/test-* |")
+	assert.NoError(t, err)
+	assert.Len(t, alternatives, 2)
+}
@@ -82,6 +82,11 @@ func ToggleIssueAssignee(issue *Issue, doer *user_model.User, assigneeID int64)
 	return removed, comment, nil
 }
 
+// ToggleIssueAssigneeCtx changes a user between assigned and not assigned for this issue with the given context.
+func ToggleIssueAssigneeCtx(ctx context.Context, issue *Issue, doer *user_model.User, assigneeID int64) (removed bool, comment *Comment, err error) {
+	return toggleIssueAssignee(ctx, issue, doer, assigneeID, false)
+}
+
 func toggleIssueAssignee(ctx context.Context, issue *Issue, doer *user_model.User, assigneeID int64, isCreate bool) (removed bool, comment *Comment, err error) {
 	removed, err = toggleUserAssignee(ctx, issue, assigneeID)
 	if err != nil {
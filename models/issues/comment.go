@@ -130,6 +130,10 @@ const (
 	CommentTypePRScheduledToAutoMerge
 	// 35 pr was un scheduled to auto merge when checks succeed
 	CommentTypePRUnScheduledToAutoMerge
+	// 36 Mark an issue confidential, hiding it from the users who can't write to the issues
+	CommentTypeMarkConfidential
+	// 37 Unmark a previously confidential issue
+	CommentTypeUnmarkConfidential
 )
 
 var commentStrings = []string{
@@ -169,6 +173,8 @@ var commentStrings = []string{
 	"change_issue_ref",
 	"pull_scheduled_merge",
 	"pull_cancel_scheduled_merge",
+	"mark_confidential",
+	"unmark_confidential",
 }
 
 func (t CommentType) String() string {
@@ -254,6 +260,8 @@ type Comment struct {
 	TreePath        string
 	Content         string `xorm:"LONGTEXT"`
 	RenderedContent string `xorm:"-"`
+	// IsHidden is set when a moderator hid the comment, its content is only shown to the moderators and its poster
+	IsHidden bool `xorm:"NOT NULL DEFAULT false"`
 
 	// Path represents the 4 lines of code cemented by this comment
 	Patch       string `xorm:"-"`
@@ -1068,6 +1076,8 @@ type FindCommentsOptions struct {
 	Line     int64
 	TreePath string
 	Type     CommentType
+	// ConfidentialCond hides the comments of the confidential issues the viewer can't see, it requires RepoID
+	ConfidentialCond builder.Cond
 }
 
 func (opts *FindCommentsOptions) toConds() builder.Cond {
@@ -1096,6 +1106,9 @@ func (opts *FindCommentsOptions) toConds() builder.Cond {
 	if len(opts.TreePath) > 0 {
 		cond = cond.And(builder.Eq{"comment.tree_path": opts.TreePath})
 	}
+	if opts.RepoID > 0 && opts.ConfidentialCond != nil {
+		cond = cond.And(opts.ConfidentialCond)
+	}
 	return cond
 }
 
@@ -1119,6 +1132,47 @@ func FindComments(ctx context.Context, opts *FindCommentsOptions) ([]*Comment, e
 		Find(&comments)
 }
 
+// FindReopenComments returns the newest comments reopening the issues, or the pull requests if isPull, of a repository
+// with their issue and its attributes. The issues have at least one of the labels if any are given, and match the
+// confidential condition of the viewer if it isn't nil, see ConfidentialIssueCond.
+func FindReopenComments(ctx context.Context, repoID int64, isPull bool, labelNames []string, confidentialCond builder.Cond, limit int) (CommentList, error) {
+	issueCond := builder.Eq{"issue.repo_id": repoID, "issue.is_pull": isPull}.And()
+	if len(labelNames) > 0 {
+		issueCond = issueCond.And(builder.In("issue.id", BuildLabelNamesIssueIDsCondition(labelNames)))
+	}
+	if confidentialCond != nil {
+		issueCond = issueCond.And(confidentialCond)
+	}
+	comments := make(CommentList, 0, limit)
+	if err := db.GetEngine(ctx).
+		Where(builder.Eq{"type": CommentTypeReopen}).
+		And(builder.In("issue_id", builder.Select("id").From("issue").Where(issueCond))).
+		Desc("created_unix").Desc("id").
+		Limit(limit).
+		Find(&comments); err != nil {
+		return nil, err
+	}
+	if len(comments) == 0 {
+		return comments, nil
+	}
+
+	issues, err := GetIssuesByIDs(ctx, comments.getIssueIDs())
+	if err != nil {
+		return nil, err
+	}
+	if err := IssueList(issues).LoadAttributes(); err != nil {
+		return nil, err
+	}
+	issuesByID := make(map[int64]*Issue, len(issues))
+	for _, issue := range issues {
+		issuesByID[issue.ID] = issue
+	}
+	for _, comment := range comments {
+		comment.Issue = issuesByID[comment.IssueID]
+	}
+	return comments, comments.LoadPosters()
+}
+
 // CountComments count all comments according options by ignoring pagination
 func CountComments(opts *FindCommentsOptions) (int64, error) {
 	sess := db.GetEngine(db.DefaultContext).Where(opts.toConds())
@@ -1153,6 +1207,19 @@ func UpdateComment(c *Comment, doer *user_model.User) error {
 	return nil
 }
 
+// CountPosterCommentsWithContent counts the other comments of the poster with the same content created since the given time
+func CountPosterCommentsWithContent(ctx context.Context, posterID, excludeID int64, content string, since timeutil.TimeStamp) (int64, error) {
+	return db.GetEngine(ctx).
+		Where("poster_id = ? AND id <> ? AND type = ? AND content = ? AND created_unix >= ?", posterID, excludeID, CommentTypeComment, content, since).
+		Count(new(Comment))
+}
+
+// UpdateCommentCols updates the given columns of a comment
+func UpdateCommentCols(ctx context.Context, c *Comment, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(c.ID).Cols(cols...).NoAutoTime().Update(c)
+	return err
+}
+
 // DeleteComment deletes the comment
 func DeleteComment(ctx context.Context, comment *Comment) error {
 	e := db.GetEngine(ctx)
@@ -63,3 +63,69 @@ func TestFetchCodeComments(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, res, 1)
 }
+
+func TestFindReopenComments(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	reopen := func(issueID int64) *issues_model.Comment {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issueID})
+		comment, err := issues_model.CreateComment(&issues_model.CreateCommentOptions{
+			Type:  issues_model.CommentTypeReopen,
+			Doer:  doer,
+			Repo:  repo,
+			Issue: issue,
+		})
+		assert.NoError(t, err)
+		return comment
+	}
+	// issue 1 has label1 and issue 5 label2, issue 2 is a pull request
+	reopen1, reopen5, reopen2 := reopen(1), reopen(5), reopen(2)
+
+	comments, err := issues_model.FindReopenComments(db.DefaultContext, repo.ID, false, nil, nil, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 2) {
+		assert.EqualValues(t, reopen5.ID, comments[0].ID)
+		assert.EqualValues(t, reopen1.ID, comments[1].ID)
+		assert.EqualValues(t, 5, comments[0].Issue.ID)
+		assert.EqualValues(t, doer.ID, comments[0].Poster.ID)
+		assert.Len(t, comments[1].Issue.Labels, 1)
+	}
+
+	comments, err = issues_model.FindReopenComments(db.DefaultContext, repo.ID, false, nil, nil, 1)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+
+	comments, err = issues_model.FindReopenComments(db.DefaultContext, repo.ID, false, []string{"label1"}, nil, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 1) {
+		assert.EqualValues(t, reopen1.ID, comments[0].ID)
+	}
+
+	comments, err = issues_model.FindReopenComments(db.DefaultContext, repo.ID, false, []string{"label1", "label2"}, nil, 10)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+
+	comments, err = issues_model.FindReopenComments(db.DefaultContext, repo.ID, true, nil, nil, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 1) {
+		assert.EqualValues(t, reopen2.ID, comments[0].ID)
+	}
+
+	comments, err = issues_model.FindReopenComments(db.DefaultContext, repo.ID, false, []string{"unknown"}, nil, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+
+	// the reopenings of the confidential issues are hidden like their issues
+	_, err = db.GetEngine(db.DefaultContext).ID(5).Cols("is_confidential").Update(&issues_model.Issue{IsConfidential: true})
+	assert.NoError(t, err)
+	comments, err = issues_model.FindReopenComments(db.DefaultContext, repo.ID, false, nil, issues_model.ConfidentialIssueCond(nil), 10)
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 1) {
+		assert.EqualValues(t, reopen1.ID, comments[0].ID)
+	}
+	comments, err = issues_model.FindReopenComments(db.DefaultContext, repo.ID, false, nil, issues_model.ConfidentialIssueCond(doer), 10)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+}
@@ -0,0 +1,319 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/gobwas/glob"
+	"xorm.io/builder"
+)
+
+// ErrComponentNotExist represents a "ComponentNotExist" kind of error.
+type ErrComponentNotExist struct {
+	ID     int64
+	RepoID int64
+	Name   string
+}
+
+// IsErrComponentNotExist checks if an error is a ErrComponentNotExist.
+func IsErrComponentNotExist(err error) bool {
+	_, ok := err.(ErrComponentNotExist)
+	return ok
+}
+
+func (err ErrComponentNotExist) Error() string {
+	if len(err.Name) > 0 {
+		return fmt.Sprintf("component does not exist [name: %s, repo_id: %d]", err.Name, err.RepoID)
+	}
+	return fmt.Sprintf("component does not exist [id: %d, repo_id: %d]", err.ID, err.RepoID)
+}
+
+// ErrComponentAlreadyExist represents a "ComponentAlreadyExist" kind of error.
+type ErrComponentAlreadyExist struct {
+	RepoID int64
+	Name   string
+}
+
+// IsErrComponentAlreadyExist checks if an error is a ErrComponentAlreadyExist.
+func IsErrComponentAlreadyExist(err error) bool {
+	_, ok := err.(ErrComponentAlreadyExist)
+	return ok
+}
+
+func (err ErrComponentAlreadyExist) Error() string {
+	return fmt.Sprintf("component already exists [name: %s, repo_id: %d]", err.Name, err.RepoID)
+}
+
+// ErrInvalidComponentPath represents a "InvalidComponentPath" kind of error.
+type ErrInvalidComponentPath struct {
+	Path string
+	Err  error
+}
+
+// IsErrInvalidComponentPath checks if an error is a ErrInvalidComponentPath.
+func IsErrInvalidComponentPath(err error) bool {
+	_, ok := err.(ErrInvalidComponentPath)
+	return ok
+}
+
+func (err ErrInvalidComponentPath) Error() string {
+	return fmt.Sprintf("invalid component path pattern [path: %s]: %v", err.Path, err.Err)
+}
+
+// Component is a named part of a repository, e.g. a project of a monorepo, made of the files matching its path
+// patterns. The issues and the pull requests touching a component are linked to it and get its labels, and its
+// owners are notified of them.
+type Component struct {
+	ID          int64  `xorm:"pk autoincr"`
+	RepoID      int64  `xorm:"UNIQUE(s) NOT NULL"`
+	Name        string `xorm:"NOT NULL"`
+	LowerName   string `xorm:"UNIQUE(s) NOT NULL"`
+	Description string `xorm:"TEXT"`
+	// Paths are glob patterns of the files of the component, "*" doesn't match "/" and "**" matches anything,
+	// e.g. "services/auth/**" or "**/*.proto"
+	Paths        []string `xorm:"JSON TEXT"`
+	OwnerUserIDs []int64  `xorm:"JSON TEXT"`
+	OwnerTeamIDs []int64  `xorm:"JSON TEXT"`
+	LabelIDs     []int64  `xorm:"JSON TEXT"`
+	// NotifyOwners subscribes the owners to the issues and the pull requests touching the component
+	NotifyOwners bool `xorm:"NOT NULL DEFAULT true"`
+
+	NumOpenIssues int `xorm:"-"`
+	NumOpenPulls  int `xorm:"-"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+
+	globs []glob.Glob `xorm:"-"`
+}
+
+// IssueComponent links an issue or a pull request to a component it touches
+type IssueComponent struct {
+	ID          int64 `xorm:"pk autoincr"`
+	IssueID     int64 `xorm:"UNIQUE(s) NOT NULL"`
+	ComponentID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(Component))
+	db.RegisterModel(new(IssueComponent))
+}
+
+// ValidateComponentPaths returns an ErrInvalidComponentPath if a path pattern of a component isn't a valid glob
+func ValidateComponentPaths(paths []string) error {
+	for _, p := range paths {
+		if _, err := glob.Compile(p, '/'); err != nil {
+			return ErrInvalidComponentPath{Path: p, Err: err}
+		}
+	}
+	return nil
+}
+
+// Match returns whether a file of the repository belongs to the component
+func (c *Component) Match(file string) bool {
+	if c.globs == nil {
+		c.globs = make([]glob.Glob, 0, len(c.Paths))
+		for _, p := range c.Paths {
+			if g, err := glob.Compile(p, '/'); err == nil {
+				c.globs = append(c.globs, g)
+			}
+		}
+	}
+	file = strings.TrimPrefix(file, "/")
+	for _, g := range c.globs {
+		if g.Match(file) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchComponents returns the components some files of the repository belong to
+func MatchComponents(components []*Component, files []string) []*Component {
+	matched := make([]*Component, 0, len(components))
+	for _, c := range components {
+		for _, file := range files {
+			if c.Match(file) {
+				matched = append(matched, c)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+func normalizeComponent(c *Component) error {
+	c.Name = strings.TrimSpace(c.Name)
+	c.LowerName = strings.ToLower(c.Name)
+	paths := make([]string, 0, len(c.Paths))
+	for _, p := range c.Paths {
+		if p = strings.TrimPrefix(strings.TrimSpace(p), "/"); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	c.Paths = paths
+	c.globs = nil
+	return ValidateComponentPaths(c.Paths)
+}
+
+func isComponentNameTaken(ctx context.Context, c *Component) (bool, error) {
+	return db.GetEngine(ctx).Where("repo_id=? AND lower_name=? AND id<>?", c.RepoID, strings.ToLower(c.Name), c.ID).Exist(new(Component))
+}
+
+// CreateComponent creates a component of a repository
+func CreateComponent(ctx context.Context, c *Component) error {
+	if err := normalizeComponent(c); err != nil {
+		return err
+	}
+	if taken, err := isComponentNameTaken(ctx, c); err != nil {
+		return err
+	} else if taken {
+		return ErrComponentAlreadyExist{RepoID: c.RepoID, Name: c.Name}
+	}
+	return db.Insert(ctx, c)
+}
+
+// UpdateComponent updates a component, the issues and the pull requests already linked to it stay linked
+func UpdateComponent(ctx context.Context, c *Component) error {
+	if err := normalizeComponent(c); err != nil {
+		return err
+	}
+	if taken, err := isComponentNameTaken(ctx, c); err != nil {
+		return err
+	} else if taken {
+		return ErrComponentAlreadyExist{RepoID: c.RepoID, Name: c.Name}
+	}
+	_, err := db.GetEngine(ctx).ID(c.ID).
+		Cols("name", "lower_name", "description", "paths", "owner_user_i_ds", "owner_team_i_ds", "label_i_ds", "notify_owners").
+		Update(c)
+	return err
+}
+
+// DeleteComponent deletes a component of a repository and its links to the issues
+func DeleteComponent(ctx context.Context, repoID, id int64) error {
+	return db.WithTx(func(ctx context.Context) error {
+		deleted, err := db.GetEngine(ctx).Where("id=? AND repo_id=?", id, repoID).Delete(new(Component))
+		if err != nil || deleted == 0 {
+			return err
+		}
+		_, err = db.GetEngine(ctx).Where("component_id=?", id).Delete(new(IssueComponent))
+		return err
+	}, ctx)
+}
+
+// GetComponentByID returns a component of a repository
+func GetComponentByID(ctx context.Context, repoID, id int64) (*Component, error) {
+	c := new(Component)
+	has, err := db.GetEngine(ctx).Where("id=? AND repo_id=?", id, repoID).Get(c)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrComponentNotExist{ID: id, RepoID: repoID}
+	}
+	return c, nil
+}
+
+// GetComponentByName returns a component of a repository by its case-insensitive name
+func GetComponentByName(ctx context.Context, repoID int64, name string) (*Component, error) {
+	c := new(Component)
+	has, err := db.GetEngine(ctx).Where("repo_id=? AND lower_name=?", repoID, strings.ToLower(name)).Get(c)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrComponentNotExist{RepoID: repoID, Name: name}
+	}
+	return c, nil
+}
+
+// GetComponents returns the components of a repository in the order of their names
+func GetComponents(ctx context.Context, repoID int64) ([]*Component, error) {
+	components := make([]*Component, 0, 10)
+	return components, db.GetEngine(ctx).Where("repo_id=?", repoID).Asc("lower_name").Find(&components)
+}
+
+// LoadComponentIssueCounts loads the numbers of open issues and pull requests of components
+func LoadComponentIssueCounts(ctx context.Context, components []*Component) error {
+	if len(components) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(components))
+	for _, c := range components {
+		ids = append(ids, c.ID)
+	}
+
+	type count struct {
+		ComponentID int64
+		IsPull      bool
+		Count       int
+	}
+	counts := make([]*count, 0, len(components)*2)
+	if err := db.GetEngine(ctx).Table("issue_component").
+		Join("INNER", "issue", "issue.id = issue_component.issue_id").
+		Where(builder.In("issue_component.component_id", ids).And(builder.Eq{"issue.is_closed": false})).
+		GroupBy("issue_component.component_id, issue.is_pull").
+		Select("issue_component.component_id AS component_id, issue.is_pull AS is_pull, COUNT(*) AS count").
+		Find(&counts); err != nil {
+		return err
+	}
+
+	byID := make(map[int64]*Component, len(components))
+	for _, c := range components {
+		c.NumOpenIssues, c.NumOpenPulls = 0, 0
+		byID[c.ID] = c
+	}
+	for _, cnt := range counts {
+		if cnt.IsPull {
+			byID[cnt.ComponentID].NumOpenPulls = cnt.Count
+		} else {
+			byID[cnt.ComponentID].NumOpenIssues = cnt.Count
+		}
+	}
+	return nil
+}
+
+// GetIssueComponents returns the components an issue or a pull request touches
+func GetIssueComponents(ctx context.Context, issueID int64) ([]*Component, error) {
+	components := make([]*Component, 0, 2)
+	return components, db.GetEngine(ctx).
+		Join("INNER", "issue_component", "issue_component.component_id = component.id").
+		Where("issue_component.issue_id=?", issueID).
+		Asc("component.lower_name").
+		Find(&components)
+}
+
+// AddIssueComponents links an issue or a pull request to the components it touches and returns the ones it wasn't
+// linked to yet. The links are never removed automatically, a pull request which touched a component is still
+// considered as touching it after a force-push.
+func AddIssueComponents(ctx context.Context, issueID int64, components []*Component) ([]*Component, error) {
+	added := make([]*Component, 0, len(components))
+	return added, db.WithTx(func(ctx context.Context) error {
+		for _, c := range components {
+			has, err := db.GetEngine(ctx).Where("issue_id=? AND component_id=?", issueID, c.ID).Exist(new(IssueComponent))
+			if err != nil {
+				return err
+			}
+			if has {
+				continue
+			}
+			if err := db.Insert(ctx, &IssueComponent{IssueID: issueID, ComponentID: c.ID}); err != nil {
+				return err
+			}
+			added = append(added, c)
+		}
+		return nil
+	}, ctx)
+}
+
+// issueComponentCond returns the condition selecting the issues touching a component
+func issueComponentCond(componentID int64) builder.Cond {
+	return builder.In("issue.id", builder.Select("issue_id").From("issue_component").
+		Where(builder.Eq{"component_id": componentID}))
+}
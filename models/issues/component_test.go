@@ -0,0 +1,106 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchComponents(t *testing.T) {
+	backend := &issues_model.Component{Name: "backend", Paths: []string{"services/**"}}
+	docs := &issues_model.Component{Name: "docs", Paths: []string{"docs/**", "*.md"}}
+	components := []*issues_model.Component{backend, docs}
+
+	assert.True(t, backend.Match("services/auth/main.go"))
+	assert.False(t, backend.Match("modules/services/main.go"))
+	assert.True(t, docs.Match("README.md"))
+	assert.False(t, docs.Match("services/README.md"))
+
+	assert.Equal(t, []*issues_model.Component{backend, docs}, issues_model.MatchComponents(components, []string{"services/a.go", "README.md"}))
+	assert.Empty(t, issues_model.MatchComponents(components, []string{"go.mod"}))
+
+	assert.True(t, issues_model.IsErrInvalidComponentPath(issues_model.ValidateComponentPaths([]string{"services/[a"})))
+}
+
+func TestCreateComponent(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	c := &issues_model.Component{RepoID: 1, Name: " Frontend ", Paths: []string{" /web_src/** ", ""}, NotifyOwners: true}
+	assert.NoError(t, issues_model.CreateComponent(db.DefaultContext, c))
+	c = unittest.AssertExistsAndLoadBean(t, &issues_model.Component{ID: c.ID})
+	assert.Equal(t, "Frontend", c.Name)
+	assert.Equal(t, "frontend", c.LowerName)
+	assert.Equal(t, []string{"web_src/**"}, c.Paths)
+
+	err := issues_model.CreateComponent(db.DefaultContext, &issues_model.Component{RepoID: 1, Name: "backend"})
+	assert.True(t, issues_model.IsErrComponentAlreadyExist(err))
+	err = issues_model.CreateComponent(db.DefaultContext, &issues_model.Component{RepoID: 1, Name: "invalid", Paths: []string{"[a"}})
+	assert.True(t, issues_model.IsErrInvalidComponentPath(err))
+
+	c.Name = "Docs"
+	assert.True(t, issues_model.IsErrComponentAlreadyExist(issues_model.UpdateComponent(db.DefaultContext, c)))
+	c.Name = "Web"
+	c.LabelIDs = []int64{2}
+	assert.NoError(t, issues_model.UpdateComponent(db.DefaultContext, c))
+	c, err = issues_model.GetComponentByName(db.DefaultContext, 1, "WEB")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2}, c.LabelIDs)
+}
+
+func TestGetComponents(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	components, err := issues_model.GetComponents(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, components, 2) {
+		assert.Equal(t, "Backend", components[0].Name)
+		assert.Equal(t, "Docs", components[1].Name)
+	}
+
+	// the issue 5 of the docs is closed
+	assert.NoError(t, issues_model.LoadComponentIssueCounts(db.DefaultContext, components))
+	assert.Equal(t, 1, components[0].NumOpenIssues)
+	assert.Equal(t, 1, components[0].NumOpenPulls)
+	assert.Equal(t, 0, components[1].NumOpenIssues)
+
+	_, err = issues_model.GetComponentByID(db.DefaultContext, 2, 1)
+	assert.True(t, issues_model.IsErrComponentNotExist(err))
+}
+
+func TestAddIssueComponents(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	backend := unittest.AssertExistsAndLoadBean(t, &issues_model.Component{ID: 1})
+	docs := unittest.AssertExistsAndLoadBean(t, &issues_model.Component{ID: 2})
+	added, err := issues_model.AddIssueComponents(db.DefaultContext, 1, []*issues_model.Component{backend, docs})
+	assert.NoError(t, err)
+	if assert.Len(t, added, 1) {
+		assert.EqualValues(t, 2, added[0].ID)
+	}
+
+	components, err := issues_model.GetIssueComponents(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.Len(t, components, 2)
+
+	issues, err := issues_model.Issues(&issues_model.IssuesOptions{RepoID: 1, ComponentID: 2, IsClosed: util.OptionalBoolFalse})
+	assert.NoError(t, err)
+	if assert.Len(t, issues, 1) {
+		assert.EqualValues(t, 1, issues[0].ID)
+	}
+	stats, err := issues_model.GetIssueStats(&issues_model.IssueStatsOptions{RepoID: 1, ComponentID: 2})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, stats.OpenCount)
+	assert.EqualValues(t, 1, stats.ClosedCount)
+
+	assert.NoError(t, issues_model.DeleteComponent(db.DefaultContext, 1, 2))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueComponent{ComponentID: 2})
+}
@@ -7,10 +7,12 @@ package issues
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"code.gitea.io/gitea/models/avatars"
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/timeutil"
 
 	"xorm.io/builder"
@@ -51,9 +53,9 @@ func SaveIssueContentHistory(ctx context.Context, posterID, issueID, commentID i
 		log.Error("can not save issue content history. err=%v", err)
 		return err
 	}
-	// We only keep at most 20 history revisions now. It is enough in most cases.
-	// If there is a special requirement to keep more, we can consider introducing a new setting option then, but not now.
-	KeepLimitedContentHistory(ctx, issueID, commentID, 20)
+	if setting.Repository.Issue.ContentHistoryMaxRevisions > 0 {
+		KeepLimitedContentHistory(ctx, issueID, commentID, setting.Repository.Issue.ContentHistoryMaxRevisions)
+	}
 	return nil
 }
 
@@ -225,18 +227,74 @@ func GetIssueContentHistoryAndPrev(dbCtx context.Context, id int64) (history, pr
 		return nil, nil, &ErrIssueContentHistoryNotExist{id}
 	}
 
-	prevHistory = &ContentHistory{}
-	has, err = db.GetEngine(dbCtx).Where(builder.Eq{"issue_id": history.IssueID, "comment_id": history.CommentID, "is_deleted": false}).
-		And(builder.Lt{"edited_unix": history.EditedUnix}).
-		OrderBy("edited_unix DESC").Limit(1).
-		Get(prevHistory)
-
+	prevHistory, err = GetPrevIssueContentHistory(dbCtx, history)
 	if err != nil {
 		log.Error("failed to get issue content history %v. err=%v", id, err)
 		return nil, nil, err
-	} else if !has {
-		return history, nil, nil
 	}
 
 	return history, prevHistory, nil
 }
+
+// GetPrevIssueContentHistory get the previous non-deleted history of a history, nil if there is none
+func GetPrevIssueContentHistory(dbCtx context.Context, history *ContentHistory) (*ContentHistory, error) {
+	prevHistory := &ContentHistory{}
+	has, err := db.GetEngine(dbCtx).Where(builder.Eq{"issue_id": history.IssueID, "comment_id": history.CommentID, "is_deleted": false}).
+		And(builder.Lt{"edited_unix": history.EditedUnix}).
+		OrderBy("edited_unix DESC").Limit(1).
+		Get(prevHistory)
+	if err != nil || !has {
+		return nil, err
+	}
+	return prevHistory, nil
+}
+
+// FindIssueContentHistories find the history revisions of an issue (comment_id = 0) or a comment, the latest first
+func FindIssueContentHistories(dbCtx context.Context, issueID, commentID int64, listOptions db.ListOptions) ([]*ContentHistory, int64, error) {
+	sess := db.GetEngine(dbCtx).Where(builder.Eq{"issue_id": issueID, "comment_id": commentID}).
+		OrderBy("edited_unix DESC, id DESC")
+	if listOptions.Page > 0 {
+		sess = db.SetSessionPagination(sess, &listOptions)
+	}
+	histories := make([]*ContentHistory, 0, listOptions.PageSize)
+	count, err := sess.FindAndCount(&histories)
+	return histories, count, err
+}
+
+// DeleteOldIssueContentHistories hard deletes the history revisions edited before the given duration, the latest
+// revision of each issue and comment is always kept
+func DeleteOldIssueContentHistories(dbCtx context.Context, olderThan time.Duration) error {
+	if olderThan <= 0 {
+		return nil
+	}
+	cutoff := time.Now().Add(-olderThan).Unix()
+
+	type IssueCommentID struct {
+		IssueID   int64
+		CommentID int64
+	}
+	var ids []*IssueCommentID
+	if err := db.GetEngine(dbCtx).Select("issue_id, comment_id").Table("issue_content_history").
+		Where(builder.Lt{"edited_unix": cutoff}).
+		GroupBy("issue_id, comment_id").
+		Find(&ids); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		latest := &ContentHistory{}
+		has, err := db.GetEngine(dbCtx).Cols("id").Where(builder.Eq{"issue_id": id.IssueID, "comment_id": id.CommentID}).
+			OrderBy("edited_unix DESC, id DESC").Get(latest)
+		if err != nil {
+			return err
+		} else if !has {
+			continue
+		}
+		if _, err := db.GetEngine(dbCtx).Where(builder.Eq{"issue_id": id.IssueID, "comment_id": id.CommentID}).
+			And(builder.Lt{"edited_unix": cutoff}).And(builder.Neq{"id": latest.ID}).
+			Delete(&ContentHistory{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package issues_test
 
 import (
 	"testing"
+	"time"
 
 	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
@@ -79,3 +80,30 @@ func TestContentHistory(t *testing.T) {
 	assert.EqualValues(t, 7, list2[1].HistoryID)
 	assert.EqualValues(t, 4, list2[2].HistoryID)
 }
+
+func TestDeleteOldIssueContentHistories(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	dbCtx := db.DefaultContext
+	old := timeutil.TimeStamp(time.Now().Add(-48 * time.Hour).Unix())
+	_ = issues_model.SaveIssueContentHistory(dbCtx, 1, 20, 0, old, "i-a", true)
+	_ = issues_model.SaveIssueContentHistory(dbCtx, 1, 20, 0, old.Add(1), "i-b", false)
+	_ = issues_model.SaveIssueContentHistory(dbCtx, 1, 20, 0, timeutil.TimeStampNow(), "i-c", false)
+	_ = issues_model.SaveIssueContentHistory(dbCtx, 1, 20, 100, old, "c-a", true)
+	_ = issues_model.SaveIssueContentHistory(dbCtx, 1, 20, 100, old.Add(1), "c-b", false)
+
+	assert.NoError(t, issues_model.DeleteOldIssueContentHistories(dbCtx, 24*time.Hour))
+
+	// the latest revision of the issue and of the comment are kept even if they are old
+	histories, count, err := issues_model.FindIssueContentHistories(dbCtx, 20, 0, db.ListOptions{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	if assert.Len(t, histories, 1) {
+		assert.Equal(t, "i-c", histories[0].ContentText)
+	}
+	histories, _, err = issues_model.FindIssueContentHistories(dbCtx, 20, 100, db.ListOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, histories, 1) {
+		assert.Equal(t, "c-b", histories[0].ContentText)
+	}
+}
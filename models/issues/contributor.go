@@ -0,0 +1,64 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// GetUserIDsByMergedPulls returns the ids of the users who authored at least min merged pull requests
+func GetUserIDsByMergedPulls(ctx context.Context, min int64) ([]int64, error) {
+	ids := make([]int64, 0, 10)
+	return ids, db.GetEngine(ctx).Table("pull_request").
+		Join("INNER", "issue", "issue.id = pull_request.issue_id").
+		Where(builder.Eq{"pull_request.has_merged": true}.And(builder.Gt{"issue.poster_id": 0})).
+		GroupBy("issue.poster_id").
+		Having(fmt.Sprintf("COUNT(*) >= %d", min)).
+		Cols("issue.poster_id").
+		Find(&ids)
+}
+
+// GetUserIDsBySubmittedReviews returns the ids of the users who submitted at least min reviews
+func GetUserIDsBySubmittedReviews(ctx context.Context, min int64) ([]int64, error) {
+	ids := make([]int64, 0, 10)
+	return ids, db.GetEngine(ctx).Table("review").
+		Where(builder.In("type", ReviewTypeApprove, ReviewTypeComment, ReviewTypeReject)).
+		And(builder.Gt{"reviewer_id": 0}).
+		And(builder.Eq{"original_author_id": 0}).
+		GroupBy("reviewer_id").
+		Having(fmt.Sprintf("COUNT(*) >= %d", min)).
+		Cols("reviewer_id").
+		Find(&ids)
+}
+
+// GetUserIDsByMergingPeriod returns the ids of the users who merged their first pull request before since
+// and merged one after activeSince
+func GetUserIDsByMergingPeriod(ctx context.Context, since, activeSince timeutil.TimeStamp) ([]int64, error) {
+	ids := make([]int64, 0, 10)
+	return ids, db.GetEngine(ctx).Table("pull_request").
+		Where(builder.Eq{"has_merged": true}.And(builder.Gt{"merger_id": 0})).
+		GroupBy("merger_id").
+		Having(fmt.Sprintf("MIN(merged_unix) <= %d AND MAX(merged_unix) >= %d", since, activeSince)).
+		Cols("merger_id").
+		Find(&ids)
+}
+
+// HasMergedPullRequest returns true if the user authored a pull request merged into the repository
+func HasMergedPullRequest(ctx context.Context, repoID, posterID int64) (bool, error) {
+	return db.GetEngine(ctx).Table("pull_request").
+		Join("INNER", "issue", "issue.id = pull_request.issue_id").
+		Where(builder.Eq{
+			"pull_request.base_repo_id": repoID,
+			"pull_request.has_merged":   true,
+			"issue.poster_id":           posterID,
+		}).
+		Exist()
+}
@@ -125,6 +125,9 @@ type Issue struct {
 	IsPull           bool             `xorm:"INDEX"` // Indicates whether is a pull request or not.
 	PullRequest      *PullRequest     `xorm:"-"`
 	NumComments      int
+	NumReactions     int `xorm:"INDEX NOT NULL DEFAULT 0"` // reactions to the issue itself, not to its comments
+	NumThumbsUp      int `xorm:"INDEX NOT NULL DEFAULT 0"`
+	NumVotes         int `xorm:"INDEX NOT NULL DEFAULT 0"`
 	Ref              string
 
 	DeadlineUnix timeutil.TimeStamp `xorm:"INDEX"`
@@ -144,6 +147,10 @@ type Issue struct {
 	// with write access
 	IsLocked bool `xorm:"NOT NULL DEFAULT false"`
 
+	// IsConfidential hides the issue from the users who can't write to the issues of the repository, except its poster
+	// and its assignees, see ConfidentialIssueCond
+	IsConfidential bool `xorm:"INDEX NOT NULL DEFAULT false"`
+
 	// For view issue page.
 	ShowRole RoleDescriptor `xorm:"-"`
 }
@@ -473,6 +480,16 @@ func (issue *Issue) Link() string {
 	return fmt.Sprintf("%s/%s/%d", issue.Repo.Link(), path, issue.Index)
 }
 
+// ServiceDeskHTMLURL returns the absolute URL of the issue in the service desk of its repository
+func (issue *Issue) ServiceDeskHTMLURL() string {
+	return fmt.Sprintf("%s/service_desk/%d", issue.Repo.HTMLURL(), issue.Index)
+}
+
+// ServiceDeskLink returns the relative URL of the issue in the service desk of its repository
+func (issue *Issue) ServiceDeskLink() string {
+	return fmt.Sprintf("%s/service_desk/%d", issue.Repo.Link(), issue.Index)
+}
+
 // DiffURL returns the absolute URL to this diff
 func (issue *Issue) DiffURL() string {
 	if issue.IsPull {
@@ -704,24 +721,7 @@ func doChangeIssueStatus(ctx context.Context, issue *Issue, doer *user_model.Use
 		return nil, err
 	}
 
-	// Update issue count of labels
-	if err := issue.getLabels(ctx); err != nil {
-		return nil, err
-	}
-	for idx := range issue.Labels {
-		if err := updateLabelCols(ctx, issue.Labels[idx], "num_issues", "num_closed_issue"); err != nil {
-			return nil, err
-		}
-	}
-
-	// Update issue count of milestone
-	if issue.MilestoneID > 0 {
-		if err := UpdateMilestoneCounters(ctx, issue.MilestoneID); err != nil {
-			return nil, err
-		}
-	}
-
-	if err := updateIssueClosedNum(ctx, issue); err != nil {
+	if err := updateIssueStatusCounters(ctx, issue); err != nil {
 		return nil, err
 	}
 
@@ -741,6 +741,53 @@ func doChangeIssueStatus(ctx context.Context, issue *Issue, doer *user_model.Use
 	})
 }
 
+func updateIssueStatusCounters(ctx context.Context, issue *Issue) error {
+	// Update issue count of labels
+	if err := issue.getLabels(ctx); err != nil {
+		return err
+	}
+	for idx := range issue.Labels {
+		if err := updateLabelCols(ctx, issue.Labels[idx], "num_issues", "num_closed_issue"); err != nil {
+			return err
+		}
+	}
+
+	// Update issue count of milestone
+	if issue.MilestoneID > 0 {
+		if err := UpdateMilestoneCounters(ctx, issue.MilestoneID); err != nil {
+			return err
+		}
+	}
+
+	return updateIssueClosedNum(ctx, issue)
+}
+
+// SetIssueHeld closes and locks an issue held for review, or reopens and unlocks it once approved.
+// Unlike ChangeIssueStatus it doesn't add a comment to the timeline.
+func SetIssueHeld(ctx context.Context, issue *Issue, held bool) error {
+	if issue.IsClosed == held && issue.IsLocked == held {
+		return nil
+	}
+	issue.IsClosed = held
+	issue.IsLocked = held
+	if held {
+		issue.ClosedUnix = timeutil.TimeStampNow()
+	} else {
+		issue.ClosedUnix = 0
+	}
+	if err := UpdateIssueCols(ctx, issue, "is_closed", "is_locked", "closed_unix"); err != nil {
+		return err
+	}
+	return updateIssueStatusCounters(ctx, issue)
+}
+
+// CountPosterIssuesWithContent counts the other issues of the poster with the same content created since the given time
+func CountPosterIssuesWithContent(ctx context.Context, posterID, excludeID int64, content string, since timeutil.TimeStamp) (int64, error) {
+	return db.GetEngine(ctx).
+		Where("poster_id = ? AND id <> ? AND content = ? AND created_unix >= ?", posterID, excludeID, content, since).
+		Count(new(Issue))
+}
+
 // ChangeIssueStatus changes issue status to open or closed.
 func ChangeIssueStatus(ctx context.Context, issue *Issue, doer *user_model.User, isClosed bool) (*Comment, error) {
 	if err := issue.LoadRepo(ctx); err != nil {
@@ -1195,16 +1242,24 @@ type IssuesOptions struct { //nolint
 	IncludedLabelNames []string
 	ExcludedLabelNames []string
 	IncludeMilestones  []string
-	SortType           string
-	IssueIDs           []int64
-	UpdatedAfterUnix   int64
-	UpdatedBeforeUnix  int64
+	// FieldValues are the values of the fields of the issues, keyed by field
+	FieldValues       map[int64]string
+	ComponentID       int64 // the issues touching a component
+	SortType          string
+	IssueIDs          []int64
+	UpdatedAfterUnix  int64
+	UpdatedBeforeUnix int64
+	// MinReactions and MinThumbsUp are the minimum numbers of reactions and of thumbs up to the issues
+	MinReactions int
+	MinThumbsUp  int
 	// prioritize issues from this repo
 	PriorityRepoID int64
 	IsArchived     util.OptionalBool
 	Org            *organization.Organization // issues permission scope
 	Team           *organization.Team         // issues permission scope
 	User           *user_model.User           // issues permission scope
+	// ConfidentialCond hides the confidential issues the viewer can't see, see ConfidentialIssueCond
+	ConfidentialCond builder.Cond
 }
 
 // sortIssuesSession sort an issues-related session based on the provided
@@ -1221,6 +1276,14 @@ func sortIssuesSession(sess *xorm.Session, sortType string, priorityRepoID int64
 		sess.Desc("issue.num_comments").Desc("issue.created_unix").Desc("issue.id")
 	case "leastcomment":
 		sess.Asc("issue.num_comments").Desc("issue.created_unix").Desc("issue.id")
+	case "mostreactions":
+		sess.Desc("issue.num_reactions").Desc("issue.created_unix").Desc("issue.id")
+	case "leastreactions":
+		sess.Asc("issue.num_reactions").Desc("issue.created_unix").Desc("issue.id")
+	case "mostthumbsup":
+		sess.Desc("issue.num_thumbs_up").Desc("issue.created_unix").Desc("issue.id")
+	case "mostvotes":
+		sess.Desc("issue.num_votes").Desc("issue.created_unix").Desc("issue.id")
 	case "priority":
 		sess.Desc("issue.priority").Desc("issue.created_unix").Desc("issue.id")
 	case "nearduedate":
@@ -1309,6 +1372,12 @@ func (opts *IssuesOptions) setupSessionNoLimit(sess *xorm.Session) {
 	if opts.UpdatedBeforeUnix != 0 {
 		sess.And(builder.Lte{"issue.updated_unix": opts.UpdatedBeforeUnix})
 	}
+	if opts.MinReactions > 0 {
+		sess.And(builder.Gte{"issue.num_reactions": opts.MinReactions})
+	}
+	if opts.MinThumbsUp > 0 {
+		sess.And(builder.Gte{"issue.num_thumbs_up": opts.MinThumbsUp})
+	}
 
 	if opts.ProjectID > 0 {
 		sess.Join("INNER", "project_issue", "issue.id = project_issue.issue_id").
@@ -1360,9 +1429,21 @@ func (opts *IssuesOptions) setupSessionNoLimit(sess *xorm.Session) {
 				Where(builder.In("name", opts.IncludeMilestones)))
 	}
 
+	if len(opts.FieldValues) > 0 {
+		sess.And(issueFieldValuesCond(opts.FieldValues))
+	}
+
+	if opts.ComponentID > 0 {
+		sess.And(issueComponentCond(opts.ComponentID))
+	}
+
 	if opts.User != nil {
 		sess.And(issuePullAccessibleRepoCond("issue.repo_id", opts.User.ID, opts.Org, opts.Team, opts.IsPull.IsTrue()))
 	}
+
+	if opts.ConfidentialCond != nil {
+		sess.And(opts.ConfidentialCond)
+	}
 }
 
 // teamUnitsRepoCond returns query condition for those repo id in the special org team with special units access
@@ -1610,6 +1691,9 @@ type IssueStatsOptions struct {
 	ReviewRequestedID int64
 	IsPull            util.OptionalBool
 	IssueIDs          []int64
+	FieldValues       map[int64]string
+	ComponentID       int64
+	ConfidentialCond  builder.Cond
 }
 
 const (
@@ -1662,6 +1746,10 @@ func getIssueStatsChunk(opts *IssueStatsOptions, issueIDs []int64) (*IssueStats,
 			sess.In("issue.id", issueIDs)
 		}
 
+		if opts.ConfidentialCond != nil {
+			sess.And(opts.ConfidentialCond)
+		}
+
 		if len(opts.Labels) > 0 && opts.Labels != "0" {
 			labelIDs, err := base.StringsToInt64s(strings.Split(opts.Labels, ","))
 			if err != nil {
@@ -1698,6 +1786,14 @@ func getIssueStatsChunk(opts *IssueStatsOptions, issueIDs []int64) (*IssueStats,
 			applyReviewRequestedCondition(sess, opts.ReviewRequestedID)
 		}
 
+		if len(opts.FieldValues) > 0 {
+			sess.And(issueFieldValuesCond(opts.FieldValues))
+		}
+
+		if opts.ComponentID > 0 {
+			sess.And(issueComponentCond(opts.ComponentID))
+		}
+
 		switch opts.IsPull {
 		case util.OptionalBoolTrue:
 			sess.And("issue.is_pull=?", true)
@@ -1734,6 +1830,8 @@ type UserIssueStatsOptions struct {
 	RepoCond   builder.Cond
 	Org        *organization.Organization
 	Team       *organization.Team
+	// ConfidentialCond hides the confidential issues the viewer can't see, see ConfidentialIssueCond
+	ConfidentialCond builder.Cond
 }
 
 // GetUserIssueStats returns issue statistic information for dashboard by given conditions.
@@ -1752,6 +1850,9 @@ func GetUserIssueStats(opts UserIssueStatsOptions) (*IssueStats, error) {
 	if opts.RepoCond != nil {
 		cond = cond.And(opts.RepoCond)
 	}
+	if opts.ConfidentialCond != nil {
+		cond = cond.And(opts.ConfidentialCond)
+	}
 
 	if opts.UserID > 0 {
 		cond = cond.And(issuePullAccessibleRepoCond("issue.repo_id", opts.UserID, opts.Org, opts.Team, opts.IsPull))
@@ -1941,6 +2042,59 @@ func SearchIssueIDsByKeyword(ctx context.Context, kw string, repoIDs []int64, li
 	return total, ids, nil
 }
 
+// similarTitleCandidates is the number of the most recently updated issues whose title contains any of the keywords
+// which are ranked by SearchIssueIDsBySimilarTitle
+const similarTitleCandidates = 200
+
+// SearchIssueIDsBySimilarTitle returns the ids of the issues whose title contains any of the lower-cased keywords, the
+// ones containing the most keywords first
+func SearchIssueIDsBySimilarTitle(ctx context.Context, keywords []string, repoIDs []int64, limit int) ([]int64, error) {
+	if len(keywords) == 0 {
+		return []int64{}, nil
+	}
+	kwCond := builder.NewCond()
+	for _, kw := range keywords {
+		kwCond = kwCond.Or(db.BuildCaseInsensitiveLike("name", kw))
+	}
+
+	candidates := make([]struct {
+		ID   int64
+		Name string
+	}, 0, similarTitleCandidates)
+	if err := db.GetEngine(ctx).Table("issue").Cols("id", "name").
+		Where(builder.And(builder.In("repo_id", repoIDs), kwCond)).
+		OrderBy("`updated_unix` DESC").Limit(similarTitleCandidates).
+		Find(&candidates); err != nil {
+		return nil, err
+	}
+
+	matches := make([]int, len(candidates))
+	for i, c := range candidates {
+		name := strings.ToLower(c.Name)
+		for _, kw := range keywords {
+			if strings.Contains(name, kw) {
+				matches[i]++
+			}
+		}
+	}
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return matches[order[i]] > matches[order[j]]
+	})
+
+	if len(order) > limit {
+		order = order[:limit]
+	}
+	ids := make([]int64, 0, len(order))
+	for _, i := range order {
+		ids = append(ids, candidates[i].ID)
+	}
+	return ids, nil
+}
+
 // UpdateIssueByAPI updates all allowed fields of given issue.
 // If the issue status is changed a statusChangeComment is returned
 // similarly if the title is changed the titleChanged bool is set to true
@@ -2190,6 +2344,13 @@ func ResolveIssueMentionsByVisibility(ctx context.Context, issue *Issue, doer *u
 				}
 			}
 			if len(checked) != 0 {
+				// mentioning a team also mentions the members of its descendants
+				descendantIDs, err := organization.GetTeamDescendantIDs(ctx, issue.Repo.OwnerID, checked...)
+				if err != nil {
+					return nil, fmt.Errorf("get descendant teams: %v", err)
+				}
+				checked = append(checked, descendantIDs...)
+
 				teamusers := make([]*user_model.User, 0, 20)
 				if err := db.GetEngine(ctx).
 					Join("INNER", "team_user", "team_user.uid = `user`.id").
@@ -2334,6 +2495,11 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 		return
 	}
 
+	if _, err = sess.In("issue_id", deleteCond).
+		Delete(&IssueVote{}); err != nil {
+		return
+	}
+
 	if _, err = sess.In("issue_id", deleteCond).
 		Delete(&Stopwatch{}); err != nil {
 		return
@@ -2349,6 +2515,26 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 		return
 	}
 
+	if _, err = sess.In("issue_id", deleteCond).
+		Delete(&PinnedIssue{}); err != nil {
+		return
+	}
+
+	if _, err = sess.In("issue_id", deleteCond).
+		Delete(&IssueFieldValue{}); err != nil {
+		return
+	}
+
+	if _, err = sess.In("issue_id", deleteCond).
+		Delete(&IssueComponent{}); err != nil {
+		return
+	}
+
+	if _, err = sess.In("issue_id", deleteCond).
+		Delete(&SubIssue{}); err != nil {
+		return
+	}
+
 	if _, err = sess.In("dependent_issue_id", deleteCond).
 		Delete(&Comment{}); err != nil {
 		return
@@ -0,0 +1,126 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/perm"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"xorm.io/builder"
+)
+
+// ConfidentialIssueCond returns the condition of the issues the user can see regarding their confidentiality: the
+// issues which aren't confidential, the ones the user posted or is assigned to and the ones of the repositories the
+// user owns or has write access to. The access is the one recorded for the whole repository, so a team member who can
+// only write to some units doesn't see them. It returns nil for the site admins, who see all of them.
+func ConfidentialIssueCond(user *user_model.User) builder.Cond {
+	cond := builder.Eq{"issue.is_confidential": false}
+	if user == nil {
+		return cond
+	}
+	if user.IsAdmin {
+		return nil
+	}
+	return builder.Or(
+		cond,
+		builder.Eq{"issue.poster_id": user.ID},
+		builder.In("issue.id", builder.Select("issue_id").From("issue_assignees").
+			Where(builder.Eq{"assignee_id": user.ID})),
+		builder.In("issue.repo_id", builder.Select("id").From("repository").
+			Where(builder.Eq{"owner_id": user.ID})),
+		builder.In("issue.repo_id", builder.Select("repo_id").From("access").
+			Where(builder.Eq{"user_id": user.ID}.And(builder.Gte{"mode": perm.AccessModeWrite}))),
+	)
+}
+
+// IsHiddenFrom returns whether the issue is confidential and the user can't see it. It checks ConfidentialIssueCond
+// on the issue, so an issue is hidden from the user on its own page exactly when it is missing from their lists.
+func (issue *Issue) IsHiddenFrom(ctx context.Context, user *user_model.User) (bool, error) {
+	if !issue.IsConfidential {
+		return false, nil
+	}
+	cond := ConfidentialIssueCond(user)
+	if cond == nil {
+		return false, nil
+	}
+	visible, err := db.GetEngine(ctx).Table("issue").Where(builder.Eq{"issue.id": issue.ID}.And(cond)).Exist()
+	return !visible, err
+}
+
+// VisibleTo returns the issues of the list which aren't hidden from the user
+func (issues IssueList) VisibleTo(ctx context.Context, user *user_model.User) (IssueList, error) {
+	visible := make(IssueList, 0, len(issues))
+	for _, issue := range issues {
+		if hidden, err := issue.IsHiddenFrom(ctx, user); err != nil {
+			return nil, err
+		} else if !hidden {
+			visible = append(visible, issue)
+		}
+	}
+	return visible, nil
+}
+
+// VisibleTo returns the pinned issues of the list which aren't hidden from the user
+func (pins PinnedIssueList) VisibleTo(ctx context.Context, user *user_model.User) (PinnedIssueList, error) {
+	visible := make(PinnedIssueList, 0, len(pins))
+	for _, pin := range pins {
+		if hidden, err := pin.Issue.IsHiddenFrom(ctx, user); err != nil {
+			return nil, err
+		} else if !hidden {
+			visible = append(visible, pin)
+		}
+	}
+	return visible, nil
+}
+
+// VisibleTo returns the tree without the sub-issues hidden from the user, their own sub-issues are removed with them
+func (tree SubIssueTree) VisibleTo(ctx context.Context, user *user_model.User) (SubIssueTree, error) {
+	visible := make(SubIssueTree, 0, len(tree))
+	for _, node := range tree {
+		if hidden, err := node.Issue.IsHiddenFrom(ctx, user); err != nil {
+			return nil, err
+		} else if hidden {
+			continue
+		}
+		children, err := node.Children.VisibleTo(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		visible = append(visible, &SubIssueNode{Issue: node.Issue, Children: children})
+	}
+	return visible, nil
+}
+
+// ChangeIssueConfidentiality marks or unmarks an issue confidential and adds a comment telling so
+func ChangeIssueConfidentiality(doer *user_model.User, issue *Issue, confidential bool) error {
+	if issue.IsConfidential == confidential {
+		return nil
+	}
+
+	return db.WithTx(func(ctx context.Context) error {
+		issue.IsConfidential = confidential
+		if err := UpdateIssueCols(ctx, issue, "is_confidential"); err != nil {
+			return err
+		}
+
+		if err := issue.LoadRepo(ctx); err != nil {
+			return err
+		}
+		commentType := CommentTypeUnmarkConfidential
+		if confidential {
+			commentType = CommentTypeMarkConfidential
+		}
+		_, err := CreateCommentCtx(ctx, &CreateCommentOptions{
+			Doer:  doer,
+			Issue: issue,
+			Repo:  issue.Repo,
+			Type:  commentType,
+		})
+		return err
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfidentialIssues(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// issue1 of the public repo1 of user2, posted by and assigned to the admin user1
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	stranger := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	assert.NoError(t, issues_model.ChangeIssueConfidentiality(owner, issue, true))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, IsConfidential: true})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 1, PosterID: 2, Type: issues_model.CommentTypeMarkConfidential})
+
+	for _, user := range []*user_model.User{nil, stranger} {
+		hidden, err := issue.IsHiddenFrom(db.DefaultContext, user)
+		assert.NoError(t, err)
+		assert.True(t, hidden)
+	}
+	hidden, err := issue.IsHiddenFrom(db.DefaultContext, owner)
+	assert.NoError(t, err)
+	assert.False(t, hidden)
+
+	findIDs := func(user *user_model.User) []int64 {
+		issues, err := issues_model.Issues(&issues_model.IssuesOptions{
+			RepoID:           1,
+			IsPull:           util.OptionalBoolFalse,
+			ConfidentialCond: issues_model.ConfidentialIssueCond(user),
+		})
+		assert.NoError(t, err)
+		ids := make([]int64, 0, len(issues))
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+	assert.NotContains(t, findIDs(nil), int64(1))
+	assert.NotContains(t, findIDs(stranger), int64(1))
+	assert.Contains(t, findIDs(owner), int64(1))
+	assert.Contains(t, findIDs(unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})), int64(1))
+
+	assert.NoError(t, issues_model.ChangeIssueConfidentiality(owner, issue, false))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 1, PosterID: 2, Type: issues_model.CommentTypeUnmarkConfidential})
+	assert.Contains(t, findIDs(stranger), int64(1))
+}
+
+func TestConfidentialIssuesPerUnitAccess(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// issue6 of the private repo3 of org3, whose team1 can now only read the code but still write the issues
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 6})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	_, err := db.GetEngine(db.DefaultContext).ID(2).Cols("authorize").Update(&organization.Team{AccessMode: perm.AccessModeRead})
+	assert.NoError(t, err)
+	_, err = db.GetEngine(db.DefaultContext).Where("team_id = ? AND type = ?", 2, unit.TypeCode).
+		Cols("access_mode").Update(&organization.TeamUnit{AccessMode: perm.AccessModeRead})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.GetOwner(db.DefaultContext))
+	assert.NoError(t, access_model.RecalculateAccesses(db.DefaultContext, repo))
+	assert.NoError(t, issues_model.ChangeIssueConfidentiality(unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1}), issue, true))
+
+	// the issue page and the issue lists agree for every user, the team member writing the issues doesn't see it
+	for _, id := range []int64{0, 1, 2, 4, 5} {
+		var user *user_model.User
+		if id > 0 {
+			user = unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: id})
+		}
+		hidden, err := issue.IsHiddenFrom(db.DefaultContext, user)
+		assert.NoError(t, err)
+		assert.Equal(t, id == 4 || id == 5 || id == 0, hidden, "user %d", id)
+
+		issues, err := issues_model.Issues(&issues_model.IssuesOptions{
+			RepoID:           3,
+			ConfidentialCond: issues_model.ConfidentialIssueCond(user),
+		})
+		assert.NoError(t, err)
+		visible, err := issues_model.IssueList{issue}.VisibleTo(db.DefaultContext, user)
+		assert.NoError(t, err)
+		assert.Equal(t, !hidden, len(visible) == 1, "user %d", id)
+		listed := false
+		for _, i := range issues {
+			listed = listed || i.ID == issue.ID
+		}
+		assert.Equal(t, !hidden, listed, "user %d", id)
+	}
+}
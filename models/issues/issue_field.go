@@ -0,0 +1,388 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrIssueFieldNotExist represents a "IssueFieldNotExist" kind of error.
+type ErrIssueFieldNotExist struct {
+	ID     int64
+	RepoID int64
+	OrgID  int64
+}
+
+// IsErrIssueFieldNotExist checks if an error is a ErrIssueFieldNotExist.
+func IsErrIssueFieldNotExist(err error) bool {
+	_, ok := err.(ErrIssueFieldNotExist)
+	return ok
+}
+
+func (err ErrIssueFieldNotExist) Error() string {
+	return fmt.Sprintf("issue field does not exist [id: %d, repo_id: %d, org_id: %d]", err.ID, err.RepoID, err.OrgID)
+}
+
+// ErrIssueFieldAlreadyExist represents a "IssueFieldAlreadyExist" kind of error.
+type ErrIssueFieldAlreadyExist struct {
+	Name string
+}
+
+// IsErrIssueFieldAlreadyExist checks if an error is a ErrIssueFieldAlreadyExist.
+func IsErrIssueFieldAlreadyExist(err error) bool {
+	_, ok := err.(ErrIssueFieldAlreadyExist)
+	return ok
+}
+
+func (err ErrIssueFieldAlreadyExist) Error() string {
+	return fmt.Sprintf("issue field already exists [name: %s]", err.Name)
+}
+
+// ErrInvalidIssueField represents a "InvalidIssueField" kind of error.
+type ErrInvalidIssueField struct {
+	Name   string
+	Reason string
+}
+
+// IsErrInvalidIssueField checks if an error is a ErrInvalidIssueField.
+func IsErrInvalidIssueField(err error) bool {
+	_, ok := err.(ErrInvalidIssueField)
+	return ok
+}
+
+func (err ErrInvalidIssueField) Error() string {
+	return fmt.Sprintf("invalid issue field [name: %s]: %s", err.Name, err.Reason)
+}
+
+// ErrInvalidIssueFieldValue represents a "InvalidIssueFieldValue" kind of error.
+type ErrInvalidIssueFieldValue struct {
+	Name  string
+	Value string
+}
+
+// IsErrInvalidIssueFieldValue checks if an error is a ErrInvalidIssueFieldValue.
+func IsErrInvalidIssueFieldValue(err error) bool {
+	_, ok := err.(ErrInvalidIssueFieldValue)
+	return ok
+}
+
+func (err ErrInvalidIssueFieldValue) Error() string {
+	return fmt.Sprintf("invalid value of issue field [name: %s, value: %s]", err.Name, err.Value)
+}
+
+// IssueFieldType is the type of the values of an issue field
+type IssueFieldType string
+
+// The types of the issue fields
+const (
+	IssueFieldTypeText   IssueFieldType = "text"
+	IssueFieldTypeNumber IssueFieldType = "number"
+	IssueFieldTypeEnum   IssueFieldType = "enum"
+	IssueFieldTypeDate   IssueFieldType = "date"
+)
+
+// IssueFieldTypes lists the types of the issue fields
+var IssueFieldTypes = []IssueFieldType{IssueFieldTypeText, IssueFieldTypeNumber, IssueFieldTypeEnum, IssueFieldTypeDate}
+
+// IsValid returns true if the type is known
+func (t IssueFieldType) IsValid() bool {
+	for _, fieldType := range IssueFieldTypes {
+		if t == fieldType {
+			return true
+		}
+	}
+	return false
+}
+
+// IssueFieldDateLayout is the layout of the values of the date fields
+const IssueFieldDateLayout = "2006-01-02"
+
+// maxIssueFieldValueLength is the maximum number of characters of the value of a text field
+const maxIssueFieldValueLength = 255
+
+// IssueField is a custom field of the issues of a repository, or of all the repositories of an organization
+type IssueField struct {
+	ID          int64          `xorm:"pk autoincr"`
+	RepoID      int64          `xorm:"INDEX NOT NULL DEFAULT 0"`
+	OrgID       int64          `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string         `xorm:"NOT NULL"`
+	Description string         `xorm:"TEXT"`
+	Type        IssueFieldType `xorm:"VARCHAR(10) NOT NULL"`
+	// Options are the values an enum field accepts
+	Options     []string           `xorm:"TEXT JSON"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// IssueFieldValue is the value of a custom field of an issue
+type IssueFieldValue struct {
+	ID          int64              `xorm:"pk autoincr"`
+	IssueID     int64              `xorm:"UNIQUE(s) NOT NULL"`
+	FieldID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Value       string             `xorm:"NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueField))
+	db.RegisterModel(new(IssueFieldValue))
+}
+
+// BelongsToOrg returns true if the field is a field of an organization
+func (f *IssueField) BelongsToOrg() bool {
+	return f.OrgID > 0
+}
+
+// Validate checks the name, the type and the options of the field
+func (f *IssueField) Validate() error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" {
+		return ErrInvalidIssueField{Name: f.Name, Reason: "the name is empty"}
+	}
+	if !f.Type.IsValid() {
+		return ErrInvalidIssueField{Name: f.Name, Reason: fmt.Sprintf("unknown type %q", f.Type)}
+	}
+	if f.Type != IssueFieldTypeEnum {
+		f.Options = nil
+		return nil
+	}
+
+	options := make([]string, 0, len(f.Options))
+	for _, option := range f.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || util.IsStringInSlice(option, options) {
+			continue
+		}
+		if utf8.RuneCountInString(option) > maxIssueFieldValueLength {
+			return ErrInvalidIssueField{Name: f.Name, Reason: fmt.Sprintf("the option %q is too long", option)}
+		}
+		options = append(options, option)
+	}
+	if len(options) == 0 {
+		return ErrInvalidIssueField{Name: f.Name, Reason: "an enum field needs options"}
+	}
+	f.Options = options
+	return nil
+}
+
+// NormalizeValue checks that a value is valid for the type of the field and returns it in the form it is stored:
+// numbers are formatted without superfluous digits and dates as IssueFieldDateLayout
+func (f *IssueField) NormalizeValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch f.Type {
+	case IssueFieldTypeText:
+		if utf8.RuneCountInString(value) <= maxIssueFieldValueLength {
+			return value, nil
+		}
+	case IssueFieldTypeNumber:
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return strconv.FormatFloat(number, 'f', -1, 64), nil
+		}
+	case IssueFieldTypeEnum:
+		if util.IsStringInSlice(value, f.Options) {
+			return value, nil
+		}
+	case IssueFieldTypeDate:
+		if date, err := time.Parse(IssueFieldDateLayout, value); err == nil {
+			return date.Format(IssueFieldDateLayout), nil
+		}
+	}
+	return "", ErrInvalidIssueFieldValue{Name: f.Name, Value: value}
+}
+
+func issueFieldNameExists(ctx context.Context, f *IssueField) (bool, error) {
+	return db.GetEngine(ctx).
+		Where(builder.Eq{"repo_id": f.RepoID, "org_id": f.OrgID, "name": f.Name}).
+		And(builder.Neq{"id": f.ID}).
+		Exist(new(IssueField))
+}
+
+// NewIssueField creates a custom field of the issues of a repository or an organization
+func NewIssueField(ctx context.Context, f *IssueField) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	if exist, err := issueFieldNameExists(ctx, f); err != nil {
+		return err
+	} else if exist {
+		return ErrIssueFieldAlreadyExist{Name: f.Name}
+	}
+	return db.Insert(ctx, f)
+}
+
+// UpdateIssueField updates the name, the description and the options of a field, the values of an enum field which
+// are not an option anymore are deleted. The type of a field cannot change.
+func UpdateIssueField(ctx context.Context, f *IssueField) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+
+	return db.WithTx(func(ctx context.Context) error {
+		if exist, err := issueFieldNameExists(ctx, f); err != nil {
+			return err
+		} else if exist {
+			return ErrIssueFieldAlreadyExist{Name: f.Name}
+		}
+		if _, err := db.GetEngine(ctx).ID(f.ID).Cols("name", "description", "options").Update(f); err != nil {
+			return err
+		}
+		if f.Type != IssueFieldTypeEnum {
+			return nil
+		}
+		_, err := db.GetEngine(ctx).Where(builder.Eq{"field_id": f.ID}).
+			And(builder.NotIn("value", f.Options)).
+			Delete(new(IssueFieldValue))
+		return err
+	}, ctx)
+}
+
+// DeleteIssueField deletes a field and its values
+func DeleteIssueField(ctx context.Context, f *IssueField) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.DeleteByBean(ctx, &IssueFieldValue{FieldID: f.ID}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(f.ID).Delete(new(IssueField))
+		return err
+	}, ctx)
+}
+
+// GetIssueFieldInRepoByID returns a field of the issues of a repository
+func GetIssueFieldInRepoByID(ctx context.Context, repoID, id int64) (*IssueField, error) {
+	f := &IssueField{}
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "repo_id": repoID}).Get(f)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrIssueFieldNotExist{ID: id, RepoID: repoID}
+	}
+	return f, nil
+}
+
+// GetIssueFieldInOrgByID returns a field of the issues of an organization
+func GetIssueFieldInOrgByID(ctx context.Context, orgID, id int64) (*IssueField, error) {
+	f := &IssueField{}
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "org_id": orgID}).Get(f)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrIssueFieldNotExist{ID: id, OrgID: orgID}
+	}
+	return f, nil
+}
+
+// GetIssueFieldsByRepoID returns the fields defined by a repository
+func GetIssueFieldsByRepoID(ctx context.Context, repoID int64) ([]*IssueField, error) {
+	fields := make([]*IssueField, 0, 5)
+	return fields, db.GetEngine(ctx).Where("repo_id=?", repoID).Asc("name", "id").Find(&fields)
+}
+
+// GetIssueFieldsByOrgID returns the fields defined by an organization
+func GetIssueFieldsByOrgID(ctx context.Context, orgID int64) ([]*IssueField, error) {
+	fields := make([]*IssueField, 0, 5)
+	return fields, db.GetEngine(ctx).Where("org_id=?", orgID).Asc("name", "id").Find(&fields)
+}
+
+func applicableIssueFieldsCond(repoID, ownerID int64) builder.Cond {
+	return builder.Or(builder.Eq{"repo_id": repoID}, builder.Eq{"org_id": ownerID})
+}
+
+// GetApplicableIssueFields returns the fields of the issues of a repository: the fields of its organization followed
+// by its own fields
+func GetApplicableIssueFields(ctx context.Context, repoID, ownerID int64) ([]*IssueField, error) {
+	fields := make([]*IssueField, 0, 5)
+	return fields, db.GetEngine(ctx).Where(applicableIssueFieldsCond(repoID, ownerID)).
+		Desc("org_id").Asc("name", "id").Find(&fields)
+}
+
+// GetApplicableIssueFieldByID returns a field of the issues of a repository, defined by the repository or by its
+// organization
+func GetApplicableIssueFieldByID(ctx context.Context, repoID, ownerID, id int64) (*IssueField, error) {
+	f := &IssueField{}
+	has, err := db.GetEngine(ctx).Where(applicableIssueFieldsCond(repoID, ownerID)).And("id=?", id).Get(f)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrIssueFieldNotExist{ID: id, RepoID: repoID}
+	}
+	return f, nil
+}
+
+// GetIssueFieldValues returns the values of the fields of an issue which apply to its repository
+func GetIssueFieldValues(ctx context.Context, issue *Issue) ([]*IssueFieldValue, error) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	values := make([]*IssueFieldValue, 0, 5)
+	return values, db.GetEngine(ctx).Where("issue_id=?", issue.ID).
+		In("field_id", builder.Select("id").From("issue_field").
+			Where(applicableIssueFieldsCond(issue.RepoID, issue.Repo.OwnerID))).
+		Find(&values)
+}
+
+// SetIssueFieldValue sets the value of a field of an issue, an empty value clears it
+func SetIssueFieldValue(ctx context.Context, issue *Issue, f *IssueField, value string) error {
+	if strings.TrimSpace(value) == "" {
+		_, err := db.DeleteByBean(ctx, &IssueFieldValue{IssueID: issue.ID, FieldID: f.ID})
+		return err
+	}
+	value, err := f.NormalizeValue(value)
+	if err != nil {
+		return err
+	}
+
+	return db.WithTx(func(ctx context.Context) error {
+		fieldValue := &IssueFieldValue{IssueID: issue.ID, FieldID: f.ID}
+		if has, err := db.GetByBean(ctx, fieldValue); err != nil {
+			return err
+		} else if !has {
+			fieldValue.Value = value
+			return db.Insert(ctx, fieldValue)
+		} else if fieldValue.Value == value {
+			return nil
+		}
+		fieldValue.Value = value
+		_, err := db.GetEngine(ctx).ID(fieldValue.ID).Cols("value").Update(fieldValue)
+		return err
+	}, ctx)
+}
+
+// issueFieldValuesCond returns the condition selecting the issues whose fields have the given values, keyed by field
+func issueFieldValuesCond(fieldValues map[int64]string) builder.Cond {
+	cond := builder.NewCond()
+	for fieldID, value := range fieldValues {
+		cond = cond.And(builder.In("issue.id", builder.Select("issue_id").From("issue_field_value").
+			Where(builder.Eq{"field_id": fieldID, "value": value})))
+	}
+	return cond
+}
+
+// ParseIssueFieldFilters parses the filters on the values of the fields of the issues, written as `<field id>:<value>`
+func ParseIssueFieldFilters(filters []string) (map[int64]string, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	fieldValues := make(map[int64]string, len(filters))
+	for _, filter := range filters {
+		id, value, ok := strings.Cut(filter, ":")
+		fieldID, err := strconv.ParseInt(id, 10, 64)
+		if !ok || err != nil || fieldID <= 0 {
+			return nil, fmt.Errorf("malformed issue field filter: %q", filter)
+		}
+		fieldValues[fieldID] = strings.TrimSpace(value)
+	}
+	return fieldValues, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueFieldNormalizeValue(t *testing.T) {
+	for _, tt := range []struct {
+		fieldType issues_model.IssueFieldType
+		value     string
+		want      string
+		valid     bool
+	}{
+		{issues_model.IssueFieldTypeText, " some text ", "some text", true},
+		{issues_model.IssueFieldTypeNumber, "3.50", "3.5", true},
+		{issues_model.IssueFieldTypeNumber, "three", "", false},
+		{issues_model.IssueFieldTypeEnum, "web", "web", true},
+		{issues_model.IssueFieldTypeEnum, "cli", "", false},
+		{issues_model.IssueFieldTypeDate, "2022-10-01", "2022-10-01", true},
+		{issues_model.IssueFieldTypeDate, "01/10/2022", "", false},
+	} {
+		field := &issues_model.IssueField{Name: "field", Type: tt.fieldType, Options: []string{"api", "web"}}
+		value, err := field.NormalizeValue(tt.value)
+		if tt.valid {
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		} else {
+			assert.True(t, issues_model.IsErrInvalidIssueFieldValue(err), "%s %q", tt.fieldType, tt.value)
+		}
+	}
+}
+
+func TestNewIssueField(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	field := &issues_model.IssueField{RepoID: 1, Name: " Severity ", Type: issues_model.IssueFieldTypeEnum, Options: []string{"low", " high", "", "low"}}
+	assert.NoError(t, issues_model.NewIssueField(db.DefaultContext, field))
+	field = unittest.AssertExistsAndLoadBean(t, &issues_model.IssueField{ID: field.ID})
+	assert.Equal(t, "Severity", field.Name)
+	assert.Equal(t, []string{"low", "high"}, field.Options)
+
+	err := issues_model.NewIssueField(db.DefaultContext, &issues_model.IssueField{RepoID: 1, Name: "Component", Type: issues_model.IssueFieldTypeText})
+	assert.True(t, issues_model.IsErrIssueFieldAlreadyExist(err))
+	// the names are unique within a repository or an organization
+	assert.NoError(t, issues_model.NewIssueField(db.DefaultContext, &issues_model.IssueField{OrgID: 3, Name: "Component", Type: issues_model.IssueFieldTypeText}))
+
+	err = issues_model.NewIssueField(db.DefaultContext, &issues_model.IssueField{RepoID: 1, Name: "Kind", Type: issues_model.IssueFieldTypeEnum})
+	assert.True(t, issues_model.IsErrInvalidIssueField(err))
+	err = issues_model.NewIssueField(db.DefaultContext, &issues_model.IssueField{RepoID: 1, Name: "Kind", Type: "list"})
+	assert.True(t, issues_model.IsErrInvalidIssueField(err))
+}
+
+func TestUpdateAndDeleteIssueField(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// the values which are not an option anymore are deleted
+	field := unittest.AssertExistsAndLoadBean(t, &issues_model.IssueField{ID: 2})
+	field.Options = []string{"api", "cli"}
+	assert.NoError(t, issues_model.UpdateIssueField(db.DefaultContext, field))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueFieldValue{FieldID: 2})
+
+	field.Name = "Story points"
+	assert.True(t, issues_model.IsErrIssueFieldAlreadyExist(issues_model.UpdateIssueField(db.DefaultContext, field)))
+
+	field = unittest.AssertExistsAndLoadBean(t, &issues_model.IssueField{ID: 1})
+	assert.NoError(t, issues_model.DeleteIssueField(db.DefaultContext, field))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueField{ID: 1})
+	unittest.AssertNotExistsBean(t, &issues_model.IssueFieldValue{FieldID: 1})
+}
+
+func TestGetApplicableIssueFields(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	fields, err := issues_model.GetApplicableIssueFields(db.DefaultContext, 1, 2)
+	assert.NoError(t, err)
+	if assert.Len(t, fields, 2) {
+		assert.EqualValues(t, 2, fields[0].ID)
+		assert.EqualValues(t, 1, fields[1].ID)
+	}
+
+	// the fields of the organization come first
+	fields, err = issues_model.GetApplicableIssueFields(db.DefaultContext, 3, 3)
+	assert.NoError(t, err)
+	if assert.Len(t, fields, 1) {
+		assert.EqualValues(t, 3, fields[0].ID)
+	}
+
+	_, err = issues_model.GetApplicableIssueFieldByID(db.DefaultContext, 3, 3, 1)
+	assert.True(t, issues_model.IsErrIssueFieldNotExist(err))
+}
+
+func TestSetIssueFieldValue(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+	field := unittest.AssertExistsAndLoadBean(t, &issues_model.IssueField{ID: 1})
+	assert.NoError(t, issues_model.SetIssueFieldValue(db.DefaultContext, issue, field, "8.0"))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueFieldValue{IssueID: 2, FieldID: 1, Value: "8"})
+	assert.True(t, issues_model.IsErrInvalidIssueFieldValue(issues_model.SetIssueFieldValue(db.DefaultContext, issue, field, "a lot")))
+
+	enumField := unittest.AssertExistsAndLoadBean(t, &issues_model.IssueField{ID: 2})
+	assert.NoError(t, issues_model.SetIssueFieldValue(db.DefaultContext, issue, enumField, "api"))
+	values, err := issues_model.GetIssueFieldValues(db.DefaultContext, issue)
+	assert.NoError(t, err)
+	assert.Len(t, values, 2)
+
+	assert.NoError(t, issues_model.SetIssueFieldValue(db.DefaultContext, issue, field, ""))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueFieldValue{IssueID: 2, FieldID: 1})
+}
+
+func TestIssuesByFieldValues(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	fieldValues, err := issues_model.ParseIssueFieldFilters([]string{"1:3", "2:web"})
+	assert.NoError(t, err)
+	issues, err := issues_model.Issues(&issues_model.IssuesOptions{RepoID: 1, FieldValues: fieldValues})
+	assert.NoError(t, err)
+	if assert.Len(t, issues, 1) {
+		assert.EqualValues(t, 1, issues[0].ID)
+	}
+
+	stats, err := issues_model.GetIssueStats(&issues_model.IssueStatsOptions{RepoID: 1, FieldValues: map[int64]string{1: "5"}})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, stats.OpenCount+stats.ClosedCount)
+
+	_, err = issues_model.ParseIssueFieldFilters([]string{"component:web"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,194 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// MaxIssueFilters is the maximum number of filters a user can save for the issues or the pull requests of a repository
+const MaxIssueFilters = 20
+
+// ErrTooManyIssueFilters is returned when a user has already saved the maximum number of filters
+var ErrTooManyIssueFilters = errors.New("too many issue filters")
+
+// ErrIssueFilterNotExist represents a "IssueFilterNotExist" kind of error.
+type ErrIssueFilterNotExist struct {
+	ID int64
+}
+
+// IsErrIssueFilterNotExist checks if an error is a ErrIssueFilterNotExist.
+func IsErrIssueFilterNotExist(err error) bool {
+	_, ok := err.(ErrIssueFilterNotExist)
+	return ok
+}
+
+func (err ErrIssueFilterNotExist) Error() string {
+	return fmt.Sprintf("issue filter does not exist [id: %d]", err.ID)
+}
+
+// IssueFilterSortTypes are the sort types of the issue list a filter can use
+var IssueFilterSortTypes = []string{
+	"", "latest", "oldest", "recentupdate", "leastupdate", "mostcomment", "leastcomment",
+	"mostreactions", "leastreactions", "mostthumbsup", "mostvotes", "nearduedate", "farduedate", "priority",
+}
+
+// IssueFilter is a named combination of filters of the issue or pull request list of a repository saved by a user,
+// the pinned filters are shown above the list
+type IssueFilter struct {
+	ID     int64  `xorm:"pk autoincr"`
+	UserID int64  `xorm:"INDEX(s) NOT NULL"`
+	RepoID int64  `xorm:"INDEX(s) NOT NULL"`
+	IsPull bool   `xorm:"NOT NULL DEFAULT false"`
+	Name   string `xorm:"NOT NULL"`
+	// Labels are the selected label ids, negative ids exclude their label
+	Labels      string `xorm:"TEXT"`
+	MilestoneID int64  `xorm:"NOT NULL DEFAULT 0"`
+	AssigneeID  int64  `xorm:"NOT NULL DEFAULT 0"`
+	PosterID    int64  `xorm:"NOT NULL DEFAULT 0"`
+	IsClosed    bool   `xorm:"NOT NULL DEFAULT false"`
+	SortType    string `xorm:"VARCHAR(20)"`
+	Keyword     string
+	IsPinned    bool               `xorm:"NOT NULL DEFAULT false"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueFilter))
+}
+
+// LabelIDs returns the selected label ids of the filter
+func (f *IssueFilter) LabelIDs() []int64 {
+	if f.Labels == "" {
+		return []int64{}
+	}
+	ids := make([]int64, 0, strings.Count(f.Labels, ",")+1)
+	for _, s := range strings.Split(f.Labels, ",") {
+		if id, err := strconv.ParseInt(s, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// SetLabelIDs sets the selected label ids of the filter
+func (f *IssueFilter) SetLabelIDs(ids []int64) {
+	labels := make([]string, 0, len(ids))
+	for _, id := range ids {
+		labels = append(labels, strconv.FormatInt(id, 10))
+	}
+	f.Labels = strings.Join(labels, ",")
+}
+
+// State returns the state of the issues the filter selects
+func (f *IssueFilter) State() string {
+	if f.IsClosed {
+		return "closed"
+	}
+	return "open"
+}
+
+// Link returns the link to the issue or pull request list of the repository with the filter applied
+func (f *IssueFilter) Link(repoLink string) string {
+	query := url.Values{}
+	query.Set("type", "all")
+	query.Set("state", f.State())
+	if f.SortType != "" {
+		query.Set("sort", f.SortType)
+	}
+	if f.Labels != "" {
+		query.Set("labels", f.Labels)
+	}
+	if f.MilestoneID != 0 {
+		query.Set("milestone", strconv.FormatInt(f.MilestoneID, 10))
+	}
+	if f.AssigneeID != 0 {
+		query.Set("assignee", strconv.FormatInt(f.AssigneeID, 10))
+	}
+	if f.PosterID != 0 {
+		query.Set("poster", strconv.FormatInt(f.PosterID, 10))
+	}
+	if f.Keyword != "" {
+		query.Set("q", f.Keyword)
+	}
+	if f.IsPull {
+		return repoLink + "/pulls?" + query.Encode()
+	}
+	return repoLink + "/issues?" + query.Encode()
+}
+
+// validate checks the name and the sort type of the filter
+func (f *IssueFilter) validate() error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" {
+		return errors.New("the name of an issue filter must not be empty")
+	}
+	if !util.IsStringInSlice(f.SortType, IssueFilterSortTypes) {
+		return fmt.Errorf("invalid sort type %q", f.SortType)
+	}
+	return nil
+}
+
+// CreateIssueFilter saves a filter of a user
+func CreateIssueFilter(ctx context.Context, f *IssueFilter) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	count, err := db.GetEngine(ctx).Where("user_id=? AND repo_id=?", f.UserID, f.RepoID).Count(new(IssueFilter))
+	if err != nil {
+		return err
+	}
+	if count >= MaxIssueFilters {
+		return ErrTooManyIssueFilters
+	}
+	return db.Insert(ctx, f)
+}
+
+// GetIssueFilter returns a filter saved by a user for a repository
+func GetIssueFilter(ctx context.Context, userID, repoID, id int64) (*IssueFilter, error) {
+	f := &IssueFilter{}
+	has, err := db.GetEngine(ctx).Where("id=? AND user_id=? AND repo_id=?", id, userID, repoID).Get(f)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrIssueFilterNotExist{ID: id}
+	}
+	return f, nil
+}
+
+// GetIssueFilters returns the filters saved by a user for the issues and the pull requests of a repository, the
+// pinned ones first and then in the order they were saved
+func GetIssueFilters(ctx context.Context, userID, repoID int64, isPull util.OptionalBool) ([]*IssueFilter, error) {
+	sess := db.GetEngine(ctx).Where("user_id=? AND repo_id=?", userID, repoID)
+	if !isPull.IsNone() {
+		sess = sess.And("is_pull=?", isPull.IsTrue())
+	}
+	filters := make([]*IssueFilter, 0, 5)
+	return filters, sess.Desc("is_pinned").Asc("id").Find(&filters)
+}
+
+// UpdateIssueFilter updates a saved filter
+func UpdateIssueFilter(ctx context.Context, f *IssueFilter) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(f.ID).AllCols().Omit("user_id", "repo_id", "created_unix").Update(f)
+	return err
+}
+
+// DeleteIssueFilter deletes a filter saved by a user for a repository
+func DeleteIssueFilter(ctx context.Context, userID, repoID, id int64) error {
+	_, err := db.GetEngine(ctx).Where("id=? AND user_id=? AND repo_id=?", id, userID, repoID).Delete(new(IssueFilter))
+	return err
+}
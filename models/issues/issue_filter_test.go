@@ -0,0 +1,70 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueFilterLink(t *testing.T) {
+	f := &issues_model.IssueFilter{IsPull: true, SortType: "oldest", AssigneeID: 2, Keyword: "a b"}
+	f.SetLabelIDs([]int64{1, -2})
+	assert.Equal(t, []int64{1, -2}, f.LabelIDs())
+	assert.Equal(t, "/user2/repo1/pulls?assignee=2&labels=1%2C-2&q=a+b&sort=oldest&state=open&type=all", f.Link("/user2/repo1"))
+}
+
+func TestCreateIssueFilter(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	f := &issues_model.IssueFilter{UserID: 2, RepoID: 1, Name: " Closed ", IsClosed: true, IsPinned: true}
+	assert.NoError(t, issues_model.CreateIssueFilter(db.DefaultContext, f))
+	assert.Equal(t, "Closed", f.Name)
+	assert.Error(t, issues_model.CreateIssueFilter(db.DefaultContext, &issues_model.IssueFilter{UserID: 2, RepoID: 1, Name: ""}))
+	assert.Error(t, issues_model.CreateIssueFilter(db.DefaultContext, &issues_model.IssueFilter{UserID: 2, RepoID: 1, Name: "a", SortType: "unknown"}))
+	assert.NoError(t, issues_model.CreateIssueFilter(db.DefaultContext, &issues_model.IssueFilter{UserID: 2, RepoID: 1, Name: "Pulls", IsPull: true}))
+
+	filters, err := issues_model.GetIssueFilters(db.DefaultContext, 2, 1, util.OptionalBoolFalse)
+	assert.NoError(t, err)
+	if assert.Len(t, filters, 2) {
+		assert.EqualValues(t, 1, filters[0].ID)
+		assert.Equal(t, f.ID, filters[1].ID)
+	}
+	filters, err = issues_model.GetIssueFilters(db.DefaultContext, 2, 1, util.OptionalBoolNone)
+	assert.NoError(t, err)
+	assert.Len(t, filters, 3)
+
+	for i := 3; i < issues_model.MaxIssueFilters; i++ {
+		assert.NoError(t, issues_model.CreateIssueFilter(db.DefaultContext, &issues_model.IssueFilter{UserID: 2, RepoID: 1, Name: "f"}))
+	}
+	assert.ErrorIs(t, issues_model.CreateIssueFilter(db.DefaultContext, &issues_model.IssueFilter{UserID: 2, RepoID: 1, Name: "f"}), issues_model.ErrTooManyIssueFilters)
+}
+
+func TestUpdateAndDeleteIssueFilter(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	_, err := issues_model.GetIssueFilter(db.DefaultContext, 4, 1, 1)
+	assert.True(t, issues_model.IsErrIssueFilterNotExist(err))
+
+	f, err := issues_model.GetIssueFilter(db.DefaultContext, 2, 1, 1)
+	assert.NoError(t, err)
+	f.IsPinned = false
+	f.Labels = ""
+	assert.NoError(t, issues_model.UpdateIssueFilter(db.DefaultContext, f))
+	f = unittest.AssertExistsAndLoadBean(t, &issues_model.IssueFilter{ID: 1, UserID: 2})
+	assert.False(t, f.IsPinned)
+	assert.Empty(t, f.Labels)
+
+	assert.NoError(t, issues_model.DeleteIssueFilter(db.DefaultContext, 4, 1, 1))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueFilter{ID: 1})
+	assert.NoError(t, issues_model.DeleteIssueFilter(db.DefaultContext, 2, 1, 1))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueFilter{ID: 1})
+}
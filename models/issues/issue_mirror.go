@@ -0,0 +1,299 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/foreignreference"
+	"code.gitea.io/gitea/modules/secret"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// IssueMirrorServices are the services whose issue trackers can be mirrored
+var IssueMirrorServices = []structs.GitServiceType{
+	structs.GithubService,
+	structs.GitlabService,
+	structs.GiteaService,
+	structs.GogsService,
+	structs.GitBucketService,
+}
+
+// IssueMirror represents the read-only mirror of the issue tracker of an external repository into a repository. The
+// mirrored issues are matched to the external ones by their foreign references, and while the mirror exists the issues
+// of the repository can't be opened or changed locally.
+type IssueMirror struct {
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"UNIQUE NOT NULL"`
+	// DoerID is the user who set up the mirror, the issues and comments of the external users without a local account
+	// are attributed to this user
+	DoerID      int64                  `xorm:"NOT NULL"`
+	ServiceType structs.GitServiceType `xorm:"NOT NULL DEFAULT 0"`
+	// RemoteAddress is the address of the external repository, without credentials
+	RemoteAddress      string             `xorm:"VARCHAR(2048) NOT NULL"`
+	AuthTokenEncrypted string             `xorm:"TEXT"`
+	LastSyncUnix       timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	// LastError is the reason of the failure of the last synchronization, empty if it succeeded
+	LastError   string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueMirror))
+}
+
+// IsIssueMirrorService returns whether the issue tracker of a service can be mirrored
+func IsIssueMirrorService(tp structs.GitServiceType) bool {
+	for _, t := range IssueMirrorServices {
+		if t == tp {
+			return true
+		}
+	}
+	return false
+}
+
+// SetAuthToken encrypts the token used to read the external repository
+func (m *IssueMirror) SetAuthToken(token string) (err error) {
+	if token == "" {
+		m.AuthTokenEncrypted = ""
+		return nil
+	}
+	m.AuthTokenEncrypted, err = secret.EncryptSecret(setting.SecretKey, token)
+	return err
+}
+
+// AuthToken decrypts the token used to read the external repository
+func (m *IssueMirror) AuthToken() (string, error) {
+	if m.AuthTokenEncrypted == "" {
+		return "", nil
+	}
+	return secret.DecryptSecret(setting.SecretKey, m.AuthTokenEncrypted)
+}
+
+// IssueURL returns the address of an external issue from its foreign index
+func (m *IssueMirror) IssueURL(foreignIndex string) string {
+	base := strings.TrimSuffix(strings.TrimSuffix(m.RemoteAddress, "/"), ".git")
+	if m.ServiceType == structs.GitlabService {
+		return base + "/-/issues/" + foreignIndex
+	}
+	return base + "/issues/" + foreignIndex
+}
+
+// ExternalIssueURL returns the address of the external issue of a mirrored issue, empty if the issue isn't mirrored
+func (m *IssueMirror) ExternalIssueURL(ctx context.Context, issue *Issue) (string, error) {
+	if err := issue.loadForeignReference(ctx); err != nil {
+		if foreignreference.IsErrForeignIndexNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return m.IssueURL(issue.ForeignReference.ForeignIndex), nil
+}
+
+// GetIssueMirror returns the issue mirror of a repository, nil if its issues aren't mirrored
+func GetIssueMirror(ctx context.Context, repoID int64) (*IssueMirror, error) {
+	m := new(IssueMirror)
+	has, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Get(m)
+	if err != nil || !has {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IsIssueMirror returns whether the issues of a repository are mirrored from an external repository
+func IsIssueMirror(ctx context.Context, repoID int64) (bool, error) {
+	return db.GetEngine(ctx).Where("repo_id = ?", repoID).Exist(new(IssueMirror))
+}
+
+// SaveIssueMirror sets up the issue mirror of a repository, replacing the previous one if any
+func SaveIssueMirror(ctx context.Context, m *IssueMirror) error {
+	return db.WithTx(func(ctx context.Context) error {
+		old, err := GetIssueMirror(ctx, m.RepoID)
+		if err != nil {
+			return err
+		}
+		if old == nil {
+			return db.Insert(ctx, m)
+		}
+		m.ID = old.ID
+		_, err = db.GetEngine(ctx).ID(m.ID).Cols("doer_id", "service_type", "remote_address", "auth_token_encrypted").Update(m)
+		return err
+	}, ctx)
+}
+
+// DeleteIssueMirror stops mirroring the issues of a repository, the mirrored issues are kept and can be changed again
+func DeleteIssueMirror(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(new(IssueMirror))
+	return err
+}
+
+// FindIssueMirrors returns all the issue mirrors
+func FindIssueMirrors(ctx context.Context) ([]*IssueMirror, error) {
+	mirrors := make([]*IssueMirror, 0, 10)
+	return mirrors, db.GetEngine(ctx).Asc("id").Find(&mirrors)
+}
+
+// UpdateIssueMirrorResult records the time and the error, if any, of the last synchronization of an issue mirror
+func UpdateIssueMirrorResult(ctx context.Context, m *IssueMirror) error {
+	_, err := db.GetEngine(ctx).ID(m.ID).Cols("last_sync_unix", "last_error").Update(m)
+	return err
+}
+
+// InsertMirroredIssue inserts an issue mirrored from the external issue of the foreign index, with its labels. The
+// issue keeps the number of the external issue when it's free, so that the references between the issues still work.
+func InsertMirroredIssue(ctx context.Context, issue *Issue, foreignIndex string) error {
+	taken := issue.Index <= 0
+	if !taken {
+		var err error
+		if taken, err = db.GetEngine(ctx).Exist(&Issue{RepoID: issue.RepoID, Index: issue.Index}); err != nil {
+			return err
+		}
+	}
+	if taken {
+		var err error
+		if issue.Index, err = db.GetNextResourceIndex("issue_index", issue.RepoID); err != nil {
+			return err
+		}
+	}
+
+	return db.WithTx(func(ctx context.Context) error {
+		e := db.GetEngine(ctx)
+		// the reference of a mirrored issue which has been deleted since
+		if _, err := e.Delete(&foreignreference.ForeignReference{
+			RepoID:       issue.RepoID,
+			ForeignIndex: foreignIndex,
+			Type:         foreignreference.TypeIssue,
+		}); err != nil {
+			return err
+		}
+
+		if _, err := e.NoAutoTime().Insert(issue); err != nil {
+			return err
+		}
+		if err := insertMirroredIssueLabels(ctx, issue.ID, issue.Labels); err != nil {
+			return err
+		}
+		if err := db.Insert(ctx, &foreignreference.ForeignReference{
+			RepoID:       issue.RepoID,
+			LocalIndex:   issue.Index,
+			ForeignIndex: foreignIndex,
+			Type:         foreignreference.TypeIssue,
+		}); err != nil {
+			return err
+		}
+
+		if err := db.UpsertResourceIndex(ctx, "issue_index", issue.RepoID); err != nil {
+			return err
+		}
+		_, err := e.Exec("UPDATE `issue_index` SET max_index = (SELECT MAX(`index`) FROM `issue` WHERE repo_id = ?) WHERE group_id = ?",
+			issue.RepoID, issue.RepoID)
+		return err
+	}, ctx)
+}
+
+// UpdateMirroredIssue updates an issue and replaces its labels from its external issue, without any comment
+func UpdateMirroredIssue(ctx context.Context, issue *Issue) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(issue.ID).NoAutoTime().
+			Cols("name", "content", "is_closed", "is_locked", "closed_unix", "updated_unix", "original_author", "original_author_id", "poster_id").
+			Update(issue); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).Delete(new(IssueLabel)); err != nil {
+			return err
+		}
+		return insertMirroredIssueLabels(ctx, issue.ID, issue.Labels)
+	}, ctx)
+}
+
+func insertMirroredIssueLabels(ctx context.Context, issueID int64, labels []*Label) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	issueLabels := make([]*IssueLabel, 0, len(labels))
+	for _, label := range labels {
+		issueLabels = append(issueLabels, &IssueLabel{IssueID: issueID, LabelID: label.ID})
+	}
+	return db.Insert(ctx, issueLabels)
+}
+
+// GetMirroredComments returns the comments of an issue mirrored from external comments, by their foreign indexes
+func GetMirroredComments(ctx context.Context, issue *Issue) (map[string]*Comment, error) {
+	comments := make([]*Comment, 0, 10)
+	if err := db.GetEngine(ctx).Where(builder.Eq{"issue_id": issue.ID, "type": CommentTypeComment}).Find(&comments); err != nil {
+		return nil, err
+	}
+	if len(comments) == 0 {
+		return map[string]*Comment{}, nil
+	}
+	byID := make(map[int64]*Comment, len(comments))
+	ids := make([]int64, 0, len(comments))
+	for _, c := range comments {
+		byID[c.ID] = c
+		ids = append(ids, c.ID)
+	}
+
+	refs := make([]*foreignreference.ForeignReference, 0, len(comments))
+	if err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": issue.RepoID, "type": foreignreference.TypeComment}).
+		And(builder.In("local_index", ids)).
+		Find(&refs); err != nil {
+		return nil, err
+	}
+	mirrored := make(map[string]*Comment, len(refs))
+	for _, ref := range refs {
+		mirrored[ref.ForeignIndex] = byID[ref.LocalIndex]
+	}
+	return mirrored, nil
+}
+
+// InsertMirroredComment inserts a comment mirrored from the external comment of the foreign index
+func InsertMirroredComment(ctx context.Context, repoID int64, c *Comment, foreignIndex string) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).NoAutoTime().Insert(c); err != nil {
+			return err
+		}
+		if err := db.Insert(ctx, &foreignreference.ForeignReference{
+			RepoID:       repoID,
+			LocalIndex:   c.ID,
+			ForeignIndex: foreignIndex,
+			Type:         foreignreference.TypeComment,
+		}); err != nil {
+			return err
+		}
+		return updateMirroredIssueNumComments(ctx, c.IssueID)
+	}, ctx)
+}
+
+// UpdateMirroredComment updates the content of a comment from its external comment
+func UpdateMirroredComment(ctx context.Context, c *Comment) error {
+	_, err := db.GetEngine(ctx).ID(c.ID).NoAutoTime().Cols("content", "updated_unix").Update(c)
+	return err
+}
+
+// DeleteMirroredComment deletes a comment whose external comment has been deleted
+func DeleteMirroredComment(ctx context.Context, repoID int64, c *Comment) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(c.ID).NoAutoCondition().Delete(c); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID, "type": foreignreference.TypeComment, "local_index": c.ID}).
+			Delete(new(foreignreference.ForeignReference)); err != nil {
+			return err
+		}
+		return updateMirroredIssueNumComments(ctx, c.IssueID)
+	}, ctx)
+}
+
+func updateMirroredIssueNumComments(ctx context.Context, issueID int64) error {
+	_, err := db.Exec(ctx, "UPDATE issue SET num_comments = (SELECT COUNT(*) FROM comment WHERE issue_id = ? AND `type` = ?) WHERE id = ?",
+		issueID, CommentTypeComment, issueID)
+	return err
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/foreignreference"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueMirror(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	m, err := issues_model.GetIssueMirror(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+
+	m = &issues_model.IssueMirror{
+		RepoID:        1,
+		DoerID:        2,
+		ServiceType:   structs.GitlabService,
+		RemoteAddress: "https://gitlab.com/owner/repo.git",
+	}
+	assert.NoError(t, m.SetAuthToken("secret"))
+	assert.NotContains(t, m.AuthTokenEncrypted, "secret")
+	assert.NoError(t, issues_model.SaveIssueMirror(db.DefaultContext, m))
+	assert.Equal(t, "https://gitlab.com/owner/repo/-/issues/7", m.IssueURL("7"))
+
+	// saving again replaces the mirror of the repository
+	m = &issues_model.IssueMirror{RepoID: 1, DoerID: 2, ServiceType: structs.GithubService, RemoteAddress: "https://github.com/owner/repo/"}
+	assert.NoError(t, issues_model.SaveIssueMirror(db.DefaultContext, m))
+	unittest.AssertCount(t, &issues_model.IssueMirror{RepoID: 1}, 1)
+	m, err = issues_model.GetIssueMirror(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, structs.GithubService, m.ServiceType)
+	assert.Equal(t, "https://github.com/owner/repo/issues/7", m.IssueURL("7"))
+	token, err := m.AuthToken()
+	assert.NoError(t, err)
+	assert.Empty(t, token)
+
+	isMirror, err := issues_model.IsIssueMirror(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.True(t, isMirror)
+
+	assert.NoError(t, issues_model.DeleteIssueMirror(db.DefaultContext, 1))
+	isMirror, err = issues_model.IsIssueMirror(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.False(t, isMirror)
+}
+
+func TestInsertMirroredIssue(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	label := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 1, RepoID: 1})
+
+	// the number of the external issue is kept when it's free
+	issue := &issues_model.Issue{RepoID: 1, Index: 1000, PosterID: 2, Title: "free", Labels: []*issues_model.Label{label}}
+	assert.NoError(t, issues_model.InsertMirroredIssue(db.DefaultContext, issue, "1000"))
+	assert.EqualValues(t, 1000, issue.Index)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: issue.ID, LabelID: 1})
+	unittest.AssertExistsAndLoadBean(t, &foreignreference.ForeignReference{RepoID: 1, LocalIndex: 1000, ForeignIndex: "1000", Type: foreignreference.TypeIssue})
+
+	// otherwise the issue gets the next index of the repository
+	issue = &issues_model.Issue{RepoID: 1, Index: 1, PosterID: 2, Title: "taken"}
+	assert.NoError(t, issues_model.InsertMirroredIssue(db.DefaultContext, issue, "1"))
+	assert.EqualValues(t, 1001, issue.Index)
+
+	c := &issues_model.Comment{IssueID: issue.ID, PosterID: 2, Type: issues_model.CommentTypeComment, Content: "mirrored"}
+	assert.NoError(t, issues_model.InsertMirroredComment(db.DefaultContext, 1, c, "42"))
+	comments, err := issues_model.GetMirroredComments(db.DefaultContext, issue)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, c.ID, comments["42"].ID)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID, NumComments: 1})
+
+	assert.NoError(t, issues_model.DeleteMirroredComment(db.DefaultContext, 1, c))
+	unittest.AssertNotExistsBean(t, &issues_model.Comment{ID: c.ID})
+	unittest.AssertNotExistsBean(t, &foreignreference.ForeignReference{RepoID: 1, LocalIndex: c.ID, Type: foreignreference.TypeComment})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID}, "num_comments = 0")
+}
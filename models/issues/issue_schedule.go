@@ -0,0 +1,148 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/gogs/cron"
+)
+
+// ErrIssueScheduleNotExist represents a "IssueScheduleNotExist" kind of error.
+type ErrIssueScheduleNotExist struct {
+	ID     int64
+	RepoID int64
+}
+
+// IsErrIssueScheduleNotExist checks if an error is a ErrIssueScheduleNotExist.
+func IsErrIssueScheduleNotExist(err error) bool {
+	_, ok := err.(ErrIssueScheduleNotExist)
+	return ok
+}
+
+func (err ErrIssueScheduleNotExist) Error() string {
+	return fmt.Sprintf("issue schedule does not exist [id: %d, repo_id: %d]", err.ID, err.RepoID)
+}
+
+// IssueSchedule is a recurring issue created in a repository on a cron schedule, e.g. a weekly release checklist.
+// The issues are posted by the creator of the schedule.
+type IssueSchedule struct {
+	ID        int64 `xorm:"pk autoincr"`
+	RepoID    int64 `xorm:"INDEX NOT NULL"`
+	CreatorID int64 `xorm:"NOT NULL"`
+	Title     string
+	Content   string `xorm:"LONGTEXT"`
+	// TemplateFile is the issue template of the repository whose content replaces Content when it is set
+	TemplateFile string
+	// Schedule is a standard crontab spec, e.g. "0 9 * * 1", or a descriptor, e.g. "@weekly"
+	Schedule    string
+	AssigneeIDs []int64 `xorm:"JSON TEXT"`
+	LabelIDs    []int64 `xorm:"JSON TEXT"`
+	IsActive    bool    `xorm:"INDEX NOT NULL DEFAULT true"`
+
+	NextRunUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	LastRunUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	// LastIssueID is the issue created by the last run
+	LastIssueID int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueSchedule))
+}
+
+// ValidateIssueSchedule returns an error if a schedule isn't a valid standard crontab spec or descriptor
+func ValidateIssueSchedule(schedule string) error {
+	_, err := cron.ParseStandard(schedule)
+	return err
+}
+
+// NextRun returns the first time of the schedule after a given time
+func (s *IssueSchedule) NextRun(after time.Time) (timeutil.TimeStamp, error) {
+	schedule, err := cron.ParseStandard(s.Schedule)
+	if err != nil {
+		return 0, err
+	}
+	return timeutil.TimeStamp(schedule.Next(after).Unix()), nil
+}
+
+// CreateIssueSchedule creates a recurring issue, its first run is computed from now
+func CreateIssueSchedule(ctx context.Context, s *IssueSchedule) (err error) {
+	if s.NextRunUnix, err = s.NextRun(time.Now()); err != nil {
+		return err
+	}
+	return db.Insert(ctx, s)
+}
+
+// UpdateIssueSchedule updates a recurring issue, its next run is computed again from now
+func UpdateIssueSchedule(ctx context.Context, s *IssueSchedule) (err error) {
+	if s.NextRunUnix, err = s.NextRun(time.Now()); err != nil {
+		return err
+	}
+	_, err = db.GetEngine(ctx).ID(s.ID).
+		Cols("title", "content", "template_file", "schedule", "assignee_i_ds", "label_i_ds", "is_active", "next_run_unix").
+		Update(s)
+	return err
+}
+
+// UpdateIssueScheduleRun records the issue created by a run of a recurring issue and computes its next run
+func UpdateIssueScheduleRun(ctx context.Context, s *IssueSchedule, issueID int64, runAt time.Time) (err error) {
+	if s.NextRunUnix, err = s.NextRun(runAt); err != nil {
+		return err
+	}
+	s.LastRunUnix = timeutil.TimeStamp(runAt.Unix())
+	s.LastIssueID = issueID
+	_, err = db.GetEngine(ctx).ID(s.ID).Cols("next_run_unix", "last_run_unix", "last_issue_id").NoAutoTime().Update(s)
+	return err
+}
+
+// GetIssueScheduleByID returns a recurring issue of a repository
+func GetIssueScheduleByID(ctx context.Context, repoID, id int64) (*IssueSchedule, error) {
+	s := new(IssueSchedule)
+	has, err := db.GetEngine(ctx).Where("id=? AND repo_id=?", id, repoID).Get(s)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrIssueScheduleNotExist{ID: id, RepoID: repoID}
+	}
+	return s, nil
+}
+
+// GetIssueSchedules returns the recurring issues of a repository
+func GetIssueSchedules(ctx context.Context, repoID int64) ([]*IssueSchedule, error) {
+	schedules := make([]*IssueSchedule, 0, 5)
+	return schedules, db.GetEngine(ctx).Where("repo_id=?", repoID).Asc("id").Find(&schedules)
+}
+
+// FindDueIssueSchedules returns at most limit active recurring issues whose next run is before a given time,
+// the most overdue first
+func FindDueIssueSchedules(ctx context.Context, before timeutil.TimeStamp, limit int) ([]*IssueSchedule, error) {
+	schedules := make([]*IssueSchedule, 0, 10)
+	return schedules, db.GetEngine(ctx).
+		Where("is_active=? AND next_run_unix<=?", true, before).
+		Asc("next_run_unix").
+		Limit(limit).
+		Find(&schedules)
+}
+
+// DeactivateIssueSchedule stops a recurring issue from running until it is updated again
+func DeactivateIssueSchedule(ctx context.Context, s *IssueSchedule) error {
+	s.IsActive = false
+	_, err := db.GetEngine(ctx).ID(s.ID).Cols("is_active").Update(s)
+	return err
+}
+
+// DeleteIssueSchedule deletes a recurring issue of a repository
+func DeleteIssueSchedule(ctx context.Context, repoID, id int64) error {
+	_, err := db.GetEngine(ctx).Where("id=? AND repo_id=?", id, repoID).Delete(new(IssueSchedule))
+	return err
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues_test
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueSchedule_NextRun(t *testing.T) {
+	s := &issues_model.IssueSchedule{Schedule: "0 9 * * 1"}
+	// 2022-10-12 was a Wednesday
+	after := time.Date(2022, 10, 12, 12, 0, 0, 0, time.Local)
+	next, err := s.NextRun(after)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 10, 17, 9, 0, 0, 0, time.Local).Unix(), int64(next))
+
+	s.Schedule = "not a schedule"
+	_, err = s.NextRun(after)
+	assert.Error(t, err)
+	assert.Error(t, issues_model.ValidateIssueSchedule("0 9 * *"))
+	assert.NoError(t, issues_model.ValidateIssueSchedule("@weekly"))
+}
+
+func TestCreateIssueSchedule(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	s := &issues_model.IssueSchedule{
+		RepoID:    1,
+		CreatorID: 2,
+		Title:     "Weekly sync",
+		Schedule:  "@weekly",
+		IsActive:  true,
+	}
+	assert.NoError(t, issues_model.CreateIssueSchedule(db.DefaultContext, s))
+	assert.Greater(t, int64(s.NextRunUnix), time.Now().Unix())
+
+	s.Schedule = "@daily"
+	s.IsActive = false
+	assert.NoError(t, issues_model.UpdateIssueSchedule(db.DefaultContext, s))
+	s = unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSchedule{ID: s.ID})
+	assert.Equal(t, "@daily", s.Schedule)
+	assert.False(t, s.IsActive)
+
+	assert.Error(t, issues_model.CreateIssueSchedule(db.DefaultContext, &issues_model.IssueSchedule{RepoID: 1, Schedule: "invalid"}))
+}
+
+func TestGetIssueSchedules(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	schedules, err := issues_model.GetIssueSchedules(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, schedules, 2) {
+		assert.Equal(t, "Release checklist", schedules[0].Title)
+		assert.Equal(t, []int64{2}, schedules[0].AssigneeIDs)
+		assert.Equal(t, []int64{1}, schedules[0].LabelIDs)
+	}
+
+	_, err = issues_model.GetIssueScheduleByID(db.DefaultContext, 2, 1)
+	assert.True(t, issues_model.IsErrIssueScheduleNotExist(err))
+
+	assert.NoError(t, issues_model.DeleteIssueSchedule(db.DefaultContext, 1, 2))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueSchedule{ID: 2})
+}
+
+func TestFindDueIssueSchedules(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// the inactive schedule is never due
+	schedules, err := issues_model.FindDueIssueSchedules(db.DefaultContext, timeutil.TimeStampNow(), 10)
+	assert.NoError(t, err)
+	if assert.Len(t, schedules, 1) {
+		assert.EqualValues(t, 1, schedules[0].ID)
+	}
+
+	runAt := time.Now()
+	assert.NoError(t, issues_model.UpdateIssueScheduleRun(db.DefaultContext, schedules[0], 42, runAt))
+	s := unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSchedule{ID: 1})
+	assert.EqualValues(t, 42, s.LastIssueID)
+	assert.EqualValues(t, runAt.Unix(), s.LastRunUnix)
+	assert.Greater(t, int64(s.NextRunUnix), runAt.Unix())
+
+	schedules, err = issues_model.FindDueIssueSchedules(db.DefaultContext, timeutil.TimeStampNow(), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, schedules)
+}
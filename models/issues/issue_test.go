@@ -20,6 +20,7 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
 
 	"github.com/stretchr/testify/assert"
 	"xorm.io/builder"
@@ -188,6 +189,32 @@ func TestIssues(t *testing.T) {
 			},
 			[]int64{}, // issues with **both** label 1 and 2, none of these issues matches, TODO: add more tests
 		},
+		{
+			issues_model.IssuesOptions{
+				RepoID:       1,
+				MinReactions: 1,
+			},
+			[]int64{1},
+		},
+		{
+			issues_model.IssuesOptions{
+				RepoID:   1,
+				SortType: "mostreactions",
+				ListOptions: db.ListOptions{
+					Page:     1,
+					PageSize: 1,
+				},
+			},
+			[]int64{1},
+		},
+		{
+			issues_model.IssuesOptions{
+				RepoID:   1,
+				IsPull:   util.OptionalBoolFalse,
+				SortType: "mostvotes",
+			},
+			[]int64{1, 5},
+		},
 	} {
 		issues, err := issues_model.Issues(&test.Opts)
 		assert.NoError(t, err)
@@ -345,6 +372,25 @@ func TestIssue_SearchIssueIDsByKeyword(t *testing.T) {
 	assert.EqualValues(t, []int64{1}, ids)
 }
 
+func TestIssue_SearchIssueIDsBySimilarTitle(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	ids, err := issues_model.SearchIssueIDsBySimilarTitle(context.TODO(), []string{"issue1", "issue"}, []int64{1}, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, ids, 4) {
+		// issue1 contains both keywords
+		assert.EqualValues(t, 1, ids[0])
+		assert.ElementsMatch(t, []int64{2, 3, 5}, ids[1:])
+	}
+
+	ids, err = issues_model.SearchIssueIDsBySimilarTitle(context.TODO(), []string{"issue1", "issue"}, []int64{1}, 2)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 2)
+
+	ids, err = issues_model.SearchIssueIDsBySimilarTitle(context.TODO(), []string{"nothing"}, []int64{1}, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
 func TestGetRepoIDsForIssuesOptions(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
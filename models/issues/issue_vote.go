@@ -0,0 +1,101 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// IssueVote is the vote of a user for an issue, unlike the reactions a user votes at most once for an issue and the
+// votes are counted by Issue.NumVotes to sort the issues by popularity
+type IssueVote struct {
+	ID          int64              `xorm:"pk autoincr"`
+	IssueID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	UserID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(IssueVote))
+}
+
+// UpdateIssueVoteCount updates the number of votes for an issue
+func UpdateIssueVoteCount(ctx context.Context, issueID int64) error {
+	_, err := db.GetEngine(ctx).Exec("UPDATE `issue` SET num_votes=(SELECT COUNT(*) FROM `issue_vote` WHERE issue_id=?) WHERE id=?",
+		issueID, issueID)
+	return err
+}
+
+// HasVotedForIssue returns whether the user voted for the issue
+func HasVotedForIssue(ctx context.Context, userID, issueID int64) (bool, error) {
+	return db.GetEngine(ctx).Exist(&IssueVote{IssueID: issueID, UserID: userID})
+}
+
+// VoteForIssue adds the vote of the user for the issue, it does nothing if the user already voted for it
+func VoteForIssue(ctx context.Context, userID int64, issue *Issue) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if has, err := HasVotedForIssue(ctx, userID, issue.ID); err != nil || has {
+			return err
+		}
+		if err := db.Insert(ctx, &IssueVote{IssueID: issue.ID, UserID: userID}); err != nil {
+			return err
+		}
+		if err := UpdateIssueVoteCount(ctx, issue.ID); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(issue.ID).NoAutoCondition().Cols("num_votes").Get(issue)
+		return err
+	}, ctx)
+}
+
+// RemoveIssueVote removes the vote of the user for the issue
+func RemoveIssueVote(ctx context.Context, userID int64, issue *Issue) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.DeleteByBean(ctx, &IssueVote{IssueID: issue.ID, UserID: userID}); err != nil {
+			return err
+		}
+		if err := UpdateIssueVoteCount(ctx, issue.ID); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(issue.ID).NoAutoCondition().Cols("num_votes").Get(issue)
+		return err
+	}, ctx)
+}
+
+// GetIssueVoters returns the users who voted for the issue, latest first
+func GetIssueVoters(ctx context.Context, issueID int64, listOptions db.ListOptions) ([]*user_model.User, error) {
+	sess := db.GetEngine(ctx).
+		Join("INNER", "issue_vote", "issue_vote.user_id = `user`.id").
+		Where(builder.Eq{"issue_vote.issue_id": issueID}).
+		Desc("issue_vote.id")
+	if listOptions.Page > 0 {
+		sess = db.SetSessionPagination(sess, &listOptions)
+	}
+	users := make([]*user_model.User, 0, listOptions.PageSize)
+	return users, sess.Find(&users)
+}
+
+// DeleteIssueVotesByUserID deletes the votes of a user and updates the numbers of votes of the issues
+func DeleteIssueVotesByUserID(ctx context.Context, userID int64) error {
+	issueIDs := make([]int64, 0, 10)
+	if err := db.GetEngine(ctx).Table("issue_vote").Where(builder.Eq{"user_id": userID}).Cols("issue_id").Find(&issueIDs); err != nil {
+		return err
+	}
+	if _, err := db.DeleteByBean(ctx, &IssueVote{UserID: userID}); err != nil {
+		return err
+	}
+	for _, issueID := range issueIDs {
+		if err := UpdateIssueVoteCount(ctx, issueID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueVotes(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+	assert.NoError(t, issues_model.VoteForIssue(db.DefaultContext, 1, issue))
+	assert.Equal(t, 1, issue.NumVotes)
+	// a user votes only once
+	assert.NoError(t, issues_model.VoteForIssue(db.DefaultContext, 1, issue))
+	assert.Equal(t, 1, issue.NumVotes)
+	unittest.AssertCount(t, &issues_model.IssueVote{IssueID: 5}, 1)
+
+	voted, err := issues_model.HasVotedForIssue(db.DefaultContext, 1, 5)
+	assert.NoError(t, err)
+	assert.True(t, voted)
+
+	users, err := issues_model.GetIssueVoters(db.DefaultContext, 1, db.ListOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, users, 2) {
+		assert.EqualValues(t, 4, users[0].ID)
+		assert.EqualValues(t, 2, users[1].ID)
+	}
+
+	assert.NoError(t, issues_model.RemoveIssueVote(db.DefaultContext, 1, issue))
+	assert.Equal(t, 0, issue.NumVotes)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueVote{IssueID: 5})
+
+	assert.NoError(t, issues_model.DeleteIssueVotesByUserID(db.DefaultContext, 4))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, NumVotes: 1})
+	unittest.CheckConsistencyFor(t, &issues_model.Issue{})
+}
@@ -84,6 +84,8 @@ type Label struct {
 	NumClosedIssues int
 	CreatedUnix     timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix     timeutil.TimeStamp `xorm:"INDEX updated"`
+	// SyncedFromID is the organization label a repository label is synchronized from, 0 if it isn't synchronized
+	SyncedFromID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
 
 	NumOpenIssues     int    `xorm:"-"`
 	NumOpenRepoIssues int64  `xorm:"-"`
@@ -245,6 +247,14 @@ func UpdateLabel(l *Label) error {
 	return updateLabelCols(db.DefaultContext, l, "name", "description", "color")
 }
 
+// UpdateSyncedLabel updates the information of a label synchronized from a label set, including its source.
+func UpdateSyncedLabel(ctx context.Context, l *Label) error {
+	if !LabelColorPattern.MatchString(l.Color) {
+		return fmt.Errorf("bad color code: %s", l.Color)
+	}
+	return updateLabelCols(ctx, l, "name", "description", "color", "synced_from_id")
+}
+
 // DeleteLabel delete a label
 func DeleteLabel(id, labelID int64) error {
 	label, err := GetLabelByID(db.DefaultContext, labelID)
@@ -670,16 +680,21 @@ func NewIssueLabels(issue *Issue, labels []*Label, doer *user_model.User) (err e
 	}
 	defer committer.Close()
 
-	if err = newIssueLabels(ctx, issue, labels, doer); err != nil {
+	if err = NewIssueLabelsCtx(ctx, issue, labels, doer); err != nil {
 		return err
 	}
 
-	issue.Labels = nil
-	if err = issue.LoadLabels(ctx); err != nil {
+	return committer.Commit()
+}
+
+// NewIssueLabelsCtx creates a list of issue-label relations with the given context.
+func NewIssueLabelsCtx(ctx context.Context, issue *Issue, labels []*Label, doer *user_model.User) error {
+	if err := newIssueLabels(ctx, issue, labels, doer); err != nil {
 		return err
 	}
 
-	return committer.Commit()
+	issue.Labels = nil
+	return issue.LoadLabels(ctx)
 }
 
 func deleteIssueLabel(ctx context.Context, issue *Issue, label *Label, doer *user_model.User) (err error) {
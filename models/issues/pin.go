@@ -0,0 +1,225 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/gitea/models/db"
+
+	"xorm.io/builder"
+)
+
+// MaxPinnedIssues is the maximum number of issues and pull requests a repository or an organization can pin
+const MaxPinnedIssues = 3
+
+var (
+	// ErrTooManyPinnedIssues is returned when more than MaxPinnedIssues issues are pinned
+	ErrTooManyPinnedIssues = errors.New("too many pinned issues")
+	// ErrIssueNotPinned is returned when an issue which isn't pinned is moved
+	ErrIssueNotPinned = errors.New("issue is not pinned")
+)
+
+// PinnedIssue represents an issue or a pull request pinned to the top of the issues of its repository, or to the
+// home page of the organization owning its repository. The announcement of a repository is shown on its home page.
+type PinnedIssue struct {
+	ID             int64 `xorm:"pk autoincr"`
+	RepoID         int64 `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"` // 0 if pinned to an organization
+	OrgID          int64 `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"` // 0 if pinned to a repository
+	IssueID        int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Position       int   `xorm:"NOT NULL DEFAULT 0"`
+	IsAnnouncement bool  `xorm:"NOT NULL DEFAULT false"`
+
+	Issue *Issue `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(PinnedIssue))
+}
+
+// PinnedIssueList is a list of pinned issues
+type PinnedIssueList []*PinnedIssue
+
+// Issues returns the issues of the pins
+func (pins PinnedIssueList) Issues() IssueList {
+	issues := make(IssueList, 0, len(pins))
+	for _, pin := range pins {
+		issues = append(issues, pin.Issue)
+	}
+	return issues
+}
+
+// getPinnedIssues returns the pins of the condition in their order, with their issue and its attributes
+func getPinnedIssues(ctx context.Context, cond builder.Cond) (PinnedIssueList, error) {
+	pins := make(PinnedIssueList, 0, MaxPinnedIssues)
+	if err := db.GetEngine(ctx).Where(cond).Asc("position").Find(&pins); err != nil {
+		return nil, err
+	}
+	if len(pins) == 0 {
+		return pins, nil
+	}
+
+	issueIDs := make([]int64, 0, len(pins))
+	for _, pin := range pins {
+		issueIDs = append(issueIDs, pin.IssueID)
+	}
+	issues := make(map[int64]*Issue, len(pins))
+	if err := db.GetEngine(ctx).In("id", issueIDs).Find(&issues); err != nil {
+		return nil, err
+	}
+	loaded := make(PinnedIssueList, 0, len(pins))
+	for _, pin := range pins {
+		if pin.Issue = issues[pin.IssueID]; pin.Issue != nil {
+			loaded = append(loaded, pin)
+		}
+	}
+	if err := loaded.Issues().loadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	return loaded, nil
+}
+
+// GetPinnedIssues returns the issues and pull requests pinned to the repository in their order
+func GetPinnedIssues(ctx context.Context, repoID int64) (PinnedIssueList, error) {
+	return getPinnedIssues(ctx, builder.Eq{"repo_id": repoID})
+}
+
+// GetOrgPinnedIssues returns the issues and pull requests pinned to the organization in their order, the issues of
+// repositories which have been transferred to another owner since they were pinned are skipped
+func GetOrgPinnedIssues(ctx context.Context, orgID int64) (PinnedIssueList, error) {
+	pins, err := getPinnedIssues(ctx, builder.Eq{"org_id": orgID})
+	if err != nil {
+		return nil, err
+	}
+	owned := make(PinnedIssueList, 0, len(pins))
+	for _, pin := range pins {
+		if pin.Issue.Repo.OwnerID == orgID {
+			owned = append(owned, pin)
+		}
+	}
+	return owned, nil
+}
+
+// GetAnnouncement returns the issue or pull request pinned as announcement of the repository, nil if there is none
+func GetAnnouncement(ctx context.Context, repoID int64) (*Issue, error) {
+	pin := &PinnedIssue{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND is_announcement = ?", repoID, true).Get(pin)
+	if err != nil || !has {
+		return nil, err
+	}
+	return GetIssueByID(ctx, pin.IssueID)
+}
+
+// IsIssuePinned returns whether the issue is pinned to its repository, and whether as announcement
+func IsIssuePinned(ctx context.Context, issue *Issue) (isPinned, isAnnouncement bool, err error) {
+	pin := &PinnedIssue{}
+	isPinned, err = db.GetEngine(ctx).Where("repo_id = ? AND issue_id = ?", issue.RepoID, issue.ID).Get(pin)
+	return isPinned, pin.IsAnnouncement, err
+}
+
+// PinIssue pins the issue after the other issues pinned to its repository, or changes whether it is the announcement
+// of the repository if it is already pinned. The repository has at most one announcement.
+func PinIssue(ctx context.Context, issue *Issue, isAnnouncement bool) error {
+	return db.WithTx(func(ctx context.Context) error {
+		pins := make([]*PinnedIssue, 0, MaxPinnedIssues)
+		if err := db.GetEngine(ctx).Where("repo_id = ?", issue.RepoID).Asc("position").Find(&pins); err != nil {
+			return err
+		}
+
+		var pin *PinnedIssue
+		for _, p := range pins {
+			if p.IssueID == issue.ID {
+				pin = p
+			}
+		}
+		if pin == nil && len(pins) >= MaxPinnedIssues {
+			return ErrTooManyPinnedIssues
+		}
+
+		if isAnnouncement {
+			if _, err := db.GetEngine(ctx).Where("repo_id = ?", issue.RepoID).Cols("is_announcement").
+				Update(&PinnedIssue{IsAnnouncement: false}); err != nil {
+				return err
+			}
+		}
+		if pin == nil {
+			position := 0
+			if len(pins) > 0 {
+				position = pins[len(pins)-1].Position + 1
+			}
+			return db.Insert(ctx, &PinnedIssue{RepoID: issue.RepoID, IssueID: issue.ID, Position: position, IsAnnouncement: isAnnouncement})
+		}
+		_, err := db.GetEngine(ctx).ID(pin.ID).Cols("is_announcement").Update(&PinnedIssue{IsAnnouncement: isAnnouncement})
+		return err
+	}, ctx)
+}
+
+// UnpinIssue unpins the issue from its repository
+func UnpinIssue(ctx context.Context, issue *Issue) error {
+	_, err := db.DeleteByBean(ctx, &PinnedIssue{RepoID: issue.RepoID, IssueID: issue.ID})
+	return err
+}
+
+// MovePinnedIssue moves the issue to the given position among the issues pinned to its repository, starting at 1
+func MovePinnedIssue(ctx context.Context, issue *Issue, position int) error {
+	return db.WithTx(func(ctx context.Context) error {
+		pins := make([]*PinnedIssue, 0, MaxPinnedIssues)
+		if err := db.GetEngine(ctx).Where("repo_id = ?", issue.RepoID).Asc("position").Find(&pins); err != nil {
+			return err
+		}
+
+		index := -1
+		for i, pin := range pins {
+			if pin.IssueID == issue.ID {
+				index = i
+			}
+		}
+		if index < 0 {
+			return ErrIssueNotPinned
+		}
+		if position < 1 {
+			position = 1
+		} else if position > len(pins) {
+			position = len(pins)
+		}
+
+		pin := pins[index]
+		pins = append(pins[:index], pins[index+1:]...)
+		pins = append(pins[:position-1], append([]*PinnedIssue{pin}, pins[position-1:]...)...)
+		for i, pin := range pins {
+			if _, err := db.GetEngine(ctx).ID(pin.ID).Cols("position").Update(&PinnedIssue{Position: i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, ctx)
+}
+
+// SetOrgPinnedIssues replaces the issues and pull requests pinned to the organization, the issues are shown in the
+// given order
+func SetOrgPinnedIssues(ctx context.Context, orgID int64, issueIDs []int64) error {
+	if len(issueIDs) > MaxPinnedIssues {
+		return ErrTooManyPinnedIssues
+	}
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.DeleteByBean(ctx, &PinnedIssue{OrgID: orgID}); err != nil {
+			return err
+		}
+		pins := make([]*PinnedIssue, 0, len(issueIDs))
+		seen := make(map[int64]bool, len(issueIDs))
+		for _, issueID := range issueIDs {
+			if seen[issueID] {
+				continue
+			}
+			seen[issueID] = true
+			pins = append(pins, &PinnedIssue{OrgID: orgID, IssueID: issueID, Position: len(pins)})
+		}
+		if len(pins) == 0 {
+			return nil
+		}
+		return db.Insert(ctx, pins)
+	}, ctx)
+}
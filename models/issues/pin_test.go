@@ -0,0 +1,82 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pinnedIssueIDs(pins issues_model.PinnedIssueList) []int64 {
+	ids := make([]int64, 0, len(pins))
+	for _, pin := range pins {
+		ids = append(ids, pin.IssueID)
+	}
+	return ids
+}
+
+func TestPinIssue(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue2 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+	issue3 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 3})
+	issue5 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+
+	assert.NoError(t, issues_model.PinIssue(db.DefaultContext, issue1, false))
+	assert.NoError(t, issues_model.PinIssue(db.DefaultContext, issue2, true))
+	assert.NoError(t, issues_model.PinIssue(db.DefaultContext, issue3, false))
+	assert.ErrorIs(t, issues_model.PinIssue(db.DefaultContext, issue5, false), issues_model.ErrTooManyPinnedIssues)
+
+	pins, err := issues_model.GetPinnedIssues(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, pinnedIssueIDs(pins))
+	assert.NotNil(t, pins[0].Issue.Repo)
+
+	announcement, err := issues_model.GetAnnouncement(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, announcement.ID)
+
+	// the repository has at most one announcement
+	assert.NoError(t, issues_model.PinIssue(db.DefaultContext, issue3, true))
+	announcement, err = issues_model.GetAnnouncement(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, announcement.ID)
+	isPinned, isAnnouncement, err := issues_model.IsIssuePinned(db.DefaultContext, issue2)
+	assert.NoError(t, err)
+	assert.True(t, isPinned)
+	assert.False(t, isAnnouncement)
+
+	assert.NoError(t, issues_model.MovePinnedIssue(db.DefaultContext, issue3, 1))
+	pins, err = issues_model.GetPinnedIssues(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 1, 2}, pinnedIssueIDs(pins))
+
+	assert.NoError(t, issues_model.UnpinIssue(db.DefaultContext, issue1))
+	assert.ErrorIs(t, issues_model.MovePinnedIssue(db.DefaultContext, issue1, 1), issues_model.ErrIssueNotPinned)
+	pins, err = issues_model.GetPinnedIssues(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 2}, pinnedIssueIDs(pins))
+}
+
+func TestOrgPinnedIssues(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// the issue 1 isn't in a repository of the organization
+	assert.NoError(t, issues_model.SetOrgPinnedIssues(db.DefaultContext, 3, []int64{12, 6, 1}))
+	pins, err := issues_model.GetOrgPinnedIssues(db.DefaultContext, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{12, 6}, pinnedIssueIDs(pins))
+
+	assert.ErrorIs(t, issues_model.SetOrgPinnedIssues(db.DefaultContext, 3, []int64{1, 2, 3, 6}), issues_model.ErrTooManyPinnedIssues)
+
+	assert.NoError(t, issues_model.SetOrgPinnedIssues(db.DefaultContext, 3, nil))
+	unittest.AssertNotExistsBean(t, &issues_model.PinnedIssue{OrgID: 3})
+}
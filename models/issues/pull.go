@@ -161,6 +161,9 @@ type PullRequest struct {
 	MergeBase           string                     `xorm:"VARCHAR(40)"`
 	AllowMaintainerEdit bool                       `xorm:"NOT NULL DEFAULT false"`
 
+	// DisableReviewReminders opts this pull request out of the repository's review reminder policy
+	DisableReviewReminders bool `xorm:"NOT NULL DEFAULT false"`
+
 	HasMerged      bool               `xorm:"INDEX"`
 	MergedCommitID string             `xorm:"VARCHAR(40)"`
 	MergerID       int64              `xorm:"INDEX"`
@@ -765,6 +768,12 @@ func UpdateAllowEdits(ctx context.Context, pr *PullRequest) error {
 	return nil
 }
 
+// UpdateDisableReviewReminders update if the pull request is opted out of review reminders
+func UpdateDisableReviewReminders(ctx context.Context, pr *PullRequest) error {
+	_, err := db.GetEngine(ctx).ID(pr.ID).Cols("disable_review_reminders").Update(pr)
+	return err
+}
+
 // Mergeable returns if the pullrequest is mergeable.
 func (pr *PullRequest) Mergeable() bool {
 	// If a pull request isn't mergable if it's:
@@ -784,7 +793,8 @@ func HasEnoughApprovals(ctx context.Context, protectBranch *git_model.ProtectedB
 	return GetGrantedApprovalsCount(ctx, protectBranch, pr) >= protectBranch.RequiredApprovals
 }
 
-// GetGrantedApprovalsCount returns the number of granted approvals for pr. A granted approval must be authored by a user in an approval whitelist.
+// GetGrantedApprovalsCount returns the number of granted approvals for pr. A granted approval must be authored by a user in an approval whitelist,
+// and not by one of the last committers of the pull request if the protected branch ignores their approvals.
 func GetGrantedApprovalsCount(ctx context.Context, protectBranch *git_model.ProtectedBranch, pr *PullRequest) int64 {
 	sess := db.GetEngine(ctx).Where("issue_id = ?", pr.IssueID).
 		And("type = ?", ReviewTypeApprove).
@@ -793,6 +803,16 @@ func GetGrantedApprovalsCount(ctx context.Context, protectBranch *git_model.Prot
 	if protectBranch.DismissStaleApprovals {
 		sess = sess.And("stale = ?", false)
 	}
+	if protectBranch.IgnoreLastCommittersApprovals > 0 {
+		committerIDs, err := pr.GetLastCommitterIDs(ctx, protectBranch.IgnoreLastCommittersApprovals)
+		if err != nil {
+			log.Error("GetLastCommitterIDs: %v", err)
+			return 0
+		}
+		if len(committerIDs) > 0 {
+			sess = sess.NotIn("reviewer_id", committerIDs)
+		}
+	}
 	approvals, err := sess.Count(new(Review))
 	if err != nil {
 		log.Error("GetGrantedApprovalsCount: %v", err)
@@ -802,6 +822,71 @@ func GetGrantedApprovalsCount(ctx context.Context, protectBranch *git_model.Prot
 	return approvals
 }
 
+// GetLastCommitterIDs returns the IDs of the users who authored or committed the last commits of the pull request,
+// up to n distinct users, the most recent first. The commits of unknown users are skipped.
+func (pr *PullRequest) GetLastCommitterIDs(ctx context.Context, n int64) ([]int64, error) {
+	if err := pr.LoadBaseRepoCtx(ctx); err != nil {
+		return nil, err
+	}
+	gitRepo, closer, err := git.RepositoryFromContextOrOpen(ctx, pr.BaseRepo.RepoPath())
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	headCommitID, err := gitRepo.GetRefCommitID(pr.GetGitRefName())
+	if err != nil {
+		return nil, err
+	}
+	headCommit, err := gitRepo.GetCommit(headCommitID)
+	if err != nil {
+		return nil, err
+	}
+	var baseCommit *git.Commit
+	if pr.MergeBase != "" {
+		if baseCommit, err = gitRepo.GetCommit(pr.MergeBase); err != nil {
+			return nil, err
+		}
+	}
+
+	const pageSize = 50
+	ids := make([]int64, 0, n)
+	seenEmails := make(map[string]bool)
+	for skip := 0; int64(len(ids)) < n; skip += pageSize {
+		commits, err := gitRepo.CommitsBetweenLimit(headCommit, baseCommit, pageSize, skip)
+		if err != nil {
+			return nil, err
+		}
+		for _, commit := range commits {
+			for _, sig := range []*git.Signature{commit.Committer, commit.Author} {
+				if sig == nil || seenEmails[strings.ToLower(sig.Email)] {
+					continue
+				}
+				seenEmails[strings.ToLower(sig.Email)] = true
+
+				u, err := user_model.GetUserByEmailContext(ctx, sig.Email)
+				if err != nil {
+					if user_model.IsErrUserNotExist(err) {
+						continue
+					}
+					return nil, err
+				}
+				if util.IsInt64InSlice(u.ID, ids) {
+					continue
+				}
+				ids = append(ids, u.ID)
+				if int64(len(ids)) >= n {
+					return ids, nil
+				}
+			}
+		}
+		if len(commits) < pageSize {
+			break
+		}
+	}
+	return ids, nil
+}
+
 // MergeBlockedByRejectedReview returns true if merge is blocked by rejected reviews
 func MergeBlockedByRejectedReview(ctx context.Context, protectBranch *git_model.ProtectedBranch, pr *PullRequest) bool {
 	if !protectBranch.BlockOnRejectedReviews {
@@ -15,6 +15,7 @@ import (
 	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
 
 	"xorm.io/xorm"
 )
@@ -116,6 +117,16 @@ func GetUnmergedPullRequestsByBaseInfo(repoID int64, branch string) ([]*PullRequ
 		Find(&prs)
 }
 
+// GetPullRequestsMergedSince returns the pull requests merged into the branch of the repository after the given time,
+// oldest first
+func GetPullRequestsMergedSince(ctx context.Context, repoID int64, branch string, since timeutil.TimeStamp) (PullRequestList, error) {
+	prs := make([]*PullRequest, 0, 10)
+	return prs, db.GetEngine(ctx).
+		Where("base_repo_id=? AND base_branch=? AND has_merged=? AND merged_unix>?", repoID, branch, true, since).
+		Asc("merged_unix", "id").
+		Find(&prs)
+}
+
 // GetPullRequestIDsByCheckStatus returns all pull requests according the special checking status.
 func GetPullRequestIDsByCheckStatus(status PullRequestStatus) ([]int64, error) {
 	prs := make([]int64, 0, 10)
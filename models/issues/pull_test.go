@@ -8,8 +8,10 @@ import (
 	"testing"
 
 	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -275,3 +277,28 @@ func TestDeleteOrphanedObjects(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, countBefore, countAfter)
 }
+
+func TestGetGrantedApprovalsCountIgnoreLastCommitters(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	protectBranch := &git_model.ProtectedBranch{}
+	approvals := issues_model.GetGrantedApprovalsCount(db.DefaultContext, protectBranch, pr)
+
+	// the only commit of the pull request is authored and committed by user 4, who already left an unofficial approval
+	assert.NoError(t, db.Insert(db.DefaultContext, &user_model.EmailAddress{UID: 4, Email: "art27@cantab.net", LowerEmail: "art27@cantab.net", IsActivated: true}))
+	assert.NoError(t, db.Insert(db.DefaultContext, &issues_model.Review{Type: issues_model.ReviewTypeApprove, ReviewerID: 4, IssueID: pr.IssueID, Official: true}))
+	assert.EqualValues(t, approvals+1, issues_model.GetGrantedApprovalsCount(db.DefaultContext, protectBranch, pr))
+
+	committerIDs, err := pr.GetLastCommitterIDs(db.DefaultContext, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{4}, committerIDs)
+
+	protectBranch.IgnoreLastCommittersApprovals = 1
+	assert.EqualValues(t, approvals, issues_model.GetGrantedApprovalsCount(db.DefaultContext, protectBranch, pr))
+
+	protectBranch.IgnoreLastCommittersApprovals = 0
+	dismissed, err := issues_model.DismissApprovalsByReviewer(db.DefaultContext, pr.IssueID, 4)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, dismissed)
+	assert.EqualValues(t, approvals, issues_model.GetGrantedApprovalsCount(db.DefaultContext, protectBranch, pr))
+}
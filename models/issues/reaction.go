@@ -49,6 +49,9 @@ func (err ErrReactionAlreadyExist) Error() string {
 	return fmt.Sprintf("reaction '%s' already exists", err.Reaction)
 }
 
+// ReactionThumbsUp is the type of the thumbs up reactions
+const ReactionThumbsUp = "+1"
+
 // Reaction represents a reactions on issues and comments.
 type Reaction struct {
 	ID               int64              `xorm:"pk autoincr"`
@@ -193,10 +196,23 @@ func createReaction(ctx context.Context, opts *ReactionOptions) (*Reaction, erro
 	if err := db.Insert(ctx, reaction); err != nil {
 		return nil, err
 	}
+	if opts.CommentID == 0 {
+		if err := UpdateIssueReactionCounts(ctx, opts.IssueID); err != nil {
+			return nil, err
+		}
+	}
 
 	return reaction, nil
 }
 
+// UpdateIssueReactionCounts updates the numbers of reactions and of thumbs up to an issue
+func UpdateIssueReactionCounts(ctx context.Context, issueID int64) error {
+	_, err := db.GetEngine(ctx).Exec("UPDATE `issue` SET num_reactions=(SELECT COUNT(*) FROM `reaction` WHERE issue_id=? AND comment_id=0), "+
+		"num_thumbs_up=(SELECT COUNT(*) FROM `reaction` WHERE issue_id=? AND comment_id=0 AND `type`=?) WHERE id=?",
+		issueID, issueID, ReactionThumbsUp, issueID)
+	return err
+}
+
 // ReactionOptions defines options for creating or deleting reactions
 type ReactionOptions struct {
 	Type      string
@@ -256,8 +272,13 @@ func DeleteReaction(ctx context.Context, opts *ReactionOptions) error {
 		CommentID: opts.CommentID,
 	}
 
-	_, err := db.GetEngine(ctx).Where("original_author_id = 0").Delete(reaction)
-	return err
+	if _, err := db.GetEngine(ctx).Where("original_author_id = 0").Delete(reaction); err != nil {
+		return err
+	}
+	if opts.CommentID == 0 {
+		return UpdateIssueReactionCounts(ctx, opts.IssueID)
+	}
+	return nil
 }
 
 // DeleteIssueReaction deletes a reaction on issue.
@@ -39,6 +39,16 @@ func TestIssueAddReaction(t *testing.T) {
 	addReaction(t, user1.ID, issue1ID, 0, "heart")
 
 	unittest.AssertExistsAndLoadBean(t, &issues_model.Reaction{Type: "heart", UserID: user1.ID, IssueID: issue1ID})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue1ID})
+	assert.EqualValues(t, 4, issue.NumReactions)
+	assert.EqualValues(t, 0, issue.NumThumbsUp)
+
+	addReaction(t, user1.ID, issue1ID, 0, issues_model.ReactionThumbsUp)
+	// the reactions to comments are not counted
+	addReaction(t, user1.ID, issue1ID, 2, issues_model.ReactionThumbsUp)
+	issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue1ID})
+	assert.EqualValues(t, 5, issue.NumReactions)
+	assert.EqualValues(t, 1, issue.NumThumbsUp)
 }
 
 func TestIssueAddDuplicateReaction(t *testing.T) {
@@ -75,6 +85,8 @@ func TestIssueDeleteReaction(t *testing.T) {
 	assert.NoError(t, err)
 
 	unittest.AssertNotExistsBean(t, &issues_model.Reaction{Type: "heart", UserID: user1.ID, IssueID: issue1ID})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue1ID})
+	assert.EqualValues(t, 3, issue.NumReactions)
 }
 
 func TestIssueReactionCount(t *testing.T) {
@@ -116,6 +116,10 @@ type Review struct {
 	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
 
+	// RemindedUnix and EscalatedUnix record when a pending review request was last reminded about or escalated
+	RemindedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	EscalatedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+
 	// CodeComments are the initial code comments of the review
 	CodeComments CodeComments `xorm:"-"`
 
@@ -458,7 +462,7 @@ func SubmitReview(doer *user_model.User, issue *Issue, reviewType ReviewType, co
 		}
 
 		for _, teamReviewRequest := range teamReviewRequests {
-			ok, err := organization.IsTeamMember(ctx, issue.Repo.OwnerID, teamReviewRequest.ReviewerTeamID, doer.ID)
+			ok, err := organization.IsTeamSubtreeMember(ctx, issue.Repo.OwnerID, teamReviewRequest.ReviewerTeamID, doer.ID)
 			if err != nil {
 				return nil, nil, err
 			} else if !ok {
@@ -588,6 +592,14 @@ func MarkReviewsAsStale(issueID int64) (err error) {
 	return err
 }
 
+// DismissApprovalsByReviewer dismisses the approvals of a reviewer of a pull request
+func DismissApprovalsByReviewer(ctx context.Context, issueID, reviewerID int64) (int64, error) {
+	return db.GetEngine(ctx).
+		Where("issue_id = ? AND reviewer_id = ? AND type = ? AND dismissed = ?", issueID, reviewerID, ReviewTypeApprove, false).
+		Cols("dismissed").
+		Update(&Review{Dismissed: true})
+}
+
 // MarkReviewsAsNotStale marks existing reviews as not stale for a giving commit SHA
 func MarkReviewsAsNotStale(issueID int64, commitID string) (err error) {
 	_, err = db.GetEngine(db.DefaultContext).Exec("UPDATE `review` SET stale=? WHERE issue_id=? AND commit_id=?", false, issueID, commitID)
@@ -0,0 +1,71 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// latestReviewsCond returns the condition selecting the latest review of every reviewer (user or team) of a pull request
+func latestReviewsCond() builder.Cond {
+	return builder.In("review.id", builder.Select("max(id)").From("review").
+		Where(builder.Neq{"type": ReviewTypePending}).
+		GroupBy("issue_id, reviewer_id, reviewer_team_id"))
+}
+
+// FindPendingReviewRequests returns the review requests on open pull requests which have been
+// waiting for an answer since before the given time and whose pull request has not opted out of reminders
+func FindPendingReviewRequests(ctx context.Context, before timeutil.TimeStamp) ([]*Review, error) {
+	reviews := make([]*Review, 0, 10)
+	return reviews, db.GetEngine(ctx).
+		Join("INNER", "issue", "issue.id = review.issue_id").
+		Join("INNER", "pull_request", "pull_request.issue_id = review.issue_id").
+		Where(builder.Eq{
+			"review.type":                           ReviewTypeRequest,
+			"issue.is_closed":                       false,
+			"pull_request.disable_review_reminders": false,
+		}).
+		And(builder.Lt{"review.created_unix": before}).
+		And(latestReviewsCond()).
+		Asc("review.id").
+		Find(&reviews)
+}
+
+// FindApprovalsBefore returns the not yet dismissed approvals on open pull requests which were given before the given time
+func FindApprovalsBefore(ctx context.Context, before timeutil.TimeStamp) ([]*Review, error) {
+	reviews := make([]*Review, 0, 10)
+	return reviews, db.GetEngine(ctx).
+		Join("INNER", "issue", "issue.id = review.issue_id").
+		Join("INNER", "pull_request", "pull_request.issue_id = review.issue_id").
+		Where(builder.Eq{
+			"review.type":                           ReviewTypeApprove,
+			"review.dismissed":                      false,
+			"review.original_author_id":             0,
+			"issue.is_closed":                       false,
+			"pull_request.disable_review_reminders": false,
+		}).
+		And(builder.Lt{"review.updated_unix": before}).
+		Asc("review.id").
+		Find(&reviews)
+}
+
+// SetReviewReminded records that a reminder has been sent for the review request
+func SetReviewReminded(ctx context.Context, review *Review) error {
+	review.RemindedUnix = timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).ID(review.ID).Cols("reminded_unix").NoAutoTime().Update(review)
+	return err
+}
+
+// SetReviewEscalated records that the review request has been escalated
+func SetReviewEscalated(ctx context.Context, review *Review) error {
+	review.EscalatedUnix = timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).ID(review.ID).Cols("escalated_unix").NoAutoTime().Update(review)
+	return err
+}
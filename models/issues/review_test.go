@@ -11,6 +11,7 @@ import (
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -201,3 +202,43 @@ func TestDismissReview(t *testing.T) {
 	assert.False(t, requestReviewExample.Dismissed)
 	assert.True(t, approveReviewExample.Dismissed)
 }
+
+func TestFindPendingReviewRequests(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	reviews, err := issues_model.FindPendingReviewRequests(db.DefaultContext, timeutil.TimeStampNow())
+	assert.NoError(t, err)
+	if assert.Len(t, reviews, 2) {
+		assert.EqualValues(t, 11, reviews[0].ID)
+		assert.EqualValues(t, 12, reviews[1].ID)
+	}
+
+	reviews, err = issues_model.FindPendingReviewRequests(db.DefaultContext, 1602936509)
+	assert.NoError(t, err)
+	assert.Len(t, reviews, 0)
+
+	review := unittest.AssertExistsAndLoadBean(t, &issues_model.Review{ID: 12})
+	assert.NoError(t, issues_model.SetReviewReminded(db.DefaultContext, review))
+	review = unittest.AssertExistsAndLoadBean(t, &issues_model.Review{ID: 12})
+	assert.NotZero(t, review.RemindedUnix)
+	assert.EqualValues(t, 1603196749, review.UpdatedUnix)
+
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 6})
+	pr.DisableReviewReminders = true
+	assert.NoError(t, issues_model.UpdateDisableReviewReminders(db.DefaultContext, pr))
+	reviews, err = issues_model.FindPendingReviewRequests(db.DefaultContext, timeutil.TimeStampNow())
+	assert.NoError(t, err)
+	assert.Len(t, reviews, 0)
+}
+
+func TestCountPendingReviewRequests(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	counts, err := issues_model.CountPendingReviewRequests(db.DefaultContext, []int64{1, 2})
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[int64]int64{1: 1}, counts)
+
+	counts, err = issues_model.CountPendingReviewRequests(db.DefaultContext, nil)
+	assert.NoError(t, err)
+	assert.Len(t, counts, 0)
+}
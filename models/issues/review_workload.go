@@ -0,0 +1,46 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+
+	"xorm.io/builder"
+)
+
+// CountPendingReviewRequests returns the number of review requests on open pull requests
+// every given user still has to answer, keyed by user ID
+func CountPendingReviewRequests(ctx context.Context, userIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	type reviewerCount struct {
+		ReviewerID int64
+		Count      int64
+	}
+	rows := make([]*reviewerCount, 0, len(userIDs))
+	if err := db.GetEngine(ctx).Table("review").
+		Select("review.reviewer_id, count(*) AS count").
+		Join("INNER", "issue", "issue.id = review.issue_id").
+		Where(builder.Eq{
+			"review.type":     ReviewTypeRequest,
+			"issue.is_closed": false,
+		}).
+		And(builder.In("review.reviewer_id", userIDs)).
+		And(latestReviewsCond()).
+		GroupBy("review.reviewer_id").
+		Find(&rows); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.ReviewerID] = row.Count
+	}
+	return counts, nil
+}
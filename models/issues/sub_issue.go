@@ -0,0 +1,253 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ErrSubIssueHasParent represents a "SubIssueHasParent" kind of error.
+type ErrSubIssueHasParent struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrSubIssueHasParent checks if an error is a ErrSubIssueHasParent.
+func IsErrSubIssueHasParent(err error) bool {
+	_, ok := err.(ErrSubIssueHasParent)
+	return ok
+}
+
+func (err ErrSubIssueHasParent) Error() string {
+	return fmt.Sprintf("issue is already a sub-issue of another issue [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+// ErrSubIssueNotExist represents a "SubIssueNotExist" kind of error.
+type ErrSubIssueNotExist struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrSubIssueNotExist checks if an error is a ErrSubIssueNotExist.
+func IsErrSubIssueNotExist(err error) bool {
+	_, ok := err.(ErrSubIssueNotExist)
+	return ok
+}
+
+func (err ErrSubIssueNotExist) Error() string {
+	return fmt.Sprintf("issue is not a sub-issue [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+// ErrCircularSubIssue represents a "CircularSubIssue" kind of error.
+type ErrCircularSubIssue struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrCircularSubIssue checks if an error is a ErrCircularSubIssue.
+func IsErrCircularSubIssue(err error) bool {
+	_, ok := err.(ErrCircularSubIssue)
+	return ok
+}
+
+func (err ErrCircularSubIssue) Error() string {
+	return fmt.Sprintf("issue is an ancestor of its parent [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+// ErrInvalidSubIssue represents a "InvalidSubIssue" kind of error.
+type ErrInvalidSubIssue struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrInvalidSubIssue checks if an error is a ErrInvalidSubIssue.
+func IsErrInvalidSubIssue(err error) bool {
+	_, ok := err.(ErrInvalidSubIssue)
+	return ok
+}
+
+func (err ErrInvalidSubIssue) Error() string {
+	return fmt.Sprintf("sub-issues must be issues of the repository of their parent [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+// SubIssue represents an issue which is a child of another issue of the same repository, an issue has at most one
+// parent so the sub-issues of an issue form a tree
+type SubIssue struct {
+	ID          int64              `xorm:"pk autoincr"`
+	IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+	ParentID    int64              `xorm:"INDEX NOT NULL"`
+	UserID      int64              `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SubIssue))
+}
+
+// getParentID returns the id of the parent of an issue, 0 if it has no parent
+func getParentID(ctx context.Context, issueID int64) (int64, error) {
+	sub := &SubIssue{}
+	has, err := db.GetEngine(ctx).Where("issue_id=?", issueID).Get(sub)
+	if err != nil || !has {
+		return 0, err
+	}
+	return sub.ParentID, nil
+}
+
+// AddSubIssue makes an issue a sub-issue of another issue of the same repository
+func AddSubIssue(ctx context.Context, doer *user_model.User, parent, issue *Issue) error {
+	if parent.RepoID != issue.RepoID || parent.IsPull || issue.IsPull {
+		return ErrInvalidSubIssue{IssueID: issue.ID, ParentID: parent.ID}
+	}
+	if parent.ID == issue.ID {
+		return ErrCircularSubIssue{IssueID: issue.ID, ParentID: parent.ID}
+	}
+
+	return db.WithTx(func(ctx context.Context) error {
+		parentID, err := getParentID(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		if parentID != 0 {
+			return ErrSubIssueHasParent{IssueID: issue.ID, ParentID: parentID}
+		}
+
+		// the issue must not be one of the ancestors of the parent
+		visited := make(map[int64]bool)
+		for ancestorID := parent.ID; ancestorID != 0 && !visited[ancestorID]; {
+			if ancestorID == issue.ID {
+				return ErrCircularSubIssue{IssueID: issue.ID, ParentID: parent.ID}
+			}
+			visited[ancestorID] = true
+			if ancestorID, err = getParentID(ctx, ancestorID); err != nil {
+				return err
+			}
+		}
+
+		return db.Insert(ctx, &SubIssue{IssueID: issue.ID, ParentID: parent.ID, UserID: doer.ID})
+	}, ctx)
+}
+
+// RemoveSubIssue detaches a sub-issue from its parent, its own sub-issues stay attached to it
+func RemoveSubIssue(ctx context.Context, parent, issue *Issue) error {
+	affected, err := db.GetEngine(ctx).Where("issue_id=? AND parent_id=?", issue.ID, parent.ID).Delete(new(SubIssue))
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrSubIssueNotExist{IssueID: issue.ID, ParentID: parent.ID}
+	}
+	return nil
+}
+
+// GetParentIssue returns the parent of an issue, nil if it isn't a sub-issue
+func GetParentIssue(ctx context.Context, issueID int64) (*Issue, error) {
+	parentID, err := getParentID(ctx, issueID)
+	if err != nil || parentID == 0 {
+		return nil, err
+	}
+	return GetIssueByID(ctx, parentID)
+}
+
+// GetSubIssues returns the sub-issues of an issue, in the order of their index
+func GetSubIssues(ctx context.Context, parentID int64) (IssueList, error) {
+	issues := make(IssueList, 0, 10)
+	return issues, db.GetEngine(ctx).
+		Join("INNER", "sub_issue", "sub_issue.issue_id = issue.id").
+		Where("sub_issue.parent_id=?", parentID).
+		Asc("issue.`index`").
+		Find(&issues)
+}
+
+// SubIssueNode is an issue of the tree of the sub-issues of an issue and its own sub-issues
+type SubIssueNode struct {
+	Issue    *Issue
+	Children SubIssueTree
+}
+
+// SubIssueTree is the tree of the sub-issues of an issue
+type SubIssueTree []*SubIssueNode
+
+// SubIssueProgress is the number of the sub-issues of an issue, at all the levels of its tree, and how many of them
+// are closed
+type SubIssueProgress struct {
+	Total  int
+	Closed int
+}
+
+// Percent returns the percentage of closed sub-issues
+func (p SubIssueProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Closed * 100 / p.Total
+}
+
+// Progress returns the progress of the sub-issues of the tree, at all its levels
+func (tree SubIssueTree) Progress() SubIssueProgress {
+	var progress SubIssueProgress
+	for _, node := range tree {
+		progress.Total++
+		if node.Issue.IsClosed {
+			progress.Closed++
+		}
+		children := node.Children.Progress()
+		progress.Total += children.Total
+		progress.Closed += children.Closed
+	}
+	return progress
+}
+
+// GetSubIssueTree returns the tree of the sub-issues of an issue, the sub-issues of each level are in the order of
+// their index
+func GetSubIssueTree(ctx context.Context, issue *Issue) (SubIssueTree, error) {
+	tree := make(SubIssueTree, 0, 10)
+	nodes := map[int64]*SubIssueNode{issue.ID: {Issue: issue}}
+	parentIDs := []int64{issue.ID}
+	for len(parentIDs) > 0 {
+		subs := make([]*SubIssue, 0, len(parentIDs))
+		if err := db.GetEngine(ctx).In("parent_id", parentIDs).Find(&subs); err != nil {
+			return nil, err
+		}
+		if len(subs) == 0 {
+			break
+		}
+		parentOf := make(map[int64]int64, len(subs))
+		issueIDs := make([]int64, 0, len(subs))
+		for _, sub := range subs {
+			parentOf[sub.IssueID] = sub.ParentID
+			issueIDs = append(issueIDs, sub.IssueID)
+		}
+		issues := make(IssueList, 0, len(issueIDs))
+		if err := db.GetEngine(ctx).In("id", issueIDs).Asc("`index`").Find(&issues); err != nil {
+			return nil, err
+		}
+		if _, err := issues.loadRepositories(ctx); err != nil {
+			return nil, err
+		}
+
+		parentIDs = make([]int64, 0, len(issues))
+		for _, child := range issues {
+			if nodes[child.ID] != nil {
+				continue
+			}
+			node := &SubIssueNode{Issue: child}
+			nodes[child.ID] = node
+			if parentOf[child.ID] == issue.ID {
+				tree = append(tree, node)
+			} else {
+				parent := nodes[parentOf[child.ID]]
+				parent.Children = append(parent.Children, node)
+			}
+			parentIDs = append(parentIDs, child.ID)
+		}
+	}
+	return tree, nil
+}
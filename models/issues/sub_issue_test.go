@@ -0,0 +1,83 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issues_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSubIssue(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	pull2 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+	issue5 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+	otherRepoIssue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 4})
+	issue6 := testCreateIssue(t, 1, 2, "sub-issue of #4", "", false)
+	issue7 := testCreateIssue(t, 1, 2, "sub-issue of the sub-issue", "", false)
+
+	// the sub-issues are issues of the same repository and have a single parent
+	assert.True(t, issues_model.IsErrInvalidSubIssue(issues_model.AddSubIssue(db.DefaultContext, doer, issue1, pull2)))
+	assert.True(t, issues_model.IsErrInvalidSubIssue(issues_model.AddSubIssue(db.DefaultContext, doer, issue1, otherRepoIssue)))
+	assert.True(t, issues_model.IsErrSubIssueHasParent(issues_model.AddSubIssue(db.DefaultContext, doer, issue6, issue5)))
+
+	assert.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, issue5, issue6))
+	assert.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, issue6, issue7))
+
+	// an issue cannot be a sub-issue of itself or of one of its descendants
+	assert.True(t, issues_model.IsErrCircularSubIssue(issues_model.AddSubIssue(db.DefaultContext, doer, issue7, issue7)))
+	assert.NoError(t, issues_model.RemoveSubIssue(db.DefaultContext, issue1, issue5))
+	assert.True(t, issues_model.IsErrCircularSubIssue(issues_model.AddSubIssue(db.DefaultContext, doer, issue7, issue5)))
+	assert.True(t, issues_model.IsErrSubIssueNotExist(issues_model.RemoveSubIssue(db.DefaultContext, issue1, issue5)))
+}
+
+func TestGetSubIssueTree(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue5 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+	issue6 := testCreateIssue(t, 1, 2, "second sub-issue", "", false)
+	issue7 := testCreateIssue(t, 1, 2, "sub-issue of the sub-issue", "", false)
+	assert.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, issue1, issue6))
+	assert.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, issue5, issue7))
+
+	parent, err := issues_model.GetParentIssue(db.DefaultContext, issue7.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, issue5.ID, parent.ID)
+	parent, err = issues_model.GetParentIssue(db.DefaultContext, issue1.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, parent)
+
+	subIssues, err := issues_model.GetSubIssues(db.DefaultContext, issue1.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, subIssues, 2) {
+		assert.Equal(t, issue5.ID, subIssues[0].ID)
+		assert.Equal(t, issue6.ID, subIssues[1].ID)
+	}
+
+	tree, err := issues_model.GetSubIssueTree(db.DefaultContext, issue1)
+	assert.NoError(t, err)
+	if assert.Len(t, tree, 2) {
+		assert.Equal(t, issue5.ID, tree[0].Issue.ID)
+		if assert.Len(t, tree[0].Children, 1) {
+			assert.Equal(t, issue7.ID, tree[0].Children[0].Issue.ID)
+		}
+		assert.Equal(t, issue6.ID, tree[1].Issue.ID)
+		assert.Empty(t, tree[1].Children)
+	}
+	// issue 5 is closed
+	progress := tree.Progress()
+	assert.Equal(t, issues_model.SubIssueProgress{Total: 3, Closed: 1}, progress)
+	assert.Equal(t, 33, progress.Percent())
+}
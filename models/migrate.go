@@ -57,6 +57,12 @@ func InsertIssues(issues ...*issues_model.Issue) error {
 
 func insertIssue(ctx context.Context, issue *issues_model.Issue) error {
 	sess := db.GetEngine(ctx)
+	issue.NumReactions = len(issue.Reactions)
+	for _, reaction := range issue.Reactions {
+		if reaction.Type == issues_model.ReactionThumbsUp {
+			issue.NumThumbsUp++
+		}
+	}
 	if _, err := sess.NoAutoTime().Insert(issue); err != nil {
 		return err
 	}
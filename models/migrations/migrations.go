@@ -413,6 +413,98 @@ var migrations = []Migration{
 	NewMigration("Add badges to users", createUserBadgesTable),
 	// v225 -> v226
 	NewMigration("Alter gpg_key/public_key content TEXT fields to MEDIUMTEXT", alterPublicGPGKeyContentFieldsToMediumText),
+	// v226 -> v227
+	NewMigration("Add review reminder columns to review and pull_request tables", addReviewReminderColumns),
+	// v227 -> v228
+	NewMigration("Add review workload columns to user and team tables", addReviewWorkloadColumns),
+	// v228 -> v229
+	NewMigration("Add code scanning tables and block_on_code_scanning_alerts to protected_branch", addCodeScanningTables),
+	// v229 -> v230
+	NewMigration("Add coverage tables and minimum_diff_coverage to protected_branch", addCoverageTables),
+	// v230 -> v231
+	NewMigration("Add deployment tables", addDeploymentTables),
+	// v231 -> v232
+	NewMigration("Add repository advisories and enable_private_reporting to repository", addRepositoryAdvisories),
+	// v232 -> v233
+	NewMigration("Add pinned_repository table and profile section columns to user", addPinnedRepositories),
+	// v233 -> v234
+	NewMigration("Add user_status table", addUserStatusTable),
+	// v234 -> v235
+	NewMigration("Add slug and award time to badges", addBadgeSlugAndAwardTime),
+	// v235 -> v236
+	NewMigration("Add blocked_user and interaction_limit tables", addBlockedUserAndInteractionLimitTables),
+	// v236 -> v237
+	NewMigration("Add scheduled_deletion table", addScheduledDeletionTable),
+	// v237 -> v238
+	NewMigration("Add parent_id column to team", addParentIDToTeam),
+	// v238 -> v239
+	NewMigration("Add role and role_unit tables and role_id columns to team and collaboration", addRoleTables),
+	// v239 -> v240
+	NewMigration("Add is_guest column to org_user", addIsGuestToOrgUser),
+	// v240 -> v241
+	NewMigration("Add org_domain table", addOrgDomainTable),
+	// v241 -> v242
+	NewMigration("Add org_insight table", addOrgInsightTable),
+	// v242 -> v243
+	NewMigration("Add partial clone settings to repository", addRepositoryPartialCloneSettings),
+	// v243 -> v244
+	NewMigration("Add language stats history and language trend tables", addLanguageStatsHistoryTables),
+	// v244 -> v245
+	NewMigration("Add committer approval settings to protected branch", addProtectedBranchCommitterApprovalSettings),
+	// v245 -> v246
+	NewMigration("Add webhook template columns to webhook table", addWebhookTemplateColumns),
+	// v246 -> v247
+	NewMigration("Add synced from column to label table", addLabelSyncedFromIDColumn),
+	// v247 -> v248
+	NewMigration("Add fork_sync table", addForkSyncTable),
+	// v248 -> v249
+	NewMigration("Add web_sub_subscription table", addWebSubSubscriptionTable),
+	// v249 -> v250
+	NewMigration("Add org_bot table and scope column to access_token table", addOrgBotTableAndAccessTokenScope),
+	// v250 -> v251
+	NewMigration("Add oauth2_device_code table", addOAuth2DeviceCodeTable),
+	// v251 -> v252
+	NewMigration("Add commit identity policy columns to repository table", addRepositoryCommitIdentityPolicy),
+	// v252 -> v253
+	NewMigration("Add moderation reports, audit log and shadow limits", addModerationTables),
+	// v253 -> v254
+	NewMigration("Add held content of the spam detection", addModerationHoldTable),
+	// v254 -> v255
+	NewMigration("Add release builds", addReleaseBuildTable),
+	// v255 -> v256
+	NewMigration("Add pinned issues", addPinnedIssueTable),
+	// v256 -> v257
+	NewMigration("Add saved searches", addSavedSearchTable),
+	// v257 -> v258
+	NewMigration("Add download usages of repositories", addDownloadUsageTable),
+	// v258 -> v259
+	NewMigration("Add custom fields of issues", addIssueFieldTables),
+	// v259 -> v260
+	NewMigration("Add replication states of repositories", addRepoReplicationTable),
+	// v260 -> v261
+	NewMigration("Add saved issue filters", addIssueFilterTable),
+	// v261 -> v262
+	NewMigration("Add sub-issues", addSubIssueTable),
+	// v262 -> v263
+	NewMigration("Add repository expertise table", addRepoExpertiseTable),
+	// v263 -> v264
+	NewMigration("Add maintenance report table", addMaintenanceReportTable),
+	// v264 -> v265
+	NewMigration("Add reaction counts of issues", addIssueReactionCounts),
+	// v265 -> v266
+	NewMigration("Add issue schedule table", addIssueScheduleTable),
+	// v266 -> v267
+	NewMigration("Add component tables", addComponentTables),
+	// v267 -> v268
+	NewMigration("Add attestation tables", addAttestationTables),
+	// v268 -> v269
+	NewMigration("Add explore ranking tables", addExploreRankingTables),
+	// v269 -> v270
+	NewMigration("Add issue votes", addIssueVotes),
+	// v270 -> v271
+	NewMigration("Add confidential issues", addIssueConfidential),
+	// v271 -> v272
+	NewMigration("Add issue mirrors", addIssueMirrors),
 }
 
 // GetCurrentDBVersion returns the current db version
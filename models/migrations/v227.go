@@ -0,0 +1,28 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addReviewWorkloadColumns(x *xorm.Engine) error {
+	type User struct {
+		ReviewCapacity   int                `xorm:"NOT NULL DEFAULT 0"`
+		OutOfOffice      bool               `xorm:"NOT NULL DEFAULT false"`
+		OutOfOfficeUntil timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type Team struct {
+		AutoAssignReviewer bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	if err := x.Sync2(new(User)); err != nil {
+		return err
+	}
+	return x.Sync2(new(Team))
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addCodeScanningTables(x *xorm.Engine) error {
+	type CodeScanningAnalysis struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"INDEX(s) NOT NULL"`
+		CommitSHA   string             `xorm:"VARCHAR(40) INDEX(s) NOT NULL"`
+		Ref         string             `xorm:"NOT NULL DEFAULT ''"`
+		ToolName    string             `xorm:"NOT NULL DEFAULT ''"`
+		ToolVersion string             `xorm:"NOT NULL DEFAULT ''"`
+		Category    string             `xorm:"NOT NULL DEFAULT ''"`
+		NumResults  int                `xorm:"NOT NULL DEFAULT 0"`
+		UploaderID  int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	type CodeScanningAlert struct {
+		ID               int64  `xorm:"pk autoincr"`
+		RepoID           int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Fingerprint      string `xorm:"VARCHAR(64) UNIQUE(s) NOT NULL"`
+		ToolName         string `xorm:"NOT NULL DEFAULT ''"`
+		Category         string `xorm:"NOT NULL DEFAULT ''"`
+		RuleID           string `xorm:"NOT NULL DEFAULT ''"`
+		RuleDescription  string `xorm:"TEXT"`
+		Severity         string `xorm:"NOT NULL DEFAULT ''"`
+		Message          string `xorm:"TEXT"`
+		Path             string `xorm:"TEXT"`
+		StartLine        int
+		EndLine          int
+		State            int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+		FirstSeenSHA     string             `xorm:"VARCHAR(40)"`
+		LastSeenSHA      string             `xorm:"VARCHAR(40)"`
+		DismissedByID    int64              `xorm:"NOT NULL DEFAULT 0"`
+		DismissedReason  string             `xorm:"NOT NULL DEFAULT ''"`
+		DismissedComment string             `xorm:"TEXT"`
+		DismissedUnix    timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		FixedUnix        timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix      timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix      timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type CodeScanningAlertInstance struct {
+		ID         int64  `xorm:"pk autoincr"`
+		AlertID    int64  `xorm:"INDEX NOT NULL"`
+		AnalysisID int64  `xorm:"INDEX NOT NULL"`
+		RepoID     int64  `xorm:"INDEX(s) NOT NULL"`
+		CommitSHA  string `xorm:"VARCHAR(40) INDEX(s) NOT NULL"`
+		Path       string `xorm:"TEXT"`
+		StartLine  int
+		EndLine    int
+		Message    string `xorm:"TEXT"`
+	}
+
+	type ProtectedBranch struct {
+		BlockOnCodeScanningAlerts bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	if err := x.Sync2(new(CodeScanningAnalysis), new(CodeScanningAlert), new(CodeScanningAlertInstance)); err != nil {
+		return err
+	}
+	return x.Sync2(new(ProtectedBranch))
+}
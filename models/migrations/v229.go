@@ -0,0 +1,44 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addCoverageTables(x *xorm.Engine) error {
+	type CoverageReport struct {
+		ID           int64              `xorm:"pk autoincr"`
+		RepoID       int64              `xorm:"INDEX(s) NOT NULL"`
+		CommitSHA    string             `xorm:"VARCHAR(40) INDEX(s) NOT NULL"`
+		Flag         string             `xorm:"NOT NULL DEFAULT ''"`
+		Format       string             `xorm:"NOT NULL DEFAULT ''"`
+		LinesCovered int64              `xorm:"NOT NULL DEFAULT 0"`
+		LinesValid   int64              `xorm:"NOT NULL DEFAULT 0"`
+		UploaderID   int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	}
+
+	type CoverageFile struct {
+		ID             int64  `xorm:"pk autoincr"`
+		ReportID       int64  `xorm:"INDEX NOT NULL"`
+		RepoID         int64  `xorm:"INDEX(s) NOT NULL"`
+		CommitSHA      string `xorm:"VARCHAR(40) INDEX(s) NOT NULL"`
+		Path           string `xorm:"TEXT"`
+		CoveredLines   []int  `xorm:"JSON TEXT"`
+		UncoveredLines []int  `xorm:"JSON TEXT"`
+	}
+
+	type ProtectedBranch struct {
+		MinimumDiffCoverage int64 `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	if err := x.Sync2(new(CoverageReport), new(CoverageFile)); err != nil {
+		return err
+	}
+	return x.Sync2(new(ProtectedBranch))
+}
@@ -0,0 +1,43 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addDeploymentTables(x *xorm.Engine) error {
+	type Deployment struct {
+		ID             int64  `xorm:"pk autoincr"`
+		RepoID         int64  `xorm:"INDEX NOT NULL"`
+		Environment    string `xorm:"INDEX NOT NULL"`
+		Ref            string `xorm:"NOT NULL DEFAULT ''"`
+		SHA            string `xorm:"VARCHAR(64) INDEX NOT NULL"`
+		Description    string `xorm:"TEXT"`
+		State          string `xorm:"VARCHAR(20) NOT NULL"`
+		EnvironmentURL string `xorm:"TEXT"`
+		CreatorID      int64  `xorm:"NOT NULL DEFAULT 0"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type DeploymentStatus struct {
+		ID             int64  `xorm:"pk autoincr"`
+		RepoID         int64  `xorm:"INDEX NOT NULL"`
+		DeploymentID   int64  `xorm:"INDEX NOT NULL"`
+		State          string `xorm:"VARCHAR(20) NOT NULL"`
+		Description    string `xorm:"TEXT"`
+		LogURL         string `xorm:"TEXT"`
+		EnvironmentURL string `xorm:"TEXT"`
+		CreatorID      int64  `xorm:"NOT NULL DEFAULT 0"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync2(new(Deployment), new(DeploymentStatus))
+}
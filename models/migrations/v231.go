@@ -0,0 +1,41 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addRepositoryAdvisories(x *xorm.Engine) error {
+	type RepositoryAdvisory struct {
+		ID                 int64    `xorm:"pk autoincr"`
+		RepoID             int64    `xorm:"INDEX NOT NULL"`
+		Title              string   `xorm:"NOT NULL"`
+		Description        string   `xorm:"TEXT"`
+		Severity           string   `xorm:"VARCHAR(20) NOT NULL"`
+		CVEID              string   `xorm:"VARCHAR(32)"`
+		CWEIDs             []string `xorm:"TEXT JSON"`
+		Ecosystem          string   `xorm:"VARCHAR(32)"`
+		PackageName        string
+		VulnerableVersions string
+		PatchedVersions    string
+		State              string `xorm:"VARCHAR(20) INDEX NOT NULL"`
+		ReporterID         int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+		PublisherID        int64  `xorm:"NOT NULL DEFAULT 0"`
+		ForkID             int64  `xorm:"NOT NULL DEFAULT 0"`
+
+		CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+		PublishedUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	}
+
+	type Repository struct {
+		EnablePrivateReporting bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	return x.Sync2(new(RepositoryAdvisory), new(Repository))
+}
@@ -0,0 +1,29 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func addPinnedRepositories(x *xorm.Engine) error {
+	type PinnedRepository struct {
+		ID       int64 `xorm:"pk autoincr"`
+		OwnerID  int64 `xorm:"UNIQUE(s) NOT NULL"`
+		RepoID   int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Position int   `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type User struct {
+		HideProfileActivity bool `xorm:"NOT NULL DEFAULT false"`
+		HideProfilePackages bool `xorm:"NOT NULL DEFAULT false"`
+		HideProfileProjects bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	if err := x.Sync2(new(PinnedRepository)); err != nil {
+		return err
+	}
+	return x.Sync2(new(User))
+}
@@ -0,0 +1,26 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addUserStatusTable(x *xorm.Engine) error {
+	type UserStatus struct {
+		ID          int64              `xorm:"pk autoincr"`
+		UID         int64              `xorm:"UNIQUE NOT NULL"`
+		Emoji       string             `xorm:"VARCHAR(64)"`
+		Message     string             `xorm:"VARCHAR(255)"`
+		Busy        bool               `xorm:"NOT NULL DEFAULT false"`
+		ExpiresUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync2(new(UserStatus))
+}
@@ -0,0 +1,30 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addBadgeSlugAndAwardTime(x *xorm.Engine) error {
+	type Badge struct {
+		ID          int64  `xorm:"pk autoincr"`
+		Slug        string `xorm:"VARCHAR(64) INDEX"`
+		Description string
+		ImageURL    string
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	type UserBadge struct {
+		ID          int64              `xorm:"pk autoincr"`
+		BadgeID     int64              `xorm:"INDEX"`
+		UserID      int64              `xorm:"INDEX"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync2(new(Badge), new(UserBadge))
+}
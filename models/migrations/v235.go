@@ -0,0 +1,31 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addBlockedUserAndInteractionLimitTables(x *xorm.Engine) error {
+	type BlockedUser struct {
+		ID          int64              `xorm:"pk autoincr"`
+		BlockerID   int64              `xorm:"UNIQUE(block) NOT NULL"`
+		BlockeeID   int64              `xorm:"UNIQUE(block) INDEX NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	type InteractionLimit struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OwnerID     int64              `xorm:"UNIQUE(limit) NOT NULL DEFAULT 0"`
+		RepoID      int64              `xorm:"UNIQUE(limit) NOT NULL DEFAULT 0"`
+		Type        string             `xorm:"VARCHAR(32) NOT NULL"`
+		ExpiresUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync2(new(BlockedUser), new(InteractionLimit))
+}
@@ -0,0 +1,26 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addOrgDomainTable(x *xorm.Engine) error {
+	type OrgDomain struct {
+		ID           int64  `xorm:"pk autoincr"`
+		OrgID        int64  `xorm:"UNIQUE(s) INDEX"`
+		Domain       string `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Token        string `xorm:"NOT NULL"`
+		TeamID       int64  `xorm:"NOT NULL DEFAULT 0"`
+		IsVerified   bool   `xorm:"INDEX NOT NULL DEFAULT false"`
+		VerifiedUnix timeutil.TimeStamp
+		CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync2(new(OrgDomain))
+}
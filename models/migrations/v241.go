@@ -0,0 +1,41 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addOrgInsightTable(x *xorm.Engine) error {
+	type OrgInsight struct {
+		ID    int64              `xorm:"pk autoincr"`
+		OrgID int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Day   timeutil.TimeStamp `xorm:"UNIQUE(s) INDEX NOT NULL"`
+
+		PullsOpened  int64 `xorm:"NOT NULL DEFAULT 0"`
+		PullsMerged  int64 `xorm:"NOT NULL DEFAULT 0"`
+		MergeTimeSum int64 `xorm:"NOT NULL DEFAULT 0"`
+
+		PullsReviewed    int64 `xorm:"NOT NULL DEFAULT 0"`
+		ReviewLatencySum int64 `xorm:"NOT NULL DEFAULT 0"`
+
+		IssuesOpened      int64 `xorm:"NOT NULL DEFAULT 0"`
+		IssuesClosed      int64 `xorm:"NOT NULL DEFAULT 0"`
+		IssuesOpenWeek    int64 `xorm:"NOT NULL DEFAULT 0"`
+		IssuesOpenMonth   int64 `xorm:"NOT NULL DEFAULT 0"`
+		IssuesOpenQuarter int64 `xorm:"NOT NULL DEFAULT 0"`
+		IssuesOpenYear    int64 `xorm:"NOT NULL DEFAULT 0"`
+		IssuesOpenOlder   int64 `xorm:"NOT NULL DEFAULT 0"`
+
+		StatusesSucceeded int64 `xorm:"NOT NULL DEFAULT 0"`
+		StatusesFailed    int64 `xorm:"NOT NULL DEFAULT 0"`
+
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync2(new(OrgInsight))
+}
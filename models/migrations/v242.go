@@ -0,0 +1,18 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func addRepositoryPartialCloneSettings(x *xorm.Engine) error {
+	type Repository struct {
+		PartialCloneFilters      string `xorm:"VARCHAR(255)"`
+		PartialCloneTreeMaxDepth int    `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync2(new(Repository))
+}
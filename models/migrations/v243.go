@@ -0,0 +1,32 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addLanguageStatsHistoryTables(x *xorm.Engine) error {
+	type LanguageStatsHistory struct {
+		ID       int64              `xorm:"pk autoincr"`
+		RepoID   int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Day      timeutil.TimeStamp `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Language string             `xorm:"VARCHAR(50) UNIQUE(s) NOT NULL"`
+		CommitID string
+		Size     int64 `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type LanguageTrend struct {
+		ID       int64              `xorm:"pk autoincr"`
+		Day      timeutil.TimeStamp `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Language string             `xorm:"VARCHAR(50) UNIQUE(s) NOT NULL"`
+		Size     int64              `xorm:"NOT NULL DEFAULT 0"`
+		NumRepos int64              `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync2(new(LanguageStatsHistory), new(LanguageTrend))
+}
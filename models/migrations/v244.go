@@ -0,0 +1,18 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func addProtectedBranchCommitterApprovalSettings(x *xorm.Engine) error {
+	type ProtectedBranch struct {
+		IgnoreLastCommittersApprovals int64 `xorm:"NOT NULL DEFAULT 0"`
+		DismissPusherApprovals        bool  `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	return x.Sync2(new(ProtectedBranch))
+}
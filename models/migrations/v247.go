@@ -0,0 +1,24 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addForkSyncTable(x *xorm.Engine) error {
+	type ForkSync struct {
+		ID           int64              `xorm:"pk autoincr"`
+		RepoID       int64              `xorm:"UNIQUE NOT NULL"`
+		DoerID       int64              `xorm:"NOT NULL"`
+		LastSyncUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		LastError    string             `xorm:"TEXT"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync2(new(ForkSync))
+}
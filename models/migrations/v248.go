@@ -0,0 +1,25 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addWebSubSubscriptionTable(x *xorm.Engine) error {
+	type WebSubSubscription struct {
+		ID          int64              `xorm:"pk autoincr"`
+		Topic       string             `xorm:"UNIQUE(s) VARCHAR(512) NOT NULL"`
+		Callback    string             `xorm:"UNIQUE(s) VARCHAR(512) NOT NULL"`
+		Secret      string             `xorm:"TEXT"`
+		ExpiresUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync2(new(WebSubSubscription))
+}
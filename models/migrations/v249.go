@@ -0,0 +1,27 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addOrgBotTableAndAccessTokenScope(x *xorm.Engine) error {
+	type OrgBot struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OrgID       int64              `xorm:"INDEX NOT NULL"`
+		BotID       int64              `xorm:"UNIQUE NOT NULL"`
+		CreatorID   int64              `xorm:"NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	type AccessToken struct {
+		Scope string `xorm:"VARCHAR(20) NOT NULL DEFAULT ''"`
+	}
+
+	return x.Sync2(new(OrgBot), new(AccessToken))
+}
@@ -0,0 +1,18 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func addRepositoryCommitIdentityPolicy(x *xorm.Engine) error {
+	type Repository struct {
+		EnforceCommitIdentity bool   `xorm:"NOT NULL DEFAULT false"`
+		CommitEmailDomains    string `xorm:"TEXT"`
+	}
+
+	return x.Sync2(new(Repository))
+}
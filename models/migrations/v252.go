@@ -0,0 +1,56 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addModerationTables(x *xorm.Engine) error {
+	type ModerationReport struct {
+		ID          int64              `xorm:"pk autoincr"`
+		ReporterID  int64              `xorm:"INDEX NOT NULL"`
+		OwnerID     int64              `xorm:"INDEX NOT NULL"`
+		RepoID      int64              `xorm:"INDEX NOT NULL"`
+		IssueID     int64              `xorm:"INDEX NOT NULL"`
+		CommentID   int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		PosterID    int64              `xorm:"INDEX NOT NULL"`
+		Category    string             `xorm:"VARCHAR(20) NOT NULL"`
+		Remark      string             `xorm:"TEXT"`
+		Status      string             `xorm:"VARCHAR(20) INDEX NOT NULL"`
+		ResolverID  int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ModerationLog struct {
+		ID           int64              `xorm:"pk autoincr"`
+		ModeratorID  int64              `xorm:"INDEX NOT NULL"`
+		OwnerID      int64              `xorm:"INDEX NOT NULL"`
+		RepoID       int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Action       string             `xorm:"VARCHAR(32) NOT NULL"`
+		TargetUserID int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		IssueID      int64              `xorm:"NOT NULL DEFAULT 0"`
+		CommentID    int64              `xorm:"NOT NULL DEFAULT 0"`
+		ReportID     int64              `xorm:"NOT NULL DEFAULT 0"`
+		Reason       string             `xorm:"TEXT"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"INDEX created"`
+	}
+
+	type ModerationShadowLimit struct {
+		ID          int64              `xorm:"pk autoincr"`
+		OwnerID     int64              `xorm:"UNIQUE(limit) NOT NULL"`
+		UserID      int64              `xorm:"UNIQUE(limit) INDEX NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	type Comment struct {
+		IsHidden bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	return x.Sync2(new(ModerationReport), new(ModerationLog), new(ModerationShadowLimit), new(Comment))
+}
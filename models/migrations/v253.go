@@ -0,0 +1,31 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addModerationHoldTable(x *xorm.Engine) error {
+	type ModerationHold struct {
+		ID          int64              `xorm:"pk autoincr"`
+		Type        string             `xorm:"VARCHAR(16) NOT NULL"`
+		PosterID    int64              `xorm:"INDEX NOT NULL"`
+		OwnerID     int64              `xorm:"INDEX NOT NULL"`
+		RepoID      int64              `xorm:"INDEX NOT NULL"`
+		IssueID     int64              `xorm:"NOT NULL DEFAULT 0"`
+		CommentID   int64              `xorm:"NOT NULL DEFAULT 0"`
+		Score       int                `xorm:"NOT NULL DEFAULT 0"`
+		Reasons     string             `xorm:"TEXT"`
+		Status      string             `xorm:"VARCHAR(20) INDEX NOT NULL"`
+		ReviewerID  int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync2(new(ModerationHold))
+}
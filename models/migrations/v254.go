@@ -0,0 +1,30 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addReleaseBuildTable(x *xorm.Engine) error {
+	type ReleaseBuild struct {
+		ID           int64  `xorm:"pk autoincr"`
+		RepoID       int64  `xorm:"UNIQUE(s) NOT NULL"`
+		ReleaseID    int64  `xorm:"INDEX NOT NULL"`
+		TagName      string `xorm:"NOT NULL"`
+		LowerTagName string `xorm:"UNIQUE(s) NOT NULL"`
+		BuilderID    int64  `xorm:"INDEX NOT NULL"`
+		Status       string `xorm:"VARCHAR(20) NOT NULL"`
+		Description  string
+		Log          string `xorm:"LONGTEXT"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync2(new(ReleaseBuild))
+}
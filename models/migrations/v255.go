@@ -0,0 +1,22 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func addPinnedIssueTable(x *xorm.Engine) error {
+	type PinnedIssue struct {
+		ID             int64 `xorm:"pk autoincr"`
+		RepoID         int64 `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+		OrgID          int64 `xorm:"UNIQUE(s) NOT NULL DEFAULT 0"`
+		IssueID        int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Position       int   `xorm:"NOT NULL DEFAULT 0"`
+		IsAnnouncement bool  `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	return x.Sync2(new(PinnedIssue))
+}
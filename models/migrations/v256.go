@@ -0,0 +1,26 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addSavedSearchTable(x *xorm.Engine) error {
+	type SavedSearch struct {
+		ID                int64  `xorm:"pk autoincr"`
+		UserID            int64  `xorm:"INDEX NOT NULL"`
+		Name              string `xorm:"NOT NULL"`
+		Type              string `xorm:"VARCHAR(10) NOT NULL"`
+		Keyword           string `xorm:"NOT NULL"`
+		RepoID            int64  `xorm:"NOT NULL DEFAULT 0"`
+		LastGeneratedUnix timeutil.TimeStamp
+		CreatedUnix       timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync2(new(SavedSearch))
+}
@@ -0,0 +1,25 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addRepoReplicationTable(x *xorm.Engine) error {
+	type RepoReplication struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"UNIQUE NOT NULL"`
+		PendingUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		ChangedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		SyncedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		LastError   string             `xorm:"TEXT"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync2(new(RepoReplication))
+}
@@ -0,0 +1,32 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addIssueFilterTable(x *xorm.Engine) error {
+	type IssueFilter struct {
+		ID          int64  `xorm:"pk autoincr"`
+		UserID      int64  `xorm:"INDEX(s) NOT NULL"`
+		RepoID      int64  `xorm:"INDEX(s) NOT NULL"`
+		IsPull      bool   `xorm:"NOT NULL DEFAULT false"`
+		Name        string `xorm:"NOT NULL"`
+		Labels      string `xorm:"TEXT"`
+		MilestoneID int64  `xorm:"NOT NULL DEFAULT 0"`
+		AssigneeID  int64  `xorm:"NOT NULL DEFAULT 0"`
+		PosterID    int64  `xorm:"NOT NULL DEFAULT 0"`
+		IsClosed    bool   `xorm:"NOT NULL DEFAULT false"`
+		SortType    string `xorm:"VARCHAR(20)"`
+		Keyword     string
+		IsPinned    bool               `xorm:"NOT NULL DEFAULT false"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync2(new(IssueFilter))
+}
@@ -0,0 +1,27 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addRepoExpertiseTable(x *xorm.Engine) error {
+	type RepoExpertise struct {
+		ID             int64              `xorm:"pk autoincr"`
+		RepoID         int64              `xorm:"INDEX(s) NOT NULL"`
+		TreePath       string             `xorm:"VARCHAR(500) INDEX(s) NOT NULL"`
+		ParentPath     string             `xorm:"VARCHAR(500) NOT NULL"`
+		IsDir          bool               `xorm:"NOT NULL DEFAULT false"`
+		Email          string             `xorm:"NOT NULL"`
+		Lines          int64              `xorm:"NOT NULL DEFAULT 0"`
+		Commits        int64              `xorm:"NOT NULL DEFAULT 0"`
+		LastCommitUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync2(new(RepoExpertise))
+}
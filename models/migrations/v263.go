@@ -0,0 +1,38 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addMaintenanceReportTable(x *xorm.Engine) error {
+	type MaintenanceReportEntry struct {
+		Path           string             `json:"path"`
+		Email          string             `json:"email,omitempty"`
+		LastCommitUnix timeutil.TimeStamp `json:"last_commit_unix"`
+	}
+
+	type MaintenanceReport struct {
+		ID              int64              `xorm:"pk autoincr"`
+		RepoID          int64              `xorm:"UNIQUE NOT NULL"`
+		CommitID        string             `xorm:"VARCHAR(40)"`
+		StaleBeforeUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+
+		NumSingleAuthorFiles int64 `xorm:"NOT NULL DEFAULT 0"`
+		NumStaleDirectories  int64 `xorm:"NOT NULL DEFAULT 0"`
+		NumStaleManifests    int64 `xorm:"NOT NULL DEFAULT 0"`
+
+		SingleAuthorFiles []*MaintenanceReportEntry `xorm:"LONGTEXT JSON"`
+		StaleDirectories  []*MaintenanceReportEntry `xorm:"LONGTEXT JSON"`
+		StaleManifests    []*MaintenanceReportEntry `xorm:"LONGTEXT JSON"`
+
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync2(new(MaintenanceReport))
+}
@@ -0,0 +1,24 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func addIssueReactionCounts(x *xorm.Engine) error {
+	type Issue struct {
+		NumReactions int `xorm:"INDEX NOT NULL DEFAULT 0"`
+		NumThumbsUp  int `xorm:"INDEX NOT NULL DEFAULT 0"`
+	}
+
+	if err := x.Sync2(new(Issue)); err != nil {
+		return err
+	}
+
+	_, err := x.Exec("UPDATE `issue` SET num_reactions=(SELECT COUNT(*) FROM `reaction` WHERE issue_id=`issue`.id AND comment_id=0), "+
+		"num_thumbs_up=(SELECT COUNT(*) FROM `reaction` WHERE issue_id=`issue`.id AND comment_id=0 AND `type`=?)", "+1")
+	return err
+}
@@ -0,0 +1,35 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addIssueScheduleTable(x *xorm.Engine) error {
+	type IssueSchedule struct {
+		ID           int64 `xorm:"pk autoincr"`
+		RepoID       int64 `xorm:"INDEX NOT NULL"`
+		CreatorID    int64 `xorm:"NOT NULL"`
+		Title        string
+		Content      string `xorm:"LONGTEXT"`
+		TemplateFile string
+		Schedule     string
+		AssigneeIDs  []int64 `xorm:"JSON TEXT"`
+		LabelIDs     []int64 `xorm:"JSON TEXT"`
+		IsActive     bool    `xorm:"INDEX NOT NULL DEFAULT true"`
+
+		NextRunUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		LastRunUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		LastIssueID int64              `xorm:"NOT NULL DEFAULT 0"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync2(new(IssueSchedule))
+}
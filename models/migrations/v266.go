@@ -0,0 +1,37 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addComponentTables(x *xorm.Engine) error {
+	type Component struct {
+		ID           int64    `xorm:"pk autoincr"`
+		RepoID       int64    `xorm:"UNIQUE(s) NOT NULL"`
+		Name         string   `xorm:"NOT NULL"`
+		LowerName    string   `xorm:"UNIQUE(s) NOT NULL"`
+		Description  string   `xorm:"TEXT"`
+		Paths        []string `xorm:"JSON TEXT"`
+		OwnerUserIDs []int64  `xorm:"JSON TEXT"`
+		OwnerTeamIDs []int64  `xorm:"JSON TEXT"`
+		LabelIDs     []int64  `xorm:"JSON TEXT"`
+		NotifyOwners bool     `xorm:"NOT NULL DEFAULT true"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type IssueComponent struct {
+		ID          int64 `xorm:"pk autoincr"`
+		IssueID     int64 `xorm:"UNIQUE(s) NOT NULL"`
+		ComponentID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	}
+
+	return x.Sync2(new(Component), new(IssueComponent))
+}
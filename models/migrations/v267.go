@@ -0,0 +1,36 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addAttestationTables(x *xorm.Engine) error {
+	type Attestation struct {
+		ID            int64              `xorm:"pk autoincr"`
+		OwnerID       int64              `xorm:"INDEX NOT NULL"`
+		RepoID        int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		PackageID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		SubjectType   string             `xorm:"VARCHAR(20) NOT NULL"`
+		Subject       string             `xorm:"INDEX NOT NULL"`
+		PredicateType string             `xorm:"INDEX NOT NULL DEFAULT ''"`
+		Content       string             `xorm:"LONGTEXT"`
+		UploaderID    int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	}
+
+	type AttestationDigest struct {
+		ID            int64  `xorm:"pk autoincr"`
+		AttestationID int64  `xorm:"INDEX NOT NULL"`
+		RepoID        int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+		PackageID     int64  `xorm:"INDEX(s) NOT NULL DEFAULT 0"`
+		Digest        string `xorm:"INDEX(s) NOT NULL"`
+	}
+
+	return x.Sync2(new(Attestation), new(AttestationDigest))
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func addExploreRankingTables(x *xorm.Engine) error {
+	type TrendingRepo struct {
+		RepoID      int64              `xorm:"pk"`
+		Score       float64            `xorm:"INDEX NOT NULL DEFAULT 0"`
+		NumStars    int64              `xorm:"NOT NULL DEFAULT 0"`
+		NumForks    int64              `xorm:"NOT NULL DEFAULT 0"`
+		NumActions  int64              `xorm:"NOT NULL DEFAULT 0"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ExploreCollection struct {
+		ID          int64              `xorm:"pk autoincr"`
+		Name        string             `xorm:"NOT NULL"`
+		Description string             `xorm:"TEXT"`
+		Topic       string             `xorm:"VARCHAR(50)"`
+		IsPinned    bool               `xorm:"NOT NULL DEFAULT false"`
+		Position    int                `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ExploreCollectionRepo struct {
+		ID           int64              `xorm:"pk autoincr"`
+		CollectionID int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		RepoID       int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync2(new(TrendingRepo), new(ExploreCollection), new(ExploreCollectionRepo))
+}
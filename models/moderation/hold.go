@@ -0,0 +1,218 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package moderation
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+var (
+	// ErrHoldNotExist indicates a hold not exist error
+	ErrHoldNotExist = errors.New("Hold does not exist")
+	// ErrHoldClosed is returned when approving or rejecting content which has already been reviewed
+	ErrHoldClosed = errors.New("hold is already closed")
+)
+
+func init() {
+	db.RegisterModel(new(Hold))
+}
+
+// HoldType is the kind of content held for review
+type HoldType string
+
+// Held content types
+const (
+	HoldTypeIssue   HoldType = "issue"
+	HoldTypeComment HoldType = "comment"
+	HoldTypeRepo    HoldType = "repo"
+)
+
+// HoldStatus represents the state of held content
+type HoldStatus string
+
+// Hold statuses
+const (
+	HoldStatusHeld     HoldStatus = "held"
+	HoldStatusApproved HoldStatus = "approved"
+	HoldStatusRejected HoldStatus = "rejected"
+)
+
+// HoldStatuses lists the known hold statuses
+var HoldStatuses = []HoldStatus{HoldStatusHeld, HoldStatusApproved, HoldStatusRejected}
+
+// IsValid returns true if the status is known
+func (s HoldStatus) IsValid() bool {
+	for _, status := range HoldStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Hold represents new content which the spam detection held until a site administrator reviews it
+type Hold struct {
+	ID        int64                  `xorm:"pk autoincr"`
+	Type      HoldType               `xorm:"VARCHAR(16) NOT NULL"`
+	PosterID  int64                  `xorm:"INDEX NOT NULL"`
+	Poster    *user_model.User       `xorm:"-"`
+	OwnerID   int64                  `xorm:"INDEX NOT NULL"`
+	RepoID    int64                  `xorm:"INDEX NOT NULL"`
+	Repo      *repo_model.Repository `xorm:"-"`
+	IssueID   int64                  `xorm:"NOT NULL DEFAULT 0"`
+	Issue     *issues_model.Issue    `xorm:"-"`
+	CommentID int64                  `xorm:"NOT NULL DEFAULT 0"`
+	Comment   *issues_model.Comment  `xorm:"-"`
+	Score     int                    `xorm:"NOT NULL DEFAULT 0"`
+	// Reasons lists the reasons given by the spam checkers, one per line
+	Reasons    string           `xorm:"TEXT"`
+	Status     HoldStatus       `xorm:"VARCHAR(20) INDEX NOT NULL"`
+	ReviewerID int64            `xorm:"NOT NULL DEFAULT 0"`
+	Reviewer   *user_model.User `xorm:"-"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the table name
+func (Hold) TableName() string {
+	return "moderation_hold"
+}
+
+// IsHeld returns true if no site administrator reviewed the content yet
+func (h *Hold) IsHeld() bool {
+	return h.Status == HoldStatusHeld
+}
+
+// ReasonList returns the reasons given by the spam checkers
+func (h *Hold) ReasonList() []string {
+	if h.Reasons == "" {
+		return nil
+	}
+	return strings.Split(h.Reasons, "\n")
+}
+
+// LoadAttributes loads the poster, the reviewer, the repository, the issue and the comment of the hold.
+// The content may have been deleted in the meantime, in which case it is left nil.
+func (h *Hold) LoadAttributes(ctx context.Context) (err error) {
+	if h.Poster == nil {
+		if h.Poster, err = getPossibleUser(ctx, h.PosterID); err != nil {
+			return err
+		}
+	}
+	if h.Reviewer == nil && h.ReviewerID > 0 {
+		if h.Reviewer, err = getPossibleUser(ctx, h.ReviewerID); err != nil {
+			return err
+		}
+	}
+	if h.Repo == nil {
+		h.Repo, err = repo_model.GetRepositoryByIDCtx(ctx, h.RepoID)
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	if h.Issue == nil && h.IssueID > 0 {
+		h.Issue, err = issues_model.GetIssueByID(ctx, h.IssueID)
+		if issues_model.IsErrIssueNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		h.Issue.Repo = h.Repo
+	}
+	if h.Comment == nil && h.CommentID > 0 {
+		h.Comment, err = issues_model.GetCommentByID(ctx, h.CommentID)
+		if issues_model.IsErrCommentNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		h.Comment.Issue = h.Issue
+	}
+	return nil
+}
+
+// CreateHold records content held for review
+func CreateHold(ctx context.Context, h *Hold) error {
+	h.Status = HoldStatusHeld
+	return db.Insert(ctx, h)
+}
+
+// GetHoldByID returns the hold with the given id
+func GetHoldByID(ctx context.Context, id int64) (*Hold, error) {
+	h := &Hold{}
+	has, err := db.GetEngine(ctx).ID(id).Get(h)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrHoldNotExist
+	}
+	return h, nil
+}
+
+// IsContentHeld returns true if the issue (commentID = 0), the comment or the repository (issueID = 0) is held
+func IsContentHeld(ctx context.Context, repoID, issueID, commentID int64) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("repo_id = ? AND issue_id = ? AND comment_id = ? AND status = ?", repoID, issueID, commentID, HoldStatusHeld).
+		Exist(new(Hold))
+}
+
+// CloseHold sets the status of held content once it was reviewed, it returns ErrHoldClosed if it was already reviewed
+func CloseHold(ctx context.Context, h *Hold, reviewerID int64, status HoldStatus) error {
+	n, err := db.GetEngine(ctx).
+		Where("id = ? AND status = ?", h.ID, HoldStatusHeld).
+		Cols("status", "reviewer_id").
+		Update(&Hold{Status: status, ReviewerID: reviewerID})
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return ErrHoldClosed
+	}
+	h.Status = status
+	h.ReviewerID = reviewerID
+	return nil
+}
+
+// FindHoldsOptions represents the options to search held content
+type FindHoldsOptions struct {
+	db.ListOptions
+	PosterID int64
+	Status   HoldStatus
+}
+
+func (opts *FindHoldsOptions) toConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.PosterID > 0 {
+		cond = cond.And(builder.Eq{"poster_id": opts.PosterID})
+	}
+	if opts.Status != "" {
+		cond = cond.And(builder.Eq{"status": opts.Status})
+	}
+	return cond
+}
+
+// FindHolds returns the held content matching the options, oldest first as the queue is handled in order
+func FindHolds(ctx context.Context, opts *FindHoldsOptions) ([]*Hold, int64, error) {
+	sess := db.GetEngine(ctx).Where(opts.toConds()).Asc("id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, opts)
+	}
+	holds := make([]*Hold, 0, setting.UI.IssuePagingNum)
+	count, err := sess.FindAndCount(&holds)
+	return holds, count, err
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package moderation_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHold(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	hold := &moderation_model.Hold{
+		Type:      moderation_model.HoldTypeComment,
+		PosterID:  3,
+		OwnerID:   2,
+		RepoID:    1,
+		IssueID:   1,
+		CommentID: 2,
+		Score:     150,
+		Reasons:   "2 links\nblocked words: casino",
+	}
+	assert.NoError(t, moderation_model.CreateHold(db.DefaultContext, hold))
+	assert.True(t, hold.IsHeld())
+	assert.Equal(t, []string{"2 links", "blocked words: casino"}, hold.ReasonList())
+
+	held, err := moderation_model.IsContentHeld(db.DefaultContext, 1, 1, 2)
+	assert.NoError(t, err)
+	assert.True(t, held)
+	held, err = moderation_model.IsContentHeld(db.DefaultContext, 1, 1, 0)
+	assert.NoError(t, err)
+	assert.False(t, held)
+
+	holds, count, err := moderation_model.FindHolds(db.DefaultContext, &moderation_model.FindHoldsOptions{
+		Status: moderation_model.HoldStatusHeld,
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	if assert.Len(t, holds, 1) {
+		assert.NoError(t, holds[0].LoadAttributes(db.DefaultContext))
+		assert.EqualValues(t, 3, holds[0].Poster.ID)
+		assert.EqualValues(t, 2, holds[0].Comment.ID)
+	}
+
+	assert.NoError(t, moderation_model.CloseHold(db.DefaultContext, hold, 1, moderation_model.HoldStatusApproved))
+	assert.ErrorIs(t, moderation_model.CloseHold(db.DefaultContext, hold, 1, moderation_model.HoldStatusRejected), moderation_model.ErrHoldClosed)
+	hold, err = moderation_model.GetHoldByID(db.DefaultContext, hold.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, moderation_model.HoldStatusApproved, hold.Status)
+	assert.EqualValues(t, 1, hold.ReviewerID)
+
+	_, err = moderation_model.GetHoldByID(db.DefaultContext, 1000)
+	assert.ErrorIs(t, err, moderation_model.ErrHoldNotExist)
+}
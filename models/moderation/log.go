@@ -0,0 +1,146 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package moderation
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(Log))
+}
+
+// ActionType is the kind of action taken by a moderator
+type ActionType string
+
+// Moderation actions
+const (
+	ActionHideComment       ActionType = "hide_comment"
+	ActionUnhideComment     ActionType = "unhide_comment"
+	ActionLockIssue         ActionType = "lock_issue"
+	ActionShadowLimit       ActionType = "shadow_limit"
+	ActionRemoveShadowLimit ActionType = "remove_shadow_limit"
+	ActionDismissReport     ActionType = "dismiss_report"
+	ActionApproveHold       ActionType = "approve_hold"
+	ActionRejectHold        ActionType = "reject_hold"
+)
+
+// ReportActions lists the actions which resolve a report
+var ReportActions = []ActionType{ActionHideComment, ActionLockIssue, ActionShadowLimit, ActionDismissReport}
+
+// IsReportAction returns true if the action can be taken on a report
+func (a ActionType) IsReportAction() bool {
+	for _, action := range ReportActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Log is an entry of the moderation audit trail, every action of a moderator is recorded
+type Log struct {
+	ID          int64                  `xorm:"pk autoincr"`
+	ModeratorID int64                  `xorm:"INDEX NOT NULL"`
+	Moderator   *user_model.User       `xorm:"-"`
+	OwnerID     int64                  `xorm:"INDEX NOT NULL"`
+	RepoID      int64                  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Repo        *repo_model.Repository `xorm:"-"`
+	Action      ActionType             `xorm:"VARCHAR(32) NOT NULL"`
+	// TargetUserID is the author of the moderated content or the shadow limited user
+	TargetUserID int64               `xorm:"INDEX NOT NULL DEFAULT 0"`
+	TargetUser   *user_model.User    `xorm:"-"`
+	IssueID      int64               `xorm:"NOT NULL DEFAULT 0"`
+	Issue        *issues_model.Issue `xorm:"-"`
+	CommentID    int64               `xorm:"NOT NULL DEFAULT 0"`
+	ReportID     int64               `xorm:"NOT NULL DEFAULT 0"`
+	Reason       string              `xorm:"TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+// TableName sets the table name
+func (Log) TableName() string {
+	return "moderation_log"
+}
+
+// LoadAttributes loads the moderator, the target user, the repository and the issue of the log entry
+func (l *Log) LoadAttributes(ctx context.Context) (err error) {
+	if l.Moderator == nil {
+		if l.Moderator, err = getPossibleUser(ctx, l.ModeratorID); err != nil {
+			return err
+		}
+	}
+	if l.TargetUser == nil && l.TargetUserID > 0 {
+		if l.TargetUser, err = getPossibleUser(ctx, l.TargetUserID); err != nil {
+			return err
+		}
+	}
+	if l.Repo == nil && l.RepoID > 0 {
+		l.Repo, err = repo_model.GetRepositoryByIDCtx(ctx, l.RepoID)
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	if l.Issue == nil && l.IssueID > 0 && l.Repo != nil {
+		l.Issue, err = issues_model.GetIssueByID(ctx, l.IssueID)
+		if issues_model.IsErrIssueNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		l.Issue.Repo = l.Repo
+	}
+	return nil
+}
+
+// InsertLog records an action of a moderator
+func InsertLog(ctx context.Context, l *Log) error {
+	return db.Insert(ctx, l)
+}
+
+// FindLogsOptions represents the options to search the audit trail
+type FindLogsOptions struct {
+	db.ListOptions
+	OwnerID      int64
+	RepoID       int64
+	TargetUserID int64
+}
+
+func (opts *FindLogsOptions) toConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.TargetUserID > 0 {
+		cond = cond.And(builder.Eq{"target_user_id": opts.TargetUserID})
+	}
+	return cond
+}
+
+// FindLogs returns the entries of the audit trail matching the options, newest first
+func FindLogs(ctx context.Context, opts *FindLogsOptions) ([]*Log, int64, error) {
+	sess := db.GetEngine(ctx).Where(opts.toConds()).Desc("id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, opts)
+	}
+	logs := make([]*Log, 0, setting.UI.IssuePagingNum)
+	count, err := sess.FindAndCount(&logs)
+	return logs, count, err
+}
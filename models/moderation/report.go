@@ -0,0 +1,237 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package moderation
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+var (
+	// ErrReportNotExist indicates a report not exist error
+	ErrReportNotExist = errors.New("Report does not exist")
+	// ErrReportAlreadyExists is returned when the reporter already has an open report about the same content
+	ErrReportAlreadyExists = errors.New("Report already exists")
+	// ErrInvalidReportCategory is returned when the category of a report is unknown
+	ErrInvalidReportCategory = errors.New("invalid report category")
+)
+
+func init() {
+	db.RegisterModel(new(Report))
+}
+
+// ReportCategory is the reason given by a user to report some content
+type ReportCategory string
+
+// Report categories
+const (
+	ReportCategorySpam     ReportCategory = "spam"
+	ReportCategoryAbuse    ReportCategory = "abuse"
+	ReportCategoryOffTopic ReportCategory = "off_topic"
+	ReportCategoryOther    ReportCategory = "other"
+)
+
+// ReportCategories lists the known report categories
+var ReportCategories = []ReportCategory{ReportCategorySpam, ReportCategoryAbuse, ReportCategoryOffTopic, ReportCategoryOther}
+
+// IsValid returns true if the category is known
+func (c ReportCategory) IsValid() bool {
+	for _, category := range ReportCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// ReportStatus represents the state of a report in the moderation queue
+type ReportStatus string
+
+// Report statuses
+const (
+	ReportStatusOpen      ReportStatus = "open"
+	ReportStatusResolved  ReportStatus = "resolved"
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+// ReportStatuses lists the known report statuses
+var ReportStatuses = []ReportStatus{ReportStatusOpen, ReportStatusResolved, ReportStatusDismissed}
+
+// IsValid returns true if the status is known
+func (s ReportStatus) IsValid() bool {
+	for _, status := range ReportStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Report represents the report of an issue, a pull request or a comment by a user.
+// The reports are queued for the moderators of the owner of the repository and for the site administrators.
+type Report struct {
+	ID         int64                  `xorm:"pk autoincr"`
+	ReporterID int64                  `xorm:"INDEX NOT NULL"`
+	Reporter   *user_model.User       `xorm:"-"`
+	OwnerID    int64                  `xorm:"INDEX NOT NULL"`
+	RepoID     int64                  `xorm:"INDEX NOT NULL"`
+	Repo       *repo_model.Repository `xorm:"-"`
+	IssueID    int64                  `xorm:"INDEX NOT NULL"`
+	Issue      *issues_model.Issue    `xorm:"-"`
+	// CommentID is 0 when the content of the issue itself is reported
+	CommentID int64                 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Comment   *issues_model.Comment `xorm:"-"`
+	// PosterID is the author of the reported content
+	PosterID   int64            `xorm:"INDEX NOT NULL"`
+	Poster     *user_model.User `xorm:"-"`
+	Category   ReportCategory   `xorm:"VARCHAR(20) NOT NULL"`
+	Remark     string           `xorm:"TEXT"`
+	Status     ReportStatus     `xorm:"VARCHAR(20) INDEX NOT NULL"`
+	ResolverID int64            `xorm:"NOT NULL DEFAULT 0"`
+	Resolver   *user_model.User `xorm:"-"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName sets the table name
+func (Report) TableName() string {
+	return "moderation_report"
+}
+
+// IsOpen returns true if no moderator handled the report yet
+func (r *Report) IsOpen() bool {
+	return r.Status == ReportStatusOpen
+}
+
+// LoadAttributes loads the reporter, the repository, the issue, the comment, the poster and the resolver of the report
+func (r *Report) LoadAttributes(ctx context.Context) (err error) {
+	if r.Reporter == nil {
+		if r.Reporter, err = getPossibleUser(ctx, r.ReporterID); err != nil {
+			return err
+		}
+	}
+	if r.Poster == nil {
+		if r.Poster, err = getPossibleUser(ctx, r.PosterID); err != nil {
+			return err
+		}
+	}
+	if r.Resolver == nil && r.ResolverID > 0 {
+		if r.Resolver, err = getPossibleUser(ctx, r.ResolverID); err != nil {
+			return err
+		}
+	}
+	if r.Repo == nil {
+		if r.Repo, err = repo_model.GetRepositoryByIDCtx(ctx, r.RepoID); err != nil {
+			return err
+		}
+	}
+	if r.Issue == nil {
+		if r.Issue, err = issues_model.GetIssueByID(ctx, r.IssueID); err != nil {
+			return err
+		}
+		r.Issue.Repo = r.Repo
+	}
+	if r.Comment == nil && r.CommentID > 0 {
+		r.Comment, err = issues_model.GetCommentByID(ctx, r.CommentID)
+		if issues_model.IsErrCommentNotExist(err) {
+			err = nil
+		}
+	}
+	return err
+}
+
+func getPossibleUser(ctx context.Context, id int64) (*user_model.User, error) {
+	u, err := user_model.GetUserByIDCtx(ctx, id)
+	if user_model.IsErrUserNotExist(err) {
+		return user_model.NewGhostUser(), nil
+	}
+	return u, err
+}
+
+// CreateReport inserts a new open report, a reporter can't report the same content again while a report is open
+func CreateReport(ctx context.Context, r *Report) error {
+	if !r.Category.IsValid() {
+		return ErrInvalidReportCategory
+	}
+	has, err := db.GetEngine(ctx).
+		Where("reporter_id = ? AND issue_id = ? AND comment_id = ? AND status = ?", r.ReporterID, r.IssueID, r.CommentID, ReportStatusOpen).
+		Exist(new(Report))
+	if err != nil {
+		return err
+	} else if has {
+		return ErrReportAlreadyExists
+	}
+	r.Status = ReportStatusOpen
+	return db.Insert(ctx, r)
+}
+
+// GetReportByID returns the report with the given id
+func GetReportByID(ctx context.Context, id int64) (*Report, error) {
+	r := &Report{}
+	has, err := db.GetEngine(ctx).ID(id).Get(r)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrReportNotExist
+	}
+	return r, nil
+}
+
+// CloseReports sets the status of the open reports about a content, the reports about the issue itself are closed
+// when commentID is 0
+func CloseReports(ctx context.Context, issueID, commentID, resolverID int64, status ReportStatus) error {
+	_, err := db.GetEngine(ctx).
+		Where("issue_id = ? AND comment_id = ? AND status = ?", issueID, commentID, ReportStatusOpen).
+		Cols("status", "resolver_id").
+		Update(&Report{Status: status, ResolverID: resolverID})
+	return err
+}
+
+// FindReportsOptions represents the options to search reports
+type FindReportsOptions struct {
+	db.ListOptions
+	OwnerID  int64
+	RepoID   int64
+	PosterID int64
+	Status   ReportStatus
+}
+
+func (opts *FindReportsOptions) toConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.PosterID > 0 {
+		cond = cond.And(builder.Eq{"poster_id": opts.PosterID})
+	}
+	if opts.Status != "" {
+		cond = cond.And(builder.Eq{"status": opts.Status})
+	}
+	return cond
+}
+
+// FindReports returns the reports matching the options, oldest first as the queue is handled in order
+func FindReports(ctx context.Context, opts *FindReportsOptions) ([]*Report, int64, error) {
+	sess := db.GetEngine(ctx).Where(opts.toConds()).Asc("id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, opts)
+	}
+	reports := make([]*Report, 0, setting.UI.IssuePagingNum)
+	count, err := sess.FindAndCount(&reports)
+	return reports, count, err
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package moderation_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateReport(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	newReport := func(reporterID int64) *moderation_model.Report {
+		return &moderation_model.Report{
+			ReporterID: reporterID,
+			OwnerID:    2,
+			RepoID:     1,
+			IssueID:    1,
+			CommentID:  2,
+			PosterID:   3,
+			Category:   moderation_model.ReportCategorySpam,
+		}
+	}
+
+	invalid := newReport(4)
+	invalid.Category = "unknown"
+	assert.ErrorIs(t, moderation_model.CreateReport(db.DefaultContext, invalid), moderation_model.ErrInvalidReportCategory)
+
+	report := newReport(4)
+	assert.NoError(t, moderation_model.CreateReport(db.DefaultContext, report))
+	assert.True(t, report.IsOpen())
+	assert.ErrorIs(t, moderation_model.CreateReport(db.DefaultContext, newReport(4)), moderation_model.ErrReportAlreadyExists)
+	assert.NoError(t, moderation_model.CreateReport(db.DefaultContext, newReport(5)))
+
+	reports, count, err := moderation_model.FindReports(db.DefaultContext, &moderation_model.FindReportsOptions{
+		OwnerID: 2,
+		Status:  moderation_model.ReportStatusOpen,
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	if assert.Len(t, reports, 2) {
+		assert.Equal(t, report.ID, reports[0].ID)
+	}
+
+	// closing the reports about the content closes all of them
+	assert.NoError(t, moderation_model.CloseReports(db.DefaultContext, 1, 2, 1, moderation_model.ReportStatusDismissed))
+	_, count, err = moderation_model.FindReports(db.DefaultContext, &moderation_model.FindReportsOptions{
+		OwnerID: 2,
+		Status:  moderation_model.ReportStatusOpen,
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+
+	report, err = moderation_model.GetReportByID(db.DefaultContext, report.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, moderation_model.ReportStatusDismissed, report.Status)
+	assert.EqualValues(t, 1, report.ResolverID)
+
+	// the content can be reported again once the report is closed
+	assert.NoError(t, moderation_model.CreateReport(db.DefaultContext, newReport(4)))
+
+	_, err = moderation_model.GetReportByID(db.DefaultContext, 1000)
+	assert.ErrorIs(t, err, moderation_model.ErrReportNotExist)
+}
+
+func TestShadowLimit(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	added, err := moderation_model.AddShadowLimit(db.DefaultContext, 3, 5)
+	assert.NoError(t, err)
+	assert.True(t, added)
+	added, err = moderation_model.AddShadowLimit(db.DefaultContext, 3, 5)
+	assert.NoError(t, err)
+	assert.False(t, added)
+
+	limited, err := moderation_model.IsShadowLimited(db.DefaultContext, 3, 5)
+	assert.NoError(t, err)
+	assert.True(t, limited)
+	limited, err = moderation_model.IsShadowLimited(db.DefaultContext, 2, 5)
+	assert.NoError(t, err)
+	assert.False(t, limited)
+
+	ids, err := moderation_model.GetShadowLimitedUserIDs(db.DefaultContext, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{5}, ids)
+
+	users, count, err := moderation_model.GetShadowLimitedUsers(db.DefaultContext, 3, db.ListOptions{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	if assert.Len(t, users, 1) {
+		assert.EqualValues(t, 5, users[0].ID)
+	}
+
+	removed, err := moderation_model.RemoveShadowLimit(db.DefaultContext, 3, 5)
+	assert.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = moderation_model.RemoveShadowLimit(db.DefaultContext, 3, 5)
+	assert.NoError(t, err)
+	assert.False(t, removed)
+}
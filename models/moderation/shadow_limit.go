@@ -0,0 +1,76 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package moderation
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+func init() {
+	db.RegisterModel(new(ShadowLimit))
+}
+
+// ShadowLimit limits a user in the repositories of an owner without telling them: their comments are only shown
+// to themselves and to the moderators.
+type ShadowLimit struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"UNIQUE(limit) NOT NULL"`
+	UserID      int64              `xorm:"UNIQUE(limit) INDEX NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName sets the table name
+func (ShadowLimit) TableName() string {
+	return "moderation_shadow_limit"
+}
+
+// IsShadowLimited returns true if the user is shadow limited in the repositories of the owner
+func IsShadowLimited(ctx context.Context, ownerID, userID int64) (bool, error) {
+	if ownerID == 0 || userID == 0 {
+		return false, nil
+	}
+	return db.GetEngine(ctx).Exist(&ShadowLimit{OwnerID: ownerID, UserID: userID})
+}
+
+// AddShadowLimit shadow limits the user in the repositories of the owner, it returns false if the user was already limited
+func AddShadowLimit(ctx context.Context, ownerID, userID int64) (bool, error) {
+	has, err := IsShadowLimited(ctx, ownerID, userID)
+	if err != nil || has {
+		return false, err
+	}
+	return true, db.Insert(ctx, &ShadowLimit{OwnerID: ownerID, UserID: userID})
+}
+
+// RemoveShadowLimit lifts the shadow limit of the user in the repositories of the owner, it returns false if the user
+// was not limited
+func RemoveShadowLimit(ctx context.Context, ownerID, userID int64) (bool, error) {
+	n, err := db.DeleteByBean(ctx, &ShadowLimit{OwnerID: ownerID, UserID: userID})
+	return n > 0, err
+}
+
+// GetShadowLimitedUserIDs returns the ids of the users shadow limited in the repositories of the owner
+func GetShadowLimitedUserIDs(ctx context.Context, ownerID int64) ([]int64, error) {
+	ids := make([]int64, 0, 10)
+	return ids, db.GetEngine(ctx).Table("moderation_shadow_limit").Where("owner_id = ?", ownerID).Cols("user_id").Find(&ids)
+}
+
+// GetShadowLimitedUsers returns the users shadow limited in the repositories of the owner, most recently limited first
+func GetShadowLimitedUsers(ctx context.Context, ownerID int64, opts db.ListOptions) ([]*user_model.User, int64, error) {
+	sess := db.GetEngine(ctx).
+		Join("INNER", "moderation_shadow_limit", "`moderation_shadow_limit`.user_id=`user`.id").
+		Where("moderation_shadow_limit.owner_id=?", ownerID).
+		Desc("moderation_shadow_limit.id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+
+	users := make([]*user_model.User, 0, 10)
+	count, err := sess.FindAndCount(&users)
+	return users, count, err
+}
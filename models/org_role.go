@@ -0,0 +1,156 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package models
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+)
+
+// UpdateRole updates the name, the description and the units of the role,
+// and the permissions of the teams and collaborators the role is assigned to.
+func UpdateRole(r *organization.Role) error {
+	if err := organization.CheckRoleName(db.DefaultContext, r); err != nil {
+		return err
+	}
+
+	ctx, committer, err := db.TxContext()
+	if err != nil {
+		return err
+	}
+	defer committer.Close()
+	sess := db.GetEngine(ctx)
+
+	r.LowerName = strings.ToLower(r.Name)
+	if _, err := sess.ID(r.ID).Cols("name", "lower_name", "description").Update(r); err != nil {
+		return err
+	}
+
+	if _, err := sess.Where("role_id = ?", r.ID).Delete(new(organization.RoleUnit)); err != nil {
+		return err
+	}
+	for _, u := range r.Units {
+		u.OrgID = r.OrgID
+		u.RoleID = r.ID
+	}
+	if len(r.Units) > 0 {
+		if err := db.Insert(ctx, r.Units); err != nil {
+			return err
+		}
+	}
+
+	if err := syncTeamRoles(ctx, r); err != nil {
+		return err
+	}
+	if err := syncCollaborationRoles(ctx, r); err != nil {
+		return err
+	}
+
+	return committer.Commit()
+}
+
+// applyTeamRole replaces the access mode and the units of the team by the ones of its role
+func applyTeamRole(ctx context.Context, t *organization.Team) error {
+	r, err := organization.GetOrgRoleByID(ctx, t.OrgID, t.RoleID)
+	if err != nil {
+		return err
+	}
+	if err := r.LoadUnits(ctx); err != nil {
+		return err
+	}
+	t.AccessMode = r.AccessMode()
+	t.Units = r.TeamUnits(t.ID)
+	return nil
+}
+
+// syncTeamRoles updates the teams having the role to its permissions
+func syncTeamRoles(ctx context.Context, r *organization.Role) error {
+	teams := make([]*organization.Team, 0, 5)
+	if err := db.GetEngine(ctx).Where("role_id = ?", r.ID).Find(&teams); err != nil {
+		return err
+	}
+
+	teamIDs := make([]int64, 0, len(teams))
+	for _, t := range teams {
+		t.AccessMode = r.AccessMode()
+		if _, err := db.GetEngine(ctx).ID(t.ID).Cols("authorize").Update(t); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("team_id = ?", t.ID).Delete(new(organization.TeamUnit)); err != nil {
+			return err
+		}
+		if units := r.TeamUnits(t.ID); len(units) > 0 {
+			if err := db.Insert(ctx, units); err != nil {
+				return err
+			}
+		}
+		teamIDs = append(teamIDs, t.ID)
+	}
+	return recalculateTeamsAccesses(ctx, teamIDs)
+}
+
+// syncCollaborationRoles updates the collaborations having the role to its permissions
+func syncCollaborationRoles(ctx context.Context, r *organization.Role) error {
+	collaborations := make([]*repo_model.Collaboration, 0, 10)
+	if err := db.GetEngine(ctx).Where("role_id = ?", r.ID).Find(&collaborations); err != nil {
+		return err
+	}
+
+	for _, c := range collaborations {
+		c.Mode = r.AccessMode()
+		if _, err := db.GetEngine(ctx).ID(c.ID).Cols("mode").Update(c); err != nil {
+			return err
+		}
+		repo, err := repo_model.GetRepositoryByIDCtx(ctx, c.RepoID)
+		if err != nil {
+			return err
+		}
+		if err := access_model.RecalculateUserAccess(ctx, repo, c.UserID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetCollaborationRole assigns a role of the organization owning the repository to the collaborator,
+// the collaborator is granted the permissions of the role instead of an access mode.
+func SetCollaborationRole(repo *repo_model.Repository, uid, roleID int64) error {
+	ctx, committer, err := db.TxContext()
+	if err != nil {
+		return err
+	}
+	defer committer.Close()
+
+	r, err := organization.GetOrgRoleByID(ctx, repo.OwnerID, roleID)
+	if err != nil {
+		return err
+	}
+	if err := r.LoadUnits(ctx); err != nil {
+		return err
+	}
+
+	collaboration, err := repo_model.GetCollaboration(ctx, repo.ID, uid)
+	if err != nil {
+		return err
+	} else if collaboration == nil {
+		return nil
+	}
+
+	collaboration.Mode = r.AccessMode()
+	collaboration.RoleID = r.ID
+	if _, err := db.GetEngine(ctx).ID(collaboration.ID).Cols("mode", "role_id").Update(collaboration); err != nil {
+		return err
+	}
+	if err := access_model.RecalculateUserAccess(ctx, repo, uid); err != nil {
+		return err
+	}
+
+	return committer.Commit()
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package models
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRole(t *testing.T, orgID int64, name string, unitsMode map[unit.Type]perm.AccessMode) *organization.Role {
+	role := &organization.Role{
+		OrgID: orgID,
+		Name:  name,
+		Units: organization.NewRoleUnits(orgID, unitsMode),
+	}
+	assert.NoError(t, organization.NewRole(role))
+	return role
+}
+
+func TestTeamRole(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	role := newTestRole(t, 3, "triage", map[unit.Type]perm.AccessMode{
+		unit.TypeCode:   perm.AccessModeRead,
+		unit.TypeIssues: perm.AccessModeWrite,
+	})
+
+	// the permissions of the role replace the ones of the team
+	team := &organization.Team{
+		OrgID:      3,
+		Name:       "triagers",
+		AccessMode: perm.AccessModeAdmin,
+		RoleID:     role.ID,
+	}
+	assert.NoError(t, NewTeam(team))
+	team = unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: team.ID})
+	assert.Equal(t, perm.AccessModeRead, team.AccessMode)
+	unittest.AssertExistsAndLoadBean(t, &organization.TeamUnit{TeamID: team.ID, Type: unit.TypeIssues, AccessMode: perm.AccessModeWrite})
+	unittest.AssertNotExistsBean(t, &organization.TeamUnit{TeamID: team.ID, Type: unit.TypePullRequests})
+
+	// changing the role changes the permissions of the team
+	role.Units = organization.NewRoleUnits(3, map[unit.Type]perm.AccessMode{
+		unit.TypeCode:         perm.AccessModeWrite,
+		unit.TypePullRequests: perm.AccessModeWrite,
+	})
+	assert.NoError(t, UpdateRole(role))
+	team = unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: team.ID})
+	assert.Equal(t, perm.AccessModeWrite, team.AccessMode)
+	unittest.AssertExistsAndLoadBean(t, &organization.TeamUnit{TeamID: team.ID, Type: unit.TypePullRequests, AccessMode: perm.AccessModeWrite})
+	unittest.AssertNotExistsBean(t, &organization.TeamUnit{TeamID: team.ID, Type: unit.TypeIssues})
+
+	// a role of another organization cannot be assigned
+	other := newTestRole(t, 6, "triage", map[unit.Type]perm.AccessMode{unit.TypeCode: perm.AccessModeRead})
+	assert.True(t, organization.IsErrRoleNotExist(NewTeam(&organization.Team{OrgID: 3, Name: "others", RoleID: other.ID})))
+}
+
+func TestSetCollaborationRole(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+	assert.NoError(t, db.Insert(db.DefaultContext, &repo_model.Collaboration{RepoID: repo.ID, UserID: user.ID, Mode: perm.AccessModeWrite}))
+
+	role := newTestRole(t, 3, "triage", map[unit.Type]perm.AccessMode{
+		unit.TypeCode:   perm.AccessModeRead,
+		unit.TypeIssues: perm.AccessModeWrite,
+	})
+	assert.NoError(t, SetCollaborationRole(repo, user.ID, role.ID))
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Collaboration{RepoID: repo.ID, UserID: user.ID, RoleID: role.ID, Mode: perm.AccessModeRead})
+	unittest.AssertExistsAndLoadBean(t, &access_model.Access{RepoID: repo.ID, UserID: user.ID, Mode: perm.AccessModeRead})
+
+	permission, err := access_model.GetUserRepoPermission(db.DefaultContext, repo, user)
+	assert.NoError(t, err)
+	assert.True(t, permission.CanRead(unit.TypeCode))
+	assert.False(t, permission.CanWrite(unit.TypeCode))
+	assert.True(t, permission.CanWrite(unit.TypeIssues))
+	assert.False(t, permission.CanRead(unit.TypePullRequests))
+
+	// changing the role changes the permissions of the collaborator
+	role.Units = organization.NewRoleUnits(3, map[unit.Type]perm.AccessMode{
+		unit.TypeCode:         perm.AccessModeWrite,
+		unit.TypePullRequests: perm.AccessModeWrite,
+	})
+	assert.NoError(t, UpdateRole(role))
+	permission, err = access_model.GetUserRepoPermission(db.DefaultContext, repo, user)
+	assert.NoError(t, err)
+	assert.True(t, permission.CanWrite(unit.TypeCode))
+	assert.True(t, permission.CanWrite(unit.TypePullRequests))
+	assert.False(t, permission.CanRead(unit.TypeIssues))
+	assert.True(t, organization.IsErrRoleInUse(organization.DeleteRole(role)))
+
+	// a role of another organization cannot be assigned
+	other := newTestRole(t, 6, "triage", map[unit.Type]perm.AccessMode{unit.TypeCode: perm.AccessModeRead})
+	assert.True(t, organization.IsErrRoleNotExist(SetCollaborationRole(repo, user.ID, other.ID)))
+
+	// an access mode replaces the role
+	assert.NoError(t, repo_model.ChangeCollaborationAccessMode(repo, user.ID, perm.AccessModeRead))
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Collaboration{RepoID: repo.ID, UserID: user.ID, RoleID: 0, Mode: perm.AccessModeRead})
+	assert.NoError(t, organization.DeleteRole(role))
+}
@@ -263,6 +263,16 @@ func NewTeam(t *organization.Team) (err error) {
 		return organization.ErrTeamAlreadyExist{OrgID: t.OrgID, Name: t.LowerName}
 	}
 
+	if err = organization.CheckTeamParent(db.DefaultContext, t, t.ParentID); err != nil {
+		return err
+	}
+
+	if t.RoleID > 0 {
+		if err = applyTeamRole(db.DefaultContext, t); err != nil {
+			return err
+		}
+	}
+
 	ctx, committer, err := db.TxContext()
 	if err != nil {
 		return err
@@ -327,8 +337,16 @@ func UpdateTeam(t *organization.Team, authChanged, includeAllChanged bool) (err
 		return organization.ErrTeamAlreadyExist{OrgID: t.OrgID, Name: t.LowerName}
 	}
 
-	if _, err = sess.ID(t.ID).Cols("name", "lower_name", "description",
-		"can_create_org_repo", "authorize", "includes_all_repositories").Update(t); err != nil {
+	// the permissions of a team having a role are the ones of the role
+	if t.RoleID > 0 {
+		if err = applyTeamRole(ctx, t); err != nil {
+			return err
+		}
+		authChanged = true
+	}
+
+	if _, err = sess.ID(t.ID).Cols("name", "lower_name", "description", "role_id",
+		"can_create_org_repo", "authorize", "includes_all_repositories", "auto_assign_reviewer").Update(t); err != nil {
 		return fmt.Errorf("update: %v", err)
 	}
 
@@ -372,6 +390,92 @@ func UpdateTeam(t *organization.Team, authChanged, includeAllChanged bool) (err
 	return committer.Commit()
 }
 
+// SetTeamParent nests the team below the parent team, or makes it a top-level team if parentID is 0.
+// The members of the team and its descendants gain the access of the new ancestors and lose the access of the old ones.
+func SetTeamParent(t *organization.Team, parentID int64) error {
+	if t.ParentID == parentID {
+		return nil
+	}
+
+	ctx, committer, err := db.TxContext()
+	if err != nil {
+		return err
+	}
+	defer committer.Close()
+
+	if err := organization.CheckTeamParent(ctx, t, parentID); err != nil {
+		return err
+	}
+
+	oldAncestorIDs, err := organization.GetTeamAncestorIDs(ctx, t.OrgID, t.ID)
+	if err != nil {
+		return err
+	}
+
+	t.ParentID = parentID
+	if _, err := db.GetEngine(ctx).ID(t.ID).Cols("parent_id").Update(t); err != nil {
+		return err
+	}
+
+	newAncestorIDs, err := organization.GetTeamAncestorIDs(ctx, t.OrgID, t.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := recalculateTeamsAccesses(ctx, append(oldAncestorIDs, newAncestorIDs...)); err != nil {
+		return err
+	}
+
+	return committer.Commit()
+}
+
+// recalculateTeamsAccesses recalculates the accesses of all repositories of the teams
+func recalculateTeamsAccesses(ctx context.Context, teamIDs []int64) error {
+	recalculated := make(map[int64]bool)
+	for _, teamID := range teamIDs {
+		team, err := organization.GetTeamByID(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		if err := team.GetRepositoriesCtx(ctx); err != nil {
+			return fmt.Errorf("getRepositories: %v", err)
+		}
+		for _, repo := range team.Repos {
+			if recalculated[repo.ID] {
+				continue
+			}
+			recalculated[repo.ID] = true
+			if err := access_model.RecalculateTeamAccesses(ctx, repo, 0); err != nil {
+				return fmt.Errorf("recalculateTeamAccesses: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// recalculateInheritedUserAccesses recalculates the access of the user to the repositories of the ancestors of the team
+func recalculateInheritedUserAccesses(ctx context.Context, team *organization.Team, userID int64) error {
+	ancestorIDs, err := organization.GetTeamAncestorIDs(ctx, team.OrgID, team.ID)
+	if err != nil {
+		return err
+	}
+	for _, ancestorID := range ancestorIDs {
+		ancestor, err := organization.GetTeamByID(ctx, ancestorID)
+		if err != nil {
+			return err
+		}
+		if err := ancestor.GetRepositoriesCtx(ctx); err != nil {
+			return fmt.Errorf("getRepositories: %v", err)
+		}
+		for _, repo := range ancestor.Repos {
+			if err := access_model.RecalculateUserAccess(ctx, repo, userID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // DeleteTeam deletes given team.
 // It's caller's responsibility to assign organization ID.
 func DeleteTeam(t *organization.Team) error {
@@ -425,6 +529,15 @@ func DeleteTeam(t *organization.Team) error {
 		}
 	}
 
+	// Move the children of the team up to its parent.
+	if _, err := sess.
+		Where("org_id=?", t.OrgID).
+		And("parent_id=?", t.ID).
+		Cols("parent_id").
+		Update(&organization.Team{ParentID: t.ParentID}); err != nil {
+		return err
+	}
+
 	if !t.IncludesAllRepositories {
 		if err := removeAllRepositories(ctx, t); err != nil {
 			return err
@@ -446,6 +559,15 @@ func DeleteTeam(t *organization.Team) error {
 		return err
 	}
 
+	// The users of the domains of the team don't join a team anymore.
+	if _, err := sess.
+		Where("org_id=?", t.OrgID).
+		And("team_id=?", t.ID).
+		Cols("team_id").
+		Update(&organization.OrgDomain{TeamID: 0}); err != nil {
+		return err
+	}
+
 	// Delete team.
 	if _, err := sess.ID(t.ID).Delete(new(organization.Team)); err != nil {
 		return err
@@ -466,6 +588,21 @@ func AddTeamMember(team *organization.Team, userID int64) error {
 		return err
 	}
 
+	if isForeignBot, err := organization.IsForeignOrgBot(db.DefaultContext, team.OrgID, userID); err != nil {
+		return err
+	} else if isForeignBot {
+		return organization.ErrOrgBotForeign{OrgID: team.OrgID, UID: userID}
+	}
+
+	if team.IsOwnerTeam() {
+		isGuest, err := organization.IsOrganizationGuest(db.DefaultContext, team.OrgID, userID)
+		if err != nil {
+			return err
+		} else if isGuest {
+			return organization.ErrOrgGuestOwner{OrgID: team.OrgID, UID: userID}
+		}
+	}
+
 	if err := organization.AddOrgUser(team.OrgID, userID); err != nil {
 		return err
 	}
@@ -527,6 +664,13 @@ func AddTeamMember(team *organization.Team, userID int64) error {
 		}
 	}
 
+	// Give access to the repositories of the parent teams.
+	if team.ParentID > 0 {
+		if err := recalculateInheritedUserAccesses(ctx, team, userID); err != nil {
+			return err
+		}
+	}
+
 	// watch could be failed, so run it in a goroutine
 	if setting.Service.AutoWatchNewRepos {
 		// Get team and its repositories.
@@ -593,6 +737,13 @@ func removeTeamMember(ctx context.Context, team *organization.Team, userID int64
 		}
 	}
 
+	// Delete access to the repositories of the parent teams.
+	if team.ParentID > 0 {
+		if err := recalculateInheritedUserAccesses(ctx, team, userID); err != nil {
+			return err
+		}
+	}
+
 	// Check if the user is a member of any team in the organization.
 	if count, err := e.Count(&organization.TeamUser{
 		UID:   userID,
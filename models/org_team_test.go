@@ -198,3 +198,27 @@ func TestRepository_RecalculateAccesses3(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, has)
 }
+
+func TestSetTeamParent(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// user 4 is a member of team1, which gains access to repo 32 of test_team as its child
+	team := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 2})
+	unittest.AssertNotExistsBean(t, &access_model.Access{UserID: 4, RepoID: 32})
+	assert.NoError(t, SetTeamParent(team, 7))
+	unittest.AssertExistsAndLoadBean(t, &access_model.Access{UserID: 4, RepoID: 32, Mode: perm.AccessModeWrite})
+
+	// the members of test_team don't gain access to the repositories of its child
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 15})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	accessMode, err := access_model.AccessLevel(user, repo)
+	assert.NoError(t, err)
+	assert.True(t, accessMode < perm.AccessModeWrite)
+
+	parent := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 7})
+	assert.True(t, organization.IsErrTeamParentInvalid(SetTeamParent(parent, 2)))
+
+	assert.NoError(t, SetTeamParent(team, 0))
+	unittest.AssertNotExistsBean(t, &access_model.Access{UserID: 4, RepoID: 32})
+	unittest.CheckConsistencyFor(t, &organization.Team{ID: team.ID})
+}
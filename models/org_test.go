@@ -7,7 +7,12 @@ package models
 import (
 	"testing"
 
+	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 
@@ -59,3 +64,44 @@ func TestRemoveOrgUser(t *testing.T) {
 	unittest.AssertExistsAndLoadBean(t, &organization.OrgUser{OrgID: 7, UID: 5})
 	unittest.CheckConsistencyFor(t, &user_model.User{}, &organization.Team{})
 }
+
+func TestOrgGuest(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// public repository of a limited organization
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 38})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+	assert.NoError(t, organization.AddOrgUser(repo.OwnerID, user.ID))
+
+	testAccess := func(expected bool) {
+		permission, err := access_model.GetUserRepoPermission(db.DefaultContext, repo, user)
+		assert.NoError(t, err)
+		assert.EqualValues(t, expected, permission.CanRead(unit.TypeCode))
+
+		_, count, err := repo_model.SearchRepository(&repo_model.SearchRepoOptions{
+			Actor:   user,
+			OwnerID: repo.OwnerID,
+			Private: true,
+		})
+		assert.NoError(t, err)
+		if expected {
+			assert.EqualValues(t, 1, count)
+		} else {
+			assert.EqualValues(t, 0, count)
+		}
+	}
+	testAccess(true)
+
+	// a guest only accesses the repositories it is granted
+	assert.NoError(t, organization.ChangeOrgUserGuest(repo.OwnerID, user.ID, true))
+	testAccess(false)
+
+	assert.NoError(t, db.Insert(db.DefaultContext, &repo_model.Collaboration{RepoID: repo.ID, UserID: user.ID, Mode: perm.AccessModeRead}))
+	assert.NoError(t, access_model.RecalculateUserAccess(db.DefaultContext, repo, user.ID))
+	testAccess(true)
+
+	// a guest can't be an owner
+	ownerTeam := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 1})
+	assert.NoError(t, organization.ChangeOrgUserGuest(ownerTeam.OrgID, 4, true))
+	assert.True(t, organization.IsErrOrgGuestOwner(AddTeamMember(ownerTeam, 4)))
+}
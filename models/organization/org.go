@@ -60,6 +60,22 @@ func (err ErrLastOrgOwner) Error() string {
 	return fmt.Sprintf("user is the last member of owner team [uid: %d]", err.UID)
 }
 
+// ErrOrgGuestOwner represents a "OrgGuestOwner" kind of error.
+type ErrOrgGuestOwner struct {
+	OrgID int64
+	UID   int64
+}
+
+// IsErrOrgGuestOwner checks if an error is a ErrOrgGuestOwner.
+func IsErrOrgGuestOwner(err error) bool {
+	_, ok := err.(ErrOrgGuestOwner)
+	return ok
+}
+
+func (err ErrOrgGuestOwner) Error() string {
+	return fmt.Sprintf("a guest of the organization can't be an owner [org_id: %d, uid: %d]", err.OrgID, err.UID)
+}
+
 // ErrUserNotAllowedCreateOrg represents a "UserNotAllowedCreateOrg" kind of error.
 type ErrUserNotAllowedCreateOrg struct{}
 
@@ -190,6 +206,7 @@ type FindOrgMembersOpts struct {
 	db.ListOptions
 	OrgID      int64
 	PublicOnly bool
+	GuestOnly  bool
 }
 
 // CountOrgMembers counts the organization's members
@@ -198,6 +215,9 @@ func CountOrgMembers(opts *FindOrgMembersOpts) (int64, error) {
 	if opts.PublicOnly {
 		sess.And("is_public = ?", true)
 	}
+	if opts.GuestOnly {
+		sess.And("is_guest = ?", true)
+	}
 	return sess.Count(new(OrgUser))
 }
 
@@ -361,6 +381,11 @@ func DeleteOrganization(ctx context.Context, org *Organization) error {
 		&OrgUser{OrgID: org.ID},
 		&TeamUser{OrgID: org.ID},
 		&TeamUnit{OrgID: org.ID},
+		&Role{OrgID: org.ID},
+		&RoleUnit{OrgID: org.ID},
+		&OrgDomain{OrgID: org.ID},
+		&user_model.BlockedUser{BlockerID: org.ID},
+		&repo_model.InteractionLimit{OwnerID: org.ID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %v", err)
 	}
@@ -486,7 +511,8 @@ func GetOrgsCanCreateRepoByUserID(userID int64) ([]*Organization, error) {
 		Join("INNER", "`team_user`", "`team_user`.org_id = `user`.id").
 		Join("INNER", "`team`", "`team`.id = `team_user`.team_id").
 		Where(builder.Eq{"`team_user`.uid": userID}).
-		And(builder.Eq{"`team`.authorize": perm.AccessModeOwner}.Or(builder.Eq{"`team`.can_create_org_repo": true})))).
+		And(builder.Eq{"`team`.authorize": perm.AccessModeOwner}.Or(builder.Eq{"`team`.can_create_org_repo": true})).
+		And(builder.NotIn("`user`.id", builder.Select("org_id").From("org_user").Where(builder.Eq{"uid": userID, "is_guest": true}))))).
 		Asc("`user`.name").
 		Find(&orgs)
 }
@@ -518,6 +544,9 @@ func GetOrgUsersByOrgID(ctx context.Context, opts *FindOrgMembersOpts) ([]*OrgUs
 	if opts.PublicOnly {
 		sess.And("is_public = ?", true)
 	}
+	if opts.GuestOnly {
+		sess.And("is_guest = ?", true)
+	}
 	if opts.ListOptions.PageSize > 0 {
 		sess = db.SetSessionPagination(sess, opts)
 
@@ -542,7 +571,8 @@ func ChangeOrgUserStatus(orgID, uid int64, public bool) error {
 		return nil
 	}
 
-	ou.IsPublic = public
+	// the membership of a guest is never public
+	ou.IsPublic = public && !ou.IsGuest
 	_, err = db.GetEngine(db.DefaultContext).ID(ou.ID).Cols("is_public").Update(ou)
 	return err
 }
@@ -0,0 +1,125 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ErrOrgBotNotExist represents a "OrgBotNotExist" kind of error.
+type ErrOrgBotNotExist struct {
+	OrgID int64
+	Name  string
+}
+
+// IsErrOrgBotNotExist checks if an error is a ErrOrgBotNotExist.
+func IsErrOrgBotNotExist(err error) bool {
+	_, ok := err.(ErrOrgBotNotExist)
+	return ok
+}
+
+func (err ErrOrgBotNotExist) Error() string {
+	return fmt.Sprintf("bot does not exist [org_id: %d, name: %s]", err.OrgID, err.Name)
+}
+
+// ErrOrgBotForeign represents a "OrgBotForeign" kind of error.
+type ErrOrgBotForeign struct {
+	OrgID int64
+	UID   int64
+}
+
+// IsErrOrgBotForeign checks if an error is a ErrOrgBotForeign.
+func IsErrOrgBotForeign(err error) bool {
+	_, ok := err.(ErrOrgBotForeign)
+	return ok
+}
+
+func (err ErrOrgBotForeign) Error() string {
+	return fmt.Sprintf("bot of another organization can't join the organization [org_id: %d, uid: %d]", err.OrgID, err.UID)
+}
+
+// OrgBot links a bot account to the organization owning it and records who created it,
+// the bot gets its permissions from the teams of the organization it is added to.
+type OrgBot struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OrgID       int64              `xorm:"INDEX NOT NULL"`
+	BotID       int64              `xorm:"UNIQUE NOT NULL"`
+	CreatorID   int64              `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+
+	Bot     *user_model.User `xorm:"-"`
+	Creator *user_model.User `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(OrgBot))
+}
+
+// LoadAttributes loads the bot account and its creator, a deleted creator is replaced by the ghost user
+func (b *OrgBot) LoadAttributes(ctx context.Context) (err error) {
+	if b.Bot == nil {
+		if b.Bot, err = user_model.GetUserByIDCtx(ctx, b.BotID); err != nil {
+			return err
+		}
+	}
+	if b.Creator == nil {
+		if b.Creator, err = user_model.GetUserByIDCtx(ctx, b.CreatorID); err != nil {
+			if !user_model.IsErrUserNotExist(err) {
+				return err
+			}
+			b.Creator = user_model.NewGhostUser()
+		}
+	}
+	return nil
+}
+
+// CreateOrgBot records the ownership of a bot account by an organization
+func CreateOrgBot(ctx context.Context, b *OrgBot) error {
+	return db.Insert(ctx, b)
+}
+
+// GetOrgBots returns the bots of an organization with their accounts, oldest first
+func GetOrgBots(ctx context.Context, orgID int64) ([]*OrgBot, error) {
+	bots := make([]*OrgBot, 0, 5)
+	if err := db.GetEngine(ctx).Where("org_id = ?", orgID).Asc("id").Find(&bots); err != nil {
+		return nil, err
+	}
+	for _, b := range bots {
+		if err := b.LoadAttributes(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return bots, nil
+}
+
+// GetOrgBotByName returns the bot of an organization by the name of its account
+func GetOrgBotByName(ctx context.Context, orgID int64, name string) (*OrgBot, error) {
+	u, err := user_model.GetUserByName(ctx, name)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return nil, ErrOrgBotNotExist{OrgID: orgID, Name: name}
+		}
+		return nil, err
+	}
+	b := &OrgBot{OrgID: orgID, BotID: u.ID}
+	if has, err := db.GetEngine(ctx).Get(b); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrOrgBotNotExist{OrgID: orgID, Name: name}
+	}
+	b.Bot = u
+	return b, b.LoadAttributes(ctx)
+}
+
+// IsForeignOrgBot returns whether the user is a bot owned by another organization,
+// a bot can only join the teams of its own organization
+func IsForeignOrgBot(ctx context.Context, orgID, userID int64) (bool, error) {
+	return db.GetEngine(ctx).Where("bot_id = ? AND org_id != ?", userID, orgID).Exist(new(OrgBot))
+}
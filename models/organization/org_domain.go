@@ -0,0 +1,198 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organization
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrOrgDomainAlreadyExist represents a "OrgDomainAlreadyExist" kind of error.
+type ErrOrgDomainAlreadyExist struct {
+	Domain string
+}
+
+// IsErrOrgDomainAlreadyExist checks if an error is a ErrOrgDomainAlreadyExist.
+func IsErrOrgDomainAlreadyExist(err error) bool {
+	_, ok := err.(ErrOrgDomainAlreadyExist)
+	return ok
+}
+
+func (err ErrOrgDomainAlreadyExist) Error() string {
+	return fmt.Sprintf("domain already exists [domain: %s]", err.Domain)
+}
+
+// ErrOrgDomainNotExist represents a "OrgDomainNotExist" kind of error.
+type ErrOrgDomainNotExist struct {
+	OrgID int64
+	ID    int64
+}
+
+// IsErrOrgDomainNotExist checks if an error is a ErrOrgDomainNotExist.
+func IsErrOrgDomainNotExist(err error) bool {
+	_, ok := err.(ErrOrgDomainNotExist)
+	return ok
+}
+
+func (err ErrOrgDomainNotExist) Error() string {
+	return fmt.Sprintf("domain does not exist [org_id: %d, id: %d]", err.OrgID, err.ID)
+}
+
+// ErrOrgDomainInvalid represents a "OrgDomainInvalid" kind of error.
+type ErrOrgDomainInvalid struct {
+	Domain string
+}
+
+// IsErrOrgDomainInvalid checks if an error is a ErrOrgDomainInvalid.
+func IsErrOrgDomainInvalid(err error) bool {
+	_, ok := err.(ErrOrgDomainInvalid)
+	return ok
+}
+
+func (err ErrOrgDomainInvalid) Error() string {
+	return fmt.Sprintf("domain is invalid [domain: %s]", err.Domain)
+}
+
+// ErrOrgDomainNotVerified represents a "OrgDomainNotVerified" kind of error.
+type ErrOrgDomainNotVerified struct {
+	Domain string
+	Reason string
+}
+
+// IsErrOrgDomainNotVerified checks if an error is a ErrOrgDomainNotVerified.
+func IsErrOrgDomainNotVerified(err error) bool {
+	_, ok := err.(ErrOrgDomainNotVerified)
+	return ok
+}
+
+func (err ErrOrgDomainNotVerified) Error() string {
+	return fmt.Sprintf("domain could not be verified [domain: %s]: %s", err.Domain, err.Reason)
+}
+
+var validDomainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// IsValidDomain returns true if the domain is a valid host name of at least two labels
+func IsValidDomain(domain string) bool {
+	return len(domain) <= 253 && validDomainPattern.MatchString(domain)
+}
+
+// OrgDomain is an email domain claimed by an organization, once verified through a DNS TXT record
+// the users having a verified email address on the domain join the team of the domain.
+type OrgDomain struct {
+	ID           int64  `xorm:"pk autoincr"`
+	OrgID        int64  `xorm:"UNIQUE(s) INDEX"`
+	Domain       string `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Token        string `xorm:"NOT NULL"`
+	TeamID       int64  `xorm:"NOT NULL DEFAULT 0"`
+	IsVerified   bool   `xorm:"INDEX NOT NULL DEFAULT false"`
+	VerifiedUnix timeutil.TimeStamp
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(OrgDomain))
+}
+
+// ChallengeName returns the name of the DNS TXT record proving the ownership of the domain
+func (d *OrgDomain) ChallengeName(orgName string) string {
+	return "_gitea-challenge-" + strings.ToLower(orgName) + "." + d.Domain
+}
+
+// EmailDomain returns the lower cased domain of the email address, or an empty string
+func EmailDomain(email string) string {
+	i := strings.LastIndex(email, "@")
+	if i < 0 {
+		return ""
+	}
+	return strings.ToLower(email[i+1:])
+}
+
+// CreateOrgDomain claims a domain for the organization, it needs to be verified to be used
+func CreateOrgDomain(ctx context.Context, d *OrgDomain) error {
+	d.Domain = strings.ToLower(strings.TrimSpace(d.Domain))
+	if !IsValidDomain(d.Domain) {
+		return ErrOrgDomainInvalid{Domain: d.Domain}
+	}
+	has, err := db.GetEngine(ctx).Exist(&OrgDomain{OrgID: d.OrgID, Domain: d.Domain})
+	if err != nil {
+		return err
+	} else if has {
+		return ErrOrgDomainAlreadyExist{Domain: d.Domain}
+	}
+
+	if d.Token, err = util.CryptoRandomString(32); err != nil {
+		return err
+	}
+	d.IsVerified = false
+	return db.Insert(ctx, d)
+}
+
+// GetOrgDomainByID returns the domain of the organization by its ID
+func GetOrgDomainByID(ctx context.Context, orgID, id int64) (*OrgDomain, error) {
+	d := &OrgDomain{ID: id, OrgID: orgID}
+	has, err := db.GetEngine(ctx).Get(d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrOrgDomainNotExist{OrgID: orgID, ID: id}
+	}
+	return d, nil
+}
+
+// GetOrgDomains returns the domains of the organization
+func GetOrgDomains(ctx context.Context, orgID int64) ([]*OrgDomain, error) {
+	domains := make([]*OrgDomain, 0, 5)
+	return domains, db.GetEngine(ctx).Where("org_id = ?", orgID).Asc("domain").Find(&domains)
+}
+
+// IsDomainVerifiedByOtherOrg returns true if another organization has verified the domain
+func IsDomainVerifiedByOtherOrg(ctx context.Context, orgID int64, domain string) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("domain = ?", domain).
+		And("org_id != ?", orgID).
+		And("is_verified = ?", true).
+		Exist(new(OrgDomain))
+}
+
+// UpdateOrgDomainCols updates the given columns of the domain
+func UpdateOrgDomainCols(ctx context.Context, d *OrgDomain, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(d.ID).Cols(cols...).Update(d)
+	return err
+}
+
+// DeleteOrgDomain deletes the domain of the organization
+func DeleteOrgDomain(ctx context.Context, d *OrgDomain) error {
+	_, err := db.GetEngine(ctx).ID(d.ID).Delete(new(OrgDomain))
+	return err
+}
+
+// FindVerifiedOrgDomains returns the verified domains among the given ones which have a team to join
+func FindVerifiedOrgDomains(ctx context.Context, domains []string) ([]*OrgDomain, error) {
+	orgDomains := make([]*OrgDomain, 0, len(domains))
+	if len(domains) == 0 {
+		return orgDomains, nil
+	}
+	return orgDomains, db.GetEngine(ctx).
+		Where(builder.In("domain", domains)).
+		And("is_verified = ?", true).
+		And("team_id > 0").
+		Find(&orgDomains)
+}
+
+// IsOrgVerified returns true if the organization has verified at least one domain
+func IsOrgVerified(ctx context.Context, orgID int64) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("org_id = ?", orgID).
+		And("is_verified = ?", true).
+		Exist(new(OrgDomain))
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organization_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidDomain(t *testing.T) {
+	assert.True(t, organization.IsValidDomain("example.com"))
+	assert.True(t, organization.IsValidDomain("mail.example-1.co.uk"))
+	assert.False(t, organization.IsValidDomain("localhost"))
+	assert.False(t, organization.IsValidDomain("-example.com"))
+	assert.False(t, organization.IsValidDomain("exa_mple.com"))
+	assert.False(t, organization.IsValidDomain("user@example.com"))
+}
+
+func TestCreateOrgDomain(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	d := &organization.OrgDomain{OrgID: 3, Domain: " Example.COM "}
+	assert.NoError(t, organization.CreateOrgDomain(db.DefaultContext, d))
+	assert.Equal(t, "example.com", d.Domain)
+	assert.Len(t, d.Token, 32)
+	assert.False(t, d.IsVerified)
+
+	err := organization.CreateOrgDomain(db.DefaultContext, &organization.OrgDomain{OrgID: 3, Domain: "example.com"})
+	assert.True(t, organization.IsErrOrgDomainAlreadyExist(err))
+	err = organization.CreateOrgDomain(db.DefaultContext, &organization.OrgDomain{OrgID: 3, Domain: "not a domain"})
+	assert.True(t, organization.IsErrOrgDomainInvalid(err))
+
+	// several organizations can claim the same domain
+	assert.NoError(t, organization.CreateOrgDomain(db.DefaultContext, &organization.OrgDomain{OrgID: 6, Domain: "example.com"}))
+
+	verified, err := organization.IsOrgVerified(db.DefaultContext, 3)
+	assert.NoError(t, err)
+	assert.False(t, verified)
+
+	d.IsVerified = true
+	assert.NoError(t, organization.UpdateOrgDomainCols(db.DefaultContext, d, "is_verified"))
+	verified, err = organization.IsOrgVerified(db.DefaultContext, 3)
+	assert.NoError(t, err)
+	assert.True(t, verified)
+
+	taken, err := organization.IsDomainVerifiedByOtherOrg(db.DefaultContext, 6, "example.com")
+	assert.NoError(t, err)
+	assert.True(t, taken)
+
+	domains, err := organization.FindVerifiedOrgDomains(db.DefaultContext, []string{"example.com"})
+	assert.NoError(t, err)
+	assert.Empty(t, domains, "domains without a team are not joined")
+
+	assert.NoError(t, organization.DeleteOrgDomain(db.DefaultContext, d))
+	unittest.AssertNotExistsBean(t, &organization.OrgDomain{ID: d.ID})
+}
@@ -28,6 +28,7 @@ type OrgUser struct {
 	UID      int64 `xorm:"INDEX UNIQUE(s)"`
 	OrgID    int64 `xorm:"INDEX UNIQUE(s)"`
 	IsPublic bool  `xorm:"INDEX"`
+	IsGuest  bool  `xorm:"NOT NULL DEFAULT false"`
 }
 
 func init() {
@@ -63,6 +64,16 @@ func IsOrganizationMember(ctx context.Context, orgID, uid int64) (bool, error) {
 		Exist()
 }
 
+// IsOrganizationGuest returns true if given user is a guest of organization.
+func IsOrganizationGuest(ctx context.Context, orgID, uid int64) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("uid=?", uid).
+		And("org_id=?", orgID).
+		And("is_guest=?", true).
+		Table("org_user").
+		Exist()
+}
+
 // IsPublicMembership returns true if the given user's membership of given org is public.
 func IsPublicMembership(orgID, uid int64) (bool, error) {
 	return db.GetEngine(db.DefaultContext).
@@ -73,16 +84,80 @@ func IsPublicMembership(orgID, uid int64) (bool, error) {
 		Exist()
 }
 
-// CanCreateOrgRepo returns true if user can create repo in organization
+// CanCreateOrgRepo returns true if user can create repo in organization,
+// guests never can.
 func CanCreateOrgRepo(orgID, uid int64) (bool, error) {
 	return db.GetEngine(db.DefaultContext).
 		Where(builder.Eq{"team.can_create_org_repo": true}).
 		Join("INNER", "team_user", "team_user.team_id = team.id").
 		And("team_user.uid = ?", uid).
 		And("team_user.org_id = ?", orgID).
+		And(builder.NotIn("team_user.uid", orgGuestsCond(orgID))).
 		Exist(new(Team))
 }
 
+// orgGuestsCond returns the condition selecting the ids of the guests of the organization
+func orgGuestsCond(orgID int64) *builder.Builder {
+	return builder.Select("uid").From("org_user").Where(builder.Eq{"org_id": orgID, "is_guest": true})
+}
+
+// ChangeOrgUserGuest turns the membership of the user into a guest or a full membership.
+// Owners can't be guests, and the membership of a guest is always private.
+func ChangeOrgUserGuest(orgID, uid int64, guest bool) error {
+	ctx, committer, err := db.TxContext()
+	if err != nil {
+		return err
+	}
+	defer committer.Close()
+
+	ou := new(OrgUser)
+	has, err := db.GetEngine(ctx).
+		Where("uid=?", uid).
+		And("org_id=?", orgID).
+		Get(ou)
+	if err != nil {
+		return err
+	} else if !has || ou.IsGuest == guest {
+		return nil
+	}
+
+	if guest {
+		isOwner, err := IsOrganizationOwner(ctx, orgID, uid)
+		if err != nil {
+			return err
+		} else if isOwner {
+			return ErrOrgGuestOwner{OrgID: orgID, UID: uid}
+		}
+		ou.IsPublic = false
+	}
+
+	ou.IsGuest = guest
+	if _, err := db.GetEngine(ctx).ID(ou.ID).Cols("is_guest", "is_public").Update(ou); err != nil {
+		return err
+	}
+	return committer.Commit()
+}
+
+// IsUserOrgGuest returns true by user ID for the users which are guests of given organization.
+func IsUserOrgGuest(users user_model.UserList, orgID int64) map[int64]bool {
+	results := make(map[int64]bool, len(users))
+	if len(users) == 0 {
+		return results
+	}
+	guests := make([]*OrgUser, 0, len(users))
+	if err := db.GetEngine(db.DefaultContext).In("uid", users.GetUserIDs()).
+		And("org_id=?", orgID).
+		And("is_guest=?", true).
+		Find(&guests); err != nil {
+		log.Error("find guests of organization %d: %v", orgID, err)
+		return results
+	}
+	for _, guest := range guests {
+		results[guest.UID] = true
+	}
+	return results
+}
+
 // IsUserOrgOwner returns true if user is in the owner team of given organization.
 func IsUserOrgOwner(users user_model.UserList, orgID int64) map[int64]bool {
 	results := make(map[int64]bool, len(users))
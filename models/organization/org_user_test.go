@@ -153,3 +153,38 @@ func TestAddOrgUser(t *testing.T) {
 
 	unittest.CheckConsistencyFor(t, &user_model.User{}, &organization.Team{})
 }
+
+func TestChangeOrgUserGuest(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// owners can't be guests
+	assert.True(t, organization.IsErrOrgGuestOwner(organization.ChangeOrgUserGuest(3, 2, true)))
+
+	assert.NoError(t, organization.ChangeOrgUserGuest(3, 28, true))
+	isGuest, err := organization.IsOrganizationGuest(db.DefaultContext, 3, 28)
+	assert.NoError(t, err)
+	assert.True(t, isGuest)
+	canCreate, err := organization.CanCreateOrgRepo(3, 28)
+	assert.NoError(t, err)
+	assert.False(t, canCreate)
+
+	// the membership of a guest is never public
+	assert.NoError(t, organization.ChangeOrgUserStatus(3, 28, true))
+	isPublic, err := organization.IsPublicMembership(3, 28)
+	assert.NoError(t, err)
+	assert.False(t, isPublic)
+
+	guests, _, err := organization.FindOrgMembers(&organization.FindOrgMembersOpts{OrgID: 3, GuestOnly: true})
+	assert.NoError(t, err)
+	if assert.Len(t, guests, 1) {
+		assert.EqualValues(t, 28, guests[0].ID)
+	}
+	members, _, err := organization.FindOrgMembers(&organization.FindOrgMembersOpts{OrgID: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]bool{28: true}, organization.IsUserOrgGuest(members, 3))
+
+	assert.NoError(t, organization.ChangeOrgUserGuest(3, 28, false))
+	isGuest, err = organization.IsOrganizationGuest(db.DefaultContext, 3, 28)
+	assert.NoError(t, err)
+	assert.False(t, isGuest)
+}
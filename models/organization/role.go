@@ -0,0 +1,313 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/perm"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ErrRoleAlreadyExist represents a "RoleAlreadyExist" kind of error.
+type ErrRoleAlreadyExist struct {
+	OrgID int64
+	Name  string
+}
+
+// IsErrRoleAlreadyExist checks if an error is a ErrRoleAlreadyExist.
+func IsErrRoleAlreadyExist(err error) bool {
+	_, ok := err.(ErrRoleAlreadyExist)
+	return ok
+}
+
+func (err ErrRoleAlreadyExist) Error() string {
+	return fmt.Sprintf("role already exists [org_id: %d, name: %s]", err.OrgID, err.Name)
+}
+
+// ErrRoleNotExist represents a "RoleNotExist" kind of error.
+type ErrRoleNotExist struct {
+	OrgID  int64
+	RoleID int64
+	Name   string
+}
+
+// IsErrRoleNotExist checks if an error is a ErrRoleNotExist.
+func IsErrRoleNotExist(err error) bool {
+	_, ok := err.(ErrRoleNotExist)
+	return ok
+}
+
+func (err ErrRoleNotExist) Error() string {
+	return fmt.Sprintf("role does not exist [org_id: %d, role_id: %d, name: %s]", err.OrgID, err.RoleID, err.Name)
+}
+
+// ErrRoleInUse represents a "RoleInUse" kind of error.
+type ErrRoleInUse struct {
+	RoleID int64
+}
+
+// IsErrRoleInUse checks if an error is a ErrRoleInUse.
+func IsErrRoleInUse(err error) bool {
+	_, ok := err.(ErrRoleInUse)
+	return ok
+}
+
+func (err ErrRoleInUse) Error() string {
+	return fmt.Sprintf("role is still assigned to teams or collaborators [role_id: %d]", err.RoleID)
+}
+
+// Role is a set of repository unit permissions defined by an organization,
+// which can be assigned to its teams and to the collaborators of its repositories.
+type Role struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OrgID       int64              `xorm:"UNIQUE(s) INDEX"`
+	LowerName   string             `xorm:"UNIQUE(s) NOT NULL"`
+	Name        string             `xorm:"NOT NULL"`
+	Description string             `xorm:"TEXT"`
+	Units       []*RoleUnit        `xorm:"-"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+// RoleUnit is the access mode a role grants to a unit of the repositories
+type RoleUnit struct {
+	ID         int64     `xorm:"pk autoincr"`
+	OrgID      int64     `xorm:"INDEX"`
+	RoleID     int64     `xorm:"UNIQUE(s)"`
+	Type       unit.Type `xorm:"UNIQUE(s)"`
+	AccessMode perm.AccessMode
+}
+
+func init() {
+	db.RegisterModel(new(Role))
+	db.RegisterModel(new(RoleUnit))
+}
+
+// Unit returns Unit
+func (u *RoleUnit) Unit() unit.Unit {
+	return unit.Units[u.Type]
+}
+
+// LoadUnits loads the units of the role
+func (r *Role) LoadUnits(ctx context.Context) error {
+	if r.Units != nil {
+		return nil
+	}
+	r.Units = make([]*RoleUnit, 0, len(unit.Units))
+	return db.GetEngine(ctx).Where("role_id = ?", r.ID).Find(&r.Units)
+}
+
+// UnitAccessMode returns the access mode the role grants to the unit,
+// it is called in templates
+func (r *Role) UnitAccessMode(tp unit.Type) perm.AccessMode {
+	if err := r.LoadUnits(db.DefaultContext); err != nil {
+		log.Warn("Error loading role (ID: %d) units: %s", r.ID, err.Error())
+	}
+
+	for _, u := range r.Units {
+		if u.Type == tp {
+			return u.AccessMode
+		}
+	}
+	return perm.AccessModeNone
+}
+
+// UnitsMode returns the access modes the role grants by unit type, the units must be loaded
+func (r *Role) UnitsMode() map[unit.Type]perm.AccessMode {
+	unitsMode := make(map[unit.Type]perm.AccessMode, len(r.Units))
+	for _, u := range r.Units {
+		unitsMode[u.Type] = u.AccessMode
+	}
+	return unitsMode
+}
+
+// AccessMode returns the general access mode of the teams and collaborators having the role,
+// which is the minimal access mode of its units like for teams, the units must be loaded
+func (r *Role) AccessMode() perm.AccessMode {
+	return unit.MinUnitAccessMode(r.UnitsMode())
+}
+
+// GetUnitsMap returns the role units permissions by unit name, the units must be loaded
+func (r *Role) GetUnitsMap() map[string]string {
+	m := make(map[string]string, len(r.Units))
+	for _, u := range r.Units {
+		m[u.Unit().NameKey] = u.AccessMode.String()
+	}
+	return m
+}
+
+// TeamUnits returns the units of the role as the units of the team
+func (r *Role) TeamUnits(teamID int64) []*TeamUnit {
+	units := make([]*TeamUnit, 0, len(r.Units))
+	for _, u := range r.Units {
+		units = append(units, &TeamUnit{
+			OrgID:      r.OrgID,
+			TeamID:     teamID,
+			Type:       u.Type,
+			AccessMode: u.AccessMode,
+		})
+	}
+	return units
+}
+
+// NewRoleUnits returns the units of the role from the access modes by unit type,
+// units without access are left out and the access modes are limited to what the units support
+func NewRoleUnits(orgID int64, unitsMode map[unit.Type]perm.AccessMode) []*RoleUnit {
+	units := make([]*RoleUnit, 0, len(unitsMode))
+	for tp, mode := range unitsMode {
+		u, ok := unit.Units[tp]
+		if !ok || mode <= perm.AccessModeNone {
+			continue
+		}
+		// like for teams, admin access is not granted per unit
+		if mode > perm.AccessModeWrite {
+			mode = perm.AccessModeWrite
+		}
+		if mode > u.MaxPerm() {
+			mode = u.MaxPerm()
+		}
+		units = append(units, &RoleUnit{
+			OrgID:      orgID,
+			Type:       tp,
+			AccessMode: mode,
+		})
+	}
+	return units
+}
+
+// GetRoleByID returns the role by its ID
+func GetRoleByID(ctx context.Context, id int64) (*Role, error) {
+	r := new(Role)
+	has, err := db.GetEngine(ctx).ID(id).Get(r)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrRoleNotExist{RoleID: id}
+	}
+	return r, nil
+}
+
+// GetOrgRoleByID returns the role of the organization by its ID
+func GetOrgRoleByID(ctx context.Context, orgID, id int64) (*Role, error) {
+	r := &Role{ID: id, OrgID: orgID}
+	has, err := db.GetEngine(ctx).Get(r)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrRoleNotExist{OrgID: orgID, RoleID: id}
+	}
+	return r, nil
+}
+
+// GetOrgRoleByName returns the role of the organization by its name
+func GetOrgRoleByName(ctx context.Context, orgID int64, name string) (*Role, error) {
+	r := &Role{OrgID: orgID, LowerName: strings.ToLower(name)}
+	has, err := db.GetEngine(ctx).Get(r)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrRoleNotExist{OrgID: orgID, Name: name}
+	}
+	return r, nil
+}
+
+// GetOrgRoles returns the roles of the organization with their units
+func GetOrgRoles(ctx context.Context, orgID int64) ([]*Role, error) {
+	roles := make([]*Role, 0, 5)
+	if err := db.GetEngine(ctx).Where("org_id = ?", orgID).OrderBy("lower_name").Find(&roles); err != nil {
+		return nil, err
+	}
+	for _, r := range roles {
+		if err := r.LoadUnits(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return roles, nil
+}
+
+// CheckRoleName returns an ErrRoleAlreadyExist if another role of the organization has the name
+func CheckRoleName(ctx context.Context, r *Role) error {
+	if len(r.Name) == 0 {
+		return errors.New("empty role name")
+	}
+	has, err := db.GetEngine(ctx).
+		Where("org_id = ?", r.OrgID).
+		And("lower_name = ?", strings.ToLower(r.Name)).
+		And("id != ?", r.ID).
+		Exist(new(Role))
+	if err != nil {
+		return err
+	} else if has {
+		return ErrRoleAlreadyExist{OrgID: r.OrgID, Name: r.Name}
+	}
+	return nil
+}
+
+// NewRole creates a role of the organization with its units
+func NewRole(r *Role) error {
+	if err := CheckRoleName(db.DefaultContext, r); err != nil {
+		return err
+	}
+
+	ctx, committer, err := db.TxContext()
+	if err != nil {
+		return err
+	}
+	defer committer.Close()
+
+	r.LowerName = strings.ToLower(r.Name)
+	if err := db.Insert(ctx, r); err != nil {
+		return err
+	}
+	for _, u := range r.Units {
+		u.OrgID = r.OrgID
+		u.RoleID = r.ID
+	}
+	if len(r.Units) > 0 {
+		if err := db.Insert(ctx, r.Units); err != nil {
+			return err
+		}
+	}
+	return committer.Commit()
+}
+
+// IsRoleInUse returns true if the role is assigned to teams or collaborators
+func IsRoleInUse(ctx context.Context, roleID int64) (bool, error) {
+	if has, err := db.GetEngine(ctx).Where("role_id = ?", roleID).Exist(new(Team)); err != nil || has {
+		return has, err
+	}
+	return db.GetEngine(ctx).Where("role_id = ?", roleID).Exist(new(repo_model.Collaboration))
+}
+
+// DeleteRole deletes a role which is assigned to no team nor collaborator
+func DeleteRole(r *Role) error {
+	ctx, committer, err := db.TxContext()
+	if err != nil {
+		return err
+	}
+	defer committer.Close()
+
+	if inUse, err := IsRoleInUse(ctx, r.ID); err != nil {
+		return err
+	} else if inUse {
+		return ErrRoleInUse{RoleID: r.ID}
+	}
+
+	if _, err := db.GetEngine(ctx).Where("role_id = ?", r.ID).Delete(new(RoleUnit)); err != nil {
+		return err
+	}
+	if _, err := db.GetEngine(ctx).ID(r.ID).Delete(new(Role)); err != nil {
+		return err
+	}
+	return committer.Commit()
+}
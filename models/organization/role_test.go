@@ -0,0 +1,89 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organization_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRoleUnits(t *testing.T) {
+	units := organization.NewRoleUnits(3, map[unit.Type]perm.AccessMode{
+		unit.TypeCode:            perm.AccessModeNone,
+		unit.TypeIssues:          perm.AccessModeAdmin,
+		unit.TypeExternalTracker: perm.AccessModeWrite,
+		unit.Type(999):           perm.AccessModeRead,
+	})
+	role := &organization.Role{OrgID: 3, Units: units}
+	assert.Equal(t, map[unit.Type]perm.AccessMode{
+		unit.TypeIssues:          perm.AccessModeWrite,
+		unit.TypeExternalTracker: perm.AccessModeRead,
+	}, role.UnitsMode())
+	assert.Equal(t, perm.AccessModeWrite, role.AccessMode())
+}
+
+func TestNewRole(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	role := &organization.Role{
+		OrgID:       3,
+		Name:        "Triage",
+		Description: "Manage issues",
+		Units: organization.NewRoleUnits(3, map[unit.Type]perm.AccessMode{
+			unit.TypeCode:   perm.AccessModeRead,
+			unit.TypeIssues: perm.AccessModeWrite,
+		}),
+	}
+	assert.NoError(t, organization.NewRole(role))
+	unittest.AssertExistsAndLoadBean(t, &organization.Role{ID: role.ID, OrgID: 3, LowerName: "triage"})
+	unittest.AssertExistsAndLoadBean(t, &organization.RoleUnit{RoleID: role.ID, Type: unit.TypeIssues, AccessMode: perm.AccessModeWrite})
+
+	err := organization.NewRole(&organization.Role{OrgID: 3, Name: "TRIAGE"})
+	assert.True(t, organization.IsErrRoleAlreadyExist(err))
+	// another organization can have a role with the same name
+	assert.NoError(t, organization.NewRole(&organization.Role{OrgID: 6, Name: "triage"}))
+
+	roles, err := organization.GetOrgRoles(db.DefaultContext, 3)
+	assert.NoError(t, err)
+	if assert.Len(t, roles, 1) {
+		assert.Equal(t, "Triage", roles[0].Name)
+		assert.Equal(t, map[string]string{"repo.code": "read", "repo.issues": "write"}, roles[0].GetUnitsMap())
+		assert.Equal(t, perm.AccessModeRead, roles[0].AccessMode())
+	}
+
+	_, err = organization.GetOrgRoleByID(db.DefaultContext, 6, role.ID)
+	assert.True(t, organization.IsErrRoleNotExist(err))
+	found, err := organization.GetOrgRoleByName(db.DefaultContext, 3, "triage")
+	assert.NoError(t, err)
+	assert.Equal(t, role.ID, found.ID)
+}
+
+func TestDeleteRole(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	role := &organization.Role{
+		OrgID: 3,
+		Name:  "releaser",
+		Units: organization.NewRoleUnits(3, map[unit.Type]perm.AccessMode{unit.TypeReleases: perm.AccessModeWrite}),
+	}
+	assert.NoError(t, organization.NewRole(role))
+
+	_, err := db.GetEngine(db.DefaultContext).ID(2).Cols("role_id").Update(&organization.Team{RoleID: role.ID})
+	assert.NoError(t, err)
+	assert.True(t, organization.IsErrRoleInUse(organization.DeleteRole(role)))
+
+	_, err = db.GetEngine(db.DefaultContext).ID(2).Cols("role_id").Update(&organization.Team{})
+	assert.NoError(t, err)
+	assert.NoError(t, organization.DeleteRole(role))
+	unittest.AssertNotExistsBean(t, &organization.Role{ID: role.ID})
+	unittest.AssertNotExistsBean(t, &organization.RoleUnit{RoleID: role.ID})
+}
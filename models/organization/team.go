@@ -67,6 +67,8 @@ const OwnerTeamName = "Owners"
 type Team struct {
 	ID                      int64 `xorm:"pk autoincr"`
 	OrgID                   int64 `xorm:"INDEX"`
+	ParentID                int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RoleID                  int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
 	LowerName               string
 	Name                    string
 	Description             string
@@ -78,6 +80,7 @@ type Team struct {
 	Units                   []*TeamUnit `xorm:"-"`
 	IncludesAllRepositories bool        `xorm:"NOT NULL DEFAULT false"`
 	CanCreateOrgRepo        bool        `xorm:"NOT NULL DEFAULT false"`
+	AutoAssignReviewer      bool        `xorm:"NOT NULL DEFAULT false"`
 }
 
 func init() {
@@ -0,0 +1,172 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organization
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+)
+
+// ErrTeamParentInvalid represents an invalid parent of a team: a team of another organization,
+// the team itself or one of its descendants, or the owner team
+type ErrTeamParentInvalid struct {
+	TeamID   int64
+	ParentID int64
+}
+
+// IsErrTeamParentInvalid checks if an error is a ErrTeamParentInvalid.
+func IsErrTeamParentInvalid(err error) bool {
+	_, ok := err.(ErrTeamParentInvalid)
+	return ok
+}
+
+func (err ErrTeamParentInvalid) Error() string {
+	return fmt.Sprintf("team cannot be a child of this team [team_id: %d, parent_id: %d]", err.TeamID, err.ParentID)
+}
+
+// getOrgTeamParents returns the parent ID of every team of the organization
+func getOrgTeamParents(ctx context.Context, orgID int64) (map[int64]int64, error) {
+	teams := make([]*Team, 0, 10)
+	if err := db.GetEngine(ctx).Cols("id", "parent_id").Where("org_id=?", orgID).Find(&teams); err != nil {
+		return nil, err
+	}
+	parents := make(map[int64]int64, len(teams))
+	for _, team := range teams {
+		parents[team.ID] = team.ParentID
+	}
+	return parents, nil
+}
+
+// GetTeamAncestorIDs returns the IDs of the parent, grandparent, etc. of the teams, without duplicates
+func GetTeamAncestorIDs(ctx context.Context, orgID int64, teamIDs ...int64) ([]int64, error) {
+	parents, err := getOrgTeamParents(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	visited := make(map[int64]bool, len(teamIDs))
+	for _, teamID := range teamIDs {
+		visited[teamID] = true
+	}
+	ancestorIDs := make([]int64, 0, len(parents))
+	for _, teamID := range teamIDs {
+		for parentID := parents[teamID]; parentID > 0 && !visited[parentID]; parentID = parents[parentID] {
+			visited[parentID] = true
+			ancestorIDs = append(ancestorIDs, parentID)
+		}
+	}
+	return ancestorIDs, nil
+}
+
+// GetTeamDescendantIDs returns the IDs of the children, grandchildren, etc. of the teams, without duplicates
+func GetTeamDescendantIDs(ctx context.Context, orgID int64, teamIDs ...int64) ([]int64, error) {
+	parents, err := getOrgTeamParents(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[int64][]int64, len(parents))
+	for teamID, parentID := range parents {
+		if parentID > 0 {
+			children[parentID] = append(children[parentID], teamID)
+		}
+	}
+
+	visited := make(map[int64]bool, len(teamIDs))
+	for _, teamID := range teamIDs {
+		visited[teamID] = true
+	}
+	descendantIDs := make([]int64, 0, len(parents))
+	queue := append([]int64{}, teamIDs...)
+	for len(queue) > 0 {
+		teamID := queue[0]
+		queue = queue[1:]
+		for _, childID := range children[teamID] {
+			if !visited[childID] {
+				visited[childID] = true
+				descendantIDs = append(descendantIDs, childID)
+				queue = append(queue, childID)
+			}
+		}
+	}
+	return descendantIDs, nil
+}
+
+// CheckTeamParent returns an ErrTeamParentInvalid if the team cannot become a child of the parent team.
+// The owner team cannot be nested, and a team cannot become a descendant of itself.
+func CheckTeamParent(ctx context.Context, t *Team, parentID int64) error {
+	if parentID == 0 {
+		return nil
+	}
+	if t.IsOwnerTeam() || parentID == t.ID {
+		return ErrTeamParentInvalid{t.ID, parentID}
+	}
+
+	parent, err := GetTeamByID(ctx, parentID)
+	if err != nil {
+		if IsErrTeamNotExist(err) {
+			return ErrTeamParentInvalid{t.ID, parentID}
+		}
+		return err
+	}
+	if parent.OrgID != t.OrgID || parent.IsOwnerTeam() {
+		return ErrTeamParentInvalid{t.ID, parentID}
+	}
+
+	if t.ID > 0 {
+		descendantIDs, err := GetTeamDescendantIDs(ctx, t.OrgID, t.ID)
+		if err != nil {
+			return err
+		}
+		for _, descendantID := range descendantIDs {
+			if descendantID == parentID {
+				return ErrTeamParentInvalid{t.ID, parentID}
+			}
+		}
+	}
+	return nil
+}
+
+// GetParent returns the parent team, or nil if the team is not nested
+func (t *Team) GetParent(ctx context.Context) (*Team, error) {
+	if t.ParentID == 0 {
+		return nil, nil
+	}
+	return GetTeamByID(ctx, t.ParentID)
+}
+
+// GetChildren returns the teams whose parent is the team
+func (t *Team) GetChildren(ctx context.Context) ([]*Team, error) {
+	children := make([]*Team, 0, 5)
+	return children, db.GetEngine(ctx).
+		Where("org_id=?", t.OrgID).
+		And("parent_id=?", t.ID).
+		OrderBy("lower_name").
+		Find(&children)
+}
+
+// IsTeamSubtreeMember returns true if the user is a member of the team or of one of its descendants
+func IsTeamSubtreeMember(ctx context.Context, orgID, teamID, userID int64) (bool, error) {
+	descendantIDs, err := GetTeamDescendantIDs(ctx, orgID, teamID)
+	if err != nil {
+		return false, err
+	}
+	return IsUserInTeams(ctx, userID, append(descendantIDs, teamID))
+}
+
+// HasInheritedTeamRepo returns true if the team or one of its ancestors has the repository
+func HasInheritedTeamRepo(ctx context.Context, orgID, teamID, repoID int64) (bool, error) {
+	ancestorIDs, err := GetTeamAncestorIDs(ctx, orgID, teamID)
+	if err != nil {
+		return false, err
+	}
+	return db.GetEngine(ctx).
+		Where("org_id=?", orgID).
+		In("team_id", append(ancestorIDs, teamID)).
+		And("repo_id=?", repoID).
+		Exist(new(TeamRepo))
+}
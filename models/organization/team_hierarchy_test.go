@@ -0,0 +1,92 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package organization_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setTeamParent(t *testing.T, teamID, parentID int64) {
+	_, err := db.GetEngine(db.DefaultContext).ID(teamID).Cols("parent_id").Update(&organization.Team{ParentID: parentID})
+	assert.NoError(t, err)
+}
+
+func TestGetTeamAncestorAndDescendantIDs(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// test_team (7) > team1 (2) > team12Creators (12)
+	setTeamParent(t, 2, 7)
+	setTeamParent(t, 12, 2)
+
+	ancestorIDs, err := organization.GetTeamAncestorIDs(db.DefaultContext, 3, 12)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 7}, ancestorIDs)
+
+	ancestorIDs, err = organization.GetTeamAncestorIDs(db.DefaultContext, 3, 7)
+	assert.NoError(t, err)
+	assert.Empty(t, ancestorIDs)
+
+	descendantIDs, err := organization.GetTeamDescendantIDs(db.DefaultContext, 3, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 12}, descendantIDs)
+
+	descendantIDs, err = organization.GetTeamDescendantIDs(db.DefaultContext, 3, 12)
+	assert.NoError(t, err)
+	assert.Empty(t, descendantIDs)
+
+	team := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 7})
+	children, err := team.GetChildren(db.DefaultContext)
+	assert.NoError(t, err)
+	if assert.Len(t, children, 1) {
+		assert.EqualValues(t, 2, children[0].ID)
+	}
+
+	// user 15 is only a member of test_team, users 2 and 4 are members of team1
+	isMember, err := organization.IsTeamSubtreeMember(db.DefaultContext, 3, 7, 4)
+	assert.NoError(t, err)
+	assert.True(t, isMember)
+	isMember, err = organization.IsTeamSubtreeMember(db.DefaultContext, 3, 2, 15)
+	assert.NoError(t, err)
+	assert.False(t, isMember)
+
+	// team1 inherits repo 32 of test_team, but not the other way round
+	has, err := organization.HasInheritedTeamRepo(db.DefaultContext, 3, 2, 32)
+	assert.NoError(t, err)
+	assert.True(t, has)
+	has, err = organization.HasInheritedTeamRepo(db.DefaultContext, 3, 7, 3)
+	assert.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestCheckTeamParent(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	setTeamParent(t, 2, 7)
+
+	test := func(teamID, parentID int64, valid bool) {
+		team := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: teamID})
+		err := organization.CheckTeamParent(db.DefaultContext, team, parentID)
+		if valid {
+			assert.NoError(t, err)
+		} else {
+			assert.True(t, organization.IsErrTeamParentInvalid(err), "team %d, parent %d: %v", teamID, parentID, err)
+		}
+	}
+	test(2, 0, true)
+	test(12, 2, true)
+	test(2, 7, true)
+	test(2, 2, false)    // itself
+	test(7, 2, false)    // its own child
+	test(1, 2, false)    // the owner team
+	test(2, 1, false)    // under the owner team
+	test(2, 8, false)    // team of another organization
+	test(2, 9999, false) // nonexistent team
+}
@@ -42,7 +42,9 @@ func GetTeamUsersByTeamID(ctx context.Context, teamID int64) ([]*TeamUser, error
 // SearchMembersOptions holds the search options
 type SearchMembersOptions struct {
 	db.ListOptions
-	TeamID int64
+	TeamID          int64
+	OrgID           int64 // required with IncludeSubteams
+	IncludeSubteams bool  // include the members of the descendants of the team
 }
 
 func (opts SearchMembersOptions) ToConds() builder.Cond {
@@ -58,10 +60,18 @@ func GetTeamMembers(ctx context.Context, opts *SearchMembersOptions) ([]*user_mo
 	var members []*user_model.User
 	sess := db.GetEngine(ctx)
 	if opts.TeamID > 0 {
+		teamIDs := []int64{opts.TeamID}
+		if opts.IncludeSubteams {
+			descendantIDs, err := GetTeamDescendantIDs(ctx, opts.OrgID, opts.TeamID)
+			if err != nil {
+				return nil, err
+			}
+			teamIDs = append(teamIDs, descendantIDs...)
+		}
 		sess = sess.In("id",
 			builder.Select("uid").
 				From("team_user").
-				Where(builder.Eq{"team_id": opts.TeamID}),
+				Where(builder.In("team_id", teamIDs)),
 		)
 	}
 	if opts.PageSize > 0 && opts.Page > -1 {
@@ -82,13 +92,26 @@ func GetUserOrgTeams(ctx context.Context, orgID, userID int64) (teams []*Team, e
 		Find(&teams)
 }
 
-// GetUserRepoTeams returns user repo's teams
+// GetUserRepoTeams returns user repo's teams, including the ancestors of the teams the user belongs to
 func GetUserRepoTeams(ctx context.Context, orgID, userID, repoID int64) (teams []*Team, err error) {
+	var teamIDs []int64
+	if err := db.GetEngine(ctx).Table("team_user").
+		Where("org_id=?", orgID).
+		And("uid=?", userID).
+		Cols("team_id").
+		Find(&teamIDs); err != nil || len(teamIDs) == 0 {
+		return nil, err
+	}
+
+	ancestorIDs, err := GetTeamAncestorIDs(ctx, orgID, teamIDs...)
+	if err != nil {
+		return nil, err
+	}
+
 	return teams, db.GetEngine(ctx).
-		Join("INNER", "team_user", "team_user.team_id = team.id").
 		Join("INNER", "team_repo", "team_repo.team_id = team.id").
 		Where("team.org_id = ?", orgID).
-		And("team_user.uid=?", userID).
+		In("team.id", append(teamIDs, ancestorIDs...)).
 		And("team_repo.repo_id=?", repoID).
 		Find(&teams)
 }
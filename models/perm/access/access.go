@@ -159,6 +159,14 @@ func RecalculateTeamAccesses(ctx context.Context, repo *repo_model.Repository, i
 		return err
 	}
 
+	// members of child teams inherit the access of their parent teams
+	children := make(map[int64][]*organization.Team, len(teams))
+	for _, t := range teams {
+		if t.ParentID > 0 && t.ID != ignTeamID {
+			children[t.ParentID] = append(children[t.ParentID], t)
+		}
+	}
+
 	for _, t := range teams {
 		if t.ID == ignTeamID {
 			continue
@@ -172,11 +180,25 @@ func RecalculateTeamAccesses(ctx context.Context, repo *repo_model.Repository, i
 			continue
 		}
 
-		if err = t.GetMembersCtx(ctx); err != nil {
-			return fmt.Errorf("getMembers '%d': %v", t.ID, err)
-		}
-		for _, m := range t.Members {
-			updateUserAccess(accessMap, m, t.AccessMode)
+		visited := make(map[int64]bool)
+		subtree := []*organization.Team{t}
+		for len(subtree) > 0 {
+			sub := subtree[0]
+			subtree = subtree[1:]
+			if visited[sub.ID] {
+				continue
+			}
+			visited[sub.ID] = true
+			subtree = append(subtree, children[sub.ID]...)
+
+			if sub.Members == nil {
+				if err = sub.GetMembersCtx(ctx); err != nil {
+					return fmt.Errorf("getMembers '%d': %v", sub.ID, err)
+				}
+			}
+			for _, m := range sub.Members {
+				updateUserAccess(accessMap, m, t.AccessMode)
+			}
 		}
 	}
 
@@ -203,13 +225,8 @@ func RecalculateUserAccess(ctx context.Context, repo *repo_model.Repository, uid
 	if err = repo.GetOwner(ctx); err != nil {
 		return err
 	} else if repo.Owner.IsOrganization() {
-		var teams []organization.Team
-		if err := e.Join("INNER", "team_repo", "team_repo.team_id = team.id").
-			Join("INNER", "team_user", "team_user.team_id = team.id").
-			Where("team.org_id = ?", repo.OwnerID).
-			And("team_repo.repo_id=?", repo.ID).
-			And("team_user.uid=?", uid).
-			Find(&teams); err != nil {
+		teams, err := organization.GetUserRepoTeams(ctx, repo.OwnerID, uid, repo.ID)
+		if err != nil {
 			return err
 		}
 
@@ -168,13 +168,14 @@ func GetUserRepoPermission(ctx context.Context, repo *repo_model.Repository, use
 		return
 	}
 
-	var is bool
+	var collaboration *repo_model.Collaboration
 	if user != nil {
-		is, err = repo_model.IsCollaborator(ctx, repo.ID, user.ID)
+		collaboration, err = repo_model.GetCollaboration(ctx, repo.ID, user.ID)
 		if err != nil {
 			return perm, err
 		}
 	}
+	is := collaboration != nil
 
 	if err = repo.GetOwner(ctx); err != nil {
 		return
@@ -222,8 +223,26 @@ func GetUserRepoPermission(ctx context.Context, repo *repo_model.Repository, use
 
 	// Collaborators on organization
 	if is {
-		for _, u := range repo.Units {
-			perm.UnitsMode[u.Type] = perm.AccessMode
+		if collaboration.RoleID > 0 {
+			// a collaborator having a role of the organization only has the access of the role to each unit
+			var role *organization.Role
+			role, err = organization.GetRoleByID(ctx, collaboration.RoleID)
+			if err != nil {
+				return
+			}
+			if err = role.LoadUnits(ctx); err != nil {
+				return
+			}
+			roleUnitsMode := role.UnitsMode()
+			for _, u := range repo.Units {
+				if mode := roleUnitsMode[u.Type]; mode > perm_model.AccessModeNone {
+					perm.UnitsMode[u.Type] = mode
+				}
+			}
+		} else {
+			for _, u := range repo.Units {
+				perm.UnitsMode[u.Type] = perm.AccessMode
+			}
 		}
 	}
 
@@ -242,6 +261,15 @@ func GetUserRepoPermission(ctx context.Context, repo *repo_model.Repository, use
 		}
 	}
 
+	// a guest of a limited or private organization only accesses the units it is granted
+	var isGuest bool
+	if !repo.Owner.Visibility.IsPublic() {
+		isGuest, err = organization.IsOrganizationGuest(ctx, repo.OwnerID, user.ID)
+		if err != nil {
+			return
+		}
+	}
+
 	for _, u := range repo.Units {
 		var found bool
 		for _, team := range teams {
@@ -256,13 +284,17 @@ func GetUserRepoPermission(ctx context.Context, repo *repo_model.Repository, use
 		}
 
 		// for a public repo on an organization, a non-restricted user has read permission on non-team defined units.
-		if !found && !repo.IsPrivate && !user.IsRestricted {
+		if !found && !repo.IsPrivate && !user.IsRestricted && !isGuest {
 			if _, ok := perm.UnitsMode[u.Type]; !ok {
 				perm.UnitsMode[u.Type] = perm_model.AccessModeRead
 			}
 		}
 	}
 
+	if isGuest && len(perm.UnitsMode) == 0 {
+		perm.AccessMode = perm_model.AccessModeNone
+	}
+
 	// remove no permission units
 	perm.Units = make([]*repo_model.RepoUnit, 0, len(repo.Units))
 	for t := range perm.UnitsMode {
@@ -10,6 +10,9 @@ import (
 	"fmt"
 
 	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
@@ -81,7 +84,8 @@ type Project struct {
 	BoardType   BoardType
 	Type        Type
 
-	RenderedContent string `xorm:"-"`
+	RenderedContent string                 `xorm:"-"`
+	Repo            *repo_model.Repository `xorm:"-"`
 
 	CreatedUnix    timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix    timeutil.TimeStamp `xorm:"INDEX updated"`
@@ -92,6 +96,14 @@ func init() {
 	db.RegisterModel(new(Project))
 }
 
+// LoadRepo loads the repository of a repository project
+func (p *Project) LoadRepo(ctx context.Context) (err error) {
+	if p.Repo == nil && p.RepoID > 0 {
+		p.Repo, err = repo_model.GetRepositoryByIDCtx(ctx, p.RepoID)
+	}
+	return err
+}
+
 // GetProjectsConfig retrieves the types of configurations projects could have
 func GetProjectsConfig() []ProjectsConfig {
 	return []ProjectsConfig{
@@ -118,6 +130,9 @@ type SearchOptions struct {
 	IsClosed util.OptionalBool
 	SortType string
 	Type     Type
+	// OwnerID selects the projects of the repositories of the owner which Actor has access to, instead of RepoID
+	OwnerID int64
+	Actor   *user_model.User
 }
 
 // GetProjects returns a list of all projects that have been created in the repository
@@ -126,6 +141,10 @@ func GetProjects(ctx context.Context, opts SearchOptions) ([]*Project, int64, er
 	projects := make([]*Project, 0, setting.UI.IssuePagingNum)
 
 	var cond builder.Cond = builder.Eq{"repo_id": opts.RepoID}
+	if opts.OwnerID > 0 {
+		cond = builder.In("repo_id", builder.Select("id").From("repository").
+			Where(builder.Eq{"owner_id": opts.OwnerID}.And(repo_model.AccessibleRepositoryCondition(opts.Actor, unit.TypeProjects))))
+	}
 	switch opts.IsClosed {
 	case util.OptionalBoolTrue:
 		cond = cond.And(builder.Eq{"is_closed": true})
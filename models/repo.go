@@ -14,10 +14,16 @@ import (
 
 	activities_model "code.gitea.io/gitea/models/activities"
 	admin_model "code.gitea.io/gitea/models/admin"
+	advisory_model "code.gitea.io/gitea/models/advisory"
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	attestation_model "code.gitea.io/gitea/models/attestation"
+	"code.gitea.io/gitea/models/codescanning"
+	coverage_model "code.gitea.io/gitea/models/coverage"
 	"code.gitea.io/gitea/models/db"
+	deployment_model "code.gitea.io/gitea/models/deployment"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
 	"code.gitea.io/gitea/models/organization"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	project_model "code.gitea.io/gitea/models/project"
@@ -121,20 +127,48 @@ func DeleteRepository(doer *user_model.User, uid, repoID int64) error {
 	if err := db.DeleteBeans(ctx,
 		&access_model.Access{RepoID: repo.ID},
 		&activities_model.Action{RepoID: repo.ID},
+		&attestation_model.Attestation{RepoID: repoID},
+		&attestation_model.Digest{RepoID: repoID},
+		&codescanning.Alert{RepoID: repoID},
+		&codescanning.AlertInstance{RepoID: repoID},
+		&codescanning.Analysis{RepoID: repoID},
 		&repo_model.Collaboration{RepoID: repoID},
+		&coverage_model.File{RepoID: repoID},
+		&coverage_model.Report{RepoID: repoID},
 		&issues_model.Comment{RefRepoID: repoID},
+		&issues_model.IssueField{RepoID: repoID},
+		&issues_model.IssueFilter{RepoID: repoID},
+		&issues_model.IssueSchedule{RepoID: repoID},
+		&issues_model.Component{RepoID: repoID},
 		&git_model.CommitStatus{RepoID: repoID},
 		&git_model.DeletedBranch{RepoID: repoID},
+		&deployment_model.Deployment{RepoID: repoID},
+		&deployment_model.Status{RepoID: repoID},
+		&repo_model.DownloadUsage{RepoID: repoID},
+		&repo_model.RepoReplication{RepoID: repoID},
+		&advisory_model.Advisory{RepoID: repoID},
+		&repo_model.PinnedRepository{RepoID: repoID},
+		&repo_model.InteractionLimit{RepoID: repoID},
+		&repo_model.ForkSync{RepoID: repoID},
+		&issues_model.IssueMirror{RepoID: repoID},
 		&webhook.HookTask{RepoID: repoID},
 		&git_model.LFSLock{RepoID: repoID},
 		&repo_model.LanguageStat{RepoID: repoID},
+		&repo_model.LanguageStatsHistory{RepoID: repoID},
+		&repo_model.TrendingRepo{RepoID: repoID},
+		&repo_model.ExploreCollectionRepo{RepoID: repoID},
+		&repo_model.RepoExpertise{RepoID: repoID},
+		&repo_model.MaintenanceReport{RepoID: repoID},
 		&issues_model.Milestone{RepoID: repoID},
+		&moderation_model.Report{RepoID: repoID},
+		&moderation_model.Hold{RepoID: repoID},
 		&repo_model.Mirror{RepoID: repoID},
 		&activities_model.Notification{RepoID: repoID},
 		&git_model.ProtectedBranch{RepoID: repoID},
 		&git_model.ProtectedTag{RepoID: repoID},
 		&repo_model.PushMirror{RepoID: repoID},
 		&repo_model.Release{RepoID: repoID},
+		&repo_model.ReleaseBuild{RepoID: repoID},
 		&repo_model.RepoIndexerStatus{RepoID: repoID},
 		&repo_model.Redirect{RedirectRepoID: repoID},
 		&repo_model.RepoUnit{RepoID: repoID},
@@ -480,6 +514,19 @@ func CheckRepoStats(ctx context.Context) error {
 			repoStatsCorrectIssueNumComments,
 			"issue count 'num_comments'",
 		},
+		// Issue.Num{Reactions,ThumbsUp}
+		{
+			statsQuery("SELECT `issue`.id FROM `issue` WHERE `issue`.num_reactions!=(SELECT COUNT(*) FROM `reaction` WHERE issue_id=`issue`.id AND comment_id=0) "+
+				"OR `issue`.num_thumbs_up!=(SELECT COUNT(*) FROM `reaction` WHERE issue_id=`issue`.id AND comment_id=0 AND `type`=?)", issues_model.ReactionThumbsUp),
+			issues_model.UpdateIssueReactionCounts,
+			"issue count 'num_reactions' and 'num_thumbs_up'",
+		},
+		// Issue.NumVotes
+		{
+			statsQuery("SELECT `issue`.id FROM `issue` WHERE `issue`.num_votes!=(SELECT COUNT(*) FROM `issue_vote` WHERE issue_id=`issue`.id)"),
+			issues_model.UpdateIssueVoteCount,
+			"issue count 'num_votes'",
+		},
 	}
 	for _, checker := range checkers {
 		select {
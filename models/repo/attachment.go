@@ -238,3 +238,62 @@ func DeleteOrphanedAttachments() error {
 		Delete(new(Attachment))
 	return err
 }
+
+// orphanedAttachmentsCond matches the attachments linked neither to an existing issue nor to an existing release
+const orphanedAttachmentsCond = "((issue_id = 0 AND release_id = 0) OR " +
+	"(issue_id > 0 AND issue_id NOT IN (SELECT id FROM issue)) OR " +
+	"(release_id > 0 AND release_id NOT IN (SELECT id FROM `release`)))"
+
+// FindOrphanedAttachments returns at most limit attachments created before the given time which are linked
+// neither to an existing issue nor to an existing release, including the uploads never linked to anything
+func FindOrphanedAttachments(ctx context.Context, createdBefore timeutil.TimeStamp, limit int) ([]*Attachment, error) {
+	attachments := make([]*Attachment, 0, limit)
+	return attachments, db.GetEngine(ctx).
+		Where("created_unix < ?", createdBefore).
+		And(orphanedAttachmentsCond).
+		Asc("id").
+		Limit(limit).
+		Find(&attachments)
+}
+
+// FindAttachmentsOfClosedIssues returns at most limit attachments of the issues and pull requests,
+// and of their comments, closed before the given time
+func FindAttachmentsOfClosedIssues(ctx context.Context, closedBefore timeutil.TimeStamp, limit int) ([]*Attachment, error) {
+	attachments := make([]*Attachment, 0, limit)
+	return attachments, db.GetEngine(ctx).
+		Where("issue_id IN (SELECT id FROM issue WHERE is_closed = ? AND closed_unix < ?)", true, closedBefore).
+		Asc("id").
+		Limit(limit).
+		Find(&attachments)
+}
+
+// GetRepoAttachmentsSize returns the total size of the attachments of a repository
+func GetRepoAttachmentsSize(ctx context.Context, repoID int64) (int64, error) {
+	return db.GetEngine(ctx).Where("repo_id = ?", repoID).SumInt(new(Attachment), "size")
+}
+
+// RepoAttachmentsUsage is the storage used by the attachments of a repository
+type RepoAttachmentsUsage struct {
+	RepoID         int64
+	NumAttachments int64
+	Size           int64
+}
+
+// FindRepoAttachmentsUsages returns the storage used by the attachments of every repository having attachments,
+// largest first, and the number of these repositories
+func FindRepoAttachmentsUsages(ctx context.Context, opts db.ListOptions) ([]*RepoAttachmentsUsage, int64, error) {
+	var count int64
+	if _, err := db.GetEngine(ctx).Table("attachment").Select("COUNT(DISTINCT `repo_id`)").Get(&count); err != nil {
+		return nil, 0, err
+	}
+
+	sess := db.GetEngine(ctx).Table("attachment").
+		Select("`repo_id`, COUNT(`id`) AS `num_attachments`, SUM(`size`) AS `size`").
+		GroupBy("`repo_id`").
+		OrderBy("SUM(`size`) DESC, `repo_id`")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	usages := make([]*RepoAttachmentsUsage, 0, opts.PageSize)
+	return usages, count, sess.Find(&usages)
+}
@@ -103,3 +103,24 @@ func TestGetAttachmentsByUUIDs(t *testing.T) {
 	assert.Equal(t, int64(1), attachList[0].IssueID)
 	assert.Equal(t, int64(5), attachList[1].IssueID)
 }
+
+func TestFindRepoAttachmentsUsages(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	_, err := db.GetEngine(db.DefaultContext).In("id", 1, 3).Cols("size").Update(&repo_model.Attachment{Size: 100})
+	assert.NoError(t, err)
+	_, err = db.GetEngine(db.DefaultContext).ID(2).Cols("size").Update(&repo_model.Attachment{Size: 300})
+	assert.NoError(t, err)
+
+	size, err := repo_model.GetRepoAttachmentsSize(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, size)
+
+	usages, count, err := repo_model.FindRepoAttachmentsUsages(db.DefaultContext, db.ListOptions{Page: 1, PageSize: 2})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 5, count)
+	if assert.Len(t, usages, 2) {
+		assert.Equal(t, repo_model.RepoAttachmentsUsage{RepoID: 2, NumAttachments: 2, Size: 300}, *usages[0])
+		assert.Equal(t, repo_model.RepoAttachmentsUsage{RepoID: 1, NumAttachments: 6, Size: 200}, *usages[1])
+	}
+}
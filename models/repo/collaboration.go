@@ -22,6 +22,7 @@ type Collaboration struct {
 	RepoID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
 	UserID      int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
 	Mode        perm.AccessMode    `xorm:"DEFAULT 2 NOT NULL"`
+	RoleID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
 	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
 }
@@ -119,14 +120,15 @@ func ChangeCollaborationAccessModeCtx(ctx context.Context, repo *Repository, uid
 		return nil
 	}
 
-	if collaboration.Mode == mode {
+	if collaboration.Mode == mode && collaboration.RoleID == 0 {
 		return nil
 	}
 	collaboration.Mode = mode
+	collaboration.RoleID = 0
 
 	if _, err = e.
 		ID(collaboration.ID).
-		Cols("mode").
+		Cols("mode", "role_id").
 		Update(collaboration); err != nil {
 		return fmt.Errorf("update collaboration: %v", err)
 	} else if _, err = e.Exec("UPDATE access SET mode = ? WHERE user_id = ? AND repo_id = ?", mode, uid, repo.ID); err != nil {
@@ -0,0 +1,42 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// DefaultsRepoName is the name of the repository of a user or an organization providing the default
+// issue and pull request templates of the other repositories of its owner
+const DefaultsRepoName = ".gitea"
+
+// GetDefaultsRepository returns the repository providing the default templates of a repository: the ".gitea"
+// repository of its owner, or else the instance-wide defaults repository. Only public and non-empty repositories
+// are used, nil is returned when there is none.
+func GetDefaultsRepository(ctx context.Context, repo *Repository) (*Repository, error) {
+	candidates := make([][2]string, 0, 2)
+	candidates = append(candidates, [2]string{repo.OwnerName, DefaultsRepoName})
+	if ownerName, repoName, ok := strings.Cut(setting.Repository.DefaultsRepo, "/"); ok {
+		candidates = append(candidates, [2]string{ownerName, repoName})
+	}
+
+	for _, candidate := range candidates {
+		defaults, err := GetRepositoryByOwnerAndNameCtx(ctx, candidate[0], candidate[1])
+		if err != nil {
+			if IsErrRepoNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if defaults.ID == repo.ID || defaults.IsPrivate || defaults.IsEmpty {
+			continue
+		}
+		return defaults, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDefaultsRepository(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	defer func(defaultsRepo string) { setting.Repository.DefaultsRepo = defaultsRepo }(setting.Repository.DefaultsRepo)
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	repo10 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+
+	setting.Repository.DefaultsRepo = ""
+	defaults, err := repo_model.GetDefaultsRepository(db.DefaultContext, repo10)
+	assert.NoError(t, err)
+	assert.Nil(t, defaults)
+
+	setting.Repository.DefaultsRepo = "user2/repo1"
+	defaults, err = repo_model.GetDefaultsRepository(db.DefaultContext, repo10)
+	assert.NoError(t, err)
+	if assert.NotNil(t, defaults) {
+		assert.EqualValues(t, 1, defaults.ID)
+	}
+
+	// a repository isn't its own defaults repository
+	defaults, err = repo_model.GetDefaultsRepository(db.DefaultContext, repo1)
+	assert.NoError(t, err)
+	assert.Nil(t, defaults)
+
+	// the owner's .gitea repository takes precedence
+	_, err = db.GetEngine(db.DefaultContext).ID(10).Cols("name", "lower_name").
+		Update(&repo_model.Repository{Name: repo_model.DefaultsRepoName, LowerName: repo_model.DefaultsRepoName})
+	assert.NoError(t, err)
+	repo11 := &repo_model.Repository{ID: 11, OwnerName: "user12"}
+	defaults, err = repo_model.GetDefaultsRepository(db.DefaultContext, repo11)
+	assert.NoError(t, err)
+	if assert.NotNil(t, defaults) {
+		assert.EqualValues(t, 10, defaults.ID)
+	}
+
+	// private repositories are ignored
+	setting.Repository.DefaultsRepo = "user2/repo2"
+	defaults, err = repo_model.GetDefaultsRepository(db.DefaultContext, repo1)
+	assert.NoError(t, err)
+	assert.Nil(t, defaults)
+}
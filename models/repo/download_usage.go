@@ -0,0 +1,95 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// DownloadUsage is the number of downloads of the release attachments and of the raw files of a repository and the
+// number of bytes they served
+type DownloadUsage struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"UNIQUE NOT NULL"`
+	Downloads   int64              `xorm:"NOT NULL DEFAULT 0"`
+	Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+func init() {
+	db.RegisterModel(new(DownloadUsage))
+}
+
+func increaseDownloadUsage(ctx context.Context, repoID, size int64) (int64, error) {
+	return db.GetEngine(ctx).Where("repo_id=?", repoID).Incr("downloads").Incr("size", size).Update(new(DownloadUsage))
+}
+
+// AddDownloadUsage records a download of a repository which served size bytes
+func AddDownloadUsage(ctx context.Context, repoID, size int64) error {
+	affected, err := increaseDownloadUsage(ctx, repoID, size)
+	if err != nil || affected > 0 {
+		return err
+	}
+	if err := db.Insert(ctx, &DownloadUsage{RepoID: repoID, Downloads: 1, Size: size}); err == nil {
+		return nil
+	}
+	// a concurrent download of the repository has inserted its usage in the meantime
+	_, err = increaseDownloadUsage(ctx, repoID, size)
+	return err
+}
+
+// GetDownloadUsage returns the download usage of a repository, an empty usage if it has never been downloaded
+func GetDownloadUsage(ctx context.Context, repoID int64) (*DownloadUsage, error) {
+	usage := &DownloadUsage{RepoID: repoID}
+	if _, err := db.GetEngine(ctx).Where("repo_id=?", repoID).Get(usage); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// FindDownloadUsages returns the download usages of the repositories which have been downloaded, the largest first,
+// and the number of these repositories
+func FindDownloadUsages(ctx context.Context, opts db.ListOptions) ([]*DownloadUsage, int64, error) {
+	sess := db.GetEngine(ctx).OrderBy("`size` DESC, `repo_id`")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	usages := make([]*DownloadUsage, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&usages)
+	return usages, count, err
+}
+
+// OwnerDownloadUsage is the download usage of the repositories of an owner
+type OwnerDownloadUsage struct {
+	OwnerID   int64
+	Downloads int64
+	Size      int64
+}
+
+// FindOwnerDownloadUsages returns the download usages of the owners whose repositories have been downloaded, the
+// largest first, and the number of these owners
+func FindOwnerDownloadUsages(ctx context.Context, opts db.ListOptions) ([]*OwnerDownloadUsage, int64, error) {
+	var count int64
+	if _, err := db.GetEngine(ctx).Table("download_usage").
+		Join("INNER", "repository", "`repository`.id = `download_usage`.repo_id").
+		Select("COUNT(DISTINCT `repository`.owner_id)").
+		Get(&count); err != nil {
+		return nil, 0, err
+	}
+
+	sess := db.GetEngine(ctx).Table("download_usage").
+		Join("INNER", "repository", "`repository`.id = `download_usage`.repo_id").
+		Select("`repository`.owner_id AS `owner_id`, SUM(`download_usage`.downloads) AS `downloads`, SUM(`download_usage`.size) AS `size`").
+		GroupBy("`repository`.owner_id").
+		OrderBy("SUM(`download_usage`.size) DESC, `repository`.owner_id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	usages := make([]*OwnerDownloadUsage, 0, opts.PageSize)
+	return usages, count, sess.Find(&usages)
+}
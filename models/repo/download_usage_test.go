@@ -0,0 +1,50 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadUsage(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	usage, err := repo_model.GetDownloadUsage(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, usage.Downloads)
+
+	// user2/repo1 and user2/repo2 belong to user2, user3/repo3 to user3
+	assert.NoError(t, repo_model.AddDownloadUsage(db.DefaultContext, 1, 100))
+	assert.NoError(t, repo_model.AddDownloadUsage(db.DefaultContext, 1, 50))
+	assert.NoError(t, repo_model.AddDownloadUsage(db.DefaultContext, 2, 300))
+	assert.NoError(t, repo_model.AddDownloadUsage(db.DefaultContext, 3, 200))
+
+	usage, err = repo_model.GetDownloadUsage(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, usage.Downloads)
+	assert.EqualValues(t, 150, usage.Size)
+
+	usages, count, err := repo_model.FindDownloadUsages(db.DefaultContext, db.ListOptions{Page: 1, PageSize: 2})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	if assert.Len(t, usages, 2) {
+		assert.EqualValues(t, 2, usages[0].RepoID)
+		assert.EqualValues(t, 3, usages[1].RepoID)
+	}
+
+	ownerUsages, count, err := repo_model.FindOwnerDownloadUsages(db.DefaultContext, db.ListOptions{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	assert.Equal(t, []*repo_model.OwnerDownloadUsage{
+		{OwnerID: 2, Downloads: 3, Size: 450},
+		{OwnerID: 3, Downloads: 1, Size: 200},
+	}, ownerUsages)
+}
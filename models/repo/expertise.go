@@ -0,0 +1,228 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+	"path"
+	"sort"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ExpertiseCommitWeight is how many lines of a path a commit which has changed it weighs in the score of its author
+const ExpertiseCommitWeight = 10
+
+// RepoExpertise is how much an author knows a file or a directory of the default branch of a repository: the lines
+// of it they have last changed according to blame and the commits of its history which have changed it.
+// The root directory has an empty path.
+type RepoExpertise struct { //revive:disable-line:exported
+	ID             int64              `xorm:"pk autoincr"`
+	RepoID         int64              `xorm:"INDEX(s) NOT NULL"`
+	TreePath       string             `xorm:"VARCHAR(500) INDEX(s) NOT NULL"`
+	ParentPath     string             `xorm:"VARCHAR(500) NOT NULL"`
+	IsDir          bool               `xorm:"NOT NULL DEFAULT false"`
+	Email          string             `xorm:"NOT NULL"`
+	Lines          int64              `xorm:"NOT NULL DEFAULT 0"`
+	Commits        int64              `xorm:"NOT NULL DEFAULT 0"`
+	LastCommitUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(RepoExpertise))
+}
+
+// ExpertiseParentPath returns the path of the directory of a path, the root directory is its own parent
+func ExpertiseParentPath(treePath string) string {
+	parent := path.Dir(treePath)
+	if parent == "." || parent == "/" {
+		return ""
+	}
+	return parent
+}
+
+// UpdateRepoExpertise replaces the expertise of a repository with the one computed at a commit of its default branch
+func UpdateRepoExpertise(ctx context.Context, repo *Repository, commitID string, expertise []*RepoExpertise) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("repo_id=?", repo.ID).Delete(new(RepoExpertise)); err != nil {
+			return err
+		}
+		for start := 0; start < len(expertise); start += 100 {
+			end := start + 100
+			if end > len(expertise) {
+				end = len(expertise)
+			}
+			batch := expertise[start:end]
+			for _, e := range batch {
+				e.RepoID = repo.ID
+			}
+			if _, err := db.GetEngine(ctx).Insert(&batch); err != nil {
+				return err
+			}
+		}
+		return UpdateIndexerStatus(ctx, repo, RepoIndexerTypeExpertise, commitID)
+	}, ctx)
+}
+
+// Expert is an author of a repository with their expertise on a path
+type Expert struct {
+	// User is the user the email of the author belongs to, nil if none does
+	User           *user_model.User
+	Email          string
+	Lines          int64
+	Commits        int64
+	LastCommitUnix timeutil.TimeStamp
+}
+
+// Score returns how much the author knows the path, the higher the better
+func (e *Expert) Score() int64 {
+	return e.Lines + ExpertiseCommitWeight*e.Commits
+}
+
+// ExpertList is a list of experts, the best first
+type ExpertList []*Expert
+
+// BusFactor returns the smallest number of experts who have last changed more than half of the lines of the path
+func (experts ExpertList) BusFactor() int {
+	lines := make([]int64, 0, len(experts))
+	var total int64
+	for _, e := range experts {
+		lines = append(lines, e.Lines)
+		total += e.Lines
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i] > lines[j] })
+
+	var covered int64
+	for i, l := range lines {
+		if covered*2 > total {
+			return i
+		}
+		covered += l
+	}
+	if total == 0 {
+		return 0
+	}
+	return len(lines)
+}
+
+// mergeExperts merges the expertise of the emails of the same users and sorts the experts by their score
+func mergeExperts(ctx context.Context, expertise []*RepoExpertise) (ExpertList, error) {
+	users := make(map[string]*user_model.User)
+	byUser := make(map[int64]*Expert)
+	byEmail := make(map[string]*Expert)
+	experts := make(ExpertList, 0, len(expertise))
+	for _, e := range expertise {
+		u, ok := users[e.Email]
+		if !ok && e.Email != "" {
+			var err error
+			if u, err = user_model.GetUserByEmailContext(ctx, e.Email); err != nil {
+				if !user_model.IsErrUserNotExist(err) {
+					return nil, err
+				}
+				u = nil
+			}
+			users[e.Email] = u
+		}
+
+		var expert *Expert
+		if u != nil {
+			expert = byUser[u.ID]
+		} else {
+			expert = byEmail[e.Email]
+		}
+		if expert == nil {
+			expert = &Expert{User: u, Email: e.Email}
+			if u != nil {
+				byUser[u.ID] = expert
+			} else {
+				byEmail[e.Email] = expert
+			}
+			experts = append(experts, expert)
+		}
+		expert.Lines += e.Lines
+		expert.Commits += e.Commits
+		if e.LastCommitUnix > expert.LastCommitUnix {
+			expert.LastCommitUnix = e.LastCommitUnix
+		}
+	}
+
+	sort.SliceStable(experts, func(i, j int) bool {
+		if experts[i].Score() != experts[j].Score() {
+			return experts[i].Score() > experts[j].Score()
+		}
+		return experts[i].LastCommitUnix > experts[j].LastCommitUnix
+	})
+	return experts, nil
+}
+
+// GetExperts returns the experts of a file or a directory of a repository, the best first
+func GetExperts(ctx context.Context, repoID int64, treePath string) (ExpertList, error) {
+	expertise := make([]*RepoExpertise, 0, 10)
+	if err := db.GetEngine(ctx).Where("repo_id=? AND tree_path=?", repoID, treePath).Find(&expertise); err != nil {
+		return nil, err
+	}
+	return mergeExperts(ctx, expertise)
+}
+
+// GetExpertsOfFiles returns the experts of a set of files of a repository, the best first.
+// The expertise of the nearest directory is used for the files which are not indexed, e.g. new ones.
+func GetExpertsOfFiles(ctx context.Context, repoID int64, files []string) (ExpertList, error) {
+	candidates := make(map[string][]string, len(files))
+	paths := make([]string, 0, len(files)*2)
+	seen := make(map[string]bool)
+	for _, file := range files {
+		for p := file; ; p = ExpertiseParentPath(p) {
+			candidates[file] = append(candidates[file], p)
+			if !seen[p] {
+				seen[p] = true
+				paths = append(paths, p)
+			}
+			if p == "" {
+				break
+			}
+		}
+	}
+
+	expertise := make([]*RepoExpertise, 0, 10)
+	for start := 0; start < len(paths); start += 50 {
+		end := start + 50
+		if end > len(paths) {
+			end = len(paths)
+		}
+		if err := db.GetEngine(ctx).Where("repo_id=?", repoID).In("tree_path", paths[start:end]).Find(&expertise); err != nil {
+			return nil, err
+		}
+	}
+	byPath := make(map[string][]*RepoExpertise)
+	for _, e := range expertise {
+		byPath[e.TreePath] = append(byPath[e.TreePath], e)
+	}
+
+	used := make(map[string]bool)
+	selected := make([]*RepoExpertise, 0, len(expertise))
+	for _, file := range files {
+		for _, p := range candidates[file] {
+			if len(byPath[p]) == 0 {
+				continue
+			}
+			if !used[p] {
+				used[p] = true
+				selected = append(selected, byPath[p]...)
+			}
+			break
+		}
+	}
+	return mergeExperts(ctx, selected)
+}
+
+// GetExpertiseSubdirectories returns the indexed direct subdirectories of a directory of a repository
+func GetExpertiseSubdirectories(ctx context.Context, repoID int64, treePath string) ([]string, error) {
+	dirs := make([]string, 0, 10)
+	return dirs, db.GetEngine(ctx).Table("repo_expertise").
+		Where("repo_id=? AND parent_path=? AND is_dir=? AND tree_path<>?", repoID, treePath, true, "").
+		Distinct("tree_path").Asc("tree_path").Find(&dirs)
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExperts(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	experts, err := repo_model.GetExperts(db.DefaultContext, 1, "")
+	assert.NoError(t, err)
+	if assert.Len(t, experts, 3) {
+		assert.EqualValues(t, 2, experts[0].User.ID)
+		assert.EqualValues(t, 30, experts[0].Score())
+		assert.EqualValues(t, 4, experts[1].User.ID)
+		assert.Nil(t, experts[2].User)
+		assert.Equal(t, "unknown@example.com", experts[2].Email)
+	}
+	assert.Equal(t, 2, experts.BusFactor())
+
+	experts, err = repo_model.GetExperts(db.DefaultContext, 1, "does/not/exist")
+	assert.NoError(t, err)
+	assert.Empty(t, experts)
+	assert.Equal(t, 0, experts.BusFactor())
+}
+
+func TestGetExpertsOfFiles(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// a new file of docs falls back to the expertise of its directory
+	experts, err := repo_model.GetExpertsOfFiles(db.DefaultContext, 1, []string{"README.md", "docs/new.md", "docs/guide.md"})
+	assert.NoError(t, err)
+	if assert.Len(t, experts, 3) {
+		assert.EqualValues(t, 4, experts[0].User.ID)
+		assert.EqualValues(t, 12, experts[0].Lines)
+		assert.EqualValues(t, 2, experts[0].Commits)
+		assert.EqualValues(t, 2, experts[1].User.ID)
+	}
+}
+
+func TestGetExpertiseSubdirectories(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	dirs, err := repo_model.GetExpertiseSubdirectories(db.DefaultContext, 1, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs"}, dirs)
+
+	dirs, err = repo_model.GetExpertiseSubdirectories(db.DefaultContext, 1, "docs")
+	assert.NoError(t, err)
+	assert.Empty(t, dirs)
+}
+
+func TestUpdateRepoExpertise(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	assert.NoError(t, repo_model.UpdateRepoExpertise(db.DefaultContext, repo, "65f1bf27bc3bf70f64657658635e66094edbcb4d", []*repo_model.RepoExpertise{
+		{TreePath: "", IsDir: true, Email: "user5@example.com", Lines: 1, Commits: 1},
+		{TreePath: "a.txt", Email: "user5@example.com", Lines: 1, Commits: 1},
+	}))
+	unittest.AssertCount(t, &repo_model.RepoExpertise{RepoID: 1}, 2)
+
+	status, err := repo_model.GetIndexerStatus(db.DefaultContext, repo, repo_model.RepoIndexerTypeExpertise)
+	assert.NoError(t, err)
+	assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", status.CommitSha)
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// ErrExploreCollectionNotExist is returned when an explore collection does not exist
+var ErrExploreCollectionNotExist = errors.New("explore collection does not exist")
+
+// ExploreCollection is a section of the explore pages curated by the admins, it holds the repositories added to it
+// followed by the ones of its topic
+type ExploreCollection struct {
+	ID          int64  `xorm:"pk autoincr"`
+	Name        string `xorm:"NOT NULL"`
+	Description string `xorm:"TEXT"`
+	// Topic fills the collection with the repositories of the topic, ranked by trending score and stars
+	Topic string `xorm:"VARCHAR(50)"`
+	// IsPinned shows the collection on top of the repositories of the explore page
+	IsPinned bool `xorm:"NOT NULL DEFAULT false"`
+	// Position orders the collections, lowest first
+	Position    int                `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// ExploreCollectionRepo is a repository added to a collection
+type ExploreCollectionRepo struct {
+	ID           int64              `xorm:"pk autoincr"`
+	CollectionID int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	RepoID       int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(ExploreCollection))
+	db.RegisterModel(new(ExploreCollectionRepo))
+}
+
+// Link returns the relative url of the collection on the explore pages
+func (c *ExploreCollection) Link() string {
+	return setting.AppSubURL + "/explore/collections/" + strconv.FormatInt(c.ID, 10)
+}
+
+// HTMLURL returns the absolute url of the collection on the explore pages
+func (c *ExploreCollection) HTMLURL() string {
+	return setting.AppURL + "explore/collections/" + strconv.FormatInt(c.ID, 10)
+}
+
+// CreateExploreCollection creates a collection, its topic is lowercased like the topics of the repositories
+func CreateExploreCollection(ctx context.Context, c *ExploreCollection) error {
+	c.Topic = strings.ToLower(strings.TrimSpace(c.Topic))
+	return db.Insert(ctx, c)
+}
+
+// UpdateExploreCollection updates a collection
+func UpdateExploreCollection(ctx context.Context, c *ExploreCollection) error {
+	c.Topic = strings.ToLower(strings.TrimSpace(c.Topic))
+	_, err := db.GetEngine(ctx).ID(c.ID).Cols("name", "description", "topic", "is_pinned", "position").Update(c)
+	return err
+}
+
+// DeleteExploreCollection deletes a collection and the repositories added to it
+func DeleteExploreCollection(ctx context.Context, c *ExploreCollection) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Delete(&ExploreCollectionRepo{CollectionID: c.ID}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(c.ID).Delete(new(ExploreCollection))
+		return err
+	}, ctx)
+}
+
+// GetExploreCollectionByID returns a collection by its id
+func GetExploreCollectionByID(ctx context.Context, id int64) (*ExploreCollection, error) {
+	c := new(ExploreCollection)
+	has, err := db.GetEngine(ctx).ID(id).Get(c)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrExploreCollectionNotExist
+	}
+	return c, nil
+}
+
+// GetExploreCollections returns the collections in their order, only the pinned ones if onlyPinned is set
+func GetExploreCollections(ctx context.Context, onlyPinned bool) ([]*ExploreCollection, error) {
+	collections := make([]*ExploreCollection, 0, 10)
+	sess := db.GetEngine(ctx).Asc("position", "id")
+	if onlyPinned {
+		sess = sess.Where(builder.Eq{"is_pinned": true})
+	}
+	return collections, sess.Find(&collections)
+}
+
+// AddExploreCollectionRepo adds a repository to a collection, it does nothing if it's already in it
+func AddExploreCollectionRepo(ctx context.Context, c *ExploreCollection, repoID int64) error {
+	has, err := db.GetEngine(ctx).Exist(&ExploreCollectionRepo{CollectionID: c.ID, RepoID: repoID})
+	if err != nil || has {
+		return err
+	}
+	return db.Insert(ctx, &ExploreCollectionRepo{CollectionID: c.ID, RepoID: repoID})
+}
+
+// RemoveExploreCollectionRepo removes a repository from a collection
+func RemoveExploreCollectionRepo(ctx context.Context, c *ExploreCollection, repoID int64) error {
+	_, err := db.DeleteByBean(ctx, &ExploreCollectionRepo{CollectionID: c.ID, RepoID: repoID})
+	return err
+}
+
+// GetExploreCollectionAddedRepos returns the repositories added to a collection in the order they have been added,
+// without the ones of its topic
+func GetExploreCollectionAddedRepos(ctx context.Context, c *ExploreCollection) (RepositoryList, error) {
+	repos := make(RepositoryList, 0, 10)
+	return repos, db.GetEngine(ctx).Table("repository").
+		Join("INNER", "explore_collection_repo", "explore_collection_repo.repo_id = `repository`.id").
+		Where(builder.Eq{"explore_collection_repo.collection_id": c.ID}).
+		Asc("explore_collection_repo.id").
+		Select("`repository`.*").
+		Find(&repos)
+}
+
+// exploreCollectionRepoCond returns the repositories added to the collection and, if it has one, the repositories of
+// its topic
+func exploreCollectionRepoCond(c *ExploreCollection) builder.Cond {
+	cond := builder.In("`repository`.id", builder.Select("repo_id").From("explore_collection_repo").
+		Where(builder.Eq{"collection_id": c.ID}))
+	if c.Topic != "" {
+		cond = cond.Or(builder.In("`repository`.id", builder.Select("repo_topic.repo_id").From("repo_topic").
+			Join("INNER", "topic", "topic.id = repo_topic.topic_id").
+			Where(builder.Eq{"topic.name": c.Topic})))
+	}
+	return cond
+}
+
+// GetExploreCollectionRepos returns the repositories of a collection the actor can see, the ones added to it first in
+// the order they have been added, then the ones of its topic by trending score and stars
+func GetExploreCollectionRepos(ctx context.Context, c *ExploreCollection, actor *user_model.User, listOptions db.ListOptions) (RepositoryList, int64, error) {
+	cond := builder.And(exploreCollectionRepoCond(c), AccessibleRepositoryCondition(actor, unit.TypeInvalid))
+	count, err := db.GetEngine(ctx).Table("repository").Where(cond).Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sess := db.GetEngine(ctx).Table("repository").
+		Join("LEFT", "explore_collection_repo", "explore_collection_repo.repo_id = `repository`.id AND explore_collection_repo.collection_id = ?", c.ID).
+		Join("LEFT", "trending_repo", "trending_repo.repo_id = `repository`.id").
+		Where(cond).
+		OrderBy("CASE WHEN explore_collection_repo.id IS NULL THEN 1 ELSE 0 END, explore_collection_repo.id, " +
+			"COALESCE(trending_repo.score, 0) DESC, `repository`.num_stars DESC, `repository`.id")
+	if listOptions.Page > 0 {
+		sess = db.SetSessionPagination(sess, &listOptions)
+	}
+	repos := make(RepositoryList, 0, listOptions.PageSize)
+	return repos, count, sess.Select("`repository`.*").Find(&repos)
+}
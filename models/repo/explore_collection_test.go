@@ -0,0 +1,66 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExploreCollections(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	collections, err := repo_model.GetExploreCollections(db.DefaultContext, false)
+	assert.NoError(t, err)
+	assert.Len(t, collections, 2)
+
+	collections, err = repo_model.GetExploreCollections(db.DefaultContext, true)
+	assert.NoError(t, err)
+	if assert.Len(t, collections, 1) {
+		assert.Equal(t, "Featured", collections[0].Name)
+	}
+}
+
+func TestGetExploreCollectionRepos(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	featured := unittest.AssertExistsAndLoadBean(t, &repo_model.ExploreCollection{ID: 1})
+	repos, count, err := repo_model.GetExploreCollectionRepos(db.DefaultContext, featured, nil, db.ListOptions{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	if assert.Len(t, repos, 2) {
+		// in the order they have been added
+		assert.EqualValues(t, 10, repos[0].ID)
+		assert.EqualValues(t, 1, repos[1].ID)
+	}
+
+	// the repositories of the topic follow the added ones, by trending score
+	golang := unittest.AssertExistsAndLoadBean(t, &repo_model.ExploreCollection{ID: 2})
+	assert.NoError(t, repo_model.AddExploreCollectionRepo(db.DefaultContext, golang, 10))
+	assert.NoError(t, repo_model.AddExploreCollectionRepo(db.DefaultContext, golang, 10))
+	repos, _, err = repo_model.GetExploreCollectionRepos(db.DefaultContext, golang, nil, db.ListOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, repos, 3) {
+		assert.EqualValues(t, 10, repos[0].ID)
+		assert.EqualValues(t, 1, repos[1].ID)
+		assert.EqualValues(t, 33, repos[2].ID)
+	}
+	repos, err = repo_model.GetExploreCollectionAddedRepos(db.DefaultContext, golang)
+	assert.NoError(t, err)
+	if assert.Len(t, repos, 1) {
+		assert.EqualValues(t, 10, repos[0].ID)
+	}
+
+	assert.NoError(t, repo_model.RemoveExploreCollectionRepo(db.DefaultContext, golang, 10))
+	assert.NoError(t, repo_model.DeleteExploreCollection(db.DefaultContext, featured))
+	unittest.AssertNotExistsBean(t, &repo_model.ExploreCollection{ID: 1})
+	unittest.AssertNotExistsBean(t, &repo_model.ExploreCollectionRepo{CollectionID: 1})
+	unittest.AssertNotExistsBean(t, &repo_model.ExploreCollectionRepo{CollectionID: 2})
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
 
 	"xorm.io/builder"
@@ -114,3 +115,72 @@ func GetForksByUserAndOrgs(ctx context.Context, user *user_model.User, repo *Rep
 	repoList = append(repoList, orgForks...)
 	return repoList, nil
 }
+
+// GetForkNetworkRoot returns the repository at the root of the fork network of a repository,
+// which is the repository itself if it isn't a fork or if the repository it was forked from was deleted
+func GetForkNetworkRoot(ctx context.Context, repo *Repository) (*Repository, error) {
+	visited := map[int64]bool{repo.ID: true}
+	for repo.ForkID > 0 && !visited[repo.ForkID] {
+		parent, err := GetRepositoryByIDCtx(ctx, repo.ForkID)
+		if err != nil {
+			if IsErrRepoNotExist(err) {
+				break
+			}
+			return nil, err
+		}
+		visited[parent.ID] = true
+		repo = parent
+	}
+	return repo, nil
+}
+
+// GetForkNetwork returns the forks of the fork network of a root repository the user can access,
+// the forks nearest to the root first
+func GetForkNetwork(ctx context.Context, root *Repository, user *user_model.User) ([]*Repository, error) {
+	forks := make([]*Repository, 0, root.NumForks)
+	visited := map[int64]bool{root.ID: true}
+	parentIDs := []int64{root.ID}
+	for len(parentIDs) > 0 {
+		children := make([]*Repository, 0, len(parentIDs))
+		if err := db.GetEngine(ctx).In("fork_id", parentIDs).Asc("id").Find(&children); err != nil {
+			return nil, err
+		}
+
+		parentIDs = make([]int64, 0, len(children))
+		for _, child := range children {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			forks = append(forks, child)
+			parentIDs = append(parentIDs, child.ID)
+		}
+	}
+	if len(forks) == 0 {
+		return forks, nil
+	}
+
+	// the inaccessible forks are only filtered out now, as their own forks may be accessible
+	forkIDs := make([]int64, 0, len(forks))
+	for _, fork := range forks {
+		forkIDs = append(forkIDs, fork.ID)
+	}
+	accessibleIDs := make([]int64, 0, len(forkIDs))
+	if err := db.GetEngine(ctx).Table("repository").Cols("id").
+		Where(builder.In("id", forkIDs).And(AccessibleRepositoryCondition(user, unit.TypeCode))).
+		Find(&accessibleIDs); err != nil {
+		return nil, err
+	}
+	accessible := make(map[int64]bool, len(accessibleIDs))
+	for _, id := range accessibleIDs {
+		accessible[id] = true
+	}
+
+	accessibleForks := make([]*Repository, 0, len(accessibleIDs))
+	for _, fork := range forks {
+		if accessible[fork.ID] {
+			accessibleForks = append(accessibleForks, fork)
+		}
+	}
+	return accessibleForks, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ForkSync represents the scheduled synchronization of the default branch of a fork with its upstream repository
+type ForkSync struct {
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"UNIQUE NOT NULL"`
+	// DoerID is the user who enabled the synchronization, the merge commits are created and pushed as this user
+	DoerID       int64              `xorm:"NOT NULL"`
+	LastSyncUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	// LastError is the reason of the failure of the last synchronization, empty if it succeeded
+	LastError   string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(ForkSync))
+}
+
+// GetForkSync returns the scheduled synchronization of a fork, nil if it isn't enabled
+func GetForkSync(ctx context.Context, repoID int64) (*ForkSync, error) {
+	s := new(ForkSync)
+	has, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Get(s)
+	if err != nil || !has {
+		return nil, err
+	}
+	return s, nil
+}
+
+// EnableForkSync enables the scheduled synchronization of a fork as the doer, replacing the previous doer if it was enabled
+func EnableForkSync(ctx context.Context, repoID, doerID int64) error {
+	return db.WithTx(func(ctx context.Context) error {
+		s, err := GetForkSync(ctx, repoID)
+		if err != nil {
+			return err
+		}
+		if s != nil {
+			s.DoerID = doerID
+			_, err = db.GetEngine(ctx).ID(s.ID).Cols("doer_id").Update(s)
+			return err
+		}
+		return db.Insert(ctx, &ForkSync{RepoID: repoID, DoerID: doerID})
+	}, ctx)
+}
+
+// DisableForkSync disables the scheduled synchronization of a fork
+func DisableForkSync(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id = ?", repoID).Delete(new(ForkSync))
+	return err
+}
+
+// FindForkSyncs returns all the scheduled synchronizations of forks
+func FindForkSyncs(ctx context.Context) ([]*ForkSync, error) {
+	syncs := make([]*ForkSync, 0, 10)
+	return syncs, db.GetEngine(ctx).Asc("id").Find(&syncs)
+}
+
+// UpdateForkSyncResult records the time and the error, if any, of the last synchronization of a fork
+func UpdateForkSyncResult(ctx context.Context, s *ForkSync) error {
+	_, err := db.GetEngine(ctx).ID(s.ID).Cols("last_sync_unix", "last_error").Update(s)
+	return err
+}
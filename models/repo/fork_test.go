@@ -10,6 +10,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,3 +33,31 @@ func TestGetUserFork(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, repo)
 }
+
+func TestGetForkNetwork(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 29})
+	root, err := repo_model.GetForkNetworkRoot(db.DefaultContext, fork)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 27, root.ID)
+
+	forks, err := repo_model.GetForkNetwork(db.DefaultContext, root, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, forks, 1) {
+		assert.EqualValues(t, 29, forks[0].ID)
+	}
+
+	// the private forks are only listed for the users who can access them
+	root = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 28})
+	forks, err = repo_model.GetForkNetwork(db.DefaultContext, root, nil)
+	assert.NoError(t, err)
+	assert.Len(t, forks, 0)
+
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 20})
+	forks, err = repo_model.GetForkNetwork(db.DefaultContext, root, owner)
+	assert.NoError(t, err)
+	if assert.Len(t, forks, 1) {
+		assert.EqualValues(t, 30, forks[0].ID)
+	}
+}
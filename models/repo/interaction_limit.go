@@ -0,0 +1,133 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// InteractionLimitType restricts who can open issues and pull requests, comment and react
+type InteractionLimitType string
+
+const (
+	// InteractionLimitExistingUsers only lets users whose account is older than a day interact
+	InteractionLimitExistingUsers InteractionLimitType = "existing_users"
+	// InteractionLimitContributorsOnly only lets users who had a pull request merged before and collaborators interact
+	InteractionLimitContributorsOnly InteractionLimitType = "contributors_only"
+	// InteractionLimitCollaboratorsOnly only lets collaborators interact
+	InteractionLimitCollaboratorsOnly InteractionLimitType = "collaborators_only"
+)
+
+// IsValid returns true if the limit type is known
+func (t InteractionLimitType) IsValid() bool {
+	switch t {
+	case InteractionLimitExistingUsers, InteractionLimitContributorsOnly, InteractionLimitCollaboratorsOnly:
+		return true
+	}
+	return false
+}
+
+// InteractionLimitTypes lists the known limit types, from the least to the most restrictive
+var InteractionLimitTypes = []InteractionLimitType{
+	InteractionLimitExistingUsers,
+	InteractionLimitContributorsOnly,
+	InteractionLimitCollaboratorsOnly,
+}
+
+// InteractionLimitDurations are the durations an interaction limit can be set for
+var InteractionLimitDurations = map[string]time.Duration{
+	"one_day":    24 * time.Hour,
+	"three_days": 3 * 24 * time.Hour,
+	"one_week":   7 * 24 * time.Hour,
+	"one_month":  30 * 24 * time.Hour,
+	"six_months": 180 * 24 * time.Hour,
+}
+
+// InteractionLimitDurationNames lists the names of InteractionLimitDurations in increasing order
+var InteractionLimitDurationNames = []string{"one_day", "three_days", "one_week", "one_month", "six_months"}
+
+var (
+	// ErrInvalidInteractionLimit is returned when the limit type is unknown
+	ErrInvalidInteractionLimit = errors.New("invalid interaction limit")
+	// ErrInvalidInteractionLimitDuration is returned when the duration of a limit is unknown
+	ErrInvalidInteractionLimitDuration = errors.New("invalid interaction limit duration")
+)
+
+// InteractionLimit temporarily limits the interactions in a repository or in all repositories of an owner.
+// Exactly one of OwnerID and RepoID is set.
+type InteractionLimit struct {
+	ID          int64                `xorm:"pk autoincr"`
+	OwnerID     int64                `xorm:"UNIQUE(limit) NOT NULL DEFAULT 0"`
+	RepoID      int64                `xorm:"UNIQUE(limit) NOT NULL DEFAULT 0"`
+	Type        InteractionLimitType `xorm:"VARCHAR(32) NOT NULL"`
+	ExpiresUnix timeutil.TimeStamp   `xorm:"INDEX NOT NULL"`
+	CreatedUnix timeutil.TimeStamp   `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(InteractionLimit))
+}
+
+// IsExpired returns true if the limit does not apply anymore
+func (l *InteractionLimit) IsExpired() bool {
+	return l.ExpiresUnix <= timeutil.TimeStampNow()
+}
+
+// GetInteractionLimit returns the active interaction limit of the owner (repoID = 0) or of the repository (ownerID = 0),
+// nil if there is none
+func GetInteractionLimit(ctx context.Context, ownerID, repoID int64) (*InteractionLimit, error) {
+	l := new(InteractionLimit)
+	has, err := db.GetEngine(ctx).
+		Where("owner_id = ? AND repo_id = ? AND expires_unix > ?", ownerID, repoID, timeutil.TimeStampNow()).
+		Get(l)
+	if err != nil || !has {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GetEffectiveInteractionLimit returns the limit applying to the repository: its own one, or the one of its owner
+func GetEffectiveInteractionLimit(ctx context.Context, repo *Repository) (*InteractionLimit, error) {
+	l, err := GetInteractionLimit(ctx, 0, repo.ID)
+	if err != nil || l != nil {
+		return l, err
+	}
+	return GetInteractionLimit(ctx, repo.OwnerID, 0)
+}
+
+// SetInteractionLimit sets the limit of the owner or of the repository for the named duration, replacing the previous one
+func SetInteractionLimit(ctx context.Context, ownerID, repoID int64, limit InteractionLimitType, duration string) (*InteractionLimit, error) {
+	if !limit.IsValid() {
+		return nil, ErrInvalidInteractionLimit
+	}
+	d, ok := InteractionLimitDurations[duration]
+	if !ok {
+		return nil, ErrInvalidInteractionLimitDuration
+	}
+
+	l := &InteractionLimit{
+		OwnerID:     ownerID,
+		RepoID:      repoID,
+		Type:        limit,
+		ExpiresUnix: timeutil.TimeStamp(time.Now().Add(d).Unix()),
+	}
+	return l, db.WithTx(func(ctx context.Context) error {
+		if err := RemoveInteractionLimit(ctx, ownerID, repoID); err != nil {
+			return err
+		}
+		return db.Insert(ctx, l)
+	}, ctx)
+}
+
+// RemoveInteractionLimit removes the limit of the owner or of the repository
+func RemoveInteractionLimit(ctx context.Context, ownerID, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("owner_id = ? AND repo_id = ?", ownerID, repoID).Delete(new(InteractionLimit))
+	return err
+}
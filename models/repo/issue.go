@@ -70,3 +70,12 @@ func (repo *Repository) IsDependenciesEnabledCtx(ctx context.Context) bool {
 	}
 	return u.IssuesConfig().EnableDependencies
 }
+
+// IsServiceDeskEnabled returns whether the users without access to the repository can file issues and follow their own
+func (repo *Repository) IsServiceDeskEnabled(ctx context.Context) bool {
+	u, err := repo.GetUnitCtx(ctx, unit.TypeIssues)
+	if err != nil {
+		return false
+	}
+	return u.IssuesConfig().EnableServiceDesk
+}
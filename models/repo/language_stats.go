@@ -172,6 +172,10 @@ func UpdateLanguageStats(repo *Repository, commitID string, stats map[string]int
 		}
 	}
 
+	if err = saveLanguageStatsHistory(ctx, repo.ID, commitID, stats); err != nil {
+		return err
+	}
+
 	// Update indexer status
 	if err = UpdateIndexerStatus(ctx, repo, RepoIndexerTypeStats, commitID); err != nil {
 		return err
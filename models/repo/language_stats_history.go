@@ -0,0 +1,141 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// LanguageStatsDay is the duration of the period covered by a language statistics snapshot, in seconds
+const LanguageStatsDay = 24 * 60 * 60
+
+// LanguageStatsDayStart returns the start of the UTC day containing the timestamp
+func LanguageStatsDayStart(t timeutil.TimeStamp) timeutil.TimeStamp {
+	return t - t%LanguageStatsDay
+}
+
+// LanguageStatsHistory is the size of a language in a repository on a UTC day, only the last
+// statistics computed on a day are kept. The days without history kept the statistics of the previous one.
+type LanguageStatsHistory struct {
+	ID       int64              `xorm:"pk autoincr"`
+	RepoID   int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Day      timeutil.TimeStamp `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Language string             `xorm:"VARCHAR(50) UNIQUE(s) NOT NULL"`
+	CommitID string
+	Size     int64 `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// LanguageTrend is the size of a language over all the repositories of the instance on a UTC day,
+// it is computed by a cron task
+type LanguageTrend struct {
+	ID       int64              `xorm:"pk autoincr"`
+	Day      timeutil.TimeStamp `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Language string             `xorm:"VARCHAR(50) UNIQUE(s) NOT NULL"`
+	Size     int64              `xorm:"NOT NULL DEFAULT 0"`
+	NumRepos int64              `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(LanguageStatsHistory))
+	db.RegisterModel(new(LanguageTrend))
+}
+
+// LanguageStatsSnapshot holds the sizes of the languages of a repository on a day
+type LanguageStatsSnapshot struct {
+	Day      timeutil.TimeStamp
+	CommitID string
+	Sizes    map[string]int64
+}
+
+// saveLanguageStatsHistory replaces the history of the current day of the repository by the statistics
+func saveLanguageStatsHistory(ctx context.Context, repoID int64, commitID string, stats map[string]int64) error {
+	day := LanguageStatsDayStart(timeutil.TimeStampNow())
+	if _, err := db.GetEngine(ctx).Delete(&LanguageStatsHistory{RepoID: repoID, Day: day}); err != nil {
+		return err
+	}
+	if len(stats) == 0 {
+		return nil
+	}
+
+	history := make([]*LanguageStatsHistory, 0, len(stats))
+	for lang, size := range stats {
+		history = append(history, &LanguageStatsHistory{
+			RepoID:   repoID,
+			Day:      day,
+			Language: lang,
+			CommitID: commitID,
+			Size:     size,
+		})
+	}
+	return db.Insert(ctx, history)
+}
+
+// FindLanguageStatsHistory returns the daily snapshots of the language statistics of the repository
+// for the days starting in [since, before), oldest first
+func FindLanguageStatsHistory(ctx context.Context, repoID int64, since, before timeutil.TimeStamp) ([]*LanguageStatsSnapshot, error) {
+	history := make([]*LanguageStatsHistory, 0, 30)
+	if err := db.GetEngine(ctx).
+		Where("repo_id = ?", repoID).
+		And(builder.Gte{"day": since}.And(builder.Lt{"day": before})).
+		Asc("day").
+		Desc("size").
+		Find(&history); err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*LanguageStatsSnapshot, 0, 30)
+	for _, h := range history {
+		if len(snapshots) == 0 || snapshots[len(snapshots)-1].Day != h.Day {
+			snapshots = append(snapshots, &LanguageStatsSnapshot{
+				Day:      h.Day,
+				CommitID: h.CommitID,
+				Sizes:    make(map[string]int64),
+			})
+		}
+		snapshots[len(snapshots)-1].Sizes[h.Language] = h.Size
+	}
+	return snapshots, nil
+}
+
+// UpdateLanguageTrends computes the language trends of the day from the current language statistics
+// of all the repositories
+func UpdateLanguageTrends(ctx context.Context, day timeutil.TimeStamp) error {
+	trends := make([]*LanguageTrend, 0, 50)
+	if err := db.GetEngine(ctx).Table("language_stat").
+		Select("`language`, SUM(`size`) AS `size`, COUNT(`repo_id`) AS `num_repos`").
+		GroupBy("`language`").
+		Find(&trends); err != nil {
+		return err
+	}
+
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Delete(&LanguageTrend{Day: day}); err != nil {
+			return err
+		}
+		if len(trends) == 0 {
+			return nil
+		}
+		for _, trend := range trends {
+			trend.ID = 0
+			trend.Day = day
+		}
+		return db.Insert(ctx, trends)
+	}, ctx)
+}
+
+// FindLanguageTrends returns the language trends of the days starting in [since, before), oldest first
+func FindLanguageTrends(ctx context.Context, since, before timeutil.TimeStamp) ([]*LanguageTrend, error) {
+	trends := make([]*LanguageTrend, 0, 50)
+	return trends, db.GetEngine(ctx).
+		Where(builder.Gte{"day": since}.And(builder.Lt{"day": before})).
+		Asc("day").
+		Desc("size").
+		Find(&trends)
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguageStatsHistory(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	repo2 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	today := repo_model.LanguageStatsDayStart(timeutil.TimeStampNow())
+
+	// an older snapshot
+	assert.NoError(t, db.Insert(db.DefaultContext, &repo_model.LanguageStatsHistory{
+		RepoID:   repo1.ID,
+		Day:      today - repo_model.LanguageStatsDay,
+		Language: "Go",
+		CommitID: "old",
+		Size:     50,
+	}))
+
+	assert.NoError(t, repo_model.UpdateLanguageStats(repo1, "first", map[string]int64{"Go": 100, "JavaScript": 20}))
+	// the last statistics of the day replace the previous ones
+	assert.NoError(t, repo_model.UpdateLanguageStats(repo1, "second", map[string]int64{"Go": 120, "CSS": 10}))
+	assert.NoError(t, repo_model.UpdateLanguageStats(repo2, "other", map[string]int64{"Go": 30}))
+
+	snapshots, err := repo_model.FindLanguageStatsHistory(db.DefaultContext, repo1.ID, today-repo_model.LanguageStatsDay, today+1)
+	assert.NoError(t, err)
+	if assert.Len(t, snapshots, 2) {
+		assert.Equal(t, "old", snapshots[0].CommitID)
+		assert.Equal(t, map[string]int64{"Go": 50}, snapshots[0].Sizes)
+		assert.Equal(t, today, snapshots[1].Day)
+		assert.Equal(t, "second", snapshots[1].CommitID)
+		assert.Equal(t, map[string]int64{"Go": 120, "CSS": 10}, snapshots[1].Sizes)
+	}
+
+	snapshots, err = repo_model.FindLanguageStatsHistory(db.DefaultContext, repo1.ID, today, today+1)
+	assert.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+
+	assert.NoError(t, repo_model.UpdateLanguageTrends(db.DefaultContext, today))
+	// computing the day again replaces its trends
+	assert.NoError(t, repo_model.UpdateLanguageTrends(db.DefaultContext, today))
+	trends, err := repo_model.FindLanguageTrends(db.DefaultContext, today, today+1)
+	assert.NoError(t, err)
+	if assert.Len(t, trends, 2) {
+		assert.Equal(t, "Go", trends[0].Language)
+		assert.EqualValues(t, 150, trends[0].Size)
+		assert.EqualValues(t, 2, trends[0].NumRepos)
+		assert.Equal(t, "CSS", trends[1].Language)
+		assert.EqualValues(t, 10, trends[1].Size)
+		assert.EqualValues(t, 1, trends[1].NumRepos)
+	}
+}
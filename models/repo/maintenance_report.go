@@ -0,0 +1,192 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+	"path"
+	"sort"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// MaintenanceReportMaxEntries is the maximum number of entries kept in each list of a maintenance report
+const MaintenanceReportMaxEntries = 100
+
+// DependencyManifests are the names of the files declaring the dependencies of a project
+var DependencyManifests = []string{
+	"go.mod", "package.json", "requirements.txt", "Pipfile", "pyproject.toml", "setup.py", "Gemfile",
+	"Cargo.toml", "composer.json", "pom.xml", "build.gradle", "build.gradle.kts", "mix.exs", "pubspec.yaml",
+	"Package.swift",
+}
+
+// MaintenanceReportEntry is a file or a directory listed by a maintenance report
+type MaintenanceReportEntry struct {
+	Path string `json:"path"`
+	// Email is the email of the only author of a file with a single author
+	Email          string             `json:"email,omitempty"`
+	LastCommitUnix timeutil.TimeStamp `json:"last_commit_unix"`
+}
+
+// MaintenanceReport lists the files and directories of the default branch of a repository which need attention: the
+// files with a single author, the directories and the dependency manifests which have not been changed for a long
+// time. It is computed from the expertise index by a cron task.
+type MaintenanceReport struct {
+	ID       int64  `xorm:"pk autoincr"`
+	RepoID   int64  `xorm:"UNIQUE NOT NULL"`
+	CommitID string `xorm:"VARCHAR(40)"`
+	// StaleBeforeUnix is the time before which the stale directories and manifests have last been changed
+	StaleBeforeUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+
+	NumSingleAuthorFiles int64 `xorm:"NOT NULL DEFAULT 0"`
+	NumStaleDirectories  int64 `xorm:"NOT NULL DEFAULT 0"`
+	NumStaleManifests    int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	// the lists hold at most MaintenanceReportMaxEntries entries, in the order of their path
+	SingleAuthorFiles []*MaintenanceReportEntry `xorm:"LONGTEXT JSON"`
+	StaleDirectories  []*MaintenanceReportEntry `xorm:"LONGTEXT JSON"`
+	StaleManifests    []*MaintenanceReportEntry `xorm:"LONGTEXT JSON"`
+
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+
+	Repo *Repository `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(MaintenanceReport))
+}
+
+// expertisePathSummary is the summary of the expertise of the authors on a path
+type expertisePathSummary struct {
+	isDir          bool
+	authors        []string
+	lastCommitUnix timeutil.TimeStamp
+}
+
+// ComputeMaintenanceReport computes the maintenance report of a repository from its expertise index, the directories and
+// the manifests which have not been changed since staleBefore are stale
+func ComputeMaintenanceReport(ctx context.Context, repoID int64, commitID string, staleBefore timeutil.TimeStamp) (*MaintenanceReport, error) {
+	summaries := make(map[string]*expertisePathSummary)
+	paths := make([]string, 0, 100)
+	if err := db.GetEngine(ctx).Where("repo_id=?", repoID).Iterate(new(RepoExpertise), func(_ int, bean interface{}) error {
+		e := bean.(*RepoExpertise)
+		summary := summaries[e.TreePath]
+		if summary == nil {
+			summary = &expertisePathSummary{isDir: e.IsDir}
+			summaries[e.TreePath] = summary
+			paths = append(paths, e.TreePath)
+		}
+		if e.Lines > 0 || e.Commits > 0 {
+			summary.authors = append(summary.authors, e.Email)
+		}
+		if e.LastCommitUnix > summary.lastCommitUnix {
+			summary.lastCommitUnix = e.LastCommitUnix
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	// a directory comes before the paths it contains
+	sort.Strings(paths)
+
+	report := &MaintenanceReport{
+		RepoID:            repoID,
+		CommitID:          commitID,
+		StaleBeforeUnix:   staleBefore,
+		SingleAuthorFiles: make([]*MaintenanceReportEntry, 0, 10),
+		StaleDirectories:  make([]*MaintenanceReportEntry, 0, 10),
+		StaleManifests:    make([]*MaintenanceReportEntry, 0, 10),
+	}
+	add := func(entries *[]*MaintenanceReportEntry, count *int64, entry *MaintenanceReportEntry) {
+		*count++
+		if len(*entries) < MaintenanceReportMaxEntries {
+			*entries = append(*entries, entry)
+		}
+	}
+
+	staleDirs := make(map[string]bool)
+	for _, treePath := range paths {
+		summary := summaries[treePath]
+		stale := summary.lastCommitUnix < staleBefore
+		if summary.isDir {
+			if !stale {
+				continue
+			}
+			staleDirs[treePath] = true
+			// only the topmost stale directories are listed
+			if parent := ExpertiseParentPath(treePath); treePath == "" || !staleDirs[parent] {
+				add(&report.StaleDirectories, &report.NumStaleDirectories, &MaintenanceReportEntry{Path: treePath, LastCommitUnix: summary.lastCommitUnix})
+			}
+			continue
+		}
+
+		if len(summary.authors) == 1 {
+			add(&report.SingleAuthorFiles, &report.NumSingleAuthorFiles, &MaintenanceReportEntry{Path: treePath, Email: summary.authors[0], LastCommitUnix: summary.lastCommitUnix})
+		}
+		if stale && util.IsStringInSlice(path.Base(treePath), DependencyManifests) {
+			add(&report.StaleManifests, &report.NumStaleManifests, &MaintenanceReportEntry{Path: treePath, LastCommitUnix: summary.lastCommitUnix})
+		}
+	}
+	return report, nil
+}
+
+// SaveMaintenanceReport replaces the maintenance report of a repository
+func SaveMaintenanceReport(ctx context.Context, report *MaintenanceReport) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("repo_id=?", report.RepoID).Delete(new(MaintenanceReport)); err != nil {
+			return err
+		}
+		report.ID = 0
+		return db.Insert(ctx, report)
+	}, ctx)
+}
+
+// GetMaintenanceReport returns the maintenance report of a repository, nil if it has none
+func GetMaintenanceReport(ctx context.Context, repoID int64) (*MaintenanceReport, error) {
+	report := &MaintenanceReport{}
+	has, err := db.GetEngine(ctx).Where("repo_id=?", repoID).Get(report)
+	if err != nil || !has {
+		return nil, err
+	}
+	return report, nil
+}
+
+// FindOrgMaintenanceReports returns the maintenance reports of the repositories of an organization whose code the doer
+// can read, in the order of the names of the repositories
+func FindOrgMaintenanceReports(ctx context.Context, orgID int64, doer *user_model.User) ([]*MaintenanceReport, error) {
+	reports := make([]*MaintenanceReport, 0, 10)
+	if err := db.GetEngine(ctx).
+		Join("INNER", "repository", "repository.id = maintenance_report.repo_id").
+		Where(builder.Eq{"`repository`.owner_id": orgID}.And(AccessibleRepositoryCondition(doer, unit.TypeCode))).
+		Asc("repository.lower_name").
+		Find(&reports); err != nil {
+		return nil, err
+	}
+
+	repoIDs := make([]int64, 0, len(reports))
+	for _, report := range reports {
+		repoIDs = append(repoIDs, report.RepoID)
+	}
+	repos := make(map[int64]*Repository, len(repoIDs))
+	if err := db.GetEngine(ctx).In("id", repoIDs).Find(&repos); err != nil {
+		return nil, err
+	}
+	for _, report := range reports {
+		report.Repo = repos[report.RepoID]
+	}
+	return reports, nil
+}
+
+// DeleteMaintenanceReport deletes the maintenance report of a repository
+func DeleteMaintenanceReport(ctx context.Context, repoID int64) error {
+	_, err := db.GetEngine(ctx).Where("repo_id=?", repoID).Delete(new(MaintenanceReport))
+	return err
+}
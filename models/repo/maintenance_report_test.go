@@ -0,0 +1,101 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeMaintenanceReport(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	report, err := repo_model.ComputeMaintenanceReport(db.DefaultContext, 1, "65f1bf27bc3bf70f64657658635e66094edbcb4d", 946684815)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, report.NumSingleAuthorFiles)
+	if assert.Len(t, report.SingleAuthorFiles, 1) {
+		assert.Equal(t, "docs/guide.md", report.SingleAuthorFiles[0].Path)
+		assert.Equal(t, "user4@example.com", report.SingleAuthorFiles[0].Email)
+	}
+	assert.Empty(t, report.StaleDirectories)
+	assert.Empty(t, report.StaleManifests)
+
+	// only the topmost stale directory is listed
+	report, err = repo_model.ComputeMaintenanceReport(db.DefaultContext, 1, "65f1bf27bc3bf70f64657658635e66094edbcb4d", 946684830)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, report.NumStaleDirectories)
+	if assert.Len(t, report.StaleDirectories, 1) {
+		assert.Equal(t, "", report.StaleDirectories[0].Path)
+		assert.EqualValues(t, 946684820, report.StaleDirectories[0].LastCommitUnix)
+	}
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	assert.NoError(t, repo_model.UpdateRepoExpertise(db.DefaultContext, repo, "65f1bf27bc3bf70f64657658635e66094edbcb4d", []*repo_model.RepoExpertise{
+		{TreePath: "", IsDir: true, Email: "user2@example.com", Lines: 4, Commits: 2, LastCommitUnix: 2000},
+		{TreePath: "", IsDir: true, Email: "user5@example.com", Lines: 1, Commits: 1, LastCommitUnix: 1000},
+		{TreePath: "go.mod", Email: "user2@example.com", Lines: 3, Commits: 1, LastCommitUnix: 1000},
+		{TreePath: "go.mod", Email: "user5@example.com", Lines: 1, Commits: 1, LastCommitUnix: 1000},
+		{TreePath: "web/package.json", Email: "user2@example.com", Lines: 1, Commits: 1, LastCommitUnix: 2000},
+		{TreePath: "web", IsDir: true, Email: "user2@example.com", Lines: 1, Commits: 1, LastCommitUnix: 2000},
+	}))
+	report, err = repo_model.ComputeMaintenanceReport(db.DefaultContext, 1, "65f1bf27bc3bf70f64657658635e66094edbcb4d", 1500)
+	assert.NoError(t, err)
+	assert.Empty(t, report.StaleDirectories)
+	if assert.Len(t, report.StaleManifests, 1) {
+		assert.Equal(t, "go.mod", report.StaleManifests[0].Path)
+	}
+	if assert.Len(t, report.SingleAuthorFiles, 1) {
+		assert.Equal(t, "web/package.json", report.SingleAuthorFiles[0].Path)
+	}
+}
+
+func TestSaveMaintenanceReport(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	report, err := repo_model.GetMaintenanceReport(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	if assert.NotNil(t, report) && assert.Len(t, report.SingleAuthorFiles, 1) {
+		assert.Equal(t, "docs/guide.md", report.SingleAuthorFiles[0].Path)
+	}
+
+	report, err = repo_model.GetMaintenanceReport(db.DefaultContext, 2)
+	assert.NoError(t, err)
+	assert.Nil(t, report)
+
+	assert.NoError(t, repo_model.SaveMaintenanceReport(db.DefaultContext, &repo_model.MaintenanceReport{
+		RepoID:              1,
+		NumStaleDirectories: 1,
+		StaleDirectories:    []*repo_model.MaintenanceReportEntry{{Path: "docs"}},
+	}))
+	unittest.AssertCount(t, &repo_model.MaintenanceReport{RepoID: 1}, 1)
+	report, err = repo_model.GetMaintenanceReport(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, report.NumStaleDirectories)
+	assert.Empty(t, report.SingleAuthorFiles)
+}
+
+func TestFindOrgMaintenanceReports(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// repository 3 of organization 3 is private
+	assert.NoError(t, repo_model.SaveMaintenanceReport(db.DefaultContext, &repo_model.MaintenanceReport{RepoID: 3}))
+
+	member := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	reports, err := repo_model.FindOrgMaintenanceReports(db.DefaultContext, 3, member)
+	assert.NoError(t, err)
+	if assert.Len(t, reports, 1) {
+		assert.EqualValues(t, 3, reports[0].Repo.ID)
+	}
+
+	reports, err = repo_model.FindOrgMaintenanceReports(db.DefaultContext, 3, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, reports)
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/gitea/models/db"
+)
+
+// MaxPinnedRepositories is the maximum number of repositories a user or an organization can pin
+const MaxPinnedRepositories = 6
+
+// ErrTooManyPinnedRepositories is returned when more than MaxPinnedRepositories repositories are pinned
+var ErrTooManyPinnedRepositories = errors.New("too many pinned repositories")
+
+// PinnedRepository represents a repository pinned to the profile page of a user or an organization
+type PinnedRepository struct {
+	ID       int64 `xorm:"pk autoincr"`
+	OwnerID  int64 `xorm:"UNIQUE(s) NOT NULL"`
+	RepoID   int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Position int   `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(PinnedRepository))
+}
+
+// GetPinnedRepositories returns the repositories pinned by the user or organization in their order,
+// repositories which have been transferred to another owner since they were pinned are skipped
+func GetPinnedRepositories(ctx context.Context, ownerID int64) (RepositoryList, error) {
+	repos := make(RepositoryList, 0, MaxPinnedRepositories)
+	return repos, db.GetEngine(ctx).
+		Join("INNER", "pinned_repository", "pinned_repository.repo_id = repository.id").
+		Where("pinned_repository.owner_id = ?", ownerID).
+		And("repository.owner_id = pinned_repository.owner_id").
+		Asc("pinned_repository.position").
+		Find(&repos)
+}
+
+// SetPinnedRepositories replaces the repositories pinned by the user or organization, the repositories are shown in the given order
+func SetPinnedRepositories(ctx context.Context, ownerID int64, repoIDs []int64) error {
+	if len(repoIDs) > MaxPinnedRepositories {
+		return ErrTooManyPinnedRepositories
+	}
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.DeleteByBean(ctx, &PinnedRepository{OwnerID: ownerID}); err != nil {
+			return err
+		}
+		pins := make([]*PinnedRepository, 0, len(repoIDs))
+		seen := make(map[int64]bool, len(repoIDs))
+		for _, repoID := range repoIDs {
+			if seen[repoID] {
+				continue
+			}
+			seen[repoID] = true
+			pins = append(pins, &PinnedRepository{OwnerID: ownerID, RepoID: repoID, Position: len(pins)})
+		}
+		if len(pins) == 0 {
+			return nil
+		}
+		return db.Insert(ctx, pins)
+	}, ctx)
+}
@@ -0,0 +1,33 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinnedRepositories(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// repository 3 is not owned by user 2 and is skipped
+	assert.NoError(t, repo_model.SetPinnedRepositories(db.DefaultContext, 2, []int64{2, 3, 1, 2}))
+	repos, err := repo_model.GetPinnedRepositories(db.DefaultContext, 2)
+	assert.NoError(t, err)
+	if assert.Len(t, repos, 2) {
+		assert.EqualValues(t, 2, repos[0].ID)
+		assert.EqualValues(t, 1, repos[1].ID)
+	}
+
+	assert.ErrorIs(t, repo_model.SetPinnedRepositories(db.DefaultContext, 2, []int64{1, 2, 3, 4, 5, 6, 7}), repo_model.ErrTooManyPinnedRepositories)
+
+	assert.NoError(t, repo_model.SetPinnedRepositories(db.DefaultContext, 2, nil))
+	unittest.AssertNotExistsBean(t, &repo_model.PinnedRepository{OwnerID: 2})
+}
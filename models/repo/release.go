@@ -165,6 +165,12 @@ func AddReleaseAttachments(ctx context.Context, releaseID int64, attachmentUUIDs
 	return err
 }
 
+// MoveReleaseAttachments moves the attachments of a release to another release
+func MoveReleaseAttachments(ctx context.Context, fromReleaseID, toReleaseID int64) error {
+	_, err := db.GetEngine(ctx).Where("release_id=?", fromReleaseID).Cols("release_id").Update(&Attachment{ReleaseID: toReleaseID})
+	return err
+}
+
 // GetRelease returns release by given ID.
 func GetRelease(repoID int64, tagName string) (*Release, error) {
 	rel := &Release{RepoID: repoID, LowerTagName: strings.ToLower(tagName)}
@@ -200,6 +206,7 @@ type FindReleasesOptions struct {
 	IsPreRelease  util.OptionalBool
 	IsDraft       util.OptionalBool
 	TagNames      []string
+	Since         int64 // the unix time the releases are created since, all the releases if 0
 }
 
 func (opts *FindReleasesOptions) toConds(repoID int64) builder.Cond {
@@ -221,6 +228,9 @@ func (opts *FindReleasesOptions) toConds(repoID int64) builder.Cond {
 	if !opts.IsDraft.IsNone() {
 		cond = cond.And(builder.Eq{"is_draft": opts.IsDraft.IsTrue()})
 	}
+	if opts.Since > 0 {
+		cond = cond.And(builder.Gte{"created_unix": opts.Since})
+	}
 	return cond
 }
 
@@ -0,0 +1,200 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ErrReleaseBuildNotExist represents a "ReleaseBuildNotExist" kind of error.
+type ErrReleaseBuildNotExist struct {
+	ID int64
+}
+
+// IsErrReleaseBuildNotExist checks if an error is a ErrReleaseBuildNotExist.
+func IsErrReleaseBuildNotExist(err error) bool {
+	_, ok := err.(ErrReleaseBuildNotExist)
+	return ok
+}
+
+func (err ErrReleaseBuildNotExist) Error() string {
+	return fmt.Sprintf("release build does not exist [id: %d]", err.ID)
+}
+
+// ErrReleaseBuildAlreadyClaimed represents a "ReleaseBuildAlreadyClaimed" kind of error.
+type ErrReleaseBuildAlreadyClaimed struct {
+	TagName string
+}
+
+// IsErrReleaseBuildAlreadyClaimed checks if an error is a ErrReleaseBuildAlreadyClaimed.
+func IsErrReleaseBuildAlreadyClaimed(err error) bool {
+	_, ok := err.(ErrReleaseBuildAlreadyClaimed)
+	return ok
+}
+
+func (err ErrReleaseBuildAlreadyClaimed) Error() string {
+	return fmt.Sprintf("release build already claimed [tag_name: %s]", err.TagName)
+}
+
+// ErrReleaseBuildFinished represents a "ReleaseBuildFinished" kind of error.
+type ErrReleaseBuildFinished struct {
+	ID int64
+}
+
+// IsErrReleaseBuildFinished checks if an error is a ErrReleaseBuildFinished.
+func IsErrReleaseBuildFinished(err error) bool {
+	_, ok := err.(ErrReleaseBuildFinished)
+	return ok
+}
+
+func (err ErrReleaseBuildFinished) Error() string {
+	return fmt.Sprintf("release build is finished [id: %d]", err.ID)
+}
+
+// ReleaseBuildStatus represents the state of the build of the assets of a release
+type ReleaseBuildStatus string
+
+// Release build statuses
+const (
+	ReleaseBuildStatusPending ReleaseBuildStatus = "pending"
+	ReleaseBuildStatusRunning ReleaseBuildStatus = "running"
+	ReleaseBuildStatusSuccess ReleaseBuildStatus = "success"
+	ReleaseBuildStatusFailure ReleaseBuildStatus = "failure"
+)
+
+// IsValid returns true if the status is known
+func (s ReleaseBuildStatus) IsValid() bool {
+	switch s {
+	case ReleaseBuildStatusPending, ReleaseBuildStatusRunning, ReleaseBuildStatusSuccess, ReleaseBuildStatusFailure:
+		return true
+	}
+	return false
+}
+
+// IsFinished returns true if the build succeeded or failed
+func (s ReleaseBuildStatus) IsFinished() bool {
+	return s == ReleaseBuildStatusSuccess || s == ReleaseBuildStatusFailure
+}
+
+// ReleaseBuild represents the build of the assets of a release by an external build system which claimed a pushed
+// tag. The release stays a draft until the build succeeds, so that it is published with all its assets at once.
+type ReleaseBuild struct {
+	ID           int64              `xorm:"pk autoincr"`
+	RepoID       int64              `xorm:"UNIQUE(s) NOT NULL"`
+	ReleaseID    int64              `xorm:"INDEX NOT NULL"`
+	Release      *Release           `xorm:"-"`
+	TagName      string             `xorm:"NOT NULL"`
+	LowerTagName string             `xorm:"UNIQUE(s) NOT NULL"`
+	BuilderID    int64              `xorm:"INDEX NOT NULL"`
+	Builder      *user_model.User   `xorm:"-"`
+	Status       ReleaseBuildStatus `xorm:"VARCHAR(20) NOT NULL"`
+	Description  string
+	Log          string `xorm:"LONGTEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ReleaseBuild))
+}
+
+// LoadBuilder loads the user which claimed the build
+func (b *ReleaseBuild) LoadBuilder(ctx context.Context) (err error) {
+	if b.Builder != nil {
+		return nil
+	}
+	b.Builder, err = user_model.GetUserByIDCtx(ctx, b.BuilderID)
+	if user_model.IsErrUserNotExist(err) {
+		b.Builder = user_model.NewGhostUser()
+		return nil
+	}
+	return err
+}
+
+// LoadRelease loads the release of the build
+func (b *ReleaseBuild) LoadRelease(ctx context.Context) (err error) {
+	if b.Release == nil {
+		b.Release, err = GetReleaseByID(ctx, b.ReleaseID)
+	}
+	return err
+}
+
+// GetReleaseBuildByID returns the build of a release of the repository with the given id
+func GetReleaseBuildByID(ctx context.Context, repoID, id int64) (*ReleaseBuild, error) {
+	b := &ReleaseBuild{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(b)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrReleaseBuildNotExist{ID: id}
+	}
+	return b, nil
+}
+
+// GetReleaseBuildByTag returns the build of the release of the repository with the given tag
+func GetReleaseBuildByTag(ctx context.Context, repoID int64, tagName string) (*ReleaseBuild, error) {
+	b := &ReleaseBuild{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND lower_tag_name = ?", repoID, strings.ToLower(tagName)).Get(b)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrReleaseBuildNotExist{}
+	}
+	return b, nil
+}
+
+// FindReleaseBuilds returns the builds of the releases of a repository, the latest first
+func FindReleaseBuilds(ctx context.Context, repoID int64, opts db.ListOptions) ([]*ReleaseBuild, int64, error) {
+	sess := db.GetEngine(ctx).Where("repo_id = ?", repoID).Desc("id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	builds := make([]*ReleaseBuild, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&builds)
+	return builds, count, err
+}
+
+// UpdateReleaseBuildCols updates the given columns of a release build
+func UpdateReleaseBuildCols(ctx context.Context, b *ReleaseBuild, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(b.ID).Cols(cols...).Update(b)
+	return err
+}
+
+// UpdateReleaseBuildStatus updates the status and the description of a release build, it returns
+// ErrReleaseBuildFinished if the build is already finished
+func UpdateReleaseBuildStatus(ctx context.Context, b *ReleaseBuild, status ReleaseBuildStatus, description string) error {
+	n, err := db.GetEngine(ctx).
+		Where("id = ? AND status IN (?, ?)", b.ID, ReleaseBuildStatusPending, ReleaseBuildStatusRunning).
+		Cols("status", "description").
+		Update(&ReleaseBuild{Status: status, Description: description})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		// the row is also left untouched when nothing changed
+		current, err := GetReleaseBuildByID(ctx, b.RepoID, b.ID)
+		if err != nil {
+			return err
+		} else if current.Status.IsFinished() {
+			return ErrReleaseBuildFinished{ID: b.ID}
+		}
+	}
+	b.Status = status
+	b.Description = description
+	return nil
+}
+
+// DeleteReleaseBuildsByReleaseID deletes the builds of a release
+func DeleteReleaseBuildsByReleaseID(ctx context.Context, releaseID int64) error {
+	_, err := db.GetEngine(ctx).Where("release_id = ?", releaseID).Delete(new(ReleaseBuild))
+	return err
+}
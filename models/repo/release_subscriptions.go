@@ -0,0 +1,73 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"xorm.io/builder"
+)
+
+// FindSubscribedReleasesOptions represents the options to find the releases of the repositories
+// a user watches or stars
+type FindSubscribedReleasesOptions struct {
+	db.ListOptions
+	Doer        *user_model.User
+	IncludeTags bool
+}
+
+func (opts *FindSubscribedReleasesOptions) toConds() builder.Cond {
+	subscribed := builder.Or(
+		builder.In("repo_id", builder.Select("repo_id").From("watch").Where(
+			builder.Eq{"user_id": opts.Doer.ID}.And(builder.In("mode", WatchModeNormal, WatchModeAuto)),
+		)),
+		builder.In("repo_id", builder.Select("repo_id").From("star").Where(builder.Eq{"uid": opts.Doer.ID})),
+	)
+
+	cond := builder.NewCond().
+		And(subscribed).
+		And(builder.Eq{"is_draft": false}).
+		// the watches and the stars are kept when the user loses the access to a repository
+		And(builder.In("repo_id", builder.Select("id").From("repository").Where(
+			AccessibleRepositoryCondition(opts.Doer, unit.TypeReleases),
+		)))
+	if !opts.IncludeTags {
+		cond = cond.And(builder.Eq{"is_tag": false})
+	}
+	return cond
+}
+
+// FindSubscribedReleases returns the releases, and the tags if asked, of the repositories the user watches
+// or stars and can read the releases of, newest first, with their attributes loaded
+func FindSubscribedReleases(ctx context.Context, opts FindSubscribedReleasesOptions) ([]*Release, int64, error) {
+	sess := db.GetEngine(ctx).
+		Where(opts.toConds()).
+		Desc("created_unix", "id")
+	if opts.PageSize != 0 {
+		sess = db.SetSessionPagination(sess, &opts.ListOptions)
+	}
+
+	rels := make([]*Release, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&rels)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	repos := make(map[int64]*Repository)
+	for _, rel := range rels {
+		if repo, ok := repos[rel.RepoID]; ok {
+			rel.Repo = repo
+		}
+		if err := rel.loadAttributes(ctx); err != nil {
+			return nil, 0, err
+		}
+		repos[rel.RepoID] = rel.Repo
+	}
+	return rels, count, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindSubscribedReleases(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	releaseIDs := func(rels []*repo_model.Release) []int64 {
+		ids := make([]int64, 0, len(rels))
+		for _, rel := range rels {
+			ids = append(ids, rel.ID)
+		}
+		return ids
+	}
+
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+	assert.NoError(t, db.Insert(db.DefaultContext, &repo_model.Watch{UserID: 5, RepoID: 1, Mode: repo_model.WatchModeAuto}))
+	// the public repository 40 of a private organization isn't readable by the user
+	assert.NoError(t, db.Insert(db.DefaultContext, &repo_model.Watch{UserID: 5, RepoID: 40, Mode: repo_model.WatchModeNormal}))
+
+	rels, count, err := repo_model.FindSubscribedReleases(db.DefaultContext, repo_model.FindSubscribedReleasesOptions{Doer: user5})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	assert.ElementsMatch(t, []int64{1, 5}, releaseIDs(rels))
+	for _, rel := range rels {
+		assert.NotNil(t, rel.Repo)
+		assert.NotNil(t, rel.Publisher)
+	}
+
+	rels, count, err = repo_model.FindSubscribedReleases(db.DefaultContext, repo_model.FindSubscribedReleasesOptions{
+		Doer:        user5,
+		IncludeTags: true,
+		ListOptions: db.ListOptions{Page: 1, PageSize: 2},
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	assert.Len(t, rels, 2)
+
+	// the stars are subscriptions too, and repositories without subscriptions have no releases
+	user8 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 8})
+	rels, _, err = repo_model.FindSubscribedReleases(db.DefaultContext, repo_model.FindSubscribedReleasesOptions{Doer: user8})
+	assert.NoError(t, err)
+	assert.Empty(t, rels)
+	assert.NoError(t, db.Insert(db.DefaultContext, &repo_model.Star{UID: 8, RepoID: 1}))
+	rels, _, err = repo_model.FindSubscribedReleases(db.DefaultContext, repo_model.FindSubscribedReleasesOptions{Doer: user8})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1, 5}, releaseIDs(rels))
+}
@@ -0,0 +1,174 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// RepoReplication is the state of the replication of a repository to the standby instance
+type RepoReplication struct { //revive:disable-line:exported
+	ID     int64 `xorm:"pk autoincr"`
+	RepoID int64 `xorm:"UNIQUE NOT NULL"`
+	// PendingUnix is the time of the oldest change which is not replicated yet, 0 when the standby is in sync
+	PendingUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	// ChangedUnix is the time of the latest change
+	ChangedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	SyncedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	LastError   string             `xorm:"TEXT"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(RepoReplication))
+}
+
+// Lag returns for how long the changes of the repository have not been replicated, 0 when the standby is in sync
+func (r *RepoReplication) Lag(now timeutil.TimeStamp) int64 {
+	if r.PendingUnix == 0 || now < r.PendingUnix {
+		return 0
+	}
+	return int64(now - r.PendingUnix)
+}
+
+func markRepoReplicationChanged(ctx context.Context, repoID int64, now timeutil.TimeStamp) (int64, error) {
+	affected, err := db.GetEngine(ctx).Where("repo_id=?", repoID).Cols("changed_unix").Update(&RepoReplication{ChangedUnix: now})
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+	_, err = db.GetEngine(ctx).Where("repo_id=? AND pending_unix=0", repoID).Cols("pending_unix").Update(&RepoReplication{PendingUnix: now})
+	return affected, err
+}
+
+// MarkRepoReplicationPending records a change of a repository which has to be replicated
+func MarkRepoReplicationPending(ctx context.Context, repoID int64) error {
+	now := timeutil.TimeStampNow()
+	affected, err := markRepoReplicationChanged(ctx, repoID, now)
+	if err != nil || affected > 0 {
+		return err
+	}
+	if err := db.Insert(ctx, &RepoReplication{RepoID: repoID, PendingUnix: now, ChangedUnix: now}); err == nil {
+		return nil
+	}
+	// a concurrent change of the repository has inserted its replication in the meantime
+	_, err = markRepoReplicationChanged(ctx, repoID, now)
+	return err
+}
+
+// MarkRepoReplicationSynced records the end of a replication of a repository which started at startedUnix, the
+// changes done since then stay pending
+func MarkRepoReplicationSynced(ctx context.Context, repoID int64, startedUnix timeutil.TimeStamp, syncErr string) error {
+	exists, err := db.GetEngine(ctx).Where("repo_id=?", repoID).Exist(new(RepoReplication))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if err := db.Insert(ctx, &RepoReplication{RepoID: repoID}); err != nil {
+			return err
+		}
+	}
+
+	if syncErr != "" {
+		_, err = db.GetEngine(ctx).Where("repo_id=?", repoID).Cols("last_error").Update(&RepoReplication{LastError: syncErr})
+		return err
+	}
+	if _, err := db.GetEngine(ctx).Where("repo_id=?", repoID).Cols("synced_unix", "last_error").
+		Update(&RepoReplication{SyncedUnix: startedUnix}); err != nil {
+		return err
+	}
+	_, err = db.GetEngine(ctx).Where("repo_id=? AND changed_unix<=?", repoID, startedUnix).Cols("pending_unix").
+		Update(&RepoReplication{PendingUnix: 0})
+	return err
+}
+
+// GetRepoReplication returns the replication of a repository, an empty one if it has never been replicated
+func GetRepoReplication(ctx context.Context, repoID int64) (*RepoReplication, error) {
+	r := &RepoReplication{RepoID: repoID}
+	if _, err := db.GetEngine(ctx).Where("repo_id=?", repoID).Get(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FindRepoReplicationsOptions represents the options to find the replications of repositories
+type FindRepoReplicationsOptions struct {
+	db.ListOptions
+	OnlyPending bool
+	OnlyFailing bool
+}
+
+func (opts FindRepoReplicationsOptions) toConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OnlyPending {
+		cond = cond.And(builder.Gt{"pending_unix": 0})
+	}
+	if opts.OnlyFailing {
+		cond = cond.And(builder.Neq{"last_error": ""})
+	}
+	return cond
+}
+
+// FindRepoReplications returns the replications of repositories, the most lagging first, and their number
+func FindRepoReplications(ctx context.Context, opts FindRepoReplicationsOptions) ([]*RepoReplication, int64, error) {
+	sess := db.GetEngine(ctx).Where(opts.toConds()).
+		OrderBy("CASE WHEN pending_unix = 0 THEN 1 ELSE 0 END, pending_unix, repo_id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	replications := make([]*RepoReplication, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&replications)
+	return replications, count, err
+}
+
+// ReplicationStats are the numbers of repositories whose replication is pending, failing, done or not done yet,
+// and the time of the oldest change which is not replicated yet
+type ReplicationStats struct {
+	PendingRepos       int64
+	FailingRepos       int64
+	OldestPendingUnix  timeutil.TimeStamp
+	ReplicatedRepos    int64
+	NotReplicatedRepos int64
+}
+
+// Lag returns for how long the oldest change which is not replicated yet is pending, 0 when the standby is in sync
+func (stats *ReplicationStats) Lag(now timeutil.TimeStamp) int64 {
+	if stats.OldestPendingUnix == 0 || now < stats.OldestPendingUnix {
+		return 0
+	}
+	return int64(now - stats.OldestPendingUnix)
+}
+
+// GetReplicationStats returns the statistics of the replication of all the repositories
+func GetReplicationStats(ctx context.Context) (*ReplicationStats, error) {
+	e := db.GetEngine(ctx)
+	stats := &ReplicationStats{}
+	var err error
+	if stats.PendingRepos, err = e.Where("pending_unix>0").Count(new(RepoReplication)); err != nil {
+		return nil, err
+	}
+	if stats.FailingRepos, err = e.Where("last_error<>''").Count(new(RepoReplication)); err != nil {
+		return nil, err
+	}
+	if stats.ReplicatedRepos, err = e.Where("synced_unix>0").Count(new(RepoReplication)); err != nil {
+		return nil, err
+	}
+	repos, err := e.Count(new(Repository))
+	if err != nil {
+		return nil, err
+	}
+	stats.NotReplicatedRepos = repos - stats.ReplicatedRepos
+	if stats.PendingRepos > 0 {
+		if _, err := e.Table("repo_replication").Where("pending_unix>0").
+			Select("MIN(pending_unix)").Get(&stats.OldestPendingUnix); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
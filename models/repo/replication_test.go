@@ -0,0 +1,79 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoReplication(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	r, err := repo_model.GetRepoReplication(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, r.PendingUnix)
+	assert.EqualValues(t, 0, r.Lag(timeutil.TimeStampNow()))
+
+	assert.NoError(t, repo_model.MarkRepoReplicationPending(db.DefaultContext, 1))
+	assert.NoError(t, repo_model.MarkRepoReplicationPending(db.DefaultContext, 2))
+	r, err = repo_model.GetRepoReplication(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.NotZero(t, r.PendingUnix)
+	pendingUnix := r.PendingUnix
+	assert.EqualValues(t, 10, r.Lag(pendingUnix+10))
+
+	// a new change keeps the time of the oldest pending change
+	assert.NoError(t, repo_model.MarkRepoReplicationPending(db.DefaultContext, 1))
+	r, err = repo_model.GetRepoReplication(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, pendingUnix, r.PendingUnix)
+	changedUnix := r.ChangedUnix
+
+	stats, err := repo_model.GetReplicationStats(db.DefaultContext)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, stats.PendingRepos)
+	assert.EqualValues(t, 0, stats.FailingRepos)
+	assert.Equal(t, pendingUnix, stats.OldestPendingUnix)
+
+	// a failed replication keeps the changes pending
+	assert.NoError(t, repo_model.MarkRepoReplicationSynced(db.DefaultContext, 1, changedUnix, "unreachable"))
+	replications, count, err := repo_model.FindRepoReplications(db.DefaultContext, repo_model.FindRepoReplicationsOptions{OnlyFailing: true})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	if assert.Len(t, replications, 1) {
+		assert.EqualValues(t, 1, replications[0].RepoID)
+		assert.Equal(t, pendingUnix, replications[0].PendingUnix)
+		assert.Equal(t, "unreachable", replications[0].LastError)
+	}
+
+	// a replication started before the latest change keeps it pending
+	r, err = repo_model.GetRepoReplication(db.DefaultContext, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, repo_model.MarkRepoReplicationSynced(db.DefaultContext, 2, r.ChangedUnix-1, ""))
+	r, err = repo_model.GetRepoReplication(db.DefaultContext, 2)
+	assert.NoError(t, err)
+	assert.NotZero(t, r.PendingUnix)
+
+	assert.NoError(t, repo_model.MarkRepoReplicationSynced(db.DefaultContext, 1, changedUnix, ""))
+	r, err = repo_model.GetRepoReplication(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, r.PendingUnix)
+	assert.NotZero(t, r.SyncedUnix)
+	assert.Empty(t, r.LastError)
+
+	replications, count, err = repo_model.FindRepoReplications(db.DefaultContext, repo_model.FindRepoReplicationsOptions{OnlyPending: true})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	if assert.Len(t, replications, 1) {
+		assert.EqualValues(t, 2, replications[0].RepoID)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/markup"
 	"code.gitea.io/gitea/modules/setting"
@@ -161,10 +162,20 @@ type Repository struct {
 	StatsIndexerStatus              *RepoIndexerStatus `xorm:"-"`
 	IsFsckEnabled                   bool               `xorm:"NOT NULL DEFAULT true"`
 	CloseIssuesViaCommitInAnyBranch bool               `xorm:"NOT NULL DEFAULT false"`
+	EnablePrivateReporting          bool               `xorm:"NOT NULL DEFAULT false"`
 	Topics                          []string           `xorm:"TEXT JSON"`
 
 	TrustModel TrustModelType
 
+	// PartialCloneFilters restricts the partial clone filters allowed by the instance, empty to allow all of them
+	PartialCloneFilters      string `xorm:"VARCHAR(255)"`
+	PartialCloneTreeMaxDepth int    `xorm:"NOT NULL DEFAULT 0"`
+
+	// EnforceCommitIdentity requires the authors and committers of the pushed commits to be a verified email address
+	// of the pusher, or an address of one of the CommitEmailDomains separated by commas
+	EnforceCommitIdentity bool   `xorm:"NOT NULL DEFAULT false"`
+	CommitEmailDomains    string `xorm:"TEXT"`
+
 	// Avatar: ID(10-20)-md5(32) - must fit into 64 symbols
 	Avatar string `xorm:"VARCHAR(64)"`
 
@@ -219,6 +230,63 @@ func (repo *Repository) IsBroken() bool {
 	return repo.Status == RepositoryBroken
 }
 
+// PartialCloneConfig returns the partial clone filters allowed in the repository by the instance and
+// the repository settings, and the maximum depth of the tree filter, 0 for unlimited
+func (repo *Repository) PartialCloneConfig() ([]string, int) {
+	allowed := InstancePartialCloneFilters()
+	if repo.PartialCloneFilters != "" {
+		allowed = filterPartialCloneFilters(allowed, strings.Split(repo.PartialCloneFilters, ","))
+	}
+
+	treeMaxDepth := setting.Git.PartialCloneTreeMaxDepth
+	if repo.PartialCloneTreeMaxDepth > 0 && (treeMaxDepth <= 0 || repo.PartialCloneTreeMaxDepth < treeMaxDepth) {
+		treeMaxDepth = repo.PartialCloneTreeMaxDepth
+	}
+	return allowed, treeMaxDepth
+}
+
+// InstancePartialCloneFilters returns the partial clone filters allowed by the instance
+func InstancePartialCloneFilters() []string {
+	if len(setting.Git.PartialCloneFilters) == 0 {
+		return git.PartialCloneFilters
+	}
+	return filterPartialCloneFilters(git.PartialCloneFilters, setting.Git.PartialCloneFilters)
+}
+
+func filterPartialCloneFilters(filters, restriction []string) []string {
+	allowed := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		if util.IsStringInSlice(filter, restriction, true) {
+			allowed = append(allowed, filter)
+		}
+	}
+	return allowed
+}
+
+// GetCommitEmailDomains returns the email domains allowed for the authors and committers of the pushed commits
+func (repo *Repository) GetCommitEmailDomains() []string {
+	domains := make([]string, 0, 2)
+	for _, domain := range strings.Split(repo.CommitEmailDomains, ",") {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// IsCommitEmailAllowed returns whether an author or committer email is allowed by the commit identity policy,
+// given the verified email addresses of the pusher
+func (repo *Repository) IsCommitEmailAllowed(email string, verifiedEmails []string) bool {
+	if util.IsStringInSlice(email, verifiedEmails, true) {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	return util.IsStringInSlice(strings.ToLower(email[at+1:]), repo.GetCommitEmailDomains())
+}
+
 // AfterLoad is invoked from XORM after setting the values of all fields of this object.
 func (repo *Repository) AfterLoad() {
 	// FIXME: use models migration to solve all at once.
@@ -364,6 +432,11 @@ func (repo *Repository) MustGetUnit(tp unit.Type) *RepoUnit {
 			Type:   tp,
 			Config: new(IssuesConfig),
 		}
+	} else if tp == unit.TypeReleases {
+		return &RepoUnit{
+			Type:   tp,
+			Config: new(ReleasesConfig),
+		}
 	}
 	return &RepoUnit{
 		Type:   tp,
@@ -21,6 +21,8 @@ const (
 	RepoIndexerTypeCode RepoIndexerType = iota // 0
 	// RepoIndexerTypeStats repository stats indexer
 	RepoIndexerTypeStats // 1
+	// RepoIndexerTypeExpertise repository expertise indexer
+	RepoIndexerTypeExpertise // 2
 )
 
 // RepoIndexerStatus status of a repo's entry in the repo indexer
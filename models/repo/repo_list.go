@@ -305,18 +305,30 @@ func UserOrgUnitRepoCond(idStr string, userID, orgID int64, unitType unit.Type)
 	)
 }
 
-// userOrgPublicRepoCond returns the condition that one user could access all public repositories in organizations
+// userOrgPublicRepoCond returns the condition that one user could access all public repositories in organizations,
+// the organizations the user is a guest of are left out as guests only access the repositories they are granted.
 func userOrgPublicRepoCond(userID int64) builder.Cond {
 	return builder.And(
 		builder.Eq{"`repository`.is_private": false},
 		builder.In("`repository`.owner_id",
 			builder.Select("`org_user`.org_id").
 				From("org_user").
-				Where(builder.Eq{"`org_user`.uid": userID}),
+				Where(builder.Eq{"`org_user`.uid": userID, "`org_user`.is_guest": false}),
 		),
 	)
 }
 
+// userGuestOrgCond returns the condition selecting the ids of the non public organizations the user is a guest of
+func userGuestOrgCond(userID int64) *builder.Builder {
+	return builder.Select("`org_user`.org_id").
+		From("org_user").
+		Join("INNER", "`user`", "`user`.id = `org_user`.org_id").
+		Where(builder.And(
+			builder.Eq{"`org_user`.uid": userID, "`org_user`.is_guest": true},
+			builder.Neq{"`user`.visibility": structs.VisibleTypePublic},
+		))
+}
+
 // userOrgPublicRepoCondPrivate returns the condition that one user could access all public repositories in private organizations
 func userOrgPublicRepoCondPrivate(userID int64) builder.Cond {
 	return builder.And(
@@ -326,9 +338,10 @@ func userOrgPublicRepoCondPrivate(userID int64) builder.Cond {
 				From("org_user").
 				Join("INNER", "`user`", "`user`.id = `org_user`.org_id").
 				Where(builder.Eq{
-					"`org_user`.uid":    userID,
-					"`user`.`type`":     user_model.UserTypeOrganization,
-					"`user`.visibility": structs.VisibleTypePrivate,
+					"`org_user`.uid":      userID,
+					"`org_user`.is_guest": false,
+					"`user`.`type`":       user_model.UserTypeOrganization,
+					"`user`.visibility":   structs.VisibleTypePrivate,
 				}),
 		),
 	)
@@ -603,14 +616,19 @@ func AccessibleRepositoryCondition(user *user_model.User, unitType unit.Type) bu
 			orgVisibilityLimit = append(orgVisibilityLimit, structs.VisibleTypeLimited)
 		}
 		// 1. Be able to see all non-private repositories that either:
-		cond = cond.Or(builder.And(
+		publicCond := builder.And(
 			builder.Eq{"`repository`.is_private": false},
 			// 2. Aren't in an private organisation or limited organisation if we're not logged in
 			builder.NotIn("`repository`.owner_id", builder.Select("id").From("`user`").Where(
 				builder.And(
 					builder.Eq{"type": user_model.UserTypeOrganization},
 					builder.In("visibility", orgVisibilityLimit)),
-			))))
+			)))
+		if user != nil && user.ID > 0 {
+			// 3. Aren't in a limited organisation we are a guest of
+			publicCond = publicCond.And(builder.NotIn("`repository`.owner_id", userGuestOrgCond(user.ID)))
+		}
+		cond = cond.Or(publicCond)
 	}
 
 	if user != nil {
@@ -620,6 +638,8 @@ func AccessibleRepositoryCondition(user *user_model.User, unitType unit.Type) bu
 			// Regardless of UnitType
 			cond = cond.Or(
 				UserAccessRepoCond("`repository`.id", user.ID),
+				// read access to public repositories isn't recorded, which matters for guests of organizations
+				UserCollaborationRepoCond("`repository`.id", user.ID),
 				UserOrgTeamRepoCond("`repository`.id", user.ID),
 			)
 		} else {
@@ -681,13 +701,18 @@ func AccessibleRepoIDsQuery(user *user_model.User) *builder.Builder {
 
 // FindUserCodeAccessibleRepoIDs finds all at Code level accessible repositories' ID by the user's id
 func FindUserCodeAccessibleRepoIDs(user *user_model.User) ([]int64, error) {
+	return FindUserAccessibleRepoIDs(user, unit.TypeCode)
+}
+
+// FindUserAccessibleRepoIDs finds the IDs of the repositories whose unit is accessible by the user
+func FindUserAccessibleRepoIDs(user *user_model.User, unitType unit.Type) ([]int64, error) {
 	repoIDs := make([]int64, 0, 10)
 	if err := db.GetEngine(db.DefaultContext).
 		Table("repository").
 		Cols("id").
-		Where(AccessibleRepositoryCondition(user, unit.TypeCode)).
+		Where(AccessibleRepositoryCondition(user, unitType)).
 		Find(&repoIDs); err != nil {
-		return nil, fmt.Errorf("FindUserCodeAccesibleRepoIDs: %v", err)
+		return nil, fmt.Errorf("FindUserAccessibleRepoIDs: %v", err)
 	}
 	return repoIDs, nil
 }
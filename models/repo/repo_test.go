@@ -12,7 +12,9 @@ import (
 	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
 
 	"github.com/stretchr/testify/assert"
@@ -125,3 +127,45 @@ func TestMetas(t *testing.T) {
 	assert.Equal(t, "user3", metas["org"])
 	assert.Equal(t, ",owners,team1,", metas["teams"])
 }
+
+func TestPartialCloneConfig(t *testing.T) {
+	defer func(filters []string, treeMaxDepth int) {
+		setting.Git.PartialCloneFilters = filters
+		setting.Git.PartialCloneTreeMaxDepth = treeMaxDepth
+	}(setting.Git.PartialCloneFilters, setting.Git.PartialCloneTreeMaxDepth)
+
+	repo := &repo_model.Repository{}
+	filters, treeMaxDepth := repo.PartialCloneConfig()
+	assert.Equal(t, git.PartialCloneFilters, filters)
+	assert.Equal(t, 0, treeMaxDepth)
+
+	setting.Git.PartialCloneFilters = []string{"tree", "blob:none", "sparse:path"}
+	setting.Git.PartialCloneTreeMaxDepth = 3
+	assert.Equal(t, []string{"blob:none", "tree"}, repo_model.InstancePartialCloneFilters())
+
+	repo.PartialCloneFilters = "tree,object:type"
+	repo.PartialCloneTreeMaxDepth = 1
+	filters, treeMaxDepth = repo.PartialCloneConfig()
+	assert.Equal(t, []string{"tree"}, filters)
+	assert.Equal(t, 1, treeMaxDepth)
+
+	// the repository can't lift the restrictions of the instance
+	repo.PartialCloneFilters = "none"
+	repo.PartialCloneTreeMaxDepth = 5
+	filters, treeMaxDepth = repo.PartialCloneConfig()
+	assert.Empty(t, filters)
+	assert.Equal(t, 3, treeMaxDepth)
+}
+
+func TestIsCommitEmailAllowed(t *testing.T) {
+	repo := &repo_model.Repository{CommitEmailDomains: " Example.com, ,corp.example.org"}
+	assert.Equal(t, []string{"example.com", "corp.example.org"}, repo.GetCommitEmailDomains())
+
+	verified := []string{"User2@example.net"}
+	assert.True(t, repo.IsCommitEmailAllowed("user2@EXAMPLE.net", verified))
+	assert.True(t, repo.IsCommitEmailAllowed("someone@EXAMPLE.com", verified))
+	assert.True(t, repo.IsCommitEmailAllowed("someone@corp.example.org", verified))
+	assert.False(t, repo.IsCommitEmailAllowed("someone@example.net", verified))
+	assert.False(t, repo.IsCommitEmailAllowed("someone@sub.example.com", verified))
+	assert.False(t, repo.IsCommitEmailAllowed("example.com", verified))
+}
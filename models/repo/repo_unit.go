@@ -7,6 +7,7 @@ package repo
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/unit"
@@ -97,6 +98,10 @@ type IssuesConfig struct {
 	EnableTimetracker                bool
 	AllowOnlyContributorsToTrackTime bool
 	EnableDependencies               bool
+	StalePolicy
+	// EnableServiceDesk lets the signed in users without access to the repository file issues through the service
+	// desk, where they only see their own issues
+	EnableServiceDesk bool
 }
 
 // FromDB fills up a IssuesConfig from serialized format.
@@ -109,6 +114,80 @@ func (cfg *IssuesConfig) ToDB() ([]byte, error) {
 	return json.Marshal(cfg)
 }
 
+// StalePolicy is the policy of a repository marking and closing the issues without activity, its fields are stored
+// with the other fields of the IssuesConfig
+type StalePolicy struct {
+	// StaleDays is the number of days without activity after which an open issue is marked as stale, 0 disables the stale policy
+	StaleDays int
+	// StaleCloseDays is the number of days a stale issue stays without activity before being closed, 0 never closes them
+	StaleCloseDays int
+	// StaleLabel is the name of the label marking the stale issues, it is created when missing
+	StaleLabel string
+	// StaleExemptLabels is the comma separated list of the labels exempting issues from the stale policy
+	StaleExemptLabels string
+	// StaleExemptMilestones exempts the issues assigned to a milestone from the stale policy
+	StaleExemptMilestones bool
+	// StaleIncludePulls applies the stale policy to pull requests as well
+	StaleIncludePulls bool
+}
+
+const (
+	// DefaultStaleLabel is the name of the label marking the stale issues when none is configured
+	DefaultStaleLabel = "stale"
+	// MaxStaleDays is the maximum number of days of the stale policy before marking or closing an issue
+	MaxStaleDays = 3650
+	// MaxStaleLabelLength is the maximum length of the name of the stale label
+	MaxStaleLabelLength = 50
+)
+
+// ErrInvalidStalePolicy represents an invalid stale policy
+type ErrInvalidStalePolicy struct {
+	Reason string
+}
+
+// IsErrInvalidStalePolicy checks if an error is a ErrInvalidStalePolicy.
+func IsErrInvalidStalePolicy(err error) bool {
+	_, ok := err.(ErrInvalidStalePolicy)
+	return ok
+}
+
+func (err ErrInvalidStalePolicy) Error() string {
+	return fmt.Sprintf("invalid stale policy: %s", err.Reason)
+}
+
+// Validate checks the stale policy before it is stored and normalizes its label names, every way of setting it must
+// call it
+func (p *StalePolicy) Validate() error {
+	if p.StaleDays < 0 || p.StaleDays > MaxStaleDays || p.StaleCloseDays < 0 || p.StaleCloseDays > MaxStaleDays {
+		return ErrInvalidStalePolicy{Reason: fmt.Sprintf("stale days must be between 0 and %d", MaxStaleDays)}
+	}
+	p.StaleLabel = strings.TrimSpace(p.StaleLabel)
+	if len(p.StaleLabel) > MaxStaleLabelLength {
+		return ErrInvalidStalePolicy{Reason: fmt.Sprintf("stale label must not be longer than %d characters", MaxStaleLabelLength)}
+	}
+	p.StaleExemptLabels = strings.Join(p.GetStaleExemptLabels(), ",")
+	return nil
+}
+
+// GetStaleLabel returns the name of the label marking the stale issues
+func (p *StalePolicy) GetStaleLabel() string {
+	if p.StaleLabel == "" {
+		return DefaultStaleLabel
+	}
+	return p.StaleLabel
+}
+
+// GetStaleExemptLabels returns the names of the labels exempting issues from the stale policy
+func (p *StalePolicy) GetStaleExemptLabels() []string {
+	var names []string
+	for _, name := range strings.Split(p.StaleExemptLabels, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // PullRequestsConfig describes pull requests config
 type PullRequestsConfig struct {
 	IgnoreWhitespaceConflicts     bool
@@ -121,6 +200,18 @@ type PullRequestsConfig struct {
 	AllowRebaseUpdate             bool
 	DefaultDeleteBranchAfterMerge bool
 	DefaultMergeStyle             MergeStyle
+	// ReviewReminderDays is the number of days after which requested reviewers are reminded, 0 disables reminders
+	ReviewReminderDays int
+	// ReviewEscalationDays is the number of days after which a pending review request is escalated, 0 disables escalation
+	ReviewEscalationDays int
+	// ApprovalExpiryDays is the number of days after which approvals are dismissed automatically, 0 keeps them forever
+	ApprovalExpiryDays int
+	// DefaultMergeMessageTemplate is the template of the message of the merge commits, used by the merge and rebase-merge styles
+	DefaultMergeMessageTemplate string
+	// DefaultSquashMessageTemplate is the template of the message of the squash commits
+	DefaultSquashMessageTemplate string
+	// EnforceMergeMessageTemplate prevents the title of the commit message generated by a template from being changed when merging
+	EnforceMergeMessageTemplate bool
 }
 
 // FromDB fills up a PullRequestsConfig from serialized format.
@@ -157,6 +248,60 @@ func (cfg *PullRequestsConfig) GetDefaultMergeStyle() MergeStyle {
 	return MergeStyleMerge
 }
 
+// GetMergeMessageTemplate returns the merge message template of the given merge style, if any
+func (cfg *PullRequestsConfig) GetMergeMessageTemplate(mergeStyle MergeStyle) string {
+	switch mergeStyle {
+	case MergeStyleMerge, MergeStyleRebaseMerge:
+		return cfg.DefaultMergeMessageTemplate
+	case MergeStyleSquash:
+		return cfg.DefaultSquashMessageTemplate
+	}
+	return ""
+}
+
+// HasReviewPolicy returns if any of the review reminder policies is enabled
+func (cfg *PullRequestsConfig) HasReviewPolicy() bool {
+	return cfg.ReviewReminderDays > 0 || cfg.ReviewEscalationDays > 0 || cfg.ApprovalExpiryDays > 0
+}
+
+// DefaultReleaseDraftTagName is the tag name of the drafted release when none is configured
+const DefaultReleaseDraftTagName = "unreleased"
+
+// ReleasesConfig describes releases config
+type ReleasesConfig struct {
+	// EnableReleaseDrafter drafts a release with the changelog of the pull requests merged since the latest release
+	EnableReleaseDrafter bool
+	// ReleaseDraftTagName is the tag name of the drafted release until it is published
+	ReleaseDraftTagName string
+	// ReleaseDraftTemplate is the note of the drafted release, $CHANGES is replaced by the changelog,
+	// $CONTRIBUTORS by the authors of the pull requests and $PREVIOUS_TAG by the tag of the latest release
+	ReleaseDraftTemplate string
+	// ChangelogCategories groups the pull requests of the changelog by label, one category per line
+	// written as "Title: label1, label2"
+	ChangelogCategories string
+	// RequireAttestations requires an attestation of the tag or the commit of a release to publish it, and an
+	// attestation of the digest of a container image linked to the repository to tag it
+	RequireAttestations bool
+}
+
+// FromDB fills up a ReleasesConfig from serialized format.
+func (cfg *ReleasesConfig) FromDB(bs []byte) error {
+	return json.UnmarshalHandleDoubleEncode(bs, &cfg)
+}
+
+// ToDB exports a ReleasesConfig to a serialized format.
+func (cfg *ReleasesConfig) ToDB() ([]byte, error) {
+	return json.Marshal(cfg)
+}
+
+// GetReleaseDraftTagName returns the tag name of the drafted release
+func (cfg *ReleasesConfig) GetReleaseDraftTagName() string {
+	if cfg.ReleaseDraftTagName == "" {
+		return DefaultReleaseDraftTagName
+	}
+	return cfg.ReleaseDraftTagName
+}
+
 // BeforeSet is invoked from XORM before setting the value of a field of this object.
 func (r *RepoUnit) BeforeSet(colName string, val xorm.Cell) {
 	switch colName {
@@ -170,7 +315,9 @@ func (r *RepoUnit) BeforeSet(colName string, val xorm.Cell) {
 			r.Config = new(PullRequestsConfig)
 		case unit.TypeIssues:
 			r.Config = new(IssuesConfig)
-		case unit.TypeCode, unit.TypeReleases, unit.TypeWiki, unit.TypeProjects, unit.TypePackages:
+		case unit.TypeReleases:
+			r.Config = new(ReleasesConfig)
+		case unit.TypeCode, unit.TypeWiki, unit.TypeProjects, unit.TypePackages:
 			fallthrough
 		default:
 			r.Config = new(UnitConfig)
@@ -194,8 +341,8 @@ func (r *RepoUnit) PullRequestsConfig() *PullRequestsConfig {
 }
 
 // ReleasesConfig returns config for unit.TypeReleases
-func (r *RepoUnit) ReleasesConfig() *UnitConfig {
-	return r.Config.(*UnitConfig)
+func (r *RepoUnit) ReleasesConfig() *ReleasesConfig {
+	return r.Config.(*ReleasesConfig)
 }
 
 // ExternalWikiConfig returns config for unit.TypeExternalWiki
@@ -228,6 +375,12 @@ func getUnitsByRepoID(ctx context.Context, repoID int64) (units []*RepoUnit, err
 	return units, nil
 }
 
+// FindRepoUnitsByType returns the units of the given type of all the repositories
+func FindRepoUnitsByType(ctx context.Context, unitType unit.Type) ([]*RepoUnit, error) {
+	units := make([]*RepoUnit, 0, 10)
+	return units, db.GetEngine(ctx).Where("`type` = ?", unitType).Find(&units)
+}
+
 // UpdateRepoUnit updates the provided repo unit
 func UpdateRepoUnit(unit *RepoUnit) error {
 	_, err := db.GetEngine(db.DefaultContext).ID(unit.ID).Update(unit)
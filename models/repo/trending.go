@@ -0,0 +1,142 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// TrendingRepo is the trending score of a repository computed by a cron task from its stars, forks and activity
+// during the last days, only the repositories with a score are kept
+type TrendingRepo struct {
+	RepoID      int64              `xorm:"pk"`
+	Score       float64            `xorm:"INDEX NOT NULL DEFAULT 0"`
+	NumStars    int64              `xorm:"NOT NULL DEFAULT 0"`
+	NumForks    int64              `xorm:"NOT NULL DEFAULT 0"`
+	NumActions  int64              `xorm:"NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(TrendingRepo))
+}
+
+// TrendingOptions configures the computation of the trending scores
+type TrendingOptions struct {
+	// WindowDays is the number of past days whose stars, forks and activity are counted
+	WindowDays     int64
+	StarWeight     float64
+	ForkWeight     float64
+	ActivityWeight float64
+}
+
+type trendingCount struct {
+	RepoID int64
+	Num    int64
+}
+
+func countTrending(ctx context.Context, table, repoCol string, cond builder.Cond) ([]*trendingCount, error) {
+	counts := make([]*trendingCount, 0, 50)
+	return counts, db.GetEngine(ctx).Table(table).
+		Select("`" + repoCol + "` AS repo_id, COUNT(*) AS num").
+		Where(cond).
+		GroupBy("`" + repoCol + "`").
+		Find(&counts)
+}
+
+// UpdateTrendingRepos replaces the trending scores of the repositories by the ones of the window ending now,
+// an action is only counted once even though it's copied to the feeds of the watchers
+func UpdateTrendingRepos(ctx context.Context, opts *TrendingOptions) error {
+	since := timeutil.TimeStampNow().Add(-opts.WindowDays * 24 * 60 * 60)
+
+	trending := make(map[int64]*TrendingRepo)
+	get := func(repoID int64) *TrendingRepo {
+		t, ok := trending[repoID]
+		if !ok {
+			t = &TrendingRepo{RepoID: repoID}
+			trending[repoID] = t
+		}
+		return t
+	}
+
+	stars, err := countTrending(ctx, "star", "repo_id", builder.Gte{"created_unix": since})
+	if err != nil {
+		return err
+	}
+	for _, c := range stars {
+		get(c.RepoID).NumStars = c.Num
+	}
+
+	forks, err := countTrending(ctx, "repository", "fork_id", builder.Eq{"is_fork": true}.And(builder.Gte{"created_unix": since}))
+	if err != nil {
+		return err
+	}
+	for _, c := range forks {
+		get(c.RepoID).NumForks = c.Num
+	}
+
+	actions, err := countTrending(ctx, "action", "repo_id", builder.Gte{"created_unix": since}.
+		And(builder.Expr("user_id = act_user_id")).
+		And(builder.Eq{"is_deleted": false}))
+	if err != nil {
+		return err
+	}
+	for _, c := range actions {
+		get(c.RepoID).NumActions = c.Num
+	}
+
+	repos := make([]*TrendingRepo, 0, len(trending))
+	for _, t := range trending {
+		t.Score = opts.StarWeight*float64(t.NumStars) + opts.ForkWeight*float64(t.NumForks) + opts.ActivityWeight*float64(t.NumActions)
+		if t.RepoID > 0 && t.Score > 0 {
+			repos = append(repos, t)
+		}
+	}
+
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where(builder.Expr("1 = 1")).Delete(new(TrendingRepo)); err != nil {
+			return err
+		}
+		for i := 0; i < len(repos); i += db.DefaultMaxInSize {
+			end := i + db.DefaultMaxInSize
+			if end > len(repos) {
+				end = len(repos)
+			}
+			if err := db.Insert(ctx, repos[i:end]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, ctx)
+}
+
+// FindTrendingRepos returns the trending repositories the actor can see, best score first
+func FindTrendingRepos(ctx context.Context, actor *user_model.User, listOptions db.ListOptions) (RepositoryList, int64, error) {
+	cond := AccessibleRepositoryCondition(actor, unit.TypeInvalid)
+	count, err := db.GetEngine(ctx).Table("repository").
+		Join("INNER", "trending_repo", "trending_repo.repo_id = `repository`.id").
+		Where(cond).
+		Count()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sess := db.GetEngine(ctx).Table("repository").
+		Join("INNER", "trending_repo", "trending_repo.repo_id = `repository`.id").
+		Where(cond).
+		OrderBy("trending_repo.score DESC, `repository`.num_stars DESC, `repository`.id")
+	if listOptions.Page > 0 {
+		sess = db.SetSessionPagination(sess, &listOptions)
+	}
+	repos := make(RepositoryList, 0, listOptions.PageSize)
+	return repos, count, sess.Select("`repository`.*").Find(&repos)
+}
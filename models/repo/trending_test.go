@@ -0,0 +1,61 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateTrendingRepos(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	assert.NoError(t, db.Insert(db.DefaultContext, &repo_model.Star{UID: 5, RepoID: 4}))
+	assert.NoError(t, db.Insert(db.DefaultContext, &repo_model.Star{UID: 4, RepoID: 4}))
+
+	assert.NoError(t, repo_model.UpdateTrendingRepos(db.DefaultContext, &repo_model.TrendingOptions{
+		WindowDays:     7,
+		StarWeight:     2,
+		ForkWeight:     3,
+		ActivityWeight: 1,
+	}))
+
+	// the scores of the fixtures are out of the window
+	unittest.AssertNotExistsBean(t, &repo_model.TrendingRepo{RepoID: 1})
+	trending := unittest.AssertExistsAndLoadBean(t, &repo_model.TrendingRepo{RepoID: 4})
+	assert.EqualValues(t, 2, trending.NumStars)
+	assert.EqualValues(t, 4, trending.Score)
+}
+
+func TestFindTrendingRepos(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repos, count, err := repo_model.FindTrendingRepos(db.DefaultContext, nil, db.ListOptions{Page: 1, PageSize: 10})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	if assert.Len(t, repos, 2) {
+		assert.EqualValues(t, 1, repos[0].ID)
+		assert.EqualValues(t, 10, repos[1].ID)
+	}
+
+	// the private repositories are only seen by the users who can access them
+	assert.NoError(t, db.Insert(db.DefaultContext, &repo_model.TrendingRepo{RepoID: 2, Score: 20}))
+	_, count, err = repo_model.FindTrendingRepos(db.DefaultContext, nil, db.ListOptions{Page: 1, PageSize: 10})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repos, _, err = repo_model.FindTrendingRepos(db.DefaultContext, user2, db.ListOptions{Page: 1, PageSize: 10})
+	assert.NoError(t, err)
+	if assert.Len(t, repos, 3) {
+		assert.EqualValues(t, 2, repos[0].ID)
+	}
+}
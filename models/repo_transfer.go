@@ -15,6 +15,7 @@ import (
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/models/webhook"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
@@ -135,6 +136,11 @@ func CancelRepositoryTransfer(repo *repo_model.Repository) error {
 		return err
 	}
 
+	// Replace the webhooks created from the templates of the old owner by the ones of the new owner.
+	if err := webhook.SyncWebhookTemplatesToRepo(ctx, repo); err != nil {
+		return fmt.Errorf("SyncWebhookTemplatesToRepo: %v", err)
+	}
+
 	if err := deleteRepositoryTransfer(ctx, repo.ID); err != nil {
 		return err
 	}
@@ -315,6 +321,10 @@ func TransferOwnership(doer *user_model.User, newOwnerName string, repo *repo_mo
 
 	// Remove old team-repository relations.
 	if oldOwner.IsOrganization() {
+		// the roles of the old owner don't apply anymore, the collaborators keep their general access mode
+		if _, err := sess.Where("repo_id = ?", repo.ID).Cols("role_id").Update(&repo_model.Collaboration{}); err != nil {
+			return fmt.Errorf("reset collaboration roles: %v", err)
+		}
 		if err := organization.RemoveOrgRepo(ctx, oldOwner.ID, repo.ID); err != nil {
 			return fmt.Errorf("removeOrgRepo: %v", err)
 		}
@@ -119,6 +119,10 @@ func init() {
 		typeComment := modelsCommentTypeComment
 		actual := GetCountByCond(t, "comment", builder.Eq{"`type`": typeComment, "issue_id": issue.int("ID")})
 		assert.EqualValues(t, issue.int("NumComments"), actual, "Unexpected number of comments for issue id: %d", issue.int("ID"))
+		actual = GetCountByCond(t, "reaction", builder.Eq{"issue_id": issue.int("ID"), "comment_id": 0})
+		assert.EqualValues(t, issue.int("NumReactions"), actual, "Unexpected number of reactions for issue id: %d", issue.int("ID"))
+		actual = GetCountByCond(t, "issue_vote", builder.Eq{"issue_id": issue.int("ID")})
+		assert.EqualValues(t, issue.int("NumVotes"), actual, "Unexpected number of votes for issue id: %d", issue.int("ID"))
 		if issue.bool("IsPull") {
 			prRow := AssertExistsAndLoadMap(t, "pull_request", builder.Eq{"issue_id": issue.int("ID")})
 			assert.EqualValues(t, parseInt(prRow["index"]), issue.int("Index"), "Unexpected index for issue id: %d", issue.int("ID"))
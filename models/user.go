@@ -18,6 +18,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
 	"code.gitea.io/gitea/models/organization"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	pull_model "code.gitea.io/gitea/models/pull"
@@ -25,6 +26,8 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
 )
 
 // DeleteUser deletes models associated to an user.
@@ -89,10 +92,28 @@ func DeleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		&user_model.UserBadge{UserID: u.ID},
 		&pull_model.AutoMerge{DoerID: u.ID},
 		&pull_model.ReviewState{UserID: u.ID},
+		&repo_model.PinnedRepository{OwnerID: u.ID},
+		&user_model.Status{UID: u.ID},
+		&user_model.BlockedUser{BlockerID: u.ID},
+		&user_model.BlockedUser{BlockeeID: u.ID},
+		&repo_model.InteractionLimit{OwnerID: u.ID},
+		&moderation_model.Report{ReporterID: u.ID},
+		&moderation_model.Log{OwnerID: u.ID},
+		&moderation_model.ShadowLimit{OwnerID: u.ID},
+		&moderation_model.ShadowLimit{UserID: u.ID},
+		&moderation_model.Hold{PosterID: u.ID},
+		&user_model.ScheduledDeletion{UserID: u.ID},
+		&user_model.SavedSearch{UserID: u.ID},
+		&issues_model.IssueFilter{UserID: u.ID},
+		&organization.OrgBot{BotID: u.ID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %v", err)
 	}
 
+	if err := issues_model.DeleteIssueVotesByUserID(ctx, u.ID); err != nil {
+		return err
+	}
+
 	if err := auth_model.DeleteOAuth2RelictsByUserID(ctx, u.ID); err != nil {
 		return err
 	}
@@ -207,3 +228,91 @@ func DeleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 
 	return nil
 }
+
+// userMergeColumns lists the columns referencing the content and credentials reassigned when merging users
+var userMergeColumns = []struct {
+	Table, Column string
+}{
+	{"issue", "poster_id"},
+	{"comment", "poster_id"},
+	{"review", "reviewer_id"},
+	{"release", "publisher_id"},
+	{"attachment", "uploader_id"},
+	{"tracked_time", "user_id"},
+	{"access_token", "uid"},
+	{"public_key", "owner_id"},
+	{"gpg_key", "owner_id"},
+	{"external_login_user", "user_id"},
+	{"user_open_id", "uid"},
+	{"user_redirect", "redirect_user_id"},
+}
+
+// MergeUserContent reassigns the content and credentials of the source user to the target user and
+// returns the number of reassigned rows per table. Rows which would duplicate the ones of the target are left
+// to the source, whose remaining data is removed by DeleteUser.
+func MergeUserContent(ctx context.Context, source, target *user_model.User) (map[string]int64, error) {
+	e := db.GetEngine(ctx)
+	counts := make(map[string]int64, len(userMergeColumns)+4)
+
+	for _, c := range userMergeColumns {
+		n, err := e.Table(c.Table).Where(builder.Eq{c.Column: source.ID}).Update(map[string]interface{}{c.Column: target.ID})
+		if err != nil {
+			return nil, fmt.Errorf("reassign %s: %v", c.Table, err)
+		}
+		counts[c.Table] = n
+	}
+
+	// the emails of the source stay verified but are never primary for the target
+	n, err := e.Table("email_address").Where("uid = ?", source.ID).
+		Update(map[string]interface{}{"uid": target.ID, "is_primary": false})
+	if err != nil {
+		return nil, fmt.Errorf("reassign email_address: %v", err)
+	}
+	counts["email_address"] = n
+
+	assignedIssueIDs := make([]int64, 0, 10)
+	if err := e.Table("issue_assignees").Where("assignee_id = ?", target.ID).Cols("issue_id").Find(&assignedIssueIDs); err != nil {
+		return nil, err
+	}
+	if n, err = e.Table("issue_assignees").
+		Where(builder.Eq{"assignee_id": source.ID}.And(builder.NotIn("issue_id", assignedIssueIDs))).
+		Update(map[string]interface{}{"assignee_id": target.ID}); err != nil {
+		return nil, fmt.Errorf("reassign issue_assignees: %v", err)
+	}
+	counts["issue_assignees"] = n
+
+	reactions := make([]*issues_model.Reaction, 0, 10)
+	if err := e.Where("user_id = ?", source.ID).Find(&reactions); err != nil {
+		return nil, err
+	}
+	for _, reaction := range reactions {
+		has, err := e.Table("reaction").Where(builder.Eq{
+			"type":       reaction.Type,
+			"issue_id":   reaction.IssueID,
+			"comment_id": reaction.CommentID,
+			"user_id":    target.ID,
+		}).Exist()
+		if err != nil {
+			return nil, err
+		} else if has {
+			continue
+		}
+		if _, err := e.ID(reaction.ID).Cols("user_id").Update(&issues_model.Reaction{UserID: target.ID}); err != nil {
+			return nil, fmt.Errorf("reassign reaction: %v", err)
+		}
+		counts["reaction"]++
+	}
+
+	votedIssueIDs := make([]int64, 0, 10)
+	if err := e.Table("issue_vote").Where("user_id = ?", target.ID).Cols("issue_id").Find(&votedIssueIDs); err != nil {
+		return nil, err
+	}
+	if n, err = e.Table("issue_vote").
+		Where(builder.Eq{"user_id": source.ID}.And(builder.NotIn("issue_id", votedIssueIDs))).
+		Update(map[string]interface{}{"user_id": target.ID}); err != nil {
+		return nil, fmt.Errorf("reassign issue_vote: %v", err)
+	}
+	counts["issue_vote"] = n
+
+	return counts, nil
+}
@@ -6,22 +6,32 @@ package user
 
 import (
 	"context"
+	"errors"
 
 	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
 )
 
+// ErrBadgeNotExist is returned when a badge does not exist
+var ErrBadgeNotExist = errors.New("badge does not exist")
+
 // Badge represents a user badge
 type Badge struct {
-	ID          int64 `xorm:"pk autoincr"`
+	ID int64 `xorm:"pk autoincr"`
+	// Slug identifies the achievements awarded automatically, badges defined by admins have none
+	Slug        string `xorm:"VARCHAR(64) INDEX"`
 	Description string
 	ImageURL    string
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
 }
 
 // UserBadge represents a user badge
 type UserBadge struct {
-	ID      int64 `xorm:"pk autoincr"`
-	BadgeID int64
-	UserID  int64 `xorm:"INDEX"`
+	ID          int64              `xorm:"pk autoincr"`
+	BadgeID     int64              `xorm:"INDEX"`
+	UserID      int64              `xorm:"INDEX"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
 }
 
 func init() {
@@ -29,14 +39,111 @@ func init() {
 	db.RegisterModel(new(UserBadge))
 }
 
+// IsAchievement returns true if the badge is awarded automatically
+func (b *Badge) IsAchievement() bool {
+	return b.Slug != ""
+}
+
+// ImageLink returns the link to the image of the badge, achievements without image use the image shipped with Gitea
+func (b *Badge) ImageLink() string {
+	if b.ImageURL == "" && b.IsAchievement() {
+		return setting.StaticURLPrefix + "/assets/img/badges/" + b.Slug + ".svg"
+	}
+	return b.ImageURL
+}
+
 // GetUserBadges returns the user's badges.
 func GetUserBadges(ctx context.Context, u *User) ([]*Badge, int64, error) {
 	sess := db.GetEngine(ctx).
 		Select("`badge`.*").
 		Join("INNER", "user_badge", "`user_badge`.badge_id=badge.id").
-		Where("user_badge.user_id=?", u.ID)
+		Where("user_badge.user_id=?", u.ID).
+		Asc("user_badge.id")
 
 	badges := make([]*Badge, 0, 8)
 	count, err := sess.FindAndCount(&badges)
 	return badges, count, err
 }
+
+// GetBadges returns all badges
+func GetBadges(ctx context.Context) ([]*Badge, error) {
+	badges := make([]*Badge, 0, 10)
+	return badges, db.GetEngine(ctx).Asc("id").Find(&badges)
+}
+
+// GetBadgeByID returns the badge with the given id
+func GetBadgeByID(ctx context.Context, id int64) (*Badge, error) {
+	b := new(Badge)
+	has, err := db.GetEngine(ctx).ID(id).Get(b)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrBadgeNotExist
+	}
+	return b, nil
+}
+
+// GetBadgeBySlug returns the achievement badge with the given slug
+func GetBadgeBySlug(ctx context.Context, slug string) (*Badge, error) {
+	b := new(Badge)
+	has, err := db.GetEngine(ctx).Where("slug = ?", slug).Get(b)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrBadgeNotExist
+	}
+	return b, nil
+}
+
+// CreateBadge creates a new badge
+func CreateBadge(ctx context.Context, b *Badge) error {
+	return db.Insert(ctx, b)
+}
+
+// UpdateBadge updates the description and the image of a badge
+func UpdateBadge(ctx context.Context, b *Badge) error {
+	_, err := db.GetEngine(ctx).ID(b.ID).Cols("description", "image_url").Update(b)
+	return err
+}
+
+// DeleteBadge deletes a badge and takes it away from all users
+func DeleteBadge(ctx context.Context, b *Badge) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.DeleteByBean(ctx, &UserBadge{BadgeID: b.ID}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByBean(ctx, &Badge{ID: b.ID})
+		return err
+	}, ctx)
+}
+
+// GetBadgeUsers returns the users who have been given the badge
+func GetBadgeUsers(ctx context.Context, b *Badge) ([]*User, error) {
+	users := make([]*User, 0, 10)
+	return users, db.GetEngine(ctx).
+		Join("INNER", "user_badge", "`user_badge`.user_id=`user`.id").
+		Where("user_badge.badge_id=?", b.ID).
+		Asc("user_badge.id").
+		Find(&users)
+}
+
+// GetBadgeUserIDs returns the ids of the users who have been given the badge
+func GetBadgeUserIDs(ctx context.Context, b *Badge) ([]int64, error) {
+	ids := make([]int64, 0, 10)
+	return ids, db.GetEngine(ctx).Table("user_badge").Where("badge_id=?", b.ID).Cols("user_id").Find(&ids)
+}
+
+// AddUserBadge gives the badge to the user if they do not have it yet
+func AddUserBadge(ctx context.Context, u *User, b *Badge) error {
+	has, err := db.GetEngine(ctx).Exist(&UserBadge{UserID: u.ID, BadgeID: b.ID})
+	if err != nil || has {
+		return err
+	}
+	return db.Insert(ctx, &UserBadge{UserID: u.ID, BadgeID: b.ID})
+}
+
+// RemoveUserBadge takes the badge away from the user
+func RemoveUserBadge(ctx context.Context, u *User, b *Badge) error {
+	_, err := db.DeleteByBean(ctx, &UserBadge{UserID: u.ID, BadgeID: b.ID})
+	return err
+}
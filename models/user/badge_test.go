@@ -0,0 +1,67 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBadges(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	custom := &user_model.Badge{Description: "Conference speaker", ImageURL: "https://example.com/speaker.png"}
+	assert.NoError(t, user_model.CreateBadge(db.DefaultContext, custom))
+	assert.False(t, custom.IsAchievement())
+	assert.Equal(t, "https://example.com/speaker.png", custom.ImageLink())
+
+	achievement := &user_model.Badge{Slug: "first-pull-merged", Description: "Got a first pull request merged"}
+	assert.NoError(t, user_model.CreateBadge(db.DefaultContext, achievement))
+	assert.True(t, achievement.IsAchievement())
+	assert.Equal(t, setting.StaticURLPrefix+"/assets/img/badges/first-pull-merged.svg", achievement.ImageLink())
+
+	b, err := user_model.GetBadgeBySlug(db.DefaultContext, "first-pull-merged")
+	assert.NoError(t, err)
+	assert.Equal(t, achievement.ID, b.ID)
+	_, err = user_model.GetBadgeBySlug(db.DefaultContext, "unknown")
+	assert.ErrorIs(t, err, user_model.ErrBadgeNotExist)
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	assert.NoError(t, user_model.AddUserBadge(db.DefaultContext, user2, custom))
+	assert.NoError(t, user_model.AddUserBadge(db.DefaultContext, user2, achievement))
+	// giving a badge twice is a no-op
+	assert.NoError(t, user_model.AddUserBadge(db.DefaultContext, user2, custom))
+	assert.NoError(t, user_model.AddUserBadge(db.DefaultContext, user4, custom))
+
+	badges, count, err := user_model.GetUserBadges(db.DefaultContext, user2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	if assert.Len(t, badges, 2) {
+		assert.Equal(t, custom.ID, badges[0].ID)
+		assert.Equal(t, achievement.ID, badges[1].ID)
+	}
+
+	users, err := user_model.GetBadgeUsers(db.DefaultContext, custom)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	assert.NoError(t, user_model.RemoveUserBadge(db.DefaultContext, user4, custom))
+	ids, err := user_model.GetBadgeUserIDs(db.DefaultContext, custom)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2}, ids)
+
+	assert.NoError(t, user_model.DeleteBadge(db.DefaultContext, custom))
+	_, err = user_model.GetBadgeByID(db.DefaultContext, custom.ID)
+	assert.ErrorIs(t, err, user_model.ErrBadgeNotExist)
+	unittest.AssertNotExistsBean(t, &user_model.UserBadge{BadgeID: custom.ID})
+	unittest.AssertExistsAndLoadBean(t, &user_model.UserBadge{BadgeID: achievement.ID, UserID: 2})
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ErrCannotBlockSelf is returned when a user or an organization tries to block itself
+var ErrCannotBlockSelf = errors.New("cannot block yourself")
+
+// BlockedUser represents a user blocked by another user or by an organization.
+// A blocked user cannot open issues or pull requests, comment or react in the repositories of the blocker.
+type BlockedUser struct {
+	ID          int64              `xorm:"pk autoincr"`
+	BlockerID   int64              `xorm:"UNIQUE(block) NOT NULL"`
+	BlockeeID   int64              `xorm:"UNIQUE(block) INDEX NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(BlockedUser))
+}
+
+// IsBlocked returns true if the blockee is blocked by the blocker
+func IsBlocked(ctx context.Context, blockerID, blockeeID int64) (bool, error) {
+	if blockerID == 0 || blockeeID == 0 {
+		return false, nil
+	}
+	return db.GetEngine(ctx).Exist(&BlockedUser{BlockerID: blockerID, BlockeeID: blockeeID})
+}
+
+// BlockUser blocks the blockee for the blocker, the follow relations between them are removed
+func BlockUser(ctx context.Context, blockerID, blockeeID int64) error {
+	if blockerID == blockeeID {
+		return ErrCannotBlockSelf
+	}
+	has, err := IsBlocked(ctx, blockerID, blockeeID)
+	if err != nil || has {
+		return err
+	}
+	if err := db.Insert(ctx, &BlockedUser{BlockerID: blockerID, BlockeeID: blockeeID}); err != nil {
+		return err
+	}
+	if err := UnfollowUser(blockeeID, blockerID); err != nil {
+		return err
+	}
+	return UnfollowUser(blockerID, blockeeID)
+}
+
+// UnblockUser removes the block of the blockee by the blocker
+func UnblockUser(ctx context.Context, blockerID, blockeeID int64) error {
+	_, err := db.DeleteByBean(ctx, &BlockedUser{BlockerID: blockerID, BlockeeID: blockeeID})
+	return err
+}
+
+// GetBlockedUsers returns the users blocked by the blocker, most recently blocked first
+func GetBlockedUsers(ctx context.Context, blockerID int64, opts db.ListOptions) ([]*User, int64, error) {
+	sess := db.GetEngine(ctx).
+		Join("INNER", "blocked_user", "`blocked_user`.blockee_id=`user`.id").
+		Where("blocked_user.blocker_id=?", blockerID).
+		Desc("blocked_user.id")
+	if opts.Page > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+
+	users := make([]*User, 0, 10)
+	count, err := sess.FindAndCount(&users)
+	return users, count, err
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockUser(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	assert.ErrorIs(t, user_model.BlockUser(db.DefaultContext, 2, 2), user_model.ErrCannotBlockSelf)
+
+	// users 2 and 8 follow each other, blocking removes both relations
+	assert.True(t, user_model.IsFollowing(8, 2))
+	assert.NoError(t, user_model.BlockUser(db.DefaultContext, 2, 8))
+	assert.False(t, user_model.IsFollowing(8, 2))
+	assert.False(t, user_model.IsFollowing(2, 8))
+
+	// blocking twice is a no-op
+	assert.NoError(t, user_model.BlockUser(db.DefaultContext, 2, 8))
+	unittest.AssertCount(t, &user_model.BlockedUser{BlockerID: 2}, 1)
+
+	blocked, err := user_model.IsBlocked(db.DefaultContext, 2, 8)
+	assert.NoError(t, err)
+	assert.True(t, blocked)
+	blocked, err = user_model.IsBlocked(db.DefaultContext, 8, 2)
+	assert.NoError(t, err)
+	assert.False(t, blocked)
+
+	// organizations can block users too
+	assert.NoError(t, user_model.BlockUser(db.DefaultContext, 3, 8))
+	users, count, err := user_model.GetBlockedUsers(db.DefaultContext, 3, db.ListOptions{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	if assert.Len(t, users, 1) {
+		assert.EqualValues(t, 8, users[0].ID)
+	}
+
+	assert.NoError(t, user_model.UnblockUser(db.DefaultContext, 2, 8))
+	unittest.AssertNotExistsBean(t, &user_model.BlockedUser{BlockerID: 2, BlockeeID: 8})
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ScheduledDeletion represents a pending deletion of a user account.
+// The deletion can be canceled until DeleteUnix, after which the owned repositories
+// and organizations are handed over to the successor (if any) and the account is deleted.
+type ScheduledDeletion struct {
+	ID          int64              `xorm:"pk autoincr"`
+	UserID      int64              `xorm:"UNIQUE NOT NULL"`
+	SuccessorID int64              `xorm:"NOT NULL DEFAULT 0"`
+	DeleteUnix  timeutil.TimeStamp `xorm:"INDEX NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(ScheduledDeletion))
+}
+
+// GetScheduledDeletion returns the pending deletion of the user, or nil if none is scheduled
+func GetScheduledDeletion(ctx context.Context, userID int64) (*ScheduledDeletion, error) {
+	deletion := &ScheduledDeletion{UserID: userID}
+	has, err := db.GetEngine(ctx).Get(deletion)
+	if err != nil || !has {
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// ScheduleDeletion schedules the deletion of the user, replacing any deletion scheduled before
+func ScheduleDeletion(ctx context.Context, userID, successorID int64, deleteUnix timeutil.TimeStamp) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.DeleteByBean(ctx, &ScheduledDeletion{UserID: userID}); err != nil {
+			return err
+		}
+		return db.Insert(ctx, &ScheduledDeletion{
+			UserID:      userID,
+			SuccessorID: successorID,
+			DeleteUnix:  deleteUnix,
+		})
+	}, ctx)
+}
+
+// CancelScheduledDeletion cancels the pending deletion of the user
+func CancelScheduledDeletion(ctx context.Context, userID int64) error {
+	_, err := db.DeleteByBean(ctx, &ScheduledDeletion{UserID: userID})
+	return err
+}
+
+// GetDueScheduledDeletions returns the deletions whose grace period ended before the given time
+func GetDueScheduledDeletions(ctx context.Context, now timeutil.TimeStamp) ([]*ScheduledDeletion, error) {
+	deletions := make([]*ScheduledDeletion, 0, 10)
+	return deletions, db.GetEngine(ctx).
+		Where("delete_unix <= ?", now).
+		Asc("delete_unix").
+		Find(&deletions)
+}
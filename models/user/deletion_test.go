@@ -0,0 +1,44 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledDeletion(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	deletion, err := user_model.GetScheduledDeletion(db.DefaultContext, 8)
+	assert.NoError(t, err)
+	assert.Nil(t, deletion)
+
+	now := timeutil.TimeStampNow()
+	assert.NoError(t, user_model.ScheduleDeletion(db.DefaultContext, 8, 0, now.Add(3600)))
+	// scheduling again replaces the previous deletion
+	assert.NoError(t, user_model.ScheduleDeletion(db.DefaultContext, 8, 4, now.Add(7200)))
+	deletion, err = user_model.GetScheduledDeletion(db.DefaultContext, 8)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 4, deletion.SuccessorID)
+	assert.EqualValues(t, now.Add(7200), deletion.DeleteUnix)
+	assert.Equal(t, 1, unittest.GetCount(t, &user_model.ScheduledDeletion{UserID: 8}))
+
+	assert.NoError(t, user_model.ScheduleDeletion(db.DefaultContext, 9, 0, now.Add(-60)))
+	due, err := user_model.GetDueScheduledDeletions(db.DefaultContext, now)
+	assert.NoError(t, err)
+	if assert.Len(t, due, 1) {
+		assert.EqualValues(t, 9, due[0].UserID)
+	}
+
+	assert.NoError(t, user_model.CancelScheduledDeletion(db.DefaultContext, 8))
+	unittest.AssertNotExistsBean(t, &user_model.ScheduledDeletion{UserID: 8})
+}
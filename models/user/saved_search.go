@@ -0,0 +1,117 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// MaxSavedSearches is the maximum number of searches a user can save
+const MaxSavedSearches = 50
+
+// ErrTooManySavedSearches is returned when a user has already saved the maximum number of searches
+var ErrTooManySavedSearches = errors.New("too many saved searches")
+
+// ErrSavedSearchNotExist represents a "SavedSearchNotExist" kind of error.
+type ErrSavedSearchNotExist struct {
+	ID int64
+}
+
+// IsErrSavedSearchNotExist checks if an error is a ErrSavedSearchNotExist.
+func IsErrSavedSearchNotExist(err error) bool {
+	_, ok := err.(ErrSavedSearchNotExist)
+	return ok
+}
+
+func (err ErrSavedSearchNotExist) Error() string {
+	return fmt.Sprintf("saved search does not exist [id: %d]", err.ID)
+}
+
+// SavedSearchType is the type of the matches of a saved search
+type SavedSearchType string
+
+const (
+	// SavedSearchTypeIssue searches the issues with the issue indexer
+	SavedSearchTypeIssue SavedSearchType = "issue"
+	// SavedSearchTypeCode searches the code with the code indexer
+	SavedSearchTypeCode SavedSearchType = "code"
+)
+
+// SavedSearchTypes are the types of the saved searches
+var SavedSearchTypes = []SavedSearchType{SavedSearchTypeIssue, SavedSearchTypeCode}
+
+// IsValid returns true if the type is a known one
+func (t SavedSearchType) IsValid() bool {
+	return t == SavedSearchTypeIssue || t == SavedSearchTypeCode
+}
+
+// SavedSearch is a search query saved by a user, the matches which are new since the previous generation of its feed
+// are the items of its feed
+type SavedSearch struct {
+	ID      int64           `xorm:"pk autoincr"`
+	UserID  int64           `xorm:"INDEX NOT NULL"`
+	Name    string          `xorm:"NOT NULL"`
+	Type    SavedSearchType `xorm:"VARCHAR(10) NOT NULL"`
+	Keyword string          `xorm:"NOT NULL"`
+	// RepoID limits the search to a repository, 0 searches all the repositories the user can read
+	RepoID            int64 `xorm:"NOT NULL DEFAULT 0"`
+	LastGeneratedUnix timeutil.TimeStamp
+	CreatedUnix       timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SavedSearch))
+}
+
+// CreateSavedSearch saves a search of a user
+func CreateSavedSearch(ctx context.Context, search *SavedSearch) error {
+	if !search.Type.IsValid() {
+		return fmt.Errorf("invalid saved search type %q", search.Type)
+	}
+	count, err := db.GetEngine(ctx).Where("user_id=?", search.UserID).Count(new(SavedSearch))
+	if err != nil {
+		return err
+	}
+	if count >= MaxSavedSearches {
+		return ErrTooManySavedSearches
+	}
+	return db.Insert(ctx, search)
+}
+
+// GetSavedSearch returns a search saved by a user
+func GetSavedSearch(ctx context.Context, userID, id int64) (*SavedSearch, error) {
+	search := &SavedSearch{}
+	has, err := db.GetEngine(ctx).Where("id=? AND user_id=?", id, userID).Get(search)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrSavedSearchNotExist{ID: id}
+	}
+	return search, nil
+}
+
+// GetSavedSearches returns the searches saved by a user, in the order they were saved
+func GetSavedSearches(ctx context.Context, userID int64) ([]*SavedSearch, error) {
+	searches := make([]*SavedSearch, 0, 10)
+	return searches, db.GetEngine(ctx).Where("user_id=?", userID).Asc("id").Find(&searches)
+}
+
+// DeleteSavedSearch deletes a search saved by a user
+func DeleteSavedSearch(ctx context.Context, userID, id int64) error {
+	_, err := db.GetEngine(ctx).Where("id=? AND user_id=?", id, userID).Delete(new(SavedSearch))
+	return err
+}
+
+// UpdateSavedSearchGenerated records the time of the latest generation of the feed of a saved search
+func UpdateSavedSearchGenerated(ctx context.Context, search *SavedSearch, generated timeutil.TimeStamp) error {
+	search.LastGeneratedUnix = generated
+	_, err := db.GetEngine(ctx).ID(search.ID).Cols("last_generated_unix").NoAutoTime().Update(search)
+	return err
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearches(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	assert.Error(t, user_model.CreateSavedSearch(db.DefaultContext, &user_model.SavedSearch{UserID: 2, Name: "Invalid", Type: "wiki", Keyword: "test"}))
+
+	issues := &user_model.SavedSearch{UserID: 2, Name: "Bugs", Type: user_model.SavedSearchTypeIssue, Keyword: "bug"}
+	assert.NoError(t, user_model.CreateSavedSearch(db.DefaultContext, issues))
+	code := &user_model.SavedSearch{UserID: 2, Name: "TODOs", Type: user_model.SavedSearchTypeCode, Keyword: "TODO", RepoID: 1}
+	assert.NoError(t, user_model.CreateSavedSearch(db.DefaultContext, code))
+
+	searches, err := user_model.GetSavedSearches(db.DefaultContext, 2)
+	assert.NoError(t, err)
+	if assert.Len(t, searches, 2) {
+		assert.Equal(t, "Bugs", searches[0].Name)
+		assert.Equal(t, "TODOs", searches[1].Name)
+	}
+
+	// the searches of other users can't be read nor deleted
+	_, err = user_model.GetSavedSearch(db.DefaultContext, 4, issues.ID)
+	assert.True(t, user_model.IsErrSavedSearchNotExist(err))
+	assert.NoError(t, user_model.DeleteSavedSearch(db.DefaultContext, 4, issues.ID))
+	unittest.AssertExistsAndLoadBean(t, &user_model.SavedSearch{ID: issues.ID})
+
+	assert.NoError(t, user_model.UpdateSavedSearchGenerated(db.DefaultContext, code, 1000))
+	search, err := user_model.GetSavedSearch(db.DefaultContext, 2, code.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, timeutil.TimeStamp(1000), search.LastGeneratedUnix)
+	assert.EqualValues(t, 1, search.RepoID)
+
+	assert.NoError(t, user_model.DeleteSavedSearch(db.DefaultContext, 2, issues.ID))
+	unittest.AssertNotExistsBean(t, &user_model.SavedSearch{ID: issues.ID})
+}
+
+func TestCreateSavedSearchLimit(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	for i := 0; i < user_model.MaxSavedSearches; i++ {
+		assert.NoError(t, user_model.CreateSavedSearch(db.DefaultContext, &user_model.SavedSearch{UserID: 2, Name: "Search", Type: user_model.SavedSearchTypeIssue, Keyword: "bug"}))
+	}
+	assert.ErrorIs(t, user_model.CreateSavedSearch(db.DefaultContext, &user_model.SavedSearch{UserID: 2, Name: "Search", Type: user_model.SavedSearchTypeIssue, Keyword: "bug"}), user_model.ErrTooManySavedSearches)
+}
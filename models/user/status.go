@@ -0,0 +1,130 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/emoji"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// MaxStatusMessageLength is the maximum number of characters of a status message
+const MaxStatusMessageLength = 80
+
+var (
+	// ErrInvalidStatusEmoji is returned when the emoji of a status is not a known emoji
+	ErrInvalidStatusEmoji = errors.New("status emoji is not a known emoji")
+	// ErrStatusMessageTooLong is returned when the message of a status is longer than MaxStatusMessageLength
+	ErrStatusMessageTooLong = errors.New("status message is too long")
+)
+
+// Status represents the status a user shows next to their name
+type Status struct {
+	ID      int64  `xorm:"pk autoincr"`
+	UID     int64  `xorm:"UNIQUE NOT NULL"`
+	Emoji   string `xorm:"VARCHAR(64)"`
+	Message string `xorm:"VARCHAR(255)"`
+	// Busy users are skipped by the automatic assignment of reviewers
+	Busy        bool               `xorm:"NOT NULL DEFAULT false"`
+	ExpiresUnix timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(Status))
+}
+
+// TableName returns the table name of the status
+func (s *Status) TableName() string {
+	return "user_status"
+}
+
+// IsExpired returns true if the status has an expiry which has passed
+func (s *Status) IsExpired() bool {
+	return s.ExpiresUnix != 0 && s.ExpiresUnix <= timeutil.TimeStampNow()
+}
+
+// IsEmpty returns true if the status shows nothing
+func (s *Status) IsEmpty() bool {
+	return s.Emoji == "" && s.Message == "" && !s.Busy
+}
+
+// Validate normalizes the emoji of the status to its unicode code and checks the status
+func (s *Status) Validate() error {
+	s.Emoji = strings.TrimSpace(s.Emoji)
+	s.Message = strings.TrimSpace(s.Message)
+	if s.Emoji != "" {
+		if e := emoji.FromAlias(s.Emoji); e != nil {
+			s.Emoji = e.Emoji
+		} else if emoji.FromCode(s.Emoji) == nil {
+			return ErrInvalidStatusEmoji
+		}
+	}
+	if utf8.RuneCountInString(s.Message) > MaxStatusMessageLength {
+		return ErrStatusMessageTooLong
+	}
+	return nil
+}
+
+func activeStatusCond() builder.Cond {
+	return builder.Eq{"expires_unix": 0}.Or(builder.Gt{"expires_unix": timeutil.TimeStampNow()})
+}
+
+// GetUserStatus returns the status of the user, or nil if the user has no status or it has expired
+func GetUserStatus(ctx context.Context, uid int64) (*Status, error) {
+	s := new(Status)
+	has, err := db.GetEngine(ctx).Where("uid = ?", uid).And(activeStatusCond()).Get(s)
+	if err != nil || !has {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetUserStatuses returns the statuses of the users which have one which has not expired
+func GetUserStatuses(ctx context.Context, uids []int64) (map[int64]*Status, error) {
+	statuses := make(map[int64]*Status, len(uids))
+	if len(uids) == 0 {
+		return statuses, nil
+	}
+	list := make([]*Status, 0, len(uids))
+	if err := db.GetEngine(ctx).In("uid", uids).And(activeStatusCond()).Find(&list); err != nil {
+		return nil, err
+	}
+	for _, s := range list {
+		statuses[s.UID] = s
+	}
+	return statuses, nil
+}
+
+// SetUserStatus replaces the status of the user, an empty status clears it
+func SetUserStatus(ctx context.Context, s *Status) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	return db.WithTx(func(ctx context.Context) error {
+		if err := DeleteUserStatus(ctx, s.UID); err != nil {
+			return err
+		}
+		if s.IsEmpty() {
+			return nil
+		}
+		s.ID = 0
+		return db.Insert(ctx, s)
+	}, ctx)
+}
+
+// DeleteUserStatus clears the status of the user
+func DeleteUserStatus(ctx context.Context, uid int64) error {
+	_, err := db.GetEngine(ctx).Where("uid = ?", uid).Delete(new(Status))
+	return err
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user_test
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserStatus(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	assert.ErrorIs(t, user_model.SetUserStatus(db.DefaultContext, &user_model.Status{UID: 2, Emoji: ":not_an_emoji:"}), user_model.ErrInvalidStatusEmoji)
+
+	// aliases are stored as unicode emoji
+	assert.NoError(t, user_model.SetUserStatus(db.DefaultContext, &user_model.Status{UID: 2, Emoji: ":palm_tree:", Message: "On vacation", Busy: true}))
+	status, err := user_model.GetUserStatus(db.DefaultContext, 2)
+	assert.NoError(t, err)
+	if assert.NotNil(t, status) {
+		assert.Equal(t, "\U0001f334", status.Emoji)
+		assert.Equal(t, "On vacation", status.Message)
+		assert.True(t, status.Busy)
+	}
+
+	assert.NoError(t, user_model.SetUserStatus(db.DefaultContext, &user_model.Status{UID: 4, Message: "Gone", ExpiresUnix: timeutil.TimeStampNow() - 1}))
+	status, err = user_model.GetUserStatus(db.DefaultContext, 4)
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+
+	statuses, err := user_model.GetUserStatuses(db.DefaultContext, []int64{2, 4, 5})
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.NotNil(t, statuses[2])
+
+	// an empty status clears the status
+	assert.NoError(t, user_model.SetUserStatus(db.DefaultContext, &user_model.Status{UID: 2}))
+	unittest.AssertNotExistsBean(t, &user_model.Status{UID: 2})
+}
@@ -46,6 +46,9 @@ const (
 
 	// UserTypeOrganization defines an organization
 	UserTypeOrganization
+
+	// UserTypeBot defines an automation account owned by an organization, it can't sign in and only acts through its access tokens
+	UserTypeBot
 )
 
 const (
@@ -148,6 +151,16 @@ type User struct {
 	DiffViewStyle       string `xorm:"NOT NULL DEFAULT ''"`
 	Theme               string `xorm:"NOT NULL DEFAULT ''"`
 	KeepActivityPrivate bool   `xorm:"NOT NULL DEFAULT false"`
+
+	// Review workload, a ReviewCapacity of 0 means unlimited
+	ReviewCapacity   int                `xorm:"NOT NULL DEFAULT 0"`
+	OutOfOffice      bool               `xorm:"NOT NULL DEFAULT false"`
+	OutOfOfficeUntil timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+
+	// Profile sections hidden from the profile page
+	HideProfileActivity bool `xorm:"NOT NULL DEFAULT false"`
+	HideProfilePackages bool `xorm:"NOT NULL DEFAULT false"`
+	HideProfileProjects bool `xorm:"NOT NULL DEFAULT false"`
 }
 
 func init() {
@@ -451,6 +464,16 @@ func (u *User) IsOrganization() bool {
 	return u.Type == UserTypeOrganization
 }
 
+// IsBot returns true if user is an automation account of an organization.
+func (u *User) IsBot() bool {
+	return u.Type == UserTypeBot
+}
+
+// IsOutOfOffice returns true if the user is out of office and should not be assigned new reviews.
+func (u *User) IsOutOfOffice() bool {
+	return u.OutOfOffice && (u.OutOfOfficeUntil == 0 || timeutil.TimeStampNow() < u.OutOfOfficeUntil)
+}
+
 // DisplayName returns full name if it's not empty,
 // returns username otherwise.
 func (u *User) DisplayName() string {
@@ -580,6 +603,7 @@ var (
 	reservedUsernames = []string{
 		".",
 		"..",
+		"-",
 		".well-known",
 		"admin",
 		"api",
@@ -610,6 +634,7 @@ var (
 		"search",
 		"serviceworker.js",
 		"ssh_info",
+		"subscriptions",
 		"swagger.v1.json",
 		"user",
 		"v2",
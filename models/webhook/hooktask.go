@@ -51,6 +51,8 @@ const (
 	HookEventRepository                HookEventType = "repository"
 	HookEventRelease                   HookEventType = "release"
 	HookEventPackage                   HookEventType = "package"
+	HookEventDeployment                HookEventType = "deployment"
+	HookEventDeploymentStatus          HookEventType = "deployment_status"
 )
 
 // Event returns the HookEventType as an event string
@@ -83,6 +85,10 @@ func (h HookEventType) Event() string {
 		return "repository"
 	case HookEventRelease:
 		return "release"
+	case HookEventDeployment:
+		return "deployment"
+	case HookEventDeploymentStatus:
+		return "deployment_status"
 	}
 	return ""
 }
@@ -17,6 +17,8 @@ func TestMain(m *testing.M) {
 		FixtureFiles: []string{
 			"webhook.yml",
 			"hook_task.yml",
+			"repository.yml",
+			"user.yml",
 		},
 	})
 }
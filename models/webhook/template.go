@@ -0,0 +1,158 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+
+	"xorm.io/builder"
+)
+
+// ExpandWebhookTemplateURL replaces the variables of the URL of a webhook template by the values of the repository.
+// The available variables are $REPO_ID, $REPO_NAME, $REPO_OWNER and $REPO_FULL_NAME, they can also be written as ${REPO_NAME}.
+func ExpandWebhookTemplateURL(url string, repo *repo_model.Repository) string {
+	expansions := map[string]string{
+		"REPO_ID":        strconv.FormatInt(repo.ID, 10),
+		"REPO_NAME":      repo.Name,
+		"REPO_OWNER":     repo.OwnerName,
+		"REPO_FULL_NAME": repo.FullName(),
+	}
+
+	return os.Expand(url, func(key string) string {
+		if value, ok := expansions[key]; ok {
+			return value
+		}
+		return "$" + key
+	})
+}
+
+// GetWebhookTemplateByOrgID returns webhook template of organization by given ID.
+func GetWebhookTemplateByOrgID(ctx context.Context, orgID, id int64) (*Webhook, error) {
+	return getOrgWebhook(ctx, orgID, id, true)
+}
+
+// CreateWebhookTemplate creates a new webhook template of an organization and creates its webhook
+// in every repository of the organization.
+func CreateWebhookTemplate(ctx context.Context, tmpl *Webhook) error {
+	tmpl.IsTemplate = true
+	tmpl.RepoID = 0
+	return db.WithTx(func(ctx context.Context) error {
+		if err := CreateWebhook(ctx, tmpl); err != nil {
+			return err
+		}
+		return SyncWebhookTemplate(ctx, tmpl)
+	}, ctx)
+}
+
+// UpdateWebhookTemplate updates a webhook template and the webhooks created from it.
+func UpdateWebhookTemplate(ctx context.Context, tmpl *Webhook) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(tmpl.ID).AllCols().Update(tmpl); err != nil {
+			return err
+		}
+		return SyncWebhookTemplate(ctx, tmpl)
+	}, ctx)
+}
+
+// DeleteWebhookTemplateByOrgID deletes webhook template of organization by given ID
+// and the webhooks created from it.
+func DeleteWebhookTemplateByOrgID(ctx context.Context, orgID, id int64) error {
+	return db.WithTx(func(ctx context.Context) error {
+		if _, err := GetWebhookTemplateByOrgID(ctx, orgID, id); err != nil {
+			return err
+		}
+		return deleteWebhooksByCond(ctx, builder.Eq{"id": id}.Or(builder.Eq{"template_id": id}))
+	}, ctx)
+}
+
+// deleteWebhooksByCond deletes the webhooks matching the condition and their hook tasks
+func deleteWebhooksByCond(ctx context.Context, cond builder.Cond) error {
+	ids := make([]int64, 0, 10)
+	if err := db.GetEngine(ctx).Table("webhook").Where(cond).Cols("id").Find(&ids); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := db.GetEngine(ctx).In("id", ids).Delete(&Webhook{}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).In("hook_id", ids).Delete(&HookTask{})
+	return err
+}
+
+// syncWebhookTemplateToRepo creates or updates the webhook of the repository created from the template
+func syncWebhookTemplateToRepo(ctx context.Context, tmpl *Webhook, repo *repo_model.Repository) error {
+	w := &Webhook{}
+	has, err := db.GetEngine(ctx).Where("repo_id=? AND template_id=?", repo.ID, tmpl.ID).Get(w)
+	if err != nil {
+		return err
+	}
+
+	w.RepoID = repo.ID
+	w.TemplateID = tmpl.ID
+	w.URL = ExpandWebhookTemplateURL(tmpl.URL, repo)
+	w.HTTPMethod = tmpl.HTTPMethod
+	w.ContentType = tmpl.ContentType
+	w.Secret = tmpl.Secret
+	w.Events = tmpl.Events
+	w.HookEvent = tmpl.HookEvent
+	w.IsActive = tmpl.IsActive
+	w.Type = tmpl.Type
+	w.Meta = tmpl.Meta
+
+	if !has {
+		return CreateWebhook(ctx, w)
+	}
+	_, err = db.GetEngine(ctx).ID(w.ID).AllCols().Update(w)
+	return err
+}
+
+// SyncWebhookTemplate reconciles the webhooks created from the template in every repository of its organization
+func SyncWebhookTemplate(ctx context.Context, tmpl *Webhook) error {
+	repos := make([]*repo_model.Repository, 0, 10)
+	if err := db.GetEngine(ctx).Where("owner_id=?", tmpl.OrgID).Find(&repos); err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		if err := syncWebhookTemplateToRepo(ctx, tmpl, repo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SyncWebhookTemplatesToRepo reconciles the webhooks of the repository with the webhook templates of its owner,
+// the webhooks created from the templates of another organization are deleted.
+func SyncWebhookTemplatesToRepo(ctx context.Context, repo *repo_model.Repository) error {
+	templates, err := ListWebhooksByOpts(ctx, &ListWebhookOptions{OrgID: repo.OwnerID, IsTemplate: true})
+	if err != nil {
+		return err
+	}
+
+	templateIDs := make([]int64, 0, len(templates))
+	for _, tmpl := range templates {
+		templateIDs = append(templateIDs, tmpl.ID)
+	}
+	if err := deleteWebhooksByCond(ctx, builder.Eq{"repo_id": repo.ID}.
+		And(builder.Neq{"template_id": 0}).
+		And(builder.NotIn("template_id", templateIDs))); err != nil {
+		return err
+	}
+
+	for _, tmpl := range templates {
+		if err := syncWebhookTemplateToRepo(ctx, tmpl, repo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"fmt"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandWebhookTemplateURL(t *testing.T) {
+	repo := &repo_model.Repository{ID: 42, OwnerName: "org", Name: "repo"}
+	assert.Equal(t, "https://ci.example.com/org/repo/42?name=org/repo",
+		ExpandWebhookTemplateURL("https://ci.example.com/$REPO_OWNER/${REPO_NAME}/$REPO_ID?name=$REPO_FULL_NAME", repo))
+	assert.Equal(t, "https://ci.example.com/$UNKNOWN", ExpandWebhookTemplateURL("https://ci.example.com/$UNKNOWN", repo))
+}
+
+func TestWebhookTemplate(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	tmpl := &Webhook{
+		OrgID:       3,
+		URL:         "https://ci.example.com/$REPO_FULL_NAME",
+		ContentType: ContentTypeJSON,
+		Secret:      "secret",
+		HookEvent:   &HookEvent{PushOnly: true},
+		IsActive:    true,
+		Type:        GITEA,
+	}
+	assert.NoError(t, tmpl.UpdateEvent())
+	assert.NoError(t, CreateWebhookTemplate(db.DefaultContext, tmpl))
+
+	// the templates are not webhooks of the organization
+	hooks, err := ListWebhooksByOpts(db.DefaultContext, &ListWebhookOptions{OrgID: 3})
+	assert.NoError(t, err)
+	assert.Len(t, hooks, 1)
+	_, err = GetWebhookByOrgID(3, tmpl.ID)
+	assert.True(t, IsErrWebhookNotExist(err))
+	templates, err := ListWebhooksByOpts(db.DefaultContext, &ListWebhookOptions{OrgID: 3, IsTemplate: true})
+	assert.NoError(t, err)
+	if assert.Len(t, templates, 1) {
+		assert.Equal(t, tmpl.ID, templates[0].ID)
+	}
+
+	repos := make([]*repo_model.Repository, 0, 10)
+	assert.NoError(t, db.GetEngine(db.DefaultContext).Where("owner_id=?", 3).Find(&repos))
+	assert.NotEmpty(t, repos)
+	for _, repo := range repos {
+		w := unittest.AssertExistsAndLoadBean(t, &Webhook{RepoID: repo.ID, TemplateID: tmpl.ID})
+		assert.Equal(t, fmt.Sprintf("https://ci.example.com/%s", repo.FullName()), w.URL)
+		assert.Equal(t, "secret", w.Secret)
+		assert.True(t, w.HasPushEvent())
+	}
+
+	tmpl.URL = "https://ci.example.com/$REPO_ID"
+	tmpl.IsActive = false
+	assert.NoError(t, UpdateWebhookTemplate(db.DefaultContext, tmpl))
+	for _, repo := range repos {
+		w := unittest.AssertExistsAndLoadBean(t, &Webhook{RepoID: repo.ID, TemplateID: tmpl.ID})
+		assert.Equal(t, fmt.Sprintf("https://ci.example.com/%d", repo.ID), w.URL)
+		assert.False(t, w.IsActive)
+	}
+
+	// the webhooks created from the templates of another owner are deleted
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	assert.NoError(t, CreateWebhook(db.DefaultContext, &Webhook{RepoID: repo1.ID, TemplateID: tmpl.ID, URL: "https://ci.example.com/1"}))
+	assert.NoError(t, SyncWebhookTemplatesToRepo(db.DefaultContext, repo1))
+	unittest.AssertNotExistsBean(t, &Webhook{RepoID: repo1.ID, TemplateID: tmpl.ID})
+	unittest.AssertExistsAndLoadBean(t, &Webhook{ID: 1, RepoID: repo1.ID})
+
+	assert.NoError(t, DeleteWebhookTemplateByOrgID(db.DefaultContext, 3, tmpl.ID))
+	unittest.AssertNotExistsBean(t, &Webhook{ID: tmpl.ID})
+	unittest.AssertNotExistsBean(t, &Webhook{TemplateID: tmpl.ID})
+	assert.True(t, IsErrWebhookNotExist(DeleteWebhookTemplateByOrgID(db.DefaultContext, 3, tmpl.ID)))
+}
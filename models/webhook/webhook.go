@@ -136,6 +136,7 @@ type HookEvents struct {
 	Repository           bool `json:"repository"`
 	Release              bool `json:"release"`
 	Package              bool `json:"package"`
+	Deployment           bool `json:"deployment"`
 }
 
 // HookEvent represents events that will delivery hook.
@@ -182,6 +183,8 @@ type Webhook struct {
 	RepoID          int64 `xorm:"INDEX"` // An ID of 0 indicates either a default or system webhook
 	OrgID           int64 `xorm:"INDEX"`
 	IsSystemWebhook bool
+	IsTemplate      bool   `xorm:"INDEX NOT NULL DEFAULT false"` // An organization webhook template, applied to the repositories of the organization
+	TemplateID      int64  `xorm:"INDEX NOT NULL DEFAULT 0"`     // The organization webhook template the webhook is created from
 	URL             string `xorm:"url TEXT"`
 	HTTPMethod      string `xorm:"http_method"`
 	ContentType     HookContentType
@@ -353,6 +356,12 @@ func (w *Webhook) HasPackageEvent() bool {
 		(w.ChooseEvents && w.HookEvents.Package)
 }
 
+// HasDeploymentEvent returns if hook enabled deployment and deployment status events.
+func (w *Webhook) HasDeploymentEvent() bool {
+	return w.SendEverything ||
+		(w.ChooseEvents && w.HookEvents.Deployment)
+}
+
 // EventCheckers returns event checkers
 func (w *Webhook) EventCheckers() []struct {
 	Has  func() bool
@@ -384,6 +393,8 @@ func (w *Webhook) EventCheckers() []struct {
 		{w.HasRepositoryEvent, HookEventRepository},
 		{w.HasReleaseEvent, HookEventRelease},
 		{w.HasPackageEvent, HookEventPackage},
+		{w.HasDeploymentEvent, HookEventDeployment},
+		{w.HasDeploymentEvent, HookEventDeploymentStatus},
 	}
 }
 
@@ -444,20 +455,30 @@ func GetWebhookByRepoID(repoID, id int64) (*Webhook, error) {
 	})
 }
 
+// getOrgWebhook returns the webhook or the webhook template of organization by given ID.
+func getOrgWebhook(ctx context.Context, orgID, id int64, isTemplate bool) (*Webhook, error) {
+	w := &Webhook{ID: id, OrgID: orgID}
+	has, err := db.GetEngine(ctx).Where("is_template=?", isTemplate).Get(w)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrWebhookNotExist{id}
+	}
+	return w, nil
+}
+
 // GetWebhookByOrgID returns webhook of organization by given ID.
 func GetWebhookByOrgID(orgID, id int64) (*Webhook, error) {
-	return getWebhook(&Webhook{
-		ID:    id,
-		OrgID: orgID,
-	})
+	return getOrgWebhook(db.DefaultContext, orgID, id, false)
 }
 
 // ListWebhookOptions are options to filter webhooks on ListWebhooksByOpts
 type ListWebhookOptions struct {
 	db.ListOptions
-	RepoID   int64
-	OrgID    int64
-	IsActive util.OptionalBool
+	RepoID     int64
+	OrgID      int64
+	IsTemplate bool // list the webhook templates instead of the webhooks of the organization
+	IsActive   util.OptionalBool
 }
 
 func (opts *ListWebhookOptions) toCond() builder.Cond {
@@ -466,7 +487,8 @@ func (opts *ListWebhookOptions) toCond() builder.Cond {
 		cond = cond.And(builder.Eq{"webhook.repo_id": opts.RepoID})
 	}
 	if opts.OrgID != 0 {
-		cond = cond.And(builder.Eq{"webhook.org_id": opts.OrgID})
+		cond = cond.And(builder.Eq{"webhook.org_id": opts.OrgID}).
+			And(builder.Eq{"webhook.is_template": opts.IsTemplate})
 	}
 	if !opts.IsActive.IsNone() {
 		cond = cond.And(builder.Eq{"webhook.is_active": opts.IsActive.IsTrue()})
@@ -536,6 +558,54 @@ func UpdateWebhook(w *Webhook) error {
 	return err
 }
 
+// renameBranchInFilter replaces a branch in a branch filter, the branch must be the whole filter or one of the
+// patterns of a filter of the form "{pattern1,pattern2}", the filter is unchanged if it only matches the branch by a glob
+func renameBranchInFilter(filter, from, to string) (string, bool) {
+	if filter == from {
+		return to, true
+	}
+	if !strings.HasPrefix(filter, "{") || !strings.HasSuffix(filter, "}") || strings.ContainsAny(filter[1:len(filter)-1], "{}") {
+		return filter, false
+	}
+	patterns := strings.Split(filter[1:len(filter)-1], ",")
+	renamed := false
+	for i, pattern := range patterns {
+		if pattern == from {
+			patterns[i] = to
+			renamed = true
+		}
+	}
+	if !renamed {
+		return filter, false
+	}
+	return "{" + strings.Join(patterns, ",") + "}", true
+}
+
+// RenameBranchInFilters replaces a renamed branch in the branch filters of the webhooks of a repository or of an
+// organization, it returns the number of updated webhooks
+func RenameBranchInFilters(ctx context.Context, opts *ListWebhookOptions, from, to string) (int, error) {
+	webhooks, err := ListWebhooksByOpts(ctx, opts)
+	if err != nil {
+		return 0, err
+	}
+	updated := 0
+	for _, w := range webhooks {
+		filter, ok := renameBranchInFilter(w.BranchFilter, from, to)
+		if !ok {
+			continue
+		}
+		w.BranchFilter = filter
+		if err := w.UpdateEvent(); err != nil {
+			return updated, err
+		}
+		if _, err := db.GetEngine(ctx).ID(w.ID).Cols("events").Update(w); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
 // UpdateWebhookLastStatus updates last status of webhook.
 func UpdateWebhookLastStatus(w *Webhook) error {
 	_, err := db.GetEngine(db.DefaultContext).ID(w.ID).Cols("last_status").Update(w)
@@ -572,6 +642,9 @@ func DeleteWebhookByRepoID(repoID, id int64) error {
 
 // DeleteWebhookByOrgID deletes webhook of organization by given ID.
 func DeleteWebhookByOrgID(orgID, id int64) error {
+	if _, err := GetWebhookByOrgID(orgID, id); err != nil {
+		return err
+	}
 	return deleteWebhook(&Webhook{
 		ID:    id,
 		OrgID: orgID,
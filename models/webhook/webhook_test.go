@@ -72,7 +72,7 @@ func TestWebhook_EventsArray(t *testing.T) {
 		"pull_request", "pull_request_assign", "pull_request_label", "pull_request_milestone",
 		"pull_request_comment", "pull_request_review_approved", "pull_request_review_rejected",
 		"pull_request_review_comment", "pull_request_sync", "wiki", "repository", "release",
-		"package",
+		"package", "deployment", "deployment_status",
 	},
 		(&Webhook{
 			HookEvent: &HookEvent{SendEverything: true},
@@ -328,3 +328,38 @@ func TestCleanupHookTaskTable_OlderThan_LeavesTaskEarlierThanAgeToDelete(t *test
 	assert.NoError(t, CleanupHookTaskTable(context.Background(), OlderThan, 168*time.Hour, 0))
 	unittest.AssertExistsAndLoadBean(t, hookTask)
 }
+
+func TestRenameBranchInFilter(t *testing.T) {
+	for _, c := range []struct {
+		filter, expected string
+		renamed          bool
+	}{
+		{"master", "main", true},
+		{"{master,feature*}", "{main,feature*}", true},
+		{"{release*,master}", "{release*,main}", true},
+		{"", "", false},
+		{"*", "*", false},
+		{"mast*", "mast*", false},
+		{"{feature*,dev}", "{feature*,dev}", false},
+		{"{master,{a,b}}", "{master,{a,b}}", false},
+	} {
+		filter, renamed := renameBranchInFilter(c.filter, "master", "main")
+		assert.Equal(t, c.expected, filter, c.filter)
+		assert.Equal(t, c.renamed, renamed, c.filter)
+	}
+}
+
+func TestRenameBranchInFilters(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	updated, err := RenameBranchInFilters(db.DefaultContext, &ListWebhookOptions{RepoID: 2}, "master", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	hook := unittest.AssertExistsAndLoadBean(t, &Webhook{ID: 4})
+	assert.Equal(t, "{main,feature*}", hook.BranchFilter)
+	assert.True(t, hook.PushOnly)
+
+	updated, err = RenameBranchInFilters(db.DefaultContext, &ListWebhookOptions{RepoID: 1}, "master", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, updated)
+}
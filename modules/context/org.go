@@ -19,6 +19,7 @@ import (
 type Organization struct {
 	IsOwner          bool
 	IsMember         bool
+	IsGuest          bool // Is a guest, who only accesses the repositories granted to it.
 	IsTeamMember     bool // Is member of team.
 	IsTeamAdmin      bool // In owner team or team that has admin permission level.
 	Organization     *organization.Organization
@@ -112,6 +113,13 @@ func HandleOrgAssignment(ctx *Context, args ...bool) {
 				ctx.ServerError("IsOrgMember", err)
 				return
 			}
+			if ctx.Org.IsMember {
+				ctx.Org.IsGuest, err = organization.IsOrganizationGuest(ctx, org.ID, ctx.Doer.ID)
+				if err != nil {
+					ctx.ServerError("IsOrganizationGuest", err)
+					return
+				}
+			}
 			ctx.Org.CanCreateOrgRepo, err = org.CanCreateOrgRepo(ctx.Doer.ID)
 			if err != nil {
 				ctx.ServerError("CanCreateOrgRepo", err)
@@ -129,12 +137,18 @@ func HandleOrgAssignment(ctx *Context, args ...bool) {
 	}
 	ctx.Data["IsOrganizationOwner"] = ctx.Org.IsOwner
 	ctx.Data["IsOrganizationMember"] = ctx.Org.IsMember
+	ctx.Data["IsOrganizationGuest"] = ctx.Org.IsGuest
 	ctx.Data["IsPackageEnabled"] = setting.Packages.Enabled
 	ctx.Data["IsPublicMember"] = func(uid int64) bool {
 		is, _ := organization.IsPublicMembership(ctx.Org.Organization.ID, uid)
 		return is
 	}
 	ctx.Data["CanCreateOrgRepo"] = ctx.Org.CanCreateOrgRepo
+	ctx.Data["IsOrgVerified"], err = organization.IsOrgVerified(ctx, org.ID)
+	if err != nil {
+		ctx.ServerError("IsOrgVerified", err)
+		return
+	}
 
 	ctx.Org.OrgLink = org.AsUser().OrganisationLink()
 	ctx.Data["OrgLink"] = ctx.Org.OrgLink
@@ -1056,9 +1056,31 @@ func (ctx *Context) IssueTemplatesErrorsFromDefaultBranch() ([]*api.IssueTemplat
 		}
 	}
 
+	issueTemplates, invalidFiles := issueTemplatesFromCommit(ctx.Repo.Commit)
+	if len(issueTemplates) > 0 || len(invalidFiles) > 0 {
+		return issueTemplates, invalidFiles
+	}
+
+	// the repository has no issue template of its own, use the ones of its defaults repository
+	defaultsCommit, defaultsGitRepo, err := ctx.Repo.GetDefaultsCommit(ctx)
+	if err != nil {
+		log.Error("GetDefaultsCommit: %v", err)
+		return issueTemplates, invalidFiles
+	}
+	if defaultsGitRepo == nil {
+		return issueTemplates, invalidFiles
+	}
+	defer defaultsGitRepo.Close()
+	return issueTemplatesFromCommit(defaultsCommit)
+}
+
+// issueTemplatesFromCommit returns the valid issue templates of the template directories of a commit,
+// and the errors of its invalid template files
+func issueTemplatesFromCommit(commit *git.Commit) ([]*api.IssueTemplate, map[string]error) {
+	var issueTemplates []*api.IssueTemplate
 	invalidFiles := map[string]error{}
 	for _, dirName := range IssueTemplateDirCandidates {
-		tree, err := ctx.Repo.Commit.SubTree(dirName)
+		tree, err := commit.SubTree(dirName)
 		if err != nil {
 			log.Debug("get sub tree of %s: %v", dirName, err)
 			continue
@@ -1082,3 +1104,22 @@ func (ctx *Context) IssueTemplatesErrorsFromDefaultBranch() ([]*api.IssueTemplat
 	}
 	return issueTemplates, invalidFiles
 }
+
+// GetDefaultsCommit returns the commit of the default branch of the defaults repository of the repository, see
+// repo_model.GetDefaultsRepository, with its git repository which must be closed. Both are nil if there is none.
+func (r *Repository) GetDefaultsCommit(ctx context.Context) (*git.Commit, *git.Repository, error) {
+	defaults, err := repo_model.GetDefaultsRepository(ctx, r.Repository)
+	if err != nil || defaults == nil {
+		return nil, nil, err
+	}
+	gitRepo, err := git.OpenRepository(ctx, defaults.RepoPath())
+	if err != nil {
+		return nil, nil, err
+	}
+	commit, err := gitRepo.GetBranchCommit(defaults.DefaultBranch)
+	if err != nil {
+		gitRepo.Close()
+		return nil, nil, err
+	}
+	return commit, gitRepo, nil
+}
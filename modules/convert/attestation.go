@@ -0,0 +1,30 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package convert
+
+import (
+	"context"
+
+	attestation_model "code.gitea.io/gitea/models/attestation"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToAttestation converts attestation_model.Attestation to api.Attestation
+func ToAttestation(ctx context.Context, a *attestation_model.Attestation, doer *user_model.User) (*api.Attestation, error) {
+	if err := a.LoadUploader(ctx); err != nil {
+		return nil, err
+	}
+	return &api.Attestation{
+		ID:            a.ID,
+		SubjectType:   string(a.SubjectType),
+		Subject:       a.Subject,
+		PredicateType: a.PredicateType,
+		Digests:       a.Digests,
+		Content:       a.Content,
+		Uploader:      ToUser(a.Uploader, doer),
+		Created:       a.CreatedUnix.AsTime(),
+	}, nil
+}
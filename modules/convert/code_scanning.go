@@ -0,0 +1,61 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package convert
+
+import (
+	"context"
+
+	codescanning_model "code.gitea.io/gitea/models/codescanning"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToCodeScanningAnalysis converts codescanning_model.Analysis to api.CodeScanningAnalysis
+func ToCodeScanningAnalysis(analysis *codescanning_model.Analysis) *api.CodeScanningAnalysis {
+	return &api.CodeScanningAnalysis{
+		ID:           analysis.ID,
+		CommitSHA:    analysis.CommitSHA,
+		Ref:          analysis.Ref,
+		ToolName:     analysis.ToolName,
+		ToolVersion:  analysis.ToolVersion,
+		Category:     analysis.Category,
+		ResultsCount: analysis.NumResults,
+		Created:      analysis.CreatedUnix.AsTime(),
+	}
+}
+
+// ToCodeScanningAlert converts codescanning_model.Alert to api.CodeScanningAlert
+func ToCodeScanningAlert(ctx context.Context, alert *codescanning_model.Alert, doer *user_model.User) (*api.CodeScanningAlert, error) {
+	apiAlert := &api.CodeScanningAlert{
+		ID:               alert.ID,
+		State:            alert.State.String(),
+		ToolName:         alert.ToolName,
+		Category:         alert.Category,
+		RuleID:           alert.RuleID,
+		RuleDescription:  alert.RuleDescription,
+		Severity:         alert.Severity,
+		Message:          alert.Message,
+		Path:             alert.Path,
+		StartLine:        alert.StartLine,
+		EndLine:          alert.EndLine,
+		FirstSeenSHA:     alert.FirstSeenSHA,
+		LastSeenSHA:      alert.LastSeenSHA,
+		DismissedReason:  alert.DismissedReason,
+		DismissedComment: alert.DismissedComment,
+		Created:          alert.CreatedUnix.AsTime(),
+		Updated:          alert.UpdatedUnix.AsTime(),
+	}
+	if alert.State == codescanning_model.AlertStateDismissed {
+		if err := alert.LoadDismissedBy(ctx); err != nil {
+			return nil, err
+		}
+		apiAlert.DismissedBy = ToUser(alert.DismissedBy, doer)
+		apiAlert.Dismissed = alert.DismissedUnix.AsTimePtr()
+	}
+	if alert.State == codescanning_model.AlertStateFixed {
+		apiAlert.Fixed = alert.FixedUnix.AsTimePtr()
+	}
+	return apiAlert, nil
+}
@@ -141,7 +141,11 @@ func ToBranchProtection(bp *git_model.ProtectedBranch) *api.BranchProtection {
 		BlockOnRejectedReviews:        bp.BlockOnRejectedReviews,
 		BlockOnOfficialReviewRequests: bp.BlockOnOfficialReviewRequests,
 		BlockOnOutdatedBranch:         bp.BlockOnOutdatedBranch,
+		BlockOnCodeScanningAlerts:     bp.BlockOnCodeScanningAlerts,
+		MinimumDiffCoverage:           bp.MinimumDiffCoverage,
 		DismissStaleApprovals:         bp.DismissStaleApprovals,
+		IgnoreLastCommittersApprovals: bp.IgnoreLastCommittersApprovals,
+		DismissPusherApprovals:        bp.DismissPusherApprovals,
 		RequireSignedCommits:          bp.RequireSignedCommits,
 		ProtectedFilePatterns:         bp.ProtectedFilePatterns,
 		UnprotectedFilePatterns:       bp.UnprotectedFilePatterns,
@@ -182,6 +186,74 @@ func ToVerification(c *git.Commit) *api.PayloadCommitVerification {
 	return commitVerification
 }
 
+// ToCommitSignatureVerification verifies the signature of a commit against the keys known by the instance and
+// computes its trust status in the trust model of the repository
+func ToCommitSignatureVerification(repo *repo_model.Repository, c *git.Commit, doer *user_model.User) (*api.CommitSignatureVerification, error) {
+	verif := asymkey_model.ParseCommitWithSignature(c)
+	trustModel := repo.GetTrustModel()
+	if err := asymkey_model.CalculateTrustStatus(verif, trustModel, func(user *user_model.User) (bool, error) {
+		return repo_model.IsOwnerMemberCollaborator(repo, user.ID)
+	}, nil); err != nil {
+		return nil, err
+	}
+
+	result := &api.CommitSignatureVerification{
+		SHA:         c.ID.String(),
+		Verified:    verif.Verified,
+		Warning:     verif.Warning,
+		Reason:      verif.Reason,
+		TrustModel:  trustModel.String(),
+		TrustStatus: verif.TrustStatus,
+		KeyChain:    []*api.CommitSignatureKey{},
+	}
+	if c.Committer != nil {
+		result.Committer = &api.Identity{Name: c.Committer.Name, Email: c.Committer.Email}
+	}
+	if c.Signature != nil {
+		result.Signature = c.Signature.Signature
+		result.Payload = c.Signature.Payload
+	}
+	if !verif.Verified {
+		return result, nil
+	}
+
+	result.SignerIdentity = &api.Identity{Name: verif.SigningUser.Name, Email: verif.SigningEmail}
+	if verif.SigningUser.ID != 0 {
+		result.Signer = ToUser(verif.SigningUser, doer)
+	}
+	if verif.SigningSSHKey != nil {
+		result.KeyChain = append(result.KeyChain, &api.CommitSignatureKey{
+			Type:        "ssh",
+			Fingerprint: verif.SigningSSHKey.Fingerprint,
+			Verified:    verif.SigningSSHKey.Verified,
+		})
+	}
+	if verif.SigningKey != nil {
+		result.KeyChain = append(result.KeyChain, &api.CommitSignatureKey{
+			Type:     "gpg",
+			KeyID:    verif.SigningKey.KeyID,
+			Verified: verif.SigningKey.Verified,
+		})
+		if verif.SigningKey.PrimaryKeyID != "" {
+			primaryKeys, err := asymkey_model.GetGPGKeysByKeyID(verif.SigningKey.PrimaryKeyID)
+			if err != nil {
+				return nil, err
+			}
+			for _, primaryKey := range primaryKeys {
+				if primaryKey.OwnerID == verif.SigningKey.OwnerID {
+					result.KeyChain = append(result.KeyChain, &api.CommitSignatureKey{
+						Type:     "gpg",
+						KeyID:    primaryKey.KeyID,
+						Verified: primaryKey.Verified,
+					})
+					break
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
 // ToPublicKey convert asymkey_model.PublicKey to api.PublicKey
 func ToPublicKey(apiLink string, key *asymkey_model.PublicKey) *api.PublicKey {
 	return &api.PublicKey{
@@ -292,6 +364,10 @@ func ToDeployKey(apiLink string, key *asymkey_model.DeployKey) *api.DeployKey {
 
 // ToOrganization convert user_model.User to api.Organization
 func ToOrganization(org *organization.Organization) *api.Organization {
+	verified, err := organization.IsOrgVerified(db.DefaultContext, org.ID)
+	if err != nil {
+		log.Error("IsOrgVerified(%d): %v", org.ID, err)
+	}
 	return &api.Organization{
 		ID:                        org.ID,
 		AvatarURL:                 org.AsUser().AvatarLink(),
@@ -302,6 +378,7 @@ func ToOrganization(org *organization.Organization) *api.Organization {
 		Location:                  org.Location,
 		Visibility:                org.Visibility.String(),
 		RepoAdminChangeTeamAccess: org.RepoAdminChangeTeamAccess,
+		Verified:                  verified,
 	}
 }
 
@@ -329,10 +406,13 @@ func ToTeams(teams []*organization.Team, loadOrgs bool) ([]*api.Team, error) {
 
 		apiTeams[i] = &api.Team{
 			ID:                      teams[i].ID,
+			ParentID:                teams[i].ParentID,
+			RoleID:                  teams[i].RoleID,
 			Name:                    teams[i].Name,
 			Description:             teams[i].Description,
 			IncludesAllRepositories: teams[i].IncludesAllRepositories,
 			CanCreateOrgRepo:        teams[i].CanCreateOrgRepo,
+			AutoAssignReviewer:      teams[i].AutoAssignReviewer,
 			Permission:              teams[i].AccessMode.String(),
 			Units:                   teams[i].GetUnitNames(),
 			UnitsMap:                teams[i].GetUnitsMap(),
@@ -354,6 +434,33 @@ func ToTeams(teams []*organization.Team, loadOrgs bool) ([]*api.Team, error) {
 	return apiTeams, nil
 }
 
+// ToRole convert organization.Role to api.Role, the units of the role must be loaded
+func ToRole(role *organization.Role) *api.Role {
+	return &api.Role{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		UnitsMap:    role.GetUnitsMap(),
+	}
+}
+
+// ToOrgDomain convert organization.OrgDomain to api.OrgDomain
+func ToOrgDomain(org *organization.Organization, d *organization.OrgDomain) *api.OrgDomain {
+	apiDomain := &api.OrgDomain{
+		ID:             d.ID,
+		Domain:         d.Domain,
+		TeamID:         d.TeamID,
+		Verified:       d.IsVerified,
+		ChallengeName:  d.ChallengeName(org.Name),
+		ChallengeValue: d.Token,
+	}
+	if d.IsVerified {
+		verifiedAt := d.VerifiedUnix.AsTime()
+		apiDomain.VerifiedAt = &verifiedAt
+	}
+	return apiDomain
+}
+
 // ToAnnotatedTag convert git.Tag to api.AnnotatedTag
 func ToAnnotatedTag(repo *repo_model.Repository, t *git.Tag, c *git.Commit) *api.AnnotatedTag {
 	return &api.AnnotatedTag{
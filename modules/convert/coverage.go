@@ -0,0 +1,54 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package convert
+
+import (
+	"sort"
+
+	api "code.gitea.io/gitea/modules/structs"
+	coverage_service "code.gitea.io/gitea/services/coverage"
+)
+
+// ToCommitCoverage converts coverage_service.CommitCoverage to api.CommitCoverage
+func ToCommitCoverage(c *coverage_service.CommitCoverage) *api.CommitCoverage {
+	result := &api.CommitCoverage{
+		CommitSHA:    c.CommitSHA,
+		LinesCovered: c.LinesCovered(),
+		LinesValid:   c.LinesValid(),
+		Percentage:   c.Percentage(),
+		Files:        make([]*api.FileCoverage, 0, len(c.Files)),
+	}
+	for _, f := range c.Files {
+		result.Files = append(result.Files, &api.FileCoverage{
+			Path:         f.Path,
+			LinesCovered: f.LinesCovered(),
+			LinesValid:   f.LinesValid(),
+			Percentage:   f.Percentage(),
+		})
+	}
+	sort.Slice(result.Files, func(i, j int) bool { return result.Files[i].Path < result.Files[j].Path })
+	return result
+}
+
+// ToPullRequestCoverage converts coverage_service.DiffCoverage to api.PullRequestCoverage
+func ToPullRequestCoverage(c *coverage_service.DiffCoverage) *api.PullRequestCoverage {
+	result := &api.PullRequestCoverage{
+		HeadSHA:      c.HeadSHA,
+		BaseSHA:      c.BaseSHA,
+		LinesCovered: c.LinesCovered,
+		LinesValid:   c.LinesValid,
+		Percentage:   c.Percentage(),
+		Files:        make([]*api.FileCoverage, 0, len(c.Files)),
+	}
+	for _, f := range c.Files {
+		result.Files = append(result.Files, &api.FileCoverage{
+			Path:         f.Path,
+			LinesCovered: f.LinesCovered,
+			LinesValid:   f.LinesValid,
+			Percentage:   f.Percentage(),
+		})
+	}
+	return result
+}
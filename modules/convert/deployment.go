@@ -0,0 +1,52 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package convert
+
+import (
+	"context"
+	"fmt"
+
+	deployment_model "code.gitea.io/gitea/models/deployment"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToDeployment converts deployment_model.Deployment to api.Deployment
+func ToDeployment(ctx context.Context, repo *repo_model.Repository, d *deployment_model.Deployment) (*api.Deployment, error) {
+	if err := d.LoadCreator(ctx); err != nil {
+		return nil, err
+	}
+	link := fmt.Sprintf("%s/deployments/%d", repo.APIURL(), d.ID)
+	return &api.Deployment{
+		ID:             d.ID,
+		SHA:            d.SHA,
+		Ref:            d.Ref,
+		Environment:    d.Environment,
+		Description:    d.Description,
+		State:          string(d.State),
+		EnvironmentURL: d.EnvironmentURL,
+		Creator:        ToUser(d.Creator, nil),
+		URL:            link,
+		StatusesURL:    link + "/statuses",
+		Created:        d.CreatedUnix.AsTime(),
+		Updated:        d.UpdatedUnix.AsTime(),
+	}, nil
+}
+
+// ToDeploymentStatus converts deployment_model.Status to api.DeploymentStatus
+func ToDeploymentStatus(ctx context.Context, s *deployment_model.Status) (*api.DeploymentStatus, error) {
+	if err := s.LoadCreator(ctx); err != nil {
+		return nil, err
+	}
+	return &api.DeploymentStatus{
+		ID:             s.ID,
+		State:          string(s.State),
+		Description:    s.Description,
+		LogURL:         s.LogURL,
+		EnvironmentURL: s.EnvironmentURL,
+		Creator:        ToUser(s.Creator, nil),
+		Created:        s.CreatedUnix.AsTime(),
+	}, nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package convert
+
+import (
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToExpert convert a repo_model.Expert to an api.Expert
+func ToExpert(expert *repo_model.Expert, doer *user_model.User) *api.Expert {
+	apiExpert := &api.Expert{
+		Email:   expert.Email,
+		Lines:   expert.Lines,
+		Commits: expert.Commits,
+		Score:   expert.Score(),
+	}
+	if expert.User != nil {
+		apiExpert.User = ToUser(expert.User, doer)
+	}
+	if expert.LastCommitUnix > 0 {
+		lastCommit := expert.LastCommitUnix.AsTime()
+		apiExpert.LastCommit = &lastCommit
+	}
+	return apiExpert
+}
+
+// ToExpertList convert a repo_model.ExpertList to a list of api.Expert
+func ToExpertList(experts repo_model.ExpertList, doer *user_model.User) []*api.Expert {
+	apiExperts := make([]*api.Expert, 0, len(experts))
+	for _, expert := range experts {
+		apiExperts = append(apiExperts, ToExpert(expert, doer))
+	}
+	return apiExperts
+}
@@ -0,0 +1,25 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package convert
+
+import (
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToExploreCollection convert a repo_model.ExploreCollection to an api.ExploreCollection
+func ToExploreCollection(c *repo_model.ExploreCollection) *api.ExploreCollection {
+	return &api.ExploreCollection{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		Topic:       c.Topic,
+		Pinned:      c.IsPinned,
+		Position:    c.Position,
+		HTMLURL:     c.HTMLURL(),
+		Created:     c.CreatedUnix.AsTime(),
+		Updated:     c.UpdatedUnix.AsTime(),
+	}
+}
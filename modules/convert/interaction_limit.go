@@ -0,0 +1,27 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package convert
+
+import (
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToInteractionLimit convert repo_model.InteractionLimit to api.InteractionLimit
+func ToInteractionLimit(l *repo_model.InteractionLimit, owner *user_model.User) *api.InteractionLimit {
+	origin := "repository"
+	if l.RepoID == 0 {
+		origin = "user"
+		if owner != nil && owner.IsOrganization() {
+			origin = "organization"
+		}
+	}
+	return &api.InteractionLimit{
+		Limit:     string(l.Type),
+		Origin:    origin,
+		ExpiresAt: l.ExpiresUnix.AsTime(),
+	}
+}
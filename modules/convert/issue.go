@@ -37,20 +37,24 @@ func ToAPIIssue(issue *issues_model.Issue) *api.Issue {
 	}
 
 	apiIssue := &api.Issue{
-		ID:       issue.ID,
-		URL:      issue.APIURL(),
-		HTMLURL:  issue.HTMLURL(),
-		Index:    issue.Index,
-		Poster:   ToUser(issue.Poster, nil),
-		Title:    issue.Title,
-		Body:     issue.Content,
-		Ref:      issue.Ref,
-		Labels:   ToLabelList(issue.Labels, issue.Repo, issue.Repo.Owner),
-		State:    issue.State(),
-		IsLocked: issue.IsLocked,
-		Comments: issue.NumComments,
-		Created:  issue.CreatedUnix.AsTime(),
-		Updated:  issue.UpdatedUnix.AsTime(),
+		ID:             issue.ID,
+		URL:            issue.APIURL(),
+		HTMLURL:        issue.HTMLURL(),
+		Index:          issue.Index,
+		Poster:         ToUser(issue.Poster, nil),
+		Title:          issue.Title,
+		Body:           issue.Content,
+		Ref:            issue.Ref,
+		Labels:         ToLabelList(issue.Labels, issue.Repo, issue.Repo.Owner),
+		State:          issue.State(),
+		IsLocked:       issue.IsLocked,
+		IsConfidential: issue.IsConfidential,
+		Comments:       issue.NumComments,
+		Reactions:      issue.NumReactions,
+		ThumbsUp:       issue.NumThumbsUp,
+		Votes:          issue.NumVotes,
+		Created:        issue.CreatedUnix.AsTime(),
+		Updated:        issue.UpdatedUnix.AsTime(),
 	}
 
 	apiIssue.Repo = &api.RepositoryMeta{
@@ -107,6 +111,19 @@ func ToAPIIssueList(il issues_model.IssueList) []*api.Issue {
 	return result
 }
 
+// ToAPIPinnedIssueList converts pinned issues to API format, their positions are the ones in the list
+func ToAPIPinnedIssueList(pins issues_model.PinnedIssueList) []*api.PinnedIssue {
+	result := make([]*api.PinnedIssue, len(pins))
+	for i := range pins {
+		result[i] = &api.PinnedIssue{
+			Issue:          ToAPIIssue(pins[i].Issue),
+			Position:       i + 1,
+			IsAnnouncement: pins[i].IsAnnouncement,
+		}
+	}
+	return result
+}
+
 // ToTrackedTime converts TrackedTime to API format
 func ToTrackedTime(t *issues_model.TrackedTime) (apiT *api.TrackedTime) {
 	apiT = &api.TrackedTime{
@@ -213,6 +230,83 @@ func ToLabelList(labels []*issues_model.Label, repo *repo_model.Repository, org
 	return result
 }
 
+// ToIssueField converts IssueField to API format
+func ToIssueField(f *issues_model.IssueField) *api.IssueField {
+	options := f.Options
+	if options == nil {
+		options = []string{}
+	}
+	return &api.IssueField{
+		ID:          f.ID,
+		Name:        f.Name,
+		Description: f.Description,
+		Type:        string(f.Type),
+		Options:     options,
+		IsOrgField:  f.BelongsToOrg(),
+	}
+}
+
+// ToIssueFieldList converts list of IssueField to API format
+func ToIssueFieldList(fields []*issues_model.IssueField) []*api.IssueField {
+	result := make([]*api.IssueField, len(fields))
+	for i := range fields {
+		result[i] = ToIssueField(fields[i])
+	}
+	return result
+}
+
+// ToIssueFieldValues converts the values of the fields of an issue to API format, in the order of the fields
+func ToIssueFieldValues(fields []*issues_model.IssueField, values []*issues_model.IssueFieldValue) []*api.IssueFieldValue {
+	valueByField := make(map[int64]string, len(values))
+	for _, v := range values {
+		valueByField[v.FieldID] = v.Value
+	}
+	result := make([]*api.IssueFieldValue, 0, len(values))
+	for _, f := range fields {
+		if value, ok := valueByField[f.ID]; ok {
+			result = append(result, &api.IssueFieldValue{
+				FieldID: f.ID,
+				Name:    f.Name,
+				Type:    string(f.Type),
+				Value:   value,
+			})
+		}
+	}
+	return result
+}
+
+// ToIssueFilter converts IssueFilter to API format
+func ToIssueFilter(f *issues_model.IssueFilter, repo *repo_model.Repository) *api.IssueFilter {
+	filterType := "issues"
+	if f.IsPull {
+		filterType = "pulls"
+	}
+	return &api.IssueFilter{
+		ID:        f.ID,
+		Name:      f.Name,
+		Type:      filterType,
+		Labels:    f.LabelIDs(),
+		Milestone: f.MilestoneID,
+		Assignee:  f.AssigneeID,
+		Poster:    f.PosterID,
+		State:     f.State(),
+		Sort:      f.SortType,
+		Keyword:   f.Keyword,
+		Pinned:    f.IsPinned,
+		Created:   f.CreatedUnix.AsTime(),
+		HTMLURL:   f.Link(repo.HTMLURL()),
+	}
+}
+
+// ToIssueFilterList converts list of IssueFilter to API format
+func ToIssueFilterList(filters []*issues_model.IssueFilter, repo *repo_model.Repository) []*api.IssueFilter {
+	result := make([]*api.IssueFilter, len(filters))
+	for i := range filters {
+		result[i] = ToIssueFilter(filters[i], repo)
+	}
+	return result
+}
+
 // ToAPIMilestone converts Milestone into API Format
 func ToAPIMilestone(m *issues_model.Milestone) *api.Milestone {
 	apiMilestone := &api.Milestone{
@@ -233,3 +327,36 @@ func ToAPIMilestone(m *issues_model.Milestone) *api.Milestone {
 	}
 	return apiMilestone
 }
+
+// ToAPIComponent converts a component of a repository to API format
+func ToAPIComponent(c *issues_model.Component) *api.Component {
+	return &api.Component{
+		ID:           c.ID,
+		Name:         c.Name,
+		Description:  c.Description,
+		Paths:        c.Paths,
+		OwnerUserIDs: c.OwnerUserIDs,
+		OwnerTeamIDs: c.OwnerTeamIDs,
+		LabelIDs:     c.LabelIDs,
+		NotifyOwners: c.NotifyOwners,
+		OpenIssues:   c.NumOpenIssues,
+		OpenPulls:    c.NumOpenPulls,
+		Created:      c.CreatedUnix.AsTime(),
+		Updated:      c.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToAPIIssueMirror converts an IssueMirror to API format
+func ToAPIIssueMirror(m *issues_model.IssueMirror) *api.IssueMirror {
+	apiMirror := &api.IssueMirror{
+		Service:       m.ServiceType.Name(),
+		RemoteAddress: m.RemoteAddress,
+		LastError:     m.LastError,
+		Created:       m.CreatedUnix.AsTime(),
+	}
+	if m.LastSyncUnix > 0 {
+		lastSync := m.LastSyncUnix.AsTime()
+		apiMirror.LastSync = &lastSync
+	}
+	return apiMirror
+}
@@ -22,6 +22,7 @@ func ToComment(c *issues_model.Comment) *api.Comment {
 		IssueURL: c.IssueURL(),
 		PRURL:    c.PRURL(),
 		Body:     c.Content,
+		IsHidden: c.IsHidden,
 		Created:  c.CreatedUnix.AsTime(),
 		Updated:  c.UpdatedUnix.AsTime(),
 	}
@@ -172,3 +173,16 @@ func ToTimelineComment(c *issues_model.Comment, doer *user_model.User) *api.Time
 
 	return comment
 }
+
+// ToAPIContentHistory converts a revision of an edit history to api.ContentHistory
+func ToAPIContentHistory(history *issues_model.ContentHistory, editor, doer *user_model.User, diff string) *api.ContentHistory {
+	return &api.ContentHistory{
+		ID:             history.ID,
+		Editor:         ToUser(editor, doer),
+		Edited:         history.EditedUnix.AsTime(),
+		IsFirstCreated: history.IsFirstCreated,
+		IsDeleted:      history.IsDeleted,
+		Content:        history.ContentText,
+		Diff:           diff,
+	}
+}
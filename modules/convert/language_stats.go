@@ -0,0 +1,29 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package convert
+
+import (
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToLanguageStatsSnapshot convert a repo_model.LanguageStatsSnapshot to an api.LanguageStatsSnapshot
+func ToLanguageStatsSnapshot(snapshot *repo_model.LanguageStatsSnapshot) *api.LanguageStatsSnapshot {
+	return &api.LanguageStatsSnapshot{
+		Date:      snapshot.Day.AsTime().UTC(),
+		CommitID:  snapshot.CommitID,
+		Languages: snapshot.Sizes,
+	}
+}
+
+// ToLanguageTrend convert a repo_model.LanguageTrend to an api.LanguageTrend
+func ToLanguageTrend(trend *repo_model.LanguageTrend) *api.LanguageTrend {
+	return &api.LanguageTrend{
+		Date:         trend.Day.AsTime().UTC(),
+		Language:     trend.Language,
+		Size:         trend.Size,
+		Repositories: trend.NumRepos,
+	}
+}
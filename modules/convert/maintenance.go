@@ -0,0 +1,42 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package convert
+
+import (
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+func toMaintenanceReportEntries(entries []*repo_model.MaintenanceReportEntry) []*api.MaintenanceReportEntry {
+	apiEntries := make([]*api.MaintenanceReportEntry, 0, len(entries))
+	for _, entry := range entries {
+		apiEntry := &api.MaintenanceReportEntry{
+			Path:  entry.Path,
+			Email: entry.Email,
+		}
+		if entry.LastCommitUnix > 0 {
+			lastCommit := entry.LastCommitUnix.AsTime()
+			apiEntry.LastCommit = &lastCommit
+		}
+		apiEntries = append(apiEntries, apiEntry)
+	}
+	return apiEntries
+}
+
+// ToMaintenanceReport convert a repo_model.MaintenanceReport of a repository to an api.MaintenanceReport
+func ToMaintenanceReport(report *repo_model.MaintenanceReport, repo *repo_model.Repository) *api.MaintenanceReport {
+	return &api.MaintenanceReport{
+		Repository:           toRepositoryMeta(repo),
+		CommitID:             report.CommitID,
+		StaleBefore:          report.StaleBeforeUnix.AsTime(),
+		NumSingleAuthorFiles: report.NumSingleAuthorFiles,
+		NumStaleDirectories:  report.NumStaleDirectories,
+		NumStaleManifests:    report.NumStaleManifests,
+		SingleAuthorFiles:    toMaintenanceReportEntries(report.SingleAuthorFiles),
+		StaleDirectories:     toMaintenanceReportEntries(report.StaleDirectories),
+		StaleManifests:       toMaintenanceReportEntries(report.StaleManifests),
+		Updated:              report.UpdatedUnix.AsTime(),
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package convert
+
+import (
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+func toRepositoryMeta(repo *repo_model.Repository) *api.RepositoryMeta {
+	if repo == nil {
+		return nil
+	}
+	return &api.RepositoryMeta{
+		ID:       repo.ID,
+		Name:     repo.Name,
+		Owner:    repo.OwnerName,
+		FullName: repo.FullName(),
+	}
+}
+
+// ToModerationReport convert moderation_model.Report to api.ModerationReport, the attributes of the report must be loaded
+func ToModerationReport(r *moderation_model.Report, doer *user_model.User) *api.ModerationReport {
+	report := &api.ModerationReport{
+		ID:           r.ID,
+		Reporter:     ToUser(r.Reporter, doer),
+		Repository:   toRepositoryMeta(r.Repo),
+		CommentID:    r.CommentID,
+		ReportedUser: ToUser(r.Poster, doer),
+		Category:     string(r.Category),
+		Remark:       r.Remark,
+		Status:       string(r.Status),
+		Created:      r.CreatedUnix.AsTime(),
+		Updated:      r.UpdatedUnix.AsTime(),
+	}
+	if r.Issue != nil {
+		report.IssueIndex = r.Issue.Index
+	}
+	if r.Resolver != nil {
+		report.Resolver = ToUser(r.Resolver, doer)
+	}
+	return report
+}
+
+// ToModerationLogEntry convert moderation_model.Log to api.ModerationLogEntry, the attributes of the entry must be loaded
+func ToModerationLogEntry(l *moderation_model.Log, doer *user_model.User) *api.ModerationLogEntry {
+	entry := &api.ModerationLogEntry{
+		ID:         l.ID,
+		Moderator:  ToUser(l.Moderator, doer),
+		Action:     string(l.Action),
+		Repository: toRepositoryMeta(l.Repo),
+		CommentID:  l.CommentID,
+		ReportID:   l.ReportID,
+		Reason:     l.Reason,
+		Created:    l.CreatedUnix.AsTime(),
+	}
+	if l.Issue != nil {
+		entry.IssueIndex = l.Issue.Index
+	}
+	if l.TargetUser != nil {
+		entry.TargetUser = ToUser(l.TargetUser, doer)
+	}
+	return entry
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package convert
+
+import (
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+func average(total, n int64) int64 {
+	if n == 0 {
+		return 0
+	}
+	return total / n
+}
+
+func toOrgInsightMetrics(in *activities_model.OrgInsight) (*api.OrgInsightPulls, *api.OrgInsightReviews, *api.OrgInsightIssues, *api.OrgInsightStatuses) {
+	pulls := &api.OrgInsightPulls{
+		Opened:           in.PullsOpened,
+		Merged:           in.PullsMerged,
+		AverageMergeTime: average(in.MergeTimeSum, in.PullsMerged),
+	}
+	reviews := &api.OrgInsightReviews{
+		Reviewed:       in.PullsReviewed,
+		AverageLatency: average(in.ReviewLatencySum, in.PullsReviewed),
+	}
+	issues := &api.OrgInsightIssues{
+		Opened: in.IssuesOpened,
+		Closed: in.IssuesClosed,
+		OpenAges: &api.OrgInsightIssueAges{
+			Week:    in.IssuesOpenWeek,
+			Month:   in.IssuesOpenMonth,
+			Quarter: in.IssuesOpenQuarter,
+			Year:    in.IssuesOpenYear,
+			Older:   in.IssuesOpenOlder,
+		},
+	}
+	statuses := &api.OrgInsightStatuses{
+		Succeeded: in.StatusesSucceeded,
+		Failed:    in.StatusesFailed,
+	}
+	if total := in.StatusesSucceeded + in.StatusesFailed; total > 0 {
+		statuses.SuccessRate = float64(in.StatusesSucceeded) / float64(total)
+	}
+	return pulls, reviews, issues, statuses
+}
+
+// ToOrgInsight convert an activities_model.OrgInsight to an api.OrgInsight
+func ToOrgInsight(in *activities_model.OrgInsight) *api.OrgInsight {
+	apiInsight := &api.OrgInsight{Date: in.Day.AsTime().UTC()}
+	apiInsight.Pulls, apiInsight.Reviews, apiInsight.Issues, apiInsight.Statuses = toOrgInsightMetrics(in)
+	return apiInsight
+}
+
+// ToOrgInsightSummary convert the daily insights of a period to an api.OrgInsightSummary
+func ToOrgInsightSummary(insights activities_model.OrgInsightList, since, before time.Time) *api.OrgInsightSummary {
+	summary := &api.OrgInsightSummary{
+		Since:  since,
+		Before: before,
+		Days:   len(insights),
+	}
+	summary.Pulls, summary.Reviews, summary.Issues, summary.Statuses = toOrgInsightMetrics(insights.Sum())
+	return summary
+}
@@ -74,7 +74,8 @@ func ToAPIPullRequest(ctx context.Context, pr *issues_model.PullRequest, doer *u
 		Created:   pr.Issue.CreatedUnix.AsTimePtr(),
 		Updated:   pr.Issue.UpdatedUnix.AsTimePtr(),
 
-		AllowMaintainerEdit: pr.AllowMaintainerEdit,
+		AllowMaintainerEdit:    pr.AllowMaintainerEdit,
+		DisableReviewReminders: pr.DisableReviewReminders,
 
 		Base: &api.PRBranchInfo{
 			Name:       pr.BaseBranch,
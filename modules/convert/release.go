@@ -46,3 +46,17 @@ func ToReleaseAttachment(a *repo_model.Attachment) *api.Attachment {
 		DownloadURL:   a.DownloadURL(),
 	}
 }
+
+// ToReleaseBuild converts a repo_model.ReleaseBuild, with its builder loaded, to api.ReleaseBuild
+func ToReleaseBuild(b *repo_model.ReleaseBuild) *api.ReleaseBuild {
+	return &api.ReleaseBuild{
+		ID:          b.ID,
+		TagName:     b.TagName,
+		ReleaseID:   b.ReleaseID,
+		Builder:     ToUser(b.Builder, nil),
+		Status:      string(b.Status),
+		Description: b.Description,
+		Created:     b.CreatedUnix.AsTime(),
+		Updated:     b.UpdatedUnix.AsTime(),
+	}
+}
@@ -51,6 +51,14 @@ func innerToRepo(repo *repo_model.Repository, mode perm.AccessMode, isParent boo
 			EnableTimeTracker:                config.EnableTimetracker,
 			AllowOnlyContributorsToTrackTime: config.AllowOnlyContributorsToTrackTime,
 			EnableIssueDependencies:          config.EnableDependencies,
+			StalePolicy: &api.StalePolicy{
+				StaleDays:        config.StaleDays,
+				StaleCloseDays:   config.StaleCloseDays,
+				StaleLabel:       config.GetStaleLabel(),
+				ExemptLabels:     config.GetStaleExemptLabels(),
+				ExemptMilestones: config.StaleExemptMilestones,
+				IncludePulls:     config.StaleIncludePulls,
+			},
 		}
 	} else if unit, err := repo.GetUnit(unit_model.TypeExternalTracker); err == nil {
 		config := unit.ExternalTrackerConfig()
@@ -81,6 +89,12 @@ func innerToRepo(repo *repo_model.Repository, mode perm.AccessMode, isParent boo
 	allowRebaseUpdate := false
 	defaultDeleteBranchAfterMerge := false
 	defaultMergeStyle := repo_model.MergeStyleMerge
+	reviewReminderDays := 0
+	reviewEscalationDays := 0
+	approvalExpiryDays := 0
+	defaultMergeMessageTemplate := ""
+	defaultSquashMessageTemplate := ""
+	enforceMergeMessageTemplate := false
 	if unit, err := repo.GetUnit(unit_model.TypePullRequests); err == nil {
 		config := unit.PullRequestsConfig()
 		hasPullRequests = true
@@ -92,6 +106,12 @@ func innerToRepo(repo *repo_model.Repository, mode perm.AccessMode, isParent boo
 		allowRebaseUpdate = config.AllowRebaseUpdate
 		defaultDeleteBranchAfterMerge = config.DefaultDeleteBranchAfterMerge
 		defaultMergeStyle = config.GetDefaultMergeStyle()
+		reviewReminderDays = config.ReviewReminderDays
+		reviewEscalationDays = config.ReviewEscalationDays
+		approvalExpiryDays = config.ApprovalExpiryDays
+		defaultMergeMessageTemplate = config.DefaultMergeMessageTemplate
+		defaultSquashMessageTemplate = config.DefaultSquashMessageTemplate
+		enforceMergeMessageTemplate = config.EnforceMergeMessageTemplate
 	}
 	hasProjects := false
 	if _, err := repo.GetUnit(unit_model.TypeProjects); err == nil {
@@ -182,6 +202,12 @@ func innerToRepo(repo *repo_model.Repository, mode perm.AccessMode, isParent boo
 		AllowRebaseUpdate:             allowRebaseUpdate,
 		DefaultDeleteBranchAfterMerge: defaultDeleteBranchAfterMerge,
 		DefaultMergeStyle:             string(defaultMergeStyle),
+		ReviewReminderDays:            reviewReminderDays,
+		ReviewEscalationDays:          reviewEscalationDays,
+		ApprovalExpiryDays:            approvalExpiryDays,
+		DefaultMergeMessageTemplate:   defaultMergeMessageTemplate,
+		DefaultSquashMessageTemplate:  defaultSquashMessageTemplate,
+		EnforceMergeMessageTemplate:   enforceMergeMessageTemplate,
 		AvatarURL:                     repo.AvatarLink(),
 		Internal:                      !repo.IsPrivate && repo.Owner.Visibility == api.VisibleTypePrivate,
 		MirrorInterval:                mirrorInterval,
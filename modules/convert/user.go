@@ -54,6 +54,7 @@ func toUser(user *user_model.User, signed, authed bool) *api.User {
 		AvatarURL:   user.AvatarLink(),
 		Created:     user.CreatedUnix.AsTime(),
 		Restricted:  user.IsRestricted,
+		IsBot:       user.IsBot(),
 		Location:    user.Location,
 		Website:     user.Website,
 		Description: user.Description,
@@ -84,7 +85,7 @@ func toUser(user *user_model.User, signed, authed bool) *api.User {
 
 // User2UserSettings return UserSettings based on a user
 func User2UserSettings(user *user_model.User) api.UserSettings {
-	return api.UserSettings{
+	settings := api.UserSettings{
 		FullName:      user.FullName,
 		Website:       user.Website,
 		Location:      user.Location,
@@ -94,7 +95,46 @@ func User2UserSettings(user *user_model.User) api.UserSettings {
 		HideEmail:     user.KeepEmailPrivate,
 		HideActivity:  user.KeepActivityPrivate,
 		DiffViewStyle: user.DiffViewStyle,
+
+		ReviewCapacity: user.ReviewCapacity,
+		OutOfOffice:    user.OutOfOffice,
+	}
+	if user.OutOfOfficeUntil != 0 {
+		settings.OutOfOfficeUntil = user.OutOfOfficeUntil.AsTimePtr()
 	}
+	return settings
+}
+
+// ToUserStatus converts user_model.Status to api.UserStatus
+func ToUserStatus(status *user_model.Status) *api.UserStatus {
+	apiStatus := &api.UserStatus{
+		Emoji:   status.Emoji,
+		Message: status.Message,
+		Busy:    status.Busy,
+	}
+	if status.ExpiresUnix != 0 {
+		apiStatus.ExpiresAt = status.ExpiresUnix.AsTimePtr()
+	}
+	return apiStatus
+}
+
+// ToBadge convert user_model.Badge to api.Badge
+func ToBadge(b *user_model.Badge) *api.Badge {
+	return &api.Badge{
+		ID:          b.ID,
+		Slug:        b.Slug,
+		Description: b.Description,
+		ImageURL:    b.ImageLink(),
+	}
+}
+
+// ToBadges convert a list of user_model.Badge to a list of api.Badge
+func ToBadges(badges []*user_model.Badge) []*api.Badge {
+	result := make([]*api.Badge, len(badges))
+	for i, b := range badges {
+		result[i] = ToBadge(b)
+	}
+	return result
 }
 
 // ToUserAndPermission return User and its collaboration permission for a repository
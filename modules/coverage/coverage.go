@@ -0,0 +1,186 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package coverage parses line coverage reports in the lcov and cobertura formats.
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported report formats
+const (
+	FormatLcov      = "lcov"
+	FormatCobertura = "cobertura"
+)
+
+var (
+	// ErrUnknownFormat is returned for unsupported report formats
+	ErrUnknownFormat = errors.New("unknown coverage report format")
+	// ErrInvalidReport is returned if the report can not be parsed
+	ErrInvalidReport = errors.New("invalid coverage report")
+)
+
+// File holds the hit counts of the instrumented lines of a source file
+type File struct {
+	Path  string
+	Lines map[int]int64
+}
+
+// CoveredLines returns the sorted instrumented lines which were hit
+func (f *File) CoveredLines() []int {
+	return f.lines(true)
+}
+
+// UncoveredLines returns the sorted instrumented lines which were not hit
+func (f *File) UncoveredLines() []int {
+	return f.lines(false)
+}
+
+func (f *File) lines(covered bool) []int {
+	lines := make([]int, 0, len(f.Lines))
+	for line, hits := range f.Lines {
+		if (hits > 0) == covered {
+			lines = append(lines, line)
+		}
+	}
+	sort.Ints(lines)
+	return lines
+}
+
+// Report represents the line coverage of a commit
+type Report struct {
+	Files map[string]*File
+}
+
+func (r *Report) addLine(file string, line int, hits int64) {
+	file = cleanPath(file)
+	if file == "" || line <= 0 {
+		return
+	}
+	f, ok := r.Files[file]
+	if !ok {
+		f = &File{Path: file, Lines: make(map[int]int64)}
+		r.Files[file] = f
+	}
+	f.Lines[line] += hits
+}
+
+// Decode parses a base64 encoded and optionally gzip compressed report of the given format
+func Decode(format, encoded string) (*Report, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidReport
+	}
+
+	var r io.Reader = bytes.NewReader(data)
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, ErrInvalidReport
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return Parse(format, r)
+}
+
+// Parse parses a report of the given format
+func Parse(format string, r io.Reader) (*Report, error) {
+	switch format {
+	case FormatLcov:
+		return ParseLcov(r)
+	case FormatCobertura:
+		return ParseCobertura(r)
+	}
+	return nil, ErrUnknownFormat
+}
+
+// ParseLcov parses a report in the lcov tracefile format
+func ParseLcov(r io.Reader) (*Report, error) {
+	report := &Report{Files: make(map[string]*File)}
+
+	var file string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			file = line[3:]
+		case strings.HasPrefix(line, "DA:"):
+			if file == "" {
+				return nil, ErrInvalidReport
+			}
+			fields := strings.Split(line[3:], ",")
+			if len(fields) < 2 {
+				return nil, ErrInvalidReport
+			}
+			number, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return nil, ErrInvalidReport
+			}
+			hits, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return nil, ErrInvalidReport
+			}
+			report.addLine(file, number, hits)
+		case line == "end_of_record":
+			file = ""
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, ErrInvalidReport
+	}
+	return report, nil
+}
+
+type coberturaReport struct {
+	XMLName  xml.Name `xml:"coverage"`
+	Packages []struct {
+		Classes []struct {
+			Filename string `xml:"filename,attr"`
+			Lines    []struct {
+				Number int   `xml:"number,attr"`
+				Hits   int64 `xml:"hits,attr"`
+			} `xml:"lines>line"`
+		} `xml:"classes>class"`
+	} `xml:"packages>package"`
+}
+
+// ParseCobertura parses a report in the cobertura XML format
+func ParseCobertura(r io.Reader) (*Report, error) {
+	var cobertura coberturaReport
+	if err := xml.NewDecoder(r).Decode(&cobertura); err != nil {
+		return nil, ErrInvalidReport
+	}
+
+	report := &Report{Files: make(map[string]*File)}
+	for _, pkg := range cobertura.Packages {
+		for _, class := range pkg.Classes {
+			for _, line := range class.Lines {
+				report.addLine(class.Filename, line.Number, line.Hits)
+			}
+		}
+	}
+	return report, nil
+}
+
+func cleanPath(p string) string {
+	p = strings.TrimPrefix(p, "file://")
+	p = path.Clean(strings.ReplaceAll(p, "\\", "/"))
+	if p == "." {
+		return ""
+	}
+	return strings.TrimPrefix(p, "./")
+}
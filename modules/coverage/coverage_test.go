@@ -0,0 +1,70 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package coverage
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLcov(t *testing.T) {
+	report, err := ParseLcov(strings.NewReader(`TN:
+SF:./modules/util/util.go
+DA:1,1
+DA:2,0
+DA:3,4,abcdef
+end_of_record
+SF:main.go
+DA:10,0
+end_of_record
+`))
+	assert.NoError(t, err)
+	if assert.Len(t, report.Files, 2) {
+		assert.Equal(t, []int{1, 3}, report.Files["modules/util/util.go"].CoveredLines())
+		assert.Equal(t, []int{2}, report.Files["modules/util/util.go"].UncoveredLines())
+		assert.Equal(t, []int{10}, report.Files["main.go"].UncoveredLines())
+	}
+
+	_, err = ParseLcov(strings.NewReader("DA:1,1\n"))
+	assert.ErrorIs(t, err, ErrInvalidReport)
+}
+
+func TestParseCobertura(t *testing.T) {
+	report, err := ParseCobertura(strings.NewReader(`<?xml version="1.0" ?>
+<coverage line-rate="0.5">
+	<packages>
+		<package name="util">
+			<classes>
+				<class name="util.go" filename="modules/util/util.go">
+					<lines>
+						<line number="1" hits="2"/>
+						<line number="2" hits="0"/>
+					</lines>
+				</class>
+			</classes>
+		</package>
+	</packages>
+</coverage>`))
+	assert.NoError(t, err)
+	if assert.Len(t, report.Files, 1) {
+		assert.Equal(t, []int{1}, report.Files["modules/util/util.go"].CoveredLines())
+		assert.Equal(t, []int{2}, report.Files["modules/util/util.go"].UncoveredLines())
+	}
+
+	_, err = ParseCobertura(strings.NewReader("<html></html>"))
+	assert.ErrorIs(t, err, ErrInvalidReport)
+}
+
+func TestDecode(t *testing.T) {
+	_, err := Decode("jacoco", "")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+
+	report, err := Decode(FormatLcov, base64.StdEncoding.EncodeToString([]byte("SF:a.go\nDA:1,1\nend_of_record\n")))
+	assert.NoError(t, err)
+	assert.Len(t, report.Files, 1)
+}
@@ -0,0 +1,122 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dependency
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigPaths are the paths of the dependency update configuration in a repository, the first existing one is used
+var ConfigPaths = []string{".gitea/dependency-updates.yaml", ".gitea/dependency-updates.yml"}
+
+// Group bundles the updates of all dependencies matching one of its patterns into one pull request
+type Group struct {
+	Name     string   `yaml:"name"`
+	Patterns []string `yaml:"patterns"`
+}
+
+// Config represents the dependency update configuration of a repository
+type Config struct {
+	// Schedule lists the weekdays on which updates are proposed, empty means every day
+	Schedule []string `yaml:"schedule"`
+	// Manifests lists the manifest files to check, defaults to the known manifests in the repository root
+	Manifests []string `yaml:"manifests"`
+	// Automerge merges the update pull requests once their status checks succeeded
+	Automerge bool `yaml:"automerge"`
+	// MergeStyle is the merge style used for auto merging: merge, rebase, rebase-merge or squash
+	MergeStyle string `yaml:"merge_style"`
+	// Groups bundle the updates of several dependencies, every other dependency gets its own pull request
+	Groups []*Group `yaml:"groups"`
+	// Ignore lists patterns of dependencies which are never updated
+	Ignore []string `yaml:"ignore"`
+	// Labels are added to the update pull requests if they exist in the repository
+	Labels []string `yaml:"labels"`
+}
+
+// ParseConfig parses and validates a dependency update configuration
+func ParseConfig(content []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return nil, err
+	}
+	for _, day := range cfg.Schedule {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return nil, fmt.Errorf("invalid schedule day: %s", day)
+		}
+	}
+	switch cfg.MergeStyle {
+	case "", "merge", "rebase", "rebase-merge", "squash":
+	default:
+		return nil, fmt.Errorf("invalid merge style: %s", cfg.MergeStyle)
+	}
+	for _, group := range cfg.Groups {
+		if group.Name == "" {
+			return nil, fmt.Errorf("dependency group without a name")
+		}
+		for _, pattern := range group.Patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q of group %s: %w", pattern, group.Name, err)
+			}
+		}
+	}
+	for _, pattern := range cfg.Ignore {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %w", pattern, err)
+		}
+	}
+	return cfg, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// IsScheduled returns true if updates may be proposed at the given time
+func (cfg *Config) IsScheduled(t time.Time) bool {
+	if len(cfg.Schedule) == 0 {
+		return true
+	}
+	for _, day := range cfg.Schedule {
+		if weekdays[strings.ToLower(day)] == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsIgnored returns true if the dependency must not be updated
+func (cfg *Config) IsIgnored(name string) bool {
+	return matchAny(cfg.Ignore, name)
+}
+
+// GroupName returns the name of the group the dependency belongs to or an empty string
+func (cfg *Config) GroupName(name string) string {
+	for _, group := range cfg.Groups {
+		if matchAny(group.Patterns, name) {
+			return group.Name
+		}
+	}
+	return ""
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
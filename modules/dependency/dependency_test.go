@@ -0,0 +1,102 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dependency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+schedule: [monday, Thursday]
+automerge: true
+groups:
+  - name: vue
+    patterns: ["vue", "@vue/*"]
+ignore: ["left-pad"]
+`))
+	assert.NoError(t, err)
+	assert.True(t, cfg.Automerge)
+	assert.True(t, cfg.IsScheduled(time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC)))  // monday
+	assert.False(t, cfg.IsScheduled(time.Date(2022, 8, 2, 0, 0, 0, 0, time.UTC))) // tuesday
+	assert.True(t, cfg.IsScheduled(time.Date(2022, 8, 4, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "vue", cfg.GroupName("@vue/compiler-sfc"))
+	assert.Equal(t, "", cfg.GroupName("vuex"))
+	assert.True(t, cfg.IsIgnored("left-pad"))
+
+	_, err = ParseConfig([]byte(`schedule: [someday]`))
+	assert.Error(t, err)
+	_, err = ParseConfig([]byte(`groups: [{patterns: ["*"]}]`))
+	assert.Error(t, err)
+	_, err = ParseConfig([]byte(`merge_style: fast-forward`))
+	assert.Error(t, err)
+}
+
+func TestPackageJSON(t *testing.T) {
+	content := []byte(`{
+  "name": "test",
+  "dependencies": {
+    "vue": "^3.2.37",
+    "left-pad": "1.3.0",
+    "local": "file:../local"
+  },
+  "devDependencies": {
+    "eslint": "~8.20.0",
+    "range": ">=1.0.0 <2.0.0"
+  }
+}
+`)
+	deps, err := ParseManifest("web/package.json", content)
+	assert.NoError(t, err)
+	if assert.Len(t, deps, 3) {
+		assert.Equal(t, &Dependency{Manifest: "web/package.json", Ecosystem: EcosystemNpm, Name: "eslint", Constraint: "~8.20.0", Version: "8.20.0"}, deps[0])
+		assert.Equal(t, "left-pad", deps[1].Name)
+		assert.Equal(t, "vue", deps[2].Name)
+	}
+
+	updated, err := UpdateManifest(content, map[*Dependency]string{deps[0]: "8.21.0", deps[2]: "3.2.38"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(updated), `"vue": "^3.2.38"`)
+	assert.Contains(t, string(updated), `"eslint": "~8.21.0"`)
+	assert.Contains(t, string(updated), `"left-pad": "1.3.0"`)
+}
+
+func TestRequirements(t *testing.T) {
+	content := []byte(`# comment
+requests[security]==2.28.0 ; python_version > "3.6"
+Django==4.0.6
+flask>=2.0
+-r other.txt
+`)
+	deps, err := ParseManifest("requirements.txt", content)
+	assert.NoError(t, err)
+	if assert.Len(t, deps, 2) {
+		assert.Equal(t, "Django", deps[0].Name)
+		assert.Equal(t, "4.0.6", deps[0].Version)
+		assert.Equal(t, "requests", deps[1].Name)
+		assert.Equal(t, "==2.28.0", deps[1].Constraint)
+	}
+
+	updated, err := UpdateManifest(content, map[*Dependency]string{deps[1]: "2.28.1"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(updated), "requests[security]==2.28.1 ;")
+
+	_, err = ParseManifest("Cargo.toml", nil)
+	assert.ErrorIs(t, err, ErrUnknownManifest)
+}
+
+func TestVersions(t *testing.T) {
+	versions := []string{"1.0.0", "1.2.0", "1.10.0", "2.0.0-rc.1", "1.1.0"}
+	assert.Equal(t, "1.10.0", LatestVersion(versions))
+	assert.Equal(t, []string{"1.1.0", "1.2.0", "1.10.0"}, VersionsBetween(versions, "1.0.0", "1.10.0"))
+
+	dep := &Dependency{Constraint: "^1.2.0", Version: "1.2.0"}
+	assert.True(t, dep.IsOutdatedBy("1.10.0"))
+	assert.False(t, dep.IsOutdatedBy("1.2.0"))
+	assert.Equal(t, "^1.10.0", dep.WithVersion("1.10.0"))
+}
@@ -0,0 +1,215 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dependency
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Ecosystem is the package ecosystem of a manifest
+type Ecosystem string
+
+// Supported ecosystems
+const (
+	EcosystemNpm  Ecosystem = "npm"
+	EcosystemPyPI Ecosystem = "pypi"
+)
+
+var manifestNames = map[Ecosystem]string{
+	EcosystemNpm:  "package.json",
+	EcosystemPyPI: "requirements.txt",
+}
+
+// ErrUnknownManifest is returned for files which are not a supported manifest
+var ErrUnknownManifest = errors.New("unknown manifest")
+
+// DefaultManifests returns the manifests checked if the configuration does not list any
+func DefaultManifests() []string {
+	manifests := make([]string, 0, len(manifestNames))
+	for _, name := range manifestNames {
+		manifests = append(manifests, name)
+	}
+	sort.Strings(manifests)
+	return manifests
+}
+
+// EcosystemOf returns the ecosystem of the manifest at the path
+func EcosystemOf(manifest string) (Ecosystem, error) {
+	base := path.Base(manifest)
+	for ecosystem, name := range manifestNames {
+		if base == name {
+			return ecosystem, nil
+		}
+	}
+	return "", ErrUnknownManifest
+}
+
+// Dependency represents a dependency pinned to a version in a manifest
+type Dependency struct {
+	Manifest   string
+	Ecosystem  Ecosystem
+	Name       string
+	Constraint string // the constraint as written in the manifest
+	Version    string // the version of the constraint without its operator
+}
+
+// WithVersion returns the constraint of the dependency changed to the given version
+func (d *Dependency) WithVersion(v string) string {
+	return strings.TrimSuffix(d.Constraint, d.Version) + v
+}
+
+// IsOutdatedBy returns true if the given version is newer than the version of the dependency
+func (d *Dependency) IsOutdatedBy(v string) bool {
+	current, err := version.NewVersion(d.Version)
+	if err != nil {
+		return false
+	}
+	candidate, err := version.NewVersion(v)
+	if err != nil {
+		return false
+	}
+	return candidate.GreaterThan(current)
+}
+
+// LatestVersion returns the newest stable version of the list
+func LatestVersion(versions []string) string {
+	var latest *version.Version
+	var latestRaw string
+	for _, raw := range versions {
+		v, err := version.NewVersion(raw)
+		if err != nil || v.Prerelease() != "" || v.Metadata() != "" {
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest) {
+			latest, latestRaw = v, raw
+		}
+	}
+	return latestRaw
+}
+
+// VersionsBetween returns the stable versions of the list which are newer than from and not newer than to, oldest first
+func VersionsBetween(versions []string, from, to string) []string {
+	fromVersion, err := version.NewVersion(from)
+	if err != nil {
+		return nil
+	}
+	toVersion, err := version.NewVersion(to)
+	if err != nil {
+		return nil
+	}
+	type parsed struct {
+		raw string
+		v   *version.Version
+	}
+	result := make([]parsed, 0, 5)
+	for _, raw := range versions {
+		v, err := version.NewVersion(raw)
+		if err != nil || v.Prerelease() != "" || !v.GreaterThan(fromVersion) || v.GreaterThan(toVersion) {
+			continue
+		}
+		result = append(result, parsed{raw, v})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].v.LessThan(result[j].v) })
+	raws := make([]string, len(result))
+	for i, p := range result {
+		raws[i] = p.raw
+	}
+	return raws
+}
+
+// ParseManifest returns the dependencies of a manifest which are pinned to a version that can be updated
+func ParseManifest(manifest string, content []byte) ([]*Dependency, error) {
+	ecosystem, err := EcosystemOf(manifest)
+	if err != nil {
+		return nil, err
+	}
+	var deps []*Dependency
+	switch ecosystem {
+	case EcosystemNpm:
+		deps, err = parsePackageJSON(content)
+	case EcosystemPyPI:
+		deps = parseRequirements(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, dep := range deps {
+		dep.Manifest = manifest
+		dep.Ecosystem = ecosystem
+	}
+	sort.SliceStable(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+	return deps, nil
+}
+
+// UpdateManifest changes the constraints of the dependencies in the manifest content to the given versions
+func UpdateManifest(content []byte, updates map[*Dependency]string) ([]byte, error) {
+	result := string(content)
+	for dep, v := range updates {
+		var pattern *regexp.Regexp
+		var replacement string
+		switch dep.Ecosystem {
+		case EcosystemNpm:
+			pattern = regexp.MustCompile(`("` + regexp.QuoteMeta(dep.Name) + `"\s*:\s*")` + regexp.QuoteMeta(dep.Constraint) + `"`)
+			replacement = "${1}" + dep.WithVersion(v) + `"`
+		case EcosystemPyPI:
+			pattern = regexp.MustCompile(`(?m)^(` + regexp.QuoteMeta(dep.Name) + `(?:\[[^\]]*\])?\s*==\s*)` + regexp.QuoteMeta(dep.Version) + `(\s|;|#|$)`)
+			replacement = "${1}" + v + "${2}"
+		default:
+			return nil, ErrUnknownManifest
+		}
+		if !pattern.MatchString(result) {
+			return nil, fmt.Errorf("dependency %s@%s not found in %s", dep.Name, dep.Constraint, dep.Manifest)
+		}
+		result = pattern.ReplaceAllString(result, replacement)
+	}
+	return []byte(result), nil
+}
+
+// npm constraints which pin a release or a compatible range of it
+var npmConstraintPattern = regexp.MustCompile(`^[\^~=]?v?(\d+\.\d+\.\d+)$`)
+
+func parsePackageJSON(content []byte) ([]*Dependency, error) {
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, err
+	}
+	deps := make([]*Dependency, 0, len(pkg.Dependencies)+len(pkg.DevDependencies))
+	for _, m := range []map[string]string{pkg.Dependencies, pkg.DevDependencies} {
+		for name, constraint := range m {
+			match := npmConstraintPattern.FindStringSubmatch(constraint)
+			if match == nil {
+				continue
+			}
+			deps = append(deps, &Dependency{Name: name, Constraint: constraint, Version: match[1]})
+		}
+	}
+	return deps, nil
+}
+
+// pinned requirements, e.g. "requests[security]==2.28.1 ; python_version > '3.6'"
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(?:\[[^\]]*\])?\s*==\s*([0-9][0-9A-Za-z.]*)\s*(?:[;#].*)?$`)
+
+func parseRequirements(content []byte) []*Dependency {
+	deps := make([]*Dependency, 0, 10)
+	for _, line := range strings.Split(string(content), "\n") {
+		match := requirementPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		deps = append(deps, &Dependency{Name: match[1], Constraint: "==" + match[2], Version: match[2]})
+	}
+	return deps
+}
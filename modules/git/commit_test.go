@@ -107,6 +107,13 @@ empty commit`, commitFromReader.Signature.Payload)
 	assert.EqualValues(t, commitFromReader, commitFromReader2)
 }
 
+func TestComputeCommitHash(t *testing.T) {
+	raw, _, err := NewCommand(DefaultContext, "cat-file", "commit", "feaf4ba6bc635fec442f46ddd4512416ec43c2c2").
+		RunStdBytes(&RunOpts{Dir: filepath.Join(testReposDir, "repo1_bare")})
+	assert.NoError(t, err)
+	assert.Equal(t, "feaf4ba6bc635fec442f46ddd4512416ec43c2c2", ComputeCommitHash(raw).String())
+}
+
 func TestHasPreviousCommit(t *testing.T) {
 	bareRepo1Path := filepath.Join(testReposDir, "repo1_bare")
 
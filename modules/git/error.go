@@ -176,3 +176,19 @@ func IsErrMoreThanOne(err error) bool {
 func (err *ErrMoreThanOne) Error() string {
 	return fmt.Sprintf("ErrMoreThanOne Error: %v: %s\n%s", err.Err, err.StdErr, err.StdOut)
 }
+
+// ErrInvalidGrepPattern represents an error if git grep fails to compile the pattern of a search
+type ErrInvalidGrepPattern struct {
+	Pattern string
+	Message string
+}
+
+// IsErrInvalidGrepPattern checks if an error is a ErrInvalidGrepPattern
+func IsErrInvalidGrepPattern(err error) bool {
+	_, ok := err.(ErrInvalidGrepPattern)
+	return ok
+}
+
+func (err ErrInvalidGrepPattern) Error() string {
+	return fmt.Sprintf("invalid grep pattern [pattern: %s]: %s", err.Pattern, err.Message)
+}
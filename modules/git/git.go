@@ -293,6 +293,16 @@ func syncGitConfig() (err error) {
 		}
 		err = configUnsetAll("uploadpack.allowAnySHA1InWant", "true")
 	}
+	if err != nil {
+		return err
+	}
+
+	// The object-info command of protocol v2 is always advertised by git before v2.42, it has to be enabled later on
+	if setting.Git.EnableObjectInfo {
+		err = configSet("transfer.advertiseObjectInfo", "true")
+	} else {
+		err = configUnsetAll("transfer.advertiseObjectInfo", "true")
+	}
 
 	return err
 }
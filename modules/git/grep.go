@@ -0,0 +1,174 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GrepOptions represents the options of a grep search in the files of a commit
+type GrepOptions struct {
+	Pattern       string // extended regular expression
+	IgnoreCase    bool
+	PathSpecs     []string
+	MaxResults    int
+	ContextLines  int
+	MaxLineLength int
+	Timeout       time.Duration
+}
+
+// GrepMatch represents a line of a file matching a grep search, with the lines around it
+type GrepMatch struct {
+	Filename      string
+	LineNumber    int
+	Line          string
+	ContextBefore []string
+	ContextAfter  []string
+}
+
+// GrepResult represents the lines matching a grep search, it is truncated when the search reached the maximum
+// number of results or timed out
+type GrepResult struct {
+	Matches   []*GrepMatch
+	Truncated bool
+}
+
+// grepLine is a line of the output of git grep --heading --break --line-number
+type grepLine struct {
+	Number  int
+	Text    string
+	IsMatch bool
+}
+
+var errGrepMaxResults = errors.New("grep reached the maximum number of results")
+
+// Grep searches the lines matching the extended regular expression of the options in the files of a commit
+func (repo *Repository) Grep(commitID string, opts GrepOptions) (*GrepResult, error) {
+	cmd := NewCommand(repo.Ctx, "-c", "core.quotePath=false", "grep", "--heading", "--break", "--line-number", "--full-name", "-I", "--extended-regexp")
+	if opts.IgnoreCase {
+		cmd.AddArguments("--ignore-case")
+	}
+	if opts.ContextLines > 0 {
+		cmd.AddArguments("--context", strconv.Itoa(opts.ContextLines))
+	}
+	cmd.AddArguments("-e", opts.Pattern, commitID, "--")
+	cmd.AddArguments(opts.PathSpecs...)
+
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = stdoutReader.Close()
+		_ = stdoutWriter.Close()
+	}()
+
+	result := &GrepResult{}
+	stderr := new(strings.Builder)
+	err = cmd.Run(&RunOpts{
+		Timeout: opts.Timeout,
+		Dir:     repo.Path,
+		Stdout:  stdoutWriter,
+		Stderr:  stderr,
+		PipelineFunc: func(ctx context.Context, cancel context.CancelFunc) error {
+			_ = stdoutWriter.Close()
+			reader := bufio.NewReader(stdoutReader)
+
+			var filename string
+			var hunk []*grepLine
+			matched := 0
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil && line == "" {
+					break
+				}
+				line = strings.TrimSuffix(line, "\n")
+
+				switch {
+				case filename == "":
+					// the heading of a file is its name, prefixed by the commit
+					filename = strings.TrimPrefix(line, commitID+":")
+					if unquoted, err := strconv.Unquote(filename); err == nil && strings.HasPrefix(filename, `"`) {
+						filename = unquoted
+					}
+				case line == "" || line == "--":
+					// an empty line separates the files and "--" the hunks of a file
+					result.Matches = append(result.Matches, grepHunkMatches(filename, hunk, opts.ContextLines)...)
+					hunk = hunk[:0]
+					if line == "" {
+						filename = ""
+					}
+				default:
+					sep := strings.IndexAny(line, ":-")
+					if sep < 0 {
+						continue
+					}
+					number, err := strconv.Atoi(line[:sep])
+					if err != nil {
+						continue
+					}
+					text := line[sep+1:]
+					if opts.MaxLineLength > 0 && len(text) > opts.MaxLineLength {
+						text = strings.ToValidUTF8(text[:opts.MaxLineLength], "")
+					}
+					isMatch := line[sep] == ':'
+					if isMatch {
+						matched++
+						if opts.MaxResults > 0 && matched > opts.MaxResults {
+							// the lines of the previous matches and their context are complete
+							result.Matches = append(result.Matches, grepHunkMatches(filename, hunk, opts.ContextLines)...)
+							return errGrepMaxResults
+						}
+					}
+					hunk = append(hunk, &grepLine{Number: number, Text: text, IsMatch: isMatch})
+				}
+			}
+			result.Matches = append(result.Matches, grepHunkMatches(filename, hunk, opts.ContextLines)...)
+			return nil
+		},
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, errGrepMaxResults), errors.Is(err, context.DeadlineExceeded):
+		result.Truncated = true
+	case strings.HasPrefix(stderr.String(), "fatal: -e option"):
+		return nil, ErrInvalidGrepPattern{Pattern: opts.Pattern, Message: strings.TrimSpace(stderr.String())}
+	default:
+		var exitErr interface{ ExitCode() int }
+		// git grep exits with 1 when nothing matches
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && stderr.Len() == 0 {
+			break
+		}
+		return nil, ConcatenateError(err, stderr.String())
+	}
+
+	return result, nil
+}
+
+// grepHunkMatches returns the matches of a hunk of a file, with at most contextLines lines before and after each
+// match. The lines between two matches are in the context of both.
+func grepHunkMatches(filename string, hunk []*grepLine, contextLines int) []*GrepMatch {
+	var matches []*GrepMatch
+	for i, line := range hunk {
+		if !line.IsMatch {
+			continue
+		}
+		match := &GrepMatch{Filename: filename, LineNumber: line.Number, Line: line.Text}
+		for j := i - 1; j >= 0 && j >= i-contextLines && !hunk[j].IsMatch; j-- {
+			match.ContextBefore = append([]string{hunk[j].Text}, match.ContextBefore...)
+		}
+		for j := i + 1; j < len(hunk) && j <= i+contextLines && !hunk[j].IsMatch; j++ {
+			match.ContextAfter = append(match.ContextAfter, hunk[j].Text)
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_Grep(t *testing.T) {
+	bareRepo1Path := filepath.Join(testReposDir, "repo1_bare")
+	bareRepo1, err := openRepositoryWithDefaultContext(bareRepo1Path)
+	assert.NoError(t, err)
+	defer bareRepo1.Close()
+
+	const commitID = "feaf4ba6bc635fec442f46ddd4512416ec43c2c2"
+
+	result, err := bareRepo1.Grep(commitID, GrepOptions{Pattern: "^file[0-9]$"})
+	assert.NoError(t, err)
+	assert.False(t, result.Truncated)
+	assert.Equal(t, []*GrepMatch{
+		{Filename: "file1.txt", LineNumber: 1, Line: "file1"},
+		{Filename: "file2.txt", LineNumber: 1, Line: "file2"},
+	}, result.Matches)
+
+	result, err = bareRepo1.Grep(commitID, GrepOptions{Pattern: "^HI$", IgnoreCase: true, PathSpecs: []string{"foo"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*GrepMatch{{Filename: "foo/nar/hello", LineNumber: 1, Line: "Hi"}}, result.Matches)
+
+	result, err = bareRepo1.Grep(commitID, GrepOptions{Pattern: "file", MaxResults: 1})
+	assert.NoError(t, err)
+	assert.True(t, result.Truncated)
+	assert.Len(t, result.Matches, 1)
+
+	result, err = bareRepo1.Grep(commitID, GrepOptions{Pattern: "unknown"})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Matches)
+
+	_, err = bareRepo1.Grep(commitID, GrepOptions{Pattern: "("})
+	assert.True(t, IsErrInvalidGrepPattern(err))
+}
+
+func TestGrepHunkMatches(t *testing.T) {
+	hunk := []*grepLine{
+		{Number: 1, Text: "a"},
+		{Number: 2, Text: "b"},
+		{Number: 3, Text: "foo", IsMatch: true},
+		{Number: 4, Text: "c"},
+		{Number: 5, Text: "foo", IsMatch: true},
+		{Number: 6, Text: "d"},
+	}
+	assert.Equal(t, []*GrepMatch{
+		{Filename: "f.txt", LineNumber: 3, Line: "foo", ContextBefore: []string{"b"}, ContextAfter: []string{"c"}},
+		{Filename: "f.txt", LineNumber: 5, Line: "foo", ContextBefore: []string{"c"}, ContextAfter: []string{"d"}},
+	}, grepHunkMatches("f.txt", hunk, 1))
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// PartialCloneFilters are the kinds of partial clone filters which can be allowed in git upload-pack
+var PartialCloneFilters = []string{"blob:none", "blob:limit", "tree", "object:type", "sparse:oid", "combine"}
+
+// PartialCloneFilterKind returns the kind of a filter spec sent by a client, e.g. "tree" for "tree:1",
+// or "unknown" if it isn't a known kind
+func PartialCloneFilterKind(spec string) string {
+	for _, kind := range PartialCloneFilters {
+		if spec == kind || strings.HasPrefix(spec, kind+"=") || strings.HasPrefix(spec, kind+":") {
+			return kind
+		}
+	}
+	return "unknown"
+}
+
+// PartialCloneConfigEnv returns the environment restricting git upload-pack to the allowed filters and
+// to a maximum depth of the tree filter, 0 for unlimited. It returns nothing if there is no restriction,
+// partial clones are disabled or the git version can't take its config from the environment.
+func PartialCloneConfigEnv(allowed []string, treeMaxDepth int) []string {
+	if setting.Git.DisablePartialClone || (len(allowed) == len(PartialCloneFilters) && treeMaxDepth <= 0) {
+		return nil
+	}
+	// GIT_CONFIG_COUNT is supported from git v2.31
+	if CheckGitVersionAtLeast("2.31") != nil {
+		return nil
+	}
+
+	config := [][2]string{{"uploadpackfilter.allow", "false"}}
+	for _, kind := range allowed {
+		config = append(config, [2]string{"uploadpackfilter." + kind + ".allow", "true"})
+	}
+	if treeMaxDepth > 0 {
+		config = append(config, [2]string{"uploadpackfilter.tree.maxDepth", strconv.Itoa(treeMaxDepth)})
+	}
+
+	env := make([]string, 0, 2*len(config)+1)
+	env = append(env, "GIT_CONFIG_COUNT="+strconv.Itoa(len(config)))
+	for i, kv := range config {
+		env = append(env, "GIT_CONFIG_KEY_"+strconv.Itoa(i)+"="+kv[0], "GIT_CONFIG_VALUE_"+strconv.Itoa(i)+"="+kv[1])
+	}
+	return env
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartialCloneFilterKind(t *testing.T) {
+	assert.Equal(t, "blob:none", PartialCloneFilterKind("blob:none"))
+	assert.Equal(t, "blob:limit", PartialCloneFilterKind("blob:limit=1k"))
+	assert.Equal(t, "tree", PartialCloneFilterKind("tree:0"))
+	assert.Equal(t, "object:type", PartialCloneFilterKind("object:type=blob"))
+	assert.Equal(t, "combine", PartialCloneFilterKind("combine:blob:none+tree:1"))
+	assert.Equal(t, "unknown", PartialCloneFilterKind("blob:nonexistent"))
+}
+
+func TestPartialCloneConfigEnv(t *testing.T) {
+	if CheckGitVersionAtLeast("2.31") != nil {
+		t.Skip("git config environment is not supported")
+	}
+
+	assert.Empty(t, PartialCloneConfigEnv(PartialCloneFilters, 0))
+	assert.Equal(t, []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=uploadpackfilter.allow", "GIT_CONFIG_VALUE_0=false",
+	}, PartialCloneConfigEnv(nil, 0))
+	assert.Equal(t, []string{
+		"GIT_CONFIG_COUNT=4",
+		"GIT_CONFIG_KEY_0=uploadpackfilter.allow", "GIT_CONFIG_VALUE_0=false",
+		"GIT_CONFIG_KEY_1=uploadpackfilter.blob:none.allow", "GIT_CONFIG_VALUE_1=true",
+		"GIT_CONFIG_KEY_2=uploadpackfilter.tree.allow", "GIT_CONFIG_VALUE_2=true",
+		"GIT_CONFIG_KEY_3=uploadpackfilter.tree.maxDepth", "GIT_CONFIG_VALUE_3=2",
+	}, PartialCloneConfigEnv([]string{"blob:none", "tree"}, 2))
+
+	setting.Git.DisablePartialClone = true
+	defer func() {
+		setting.Git.DisablePartialClone = false
+	}()
+	assert.Empty(t, PartialCloneConfigEnv(nil, 0))
+}
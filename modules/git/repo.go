@@ -305,6 +305,50 @@ func GetDivergingCommits(ctx context.Context, repoPath, baseBranch, targetBranch
 	return DivergeObject{ahead, behind}, nil
 }
 
+// GetDivergingCommitsBetweenRepos returns the number of commits a targetCommitID of the repository at targetRepoPath
+// is ahead or behind a baseCommitID of the repository at baseRepoPath, e.g. a fork of the target repository
+func GetDivergingCommitsBetweenRepos(ctx context.Context, baseRepoPath, baseCommitID, targetRepoPath, targetCommitID string) (DivergeObject, error) {
+	// the objects of the base repository are made available to the target repository without changing any of them
+	env := append(os.Environ(), "GIT_ALTERNATE_OBJECT_DIRECTORIES="+filepath.Join(baseRepoPath, "objects"))
+
+	// $(git rev-list --left-right --count base...target) commits behind and ahead of base
+	cmd := NewCommand(ctx, "rev-list", "--left-right", "--count", baseCommitID+"..."+targetCommitID)
+	stdout, _, runErr := cmd.RunStdString(&RunOpts{Dir: targetRepoPath, Env: env})
+	if runErr != nil {
+		return DivergeObject{}, runErr
+	}
+
+	fields := strings.Fields(stdout)
+	if len(fields) != 2 {
+		return DivergeObject{}, fmt.Errorf("unexpected output of rev-list: %q", stdout)
+	}
+	behind, errBehind := strconv.Atoi(fields[0])
+	if errBehind != nil {
+		return DivergeObject{}, errBehind
+	}
+	ahead, errAhead := strconv.Atoi(fields[1])
+	if errAhead != nil {
+		return DivergeObject{}, errAhead
+	}
+	return DivergeObject{ahead, behind}, nil
+}
+
+// GetBranchesContainingCommit returns the names of the branches of the repository at repoPath containing a commit,
+// or nothing if the commit doesn't exist in the repository
+func GetBranchesContainingCommit(ctx context.Context, repoPath, commitID string) ([]string, error) {
+	if !IsValidSHAPattern(commitID) {
+		return nil, fmt.Errorf("invalid commit ID: %s", commitID)
+	}
+	stdout, _, err := NewCommand(ctx, "branch", "--format=%(refname:short)", "--contains", commitID).RunStdString(&RunOpts{Dir: repoPath})
+	if err != nil {
+		if strings.Contains(err.Error(), "malformed object name") || strings.Contains(err.Error(), "no such commit") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return strings.Fields(stdout), nil
+}
+
 // CreateBundle create bundle content to the target path
 func (repo *Repository) CreateBundle(ctx context.Context, commit string, out io.Writer) error {
 	tmp, err := os.MkdirTemp(os.TempDir(), "gitea-bundle")
@@ -185,3 +185,39 @@ func (repo *Repository) RenameBranch(from, to string) error {
 	_, _, err := NewCommand(repo.Ctx, "branch", "-m", from, to).RunStdString(&RunOpts{Dir: repo.Path})
 	return err
 }
+
+// CreateBranchRedirect makes a branch a symbolic reference to another branch, the fetches of the redirecting branch
+// get the commits of the other branch. It replaces the target of an existing redirect.
+func (repo *Repository) CreateBranchRedirect(from, to string) error {
+	_, _, err := NewCommand(repo.Ctx, "symbolic-ref", BranchPrefix+from, BranchPrefix+to).RunStdString(&RunOpts{Dir: repo.Path})
+	return err
+}
+
+// GetBranchRedirect returns the branch a branch redirects to, or an empty string if it isn't a redirect
+func (repo *Repository) GetBranchRedirect(name string) (string, error) {
+	stdout, _, err := NewCommand(repo.Ctx, "symbolic-ref", "-q", BranchPrefix+name).RunStdString(&RunOpts{Dir: repo.Path})
+	if err != nil {
+		if err.IsExitCode(1) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimPrefix(strings.TrimSpace(stdout), BranchPrefix), nil
+}
+
+// GetBranchRedirects returns the branches which redirect to other branches and the branches they redirect to, the
+// redirects whose target doesn't exist are omitted
+func (repo *Repository) GetBranchRedirects() (map[string]string, error) {
+	stdout, _, err := NewCommand(repo.Ctx, "for-each-ref", "--format=%(refname) %(symref)", BranchPrefix).RunStdString(&RunOpts{Dir: repo.Path})
+	if err != nil {
+		return nil, err
+	}
+	redirects := make(map[string]string)
+	for _, line := range strings.Split(stdout, "\n") {
+		name, target, _ := strings.Cut(line, " ")
+		if strings.HasPrefix(target, BranchPrefix) {
+			redirects[strings.TrimPrefix(name, BranchPrefix)] = strings.TrimPrefix(target, BranchPrefix)
+		}
+	}
+	return redirects, nil
+}
@@ -95,3 +95,43 @@ func BenchmarkGetRefsBySha(b *testing.B) {
 	_, _ = bareRepo5.GetRefsBySha("c83380d7056593c51a699d12b9c00627bd5743e9", "")
 	_, _ = bareRepo5.GetRefsBySha("58a4bcc53ac13e7ff76127e0fb518b5262bf09af", "")
 }
+
+func TestRepository_BranchRedirects(t *testing.T) {
+	clonedPath, err := cloneRepo(t, filepath.Join(testReposDir, "repo1_bare"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	repo, err := openRepositoryWithDefaultContext(clonedPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer repo.Close()
+
+	target, err := repo.GetBranchRedirect("master")
+	assert.NoError(t, err)
+	assert.Empty(t, target)
+	target, err = repo.GetBranchRedirect("no-such-branch")
+	assert.NoError(t, err)
+	assert.Empty(t, target)
+
+	assert.NoError(t, repo.RenameBranch("master", "main"))
+	assert.NoError(t, repo.CreateBranchRedirect("master", "main"))
+	target, err = repo.GetBranchRedirect("master")
+	assert.NoError(t, err)
+	assert.Equal(t, "main", target)
+	assert.True(t, repo.IsBranchExist("master"))
+	masterID, err := repo.GetBranchCommitID("master")
+	assert.NoError(t, err)
+	mainID, err := repo.GetBranchCommitID("main")
+	assert.NoError(t, err)
+	assert.Equal(t, mainID, masterID)
+
+	redirects, err := repo.GetBranchRedirects()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"master": "main"}, redirects)
+
+	// deleting the redirect keeps the branch it redirects to
+	assert.NoError(t, repo.DeleteBranch("master", DeleteBranchOptions{Force: true}))
+	assert.False(t, repo.IsBranchExist("master"))
+	assert.True(t, repo.IsBranchExist("main"))
+}
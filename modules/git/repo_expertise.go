@@ -0,0 +1,73 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetBlameAuthors returns the number of lines of a file at a revision each author email has last changed
+func (repo *Repository) GetBlameAuthors(revision, file string) (map[string]int64, error) {
+	stdout, _, err := NewCommand(repo.Ctx, "blame", "--line-porcelain", revision, "--", file).RunStdBytes(&RunOpts{Dir: repo.Path})
+	if err != nil {
+		return nil, err
+	}
+
+	authors := make(map[string]int64)
+	scanner := bufio.NewScanner(bytes.NewReader(stdout))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "author-mail ") {
+			continue
+		}
+		email := strings.TrimSuffix(strings.TrimPrefix(line[len("author-mail "):], "<"), ">")
+		authors[strings.ToLower(email)]++
+	}
+	return authors, scanner.Err()
+}
+
+// AuthorCommit is a commit of the history of a revision with the files it has changed
+type AuthorCommit struct {
+	Email string
+	When  time.Time
+	Files []string
+}
+
+// GetAuthorHistory returns at most limit non-merge commits of the history of a revision, the most recent first
+func (repo *Repository) GetAuthorHistory(revision string, limit int) ([]*AuthorCommit, error) {
+	stdout, _, err := NewCommand(repo.Ctx, "log", "--no-merges", "--format=%x00%ae%x00%at", "--name-only", "-n", strconv.Itoa(limit), revision, "--").RunStdBytes(&RunOpts{Dir: repo.Path})
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]*AuthorCommit, 0, limit)
+	var commit *AuthorCommit
+	scanner := bufio.NewScanner(bytes.NewReader(stdout))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if line[0] != 0 {
+			if commit != nil {
+				commit.Files = append(commit.Files, line)
+			}
+			continue
+		}
+		fields := strings.SplitN(line[1:], "\x00", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		unix, _ := strconv.ParseInt(fields[1], 10, 64)
+		commit = &AuthorCommit{Email: strings.ToLower(fields[0]), When: time.Unix(unix, 0)}
+		commits = append(commits, commit)
+	}
+	return commits, scanner.Err()
+}
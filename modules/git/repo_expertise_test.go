@@ -0,0 +1,46 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_GetBlameAuthors(t *testing.T) {
+	bareRepo1Path := filepath.Join(testReposDir, "repo1_bare")
+	bareRepo1, err := openRepositoryWithDefaultContext(bareRepo1Path)
+	assert.NoError(t, err)
+	defer bareRepo1.Close()
+
+	authors, err := bareRepo1.GetBlameAuthors("master", "foo/nar/hello")
+	assert.NoError(t, err)
+	assert.EqualValues(t, map[string]int64{"tris.git@shoddynet.org": 1}, authors)
+
+	_, err = bareRepo1.GetBlameAuthors("master", "does-not-exist")
+	assert.Error(t, err)
+}
+
+func TestRepository_GetAuthorHistory(t *testing.T) {
+	bareRepo1Path := filepath.Join(testReposDir, "repo1_bare")
+	bareRepo1, err := openRepositoryWithDefaultContext(bareRepo1Path)
+	assert.NoError(t, err)
+	defer bareRepo1.Close()
+
+	commits, err := bareRepo1.GetAuthorHistory("master", 100)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 6)
+	assert.EqualValues(t, "me@silverwind.io", commits[0].Email)
+	assert.Empty(t, commits[0].Files)
+	assert.EqualValues(t, "tris.git@shoddynet.org", commits[1].Email)
+	assert.EqualValues(t, []string{"foo/link_short"}, commits[1].Files)
+	assert.EqualValues(t, 1524183916, commits[1].When.Unix())
+
+	commits, err = bareRepo1.GetAuthorHistory("master", 2)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 2)
+}
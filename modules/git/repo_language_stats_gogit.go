@@ -45,6 +45,8 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 	defer deferable()
 
 	sizes := make(map[string]int64)
+	// languages which are counted whatever their type because of linguist-detectable
+	detectableLanguages := make(map[string]bool)
 	err = tree.Files().ForEach(func(f *object.File) error {
 		if f.Size == 0 {
 			return nil
@@ -52,6 +54,8 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 
 		notVendored := false
 		notGenerated := false
+		notDocumentation := false
+		isDetectable := false
 
 		if checker != nil {
 			attrs, err := checker.CheckPath(f.Name)
@@ -68,6 +72,18 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 					}
 					notGenerated = generated == "false"
 				}
+				if documentation, has := attrs["linguist-documentation"]; has {
+					if documentation == "set" || documentation == "true" {
+						return nil
+					}
+					notDocumentation = documentation == "false" || documentation == "unset"
+				}
+				if detectable, has := attrs["linguist-detectable"]; has {
+					if detectable == "false" || detectable == "unset" {
+						return nil
+					}
+					isDetectable = detectable == "set" || detectable == "true"
+				}
 				if language, has := attrs["linguist-language"]; has && language != "unspecified" && language != "" {
 					// group languages, such as Pug -> HTML; SCSS -> CSS
 					group := enry.GetLanguageGroup(language)
@@ -76,6 +92,9 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 					}
 
 					sizes[language] += f.Size
+					if isDetectable {
+						detectableLanguages[language] = true
+					}
 
 					return nil
 				} else if language, has := attrs["gitlab-language"]; has && language != "unspecified" && language != "" {
@@ -91,14 +110,17 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 						}
 
 						sizes[language] += f.Size
+						if isDetectable {
+							detectableLanguages[language] = true
+						}
 						return nil
 					}
 				}
 			}
 		}
 
-		if (!notVendored && analyze.IsVendor(f.Name)) || enry.IsDotFile(f.Name) ||
-			enry.IsDocumentation(f.Name) || enry.IsConfiguration(f.Name) {
+		if (!notVendored && analyze.IsVendor(f.Name)) || (!notDocumentation && enry.IsDocumentation(f.Name)) ||
+			(!isDetectable && (enry.IsDotFile(f.Name) || enry.IsConfiguration(f.Name))) {
 			return nil
 		}
 
@@ -111,8 +133,6 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 			return nil
 		}
 
-		// TODO: Use .gitattributes file for linguist overrides
-
 		language := analyze.GetCodeLanguage(f.Name, content)
 		if language == enry.OtherLanguage || language == "" {
 			return nil
@@ -125,6 +145,9 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 		}
 
 		sizes[language] += f.Size
+		if isDetectable {
+			detectableLanguages[language] = true
+		}
 
 		return nil
 	})
@@ -136,7 +159,7 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 	if len(sizes) > 1 {
 		for language := range sizes {
 			langtype := enry.GetLanguageType(language)
-			if langtype != enry.Programming && langtype != enry.Markup {
+			if langtype != enry.Programming && langtype != enry.Markup && !detectableLanguages[language] {
 				delete(sizes, language)
 			}
 		}
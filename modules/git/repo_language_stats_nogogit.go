@@ -68,6 +68,8 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 	contentBuf := bytes.Buffer{}
 	var content []byte
 	sizes := make(map[string]int64)
+	// languages which are counted whatever their type because of linguist-detectable
+	detectableLanguages := make(map[string]bool)
 	for _, f := range entries {
 		select {
 		case <-repo.Ctx.Done():
@@ -84,6 +86,8 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 
 		notVendored := false
 		notGenerated := false
+		notDocumentation := false
+		isDetectable := false
 
 		if checker != nil {
 			attrs, err := checker.CheckPath(f.Name())
@@ -100,6 +104,18 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 					}
 					notGenerated = generated == "false"
 				}
+				if documentation, has := attrs["linguist-documentation"]; has {
+					if documentation == "set" || documentation == "true" {
+						continue
+					}
+					notDocumentation = documentation == "false" || documentation == "unset"
+				}
+				if detectable, has := attrs["linguist-detectable"]; has {
+					if detectable == "false" || detectable == "unset" {
+						continue
+					}
+					isDetectable = detectable == "set" || detectable == "true"
+				}
 				if language, has := attrs["linguist-language"]; has && language != "unspecified" && language != "" {
 					// group languages, such as Pug -> HTML; SCSS -> CSS
 					group := enry.GetLanguageGroup(language)
@@ -108,6 +124,9 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 					}
 
 					sizes[language] += f.Size()
+					if isDetectable {
+						detectableLanguages[language] = true
+					}
 					continue
 				} else if language, has := attrs["gitlab-language"]; has && language != "unspecified" && language != "" {
 					// strip off a ? if present
@@ -122,6 +141,9 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 						}
 
 						sizes[language] += f.Size()
+						if isDetectable {
+							detectableLanguages[language] = true
+						}
 						continue
 					}
 				}
@@ -129,8 +151,8 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 			}
 		}
 
-		if (!notVendored && analyze.IsVendor(f.Name())) || enry.IsDotFile(f.Name()) ||
-			enry.IsDocumentation(f.Name()) || enry.IsConfiguration(f.Name()) {
+		if (!notVendored && analyze.IsVendor(f.Name())) || (!notDocumentation && enry.IsDocumentation(f.Name())) ||
+			(!isDetectable && (enry.IsDotFile(f.Name()) || enry.IsConfiguration(f.Name()))) {
 			continue
 		}
 
@@ -181,6 +203,9 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 		}
 
 		sizes[language] += f.Size()
+		if isDetectable {
+			detectableLanguages[language] = true
+		}
 		continue
 	}
 
@@ -188,7 +213,7 @@ func (repo *Repository) GetLanguageStats(commitID string) (map[string]int64, err
 	if len(sizes) > 1 {
 		for language := range sizes {
 			langtype := enry.GetLanguageType(language)
-			if langtype != enry.Programming && langtype != enry.Markup {
+			if langtype != enry.Programming && langtype != enry.Markup && !detectableLanguages[language] {
 				delete(sizes, language)
 			}
 		}
@@ -7,6 +7,7 @@
 package git
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -31,3 +32,44 @@ func TestRepository_GetLanguageStats(t *testing.T) {
 		"Java":   112,
 	}, stats)
 }
+
+func TestRepository_GetLanguageStatsLinguistOverrides(t *testing.T) {
+	repoPath := t.TempDir()
+	assert.NoError(t, InitRepository(DefaultContext, repoPath, false))
+
+	files := map[string]string{
+		".gitattributes": "docs/*.py linguist-documentation=false\n" +
+			"*.json linguist-detectable\n" +
+			"scripts/*.py -linguist-detectable\n" +
+			"lib/*.java linguist-documentation\n",
+		"main.go":            "package main\n\nfunc main() {}\n",
+		"docs/example.py":    "print('hello')\n",
+		"scripts/release.py": "print('release')\n",
+		"lib/Example.java":   "class Example {}\n",
+		"data.json":          "{\"key\": \"value\"}\n",
+	}
+	for name, content := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Join(repoPath, filepath.Dir(name)), os.ModePerm))
+		assert.NoError(t, os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0o644))
+	}
+	assert.NoError(t, NewCommand(DefaultContext, "add", "--all").Run(&RunOpts{Dir: repoPath}))
+	assert.NoError(t, NewCommand(DefaultContext, "commit", "-m", "overrides").Run(&RunOpts{Dir: repoPath}))
+
+	gitRepo, err := openRepositoryWithDefaultContext(repoPath)
+	if !assert.NoError(t, err) {
+		t.Fatal()
+	}
+	defer gitRepo.Close()
+
+	stats, err := gitRepo.GetLanguageStats("HEAD")
+	if !assert.NoError(t, err) {
+		t.Fatal()
+	}
+
+	// the documentation is counted when overridden, the detectable data is kept with the programming languages
+	assert.EqualValues(t, map[string]int64{
+		"Go":     int64(len(files["main.go"])),
+		"Python": int64(len(files["docs/example.py"])),
+		"JSON":   int64(len(files["data.json"])),
+	}, stats)
+}
@@ -30,3 +30,25 @@ func TestRepoIsEmpty(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, isEmpty)
 }
+
+func TestGetDivergingCommitsBetweenRepos(t *testing.T) {
+	bareRepo1Path := filepath.Join(testReposDir, "repo1_bare")
+	divergence, err := GetDivergingCommitsBetweenRepos(DefaultContext, bareRepo1Path, "feaf4ba6bc635fec442f46ddd4512416ec43c2c2", bareRepo1Path, "2839944139e0de9737a044f78b0e4b40d989a9e3")
+	assert.NoError(t, err)
+	assert.Equal(t, DivergeObject{Ahead: 2, Behind: 5}, divergence)
+}
+
+func TestGetBranchesContainingCommit(t *testing.T) {
+	bareRepo1Path := filepath.Join(testReposDir, "repo1_bare")
+	branches, err := GetBranchesContainingCommit(DefaultContext, bareRepo1Path, "95bb4d39648ee7e325106df01a621c530863a653")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"branch1", "branch2", "master"}, branches)
+
+	branches, err = GetBranchesContainingCommit(DefaultContext, bareRepo1Path, "9c9aef8dd84e02bc7ec12641deb4c930a7c30185")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"branch1"}, branches)
+
+	branches, err = GetBranchesContainingCommit(DefaultContext, bareRepo1Path, "0000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	assert.Empty(t, branches)
+}
@@ -18,3 +18,8 @@ type SHA1 = plumbing.Hash
 func ComputeBlobHash(content []byte) SHA1 {
 	return plumbing.ComputeHash(plumbing.BlobObject, content)
 }
+
+// ComputeCommitHash compute the hash for a given raw commit object
+func ComputeCommitHash(content []byte) SHA1 {
+	return plumbing.ComputeHash(plumbing.CommitObject, content)
+}
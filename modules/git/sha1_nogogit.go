@@ -33,6 +33,11 @@ func ComputeBlobHash(content []byte) SHA1 {
 	return ComputeHash(ObjectBlob, content)
 }
 
+// ComputeCommitHash compute the hash for a given raw commit object
+func ComputeCommitHash(content []byte) SHA1 {
+	return ComputeHash(ObjectCommit, content)
+}
+
 // ComputeHash compute the hash for a given ObjectType and content
 func ComputeHash(t ObjectType, content []byte) SHA1 {
 	h := NewHasher(t, int64(len(content)))
@@ -0,0 +1,208 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package highlight
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/lexers"
+	"gopkg.in/yaml.v2"
+)
+
+// customLexerPriority is the default priority of the custom lexers, higher than the one of the bundled lexers so that
+// a custom lexer is picked for the files both match
+const customLexerPriority = 10
+
+// customLexers are the lexers registered from the definitions of the custom lexers directory
+var customLexers []chroma.Lexer
+
+// CustomLexerDefinition is the YAML definition of a custom chroma lexer, its rules are the regular expressions of each
+// state of the lexer, starting with the "root" state
+type CustomLexerDefinition struct {
+	Name            string                        `yaml:"name"`
+	Aliases         []string                      `yaml:"aliases"`
+	Filenames       []string                      `yaml:"filenames"`
+	MimeTypes       []string                      `yaml:"mime_types"`
+	CaseInsensitive bool                          `yaml:"case_insensitive"`
+	DotAll          bool                          `yaml:"dot_all"`
+	EnsureNL        bool                          `yaml:"ensure_nl"`
+	Priority        float32                       `yaml:"priority"`
+	Rules           map[string][]*CustomLexerRule `yaml:"rules"`
+}
+
+// CustomLexerRule is a rule of a state of a custom lexer. It either includes the rules of another state, or emits the
+// tokens of its pattern, one token for the whole match or one per group of the match, then optionally pushes states
+// or pops some.
+type CustomLexerRule struct {
+	Include string   `yaml:"include"`
+	Pattern string   `yaml:"pattern"`
+	Token   string   `yaml:"token"`
+	Tokens  []string `yaml:"tokens"`
+	Push    []string `yaml:"push"`
+	Pop     int      `yaml:"pop"`
+}
+
+// parseTokenType returns the chroma token type of a name, e.g. "CommentSingle"
+func parseTokenType(name string) (chroma.TokenType, error) {
+	data, err := json.Marshal(name)
+	if err != nil {
+		return 0, err
+	}
+	var tokenType chroma.TokenType
+	return tokenType, tokenType.UnmarshalJSON(data)
+}
+
+// toChromaRule converts the rule to a rule of a chroma lexer
+func (r *CustomLexerRule) toChromaRule() (chroma.Rule, error) {
+	if r.Include != "" {
+		return chroma.Include(r.Include), nil
+	}
+	if r.Pattern == "" {
+		return chroma.Rule{}, errors.New("a rule needs a pattern or an include")
+	}
+
+	var emitter chroma.Emitter
+	switch {
+	case len(r.Tokens) > 0:
+		emitters := make([]chroma.Emitter, 0, len(r.Tokens))
+		for _, token := range r.Tokens {
+			tokenType, err := parseTokenType(token)
+			if err != nil {
+				return chroma.Rule{}, err
+			}
+			emitters = append(emitters, tokenType)
+		}
+		emitter = chroma.ByGroups(emitters...)
+	case r.Token != "":
+		tokenType, err := parseTokenType(r.Token)
+		if err != nil {
+			return chroma.Rule{}, err
+		}
+		emitter = tokenType
+	}
+
+	var mutator chroma.Mutator
+	switch {
+	case len(r.Push) > 0 && r.Pop > 0:
+		return chroma.Rule{}, fmt.Errorf("the rule of %q can't both push and pop states", r.Pattern)
+	case len(r.Push) > 0:
+		mutator = chroma.Push(r.Push...)
+	case r.Pop > 0:
+		mutator = chroma.Pop(r.Pop)
+	}
+	return chroma.Rule{Pattern: r.Pattern, Type: emitter, Mutator: mutator}, nil
+}
+
+// NewCustomLexer returns the chroma lexer of a definition, with its regular expressions compiled
+func NewCustomLexer(def *CustomLexerDefinition) (chroma.Lexer, error) {
+	if def.Name == "" {
+		return nil, errors.New("a lexer needs a name")
+	}
+	if _, ok := def.Rules["root"]; !ok {
+		return nil, errors.New("a lexer needs a root state")
+	}
+
+	rules := make(chroma.Rules, len(def.Rules))
+	for state, stateRules := range def.Rules {
+		rules[state] = make([]chroma.Rule, 0, len(stateRules))
+		for _, rule := range stateRules {
+			for _, name := range append([]string{rule.Include}, rule.Push...) {
+				if _, ok := def.Rules[name]; name != "" && !ok {
+					return nil, fmt.Errorf("state %s: unknown state %s", state, name)
+				}
+			}
+			chromaRule, err := rule.toChromaRule()
+			if err != nil {
+				return nil, fmt.Errorf("state %s: %w", state, err)
+			}
+			rules[state] = append(rules[state], chromaRule)
+		}
+	}
+
+	priority := def.Priority
+	if priority == 0 {
+		priority = customLexerPriority
+	}
+	lexer, err := chroma.NewLexer(&chroma.Config{
+		Name:            def.Name,
+		Aliases:         def.Aliases,
+		Filenames:       def.Filenames,
+		MimeTypes:       def.MimeTypes,
+		CaseInsensitive: def.CaseInsensitive,
+		DotAll:          def.DotAll,
+		EnsureNL:        def.EnsureNL,
+		Priority:        priority,
+	}, rules)
+	if err != nil {
+		return nil, err
+	}
+	// the rules are compiled on the first use, an invalid regular expression or state fails now rather than then
+	if _, err := lexer.Tokenise(nil, ""); err != nil {
+		return nil, err
+	}
+	return lexer, nil
+}
+
+// loadCustomLexers registers the lexers of the YAML definitions of a directory
+func loadCustomLexers(dir string) {
+	isDir, err := util.IsDir(dir)
+	if err != nil {
+		log.Error("Unable to check if %s is a directory: %v", dir, err)
+		return
+	}
+	if !isDir {
+		return
+	}
+	files, err := util.StatDir(dir)
+	if err != nil {
+		log.Error("Unable to list the custom lexers of %s: %v", dir, err)
+		return
+	}
+
+	for _, file := range files {
+		if ext := strings.ToLower(filepath.Ext(file)); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			log.Error("Unable to read the custom lexer %s: %v", file, err)
+			continue
+		}
+		def := &CustomLexerDefinition{}
+		if err := yaml.Unmarshal(data, def); err != nil {
+			log.Error("Unable to parse the custom lexer %s: %v", file, err)
+			continue
+		}
+		lexer, err := NewCustomLexer(def)
+		if err != nil {
+			log.Error("Invalid custom lexer %s: %v", file, err)
+			continue
+		}
+		customLexers = append(customLexers, lexers.Register(lexer))
+		log.Info("Registered the custom lexer %s", def.Name)
+	}
+}
+
+// matchCustomLexer returns the custom lexer whose filename globs match the file, nil if there is none
+func matchCustomLexer(fileName string) chroma.Lexer {
+	fileName = filepath.Base(fileName)
+	for _, lexer := range customLexers {
+		for _, glob := range lexer.Config().Filenames {
+			if ok, _ := filepath.Match(glob, fileName); ok {
+				return lexer
+			}
+		}
+	}
+	return nil
+}
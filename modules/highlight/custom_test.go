@@ -0,0 +1,85 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package highlight
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testLexer = `name: Gitea Test Config
+aliases: [giteatestconfig]
+filenames: ["*.gtc"]
+rules:
+  root:
+    - pattern: '#.*\n'
+      token: CommentSingle
+    - pattern: '(\w+)(\s*)(=)'
+      tokens: [NameAttribute, Text, Operator]
+    - pattern: '"'
+      token: LiteralString
+      push: [string]
+    - pattern: '\s+'
+      token: Text
+    - pattern: '\S+'
+      token: Text
+  string:
+    - pattern: '[^"]+'
+      token: LiteralString
+    - pattern: '"'
+      token: LiteralString
+      pop: 1
+`
+
+func TestCustomLexers(t *testing.T) {
+	NewContext()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "gtc.yaml"), []byte(testLexer), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.yml"), []byte("name: Invalid\nrules:\n  root:\n    - pattern: '('\n      token: Text\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a lexer"), 0o644))
+
+	customLexers = nil
+	defer func() { customLexers = nil }()
+	loadCustomLexers(dir)
+	if assert.Len(t, customLexers, 1) {
+		assert.Equal(t, "Gitea Test Config", customLexers[0].Config().Name)
+	}
+	assert.Nil(t, matchCustomLexer("test.py"))
+	assert.NotNil(t, matchCustomLexer("dir/test.gtc"))
+
+	out, name, err := File("test.gtc", "", []byte("# comment\nkey = \"value\"\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Gitea Test Config", name)
+	assert.Equal(t, []string{
+		`<span class="c1"># comment
+</span>`,
+		`<span class="c1"></span><span class="na">key</span> <span class="o">=</span> <span class="s">&#34;</span><span class="s">value</span><span class="s">&#34;</span>
+`,
+	}, out)
+
+	// the alias selects the lexer whatever the file name
+	_, name, err = File("config", "giteatestconfig", []byte("key = 1\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Gitea Test Config", name)
+}
+
+func TestNewCustomLexer(t *testing.T) {
+	_, err := NewCustomLexer(&CustomLexerDefinition{Rules: map[string][]*CustomLexerRule{"root": {{Pattern: ".", Token: "Text"}}}})
+	assert.Error(t, err)
+	_, err = NewCustomLexer(&CustomLexerDefinition{Name: "No root", Rules: map[string][]*CustomLexerRule{"other": {{Pattern: ".", Token: "Text"}}}})
+	assert.Error(t, err)
+	_, err = NewCustomLexer(&CustomLexerDefinition{Name: "Unknown token", Rules: map[string][]*CustomLexerRule{"root": {{Pattern: ".", Token: "NoSuchToken"}}}})
+	assert.Error(t, err)
+	_, err = NewCustomLexer(&CustomLexerDefinition{Name: "Unknown state", Rules: map[string][]*CustomLexerRule{"root": {{Pattern: ".", Token: "Text", Push: []string{"missing"}}}}})
+	assert.Error(t, err)
+
+	lexer, err := NewCustomLexer(&CustomLexerDefinition{Name: "Valid", Rules: map[string][]*CustomLexerRule{"root": {{Pattern: ".", Token: "Text"}}}})
+	assert.NoError(t, err)
+	assert.EqualValues(t, customLexerPriority, lexer.Config().Priority)
+}
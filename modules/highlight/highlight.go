@@ -46,6 +46,12 @@ func NewContext() {
 			for i := range keys {
 				highlightMapping[keys[i].Name()] = keys[i].Value()
 			}
+
+			customLexersPath := setting.Cfg.Section("highlight").Key("CUSTOM_LEXERS_PATH").MustString(filepath.Join(setting.CustomPath, "options", "lexers"))
+			if !filepath.IsAbs(customLexersPath) {
+				customLexersPath = filepath.Join(setting.CustomPath, customLexersPath)
+			}
+			loadCustomLexers(customLexersPath)
 		}
 		// The size 512 is simply a conservative rule of thumb
 		c, err := lru.New2Q(512)
@@ -134,12 +140,12 @@ func CodeFromLexer(lexer chroma.Lexer, code string) string {
 	return strings.TrimSuffix(htmlbuf.String(), "\n")
 }
 
-// File returns a slice of chroma syntax highlighted HTML lines of code
-func File(fileName, language string, code []byte) ([]string, error) {
+// File returns a slice of chroma syntax highlighted HTML lines of code and the name of the lexer used to highlight them
+func File(fileName, language string, code []byte) ([]string, string, error) {
 	NewContext()
 
 	if len(code) > sizeLimit {
-		return PlainText(code), nil
+		return PlainText(code), "", nil
 	}
 
 	formatter := html.New(html.WithClasses(true),
@@ -163,6 +169,11 @@ func File(fileName, language string, code []byte) ([]string, error) {
 		}
 	}
 
+	// the custom lexers are for languages the language detection doesn't know
+	if lexer == nil {
+		lexer = matchCustomLexer(fileName)
+	}
+
 	if lexer == nil {
 		guessLanguage := analyze.GetCodeLanguage(fileName, code)
 
@@ -177,12 +188,12 @@ func File(fileName, language string, code []byte) ([]string, error) {
 
 	iterator, err := lexer.Tokenise(nil, string(code))
 	if err != nil {
-		return nil, fmt.Errorf("can't tokenize code: %w", err)
+		return nil, "", fmt.Errorf("can't tokenize code: %w", err)
 	}
 
 	err = formatter.Format(htmlWriter, styles.GitHub, iterator)
 	if err != nil {
-		return nil, fmt.Errorf("can't format code: %w", err)
+		return nil, "", fmt.Errorf("can't format code: %w", err)
 	}
 
 	_ = htmlWriter.Flush()
@@ -196,7 +207,7 @@ func File(fileName, language string, code []byte) ([]string, error) {
 		line = strings.TrimSuffix(line, "</span></span>")
 		m = append(m, line)
 	}
-	return m, nil
+	return m, lexer.Config().Name, nil
 }
 
 // PlainText returns non-highlighted HTML for code
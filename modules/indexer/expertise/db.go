@@ -0,0 +1,193 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package expertise
+
+import (
+	"fmt"
+	"sort"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/analyze"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// maxTreePathLength is the length of the longest path which can be indexed
+const maxTreePathLength = 500
+
+// DBIndexer implements Indexer interface to save the expertise in the database
+type DBIndexer struct{}
+
+// Index the expertise of the authors of a repository
+func (db *DBIndexer) Index(id int64) error {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().ShutdownContext(), fmt.Sprintf("Expertise.DB Index Repo[%d]", id))
+	defer finished()
+
+	repo, err := repo_model.GetRepositoryByID(id)
+	if err != nil {
+		return err
+	}
+	if repo.IsEmpty {
+		return nil
+	}
+
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeExpertise)
+	if err != nil {
+		return err
+	}
+
+	gitRepo, err := git.OpenRepository(ctx, repo.RepoPath())
+	if err != nil {
+		if err.Error() == "no such file or directory" {
+			return nil
+		}
+		return err
+	}
+	defer gitRepo.Close()
+
+	// Get latest commit for default branch
+	commitID, err := gitRepo.GetBranchCommitID(repo.DefaultBranch)
+	if err != nil {
+		if git.IsErrBranchNotExist(err) || git.IsErrNotExist(err) {
+			log.Debug("Unable to get commit ID for default branch %s in %s ... skipping this repository", repo.DefaultBranch, repo.RepoPath())
+			return nil
+		}
+		log.Error("Unable to get commit ID for default branch %s in %s. Error: %v", repo.DefaultBranch, repo.RepoPath(), err)
+		return err
+	}
+
+	// Do not recompute the expertise if already computed for this commit
+	if status.CommitSha == commitID {
+		return nil
+	}
+
+	commit, err := gitRepo.GetCommit(commitID)
+	if err != nil {
+		return err
+	}
+	entries, err := commit.Tree.ListEntriesRecursive()
+	if err != nil {
+		return err
+	}
+
+	files := make(map[string]bool, len(entries))
+	blames := make(map[string]map[string]int64)
+	for _, entry := range entries {
+		if !entry.IsRegular() && !entry.IsExecutable() {
+			continue
+		}
+		treePath := entry.Name()
+		if len(treePath) > maxTreePathLength || analyze.IsVendor(treePath) {
+			continue
+		}
+		files[treePath] = true
+		if len(blames) >= setting.Indexer.ExpertiseMaxBlamedFiles || entry.Size() > setting.Indexer.MaxIndexerFileSize {
+			continue
+		}
+		if blames[treePath], err = gitRepo.GetBlameAuthors(commitID, treePath); err != nil {
+			log.Error("Unable to blame %s at %s in %s. Error: %v", treePath, commitID, repo.RepoPath(), err)
+			return err
+		}
+	}
+
+	commits, err := gitRepo.GetAuthorHistory(commitID, setting.Indexer.ExpertiseMaxCommits)
+	if err != nil {
+		log.Error("Unable to get the history of %s in %s. Error: %v", commitID, repo.RepoPath(), err)
+		return err
+	}
+
+	expertise := computeExpertise(files, blames, commits)
+	if err := repo_model.UpdateRepoExpertise(ctx, repo, commitID, expertise); err != nil {
+		log.Error("Unable to update expertise for ID %s for default branch %s in %s. Error: %v", commitID, repo.DefaultBranch, repo.RepoPath(), err)
+		return err
+	}
+
+	log.Debug("DBIndexer completed expertise for ID %s for default branch %s in %s. expertise count: %d", commitID, repo.DefaultBranch, repo.RepoPath(), len(expertise))
+	return nil
+}
+
+// Close dummy function
+func (db *DBIndexer) Close() {
+}
+
+// forEachPath calls f with a file and all the directories it is in, up to the root directory
+func forEachPath(file string, f func(treePath string)) {
+	for p := file; ; p = repo_model.ExpertiseParentPath(p) {
+		f(p)
+		if p == "" {
+			return
+		}
+	}
+}
+
+// computeExpertise computes the expertise of the authors on the files and the directories of a tree from the blame
+// of its files and the commits of its history, the files which do not exist in the tree anymore are ignored
+func computeExpertise(files map[string]bool, blames map[string]map[string]int64, commits []*git.AuthorCommit) []*repo_model.RepoExpertise {
+	byPath := make(map[string]map[string]*repo_model.RepoExpertise)
+	get := func(treePath, email string) *repo_model.RepoExpertise {
+		byEmail := byPath[treePath]
+		if byEmail == nil {
+			byEmail = make(map[string]*repo_model.RepoExpertise)
+			byPath[treePath] = byEmail
+		}
+		e := byEmail[email]
+		if e == nil {
+			e = &repo_model.RepoExpertise{
+				TreePath:   treePath,
+				ParentPath: repo_model.ExpertiseParentPath(treePath),
+				IsDir:      !files[treePath],
+				Email:      email,
+			}
+			byEmail[email] = e
+		}
+		return e
+	}
+
+	for file, authors := range blames {
+		for email, lines := range authors {
+			forEachPath(file, func(treePath string) {
+				get(treePath, email).Lines += lines
+			})
+		}
+	}
+
+	for _, commit := range commits {
+		// a commit counts once for each directory, however many of its files it has changed
+		touched := make(map[string]bool)
+		for _, file := range commit.Files {
+			if files[file] {
+				forEachPath(file, func(treePath string) {
+					touched[treePath] = true
+				})
+			}
+		}
+		when := timeutil.TimeStamp(commit.When.Unix())
+		for treePath := range touched {
+			e := get(treePath, commit.Email)
+			e.Commits++
+			if when > e.LastCommitUnix {
+				e.LastCommitUnix = when
+			}
+		}
+	}
+
+	expertise := make([]*repo_model.RepoExpertise, 0, len(byPath))
+	for _, byEmail := range byPath {
+		for _, e := range byEmail {
+			expertise = append(expertise, e)
+		}
+	}
+	sort.Slice(expertise, func(i, j int) bool {
+		if expertise[i].TreePath != expertise[j].TreePath {
+			return expertise[i].TreePath < expertise[j].TreePath
+		}
+		return expertise[i].Email < expertise[j].Email
+	})
+	return expertise
+}
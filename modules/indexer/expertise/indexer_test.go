@@ -0,0 +1,104 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package expertise
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+
+	_ "code.gitea.io/gitea/models"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/ini.v1"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m, &unittest.TestOptions{
+		GiteaRootPath: filepath.Join("..", "..", ".."),
+	})
+}
+
+func TestRepoExpertiseIndex(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	setting.Cfg = ini.Empty()
+
+	setting.NewQueueService()
+
+	err := Init()
+	assert.NoError(t, err)
+
+	repo, err := repo_model.GetRepositoryByID(1)
+	assert.NoError(t, err)
+
+	err = UpdateRepoIndexer(repo)
+	assert.NoError(t, err)
+
+	queue.GetManager().FlushAll(context.Background(), 5*time.Second)
+
+	status, err := repo_model.GetIndexerStatus(db.DefaultContext, repo, repo_model.RepoIndexerTypeExpertise)
+	assert.NoError(t, err)
+	assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", status.CommitSha)
+
+	experts, err := repo_model.GetExperts(db.DefaultContext, repo.ID, "README.md")
+	assert.NoError(t, err)
+	if assert.Len(t, experts, 1) {
+		assert.Equal(t, "address1@example.com", experts[0].Email)
+		assert.EqualValues(t, 3, experts[0].Lines)
+		assert.EqualValues(t, 1, experts[0].Commits)
+	}
+	dirs, err := repo_model.GetExpertiseSubdirectories(db.DefaultContext, repo.ID, "")
+	assert.NoError(t, err)
+	assert.Empty(t, dirs)
+}
+
+func TestComputeExpertise(t *testing.T) {
+	files := map[string]bool{"a/b/c.go": true, "a/d.go": true}
+	blames := map[string]map[string]int64{
+		"a/b/c.go": {"x@example.com": 3, "y@example.com": 1},
+		"a/d.go":   {"x@example.com": 2},
+	}
+	commits := []*git.AuthorCommit{
+		{Email: "y@example.com", When: time.Unix(20, 0), Files: []string{"a/b/c.go", "a/d.go"}},
+		{Email: "x@example.com", When: time.Unix(10, 0), Files: []string{"a/b/c.go", "removed.go"}},
+	}
+
+	expertise := computeExpertise(files, blames, commits)
+	byKey := make(map[string]*repo_model.RepoExpertise, len(expertise))
+	for _, e := range expertise {
+		byKey[e.TreePath+":"+e.Email] = e
+	}
+	assert.Len(t, byKey, 10)
+
+	root := byKey[":x@example.com"]
+	assert.True(t, root.IsDir)
+	assert.EqualValues(t, 5, root.Lines)
+	assert.EqualValues(t, 1, root.Commits)
+
+	// the commit of y has changed two files of a but counts once for it
+	a := byKey["a:y@example.com"]
+	assert.Equal(t, "", a.ParentPath)
+	assert.EqualValues(t, 1, a.Lines)
+	assert.EqualValues(t, 1, a.Commits)
+	assert.EqualValues(t, 20, a.LastCommitUnix)
+
+	c := byKey["a/b/c.go:x@example.com"]
+	assert.False(t, c.IsDir)
+	assert.Equal(t, "a/b", c.ParentPath)
+	assert.EqualValues(t, 3, c.Lines)
+	assert.EqualValues(t, 1, c.Commits)
+
+	d := byKey["a/d.go:y@example.com"]
+	assert.EqualValues(t, 0, d.Lines)
+	assert.EqualValues(t, 1, d.Commits)
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package expertise
+
+import (
+	"fmt"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/queue"
+)
+
+// expertiseQueue represents a queue to handle repository expertise updates
+var expertiseQueue queue.UniqueQueue
+
+// handle passed repository IDs and index their expertise
+func handle(data ...queue.Data) []queue.Data {
+	for _, datum := range data {
+		opts := datum.(int64)
+		if err := indexer.Index(opts); err != nil {
+			log.Error("expertise queue indexer.Index(%d) failed: %v", opts, err)
+		}
+	}
+	return nil
+}
+
+func initExpertiseQueue() error {
+	expertiseQueue = queue.CreateUniqueQueue("repo_expertise_update", handle, int64(0))
+	if expertiseQueue == nil {
+		return fmt.Errorf("Unable to create repo_expertise_update Queue")
+	}
+
+	go graceful.GetManager().RunWithShutdownFns(expertiseQueue.Run)
+
+	return nil
+}
+
+// UpdateRepoIndexer update a repository's entries in the indexer
+func UpdateRepoIndexer(repo *repo_model.Repository) error {
+	if err := expertiseQueue.Push(repo.ID); err != nil {
+		if err != queue.ErrAlreadyInQueue {
+			return err
+		}
+		log.Debug("Repo ID: %d already queued", repo.ID)
+	}
+	return nil
+}
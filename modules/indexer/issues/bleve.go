@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	gitea_bleve "code.gitea.io/gitea/modules/indexer/bleve"
 	"code.gitea.io/gitea/modules/log"
@@ -54,6 +55,13 @@ func numericEqualityQuery(value int64, field string) *query.NumericRangeQuery {
 	return q
 }
 
+func newMatchQuery(match, field, analyzer string) *query.MatchQuery {
+	q := bleve.NewMatchQuery(match)
+	q.FieldVal = field
+	q.Analyzer = analyzer
+	return q
+}
+
 func newMatchPhraseQuery(matchPhrase, field, analyzer string) *query.MatchPhraseQuery {
 	q := bleve.NewMatchPhraseQuery(matchPhrase)
 	q.FieldVal = field
@@ -240,22 +248,41 @@ func (b *BleveIndexer) Delete(ids ...int64) error {
 // Search searches for issues by given conditions.
 // Returns the matching issue IDs
 func (b *BleveIndexer) Search(ctx context.Context, keyword string, repoIDs []int64, limit, start int) (*SearchResult, error) {
-	var repoQueriesP []*query.NumericRangeQuery
-	for _, repoID := range repoIDs {
-		repoQueriesP = append(repoQueriesP, numericEqualityQuery(repoID, "RepoID"))
-	}
-	repoQueries := make([]query.Query, len(repoQueriesP))
-	for i, v := range repoQueriesP {
-		repoQueries[i] = query.Query(v)
-	}
-
 	indexerQuery := bleve.NewConjunctionQuery(
-		bleve.NewDisjunctionQuery(repoQueries...),
+		repoIDsQuery(repoIDs),
 		bleve.NewDisjunctionQuery(
 			newMatchPhraseQuery(keyword, "Title", issueIndexerAnalyzer),
 			newMatchPhraseQuery(keyword, "Content", issueIndexerAnalyzer),
 			newMatchPhraseQuery(keyword, "Comments", issueIndexerAnalyzer),
 		))
+	return b.search(ctx, indexerQuery, limit, start)
+}
+
+// SearchSimilar searches for issues whose title or content contain any of the keywords, the issues with matching
+// titles weigh more
+func (b *BleveIndexer) SearchSimilar(ctx context.Context, keywords []string, repoIDs []int64, limit int) (*SearchResult, error) {
+	text := strings.Join(keywords, " ")
+	titleQuery := newMatchQuery(text, "Title", issueIndexerAnalyzer)
+	titleQuery.SetBoost(2)
+	indexerQuery := bleve.NewConjunctionQuery(
+		repoIDsQuery(repoIDs),
+		bleve.NewDisjunctionQuery(
+			titleQuery,
+			newMatchQuery(text, "Content", issueIndexerAnalyzer),
+		))
+	return b.search(ctx, indexerQuery, limit, 0)
+}
+
+// repoIDsQuery returns a query matching the issues of any of the repositories
+func repoIDsQuery(repoIDs []int64) query.Query {
+	repoQueries := make([]query.Query, 0, len(repoIDs))
+	for _, repoID := range repoIDs {
+		repoQueries = append(repoQueries, numericEqualityQuery(repoID, "RepoID"))
+	}
+	return bleve.NewDisjunctionQuery(repoQueries...)
+}
+
+func (b *BleveIndexer) search(ctx context.Context, indexerQuery query.Query, limit, start int) (*SearchResult, error) {
 	search := bleve.NewSearchRequestOptions(indexerQuery, limit, start, false)
 	search.SortBy([]string{"-_score"})
 
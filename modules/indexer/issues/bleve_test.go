@@ -85,4 +85,22 @@ func TestBleveIndexAndSearch(t *testing.T) {
 		}
 		assert.ElementsMatch(t, kw.IDs, ids)
 	}
+
+	res, err := indexer.SearchSimilar(context.TODO(), SimilarKeywords("Support for Chinese in the search"), []int64{2}, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, res.Hits, 2) {
+		// the title of the first issue matches more keywords
+		assert.EqualValues(t, 1, res.Hits[0].ID)
+		assert.EqualValues(t, 2, res.Hits[1].ID)
+	}
+
+	res, err = indexer.SearchSimilar(context.TODO(), []string{"help"}, []int64{2}, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, res.Hits)
+}
+
+func TestSimilarKeywords(t *testing.T) {
+	assert.Equal(t, []string{"crash", "opening", "settings", "page", "2022"}, SimilarKeywords("Crash when opening the settings page in 2022"))
+	assert.Equal(t, []string{"crash"}, SimilarKeywords("Crash, crash!"))
+	assert.Empty(t, SimilarKeywords("Why is it so?"))
 }
@@ -59,3 +59,21 @@ func (i *DBIndexer) Search(ctx context.Context, kw string, repoIDs []int64, limi
 	}
 	return &result, nil
 }
+
+// SearchSimilar searches for issues whose title contains any of the keywords, the ones containing the most first
+func (i *DBIndexer) SearchSimilar(ctx context.Context, keywords []string, repoIDs []int64, limit int) (*SearchResult, error) {
+	ids, err := issues_model.SearchIssueIDsBySimilarTitle(ctx, keywords, repoIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := SearchResult{
+		Total: int64(len(ids)),
+		Hits:  make([]Match, 0, len(ids)),
+	}
+	for _, id := range ids {
+		result.Hits = append(result.Hits, Match{
+			ID: id,
+		})
+	}
+	return &result, nil
+}
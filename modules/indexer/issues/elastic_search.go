@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -228,6 +229,17 @@ func (b *ElasticSearchIndexer) Delete(ids ...int64) error {
 // Returns the matching issue IDs
 func (b *ElasticSearchIndexer) Search(ctx context.Context, keyword string, repoIDs []int64, limit, start int) (*SearchResult, error) {
 	kwQuery := elastic.NewMultiMatchQuery(keyword, "title", "content", "comments")
+	return b.search(ctx, kwQuery, repoIDs, limit, start)
+}
+
+// SearchSimilar searches for issues whose title or content contain any of the keywords, the issues with matching
+// titles weigh more
+func (b *ElasticSearchIndexer) SearchSimilar(ctx context.Context, keywords []string, repoIDs []int64, limit int) (*SearchResult, error) {
+	kwQuery := elastic.NewMultiMatchQuery(strings.Join(keywords, " "), "title^2", "content")
+	return b.search(ctx, kwQuery, repoIDs, limit, 0)
+}
+
+func (b *ElasticSearchIndexer) search(ctx context.Context, kwQuery elastic.Query, repoIDs []int64, limit, start int) (*SearchResult, error) {
 	query := elastic.NewBoolQuery()
 	query = query.Must(kwQuery)
 	if len(repoIDs) > 0 {
@@ -9,8 +9,11 @@ import (
 	"fmt"
 	"os"
 	"runtime/pprof"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
@@ -54,6 +57,7 @@ type Indexer interface {
 	Index(issue []*IndexerData) error
 	Delete(ids ...int64) error
 	Search(ctx context.Context, kw string, repoIDs []int64, limit, start int) (*SearchResult, error)
+	SearchSimilar(ctx context.Context, keywords []string, repoIDs []int64, limit int) (*SearchResult, error)
 	Close()
 }
 
@@ -400,6 +404,56 @@ func SearchIssuesByKeyword(ctx context.Context, repoIDs []int64, keyword string)
 	return issueIDs, nil
 }
 
+// similarStopWords are the common words which are left out of the keywords of a title
+var similarStopWords = map[string]bool{
+	"and": true, "are": true, "but": true, "can": true, "cannot": true, "does": true, "for": true, "from": true,
+	"has": true, "have": true, "how": true, "not": true, "should": true, "that": true, "the": true, "this": true,
+	"when": true, "with": true, "why": true, "will": true, "would": true,
+}
+
+// SimilarKeywords returns the distinct lower-cased words of a title which are used to find similar issues,
+// the short and common words are left out
+func SimilarKeywords(title string) []string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	keywords := make([]string, 0, len(words))
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		if utf8.RuneCountInString(word) < 3 || similarStopWords[word] || seen[word] {
+			continue
+		}
+		seen[word] = true
+		keywords = append(keywords, word)
+	}
+	return keywords
+}
+
+// SearchSimilarIssues search the ids of the issues whose title or content share keywords with a title, the most similar
+// first
+// WARNNING: You have to ensure user have permission to visit repoIDs' issues
+func SearchSimilarIssues(ctx context.Context, repoIDs []int64, title string) ([]int64, error) {
+	keywords := SimilarKeywords(title)
+	if len(keywords) == 0 {
+		return []int64{}, nil
+	}
+
+	indexer := holder.get()
+	if indexer == nil {
+		log.Error("SearchSimilarIssues(): unable to get indexer!")
+		return nil, fmt.Errorf("unable to get issue indexer")
+	}
+	res, err := indexer.SearchSimilar(ctx, keywords, repoIDs, 50)
+	if err != nil {
+		return nil, err
+	}
+	issueIDs := make([]int64, 0, len(res.Hits))
+	for _, r := range res.Hits {
+		issueIDs = append(issueIDs, r.ID)
+	}
+	return issueIDs, nil
+}
+
 // IsAvailable checks if issue indexer is available
 func IsAvailable() bool {
 	indexer := holder.get()
@@ -0,0 +1,90 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package intoto parses the parts of in-toto attestations, e.g. SLSA build provenances, needed to store them and to
+// find them by the digests of their subjects.
+package intoto
+
+import (
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+)
+
+// ErrInvalidAttestation is returned if the content is neither an in-toto statement nor a DSSE envelope wrapping one
+var ErrInvalidAttestation = errors.New("invalid in-toto attestation")
+
+// PayloadType is the payload type of the DSSE envelopes wrapping an in-toto statement
+const PayloadType = "application/vnd.in-toto+json"
+
+// Statement represents an in-toto statement, its predicate is kept as it is
+type Statement struct {
+	Type          string     `json:"_type"`
+	Subjects      []*Subject `json:"subject"`
+	PredicateType string     `json:"predicateType"`
+}
+
+// Subject represents an artifact an in-toto statement is about
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Envelope represents a DSSE (Dead Simple Signing Envelope) signing a statement, the signatures are not verified
+type Envelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+}
+
+// Parse parses an in-toto statement, or the statement signed by a DSSE envelope
+func Parse(content []byte) (*Statement, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(content, &envelope); err != nil {
+		return nil, ErrInvalidAttestation
+	}
+	if envelope.Payload != "" {
+		if envelope.PayloadType != PayloadType {
+			return nil, ErrInvalidAttestation
+		}
+		payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+		if err != nil {
+			return nil, ErrInvalidAttestation
+		}
+		content = payload
+	}
+
+	var statement Statement
+	if err := json.Unmarshal(content, &statement); err != nil {
+		return nil, ErrInvalidAttestation
+	}
+	if !strings.HasPrefix(statement.Type, "https://in-toto.io/Statement/") || statement.PredicateType == "" || len(statement.Subjects) == 0 {
+		return nil, ErrInvalidAttestation
+	}
+	for _, subject := range statement.Subjects {
+		if subject == nil || len(subject.Digest) == 0 {
+			return nil, ErrInvalidAttestation
+		}
+	}
+	return &statement, nil
+}
+
+// Digests returns the sorted digests of the subjects of a statement written as "algorithm:value", e.g. "sha256:0a1b…"
+func (s *Statement) Digests() []string {
+	seen := make(map[string]bool)
+	digests := make([]string, 0, len(s.Subjects))
+	for _, subject := range s.Subjects {
+		for algorithm, value := range subject.Digest {
+			digest := strings.ToLower(algorithm) + ":" + strings.ToLower(value)
+			if !seen[digest] {
+				seen[digest] = true
+				digests = append(digests, digest)
+			}
+		}
+	}
+	sort.Strings(digests)
+	return digests
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package intoto
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testStatement = `{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "subject": [
+    {"name": "registry.example.com/org/app", "digest": {"sha256": "9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08"}},
+    {"name": "app.tar.gz", "digest": {"sha256": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", "sha1": "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"}}
+  ],
+  "predicateType": "https://slsa.dev/provenance/v0.2",
+  "predicate": {"builder": {"id": "https://gitea.example.com/actions"}}
+}`
+
+func TestParse(t *testing.T) {
+	statement, err := Parse([]byte(testStatement))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://slsa.dev/provenance/v0.2", statement.PredicateType)
+	assert.Len(t, statement.Subjects, 2)
+	assert.Equal(t, []string{
+		"sha1:a94a8fe5ccb19ba61c4c0873d391e987982fbbd3",
+		"sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}, statement.Digests())
+
+	envelope := `{"payloadType": "application/vnd.in-toto+json", "payload": "` +
+		base64.StdEncoding.EncodeToString([]byte(testStatement)) + `", "signatures": [{"keyid": "", "sig": "MEUCIQ=="}]}`
+	statement, err = Parse([]byte(envelope))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://slsa.dev/provenance/v0.2", statement.PredicateType)
+
+	for _, content := range []string{
+		`not json`,
+		`{"_type": "https://in-toto.io/Statement/v0.1", "subject": [], "predicateType": "https://slsa.dev/provenance/v0.2"}`,
+		`{"_type": "https://in-toto.io/Statement/v0.1", "subject": [{"name": "app"}], "predicateType": "https://slsa.dev/provenance/v0.2"}`,
+		`{"_type": "https://example.com/Statement", "subject": [{"digest": {"sha256": "00"}}], "predicateType": "https://slsa.dev/provenance/v0.2"}`,
+		`{"payloadType": "text/plain", "payload": "e30="}`,
+		`{"payloadType": "application/vnd.in-toto+json", "payload": "%%%"}`,
+	} {
+		_, err = Parse([]byte(content))
+		assert.ErrorIs(t, err, ErrInvalidAttestation, content)
+	}
+}
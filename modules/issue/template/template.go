@@ -81,6 +81,11 @@ func validateYaml(template *api.IssueTemplate) error {
 			if err := validateStringItem(position, field.Validations, false, "regex"); err != nil {
 				return err
 			}
+			if regex, ok := field.Validations["regex"].(string); ok {
+				if _, err := regexp.Compile(regex); err != nil {
+					return position.Errorf("'regex' should be a valid regular expression")
+				}
+			}
 		case api.IssueFormFieldTypeDropdown:
 			if err := validateStringItem(position, field.Attributes, false, "description"); err != nil {
 				return err
@@ -248,11 +253,144 @@ func RenderToMarkdown(template *api.IssueTemplate, values url.Values) string {
 	return builder.String()
 }
 
+// ErrInvalidFieldValue represents a submitted value of a field of an issue form which does not pass its validations
+type ErrInvalidFieldValue struct {
+	// Label is the label of the field, or of the option of checkboxes
+	Label string
+	// Reason is why the value is invalid: "required", "number", "regex" or "option"
+	Reason string
+}
+
+// IsErrInvalidFieldValue checks if an error is a ErrInvalidFieldValue.
+func IsErrInvalidFieldValue(err error) bool {
+	_, ok := err.(ErrInvalidFieldValue)
+	return ok
+}
+
+func (err ErrInvalidFieldValue) Error() string {
+	return fmt.Sprintf("invalid value of field %q: %s", err.Label, err.Reason)
+}
+
+// ValidateValues checks the values submitted for the fields of a template against their validations,
+// and returns the first invalid one
+func ValidateValues(template *api.IssueTemplate, values url.Values) error {
+	for _, field := range template.Fields {
+		f := &valuedField{
+			IssueFormField: field,
+			Values:         values,
+		}
+		if err := f.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FieldsWithValues returns copies of the fields of a template whose default values are the submitted ones,
+// to render the form again when they are invalid
+func FieldsWithValues(template *api.IssueTemplate, values url.Values) []*api.IssueFormField {
+	fields := make([]*api.IssueFormField, 0, len(template.Fields))
+	for _, field := range template.Fields {
+		f := &valuedField{
+			IssueFormField: field,
+			Values:         values,
+		}
+		attributes := make(map[string]interface{}, len(field.Attributes)+1)
+		for k, v := range field.Attributes {
+			attributes[k] = v
+		}
+		switch field.Type {
+		case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+			attributes["value"] = f.Value()
+		case api.IssueFormFieldTypeDropdown:
+			attributes["value"] = f.Get("form-field-" + f.ID)
+		case api.IssueFormFieldTypeCheckboxes:
+			options := make([]interface{}, 0, len(f.Options()))
+			for _, option := range f.Options() {
+				opt := make(map[interface{}]interface{})
+				if vs, ok := option.data.(map[interface{}]interface{}); ok {
+					for k, v := range vs {
+						opt[k] = v
+					}
+				}
+				opt["checked"] = option.IsChecked()
+				options = append(options, opt)
+			}
+			attributes["options"] = options
+		}
+		fields = append(fields, &api.IssueFormField{
+			Type:        field.Type,
+			ID:          field.ID,
+			Attributes:  attributes,
+			Validations: field.Validations,
+		})
+	}
+	return fields
+}
+
 type valuedField struct {
 	*api.IssueFormField
 	url.Values
 }
 
+// Validate checks the submitted value of the field against its validations
+func (f *valuedField) Validate() error {
+	invalid := func(label, reason string) error {
+		return ErrInvalidFieldValue{Label: label, Reason: reason}
+	}
+	required, _ := f.Validations["required"].(bool)
+
+	switch f.Type {
+	case api.IssueFormFieldTypeInput:
+		value := f.Value()
+		if value == "" {
+			if required {
+				return invalid(f.Label(), "required")
+			}
+			return nil
+		}
+		if isNumber, _ := f.Validations["is_number"].(bool); isNumber {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return invalid(f.Label(), "number")
+			}
+		}
+		if regex, _ := f.Validations["regex"].(string); regex != "" {
+			// like the pattern of the input of the form, the expression must match the whole value
+			if re, err := regexp.Compile("^(?:" + regex + ")$"); err != nil || !re.MatchString(value) {
+				return invalid(f.Label(), "regex")
+			}
+		}
+	case api.IssueFormFieldTypeTextarea:
+		if required && f.Value() == "" {
+			return invalid(f.Label(), "required")
+		}
+	case api.IssueFormFieldTypeDropdown:
+		var checked int
+		for _, v := range strings.Split(f.Get("form-field-"+f.ID), ",") {
+			if v == "" {
+				continue
+			}
+			if idx, err := strconv.Atoi(v); err != nil || idx < 0 || idx >= len(f.Options()) {
+				return invalid(f.Label(), "option")
+			}
+			checked++
+		}
+		if multiple, _ := f.Attributes["multiple"].(bool); !multiple && checked > 1 {
+			return invalid(f.Label(), "option")
+		}
+		if required && checked == 0 {
+			return invalid(f.Label(), "required")
+		}
+	case api.IssueFormFieldTypeCheckboxes:
+		for _, option := range f.Options() {
+			if option.IsRequired() && !option.IsChecked() {
+				return invalid(option.Label(), "required")
+			}
+		}
+	}
+	return nil
+}
+
 func (f *valuedField) WriteTo(builder *strings.Builder) {
 	if f.Type == api.IssueFormFieldTypeMarkdown {
 		// markdown blocks do not appear in output
@@ -360,6 +498,16 @@ func (o *valuedOption) Label() string {
 	return ""
 }
 
+func (o *valuedOption) IsRequired() bool {
+	if o.field.Type == api.IssueFormFieldTypeCheckboxes {
+		if vs, ok := o.data.(map[interface{}]interface{}); ok {
+			required, _ := vs["required"].(bool)
+			return required
+		}
+	}
+	return false
+}
+
 func (o *valuedOption) IsChecked() bool {
 	switch o.field.Type {
 	case api.IssueFormFieldTypeDropdown:
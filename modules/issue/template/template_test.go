@@ -185,6 +185,21 @@ body:
 `,
 			wantErr: "body[0](input): 'regex' should be a string",
 		},
+		{
+			name: "input regex not compiling",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: "input"
+    id: "1"
+    attributes:
+      label: "a"
+    validations:
+      regex: "[a-z"
+`,
+			wantErr: "body[0](input): 'regex' should be a valid regular expression",
+		},
 		{
 			name: "dropdown invalid description",
 			content: `
@@ -590,6 +605,109 @@ Option 1 of dropdown, Option 2 of dropdown
 	}
 }
 
+func TestValidateValues(t *testing.T) {
+	template, err := Unmarshal("test.yaml", []byte(`
+name: Name
+about: About
+body:
+  - type: markdown
+    attributes:
+      value: Value of the markdown
+  - type: textarea
+    id: logs
+    attributes:
+      label: Logs
+    validations:
+      required: true
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      regex: "v[0-9]+"
+  - type: input
+    id: count
+    attributes:
+      label: Count
+    validations:
+      is_number: true
+  - type: dropdown
+    id: os
+    attributes:
+      label: OS
+      options:
+        - Linux
+        - Windows
+    validations:
+      required: true
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Terms
+      options:
+        - label: I agree to the code of conduct
+          required: true
+        - label: I searched the existing issues
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := url.Values{
+		"form-field-logs":    {"some logs"},
+		"form-field-version": {"v12"},
+		"form-field-count":   {"1.5"},
+		"form-field-os":      {"1"},
+		"form-field-terms-0": {"on"},
+	}
+	if err := ValidateValues(template, valid); err != nil {
+		t.Errorf("ValidateValues() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		want  ErrInvalidFieldValue
+	}{
+		{name: "required textarea", key: "form-field-logs", value: " ", want: ErrInvalidFieldValue{Label: "Logs", Reason: "required"}},
+		{name: "partial regex match", key: "form-field-version", value: "v12-rc1", want: ErrInvalidFieldValue{Label: "Version", Reason: "regex"}},
+		{name: "not a number", key: "form-field-count", value: "two", want: ErrInvalidFieldValue{Label: "Count", Reason: "number"}},
+		{name: "required dropdown", key: "form-field-os", value: "", want: ErrInvalidFieldValue{Label: "OS", Reason: "required"}},
+		{name: "unknown option", key: "form-field-os", value: "2", want: ErrInvalidFieldValue{Label: "OS", Reason: "option"}},
+		{name: "several options", key: "form-field-os", value: "0,1", want: ErrInvalidFieldValue{Label: "OS", Reason: "option"}},
+		{name: "required checkbox", key: "form-field-terms-0", value: "", want: ErrInvalidFieldValue{Label: "I agree to the code of conduct", Reason: "required"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := url.Values{}
+			for k, v := range valid {
+				values[k] = v
+			}
+			values.Set(tt.key, tt.value)
+			if err := ValidateValues(template, values); err != tt.want {
+				t.Errorf("ValidateValues() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// the values are kept to render the form again
+	fields := FieldsWithValues(template, valid)
+	if got := fields[1].Attributes["value"]; got != "some logs" {
+		t.Errorf("FieldsWithValues() textarea value = %v", got)
+	}
+	if got := fields[4].Attributes["value"]; got != "1" {
+		t.Errorf("FieldsWithValues() dropdown value = %v", got)
+	}
+	options := fields[5].Attributes["options"].([]interface{})
+	if checked := options[0].(map[interface{}]interface{})["checked"]; checked != true {
+		t.Errorf("FieldsWithValues() checkbox checked = %v", checked)
+	}
+	if _, ok := template.Fields[1].Attributes["value"]; ok {
+		t.Errorf("FieldsWithValues() changed the template")
+	}
+}
+
 func Test_minQuotes(t *testing.T) {
 	type args struct {
 		value string
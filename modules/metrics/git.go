@@ -0,0 +1,23 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// GitUploadPackFilterRequests counts the git upload-pack requests by kind of partial clone filter
+	GitUploadPackFilterRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: namespace + "git_upload_pack_filter_requests_total",
+		Help: "Number of git upload-pack requests with a partial clone filter",
+	}, []string{"filter"})
+
+	// GitObjectInfoRequests counts the git upload-pack requests of the object-info command
+	GitObjectInfoRequests = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: namespace + "git_object_info_requests_total",
+		Help: "Number of git upload-pack requests of the object-info command",
+	})
+)
@@ -10,7 +10,9 @@ import (
 	"strings"
 
 	activities_model "code.gitea.io/gitea/models/activities"
+	advisory_model "code.gitea.io/gitea/models/advisory"
 	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
@@ -182,6 +184,31 @@ func (a *actionNotifier) NotifyTransferRepository(doer *user_model.User, repo *r
 	}
 }
 
+// NotifyStarRepository only adds the star to the feed of the user, the watchers of the repository aren't notified.
+// The star is removed from the feed when the repository is unstarred.
+func (a *actionNotifier) NotifyStarRepository(doer *user_model.User, repo *repo_model.Repository, star bool) {
+	if !star {
+		if _, err := db.DeleteByBean(db.DefaultContext, &activities_model.Action{
+			UserID:    doer.ID,
+			ActUserID: doer.ID,
+			OpType:    activities_model.ActionStarRepo,
+			RepoID:    repo.ID,
+		}); err != nil {
+			log.Error("delete star actions '%d/%d': %v", doer.ID, repo.ID, err)
+		}
+		return
+	}
+	if err := db.Insert(db.DefaultContext, &activities_model.Action{
+		UserID:    doer.ID,
+		ActUserID: doer.ID,
+		OpType:    activities_model.ActionStarRepo,
+		RepoID:    repo.ID,
+		IsPrivate: repo.IsPrivate,
+	}); err != nil {
+		log.Error("insert star action '%d/%d': %v", doer.ID, repo.ID, err)
+	}
+}
+
 func (a *actionNotifier) NotifyCreateRepository(doer, u *user_model.User, repo *repo_model.Repository) {
 	if err := activities_model.NotifyWatchers(&activities_model.Action{
 		ActUserID: doer.ID,
@@ -441,3 +468,46 @@ func (a *actionNotifier) NotifyNewRelease(rel *repo_model.Release) {
 		log.Error("notifyWatchers: %v", err)
 	}
 }
+
+func (a *actionNotifier) NotifyPublishAdvisory(doer *user_model.User, adv *advisory_model.Advisory) {
+	if err := adv.LoadAttributes(db.DefaultContext); err != nil {
+		log.Error("NotifyPublishAdvisory: %v", err)
+		return
+	}
+	if err := activities_model.NotifyWatchers(&activities_model.Action{
+		ActUserID: doer.ID,
+		ActUser:   doer,
+		OpType:    activities_model.ActionPublishAdvisory,
+		RepoID:    adv.RepoID,
+		Repo:      adv.Repo,
+		IsPrivate: adv.Repo.IsPrivate,
+		Content:   fmt.Sprintf("%d|%s", adv.ID, adv.Title),
+	}); err != nil {
+		log.Error("notifyWatchers: %v", err)
+	}
+}
+
+// NotifyCreateCommitStatus records the finished workflow runs, i.e. the successful or failed commit statuses.
+// The content is "sha|target URL|context", the target URL being the link to the run.
+func (a *actionNotifier) NotifyCreateCommitStatus(doer *user_model.User, repo *repo_model.Repository, sha string, status *git_model.CommitStatus) {
+	var opType activities_model.ActionType
+	switch {
+	case status.State.IsSuccess():
+		opType = activities_model.ActionWorkflowRunSuccess
+	case status.State.IsFailure(), status.State.IsError():
+		opType = activities_model.ActionWorkflowRunFailure
+	default:
+		return
+	}
+	if err := activities_model.NotifyWatchers(&activities_model.Action{
+		ActUserID: doer.ID,
+		ActUser:   doer,
+		OpType:    opType,
+		RepoID:    repo.ID,
+		Repo:      repo,
+		IsPrivate: repo.IsPrivate,
+		Content:   fmt.Sprintf("%s|%s|%s", sha, status.TargetURL, status.Context),
+	}); err != nil {
+		log.Error("notifyWatchers: %v", err)
+	}
+}
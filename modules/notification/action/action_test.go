@@ -10,9 +10,11 @@ import (
 	"testing"
 
 	activities_model "code.gitea.io/gitea/models/activities"
+	git_model "code.gitea.io/gitea/models/git"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -51,3 +53,51 @@ func TestRenameRepoAction(t *testing.T) {
 	unittest.AssertExistsAndLoadBean(t, actionBean)
 	unittest.CheckConsistencyFor(t, &activities_model.Action{})
 }
+
+func TestCreateCommitStatusAction(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	const sha = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+
+	notify := func(state api.CommitStatusState) {
+		NewNotifier().NotifyCreateCommitStatus(user, repo, sha, &git_model.CommitStatus{
+			State:     state,
+			TargetURL: "https://ci.example.com/runs/1",
+			Context:   "ci/build",
+		})
+	}
+	content := sha + "|https://ci.example.com/runs/1|ci/build"
+
+	// the runs which are not finished are not recorded
+	notify(api.CommitStatusPending)
+	unittest.AssertNotExistsBean(t, &activities_model.Action{RepoID: repo.ID, Content: content})
+
+	notify(api.CommitStatusSuccess)
+	unittest.AssertExistsAndLoadBean(t, &activities_model.Action{RepoID: repo.ID, OpType: activities_model.ActionWorkflowRunSuccess, Content: content})
+	notify(api.CommitStatusError)
+	unittest.AssertExistsAndLoadBean(t, &activities_model.Action{RepoID: repo.ID, OpType: activities_model.ActionWorkflowRunFailure, Content: content})
+	unittest.CheckConsistencyFor(t, &activities_model.Action{})
+}
+
+func TestStarRepoAction(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	actionBean := &activities_model.Action{
+		UserID:    user.ID,
+		ActUserID: user.ID,
+		OpType:    activities_model.ActionStarRepo,
+		RepoID:    repo.ID,
+	}
+
+	NewNotifier().NotifyStarRepository(user, repo, true)
+	unittest.AssertExistsAndLoadBean(t, actionBean)
+	// the watchers of the repository are not notified
+	assert.EqualValues(t, 1, unittest.GetCount(t, &activities_model.Action{OpType: activities_model.ActionStarRepo, RepoID: repo.ID}))
+
+	NewNotifier().NotifyStarRepository(user, repo, false)
+	unittest.AssertNotExistsBean(t, actionBean)
+}
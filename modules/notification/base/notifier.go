@@ -5,6 +5,9 @@
 package base
 
 import (
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	deployment_model "code.gitea.io/gitea/models/deployment"
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -21,12 +24,14 @@ type Notifier interface {
 	NotifyForkRepository(doer *user_model.User, oldRepo, repo *repo_model.Repository)
 	NotifyRenameRepository(doer *user_model.User, repo *repo_model.Repository, oldRepoName string)
 	NotifyTransferRepository(doer *user_model.User, repo *repo_model.Repository, oldOwnerName string)
+	NotifyStarRepository(doer *user_model.User, repo *repo_model.Repository, star bool)
 	NotifyNewIssue(issue *issues_model.Issue, mentions []*user_model.User)
 	NotifyIssueChangeStatus(*user_model.User, *issues_model.Issue, *issues_model.Comment, bool)
 	NotifyDeleteIssue(*user_model.User, *issues_model.Issue)
 	NotifyIssueChangeMilestone(doer *user_model.User, issue *issues_model.Issue, oldMilestoneID int64)
 	NotifyIssueChangeAssignee(doer *user_model.User, issue *issues_model.Issue, assignee *user_model.User, removed bool, comment *issues_model.Comment)
 	NotifyPullReviewRequest(doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, isRequest bool, comment *issues_model.Comment)
+	NotifyPullReviewReminder(doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, escalated bool)
 	NotifyIssueChangeContent(doer *user_model.User, issue *issues_model.Issue, oldContent string)
 	NotifyIssueClearLabels(doer *user_model.User, issue *issues_model.Issue)
 	NotifyIssueChangeTitle(doer *user_model.User, issue *issues_model.Issue, oldTitle string)
@@ -60,4 +65,8 @@ type Notifier interface {
 	NotifyRepoPendingTransfer(doer, newOwner *user_model.User, repo *repo_model.Repository)
 	NotifyPackageCreate(doer *user_model.User, pd *packages_model.PackageDescriptor)
 	NotifyPackageDelete(doer *user_model.User, pd *packages_model.PackageDescriptor)
+	NotifyDeploymentCreate(doer *user_model.User, repo *repo_model.Repository, d *deployment_model.Deployment)
+	NotifyDeploymentStatus(doer *user_model.User, repo *repo_model.Repository, d *deployment_model.Deployment, status *deployment_model.Status)
+	NotifyPublishAdvisory(doer *user_model.User, a *advisory_model.Advisory)
+	NotifyCreateCommitStatus(doer *user_model.User, repo *repo_model.Repository, sha string, status *git_model.CommitStatus)
 }
@@ -5,6 +5,9 @@
 package base
 
 import (
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	deployment_model "code.gitea.io/gitea/models/deployment"
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -118,6 +121,10 @@ func (*NullNotifier) NotifyIssueChangeAssignee(doer *user_model.User, issue *iss
 func (*NullNotifier) NotifyPullReviewRequest(doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, isRequest bool, comment *issues_model.Comment) {
 }
 
+// NotifyPullReviewReminder places a place holder function
+func (*NullNotifier) NotifyPullReviewReminder(doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, escalated bool) {
+}
+
 // NotifyIssueClearLabels places a place holder function
 func (*NullNotifier) NotifyIssueClearLabels(doer *user_model.User, issue *issues_model.Issue) {
 }
@@ -194,3 +201,23 @@ func (*NullNotifier) NotifyPackageCreate(doer *user_model.User, pd *packages_mod
 // NotifyPackageDelete places a place holder function
 func (*NullNotifier) NotifyPackageDelete(doer *user_model.User, pd *packages_model.PackageDescriptor) {
 }
+
+// NotifyDeploymentCreate places a place holder function
+func (*NullNotifier) NotifyDeploymentCreate(doer *user_model.User, repo *repo_model.Repository, d *deployment_model.Deployment) {
+}
+
+// NotifyDeploymentStatus places a place holder function
+func (*NullNotifier) NotifyDeploymentStatus(doer *user_model.User, repo *repo_model.Repository, d *deployment_model.Deployment, status *deployment_model.Status) {
+}
+
+// NotifyStarRepository places a place holder function
+func (*NullNotifier) NotifyStarRepository(doer *user_model.User, repo *repo_model.Repository, star bool) {
+}
+
+// NotifyPublishAdvisory places a place holder function
+func (*NullNotifier) NotifyPublishAdvisory(doer *user_model.User, a *advisory_model.Advisory) {
+}
+
+// NotifyCreateCommitStatus places a place holder function
+func (*NullNotifier) NotifyCreateCommitStatus(doer *user_model.User, repo *repo_model.Repository, sha string, status *git_model.CommitStatus) {
+}
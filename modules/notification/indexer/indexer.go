@@ -10,6 +10,7 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	expertise_indexer "code.gitea.io/gitea/modules/indexer/expertise"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
 	stats_indexer "code.gitea.io/gitea/modules/indexer/stats"
 	"code.gitea.io/gitea/modules/log"
@@ -122,6 +123,11 @@ func (r *indexerNotifier) NotifyMigrateRepository(doer, u *user_model.User, repo
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
+	if setting.Indexer.ExpertiseIndexerEnabled && !repo.IsEmpty {
+		if err := expertise_indexer.UpdateRepoIndexer(repo); err != nil {
+			log.Error("expertise_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+	}
 }
 
 func (r *indexerNotifier) NotifyPushCommits(pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
@@ -131,6 +137,11 @@ func (r *indexerNotifier) NotifyPushCommits(pusher *user_model.User, repo *repo_
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
+	if setting.Indexer.ExpertiseIndexerEnabled && opts.RefFullName == git.BranchPrefix+repo.DefaultBranch {
+		if err := expertise_indexer.UpdateRepoIndexer(repo); err != nil {
+			log.Error("expertise_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+	}
 }
 
 func (r *indexerNotifier) NotifySyncPushCommits(pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
@@ -140,6 +151,11 @@ func (r *indexerNotifier) NotifySyncPushCommits(pusher *user_model.User, repo *r
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
+	if setting.Indexer.ExpertiseIndexerEnabled && opts.RefFullName == git.BranchPrefix+repo.DefaultBranch {
+		if err := expertise_indexer.UpdateRepoIndexer(repo); err != nil {
+			log.Error("expertise_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+	}
 }
 
 func (r *indexerNotifier) NotifyIssueChangeContent(doer *user_model.User, issue *issues_model.Issue, oldContent string) {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	activities_model "code.gitea.io/gitea/models/activities"
+	advisory_model "code.gitea.io/gitea/models/advisory"
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
@@ -143,6 +144,19 @@ func (m *mailNotifier) NotifyPullReviewRequest(doer *user_model.User, issue *iss
 	}
 }
 
+func (m *mailNotifier) NotifyPullReviewReminder(doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, escalated bool) {
+	if reviewer.EmailNotifications() == user_model.EmailNotificationsDisabled {
+		return
+	}
+	ct := fmt.Sprintf("Reminder: your review is still requested on %s.", issue.HTMLURL())
+	if escalated {
+		ct = fmt.Sprintf("A review requested on %s has been pending for a long time and was escalated to you.", issue.HTMLURL())
+	}
+	if err := mailer.SendIssueAssignedMail(issue, doer, ct, nil, []*user_model.User{reviewer}); err != nil {
+		log.Error("Error in SendIssueAssignedMail for issue[%d] to reviewer[%d]: %v", issue.ID, reviewer.ID, err)
+	}
+}
+
 func (m *mailNotifier) NotifyMergePullRequest(pr *issues_model.PullRequest, doer *user_model.User) {
 	if err := pr.LoadIssue(); err != nil {
 		log.Error("pr.LoadIssue: %v", err)
@@ -205,6 +219,18 @@ func (m *mailNotifier) NotifyNewRelease(rel *repo_model.Release) {
 	mailer.MailNewRelease(ctx, rel)
 }
 
+func (m *mailNotifier) NotifyPublishAdvisory(doer *user_model.User, adv *advisory_model.Advisory) {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().HammerContext(), fmt.Sprintf("mailNotifier.NotifyPublishAdvisory advisory[%d] in [%d]", adv.ID, adv.RepoID))
+	defer finished()
+
+	if err := adv.LoadAttributes(ctx); err != nil {
+		log.Error("NotifyPublishAdvisory: %v", err)
+		return
+	}
+
+	mailer.MailPublishedAdvisory(ctx, doer, adv)
+}
+
 func (m *mailNotifier) NotifyRepoPendingTransfer(doer, newOwner *user_model.User, repo *repo_model.Repository) {
 	if err := mailer.SendRepoTransferNotifyMail(doer, newOwner, repo); err != nil {
 		log.Error("NotifyRepoPendingTransfer: %v", err)
@@ -5,6 +5,9 @@
 package notification
 
 import (
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	deployment_model "code.gitea.io/gitea/models/deployment"
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -210,6 +213,13 @@ func NotifyPullReviewRequest(doer *user_model.User, issue *issues_model.Issue, r
 	}
 }
 
+// NotifyPullReviewReminder notifies a reviewer about a pending review request
+func NotifyPullReviewReminder(doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, escalated bool) {
+	for _, notifier := range notifiers {
+		notifier.NotifyPullReviewReminder(doer, issue, reviewer, escalated)
+	}
+}
+
 // NotifyIssueClearLabels notifies clear labels to notifiers
 func NotifyIssueClearLabels(doer *user_model.User, issue *issues_model.Issue) {
 	for _, notifier := range notifiers {
@@ -344,3 +354,38 @@ func NotifyPackageDelete(doer *user_model.User, pd *packages_model.PackageDescri
 		notifier.NotifyPackageDelete(doer, pd)
 	}
 }
+
+// NotifyDeploymentCreate notifies creation of a deployment to notifiers
+func NotifyDeploymentCreate(doer *user_model.User, repo *repo_model.Repository, d *deployment_model.Deployment) {
+	for _, notifier := range notifiers {
+		notifier.NotifyDeploymentCreate(doer, repo, d)
+	}
+}
+
+// NotifyDeploymentStatus notifies a status change of a deployment to notifiers
+func NotifyDeploymentStatus(doer *user_model.User, repo *repo_model.Repository, d *deployment_model.Deployment, status *deployment_model.Status) {
+	for _, notifier := range notifiers {
+		notifier.NotifyDeploymentStatus(doer, repo, d, status)
+	}
+}
+
+// NotifyStarRepository notifies that a user starred or unstarred a repository to notifiers
+func NotifyStarRepository(doer *user_model.User, repo *repo_model.Repository, star bool) {
+	for _, notifier := range notifiers {
+		notifier.NotifyStarRepository(doer, repo, star)
+	}
+}
+
+// NotifyPublishAdvisory notifies the publication of a security advisory to notifiers
+func NotifyPublishAdvisory(doer *user_model.User, a *advisory_model.Advisory) {
+	for _, notifier := range notifiers {
+		notifier.NotifyPublishAdvisory(doer, a)
+	}
+}
+
+// NotifyCreateCommitStatus notifies a new status of a commit, e.g. the result of a CI run, to notifiers
+func NotifyCreateCommitStatus(doer *user_model.User, repo *repo_model.Repository, sha string, status *git_model.CommitStatus) {
+	for _, notifier := range notifiers {
+		notifier.NotifyCreateCommitStatus(doer, repo, sha, status)
+	}
+}
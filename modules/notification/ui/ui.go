@@ -236,6 +236,14 @@ func (ns *notificationService) NotifyPullReviewRequest(doer *user_model.User, is
 	}
 }
 
+func (ns *notificationService) NotifyPullReviewReminder(doer *user_model.User, issue *issues_model.Issue, reviewer *user_model.User, escalated bool) {
+	_ = ns.issueQueue.Push(issueNotificationOpts{
+		IssueID:              issue.ID,
+		NotificationAuthorID: doer.ID,
+		ReceiverID:           reviewer.ID,
+	})
+}
+
 func (ns *notificationService) NotifyRepoPendingTransfer(doer, newOwner *user_model.User, repo *repo_model.Repository) {
 	if err := activities_model.CreateRepoTransferNotification(doer, newOwner, repo); err != nil {
 		log.Error("NotifyRepoPendingTransfer: %v", err)
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"code.gitea.io/gitea/models/db"
+	deployment_model "code.gitea.io/gitea/models/deployment"
 	issues_model "code.gitea.io/gitea/models/issues"
 	packages_model "code.gitea.io/gitea/models/packages"
 	"code.gitea.io/gitea/models/perm"
@@ -928,3 +929,51 @@ func notifyPackage(sender *user_model.User, pd *packages_model.PackageDescriptor
 		log.Error("PrepareWebhooks: %v", err)
 	}
 }
+
+func (m *webhookNotifier) NotifyDeploymentCreate(doer *user_model.User, repo *repo_model.Repository, d *deployment_model.Deployment) {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().HammerContext(), fmt.Sprintf("webhook.NotifyDeploymentCreate Deployment: %s[%d]", repo.FullName(), d.ID))
+	defer finished()
+
+	apiDeployment, err := convert.ToDeployment(ctx, repo, d)
+	if err != nil {
+		log.Error("ToDeployment: %v", err)
+		return
+	}
+
+	mode, _ := access_model.AccessLevel(doer, repo)
+	if err := webhook_services.PrepareWebhooks(repo, webhook.HookEventDeployment, &api.DeploymentPayload{
+		Action:     api.HookDeploymentCreated,
+		Deployment: apiDeployment,
+		Repository: convert.ToRepo(repo, mode),
+		Sender:     convert.ToUser(doer, nil),
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
+
+func (m *webhookNotifier) NotifyDeploymentStatus(doer *user_model.User, repo *repo_model.Repository, d *deployment_model.Deployment, status *deployment_model.Status) {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().HammerContext(), fmt.Sprintf("webhook.NotifyDeploymentStatus Deployment: %s[%d]", repo.FullName(), d.ID))
+	defer finished()
+
+	apiDeployment, err := convert.ToDeployment(ctx, repo, d)
+	if err != nil {
+		log.Error("ToDeployment: %v", err)
+		return
+	}
+	apiStatus, err := convert.ToDeploymentStatus(ctx, status)
+	if err != nil {
+		log.Error("ToDeploymentStatus: %v", err)
+		return
+	}
+
+	mode, _ := access_model.AccessLevel(doer, repo)
+	if err := webhook_services.PrepareWebhooks(repo, webhook.HookEventDeploymentStatus, &api.DeploymentStatusPayload{
+		Action:           api.HookDeploymentCreated,
+		Deployment:       apiDeployment,
+		DeploymentStatus: apiStatus,
+		Repository:       convert.ToRepo(repo, mode),
+		Sender:           convert.ToUser(doer, nil),
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package osv implements the Open Source Vulnerability format, see https://ossf.github.io/osv-schema/
+package osv
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+// SchemaVersion is the version of the OSV schema the vulnerabilities are written in
+const SchemaVersion = "1.3.1"
+
+// Range types
+const (
+	RangeTypeEcosystem = "ECOSYSTEM"
+	RangeTypeSemver    = "SEMVER"
+)
+
+// Reference types
+const (
+	ReferenceTypeAdvisory = "ADVISORY"
+	ReferenceTypePackage  = "PACKAGE"
+	ReferenceTypeWeb      = "WEB"
+)
+
+var ecosystems = map[string]string{
+	"npm":       "npm",
+	"pypi":      "PyPI",
+	"go":        "Go",
+	"maven":     "Maven",
+	"nuget":     "NuGet",
+	"rubygems":  "RubyGems",
+	"crates.io": "crates.io",
+	"cargo":     "crates.io",
+	"packagist": "Packagist",
+	"composer":  "Packagist",
+	"pub":       "Pub",
+	"hex":       "Hex",
+}
+
+// NormalizeEcosystem returns the OSV name of a known ecosystem, unknown ecosystems are returned as is
+func NormalizeEcosystem(ecosystem string) string {
+	ecosystem = strings.TrimSpace(ecosystem)
+	if name, ok := ecosystems[strings.ToLower(ecosystem)]; ok {
+		return name
+	}
+	return ecosystem
+}
+
+// Vulnerability represents an OSV vulnerability entry
+type Vulnerability struct {
+	SchemaVersion    string                 `json:"schema_version"`
+	ID               string                 `json:"id"`
+	Modified         time.Time              `json:"modified"`
+	Published        *time.Time             `json:"published,omitempty"`
+	Withdrawn        *time.Time             `json:"withdrawn,omitempty"`
+	Aliases          []string               `json:"aliases,omitempty"`
+	Summary          string                 `json:"summary,omitempty"`
+	Details          string                 `json:"details,omitempty"`
+	Affected         []*Affected            `json:"affected,omitempty"`
+	References       []*Reference           `json:"references,omitempty"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific,omitempty"`
+}
+
+// Package identifies an affected package
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+// Affected describes the affected versions of a package
+type Affected struct {
+	Package  Package  `json:"package"`
+	Ranges   []*Range `json:"ranges,omitempty"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+// Range represents a list of version events
+type Range struct {
+	Type   string   `json:"type"`
+	Events []*Event `json:"events"`
+}
+
+// Event represents a version at which a package becomes vulnerable or is not vulnerable anymore
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+}
+
+// Reference links to further information about the vulnerability
+type Reference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Affects returns true if the version of the package is affected by the vulnerability
+func (v *Vulnerability) Affects(ecosystem, name, ver string) bool {
+	for _, affected := range v.Affected {
+		if affected.Package.Ecosystem == NormalizeEcosystem(ecosystem) && affected.Package.Name == name && affected.Affects(ver) {
+			return true
+		}
+	}
+	return false
+}
+
+// Affects returns true if the version is listed or contained in one of the ranges
+func (a *Affected) Affects(ver string) bool {
+	for _, v := range a.Versions {
+		if v == ver {
+			return true
+		}
+	}
+	current, err := version.NewVersion(ver)
+	if err != nil {
+		return false
+	}
+	for _, r := range a.Ranges {
+		if r.Type == RangeTypeEcosystem || r.Type == RangeTypeSemver {
+			if r.contains(current) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type versionEvent struct {
+	version *version.Version // nil for the introduced event "0"
+	event   *Event
+}
+
+// contains evaluates the events in version order, the last event not after the version decides
+func (r *Range) contains(current *version.Version) bool {
+	events := make([]versionEvent, 0, len(r.Events))
+	for _, e := range r.Events {
+		raw := e.Introduced + e.Fixed + e.LastAffected
+		if e.Introduced == "0" {
+			events = append(events, versionEvent{event: e})
+			continue
+		}
+		v, err := version.NewVersion(raw)
+		if err != nil {
+			continue
+		}
+		events = append(events, versionEvent{version: v, event: e})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].version == nil || events[j].version == nil {
+			return events[i].version == nil && events[j].version != nil
+		}
+		return events[i].version.LessThan(events[j].version)
+	})
+
+	affected := false
+	for _, e := range events {
+		if e.version != nil && current.LessThan(e.version) {
+			break
+		}
+		switch {
+		case e.event.Introduced != "":
+			affected = true
+		case e.event.Fixed != "":
+			affected = false
+		case e.event.LastAffected != "":
+			affected = affected && current.Equal(e.version)
+		}
+	}
+	return affected
+}
+
+// ParseVersions converts a version constraint like ">= 1.0.0, < 1.2.3 || = 0.9.1" and a comma-separated
+// list of patched versions into OSV ranges and explicitly listed versions. Ranges without an upper bound
+// are closed by the first patched version which is not lower than their start.
+func ParseVersions(vulnerable, patched string) ([]*Range, []string) {
+	var fixes []string
+	for _, p := range strings.Split(patched, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			fixes = append(fixes, p)
+		}
+	}
+
+	var ranges []*Range
+	var versions []string
+	for _, alternative := range strings.Split(vulnerable, "||") {
+		introduced, fixed, lastAffected, exact := "", "", "", ""
+		for _, constraint := range strings.Split(alternative, ",") {
+			constraint = strings.TrimSpace(constraint)
+			switch {
+			case constraint == "":
+			case strings.HasPrefix(constraint, ">="):
+				introduced = strings.TrimSpace(constraint[2:])
+			case strings.HasPrefix(constraint, "<="):
+				lastAffected = strings.TrimSpace(constraint[2:])
+			case strings.HasPrefix(constraint, "<"):
+				fixed = strings.TrimSpace(constraint[1:])
+			case strings.HasPrefix(constraint, "="):
+				exact = strings.TrimSpace(strings.TrimLeft(constraint, "="))
+			default:
+				exact = constraint
+			}
+		}
+		if exact != "" {
+			versions = append(versions, exact)
+			continue
+		}
+		if introduced == "" && fixed == "" && lastAffected == "" {
+			continue
+		}
+		if fixed == "" && lastAffected == "" {
+			fixed = firstFixAfter(fixes, introduced)
+		}
+		if introduced == "" {
+			introduced = "0"
+		}
+		r := &Range{Type: RangeTypeEcosystem, Events: []*Event{{Introduced: introduced}}}
+		if fixed != "" {
+			r.Events = append(r.Events, &Event{Fixed: fixed})
+		} else if lastAffected != "" {
+			r.Events = append(r.Events, &Event{LastAffected: lastAffected})
+		}
+		ranges = append(ranges, r)
+	}
+
+	// without a vulnerable range every version before the first fix is affected
+	if len(ranges) == 0 && len(versions) == 0 && len(fixes) > 0 {
+		ranges = append(ranges, &Range{Type: RangeTypeEcosystem, Events: []*Event{{Introduced: "0"}, {Fixed: fixes[0]}}})
+	}
+	return ranges, versions
+}
+
+func firstFixAfter(fixes []string, introduced string) string {
+	start, err := version.NewVersion(introduced)
+	for _, fix := range fixes {
+		if introduced == "" || err != nil {
+			return fix
+		}
+		if v, err := version.NewVersion(fix); err == nil && !v.LessThan(start) {
+			return fix
+		}
+	}
+	return ""
+}
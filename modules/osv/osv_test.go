@@ -0,0 +1,66 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package osv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEcosystem(t *testing.T) {
+	assert.Equal(t, "PyPI", NormalizeEcosystem("pypi"))
+	assert.Equal(t, "npm", NormalizeEcosystem(" NPM "))
+	assert.Equal(t, "crates.io", NormalizeEcosystem("cargo"))
+	assert.Equal(t, "Linux", NormalizeEcosystem("Linux"))
+}
+
+func TestParseVersions(t *testing.T) {
+	ranges, versions := ParseVersions(">= 1.0.0, < 1.2.3 || = 0.9.1", "")
+	assert.Equal(t, []string{"0.9.1"}, versions)
+	assert.Equal(t, []*Range{{Type: RangeTypeEcosystem, Events: []*Event{{Introduced: "1.0.0"}, {Fixed: "1.2.3"}}}}, ranges)
+
+	ranges, versions = ParseVersions("<= 2.0.0", "")
+	assert.Empty(t, versions)
+	assert.Equal(t, []*Range{{Type: RangeTypeEcosystem, Events: []*Event{{Introduced: "0"}, {LastAffected: "2.0.0"}}}}, ranges)
+
+	// open ranges are closed by the matching patched version
+	ranges, _ = ParseVersions(">= 1.0.0 || >= 2.0.0", "1.4.2, 2.1.0")
+	assert.Equal(t, []*Range{
+		{Type: RangeTypeEcosystem, Events: []*Event{{Introduced: "1.0.0"}, {Fixed: "1.4.2"}}},
+		{Type: RangeTypeEcosystem, Events: []*Event{{Introduced: "2.0.0"}, {Fixed: "2.1.0"}}},
+	}, ranges)
+
+	ranges, _ = ParseVersions("", "3.0.1")
+	assert.Equal(t, []*Range{{Type: RangeTypeEcosystem, Events: []*Event{{Introduced: "0"}, {Fixed: "3.0.1"}}}}, ranges)
+}
+
+func TestAffects(t *testing.T) {
+	ranges, versions := ParseVersions(">= 1.0.0, < 1.2.3 || <= 0.5.0 || = 0.9.1", "")
+	vuln := &Vulnerability{
+		Affected: []*Affected{{
+			Package:  Package{Ecosystem: "npm", Name: "left-pad"},
+			Ranges:   ranges,
+			Versions: versions,
+		}},
+	}
+
+	cases := map[string]bool{
+		"0.1.0": true,
+		"0.5.0": true,
+		"0.6.0": false,
+		"0.9.1": true,
+		"0.9.2": false,
+		"1.0.0": true,
+		"1.2.2": true,
+		"1.2.3": false,
+		"2.0.0": false,
+	}
+	for ver, expected := range cases {
+		assert.Equal(t, expected, vuln.Affects("npm", "left-pad", ver), ver)
+	}
+	assert.False(t, vuln.Affects("PyPI", "left-pad", "1.0.0"))
+	assert.False(t, vuln.Affects("npm", "right-pad", "1.0.0"))
+}
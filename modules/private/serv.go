@@ -55,6 +55,8 @@ type ServCommandResults struct {
 	OwnerName   string
 	RepoName    string
 	RepoID      int64
+	// GitEnv is the environment restricting the partial clone filters of git upload-pack
+	GitEnv []string
 }
 
 // ErrServCommand is an error returned from ServCommmand.
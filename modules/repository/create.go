@@ -149,6 +149,10 @@ func CreateRepositoryByExample(ctx context.Context, doer, u *user_model.User, re
 		return fmt.Errorf("CopyDefaultWebhooksToRepo: %v", err)
 	}
 
+	if err = webhook.SyncWebhookTemplatesToRepo(ctx, repo); err != nil {
+		return fmt.Errorf("SyncWebhookTemplatesToRepo: %v", err)
+	}
+
 	return nil
 }
 
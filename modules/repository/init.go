@@ -12,9 +12,11 @@ import (
 	"path"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
@@ -72,6 +74,11 @@ func GetRepoInitFile(tp, name string) ([]byte, error) {
 		return os.ReadFile(customPath)
 	}
 
+	// Then the file of a custom set.
+	if data, err := getCustomInitFile(tp, cleanedName); data != nil || err != nil {
+		return data, err
+	}
+
 	switch tp {
 	case "readme":
 		return options.Readme(cleanedName)
@@ -151,6 +158,8 @@ func LoadLabelsFormatted(labelTemplate string) (string, error) {
 
 // LoadRepoConfig loads the repository config
 func LoadRepoConfig() {
+	customInitFiles = loadCustomInitFiles(db.DefaultContext)
+
 	// Load .gitignore and license files and readme templates.
 	types := []string{"gitignore", "license", "readme", "label"}
 	typeFiles := make([][]string, 4)
@@ -176,6 +185,11 @@ func LoadRepoConfig() {
 				}
 			}
 		}
+		for name := range customInitFiles[t] {
+			if !util.IsStringInSlice(name, files, true) {
+				files = append(files, name)
+			}
+		}
 		typeFiles[i] = files
 	}
 
@@ -282,7 +296,10 @@ func prepareRepoCommit(ctx context.Context, repo *repo_model.Repository, tmpDir,
 
 	// LICENSE
 	if len(opts.License) > 0 {
-		data, err = GetRepoInitFile("license", opts.License)
+		data, err = GetLicense(opts.License, LicenseValues{
+			Year:   strconv.Itoa(time.Now().Year()),
+			Author: repo.Owner.DisplayName(),
+		})
 		if err != nil {
 			return fmt.Errorf("GetRepoInitFile[%s]: %v", opts.License, err)
 		}
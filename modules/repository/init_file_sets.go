@@ -0,0 +1,151 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// maxInitFileSize is the maximum size of a file of a custom set of init files read from a repository
+const maxInitFileSize = 1024 * 1024
+
+// initFileTypes are the types of init files, they are the subdirectories of a set of init files
+var initFileTypes = []string{"gitignore", "license", "readme", "label"}
+
+// customInitFile is a file of a custom set of init files
+type customInitFile struct {
+	Path    string // the file of a set directory
+	Content []byte // the file of a set repository
+}
+
+// customInitFiles holds the files of the custom sets of init files by type and name, a file is provided by the first
+// set having it
+var customInitFiles = map[string]map[string]*customInitFile{}
+
+// getCustomInitFile returns the content of a file of the custom sets of init files, nil if no set has it
+func getCustomInitFile(tp, name string) ([]byte, error) {
+	file, ok := customInitFiles[tp][name]
+	if !ok {
+		return nil, nil
+	}
+	if file.Content != nil {
+		return file.Content, nil
+	}
+	return os.ReadFile(file.Path)
+}
+
+// loadCustomInitFiles loads the custom sets of init files of the directories then the repositories of the settings
+func loadCustomInitFiles(ctx context.Context) map[string]map[string]*customInitFile {
+	files := make(map[string]map[string]*customInitFile, len(initFileTypes))
+	for _, tp := range initFileTypes {
+		files[tp] = map[string]*customInitFile{}
+	}
+
+	for _, dir := range setting.Repository.CustomInitFileDirs {
+		if err := loadCustomInitFilesFromDir(files, dir); err != nil {
+			log.Error("Unable to load the init files of %s: %v", dir, err)
+		}
+	}
+	for _, fullName := range setting.Repository.CustomInitFileRepos {
+		if err := loadCustomInitFilesFromRepo(ctx, files, fullName); err != nil {
+			log.Error("Unable to load the init files of the repository %s: %v", fullName, err)
+		}
+	}
+	return files
+}
+
+// loadCustomInitFilesFromDir adds the init files of a set directory which aren't provided by a former set
+func loadCustomInitFilesFromDir(files map[string]map[string]*customInitFile, dir string) error {
+	for _, tp := range initFileTypes {
+		typeDir := filepath.Join(dir, tp)
+		isDir, err := util.IsDir(typeDir)
+		if err != nil {
+			return err
+		}
+		if !isDir {
+			continue
+		}
+		names, err := util.StatDir(typeDir)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if _, ok := files[tp][name]; !ok {
+				files[tp][name] = &customInitFile{Path: filepath.Join(typeDir, name)}
+			}
+		}
+	}
+	return nil
+}
+
+// loadCustomInitFilesFromRepo adds the init files of the default branch of a set repository which aren't provided by
+// a former set
+func loadCustomInitFilesFromRepo(ctx context.Context, files map[string]map[string]*customInitFile, fullName string) error {
+	ownerName, repoName, ok := strings.Cut(fullName, "/")
+	if !ok {
+		return fmt.Errorf("invalid repository name %q", fullName)
+	}
+	repo, err := repo_model.GetRepositoryByOwnerAndNameCtx(ctx, ownerName, repoName)
+	if err != nil {
+		return err
+	}
+	if repo.IsEmpty {
+		return nil
+	}
+	gitRepo, err := git.OpenRepository(ctx, repo.RepoPath())
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+	commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+	if err != nil {
+		return err
+	}
+
+	for _, tp := range initFileTypes {
+		tree, err := commit.SubTree(tp)
+		if err != nil {
+			if git.IsErrNotExist(err) {
+				continue
+			}
+			return err
+		}
+		entries, err := tree.ListEntries()
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if _, ok := files[tp][entry.Name()]; ok || !entry.IsRegular() || entry.Blob().Size() > maxInitFileSize {
+				continue
+			}
+			content, err := readBlob(entry.Blob())
+			if err != nil {
+				return err
+			}
+			files[tp][entry.Name()] = &customInitFile{Content: content}
+		}
+	}
+	return nil
+}
+
+func readBlob(blob *git.Blob) ([]byte, error) {
+	reader, err := blob.DataAsync()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomInitFiles(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	dirs, repos := setting.Repository.CustomInitFileDirs, setting.Repository.CustomInitFileRepos
+	defer func() {
+		setting.Repository.CustomInitFileDirs, setting.Repository.CustomInitFileRepos = dirs, repos
+		customInitFiles = map[string]map[string]*customInitFile{}
+	}()
+
+	first, second := t.TempDir(), t.TempDir()
+	for dir, content := range map[string]string{first: "first", second: "second"} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "license"), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "license", "Custom"), []byte(content+" <year>"), 0o644))
+	}
+	assert.NoError(t, os.MkdirAll(filepath.Join(second, "gitignore"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(second, "gitignore", "Custom"), []byte("*.custom"), 0o644))
+	setting.Repository.CustomInitFileDirs = []string{first, second}
+	// the repositories without sets or which don't exist are skipped
+	setting.Repository.CustomInitFileRepos = []string{"user2/repo1", "user2/unknown"}
+
+	customInitFiles = loadCustomInitFiles(db.DefaultContext)
+
+	// the first set having a file provides it
+	data, err := GetLicense("Custom", LicenseValues{Year: "2022"})
+	assert.NoError(t, err)
+	assert.Equal(t, "first 2022", string(data))
+
+	data, err = GetRepoInitFile("gitignore", "Custom")
+	assert.NoError(t, err)
+	assert.Equal(t, "*.custom", string(data))
+
+	// the bundled files are still available
+	data, err = GetRepoInitFile("license", "MIT")
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "MIT License")
+}
@@ -0,0 +1,52 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repository
+
+import (
+	"strings"
+)
+
+// LicenseValues are the values of the placeholders of a license template
+type LicenseValues struct {
+	Year   string
+	Author string
+}
+
+// licenseYearPlaceholders and licenseAuthorPlaceholders are the placeholders used by the bundled license templates
+var (
+	licenseYearPlaceholders   = []string{"<year>", "<YEAR>", "[year]", "[yyyy]"}
+	licenseAuthorPlaceholders = []string{
+		"<copyright holders>", "<COPYRIGHT HOLDER>", "<name of author>", "<owner>", "<OWNER>",
+		"[name of copyright owner]", "[NAME]",
+	}
+)
+
+// FillLicense replaces the placeholders of a license template by the values, the placeholders of empty values are kept
+func FillLicense(data []byte, values LicenseValues) []byte {
+	oldnew := make([]string, 0, 2*(len(licenseYearPlaceholders)+len(licenseAuthorPlaceholders)))
+	if values.Year != "" {
+		for _, placeholder := range licenseYearPlaceholders {
+			oldnew = append(oldnew, placeholder, values.Year)
+		}
+	}
+	if values.Author != "" {
+		for _, placeholder := range licenseAuthorPlaceholders {
+			oldnew = append(oldnew, placeholder, values.Author)
+		}
+	}
+	if len(oldnew) == 0 {
+		return data
+	}
+	return []byte(strings.NewReplacer(oldnew...).Replace(string(data)))
+}
+
+// GetLicense returns the license template of the given name with its placeholders replaced by the values
+func GetLicense(name string, values LicenseValues) ([]byte, error) {
+	data, err := GetRepoInitFile("license", name)
+	if err != nil {
+		return nil, err
+	}
+	return FillLicense(data, values), nil
+}
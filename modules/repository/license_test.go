@@ -0,0 +1,22 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillLicense(t *testing.T) {
+	template := []byte("Copyright (c) <year> <copyright holders>\nCopyright [yyyy] [name of copyright owner]")
+
+	assert.Equal(t, "Copyright (c) 2022 Gitea\nCopyright 2022 Gitea",
+		string(FillLicense(template, LicenseValues{Year: "2022", Author: "Gitea"})))
+	// the placeholders of empty values are kept
+	assert.Equal(t, "Copyright (c) 2022 <copyright holders>\nCopyright 2022 [name of copyright owner]",
+		string(FillLicense(template, LicenseValues{Year: "2022"})))
+	assert.Equal(t, string(template), string(FillLicense(template, LicenseValues{})))
+}
@@ -0,0 +1,233 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package sarif parses the parts of SARIF (Static Analysis Results Interchange Format) 2.1.0
+// reports needed to store code scanning alerts.
+package sarif
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+)
+
+// ErrInvalidReport is returned if the report is not a SARIF 2.1.0 document
+var ErrInvalidReport = errors.New("invalid SARIF report")
+
+// Report represents a SARIF log file
+type Report struct {
+	Version string `json:"version"`
+	Runs    []*Run `json:"runs"`
+}
+
+// Run represents a single invocation of an analysis tool
+type Run struct {
+	Tool              Tool               `json:"tool"`
+	Results           []*Result          `json:"results"`
+	AutomationDetails *AutomationDetails `json:"automationDetails"`
+}
+
+// AutomationDetails describes the automation which produced a run
+type AutomationDetails struct {
+	ID string `json:"id"`
+}
+
+// Tool describes the analysis tool of a run
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver describes the tool component containing the rules
+type Driver struct {
+	Name            string  `json:"name"`
+	Version         string  `json:"version"`
+	SemanticVersion string  `json:"semanticVersion"`
+	Rules           []*Rule `json:"rules"`
+}
+
+// Rule describes an analysis rule
+type Rule struct {
+	ID                   string   `json:"id"`
+	Name                 string   `json:"name"`
+	ShortDescription     *Message `json:"shortDescription"`
+	FullDescription      *Message `json:"fullDescription"`
+	DefaultConfiguration *struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+// Message represents a SARIF message string
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result represents a single finding of a run
+type Result struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           *int              `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             Message           `json:"message"`
+	Locations           []*Location       `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+// Location represents the location of a result
+type Location struct {
+	PhysicalLocation *PhysicalLocation `json:"physicalLocation"`
+}
+
+// PhysicalLocation represents a region in a file
+type PhysicalLocation struct {
+	ArtifactLocation *struct {
+		URI string `json:"uri"`
+	} `json:"artifactLocation"`
+	Region *struct {
+		StartLine int `json:"startLine"`
+		EndLine   int `json:"endLine"`
+	} `json:"region"`
+}
+
+// Finding is a normalized result of a run
+type Finding struct {
+	ToolName        string
+	ToolVersion     string
+	Category        string
+	RuleID          string
+	RuleDescription string
+	Severity        string
+	Message         string
+	Path            string
+	StartLine       int
+	EndLine         int
+	Fingerprint     string
+}
+
+// Parse parses a SARIF report
+func Parse(r io.Reader) (*Report, error) {
+	var report Report
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, ErrInvalidReport
+	}
+	if report.Version != "2.1.0" {
+		return nil, ErrInvalidReport
+	}
+	return &report, nil
+}
+
+// Decode parses a base64 encoded and optionally gzip compressed SARIF report
+func Decode(encoded string) (*Report, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidReport
+	}
+
+	var r io.Reader = bytes.NewReader(data)
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, ErrInvalidReport
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return Parse(r)
+}
+
+// Findings returns the normalized results of all runs of the report
+func (r *Report) Findings() []*Finding {
+	findings := make([]*Finding, 0, 10)
+	for _, run := range r.Runs {
+		rules := make(map[string]*Rule, len(run.Tool.Driver.Rules))
+		for _, rule := range run.Tool.Driver.Rules {
+			rules[rule.ID] = rule
+		}
+
+		version := run.Tool.Driver.SemanticVersion
+		if version == "" {
+			version = run.Tool.Driver.Version
+		}
+		var category string
+		if run.AutomationDetails != nil {
+			category = run.AutomationDetails.ID
+		}
+
+		for _, result := range run.Results {
+			rule := rules[result.RuleID]
+			if rule == nil && result.RuleIndex != nil && *result.RuleIndex >= 0 && *result.RuleIndex < len(run.Tool.Driver.Rules) {
+				rule = run.Tool.Driver.Rules[*result.RuleIndex]
+			}
+
+			f := &Finding{
+				ToolName:    run.Tool.Driver.Name,
+				ToolVersion: version,
+				Category:    category,
+				RuleID:      result.RuleID,
+				Severity:    result.Level,
+				Message:     result.Message.Text,
+			}
+			if rule != nil {
+				if f.RuleID == "" {
+					f.RuleID = rule.ID
+				}
+				if rule.ShortDescription != nil {
+					f.RuleDescription = rule.ShortDescription.Text
+				} else if rule.FullDescription != nil {
+					f.RuleDescription = rule.FullDescription.Text
+				}
+				if f.Severity == "" && rule.DefaultConfiguration != nil {
+					f.Severity = rule.DefaultConfiguration.Level
+				}
+			}
+			if f.Severity == "" || f.Severity == "none" {
+				f.Severity = "warning"
+			}
+
+			for _, loc := range result.Locations {
+				if loc.PhysicalLocation == nil || loc.PhysicalLocation.ArtifactLocation == nil {
+					continue
+				}
+				f.Path = cleanURI(loc.PhysicalLocation.ArtifactLocation.URI)
+				if region := loc.PhysicalLocation.Region; region != nil {
+					f.StartLine = region.StartLine
+					f.EndLine = region.EndLine
+				}
+				if f.EndLine < f.StartLine {
+					f.EndLine = f.StartLine
+				}
+				break
+			}
+
+			f.Fingerprint = fingerprint(f, result.PartialFingerprints)
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// cleanURI turns an artifact URI into a path relative to the repository root
+func cleanURI(uri string) string {
+	uri = strings.TrimPrefix(uri, "file://")
+	uri = strings.TrimPrefix(uri, "./")
+	return strings.TrimPrefix(uri, "/")
+}
+
+// fingerprint identifies a finding across commits. Line numbers are left out unless the tool
+// provides a partial fingerprint, so that a finding keeps its identity when code around it moves.
+func fingerprint(f *Finding, partial map[string]string) string {
+	parts := []string{f.ToolName, f.Category, f.RuleID, f.Path}
+	if hash, ok := partial["primaryLocationLineHash"]; ok {
+		parts = append(parts, hash)
+	} else {
+		parts = append(parts, f.Message)
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
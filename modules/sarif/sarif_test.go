@@ -0,0 +1,85 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package sarif
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testReport = `{
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "gosec", "version": "2.13.1", "rules": [
+      {"id": "G101", "shortDescription": {"text": "Potential hardcoded credentials"}, "defaultConfiguration": {"level": "error"}},
+      {"id": "G104", "shortDescription": {"text": "Errors unhandled"}}
+    ]}},
+    "automationDetails": {"id": "security/"},
+    "results": [
+      {"ruleId": "G101", "message": {"text": "Hardcoded password"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "file:///main.go"}, "region": {"startLine": 12}}}]},
+      {"ruleIndex": 1, "level": "note", "message": {"text": "Unhandled error"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "./cmd/serve.go"}, "region": {"startLine": 3, "endLine": 5}}}],
+       "partialFingerprints": {"primaryLocationLineHash": "abc:1"}}
+    ]
+  }]
+}`
+
+func TestParse(t *testing.T) {
+	_, err := Parse(strings.NewReader(`{"version": "1.0.0"}`))
+	assert.ErrorIs(t, err, ErrInvalidReport)
+	_, err = Parse(strings.NewReader(`not json`))
+	assert.ErrorIs(t, err, ErrInvalidReport)
+
+	report, err := Parse(strings.NewReader(testReport))
+	assert.NoError(t, err)
+
+	findings := report.Findings()
+	if assert.Len(t, findings, 2) {
+		assert.Equal(t, "gosec", findings[0].ToolName)
+		assert.Equal(t, "2.13.1", findings[0].ToolVersion)
+		assert.Equal(t, "security/", findings[0].Category)
+		assert.Equal(t, "G101", findings[0].RuleID)
+		assert.Equal(t, "Potential hardcoded credentials", findings[0].RuleDescription)
+		assert.Equal(t, "error", findings[0].Severity)
+		assert.Equal(t, "main.go", findings[0].Path)
+		assert.Equal(t, 12, findings[0].StartLine)
+		assert.Equal(t, 12, findings[0].EndLine)
+		assert.Len(t, findings[0].Fingerprint, 64)
+
+		assert.Equal(t, "G104", findings[1].RuleID)
+		assert.Equal(t, "note", findings[1].Severity)
+		assert.Equal(t, "cmd/serve.go", findings[1].Path)
+		assert.Equal(t, 5, findings[1].EndLine)
+	}
+
+	// moving a finding without a partial fingerprint keeps its identity
+	moved, err := Parse(strings.NewReader(strings.Replace(testReport, `"startLine": 12`, `"startLine": 40`, 1)))
+	assert.NoError(t, err)
+	assert.Equal(t, findings[0].Fingerprint, moved.Findings()[0].Fingerprint)
+}
+
+func TestDecode(t *testing.T) {
+	_, err := Decode("not base64!")
+	assert.ErrorIs(t, err, ErrInvalidReport)
+
+	report, err := Decode(base64.StdEncoding.EncodeToString([]byte(testReport)))
+	assert.NoError(t, err)
+	assert.Len(t, report.Runs, 1)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err = gz.Write([]byte(testReport))
+	assert.NoError(t, err)
+	assert.NoError(t, gz.Close())
+	report, err = Decode(base64.StdEncoding.EncodeToString(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Len(t, report.Findings(), 2)
+}
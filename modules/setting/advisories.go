@@ -0,0 +1,32 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package setting
+
+import (
+	"strings"
+
+	"code.gitea.io/gitea/modules/log"
+)
+
+// Advisory database settings
+var (
+	Advisories = struct {
+		OSVEnabled bool   `ini:"OSV_ENABLED"`
+		IDPrefix   string `ini:"ID_PREFIX"`
+	}{
+		OSVEnabled: true,
+		IDPrefix:   "GITEA",
+	}
+)
+
+func newAdvisories() {
+	if err := Cfg.Section("advisories").MapTo(&Advisories); err != nil {
+		log.Fatal("Failed to map Advisories settings: %v", err)
+	}
+	Advisories.IDPrefix = strings.Trim(strings.ToUpper(Advisories.IDPrefix), "-")
+	if Advisories.IDPrefix == "" {
+		Advisories.IDPrefix = "GITEA"
+	}
+}
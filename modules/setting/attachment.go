@@ -4,13 +4,17 @@
 
 package setting
 
+import "time"
+
 // Attachment settings
 var Attachment = struct {
 	Storage
-	AllowedTypes string
-	MaxSize      int64
-	MaxFiles     int
-	Enabled      bool
+	AllowedTypes         string
+	MaxSize              int64
+	MaxFiles             int
+	Enabled              bool
+	RepoMaxSize          int64
+	MaxAgeOnClosedIssues time.Duration
 }{
 	Storage: Storage{
 		ServeDirect: false,
@@ -31,4 +35,6 @@ func newAttachmentService() {
 	Attachment.MaxSize = sec.Key("MAX_SIZE").MustInt64(4)
 	Attachment.MaxFiles = sec.Key("MAX_FILES").MustInt(5)
 	Attachment.Enabled = sec.Key("ENABLED").MustBool(true)
+	Attachment.RepoMaxSize = sec.Key("REPO_MAX_SIZE").MustInt64(0)
+	Attachment.MaxAgeOnClosedIssues = sec.Key("MAX_AGE_ON_CLOSED_ISSUES").MustDuration(0)
 }
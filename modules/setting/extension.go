@@ -0,0 +1,75 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package setting
+
+import (
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/log"
+)
+
+const (
+	// ExtensionFailureAllow lets the action happen when an extension processor fails to answer
+	ExtensionFailureAllow = "allow"
+	// ExtensionFailureDeny rejects the action when an extension processor fails to answer
+	ExtensionFailureDeny = "deny"
+)
+
+// ExtensionProcessor is an external HTTP processor receiving the server-side events it subscribed to
+type ExtensionProcessor struct {
+	Name          string
+	URL           string
+	Token         string
+	Events        []string
+	Timeout       time.Duration
+	FailurePolicy string
+}
+
+// HasEvent returns whether the processor subscribed to an event
+func (p *ExtensionProcessor) HasEvent(event string) bool {
+	for _, e := range p.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtensionProcessors are the external processors configured in the [extension.*] sections
+var ExtensionProcessors []*ExtensionProcessor
+
+func newExtensions() {
+	ExtensionProcessors = make([]*ExtensionProcessor, 0, 2)
+	for _, sec := range Cfg.Section("extension").ChildSections() {
+		name := strings.TrimPrefix(sec.Name(), "extension.")
+		if name == "" || !sec.Key("ENABLED").MustBool(true) {
+			continue
+		}
+		p := &ExtensionProcessor{
+			Name:          name,
+			URL:           strings.TrimSpace(sec.Key("URL").String()),
+			Token:         sec.Key("TOKEN").String(),
+			Events:        make([]string, 0, 4),
+			Timeout:       sec.Key("TIMEOUT").MustDuration(5 * time.Second),
+			FailurePolicy: strings.ToLower(sec.Key("FAILURE_POLICY").MustString(ExtensionFailureAllow)),
+		}
+		if p.URL == "" {
+			log.Fatal("extension.%s.URL is required", name)
+		}
+		for _, event := range sec.Key("EVENTS").Strings(",") {
+			if event = strings.ToLower(strings.TrimSpace(event)); event != "" {
+				p.Events = append(p.Events, event)
+			}
+		}
+		if p.FailurePolicy != ExtensionFailureAllow && p.FailurePolicy != ExtensionFailureDeny {
+			log.Fatal("extension.%s.FAILURE_POLICY must be %q or %q, not %q", name, ExtensionFailureAllow, ExtensionFailureDeny, p.FailurePolicy)
+		}
+		if p.Timeout <= 0 {
+			p.Timeout = 5 * time.Second
+		}
+		ExtensionProcessors = append(ExtensionProcessors, p)
+	}
+}
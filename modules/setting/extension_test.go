@@ -0,0 +1,47 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package setting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ini "gopkg.in/ini.v1"
+)
+
+func Test_newExtensions(t *testing.T) {
+	iniStr := `
+[extension.policy]
+URL = http://localhost:8080/events
+TOKEN = secret
+EVENTS = pre_receive, Issue_Opened
+FAILURE_POLICY = deny
+
+[extension.audit]
+URL = http://localhost:8081/
+EVENTS = user_register
+
+[extension.disabled]
+ENABLED = false
+`
+	Cfg, _ = ini.Load([]byte(iniStr))
+	newExtensions()
+
+	assert.Len(t, ExtensionProcessors, 2)
+	p := ExtensionProcessors[0]
+	assert.Equal(t, "policy", p.Name)
+	assert.Equal(t, "secret", p.Token)
+	assert.Equal(t, []string{"pre_receive", "issue_opened"}, p.Events)
+	assert.Equal(t, ExtensionFailureDeny, p.FailurePolicy)
+	assert.Equal(t, 5*time.Second, p.Timeout)
+	assert.True(t, p.HasEvent("issue_opened"))
+	assert.False(t, p.HasEvent("user_register"))
+
+	p = ExtensionProcessors[1]
+	assert.Equal(t, "audit", p.Name)
+	assert.Equal(t, ExtensionFailureAllow, p.FailurePolicy)
+	assert.True(t, p.HasEvent("user_register"))
+}
@@ -29,6 +29,9 @@ var Git = struct {
 	LargeObjectThreshold      int64
 	DisableCoreProtectNTFS    bool
 	DisablePartialClone       bool
+	PartialCloneFilters       []string
+	PartialCloneTreeMaxDepth  int
+	EnableObjectInfo          bool
 	Timeout                   struct {
 		Default int
 		Migrate int
@@ -36,6 +39,7 @@ var Git = struct {
 		Clone   int
 		Pull    int
 		GC      int `ini:"GC"`
+		Grep    int
 	} `ini:"git.timeout"`
 }{
 	DisableDiffHighlight:      false,
@@ -51,6 +55,8 @@ var Git = struct {
 	PullRequestPushMessage:    true,
 	LargeObjectThreshold:      1024 * 1024,
 	DisablePartialClone:       false,
+	PartialCloneTreeMaxDepth:  0,
+	EnableObjectInfo:          true,
 	Timeout: struct {
 		Default int
 		Migrate int
@@ -58,6 +64,7 @@ var Git = struct {
 		Clone   int
 		Pull    int
 		GC      int `ini:"GC"`
+		Grep    int
 	}{
 		Default: 360,
 		Migrate: 600,
@@ -65,6 +72,7 @@ var Git = struct {
 		Clone:   300,
 		Pull:    300,
 		GC:      60,
+		Grep:    10,
 	},
 }
 
@@ -31,6 +31,10 @@ var Indexer = struct {
 	IncludePatterns    []glob.Glob
 	ExcludePatterns    []glob.Glob
 	ExcludeVendored    bool
+
+	ExpertiseIndexerEnabled bool
+	ExpertiseMaxCommits     int
+	ExpertiseMaxBlamedFiles int
 }{
 	IssueType:        "bleve",
 	IssuePath:        "indexers/issues.bleve",
@@ -44,6 +48,10 @@ var Indexer = struct {
 	RepoIndexerName:    "gitea_codes",
 	MaxIndexerFileSize: 1024 * 1024,
 	ExcludeVendored:    true,
+
+	ExpertiseIndexerEnabled: false,
+	ExpertiseMaxCommits:     1000,
+	ExpertiseMaxBlamedFiles: 1000,
 }
 
 func newIndexerService() {
@@ -78,6 +86,10 @@ func newIndexerService() {
 	Indexer.ExcludeVendored = sec.Key("REPO_INDEXER_EXCLUDE_VENDORED").MustBool(true)
 	Indexer.MaxIndexerFileSize = sec.Key("MAX_FILE_SIZE").MustInt64(1024 * 1024)
 	Indexer.StartupTimeout = sec.Key("STARTUP_TIMEOUT").MustDuration(30 * time.Second)
+
+	Indexer.ExpertiseIndexerEnabled = sec.Key("EXPERTISE_INDEXER_ENABLED").MustBool(false)
+	Indexer.ExpertiseMaxCommits = sec.Key("EXPERTISE_INDEXER_MAX_COMMITS").MustInt(1000)
+	Indexer.ExpertiseMaxBlamedFiles = sec.Key("EXPERTISE_INDEXER_MAX_BLAMED_FILES").MustInt(1000)
 }
 
 // IndexerGlobFromString parses a comma separated list of patterns and returns a glob.Glob slice suited for repo indexing
@@ -0,0 +1,44 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package setting
+
+import (
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/log"
+)
+
+// Replication settings
+var Replication = struct {
+	Enabled       bool
+	Standby       bool
+	StandbyURL    string `ini:"STANDBY_URL"`
+	StandbyToken  string
+	SkipTLSVerify bool `ini:"SKIP_TLS_VERIFY"`
+	MaxLag        time.Duration
+}{
+	Enabled: false,
+	Standby: false,
+	MaxLag:  time.Hour,
+}
+
+func newReplication() {
+	if err := Cfg.Section("replication").MapTo(&Replication); err != nil {
+		log.Fatal("Failed to map Replication settings: %v", err)
+	}
+	if !Replication.Enabled {
+		return
+	}
+	if Replication.Standby {
+		log.Fatal("replication.ENABLED and replication.STANDBY can't be both true, a standby instance can't replicate its repositories")
+	}
+	if Replication.StandbyURL == "" || Replication.StandbyToken == "" {
+		log.Fatal("replication.STANDBY_URL and replication.STANDBY_TOKEN are required when replication.ENABLED is true")
+	}
+	if !strings.HasSuffix(Replication.StandbyURL, "/") {
+		Replication.StandbyURL += "/"
+	}
+}
@@ -49,6 +49,9 @@ var (
 		AllowAdoptionOfUnadoptedRepositories    bool
 		AllowDeleteOfUnadoptedRepositories      bool
 		DisableDownloadSourceArchives           bool
+		DefaultsRepo                            string
+		CustomInitFileDirs                      []string
+		CustomInitFileRepos                     []string
 
 		// Repository editor settings
 		Editor struct {
@@ -87,7 +90,8 @@ var (
 
 		// Issue Setting
 		Issue struct {
-			LockReasons []string
+			LockReasons                []string
+			ContentHistoryMaxRevisions int
 		} `ini:"repository.issue"`
 
 		Release struct {
@@ -95,6 +99,11 @@ var (
 			DefaultPagingNum int
 		} `ini:"repository.release"`
 
+		Download struct {
+			EnableAccounting   bool
+			AnonymousRateLimit int64
+		} `ini:"repository.download"`
+
 		Signing struct {
 			SigningKey        string
 			SigningName       string
@@ -223,9 +232,11 @@ var (
 
 		// Issue settings
 		Issue: struct {
-			LockReasons []string
+			LockReasons                []string
+			ContentHistoryMaxRevisions int
 		}{
-			LockReasons: strings.Split("Too heated,Off-topic,Spam,Resolved", ","),
+			LockReasons:                strings.Split("Too heated,Off-topic,Spam,Resolved", ","),
+			ContentHistoryMaxRevisions: 20,
 		},
 
 		Release: struct {
@@ -236,6 +247,14 @@ var (
 			DefaultPagingNum: 10,
 		},
 
+		Download: struct {
+			EnableAccounting   bool
+			AnonymousRateLimit int64
+		}{
+			EnableAccounting:   true,
+			AnonymousRateLimit: 0,
+		},
+
 		// Signing settings
 		Signing: struct {
 			SigningKey        string
@@ -302,6 +321,12 @@ func newRepository() {
 		log.Fatal("Failed to map Repository.PullRequest settings: %v", err)
 	}
 
+	for i, dir := range Repository.CustomInitFileDirs {
+		if !filepath.IsAbs(dir) {
+			Repository.CustomInitFileDirs[i] = filepath.Join(CustomPath, dir)
+		}
+	}
+
 	if !Cfg.Section("packages").Key("ENABLED").MustBool(true) {
 		Repository.DisabledRepoUnits = append(Repository.DisabledRepoUnits, "repo.packages")
 	}
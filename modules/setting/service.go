@@ -65,6 +65,7 @@ var Service = struct {
 	AutoWatchOnChanges                      bool
 	DefaultOrgMemberVisible                 bool
 	UserDeleteWithCommentsMaxTime           time.Duration
+	UserDeleteGracePeriod                   time.Duration
 	ValidSiteURLSchemes                     []string
 
 	// OpenID settings
@@ -75,8 +76,9 @@ var Service = struct {
 
 	// Explore page settings
 	Explore struct {
-		RequireSigninView bool `ini:"REQUIRE_SIGNIN_VIEW"`
-		DisableUsersPage  bool `ini:"DISABLE_USERS_PAGE"`
+		RequireSigninView  bool `ini:"REQUIRE_SIGNIN_VIEW"`
+		DisableUsersPage   bool `ini:"DISABLE_USERS_PAGE"`
+		EnableActivityFeed bool `ini:"ENABLE_ACTIVITY_FEED"`
 	} `ini:"service.explore"`
 }{
 	AllowedUserVisibilityModesSlice: []bool{true, true, true},
@@ -168,6 +170,7 @@ func newService() {
 	Service.DefaultOrgVisibilityMode = structs.VisibilityModes[Service.DefaultOrgVisibility]
 	Service.DefaultOrgMemberVisible = sec.Key("DEFAULT_ORG_MEMBER_VISIBLE").MustBool()
 	Service.UserDeleteWithCommentsMaxTime = sec.Key("USER_DELETE_WITH_COMMENTS_MAX_TIME").MustDuration(0)
+	Service.UserDeleteGracePeriod = sec.Key("USER_DELETE_GRACE_PERIOD").MustDuration(7 * 24 * time.Hour)
 	sec.Key("VALID_SITE_URL_SCHEMES").MustString("http,https")
 	Service.ValidSiteURLSchemes = sec.Key("VALID_SITE_URL_SCHEMES").Strings(",")
 	schemes := make([]string, len(Service.ValidSiteURLSchemes))
@@ -224,6 +224,7 @@ var (
 		MembersPagingNum      int
 		FeedMaxCommitNum      int
 		FeedPagingNum         int
+		FeedMaxPagingNum      int
 		PackagesPagingNum     int
 		GraphMaxCommitNum     int
 		CodeCommentLines      int
@@ -279,6 +280,7 @@ var (
 		MembersPagingNum:    20,
 		FeedMaxCommitNum:    5,
 		FeedPagingNum:       20,
+		FeedMaxPagingNum:    100,
 		PackagesPagingNum:   20,
 		GraphMaxCommitNum:   100,
 		CodeCommentLines:    4,
@@ -404,6 +406,8 @@ var (
 		JWTSecretBase64            string `ini:"JWT_SECRET"`
 		JWTSigningPrivateKeyFile   string `ini:"JWT_SIGNING_PRIVATE_KEY_FILE"`
 		MaxTokenLength             int
+		DeviceCodeExpirationTime   int64
+		DeviceCodePollingInterval  int64
 	}{
 		Enable:                     true,
 		AccessTokenExpirationTime:  3600,
@@ -412,6 +416,8 @@ var (
 		JWTSigningAlgorithm:        "RS256",
 		JWTSigningPrivateKeyFile:   "jwt/private.pem",
 		MaxTokenLength:             math.MaxInt16,
+		DeviceCodeExpirationTime:   600,
+		DeviceCodePollingInterval:  5,
 	}
 
 	// Metrics settings
@@ -1043,6 +1049,16 @@ func loadFromConf(allowEmpty bool, extraConfig string) {
 
 	newPackages()
 
+	newAdvisories()
+
+	newWebSub()
+
+	newReplication()
+
+	newSpam()
+
+	newExtensions()
+
 	if err = Cfg.Section("ui").MapTo(&UI); err != nil {
 		log.Fatal("Failed to map UI settings: %v", err)
 	} else if err = Cfg.Section("markdown").MapTo(&Markdown); err != nil {
@@ -0,0 +1,49 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package setting
+
+import (
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/log"
+)
+
+// Spam detection settings
+var (
+	Spam = struct {
+		Enabled           bool
+		NewAccountAge     time.Duration
+		HoldScore         int
+		MaxLinks          int
+		BlockedWords      []string
+		ClassifierURL     string `ini:"CLASSIFIER_URL"`
+		ClassifierToken   string
+		ClassifierTimeout time.Duration
+	}{
+		Enabled:           false,
+		NewAccountAge:     72 * time.Hour,
+		HoldScore:         100,
+		MaxLinks:          3,
+		ClassifierTimeout: 5 * time.Second,
+	}
+)
+
+func newSpam() {
+	if err := Cfg.Section("spam").MapTo(&Spam); err != nil {
+		log.Fatal("Failed to map Spam settings: %v", err)
+	}
+	words := make([]string, 0, len(Spam.BlockedWords))
+	for _, word := range Spam.BlockedWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			words = append(words, word)
+		}
+	}
+	Spam.BlockedWords = words
+	Spam.ClassifierURL = strings.TrimSpace(Spam.ClassifierURL)
+	if Spam.HoldScore <= 0 {
+		Spam.HoldScore = 100
+	}
+}
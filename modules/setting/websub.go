@@ -0,0 +1,49 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package setting
+
+import (
+	"strings"
+
+	"code.gitea.io/gitea/modules/log"
+)
+
+// WebSub settings
+var (
+	WebSub = struct {
+		Enabled                 bool
+		HubURL                  string `ini:"HUB_URL"`
+		LeaseSeconds            int64
+		MaxLeaseSeconds         int64
+		MaxPendingVerifications int
+	}{
+		Enabled:                 false,
+		LeaseSeconds:            10 * 24 * 3600,
+		MaxLeaseSeconds:         30 * 24 * 3600,
+		MaxPendingVerifications: 100,
+	}
+)
+
+func newWebSub() {
+	if err := Cfg.Section("websub").MapTo(&WebSub); err != nil {
+		log.Fatal("Failed to map WebSub settings: %v", err)
+	}
+	WebSub.HubURL = strings.TrimSpace(WebSub.HubURL)
+	if WebSub.MaxLeaseSeconds < WebSub.LeaseSeconds {
+		WebSub.MaxLeaseSeconds = WebSub.LeaseSeconds
+	}
+}
+
+// WebSubHubURL returns the URL of the WebSub hub advertised by the activity feeds: the external hub when one
+// is configured, else the built-in hub when it is enabled, else an empty string
+func WebSubHubURL() string {
+	if WebSub.HubURL != "" {
+		return WebSub.HubURL
+	}
+	if WebSub.Enabled {
+		return AppURL + "-/websub"
+	}
+	return ""
+}
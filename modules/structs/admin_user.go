@@ -47,3 +47,10 @@ type EditUserOption struct {
 	Restricted              *bool   `json:"restricted"`
 	Visibility              string  `json:"visibility" binding:"In(,public,limited,private)"`
 }
+
+// MergeUserOption options to merge a duplicate user into another account
+type MergeUserOption struct {
+	// username of the account receiving the content of the merged user
+	// required: true
+	Target string `json:"target" binding:"Required"`
+}
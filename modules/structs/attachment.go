@@ -26,3 +26,13 @@ type Attachment struct {
 type EditAttachmentOptions struct {
 	Name string `json:"name"`
 }
+
+// RepoAttachmentsUsage represents the storage used by the attachments of a repository
+type RepoAttachmentsUsage struct {
+	RepoID int64 `json:"repo_id"`
+	// empty if the repository doesn't exist anymore
+	RepoFullName   string `json:"repo_full_name"`
+	NumAttachments int64  `json:"attachments_count"`
+	// the total size of the attachments in bytes
+	Size int64 `json:"size"`
+}
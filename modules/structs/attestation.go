@@ -0,0 +1,47 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"time"
+)
+
+// Attestation represents an in-toto attestation, e.g. the SLSA provenance of a build, of a commit, a tag or a package
+type Attestation struct {
+	ID int64 `json:"id"`
+	// enum: commit,tag,package
+	SubjectType string `json:"subject_type"`
+	// the commit SHA, the tag name or the package version the attestation is about
+	Subject       string `json:"subject"`
+	PredicateType string `json:"predicate_type"`
+	// digests of the subjects of the statement written as "algorithm:value"
+	Digests []string `json:"digests"`
+	// the in-toto statement or the DSSE envelope signing it
+	Content  string `json:"content"`
+	Uploader *User  `json:"uploader"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// CreateAttestationOption options for uploading an attestation of a commit or a tag of a repository
+type CreateAttestationOption struct {
+	// required: true
+	// enum: commit,tag
+	SubjectType string `json:"subject_type" binding:"Required;In(commit,tag)"`
+	// the commit SHA or the tag name, the tag may not exist yet
+	// required: true
+	Subject string `json:"subject" binding:"Required"`
+	// the in-toto statement or the DSSE envelope signing it
+	// required: true
+	Content string `json:"content" binding:"Required"`
+}
+
+// CreatePackageAttestationOption options for uploading an attestation of a package version
+type CreatePackageAttestationOption struct {
+	// the in-toto statement or the DSSE envelope signing it, the container images to tag need a subject with the
+	// digest of their manifest
+	// required: true
+	Content string `json:"content" binding:"Required"`
+}
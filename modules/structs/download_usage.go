@@ -0,0 +1,23 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+// RepoDownloadUsage represents the downloads of the attachments and of the raw files of a repository
+type RepoDownloadUsage struct {
+	RepoID       int64  `json:"repo_id"`
+	RepoFullName string `json:"repo_full_name"`
+	Downloads    int64  `json:"downloads_count"`
+	// the total number of bytes served by the downloads
+	Size int64 `json:"size"`
+}
+
+// OwnerDownloadUsage represents the downloads of the attachments and of the raw files of the repositories of an owner
+type OwnerDownloadUsage struct {
+	OwnerID   int64  `json:"owner_id"`
+	OwnerName string `json:"owner_name"`
+	Downloads int64  `json:"downloads_count"`
+	// the total number of bytes served by the downloads
+	Size int64 `json:"size"`
+}
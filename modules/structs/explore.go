@@ -0,0 +1,25 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import "time"
+
+// ExploreCollection represents a collection of repositories curated by the admins on the explore pages
+type ExploreCollection struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// the public repositories of the topic follow the ones added to the collection
+	Topic string `json:"topic"`
+	// pinned collections are shown on top of the repositories of the explore page
+	Pinned bool `json:"pinned"`
+	// the collections are ordered by position, lowest first
+	Position int    `json:"position"`
+	HTMLURL  string `json:"html_url"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
@@ -111,6 +111,8 @@ var (
 	_ Payloader = &RepositoryPayload{}
 	_ Payloader = &ReleasePayload{}
 	_ Payloader = &PackagePayload{}
+	_ Payloader = &DeploymentPayload{}
+	_ Payloader = &DeploymentStatusPayload{}
 )
 
 // _________                        __
@@ -483,3 +485,38 @@ type PackagePayload struct {
 func (p *PackagePayload) JSONPayload() ([]byte, error) {
 	return json.MarshalIndent(p, "", "  ")
 }
+
+// HookDeploymentAction an action that happens to a deployment
+type HookDeploymentAction string
+
+const (
+	// HookDeploymentCreated created
+	HookDeploymentCreated HookDeploymentAction = "created"
+)
+
+// DeploymentPayload represents a payload sent when a deployment is created
+type DeploymentPayload struct {
+	Action     HookDeploymentAction `json:"action"`
+	Deployment *Deployment          `json:"deployment"`
+	Repository *Repository          `json:"repository"`
+	Sender     *User                `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *DeploymentPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// DeploymentStatusPayload represents a payload sent when a status is added to a deployment
+type DeploymentStatusPayload struct {
+	Action           HookDeploymentAction `json:"action"`
+	Deployment       *Deployment          `json:"deployment"`
+	DeploymentStatus *DeploymentStatus    `json:"deployment_status"`
+	Repository       *Repository          `json:"repository"`
+	Sender           *User                `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *DeploymentStatusPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
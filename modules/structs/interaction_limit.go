@@ -0,0 +1,29 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import "time"
+
+// InteractionLimit represents a temporary restriction of who can open issues and pull requests, comment and react
+type InteractionLimit struct {
+	// enum: existing_users,contributors_only,collaborators_only
+	Limit string `json:"limit"`
+	// where the limit has been set
+	// enum: user,organization,repository
+	Origin string `json:"origin"`
+	// swagger:strfmt date-time
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SetInteractionLimitOption options to set an interaction limit
+// swagger:model
+type SetInteractionLimitOption struct {
+	// required: true
+	// enum: existing_users,contributors_only,collaborators_only
+	Limit string `json:"limit" binding:"Required"`
+	// how long the limit applies, one day if not set
+	// enum: one_day,three_days,one_week,one_month,six_months
+	Expiry string `json:"expiry"`
+}
@@ -59,7 +59,15 @@ type Issue struct {
 	// enum: open,closed
 	State    StateType `json:"state"`
 	IsLocked bool      `json:"is_locked"`
-	Comments int       `json:"comments"`
+	// whether the issue is only visible to the collaborators who can write to it, its poster and its assignees
+	IsConfidential bool `json:"is_confidential"`
+	Comments       int  `json:"comments"`
+	// number of reactions to the issue itself, not to its comments
+	Reactions int `json:"reactions"`
+	// number of thumbs up reactions to the issue itself
+	ThumbsUp int `json:"thumbs_up"`
+	// number of users who voted for the issue
+	Votes int `json:"votes"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 	// swagger:strfmt date-time
@@ -89,6 +97,8 @@ type CreateIssueOption struct {
 	// list of label ids
 	Labels []int64 `json:"labels"`
 	Closed bool    `json:"closed"`
+	// only visible to the collaborators who can write to the issues, the poster and the assignees
+	Confidential bool `json:"confidential"`
 }
 
 // EditIssueOption options for editing an issue
@@ -104,6 +114,63 @@ type EditIssueOption struct {
 	// swagger:strfmt date-time
 	Deadline       *time.Time `json:"due_date"`
 	RemoveDeadline *bool      `json:"unset_due_date"`
+	// only visible to the collaborators who can write to the issues, the poster and the assignees
+	Confidential *bool `json:"confidential"`
+}
+
+// EditIssuesOption options for editing several issues of a repository at once
+type EditIssuesOption struct {
+	// indices of the issues and pull requests to edit
+	// required:true
+	Indices []int64 `json:"indices" binding:"Required"`
+	// ids of the labels to add
+	AddLabels []int64 `json:"add_labels"`
+	// ids of the labels to remove
+	RemoveLabels []int64 `json:"remove_labels"`
+	// id of the milestone to set, 0 to remove the milestone
+	Milestone *int64 `json:"milestone"`
+	// usernames of the assignees to add
+	AddAssignees []string `json:"add_assignees"`
+	// usernames of the assignees to remove
+	RemoveAssignees []string `json:"remove_assignees"`
+	// enum: open,closed
+	State *string `json:"state"`
+}
+
+// ImportIssuesOption options for creating issues from the rows of a CSV file
+type ImportIssuesOption struct {
+	// CSV file whose first row holds the headers of the columns, its delimiter is guessed
+	// required:true
+	Content string `json:"content" binding:"Required"`
+	// maps the headers of the columns to the fields of the issues: title, content, state, labels, assignees,
+	// milestone or deadline. The columns named like a field are mapped by default, an empty field ignores a column
+	Mapping map[string]string `json:"mapping"`
+}
+
+// IssueMirror the read-only mirror of the issue tracker of an external repository into a repository
+type IssueMirror struct {
+	// service of the external repository
+	// enum: github,gitlab,gitea,gogs,gitbucket
+	Service       string `json:"service"`
+	RemoteAddress string `json:"remote_address"`
+	// swagger:strfmt date-time
+	LastSync *time.Time `json:"last_sync"`
+	// reason of the failure of the last synchronization, empty if it succeeded
+	LastError string `json:"last_error"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// SetIssueMirrorOption options for mirroring the issue tracker of an external repository, the issues of the repository
+// can't be opened or changed locally while it's mirrored
+type SetIssueMirrorOption struct {
+	// required: true
+	// enum: github,gitlab,gitea,gogs,gitbucket
+	Service string `json:"service" binding:"Required"`
+	// required: true
+	RemoteAddress string `json:"remote_address" binding:"Required;MaxSize(2048)"`
+	// token used to read the external repository
+	AuthToken string `json:"auth_token"`
 }
 
 // EditDeadlineOption options for creating a deadline
@@ -120,6 +187,35 @@ type IssueDeadline struct {
 	Deadline *time.Time `json:"due_date"`
 }
 
+// PinnedIssue represents an issue or a pull request pinned to a repository or an organization
+type PinnedIssue struct {
+	Issue *Issue `json:"issue"`
+	// position among the pinned issues, starting at 1
+	Position int `json:"position"`
+	// whether the issue is the announcement shown on the home page of the repository
+	IsAnnouncement bool `json:"is_announcement"`
+}
+
+// PinIssueOption options for pinning an issue or a pull request to its repository
+type PinIssueOption struct {
+	// whether the issue is the announcement shown on the home page of the repository, replacing the previous one
+	IsAnnouncement bool `json:"is_announcement"`
+}
+
+// PinnedIssueRef references an issue or a pull request of a repository of an organization
+type PinnedIssueRef struct {
+	// required:true
+	Repo string `json:"repo" binding:"Required"`
+	// required:true
+	Index int64 `json:"index" binding:"Required"`
+}
+
+// EditOrgPinnedIssuesOption options for replacing the issues and pull requests pinned to an organization
+type EditOrgPinnedIssuesOption struct {
+	// the issues in their order
+	Issues []*PinnedIssueRef `json:"issues"`
+}
+
 // IssueFormFieldType defines issue form field type, can be "markdown", "textarea", "input", "dropdown" or "checkboxes"
 type IssueFormFieldType string
 
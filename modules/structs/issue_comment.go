@@ -18,6 +18,8 @@ type Comment struct {
 	OriginalAuthor   string `json:"original_author"`
 	OriginalAuthorID int64  `json:"original_author_id"`
 	Body             string `json:"body"`
+	// true when a moderator hid the comment, its body is only returned to the moderators and its poster
+	IsHidden bool `json:"is_hidden"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 	// swagger:strfmt date-time
@@ -80,3 +82,17 @@ type TimelineComment struct {
 
 	DependentIssue *Issue `json:"dependent_issue"`
 }
+
+// ContentHistory represents a revision of the edit history of an issue, a pull request or a comment
+type ContentHistory struct {
+	ID     int64 `json:"id"`
+	Editor *User `json:"editor"`
+	// swagger:strfmt date-time
+	Edited         time.Time `json:"edited_at"`
+	IsFirstCreated bool      `json:"is_first_created"`
+	// true when the revision was deleted from the history, its content and diff are then empty
+	IsDeleted bool   `json:"is_deleted"`
+	Content   string `json:"content"`
+	// line diff of the content from the previous revision which isn't deleted, lines are prefixed by "+", "-" or " "
+	Diff string `json:"diff"`
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+// IssueField a custom field of the issues of a repository or of all the repositories of an organization
+type IssueField struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// enum: text,number,enum,date
+	Type string `json:"type"`
+	// values accepted by an enum field
+	Options []string `json:"options"`
+	// true for the fields of an organization, which apply to the issues of all its repositories
+	IsOrgField bool `json:"is_org_field"`
+}
+
+// CreateIssueFieldOption options for creating a custom field of issues
+type CreateIssueFieldOption struct {
+	// required:true
+	Name        string `json:"name" binding:"Required"`
+	Description string `json:"description"`
+	// required:true
+	// enum: text,number,enum,date
+	Type string `json:"type" binding:"Required;In(text,number,enum,date)"`
+	// values accepted by an enum field, required for an enum field
+	Options []string `json:"options"`
+}
+
+// EditIssueFieldOption options for editing a custom field of issues, its type cannot be changed
+type EditIssueFieldOption struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	// values accepted by an enum field, the values of the issues which are not an option anymore are cleared
+	Options []string `json:"options"`
+}
+
+// IssueFieldValue the value of a custom field of an issue
+type IssueFieldValue struct {
+	FieldID int64  `json:"field_id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	// numbers are formatted without superfluous digits and dates as YYYY-MM-DD
+	Value string `json:"value"`
+}
+
+// SetIssueFieldValueOption options for setting the value of a custom field of an issue
+type SetIssueFieldValueOption struct {
+	// an empty value clears the field
+	Value string `json:"value"`
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import "time"
+
+// IssueFilter a named combination of filters of the issue or pull request list of a repository saved by a user
+type IssueFilter struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// enum: issues,pulls
+	Type string `json:"type"`
+	// ids of the selected labels, a negative id excludes its label
+	Labels    []int64 `json:"labels"`
+	Milestone int64   `json:"milestone"`
+	Assignee  int64   `json:"assignee"`
+	Poster    int64   `json:"poster"`
+	// enum: open,closed
+	State   string `json:"state"`
+	Sort    string `json:"sort"`
+	Keyword string `json:"q"`
+	// pinned filters are shown above the issue or pull request list
+	Pinned bool `json:"pinned"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// link to the issue or pull request list with the filter applied
+	HTMLURL string `json:"html_url"`
+}
+
+// CreateIssueFilterOption options for saving a filter of the issue or pull request list of a repository
+type CreateIssueFilterOption struct {
+	// required:true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// enum: issues,pulls
+	Type string `json:"type" binding:"In(,issues,pulls)"`
+	// ids of the selected labels, a negative id excludes its label
+	Labels    []int64 `json:"labels"`
+	Milestone int64   `json:"milestone"`
+	Assignee  int64   `json:"assignee"`
+	Poster    int64   `json:"poster"`
+	// enum: open,closed
+	State string `json:"state" binding:"In(,open,closed)"`
+	// enum: latest,oldest,recentupdate,leastupdate,mostcomment,leastcomment,mostreactions,leastreactions,mostthumbsup,mostvotes,nearduedate,farduedate,priority
+	Sort    string `json:"sort"`
+	Keyword string `json:"q" binding:"MaxSize(255)"`
+	Pinned  bool   `json:"pinned"`
+}
+
+// EditIssueFilterOption options for editing a saved filter, its type cannot be changed
+type EditIssueFilterOption struct {
+	Name      *string `json:"name" binding:"MaxSize(255)"`
+	Labels    []int64 `json:"labels"`
+	Milestone *int64  `json:"milestone"`
+	Assignee  *int64  `json:"assignee"`
+	Poster    *int64  `json:"poster"`
+	// enum: open,closed
+	State *string `json:"state"`
+	// enum: latest,oldest,recentupdate,leastupdate,mostcomment,leastcomment,mostreactions,leastreactions,mostthumbsup,mostvotes,nearduedate,farduedate,priority
+	Sort    *string `json:"sort"`
+	Keyword *string `json:"q" binding:"MaxSize(255)"`
+	Pinned  *bool   `json:"pinned"`
+}
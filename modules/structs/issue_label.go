@@ -38,3 +38,37 @@ type IssueLabelsOption struct {
 	// list of label IDs
 	Labels []int64 `json:"labels"`
 }
+
+// SyncLabelsOption options for synchronizing the labels of repositories of an organization with its labels
+type SyncLabelsOption struct {
+	// names of the repositories of the organization to synchronize, all the repositories which aren't archived if empty
+	Repos []string `json:"repos"`
+	// set to `true` to only detect the drift of the labels of the repositories without synchronizing them
+	DryRun bool `json:"dry_run"`
+}
+
+// SyncLabelTemplateOption options for synchronizing the labels of repositories with a label template
+type SyncLabelTemplateOption struct {
+	// name of the label template
+	// required:true
+	Template string `json:"template" binding:"Required"`
+	// full names of the repositories to synchronize, e.g. `owner/repo`
+	// required:true
+	Repos []string `json:"repos" binding:"Required"`
+	// set to `true` to only detect the drift of the labels of the repositories without synchronizing them
+	DryRun bool `json:"dry_run"`
+}
+
+// LabelChange a change of a label of a repository to synchronize it with a label set
+type LabelChange struct {
+	Label *Label `json:"label"`
+	// the name of the label before the change, empty for a created label
+	OldName string `json:"old_name"`
+	Created bool   `json:"created"`
+}
+
+// LabelSyncResult the changes of the labels of a repository to synchronize them with a label set
+type LabelSyncResult struct {
+	Repository string         `json:"repository"`
+	Changes    []*LabelChange `json:"changes"`
+}
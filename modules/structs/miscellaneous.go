@@ -45,8 +45,68 @@ type ServerVersion struct {
 	Version string `json:"version"`
 }
 
+// InstanceMeta describes the version, the enabled features and the limits of the instance
+type InstanceMeta struct {
+	Version  string           `json:"version"`
+	Features InstanceFeatures `json:"features"`
+	Limits   InstanceLimits   `json:"limits"`
+}
+
+// InstanceFeatures lists the features enabled on the instance
+type InstanceFeatures struct {
+	Packages     bool     `json:"packages"`
+	PackageTypes []string `json:"package_types"`
+	// Actions aren't supported by this version
+	Actions        bool `json:"actions"`
+	Federation     bool `json:"federation"`
+	LFS            bool `json:"lfs"`
+	Mirrors        bool `json:"mirrors"`
+	Migrations     bool `json:"migrations"`
+	Attachments    bool `json:"attachments"`
+	Registration   bool `json:"registration"`
+	OAuth2Provider bool `json:"oauth2_provider"`
+}
+
+// InstanceLimits lists the limits of the instance, sizes are in bytes and a negative value or 0 is no limit
+type InstanceLimits struct {
+	MaxResponseItems  int   `json:"max_response_items"`
+	MaxFileSize       int64 `json:"max_file_size"`
+	MaxAttachmentSize int64 `json:"max_attachment_size"`
+	MaxLFSFileSize    int64 `json:"max_lfs_file_size"`
+	MaxRepoCreation   int   `json:"max_repo_creation"`
+}
+
 // APIError is an api error with a message
 type APIError struct {
 	Message string `json:"message"`
 	URL     string `json:"url"`
 }
+
+// GitignoreTemplateInfo is the name and the content of a gitignore template
+type GitignoreTemplateInfo struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+// LicenseTemplateInfo is the name and the content of a license template, with its placeholders filled
+type LicenseTemplateInfo struct {
+	Name string `json:"name"`
+	Body string `json:"body"`
+}
+
+// HighlightOption options to highlight the syntax of a code snippet
+type HighlightOption struct {
+	// Filename of the snippet, used to detect its language
+	Filename string `json:"filename"`
+	// Language of the snippet, the name or an alias of a lexer, overrides the detection
+	Language string `json:"language"`
+	// Content of the snippet
+	Content string `json:"content"`
+}
+
+// HighlightResult is a code snippet highlighted as HTML, one entry per line
+type HighlightResult struct {
+	// Language is the name of the lexer which highlighted the snippet, empty if it is too large to be highlighted
+	Language string   `json:"language"`
+	Lines    []string `json:"lines"`
+}
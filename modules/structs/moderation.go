@@ -0,0 +1,59 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import "time"
+
+// ModerationReport represents a report of an issue, a pull request or a comment to the moderators
+type ModerationReport struct {
+	ID         int64           `json:"id"`
+	Reporter   *User           `json:"reporter"`
+	Repository *RepositoryMeta `json:"repository"`
+	IssueIndex int64           `json:"issue_index"`
+	// the reported comment, 0 when the issue itself is reported
+	CommentID    int64  `json:"comment_id"`
+	ReportedUser *User  `json:"reported_user"`
+	Category     string `json:"category"`
+	Remark       string `json:"remark"`
+	// enum: open,resolved,dismissed
+	Status   string `json:"status"`
+	Resolver *User  `json:"resolver,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateModerationReportOption options for reporting an issue or a comment
+type CreateModerationReportOption struct {
+	// required: true
+	// enum: spam,abuse,off_topic,other
+	Category string `json:"category" binding:"Required;In(spam,abuse,off_topic,other)"`
+	Remark   string `json:"remark" binding:"MaxSize(1000)"`
+}
+
+// ModerationActionOption options for taking an action on a report
+type ModerationActionOption struct {
+	// required: true
+	// enum: hide_comment,lock_issue,shadow_limit,dismiss_report
+	Action string `json:"action" binding:"Required;In(hide_comment,lock_issue,shadow_limit,dismiss_report)"`
+	Reason string `json:"reason" binding:"MaxSize(1000)"`
+}
+
+// ModerationLogEntry represents an action of a moderator recorded in the audit trail
+type ModerationLogEntry struct {
+	ID        int64 `json:"id"`
+	Moderator *User `json:"moderator"`
+	// enum: hide_comment,unhide_comment,lock_issue,shadow_limit,remove_shadow_limit,dismiss_report
+	Action     string          `json:"action"`
+	Repository *RepositoryMeta `json:"repository,omitempty"`
+	IssueIndex int64           `json:"issue_index,omitempty"`
+	CommentID  int64           `json:"comment_id,omitempty"`
+	ReportID   int64           `json:"report_id,omitempty"`
+	TargetUser *User           `json:"target_user,omitempty"`
+	Reason     string          `json:"reason"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
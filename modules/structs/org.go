@@ -15,6 +15,8 @@ type Organization struct {
 	Location                  string `json:"location"`
 	Visibility                string `json:"visibility"`
 	RepoAdminChangeTeamAccess bool   `json:"repo_admin_change_team_access"`
+	// the organization has verified the ownership of an email domain
+	Verified bool `json:"verified"`
 }
 
 // OrganizationPermissions list different users permissions on an organization
@@ -24,6 +26,8 @@ type OrganizationPermissions struct {
 	CanWrite            bool `json:"can_write"`
 	CanRead             bool `json:"can_read"`
 	CanCreateRepository bool `json:"can_create_repository"`
+	// a guest only accesses the repositories it is granted and can't see the other members
+	IsGuest bool `json:"is_guest"`
 }
 
 // CreateOrgOption options for creating an organization
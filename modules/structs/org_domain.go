@@ -0,0 +1,36 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import "time"
+
+// OrgDomain represents an email domain claimed by an organization
+type OrgDomain struct {
+	ID     int64  `json:"id"`
+	Domain string `json:"domain"`
+	// the team joined by the users having a verified email address on the domain, 0 if they don't join the organization
+	TeamID   int64 `json:"team_id"`
+	Verified bool  `json:"verified"`
+	// swagger:strfmt date-time
+	VerifiedAt *time.Time `json:"verified_at"`
+	// name of the DNS TXT record proving the ownership of the domain
+	ChallengeName string `json:"challenge_name"`
+	// value of the DNS TXT record proving the ownership of the domain
+	ChallengeValue string `json:"challenge_value"`
+}
+
+// CreateOrgDomainOption options for claiming a domain
+type CreateOrgDomainOption struct {
+	// required: true
+	Domain string `json:"domain" binding:"Required;MaxSize(253)"`
+	// the team joined by the users having a verified email address on the domain
+	TeamID int64 `json:"team_id"`
+}
+
+// EditOrgDomainOption options for editing a domain
+type EditOrgDomainOption struct {
+	// the team joined by the users having a verified email address on the domain, 0 to not join the organization
+	TeamID int64 `json:"team_id"`
+}
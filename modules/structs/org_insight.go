@@ -0,0 +1,78 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import "time"
+
+// OrgInsightPulls represents the pull request throughput of an organization
+type OrgInsightPulls struct {
+	Opened int64 `json:"opened"`
+	Merged int64 `json:"merged"`
+	// average time from opening to merge of the merged pull requests, in seconds
+	AverageMergeTime int64 `json:"average_merge_time"`
+}
+
+// OrgInsightReviews represents the review latency of an organization
+type OrgInsightReviews struct {
+	// number of pull requests which got their first review
+	Reviewed int64 `json:"reviewed"`
+	// average time from opening to the first review by someone else than the poster, in seconds
+	AverageLatency int64 `json:"average_latency"`
+}
+
+// OrgInsightIssueAges represents the age distribution of the open issues of an organization
+type OrgInsightIssueAges struct {
+	// open for less than 7 days
+	Week int64 `json:"week"`
+	// open for 7 to 30 days
+	Month int64 `json:"month"`
+	// open for 30 to 90 days
+	Quarter int64 `json:"quarter"`
+	// open for 90 to 365 days
+	Year int64 `json:"year"`
+	// open for more than 365 days
+	Older int64 `json:"older"`
+}
+
+// OrgInsightIssues represents the issue activity of an organization
+type OrgInsightIssues struct {
+	Opened int64 `json:"opened"`
+	Closed int64 `json:"closed"`
+	// age of the issues still open at the end of the period
+	OpenAges *OrgInsightIssueAges `json:"open_ages"`
+}
+
+// OrgInsightStatuses represents the CI success rate of an organization, from the commit statuses
+type OrgInsightStatuses struct {
+	Succeeded int64 `json:"succeeded"`
+	// statuses in the failure or error state
+	Failed int64 `json:"failed"`
+	// share of succeeded statuses among the succeeded and failed ones, 0 if there is none
+	SuccessRate float64 `json:"success_rate"`
+}
+
+// OrgInsight represents the metrics of the repositories of an organization for one UTC day
+type OrgInsight struct {
+	// swagger:strfmt date
+	Date     time.Time           `json:"date"`
+	Pulls    *OrgInsightPulls    `json:"pulls"`
+	Reviews  *OrgInsightReviews  `json:"reviews"`
+	Issues   *OrgInsightIssues   `json:"issues"`
+	Statuses *OrgInsightStatuses `json:"statuses"`
+}
+
+// OrgInsightSummary represents the metrics of the repositories of an organization aggregated over a period
+type OrgInsightSummary struct {
+	// swagger:strfmt date-time
+	Since time.Time `json:"since"`
+	// swagger:strfmt date-time
+	Before time.Time `json:"before"`
+	// number of days computed in the period
+	Days     int                 `json:"days"`
+	Pulls    *OrgInsightPulls    `json:"pulls"`
+	Reviews  *OrgInsightReviews  `json:"reviews"`
+	Issues   *OrgInsightIssues   `json:"issues"`
+	Statuses *OrgInsightStatuses `json:"statuses"`
+}
@@ -0,0 +1,32 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+// Role represents a role of an organization, which grants permissions on the units of its repositories
+type Role struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// example: {"repo.code":"read","repo.issues":"write","repo.pulls":"read","repo.releases":"write"}
+	UnitsMap map[string]string `json:"units_map"`
+}
+
+// CreateRoleOption options for creating a role
+type CreateRoleOption struct {
+	// required: true
+	Name        string `json:"name" binding:"Required;AlphaDashDot;MaxSize(30)"`
+	Description string `json:"description" binding:"MaxSize(255)"`
+	// required: true
+	// example: {"repo.code":"read","repo.issues":"write","repo.pulls":"read","repo.releases":"write"}
+	UnitsMap map[string]string `json:"units_map"`
+}
+
+// EditRoleOption options for editing a role
+type EditRoleOption struct {
+	Name        string  `json:"name" binding:"AlphaDashDot;MaxSize(30)"`
+	Description *string `json:"description" binding:"MaxSize(255)"`
+	// example: {"repo.code":"read","repo.issues":"write","repo.pulls":"read","repo.releases":"write"}
+	UnitsMap map[string]string `json:"units_map"`
+}
@@ -18,8 +18,13 @@ type Team struct {
 	// Deprecated: This variable should be replaced by UnitsMap and will be dropped in later versions.
 	Units []string `json:"units"`
 	// example: {"repo.code":"read","repo.issues":"write","repo.ext_issues":"none","repo.wiki":"admin","repo.pulls":"owner","repo.releases":"none","repo.projects":"none","repo.ext_wiki":"none"]
-	UnitsMap         map[string]string `json:"units_map"`
-	CanCreateOrgRepo bool              `json:"can_create_org_repo"`
+	UnitsMap           map[string]string `json:"units_map"`
+	CanCreateOrgRepo   bool              `json:"can_create_org_repo"`
+	AutoAssignReviewer bool              `json:"auto_assign_reviewer"`
+	// id of the parent team, 0 if the team is not nested
+	ParentID int64 `json:"parent_id"`
+	// id of the role granting the permissions of the team, 0 if the team has no role
+	RoleID int64 `json:"role_id"`
 }
 
 // CreateTeamOption options for creating a team
@@ -34,8 +39,13 @@ type CreateTeamOption struct {
 	// Deprecated: This variable should be replaced by UnitsMap and will be dropped in later versions.
 	Units []string `json:"units"`
 	// example: {"repo.code":"read","repo.issues":"write","repo.ext_issues":"none","repo.wiki":"admin","repo.pulls":"owner","repo.releases":"none","repo.projects":"none","repo.ext_wiki":"none"]
-	UnitsMap         map[string]string `json:"units_map"`
-	CanCreateOrgRepo bool              `json:"can_create_org_repo"`
+	UnitsMap           map[string]string `json:"units_map"`
+	CanCreateOrgRepo   bool              `json:"can_create_org_repo"`
+	AutoAssignReviewer bool              `json:"auto_assign_reviewer"`
+	// id of the parent team whose access the members inherit
+	ParentID int64 `json:"parent_id"`
+	// id of a role of the organization granting its permissions to the team instead of permission and units_map
+	RoleID int64 `json:"role_id"`
 }
 
 // EditTeamOption options for editing a team
@@ -50,6 +60,24 @@ type EditTeamOption struct {
 	// Deprecated: This variable should be replaced by UnitsMap and will be dropped in later versions.
 	Units []string `json:"units"`
 	// example: {"repo.code":"read","repo.issues":"write","repo.ext_issues":"none","repo.wiki":"admin","repo.pulls":"owner","repo.releases":"none","repo.projects":"none","repo.ext_wiki":"none"]
-	UnitsMap         map[string]string `json:"units_map"`
-	CanCreateOrgRepo *bool             `json:"can_create_org_repo"`
+	UnitsMap           map[string]string `json:"units_map"`
+	CanCreateOrgRepo   *bool             `json:"can_create_org_repo"`
+	AutoAssignReviewer *bool             `json:"auto_assign_reviewer"`
+	// id of the parent team whose access the members inherit, 0 to make it a top-level team
+	ParentID *int64 `json:"parent_id"`
+	// id of a role of the organization granting its permissions to the team instead of permission and units_map,
+	// 0 to remove the role
+	RoleID *int64 `json:"role_id"`
+}
+
+// TeamReviewerWorkload represents the open review requests of a team member
+type TeamReviewerWorkload struct {
+	Reviewer     *User `json:"reviewer"`
+	OpenRequests int64 `json:"open_requests"`
+	// maximum number of open review requests, 0 means unlimited
+	Capacity    int  `json:"capacity"`
+	OutOfOffice bool `json:"out_of_office"`
+	Busy        bool `json:"busy"`
+	// whether the member can be assigned another review
+	Available bool `json:"available"`
 }
@@ -35,6 +35,8 @@ type PullRequest struct {
 	MergedCommitID      *string    `json:"merge_commit_sha"`
 	MergedBy            *User      `json:"merged_by"`
 	AllowMaintainerEdit bool       `json:"allow_maintainer_edit"`
+	// DisableReviewReminders is true if the pull request opted out of the review reminder policies
+	DisableReviewReminders bool `json:"disable_review_reminders"`
 
 	Base      *PRBranchInfo `json:"base"`
 	Head      *PRBranchInfo `json:"head"`
@@ -94,4 +96,44 @@ type EditPullRequestOption struct {
 	Deadline            *time.Time `json:"due_date"`
 	RemoveDeadline      *bool      `json:"unset_due_date"`
 	AllowMaintainerEdit *bool      `json:"allow_maintainer_edit"`
+	// set to `true` to opt the pull request out of the repository's review reminder policies
+	DisableReviewReminders *bool `json:"disable_review_reminders"`
+}
+
+// PullRequestMergePreview is what merging a pull request would produce with each merge style allowed by the repository
+type PullRequestMergePreview struct {
+	BaseCommit string                          `json:"base_commit"`
+	HeadCommit string                          `json:"head_commit"`
+	MergeBase  string                          `json:"merge_base"`
+	Styles     []*PullRequestMergeStylePreview `json:"styles"`
+	// Files are the files the pull request changes from the merge base
+	Files []*PullRequestChangedFile `json:"files"`
+	// FilesTruncated is true if the pull request changes more files than the listed ones
+	FilesTruncated bool `json:"files_truncated"`
+	Additions      int  `json:"additions"`
+	Deletions      int  `json:"deletions"`
+}
+
+// PullRequestMergeStylePreview is what merging a pull request with a merge style would produce
+type PullRequestMergeStylePreview struct {
+	// enum: merge,rebase,rebase-merge,squash
+	Style string `json:"style"`
+	// Mergeable is false if the merge conflicts or the branches have no common history
+	Mergeable bool `json:"mergeable"`
+	// CommitCount is the number of commits the merge adds to the base branch
+	CommitCount     int      `json:"commit_count"`
+	ConflictedFiles []string `json:"conflicted_files"`
+	// ConflictCommit is the commit of the head branch which fails to be rebased
+	ConflictCommit string `json:"conflict_commit,omitempty"`
+}
+
+// PullRequestChangedFile is a file changed by a pull request
+type PullRequestChangedFile struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	// enum: added,modified,deleted,renamed,copied
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Binary    bool   `json:"binary"`
 }
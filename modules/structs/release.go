@@ -49,3 +49,51 @@ type EditReleaseOption struct {
 	IsDraft      *bool  `json:"draft"`
 	IsPrerelease *bool  `json:"prerelease"`
 }
+
+// PublishReleaseDraftOption options when publishing the drafted release of a repository
+type PublishReleaseDraftOption struct {
+	// required: true
+	TagName string `json:"tag_name" binding:"Required"`
+	// the branch or commit of the tag if it doesn't exist, the default branch if empty
+	Target string `json:"target_commitish"`
+	// the name of the release, the tag name if empty
+	Title        string `json:"name"`
+	IsPrerelease bool   `json:"prerelease"`
+}
+
+// ReleaseBuild represents the build of the assets of a release by an external build system. The release stays a
+// draft until the build succeeds.
+type ReleaseBuild struct {
+	ID        int64  `json:"id"`
+	TagName   string `json:"tag_name"`
+	ReleaseID int64  `json:"release_id"`
+	Builder   *User  `json:"builder"`
+	// enum: pending,running,success,failure
+	Status      string `json:"status"`
+	Description string `json:"description"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateReleaseBuildOption options when claiming a pushed tag to build the assets of its release
+type CreateReleaseBuildOption struct {
+	// required: true
+	TagName string `json:"tag_name" binding:"Required"`
+}
+
+// EditReleaseBuildOption options when updating the status of a release build
+type EditReleaseBuildOption struct {
+	// the release is published when the build succeeds
+	// required: true
+	// enum: pending,running,success,failure
+	Status      string `json:"status" binding:"Required;In(pending,running,success,failure)"`
+	Description string `json:"description" binding:"MaxSize(255)"`
+}
+
+// AppendReleaseBuildLogOption options when appending to the log of a release build
+type AppendReleaseBuildLogOption struct {
+	// required: true
+	Content string `json:"content" binding:"Required"`
+}
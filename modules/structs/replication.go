@@ -0,0 +1,39 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import "time"
+
+// ReplicationStatus represents the state of the replication of the repositories of the instance to its standby
+type ReplicationStatus struct {
+	// true if the repositories of this instance are replicated to a standby instance
+	Enabled bool `json:"enabled"`
+	// true if this instance is a standby, which only accepts the pushes of its primary instance
+	Standby    bool   `json:"standby"`
+	StandbyURL string `json:"standby_url"`
+	// number of repositories whose changes are not replicated yet
+	PendingRepos int64 `json:"pending_repos"`
+	// number of repositories whose last replication failed
+	FailingRepos int64 `json:"failing_repos"`
+	// number of repositories which have never been replicated
+	NotReplicatedRepos int64 `json:"not_replicated_repos"`
+	// for how long the oldest change which is not replicated yet is pending, in seconds
+	LagSeconds int64 `json:"lag_seconds"`
+	// true if the lag exceeds the MAX_LAG setting of the replication
+	Lagging bool `json:"lagging"`
+}
+
+// RepoReplication represents the state of the replication of a repository to the standby instance
+type RepoReplication struct {
+	RepoID       int64  `json:"repo_id"`
+	RepoFullName string `json:"repo_full_name"`
+	// swagger:strfmt date-time
+	PendingSince *time.Time `json:"pending_since"`
+	// for how long the oldest change which is not replicated yet is pending, in seconds
+	LagSeconds int64 `json:"lag_seconds"`
+	// swagger:strfmt date-time
+	SyncedAt  *time.Time `json:"synced_at"`
+	LastError string     `json:"last_error"`
+}
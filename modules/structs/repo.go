@@ -25,6 +25,25 @@ type InternalTracker struct {
 	AllowOnlyContributorsToTrackTime bool `json:"allow_only_contributors_to_track_time"`
 	// Enable dependencies for issues and pull requests (Built-in issue tracker)
 	EnableIssueDependencies bool `json:"enable_issue_dependencies"`
+	// Stale policy of the issues (Built-in issue tracker), left unchanged when omitted
+	StalePolicy *StalePolicy `json:"stale_policy,omitempty"`
+}
+
+// StalePolicy represents the settings marking and closing the issues without activity
+// swagger:model
+type StalePolicy struct {
+	// Days without activity after which an open issue is marked as stale, 0 disables the stale policy
+	StaleDays int `json:"stale_days"`
+	// Days a stale issue stays without activity before being closed, 0 never closes them
+	StaleCloseDays int `json:"stale_close_days"`
+	// Name of the label marking the stale issues, it is created when missing
+	StaleLabel string `json:"stale_label"`
+	// Names of the labels exempting issues from the stale policy
+	ExemptLabels []string `json:"exempt_labels"`
+	// Exempt the issues assigned to a milestone from the stale policy
+	ExemptMilestones bool `json:"exempt_milestones"`
+	// Apply the stale policy to pull requests as well
+	IncludePulls bool `json:"include_pulls"`
 }
 
 // ExternalTracker represents settings for external tracker
@@ -94,6 +113,12 @@ type Repository struct {
 	AllowRebaseUpdate             bool             `json:"allow_rebase_update"`
 	DefaultDeleteBranchAfterMerge bool             `json:"default_delete_branch_after_merge"`
 	DefaultMergeStyle             string           `json:"default_merge_style"`
+	ReviewReminderDays            int              `json:"review_reminder_days"`
+	ReviewEscalationDays          int              `json:"review_escalation_days"`
+	ApprovalExpiryDays            int              `json:"approval_expiry_days"`
+	DefaultMergeMessageTemplate   string           `json:"default_merge_message_template"`
+	DefaultSquashMessageTemplate  string           `json:"default_squash_message_template"`
+	EnforceMergeMessageTemplate   bool             `json:"enforce_merge_message_template"`
 	AvatarURL                     string           `json:"avatar_url"`
 	Internal                      bool             `json:"internal"`
 	MirrorInterval                string           `json:"mirror_interval"`
@@ -185,6 +210,18 @@ type EditRepoOption struct {
 	DefaultDeleteBranchAfterMerge *bool `json:"default_delete_branch_after_merge,omitempty"`
 	// set to a merge style to be used by this repository: "merge", "rebase", "rebase-merge", or "squash". `has_pull_requests` must be `true`.
 	DefaultMergeStyle *string `json:"default_merge_style,omitempty"`
+	// set to the number of days after which requested reviewers are reminded, `0` disables reminders. `has_pull_requests` must be `true`.
+	ReviewReminderDays *int `json:"review_reminder_days,omitempty"`
+	// set to the number of days after which pending review requests are escalated, `0` disables escalation. `has_pull_requests` must be `true`.
+	ReviewEscalationDays *int `json:"review_escalation_days,omitempty"`
+	// set to the number of days after which approvals are dismissed, `0` keeps approvals forever. `has_pull_requests` must be `true`.
+	ApprovalExpiryDays *int `json:"approval_expiry_days,omitempty"`
+	// set to the template of the message of the merge commits, used by the "merge" and "rebase-merge" merge styles. `has_pull_requests` must be `true`.
+	DefaultMergeMessageTemplate *string `json:"default_merge_message_template,omitempty"`
+	// set to the template of the message of the squash commits. `has_pull_requests` must be `true`.
+	DefaultSquashMessageTemplate *string `json:"default_squash_message_template,omitempty"`
+	// set to `true` to prevent the title of the commit messages generated by the templates from being changed when merging. `has_pull_requests` must be `true`.
+	EnforceMergeMessageTemplate *bool `json:"enforce_merge_message_template,omitempty"`
 	// set to `true` to archive this repository.
 	Archived *bool `json:"archived,omitempty"`
 	// set to a string like `8h30m0s` to set the mirror interval time
@@ -353,3 +390,40 @@ type RepoTransfer struct {
 	Recipient *User   `json:"recipient"`
 	Teams     []*Team `json:"teams"`
 }
+
+// ForkNetworkRepository a repository of the fork network of a repository
+type ForkNetworkRepository struct {
+	Repository *Repository `json:"repository"`
+	// full name of the repository it was forked from, empty for the root of the network or if it isn't accessible
+	Parent string `json:"parent"`
+	// number of commits the default branch is ahead of the default branch of the root of the network, only if requested
+	AheadBy *int `json:"ahead_by,omitempty"`
+	// number of commits the default branch is behind the default branch of the root of the network, only if requested
+	BehindBy *int `json:"behind_by,omitempty"`
+}
+
+// ForkContainingCommit a repository of the fork network of a repository containing a commit
+type ForkContainingCommit struct {
+	Repository *Repository `json:"repository"`
+	// branches of the repository containing the commit
+	Branches []string `json:"branches"`
+}
+
+// SyncForkOption options for synchronizing the default branch of a fork with its upstream repository
+type SyncForkOption struct {
+	// only check how the fork would be synchronized, without changing it
+	DryRun bool `json:"dry_run"`
+}
+
+// ForkSyncResult the result of the synchronization of the default branch of a fork with its upstream repository
+type ForkSyncResult struct {
+	// how the default branch is synchronized
+	// enum: up_to_date,fast_forward,merge
+	Status string `json:"status"`
+	// number of commits the fork was ahead of its upstream repository before the synchronization
+	Ahead int `json:"ahead"`
+	// number of commits the fork was behind its upstream repository before the synchronization
+	Behind int `json:"behind"`
+	// commit of the default branch after the synchronization, or before it for a dry run
+	CommitID string `json:"commit_id"`
+}
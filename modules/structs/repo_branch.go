@@ -41,10 +41,17 @@ type BranchProtection struct {
 	BlockOnRejectedReviews        bool     `json:"block_on_rejected_reviews"`
 	BlockOnOfficialReviewRequests bool     `json:"block_on_official_review_requests"`
 	BlockOnOutdatedBranch         bool     `json:"block_on_outdated_branch"`
-	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals"`
-	RequireSignedCommits          bool     `json:"require_signed_commits"`
-	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
-	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
+	BlockOnCodeScanningAlerts     bool     `json:"block_on_code_scanning_alerts"`
+	// minimum percentage of the lines added by a pull request which must be covered by tests, 0 disables the check
+	MinimumDiffCoverage   int64 `json:"minimum_diff_coverage"`
+	DismissStaleApprovals bool  `json:"dismiss_stale_approvals"`
+	// number of the last committers of a pull request whose approvals aren't counted, 0 counts them all
+	IgnoreLastCommittersApprovals int64 `json:"ignore_last_committers_approvals"`
+	// dismiss the approvals of the users who push to the pull request afterwards
+	DismissPusherApprovals  bool   `json:"dismiss_pusher_approvals"`
+	RequireSignedCommits    bool   `json:"require_signed_commits"`
+	ProtectedFilePatterns   string `json:"protected_file_patterns"`
+	UnprotectedFilePatterns string `json:"unprotected_file_patterns"`
 	// swagger:strfmt date-time
 	Created time.Time `json:"created_at"`
 	// swagger:strfmt date-time
@@ -71,10 +78,17 @@ type CreateBranchProtectionOption struct {
 	BlockOnRejectedReviews        bool     `json:"block_on_rejected_reviews"`
 	BlockOnOfficialReviewRequests bool     `json:"block_on_official_review_requests"`
 	BlockOnOutdatedBranch         bool     `json:"block_on_outdated_branch"`
-	DismissStaleApprovals         bool     `json:"dismiss_stale_approvals"`
-	RequireSignedCommits          bool     `json:"require_signed_commits"`
-	ProtectedFilePatterns         string   `json:"protected_file_patterns"`
-	UnprotectedFilePatterns       string   `json:"unprotected_file_patterns"`
+	BlockOnCodeScanningAlerts     bool     `json:"block_on_code_scanning_alerts"`
+	// minimum percentage of the lines added by a pull request which must be covered by tests, 0 disables the check
+	MinimumDiffCoverage   int64 `json:"minimum_diff_coverage"`
+	DismissStaleApprovals bool  `json:"dismiss_stale_approvals"`
+	// number of the last committers of a pull request whose approvals aren't counted, 0 counts them all
+	IgnoreLastCommittersApprovals int64 `json:"ignore_last_committers_approvals"`
+	// dismiss the approvals of the users who push to the pull request afterwards
+	DismissPusherApprovals  bool   `json:"dismiss_pusher_approvals"`
+	RequireSignedCommits    bool   `json:"require_signed_commits"`
+	ProtectedFilePatterns   string `json:"protected_file_patterns"`
+	UnprotectedFilePatterns string `json:"unprotected_file_patterns"`
 }
 
 // EditBranchProtectionOption options for editing a branch protection
@@ -96,8 +110,55 @@ type EditBranchProtectionOption struct {
 	BlockOnRejectedReviews        *bool    `json:"block_on_rejected_reviews"`
 	BlockOnOfficialReviewRequests *bool    `json:"block_on_official_review_requests"`
 	BlockOnOutdatedBranch         *bool    `json:"block_on_outdated_branch"`
-	DismissStaleApprovals         *bool    `json:"dismiss_stale_approvals"`
-	RequireSignedCommits          *bool    `json:"require_signed_commits"`
-	ProtectedFilePatterns         *string  `json:"protected_file_patterns"`
-	UnprotectedFilePatterns       *string  `json:"unprotected_file_patterns"`
+	BlockOnCodeScanningAlerts     *bool    `json:"block_on_code_scanning_alerts"`
+	// minimum percentage of the lines added by a pull request which must be covered by tests, 0 disables the check
+	MinimumDiffCoverage   *int64 `json:"minimum_diff_coverage"`
+	DismissStaleApprovals *bool  `json:"dismiss_stale_approvals"`
+	// number of the last committers of a pull request whose approvals aren't counted, 0 counts them all
+	IgnoreLastCommittersApprovals *int64 `json:"ignore_last_committers_approvals"`
+	// dismiss the approvals of the users who push to the pull request afterwards
+	DismissPusherApprovals  *bool   `json:"dismiss_pusher_approvals"`
+	RequireSignedCommits    *bool   `json:"require_signed_commits"`
+	ProtectedFilePatterns   *string `json:"protected_file_patterns"`
+	UnprotectedFilePatterns *string `json:"unprotected_file_patterns"`
+}
+
+// RenameDefaultBranchOption options for renaming the default branch of a repository
+type RenameDefaultBranchOption struct {
+	// new name of the default branch
+	// required: true
+	NewName string `json:"new_name" binding:"Required;GitRefName;MaxSize(100)"`
+}
+
+// RenameOrgDefaultBranchesOption options for renaming the default branch of the repositories of an organization
+type RenameOrgDefaultBranchesOption struct {
+	// name of the default branch of the repositories to rename
+	// required: true
+	OldName string `json:"old_name" binding:"Required;GitRefName;MaxSize(100)"`
+	// new name of the default branch
+	// required: true
+	NewName string `json:"new_name" binding:"Required;GitRefName;MaxSize(100)"`
+}
+
+// DefaultBranchRename what renaming the default branch of a repository changed, the old name of the branch redirects
+// to the new name
+type DefaultBranchRename struct {
+	Repository string `json:"repository"`
+	OldName    string `json:"old_name"`
+	NewName    string `json:"new_name"`
+	// number of open pull requests retargeted to the renamed branch
+	RetargetedPullRequests int `json:"retargeted_pull_requests"`
+	// whether the protection of the branch moved to the renamed branch
+	UpdatedBranchProtection bool `json:"updated_branch_protection"`
+	// number of webhooks whose branch filter was updated
+	UpdatedWebhooks int `json:"updated_webhooks"`
+	// the reason why the default branch of the repository couldn't be renamed
+	Error string `json:"error,omitempty"`
+}
+
+// OrgDefaultBranchRename what renaming the default branch of the repositories of an organization changed
+type OrgDefaultBranchRename struct {
+	Repositories []*DefaultBranchRename `json:"repositories"`
+	// number of webhooks of the organization whose branch filter was updated
+	UpdatedWebhooks int `json:"updated_webhooks"`
 }
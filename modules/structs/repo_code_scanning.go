@@ -0,0 +1,75 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"time"
+)
+
+// UploadSarifOption options for uploading a SARIF report of a code scanning tool
+type UploadSarifOption struct {
+	// SHA of the analyzed commit
+	// required: true
+	CommitSHA string `json:"commit_sha" binding:"Required;Size(40)"`
+	// full git reference of the analyzed commit, e.g. refs/heads/main or refs/pull/1/head
+	// required: true
+	Ref string `json:"ref" binding:"Required"`
+	// base64 encoded, optionally gzip compressed SARIF 2.1.0 report
+	// required: true
+	Sarif string `json:"sarif" binding:"Required"`
+}
+
+// CodeScanningAnalysis represents the results of one code scanning tool for a commit
+type CodeScanningAnalysis struct {
+	ID           int64  `json:"id"`
+	CommitSHA    string `json:"commit_sha"`
+	Ref          string `json:"ref"`
+	ToolName     string `json:"tool_name"`
+	ToolVersion  string `json:"tool_version"`
+	Category     string `json:"category"`
+	ResultsCount int    `json:"results_count"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// CodeScanningAlert represents a finding of a code scanning tool
+type CodeScanningAlert struct {
+	ID int64 `json:"id"`
+	// enum: open,dismissed,fixed
+	State           string `json:"state"`
+	ToolName        string `json:"tool_name"`
+	Category        string `json:"category"`
+	RuleID          string `json:"rule_id"`
+	RuleDescription string `json:"rule_description"`
+	// enum: error,warning,note
+	Severity         string `json:"severity"`
+	Message          string `json:"message"`
+	Path             string `json:"path"`
+	StartLine        int    `json:"start_line"`
+	EndLine          int    `json:"end_line"`
+	FirstSeenSHA     string `json:"first_seen_sha"`
+	LastSeenSHA      string `json:"last_seen_sha"`
+	DismissedBy      *User  `json:"dismissed_by"`
+	DismissedReason  string `json:"dismissed_reason"`
+	DismissedComment string `json:"dismissed_comment"`
+	// swagger:strfmt date-time
+	Dismissed *time.Time `json:"dismissed_at"`
+	// swagger:strfmt date-time
+	Fixed *time.Time `json:"fixed_at"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// EditCodeScanningAlertOption options for dismissing or reopening a code scanning alert
+type EditCodeScanningAlertOption struct {
+	// enum: open,dismissed
+	// required: true
+	State string `json:"state" binding:"Required;In(open,dismissed)"`
+	// enum: false positive,won't fix,used in tests
+	DismissedReason  string `json:"dismissed_reason" binding:"In(,false positive,won't fix,used in tests)"`
+	DismissedComment string `json:"dismissed_comment" binding:"MaxSize(280)"`
+}
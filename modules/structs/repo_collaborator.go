@@ -7,6 +7,8 @@ package structs
 // AddCollaboratorOption options when adding a user as a collaborator of a repository
 type AddCollaboratorOption struct {
 	Permission *string `json:"permission"`
+	// id of a role of the organization owning the repository, which is granted instead of permission
+	RoleID *int64 `json:"role_id"`
 }
 
 // RepoCollaboratorPermission to get repository permission for a collaborator
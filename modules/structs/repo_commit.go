@@ -71,3 +71,47 @@ type CommitDateOptions struct {
 type CommitAffectedFiles struct {
 	Filename string `json:"filename"`
 }
+
+// LastCommit contains the last commit which changed a file or a directory
+type LastCommit struct {
+	Path   string              `json:"path"`
+	Commit *FileCommitResponse `json:"commit"`
+}
+
+// CommitSignatureKey is a key of the trust chain of the signature of a commit
+type CommitSignatureKey struct {
+	// Type is either gpg or ssh
+	Type        string `json:"type"`
+	KeyID       string `json:"key_id,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Verified is true if the owner of the key proved to hold it
+	Verified bool `json:"verified"`
+}
+
+// CommitSignatureVerification is the verification of the signature of a commit
+type CommitSignatureVerification struct {
+	SHA      string `json:"sha"`
+	Verified bool   `json:"verified"`
+	// Warning is true if the signature is made by a known key but doesn't match the commit
+	Warning bool   `json:"warning"`
+	Reason  string `json:"reason"`
+	// TrustModel is the trust model of the repository the signature was verified against
+	TrustModel string `json:"trust_model"`
+	// TrustStatus of a verified signature in the trust model, one of trusted, untrusted or unmatched
+	TrustStatus string    `json:"trust_status"`
+	Committer   *Identity `json:"committer"`
+	// Signer is the user owning the signing key, missing for the signing keys of the instance
+	Signer         *User     `json:"signer"`
+	SignerIdentity *Identity `json:"signer_identity"`
+	// KeyChain starts with the key which made the signature, followed by the primary key it is a subkey of
+	KeyChain  []*CommitSignatureKey `json:"key_chain"`
+	Signature string                `json:"signature"`
+	Payload   string                `json:"payload"`
+}
+
+// VerifyCommitSignatureOption options for verifying the signature of a commit which is not in a repository
+type VerifyCommitSignatureOption struct {
+	// raw commit object, as printed by `git cat-file commit`, with its signature
+	// required: true
+	Commit string `json:"commit" binding:"Required"`
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"time"
+)
+
+// Component is a named part of a repository made of the files matching its paths
+type Component struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// glob patterns of the files of the component, e.g. "services/auth/**"
+	Paths        []string `json:"paths"`
+	OwnerUserIDs []int64  `json:"owner_user_ids"`
+	OwnerTeamIDs []int64  `json:"owner_team_ids"`
+	// labels added to the issues and the pull requests touching the component
+	LabelIDs     []int64 `json:"label_ids"`
+	NotifyOwners bool    `json:"notify_owners"`
+	OpenIssues   int     `json:"open_issues"`
+	OpenPulls    int     `json:"open_pull_requests"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateComponentOption options for creating a component
+type CreateComponentOption struct {
+	// required:true
+	Name        string `json:"name" binding:"Required;MaxSize(100)"`
+	Description string `json:"description"`
+	// required:true
+	Paths        []string `json:"paths" binding:"Required"`
+	OwnerUserIDs []int64  `json:"owner_user_ids"`
+	OwnerTeamIDs []int64  `json:"owner_team_ids"`
+	LabelIDs     []int64  `json:"label_ids"`
+	NotifyOwners *bool    `json:"notify_owners"`
+}
+
+// EditComponentOption options for editing a component
+type EditComponentOption struct {
+	Name         *string  `json:"name" binding:"MaxSize(100)"`
+	Description  *string  `json:"description"`
+	Paths        []string `json:"paths"`
+	OwnerUserIDs []int64  `json:"owner_user_ids"`
+	OwnerTeamIDs []int64  `json:"owner_team_ids"`
+	LabelIDs     []int64  `json:"label_ids"`
+	NotifyOwners *bool    `json:"notify_owners"`
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+// UploadCoverageOption options for uploading a test coverage report
+type UploadCoverageOption struct {
+	// SHA of the commit the tests ran on
+	// required: true
+	CommitSHA string `json:"commit_sha" binding:"Required;Size(40)"`
+	// format of the report
+	// required: true
+	// enum: lcov,cobertura
+	Format string `json:"format" binding:"Required;In(lcov,cobertura)"`
+	// name of the test suite, reports with different flags are merged, uploading a report
+	// with an existing flag replaces it
+	Flag string `json:"flag" binding:"MaxSize(255)"`
+	// base64 encoded, optionally gzip compressed report
+	// required: true
+	Report string `json:"report" binding:"Required"`
+}
+
+// FileCoverage represents the line coverage of a file
+type FileCoverage struct {
+	Path         string  `json:"path"`
+	LinesCovered int64   `json:"lines_covered"`
+	LinesValid   int64   `json:"lines_valid"`
+	Percentage   float64 `json:"percentage"`
+}
+
+// CommitCoverage represents the line coverage of a commit merged over all uploaded reports
+type CommitCoverage struct {
+	CommitSHA    string          `json:"commit_sha"`
+	LinesCovered int64           `json:"lines_covered"`
+	LinesValid   int64           `json:"lines_valid"`
+	Percentage   float64         `json:"percentage"`
+	Files        []*FileCoverage `json:"files"`
+}
+
+// PullRequestCoverage represents the coverage of the lines added by a pull request
+type PullRequestCoverage struct {
+	HeadSHA      string  `json:"head_sha"`
+	BaseSHA      string  `json:"base_sha"`
+	LinesCovered int64   `json:"lines_covered"`
+	LinesValid   int64   `json:"lines_valid"`
+	Percentage   float64 `json:"percentage"`
+	// coverage of the added lines of every changed file
+	Files []*FileCoverage `json:"files"`
+}
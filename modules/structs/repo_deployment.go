@@ -0,0 +1,62 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import (
+	"time"
+)
+
+// Deployment represents the deployment of a commit to an environment
+type Deployment struct {
+	ID          int64  `json:"id"`
+	SHA         string `json:"sha"`
+	Ref         string `json:"ref"`
+	Environment string `json:"environment"`
+	Description string `json:"description"`
+	// state of the latest status of the deployment
+	// enum: pending,queued,in_progress,success,failure,error,inactive
+	State          string `json:"state"`
+	EnvironmentURL string `json:"environment_url"`
+	Creator        *User  `json:"creator"`
+	URL            string `json:"url"`
+	StatusesURL    string `json:"statuses_url"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// DeploymentStatus represents a state transition of a deployment
+type DeploymentStatus struct {
+	ID int64 `json:"id"`
+	// enum: pending,queued,in_progress,success,failure,error,inactive
+	State          string `json:"state"`
+	Description    string `json:"description"`
+	LogURL         string `json:"log_url"`
+	EnvironmentURL string `json:"environment_url"`
+	Creator        *User  `json:"creator"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+}
+
+// CreateDeploymentOption options for creating a deployment
+type CreateDeploymentOption struct {
+	// branch, tag or commit SHA to deploy
+	// required: true
+	Ref string `json:"ref" binding:"Required"`
+	// name of the environment, defaults to production
+	Environment string `json:"environment" binding:"MaxSize(255)"`
+	Description string `json:"description"`
+}
+
+// CreateDeploymentStatusOption options for adding a status to a deployment
+type CreateDeploymentStatusOption struct {
+	// required: true
+	// enum: pending,queued,in_progress,success,failure,error,inactive
+	State          string `json:"state" binding:"Required;In(pending,queued,in_progress,success,failure,error,inactive)"`
+	Description    string `json:"description"`
+	LogURL         string `json:"log_url" binding:"OmitEmpty;ValidUrl"`
+	EnvironmentURL string `json:"environment_url" binding:"OmitEmpty;ValidUrl"`
+}
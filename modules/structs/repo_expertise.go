@@ -0,0 +1,46 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import "time"
+
+// Expert represents an author of a repository with their expertise on a file or a directory
+type Expert struct {
+	// the user the email of the author belongs to, null if none does
+	User *User `json:"user"`
+	// the email of the commits of the author
+	Email string `json:"email"`
+	// number of lines the author has last changed according to blame
+	Lines int64 `json:"lines"`
+	// number of non-merge commits of the default branch of the author which have changed the file or the directory
+	Commits int64 `json:"commits"`
+	// lines plus ten times the commits, the higher the better the author knows the file or the directory
+	Score int64 `json:"score"`
+	// swagger:strfmt date-time
+	LastCommit *time.Time `json:"last_commit"`
+}
+
+// RepoExpertise represents the experts of a file or a directory of the default branch of a repository
+type RepoExpertise struct {
+	// the path of the file or the directory, empty for the root directory
+	Path string `json:"path"`
+	// the smallest number of authors who have last changed more than half of the lines
+	BusFactor int `json:"bus_factor"`
+	// total number of authors
+	Authors int       `json:"authors"`
+	Experts []*Expert `json:"experts"`
+}
+
+// DirectoryBusFactor represents the bus factor of a directory of the default branch of a repository
+type DirectoryBusFactor struct {
+	// the path of the directory, empty for the root directory
+	Path string `json:"path"`
+	// the smallest number of authors who have last changed more than half of the lines
+	BusFactor int `json:"bus_factor"`
+	// total number of authors
+	Authors int `json:"authors"`
+	// the best expert of the directory
+	TopExpert *Expert `json:"top_expert"`
+}
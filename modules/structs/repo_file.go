@@ -78,6 +78,13 @@ type ApplyDiffPatchFileOptions struct {
 	Content string `json:"content"`
 }
 
+// CherryPickCommitOption options for cherry-picking or reverting a commit onto a branch
+// Note: `author` and `committer` are optional (if only one is given, it will be used for the other, otherwise the authenticated user will be used)
+// If `new_branch` isn't given and `branch` is protected, the commit is pushed to a new branch and a pull request is opened
+type CherryPickCommitOption struct {
+	FileOptions
+}
+
 // FileLinksResponse contains the links for a repo's file
 type FileLinksResponse struct {
 	Self    *string `json:"self"`
@@ -127,6 +134,18 @@ type FileResponse struct {
 	Verification *PayloadCommitVerification `json:"verification"`
 }
 
+// CherryPickResponse contains information about a cherry-picked or reverted commit
+type CherryPickResponse struct {
+	Commit       *FileCommitResponse        `json:"commit"`
+	Verification *PayloadCommitVerification `json:"verification"`
+	// branch the commit was pushed to
+	Branch string `json:"branch"`
+	// pull request opened to merge the commit into the protected target branch
+	PullRequest *PullRequest `json:"pull_request"`
+	// files conflicting with the target branch, no commit is created when there are any
+	ConflictedFiles []string `json:"conflicted_files"`
+}
+
 // FileDeleteResponse contains information about a repo's file that was deleted
 type FileDeleteResponse struct {
 	Content      interface{}                `json:"content"` // to be set to nil
@@ -0,0 +1,22 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+// GrepMatch represents a line of a file matching a grep search, with the lines around it
+type GrepMatch struct {
+	Path          string   `json:"path"`
+	LineNumber    int      `json:"line_number"`
+	Line          string   `json:"line"`
+	ContextBefore []string `json:"context_before"`
+	ContextAfter  []string `json:"context_after"`
+}
+
+// GrepResponse returns the lines of the files of a commit matching a grep search
+type GrepResponse struct {
+	SHA     string       `json:"sha"`
+	Matches []*GrepMatch `json:"matches"`
+	// whether the search reached the maximum number of results or timed out
+	Truncated bool `json:"truncated"`
+}
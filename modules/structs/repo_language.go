@@ -0,0 +1,28 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import "time"
+
+// LanguageStatsSnapshot represents the languages of a repository on one UTC day
+type LanguageStatsSnapshot struct {
+	// swagger:strfmt date
+	Date time.Time `json:"date"`
+	// the commit the statistics were computed for
+	CommitID string `json:"commit_id"`
+	// number of bytes of code written by language
+	Languages map[string]int64 `json:"languages"`
+}
+
+// LanguageTrend represents the use of a language over all the repositories of the instance on one UTC day
+type LanguageTrend struct {
+	// swagger:strfmt date
+	Date     time.Time `json:"date"`
+	Language string    `json:"language"`
+	// number of bytes of code written in the language
+	Size int64 `json:"size"`
+	// number of repositories using the language
+	Repositories int64 `json:"repositories"`
+}
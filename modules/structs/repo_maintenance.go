@@ -0,0 +1,41 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import "time"
+
+// MaintenanceReportEntry represents a file or a directory listed by a maintenance report
+type MaintenanceReportEntry struct {
+	// the path of the file or the directory, empty for the root directory
+	Path string `json:"path"`
+	// the email of the only author of a file with a single author
+	Email string `json:"email,omitempty"`
+	// swagger:strfmt date-time
+	LastCommit *time.Time `json:"last_commit"`
+}
+
+// MaintenanceReport represents the files and the directories of the default branch of a repository which need attention
+type MaintenanceReport struct {
+	Repository *RepositoryMeta `json:"repository"`
+	// the commit of the default branch the report has been computed at
+	CommitID string `json:"commit_id"`
+	// the directories and the dependency manifests which have not been changed since this time are stale
+	// swagger:strfmt date-time
+	StaleBefore time.Time `json:"stale_before"`
+	// number of files which have a single author
+	NumSingleAuthorFiles int64 `json:"num_single_author_files"`
+	// number of topmost directories which are stale
+	NumStaleDirectories int64 `json:"num_stale_directories"`
+	// number of dependency manifests which are stale
+	NumStaleManifests int64 `json:"num_stale_manifests"`
+	// the first files with a single author, in the order of their path
+	SingleAuthorFiles []*MaintenanceReportEntry `json:"single_author_files"`
+	// the first stale directories, in the order of their path
+	StaleDirectories []*MaintenanceReportEntry `json:"stale_directories"`
+	// the first stale dependency manifests, in the order of their path
+	StaleManifests []*MaintenanceReportEntry `json:"stale_manifests"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
@@ -0,0 +1,32 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+import "time"
+
+// CreateSignedURLOption options for signing a download URL
+type CreateSignedURLOption struct {
+	// required: true
+	// enum: raw,archive,release_asset
+	Type string `json:"type" binding:"Required;In(raw,archive,release_asset)"`
+	// branch, tag or commit of the raw file or the archive, defaults to the default branch
+	Ref string `json:"ref"`
+	// path of the raw file
+	Path string `json:"path"`
+	// format of the archive, defaults to zip
+	// enum: zip,tar.gz,bundle
+	Format string `json:"format" binding:"In(zip,tar.gz,bundle)"`
+	// id of the release asset
+	AssetID int64 `json:"asset_id"`
+	// number of seconds the URL is valid for, defaults to 3600, at most 604800
+	ExpiresIn int64 `json:"expires_in" binding:"Range(0,604800)"`
+}
+
+// SignedURL represents a download URL which can be used without authentication until it expires
+type SignedURL struct {
+	URL string `json:"url"`
+	// swagger:strfmt date-time
+	ExpiresAt time.Time `json:"expires_at"`
+}
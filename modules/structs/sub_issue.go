@@ -0,0 +1,20 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package structs
+
+// AddSubIssueOption options for attaching a sub-issue to an issue
+type AddSubIssueOption struct {
+	// index of the issue of the same repository to attach
+	// required:true
+	Index int64 `json:"index" binding:"Required"`
+}
+
+// SubIssueProgress the progress of the sub-issues of an issue, at all the levels of its tree
+type SubIssueProgress struct {
+	Total  int `json:"total"`
+	Closed int `json:"closed"`
+	// percentage of closed sub-issues
+	Percent int `json:"percent"`
+}
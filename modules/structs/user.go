@@ -36,6 +36,8 @@ type User struct {
 	Created time.Time `json:"created,omitempty"`
 	// Is user restricted
 	Restricted bool `json:"restricted"`
+	// Is the user a bot account of an organization
+	IsBot bool `json:"is_bot"`
 	// Is user active
 	IsActive bool `json:"active"`
 	// Is user login prohibited
@@ -78,6 +80,11 @@ type UserSettings struct {
 	// Privacy
 	HideEmail    bool `json:"hide_email"`
 	HideActivity bool `json:"hide_activity"`
+	// Review workload, a review_capacity of 0 means unlimited
+	ReviewCapacity int  `json:"review_capacity"`
+	OutOfOffice    bool `json:"out_of_office"`
+	// swagger:strfmt date-time
+	OutOfOfficeUntil *time.Time `json:"out_of_office_until"`
 }
 
 // UserSettingsOptions represents options to change user settings
@@ -93,4 +100,78 @@ type UserSettingsOptions struct {
 	// Privacy
 	HideEmail    *bool `json:"hide_email"`
 	HideActivity *bool `json:"hide_activity"`
+	// Review workload, a review_capacity of 0 means unlimited
+	ReviewCapacity *int  `json:"review_capacity" binding:"Range(0,1000)"`
+	OutOfOffice    *bool `json:"out_of_office"`
+	// swagger:strfmt date-time
+	OutOfOfficeUntil *time.Time `json:"out_of_office_until"`
+}
+
+// UserProfile represents the profile page of a user or an organization
+type UserProfile struct {
+	// the pinned repositories in their order
+	PinnedRepositories []*Repository    `json:"pinned_repositories"`
+	Sections           *ProfileSections `json:"sections"`
+}
+
+// ProfileSections represents the optional sections shown on a profile page
+type ProfileSections struct {
+	Activity bool `json:"activity"`
+	Packages bool `json:"packages"`
+	Projects bool `json:"projects"`
+}
+
+// EditUserProfileOption options to change the profile page of a user or an organization
+// swagger:model
+type EditUserProfileOption struct {
+	// names of the repositories of the owner to pin in their order, replaces the pinned repositories
+	PinnedRepositories *[]string        `json:"pinned_repositories"`
+	Sections           *ProfileSections `json:"sections"`
+}
+
+// UserStatus represents the status a user shows next to their name
+type UserStatus struct {
+	Emoji   string `json:"emoji"`
+	Message string `json:"message"`
+	// busy users are skipped by the automatic assignment of reviewers
+	Busy bool `json:"busy"`
+	// swagger:strfmt date-time
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// SetUserStatusOption options to set the status of the authenticated user
+// swagger:model
+type SetUserStatusOption struct {
+	// a unicode emoji or an emoji alias like `:palm_tree:`
+	Emoji   string `json:"emoji" binding:"MaxSize(64)"`
+	Message string `json:"message" binding:"MaxSize(80)"`
+	Busy    bool   `json:"busy"`
+	// clears the status at this time, never if not set
+	// swagger:strfmt date-time
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Badge represents a badge shown on the profile of the users who have been given it
+type Badge struct {
+	ID int64 `json:"id"`
+	// set for the achievements awarded automatically, empty for the badges defined by admins
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	ImageURL    string `json:"image_url"`
+}
+
+// CreateBadgeOption options to create a badge
+// swagger:model
+type CreateBadgeOption struct {
+	// required: true
+	Description string `json:"description" binding:"Required;MaxSize(255)"`
+	// required: true
+	ImageURL string `json:"image_url" binding:"Required;ValidUrl;MaxSize(255)"`
+}
+
+// EditBadgeOption options to edit a badge
+// swagger:model
+type EditBadgeOption struct {
+	Description *string `json:"description" binding:"MaxSize(255)"`
+	ImageURL    *string `json:"image_url" binding:"OmitEmpty;ValidUrl;MaxSize(255)"`
 }
@@ -16,6 +16,9 @@ type AccessToken struct {
 	Name           string `json:"name"`
 	Token          string `json:"sha1"`
 	TokenLastEight string `json:"token_last_eight"`
+	// what the token can do: empty for everything the user can do, `read` for the requests and git operations
+	// which don't change anything, `feed` for the RSS and Atom feeds only
+	Scope string `json:"scope"`
 }
 
 // AccessTokenList represents a list of API access token.
@@ -26,6 +29,9 @@ type AccessTokenList []*AccessToken
 // swagger:parameters userCreateToken
 type CreateAccessTokenOption struct {
 	Name string `json:"name" binding:"Required"`
+	// what the token can do: empty for everything the user can do, `read` for the requests and git operations
+	// which don't change anything, `feed` for the RSS and Atom feeds only
+	Scope string `json:"scope" binding:"In(,read,feed)"`
 }
 
 // CreateOAuth2ApplicationOptions holds options to create an oauth2 application
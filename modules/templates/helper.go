@@ -26,6 +26,7 @@ import (
 
 	activities_model "code.gitea.io/gitea/models/activities"
 	"code.gitea.io/gitea/models/avatars"
+	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -351,6 +352,7 @@ func NewFuncMap() []template.FuncMap {
 		"avatarByAction": AvatarByAction,
 		"avatarByEmail":  AvatarByEmail,
 		"repoAvatar":     RepoAvatar,
+		"UserStatus":     UserStatus,
 		"SortArrow": func(normSort, revSort, urlSort string, isDefault bool) template.HTML {
 			// if needed
 			if len(normSort) == 0 || len(urlSort) == 0 {
@@ -683,6 +685,19 @@ func AvatarByEmail(email, name string, others ...interface{}) template.HTML {
 	return template.HTML("")
 }
 
+// UserStatus returns the status of the user, or nil if the user has none
+func UserStatus(u *user_model.User) *user_model.Status {
+	if u == nil || u.ID <= 0 {
+		return nil
+	}
+	status, err := user_model.GetUserStatus(db.DefaultContext, u.ID)
+	if err != nil {
+		log.Error("GetUserStatus[%d]: %v", u.ID, err)
+		return nil
+	}
+	return status
+}
+
 // Safe render raw as HTML
 func Safe(raw string) template.HTML {
 	return template.HTML(raw)
@@ -895,6 +910,12 @@ func ActionIcon(opType activities_model.ActionType) string {
 		return "tag"
 	case activities_model.ActionPullReviewDismissed:
 		return "x"
+	case activities_model.ActionPublishAdvisory:
+		return "shield"
+	case activities_model.ActionWorkflowRunSuccess:
+		return "check-circle"
+	case activities_model.ActionWorkflowRunFailure:
+		return "x-circle"
 	default:
 		return "question"
 	}
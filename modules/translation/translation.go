@@ -130,6 +130,18 @@ type locale struct {
 	Lang, LangName string // these fields are used directly in templates: .i18n.Lang
 }
 
+// ResolveLanguage returns the language the content is translated to for someone who configured lang,
+// the default language if lang is empty or isn't supported
+func ResolveLanguage(lang string) string {
+	if lang != "" && i18n.DefaultLocales.HasLang(lang) {
+		return lang
+	}
+	if len(setting.Langs) != 0 {
+		return setting.Langs[0]
+	}
+	return "en-US"
+}
+
 // NewLocale return a locale
 func NewLocale(lang string) Locale {
 	if lock != nil {
@@ -137,6 +149,8 @@ func NewLocale(lang string) Locale {
 		defer lock.RUnlock()
 	}
 
+	lang = ResolveLanguage(lang)
+
 	langName := "unknown"
 	if l, ok := allLangMap[lang]; ok {
 		langName = l.Name
@@ -0,0 +1,37 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package translation
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/translation/i18n"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveLanguage(t *testing.T) {
+	oldLangs := setting.Langs
+	defer func() {
+		setting.Langs = oldLangs
+		i18n.ResetDefaultLocales()
+	}()
+
+	i18n.ResetDefaultLocales()
+	assert.NoError(t, i18n.DefaultLocales.AddLocaleByIni("de-DE", "Deutsch", []byte("toc = Inhaltsverzeichnis")))
+	assert.NoError(t, i18n.DefaultLocales.AddLocaleByIni("fr-FR", "Français", []byte("toc = Sommaire")))
+	i18n.DefaultLocales.SetDefaultLang("de-DE")
+	setting.Langs = []string{"de-DE", "fr-FR"}
+
+	assert.Equal(t, "fr-FR", ResolveLanguage("fr-FR"))
+	assert.Equal(t, "de-DE", ResolveLanguage(""))
+	assert.Equal(t, "de-DE", ResolveLanguage("xx-XX"))
+
+	locale := NewLocale("xx-XX")
+	assert.Equal(t, "de-DE", locale.Language())
+	assert.Equal(t, "Inhaltsverzeichnis", locale.Tr("toc"))
+	assert.Equal(t, "Sommaire", NewLocale("fr-FR").Tr("toc"))
+}
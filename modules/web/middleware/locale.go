@@ -18,7 +18,8 @@ import (
 func Locale(resp http.ResponseWriter, req *http.Request) translation.Locale {
 	// 1. Check URL arguments.
 	lang := req.URL.Query().Get("lang")
-	changeLang := lang != ""
+	// the language of a feed is part of its subscribed URL, it doesn't change the language of the session
+	changeLang := lang != "" && !IsFeedRequest(req)
 
 	// 2. Get language information from cookies.
 	if len(lang) == 0 {
@@ -49,6 +50,17 @@ func Locale(resp http.ResponseWriter, req *http.Request) translation.Locale {
 	return translation.NewLocale(lang)
 }
 
+// UserLocale returns the locale of a signed in user: the language requested by the URL arguments or the
+// cookie, else the language configured by the user rather than the one of the client, e.g. for feed readers
+func UserLocale(resp http.ResponseWriter, req *http.Request, userLang string) translation.Locale {
+	if req.URL.Query().Get("lang") == "" && userLang != "" && i18n.DefaultLocales.HasLang(userLang) {
+		if ck, _ := req.Cookie("lang"); ck == nil || !i18n.DefaultLocales.HasLang(ck.Value) {
+			return translation.NewLocale(userLang)
+		}
+	}
+	return Locale(resp, req)
+}
+
 // SetLocaleCookie convenience function to set the locale cookie consistently
 func SetLocaleCookie(resp http.ResponseWriter, lang string, expiry int) {
 	SetCookie(resp, "lang", lang, expiry,
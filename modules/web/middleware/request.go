@@ -6,6 +6,7 @@ package middleware
 
 import (
 	"net/http"
+	"regexp"
 	"strings"
 )
 
@@ -18,3 +19,13 @@ func IsAPIPath(req *http.Request) bool {
 func IsInternalPath(req *http.Request) bool {
 	return strings.HasPrefix(req.URL.Path, "/api/internal/")
 }
+
+// feedPathRe matches the RSS and Atom feeds of the users, organizations, repositories, branches, issues, security
+// advisories and saved searches, and the OPML export of the feeds of the watched repositories. The branch of a commits
+// feed is a single segment, a longer path being the history of a file whose name ends with the feed extension.
+var feedPathRe = regexp.MustCompile(`^/(?:(?:[a-zA-Z0-9_.-]+|[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+|[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+/(?:releases|tags|issues|wiki|security)|[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+/(?:issues|pulls)/[0-9]+|[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+/commits/(?:branch/)?[^/]+|user/searches/[0-9]+)\.(?:rss|atom)|user/watching\.opml)$`)
+
+// IsFeedRequest returns true if the request reads a RSS or Atom feed, whose URL is subscribed to in a feed reader
+func IsFeedRequest(req *http.Request) bool {
+	return req.Method == "GET" && feedPathRe.MatchString(req.URL.Path)
+}
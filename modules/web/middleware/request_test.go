@@ -0,0 +1,117 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package middleware
+
+import (
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsFeedRequest(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+
+		want bool
+	}{
+		{"GET", "/user2.rss", true},
+		{"GET", "/subscriptions.atom", true},
+		{"GET", "/user2/repo1.rss", true},
+		{"GET", "/user2/repo1/releases.atom", true},
+		{"GET", "/user2/repo1/tags.rss", true},
+		{"GET", "/user2/repo1/issues.atom", true},
+		{"GET", "/user2/repo1/wiki.rss", true},
+		{"GET", "/user2/repo1/security.atom", true},
+		{"GET", "/user2/repo1/issues/1.rss", true},
+		{"GET", "/user/searches/1.atom", true},
+		{"GET", "/user2/repo1/commits/master.rss", true},
+		{"GET", "/user2/repo1/commits/branch/v1.1.atom", true},
+		{"GET", "/user/watching.opml", true},
+		{"POST", "/user2/repo1.rss", false},
+		{"GET", "/user2/repo1/raw/branch/master/feed.rss", false},
+		{"GET", "/user2/repo1/src/branch/master/feed.atom", false},
+		{"GET", "/user2/repo1/issues/1", false},
+		{"GET", "/user/searches/1", false},
+		{"GET", "/user2/repo1/commits/master", false},
+		{"GET", "/user2/repo1/commits/branch/master/docs/x.atom", false},
+		{"GET", "/user2/repo1/commits/master/feed.rss", false},
+		{"GET", "/user2/repo1/settings", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, "http://localhost"+tt.path, nil)
+			if got := IsFeedRequest(req); got != tt.want {
+				t.Errorf("IsFeedRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestIsFeedRequestRoutes checks that every feed handler of the web routes is detected as a feed, e.g. to accept the
+// feed scoped tokens and keep the language of the session
+func TestIsFeedRequestRoutes(t *testing.T) {
+	// the URLs served by every feed handler
+	feedURLs := map[string][]string{
+		"ShowExploreFeedRSS":        {"/explore/activity.rss"},
+		"ShowExploreFeedAtom":       {"/explore/activity.atom"},
+		"ShowSubscriptionsFeedRSS":  {"/subscriptions.rss"},
+		"ShowSubscriptionsFeedAtom": {"/subscriptions.atom"},
+		"ShowWatchingOPML":          {"/user/watching.opml"},
+		"ShowSavedSearchFeed":       {"/user/searches/1.rss", "/user/searches/1.atom"},
+		"ShowUserFeedRSS":           {"/user2.rss", "/org3.rss"},
+		"ShowUserFeedAtom":          {"/user2.atom", "/org3.atom"},
+		"ShowUserStarsFeedRSS":      {"/user2/stars.rss"},
+		"ShowUserStarsFeedAtom":     {"/user2/stars.atom"},
+		"ShowRepoFeed":              {"/user2/repo1.rss", "/user2/repo1.atom"},
+		"ShowBranchFeed":            {"/user2/repo1/commits/master.rss", "/user2/repo1/commits/branch/v1.1.atom"},
+		"ShowIssueFeed":             {"/user2/repo1/issues/1.rss", "/user2/repo1/pulls/2.atom"},
+		"ShowRepoIssuesFeedRSS":     {"/user2/repo1/issues.rss"},
+		"ShowRepoIssuesFeedAtom":    {"/user2/repo1/issues.atom"},
+		"ShowTagsFeedRSS":           {"/user2/repo1/tags.rss"},
+		"ShowTagsFeedAtom":          {"/user2/repo1/tags.atom"},
+		"ShowReleasesFeedRSS":       {"/user2/repo1/releases.rss"},
+		"ShowReleasesFeedAtom":      {"/user2/repo1/releases.atom"},
+		"ShowWikiFeedRSS":           {"/user2/repo1/wiki.rss"},
+		"ShowWikiFeedAtom":          {"/user2/repo1/wiki.atom"},
+		"ShowAdvisoriesFeedRSS":     {"/user2/repo1/security.rss"},
+		"ShowAdvisoriesFeedAtom":    {"/user2/repo1/security.atom"},
+	}
+
+	handlerRe := regexp.MustCompile(`\bfeed\.(Show\w+)`)
+	handlers := make(map[string]bool)
+	err := filepath.WalkDir(filepath.Join("..", "..", "..", "routers", "web"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, m := range handlerRe.FindAllStringSubmatch(string(content), -1) {
+			handlers[m[1]] = true
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, handlers)
+
+	for handler := range handlers {
+		urls, ok := feedURLs[handler]
+		if !assert.True(t, ok, "the URLs of feed.%s must be added to this test", handler) {
+			continue
+		}
+		for _, url := range urls {
+			req, _ := http.NewRequest("GET", "http://localhost"+url, nil)
+			assert.True(t, IsFeedRequest(req), "feed.%s serves %s", handler, url)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package osv serves the published security advisories as an OSV database, see https://ossf.github.io/osv-schema/
+package osv
+
+import (
+	"archive/zip"
+	gocontext "context"
+	"errors"
+	"net/http"
+
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/osv"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	advisory_service "code.gitea.io/gitea/services/advisory"
+	"code.gitea.io/gitea/services/auth"
+)
+
+func apiError(ctx *context.Context, status int, obj interface{}) {
+	helper.LogAndProcessError(ctx, status, obj, func(message string) {
+		ctx.JSON(status, map[string]interface{}{
+			"code":    status,
+			"message": message,
+		})
+	})
+}
+
+// Routes returns the routes of the OSV database of the instance
+func Routes(ctx gocontext.Context) *web.Route {
+	r := web.NewRoute()
+
+	r.Use(context.PackageContexter(ctx))
+
+	if setting.Service.RequireSignInView {
+		authGroup := auth.NewGroup(&auth.OAuth2{}, &auth.Basic{})
+		r.Use(func(ctx *context.Context) {
+			ctx.Doer = authGroup.Verify(ctx.Req, ctx.Resp, ctx, ctx.Session)
+			if ctx.Doer == nil {
+				ctx.Resp.Header().Set("WWW-Authenticate", `Basic realm="Gitea OSV API"`)
+				apiError(ctx, http.StatusUnauthorized, "sign in is required")
+			}
+		})
+	}
+
+	r.Get("/v1/vulns/{id}", GetVulnerability)
+	r.Post("/v1/query", QueryVulnerabilities)
+	r.Get("/{ecosystem}/all.zip", DownloadEcosystem)
+
+	return r
+}
+
+// GetVulnerability returns a vulnerability by its OSV id
+func GetVulnerability(ctx *context.Context) {
+	vuln, err := advisory_service.GetVulnerability(ctx, ctx.Params("id"))
+	if err != nil {
+		if errors.Is(err, advisory_model.ErrAdvisoryNotExist) {
+			apiError(ctx, http.StatusNotFound, "vulnerability not found")
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, vuln)
+}
+
+// Query represents a query of the vulnerabilities affecting a package
+type Query struct {
+	Package osv.Package `json:"package"`
+	Version string      `json:"version"`
+}
+
+// QueryVulnerabilities returns the vulnerabilities affecting the version of a package
+func QueryVulnerabilities(ctx *context.Context) {
+	var query Query
+	if err := json.NewDecoder(ctx.Req.Body).Decode(&query); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if query.Package.Name == "" || query.Package.Ecosystem == "" {
+		apiError(ctx, http.StatusBadRequest, "package name and ecosystem are required")
+		return
+	}
+
+	vulns, err := advisory_service.QueryVulnerabilities(ctx, query.Package.Ecosystem, query.Package.Name, query.Version)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"vulns": vulns,
+	})
+}
+
+// DownloadEcosystem returns an archive with the vulnerabilities of an ecosystem, one file per vulnerability
+func DownloadEcosystem(ctx *context.Context) {
+	vulns, err := advisory_service.ListVulnerabilities(ctx, ctx.Params("ecosystem"))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/zip")
+	ctx.Resp.WriteHeader(http.StatusOK)
+
+	w := zip.NewWriter(ctx.Resp)
+	for _, vuln := range vulns {
+		f, err := w.Create(vuln.ID + ".json")
+		if err != nil {
+			log.Error("Create[%s]: %v", vuln.ID, err)
+			return
+		}
+		if err := json.NewEncoder(f).Encode(vuln); err != nil {
+			log.Error("Encode[%s]: %v", vuln.ID, err)
+			return
+		}
+	}
+	if err := w.Close(); err != nil {
+		log.Error("Close: %v", err)
+	}
+}
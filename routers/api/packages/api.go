@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 
+	auth_model "code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/perm"
 	"code.gitea.io/gitea/modules/context"
 	"code.gitea.io/gitea/modules/setting"
@@ -40,6 +41,18 @@ func reqPackageAccess(accessMode perm.AccessMode) func(ctx *context.Context) {
 	}
 }
 
+// checkTokenScope rejects the requests which may change a package when they are authenticated by a read-only token
+func checkTokenScope(ctx *context.Context) {
+	if ctx.Data["ApiTokenScope"] != auth_model.AccessTokenScopeRead {
+		return
+	}
+	switch ctx.Req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return
+	}
+	ctx.Error(http.StatusForbidden, "the access token is read-only")
+}
+
 func Routes(ctx gocontext.Context) *web.Route {
 	r := web.NewRoute()
 
@@ -59,6 +72,7 @@ func Routes(ctx gocontext.Context) *web.Route {
 	r.Use(func(ctx *context.Context) {
 		ctx.Doer = authGroup.Verify(ctx.Req, ctx.Resp, ctx, ctx.Session)
 	})
+	r.Use(checkTokenScope)
 
 	r.Group("/{username}", func() {
 		r.Group("/composer", func() {
@@ -302,6 +316,7 @@ func ContainerRoutes(ctx gocontext.Context) *web.Route {
 	r.Use(func(ctx *context.Context) {
 		ctx.Doer = authGroup.Verify(ctx.Req, ctx.Resp, ctx, ctx.Session)
 	})
+	r.Use(checkTokenScope)
 
 	r.Get("", container.ReqContainerAccess, container.DetermineSupport)
 	r.Get("/token", container.Authenticate)
@@ -7,6 +7,7 @@ package conan
 import (
 	"net/http"
 
+	auth_model "code.gitea.io/gitea/models/auth"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/services/auth"
@@ -21,7 +22,7 @@ func (a *Auth) Name() string {
 
 // Verify extracts the user from the Bearer token
 func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataStore, sess auth.SessionStore) *user_model.User {
-	uid, err := packages.ParseAuthorizationToken(req)
+	uid, scope, err := packages.ParseAuthorizationToken(req)
 	if err != nil {
 		log.Trace("ParseAuthorizationToken: %v", err)
 		return nil
@@ -37,5 +38,8 @@ func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataS
 		return nil
 	}
 
+	if scope != auth_model.AccessTokenScopeAll {
+		store.GetData()["ApiTokenScope"] = scope
+	}
 	return u
 }
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	auth_model "code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/db"
 	packages_model "code.gitea.io/gitea/models/packages"
 	conan_model "code.gitea.io/gitea/models/packages/conan"
@@ -118,7 +119,8 @@ func Authenticate(ctx *context.Context) {
 		return
 	}
 
-	token, err := packages_service.CreateAuthorizationToken(ctx.Doer)
+	scope, _ := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope)
+	token, err := packages_service.CreateAuthorizationToken(ctx.Doer, scope)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -14,6 +14,8 @@ import (
 	"strconv"
 	"strings"
 
+	attestation_model "code.gitea.io/gitea/models/attestation"
+	auth_model "code.gitea.io/gitea/models/auth"
 	packages_model "code.gitea.io/gitea/models/packages"
 	container_model "code.gitea.io/gitea/models/packages/container"
 	user_model "code.gitea.io/gitea/models/user"
@@ -25,6 +27,7 @@ import (
 	"code.gitea.io/gitea/modules/packages/container/oci"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/routers/api/packages/helper"
+	attestation_service "code.gitea.io/gitea/services/attestation"
 	packages_service "code.gitea.io/gitea/services/packages"
 	container_service "code.gitea.io/gitea/services/packages/container"
 )
@@ -140,7 +143,8 @@ func Authenticate(ctx *context.Context) {
 		u = user_model.NewGhostUser()
 	}
 
-	token, err := packages_service.CreateAuthorizationToken(u)
+	scope, _ := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope)
+	token, err := packages_service.CreateAuthorizationToken(u, scope)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -467,6 +471,17 @@ func UploadManifest(ctx *context.Context) {
 		return
 	}
 
+	if mci.IsTagged {
+		if err := checkManifestAttestation(ctx, mci, digestFromHashSummer(buf)); err != nil {
+			if attestation_model.IsErrAttestationRequired(err) {
+				apiErrorDefined(ctx, errDenied.WithMessage("An attestation of the manifest is required to tag it"))
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
+			return
+		}
+	}
+
 	digest, err := processManifest(mci, buf)
 	if err != nil {
 		var namedError *namedError
@@ -487,6 +502,19 @@ func UploadManifest(ctx *context.Context) {
 	})
 }
 
+// checkManifestAttestation checks that a manifest to tag has an attestation if its image is linked to a repository
+// requiring attestations, the images are usually pushed by digest, attested and then tagged
+func checkManifestAttestation(ctx *context.Context, mci *manifestCreationInfo, digest string) error {
+	p, err := packages_model.GetPackageByName(ctx, mci.Owner.ID, packages_model.TypeContainer, mci.Image)
+	if err != nil {
+		if err == packages_model.ErrPackageNotExist {
+			return nil
+		}
+		return err
+	}
+	return attestation_service.CheckPackageAttestation(ctx, p, digest)
+}
+
 func getManifestFromContext(ctx *context.Context) (*packages_model.PackageFileDescriptor, error) {
 	reference := ctx.Params("reference")
 
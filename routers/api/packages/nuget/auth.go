@@ -5,12 +5,12 @@
 package nuget
 
 import (
+	"errors"
 	"net/http"
 
 	auth_model "code.gitea.io/gitea/models/auth"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
-	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/services/auth"
 )
 
@@ -22,11 +22,12 @@ func (a *Auth) Name() string {
 
 // https://docs.microsoft.com/en-us/nuget/api/package-publish-resource#request-parameters
 func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataStore, sess auth.SessionStore) *user_model.User {
-	token, err := auth_model.GetAccessTokenBySHA(req.Header.Get("X-NuGet-ApiKey"))
+	token, err := auth.VerifyAccessToken(req, store, req.Header.Get("X-NuGet-ApiKey"))
 	if err != nil {
-		if !(auth_model.IsErrAccessTokenNotExist(err) || auth_model.IsErrAccessTokenEmpty(err)) {
+		if !(auth_model.IsErrAccessTokenNotExist(err) || auth_model.IsErrAccessTokenEmpty(err) || errors.Is(err, auth.ErrAccessTokenScope) || errors.Is(err, auth.ErrAccessTokenReadOnly)) {
 			log.Error("GetAccessTokenBySHA: %v", err)
 		}
+		auth.WriteAccessTokenError(w, err)
 		return nil
 	}
 
@@ -35,11 +36,5 @@ func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataS
 		log.Error("GetUserByID:  %v", err)
 		return nil
 	}
-
-	token.UpdatedUnix = timeutil.TimeStampNow()
-	if err := auth_model.UpdateAccessToken(token); err != nil {
-		log.Error("UpdateAccessToken:  %v", err)
-	}
-
 	return u
 }
@@ -0,0 +1,72 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+// ListRepoAttachmentsUsages list the storage used by the attachments of the repositories
+func ListRepoAttachmentsUsages(ctx *context.APIContext) {
+	// swagger:operation GET /admin/attachments/usage admin adminListRepoAttachmentsUsages
+	// ---
+	// summary: List the number and the size of the attachments of the repositories having attachments, largest first
+	// description: The orphaned attachments and the expired attachments of closed issues are deleted by the cleanup_attachments cron task.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoAttachmentsUsageList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	listOptions := utils.GetListOptions(ctx)
+	usages, count, err := repo_model.FindRepoAttachmentsUsages(ctx, listOptions)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindRepoAttachmentsUsages", err)
+		return
+	}
+
+	repoIDs := make([]int64, 0, len(usages))
+	for _, usage := range usages {
+		repoIDs = append(repoIDs, usage.RepoID)
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(repoIDs)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetRepositoriesMapByIDs", err)
+		return
+	}
+
+	apiUsages := make([]*api.RepoAttachmentsUsage, 0, len(usages))
+	for _, usage := range usages {
+		apiUsage := &api.RepoAttachmentsUsage{
+			RepoID:         usage.RepoID,
+			NumAttachments: usage.NumAttachments,
+			Size:           usage.Size,
+		}
+		if repo, ok := repos[usage.RepoID]; ok {
+			apiUsage.RepoFullName = repo.FullName()
+		}
+		apiUsages = append(apiUsages, apiUsage)
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiUsages)
+}
@@ -0,0 +1,235 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+)
+
+func getBadgeByParams(ctx *context.APIContext) *user_model.Badge {
+	b, err := user_model.GetBadgeByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, user_model.ErrBadgeNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetBadgeByID", err)
+		}
+		return nil
+	}
+	return b
+}
+
+// ListBadges api for listing all badges
+func ListBadges(ctx *context.APIContext) {
+	// swagger:operation GET /admin/badges admin adminListBadges
+	// ---
+	// summary: List all badges, including the achievements awarded automatically
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/BadgeList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	badges, err := user_model.GetBadges(ctx)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetBadges", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToBadges(badges))
+}
+
+// CreateBadge api for creating a badge
+func CreateBadge(ctx *context.APIContext) {
+	// swagger:operation POST /admin/badges admin adminCreateBadge
+	// ---
+	// summary: Create a badge
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateBadgeOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Badge"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateBadgeOption)
+	b := &user_model.Badge{
+		Description: form.Description,
+		ImageURL:    form.ImageURL,
+	}
+	if err := user_model.CreateBadge(ctx, b); err != nil {
+		ctx.Error(http.StatusInternalServerError, "CreateBadge", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToBadge(b))
+}
+
+// EditBadge api for editing a badge
+func EditBadge(ctx *context.APIContext) {
+	// swagger:operation PATCH /admin/badges/{id} admin adminEditBadge
+	// ---
+	// summary: Edit a badge
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the badge to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditBadgeOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Badge"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditBadgeOption)
+	b := getBadgeByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if form.Description != nil {
+		b.Description = *form.Description
+	}
+	if form.ImageURL != nil {
+		b.ImageURL = *form.ImageURL
+	}
+	if err := user_model.UpdateBadge(ctx, b); err != nil {
+		ctx.Error(http.StatusInternalServerError, "UpdateBadge", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToBadge(b))
+}
+
+// DeleteBadge api for deleting a badge
+func DeleteBadge(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/badges/{id} admin adminDeleteBadge
+	// ---
+	// summary: Delete a badge and take it away from all users
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the badge to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	b := getBadgeByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := user_model.DeleteBadge(ctx, b); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteBadge", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// AddUserBadge api for giving a badge to a user
+func AddUserBadge(ctx *context.APIContext) {
+	// swagger:operation PUT /admin/users/{username}/badges/{id} admin adminAddUserBadge
+	// ---
+	// summary: Give a badge to a user
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of user
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the badge
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	b := getBadgeByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := user_model.AddUserBadge(ctx, ctx.ContextUser, b); err != nil {
+		ctx.Error(http.StatusInternalServerError, "AddUserBadge", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RemoveUserBadge api for taking a badge away from a user
+func RemoveUserBadge(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/users/{username}/badges/{id} admin adminRemoveUserBadge
+	// ---
+	// summary: Take a badge away from a user
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of user
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the badge
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	b := getBadgeByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := user_model.RemoveUserBadge(ctx, ctx.ContextUser, b); err != nil {
+		ctx.Error(http.StatusInternalServerError, "RemoveUserBadge", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
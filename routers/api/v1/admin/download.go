@@ -0,0 +1,131 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/context"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+// ListRepoDownloadUsages list the downloads of the repositories
+func ListRepoDownloadUsages(ctx *context.APIContext) {
+	// swagger:operation GET /admin/downloads/usage admin adminListRepoDownloadUsages
+	// ---
+	// summary: List the downloads of the attachments and of the raw files of the repositories and the bytes they served, largest first
+	// description: The downloads are recorded if the ENABLE_ACCOUNTING setting of the repository.download section is enabled.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoDownloadUsageList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	listOptions := utils.GetListOptions(ctx)
+	usages, count, err := repo_model.FindDownloadUsages(ctx, listOptions)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindDownloadUsages", err)
+		return
+	}
+
+	repoIDs := make([]int64, 0, len(usages))
+	for _, usage := range usages {
+		repoIDs = append(repoIDs, usage.RepoID)
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(repoIDs)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetRepositoriesMapByIDs", err)
+		return
+	}
+
+	apiUsages := make([]*api.RepoDownloadUsage, 0, len(usages))
+	for _, usage := range usages {
+		apiUsage := &api.RepoDownloadUsage{
+			RepoID:    usage.RepoID,
+			Downloads: usage.Downloads,
+			Size:      usage.Size,
+		}
+		if repo, ok := repos[usage.RepoID]; ok {
+			apiUsage.RepoFullName = repo.FullName()
+		}
+		apiUsages = append(apiUsages, apiUsage)
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiUsages)
+}
+
+// ListOwnerDownloadUsages list the downloads of the repositories of each owner
+func ListOwnerDownloadUsages(ctx *context.APIContext) {
+	// swagger:operation GET /admin/downloads/usage/owners admin adminListOwnerDownloadUsages
+	// ---
+	// summary: List the downloads of the attachments and of the raw files of the repositories of each owner and the bytes they served, largest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OwnerDownloadUsageList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	listOptions := utils.GetListOptions(ctx)
+	usages, count, err := repo_model.FindOwnerDownloadUsages(ctx, listOptions)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindOwnerDownloadUsages", err)
+		return
+	}
+
+	ownerIDs := make([]int64, 0, len(usages))
+	for _, usage := range usages {
+		ownerIDs = append(ownerIDs, usage.OwnerID)
+	}
+	owners, err := user_model.GetUsersByIDs(ownerIDs)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetUsersByIDs", err)
+		return
+	}
+	ownerNames := make(map[int64]string, len(owners))
+	for _, owner := range owners {
+		ownerNames[owner.ID] = owner.Name
+	}
+
+	apiUsages := make([]*api.OwnerDownloadUsage, 0, len(usages))
+	for _, usage := range usages {
+		apiUsages = append(apiUsages, &api.OwnerDownloadUsage{
+			OwnerID:   usage.OwnerID,
+			OwnerName: ownerNames[usage.OwnerID],
+			Downloads: usage.Downloads,
+			Size:      usage.Size,
+		})
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiUsages)
+}
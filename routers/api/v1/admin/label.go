@@ -0,0 +1,77 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"net/http"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	label_service "code.gitea.io/gitea/services/label"
+)
+
+// SyncLabelTemplate synchronizes the labels of repositories with a label template
+func SyncLabelTemplate(ctx *context.APIContext) {
+	// swagger:operation POST /admin/labels/sync admin adminSyncLabelTemplate
+	// ---
+	// summary: Synchronize the labels of repositories with a label template
+	// description: Missing labels are created and the labels recolored or described differently are updated,
+	//   the other labels of the repositories are left untouched.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SyncLabelTemplateOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/LabelSyncResultList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	form := web.GetForm(ctx).(*api.SyncLabelTemplateOption)
+
+	set, err := label_service.GetTemplateLabelSet(form.Template)
+	if err != nil {
+		if repo_module.IsErrIssueLabelTemplateLoad(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "GetTemplateLabelSet", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetTemplateLabelSet", err)
+		}
+		return
+	}
+
+	repos := make([]*repo_model.Repository, 0, len(form.Repos))
+	for _, fullName := range form.Repos {
+		ownerName, repoName, ok := strings.Cut(fullName, "/")
+		if !ok {
+			ctx.Error(http.StatusUnprocessableEntity, "", "repositories must be given by their full name: "+fullName)
+			return
+		}
+		repo, err := repo_model.GetRepositoryByOwnerAndNameCtx(ctx, ownerName, repoName)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.NotFound()
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetRepositoryByOwnerAndName", err)
+			}
+			return
+		}
+		repos = append(repos, repo)
+	}
+
+	utils.SyncLabels(ctx, repos, set, form.DryRun)
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// defaultLanguageTrendDays is the number of days of trends returned when the period isn't given
+const defaultLanguageTrendDays = 90
+
+// ListLanguageTrends list the daily use of the languages over all the repositories
+func ListLanguageTrends(ctx *context.APIContext) {
+	// swagger:operation GET /admin/languages/trends admin adminListLanguageTrends
+	// ---
+	// summary: List the daily size and number of repositories of the languages over all the repositories
+	// description: The trends are recorded once a day by the update_language_trends cron task.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: since
+	//   in: query
+	//   description: only days starting at or after this time, defaults to 90 days before `before`
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only days starting before this time, defaults to now
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/LanguageTrendList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	before, since, err := context.GetQueryBeforeSince(ctx.Context)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
+		return
+	}
+	if before == 0 {
+		before = int64(timeutil.TimeStampNow())
+	}
+	if since == 0 {
+		since = before - defaultLanguageTrendDays*repo_model.LanguageStatsDay
+	}
+	if since >= before {
+		ctx.Error(http.StatusUnprocessableEntity, "", "since must be before before")
+		return
+	}
+
+	trends, err := repo_model.FindLanguageTrends(ctx, timeutil.TimeStamp(since), timeutil.TimeStamp(before))
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindLanguageTrends", err)
+		return
+	}
+
+	apiTrends := make([]*api.LanguageTrend, 0, len(trends))
+	for _, trend := range trends {
+		apiTrends = append(apiTrends, convert.ToLanguageTrend(trend))
+	}
+	ctx.SetTotalCountHeader(int64(len(trends)))
+	ctx.JSON(http.StatusOK, apiTrends)
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+// ListModerationReports lists the reports about the content of all the repositories
+func ListModerationReports(ctx *context.APIContext) {
+	// swagger:operation GET /admin/moderation/reports admin adminListModerationReports
+	// ---
+	// summary: List the reports about the issues, pull requests and comments of all the repositories, oldest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: status
+	//   in: query
+	//   description: only the reports with this status
+	//   type: string
+	//   enum: [open, resolved, dismissed]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ModerationReportList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	utils.ListModerationReports(ctx, &moderation_model.FindReportsOptions{})
+}
+
+// ListModerationLog lists the moderation audit trail of the instance
+func ListModerationLog(ctx *context.APIContext) {
+	// swagger:operation GET /admin/moderation/log admin adminListModerationLog
+	// ---
+	// summary: List the actions of the moderators in all the repositories, newest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ModerationLog"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	utils.ListModerationLog(ctx, &moderation_model.FindLogsOptions{})
+}
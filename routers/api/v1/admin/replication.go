@@ -0,0 +1,125 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+// GetReplicationStatus returns the state of the replication to the standby instance
+func GetReplicationStatus(ctx *context.APIContext) {
+	// swagger:operation GET /admin/replication admin adminGetReplicationStatus
+	// ---
+	// summary: Get the state of the replication of the repositories to the standby instance
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ReplicationStatus"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	status := &api.ReplicationStatus{
+		Enabled: setting.Replication.Enabled,
+		Standby: setting.Replication.Standby,
+	}
+	if setting.Replication.Enabled {
+		stats, err := repo_model.GetReplicationStats(ctx)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetReplicationStats", err)
+			return
+		}
+		status.StandbyURL = setting.Replication.StandbyURL
+		status.PendingRepos = stats.PendingRepos
+		status.FailingRepos = stats.FailingRepos
+		status.NotReplicatedRepos = stats.NotReplicatedRepos
+		status.LagSeconds = stats.Lag(timeutil.TimeStampNow())
+		status.Lagging = time.Duration(status.LagSeconds)*time.Second > setting.Replication.MaxLag
+	}
+	ctx.JSON(http.StatusOK, status)
+}
+
+// ListRepoReplications lists the replications of the repositories, the most lagging first
+func ListRepoReplications(ctx *context.APIContext) {
+	// swagger:operation GET /admin/replication/repos admin adminListRepoReplications
+	// ---
+	// summary: List the replications of the repositories to the standby instance, the most lagging first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: state
+	//   in: query
+	//   description: only list the replications which are pending or whose last run failed
+	//   type: string
+	//   enum: [pending, failing]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoReplicationList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	listOptions := utils.GetListOptions(ctx)
+	replications, count, err := repo_model.FindRepoReplications(ctx, repo_model.FindRepoReplicationsOptions{
+		ListOptions: listOptions,
+		OnlyPending: ctx.FormString("state") == "pending",
+		OnlyFailing: ctx.FormString("state") == "failing",
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindRepoReplications", err)
+		return
+	}
+
+	repoIDs := make([]int64, 0, len(replications))
+	for _, r := range replications {
+		repoIDs = append(repoIDs, r.RepoID)
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(repoIDs)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetRepositoriesMapByIDs", err)
+		return
+	}
+
+	now := timeutil.TimeStampNow()
+	apiReplications := make([]*api.RepoReplication, 0, len(replications))
+	for _, r := range replications {
+		apiReplication := &api.RepoReplication{
+			RepoID:     r.RepoID,
+			LagSeconds: r.Lag(now),
+			LastError:  r.LastError,
+		}
+		if repo, ok := repos[r.RepoID]; ok {
+			apiReplication.RepoFullName = repo.FullName()
+		}
+		if r.PendingUnix != 0 {
+			pendingSince := r.PendingUnix.AsTime()
+			apiReplication.PendingSince = &pendingSince
+		}
+		if r.SyncedUnix != 0 {
+			syncedAt := r.SyncedUnix.AsTime()
+			apiReplication.SyncedAt = &syncedAt
+		}
+		apiReplications = append(apiReplications, apiReplication)
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiReplications)
+}
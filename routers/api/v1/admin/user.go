@@ -28,6 +28,7 @@ import (
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
 	"code.gitea.io/gitea/services/mailer"
+	org_service "code.gitea.io/gitea/services/org"
 	user_service "code.gitea.io/gitea/services/user"
 )
 
@@ -137,6 +138,10 @@ func CreateUser(ctx *context.APIContext) {
 	}
 	log.Trace("Account created by admin (%s): %s", ctx.Doer.Name, u.Name)
 
+	if err := org_service.JoinVerifiedDomainOrgs(ctx, u); err != nil {
+		log.Error("JoinVerifiedDomainOrgs: %v", err)
+	}
+
 	// Send email notification.
 	if form.SendNotify {
 		mailer.SendRegisterNotifyMail(u)
@@ -331,6 +336,63 @@ func DeleteUser(ctx *context.APIContext) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// MergeUser api for merging a duplicate user into another account
+func MergeUser(ctx *context.APIContext) {
+	// swagger:operation POST /admin/users/{username}/merge admin adminMergeUser
+	// ---
+	// summary: Merge a user into another account and delete it
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of user to merge
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/MergeUserOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/User"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.MergeUserOption)
+	target, err := user_model.GetUserByName(ctx, form.Target)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+		}
+		return
+	}
+
+	if err := user_service.MergeUser(ctx, ctx.Doer, ctx.ContextUser, target); err != nil {
+		if errors.Is(err, user_service.ErrMergeSameUser) ||
+			errors.Is(err, user_service.ErrMergeOrganization) ||
+			models.IsErrUserOwnRepos(err) ||
+			models.IsErrUserHasOrgs(err) ||
+			models.IsErrUserOwnPackages(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "MergeUser", err)
+		}
+		return
+	}
+	log.Trace("Account %s merged into %s by admin(%s)", ctx.ContextUser.Name, target.Name, ctx.Doer.Name)
+
+	ctx.JSON(http.StatusOK, convert.ToUser(target, ctx.Doer))
+}
+
 // CreatePublicKey api for creating a public key to a user
 func CreatePublicKey(ctx *context.APIContext) {
 	// swagger:operation POST /admin/users/{username}/keys admin adminCreatePublicKey
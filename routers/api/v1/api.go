@@ -71,6 +71,8 @@ import (
 	"reflect"
 	"strings"
 
+	auth_model "code.gitea.io/gitea/models/auth"
+	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
 	"code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
@@ -84,6 +86,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/activitypub"
 	"code.gitea.io/gitea/routers/api/v1/admin"
+	"code.gitea.io/gitea/routers/api/v1/explore"
 	"code.gitea.io/gitea/routers/api/v1/misc"
 	"code.gitea.io/gitea/routers/api/v1/notify"
 	"code.gitea.io/gitea/routers/api/v1/org"
@@ -224,6 +227,20 @@ func reqToken() func(ctx *context.APIContext) {
 	}
 }
 
+// checkTokenScope rejects the requests which may change something when they are authenticated by a read-only token
+func checkTokenScope() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if ctx.Data["ApiTokenScope"] != auth_model.AccessTokenScopeRead {
+			return
+		}
+		switch ctx.Req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+		ctx.Error(http.StatusForbidden, "checkTokenScope", "the access token is read-only")
+	}
+}
+
 func reqExploreSignIn() func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
 		if setting.Service.Explore.RequireSigninView && !ctx.IsSigned {
@@ -505,6 +522,92 @@ func mustEnableIssues(ctx *context.APIContext) {
 	}
 }
 
+// mustSeeIssue hides the confidential issues from the users who can't see them, it leaves the issues which don't
+// exist to the handlers
+func mustSeeIssue(ctx *context.APIContext) {
+	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if !issues_model.IsErrIssueNotExist(err) {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+	if hidden, err := issue.IsHiddenFrom(ctx, ctx.Doer); err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsHiddenFrom", err)
+	} else if hidden {
+		ctx.NotFound()
+	}
+}
+
+// mustSeeIssueComment hides the comments of the confidential issues from the users who can't see them
+func mustSeeIssueComment(ctx *context.APIContext) {
+	comment, err := issues_model.GetCommentByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if !issues_model.IsErrCommentNotExist(err) {
+			ctx.Error(http.StatusInternalServerError, "GetCommentByID", err)
+		}
+		return
+	}
+	if err := comment.LoadIssueCtx(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadIssue", err)
+		return
+	}
+	if hidden, err := comment.Issue.IsHiddenFrom(ctx, ctx.Doer); err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsHiddenFrom", err)
+	} else if hidden {
+		ctx.NotFound()
+	}
+}
+
+// mustNotBeIssueMirror forbids opening or changing issues in bulk in a repository whose issues are mirrored from an
+// external issue tracker
+func mustNotBeIssueMirror(ctx *context.APIContext) {
+	if isMirror, err := issues_model.IsIssueMirror(ctx, ctx.Repo.Repository.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsIssueMirror", err)
+	} else if isMirror {
+		ctx.Error(http.StatusForbidden, "", "the issues of this repository are mirrored from an external issue tracker")
+	}
+}
+
+// mustNotBeMirroredIssue forbids changing the issues mirrored from an external issue tracker, the requests which only
+// read them, the pull requests and the issues which don't exist are left to the handlers
+func mustNotBeMirroredIssue(ctx *context.APIContext) {
+	if ctx.Req.Method == http.MethodGet {
+		return
+	}
+	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if !issues_model.IsErrIssueNotExist(err) {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+	if !issue.IsPull {
+		mustNotBeIssueMirror(ctx)
+	}
+}
+
+// mustNotBeMirroredIssueComment forbids changing the comments of the issues mirrored from an external issue tracker
+func mustNotBeMirroredIssueComment(ctx *context.APIContext) {
+	if ctx.Req.Method == http.MethodGet {
+		return
+	}
+	comment, err := issues_model.GetCommentByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if !issues_model.IsErrCommentNotExist(err) {
+			ctx.Error(http.StatusInternalServerError, "GetCommentByID", err)
+		}
+		return
+	}
+	if err := comment.LoadIssueCtx(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadIssue", err)
+		return
+	}
+	if !comment.Issue.IsPull && comment.Issue.RepoID == ctx.Repo.Repository.ID {
+		mustNotBeIssueMirror(ctx)
+	}
+}
+
 func mustAllowPulls(ctx *context.APIContext) {
 	if !(ctx.Repo.Repository.CanEnablePulls() && ctx.Repo.CanRead(unit.TypePullRequests)) {
 		if ctx.Repo.Repository.CanEnablePulls() && log.IsTrace() {
@@ -634,6 +737,7 @@ func Routes(ctx gocontext.Context) *web.Route {
 	m.Use(context.ToggleAPI(&context.ToggleOptions{
 		SignInRequired: setting.Service.RequireSignInView,
 	}))
+	m.Use(checkTokenScope())
 
 	m.Group("", func() {
 		// Miscellaneous
@@ -643,6 +747,7 @@ func Routes(ctx gocontext.Context) *web.Route {
 			})
 		}
 		m.Get("/version", misc.Version)
+		m.Get("/meta", misc.Meta)
 		if setting.Federation.Enabled {
 			m.Get("/nodeinfo", misc.NodeInfo)
 			m.Group("/activitypub", func() {
@@ -655,6 +760,11 @@ func Routes(ctx gocontext.Context) *web.Route {
 		m.Get("/signing-key.gpg", misc.SigningKey)
 		m.Post("/markdown", bind(api.MarkdownOption{}), misc.Markdown)
 		m.Post("/markdown/raw", misc.MarkdownRaw)
+		m.Post("/highlight", bind(api.HighlightOption{}), misc.Highlight)
+		m.Get("/gitignore/templates", misc.ListGitignoreTemplates)
+		m.Get("/gitignore/templates/{name}", misc.GetGitignoreTemplate)
+		m.Get("/licenses", misc.ListLicenseTemplates)
+		m.Get("/licenses/{name}", misc.GetLicenseTemplate)
 		m.Group("/settings", func() {
 			m.Get("/ui", settings.GetGeneralUISettings)
 			m.Get("/api", settings.GetGeneralAPISettings)
@@ -685,6 +795,9 @@ func Routes(ctx gocontext.Context) *web.Route {
 				}
 
 				m.Get("/repos", reqExploreSignIn(), user.ListUserRepos)
+				m.Get("/profile", reqExploreSignIn(), user.GetProfile)
+				m.Get("/status", reqExploreSignIn(), user.GetStatus)
+				m.Get("/badges", reqExploreSignIn(), user.ListBadges)
 				m.Group("/tokens", func() {
 					m.Combo("").Get(user.ListAccessTokens).
 						Post(bind(api.CreateAccessTokenOption{}), user.CreateAccessToken)
@@ -716,11 +829,27 @@ func Routes(ctx gocontext.Context) *web.Route {
 				m.Get("", user.GetUserSettings)
 				m.Patch("", bind(api.UserSettingsOptions{}), user.UpdateUserSettings)
 			}, reqToken())
+			m.Put("/profile", reqToken(), bind(api.EditUserProfileOption{}), user.EditMyProfile)
+			m.Combo("/status", reqToken()).Get(user.GetMyStatus).
+				Put(bind(api.SetUserStatusOption{}), user.SetMyStatus).
+				Delete(user.DeleteMyStatus)
 			m.Combo("/emails").Get(user.ListEmails).
 				Post(bind(api.CreateEmailOption{}), user.AddEmail).
 				Delete(bind(api.DeleteEmailOption{}), user.DeleteEmail)
 
 			m.Get("/followers", user.ListMyFollowers)
+			m.Group("/blocks", func() {
+				m.Get("", user.ListMyBlockedUsers)
+				m.Group("/{username}", func() {
+					m.Get("", user.CheckMyBlockedUser)
+					m.Put("", user.BlockUserForMe)
+					m.Delete("", user.UnblockUserForMe)
+				})
+			})
+			m.Combo("/interaction-limits").Get(user.GetMyInteractionLimit).
+				Put(bind(api.SetInteractionLimitOption{}), user.SetMyInteractionLimit).
+				Delete(user.RemoveMyInteractionLimit)
+
 			m.Group("/following", func() {
 				m.Get("", user.ListMyFollowing)
 				m.Group("/{username}", func() {
@@ -772,6 +901,7 @@ func Routes(ctx gocontext.Context) *web.Route {
 			m.Get("/stopwatches", repo.GetStopwatches)
 
 			m.Get("/subscriptions", user.GetMyWatchedRepos)
+			m.Get("/subscriptions/releases", user.ListMySubscribedReleases)
 
 			m.Get("/teams", org.ListUserTeams)
 		}, reqToken())
@@ -826,6 +956,14 @@ func Routes(ctx gocontext.Context) *web.Route {
 						m.Get("/permission", repo.GetRepoPermissions)
 					}, reqToken())
 				}, reqToken())
+				m.Combo("/interaction-limits").Get(reqAnyRepoReader(), repo.GetInteractionLimit).
+					Put(reqToken(), reqAdmin(), bind(api.SetInteractionLimitOption{}), repo.SetInteractionLimit).
+					Delete(reqToken(), reqAdmin(), repo.RemoveInteractionLimit)
+				m.Group("/moderation", func() {
+					m.Get("/reports", repo.ListModerationReports)
+					m.Post("/reports/{id}/actions", bind(api.ModerationActionOption{}), repo.HandleModerationReport)
+					m.Get("/log", repo.ListModerationLog)
+				}, reqToken(), reqAdmin())
 				m.Get("/assignees", reqToken(), reqAnyRepoReader(), repo.GetAssignees)
 				m.Get("/reviewers", reqToken(), reqAnyRepoReader(), repo.GetReviewers)
 				m.Group("/teams", func() {
@@ -836,9 +974,17 @@ func Routes(ctx gocontext.Context) *web.Route {
 				}, reqToken())
 				m.Get("/raw/*", context.ReferencesGitRepo(), context.RepoRefForAPI, reqRepoReader(unit.TypeCode), repo.GetRawFile)
 				m.Get("/media/*", context.ReferencesGitRepo(), context.RepoRefForAPI, reqRepoReader(unit.TypeCode), repo.GetRawFileOrLFS)
+				m.Get("/render/*", context.ReferencesGitRepo(), context.RepoRefForAPI, reqRepoReader(unit.TypeCode), repo.RenderFile)
 				m.Get("/archive/*", reqRepoReader(unit.TypeCode), repo.GetArchive)
+				m.Post("/signed_urls", reqToken(), context.ReferencesGitRepo(), bind(api.CreateSignedURLOption{}), repo.CreateSignedURL)
 				m.Combo("/forks").Get(repo.ListForks).
 					Post(reqToken(), reqRepoReader(unit.TypeCode), bind(api.CreateForkOption{}), repo.CreateFork)
+				m.Group("/forks/network", func() {
+					m.Get("", repo.ListForkNetwork)
+					m.Get("/commits/{sha}", repo.ListForksContainingCommit)
+				}, reqRepoReader(unit.TypeCode))
+				m.Post("/sync_fork", reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, bind(api.SyncForkOption{}), repo.SyncFork)
+				m.Post("/default_branch/rename", reqToken(), reqAdmin(), mustNotBeArchived, bind(api.RenameDefaultBranchOption{}), repo.RenameDefaultBranch)
 				m.Group("/branches", func() {
 					m.Get("", repo.ListBranches)
 					m.Get("/*", repo.GetBranch)
@@ -881,7 +1027,12 @@ func Routes(ctx gocontext.Context) *web.Route {
 				}, mustEnableWiki)
 				m.Group("/issues", func() {
 					m.Combo("").Get(repo.ListIssues).
-						Post(reqToken(), mustNotBeArchived, bind(api.CreateIssueOption{}), repo.CreateIssue)
+						Post(reqToken(), mustNotBeArchived, mustNotBeIssueMirror, bind(api.CreateIssueOption{}), repo.CreateIssue).
+						Patch(reqToken(), mustNotBeArchived, mustNotBeIssueMirror, bind(api.EditIssuesOption{}), repo.EditIssues)
+					m.Get("/export", repo.ExportIssues)
+					m.Post("/import", reqToken(), mustNotBeArchived, mustEnableIssues, mustNotBeIssueMirror, reqRepoWriter(unit.TypeIssues), bind(api.ImportIssuesOption{}), repo.ImportIssues)
+					m.Get("/pinned", repo.ListPinnedIssues)
+					m.Get("/similar", mustEnableIssues, repo.ListSimilarIssues)
 					m.Group("/comments", func() {
 						m.Get("", repo.ListRepoIssueComments)
 						m.Group("/{id}", func() {
@@ -893,7 +1044,9 @@ func Routes(ctx gocontext.Context) *web.Route {
 								Get(repo.GetIssueCommentReactions).
 								Post(reqToken(), bind(api.EditReactionOption{}), repo.PostIssueCommentReaction).
 								Delete(reqToken(), bind(api.EditReactionOption{}), repo.DeleteIssueCommentReaction)
-						})
+							m.Post("/reports", reqToken(), bind(api.CreateModerationReportOption{}), repo.CreateIssueCommentReport)
+							m.Get("/content_history", repo.ListIssueCommentContentHistory)
+						}, mustSeeIssueComment, mustNotBeMirroredIssueComment)
 					})
 					m.Group("/{index}", func() {
 						m.Combo("").Get(repo.GetIssue).
@@ -906,6 +1059,13 @@ func Routes(ctx gocontext.Context) *web.Route {
 								Delete(repo.DeleteIssueCommentDeprecated)
 						})
 						m.Get("/timeline", repo.ListIssueCommentsAndTimeline)
+						m.Get("/content_history", repo.ListIssueContentHistory)
+						m.Group("/fields", func() {
+							m.Get("", repo.ListIssueFieldValues)
+							m.Combo("/{id}", reqToken(), mustNotBeArchived).
+								Put(bind(api.SetIssueFieldValueOption{}), repo.SetIssueFieldValue).
+								Delete(repo.DeleteIssueFieldValue)
+						})
 						m.Group("/labels", func() {
 							m.Combo("").Get(repo.ListIssueLabels).
 								Post(reqToken(), bind(api.IssueLabelsOption{}), repo.AddIssueLabels).
@@ -921,6 +1081,18 @@ func Routes(ctx gocontext.Context) *web.Route {
 							m.Delete("/{id}", repo.DeleteTime)
 						}, reqToken())
 						m.Combo("/deadline").Post(reqToken(), bind(api.EditDeadlineOption{}), repo.UpdateIssueDeadline)
+						m.Group("/sub_issues", func() {
+							m.Combo("").Get(repo.ListSubIssues).
+								Post(reqToken(), mustNotBeArchived, bind(api.AddSubIssueOption{}), repo.AddSubIssue)
+							m.Get("/progress", repo.GetSubIssueProgress)
+							m.Delete("/{sub_index}", reqToken(), mustNotBeArchived, repo.RemoveSubIssue)
+						})
+						m.Get("/parent", repo.GetParentIssue)
+						m.Group("/pin", func() {
+							m.Combo("").Post(bind(api.PinIssueOption{}), repo.PinIssue).
+								Delete(repo.UnpinIssue)
+							m.Patch("/{position}", repo.MovePinnedIssue)
+						}, reqToken(), mustNotBeArchived)
 						m.Group("/stopwatch", func() {
 							m.Post("/start", reqToken(), repo.StartIssueStopwatch)
 							m.Post("/stop", reqToken(), repo.StopIssueStopwatch)
@@ -936,8 +1108,33 @@ func Routes(ctx gocontext.Context) *web.Route {
 							Get(repo.GetIssueReactions).
 							Post(reqToken(), bind(api.EditReactionOption{}), repo.PostIssueReaction).
 							Delete(reqToken(), bind(api.EditReactionOption{}), repo.DeleteIssueReaction)
-					})
+						m.Combo("/votes").
+							Get(repo.ListIssueVoters).
+							Post(reqToken(), mustNotBeArchived, repo.VoteForIssue).
+							Delete(reqToken(), mustNotBeArchived, repo.RemoveIssueVote)
+						m.Post("/reports", reqToken(), bind(api.CreateModerationReportOption{}), repo.CreateIssueReport)
+					}, mustSeeIssue, mustNotBeMirroredIssue)
 				}, mustEnableIssuesOrPulls)
+				m.Group("/issue_mirror", func() {
+					m.Combo("").Get(repo.GetIssueMirror).
+						Put(mustNotBeArchived, bind(api.SetIssueMirrorOption{}), repo.SetIssueMirror).
+						Delete(repo.DeleteIssueMirror)
+					m.Post("/sync", mustNotBeArchived, repo.SyncIssueMirror)
+				}, reqToken(), reqAdmin(), mustEnableIssues)
+				m.Group("/issue_fields", func() {
+					m.Combo("").Get(repo.ListIssueFields).
+						Post(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.CreateIssueFieldOption{}), repo.CreateIssueField)
+					m.Combo("/{id}").Get(repo.GetIssueField).
+						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditIssueFieldOption{}), repo.EditIssueField).
+						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteIssueField)
+				})
+				m.Group("/issue_filters", func() {
+					m.Combo("").Get(repo.ListIssueFilters).
+						Post(bind(api.CreateIssueFilterOption{}), repo.CreateIssueFilter)
+					m.Combo("/{id}").Get(repo.GetIssueFilter).
+						Patch(bind(api.EditIssueFilterOption{}), repo.EditIssueFilter).
+						Delete(repo.DeleteIssueFilter)
+				}, reqToken(), mustEnableIssuesOrPulls)
 				m.Group("/labels", func() {
 					m.Combo("").Get(repo.ListLabels).
 						Post(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.CreateLabelOption{}), repo.CreateLabel)
@@ -954,6 +1151,13 @@ func Routes(ctx gocontext.Context) *web.Route {
 						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditMilestoneOption{}), repo.EditMilestone).
 						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteMilestone)
 				})
+				m.Group("/components", func() {
+					m.Combo("").Get(repo.ListComponents).
+						Post(reqToken(), reqAdmin(), bind(api.CreateComponentOption{}), repo.CreateComponent)
+					m.Combo("/{id}").Get(repo.GetComponent).
+						Patch(reqToken(), reqAdmin(), bind(api.EditComponentOption{}), repo.EditComponent).
+						Delete(reqToken(), reqAdmin(), repo.DeleteComponent)
+				})
 				m.Get("/stargazers", repo.ListStargazers)
 				m.Get("/subscribers", repo.ListSubscribers)
 				m.Group("/subscription", func() {
@@ -976,6 +1180,20 @@ func Routes(ctx gocontext.Context) *web.Route {
 								Delete(reqToken(), reqRepoWriter(unit.TypeReleases), repo.DeleteReleaseAttachment)
 						})
 					})
+					m.Group("/builds", func() {
+						m.Combo("").Get(repo.ListReleaseBuilds).
+							Post(context.ReferencesGitRepo(), bind(api.CreateReleaseBuildOption{}), repo.ClaimReleaseBuild)
+						m.Group("/{id}", func() {
+							m.Combo("").Get(repo.GetReleaseBuild).
+								Patch(bind(api.EditReleaseBuildOption{}), repo.EditReleaseBuild)
+							m.Combo("/log").Get(repo.GetReleaseBuildLog).
+								Post(bind(api.AppendReleaseBuildLogOption{}), repo.AppendReleaseBuildLog)
+						})
+					}, reqToken(), reqRepoWriter(unit.TypeReleases))
+					m.Group("/draft", func() {
+						m.Get("", repo.GetReleaseDraft)
+						m.Post("/publish", context.ReferencesGitRepo(), bind(api.PublishReleaseDraftOption{}), repo.PublishReleaseDraft)
+					}, reqToken(), reqRepoWriter(unit.TypeReleases))
 					m.Group("/tags", func() {
 						m.Combo("/{tag}").
 							Get(repo.GetReleaseByTag).
@@ -1002,6 +1220,7 @@ func Routes(ctx gocontext.Context) *web.Route {
 						m.Get(".{diffType:diff|patch}", repo.DownloadPullDiffOrPatch)
 						m.Post("/update", reqToken(), repo.UpdatePullRequest)
 						m.Get("/commits", repo.GetPullRequestCommits)
+						m.Get("/merge/preview", repo.GetPullRequestMergePreview)
 						m.Combo("/merge").Get(repo.IsPullRequestMerged).
 							Post(reqToken(), mustNotBeArchived, bind(forms.MergePullRequestForm{}), repo.MergePullRequest).
 							Delete(reqToken(), mustNotBeArchived, repo.CancelScheduledAutoMerge)
@@ -1023,12 +1242,43 @@ func Routes(ctx gocontext.Context) *web.Route {
 						m.Combo("/requested_reviewers").
 							Delete(reqToken(), bind(api.PullReviewRequestOptions{}), repo.DeleteReviewRequests).
 							Post(reqToken(), bind(api.PullReviewRequestOptions{}), repo.CreateReviewRequests)
+						m.Get("/coverage", repo.GetPullRequestCoverage)
+						m.Get("/suggested_reviewers", repo.ListSuggestedReviewers)
 					})
 				}, mustAllowPulls, reqRepoReader(unit.TypeCode), context.ReferencesGitRepo())
 				m.Group("/statuses", func() {
 					m.Combo("/{sha}").Get(repo.GetCommitStatuses).
 						Post(reqToken(), reqRepoWriter(unit.TypeCode), bind(api.CreateStatusOption{}), repo.NewCommitStatus)
 				}, reqRepoReader(unit.TypeCode))
+				// the alerts are only available to the writers of the code, see codescanning_service.CanReadAlerts
+				m.Group("/code-scanning", func() {
+					m.Post("/sarifs", context.ReferencesGitRepo(), bind(api.UploadSarifOption{}), repo.UploadCodeScanningSarif)
+					m.Group("/alerts", func() {
+						m.Get("", repo.ListCodeScanningAlerts)
+						m.Combo("/{id}").Get(repo.GetCodeScanningAlert).
+							Patch(bind(api.EditCodeScanningAlertOption{}), repo.EditCodeScanningAlert)
+					})
+				}, reqToken(), reqRepoWriter(unit.TypeCode))
+				m.Group("/deployments", func() {
+					m.Combo("").Get(repo.ListDeployments).
+						Post(reqToken(), reqRepoWriter(unit.TypeCode), context.ReferencesGitRepo(), bind(api.CreateDeploymentOption{}), repo.CreateDeployment)
+					m.Group("/{id}", func() {
+						m.Combo("").Get(repo.GetDeployment).
+							Delete(reqToken(), reqRepoWriter(unit.TypeCode), repo.DeleteDeployment)
+						m.Combo("/statuses").Get(repo.ListDeploymentStatuses).
+							Post(reqToken(), reqRepoWriter(unit.TypeCode), bind(api.CreateDeploymentStatusOption{}), repo.CreateDeploymentStatus)
+					})
+				}, reqRepoReader(unit.TypeCode))
+				m.Group("/attestations", func() {
+					m.Combo("").Get(repo.ListAttestations).
+						Post(reqToken(), reqRepoWriter(unit.TypeCode), context.ReferencesGitRepo(), bind(api.CreateAttestationOption{}), repo.CreateAttestation)
+					m.Combo("/{id}").Get(repo.GetAttestation).
+						Delete(reqToken(), reqRepoWriter(unit.TypeCode), repo.DeleteAttestation)
+				}, reqRepoReader(unit.TypeCode))
+				m.Group("/coverage", func() {
+					m.Post("", reqToken(), reqRepoWriter(unit.TypeCode), context.ReferencesGitRepo(), bind(api.UploadCoverageOption{}), repo.UploadCoverage)
+					m.Get("/{sha}", repo.GetCommitCoverage)
+				}, reqRepoReader(unit.TypeCode))
 				m.Group("/commits", func() {
 					m.Get("", context.ReferencesGitRepo(), repo.GetAllCommits)
 					m.Group("/{ref}", func() {
@@ -1040,6 +1290,11 @@ func Routes(ctx gocontext.Context) *web.Route {
 					m.Group("/commits", func() {
 						m.Get("/{sha}", repo.GetSingleCommit)
 						m.Get("/{sha}.{diffType:diff|patch}", repo.DownloadCommitDiffOrPatch)
+						m.Get("/{sha}/verification", repo.GetCommitSignatureVerification)
+						m.Group("/{sha}", func() {
+							m.Post("/cherry-pick", bind(api.CherryPickCommitOption{}), repo.CherryPickCommit)
+							m.Post("/revert", bind(api.CherryPickCommitOption{}), repo.RevertCommit)
+						}, reqToken(), reqRepoWriter(unit.TypeCode))
 					})
 					m.Get("/refs", repo.GetGitAllRefs)
 					m.Get("/refs/*", repo.GetGitRefs)
@@ -1047,6 +1302,7 @@ func Routes(ctx gocontext.Context) *web.Route {
 					m.Get("/blobs/{sha}", repo.GetBlob)
 					m.Get("/tags/{sha}", repo.GetAnnotatedTag)
 					m.Get("/notes/{sha}", repo.GetNote)
+					m.Post("/verification", bind(api.VerifyCommitSignatureOption{}), repo.VerifyCommitSignature)
 				}, context.ReferencesGitRepo(), reqRepoReader(unit.TypeCode))
 				m.Post("/diffpatch", reqRepoWriter(unit.TypeCode), reqToken(), bind(api.ApplyDiffPatchFileOptions{}), repo.ApplyDiffPatch)
 				m.Group("/contents", func() {
@@ -1069,6 +1325,14 @@ func Routes(ctx gocontext.Context) *web.Route {
 				}, reqAnyRepoReader())
 				m.Get("/issue_templates", context.ReferencesGitRepo(), repo.GetIssueTemplates)
 				m.Get("/languages", reqRepoReader(unit.TypeCode), repo.GetLanguages)
+				m.Get("/last_commits", reqRepoReader(unit.TypeCode), context.ReferencesGitRepo(), repo.GetLastCommits)
+				m.Get("/grep", reqRepoReader(unit.TypeCode), context.ReferencesGitRepo(), repo.Grep)
+				m.Get("/languages/history", reqRepoReader(unit.TypeCode), repo.ListLanguageStatsHistory)
+				m.Group("/expertise", func() {
+					m.Get("", repo.GetExpertise)
+					m.Get("/bus_factor", repo.ListBusFactors)
+				}, reqRepoReader(unit.TypeCode))
+				m.Get("/insights/maintenance", reqRepoReader(unit.TypeCode), repo.GetMaintenanceReport)
 			}, repoAssignment())
 		})
 
@@ -1077,6 +1341,11 @@ func Routes(ctx gocontext.Context) *web.Route {
 				m.Get("", packages.GetPackage)
 				m.Delete("", reqPackageAccess(perm.AccessModeWrite), packages.DeletePackage)
 				m.Get("/files", packages.ListPackageFiles)
+				m.Group("/attestations", func() {
+					m.Combo("").Get(packages.ListPackageAttestations).
+						Post(reqToken(), reqPackageAccess(perm.AccessModeWrite), bind(api.CreatePackageAttestationOption{}), packages.CreatePackageAttestation)
+					m.Delete("/{id}", reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.DeletePackageAttestation)
+				})
 			})
 			m.Get("/", packages.ListPackages)
 		}, context_service.UserAssignmentAPI(), context.PackageAssignmentAPI(), reqPackageAccess(perm.AccessModeRead))
@@ -1095,11 +1364,20 @@ func Routes(ctx gocontext.Context) *web.Route {
 				Delete(reqToken(), reqOrgOwnership(), org.Delete)
 			m.Combo("/repos").Get(user.ListOrgRepos).
 				Post(reqToken(), bind(api.CreateRepoOption{}), repo.CreateOrgRepo)
+			m.Put("/profile", reqToken(), reqOrgOwnership(), bind(api.EditUserProfileOption{}), user.EditOrgProfile)
+			m.Post("/default_branch/rename", reqToken(), reqOrgOwnership(), bind(api.RenameOrgDefaultBranchesOption{}), org.RenameDefaultBranches)
+			m.Combo("/pinned_issues").Get(org.ListPinnedIssues).
+				Put(reqToken(), reqOrgOwnership(), bind(api.EditOrgPinnedIssuesOption{}), org.EditPinnedIssues)
 			m.Group("/members", func() {
 				m.Get("", org.ListMembers)
 				m.Combo("/{username}").Get(org.IsMember).
 					Delete(reqToken(), reqOrgOwnership(), org.DeleteMember)
 			})
+			m.Group("/guests", func() {
+				m.Get("", org.ListGuests)
+				m.Combo("/{username}").Put(org.AddGuest).
+					Delete(org.RemoveGuest)
+			}, reqToken(), reqOrgOwnership())
 			m.Group("/public_members", func() {
 				m.Get("", org.ListPublicMembers)
 				m.Combo("/{username}").Get(org.IsPublicMember).
@@ -1111,13 +1389,41 @@ func Routes(ctx gocontext.Context) *web.Route {
 				m.Post("", reqOrgOwnership(), bind(api.CreateTeamOption{}), org.CreateTeam)
 				m.Get("/search", org.SearchTeam)
 			}, reqToken(), reqOrgMembership())
+			m.Group("/issue_fields", func() {
+				m.Combo("").Get(org.ListIssueFields).
+					Post(reqToken(), reqOrgOwnership(), bind(api.CreateIssueFieldOption{}), org.CreateIssueField)
+				m.Combo("/{id}").Get(org.GetIssueField).
+					Patch(reqToken(), reqOrgOwnership(), bind(api.EditIssueFieldOption{}), org.EditIssueField).
+					Delete(reqToken(), reqOrgOwnership(), org.DeleteIssueField)
+			})
 			m.Group("/labels", func() {
 				m.Get("", org.ListLabels)
 				m.Post("", reqToken(), reqOrgOwnership(), bind(api.CreateLabelOption{}), org.CreateLabel)
+				m.Post("/sync", reqToken(), reqOrgOwnership(), bind(api.SyncLabelsOption{}), org.SyncLabels)
 				m.Combo("/{id}").Get(org.GetLabel).
 					Patch(reqToken(), reqOrgOwnership(), bind(api.EditLabelOption{}), org.EditLabel).
 					Delete(reqToken(), reqOrgOwnership(), org.DeleteLabel)
 			})
+			m.Group("/roles", func() {
+				m.Get("", org.ListRoles)
+				m.Post("", reqOrgOwnership(), bind(api.CreateRoleOption{}), org.CreateRole)
+				m.Combo("/{id}").Get(org.GetRole).
+					Patch(reqOrgOwnership(), bind(api.EditRoleOption{}), org.EditRole).
+					Delete(reqOrgOwnership(), org.DeleteRole)
+			}, reqToken(), reqOrgMembership())
+			m.Group("/domains", func() {
+				m.Combo("").Get(org.ListDomains).
+					Post(bind(api.CreateOrgDomainOption{}), org.CreateDomain)
+				m.Combo("/{id}").Get(org.GetDomain).
+					Patch(bind(api.EditOrgDomainOption{}), org.EditDomain).
+					Delete(org.DeleteDomain)
+				m.Post("/{id}/verify", org.VerifyDomain)
+			}, reqToken(), reqOrgOwnership())
+			m.Group("/insights", func() {
+				m.Get("", org.ListInsights)
+				m.Get("/summary", org.GetInsightSummary)
+				m.Get("/maintenance", org.ListMaintenanceReports)
+			}, reqToken(), reqOrgMembership())
 			m.Group("/hooks", func() {
 				m.Combo("").Get(org.ListHooks).
 					Post(bind(api.CreateHookOption{}), org.CreateHook)
@@ -1125,6 +1431,28 @@ func Routes(ctx gocontext.Context) *web.Route {
 					Patch(bind(api.EditHookOption{}), org.EditHook).
 					Delete(org.DeleteHook)
 			}, reqToken(), reqOrgOwnership(), reqWebhooksEnabled())
+			m.Group("/hook_templates", func() {
+				m.Combo("").Get(org.ListHookTemplates).
+					Post(bind(api.CreateHookOption{}), org.CreateHookTemplate)
+				m.Combo("/{id}").Get(org.GetHookTemplate).
+					Patch(bind(api.EditHookOption{}), org.EditHookTemplate).
+					Delete(org.DeleteHookTemplate)
+			}, reqToken(), reqOrgOwnership(), reqWebhooksEnabled())
+			m.Group("/blocks", func() {
+				m.Get("", user.ListOrgBlockedUsers)
+				m.Group("/{username}", func() {
+					m.Get("", user.CheckOrgBlockedUser)
+					m.Put("", user.BlockUserForOrg)
+					m.Delete("", user.UnblockUserForOrg)
+				})
+			}, reqToken(), reqOrgOwnership())
+			m.Combo("/interaction-limits", reqToken(), reqOrgOwnership()).Get(user.GetOrgInteractionLimit).
+				Put(bind(api.SetInteractionLimitOption{}), user.SetOrgInteractionLimit).
+				Delete(user.RemoveOrgInteractionLimit)
+			m.Group("/moderation", func() {
+				m.Get("/reports", org.ListModerationReports)
+				m.Get("/log", org.ListModerationLog)
+			}, reqToken(), reqOrgOwnership())
 		}, orgAssignment(true))
 		m.Group("/teams/{teamid}", func() {
 			m.Combo("").Get(org.GetTeam).
@@ -1137,6 +1465,8 @@ func Routes(ctx gocontext.Context) *web.Route {
 					Put(reqOrgOwnership(), org.AddTeamMember).
 					Delete(reqOrgOwnership(), org.RemoveTeamMember)
 			})
+			m.Get("/children", org.ListTeamChildren)
+			m.Get("/reviews/workload", org.GetTeamReviewerWorkload)
 			m.Group("/repos", func() {
 				m.Get("", org.GetTeamRepos)
 				m.Combo("/{org}/{reponame}").
@@ -1152,12 +1482,30 @@ func Routes(ctx gocontext.Context) *web.Route {
 				m.Post("/{task}", admin.PostCronTask)
 			})
 			m.Get("/orgs", admin.GetAllOrgs)
+			m.Get("/languages/trends", admin.ListLanguageTrends)
+			m.Get("/attachments/usage", admin.ListRepoAttachmentsUsages)
+			m.Get("/downloads/usage", admin.ListRepoDownloadUsages)
+			m.Get("/downloads/usage/owners", admin.ListOwnerDownloadUsages)
+			m.Get("/replication", admin.GetReplicationStatus)
+			m.Get("/replication/repos", admin.ListRepoReplications)
+			m.Post("/labels/sync", bind(api.SyncLabelTemplateOption{}), admin.SyncLabelTemplate)
+			m.Group("/moderation", func() {
+				m.Get("/reports", admin.ListModerationReports)
+				m.Get("/log", admin.ListModerationLog)
+			})
+			m.Group("/badges", func() {
+				m.Get("", admin.ListBadges)
+				m.Post("", bind(api.CreateBadgeOption{}), admin.CreateBadge)
+				m.Combo("/{id}").Patch(bind(api.EditBadgeOption{}), admin.EditBadge).
+					Delete(admin.DeleteBadge)
+			})
 			m.Group("/users", func() {
 				m.Get("", admin.GetAllUsers)
 				m.Post("", bind(api.CreateUserOption{}), admin.CreateUser)
 				m.Group("/{username}", func() {
 					m.Combo("").Patch(bind(api.EditUserOption{}), admin.EditUser).
 						Delete(admin.DeleteUser)
+					m.Post("/merge", bind(api.MergeUserOption{}), admin.MergeUser)
 					m.Group("/keys", func() {
 						m.Post("", bind(api.CreateKeyOption{}), admin.CreatePublicKey)
 						m.Delete("/{id}", admin.DeleteUserPublicKey)
@@ -1165,6 +1513,8 @@ func Routes(ctx gocontext.Context) *web.Route {
 					m.Get("/orgs", org.ListUserOrgs)
 					m.Post("/orgs", bind(api.CreateOrgOption{}), admin.CreateOrg)
 					m.Post("/repos", bind(api.CreateRepoOption{}), admin.CreateRepo)
+					m.Combo("/badges/{id}").Put(admin.AddUserBadge).
+						Delete(admin.RemoveUserBadge)
 				}, context_service.UserAssignmentAPI())
 			})
 			m.Group("/unadopted", func() {
@@ -1177,6 +1527,15 @@ func Routes(ctx gocontext.Context) *web.Route {
 		m.Group("/topics", func() {
 			m.Get("/search", repo.TopicSearch)
 		})
+
+		m.Group("/explore", func() {
+			m.Get("/trending", explore.ListTrendingRepos)
+			m.Get("/collections", explore.ListCollections)
+			m.Group("/collections/{id}", func() {
+				m.Get("", explore.GetCollection)
+				m.Get("/repos", explore.ListCollectionRepos)
+			})
+		})
 	}, sudo())
 
 	return m
@@ -0,0 +1,177 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package explore
+
+import (
+	"errors"
+	"net/http"
+
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+// writeRepos writes the repositories with the pagination headers
+func writeRepos(ctx *context.APIContext, repos repo_model.RepositoryList, count int64, pageSize int) {
+	if err := repos.LoadAttributes(); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadAttributes", err)
+		return
+	}
+	apiRepos := make([]*api.Repository, 0, len(repos))
+	for _, repo := range repos {
+		access, err := access_model.AccessLevel(ctx.Doer, repo)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "AccessLevel", err)
+			return
+		}
+		apiRepos = append(apiRepos, convert.ToRepo(repo, access))
+	}
+	ctx.SetLinkHeader(int(count), pageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiRepos)
+}
+
+// ListTrendingRepos lists the trending repositories
+func ListTrendingRepos(ctx *context.APIContext) {
+	// swagger:operation GET /explore/trending explore exploreListTrendingRepos
+	// ---
+	// summary: List the trending repositories, best score first
+	// description: The scores are computed periodically from the stars, forks and activity of the last days.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepositoryList"
+
+	listOptions := utils.GetListOptions(ctx)
+	repos, count, err := repo_model.FindTrendingRepos(ctx, ctx.Doer, listOptions)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindTrendingRepos", err)
+		return
+	}
+	writeRepos(ctx, repos, count, listOptions.PageSize)
+}
+
+// ListCollections lists the collections of the explore pages
+func ListCollections(ctx *context.APIContext) {
+	// swagger:operation GET /explore/collections explore exploreListCollections
+	// ---
+	// summary: List the collections curated by the admins, in their order
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: pinned
+	//   in: query
+	//   description: only list the pinned collections
+	//   type: boolean
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ExploreCollectionList"
+
+	collections, err := repo_model.GetExploreCollections(ctx, ctx.FormBool("pinned"))
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetExploreCollections", err)
+		return
+	}
+	apiCollections := make([]*api.ExploreCollection, 0, len(collections))
+	for _, c := range collections {
+		apiCollections = append(apiCollections, convert.ToExploreCollection(c))
+	}
+	ctx.JSON(http.StatusOK, apiCollections)
+}
+
+func collectionFromParams(ctx *context.APIContext) *repo_model.ExploreCollection {
+	c, err := repo_model.GetExploreCollectionByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, repo_model.ErrExploreCollectionNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetExploreCollectionByID", err)
+		}
+		return nil
+	}
+	return c
+}
+
+// GetCollection gets a collection of the explore pages
+func GetCollection(ctx *context.APIContext) {
+	// swagger:operation GET /explore/collections/{id} explore exploreGetCollection
+	// ---
+	// summary: Get a collection curated by the admins
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the collection
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ExploreCollection"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	c := collectionFromParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToExploreCollection(c))
+}
+
+// ListCollectionRepos lists the repositories of a collection of the explore pages
+func ListCollectionRepos(ctx *context.APIContext) {
+	// swagger:operation GET /explore/collections/{id}/repos explore exploreListCollectionRepos
+	// ---
+	// summary: List the repositories of a collection curated by the admins
+	// description: The repositories added to the collection come first in the order they have been added, then the ones of its topic by trending score and stars.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the collection
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepositoryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	c := collectionFromParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	listOptions := utils.GetListOptions(ctx)
+	repos, count, err := repo_model.GetExploreCollectionRepos(ctx, c, ctx.Doer, listOptions)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetExploreCollectionRepos", err)
+		return
+	}
+	writeRepos(ctx, repos, count, listOptions.PageSize)
+}
@@ -0,0 +1,55 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/highlight"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+)
+
+// Highlight highlights the syntax of a code snippet as HTML
+func Highlight(ctx *context.APIContext) {
+	// swagger:operation POST /highlight miscellaneous renderHighlight
+	// ---
+	// summary: Highlight the syntax of a code snippet as HTML lines
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/HighlightOption"
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HighlightResult"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.HighlightOption)
+
+	if ctx.HasAPIError() {
+		ctx.Error(http.StatusUnprocessableEntity, "", ctx.GetErrMsg())
+		return
+	}
+
+	lines, language, err := highlight.File(form.Filename, form.Language, []byte(form.Content))
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	if lines == nil {
+		lines = []string{}
+	}
+	ctx.JSON(http.StatusOK, &api.HighlightResult{
+		Language: language,
+		Lines:    lines,
+	})
+}
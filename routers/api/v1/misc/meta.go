@@ -0,0 +1,65 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"net/http"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/structs"
+)
+
+// Meta shows the version, the enabled features and the limits of the instance
+func Meta(ctx *context.APIContext) {
+	// swagger:operation GET /meta miscellaneous getInstanceMeta
+	// ---
+	// summary: Returns the version, the enabled features and the limits of the instance
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/InstanceMeta"
+
+	packageTypes := make([]string, 0, len(packages_model.TypeList))
+	if setting.Packages.Enabled {
+		for _, pt := range packages_model.TypeList {
+			packageTypes = append(packageTypes, string(pt))
+		}
+	}
+
+	// the limit of repositories of the authenticated user, the administrators have none
+	maxRepoCreation := setting.Repository.MaxCreationLimit
+	if ctx.Doer != nil {
+		if ctx.Doer.IsAdmin {
+			maxRepoCreation = -1
+		} else {
+			maxRepoCreation = ctx.Doer.MaxCreationLimit()
+		}
+	}
+
+	ctx.JSON(http.StatusOK, &structs.InstanceMeta{
+		Version: setting.AppVer,
+		Features: structs.InstanceFeatures{
+			Packages:       setting.Packages.Enabled,
+			PackageTypes:   packageTypes,
+			Federation:     setting.Federation.Enabled,
+			LFS:            setting.LFS.StartServer,
+			Mirrors:        setting.Mirror.Enabled,
+			Migrations:     !setting.Repository.DisableMigrations,
+			Attachments:    setting.Attachment.Enabled,
+			Registration:   !setting.Service.DisableRegistration,
+			OAuth2Provider: setting.OAuth2.Enable,
+		},
+		Limits: structs.InstanceLimits{
+			MaxResponseItems:  setting.API.MaxResponseItems,
+			MaxFileSize:       setting.Repository.Upload.FileMaxSize * 1024 * 1024,
+			MaxAttachmentSize: setting.Attachment.MaxSize * 1024 * 1024,
+			MaxLFSFileSize:    setting.LFS.MaxFileSize,
+			MaxRepoCreation:   maxRepoCreation,
+		},
+	})
+}
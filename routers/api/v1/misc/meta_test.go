@@ -0,0 +1,56 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"net/http"
+	"testing"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPI_Meta(t *testing.T) {
+	oldPackages, oldAttachment, oldLimit := setting.Packages.Enabled, setting.Attachment.MaxSize, setting.Repository.MaxCreationLimit
+	defer func() {
+		setting.Packages.Enabled, setting.Attachment.MaxSize, setting.Repository.MaxCreationLimit = oldPackages, oldAttachment, oldLimit
+	}()
+	setting.Packages.Enabled = true
+	setting.Attachment.MaxSize = 4
+	setting.Repository.MaxCreationLimit = 10
+
+	meta := func(doer *user_model.User) *api.InstanceMeta {
+		req, _ := http.NewRequest("GET", AppURL+"api/v1/meta", nil)
+		m, resp := createContext(req)
+		ctx := wrap(m)
+		ctx.Doer = doer
+		Meta(ctx)
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		meta := new(api.InstanceMeta)
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), meta))
+		return meta
+	}
+
+	anonymous := meta(nil)
+	assert.Equal(t, setting.AppVer, anonymous.Version)
+	assert.True(t, anonymous.Features.Packages)
+	assert.Contains(t, anonymous.Features.PackageTypes, "container")
+	assert.False(t, anonymous.Features.Actions)
+	assert.EqualValues(t, 4*1024*1024, anonymous.Limits.MaxAttachmentSize)
+	assert.Equal(t, 10, anonymous.Limits.MaxRepoCreation)
+
+	assert.Equal(t, 3, meta(&user_model.User{MaxRepoCreation: 3}).Limits.MaxRepoCreation)
+	assert.Equal(t, -1, meta(&user_model.User{MaxRepoCreation: 3, IsAdmin: true}).Limits.MaxRepoCreation)
+
+	setting.Packages.Enabled = false
+	disabled := meta(nil)
+	assert.False(t, disabled.Features.Packages)
+	assert.Empty(t, disabled.Features.PackageTypes)
+}
@@ -0,0 +1,120 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.gitea.io/gitea/modules/context"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ListGitignoreTemplates list the names of the gitignore templates
+func ListGitignoreTemplates(ctx *context.APIContext) {
+	// swagger:operation GET /gitignore/templates miscellaneous listGitignoreTemplates
+	// ---
+	// summary: Returns the names of the gitignore templates
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/StringSlice"
+
+	ctx.JSON(http.StatusOK, repo_module.Gitignores)
+}
+
+// GetGitignoreTemplate returns a gitignore template
+func GetGitignoreTemplate(ctx *context.APIContext) {
+	// swagger:operation GET /gitignore/templates/{name} miscellaneous getGitignoreTemplate
+	// ---
+	// summary: Returns a gitignore template
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: name
+	//   in: path
+	//   description: name of the template
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/GitignoreTemplateInfo"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	name := ctx.Params(":name")
+	if !util.IsStringInSlice(name, repo_module.Gitignores) {
+		ctx.NotFound()
+		return
+	}
+	data, err := repo_module.GetRepoInitFile("gitignore", name)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetRepoInitFile", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &api.GitignoreTemplateInfo{Name: name, Source: string(data)})
+}
+
+// ListLicenseTemplates list the names of the license templates
+func ListLicenseTemplates(ctx *context.APIContext) {
+	// swagger:operation GET /licenses miscellaneous listLicenseTemplates
+	// ---
+	// summary: Returns the names of the license templates, the preferred ones first
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/StringSlice"
+
+	ctx.JSON(http.StatusOK, repo_module.Licenses)
+}
+
+// GetLicenseTemplate returns a license template with its placeholders filled
+func GetLicenseTemplate(ctx *context.APIContext) {
+	// swagger:operation GET /licenses/{name} miscellaneous getLicenseTemplate
+	// ---
+	// summary: Returns a license template with its year and author placeholders filled
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: name
+	//   in: path
+	//   description: name of the template
+	//   type: string
+	//   required: true
+	// - name: year
+	//   in: query
+	//   description: year of the copyright, the current year by default
+	//   type: string
+	// - name: author
+	//   in: query
+	//   description: author holding the copyright, the placeholders are kept by default
+	//   type: string
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/LicenseTemplateInfo"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	name := ctx.Params(":name")
+	if !util.IsStringInSlice(name, repo_module.Licenses) {
+		ctx.NotFound()
+		return
+	}
+	year := ctx.FormTrim("year")
+	if year == "" {
+		year = strconv.Itoa(time.Now().Year())
+	}
+	data, err := repo_module.GetLicense(name, repo_module.LicenseValues{Year: year, Author: ctx.FormTrim("author")})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetLicense", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, &api.LicenseTemplateInfo{Name: name, Body: string(data)})
+}
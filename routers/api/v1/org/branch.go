@@ -0,0 +1,62 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/context"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// RenameDefaultBranches renames the default branch of the repositories of an organization
+func RenameDefaultBranches(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/default_branch/rename organization orgRenameDefaultBranches
+	// ---
+	// summary: Rename the default branch of the repositories of an organization whose default branch has a name
+	// description: Each repository is renamed as the rename of the default branch of a repository does, the
+	//   repositories which couldn't be renamed have an error. The branch filters of the webhooks of the organization
+	//   are updated.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/RenameOrgDefaultBranchesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgDefaultBranchRename"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	opt := web.GetForm(ctx).(*api.RenameOrgDefaultBranchesOption)
+	results, updatedWebhooks, err := repo_service.RenameOrgDefaultBranches(ctx, ctx.Doer, ctx.Org.Organization, opt.OldName, opt.NewName)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "RenameOrgDefaultBranches", err)
+		return
+	}
+
+	apiResult := &api.OrgDefaultBranchRename{
+		Repositories:    make([]*api.DefaultBranchRename, 0, len(results)),
+		UpdatedWebhooks: updatedWebhooks,
+	}
+	for _, result := range results {
+		apiResult.Repositories = append(apiResult.Repositories, utils.ToDefaultBranchRename(result))
+	}
+	ctx.JSON(http.StatusOK, apiResult)
+}
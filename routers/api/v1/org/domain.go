@@ -0,0 +1,263 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	org_service "code.gitea.io/gitea/services/org"
+)
+
+// ListDomains list the domains of an organization
+func ListDomains(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/domains organization orgListDomains
+	// ---
+	// summary: List the email domains claimed by an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgDomainList"
+
+	domains, err := organization.GetOrgDomains(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetOrgDomains", err)
+		return
+	}
+
+	apiDomains := make([]*api.OrgDomain, 0, len(domains))
+	for _, d := range domains {
+		apiDomains = append(apiDomains, convert.ToOrgDomain(ctx.Org.Organization, d))
+	}
+	ctx.SetTotalCountHeader(int64(len(domains)))
+	ctx.JSON(http.StatusOK, apiDomains)
+}
+
+// CreateDomain claim a domain for an organization
+func CreateDomain(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/domains organization orgCreateDomain
+	// ---
+	// summary: Claim an email domain for an organization, its ownership must then be verified through a DNS TXT record
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrgDomainOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/OrgDomain"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateOrgDomainOption)
+	d, err := org_service.CreateDomain(ctx, ctx.Org.Organization, form.Domain, form.TeamID)
+	if err != nil {
+		if organization.IsErrOrgDomainAlreadyExist(err) || organization.IsErrOrgDomainInvalid(err) || organization.IsErrTeamNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "CreateDomain", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToOrgDomain(ctx.Org.Organization, d))
+}
+
+// getOrgDomain returns the domain of the organization whose id is in the path, it writes a response on error
+func getOrgDomain(ctx *context.APIContext) *organization.OrgDomain {
+	d, err := organization.GetOrgDomainByID(ctx, ctx.Org.Organization.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if organization.IsErrOrgDomainNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetOrgDomainByID", err)
+		}
+		return nil
+	}
+	return d
+}
+
+// GetDomain get a domain of an organization
+func GetDomain(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/domains/{id} organization orgGetDomain
+	// ---
+	// summary: Get an email domain claimed by an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the domain to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgDomain"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	d := getOrgDomain(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToOrgDomain(ctx.Org.Organization, d))
+}
+
+// EditDomain edit a domain of an organization
+func EditDomain(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/domains/{id} organization orgEditDomain
+	// ---
+	// summary: Change the team joined by the users of an email domain claimed by an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the domain to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditOrgDomainOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgDomain"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditOrgDomainOption)
+	d := getOrgDomain(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := org_service.SetDomainTeam(ctx, d, form.TeamID); err != nil {
+		if organization.IsErrTeamNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "SetDomainTeam", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToOrgDomain(ctx.Org.Organization, d))
+}
+
+// VerifyDomain verify the ownership of a domain of an organization
+func VerifyDomain(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/domains/{id}/verify organization orgVerifyDomain
+	// ---
+	// summary: Verify the ownership of an email domain claimed by an organization through its DNS TXT record
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the domain to verify
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgDomain"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	d := getOrgDomain(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := org_service.VerifyDomain(ctx, ctx.Org.Organization, d); err != nil {
+		if organization.IsErrOrgDomainNotVerified(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "VerifyDomain", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToOrgDomain(ctx.Org.Organization, d))
+}
+
+// DeleteDomain delete a domain of an organization
+func DeleteDomain(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/domains/{id} organization orgDeleteDomain
+	// ---
+	// summary: Remove an email domain claimed by an organization, the users who joined through it stay members
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the domain to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	d := getOrgDomain(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := organization.DeleteOrgDomain(ctx, d); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteOrgDomain", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,208 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+// ListHookTemplates list an organization's webhook templates
+func ListHookTemplates(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/hook_templates organization orgListHookTemplates
+	// ---
+	// summary: List an organization's webhook templates
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookList"
+
+	opts := &webhook.ListWebhookOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		OrgID:       ctx.Org.Organization.ID,
+		IsTemplate:  true,
+	}
+
+	count, err := webhook.CountWebhooksByOpts(opts)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	templates, err := webhook.ListWebhooksByOpts(ctx, opts)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	hooks := make([]*api.Hook, len(templates))
+	for i, tmpl := range templates {
+		hooks[i] = convert.ToHook(ctx.Org.Organization.AsUser().HomeLink(), tmpl)
+	}
+
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, hooks)
+}
+
+// GetHookTemplate get an organization's webhook template by id
+func GetHookTemplate(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/hook_templates/{id} organization orgGetHookTemplate
+	// ---
+	// summary: Get a webhook template
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the webhook template to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Hook"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	org := ctx.Org.Organization
+	tmpl, err := utils.GetOrgHookTemplate(ctx, org.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToHook(org.AsUser().HomeLink(), tmpl))
+}
+
+// CreateHookTemplate create a webhook template for an organization
+func CreateHookTemplate(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/hook_templates organization orgCreateHookTemplate
+	// ---
+	// summary: Create a webhook template, its webhook is created in every repository of the organization
+	// description: The url of the webhook template can contain the variables $REPO_ID, $REPO_NAME, $REPO_OWNER
+	//   and $REPO_FULL_NAME, they are replaced by the values of each repository.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CreateHookOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Hook"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateHookOption)
+	if !utils.CheckCreateHookOption(ctx, form) {
+		return
+	}
+	utils.AddOrgHookTemplate(ctx, form)
+}
+
+// EditHookTemplate modify a webhook template of an organization
+func EditHookTemplate(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/hook_templates/{id} organization orgEditHookTemplate
+	// ---
+	// summary: Update a webhook template and the webhooks created from it
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the webhook template to update
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditHookOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Hook"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	form := web.GetForm(ctx).(*api.EditHookOption)
+	utils.EditOrgHookTemplate(ctx, form, ctx.ParamsInt64(":id"))
+}
+
+// DeleteHookTemplate delete a webhook template of an organization
+func DeleteHookTemplate(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/hook_templates/{id} organization orgDeleteHookTemplate
+	// ---
+	// summary: Delete a webhook template and the webhooks created from it
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the webhook template to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	org := ctx.Org.Organization
+	if err := webhook.DeleteWebhookTemplateByOrgID(ctx, org.ID, ctx.ParamsInt64(":id")); err != nil {
+		if webhook.IsErrWebhookNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "DeleteWebhookTemplateByOrgID", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
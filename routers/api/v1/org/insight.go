@@ -0,0 +1,140 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"net/http"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// defaultInsightDays is the number of days returned when the period isn't given
+const defaultInsightDays = 30
+
+// findInsights returns the insights of the period given by the since and before parameters,
+// it writes a response on error. Guests can't see the insights as they include all the repositories.
+func findInsights(ctx *context.APIContext) (insights activities_model.OrgInsightList, since, before time.Time) {
+	if !ctx.Doer.IsAdmin {
+		isGuest, err := organization.IsOrganizationGuest(ctx, ctx.Org.Organization.ID, ctx.Doer.ID)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "IsOrganizationGuest", err)
+			return nil, since, before
+		} else if isGuest {
+			ctx.Error(http.StatusForbidden, "", "Must be an organization member")
+			return nil, since, before
+		}
+	}
+
+	beforeUnix, sinceUnix, err := context.GetQueryBeforeSince(ctx.Context)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
+		return nil, since, before
+	}
+	if beforeUnix == 0 {
+		beforeUnix = int64(activities_model.InsightDayStart(timeutil.TimeStampNow()))
+	}
+	if sinceUnix == 0 {
+		sinceUnix = beforeUnix - defaultInsightDays*activities_model.OrgInsightDay
+	}
+	if sinceUnix >= beforeUnix {
+		ctx.Error(http.StatusUnprocessableEntity, "", "since must be before before")
+		return nil, since, before
+	}
+
+	insights, err = activities_model.FindOrgInsights(ctx, ctx.Org.Organization.ID, timeutil.TimeStamp(sinceUnix), timeutil.TimeStamp(beforeUnix))
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindOrgInsights", err)
+		return nil, since, before
+	}
+	return insights, time.Unix(sinceUnix, 0).UTC(), time.Unix(beforeUnix, 0).UTC()
+}
+
+// ListInsights list the daily insights of an organization
+func ListInsights(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/insights organization orgListInsights
+	// ---
+	// summary: List the daily metrics of the repositories of an organization, computed once a day is over
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: since
+	//   in: query
+	//   description: only days starting at or after this time, defaults to 30 days before `before`
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only days starting before this time, defaults to the start of the current UTC day
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgInsightList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	insights, _, _ := findInsights(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	apiInsights := make([]*api.OrgInsight, 0, len(insights))
+	for _, in := range insights {
+		apiInsights = append(apiInsights, convert.ToOrgInsight(in))
+	}
+	ctx.SetTotalCountHeader(int64(len(insights)))
+	ctx.JSON(http.StatusOK, apiInsights)
+}
+
+// GetInsightSummary get the insights of an organization aggregated over a period
+func GetInsightSummary(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/insights/summary organization orgGetInsightSummary
+	// ---
+	// summary: Get the metrics of the repositories of an organization aggregated over a period
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: since
+	//   in: query
+	//   description: only days starting at or after this time, defaults to 30 days before `before`
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only days starting before this time, defaults to the start of the current UTC day
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OrgInsightSummary"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	insights, since, before := findInsights(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToOrgInsightSummary(insights, since, before))
+}
@@ -0,0 +1,228 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+)
+
+// ListIssueFields list the custom fields of the issues of an organization
+func ListIssueFields(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/issue_fields organization orgListIssueFields
+	// ---
+	// summary: List the custom fields of the issues of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFieldList"
+
+	fields, err := issues_model.GetIssueFieldsByOrgID(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueFieldsByOrgID", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueFieldList(fields))
+}
+
+// CreateIssueField create a custom field of the issues of an organization
+func CreateIssueField(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/issue_fields organization orgCreateIssueField
+	// ---
+	// summary: Create a custom field of the issues of the repositories of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateIssueFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/IssueField"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateIssueFieldOption)
+	field := &issues_model.IssueField{
+		OrgID:       ctx.Org.Organization.ID,
+		Name:        form.Name,
+		Description: form.Description,
+		Type:        issues_model.IssueFieldType(form.Type),
+		Options:     form.Options,
+	}
+	if err := issues_model.NewIssueField(ctx, field); err != nil {
+		handleIssueFieldError(ctx, "NewIssueField", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToIssueField(field))
+}
+
+func getIssueField(ctx *context.APIContext) *issues_model.IssueField {
+	field, err := issues_model.GetIssueFieldInOrgByID(ctx, ctx.Org.Organization.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrIssueFieldNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueFieldInOrgByID", err)
+		}
+		return nil
+	}
+	return field
+}
+
+// GetIssueField get a custom field of the issues of an organization
+func GetIssueField(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/issue_fields/{id} organization orgGetIssueField
+	// ---
+	// summary: Get a custom field of the issues of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	field := getIssueField(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueField(field))
+}
+
+// EditIssueField edit a custom field of the issues of an organization
+func EditIssueField(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/issue_fields/{id} organization orgEditIssueField
+	// ---
+	// summary: Edit a custom field of the issues of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssueFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIssueFieldOption)
+	field := getIssueField(ctx)
+	if ctx.Written() {
+		return
+	}
+	if form.Name != nil {
+		field.Name = *form.Name
+	}
+	if form.Description != nil {
+		field.Description = *form.Description
+	}
+	if form.Options != nil {
+		field.Options = form.Options
+	}
+	if err := issues_model.UpdateIssueField(ctx, field); err != nil {
+		handleIssueFieldError(ctx, "UpdateIssueField", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueField(field))
+}
+
+// DeleteIssueField delete a custom field of the issues of an organization
+func DeleteIssueField(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/issue_fields/{id} organization orgDeleteIssueField
+	// ---
+	// summary: Delete a custom field of the issues of an organization and its values
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	field := getIssueField(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.DeleteIssueField(ctx, field); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteIssueField", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func handleIssueFieldError(ctx *context.APIContext, name string, err error) {
+	switch {
+	case issues_model.IsErrIssueFieldAlreadyExist(err):
+		ctx.Error(http.StatusConflict, name, err)
+	case issues_model.IsErrInvalidIssueField(err):
+		ctx.Error(http.StatusUnprocessableEntity, name, err)
+	default:
+		ctx.Error(http.StatusInternalServerError, name, err)
+	}
+}
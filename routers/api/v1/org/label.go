@@ -11,11 +11,14 @@ import (
 	"strings"
 
 	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/context"
 	"code.gitea.io/gitea/modules/convert"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
+	label_service "code.gitea.io/gitea/services/label"
 )
 
 // ListLabels list all the labels of an organization
@@ -245,3 +248,68 @@ func DeleteLabel(ctx *context.APIContext) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// SyncLabels synchronizes the labels of repositories of an organization with the labels of the organization
+func SyncLabels(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/labels/sync organization orgSyncLabels
+	// ---
+	// summary: Synchronize the labels of repositories of an organization with the labels of the organization
+	// description: Missing labels are created and the labels renamed, recolored or described differently are updated,
+	//   the other labels of the repositories are left untouched.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SyncLabelsOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/LabelSyncResultList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	form := web.GetForm(ctx).(*api.SyncLabelsOption)
+
+	var repos []*repo_model.Repository
+	if len(form.Repos) == 0 {
+		orgRepos, err := organization.GetOrgRepositories(ctx, ctx.Org.Organization.ID)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetOrgRepositories", err)
+			return
+		}
+		for _, repo := range orgRepos {
+			if !repo.IsArchived {
+				repos = append(repos, repo)
+			}
+		}
+	} else {
+		repos = make([]*repo_model.Repository, 0, len(form.Repos))
+		for _, name := range form.Repos {
+			repo, err := repo_model.GetRepositoryByName(ctx.Org.Organization.ID, name)
+			if err != nil {
+				if repo_model.IsErrRepoNotExist(err) {
+					ctx.NotFound()
+				} else {
+					ctx.Error(http.StatusInternalServerError, "GetRepositoryByName", err)
+				}
+				return
+			}
+			repos = append(repos, repo)
+		}
+	}
+
+	set, err := label_service.GetOrgLabelSet(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetOrgLabelSet", err)
+		return
+	}
+
+	utils.SyncLabels(ctx, repos, set, form.DryRun)
+}
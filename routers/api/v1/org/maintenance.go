@@ -0,0 +1,46 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ListMaintenanceReports list the maintenance reports of the repositories of an organization
+func ListMaintenanceReports(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/insights/maintenance organization orgListMaintenanceReports
+	// ---
+	// summary: List the maintenance reports of the repositories of an organization whose code the user can read
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MaintenanceReportList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	reports, err := repo_model.FindOrgMaintenanceReports(ctx, ctx.Org.Organization.ID, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindOrgMaintenanceReports", err)
+		return
+	}
+
+	apiReports := make([]*api.MaintenanceReport, 0, len(reports))
+	for _, report := range reports {
+		apiReports = append(apiReports, convert.ToMaintenanceReport(report, report.Repo))
+	}
+	ctx.JSON(http.StatusOK, apiReports)
+}
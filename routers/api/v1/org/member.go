@@ -19,10 +19,11 @@ import (
 )
 
 // listMembers list an organization's members
-func listMembers(ctx *context.APIContext, publicOnly bool) {
+func listMembers(ctx *context.APIContext, publicOnly, guestOnly bool) {
 	opts := &organization.FindOrgMembersOpts{
 		OrgID:       ctx.Org.Organization.ID,
 		PublicOnly:  publicOnly,
+		GuestOnly:   guestOnly,
 		ListOptions: utils.GetListOptions(ctx),
 	}
 
@@ -79,9 +80,15 @@ func ListMembers(ctx *context.APIContext) {
 			ctx.Error(http.StatusInternalServerError, "IsOrgMember", err)
 			return
 		}
-		publicOnly = !isMember && !ctx.Doer.IsAdmin
+		isGuest, err := organization.IsOrganizationGuest(ctx, ctx.Org.Organization.ID, ctx.Doer.ID)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "IsOrganizationGuest", err)
+			return
+		}
+		// guests only see the public members like non members
+		publicOnly = (!isMember || isGuest) && !ctx.Doer.IsAdmin
 	}
-	listMembers(ctx, publicOnly)
+	listMembers(ctx, publicOnly, false)
 }
 
 // ListPublicMembers list an organization's public members
@@ -109,7 +116,7 @@ func ListPublicMembers(ctx *context.APIContext) {
 	//   "200":
 	//     "$ref": "#/responses/UserList"
 
-	listMembers(ctx, true)
+	listMembers(ctx, true, false)
 }
 
 // IsMember check if a user is a member of an organization
@@ -145,7 +152,13 @@ func IsMember(ctx *context.APIContext) {
 		if err != nil {
 			ctx.Error(http.StatusInternalServerError, "IsOrgMember", err)
 			return
-		} else if userIsMember || ctx.Doer.IsAdmin {
+		}
+		userIsGuest, err := organization.IsOrganizationGuest(ctx, ctx.Org.Organization.ID, ctx.Doer.ID)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "IsOrganizationGuest", err)
+			return
+		}
+		if (userIsMember && !userIsGuest) || ctx.Doer.IsAdmin {
 			userToCheckIsMember, err := ctx.Org.Organization.IsOrgMember(userToCheck.ID)
 			if err != nil {
 				ctx.Error(http.StatusInternalServerError, "IsOrgMember", err)
@@ -314,3 +327,113 @@ func DeleteMember(ctx *context.APIContext) {
 	}
 	ctx.Status(http.StatusNoContent)
 }
+
+// ListGuests list an organization's guests
+func ListGuests(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/guests organization orgListGuests
+	// ---
+	// summary: List an organization's guests, who only access the repositories they are granted
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserList"
+
+	listMembers(ctx, false, true)
+}
+
+// changeGuest turns the membership of the user in the path into a guest or a full membership
+func changeGuest(ctx *context.APIContext, guest bool) {
+	member := user.GetUserByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	isMember, err := ctx.Org.Organization.IsOrgMember(member.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsOrgMember", err)
+		return
+	} else if !isMember {
+		ctx.NotFound()
+		return
+	}
+
+	if err := organization.ChangeOrgUserGuest(ctx.Org.Organization.ID, member.ID, guest); err != nil {
+		if organization.IsErrOrgGuestOwner(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "ChangeOrgUserGuest", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// AddGuest make a member of an organization a guest
+func AddGuest(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/guests/{username} organization orgAddGuest
+	// ---
+	// summary: Make a member of an organization a guest, who only accesses the repositories it is granted
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: username
+	//   in: path
+	//   description: username of the member
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	changeGuest(ctx, true)
+}
+
+// RemoveGuest make a guest of an organization a full member
+func RemoveGuest(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/guests/{username} organization orgRemoveGuest
+	// ---
+	// summary: Make a guest of an organization a full member
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: username
+	//   in: path
+	//   description: username of the guest
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	changeGuest(ctx, false)
+}
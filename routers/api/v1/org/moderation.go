@@ -0,0 +1,78 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+// ListModerationReports lists the reports about the content of the repositories of an organization
+func ListModerationReports(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/moderation/reports organization orgListModerationReports
+	// ---
+	// summary: List the reports about the issues, pull requests and comments of the repositories of an organization, oldest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: status
+	//   in: query
+	//   description: only the reports with this status
+	//   type: string
+	//   enum: [open, resolved, dismissed]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ModerationReportList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	utils.ListModerationReports(ctx, &moderation_model.FindReportsOptions{OwnerID: ctx.Org.Organization.ID})
+}
+
+// ListModerationLog lists the moderation audit trail of an organization
+func ListModerationLog(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/moderation/log organization orgListModerationLog
+	// ---
+	// summary: List the actions of the moderators in the repositories of an organization, newest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ModerationLog"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	utils.ListModerationLog(ctx, &moderation_model.FindLogsOptions{OwnerID: ctx.Org.Organization.ID})
+}
@@ -166,6 +166,12 @@ func GetUserOrgsPermissions(ctx *context.APIContext) {
 		return
 	}
 
+	op.IsGuest, err = organization.IsOrganizationGuest(ctx, org.ID, ctx.ContextUser.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsOrganizationGuest", err)
+		return
+	}
+
 	ctx.JSON(http.StatusOK, op)
 }
 
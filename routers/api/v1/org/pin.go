@@ -0,0 +1,116 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// ListPinnedIssues list the issues and pull requests pinned to an organization
+func ListPinnedIssues(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/pinned_issues organization orgListPinnedIssues
+	// ---
+	// summary: List the issues and pull requests pinned to an organization, in their order
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PinnedIssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pins, err := issue_service.GetVisibleOrgPinnedIssues(ctx, ctx.Org.Organization.AsUser(), ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetVisibleOrgPinnedIssues", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIPinnedIssueList(pins))
+}
+
+// EditPinnedIssues replace the issues and pull requests pinned to an organization
+func EditPinnedIssues(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/pinned_issues organization orgEditPinnedIssues
+	// ---
+	// summary: Replace the issues and pull requests pinned to an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditOrgPinnedIssuesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PinnedIssueList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditOrgPinnedIssuesOption)
+	org := ctx.Org.Organization
+
+	issueIDs := make([]int64, 0, len(form.Issues))
+	for _, ref := range form.Issues {
+		repo, err := repo_model.GetRepositoryByName(org.ID, ref.Repo)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetRepositoryByName", err)
+			}
+			return
+		}
+		issue, err := issues_model.GetIssueByIndex(repo.ID, ref.Index)
+		if err != nil {
+			if issues_model.IsErrIssueNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "", fmt.Errorf("issue %s#%d does not exist", ref.Repo, ref.Index))
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+			}
+			return
+		}
+		issueIDs = append(issueIDs, issue.ID)
+	}
+
+	if err := issues_model.SetOrgPinnedIssues(ctx, org.ID, issueIDs); err != nil {
+		if errors.Is(err, issues_model.ErrTooManyPinnedIssues) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "SetOrgPinnedIssues", err)
+		}
+		return
+	}
+
+	pins, err := issue_service.GetVisibleOrgPinnedIssues(ctx, org.AsUser(), ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetVisibleOrgPinnedIssues", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIPinnedIssueList(pins))
+}
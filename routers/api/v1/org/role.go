@@ -0,0 +1,256 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+)
+
+var errRoleNoUnits = errors.New("a role must grant access to at least one unit")
+
+// ListRoles list all the roles of an organization
+func ListRoles(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/roles organization orgListRoles
+	// ---
+	// summary: List an organization's roles
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RoleList"
+
+	roles, err := organization.GetOrgRoles(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetOrgRoles", err)
+		return
+	}
+
+	apiRoles := make([]*api.Role, 0, len(roles))
+	for _, role := range roles {
+		apiRoles = append(apiRoles, convert.ToRole(role))
+	}
+	ctx.SetTotalCountHeader(int64(len(roles)))
+	ctx.JSON(http.StatusOK, apiRoles)
+}
+
+// CreateRole create a role for an organization
+func CreateRole(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/roles organization orgCreateRole
+	// ---
+	// summary: Create a role for an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateRoleOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Role"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateRoleOption)
+	role := &organization.Role{
+		OrgID:       ctx.Org.Organization.ID,
+		Name:        form.Name,
+		Description: form.Description,
+		Units:       organization.NewRoleUnits(ctx.Org.Organization.ID, convertUnitsMap(form.UnitsMap)),
+	}
+	if len(role.Units) == 0 {
+		ctx.Error(http.StatusUnprocessableEntity, "", errRoleNoUnits)
+		return
+	}
+
+	if err := organization.NewRole(role); err != nil {
+		if organization.IsErrRoleAlreadyExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "NewRole", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToRole(role))
+}
+
+// getOrgRole returns the role of the organization whose id is in the path, it writes a response on error
+func getOrgRole(ctx *context.APIContext) *organization.Role {
+	role, err := organization.GetOrgRoleByID(ctx, ctx.Org.Organization.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if organization.IsErrRoleNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetOrgRoleByID", err)
+		}
+		return nil
+	}
+	if err := role.LoadUnits(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadUnits", err)
+		return nil
+	}
+	return role
+}
+
+// GetRole get a role of an organization
+func GetRole(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/roles/{id} organization orgGetRole
+	// ---
+	// summary: Get a role of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the role to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Role"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	role := getOrgRole(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToRole(role))
+}
+
+// EditRole edit a role of an organization
+func EditRole(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/roles/{id} organization orgEditRole
+	// ---
+	// summary: Edit a role of an organization, the teams and collaborators having the role get its new permissions
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the role to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditRoleOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Role"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditRoleOption)
+	role := getOrgRole(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if len(form.Name) > 0 {
+		role.Name = form.Name
+	}
+	if form.Description != nil {
+		role.Description = *form.Description
+	}
+	if form.UnitsMap != nil {
+		role.Units = organization.NewRoleUnits(role.OrgID, convertUnitsMap(form.UnitsMap))
+		if len(role.Units) == 0 {
+			ctx.Error(http.StatusUnprocessableEntity, "", errRoleNoUnits)
+			return
+		}
+	}
+
+	if err := models.UpdateRole(role); err != nil {
+		if organization.IsErrRoleAlreadyExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "UpdateRole", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToRole(role))
+}
+
+// DeleteRole delete a role of an organization
+func DeleteRole(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/roles/{id} organization orgDeleteRole
+	// ---
+	// summary: Delete a role of an organization, which must not be assigned to teams or collaborators
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the role to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	role := getOrgRole(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := organization.DeleteRole(role); err != nil {
+		if organization.IsErrRoleInUse(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "DeleteRole", err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
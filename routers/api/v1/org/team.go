@@ -22,6 +22,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/user"
 	"code.gitea.io/gitea/routers/api/v1/utils"
+	issue_service "code.gitea.io/gitea/services/issue"
 	org_service "code.gitea.io/gitea/services/org"
 )
 
@@ -201,10 +202,14 @@ func CreateTeam(ctx *context.APIContext) {
 		Description:             form.Description,
 		IncludesAllRepositories: form.IncludesAllRepositories,
 		CanCreateOrgRepo:        form.CanCreateOrgRepo,
+		AutoAssignReviewer:      form.AutoAssignReviewer,
 		AccessMode:              p,
+		ParentID:                form.ParentID,
+		RoleID:                  form.RoleID,
 	}
 
-	if team.AccessMode < perm.AccessModeAdmin {
+	// the units of a team having a role are the ones of the role
+	if team.AccessMode < perm.AccessModeAdmin && team.RoleID == 0 {
 		if len(form.UnitsMap) > 0 {
 			attachTeamUnitsMap(team, form.UnitsMap)
 		} else if len(form.Units) > 0 {
@@ -216,7 +221,7 @@ func CreateTeam(ctx *context.APIContext) {
 	}
 
 	if err := models.NewTeam(team); err != nil {
-		if organization.IsErrTeamAlreadyExist(err) {
+		if organization.IsErrTeamAlreadyExist(err) || organization.IsErrTeamParentInvalid(err) || organization.IsErrRoleNotExist(err) {
 			ctx.Error(http.StatusUnprocessableEntity, "", err)
 		} else {
 			ctx.Error(http.StatusInternalServerError, "NewTeam", err)
@@ -254,6 +259,8 @@ func EditTeam(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/Team"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	form := web.GetForm(ctx).(*api.EditTeamOption)
 	team := ctx.Org.Team
@@ -266,6 +273,10 @@ func EditTeam(ctx *context.APIContext) {
 		team.CanCreateOrgRepo = team.IsOwnerTeam() || *form.CanCreateOrgRepo
 	}
 
+	if form.AutoAssignReviewer != nil {
+		team.AutoAssignReviewer = *form.AutoAssignReviewer
+	}
+
 	if len(form.Name) > 0 {
 		team.Name = form.Name
 	}
@@ -302,11 +313,38 @@ func EditTeam(ctx *context.APIContext) {
 		}
 	}
 
+	if !team.IsOwnerTeam() && form.RoleID != nil && team.RoleID != *form.RoleID {
+		isAuthChanged = true
+		team.RoleID = *form.RoleID
+	}
+
+	if form.ParentID != nil {
+		if err := organization.CheckTeamParent(ctx, team, *form.ParentID); err != nil {
+			if organization.IsErrTeamParentInvalid(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "CheckTeamParent", err)
+			}
+			return
+		}
+	}
+
 	if err := models.UpdateTeam(team, isAuthChanged, isIncludeAllChanged); err != nil {
-		ctx.Error(http.StatusInternalServerError, "EditTeam", err)
+		if organization.IsErrRoleNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "EditTeam", err)
+		}
 		return
 	}
 
+	if form.ParentID != nil {
+		if err := models.SetTeamParent(team, *form.ParentID); err != nil {
+			ctx.Error(http.StatusInternalServerError, "SetTeamParent", err)
+			return
+		}
+	}
+
 	apiTeam, err := convert.ToTeam(team)
 	if err != nil {
 		ctx.InternalServerError(err)
@@ -391,6 +429,88 @@ func GetTeamMembers(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, members)
 }
 
+// ListTeamChildren api for list the child teams of a team
+func ListTeamChildren(ctx *context.APIContext) {
+	// swagger:operation GET /teams/{id}/children organization orgListTeamChildren
+	// ---
+	// summary: List the child teams of a team
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the team
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TeamList"
+
+	children, err := ctx.Org.Team.GetChildren(ctx)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetChildren", err)
+		return
+	}
+
+	apiTeams, err := convert.ToTeams(children, false)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiTeams)
+}
+
+// GetTeamReviewerWorkload api for get the review workload of a team's members
+func GetTeamReviewerWorkload(ctx *context.APIContext) {
+	// swagger:operation GET /teams/{id}/reviews/workload organization orgGetTeamReviewerWorkload
+	// ---
+	// summary: List the open review requests of a team's members, least loaded first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the team
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TeamReviewerWorkloadList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	isMember, err := organization.IsOrganizationMember(ctx, ctx.Org.Team.OrgID, ctx.Doer.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsOrganizationMember", err)
+		return
+	} else if !isMember && !ctx.Doer.IsAdmin {
+		ctx.NotFound()
+		return
+	}
+
+	workloads, err := issue_service.GetTeamReviewerWorkloads(ctx, ctx.Org.Team)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetTeamReviewerWorkloads", err)
+		return
+	}
+
+	apiWorkloads := make([]*api.TeamReviewerWorkload, len(workloads))
+	for i, workload := range workloads {
+		apiWorkloads[i] = &api.TeamReviewerWorkload{
+			Reviewer:     convert.ToUser(workload.User, ctx.Doer),
+			OpenRequests: workload.OpenRequests,
+			Capacity:     workload.User.ReviewCapacity,
+			OutOfOffice:  workload.User.IsOutOfOffice(),
+			Busy:         workload.Busy,
+			Available:    workload.Available(),
+		}
+	}
+
+	ctx.JSON(http.StatusOK, apiWorkloads)
+}
+
 // GetTeamMember api for get a particular member of team
 func GetTeamMember(ctx *context.APIContext) {
 	// swagger:operation GET /teams/{id}/members/{username} organization orgListTeamMember
@@ -456,13 +576,19 @@ func AddTeamMember(ctx *context.APIContext) {
 	//     "$ref": "#/responses/empty"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	u := user.GetUserByParams(ctx)
 	if ctx.Written() {
 		return
 	}
 	if err := models.AddTeamMember(ctx.Org.Team, u.ID); err != nil {
-		ctx.Error(http.StatusInternalServerError, "AddMember", err)
+		if organization.IsErrOrgGuestOwner(err) || organization.IsErrOrgBotForeign(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "AddMember", err)
+		}
 		return
 	}
 	ctx.Status(http.StatusNoContent)
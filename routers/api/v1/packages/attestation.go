@@ -0,0 +1,211 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package packages
+
+import (
+	"net/http"
+
+	attestation_model "code.gitea.io/gitea/models/attestation"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/modules/intoto"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	attestation_service "code.gitea.io/gitea/services/attestation"
+)
+
+// ListPackageAttestations lists the attestations of a package version
+func ListPackageAttestations(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/attestations package listPackageAttestations
+	// ---
+	// summary: List the attestations of a package version
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: predicate_type
+	//   in: query
+	//   description: only show the attestations of the given predicate type, e.g. https://slsa.dev/provenance/v0.2
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AttestationList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listOptions := utils.GetListOptions(ctx)
+	attestations, count, err := attestation_model.FindAttestations(ctx, &attestation_model.FindAttestationsOptions{
+		ListOptions:   listOptions,
+		PackageID:     ctx.Package.Descriptor.Package.ID,
+		SubjectType:   attestation_model.SubjectTypePackage,
+		Subject:       ctx.Package.Descriptor.Version.LowerVersion,
+		PredicateType: ctx.FormTrim("predicate_type"),
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindAttestations", err)
+		return
+	}
+
+	apiAttestations := make([]*api.Attestation, len(attestations))
+	for i, a := range attestations {
+		if apiAttestations[i], err = convert.ToAttestation(ctx, a, ctx.Doer); err != nil {
+			ctx.Error(http.StatusInternalServerError, "ToAttestation", err)
+			return
+		}
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiAttestations)
+}
+
+// CreatePackageAttestation uploads an attestation of a package version
+func CreatePackageAttestation(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/{type}/{name}/{version}/attestations package createPackageAttestation
+	// ---
+	// summary: Upload an in-toto attestation, e.g. a SLSA provenance, of a package version
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreatePackageAttestationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Attestation"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreatePackageAttestationOption)
+
+	a, err := attestation_service.CreatePackageAttestation(ctx, ctx.Package.Descriptor, ctx.Doer, form.Content)
+	if err != nil {
+		if err == intoto.ErrInvalidAttestation {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "CreatePackageAttestation", err)
+		return
+	}
+
+	apiAttestation, err := convert.ToAttestation(ctx, a, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToAttestation", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, apiAttestation)
+}
+
+// DeletePackageAttestation deletes an attestation of a package version
+func DeletePackageAttestation(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/{type}/{name}/{version}/attestations/{id} package deletePackageAttestation
+	// ---
+	// summary: Delete an attestation of a package version
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the attestation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	a, err := attestation_model.GetAttestationByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if attestation_model.IsErrAttestationNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetAttestationByID", err)
+		}
+		return
+	}
+	if a.PackageID != ctx.Package.Descriptor.Package.ID || a.Subject != ctx.Package.Descriptor.Version.LowerVersion {
+		ctx.NotFound()
+		return
+	}
+
+	if err := attestation_model.DeleteAttestation(ctx, a); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteAttestation", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,260 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	attestation_model "code.gitea.io/gitea/models/attestation"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/intoto"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	attestation_service "code.gitea.io/gitea/services/attestation"
+)
+
+// ListAttestations lists the attestations of the commits and the tags of a repository
+func ListAttestations(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/attestations repository repoListAttestations
+	// ---
+	// summary: List the attestations of the commits and the tags of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: subject_type
+	//   in: query
+	//   description: only show the attestations of the given type of subject
+	//   type: string
+	//   enum: [commit, tag]
+	// - name: subject
+	//   in: query
+	//   description: only show the attestations of the given commit SHA or tag name
+	//   type: string
+	// - name: predicate_type
+	//   in: query
+	//   description: only show the attestations of the given predicate type, e.g. https://slsa.dev/provenance/v0.2
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AttestationList"
+
+	listOptions := utils.GetListOptions(ctx)
+	attestations, count, err := attestation_model.FindAttestations(ctx, &attestation_model.FindAttestationsOptions{
+		ListOptions:   listOptions,
+		RepoID:        ctx.Repo.Repository.ID,
+		SubjectType:   attestation_model.SubjectType(ctx.FormTrim("subject_type")),
+		Subject:       ctx.FormTrim("subject"),
+		PredicateType: ctx.FormTrim("predicate_type"),
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindAttestations", err)
+		return
+	}
+
+	apiAttestations := make([]*api.Attestation, len(attestations))
+	for i, a := range attestations {
+		if apiAttestations[i], err = convert.ToAttestation(ctx, a, ctx.Doer); err != nil {
+			ctx.Error(http.StatusInternalServerError, "ToAttestation", err)
+			return
+		}
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiAttestations)
+}
+
+func getRepoAttestation(ctx *context.APIContext) *attestation_model.Attestation {
+	a, err := attestation_model.GetAttestationByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if attestation_model.IsErrAttestationNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetAttestationByID", err)
+		}
+		return nil
+	}
+	if a.RepoID != ctx.Repo.Repository.ID {
+		ctx.NotFound()
+		return nil
+	}
+	return a
+}
+
+// GetAttestation returns an attestation of a commit or a tag of a repository
+func GetAttestation(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/attestations/{id} repository repoGetAttestation
+	// ---
+	// summary: Get an attestation of a commit or a tag of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the attestation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Attestation"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	a := getRepoAttestation(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	apiAttestation, err := convert.ToAttestation(ctx, a, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToAttestation", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiAttestation)
+}
+
+// CreateAttestation uploads an attestation of a commit or a tag of a repository
+func CreateAttestation(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/attestations repository repoCreateAttestation
+	// ---
+	// summary: Upload an in-toto attestation, e.g. a SLSA provenance, of a commit or a tag of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateAttestationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Attestation"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateAttestationOption)
+
+	subjectType := attestation_model.SubjectType(form.SubjectType)
+	subject := strings.TrimSpace(form.Subject)
+	if subjectType == attestation_model.SubjectTypeCommit {
+		commit, err := ctx.Repo.GitRepo.GetCommit(subject)
+		if err != nil {
+			if git.IsErrNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("commit does not exist: %s", subject))
+				return
+			}
+			ctx.Error(http.StatusInternalServerError, "GetCommit", err)
+			return
+		}
+		subject = commit.ID.String()
+	} else if !git.IsValidRefPattern(subject) {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("invalid tag name: %s", subject))
+		return
+	}
+
+	a, err := attestation_service.CreateRepoAttestation(ctx, ctx.Repo.Repository, ctx.Doer, subjectType, subject, form.Content)
+	if err != nil {
+		if err == intoto.ErrInvalidAttestation {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "CreateRepoAttestation", err)
+		return
+	}
+
+	apiAttestation, err := convert.ToAttestation(ctx, a, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToAttestation", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, apiAttestation)
+}
+
+// DeleteAttestation deletes an attestation of a commit or a tag of a repository
+func DeleteAttestation(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/attestations/{id} repository repoDeleteAttestation
+	// ---
+	// summary: Delete an attestation of a commit or a tag of a repository
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the attestation
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	a := getRepoAttestation(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := attestation_model.DeleteAttestation(ctx, a); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteAttestation", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
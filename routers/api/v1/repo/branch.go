@@ -370,6 +370,17 @@ func ListBranchProtections(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, apiBps)
 }
 
+// validateStatusCheckContexts returns false and writes the response if a required status check is an invalid pattern
+func validateStatusCheckContexts(ctx *context.APIContext, checks []string) bool {
+	for _, check := range checks {
+		if _, err := git_model.CompileStatusCheck(check); err != nil {
+			ctx.Error(http.StatusUnprocessableEntity, "CompileStatusCheck", err)
+			return false
+		}
+	}
+	return true
+}
+
 // CreateBranchProtection creates a branch protection for a repo
 func CreateBranchProtection(ctx *context.APIContext) {
 	// swagger:operation POST /repos/{owner}/{repo}/branch_protections repository repoCreateBranchProtection
@@ -407,6 +418,10 @@ func CreateBranchProtection(ctx *context.APIContext) {
 	form := web.GetForm(ctx).(*api.CreateBranchProtectionOption)
 	repo := ctx.Repo.Repository
 
+	if !validateStatusCheckContexts(ctx, form.StatusCheckContexts) {
+		return
+	}
+
 	// Currently protection must match an actual branch
 	if !git.IsBranchExist(ctx.Req.Context(), ctx.Repo.Repository.RepoPath(), form.BranchName) {
 		ctx.NotFound()
@@ -427,6 +442,17 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		requiredApprovals = form.RequiredApprovals
 	}
 
+	minimumDiffCoverage := form.MinimumDiffCoverage
+	if minimumDiffCoverage < 0 || minimumDiffCoverage > 100 {
+		ctx.Error(http.StatusUnprocessableEntity, "", "minimum_diff_coverage must be between 0 and 100")
+		return
+	}
+
+	if form.IgnoreLastCommittersApprovals < 0 {
+		ctx.Error(http.StatusUnprocessableEntity, "", "ignore_last_committers_approvals must not be negative")
+		return
+	}
+
 	whitelistUsers, err := user_model.GetUserIDsByNames(form.PushWhitelistUsernames, false)
 	if err != nil {
 		if user_model.IsErrUserNotExist(err) {
@@ -499,10 +525,14 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		BlockOnRejectedReviews:        form.BlockOnRejectedReviews,
 		BlockOnOfficialReviewRequests: form.BlockOnOfficialReviewRequests,
 		DismissStaleApprovals:         form.DismissStaleApprovals,
+		IgnoreLastCommittersApprovals: form.IgnoreLastCommittersApprovals,
+		DismissPusherApprovals:        form.DismissPusherApprovals,
 		RequireSignedCommits:          form.RequireSignedCommits,
 		ProtectedFilePatterns:         form.ProtectedFilePatterns,
 		UnprotectedFilePatterns:       form.UnprotectedFilePatterns,
 		BlockOnOutdatedBranch:         form.BlockOnOutdatedBranch,
+		BlockOnCodeScanningAlerts:     form.BlockOnCodeScanningAlerts,
+		MinimumDiffCoverage:           minimumDiffCoverage,
 	}
 
 	err = git_model.UpdateProtectBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
@@ -586,6 +616,10 @@ func EditBranchProtection(ctx *context.APIContext) {
 		return
 	}
 
+	if !validateStatusCheckContexts(ctx, form.StatusCheckContexts) {
+		return
+	}
+
 	if form.EnablePush != nil {
 		if !*form.EnablePush {
 			protectBranch.CanPush = false
@@ -638,6 +672,18 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.DismissStaleApprovals = *form.DismissStaleApprovals
 	}
 
+	if form.IgnoreLastCommittersApprovals != nil {
+		if *form.IgnoreLastCommittersApprovals < 0 {
+			ctx.Error(http.StatusUnprocessableEntity, "", "ignore_last_committers_approvals must not be negative")
+			return
+		}
+		protectBranch.IgnoreLastCommittersApprovals = *form.IgnoreLastCommittersApprovals
+	}
+
+	if form.DismissPusherApprovals != nil {
+		protectBranch.DismissPusherApprovals = *form.DismissPusherApprovals
+	}
+
 	if form.RequireSignedCommits != nil {
 		protectBranch.RequireSignedCommits = *form.RequireSignedCommits
 	}
@@ -654,6 +700,18 @@ func EditBranchProtection(ctx *context.APIContext) {
 		protectBranch.BlockOnOutdatedBranch = *form.BlockOnOutdatedBranch
 	}
 
+	if form.BlockOnCodeScanningAlerts != nil {
+		protectBranch.BlockOnCodeScanningAlerts = *form.BlockOnCodeScanningAlerts
+	}
+
+	if form.MinimumDiffCoverage != nil {
+		if *form.MinimumDiffCoverage < 0 || *form.MinimumDiffCoverage > 100 {
+			ctx.Error(http.StatusUnprocessableEntity, "", "minimum_diff_coverage must be between 0 and 100")
+			return
+		}
+		protectBranch.MinimumDiffCoverage = *form.MinimumDiffCoverage
+	}
+
 	var whitelistUsers []int64
 	if form.PushWhitelistUsernames != nil {
 		whitelistUsers, err = user_model.GetUserIDsByNames(form.PushWhitelistUsernames, false)
@@ -820,3 +878,58 @@ func DeleteBranchProtection(ctx *context.APIContext) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// RenameDefaultBranch renames the default branch of a repository
+func RenameDefaultBranch(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/default_branch/rename repository repoRenameDefaultBranch
+	// ---
+	// summary: Rename the default branch of a repository
+	// description: The open pull requests are retargeted to the renamed branch, its protection and the branch filters
+	//   of the webhooks of the repository are updated, and the old name redirects to the renamed branch.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/RenameDefaultBranchOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/DefaultBranchRename"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: A branch or a tag with the new name already exists.
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	opt := web.GetForm(ctx).(*api.RenameDefaultBranchOption)
+	result, err := repo_service.RenameDefaultBranch(ctx, ctx.Doer, ctx.Repo.Repository, opt.NewName)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo_service.ErrDefaultBranchNotRenamable):
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		case models.IsErrTagAlreadyExists(err):
+			ctx.Error(http.StatusConflict, "", "The tag with the same name already exists.")
+		case models.IsErrBranchAlreadyExists(err), models.IsErrBranchNameConflict(err):
+			ctx.Error(http.StatusConflict, "", "The branch with the same name already exists.")
+		default:
+			ctx.Error(http.StatusInternalServerError, "RenameDefaultBranch", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, utils.ToDefaultBranchRename(result))
+}
@@ -0,0 +1,177 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"time"
+
+	"code.gitea.io/gitea/models"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/modules/git"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/repository/files"
+)
+
+// CherryPickCommit cherry-picks a commit onto a branch
+func CherryPickCommit(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/git/commits/{sha}/cherry-pick repository repoCherryPickCommit
+	// ---
+	// summary: Cherry-pick a commit onto a branch
+	// description: If `new_branch` isn't given and the branch is protected, the commit is pushed to a new branch and a
+	//   pull request is opened to merge it into the protected branch.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: a git ref or commit sha
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CherryPickCommitOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/CherryPickResponse"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/CherryPickResponse"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	cherryPickCommit(ctx, false)
+}
+
+// RevertCommit reverts a commit on a branch
+func RevertCommit(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/git/commits/{sha}/revert repository repoRevertCommit
+	// ---
+	// summary: Revert a commit on a branch
+	// description: If `new_branch` isn't given and the branch is protected, the revert is pushed to a new branch and a
+	//   pull request is opened to merge it into the protected branch.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: a git ref or commit sha
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CherryPickCommitOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/CherryPickResponse"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/CherryPickResponse"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	cherryPickCommit(ctx, true)
+}
+
+func cherryPickCommit(ctx *context.APIContext, revert bool) {
+	form := web.GetForm(ctx).(*api.CherryPickCommitOption)
+
+	if ctx.Repo.Repository.IsMirror || ctx.Repo.Repository.IsArchived {
+		ctx.Error(http.StatusForbidden, "", "cannot commit to a mirror or an archived repository")
+		return
+	}
+
+	opts := &files.CherryPickCommitOptions{
+		SHA:       ctx.Params(":sha"),
+		Revert:    revert,
+		Branch:    form.BranchName,
+		NewBranch: form.NewBranchName,
+		Message:   form.Message,
+		Committer: &files.IdentityOptions{
+			Name:  form.Committer.Name,
+			Email: form.Committer.Email,
+		},
+		Author: &files.IdentityOptions{
+			Name:  form.Author.Name,
+			Email: form.Author.Email,
+		},
+		Dates: &files.CommitDateOptions{
+			Author:    form.Dates.Author,
+			Committer: form.Dates.Committer,
+		},
+		Signoff: form.Signoff,
+	}
+	if opts.Dates.Author.IsZero() {
+		opts.Dates.Author = time.Now()
+	}
+	if opts.Dates.Committer.IsZero() {
+		opts.Dates.Committer = time.Now()
+	}
+
+	result, err := files.CherryPickCommit(ctx, ctx.Repo.Repository, ctx.Repo.GitRepo, ctx.Doer, opts)
+	if err != nil {
+		switch {
+		case models.IsErrCherryPickConflict(err):
+			conflictErr := err.(models.ErrCherryPickConflict)
+			ctx.JSON(http.StatusConflict, &api.CherryPickResponse{
+				Branch:          conflictErr.Branch,
+				ConflictedFiles: conflictErr.ConflictedFiles,
+			})
+		case models.IsErrUserCannotCommit(err):
+			ctx.Error(http.StatusForbidden, "CherryPickCommit", err)
+		case git.IsErrNotExist(err), git.IsErrBranchNotExist(err):
+			ctx.NotFound()
+		case models.IsErrBranchAlreadyExists(err), models.IsErrCommitIDDoesNotMatch(err):
+			ctx.Error(http.StatusUnprocessableEntity, "CherryPickCommit", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "CherryPickCommit", err)
+		}
+		return
+	}
+
+	apiResult := &api.CherryPickResponse{
+		Commit:       result.Commit,
+		Verification: result.Verification,
+		Branch:       result.Branch,
+	}
+	if result.PullRequest != nil {
+		apiResult.PullRequest = convert.ToAPIPullRequest(ctx, result.PullRequest, ctx.Doer)
+	}
+	ctx.JSON(http.StatusCreated, apiResult)
+}
@@ -0,0 +1,310 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	codescanning_model "code.gitea.io/gitea/models/codescanning"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/sarif"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	codescanning_service "code.gitea.io/gitea/services/codescanning"
+)
+
+// UploadCodeScanningSarif uploads a SARIF report of a code scanning tool
+func UploadCodeScanningSarif(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/code-scanning/sarifs repository repoUploadCodeScanningSarif
+	// ---
+	// summary: Upload a SARIF report of a code scanning tool for a commit
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/UploadSarifOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/CodeScanningAnalysisList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.UploadSarifOption)
+
+	if !strings.HasPrefix(form.Ref, "refs/") {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("ref must be a full git reference: %s", form.Ref))
+		return
+	}
+
+	commit, err := ctx.Repo.GitRepo.GetCommit(form.CommitSHA)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.NotFound(form.CommitSHA)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "GetCommit", err)
+		return
+	}
+
+	report, err := sarif.Decode(form.Sarif)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "", err)
+		return
+	}
+
+	analyses, err := codescanning_service.UploadReport(ctx, ctx.Repo.Repository, ctx.Doer, commit.ID.String(), form.Ref, report)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "UploadReport", err)
+		return
+	}
+
+	apiAnalyses := make([]*api.CodeScanningAnalysis, len(analyses))
+	for i, analysis := range analyses {
+		apiAnalyses[i] = convert.ToCodeScanningAnalysis(analysis)
+	}
+	ctx.JSON(http.StatusCreated, apiAnalyses)
+}
+
+// ListCodeScanningAlerts lists the code scanning alerts of a repository
+func ListCodeScanningAlerts(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/code-scanning/alerts repository repoListCodeScanningAlerts
+	// ---
+	// summary: List a repository's code scanning alerts
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: only show alerts in the given state
+	//   type: string
+	//   enum: [open, dismissed, fixed]
+	// - name: sha
+	//   in: query
+	//   description: only show alerts found in the analyses of the given commit
+	//   type: string
+	// - name: tool
+	//   in: query
+	//   description: only show alerts of the given tool
+	//   type: string
+	// - name: severity
+	//   in: query
+	//   description: only show alerts of the given severity
+	//   type: string
+	//   enum: [error, warning, note]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeScanningAlertList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	listOptions := utils.GetListOptions(ctx)
+	opts := &codescanning_model.FindAlertsOptions{
+		ListOptions: listOptions,
+		RepoID:      ctx.Repo.Repository.ID,
+		CommitSHA:   ctx.FormTrim("sha"),
+		ToolName:    ctx.FormTrim("tool"),
+		Severity:    ctx.FormTrim("severity"),
+	}
+	if state := ctx.FormTrim("state"); state != "" {
+		alertState, ok := codescanning_model.AlertStateFromString(state)
+		if !ok {
+			ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("invalid state: %s", state))
+			return
+		}
+		opts.State = &alertState
+	}
+
+	alerts, count, err := codescanning_model.FindAlerts(ctx, opts)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindAlerts", err)
+		return
+	}
+
+	apiAlerts := make([]*api.CodeScanningAlert, len(alerts))
+	for i, alert := range alerts {
+		if apiAlerts[i], err = convert.ToCodeScanningAlert(ctx, alert, ctx.Doer); err != nil {
+			ctx.Error(http.StatusInternalServerError, "ToCodeScanningAlert", err)
+			return
+		}
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiAlerts)
+}
+
+func getCodeScanningAlert(ctx *context.APIContext) *codescanning_model.Alert {
+	alert, err := codescanning_model.GetAlertByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if err == codescanning_model.ErrAlertNotExist {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetAlertByID", err)
+		}
+		return nil
+	}
+	return alert
+}
+
+// GetCodeScanningAlert returns a code scanning alert of a repository
+func GetCodeScanningAlert(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/code-scanning/alerts/{id} repository repoGetCodeScanningAlert
+	// ---
+	// summary: Get a code scanning alert
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the alert
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeScanningAlert"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	alert := getCodeScanningAlert(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	apiAlert, err := convert.ToCodeScanningAlert(ctx, alert, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToCodeScanningAlert", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiAlert)
+}
+
+// EditCodeScanningAlert dismisses or reopens a code scanning alert
+func EditCodeScanningAlert(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/code-scanning/alerts/{id} repository repoEditCodeScanningAlert
+	// ---
+	// summary: Dismiss or reopen a code scanning alert
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the alert
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditCodeScanningAlertOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CodeScanningAlert"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditCodeScanningAlertOption)
+	alert := getCodeScanningAlert(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	var err error
+	if form.State == "dismissed" {
+		if alert.State == codescanning_model.AlertStateFixed {
+			ctx.Error(http.StatusUnprocessableEntity, "", "fixed alerts can not be dismissed")
+			return
+		}
+		if form.DismissedReason == "" {
+			ctx.Error(http.StatusUnprocessableEntity, "", "dismissed_reason is required to dismiss an alert")
+			return
+		}
+		err = codescanning_service.DismissAlert(ctx, alert, ctx.Doer, form.DismissedReason, form.DismissedComment)
+	} else {
+		err = codescanning_service.ReopenAlert(ctx, alert)
+	}
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "EditCodeScanningAlert", err)
+		return
+	}
+
+	apiAlert, err := convert.ToCodeScanningAlert(ctx, alert, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToCodeScanningAlert", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiAlert)
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"code.gitea.io/gitea/models"
+	"code.gitea.io/gitea/models/organization"
 	"code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -175,12 +176,29 @@ func AddCollaborator(ctx *context.APIContext) {
 		return
 	}
 
+	hasRole := form.RoleID != nil && *form.RoleID > 0
+	if hasRole {
+		if _, err := organization.GetOrgRoleByID(ctx, ctx.Repo.Repository.OwnerID, *form.RoleID); err != nil {
+			if organization.IsErrRoleNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetOrgRoleByID", err)
+			}
+			return
+		}
+	}
+
 	if err := repo_module.AddCollaborator(ctx.Repo.Repository, collaborator); err != nil {
 		ctx.Error(http.StatusInternalServerError, "AddCollaborator", err)
 		return
 	}
 
-	if form.Permission != nil {
+	if hasRole {
+		if err := models.SetCollaborationRole(ctx.Repo.Repository, collaborator.ID, *form.RoleID); err != nil {
+			ctx.Error(http.StatusInternalServerError, "SetCollaborationRole", err)
+			return
+		}
+	} else if form.Permission != nil {
 		if err := repo_model.ChangeCollaborationAccessMode(ctx.Repo.Repository, collaborator.ID, perm.ParseAccessMode(*form.Permission)); err != nil {
 			ctx.Error(http.StatusInternalServerError, "ChangeCollaborationAccessMode", err)
 			return
@@ -0,0 +1,114 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/modules/git"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+)
+
+// GetCommitSignatureVerification verifies the signature of a commit
+func GetCommitSignatureVerification(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/git/commits/{sha}/verification repository repoGetCommitSignatureVerification
+	// ---
+	// summary: Verify the signature of a commit against the GPG and SSH keys known by the instance
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: a git ref or commit sha
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CommitSignatureVerification"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	sha := ctx.Params(":sha")
+	if !git.IsValidRefPattern(sha) {
+		ctx.Error(http.StatusUnprocessableEntity, "no valid ref or sha", fmt.Sprintf("no valid ref or sha: %s", sha))
+		return
+	}
+	commit, err := ctx.Repo.GitRepo.GetCommit(sha)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.NotFound(sha)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "GetCommit", err)
+		return
+	}
+	writeCommitSignatureVerification(ctx, commit)
+}
+
+// VerifyCommitSignature verifies the signature of a commit which has not been pushed to the repository
+func VerifyCommitSignature(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/git/verification repository repoVerifyCommitSignature
+	// ---
+	// summary: Verify the signature of a raw commit object in the trust model of the repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/VerifyCommitSignatureOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CommitSignatureVerification"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.VerifyCommitSignatureOption)
+	sha := git.ComputeCommitHash([]byte(form.Commit))
+	commit, err := git.CommitFromReader(ctx.Repo.GitRepo, sha, strings.NewReader(form.Commit))
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "CommitFromReader", err)
+		return
+	}
+	writeCommitSignatureVerification(ctx, commit)
+}
+
+func writeCommitSignatureVerification(ctx *context.APIContext, commit *git.Commit) {
+	verification, err := convert.ToCommitSignatureVerification(ctx.Repo.Repository, commit, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToCommitSignatureVerification", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, verification)
+}
@@ -0,0 +1,302 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"strconv"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+)
+
+// ListComponents list the components of a repository
+func ListComponents(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/components repository repoListComponents
+	// ---
+	// summary: List a repository's components with their numbers of open issues and pull requests
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ComponentList"
+
+	components, err := issues_model.GetComponents(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetComponents", err)
+		return
+	}
+	if err := issues_model.LoadComponentIssueCounts(ctx, components); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadComponentIssueCounts", err)
+		return
+	}
+
+	apiComponents := make([]*api.Component, len(components))
+	for i := range components {
+		apiComponents[i] = convert.ToAPIComponent(components[i])
+	}
+	ctx.JSON(http.StatusOK, &apiComponents)
+}
+
+// GetComponent get a component of a repository by ID and if not available by name
+func GetComponent(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/components/{id} repository repoGetComponent
+	// ---
+	// summary: Get a component
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: the component to get, identified by ID and if not available by name
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Component"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	c := getComponentByIDOrName(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.LoadComponentIssueCounts(ctx, []*issues_model.Component{c}); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadComponentIssueCounts", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToAPIComponent(c))
+}
+
+// CreateComponent create a component of a repository
+func CreateComponent(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/components repository repoCreateComponent
+	// ---
+	// summary: Create a component
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateComponentOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Component"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateComponentOption)
+	c := &issues_model.Component{
+		RepoID:       ctx.Repo.Repository.ID,
+		Name:         form.Name,
+		Description:  form.Description,
+		Paths:        form.Paths,
+		OwnerUserIDs: form.OwnerUserIDs,
+		OwnerTeamIDs: form.OwnerTeamIDs,
+		LabelIDs:     form.LabelIDs,
+		NotifyOwners: form.NotifyOwners == nil || *form.NotifyOwners,
+	}
+
+	if err := issues_model.CreateComponent(ctx, c); err != nil {
+		handleComponentError(ctx, "CreateComponent", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPIComponent(c))
+}
+
+// EditComponent modify a component of a repository by ID and if not available by name
+func EditComponent(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/components/{id} repository repoEditComponent
+	// ---
+	// summary: Update a component
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: the component to edit, identified by ID and if not available by name
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditComponentOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Component"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditComponentOption)
+	c := getComponentByIDOrName(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if form.Name != nil {
+		c.Name = *form.Name
+	}
+	if form.Description != nil {
+		c.Description = *form.Description
+	}
+	if form.Paths != nil {
+		c.Paths = form.Paths
+	}
+	if form.OwnerUserIDs != nil {
+		c.OwnerUserIDs = form.OwnerUserIDs
+	}
+	if form.OwnerTeamIDs != nil {
+		c.OwnerTeamIDs = form.OwnerTeamIDs
+	}
+	if form.LabelIDs != nil {
+		c.LabelIDs = form.LabelIDs
+	}
+	if form.NotifyOwners != nil {
+		c.NotifyOwners = *form.NotifyOwners
+	}
+
+	if err := issues_model.UpdateComponent(ctx, c); err != nil {
+		handleComponentError(ctx, "UpdateComponent", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIComponent(c))
+}
+
+// DeleteComponent delete a component of a repository by ID and if not available by name
+func DeleteComponent(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/components/{id} repository repoDeleteComponent
+	// ---
+	// summary: Delete a component
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: the component to delete, identified by ID and if not available by name
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	c := getComponentByIDOrName(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.DeleteComponent(ctx, ctx.Repo.Repository.ID, c.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteComponent", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func handleComponentError(ctx *context.APIContext, name string, err error) {
+	switch {
+	case issues_model.IsErrComponentAlreadyExist(err):
+		ctx.Error(http.StatusConflict, name, err)
+	case issues_model.IsErrInvalidComponentPath(err):
+		ctx.Error(http.StatusUnprocessableEntity, name, err)
+	default:
+		ctx.Error(http.StatusInternalServerError, name, err)
+	}
+}
+
+// getComponentByIDOrName get a component by ID and if not available by name
+func getComponentByIDOrName(ctx *context.APIContext) *issues_model.Component {
+	param := ctx.Params(":id")
+	if id, _ := strconv.ParseInt(param, 10, 64); id != 0 {
+		c, err := issues_model.GetComponentByID(ctx, ctx.Repo.Repository.ID, id)
+		if err == nil {
+			return c
+		} else if !issues_model.IsErrComponentNotExist(err) {
+			ctx.Error(http.StatusInternalServerError, "GetComponentByID", err)
+			return nil
+		}
+	}
+
+	c, err := issues_model.GetComponentByName(ctx, ctx.Repo.Repository.ID, param)
+	if err != nil {
+		if issues_model.IsErrComponentNotExist(err) {
+			ctx.NotFound()
+			return nil
+		}
+		ctx.Error(http.StatusInternalServerError, "GetComponentByName", err)
+		return nil
+	}
+	return c
+}
@@ -0,0 +1,174 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/modules/coverage"
+	"code.gitea.io/gitea/modules/git"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	coverage_service "code.gitea.io/gitea/services/coverage"
+)
+
+// UploadCoverage uploads a test coverage report for a commit
+func UploadCoverage(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/coverage repository repoUploadCoverage
+	// ---
+	// summary: Upload a test coverage report for a commit
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/UploadCoverageOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/CommitCoverage"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.UploadCoverageOption)
+
+	commit, err := ctx.Repo.GitRepo.GetCommit(form.CommitSHA)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.NotFound(form.CommitSHA)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "GetCommit", err)
+		return
+	}
+
+	report, err := coverage.Decode(form.Format, form.Report)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "", err)
+		return
+	}
+
+	if _, err := coverage_service.UploadReport(ctx, ctx.Repo.Repository, ctx.Doer, commit.ID.String(), form.Flag, form.Format, report); err != nil {
+		ctx.Error(http.StatusInternalServerError, "UploadReport", err)
+		return
+	}
+
+	commitCoverage, err := coverage_service.GetCommitCoverage(ctx, ctx.Repo.Repository.ID, commit.ID.String())
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetCommitCoverage", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToCommitCoverage(commitCoverage))
+}
+
+// GetCommitCoverage returns the test coverage of a commit
+func GetCommitCoverage(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/coverage/{sha} repository repoGetCommitCoverage
+	// ---
+	// summary: Get the test coverage of a commit merged over all uploaded reports
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: SHA of the commit
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CommitCoverage"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	commitCoverage, err := coverage_service.GetCommitCoverage(ctx, ctx.Repo.Repository.ID, ctx.Params(":sha"))
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetCommitCoverage", err)
+		return
+	}
+	if commitCoverage == nil {
+		ctx.NotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToCommitCoverage(commitCoverage))
+}
+
+// GetPullRequestCoverage returns the test coverage of the lines added by a pull request
+func GetPullRequestCoverage(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/coverage repository repoGetPullRequestCoverage
+	// ---
+	// summary: Get the test coverage of the lines added by a pull request
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullRequestCoverage"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return
+	}
+
+	diffCoverage, err := coverage_service.GetPullRequestDiffCoverage(ctx, pr)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetPullRequestDiffCoverage", err)
+		return
+	}
+	if diffCoverage == nil {
+		ctx.NotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToPullRequestCoverage(diffCoverage))
+}
@@ -0,0 +1,363 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	deployment_model "code.gitea.io/gitea/models/deployment"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	deployment_service "code.gitea.io/gitea/services/deployment"
+)
+
+// ListDeployments lists the deployments of a repository
+func ListDeployments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/deployments repository repoListDeployments
+	// ---
+	// summary: List a repository's deployments
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: query
+	//   description: only show deployments of the given commit
+	//   type: string
+	// - name: ref
+	//   in: query
+	//   description: only show deployments of the given ref
+	//   type: string
+	// - name: environment
+	//   in: query
+	//   description: only show deployments to the given environment
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/DeploymentList"
+
+	listOptions := utils.GetListOptions(ctx)
+	deployments, count, err := deployment_model.FindDeployments(ctx, &deployment_model.FindDeploymentsOptions{
+		ListOptions: listOptions,
+		RepoID:      ctx.Repo.Repository.ID,
+		SHA:         ctx.FormTrim("sha"),
+		Ref:         ctx.FormTrim("ref"),
+		Environment: ctx.FormTrim("environment"),
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindDeployments", err)
+		return
+	}
+
+	apiDeployments := make([]*api.Deployment, len(deployments))
+	for i, d := range deployments {
+		if apiDeployments[i], err = convert.ToDeployment(ctx, ctx.Repo.Repository, d); err != nil {
+			ctx.Error(http.StatusInternalServerError, "ToDeployment", err)
+			return
+		}
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiDeployments)
+}
+
+// CreateDeployment creates a deployment
+func CreateDeployment(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/deployments repository repoCreateDeployment
+	// ---
+	// summary: Create a deployment of a ref to an environment
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateDeploymentOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Deployment"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateDeploymentOption)
+
+	d, err := deployment_service.CreateDeployment(ctx, ctx.Repo.Repository, ctx.Repo.GitRepo, ctx.Doer, form.Ref, form.Environment, form.Description)
+	if err != nil {
+		if deployment_service.IsErrInvalidRef(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "CreateDeployment", err)
+		return
+	}
+
+	apiDeployment, err := convert.ToDeployment(ctx, ctx.Repo.Repository, d)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToDeployment", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, apiDeployment)
+}
+
+func getDeployment(ctx *context.APIContext) *deployment_model.Deployment {
+	d, err := deployment_model.GetDeploymentByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, deployment_model.ErrDeploymentNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetDeploymentByID", err)
+		}
+		return nil
+	}
+	return d
+}
+
+// GetDeployment returns a deployment
+func GetDeployment(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/deployments/{id} repository repoGetDeployment
+	// ---
+	// summary: Get a deployment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the deployment
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Deployment"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	d := getDeployment(ctx)
+	if ctx.Written() {
+		return
+	}
+	apiDeployment, err := convert.ToDeployment(ctx, ctx.Repo.Repository, d)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToDeployment", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiDeployment)
+}
+
+// DeleteDeployment deletes a deployment
+func DeleteDeployment(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/deployments/{id} repository repoDeleteDeployment
+	// ---
+	// summary: Delete a deployment which is not active anymore
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the deployment
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	d := getDeployment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := deployment_model.DeleteDeployment(ctx, d); err != nil {
+		if errors.Is(err, deployment_model.ErrDeploymentActive) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "DeleteDeployment", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListDeploymentStatuses lists the state transitions of a deployment
+func ListDeploymentStatuses(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/deployments/{id}/statuses repository repoListDeploymentStatuses
+	// ---
+	// summary: List the statuses of a deployment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the deployment
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/DeploymentStatusList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	d := getDeployment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	statuses, count, err := deployment_model.GetStatuses(ctx, d.ID, listOptions)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetStatuses", err)
+		return
+	}
+
+	apiStatuses := make([]*api.DeploymentStatus, len(statuses))
+	for i, s := range statuses {
+		if apiStatuses[i], err = convert.ToDeploymentStatus(ctx, s); err != nil {
+			ctx.Error(http.StatusInternalServerError, "ToDeploymentStatus", err)
+			return
+		}
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiStatuses)
+}
+
+// CreateDeploymentStatus adds a state transition to a deployment
+func CreateDeploymentStatus(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/deployments/{id}/statuses repository repoCreateDeploymentStatus
+	// ---
+	// summary: Add a status to a deployment
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the deployment
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateDeploymentStatusOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/DeploymentStatus"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateDeploymentStatusOption)
+
+	d := getDeployment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	status := &deployment_model.Status{
+		State:          deployment_model.State(form.State),
+		Description:    form.Description,
+		LogURL:         form.LogURL,
+		EnvironmentURL: form.EnvironmentURL,
+	}
+	if err := deployment_service.CreateStatus(ctx, ctx.Repo.Repository, ctx.Doer, d, status); err != nil {
+		ctx.Error(http.StatusInternalServerError, "CreateStatus", err)
+		return
+	}
+
+	apiStatus, err := convert.ToDeploymentStatus(ctx, status)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ToDeploymentStatus", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, apiStatus)
+}
@@ -0,0 +1,255 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// defaultExpertsLimit is the number of experts returned when the limit isn't given
+const defaultExpertsLimit = 10
+
+// expertiseTreePath returns the cleaned path of the file or the directory of the query, empty for the root directory
+func expertiseTreePath(ctx *context.APIContext) string {
+	return strings.Trim(path.Clean("/"+ctx.FormString("path")), "/")
+}
+
+// expertsLimit returns the maximum number of experts to return
+func expertsLimit(ctx *context.APIContext) int {
+	limit := ctx.FormInt("limit")
+	if limit <= 0 {
+		return defaultExpertsLimit
+	}
+	return convert.ToCorrectPageSize(limit)
+}
+
+// GetExpertise returns the experts of a file or a directory of a repository
+func GetExpertise(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/expertise repository repoGetExpertise
+	// ---
+	// summary: Get who knows a file or a directory of the default branch of a repository best
+	// description: The expertise is computed from blame and the history of the default branch by the expertise indexer,
+	//              it is not found when the indexer is disabled.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: path
+	//   in: query
+	//   description: path of the file or the directory, the root directory by default
+	//   type: string
+	// - name: limit
+	//   in: query
+	//   description: maximum number of experts to return, 10 by default
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoExpertise"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !setting.Indexer.ExpertiseIndexerEnabled {
+		ctx.NotFound()
+		return
+	}
+
+	treePath := expertiseTreePath(ctx)
+	experts, err := repo_model.GetExperts(ctx, ctx.Repo.Repository.ID, treePath)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetExperts", err)
+		return
+	}
+
+	expertise := &api.RepoExpertise{
+		Path:      treePath,
+		BusFactor: experts.BusFactor(),
+		Authors:   len(experts),
+	}
+	if limit := expertsLimit(ctx); len(experts) > limit {
+		experts = experts[:limit]
+	}
+	expertise.Experts = convert.ToExpertList(experts, ctx.Doer)
+	ctx.JSON(http.StatusOK, expertise)
+}
+
+// ListBusFactors returns the bus factor of a directory of a repository and of its subdirectories
+func ListBusFactors(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/expertise/bus_factor repository repoListBusFactors
+	// ---
+	// summary: Get the bus factor of a directory of the default branch of a repository and of its direct subdirectories
+	// description: The bus factor is the smallest number of authors who have last changed more than half of the lines,
+	//              it is not found when the expertise indexer is disabled.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: path
+	//   in: query
+	//   description: path of the directory, the root directory by default
+	//   type: string
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/DirectoryBusFactorList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !setting.Indexer.ExpertiseIndexerEnabled {
+		ctx.NotFound()
+		return
+	}
+
+	treePath := expertiseTreePath(ctx)
+	dirs, err := repo_model.GetExpertiseSubdirectories(ctx, ctx.Repo.Repository.ID, treePath)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetExpertiseSubdirectories", err)
+		return
+	}
+
+	busFactors := make([]*api.DirectoryBusFactor, 0, len(dirs)+1)
+	for _, dir := range append([]string{treePath}, dirs...) {
+		experts, err := repo_model.GetExperts(ctx, ctx.Repo.Repository.ID, dir)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetExperts", err)
+			return
+		}
+		busFactor := &api.DirectoryBusFactor{
+			Path:      dir,
+			BusFactor: experts.BusFactor(),
+			Authors:   len(experts),
+		}
+		if len(experts) > 0 {
+			busFactor.TopExpert = convert.ToExpert(experts[0], ctx.Doer)
+		}
+		busFactors = append(busFactors, busFactor)
+	}
+	ctx.JSON(http.StatusOK, busFactors)
+}
+
+// ListSuggestedReviewers returns the experts of the files changed by a pull request who can review it
+func ListSuggestedReviewers(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/suggested_reviewers repository repoListSuggestedReviewers
+	// ---
+	// summary: Suggest reviewers for a pull request from the expertise on the files it changes
+	// description: The poster of the pull request and the users who cannot be requested to review it are left out,
+	//              it is not found when the expertise indexer is disabled.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: limit
+	//   in: query
+	//   description: maximum number of reviewers to return, 10 by default
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ExpertList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !setting.Indexer.ExpertiseIndexerEnabled {
+		ctx.NotFound()
+		return
+	}
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return
+	}
+	if err := pr.LoadIssue(); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadIssue", err)
+		return
+	}
+
+	changed, err := ctx.Repo.GitRepo.GetFilesChangedBetween(pr.MergeBase, pr.GetGitRefName())
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetFilesChangedBetween", err)
+		return
+	}
+	files := make([]string, 0, len(changed))
+	for _, file := range changed {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+
+	experts, err := repo_model.GetExpertsOfFiles(ctx, ctx.Repo.Repository.ID, files)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetExpertsOfFiles", err)
+		return
+	}
+
+	var doerID int64
+	if ctx.Doer != nil {
+		doerID = ctx.Doer.ID
+	}
+	reviewers, err := repo_model.GetReviewers(ctx, ctx.Repo.Repository, doerID, pr.Issue.PosterID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetReviewers", err)
+		return
+	}
+	canReview := make(map[int64]bool, len(reviewers))
+	for _, reviewer := range reviewers {
+		canReview[reviewer.ID] = true
+	}
+
+	limit := expertsLimit(ctx)
+	suggested := make(repo_model.ExpertList, 0, limit)
+	for _, expert := range experts {
+		if len(suggested) == limit {
+			break
+		}
+		if expert.User != nil && canReview[expert.User.ID] {
+			suggested = append(suggested, expert)
+		}
+	}
+	ctx.JSON(http.StatusOK, convert.ToExpertList(suggested, ctx.Doer))
+}
@@ -173,7 +173,7 @@ func GetRawFileOrLFS(ctx *context.APIContext) {
 		}
 
 		// OK not cached - serve!
-		if err := common.ServeData(ctx.Context, ctx.Repo.TreePath, blob.Size(), bytes.NewReader(buf)); err != nil {
+		if err := common.ServeDownload(ctx.Context, ctx.Repo.Repository, ctx.Repo.TreePath, blob.Size(), bytes.NewReader(buf)); err != nil {
 			ctx.ServerError("ServeBlob", err)
 		}
 		return
@@ -189,7 +189,7 @@ func GetRawFileOrLFS(ctx *context.APIContext) {
 			return
 		}
 
-		if err := common.ServeData(ctx.Context, ctx.Repo.TreePath, blob.Size(), bytes.NewReader(buf)); err != nil {
+		if err := common.ServeDownload(ctx.Context, ctx.Repo.Repository, ctx.Repo.TreePath, blob.Size(), bytes.NewReader(buf)); err != nil {
 			ctx.ServerError("ServeBlob", err)
 		}
 		return
@@ -219,8 +219,8 @@ func GetRawFileOrLFS(ctx *context.APIContext) {
 	}
 	defer lfsDataRc.Close()
 
-	if err := common.ServeData(ctx.Context, ctx.Repo.TreePath, meta.Size, lfsDataRc); err != nil {
-		ctx.ServerError("ServeData", err)
+	if err := common.ServeDownload(ctx.Context, ctx.Repo.Repository, ctx.Repo.TreePath, meta.Size, lfsDataRc); err != nil {
+		ctx.ServerError("ServeDownload", err)
 	}
 }
 
@@ -16,7 +16,9 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/context"
 	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/modules/git"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -153,3 +155,196 @@ func CreateFork(ctx *context.APIContext) {
 	// TODO change back to 201
 	ctx.JSON(http.StatusAccepted, convert.ToRepo(fork, perm.AccessModeOwner))
 }
+
+// ListForkNetwork list the repositories of the fork network of a repository
+func ListForkNetwork(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/forks/network repository listForkNetwork
+	// ---
+	// summary: List the repositories of the fork network of a repository, its root first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: divergence
+	//   in: query
+	//   description: include the number of commits the default branch of every fork is ahead or behind the default branch of the root
+	//   type: boolean
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ForkNetworkRepositoryList"
+
+	root, network, err := repo_service.GetForkNetwork(ctx, ctx.Repo.Repository, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetForkNetwork", err)
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	page := util.PaginateSlice(network, listOptions.Page, listOptions.PageSize).([]*repo_service.ForkNetworkRepo)
+
+	apiNetwork := make([]*api.ForkNetworkRepository, len(page))
+	for i, networkRepo := range page {
+		access, err := access_model.AccessLevel(ctx.Doer, networkRepo.Repo)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "AccessLevel", err)
+			return
+		}
+		apiNetwork[i] = &api.ForkNetworkRepository{Repository: convert.ToRepo(networkRepo.Repo, access)}
+		if networkRepo.Parent != nil {
+			apiNetwork[i].Parent = networkRepo.Parent.FullName()
+		}
+
+		// the divergence is only computed when requested, as it needs to walk the history of the repositories
+		if ctx.FormBool("divergence") && networkRepo.Repo.ID != root.ID {
+			divergence, err := repo_service.GetForkDivergence(ctx, root, networkRepo.Repo)
+			if err != nil {
+				ctx.Error(http.StatusInternalServerError, "GetForkDivergence", err)
+				return
+			}
+			if divergence != nil {
+				apiNetwork[i].AheadBy = &divergence.Ahead
+				apiNetwork[i].BehindBy = &divergence.Behind
+			}
+		}
+	}
+
+	ctx.SetTotalCountHeader(int64(len(network)))
+	ctx.JSON(http.StatusOK, apiNetwork)
+}
+
+// ListForksContainingCommit list the repositories of the fork network of a repository containing a commit
+func ListForksContainingCommit(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/forks/network/commits/{sha} repository listForksContainingCommit
+	// ---
+	// summary: List the repositories of the fork network of a repository having a branch which contains a commit
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: SHA of the commit
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ForkContainingCommitList"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	sha := ctx.Params(":sha")
+	if !git.IsValidSHAPattern(sha) {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("invalid sha: %s", sha))
+		return
+	}
+
+	_, network, err := repo_service.GetForkNetwork(ctx, ctx.Repo.Repository, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetForkNetwork", err)
+		return
+	}
+
+	forks, err := repo_service.FindForksContainingCommit(ctx, network, sha)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindForksContainingCommit", err)
+		return
+	}
+
+	apiForks := make([]*api.ForkContainingCommit, len(forks))
+	for i, fork := range forks {
+		access, err := access_model.AccessLevel(ctx.Doer, fork.Repo)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "AccessLevel", err)
+			return
+		}
+		apiForks[i] = &api.ForkContainingCommit{
+			Repository: convert.ToRepo(fork.Repo, access),
+			Branches:   fork.Branches,
+		}
+	}
+	ctx.JSON(http.StatusOK, apiForks)
+}
+
+// SyncFork synchronizes the default branch of a fork with its upstream repository
+func SyncFork(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/sync_fork repository repoSyncFork
+	// ---
+	// summary: Synchronize the default branch of a fork with the default branch of its upstream repository
+	// description: The fork is fast-forwarded if it has no commit of its own, otherwise the upstream branch is merged
+	//   into it. The fork is never force-pushed and is left unchanged when merging conflicts.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the fork
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the fork
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SyncForkOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ForkSyncResult"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: merging the upstream branch conflicts, or the default branch of the fork can't be updated
+
+	form := web.GetForm(ctx).(*api.SyncForkOption)
+	result, err := repo_service.SyncFork(ctx, ctx.Doer, ctx.Repo.Repository, form.DryRun)
+	if err != nil {
+		switch {
+		case repo_service.IsErrRepoNotFork(err):
+			ctx.NotFound(err)
+		case repo_service.IsErrForkSyncConflict(err), git.IsErrPushOutOfDate(err), git.IsErrPushRejected(err):
+			ctx.Error(http.StatusConflict, "SyncFork", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "SyncFork", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &api.ForkSyncResult{
+		Status:   string(result.Status),
+		Ahead:    result.Ahead,
+		Behind:   result.Behind,
+		CommitID: result.CommitID,
+	})
+}
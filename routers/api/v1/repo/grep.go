@@ -0,0 +1,144 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+// maxGrepContextLines is the maximum number of lines around each line matching a grep search
+const maxGrepContextLines = 10
+
+// Grep search the lines matching a regular expression in the files of a commit
+func Grep(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/grep repository repoGrep
+	// ---
+	// summary: Search the lines matching a regular expression in the files of a commit
+	// description: The search runs git grep on the commit, it doesn't need the code indexer and works on every ref.
+	//   It is bounded by the number of results and a timeout, the response is truncated when one of them is reached.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: q
+	//   in: query
+	//   description: POSIX extended regular expression to search
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: "The name of the commit/branch/tag. Default the repository’s default branch (usually master)"
+	//   type: string
+	// - name: path
+	//   in: query
+	//   description: paths or glob patterns of the files to search in
+	//   type: array
+	//   collectionFormat: multi
+	//   items:
+	//     type: string
+	// - name: ignore_case
+	//   in: query
+	//   description: whether the search ignores the case
+	//   type: boolean
+	// - name: context
+	//   in: query
+	//   description: number of lines before and after each matching line, at most 10
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: maximum number of matching lines
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/GrepResponse"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	pattern := ctx.FormString("q")
+	if pattern == "" {
+		ctx.Error(http.StatusUnprocessableEntity, "", "the pattern to search is missing")
+		return
+	}
+	contextLines := ctx.FormInt("context")
+	if contextLines < 0 || contextLines > maxGrepContextLines {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("the context must be between 0 and %d lines", maxGrepContextLines))
+		return
+	}
+	limit := ctx.FormInt("limit")
+	if limit <= 0 || limit > setting.API.MaxResponseItems {
+		limit = setting.API.MaxResponseItems
+	}
+
+	ref := ctx.FormTrim("ref")
+	if ref == "" {
+		ref = ctx.Repo.Repository.DefaultBranch
+	}
+	sha := utils.ResolveRefOrSha(ctx, ref)
+	if ctx.Written() {
+		return
+	}
+	commit, err := ctx.Repo.GitRepo.GetCommit(sha)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.NotFound("GetCommit", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetCommit", err)
+		}
+		return
+	}
+
+	result, err := ctx.Repo.GitRepo.Grep(commit.ID.String(), git.GrepOptions{
+		Pattern:       pattern,
+		IgnoreCase:    ctx.FormBool("ignore_case"),
+		PathSpecs:     ctx.FormStrings("path"),
+		MaxResults:    limit,
+		ContextLines:  contextLines,
+		MaxLineLength: setting.Git.MaxGitDiffLineCharacters,
+		Timeout:       time.Duration(setting.Git.Timeout.Grep) * time.Second,
+	})
+	if err != nil {
+		if git.IsErrInvalidGrepPattern(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "Grep", err)
+		}
+		return
+	}
+
+	matches := make([]*api.GrepMatch, 0, len(result.Matches))
+	for _, match := range result.Matches {
+		matches = append(matches, &api.GrepMatch{
+			Path:          match.Filename,
+			LineNumber:    match.LineNumber,
+			Line:          match.Line,
+			ContextBefore: match.ContextBefore,
+			ContextAfter:  match.ContextAfter,
+		})
+	}
+	ctx.JSON(http.StatusOK, &api.GrepResponse{
+		SHA:       commit.ID.String(),
+		Matches:   matches,
+		Truncated: result.Truncated,
+	})
+}
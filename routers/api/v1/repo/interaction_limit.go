@@ -0,0 +1,109 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+// GetInteractionLimit returns the interaction limit applying to a repository
+func GetInteractionLimit(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/interaction-limits repository repoGetInteractionLimit
+	// ---
+	// summary: Get the interaction limit applying to a repository, including the one of its owner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/InteractionLimit"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	limit, err := repo_model.GetEffectiveInteractionLimit(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetEffectiveInteractionLimit", err)
+		return
+	} else if limit == nil {
+		ctx.NotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToInteractionLimit(limit, ctx.Repo.Owner))
+}
+
+// SetInteractionLimit limits the interactions in a repository
+func SetInteractionLimit(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/interaction-limits repository repoSetInteractionLimit
+	// ---
+	// summary: Temporarily limit the interactions in a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetInteractionLimitOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/InteractionLimit"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	utils.SetInteractionLimit(ctx, 0, ctx.Repo.Repository.ID, ctx.Repo.Owner)
+}
+
+// RemoveInteractionLimit removes the interaction limit of a repository
+func RemoveInteractionLimit(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/interaction-limits repository repoRemoveInteractionLimit
+	// ---
+	// summary: Remove the interaction limit of a repository
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	utils.RemoveInteractionLimit(ctx, 0, ctx.Repo.Repository.ID)
+}
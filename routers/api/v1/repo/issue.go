@@ -6,6 +6,7 @@
 package repo
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -233,6 +234,7 @@ func SearchIssues(ctx *context.APIContext) {
 	// This would otherwise return all issues if no issues were found by the search.
 	if len(keyword) == 0 || len(issueIDs) > 0 || len(includedLabelNames) > 0 || len(includedMilestones) > 0 {
 		issuesOpt := &issues_model.IssuesOptions{
+			ConfidentialCond: issues_model.ConfidentialIssueCond(ctx.Doer),
 			ListOptions: db.ListOptions{
 				Page:     ctx.FormInt("page"),
 				PageSize: limit,
@@ -314,6 +316,13 @@ func ListIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: comma separated list of labels. Fetch only issues that have any of this labels. Non existent labels are discarded
 	//   type: string
+	// - name: fields
+	//   in: query
+	//   description: values of custom fields written as `<field id>:<value>`. Fetch only issues whose fields have all these values
+	//   type: array
+	//   collectionFormat: multi
+	//   items:
+	//     type: string
 	// - name: q
 	//   in: query
 	//   description: search string
@@ -327,6 +336,10 @@ func ListIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: comma separated list of milestone names or ids. It uses names and fall back to ids. Fetch only issues that have any of this milestones. Non existent milestones are discarded
 	//   type: string
+	// - name: component
+	//   in: query
+	//   description: name or id of a component. It uses the name and falls back to the id. Fetch only issues touching this component
+	//   type: string
 	// - name: since
 	//   in: query
 	//   description: Only show items updated after the given time. This is a timestamp in RFC 3339 format
@@ -351,6 +364,19 @@ func ListIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: Only show items in which the given user was mentioned
 	//   type: string
+	// - name: sort
+	//   in: query
+	//   description: type of sort, the most recently created first by default
+	//   type: string
+	//   enum: [latest, oldest, recentupdate, leastupdate, mostcomment, leastcomment, mostreactions, leastreactions, mostthumbsup, mostvotes, nearduedate, farduedate, priority]
+	// - name: min_reactions
+	//   in: query
+	//   description: Only show items which have at least this number of reactions
+	//   type: integer
+	// - name: min_thumbs_up
+	//   in: query
+	//   description: Only show items which have at least this number of thumbs up reactions
+	//   type: integer
 	// - name: page
 	//   in: query
 	//   description: page number of results to return (1-based)
@@ -362,10 +388,47 @@ func ListIssues(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/IssueList"
+	issuesOpt := getIssuesOptionsFromQuery(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+
+	var issues []*issues_model.Issue
+	var filteredCount int64
+	var err error
+
+	// Only fetch the issues if we either don't have a keyword or the search returned issues
+	// This would otherwise return all issues if no issues were found by the search.
+	if issuesOpt != nil {
+		issuesOpt.ListOptions = listOptions
+		if issues, err = issues_model.Issues(issuesOpt); err != nil {
+			ctx.Error(http.StatusInternalServerError, "Issues", err)
+			return
+		}
+
+		issuesOpt.ListOptions = db.ListOptions{
+			Page: -1,
+		}
+		if filteredCount, err = issues_model.CountIssues(issuesOpt); err != nil {
+			ctx.Error(http.StatusInternalServerError, "CountIssues", err)
+			return
+		}
+	}
+
+	ctx.SetLinkHeader(int(filteredCount), listOptions.PageSize)
+	ctx.SetTotalCountHeader(filteredCount)
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(issues))
+}
+
+// getIssuesOptionsFromQuery returns the options to find the issues of the repository matching the filters
+// of the query, or nil if the keyword search found no issue. If there is an error, write to `ctx` accordingly
+func getIssuesOptionsFromQuery(ctx *context.APIContext) *issues_model.IssuesOptions {
 	before, since, err := context.GetQueryBeforeSince(ctx.Context)
 	if err != nil {
 		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
-		return
+		return nil
 	}
 
 	var isClosed util.OptionalBool
@@ -378,9 +441,6 @@ func ListIssues(ctx *context.APIContext) {
 		isClosed = util.OptionalBoolFalse
 	}
 
-	var issues []*issues_model.Issue
-	var filteredCount int64
-
 	keyword := ctx.FormTrim("q")
 	if strings.IndexByte(keyword, 0) >= 0 {
 		keyword = ""
@@ -391,7 +451,7 @@ func ListIssues(ctx *context.APIContext) {
 		issueIDs, err = issue_indexer.SearchIssuesByKeyword(ctx, []int64{ctx.Repo.Repository.ID}, keyword)
 		if err != nil {
 			ctx.Error(http.StatusInternalServerError, "SearchIssuesByKeyword", err)
-			return
+			return nil
 		}
 	}
 
@@ -399,7 +459,7 @@ func ListIssues(ctx *context.APIContext) {
 		labelIDs, err = issues_model.GetLabelIDsInRepoByNames(ctx.Repo.Repository.ID, splitted)
 		if err != nil {
 			ctx.Error(http.StatusInternalServerError, "GetLabelIDsInRepoByNames", err)
-			return
+			return nil
 		}
 	}
 
@@ -415,7 +475,7 @@ func ListIssues(ctx *context.APIContext) {
 			}
 			if !issues_model.IsErrMilestoneNotExist(err) {
 				ctx.Error(http.StatusInternalServerError, "GetMilestoneByRepoIDANDName", err)
-				return
+				return nil
 			}
 			id, err := strconv.ParseInt(part[i], 10, 64)
 			if err != nil {
@@ -433,8 +493,6 @@ func ListIssues(ctx *context.APIContext) {
 		}
 	}
 
-	listOptions := utils.GetListOptions(ctx)
-
 	var isPull util.OptionalBool
 	switch ctx.FormString("type") {
 	case "pulls":
@@ -448,52 +506,67 @@ func ListIssues(ctx *context.APIContext) {
 	// FIXME: we should be more efficient here
 	createdByID := getUserIDForFilter(ctx, "created_by")
 	if ctx.Written() {
-		return
+		return nil
 	}
 	assignedByID := getUserIDForFilter(ctx, "assigned_by")
 	if ctx.Written() {
-		return
+		return nil
 	}
 	mentionedByID := getUserIDForFilter(ctx, "mentioned_by")
 	if ctx.Written() {
-		return
+		return nil
 	}
 
-	// Only fetch the issues if we either don't have a keyword or the search returned issues
-	// This would otherwise return all issues if no issues were found by the search.
-	if len(keyword) == 0 || len(issueIDs) > 0 || len(labelIDs) > 0 {
-		issuesOpt := &issues_model.IssuesOptions{
-			ListOptions:       listOptions,
-			RepoID:            ctx.Repo.Repository.ID,
-			IsClosed:          isClosed,
-			IssueIDs:          issueIDs,
-			LabelIDs:          labelIDs,
-			MilestoneIDs:      mileIDs,
-			IsPull:            isPull,
-			UpdatedBeforeUnix: before,
-			UpdatedAfterUnix:  since,
-			PosterID:          createdByID,
-			AssigneeID:        assignedByID,
-			MentionedID:       mentionedByID,
-		}
-
-		if issues, err = issues_model.Issues(issuesOpt); err != nil {
-			ctx.Error(http.StatusInternalServerError, "Issues", err)
-			return
-		}
+	fieldValues, err := issues_model.ParseIssueFieldFilters(ctx.FormStrings("fields"))
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "ParseIssueFieldFilters", err)
+		return nil
+	}
 
-		issuesOpt.ListOptions = db.ListOptions{
-			Page: -1,
+	var componentID int64
+	if name := ctx.FormTrim("component"); len(name) > 0 {
+		// uses names and fall back to ids
+		component, err := issues_model.GetComponentByName(ctx, ctx.Repo.Repository.ID, name)
+		if issues_model.IsErrComponentNotExist(err) {
+			if id, _ := strconv.ParseInt(name, 10, 64); id > 0 {
+				component, err = issues_model.GetComponentByID(ctx, ctx.Repo.Repository.ID, id)
+			}
 		}
-		if filteredCount, err = issues_model.CountIssues(issuesOpt); err != nil {
-			ctx.Error(http.StatusInternalServerError, "CountIssues", err)
-			return
+		if err != nil {
+			if issues_model.IsErrComponentNotExist(err) {
+				ctx.NotFound(err)
+			} else {
+				ctx.InternalServerError(err)
+			}
+			return nil
 		}
+		componentID = component.ID
 	}
 
-	ctx.SetLinkHeader(int(filteredCount), listOptions.PageSize)
-	ctx.SetTotalCountHeader(filteredCount)
-	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(issues))
+	// the options would otherwise match all the issues if no issues were found by the search
+	if len(keyword) > 0 && len(issueIDs) == 0 && len(labelIDs) == 0 {
+		return nil
+	}
+
+	return &issues_model.IssuesOptions{
+		ConfidentialCond:  issues_model.ConfidentialIssueCond(ctx.Doer),
+		RepoID:            ctx.Repo.Repository.ID,
+		IsClosed:          isClosed,
+		IssueIDs:          issueIDs,
+		LabelIDs:          labelIDs,
+		MilestoneIDs:      mileIDs,
+		IsPull:            isPull,
+		UpdatedBeforeUnix: before,
+		UpdatedAfterUnix:  since,
+		PosterID:          createdByID,
+		AssigneeID:        assignedByID,
+		MentionedID:       mentionedByID,
+		FieldValues:       fieldValues,
+		ComponentID:       componentID,
+		MinReactions:      ctx.FormInt("min_reactions"),
+		MinThumbsUp:       ctx.FormInt("min_thumbs_up"),
+		SortType:          ctx.FormTrim("sort"),
+	}
 }
 
 func getUserIDForFilter(ctx *context.APIContext, queryName string) int64 {
@@ -558,6 +631,21 @@ func GetIssue(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, convert.ToAPIIssue(issue))
 }
 
+// checkInteraction responds with a forbidden error and returns false if the doer is blocked by the owner
+// of the repository or excluded by its interaction limit
+func checkInteraction(ctx *context.APIContext) bool {
+	err := issue_service.CheckInteraction(ctx, ctx.Doer, ctx.Repo.Repository, ctx.Repo.Permission)
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, issue_service.ErrBlockedByOwner) || errors.Is(err, issue_service.ErrInteractionLimited) {
+		ctx.Error(http.StatusForbidden, "CheckInteraction", err)
+	} else {
+		ctx.Error(http.StatusInternalServerError, "CheckInteraction", err)
+	}
+	return false
+}
+
 // CreateIssue create an issue of a repository
 func CreateIssue(ctx *context.APIContext) {
 	// swagger:operation POST /repos/{owner}/{repo}/issues issue issueCreateIssue
@@ -592,6 +680,9 @@ func CreateIssue(ctx *context.APIContext) {
 	//   "422":
 	//     "$ref": "#/responses/validationError"
 	form := web.GetForm(ctx).(*api.CreateIssueOption)
+	if !checkInteraction(ctx) {
+		return
+	}
 	var deadlineUnix timeutil.TimeStamp
 	if form.Deadline != nil && ctx.Repo.CanWrite(unit.TypeIssues) {
 		deadlineUnix = timeutil.TimeStamp(form.Deadline.Unix())
@@ -606,6 +697,8 @@ func CreateIssue(ctx *context.APIContext) {
 		Content:      form.Body,
 		Ref:          form.Ref,
 		DeadlineUnix: deadlineUnix,
+		// anyone can file a confidential issue, its poster keeps seeing it
+		IsConfidential: form.Confidential,
 	}
 
 	assigneeIDs := make([]int64, 0)
@@ -713,6 +806,8 @@ func EditIssue(ctx *context.APIContext) {
 	//     "$ref": "#/responses/notFound"
 	//   "412":
 	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	form := web.GetForm(ctx).(*api.EditIssueOption)
 	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
@@ -800,6 +895,16 @@ func EditIssue(ctx *context.APIContext) {
 			return
 		}
 	}
+	if canWrite && form.Confidential != nil {
+		if issue.IsPull {
+			ctx.Error(http.StatusUnprocessableEntity, "IsPull", "pull requests can't be confidential")
+			return
+		}
+		if err = issues_model.ChangeIssueConfidentiality(ctx.Doer, issue, *form.Confidential); err != nil {
+			ctx.Error(http.StatusInternalServerError, "ChangeIssueConfidentiality", err)
+			return
+		}
+	}
 	if form.State != nil {
 		if issue.IsPull {
 			if pr, err := issue.GetPullRequest(); err != nil {
@@ -0,0 +1,225 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// maxBulkEditIssues is the maximum number of issues edited by a single request
+const maxBulkEditIssues = 500
+
+// EditIssues edit several issues of a repository at once
+func EditIssues(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issues issue issueEditIssues
+	// ---
+	// summary: Edit several issues and pull requests at once. The changes are applied to all of them or to none.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssuesOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "412":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIssuesOption)
+	if len(form.Indices) > maxBulkEditIssues {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("at most %d issues can be edited at once", maxBulkEditIssues))
+		return
+	}
+
+	issues := make([]*issues_model.Issue, 0, len(form.Indices))
+	seen := make(map[int64]bool, len(form.Indices))
+	for _, index := range form.Indices {
+		if seen[index] {
+			continue
+		}
+		seen[index] = true
+
+		issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, index)
+		if err != nil {
+			if issues_model.IsErrIssueNotExist(err) {
+				ctx.NotFound(err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+			}
+			return
+		}
+		if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+			ctx.Status(http.StatusForbidden)
+			return
+		}
+		issues = append(issues, issue)
+	}
+
+	opts := &issue_service.BulkEditOptions{
+		MilestoneID: form.Milestone,
+	}
+
+	var err error
+	if opts.AddLabels, err = getBulkEditLabels(ctx, form.AddLabels); err != nil {
+		return
+	}
+	if opts.RemoveLabels, err = getBulkEditLabels(ctx, form.RemoveLabels); err != nil {
+		return
+	}
+
+	if form.Milestone != nil && *form.Milestone > 0 {
+		has, err := issues_model.HasMilestoneByRepoID(ctx, ctx.Repo.Repository.ID, *form.Milestone)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "HasMilestoneByRepoID", err)
+			return
+		}
+		if !has {
+			ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("milestone %d does not exist", *form.Milestone))
+			return
+		}
+	}
+
+	if opts.AddAssignees, err = getBulkEditAssignees(ctx, form.AddAssignees, issues); err != nil {
+		return
+	}
+	if opts.RemoveAssignees, err = getBulkEditAssignees(ctx, form.RemoveAssignees, nil); err != nil {
+		return
+	}
+
+	if form.State != nil {
+		switch api.StateType(*form.State) {
+		case api.StateOpen:
+			opts.IsClosed = util.OptionalBoolFalse
+		case api.StateClosed:
+			opts.IsClosed = util.OptionalBoolTrue
+		default:
+			ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("invalid state %q", *form.State))
+			return
+		}
+		for _, issue := range issues {
+			if !issue.IsPull || issue.IsClosed == opts.IsClosed.IsTrue() {
+				continue
+			}
+			if err := issue.LoadPullRequest(); err != nil {
+				ctx.Error(http.StatusInternalServerError, "LoadPullRequest", err)
+				return
+			}
+			if issue.PullRequest.HasMerged {
+				ctx.Error(http.StatusPreconditionFailed, "MergedPRState", fmt.Sprintf("cannot change state of pull request #%d, it was already merged", issue.Index))
+				return
+			}
+		}
+	}
+
+	if err := issue_service.BulkEditIssues(ctx, ctx.Doer, issues, opts); err != nil {
+		if issues_model.IsErrDependenciesLeft(err) {
+			ctx.Error(http.StatusPreconditionFailed, "DependenciesLeft", err.Error())
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "BulkEditIssues", err)
+		return
+	}
+
+	// Refetch from database to assign some automatic values
+	ids := make([]int64, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	issues, err = issues_model.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssuesByIDs", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(issues))
+}
+
+// getBulkEditLabels returns the labels of the repository or its organization by their IDs, if one of them doesn't
+// exist it writes the error to `ctx`
+func getBulkEditLabels(ctx *context.APIContext, ids []int64) ([]*issues_model.Label, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	labels, err := issues_model.GetLabelsByIDs(ids)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetLabelsByIDs", err)
+		return nil, err
+	}
+	found := make(map[int64]bool, len(labels))
+	for _, label := range labels {
+		if label.RepoID == ctx.Repo.Repository.ID || label.OrgID > 0 && label.OrgID == ctx.Repo.Repository.OwnerID {
+			found[label.ID] = true
+		}
+	}
+	for _, id := range ids {
+		if !found[id] {
+			err := fmt.Errorf("label %d does not exist", id)
+			ctx.Error(http.StatusUnprocessableEntity, "", err.Error())
+			return nil, err
+		}
+	}
+	return labels, nil
+}
+
+// getBulkEditAssignees returns the users by their names and checks that they can be assigned to the issues, if one of
+// them can't it writes the error to `ctx`
+func getBulkEditAssignees(ctx *context.APIContext, names []string, issues []*issues_model.Issue) ([]*user_model.User, error) {
+	users := make([]*user_model.User, 0, len(names))
+	for _, name := range names {
+		u, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("user %s does not exist", name))
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetUserByName", err)
+			}
+			return nil, err
+		}
+		for _, issue := range issues {
+			valid, err := access_model.CanBeAssigned(ctx, u, ctx.Repo.Repository, issue.IsPull)
+			if err != nil {
+				ctx.Error(http.StatusInternalServerError, "CanBeAssigned", err)
+				return nil, err
+			}
+			if !valid {
+				err := fmt.Errorf("user %s can not be assigned to #%d", name, issue.Index)
+				ctx.Error(http.StatusUnprocessableEntity, "", err.Error())
+				return nil, err
+			}
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
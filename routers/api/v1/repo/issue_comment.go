@@ -20,6 +20,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	comment_service "code.gitea.io/gitea/services/comments"
+	moderation_service "code.gitea.io/gitea/services/moderation"
 )
 
 // ListIssueComments list all the comments of an issue
@@ -84,6 +85,11 @@ func ListIssueComments(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "FindComments", err)
 		return
 	}
+	comments, err = moderation_service.FilterComments(ctx, ctx.Doer, ctx.Repo.Repository.OwnerID, moderation_service.CanModerate(ctx.Doer, ctx.Repo.Permission), comments)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FilterComments", err)
+		return
+	}
 
 	totalCount, err := issues_model.CountComments(opts)
 	if err != nil {
@@ -262,11 +268,12 @@ func ListRepoIssueComments(ctx *context.APIContext) {
 	}
 
 	opts := &issues_model.FindCommentsOptions{
-		ListOptions: utils.GetListOptions(ctx),
-		RepoID:      ctx.Repo.Repository.ID,
-		Type:        issues_model.CommentTypeComment,
-		Since:       since,
-		Before:      before,
+		ListOptions:      utils.GetListOptions(ctx),
+		RepoID:           ctx.Repo.Repository.ID,
+		Type:             issues_model.CommentTypeComment,
+		Since:            since,
+		Before:           before,
+		ConfidentialCond: issues_model.ConfidentialIssueCond(ctx.Doer),
 	}
 
 	comments, err := issues_model.FindComments(ctx, opts)
@@ -274,6 +281,11 @@ func ListRepoIssueComments(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "FindComments", err)
 		return
 	}
+	comments, err = moderation_service.FilterComments(ctx, ctx.Doer, ctx.Repo.Repository.OwnerID, moderation_service.CanModerate(ctx.Doer, ctx.Repo.Permission), comments)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FilterComments", err)
+		return
+	}
 
 	totalCount, err := issues_model.CountComments(opts)
 	if err != nil {
@@ -354,6 +366,10 @@ func CreateIssueComment(ctx *context.APIContext) {
 		return
 	}
 
+	if !checkInteraction(ctx) {
+		return
+	}
+
 	comment, err := comment_service.CreateIssueComment(ctx.Doer, ctx.Repo.Repository, issue, form.Body, nil)
 	if err != nil {
 		ctx.Error(http.StatusInternalServerError, "CreateIssueComment", err)
@@ -428,6 +444,15 @@ func GetIssueComment(ctx *context.APIContext) {
 		return
 	}
 
+	filtered, err := moderation_service.FilterComments(ctx, ctx.Doer, ctx.Repo.Repository.OwnerID, moderation_service.CanModerate(ctx.Doer, ctx.Repo.Permission), issues_model.CommentList{comment})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FilterComments", err)
+		return
+	} else if len(filtered) == 0 {
+		ctx.NotFound()
+		return
+	}
+
 	ctx.JSON(http.StatusOK, convert.ToComment(comment))
 }
 
@@ -0,0 +1,180 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	issue_service "code.gitea.io/gitea/services/issue"
+	moderation_service "code.gitea.io/gitea/services/moderation"
+)
+
+// ListIssueContentHistory list the edit history of the description of an issue
+func ListIssueContentHistory(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/content_history issue issueListContentHistory
+	// ---
+	// summary: List the revisions of the edit history of the description of an issue or a pull request, the latest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ContentHistoryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound()
+		return
+	}
+
+	listContentHistory(ctx, issue.ID, 0)
+}
+
+// ListIssueCommentContentHistory list the edit history of a comment
+func ListIssueCommentContentHistory(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/comments/{id}/content_history issue issueListCommentContentHistory
+	// ---
+	// summary: List the revisions of the edit history of a comment, the latest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the comment
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ContentHistoryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	comment, err := issues_model.GetCommentByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrCommentNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetCommentByID", err)
+		}
+		return
+	}
+	if err = comment.LoadIssue(); err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID || !ctx.Repo.CanReadIssuesOrPulls(comment.Issue.IsPull) {
+		ctx.NotFound()
+		return
+	}
+
+	// the history of a hidden comment is only shown to the moderators and its poster
+	canModerate := moderation_service.CanModerate(ctx.Doer, ctx.Repo.Permission)
+	isPoster := ctx.Doer != nil && ctx.Doer.ID == comment.PosterID
+	filtered, err := moderation_service.FilterComments(ctx, ctx.Doer, ctx.Repo.Repository.OwnerID, canModerate, issues_model.CommentList{comment})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FilterComments", err)
+		return
+	} else if len(filtered) == 0 || (comment.IsHidden && !canModerate && !isPoster) {
+		ctx.NotFound()
+		return
+	}
+
+	listContentHistory(ctx, comment.IssueID, comment.ID)
+}
+
+// listContentHistory responds with a page of the edit history of an issue (commentID = 0) or a comment
+func listContentHistory(ctx *context.APIContext, issueID, commentID int64) {
+	listOptions := utils.GetListOptions(ctx)
+	histories, count, err := issues_model.FindIssueContentHistories(ctx, issueID, commentID, listOptions)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindIssueContentHistories", err)
+		return
+	}
+	diffs, err := issue_service.ContentHistoryDiffs(ctx, histories)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "ContentHistoryDiffs", err)
+		return
+	}
+
+	editors := make(map[int64]*user_model.User)
+	apiHistories := make([]*api.ContentHistory, 0, len(histories))
+	for i, history := range histories {
+		editor, ok := editors[history.PosterID]
+		if !ok {
+			editor, err = user_model.GetUserByID(history.PosterID)
+			if user_model.IsErrUserNotExist(err) {
+				editor = user_model.NewGhostUser()
+			} else if err != nil {
+				ctx.Error(http.StatusInternalServerError, "GetUserByID", err)
+				return
+			}
+			editors[history.PosterID] = editor
+		}
+		apiHistories = append(apiHistories, convert.ToAPIContentHistory(history, editor, ctx.Doer, diffs[i]))
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiHistories)
+}
@@ -0,0 +1,136 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/log"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// ExportIssues export the issues of a repository matching the filters
+func ExportIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/export issue issueExportIssues
+	// ---
+	// summary: Export a repository's issues and pull requests matching the filters, without pagination
+	// produces:
+	// - text/csv
+	// - application/x-ndjson
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: format
+	//   in: query
+	//   description: format of the export, CSV with a header line or JSON Lines with an object per issue
+	//   type: string
+	//   enum: [csv, jsonl]
+	// - name: state
+	//   in: query
+	//   description: whether issue is open or closed
+	//   type: string
+	//   enum: [closed, open, all]
+	// - name: labels
+	//   in: query
+	//   description: comma separated list of labels. Fetch only issues that have any of this labels. Non existent labels are discarded
+	//   type: string
+	// - name: fields
+	//   in: query
+	//   description: values of custom fields written as `<field id>:<value>`. Fetch only issues whose fields have all these values
+	//   type: array
+	//   collectionFormat: multi
+	//   items:
+	//     type: string
+	// - name: q
+	//   in: query
+	//   description: search string
+	//   type: string
+	// - name: type
+	//   in: query
+	//   description: filter by type (issues / pulls) if set
+	//   type: string
+	//   enum: [issues, pulls]
+	// - name: milestones
+	//   in: query
+	//   description: comma separated list of milestone names or ids. It uses names and fall back to ids. Fetch only issues that have any of this milestones. Non existent milestones are discarded
+	//   type: string
+	// - name: component
+	//   in: query
+	//   description: name or id of a component. It uses the name and falls back to the id. Fetch only issues touching this component
+	//   type: string
+	// - name: since
+	//   in: query
+	//   description: Only show items updated after the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	//   required: false
+	// - name: before
+	//   in: query
+	//   description: Only show items updated before the given time. This is a timestamp in RFC 3339 format
+	//   type: string
+	//   format: date-time
+	//   required: false
+	// - name: created_by
+	//   in: query
+	//   description: Only show items which were created by the the given user
+	//   type: string
+	// - name: assigned_by
+	//   in: query
+	//   description: Only show items for which the given user is assigned
+	//   type: string
+	// - name: mentioned_by
+	//   in: query
+	//   description: Only show items in which the given user was mentioned
+	//   type: string
+	// - name: sort
+	//   in: query
+	//   description: type of sort, the most recently created first by default
+	//   type: string
+	//   enum: [latest, oldest, recentupdate, leastupdate, mostcomment, leastcomment, mostreactions, leastreactions, mostthumbsup, mostvotes, nearduedate, farduedate, priority]
+	// - name: min_reactions
+	//   in: query
+	//   description: Only show items which have at least this number of reactions
+	//   type: integer
+	// - name: min_thumbs_up
+	//   in: query
+	//   description: Only show items which have at least this number of thumbs up reactions
+	//   type: integer
+	// responses:
+	//   "200":
+	//     description: the exported issues, the assignees and the labels are comma separated in CSV and the time spent is in seconds
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	format := issue_service.ExportFormat(ctx.FormString("format"))
+	if format == "" {
+		format = issue_service.ExportFormatCSV
+	}
+	if !format.IsValid() {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("Invalid export format: %s", format))
+		return
+	}
+
+	issuesOpt := getIssuesOptionsFromQuery(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", format.ContentType())
+	ctx.Resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-issues.%s"`, ctx.Repo.Repository.Name, format))
+	ctx.Resp.WriteHeader(http.StatusOK)
+	if err := issue_service.ExportIssues(ctx.Resp, format, issuesOpt); err != nil {
+		log.Error("ExportIssues[%s]: %v", ctx.Repo.Repository.FullName(), err)
+	}
+}
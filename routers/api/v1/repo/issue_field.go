@@ -0,0 +1,440 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+)
+
+// ListIssueFields list the custom fields of the issues of a repository
+func ListIssueFields(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_fields issue issueListIssueFields
+	// ---
+	// summary: List the custom fields of the issues of a repository, including the fields of its organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFieldList"
+
+	fields, err := issues_model.GetApplicableIssueFields(ctx, ctx.Repo.Repository.ID, ctx.Repo.Repository.OwnerID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetApplicableIssueFields", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueFieldList(fields))
+}
+
+// CreateIssueField create a custom field of the issues of a repository
+func CreateIssueField(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issue_fields issue issueCreateIssueField
+	// ---
+	// summary: Create a custom field of the issues of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateIssueFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/IssueField"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateIssueFieldOption)
+	field := &issues_model.IssueField{
+		RepoID:      ctx.Repo.Repository.ID,
+		Name:        form.Name,
+		Description: form.Description,
+		Type:        issues_model.IssueFieldType(form.Type),
+		Options:     form.Options,
+	}
+	if err := issues_model.NewIssueField(ctx, field); err != nil {
+		handleIssueFieldError(ctx, "NewIssueField", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToIssueField(field))
+}
+
+// GetIssueField get a custom field of the issues of a repository
+func GetIssueField(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_fields/{id} issue issueGetIssueField
+	// ---
+	// summary: Get a custom field of the issues of a repository, which may be a field of its organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	field, err := issues_model.GetApplicableIssueFieldByID(ctx, ctx.Repo.Repository.ID, ctx.Repo.Repository.OwnerID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrIssueFieldNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetApplicableIssueFieldByID", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueField(field))
+}
+
+// getRepoIssueField returns a field defined by the repository, the fields of its organization are edited through the
+// organization
+func getRepoIssueField(ctx *context.APIContext) *issues_model.IssueField {
+	field, err := issues_model.GetIssueFieldInRepoByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrIssueFieldNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueFieldInRepoByID", err)
+		}
+		return nil
+	}
+	return field
+}
+
+// EditIssueField edit a custom field of the issues of a repository
+func EditIssueField(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issue_fields/{id} issue issueEditIssueField
+	// ---
+	// summary: Edit a custom field of the issues of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssueFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIssueFieldOption)
+	field := getRepoIssueField(ctx)
+	if ctx.Written() {
+		return
+	}
+	if form.Name != nil {
+		field.Name = *form.Name
+	}
+	if form.Description != nil {
+		field.Description = *form.Description
+	}
+	if form.Options != nil {
+		field.Options = form.Options
+	}
+	if err := issues_model.UpdateIssueField(ctx, field); err != nil {
+		handleIssueFieldError(ctx, "UpdateIssueField", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueField(field))
+}
+
+// DeleteIssueField delete a custom field of the issues of a repository
+func DeleteIssueField(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issue_fields/{id} issue issueDeleteIssueField
+	// ---
+	// summary: Delete a custom field of the issues of a repository and its values
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	field := getRepoIssueField(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.DeleteIssueField(ctx, field); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteIssueField", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func handleIssueFieldError(ctx *context.APIContext, name string, err error) {
+	switch {
+	case issues_model.IsErrIssueFieldAlreadyExist(err):
+		ctx.Error(http.StatusConflict, name, err)
+	case issues_model.IsErrInvalidIssueField(err):
+		ctx.Error(http.StatusUnprocessableEntity, name, err)
+	default:
+		ctx.Error(http.StatusInternalServerError, name, err)
+	}
+}
+
+// ListIssueFieldValues list the values of the custom fields of an issue
+func ListIssueFieldValues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/fields issue issueListFieldValues
+	// ---
+	// summary: List the values of the custom fields of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFieldValueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+	writeIssueFieldValues(ctx, issue)
+}
+
+// SetIssueFieldValue set the value of a custom field of an issue
+func SetIssueFieldValue(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/issues/{index}/fields/{id} issue issueSetFieldValue
+	// ---
+	// summary: Set the value of a custom field of an issue
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetIssueFieldValueOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFieldValueList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.SetIssueFieldValueOption)
+	setIssueFieldValue(ctx, form.Value)
+}
+
+// DeleteIssueFieldValue clear the value of a custom field of an issue
+func DeleteIssueFieldValue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/fields/{id} issue issueDeleteFieldValue
+	// ---
+	// summary: Clear the value of a custom field of an issue
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the field
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFieldValueList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	setIssueFieldValue(ctx, "")
+}
+
+func setIssueFieldValue(ctx *context.APIContext, value string) {
+	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Status(http.StatusForbidden)
+		return
+	}
+
+	field, err := issues_model.GetApplicableIssueFieldByID(ctx, ctx.Repo.Repository.ID, ctx.Repo.Repository.OwnerID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrIssueFieldNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetApplicableIssueFieldByID", err)
+		}
+		return
+	}
+	if err := issues_model.SetIssueFieldValue(ctx, issue, field, value); err != nil {
+		if issues_model.IsErrInvalidIssueFieldValue(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "SetIssueFieldValue", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "SetIssueFieldValue", err)
+		}
+		return
+	}
+	writeIssueFieldValues(ctx, issue)
+}
+
+func writeIssueFieldValues(ctx *context.APIContext, issue *issues_model.Issue) {
+	fields, err := issues_model.GetApplicableIssueFields(ctx, ctx.Repo.Repository.ID, ctx.Repo.Repository.OwnerID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetApplicableIssueFields", err)
+		return
+	}
+	values, err := issues_model.GetIssueFieldValues(ctx, issue)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueFieldValues", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueFieldValues(fields, values))
+}
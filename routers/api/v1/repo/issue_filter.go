@@ -0,0 +1,302 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+)
+
+// ListIssueFilters list the filters the doer saved for the issues and the pull requests of a repository
+func ListIssueFilters(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_filters issue issueListIssueFilters
+	// ---
+	// summary: List the filters the authenticated user saved for a repository, the pinned ones first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: query
+	//   description: whether to list the filters of the issues or of the pull requests, both if empty
+	//   type: string
+	//   enum: [issues, pulls]
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFilterList"
+
+	isPull := util.OptionalBoolNone
+	switch ctx.FormString("type") {
+	case "issues":
+		isPull = util.OptionalBoolFalse
+	case "pulls":
+		isPull = util.OptionalBoolTrue
+	}
+	filters, err := issues_model.GetIssueFilters(ctx, ctx.Doer.ID, ctx.Repo.Repository.ID, isPull)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueFilters", err)
+		return
+	}
+	visible := make([]*issues_model.IssueFilter, 0, len(filters))
+	for _, f := range filters {
+		if ctx.Repo.CanReadIssuesOrPulls(f.IsPull) {
+			visible = append(visible, f)
+		}
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueFilterList(visible, ctx.Repo.Repository))
+}
+
+// CreateIssueFilter save a filter of the issues or the pull requests of a repository
+func CreateIssueFilter(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issue_filters issue issueCreateIssueFilter
+	// ---
+	// summary: Save a filter of the issues or the pull requests of a repository for the authenticated user
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateIssueFilterOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/IssueFilter"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateIssueFilterOption)
+	f := &issues_model.IssueFilter{
+		UserID:      ctx.Doer.ID,
+		RepoID:      ctx.Repo.Repository.ID,
+		IsPull:      form.Type == "pulls",
+		Name:        form.Name,
+		MilestoneID: form.Milestone,
+		AssigneeID:  form.Assignee,
+		PosterID:    form.Poster,
+		IsClosed:    form.State == "closed",
+		SortType:    form.Sort,
+		Keyword:     form.Keyword,
+		IsPinned:    form.Pinned,
+	}
+	f.SetLabelIDs(form.Labels)
+	if !ctx.Repo.CanReadIssuesOrPulls(f.IsPull) {
+		ctx.Error(http.StatusForbidden, "", "Not repo reader")
+		return
+	}
+	if err := issues_model.CreateIssueFilter(ctx, f); err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "CreateIssueFilter", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToIssueFilter(f, ctx.Repo.Repository))
+}
+
+// getIssueFilter returns the filter of the request saved by the doer
+func getIssueFilter(ctx *context.APIContext) *issues_model.IssueFilter {
+	f, err := issues_model.GetIssueFilter(ctx, ctx.Doer.ID, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrIssueFilterNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueFilter", err)
+		}
+		return nil
+	}
+	if !ctx.Repo.CanReadIssuesOrPulls(f.IsPull) {
+		ctx.NotFound()
+		return nil
+	}
+	return f
+}
+
+// GetIssueFilter get a filter the doer saved for a repository
+func GetIssueFilter(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_filters/{id} issue issueGetIssueFilter
+	// ---
+	// summary: Get a filter the authenticated user saved for a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the filter to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFilter"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	f := getIssueFilter(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueFilter(f, ctx.Repo.Repository))
+}
+
+// EditIssueFilter edit a filter the doer saved for a repository
+func EditIssueFilter(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issue_filters/{id} issue issueEditIssueFilter
+	// ---
+	// summary: Edit a filter the authenticated user saved for a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the filter to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditIssueFilterOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueFilter"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditIssueFilterOption)
+	f := getIssueFilter(ctx)
+	if ctx.Written() {
+		return
+	}
+	if form.Name != nil {
+		f.Name = *form.Name
+	}
+	if form.Labels != nil {
+		f.SetLabelIDs(form.Labels)
+	}
+	if form.Milestone != nil {
+		f.MilestoneID = *form.Milestone
+	}
+	if form.Assignee != nil {
+		f.AssigneeID = *form.Assignee
+	}
+	if form.Poster != nil {
+		f.PosterID = *form.Poster
+	}
+	if form.State != nil {
+		switch *form.State {
+		case "open":
+			f.IsClosed = false
+		case "closed":
+			f.IsClosed = true
+		default:
+			ctx.Error(http.StatusUnprocessableEntity, "", errors.New("invalid state"))
+			return
+		}
+	}
+	if form.Sort != nil {
+		f.SortType = *form.Sort
+	}
+	if form.Keyword != nil {
+		f.Keyword = *form.Keyword
+	}
+	if form.Pinned != nil {
+		f.IsPinned = *form.Pinned
+	}
+	if err := issues_model.UpdateIssueFilter(ctx, f); err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "UpdateIssueFilter", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToIssueFilter(f, ctx.Repo.Repository))
+}
+
+// DeleteIssueFilter delete a filter the doer saved for a repository
+func DeleteIssueFilter(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issue_filters/{id} issue issueDeleteIssueFilter
+	// ---
+	// summary: Delete a filter the authenticated user saved for a repository
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the filter to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	f := getIssueFilter(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.DeleteIssueFilter(ctx, ctx.Doer.ID, ctx.Repo.Repository.ID, f.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteIssueFilter", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// ImportIssues creates issues from the rows of a CSV file
+func ImportIssues(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/import issue issueImportIssues
+	// ---
+	// summary: Create issues from the rows of a CSV file, no issue is created if a row is invalid
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ImportIssuesOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/IssueList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.ImportIssuesOption)
+
+	f, err := issue_service.ReadImportFile(strings.NewReader(form.Content))
+	if err != nil {
+		if issue_service.IsErrIssueImport(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "ReadImportFile", err)
+		return
+	}
+
+	mapping := issue_service.DefaultImportMapping(f.Headers)
+	for header, field := range form.Mapping {
+		if field == "" {
+			delete(mapping, header)
+		} else {
+			mapping[header] = issue_service.ImportField(field)
+		}
+	}
+
+	issues, err := issue_service.ImportIssues(ctx, ctx.Repo.Repository, ctx.Doer, f, mapping)
+	if err != nil {
+		if issue_service.IsErrIssueImport(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "ImportIssues", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToAPIIssueList(issues_model.IssueList(issues)))
+}
@@ -0,0 +1,194 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.gitea.io/gitea/models"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/migrations"
+)
+
+// GetIssueMirror returns the issue mirror of a repository
+func GetIssueMirror(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issue_mirror repository repoGetIssueMirror
+	// ---
+	// summary: Get the external issue tracker the issues of a repository are mirrored from
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueMirror"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	m := getIssueMirror(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueMirror(m))
+}
+
+// SetIssueMirror sets up or changes the issue mirror of a repository
+func SetIssueMirror(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/issue_mirror repository repoSetIssueMirror
+	// ---
+	// summary: Mirror the issues of an external repository, the issues of the repository become read-only
+	// description: The issues, comments and labels of the external repository are synchronized every hour, the
+	//   first synchronization starts at once.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetIssueMirrorOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueMirror"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if setting.Repository.DisableMigrations {
+		ctx.Error(http.StatusForbidden, "MigrationsGlobalDisabled", fmt.Errorf("the site administrator has disabled migrations"))
+		return
+	}
+
+	form := web.GetForm(ctx).(*api.SetIssueMirrorOption)
+	m, err := migrations.SetupIssueMirror(ctx, ctx.Doer, ctx.Repo.Repository, convert.ToGitServiceType(form.Service), form.RemoteAddress, form.AuthToken)
+	if err != nil {
+		switch {
+		case migrations.IsErrIssueMirrorServiceNotSupported(err):
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		case models.IsErrInvalidCloneAddr(err):
+			handleRemoteAddrError(ctx, err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "SetupIssueMirror", err)
+		}
+		return
+	}
+	migrations.StartIssueMirrorSync(m)
+
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueMirror(m))
+}
+
+// DeleteIssueMirror stops mirroring the issues of a repository
+func DeleteIssueMirror(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issue_mirror repository repoDeleteIssueMirror
+	// ---
+	// summary: Stop mirroring the issues of a repository, the mirrored issues are kept and can be changed again
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if getIssueMirror(ctx); ctx.Written() {
+		return
+	}
+	if err := issues_model.DeleteIssueMirror(ctx, ctx.Repo.Repository.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteIssueMirror", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// SyncIssueMirror starts the synchronization of the issue mirror of a repository
+func SyncIssueMirror(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issue_mirror/sync repository repoSyncIssueMirror
+	// ---
+	// summary: Synchronize the issues of a repository with its external issue tracker in the background
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "202":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if setting.Repository.DisableMigrations {
+		ctx.Error(http.StatusForbidden, "MigrationsGlobalDisabled", fmt.Errorf("the site administrator has disabled migrations"))
+		return
+	}
+
+	m := getIssueMirror(ctx)
+	if ctx.Written() {
+		return
+	}
+	migrations.StartIssueMirrorSync(m)
+	ctx.Status(http.StatusAccepted)
+}
+
+func getIssueMirror(ctx *context.APIContext) *issues_model.IssueMirror {
+	m, err := issues_model.GetIssueMirror(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueMirror", err)
+		return nil
+	} else if m == nil {
+		ctx.NotFound()
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,232 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+)
+
+// ListPinnedIssues list the issues and pull requests pinned to a repository
+func ListPinnedIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/pinned issue issueListPinnedIssues
+	// ---
+	// summary: List the issues and pull requests pinned to a repository, in their order
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PinnedIssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pins, err := issues_model.GetPinnedIssues(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetPinnedIssues", err)
+		return
+	}
+	readable := make(issues_model.PinnedIssueList, 0, len(pins))
+	for _, pin := range pins {
+		if ctx.Repo.CanReadIssuesOrPulls(pin.Issue.IsPull) {
+			readable = append(readable, pin)
+		}
+	}
+	visible, err := readable.VisibleTo(ctx, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "VisibleTo", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIPinnedIssueList(visible))
+}
+
+// getPinnableIssue returns the issue of the request and responds with an error if the doer can't pin it
+func getPinnableIssue(ctx *context.APIContext) *issues_model.Issue {
+	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return nil
+	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden, "", "Not repo writer")
+		return nil
+	}
+	return issue
+}
+
+// PinIssue pin an issue or a pull request to its repository
+func PinIssue(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/{index}/pin issue issuePinIssue
+	// ---
+	// summary: Pin an issue or a pull request to its repository, or change whether it is the announcement
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue to pin
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/PinIssueOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.PinIssueOption)
+	issue := getPinnableIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.PinIssue(ctx, issue, form.IsAnnouncement); err != nil {
+		if errors.Is(err, issues_model.ErrTooManyPinnedIssues) {
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "PinIssue", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// UnpinIssue unpin an issue or a pull request from its repository
+func UnpinIssue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/pin issue issueUnpinIssue
+	// ---
+	// summary: Unpin an issue or a pull request from its repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue to unpin
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getPinnableIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.UnpinIssue(ctx, issue); err != nil {
+		ctx.Error(http.StatusInternalServerError, "UnpinIssue", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// MovePinnedIssue move a pinned issue or pull request among the ones pinned to its repository
+func MovePinnedIssue(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/issues/{index}/pin/{position} issue issueMovePinnedIssue
+	// ---
+	// summary: Move a pinned issue or pull request to a position among the ones pinned to its repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue to move
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: position
+	//   in: path
+	//   description: new position of the issue, starting at 1
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getPinnableIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.MovePinnedIssue(ctx, issue, int(ctx.ParamsInt64(":position"))); err != nil {
+		if errors.Is(err, issues_model.ErrIssueNotPinned) {
+			ctx.NotFound(err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "MovePinnedIssue", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -197,6 +197,9 @@ func changeIssueCommentReaction(ctx *context.APIContext, form api.EditReactionOp
 
 	if isCreateType {
 		// PostIssueCommentReaction part
+		if !checkInteraction(ctx) {
+			return
+		}
 		reaction, err := issues_model.CreateCommentReaction(ctx.Doer.ID, comment.Issue.ID, comment.ID, form.Reaction)
 		if err != nil {
 			if issues_model.IsErrForbiddenIssueReaction(err) {
@@ -407,6 +410,9 @@ func changeIssueReaction(ctx *context.APIContext, form api.EditReactionOption, i
 
 	if isCreateType {
 		// PostIssueReaction part
+		if !checkInteraction(ctx) {
+			return
+		}
 		reaction, err := issues_model.CreateIssueReaction(ctx.Doer.ID, issue.ID, form.Reaction)
 		if err != nil {
 			if issues_model.IsErrForbiddenIssueReaction(err) {
@@ -0,0 +1,72 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// defaultSimilarIssuesLimit is the number of similar issues returned when the limit isn't given
+const defaultSimilarIssuesLimit = 5
+
+// ListSimilarIssues list the issues of a repository similar to a title
+func ListSimilarIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/similar issue issueListSimilarIssues
+	// ---
+	// summary: List the open and closed issues of a repository similar to the title of a new issue
+	// description: Use it to find the possible duplicates of an issue before creating it, the most similar first.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: title
+	//   in: query
+	//   description: title of the new issue
+	//   type: string
+	//   required: true
+	// - name: limit
+	//   in: query
+	//   description: maximum number of issues to return, 5 by default
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	title := strings.TrimSpace(ctx.FormString("title"))
+	if title == "" {
+		ctx.Error(http.StatusUnprocessableEntity, "", "title is required")
+		return
+	}
+	limit := ctx.FormInt("limit")
+	if limit <= 0 {
+		limit = defaultSimilarIssuesLimit
+	}
+	limit = convert.ToCorrectPageSize(limit)
+
+	issues, err := issue_service.FindSimilarIssues(ctx, ctx.Repo.Repository, ctx.Doer, title, limit)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindSimilarIssues", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(issues))
+}
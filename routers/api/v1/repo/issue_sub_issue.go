@@ -0,0 +1,319 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+)
+
+// getSubIssueParent returns the issue of the request and responds with an error if the doer can't read it, or can't
+// change its sub-issues when write is true
+func getSubIssueParent(ctx *context.APIContext, write bool) *issues_model.Issue {
+	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return nil
+	}
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound()
+		return nil
+	}
+	if write && !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden, "", "Not repo writer")
+		return nil
+	}
+	return issue
+}
+
+// ListSubIssues list the sub-issues of an issue
+func ListSubIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueListSubIssues
+	// ---
+	// summary: List the direct sub-issues of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getSubIssueParent(ctx, false)
+	if ctx.Written() {
+		return
+	}
+	subIssues, err := issues_model.GetSubIssues(ctx, issue.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetSubIssues", err)
+		return
+	}
+	if subIssues, err = subIssues.VisibleTo(ctx, ctx.Doer); err != nil {
+		ctx.Error(http.StatusInternalServerError, "VisibleTo", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(subIssues))
+}
+
+// AddSubIssue attach a sub-issue to an issue
+func AddSubIssue(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueAddSubIssue
+	// ---
+	// summary: Attach an issue of the same repository as a sub-issue of an issue
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the parent issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/AddSubIssueOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Issue"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.AddSubIssueOption)
+	parent := getSubIssueParent(ctx, true)
+	if ctx.Written() {
+		return
+	}
+	subIssue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, form.Index)
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "GetIssueByIndex", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+
+	if err := issues_model.AddSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		switch {
+		case issues_model.IsErrSubIssueHasParent(err):
+			ctx.Error(http.StatusConflict, "AddSubIssue", err)
+		case issues_model.IsErrCircularSubIssue(err), issues_model.IsErrInvalidSubIssue(err):
+			ctx.Error(http.StatusUnprocessableEntity, "AddSubIssue", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "AddSubIssue", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(subIssue))
+}
+
+// RemoveSubIssue detach a sub-issue from an issue
+func RemoveSubIssue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/sub_issues/{sub_index} issue issueRemoveSubIssue
+	// ---
+	// summary: Detach a sub-issue from an issue, its own sub-issues stay attached to it
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the parent issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: sub_index
+	//   in: path
+	//   description: index of the sub-issue to detach
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	parent := getSubIssueParent(ctx, true)
+	if ctx.Written() {
+		return
+	}
+	subIssue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":sub_index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+	if err := issues_model.RemoveSubIssue(ctx, parent, subIssue); err != nil {
+		if issues_model.IsErrSubIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "RemoveSubIssue", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetSubIssueProgress get the progress of the sub-issues of an issue
+func GetSubIssueProgress(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/sub_issues/progress issue issueGetSubIssueProgress
+	// ---
+	// summary: Get the number of sub-issues of an issue and how many of them are closed, at all the levels of its tree
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SubIssueProgress"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getSubIssueParent(ctx, false)
+	if ctx.Written() {
+		return
+	}
+	tree, err := issues_model.GetSubIssueTree(ctx, issue)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetSubIssueTree", err)
+		return
+	}
+	if tree, err = tree.VisibleTo(ctx, ctx.Doer); err != nil {
+		ctx.Error(http.StatusInternalServerError, "VisibleTo", err)
+		return
+	}
+	progress := tree.Progress()
+	ctx.JSON(http.StatusOK, &api.SubIssueProgress{
+		Total:   progress.Total,
+		Closed:  progress.Closed,
+		Percent: progress.Percent(),
+	})
+}
+
+// GetParentIssue get the parent of a sub-issue
+func GetParentIssue(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/parent issue issueGetParentIssue
+	// ---
+	// summary: Get the parent of a sub-issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the sub-issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Issue"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getSubIssueParent(ctx, false)
+	if ctx.Written() {
+		return
+	}
+	parent, err := issues_model.GetParentIssue(ctx, issue.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetParentIssue", err)
+		return
+	}
+	if parent == nil {
+		ctx.NotFound()
+		return
+	}
+	if hidden, err := parent.IsHiddenFrom(ctx, ctx.Doer); err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsHiddenFrom", err)
+		return
+	} else if hidden {
+		ctx.NotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssue(parent))
+}
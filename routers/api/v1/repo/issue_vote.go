@@ -0,0 +1,200 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+// ListIssueVoters list the users who voted for an issue
+func ListIssueVoters(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/votes issue issueListVoters
+	// ---
+	// summary: List the users who voted for an issue, latest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getVotedIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	users, err := issues_model.GetIssueVoters(ctx, issue.ID, listOptions)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueVoters", err)
+		return
+	}
+	apiUsers := make([]*api.User, 0, len(users))
+	for _, u := range users {
+		apiUsers = append(apiUsers, convert.ToUser(u, ctx.Doer))
+	}
+
+	ctx.SetLinkHeader(issue.NumVotes, listOptions.PageSize)
+	ctx.SetTotalCountHeader(int64(issue.NumVotes))
+	ctx.JSON(http.StatusOK, apiUsers)
+}
+
+// VoteForIssue adds the vote of the authenticated user for an issue
+func VoteForIssue(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/{index}/votes issue issueVote
+	// ---
+	// summary: Vote for an issue, a user votes at most once for an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Issue"
+	//   "201":
+	//     "$ref": "#/responses/Issue"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getVotedIssue(ctx)
+	if ctx.Written() || !canVoteForIssue(ctx, issue) {
+		return
+	}
+
+	voted, err := issues_model.HasVotedForIssue(ctx, ctx.Doer.ID, issue.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "HasVotedForIssue", err)
+		return
+	}
+	if voted {
+		ctx.JSON(http.StatusOK, convert.ToAPIIssue(issue))
+		return
+	}
+	if err := issues_model.VoteForIssue(ctx, ctx.Doer.ID, issue); err != nil {
+		ctx.Error(http.StatusInternalServerError, "VoteForIssue", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(issue))
+}
+
+// RemoveIssueVote removes the vote of the authenticated user for an issue
+func RemoveIssueVote(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/votes issue issueRemoveVote
+	// ---
+	// summary: Remove the vote of the authenticated user for an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Issue"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getVotedIssue(ctx)
+	if ctx.Written() || !canVoteForIssue(ctx, issue) {
+		return
+	}
+
+	if err := issues_model.RemoveIssueVote(ctx, ctx.Doer.ID, issue); err != nil {
+		ctx.Error(http.StatusInternalServerError, "RemoveIssueVote", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssue(issue))
+}
+
+func getVotedIssue(ctx *context.APIContext) *issues_model.Issue {
+	issue, err := issues_model.GetIssueWithAttrsByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueWithAttrsByIndex", err)
+		}
+		return nil
+	}
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound()
+		return nil
+	}
+	return issue
+}
+
+// canVoteForIssue checks that the issue isn't locked and that the doer can interact with the repository
+func canVoteForIssue(ctx *context.APIContext, issue *issues_model.Issue) bool {
+	if issue.IsLocked && !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden, "VoteForIssue", errors.New("the issue is locked"))
+		return false
+	}
+	return checkInteraction(ctx)
+}
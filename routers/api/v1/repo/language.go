@@ -11,7 +11,10 @@ import (
 
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
 	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
 )
 
 type languageResponse []*repo_model.LanguageStat
@@ -80,3 +83,74 @@ func GetLanguages(ctx *context.APIContext) {
 
 	ctx.JSON(http.StatusOK, resp)
 }
+
+// defaultLanguageHistoryDays is the number of days of history returned when the period isn't given
+const defaultLanguageHistoryDays = 90
+
+// ListLanguageStatsHistory list the daily language statistics of a repository
+func ListLanguageStatsHistory(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/languages/history repository repoListLanguageStatsHistory
+	// ---
+	// summary: List the daily snapshots of the languages of a repository
+	// description: A snapshot is recorded on the days the statistics of the default branch are updated,
+	//              the days without snapshot kept the languages of the previous one.
+	// produces:
+	//   - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: since
+	//   in: query
+	//   description: only days starting at or after this time, defaults to 90 days before `before`
+	//   type: string
+	//   format: date-time
+	// - name: before
+	//   in: query
+	//   description: only days starting before this time, defaults to now
+	//   type: string
+	//   format: date-time
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/LanguageStatsSnapshotList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	before, since, err := context.GetQueryBeforeSince(ctx.Context)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
+		return
+	}
+	if before == 0 {
+		before = int64(timeutil.TimeStampNow())
+	}
+	if since == 0 {
+		since = before - defaultLanguageHistoryDays*repo_model.LanguageStatsDay
+	}
+	if since >= before {
+		ctx.Error(http.StatusUnprocessableEntity, "", "since must be before before")
+		return
+	}
+
+	snapshots, err := repo_model.FindLanguageStatsHistory(ctx, ctx.Repo.Repository.ID, timeutil.TimeStamp(since), timeutil.TimeStamp(before))
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	apiSnapshots := make([]*api.LanguageStatsSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		apiSnapshots = append(apiSnapshots, convert.ToLanguageStatsSnapshot(snapshot))
+	}
+	ctx.SetTotalCountHeader(int64(len(snapshots)))
+	ctx.JSON(http.StatusOK, apiSnapshots)
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/httpcache"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/repository/files"
+)
+
+// GetLastCommits get the last commit which changed each of the given files and directories
+func GetLastCommits(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/last_commits repository repoGetLastCommits
+	// ---
+	// summary: Get the last commit which changed each of the given files and directories
+	// description: The paths are given by the `path` parameters, the entries of the `dir` directory are added to them
+	//   page by page. The response is the same for a commit and the same paths, it can be cached using its ETag.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: "The name of the commit/branch/tag. Default the repository’s default branch (usually master)"
+	//   type: string
+	// - name: path
+	//   in: query
+	//   description: paths of the files and directories, the root directory being the empty path
+	//   type: array
+	//   collectionFormat: multi
+	//   items:
+	//     type: string
+	// - name: dir
+	//   in: query
+	//   description: directory whose entries are added to the paths
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of the entries of the directory (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of the entries of the directory
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/LastCommitList"
+	//   "304":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	paths := ctx.FormStrings("path")
+	if len(paths) > setting.API.MaxResponseItems {
+		ctx.Error(http.StatusUnprocessableEntity, "", fmt.Sprintf("at most %d paths can be given", setting.API.MaxResponseItems))
+		return
+	}
+
+	ref := ctx.FormTrim("ref")
+	if ref == "" {
+		ref = ctx.Repo.Repository.DefaultBranch
+	}
+	sha := utils.ResolveRefOrSha(ctx, ref)
+	if ctx.Written() {
+		return
+	}
+	commit, err := ctx.Repo.GitRepo.GetCommit(sha)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.NotFound("GetCommit", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetCommit", err)
+		}
+		return
+	}
+
+	if ctx.FormString("dir") != "" {
+		tree, err := commit.SubTree(ctx.FormString("dir"))
+		if err != nil {
+			if git.IsErrNotExist(err) {
+				ctx.NotFound("SubTree", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "SubTree", err)
+			}
+			return
+		}
+		entries, err := tree.ListEntries()
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "ListEntries", err)
+			return
+		}
+		listOptions := utils.GetListOptions(ctx)
+		listOptions.SetDefaultValues()
+		start, end := listOptions.GetStartEnd()
+		if start > len(entries) {
+			start = len(entries)
+		}
+		if end > len(entries) {
+			end = len(entries)
+		}
+		for _, entry := range entries[start:end] {
+			paths = append(paths, ctx.FormString("dir")+"/"+entry.Name())
+		}
+		ctx.SetLinkHeader(len(entries), listOptions.PageSize)
+		ctx.SetTotalCountHeader(int64(len(entries)))
+	}
+
+	// the last commits of the paths of a commit never change
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256([]byte(commit.ID.String()+"\n"+strings.Join(paths, "\n"))))
+	if httpcache.HandleGenericETagCache(ctx.Req, ctx.Resp, etag) {
+		return
+	}
+
+	lastCommits, err := files.GetLastCommits(ctx, ctx.Repo.Repository, commit, paths)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.NotFound("GetLastCommits", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetLastCommits", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, lastCommits)
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+)
+
+// GetMaintenanceReport returns the maintenance report of a repository
+func GetMaintenanceReport(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/insights/maintenance repository repoGetMaintenanceReport
+	// ---
+	// summary: Get the files with a single author, the stale directories and the stale dependency manifests of a repository
+	// description: The report is computed once a day from the index of the expertise indexer,
+	//              it is not found when the repository has not been indexed yet.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MaintenanceReport"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	report, err := repo_model.GetMaintenanceReport(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetMaintenanceReport", err)
+		return
+	} else if report == nil {
+		ctx.NotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToMaintenanceReport(report, ctx.Repo.Repository))
+}
@@ -0,0 +1,312 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	moderation_service "code.gitea.io/gitea/services/moderation"
+)
+
+// CreateIssueReport reports an issue or a pull request to the moderators
+func CreateIssueReport(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/{index}/reports issue issueCreateReport
+	// ---
+	// summary: Report an issue or a pull request to the moderators
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateModerationReportOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ModerationReport"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+	createReport(ctx, issue, nil)
+}
+
+// CreateIssueCommentReport reports a comment to the moderators
+func CreateIssueCommentReport(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/comments/{id}/reports issue issueCreateCommentReport
+	// ---
+	// summary: Report a comment to the moderators
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the comment
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateModerationReportOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ModerationReport"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	comment, err := issues_model.GetCommentByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrCommentNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetCommentByID", err)
+		}
+		return
+	}
+	if err := comment.LoadIssueCtx(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadIssue", err)
+		return
+	}
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+		ctx.NotFound()
+		return
+	}
+	createReport(ctx, comment.Issue, comment)
+}
+
+func createReport(ctx *context.APIContext, issue *issues_model.Issue, comment *issues_model.Comment) {
+	form := web.GetForm(ctx).(*api.CreateModerationReportOption)
+	report, err := moderation_service.ReportContent(ctx, ctx.Doer, issue, comment, moderation_model.ReportCategory(form.Category), form.Remark)
+	if err != nil {
+		switch {
+		case errors.Is(err, moderation_model.ErrReportAlreadyExists):
+			ctx.Error(http.StatusConflict, "", err)
+		case errors.Is(err, moderation_model.ErrInvalidReportCategory),
+			errors.Is(err, moderation_service.ErrCannotReportContent),
+			errors.Is(err, moderation_service.ErrCannotReportOwnContent):
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "ReportContent", err)
+		}
+		return
+	}
+	if err := report.LoadAttributes(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadAttributes", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToModerationReport(report, ctx.Doer))
+}
+
+// ListModerationReports lists the reports about the issues and comments of a repository
+func ListModerationReports(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/moderation/reports repository repoListModerationReports
+	// ---
+	// summary: List the reports about the issues, pull requests and comments of a repository, oldest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: status
+	//   in: query
+	//   description: only the reports with this status
+	//   type: string
+	//   enum: [open, resolved, dismissed]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ModerationReportList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	utils.ListModerationReports(ctx, &moderation_model.FindReportsOptions{RepoID: ctx.Repo.Repository.ID})
+}
+
+// HandleModerationReport takes an action on a report about the content of a repository
+func HandleModerationReport(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/moderation/reports/{id}/actions repository repoHandleModerationReport
+	// ---
+	// summary: Take an action on an open report, closing all the open reports about the same content
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the report
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ModerationActionOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ModerationReport"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	report, err := moderation_model.GetReportByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, moderation_model.ErrReportNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetReportByID", err)
+		}
+		return
+	}
+	if report.RepoID != ctx.Repo.Repository.ID {
+		ctx.NotFound()
+		return
+	}
+
+	form := web.GetForm(ctx).(*api.ModerationActionOption)
+	if err := moderation_service.HandleReport(ctx, ctx.Doer, report, moderation_model.ActionType(form.Action), form.Reason); err != nil {
+		switch {
+		case errors.Is(err, moderation_service.ErrReportClosed):
+			ctx.Error(http.StatusConflict, "", err)
+		case errors.Is(err, moderation_service.ErrInvalidAction), errors.Is(err, moderation_service.ErrCannotHideContent):
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "HandleReport", err)
+		}
+		return
+	}
+
+	report, err = moderation_model.GetReportByID(ctx, report.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetReportByID", err)
+		return
+	}
+	if err := report.LoadAttributes(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadAttributes", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToModerationReport(report, ctx.Doer))
+}
+
+// ListModerationLog lists the moderation audit trail of a repository
+func ListModerationLog(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/moderation/log repository repoListModerationLog
+	// ---
+	// summary: List the actions of the moderators in a repository, newest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ModerationLog"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	utils.ListModerationLog(ctx, &moderation_model.FindLogsOptions{RepoID: ctx.Repo.Repository.ID})
+}
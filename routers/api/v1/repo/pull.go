@@ -272,12 +272,17 @@ func CreatePullRequest(ctx *context.APIContext) {
 	// responses:
 	//   "201":
 	//     "$ref": "#/responses/PullRequest"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
 	//   "409":
 	//     "$ref": "#/responses/error"
 	//   "422":
 	//     "$ref": "#/responses/validationError"
 
 	form := *web.GetForm(ctx).(*api.CreatePullRequestOption)
+	if !checkInteraction(ctx) {
+		return
+	}
 	if form.Head == form.Base {
 		ctx.Error(http.StatusUnprocessableEntity, "BaseHeadSame",
 			"Invalid PullRequest: There are no changes between the head and the base")
@@ -632,6 +637,15 @@ func EditPullRequest(ctx *context.APIContext) {
 		}
 	}
 
+	// update review reminder opt-out
+	if form.DisableReviewReminders != nil && *form.DisableReviewReminders != pr.DisableReviewReminders {
+		pr.DisableReviewReminders = *form.DisableReviewReminders
+		if err := issues_model.UpdateDisableReviewReminders(ctx, pr); err != nil {
+			ctx.Error(http.StatusInternalServerError, "UpdateDisableReviewReminders", err)
+			return
+		}
+	}
+
 	// Refetch from database
 	pr, err = issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, pr.Index)
 	if err != nil {
@@ -728,6 +742,8 @@ func MergePullRequest(ctx *context.APIContext) {
 	//     "$ref": "#/responses/empty"
 	//   "409":
 	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	form := web.GetForm(ctx).(*forms.MergePullRequestForm)
 
@@ -821,6 +837,15 @@ func MergePullRequest(ctx *context.APIContext) {
 		message += "\n\n" + form.MergeMessageField
 	}
 
+	if err := pull_service.CheckMergeMessage(ctx.Repo.GitRepo, pr, repo_model.MergeStyle(form.Do), message); err != nil {
+		if pull_service.IsErrMergeMessageNotMatchTemplate(err) {
+			ctx.Error(http.StatusUnprocessableEntity, "CheckMergeMessage", err)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "CheckMergeMessage", err)
+		return
+	}
+
 	if form.MergeWhenChecksSucceed {
 		scheduled, err := automerge.ScheduleAutoMerge(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message)
 		if err != nil {
@@ -0,0 +1,179 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/gitdiff"
+	pull_service "code.gitea.io/gitea/services/pull"
+)
+
+// previewedMergeStyles are the merge styles whose result can be previewed
+var previewedMergeStyles = []repo_model.MergeStyle{
+	repo_model.MergeStyleMerge,
+	repo_model.MergeStyleRebase,
+	repo_model.MergeStyleRebaseMerge,
+	repo_model.MergeStyleSquash,
+}
+
+// diffFileStatus are the statuses of the types of the files of a diff
+var diffFileStatus = map[gitdiff.DiffFileType]string{
+	gitdiff.DiffFileAdd:    "added",
+	gitdiff.DiffFileChange: "modified",
+	gitdiff.DiffFileDel:    "deleted",
+	gitdiff.DiffFileRename: "renamed",
+	gitdiff.DiffFileCopy:   "copied",
+}
+
+// GetPullRequestMergePreview previews the merge of a pull request with each merge style allowed by the repository
+func GetPullRequestMergePreview(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/merge/preview repository repoGetPullRequestMergePreview
+	// ---
+	// summary: Preview the commits, conflicts and changed files of merging a pull request with each allowed merge style
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the pull request
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PullRequestMergePreview"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+
+	pr, err := issues_model.GetPullRequestByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrPullRequestNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetPullRequestByIndex", err)
+		}
+		return
+	}
+	if err := pr.LoadIssueCtx(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadIssue", err)
+		return
+	}
+	if err := pr.LoadBaseRepoCtx(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadBaseRepo", err)
+		return
+	}
+	if err := pr.LoadHeadRepoCtx(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadHeadRepo", err)
+		return
+	}
+	if pr.HasMerged || pr.Issue.IsClosed {
+		ctx.Error(http.StatusConflict, "", "the pull request is closed")
+		return
+	}
+	if pr.HeadRepo == nil {
+		ctx.Error(http.StatusConflict, "", "the head repository of the pull request doesn't exist")
+		return
+	}
+
+	gitRepo := ctx.Repo.GitRepo
+	baseCommitID, err := gitRepo.GetBranchCommitID(pr.BaseBranch)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetBranchCommitID", err)
+		return
+	}
+	headCommitID, err := gitRepo.GetRefCommitID(pr.GetGitRefName())
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetRefCommitID", err)
+		return
+	}
+	mergeBase, _, err := gitRepo.GetMergeBase("", baseCommitID, headCommitID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetMergeBase", err)
+		return
+	}
+
+	prConfig := ctx.Repo.Repository.MustGetUnit(unit.TypePullRequests).PullRequestsConfig()
+	styles := make([]repo_model.MergeStyle, 0, len(previewedMergeStyles))
+	for _, style := range previewedMergeStyles {
+		if prConfig.IsMergeStyleAllowed(style) {
+			styles = append(styles, style)
+		}
+	}
+	previews, err := pull_service.PreviewMerge(ctx, pr, styles)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "PreviewMerge", err)
+		return
+	}
+
+	diff, err := gitdiff.GetDiff(gitRepo, &gitdiff.DiffOptions{
+		BeforeCommitID:    mergeBase,
+		AfterCommitID:     headCommitID,
+		MaxLines:          setting.Git.MaxGitDiffLines,
+		MaxLineCharacters: setting.Git.MaxGitDiffLineCharacters,
+		MaxFiles:          setting.Git.MaxGitDiffFiles,
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetDiff", err)
+		return
+	}
+
+	result := &api.PullRequestMergePreview{
+		BaseCommit:     baseCommitID,
+		HeadCommit:     headCommitID,
+		MergeBase:      mergeBase,
+		Styles:         make([]*api.PullRequestMergeStylePreview, 0, len(previews)),
+		Files:          make([]*api.PullRequestChangedFile, 0, len(diff.Files)),
+		FilesTruncated: diff.IsIncomplete,
+		Additions:      diff.TotalAddition,
+		Deletions:      diff.TotalDeletion,
+	}
+	for _, preview := range previews {
+		conflictedFiles := preview.ConflictedFiles
+		if conflictedFiles == nil {
+			conflictedFiles = []string{}
+		}
+		result.Styles = append(result.Styles, &api.PullRequestMergeStylePreview{
+			Style:           string(preview.Style),
+			Mergeable:       preview.Mergeable,
+			CommitCount:     preview.CommitCount,
+			ConflictedFiles: conflictedFiles,
+			ConflictCommit:  preview.ConflictCommitSHA,
+		})
+	}
+	for _, file := range diff.Files {
+		changedFile := &api.PullRequestChangedFile{
+			Filename:  file.Name,
+			Status:    diffFileStatus[file.Type],
+			Additions: file.Addition,
+			Deletions: file.Deletion,
+			Binary:    file.IsBin,
+		}
+		if file.IsRenamed || file.Type == gitdiff.DiffFileCopy {
+			changedFile.PreviousFilename = file.OldName
+		}
+		result.Files = append(result.Files, changedFile)
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,351 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/modules/git"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	release_service "code.gitea.io/gitea/services/release"
+)
+
+// getReleaseBuild returns the release build of the `id` parameter, it responds with a 404 if it doesn't exist
+func getReleaseBuild(ctx *context.APIContext) *repo_model.ReleaseBuild {
+	build, err := repo_model.GetReleaseBuildByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if repo_model.IsErrReleaseBuildNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetReleaseBuildByID", err)
+		}
+		return nil
+	}
+	return build
+}
+
+// getClaimedReleaseBuild returns the release build of the `id` parameter, it responds with a 403 if the doer is
+// neither the builder which claimed it nor a repository administrator
+func getClaimedReleaseBuild(ctx *context.APIContext) *repo_model.ReleaseBuild {
+	build := getReleaseBuild(ctx)
+	if build == nil {
+		return nil
+	}
+	if build.BuilderID != ctx.Doer.ID && !ctx.Repo.IsAdmin() {
+		ctx.Error(http.StatusForbidden, "", "the release build was claimed by another user")
+		return nil
+	}
+	return build
+}
+
+// ListReleaseBuilds list the builds of the releases of a repository
+func ListReleaseBuilds(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/releases/builds repository repoListReleaseBuilds
+	// ---
+	// summary: List the builds of the release assets, the latest first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ReleaseBuildList"
+
+	listOptions := utils.GetListOptions(ctx)
+	builds, count, err := repo_model.FindReleaseBuilds(ctx, ctx.Repo.Repository.ID, listOptions)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindReleaseBuilds", err)
+		return
+	}
+
+	apiBuilds := make([]*api.ReleaseBuild, len(builds))
+	for i, build := range builds {
+		if err := build.LoadBuilder(ctx); err != nil {
+			ctx.Error(http.StatusInternalServerError, "LoadBuilder", err)
+			return
+		}
+		apiBuilds[i] = convert.ToReleaseBuild(build)
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiBuilds)
+}
+
+// ClaimReleaseBuild claim a pushed tag to build the assets of its release
+func ClaimReleaseBuild(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/releases/builds repository repoClaimReleaseBuild
+	// ---
+	// summary: Claim a pushed tag to build the assets of its release
+	// description: The release of the tag, created if it doesn't exist, is turned into a draft. The assets are
+	//   uploaded to the draft, which is published with all of them once the build succeeds. A tag whose build failed
+	//   can be claimed again, the assets of the failed build are then deleted.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateReleaseBuildOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ReleaseBuild"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateReleaseBuildOption)
+	build, err := release_service.ClaimReleaseBuild(ctx, ctx.Doer, ctx.Repo.GitRepo, ctx.Repo.Repository, form.TagName)
+	if err != nil {
+		switch {
+		case git.IsErrNotExist(err):
+			ctx.NotFound()
+		case repo_model.IsErrReleaseBuildAlreadyClaimed(err), repo_model.IsErrReleaseAlreadyExist(err):
+			ctx.Error(http.StatusConflict, "ClaimReleaseBuild", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "ClaimReleaseBuild", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToReleaseBuild(build))
+}
+
+// GetReleaseBuild get a build of the assets of a release
+func GetReleaseBuild(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/releases/builds/{id} repository repoGetReleaseBuild
+	// ---
+	// summary: Get a build of the release assets
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the build
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ReleaseBuild"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	build := getReleaseBuild(ctx)
+	if build == nil {
+		return
+	}
+	if err := build.LoadBuilder(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadBuilder", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToReleaseBuild(build))
+}
+
+// EditReleaseBuild update the status of a build of the assets of a release
+func EditReleaseBuild(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/releases/builds/{id} repository repoEditReleaseBuild
+	// ---
+	// summary: Update the status of a build of the release assets, its release is published when it succeeds
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the build
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditReleaseBuildOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ReleaseBuild"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	build := getClaimedReleaseBuild(ctx)
+	if build == nil {
+		return
+	}
+	form := web.GetForm(ctx).(*api.EditReleaseBuildOption)
+	if err := build.LoadBuilder(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadBuilder", err)
+		return
+	}
+	if err := release_service.UpdateReleaseBuildStatus(ctx, build, repo_model.ReleaseBuildStatus(form.Status), form.Description); err != nil {
+		switch {
+		case repo_model.IsErrReleaseBuildFinished(err):
+			ctx.Error(http.StatusConflict, "UpdateReleaseBuildStatus", err)
+		case repo_model.IsErrReleaseNotExist(err):
+			ctx.NotFound()
+		default:
+			ctx.Error(http.StatusInternalServerError, "UpdateReleaseBuildStatus", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToReleaseBuild(build))
+}
+
+// GetReleaseBuildLog get the log of a build of the assets of a release
+func GetReleaseBuildLog(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/releases/builds/{id}/log repository repoGetReleaseBuildLog
+	// ---
+	// summary: Get the log of a build of the release assets
+	// produces:
+	// - text/plain
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the build
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/string"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	build := getReleaseBuild(ctx)
+	if build == nil {
+		return
+	}
+	ctx.PlainText(http.StatusOK, build.Log)
+}
+
+// AppendReleaseBuildLog append to the log of a build of the assets of a release
+func AppendReleaseBuildLog(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/releases/builds/{id}/log repository repoAppendReleaseBuildLog
+	// ---
+	// summary: Append to the log of a running build of the release assets
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the build
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/AppendReleaseBuildLogOption"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	build := getClaimedReleaseBuild(ctx)
+	if build == nil {
+		return
+	}
+	form := web.GetForm(ctx).(*api.AppendReleaseBuildLogOption)
+	if err := release_service.AppendReleaseBuildLog(ctx, build, form.Content); err != nil {
+		if repo_model.IsErrReleaseBuildFinished(err) {
+			ctx.Error(http.StatusConflict, "AppendReleaseBuildLog", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "AppendReleaseBuildLog", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,114 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	release_service "code.gitea.io/gitea/services/release"
+)
+
+// GetReleaseDraft get the release drafted from the merged pull requests of a repository
+func GetReleaseDraft(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/releases/draft repository repoGetReleaseDraft
+	// ---
+	// summary: Get the release drafted from the pull requests merged since the latest release
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Release"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	draft, err := release_service.GetReleaseDraft(ctx, ctx.Repo.Repository)
+	if err != nil {
+		if repo_model.IsErrReleaseNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetReleaseDraft", err)
+		}
+		return
+	}
+	if err := draft.LoadAttributes(); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadAttributes", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToRelease(draft))
+}
+
+// PublishReleaseDraft publish the release drafted from the merged pull requests of a repository
+func PublishReleaseDraft(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/releases/draft/publish repository repoPublishReleaseDraft
+	// ---
+	// summary: Publish the drafted release with a tag, which is created if it doesn't exist
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/PublishReleaseDraftOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Release"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/error"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.PublishReleaseDraftOption)
+	rel, err := release_service.PublishReleaseDraft(ctx, ctx.Doer, ctx.Repo.GitRepo, ctx.Repo.Repository, release_service.PublishReleaseDraftOptions{
+		TagName:      form.TagName,
+		Target:       form.Target,
+		Title:        form.Title,
+		IsPrerelease: form.IsPrerelease,
+	})
+	if err != nil {
+		switch {
+		case repo_model.IsErrReleaseNotExist(err):
+			ctx.NotFound()
+		case repo_model.IsErrReleaseAlreadyExist(err):
+			ctx.Error(http.StatusConflict, "ReleaseAlreadyExist", err)
+		case models.IsErrProtectedTagName(err), models.IsErrInvalidTagName(err):
+			ctx.Error(http.StatusUnprocessableEntity, "PublishReleaseDraft", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "PublishReleaseDraft", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToRelease(rel))
+}
@@ -0,0 +1,122 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"path"
+
+	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// RenderFile renders a markup file of a repository as HTML
+func RenderFile(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/render/{filepath} repository repoRenderFile
+	// ---
+	// summary: Render a markup file of a repository as HTML
+	// description: The file is rendered and sanitized like in the web interface, its relative links and images
+	//              are resolved to the raw files of the same commit.
+	// produces:
+	// - text/html
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: filepath
+	//   in: path
+	//   description: filepath of the markup file to render
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: "The name of the commit/branch/tag. Default the repository’s default branch (usually master)"
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/MarkdownRender"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if ctx.Repo.Repository.IsEmpty {
+		ctx.NotFound()
+		return
+	}
+
+	entry, err := ctx.Repo.Commit.GetTreeEntryByPath(ctx.Repo.TreePath)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetTreeEntryByPath", err)
+		}
+		return
+	}
+	if entry.IsDir() || entry.IsSubModule() {
+		ctx.NotFound()
+		return
+	}
+	blob := entry.Blob()
+	if blob.Size() > setting.UI.MaxDisplayFileSize {
+		ctx.Error(http.StatusUnprocessableEntity, "", "the file is too large to be rendered")
+		return
+	}
+
+	dataRc, err := blob.DataAsync()
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "DataAsync", err)
+		return
+	}
+	defer dataRc.Close()
+
+	buf := make([]byte, 1024)
+	n, _ := util.ReadAtMost(dataRc, buf)
+	buf = buf[:n]
+
+	// the renderer type is only passed down when it is detected by a custom markup renderer
+	markupType := ""
+	if markup.Type(blob.Name()) == "" {
+		if markupType = markup.DetectRendererType(blob.Name(), bytes.NewReader(buf)); markupType == "" {
+			ctx.Error(http.StatusUnprocessableEntity, "", "the file is not a markup file")
+			return
+		}
+	}
+
+	commitID := ctx.Repo.Commit.ID.String()
+	urlPrefix := ctx.Repo.Repository.HTMLURL() + "/raw/commit/" + commitID
+	if dir := path.Dir(ctx.Repo.TreePath); dir != "." {
+		urlPrefix += "/" + util.PathEscapeSegments(dir)
+	}
+	metas := ctx.Repo.Repository.ComposeDocumentMetas()
+	metas["BranchNameSubURL"] = "commit/" + commitID
+
+	ctx.Resp.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := markup.Render(&markup.RenderContext{
+		Ctx:          ctx,
+		Type:         markupType,
+		RelativePath: ctx.Repo.TreePath,
+		URLPrefix:    urlPrefix,
+		Metas:        metas,
+		GitRepo:      ctx.Repo.GitRepo,
+	}, charset.ToUTF8WithFallbackReader(io.MultiReader(bytes.NewReader(buf), dataRc)), ctx.Resp); err != nil {
+		ctx.InternalServerError(err)
+	}
+}
@@ -761,10 +761,27 @@ func updateRepoUnits(ctx *context.APIContext, opts api.EditRepoOption) error {
 			var config *repo_model.IssuesConfig
 
 			if opts.InternalTracker != nil {
-				config = &repo_model.IssuesConfig{
-					EnableTimetracker:                opts.InternalTracker.EnableTimeTracker,
-					AllowOnlyContributorsToTrackTime: opts.InternalTracker.AllowOnlyContributorsToTrackTime,
-					EnableDependencies:               opts.InternalTracker.EnableIssueDependencies,
+				config = &repo_model.IssuesConfig{}
+				if unit, err := repo.GetUnit(unit_model.TypeIssues); err == nil {
+					// keep the stale policy when it isn't part of the options
+					*config = *unit.IssuesConfig()
+				}
+				config.EnableTimetracker = opts.InternalTracker.EnableTimeTracker
+				config.AllowOnlyContributorsToTrackTime = opts.InternalTracker.AllowOnlyContributorsToTrackTime
+				config.EnableDependencies = opts.InternalTracker.EnableIssueDependencies
+				if policy := opts.InternalTracker.StalePolicy; policy != nil {
+					config.StalePolicy = repo_model.StalePolicy{
+						StaleDays:             policy.StaleDays,
+						StaleCloseDays:        policy.StaleCloseDays,
+						StaleLabel:            policy.StaleLabel,
+						StaleExemptLabels:     strings.Join(policy.ExemptLabels, ","),
+						StaleExemptMilestones: policy.ExemptMilestones,
+						StaleIncludePulls:     policy.IncludePulls,
+					}
+					if err := config.StalePolicy.Validate(); err != nil {
+						ctx.Error(http.StatusUnprocessableEntity, "Invalid stale policy", err)
+						return err
+					}
 				}
 			} else if unit, err := repo.GetUnit(unit_model.TypeIssues); err != nil {
 				// Unit type doesn't exist so we make a new config file with default values
@@ -883,6 +900,24 @@ func updateRepoUnits(ctx *context.APIContext, opts api.EditRepoOption) error {
 			if opts.DefaultMergeStyle != nil {
 				config.DefaultMergeStyle = repo_model.MergeStyle(*opts.DefaultMergeStyle)
 			}
+			if opts.ReviewReminderDays != nil {
+				config.ReviewReminderDays = *opts.ReviewReminderDays
+			}
+			if opts.ReviewEscalationDays != nil {
+				config.ReviewEscalationDays = *opts.ReviewEscalationDays
+			}
+			if opts.ApprovalExpiryDays != nil {
+				config.ApprovalExpiryDays = *opts.ApprovalExpiryDays
+			}
+			if opts.DefaultMergeMessageTemplate != nil {
+				config.DefaultMergeMessageTemplate = strings.TrimSpace(*opts.DefaultMergeMessageTemplate)
+			}
+			if opts.DefaultSquashMessageTemplate != nil {
+				config.DefaultSquashMessageTemplate = strings.TrimSpace(*opts.DefaultSquashMessageTemplate)
+			}
+			if opts.EnforceMergeMessageTemplate != nil {
+				config.EnforceMergeMessageTemplate = *opts.EnforceMergeMessageTemplate
+			}
 
 			units = append(units, repo_model.RepoUnit{
 				RepoID: repo.ID,
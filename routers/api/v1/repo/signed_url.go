@@ -0,0 +1,201 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	auth_service "code.gitea.io/gitea/services/auth"
+	archiver_service "code.gitea.io/gitea/services/repository/archiver"
+)
+
+// defaultSignedURLExpiry is the validity of a signed URL when it isn't given
+const defaultSignedURLExpiry = time.Hour
+
+// resolveSignedRef returns the kind of the reference and the commit it points to
+func resolveSignedRef(ctx *context.APIContext, ref string) (string, *git.Commit, error) {
+	if ctx.Repo.GitRepo.IsBranchExist(ref) {
+		commit, err := ctx.Repo.GitRepo.GetBranchCommit(ref)
+		return "branch", commit, err
+	}
+	if ctx.Repo.GitRepo.IsTagExist(ref) {
+		commit, err := ctx.Repo.GitRepo.GetTagCommit(ref)
+		return "tag", commit, err
+	}
+	commit, err := ctx.Repo.GitRepo.GetCommit(ref)
+	return "commit", commit, err
+}
+
+// signedDownloadPath returns the path of the download described by the options, relative to the
+// application sub URL, it writes a response on error
+func signedDownloadPath(ctx *context.APIContext, form *api.CreateSignedURLOption) string {
+	repo := ctx.Repo.Repository
+	repoPath := "/" + url.PathEscape(repo.OwnerName) + "/" + url.PathEscape(repo.Name)
+
+	ref := form.Ref
+	if ref == "" {
+		ref = repo.DefaultBranch
+	}
+
+	switch form.Type {
+	case "raw":
+		if !ctx.Repo.CanRead(unit.TypeCode) {
+			ctx.NotFound()
+			return ""
+		}
+		if form.Path == "" {
+			ctx.Error(http.StatusUnprocessableEntity, "", "path is required for a raw file")
+			return ""
+		}
+		kind, commit, err := resolveSignedRef(ctx, ref)
+		if err != nil {
+			if git.IsErrNotExist(err) {
+				ctx.NotFound("GetCommit", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetCommit", err)
+			}
+			return ""
+		}
+		entry, err := commit.GetTreeEntryByPath(form.Path)
+		if err != nil {
+			if git.IsErrNotExist(err) {
+				ctx.NotFound("GetTreeEntryByPath", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetTreeEntryByPath", err)
+			}
+			return ""
+		} else if entry.IsDir() || entry.IsSubModule() {
+			ctx.Error(http.StatusUnprocessableEntity, "", "path is not a file")
+			return ""
+		}
+		if kind == "commit" {
+			ref = commit.ID.String()
+		}
+		return repoPath + "/raw/" + kind + "/" + util.PathEscapeSegments(ref) + "/" + util.PathEscapeSegments(strings.TrimPrefix(form.Path, "/"))
+
+	case "archive":
+		if !ctx.Repo.CanRead(unit.TypeCode) {
+			ctx.NotFound()
+			return ""
+		}
+		if setting.Repository.DisableDownloadSourceArchives {
+			ctx.Error(http.StatusUnprocessableEntity, "", "source archives are disabled")
+			return ""
+		}
+		format := form.Format
+		if format == "" {
+			format = "zip"
+		}
+		if _, err := archiver_service.NewRequest(repo.ID, ctx.Repo.GitRepo, ref+"."+format); err != nil {
+			if errors.Is(err, archiver_service.RepoRefNotFoundError{}) {
+				ctx.NotFound("NewRequest", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "NewRequest", err)
+			}
+			return ""
+		}
+		return repoPath + "/archive/" + util.PathEscapeSegments(ref) + "." + format
+
+	case "release_asset":
+		if !ctx.Repo.CanRead(unit.TypeReleases) {
+			ctx.NotFound()
+			return ""
+		}
+		attach, err := repo_model.GetAttachmentByID(ctx, form.AssetID)
+		if err != nil {
+			if repo_model.IsErrAttachmentNotExist(err) {
+				ctx.NotFound()
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetAttachmentByID", err)
+			}
+			return ""
+		}
+		if attach.RepoID != repo.ID || attach.ReleaseID == 0 {
+			ctx.NotFound()
+			return ""
+		}
+		// the release download links redirect to the attachment, which would drop the signature
+		return "/attachments/" + url.PathEscape(attach.UUID)
+	}
+
+	ctx.Error(http.StatusUnprocessableEntity, "", "unknown type")
+	return ""
+}
+
+// CreateSignedURL mint a signed URL for a download
+func CreateSignedURL(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/signed_urls repository repoCreateSignedURL
+	// ---
+	// summary: Sign a time-limited URL to download a raw file, an archive or a release asset without authentication
+	// description: The download is made with the permissions of the signer, the URL stops working once expired
+	//              or when the signer changes their password.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSignedURLOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SignedURL"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateSignedURLOption)
+	if ctx.Repo.Repository.IsEmpty {
+		ctx.NotFound()
+		return
+	}
+
+	path := signedDownloadPath(ctx, form)
+	if ctx.Written() {
+		return
+	}
+
+	expiry := defaultSignedURLExpiry
+	if form.ExpiresIn > 0 {
+		expiry = time.Duration(form.ExpiresIn) * time.Second
+	}
+	expires := time.Now().Add(expiry).Truncate(time.Second)
+
+	// sign the canonical escaping of the path, which is the one the signed URL auth method sees
+	u, err := url.Parse(path)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "Parse", err)
+		return
+	}
+	escapedPath := (&url.URL{Path: u.Path}).EscapedPath()
+	ctx.JSON(http.StatusCreated, &api.SignedURL{
+		URL:       strings.TrimSuffix(setting.AppURL, "/") + escapedPath + "?" + auth_service.SignURL(escapedPath, ctx.Doer, expires),
+		ExpiresAt: expires,
+	})
+}
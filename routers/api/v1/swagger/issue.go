@@ -36,6 +36,13 @@ type swaggerResponseCommentList struct {
 	Body []api.Comment `json:"body"`
 }
 
+// ContentHistoryList
+// swagger:response ContentHistoryList
+type swaggerResponseContentHistoryList struct {
+	// in:body
+	Body []api.ContentHistory `json:"body"`
+}
+
 // TimelineList
 // swagger:response TimelineList
 type swaggerResponseTimelineList struct {
@@ -57,6 +64,55 @@ type swaggerResponseLabelList struct {
 	Body []api.Label `json:"body"`
 }
 
+// IssueField
+// swagger:response IssueField
+type swaggerResponseIssueField struct {
+	// in:body
+	Body api.IssueField `json:"body"`
+}
+
+// IssueFieldList
+// swagger:response IssueFieldList
+type swaggerResponseIssueFieldList struct {
+	// in:body
+	Body []api.IssueField `json:"body"`
+}
+
+// IssueFilter
+// swagger:response IssueFilter
+type swaggerResponseIssueFilter struct {
+	// in:body
+	Body api.IssueFilter `json:"body"`
+}
+
+// IssueFilterList
+// swagger:response IssueFilterList
+type swaggerResponseIssueFilterList struct {
+	// in:body
+	Body []api.IssueFilter `json:"body"`
+}
+
+// IssueFieldValueList
+// swagger:response IssueFieldValueList
+type swaggerResponseIssueFieldValueList struct {
+	// in:body
+	Body []api.IssueFieldValue `json:"body"`
+}
+
+// SubIssueProgress
+// swagger:response SubIssueProgress
+type swaggerResponseSubIssueProgress struct {
+	// in:body
+	Body api.SubIssueProgress `json:"body"`
+}
+
+// LabelSyncResultList
+// swagger:response LabelSyncResultList
+type swaggerResponseLabelSyncResultList struct {
+	// in:body
+	Body []api.LabelSyncResult `json:"body"`
+}
+
 // Milestone
 // swagger:response Milestone
 type swaggerResponseMilestone struct {
@@ -92,6 +148,13 @@ type swaggerIssueDeadline struct {
 	Body api.IssueDeadline `json:"body"`
 }
 
+// PinnedIssueList
+// swagger:response PinnedIssueList
+type swaggerPinnedIssueList struct {
+	// in:body
+	Body []api.PinnedIssue `json:"body"`
+}
+
 // IssueTemplates
 // swagger:response IssueTemplates
 type swaggerIssueTemplates struct {
@@ -126,3 +189,45 @@ type swaggerReactionList struct {
 	// in:body
 	Body []api.Reaction `json:"body"`
 }
+
+// ModerationReport
+// swagger:response ModerationReport
+type swaggerModerationReport struct {
+	// in:body
+	Body api.ModerationReport `json:"body"`
+}
+
+// ModerationReportList
+// swagger:response ModerationReportList
+type swaggerModerationReportList struct {
+	// in:body
+	Body []api.ModerationReport `json:"body"`
+}
+
+// ModerationLog
+// swagger:response ModerationLog
+type swaggerModerationLog struct {
+	// in:body
+	Body []api.ModerationLogEntry `json:"body"`
+}
+
+// Component
+// swagger:response Component
+type swaggerResponseComponent struct {
+	// in:body
+	Body api.Component `json:"body"`
+}
+
+// ComponentList
+// swagger:response ComponentList
+type swaggerResponseComponentList struct {
+	// in:body
+	Body []api.Component `json:"body"`
+}
+
+// IssueMirror
+// swagger:response IssueMirror
+type swaggerResponseIssueMirror struct {
+	// in:body
+	Body api.IssueMirror `json:"body"`
+}
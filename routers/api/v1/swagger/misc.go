@@ -15,9 +15,37 @@ type swaggerResponseServerVersion struct {
 	Body api.ServerVersion `json:"body"`
 }
 
+// InstanceMeta
+// swagger:response InstanceMeta
+type swaggerResponseInstanceMeta struct {
+	// in:body
+	Body api.InstanceMeta `json:"body"`
+}
+
 // StringSlice
 // swagger:response StringSlice
 type swaggerResponseStringSlice struct {
 	// in:body
 	Body []string `json:"body"`
 }
+
+// GitignoreTemplateInfo
+// swagger:response GitignoreTemplateInfo
+type swaggerResponseGitignoreTemplateInfo struct {
+	// in:body
+	Body api.GitignoreTemplateInfo `json:"body"`
+}
+
+// LicenseTemplateInfo
+// swagger:response LicenseTemplateInfo
+type swaggerResponseLicenseTemplateInfo struct {
+	// in:body
+	Body api.LicenseTemplateInfo `json:"body"`
+}
+
+// HighlightResult
+// swagger:response HighlightResult
+type swaggerResponseHighlightResult struct {
+	// in:body
+	Body api.HighlightResult `json:"body"`
+}
@@ -37,6 +37,10 @@ type swaggerParameterBodies struct {
 	EditIssueOption api.EditIssueOption
 	// in:body
 	EditDeadlineOption api.EditDeadlineOption
+	// in:body
+	PinIssueOption api.PinIssueOption
+	// in:body
+	EditOrgPinnedIssuesOption api.EditOrgPinnedIssuesOption
 
 	// in:body
 	CreateIssueCommentOption api.CreateIssueCommentOption
@@ -53,10 +57,17 @@ type swaggerParameterBodies struct {
 	CreateLabelOption api.CreateLabelOption
 	// in:body
 	EditLabelOption api.EditLabelOption
+	// in:body
+	SyncLabelsOption api.SyncLabelsOption
+	// in:body
+	SyncLabelTemplateOption api.SyncLabelTemplateOption
 
 	// in:body
 	MarkdownOption api.MarkdownOption
 
+	// in:body
+	HighlightOption api.HighlightOption
+
 	// in:body
 	CreateMilestoneOption api.CreateMilestoneOption
 	// in:body
@@ -78,6 +89,14 @@ type swaggerParameterBodies struct {
 	CreateReleaseOption api.CreateReleaseOption
 	// in:body
 	EditReleaseOption api.EditReleaseOption
+	// in:body
+	PublishReleaseDraftOption api.PublishReleaseDraftOption
+	// in:body
+	CreateReleaseBuildOption api.CreateReleaseBuildOption
+	// in:body
+	EditReleaseBuildOption api.EditReleaseBuildOption
+	// in:body
+	AppendReleaseBuildLogOption api.AppendReleaseBuildLogOption
 
 	// in:body
 	CreateRepoOption api.CreateRepoOption
@@ -97,6 +116,14 @@ type swaggerParameterBodies struct {
 	CreateTeamOption api.CreateTeamOption
 	// in:body
 	EditTeamOption api.EditTeamOption
+	// in:body
+	CreateRoleOption api.CreateRoleOption
+	// in:body
+	EditRoleOption api.EditRoleOption
+	// in:body
+	CreateOrgDomainOption api.CreateOrgDomainOption
+	// in:body
+	EditOrgDomainOption api.EditOrgDomainOption
 
 	// in:body
 	AddTimeOption api.AddTimeOption
@@ -107,6 +134,9 @@ type swaggerParameterBodies struct {
 	// in:body
 	EditUserOption api.EditUserOption
 
+	// in:body
+	MergeUserOption api.MergeUserOption
+
 	// in:body
 	EditAttachmentOptions api.EditAttachmentOptions
 
@@ -119,9 +149,33 @@ type swaggerParameterBodies struct {
 	// in:body
 	DeleteFileOptions api.DeleteFileOptions
 
+	// in:body
+	CherryPickCommitOption api.CherryPickCommitOption
+
 	// in:body
 	CommitDateOptions api.CommitDateOptions
 
+	// in:body
+	CreateIssueFieldOption api.CreateIssueFieldOption
+
+	// in:body
+	EditIssueFieldOption api.EditIssueFieldOption
+
+	// in:body
+	SetIssueFieldValueOption api.SetIssueFieldValueOption
+
+	// in:body
+	CreateIssueFilterOption api.CreateIssueFilterOption
+
+	// in:body
+	EditIssueFilterOption api.EditIssueFilterOption
+
+	// in:body
+	AddSubIssueOption api.AddSubIssueOption
+
+	// in:body
+	VerifyCommitSignatureOption api.VerifyCommitSignatureOption
+
 	// in:body
 	RepoTopicOptions api.RepoTopicOptions
 
@@ -167,9 +221,76 @@ type swaggerParameterBodies struct {
 	// in:body
 	UserSettingsOptions api.UserSettingsOptions
 
+	// in:body
+	EditUserProfileOption api.EditUserProfileOption
+
+	// in:body
+	SetUserStatusOption api.SetUserStatusOption
+
+	// in:body
+	CreateBadgeOption api.CreateBadgeOption
+
+	// in:body
+	SetInteractionLimitOption api.SetInteractionLimitOption
+
+	// in:body
+	EditBadgeOption api.EditBadgeOption
+
 	// in:body
 	CreateWikiPageOptions api.CreateWikiPageOptions
 
 	// in:body
 	CreatePushMirrorOption api.CreatePushMirrorOption
+
+	// in:body
+	UploadSarifOption api.UploadSarifOption
+	// in:body
+	EditCodeScanningAlertOption api.EditCodeScanningAlertOption
+
+	// in:body
+	UploadCoverageOption api.UploadCoverageOption
+
+	// in:body
+	CreateDeploymentOption api.CreateDeploymentOption
+	// in:body
+	CreateDeploymentStatusOption api.CreateDeploymentStatusOption
+
+	// in:body
+	CreateSignedURLOption api.CreateSignedURLOption
+
+	// in:body
+	SyncForkOption api.SyncForkOption
+
+	// in:body
+	CreateModerationReportOption api.CreateModerationReportOption
+
+	// in:body
+	ModerationActionOption api.ModerationActionOption
+
+	// in:body
+	RenameDefaultBranchOption api.RenameDefaultBranchOption
+
+	// in:body
+	RenameOrgDefaultBranchesOption api.RenameOrgDefaultBranchesOption
+
+	// in:body
+	CreateComponentOption api.CreateComponentOption
+
+	// in:body
+	EditComponentOption api.EditComponentOption
+
+	// in:body
+	EditIssuesOption api.EditIssuesOption
+
+	// in:body
+	CreateAttestationOption api.CreateAttestationOption
+
+	// in:body
+	CreatePackageAttestationOption api.CreatePackageAttestationOption
+
+	// in:body
+	ImportIssuesOption api.ImportIssuesOption
+
+	// in:body
+	SetIssueMirrorOption api.SetIssueMirrorOption
 }
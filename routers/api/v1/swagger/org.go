@@ -36,9 +36,58 @@ type swaggerResponseTeamList struct {
 	Body []api.Team `json:"body"`
 }
 
+// TeamReviewerWorkloadList
+// swagger:response TeamReviewerWorkloadList
+type swaggerResponseTeamReviewerWorkloadList struct {
+	// in:body
+	Body []api.TeamReviewerWorkload `json:"body"`
+}
+
 // OrganizationPermissions
 // swagger:response OrganizationPermissions
 type swaggerResponseOrganizationPermissions struct {
 	// in:body
 	Body api.OrganizationPermissions `json:"body"`
 }
+
+// Role
+// swagger:response Role
+type swaggerResponseRole struct {
+	// in:body
+	Body api.Role `json:"body"`
+}
+
+// RoleList
+// swagger:response RoleList
+type swaggerResponseRoleList struct {
+	// in:body
+	Body []api.Role `json:"body"`
+}
+
+// OrgDomain
+// swagger:response OrgDomain
+type swaggerResponseOrgDomain struct {
+	// in:body
+	Body api.OrgDomain `json:"body"`
+}
+
+// OrgDomainList
+// swagger:response OrgDomainList
+type swaggerResponseOrgDomainList struct {
+	// in:body
+	Body []api.OrgDomain `json:"body"`
+}
+
+// OrgInsightList
+// swagger:response OrgInsightList
+type swaggerResponseOrgInsightList struct {
+	// in:body
+	Body []api.OrgInsight `json:"body"`
+}
+
+// OrgInsightSummary
+// swagger:response OrgInsightSummary
+type swaggerResponseOrgInsightSummary struct {
+	// in:body
+	Body api.OrgInsightSummary `json:"body"`
+}
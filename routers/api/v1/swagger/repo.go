@@ -36,6 +36,20 @@ type swaggerResponseBranchList struct {
 	Body []api.Branch `json:"body"`
 }
 
+// DefaultBranchRename
+// swagger:response DefaultBranchRename
+type swaggerResponseDefaultBranchRename struct {
+	// in:body
+	Body api.DefaultBranchRename `json:"body"`
+}
+
+// OrgDefaultBranchRename
+// swagger:response OrgDefaultBranchRename
+type swaggerResponseOrgDefaultBranchRename struct {
+	// in:body
+	Body api.OrgDefaultBranchRename `json:"body"`
+}
+
 // BranchProtection
 // swagger:response BranchProtection
 type swaggerResponseBranchProtection struct {
@@ -127,6 +141,20 @@ type swaggerResponseReleaseList struct {
 	Body []api.Release `json:"body"`
 }
 
+// ReleaseBuild
+// swagger:response ReleaseBuild
+type swaggerResponseReleaseBuild struct {
+	// in:body
+	Body api.ReleaseBuild `json:"body"`
+}
+
+// ReleaseBuildList
+// swagger:response ReleaseBuildList
+type swaggerResponseReleaseBuildList struct {
+	// in:body
+	Body []api.ReleaseBuild `json:"body"`
+}
+
 // PullRequest
 // swagger:response PullRequest
 type swaggerResponsePullRequest struct {
@@ -134,6 +162,13 @@ type swaggerResponsePullRequest struct {
 	Body api.PullRequest `json:"body"`
 }
 
+// PullRequestMergePreview
+// swagger:response PullRequestMergePreview
+type swaggerResponsePullRequestMergePreview struct {
+	// in:body
+	Body api.PullRequestMergePreview `json:"body"`
+}
+
 // PullRequestList
 // swagger:response PullRequestList
 type swaggerResponsePullRequestList struct {
@@ -183,6 +218,69 @@ type swaggerResponseCommitStatusList struct {
 	Body []api.CommitStatus `json:"body"`
 }
 
+// CodeScanningAnalysisList
+// swagger:response CodeScanningAnalysisList
+type swaggerResponseCodeScanningAnalysisList struct {
+	// in:body
+	Body []api.CodeScanningAnalysis `json:"body"`
+}
+
+// CodeScanningAlert
+// swagger:response CodeScanningAlert
+type swaggerResponseCodeScanningAlert struct {
+	// in:body
+	Body api.CodeScanningAlert `json:"body"`
+}
+
+// CodeScanningAlertList
+// swagger:response CodeScanningAlertList
+type swaggerResponseCodeScanningAlertList struct {
+	// in:body
+	Body []api.CodeScanningAlert `json:"body"`
+}
+
+// CommitCoverage
+// swagger:response CommitCoverage
+type swaggerResponseCommitCoverage struct {
+	// in:body
+	Body api.CommitCoverage `json:"body"`
+}
+
+// PullRequestCoverage
+// swagger:response PullRequestCoverage
+type swaggerResponsePullRequestCoverage struct {
+	// in:body
+	Body api.PullRequestCoverage `json:"body"`
+}
+
+// Deployment
+// swagger:response Deployment
+type swaggerResponseDeployment struct {
+	// in:body
+	Body api.Deployment `json:"body"`
+}
+
+// DeploymentList
+// swagger:response DeploymentList
+type swaggerResponseDeploymentList struct {
+	// in:body
+	Body []api.Deployment `json:"body"`
+}
+
+// DeploymentStatus
+// swagger:response DeploymentStatus
+type swaggerResponseDeploymentStatus struct {
+	// in:body
+	Body api.DeploymentStatus `json:"body"`
+}
+
+// DeploymentStatusList
+// swagger:response DeploymentStatusList
+type swaggerResponseDeploymentStatusList struct {
+	// in:body
+	Body []api.DeploymentStatus `json:"body"`
+}
+
 // WatchInfo
 // swagger:response WatchInfo
 type swaggerResponseWatchInfo struct {
@@ -232,6 +330,13 @@ type swaggerCommit struct {
 	Body api.Commit `json:"body"`
 }
 
+// CommitSignatureVerification
+// swagger:response CommitSignatureVerification
+type swaggerCommitSignatureVerification struct {
+	// in: body
+	Body api.CommitSignatureVerification `json:"body"`
+}
+
 // CommitList
 // swagger:response CommitList
 type swaggerCommitList struct {
@@ -275,6 +380,27 @@ type swaggerFileResponse struct {
 	Body api.FileResponse `json:"body"`
 }
 
+// CherryPickResponse
+// swagger:response CherryPickResponse
+type swaggerCherryPickResponse struct {
+	// in: body
+	Body api.CherryPickResponse `json:"body"`
+}
+
+// LastCommitList
+// swagger:response LastCommitList
+type swaggerLastCommitList struct {
+	// in: body
+	Body []api.LastCommit `json:"body"`
+}
+
+// GrepResponse
+// swagger:response GrepResponse
+type swaggerGrepResponse struct {
+	// in: body
+	Body api.GrepResponse `json:"body"`
+}
+
 // ContentsResponse
 // swagger:response ContentsResponse
 type swaggerContentsResponse struct {
@@ -317,6 +443,76 @@ type swaggerLanguageStatistics struct {
 	Body map[string]int64 `json:"body"`
 }
 
+// LanguageStatsSnapshotList
+// swagger:response LanguageStatsSnapshotList
+type swaggerLanguageStatsSnapshotList struct {
+	// in: body
+	Body []api.LanguageStatsSnapshot `json:"body"`
+}
+
+// LanguageTrendList
+// swagger:response LanguageTrendList
+type swaggerLanguageTrendList struct {
+	// in: body
+	Body []api.LanguageTrend `json:"body"`
+}
+
+// ForkNetworkRepositoryList
+// swagger:response ForkNetworkRepositoryList
+type swaggerForkNetworkRepositoryList struct {
+	// in: body
+	Body []api.ForkNetworkRepository `json:"body"`
+}
+
+// ForkContainingCommitList
+// swagger:response ForkContainingCommitList
+type swaggerForkContainingCommitList struct {
+	// in: body
+	Body []api.ForkContainingCommit `json:"body"`
+}
+
+// ForkSyncResult
+// swagger:response ForkSyncResult
+type swaggerForkSyncResult struct {
+	// in: body
+	Body api.ForkSyncResult `json:"body"`
+}
+
+// RepoAttachmentsUsageList
+// swagger:response RepoAttachmentsUsageList
+type swaggerRepoAttachmentsUsageList struct {
+	// in: body
+	Body []api.RepoAttachmentsUsage `json:"body"`
+}
+
+// RepoDownloadUsageList
+// swagger:response RepoDownloadUsageList
+type swaggerRepoDownloadUsageList struct {
+	// in: body
+	Body []api.RepoDownloadUsage `json:"body"`
+}
+
+// OwnerDownloadUsageList
+// swagger:response OwnerDownloadUsageList
+type swaggerOwnerDownloadUsageList struct {
+	// in: body
+	Body []api.OwnerDownloadUsage `json:"body"`
+}
+
+// ReplicationStatus
+// swagger:response ReplicationStatus
+type swaggerReplicationStatus struct {
+	// in: body
+	Body api.ReplicationStatus `json:"body"`
+}
+
+// RepoReplicationList
+// swagger:response RepoReplicationList
+type swaggerRepoReplicationList struct {
+	// in: body
+	Body []api.RepoReplication `json:"body"`
+}
+
 // CombinedStatus
 // swagger:response CombinedStatus
 type swaggerCombinedStatus struct {
@@ -365,3 +561,80 @@ type swaggerRepoCollaboratorPermission struct {
 	// in:body
 	Body api.RepoCollaboratorPermission `json:"body"`
 }
+
+// InteractionLimit
+// swagger:response InteractionLimit
+type swaggerResponseInteractionLimit struct {
+	// in:body
+	Body api.InteractionLimit `json:"body"`
+}
+
+// SignedURL
+// swagger:response SignedURL
+type swaggerResponseSignedURL struct {
+	// in:body
+	Body api.SignedURL `json:"body"`
+}
+
+// RepoExpertise
+// swagger:response RepoExpertise
+type swaggerResponseRepoExpertise struct {
+	// in:body
+	Body api.RepoExpertise `json:"body"`
+}
+
+// ExpertList
+// swagger:response ExpertList
+type swaggerResponseExpertList struct {
+	// in:body
+	Body []api.Expert `json:"body"`
+}
+
+// DirectoryBusFactorList
+// swagger:response DirectoryBusFactorList
+type swaggerResponseDirectoryBusFactorList struct {
+	// in:body
+	Body []api.DirectoryBusFactor `json:"body"`
+}
+
+// MaintenanceReport
+// swagger:response MaintenanceReport
+type swaggerResponseMaintenanceReport struct {
+	// in:body
+	Body api.MaintenanceReport `json:"body"`
+}
+
+// MaintenanceReportList
+// swagger:response MaintenanceReportList
+type swaggerResponseMaintenanceReportList struct {
+	// in:body
+	Body []api.MaintenanceReport `json:"body"`
+}
+
+// Attestation
+// swagger:response Attestation
+type swaggerResponseAttestation struct {
+	// in:body
+	Body api.Attestation `json:"body"`
+}
+
+// AttestationList
+// swagger:response AttestationList
+type swaggerResponseAttestationList struct {
+	// in:body
+	Body []api.Attestation `json:"body"`
+}
+
+// ExploreCollection
+// swagger:response ExploreCollection
+type swaggerResponseExploreCollection struct {
+	// in:body
+	Body api.ExploreCollection `json:"body"`
+}
+
+// ExploreCollectionList
+// swagger:response ExploreCollectionList
+type swaggerResponseExploreCollectionList struct {
+	// in:body
+	Body []api.ExploreCollection `json:"body"`
+}
@@ -49,3 +49,31 @@ type swaggerResponseUserSettings struct {
 	// in:body
 	Body []api.UserSettings `json:"body"`
 }
+
+// UserStatus
+// swagger:response UserStatus
+type swaggerResponseUserStatus struct {
+	// in:body
+	Body api.UserStatus `json:"body"`
+}
+
+// Badge
+// swagger:response Badge
+type swaggerResponseBadge struct {
+	// in:body
+	Body api.Badge `json:"body"`
+}
+
+// BadgeList
+// swagger:response BadgeList
+type swaggerResponseBadgeList struct {
+	// in:body
+	Body []api.Badge `json:"body"`
+}
+
+// UserProfile
+// swagger:response UserProfile
+type swaggerResponseUserProfile struct {
+	// in:body
+	Body api.UserProfile `json:"body"`
+}
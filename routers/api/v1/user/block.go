@@ -0,0 +1,270 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+func listBlockedUsers(ctx *context.APIContext, blocker *user_model.User) {
+	users, count, err := user_model.GetBlockedUsers(ctx, blocker.ID, utils.GetListOptions(ctx))
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetBlockedUsers", err)
+		return
+	}
+
+	ctx.SetTotalCountHeader(count)
+	responseAPIUsers(ctx, users)
+}
+
+func checkBlockedUser(ctx *context.APIContext, blocker *user_model.User) {
+	blockee := GetUserByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	blocked, err := user_model.IsBlocked(ctx, blocker.ID, blockee.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsBlocked", err)
+	} else if blocked {
+		ctx.Status(http.StatusNoContent)
+	} else {
+		ctx.NotFound()
+	}
+}
+
+func blockUser(ctx *context.APIContext, blocker *user_model.User) {
+	blockee := GetUserByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if blockee.IsOrganization() {
+		ctx.Error(http.StatusUnprocessableEntity, "BlockUser", "organizations cannot be blocked")
+		return
+	}
+	if err := user_model.BlockUser(ctx, blocker.ID, blockee.ID); err != nil {
+		if errors.Is(err, user_model.ErrCannotBlockSelf) {
+			ctx.Error(http.StatusUnprocessableEntity, "BlockUser", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "BlockUser", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func unblockUser(ctx *context.APIContext, blocker *user_model.User) {
+	blockee := GetUserByParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := user_model.UnblockUser(ctx, blocker.ID, blockee.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "UnblockUser", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListMyBlockedUsers lists the users blocked by the authenticated user
+func ListMyBlockedUsers(ctx *context.APIContext) {
+	// swagger:operation GET /user/blocks user userListBlockedUsers
+	// ---
+	// summary: List the users blocked by the authenticated user
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserList"
+
+	listBlockedUsers(ctx, ctx.Doer)
+}
+
+// CheckMyBlockedUser checks whether the authenticated user has blocked a user
+func CheckMyBlockedUser(ctx *context.APIContext) {
+	// swagger:operation GET /user/blocks/{username} user userCheckBlockedUser
+	// ---
+	// summary: Check whether the authenticated user has blocked a user
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	checkBlockedUser(ctx, ctx.Doer)
+}
+
+// BlockUserForMe blocks a user for the authenticated user
+func BlockUserForMe(ctx *context.APIContext) {
+	// swagger:operation PUT /user/blocks/{username} user userBlockUser
+	// ---
+	// summary: Block a user, they cannot interact with the repositories of the authenticated user anymore
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user to block
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	blockUser(ctx, ctx.Doer)
+}
+
+// UnblockUserForMe unblocks a user for the authenticated user
+func UnblockUserForMe(ctx *context.APIContext) {
+	// swagger:operation DELETE /user/blocks/{username} user userUnblockUser
+	// ---
+	// summary: Unblock a user
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user to unblock
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	unblockUser(ctx, ctx.Doer)
+}
+
+// ListOrgBlockedUsers lists the users blocked by an organization
+func ListOrgBlockedUsers(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/blocks organization orgListBlockedUsers
+	// ---
+	// summary: List the users blocked by an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	listBlockedUsers(ctx, ctx.Org.Organization.AsUser())
+}
+
+// CheckOrgBlockedUser checks whether an organization has blocked a user
+func CheckOrgBlockedUser(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/blocks/{username} organization orgCheckBlockedUser
+	// ---
+	// summary: Check whether an organization has blocked a user
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: username
+	//   in: path
+	//   description: username of the user
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	checkBlockedUser(ctx, ctx.Org.Organization.AsUser())
+}
+
+// BlockUserForOrg blocks a user for an organization
+func BlockUserForOrg(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/blocks/{username} organization orgBlockUser
+	// ---
+	// summary: Block a user, they cannot interact with the repositories of the organization anymore
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: username
+	//   in: path
+	//   description: username of the user to block
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	blockUser(ctx, ctx.Org.Organization.AsUser())
+}
+
+// UnblockUserForOrg unblocks a user for an organization
+func UnblockUserForOrg(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/blocks/{username} organization orgUnblockUser
+	// ---
+	// summary: Unblock a user for an organization
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: username
+	//   in: path
+	//   description: username of the user to unblock
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	unblockUser(ctx, ctx.Org.Organization.AsUser())
+}
@@ -0,0 +1,153 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+func getInteractionLimit(ctx *context.APIContext, owner *user_model.User) {
+	limit, err := repo_model.GetInteractionLimit(ctx, owner.ID, 0)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetInteractionLimit", err)
+		return
+	} else if limit == nil {
+		ctx.NotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToInteractionLimit(limit, owner))
+}
+
+// GetMyInteractionLimit returns the interaction limit of the repositories of the authenticated user
+func GetMyInteractionLimit(ctx *context.APIContext) {
+	// swagger:operation GET /user/interaction-limits user userGetInteractionLimit
+	// ---
+	// summary: Get the interaction limit of the repositories of the authenticated user
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/InteractionLimit"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	getInteractionLimit(ctx, ctx.Doer)
+}
+
+// SetMyInteractionLimit limits the interactions in the repositories of the authenticated user
+func SetMyInteractionLimit(ctx *context.APIContext) {
+	// swagger:operation PUT /user/interaction-limits user userSetInteractionLimit
+	// ---
+	// summary: Temporarily limit the interactions in the repositories of the authenticated user
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetInteractionLimitOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/InteractionLimit"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	utils.SetInteractionLimit(ctx, ctx.Doer.ID, 0, ctx.Doer)
+}
+
+// RemoveMyInteractionLimit removes the interaction limit of the repositories of the authenticated user
+func RemoveMyInteractionLimit(ctx *context.APIContext) {
+	// swagger:operation DELETE /user/interaction-limits user userRemoveInteractionLimit
+	// ---
+	// summary: Remove the interaction limit of the repositories of the authenticated user
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+
+	utils.RemoveInteractionLimit(ctx, ctx.Doer.ID, 0)
+}
+
+// GetOrgInteractionLimit returns the interaction limit of the repositories of an organization
+func GetOrgInteractionLimit(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/interaction-limits organization orgGetInteractionLimit
+	// ---
+	// summary: Get the interaction limit of the repositories of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/InteractionLimit"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	getInteractionLimit(ctx, ctx.Org.Organization.AsUser())
+}
+
+// SetOrgInteractionLimit limits the interactions in the repositories of an organization
+func SetOrgInteractionLimit(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/interaction-limits organization orgSetInteractionLimit
+	// ---
+	// summary: Temporarily limit the interactions in the repositories of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetInteractionLimitOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/InteractionLimit"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	owner := ctx.Org.Organization.AsUser()
+	utils.SetInteractionLimit(ctx, owner.ID, 0, owner)
+}
+
+// RemoveOrgInteractionLimit removes the interaction limit of the repositories of an organization
+func RemoveOrgInteractionLimit(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/interaction-limits organization orgRemoveInteractionLimit
+	// ---
+	// summary: Remove the interaction limit of the repositories of an organization
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	utils.RemoveInteractionLimit(ctx, ctx.Org.Organization.ID, 0)
+}
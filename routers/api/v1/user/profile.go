@@ -0,0 +1,153 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	user_service "code.gitea.io/gitea/services/user"
+)
+
+func writeProfile(ctx *context.APIContext, owner *user_model.User) {
+	repos, err := user_service.GetVisiblePinnedRepositories(ctx, owner, ctx.Doer)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetVisiblePinnedRepositories", err)
+		return
+	}
+
+	apiRepos := make([]*api.Repository, len(repos))
+	for i, repo := range repos {
+		access, err := access_model.AccessLevel(ctx.Doer, repo)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "AccessLevel", err)
+			return
+		}
+		apiRepos[i] = convert.ToRepo(repo, access)
+	}
+
+	ctx.JSON(http.StatusOK, &api.UserProfile{
+		PinnedRepositories: apiRepos,
+		Sections: &api.ProfileSections{
+			Activity: !owner.HideProfileActivity,
+			Packages: !owner.HideProfilePackages,
+			Projects: !owner.HideProfileProjects,
+		},
+	})
+}
+
+func editProfile(ctx *context.APIContext, owner *user_model.User) {
+	form := web.GetForm(ctx).(*api.EditUserProfileOption)
+
+	if form.PinnedRepositories != nil {
+		if err := user_service.PinRepositories(ctx, owner, *form.PinnedRepositories); err != nil {
+			if repo_model.IsErrRepoNotExist(err) || errors.Is(err, repo_model.ErrTooManyPinnedRepositories) {
+				ctx.Error(http.StatusUnprocessableEntity, "PinRepositories", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "PinRepositories", err)
+			}
+			return
+		}
+	}
+
+	if form.Sections != nil {
+		owner.HideProfileActivity = !form.Sections.Activity
+		owner.HideProfilePackages = !form.Sections.Packages
+		owner.HideProfileProjects = !form.Sections.Projects
+		if err := user_model.UpdateUserCols(ctx, owner, "hide_profile_activity", "hide_profile_packages", "hide_profile_projects"); err != nil {
+			ctx.Error(http.StatusInternalServerError, "UpdateUserCols", err)
+			return
+		}
+	}
+
+	writeProfile(ctx, owner)
+}
+
+// GetProfile returns the profile page configuration of a user or an organization
+func GetProfile(ctx *context.APIContext) {
+	// swagger:operation GET /users/{username}/profile user userGetProfile
+	// ---
+	// summary: Get the pinned repositories and the profile sections of a user or an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user or organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserProfile"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !user_model.IsUserVisibleToViewer(ctx, ctx.ContextUser, ctx.Doer) {
+		// fake ErrUserNotExist error message to not leak information about existence
+		ctx.NotFound("GetUserByName", user_model.ErrUserNotExist{Name: ctx.Params(":username")})
+		return
+	}
+	writeProfile(ctx, ctx.ContextUser)
+}
+
+// EditMyProfile changes the profile page configuration of the authenticated user
+func EditMyProfile(ctx *context.APIContext) {
+	// swagger:operation PUT /user/profile user userEditProfile
+	// ---
+	// summary: Change the pinned repositories and the profile sections of the authenticated user
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditUserProfileOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserProfile"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	editProfile(ctx, ctx.Doer)
+}
+
+// EditOrgProfile changes the profile page configuration of an organization
+func EditOrgProfile(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/profile organization orgEditProfile
+	// ---
+	// summary: Change the pinned repositories and the profile sections of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditUserProfileOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserProfile"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	editProfile(ctx, ctx.Org.Organization.AsUser())
+}
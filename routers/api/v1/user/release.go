@@ -0,0 +1,66 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+)
+
+// ListMySubscribedReleases list the recent releases of the repositories watched or starred by the authenticated user
+func ListMySubscribedReleases(ctx *context.APIContext) {
+	// swagger:operation GET /user/subscriptions/releases user userCurrentListSubscribedReleases
+	// ---
+	// summary: List the recent releases of the repositories watched or starred by the authenticated user
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: tags
+	//   in: query
+	//   description: include the tags without release
+	//   type: boolean
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ReleaseList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if unit.TypeReleases.UnitGlobalDisabled() {
+		ctx.NotFound()
+		return
+	}
+
+	releases, count, err := repo_model.FindSubscribedReleases(ctx, repo_model.FindSubscribedReleasesOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		Doer:        ctx.Doer,
+		IncludeTags: ctx.FormBool("tags"),
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindSubscribedReleases", err)
+		return
+	}
+
+	rels := make([]*api.Release, len(releases))
+	for i, release := range releases {
+		rels[i] = convert.ToRelease(release)
+	}
+
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, rels)
+}
@@ -11,6 +11,7 @@ import (
 	"code.gitea.io/gitea/modules/context"
 	"code.gitea.io/gitea/modules/convert"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/web"
 )
 
@@ -74,6 +75,19 @@ func UpdateUserSettings(ctx *context.APIContext) {
 		ctx.Doer.KeepActivityPrivate = *form.HideActivity
 	}
 
+	if form.ReviewCapacity != nil {
+		ctx.Doer.ReviewCapacity = *form.ReviewCapacity
+	}
+	if form.OutOfOffice != nil {
+		ctx.Doer.OutOfOffice = *form.OutOfOffice
+	}
+	if form.OutOfOfficeUntil != nil {
+		ctx.Doer.OutOfOfficeUntil = 0
+		if !form.OutOfOfficeUntil.IsZero() {
+			ctx.Doer.OutOfOfficeUntil = timeutil.TimeStamp(form.OutOfOfficeUntil.Unix())
+		}
+	}
+
 	if err := user_model.UpdateUser(ctx, ctx.Doer, false); err != nil {
 		ctx.InternalServerError(err)
 		return
@@ -0,0 +1,135 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/web"
+)
+
+func writeStatus(ctx *context.APIContext, u *user_model.User) {
+	status, err := user_model.GetUserStatus(ctx, u.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetUserStatus", err)
+		return
+	} else if status == nil {
+		ctx.NotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToUserStatus(status))
+}
+
+// GetStatus returns the status of a user
+func GetStatus(ctx *context.APIContext) {
+	// swagger:operation GET /users/{username}/status user userGetStatus
+	// ---
+	// summary: Get the status of a user
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of user
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserStatus"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if !user_model.IsUserVisibleToViewer(ctx, ctx.ContextUser, ctx.Doer) {
+		// fake ErrUserNotExist error message to not leak information about existence
+		ctx.NotFound("GetUserByName", user_model.ErrUserNotExist{Name: ctx.Params(":username")})
+		return
+	}
+	writeStatus(ctx, ctx.ContextUser)
+}
+
+// GetMyStatus returns the status of the authenticated user
+func GetMyStatus(ctx *context.APIContext) {
+	// swagger:operation GET /user/status user userGetMyStatus
+	// ---
+	// summary: Get the status of the authenticated user
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserStatus"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	writeStatus(ctx, ctx.Doer)
+}
+
+// SetMyStatus sets the status of the authenticated user
+func SetMyStatus(ctx *context.APIContext) {
+	// swagger:operation PUT /user/status user userSetMyStatus
+	// ---
+	// summary: Set the status of the authenticated user
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetUserStatusOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/UserStatus"
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.SetUserStatusOption)
+	status := &user_model.Status{
+		UID:     ctx.Doer.ID,
+		Emoji:   form.Emoji,
+		Message: form.Message,
+		Busy:    form.Busy,
+	}
+	if form.ExpiresAt != nil && !form.ExpiresAt.IsZero() {
+		status.ExpiresUnix = timeutil.TimeStamp(form.ExpiresAt.Unix())
+	}
+	if err := user_model.SetUserStatus(ctx, status); err != nil {
+		if errors.Is(err, user_model.ErrInvalidStatusEmoji) || errors.Is(err, user_model.ErrStatusMessageTooLong) {
+			ctx.Error(http.StatusUnprocessableEntity, "SetUserStatus", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "SetUserStatus", err)
+		}
+		return
+	}
+	if status.IsEmpty() {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToUserStatus(status))
+}
+
+// DeleteMyStatus clears the status of the authenticated user
+func DeleteMyStatus(ctx *context.APIContext) {
+	// swagger:operation DELETE /user/status user userDeleteMyStatus
+	// ---
+	// summary: Clear the status of the authenticated user
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+
+	if err := user_model.DeleteUserStatus(ctx, ctx.Doer.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteUserStatus", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
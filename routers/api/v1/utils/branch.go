@@ -0,0 +1,26 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	api "code.gitea.io/gitea/modules/structs"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// ToDefaultBranchRename converts what renaming the default branch of a repository changed to its api format
+func ToDefaultBranchRename(result *repo_service.DefaultBranchRename) *api.DefaultBranchRename {
+	apiResult := &api.DefaultBranchRename{
+		Repository:              result.Repo.FullName(),
+		OldName:                 result.OldName,
+		NewName:                 result.NewName,
+		RetargetedPullRequests:  result.RetargetedPullRequests,
+		UpdatedBranchProtection: result.UpdatedBranchProtection,
+		UpdatedWebhooks:         result.UpdatedWebhooks,
+	}
+	if result.Err != nil {
+		apiResult.Error = result.Err.Error()
+	}
+	return apiResult
+}
@@ -33,6 +33,21 @@ func GetOrgHook(ctx *context.APIContext, orgID, hookID int64) (*webhook.Webhook,
 	return w, nil
 }
 
+// GetOrgHookTemplate get an organization's webhook template. If there is an error, write to
+// `ctx` accordingly and return the error
+func GetOrgHookTemplate(ctx *context.APIContext, orgID, templateID int64) (*webhook.Webhook, error) {
+	w, err := webhook.GetWebhookTemplateByOrgID(ctx, orgID, templateID)
+	if err != nil {
+		if webhook.IsErrWebhookNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetWebhookTemplateByOrgID", err)
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
 // GetRepoHook get a repo's webhook. If there is an error, write to `ctx`
 // accordingly and return the error
 func GetRepoHook(ctx *context.APIContext, repoID, hookID int64) (*webhook.Webhook, error) {
@@ -77,6 +92,22 @@ func AddOrgHook(ctx *context.APIContext, form *api.CreateHookOption) {
 	}
 }
 
+// AddOrgHookTemplate add a webhook template to an organization, its webhook is created in every
+// repository of the organization. Writes to `ctx` accordingly
+func AddOrgHookTemplate(ctx *context.APIContext, form *api.CreateHookOption) {
+	org := ctx.Org.Organization
+	tmpl, ok := newHook(ctx, form)
+	if !ok {
+		return
+	}
+	tmpl.OrgID = org.ID
+	if err := webhook.CreateWebhookTemplate(ctx, tmpl); err != nil {
+		ctx.Error(http.StatusInternalServerError, "CreateWebhookTemplate", err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToHook(org.AsUser().HomeLink(), tmpl))
+}
+
 // AddRepoHook add a hook to a repo. Writes to `ctx` accordingly
 func AddRepoHook(ctx *context.APIContext, form *api.CreateHookOption) {
 	repo := ctx.Repo
@@ -97,12 +128,26 @@ func pullHook(events []string, event string) bool {
 // addHook add the hook specified by `form`, `orgID` and `repoID`. If there is
 // an error, write to `ctx` accordingly. Return (webhook, ok)
 func addHook(ctx *context.APIContext, form *api.CreateHookOption, orgID, repoID int64) (*webhook.Webhook, bool) {
+	w, ok := newHook(ctx, form)
+	if !ok {
+		return nil, false
+	}
+	w.OrgID = orgID
+	w.RepoID = repoID
+	if err := webhook.CreateWebhook(ctx, w); err != nil {
+		ctx.Error(http.StatusInternalServerError, "CreateWebhook", err)
+		return nil, false
+	}
+	return w, true
+}
+
+// newHook build the hook specified by `form` without saving it. If there is
+// an error, write to `ctx` accordingly. Return (webhook, ok)
+func newHook(ctx *context.APIContext, form *api.CreateHookOption) (*webhook.Webhook, bool) {
 	if len(form.Events) == 0 {
 		form.Events = []string{"push"}
 	}
 	w := &webhook.Webhook{
-		OrgID:       orgID,
-		RepoID:      repoID,
 		URL:         form.Config["url"],
 		ContentType: webhook.ToHookContentType(form.Config["content_type"]),
 		Secret:      form.Config["secret"],
@@ -129,6 +174,7 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, orgID, repoID
 				Wiki:                 util.IsStringInSlice(string(webhook.HookEventWiki), form.Events, true),
 				Repository:           util.IsStringInSlice(string(webhook.HookEventRepository), form.Events, true),
 				Release:              util.IsStringInSlice(string(webhook.HookEventRelease), form.Events, true),
+				Deployment:           util.IsStringInSlice(string(webhook.HookEventDeployment), form.Events, true),
 			},
 			BranchFilter: form.BranchFilter,
 		},
@@ -164,9 +210,6 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, orgID, repoID
 	if err := w.UpdateEvent(); err != nil {
 		ctx.Error(http.StatusInternalServerError, "UpdateEvent", err)
 		return nil, false
-	} else if err := webhook.CreateWebhook(ctx, w); err != nil {
-		ctx.Error(http.StatusInternalServerError, "CreateWebhook", err)
-		return nil, false
 	}
 	return w, true
 }
@@ -188,6 +231,24 @@ func EditOrgHook(ctx *context.APIContext, form *api.EditHookOption, hookID int64
 	ctx.JSON(http.StatusOK, convert.ToHook(org.AsUser().HomeLink(), updated))
 }
 
+// EditOrgHookTemplate edit webhook template `w` according to `form` and the webhooks created from it.
+// Writes to `ctx` accordingly
+func EditOrgHookTemplate(ctx *context.APIContext, form *api.EditHookOption, templateID int64) {
+	org := ctx.Org.Organization
+	tmpl, err := GetOrgHookTemplate(ctx, org.ID, templateID)
+	if err != nil {
+		return
+	}
+	if !editHook(ctx, form, tmpl) {
+		return
+	}
+	updated, err := GetOrgHookTemplate(ctx, org.ID, templateID)
+	if err != nil {
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToHook(org.AsUser().HomeLink(), updated))
+}
+
 // EditRepoHook edit webhook `w` according to `form`. Writes to `ctx` accordingly
 func EditRepoHook(ctx *context.APIContext, form *api.EditHookOption, hookID int64) {
 	repo := ctx.Repo
@@ -252,6 +313,7 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 	w.Repository = util.IsStringInSlice(string(webhook.HookEventRepository), form.Events, true)
 	w.Wiki = util.IsStringInSlice(string(webhook.HookEventWiki), form.Events, true)
 	w.Release = util.IsStringInSlice(string(webhook.HookEventRelease), form.Events, true)
+	w.Deployment = util.IsStringInSlice(string(webhook.HookEventDeployment), form.Events, true)
 	w.BranchFilter = form.BranchFilter
 
 	// Issues
@@ -279,6 +341,14 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 		w.IsActive = *form.Active
 	}
 
+	if w.IsTemplate {
+		if err := webhook.UpdateWebhookTemplate(ctx, w); err != nil {
+			ctx.Error(http.StatusInternalServerError, "UpdateWebhookTemplate", err)
+			return false
+		}
+		return true
+	}
+
 	if err := webhook.UpdateWebhook(w); err != nil {
 		ctx.Error(http.StatusInternalServerError, "UpdateWebhook", err)
 		return false
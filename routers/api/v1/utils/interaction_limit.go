@@ -0,0 +1,45 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"errors"
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+)
+
+// SetInteractionLimit sets the interaction limit of the owner (repoID = 0) or of the repository (ownerID = 0)
+func SetInteractionLimit(ctx *context.APIContext, ownerID, repoID int64, owner *user_model.User) {
+	form := web.GetForm(ctx).(*api.SetInteractionLimitOption)
+	expiry := form.Expiry
+	if expiry == "" {
+		expiry = "one_day"
+	}
+	limit, err := repo_model.SetInteractionLimit(ctx, ownerID, repoID, repo_model.InteractionLimitType(form.Limit), expiry)
+	if err != nil {
+		if errors.Is(err, repo_model.ErrInvalidInteractionLimit) || errors.Is(err, repo_model.ErrInvalidInteractionLimitDuration) {
+			ctx.Error(http.StatusUnprocessableEntity, "SetInteractionLimit", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "SetInteractionLimit", err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToInteractionLimit(limit, owner))
+}
+
+// RemoveInteractionLimit removes the interaction limit of the owner (repoID = 0) or of the repository (ownerID = 0)
+func RemoveInteractionLimit(ctx *context.APIContext, ownerID, repoID int64) {
+	if err := repo_model.RemoveInteractionLimit(ctx, ownerID, repoID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "RemoveInteractionLimit", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
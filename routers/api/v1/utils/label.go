@@ -0,0 +1,47 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+	label_service "code.gitea.io/gitea/services/label"
+)
+
+// SyncLabels synchronizes the labels of the given repositories with a label set, or only detects their drift
+// with dryRun, and writes the changes of every repository to `ctx`
+func SyncLabels(ctx *context.APIContext, repos []*repo_model.Repository, set []*label_service.SetLabel, dryRun bool) {
+	results := make([]*api.LabelSyncResult, 0, len(repos))
+	for _, repo := range repos {
+		result, err := label_service.SyncLabels(ctx, repo, set, dryRun)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "SyncLabels", err)
+			return
+		}
+
+		apiResult := &api.LabelSyncResult{
+			Repository: repo.FullName(),
+			Changes:    make([]*api.LabelChange, 0, len(result.Changes)),
+		}
+		for _, change := range result.Changes {
+			apiLabel := convert.ToLabel(change.Label, repo, nil)
+			if change.Label.ID == 0 {
+				// not created by a dry run
+				apiLabel.URL = ""
+			}
+			apiResult.Changes = append(apiResult.Changes, &api.LabelChange{
+				Label:   apiLabel,
+				OldName: change.OldName,
+				Created: change.IsNew,
+			})
+		}
+		results = append(results, apiResult)
+	}
+	ctx.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"net/http"
+
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/convert"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ListModerationReports responds with the reports matching the options, filtered by the status given as query parameter
+func ListModerationReports(ctx *context.APIContext, opts *moderation_model.FindReportsOptions) {
+	status := moderation_model.ReportStatus(ctx.FormString("status"))
+	if status != "" && !status.IsValid() {
+		ctx.Error(http.StatusUnprocessableEntity, "", "unknown report status")
+		return
+	}
+	opts.ListOptions = GetListOptions(ctx)
+	opts.Status = status
+
+	reports, count, err := moderation_model.FindReports(ctx, opts)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindReports", err)
+		return
+	}
+	apiReports := make([]*api.ModerationReport, 0, len(reports))
+	for _, report := range reports {
+		if err := report.LoadAttributes(ctx); err != nil {
+			ctx.Error(http.StatusInternalServerError, "LoadAttributes", err)
+			return
+		}
+		apiReports = append(apiReports, convert.ToModerationReport(report, ctx.Doer))
+	}
+
+	ctx.SetLinkHeader(int(count), opts.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiReports)
+}
+
+// ListModerationLog responds with the entries of the moderation audit trail matching the options
+func ListModerationLog(ctx *context.APIContext, opts *moderation_model.FindLogsOptions) {
+	opts.ListOptions = GetListOptions(ctx)
+
+	logs, count, err := moderation_model.FindLogs(ctx, opts)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindLogs", err)
+		return
+	}
+	entries := make([]*api.ModerationLogEntry, 0, len(logs))
+	for _, l := range logs {
+		if err := l.LoadAttributes(ctx); err != nil {
+			ctx.Error(http.StatusInternalServerError, "LoadAttributes", err)
+			return
+		}
+		entries = append(entries, convert.ToModerationLogEntry(l, ctx.Doer))
+	}
+
+	ctx.SetLinkHeader(int(count), opts.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package common
+
+import (
+	go_context "context"
+	"io"
+	"sync"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+
+	"golang.org/x/time/rate"
+)
+
+var (
+	anonymousDownloadLimiterOnce sync.Once
+	anonymousDownloadLimiter     *rate.Limiter
+)
+
+// getAnonymousDownloadLimiter returns the limiter shared by all the anonymous downloads, nil if they are unlimited
+func getAnonymousDownloadLimiter() *rate.Limiter {
+	anonymousDownloadLimiterOnce.Do(func() {
+		if limit := setting.Repository.Download.AnonymousRateLimit; limit > 0 {
+			anonymousDownloadLimiter = rate.NewLimiter(rate.Limit(limit), int(limit))
+		}
+	})
+	return anonymousDownloadLimiter
+}
+
+// downloadReader counts the bytes read from a download and throttles them if it has a limiter
+type downloadReader struct {
+	ctx     go_context.Context
+	reader  io.Reader
+	limiter *rate.Limiter
+	size    int64
+}
+
+func (r *downloadReader) Read(p []byte) (int, error) {
+	if r.limiter != nil && len(p) > r.limiter.Burst() {
+		p = p[:r.limiter.Burst()]
+	}
+	n, err := r.reader.Read(p)
+	r.size += int64(n)
+	if r.limiter != nil && n > 0 {
+		if err := r.limiter.WaitN(r.ctx, n); err != nil {
+			return n, err
+		}
+	}
+	return n, err
+}
+
+// ServeDownload serves an attachment or a raw file of a repository as ServeData does. The bytes served are recorded
+// in the download usage of the repository, if there is one, and are throttled for the anonymous users.
+func ServeDownload(ctx *context.Context, repo *repo_model.Repository, filePath string, size int64, reader io.Reader) error {
+	download := &downloadReader{ctx: ctx, reader: reader}
+	if !ctx.IsSigned {
+		download.limiter = getAnonymousDownloadLimiter()
+	}
+
+	err := ServeData(ctx, filePath, size, download)
+	if repo != nil && setting.Repository.Download.EnableAccounting && download.size > 0 {
+		if err := repo_model.AddDownloadUsage(ctx, repo.ID, download.size); err != nil {
+			log.Error("AddDownloadUsage of %-v: %v", repo, err)
+		}
+	}
+	return err
+}
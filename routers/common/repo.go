@@ -39,7 +39,7 @@ func ServeBlob(ctx *context.Context, blob *git.Blob, lastModified time.Time) err
 		}
 	}()
 
-	return ServeData(ctx, ctx.Repo.TreePath, blob.Size(), dataRc)
+	return ServeDownload(ctx, ctx.Repo.Repository, ctx.Repo.TreePath, blob.Size(), dataRc)
 }
 
 // ServeData download file from io.Reader
@@ -17,6 +17,7 @@ import (
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/highlight"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	expertise_indexer "code.gitea.io/gitea/modules/indexer/expertise"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
 	stats_indexer "code.gitea.io/gitea/modules/indexer/stats"
 	"code.gitea.io/gitea/modules/log"
@@ -31,6 +32,7 @@ import (
 	"code.gitea.io/gitea/modules/translation"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
+	osv_router "code.gitea.io/gitea/routers/api/osv"
 	packages_router "code.gitea.io/gitea/routers/api/packages"
 	apiv1 "code.gitea.io/gitea/routers/api/v1"
 	"code.gitea.io/gitea/routers/common"
@@ -39,15 +41,20 @@ import (
 	"code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/auth/source/oauth2"
 	"code.gitea.io/gitea/services/automerge"
+	component_service "code.gitea.io/gitea/services/component"
 	"code.gitea.io/gitea/services/cron"
+	"code.gitea.io/gitea/services/extension"
 	"code.gitea.io/gitea/services/mailer"
 	repo_migrations "code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	pull_service "code.gitea.io/gitea/services/pull"
+	release_service "code.gitea.io/gitea/services/release"
+	"code.gitea.io/gitea/services/replication"
 	repo_service "code.gitea.io/gitea/services/repository"
 	"code.gitea.io/gitea/services/repository/archiver"
 	"code.gitea.io/gitea/services/task"
 	"code.gitea.io/gitea/services/webhook"
+	"code.gitea.io/gitea/services/websub"
 )
 
 func mustInit(fn func() error) {
@@ -143,11 +150,19 @@ func GlobalInitInstalled(ctx context.Context) {
 	issue_indexer.InitIssueIndexer(false)
 	code_indexer.Init()
 	mustInit(stats_indexer.Init)
+	if setting.Indexer.ExpertiseIndexerEnabled {
+		mustInit(expertise_indexer.Init)
+	}
 
 	mirror_service.InitSyncMirrors()
 	mustInit(webhook.Init)
+	mustInit(websub.Init)
+	mustInit(replication.Init)
+	mustInit(extension.Init)
 	mustInit(pull_service.Init)
 	mustInit(automerge.Init)
+	mustInit(release_service.Init)
+	mustInit(component_service.Init)
 	mustInit(task.Init)
 	mustInit(repo_migrations.Init)
 	eventsource.GetManager().Init()
@@ -178,5 +193,8 @@ func NormalRoutes(ctx context.Context) *web.Route {
 		r.Mount("/api/packages", packages_router.Routes(ctx))
 		r.Mount("/v2", packages_router.ContainerRoutes(ctx))
 	}
+	if setting.Advisories.OSVEnabled {
+		r.Mount("/api/osv", osv_router.Routes(ctx))
+	}
 	return r
 }
@@ -0,0 +1,113 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package private
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/private"
+)
+
+// commitIdentity is the author and committer email of a pushed commit
+type commitIdentity struct {
+	sha            string
+	authorEmail    string
+	committerEmail string
+}
+
+// readCommitIdentities returns the identities of the commits introduced by a ref update. For a new ref, these are the
+// commits which aren't reachable from any existing ref yet.
+func readCommitIdentities(ctx *preReceiveContext, oldCommitID, newCommitID string) ([]*commitIdentity, error) {
+	cmd := git.NewCommand(ctx, "log", "--format=%H%x00%ae%x00%ce")
+	if oldCommitID == git.EmptySHA {
+		cmd.AddArguments(newCommitID, "--not", "--all")
+	} else {
+		cmd.AddArguments(oldCommitID + ".." + newCommitID)
+	}
+	stdout, _, err := cmd.RunStdString(&git.RunOpts{Dir: ctx.Repo.Repository.RepoPath(), Env: ctx.env})
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make([]*commitIdentity, 0, 10)
+	for _, line := range strings.Split(stdout, "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 3 {
+			continue
+		}
+		identities = append(identities, &commitIdentity{sha: fields[0], authorEmail: fields[1], committerEmail: fields[2]})
+	}
+	return identities, nil
+}
+
+// loadVerifiedEmails loads the verified email addresses of the pusher, it returns false if an error occurs and writes the error response
+func (ctx *preReceiveContext) loadVerifiedEmails() bool {
+	if ctx.gotVerifiedEmails {
+		return true
+	}
+	if !ctx.loadPusherAndPermission() {
+		return false
+	}
+	emails, err := user_model.GetEmailAddresses(ctx.user.ID)
+	if err != nil {
+		log.Error("Unable to get the email addresses of User id %d Error: %v", ctx.user.ID, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to get the email addresses of User id %d Error: %v", ctx.user.ID, err),
+		})
+		return false
+	}
+	ctx.verifiedEmails = make([]string, 0, len(emails))
+	for _, email := range emails {
+		if email.IsActivated {
+			ctx.verifiedEmails = append(ctx.verifiedEmails, email.Email)
+		}
+	}
+	ctx.gotVerifiedEmails = true
+	return true
+}
+
+// checkCommitIdentities enforces the commit identity policy of the repository: the authors and committers of the
+// pushed commits must be verified email addresses of the pusher or belong to the allowed domains of the repository.
+// The merges of pull requests from the UI or the API are exempted, as their commits were pushed to the head branch.
+func checkCommitIdentities(ctx *preReceiveContext, oldCommitID, newCommitID, refFullName string) {
+	repo := ctx.Repo.Repository
+	if !repo.EnforceCommitIdentity || newCommitID == git.EmptySHA || ctx.opts.PullRequestID != 0 {
+		return
+	}
+	if !ctx.loadVerifiedEmails() {
+		return
+	}
+
+	identities, err := readCommitIdentities(ctx, oldCommitID, newCommitID)
+	if err != nil {
+		log.Error("Unable to read the commits from %s to %s in %-v: %v", oldCommitID, newCommitID, repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: fmt.Sprintf("Unable to read the commits from %s to %s: %v", oldCommitID, newCommitID, err),
+		})
+		return
+	}
+	for _, identity := range identities {
+		for _, check := range []struct{ role, email string }{
+			{"author", identity.authorEmail},
+			{"committer", identity.committerEmail},
+		} {
+			if repo.IsCommitEmailAllowed(check.email, ctx.verifiedEmails) {
+				continue
+			}
+			log.Warn("Forbidden: Commit %s pushed to %s in %-v by User %d has the %s %s", identity.sha, refFullName, repo, ctx.user.ID, check.role, check.email)
+			msg := fmt.Sprintf("commit %s has the %s email %s, which is not a verified email address of %s", identity.sha, check.role, check.email, ctx.user.Name)
+			if domains := repo.GetCommitEmailDomains(); len(domains) > 0 {
+				msg += fmt.Sprintf(" nor an address of the allowed domains (%s)", strings.Join(domains, ", "))
+			}
+			ctx.JSON(http.StatusForbidden, private.Response{Err: msg})
+			return
+		}
+	}
+}
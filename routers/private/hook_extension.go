@@ -0,0 +1,36 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package private
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/private"
+	"code.gitea.io/gitea/services/extension"
+)
+
+// checkExtensions asks the extension processors subscribed to the pre_receive event whether a ref update is accepted
+func checkExtensions(ctx *preReceiveContext, oldCommitID, newCommitID, refFullName string) {
+	if !extension.HasProcessors(extension.EventPreReceive) {
+		return
+	}
+	if !ctx.loadPusherAndPermission() {
+		return
+	}
+
+	repo := ctx.Repo.Repository
+	allowed, message := extension.Decide(ctx, extension.EventPreReceive, &extension.PreReceiveData{
+		Repository: extension.NewRepository(repo),
+		Pusher:     extension.NewUser(ctx.user),
+		Ref:        refFullName,
+		Before:     oldCommitID,
+		After:      newCommitID,
+	})
+	if !allowed {
+		log.Warn("Forbidden: Push of %s to %s in %-v by User %d rejected by an extension: %s", newCommitID, refFullName, repo, ctx.user.ID, message)
+		ctx.JSON(http.StatusForbidden, private.Response{Err: message})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/private"
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/web"
 	pull_service "code.gitea.io/gitea/services/pull"
 )
@@ -45,6 +46,9 @@ type preReceiveContext struct {
 	protectedTags    []*git_model.ProtectedTag
 	gotProtectedTags bool
 
+	verifiedEmails    []string
+	gotVerifiedEmails bool
+
 	env []string
 
 	opts *private.HookOptions
@@ -114,6 +118,22 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 		opts:           opts,
 	}
 
+	// A standby only receives the refs replicated by its primary instance, which are accepted as they are
+	if setting.Replication.Standby {
+		if !ourCtx.loadPusherAndPermission() {
+			return
+		}
+		if !ourCtx.user.IsAdmin {
+			log.Warn("Forbidden: User %s can't push to %-v on a standby instance", ourCtx.user.Name, ctx.Repo.Repository)
+			ctx.JSON(http.StatusForbidden, private.Response{
+				Err: "this instance is a standby, push to its primary instance",
+			})
+			return
+		}
+		ctx.PlainText(http.StatusOK, "ok")
+		return
+	}
+
 	// Iterate across the provided old commit IDs
 	for i := range opts.OldCommitIDs {
 		oldCommitID := opts.OldCommitIDs[i]
@@ -133,6 +153,16 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 		if ctx.Written() {
 			return
 		}
+
+		checkCommitIdentities(ourCtx, oldCommitID, newCommitID, refFullName)
+		if ctx.Written() {
+			return
+		}
+
+		checkExtensions(ourCtx, oldCommitID, newCommitID, refFullName)
+		if ctx.Written() {
+			return
+		}
 	}
 
 	ctx.PlainText(http.StatusOK, "ok")
@@ -149,6 +179,22 @@ func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID, refFullN
 	repo := ctx.Repo.Repository
 	gitRepo := ctx.Repo.GitRepo
 
+	// A push to a redirect updates the branch it redirects to, without the protection of that branch being checked
+	redirect, err := gitRepo.GetBranchRedirect(branchName)
+	if err != nil {
+		log.Error("Unable to get the redirect of branch: %s in %-v Error: %v", branchName, repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+		return
+	} else if redirect != "" {
+		log.Warn("Forbidden: Branch: %s in %-v has been renamed to %s", branchName, repo, redirect)
+		ctx.JSON(http.StatusForbidden, private.Response{
+			Err: fmt.Sprintf("branch %s has been renamed to %s", branchName, redirect),
+		})
+		return
+	}
+
 	if branchName == repo.DefaultBranch && newCommitID == git.EmptySHA {
 		log.Warn("Forbidden: Branch: %s is the default branch in %-v and cannot be deleted", branchName, repo)
 		ctx.JSON(http.StatusForbidden, private.Response{
@@ -409,6 +409,10 @@ func ServCommand(ctx *context.PrivateContext) {
 			return
 		}
 	}
+
+	// the wiki shares the partial clone settings of its repository
+	results.GitEnv = git.PartialCloneConfigEnv(repo.PartialCloneConfig())
+
 	log.Debug("Serv Results:\nIsWiki: %t\nDeployKeyID: %d\nKeyID: %d\tKeyName: %s\nUserName: %s\nUserID: %d\nOwnerName: %s\nRepoName: %s\nRepoID: %d",
 		results.IsWiki,
 		results.DeployKeyID,
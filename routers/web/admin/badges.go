@@ -0,0 +1,186 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const (
+	tplBadges    base.TplName = "admin/badge/list"
+	tplBadgeEdit base.TplName = "admin/badge/edit"
+)
+
+// Badges shows the badges and the form to create one
+func Badges(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.badges")
+	ctx.Data["PageIsAdmin"] = true
+	ctx.Data["PageIsAdminBadges"] = true
+
+	badges, err := user_model.GetBadges(ctx)
+	if err != nil {
+		ctx.ServerError("GetBadges", err)
+		return
+	}
+	ctx.Data["Badges"] = badges
+
+	ctx.HTML(http.StatusOK, tplBadges)
+}
+
+// NewBadgePost creates a badge defined by an admin
+func NewBadgePost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.AdminBadgeForm)
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(setting.AppSubURL + "/admin/badges")
+		return
+	}
+
+	b := &user_model.Badge{
+		Description: form.Description,
+		ImageURL:    form.ImageURL,
+	}
+	if err := user_model.CreateBadge(ctx, b); err != nil {
+		ctx.ServerError("CreateBadge", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("admin.badges.new_success"))
+	ctx.Redirect(fmt.Sprintf("%s/admin/badges/%d", setting.AppSubURL, b.ID))
+}
+
+func badgeFromParams(ctx *context.Context) *user_model.Badge {
+	b, err := user_model.GetBadgeByID(ctx, ctx.ParamsInt64(":badgeid"))
+	if err != nil {
+		if errors.Is(err, user_model.ErrBadgeNotExist) {
+			ctx.NotFound("GetBadgeByID", err)
+		} else {
+			ctx.ServerError("GetBadgeByID", err)
+		}
+		return nil
+	}
+	return b
+}
+
+// EditBadge shows a badge and the users who have been given it
+func EditBadge(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.badges.edit")
+	ctx.Data["PageIsAdmin"] = true
+	ctx.Data["PageIsAdminBadges"] = true
+
+	b := badgeFromParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Badge"] = b
+
+	users, err := user_model.GetBadgeUsers(ctx, b)
+	if err != nil {
+		ctx.ServerError("GetBadgeUsers", err)
+		return
+	}
+	ctx.Data["Users"] = users
+
+	ctx.HTML(http.StatusOK, tplBadgeEdit)
+}
+
+// EditBadgePost updates a badge
+func EditBadgePost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.AdminBadgeForm)
+	b := badgeFromParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	link := fmt.Sprintf("%s/admin/badges/%d", setting.AppSubURL, b.ID)
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(link)
+		return
+	}
+
+	b.Description = form.Description
+	b.ImageURL = form.ImageURL
+	if err := user_model.UpdateBadge(ctx, b); err != nil {
+		ctx.ServerError("UpdateBadge", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("admin.badges.update_success"))
+	ctx.Redirect(link)
+}
+
+// DeleteBadge deletes a badge and takes it away from all users
+func DeleteBadge(ctx *context.Context) {
+	b := badgeFromParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := user_model.DeleteBadge(ctx, b); err != nil {
+		ctx.ServerError("DeleteBadge", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("admin.badges.deletion_success"))
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"redirect": setting.AppSubURL + "/admin/badges",
+	})
+}
+
+// AddBadgeUserPost gives a badge to a user
+func AddBadgeUserPost(ctx *context.Context) {
+	b := badgeFromParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	link := fmt.Sprintf("%s/admin/badges/%d", setting.AppSubURL, b.ID)
+
+	u, err := user_model.GetUserByName(ctx, ctx.FormString("user_name"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("form.user_not_exist"))
+			ctx.Redirect(link)
+		} else {
+			ctx.ServerError("GetUserByName", err)
+		}
+		return
+	}
+	if err := user_model.AddUserBadge(ctx, u, b); err != nil {
+		ctx.ServerError("AddUserBadge", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("admin.badges.award_success", u.Name))
+	ctx.Redirect(link)
+}
+
+// RemoveBadgeUser takes a badge away from a user
+func RemoveBadgeUser(ctx *context.Context) {
+	b := badgeFromParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	u, err := user_model.GetUserByID(ctx.FormInt64("id"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.NotFound("GetUserByID", err)
+		} else {
+			ctx.ServerError("GetUserByID", err)
+		}
+		return
+	}
+	if err := user_model.RemoveUserBadge(ctx, u, b); err != nil {
+		ctx.ServerError("RemoveUserBadge", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("admin.badges.remove_success", u.Name))
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"redirect": fmt.Sprintf("%s/admin/badges/%d", setting.AppSubURL, b.ID),
+	})
+}
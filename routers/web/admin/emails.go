@@ -16,6 +16,7 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
+	org_service "code.gitea.io/gitea/services/org"
 )
 
 const (
@@ -133,6 +134,13 @@ func ActivateEmail(ctx *context.Context) {
 	} else {
 		log.Info("Activation for User ID: %d, email: %s, primary: %v changed to %v", uid, email, primary, activate)
 		ctx.Flash.Info(ctx.Tr("admin.emails.updated"))
+		if activate {
+			if u, err := user_model.GetUserByID(uid); err != nil {
+				log.Error("GetUserByID(%d): %v", uid, err)
+			} else if err := org_service.JoinVerifiedDomainOrgs(ctx, u); err != nil {
+				log.Error("JoinVerifiedDomainOrgs: %v", err)
+			}
+		}
 	}
 
 	redirect, _ := url.Parse(setting.AppSubURL + "/admin/emails")
@@ -0,0 +1,189 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const (
+	tplExploreCollections    base.TplName = "admin/collection/list"
+	tplExploreCollectionEdit base.TplName = "admin/collection/edit"
+)
+
+// ExploreCollections shows the collections of the explore pages and the form to create one
+func ExploreCollections(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.collections")
+	ctx.Data["PageIsAdmin"] = true
+	ctx.Data["PageIsAdminCollections"] = true
+
+	collections, err := repo_model.GetExploreCollections(ctx, false)
+	if err != nil {
+		ctx.ServerError("GetExploreCollections", err)
+		return
+	}
+	ctx.Data["Collections"] = collections
+
+	ctx.HTML(http.StatusOK, tplExploreCollections)
+}
+
+// NewExploreCollectionPost creates a collection of the explore pages
+func NewExploreCollectionPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.AdminExploreCollectionForm)
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(setting.AppSubURL + "/admin/collections")
+		return
+	}
+
+	c := &repo_model.ExploreCollection{
+		Name:        form.Name,
+		Description: form.Description,
+		Topic:       form.Topic,
+		IsPinned:    form.IsPinned,
+		Position:    form.Position,
+	}
+	if err := repo_model.CreateExploreCollection(ctx, c); err != nil {
+		ctx.ServerError("CreateExploreCollection", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("admin.collections.new_success", c.Name))
+	ctx.Redirect(fmt.Sprintf("%s/admin/collections/%d", setting.AppSubURL, c.ID))
+}
+
+func exploreCollectionFromParams(ctx *context.Context) *repo_model.ExploreCollection {
+	c, err := repo_model.GetExploreCollectionByID(ctx, ctx.ParamsInt64(":collectionid"))
+	if err != nil {
+		if errors.Is(err, repo_model.ErrExploreCollectionNotExist) {
+			ctx.NotFound("GetExploreCollectionByID", err)
+		} else {
+			ctx.ServerError("GetExploreCollectionByID", err)
+		}
+		return nil
+	}
+	return c
+}
+
+// EditExploreCollection shows a collection of the explore pages and the repositories added to it
+func EditExploreCollection(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("admin.collections.edit")
+	ctx.Data["PageIsAdmin"] = true
+	ctx.Data["PageIsAdminCollections"] = true
+
+	c := exploreCollectionFromParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Collection"] = c
+
+	repos, err := repo_model.GetExploreCollectionAddedRepos(ctx, c)
+	if err != nil {
+		ctx.ServerError("GetExploreCollectionAddedRepos", err)
+		return
+	}
+	if err := repos.LoadAttributes(); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	ctx.Data["Repos"] = repos
+
+	ctx.HTML(http.StatusOK, tplExploreCollectionEdit)
+}
+
+// EditExploreCollectionPost updates a collection of the explore pages
+func EditExploreCollectionPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.AdminExploreCollectionForm)
+	c := exploreCollectionFromParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	link := fmt.Sprintf("%s/admin/collections/%d", setting.AppSubURL, c.ID)
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(link)
+		return
+	}
+
+	c.Name = form.Name
+	c.Description = form.Description
+	c.Topic = form.Topic
+	c.IsPinned = form.IsPinned
+	c.Position = form.Position
+	if err := repo_model.UpdateExploreCollection(ctx, c); err != nil {
+		ctx.ServerError("UpdateExploreCollection", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("admin.collections.update_success"))
+	ctx.Redirect(link)
+}
+
+// DeleteExploreCollection deletes a collection of the explore pages
+func DeleteExploreCollection(ctx *context.Context) {
+	c := exploreCollectionFromParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := repo_model.DeleteExploreCollection(ctx, c); err != nil {
+		ctx.ServerError("DeleteExploreCollection", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("admin.collections.deletion_success"))
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"redirect": setting.AppSubURL + "/admin/collections",
+	})
+}
+
+// AddExploreCollectionRepoPost adds a repository to a collection of the explore pages
+func AddExploreCollectionRepoPost(ctx *context.Context) {
+	c := exploreCollectionFromParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	link := fmt.Sprintf("%s/admin/collections/%d", setting.AppSubURL, c.ID)
+
+	owner, name, _ := strings.Cut(strings.TrimSpace(ctx.FormString("repo_name")), "/")
+	repo, err := repo_model.GetRepositoryByOwnerAndName(owner, name)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("admin.collections.repo_not_exist"))
+			ctx.Redirect(link)
+		} else {
+			ctx.ServerError("GetRepositoryByOwnerAndName", err)
+		}
+		return
+	}
+	if err := repo_model.AddExploreCollectionRepo(ctx, c, repo.ID); err != nil {
+		ctx.ServerError("AddExploreCollectionRepo", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("admin.collections.add_success", repo.FullName()))
+	ctx.Redirect(link)
+}
+
+// RemoveExploreCollectionRepo removes a repository from a collection of the explore pages
+func RemoveExploreCollectionRepo(ctx *context.Context) {
+	c := exploreCollectionFromParams(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := repo_model.RemoveExploreCollectionRepo(ctx, c, ctx.FormInt64("id")); err != nil {
+		ctx.ServerError("RemoveExploreCollectionRepo", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("admin.collections.remove_success"))
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"redirect": fmt.Sprintf("%s/admin/collections/%d", setting.AppSubURL, c.ID),
+	})
+}
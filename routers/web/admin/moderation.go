@@ -0,0 +1,130 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+	user_setting "code.gitea.io/gitea/routers/web/user/setting"
+	moderation_service "code.gitea.io/gitea/services/moderation"
+)
+
+const (
+	tplModerationReports base.TplName = "admin/moderation/reports"
+	tplModerationHolds   base.TplName = "admin/moderation/holds"
+	tplModerationLog     base.TplName = "admin/moderation/log"
+)
+
+// ModerationReports show the reports about the content of all the repositories
+func ModerationReports(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.moderation_reports")
+	ctx.Data["PageIsAdmin"] = true
+	ctx.Data["PageIsAdminModeration"] = true
+	ctx.Data["PageIsSettingsModerationReports"] = true
+	ctx.Data["ReportsLink"] = setting.AppSubURL + "/admin/moderation/reports"
+
+	if !user_setting.PrepareModerationReports(ctx, 0) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplModerationReports)
+}
+
+// ModerationReportPost response for taking an action on a report
+func ModerationReportPost(ctx *context.Context) {
+	user_setting.HandleModerationReportPost(ctx, 0, setting.AppSubURL+"/admin/moderation/reports")
+}
+
+// ModerationLog show the moderation audit trail of the whole instance
+func ModerationLog(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.moderation_log")
+	ctx.Data["PageIsAdmin"] = true
+	ctx.Data["PageIsAdminModeration"] = true
+	ctx.Data["PageIsSettingsModerationLog"] = true
+
+	if !user_setting.PrepareModerationLog(ctx, 0) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplModerationLog)
+}
+
+// ModerationHolds show the content held by the spam detection
+func ModerationHolds(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.moderation_holds")
+	ctx.Data["PageIsAdmin"] = true
+	ctx.Data["PageIsAdminModeration"] = true
+	ctx.Data["PageIsSettingsModerationHolds"] = true
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	status := moderation_model.HoldStatus(ctx.FormString("status"))
+	if !status.IsValid() {
+		status = moderation_model.HoldStatusHeld
+	}
+
+	holds, count, err := moderation_model.FindHolds(ctx, &moderation_model.FindHoldsOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.IssuePagingNum},
+		Status:      status,
+	})
+	if err != nil {
+		ctx.ServerError("FindHolds", err)
+		return
+	}
+	for _, hold := range holds {
+		if err := hold.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+	}
+	ctx.Data["Holds"] = holds
+	ctx.Data["HoldStatus"] = status
+	ctx.Data["HoldStatuses"] = moderation_model.HoldStatuses
+
+	pager := context.NewPagination(int(count), setting.UI.IssuePagingNum, page, 5)
+	pager.AddParamString("status", string(status))
+	ctx.Data["Page"] = pager
+	ctx.HTML(http.StatusOK, tplModerationHolds)
+}
+
+// ModerationHoldPost response for approving or rejecting held content
+func ModerationHoldPost(ctx *context.Context) {
+	hold, err := moderation_model.GetHoldByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, moderation_model.ErrHoldNotExist) {
+			ctx.NotFound("GetHoldByID", err)
+			return
+		}
+		ctx.ServerError("GetHoldByID", err)
+		return
+	}
+
+	reason := ctx.FormString("reason")
+	switch ctx.FormString("action") {
+	case "approve":
+		err = moderation_service.ApproveHold(ctx, ctx.Doer, hold, reason)
+	case "reject":
+		err = moderation_service.RejectHold(ctx, ctx.Doer, hold, reason)
+	default:
+		ctx.Error(http.StatusBadRequest)
+		return
+	}
+	switch {
+	case err == nil:
+		ctx.Flash.Success(ctx.Tr("settings.moderation.hold.success"))
+	case errors.Is(err, moderation_model.ErrHoldClosed):
+		ctx.Flash.Error(ctx.Tr("settings.moderation.hold.closed"))
+	default:
+		ctx.ServerError("ReviewHold", err)
+		return
+	}
+	ctx.Redirect(setting.AppSubURL + "/admin/moderation/holds")
+}
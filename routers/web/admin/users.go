@@ -6,6 +6,7 @@
 package admin
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -26,6 +27,7 @@ import (
 	user_setting "code.gitea.io/gitea/routers/web/user/setting"
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/mailer"
+	org_service "code.gitea.io/gitea/services/org"
 	user_service "code.gitea.io/gitea/services/user"
 )
 
@@ -197,6 +199,10 @@ func NewUserPost(ctx *context.Context) {
 	}
 	log.Trace("Account created by admin (%s): %s", ctx.Doer.Name, u.Name)
 
+	if err := org_service.JoinVerifiedDomainOrgs(ctx, u); err != nil {
+		log.Error("JoinVerifiedDomainOrgs: %v", err)
+	}
+
 	// Send email notification.
 	if form.SendNotify {
 		mailer.SendRegisterNotifyMail(u)
@@ -449,6 +455,50 @@ func DeleteUser(ctx *context.Context) {
 	ctx.Redirect(setting.AppSubURL + "/admin/users")
 }
 
+// MergeUserPost response for merging a duplicate user into another account
+func MergeUserPost(ctx *context.Context) {
+	u := prepareUserInfo(ctx)
+	if ctx.Written() {
+		return
+	}
+	link := setting.AppSubURL + "/admin/users/" + strconv.FormatInt(u.ID, 10)
+
+	target, err := user_model.GetUserByName(ctx, ctx.FormTrim("target"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("form.user_not_exist"))
+			ctx.Redirect(link)
+		} else {
+			ctx.ServerError("GetUserByName", err)
+		}
+		return
+	}
+
+	if err := user_service.MergeUser(ctx, ctx.Doer, u, target); err != nil {
+		switch {
+		case errors.Is(err, user_service.ErrMergeSameUser):
+			ctx.Flash.Error(ctx.Tr("admin.users.merge_same_user"))
+		case errors.Is(err, user_service.ErrMergeOrganization):
+			ctx.Flash.Error(ctx.Tr("admin.users.merge_organization"))
+		case models.IsErrUserOwnRepos(err):
+			ctx.Flash.Error(ctx.Tr("admin.users.still_own_repo"))
+		case models.IsErrUserHasOrgs(err):
+			ctx.Flash.Error(ctx.Tr("admin.users.still_has_org"))
+		case models.IsErrUserOwnPackages(err):
+			ctx.Flash.Error(ctx.Tr("admin.users.still_own_packages"))
+		default:
+			ctx.ServerError("MergeUser", err)
+			return
+		}
+		ctx.Redirect(link)
+		return
+	}
+	log.Trace("Account %s merged into %s by admin (%s)", u.Name, target.Name, ctx.Doer.Name)
+
+	ctx.Flash.Success(ctx.Tr("admin.users.merge_success", target.Name))
+	ctx.Redirect(setting.AppSubURL + "/admin/users/" + strconv.FormatInt(target.ID, 10))
+}
+
 // AvatarPost response for change user's avatar request
 func AvatarPost(ctx *context.Context) {
 	u := prepareUserInfo(ctx)
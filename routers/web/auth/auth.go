@@ -29,9 +29,11 @@ import (
 	"code.gitea.io/gitea/routers/utils"
 	auth_service "code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/auth/source/oauth2"
+	"code.gitea.io/gitea/services/extension"
 	"code.gitea.io/gitea/services/externalaccount"
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/mailer"
+	org_service "code.gitea.io/gitea/services/org"
 
 	"github.com/markbates/goth"
 )
@@ -527,6 +529,26 @@ func SignUpPost(ctx *context.Context) {
 	handleSignIn(ctx, u, false)
 }
 
+// checkRegistrationExtensions asks the extension processors subscribed to the user_register event whether the user
+// can be created, it renders the rejection and returns false otherwise
+func checkRegistrationExtensions(ctx *context.Context, tpl base.TplName, form interface{}, u *user_model.User, gothUser *goth.User) bool {
+	data := &extension.UserRegisterData{User: extension.NewUser(u)}
+	if gothUser != nil {
+		data.Provider = gothUser.Provider
+	}
+	allowed, message := extension.Decide(ctx, extension.EventUserRegister, data)
+	if allowed {
+		return true
+	}
+	log.Warn("Registration of %s <%s> rejected by an extension: %s", u.Name, u.Email, message)
+	if len(tpl) == 0 {
+		ctx.Error(http.StatusForbidden, message)
+	} else {
+		ctx.RenderWithErr(message, tpl, form)
+	}
+	return false
+}
+
 // createAndHandleCreatedUser calls createUserInContext and
 // then handleUserCreated.
 func createAndHandleCreatedUser(ctx *context.Context, tpl base.TplName, form interface{}, u *user_model.User, overwrites *user_model.CreateUserOverwriteOptions, gothUser *goth.User, allowLink bool) bool {
@@ -539,6 +561,9 @@ func createAndHandleCreatedUser(ctx *context.Context, tpl base.TplName, form int
 // createUserInContext creates a user and handles errors within a given context.
 // Optionally a template can be specified.
 func createUserInContext(ctx *context.Context, tpl base.TplName, form interface{}, u *user_model.User, overwrites *user_model.CreateUserOverwriteOptions, gothUser *goth.User, allowLink bool) (ok bool) {
+	if !checkRegistrationExtensions(ctx, tpl, form, u, gothUser) {
+		return
+	}
 	if err := user_model.CreateUser(u, overwrites); err != nil {
 		if allowLink && (user_model.IsErrUserAlreadyExist(err) || user_model.IsErrEmailAlreadyUsed(err)) {
 			if setting.OAuth2Client.AccountLinking == setting.OAuth2AccountLinkingAuto {
@@ -646,6 +671,10 @@ func handleUserCreated(ctx *context.Context, u *user_model.User, gothUser *goth.
 		return
 	}
 
+	if err := org_service.JoinVerifiedDomainOrgs(ctx, u); err != nil {
+		log.Error("JoinVerifiedDomainOrgs: %v", err)
+	}
+
 	return true
 }
 
@@ -758,6 +787,10 @@ func handleAccountActivation(ctx *context.Context, user *user_model.User) {
 
 	log.Trace("User activated: %s", user.Name)
 
+	if err := org_service.JoinVerifiedDomainOrgs(ctx, user); err != nil {
+		log.Error("JoinVerifiedDomainOrgs: %v", err)
+	}
+
 	if _, err := session.RegenerateSession(ctx.Resp, ctx.Req); err != nil {
 		log.Error("Unable to regenerate session for user: %-v with email: %s: %v", user, user.Email, err)
 		ctx.ServerError("ActivateUserEmail", err)
@@ -799,9 +832,14 @@ func ActivateEmail(ctx *context.Context) {
 
 		if u, err := user_model.GetUserByID(email.UID); err != nil {
 			log.Warn("GetUserByID: %d", email.UID)
-		} else if setting.CacheService.Enabled {
-			// Allow user to validate more emails
-			_ = ctx.Cache.Delete("MailResendLimit_" + u.LowerName)
+		} else {
+			if err := org_service.JoinVerifiedDomainOrgs(ctx, u); err != nil {
+				log.Error("JoinVerifiedDomainOrgs: %v", err)
+			}
+			if setting.CacheService.Enabled {
+				// Allow user to validate more emails
+				_ = ctx.Cache.Delete("MailResendLimit_" + u.LowerName)
+			}
 		}
 	}
 
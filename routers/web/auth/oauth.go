@@ -43,6 +43,7 @@ import (
 const (
 	tplGrantAccess base.TplName = "user/auth/grant"
 	tplGrantError  base.TplName = "user/auth/grant_error"
+	tplGrantDevice base.TplName = "user/auth/grant_device"
 )
 
 // TODO move error and responses to SDK or models
@@ -95,6 +96,25 @@ const (
 	AccessTokenErrorCodeUnsupportedGrantType = "unsupported_grant_type"
 	// AccessTokenErrorCodeInvalidScope represents an error code specified in RFC 6749
 	AccessTokenErrorCodeInvalidScope = "invalid_scope"
+	// AccessTokenErrorCodeAuthorizationPending represents an error code specified in RFC 8628
+	AccessTokenErrorCodeAuthorizationPending = "authorization_pending"
+	// AccessTokenErrorCodeSlowDown represents an error code specified in RFC 8628
+	AccessTokenErrorCodeSlowDown = "slow_down"
+	// AccessTokenErrorCodeAccessDenied represents an error code specified in RFC 8628
+	AccessTokenErrorCodeAccessDenied = "access_denied"
+	// AccessTokenErrorCodeExpiredToken represents an error code specified in RFC 8628
+	AccessTokenErrorCodeExpiredToken = "expired_token"
+	// AccessTokenErrorCodeInvalidTarget represents an error code specified in RFC 8693
+	AccessTokenErrorCodeInvalidTarget = "invalid_target"
+)
+
+const (
+	// GrantTypeDeviceCode is the grant type of the device authorization grant specified in RFC 8628
+	GrantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+	// GrantTypeTokenExchange is the grant type of the token exchange specified in RFC 8693
+	GrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	// TokenTypeIdentifierAccessToken is the token type identifier of the access tokens specified in RFC 8693
+	TokenTypeIdentifierAccessToken = "urn:ietf:params:oauth:token-type:access_token"
 )
 
 // AccessTokenError represents an error response specified in RFC 6749
@@ -135,9 +155,14 @@ type AccessTokenResponse struct {
 	ExpiresIn    int64     `json:"expires_in"`
 	RefreshToken string    `json:"refresh_token"`
 	IDToken      string    `json:"id_token,omitempty"`
+
+	// IssuedTokenType is only set in the responses to token exchanges
+	IssuedTokenType string `json:"issued_token_type,omitempty"`
 }
 
-func newAccessTokenResponse(ctx stdContext.Context, grant *auth.OAuth2Grant, serverKey, clientKey oauth2.JWTSigningKey) (*AccessTokenResponse, *AccessTokenError) {
+// newAccessTokenResponse issues new tokens for the grant. The scope narrows the one of the grant for the tokens issued
+// by a token exchange, it is nil for the others.
+func newAccessTokenResponse(ctx stdContext.Context, grant *auth.OAuth2Grant, scope *string, serverKey, clientKey oauth2.JWTSigningKey) (*AccessTokenResponse, *AccessTokenError) {
 	if setting.OAuth2.InvalidateRefreshTokens {
 		if err := grant.IncreaseCounter(ctx); err != nil {
 			return nil, &AccessTokenError{
@@ -146,11 +171,17 @@ func newAccessTokenResponse(ctx stdContext.Context, grant *auth.OAuth2Grant, ser
 			}
 		}
 	}
+	if scope != nil {
+		narrowed := *grant
+		narrowed.Scope = *scope
+		grant = &narrowed
+	}
 	// generate access token to access the API
 	expirationDate := timeutil.TimeStampNow().Add(setting.OAuth2.AccessTokenExpirationTime)
 	accessToken := &oauth2.Token{
 		GrantID: grant.ID,
 		Type:    oauth2.TypeAccessToken,
+		Scope:   scope,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationDate.AsTime()),
 		},
@@ -169,6 +200,7 @@ func newAccessTokenResponse(ctx stdContext.Context, grant *auth.OAuth2Grant, ser
 		GrantID: grant.ID,
 		Counter: grant.Counter,
 		Type:    oauth2.TypeRefreshToken,
+		Scope:   scope,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(refreshExpirationDate),
 		},
@@ -340,7 +372,7 @@ func IntrospectOAuth(ctx *context.Context) {
 				app, err := auth.GetOAuth2ApplicationByID(ctx, grant.ApplicationID)
 				if err == nil && app != nil {
 					response.Active = true
-					response.Scope = grant.Scope
+					response.Scope = tokenScope(token, grant)
 					response.Issuer = setting.AppURL
 					response.Audience = []string{app.ClientID}
 					response.Subject = fmt.Sprint(grant.UserID)
@@ -632,10 +664,14 @@ func AccessTokenOAuth(ctx *context.Context) {
 		handleRefreshToken(ctx, form, serverKey, clientKey)
 	case "authorization_code":
 		handleAuthorizationCode(ctx, form, serverKey, clientKey)
+	case GrantTypeDeviceCode:
+		handleDeviceCode(ctx, form, serverKey, clientKey)
+	case GrantTypeTokenExchange:
+		handleTokenExchange(ctx, form, serverKey, clientKey)
 	default:
 		handleAccessTokenError(ctx, AccessTokenError{
 			ErrorCode:        AccessTokenErrorCodeUnsupportedGrantType,
-			ErrorDescription: "Only refresh_token or authorization_code grant type is supported",
+			ErrorDescription: "Only refresh_token, authorization_code, device_code or token-exchange grant type is supported",
 		})
 	}
 }
@@ -668,7 +704,7 @@ func handleRefreshToken(ctx *context.Context, form forms.AccessTokenForm, server
 		log.Warn("A client tried to use a refresh token for grant_id = %d was used twice!", grant.ID)
 		return
 	}
-	accessToken, tokenErr := newAccessTokenResponse(ctx, grant, serverKey, clientKey)
+	accessToken, tokenErr := newAccessTokenResponse(ctx, grant, token.Scope, serverKey, clientKey)
 	if tokenErr != nil {
 		handleAccessTokenError(ctx, *tokenErr)
 		return
@@ -730,7 +766,7 @@ func handleAuthorizationCode(ctx *context.Context, form forms.AccessTokenForm, s
 			ErrorDescription: "cannot proceed your request",
 		})
 	}
-	resp, tokenErr := newAccessTokenResponse(ctx, authorizationCode.Grant, serverKey, clientKey)
+	resp, tokenErr := newAccessTokenResponse(ctx, authorizationCode.Grant, nil, serverKey, clientKey)
 	if tokenErr != nil {
 		handleAccessTokenError(ctx, *tokenErr)
 		return
@@ -991,8 +1027,24 @@ func updateAvatarIfNeed(url string, u *user_model.User) {
 	}
 }
 
+// syncGroupsToTeams updates the team memberships of the user according to the group claims mapped by the source
+func syncGroupsToTeams(ctx *context.Context, loginSource *auth.Source, u *user_model.User, gothUser *goth.User) {
+	source := loginSource.Cfg.(*oauth2.Source)
+	if source.GroupClaimName == "" || source.GroupTeamMap == "" {
+		return
+	}
+
+	groupClaims, has := gothUser.RawData[source.GroupClaimName]
+	if !has {
+		return
+	}
+
+	source.SyncGroupsToTeams(ctx, u, claimValueToStringSlice(groupClaims))
+}
+
 func handleOAuth2SignIn(ctx *context.Context, source *auth.Source, u *user_model.User, gothUser goth.User) {
 	updateAvatarIfNeed(gothUser.AvatarURL, u)
+	syncGroupsToTeams(ctx, source, u, &gothUser)
 
 	needs2FA := false
 	if !source.Cfg.(*oauth2.Source).SkipLocalTwoFA {
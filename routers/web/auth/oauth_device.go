@@ -0,0 +1,246 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+
+	"code.gitea.io/gitea/models/auth"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/auth/source/oauth2"
+	"code.gitea.io/gitea/services/forms"
+)
+
+// DeviceAuthorizationResponse represents a successful device authorization response specified in RFC 8628
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+}
+
+// authenticateOAuthClient returns the application of a client, the client secret is only checked when it is required
+// or given as the public clients like CLI tools can't keep it secret
+func authenticateOAuthClient(ctx *context.Context, clientID, clientSecret string, requireSecret bool) (*auth.OAuth2Application, *AccessTokenError) {
+	app, err := auth.GetOAuth2ApplicationByClientID(ctx, clientID)
+	if err != nil {
+		return nil, &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidClient,
+			ErrorDescription: fmt.Sprintf("cannot load client with client id: '%s'", clientID),
+		}
+	}
+	if (requireSecret || clientSecret != "") && !app.ValidateClientSecret([]byte(clientSecret)) {
+		return nil, &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeUnauthorizedClient,
+			ErrorDescription: "client is not authorized",
+		}
+	}
+	return app, nil
+}
+
+// DeviceAuthorizationOAuth manages the authorization requests of devices, see section 3.1 of RFC 8628
+func DeviceAuthorizationOAuth(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.DeviceAuthorizationForm)
+	app, acErr := authenticateOAuthClient(ctx, form.ClientID, form.ClientSecret, false)
+	if acErr != nil {
+		handleAccessTokenError(ctx, *acErr)
+		return
+	}
+
+	code, err := app.GenerateDeviceCode(ctx, form.Scope, setting.OAuth2.DeviceCodeExpirationTime)
+	if err != nil {
+		ctx.ServerError("GenerateDeviceCode", err)
+		return
+	}
+
+	verificationURI := setting.AppURL + "login/oauth/device"
+	ctx.JSON(http.StatusOK, &DeviceAuthorizationResponse{
+		DeviceCode:              code.DeviceCode,
+		UserCode:                code.FormattedUserCode(),
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?user_code=" + url.QueryEscape(code.FormattedUserCode()),
+		ExpiresIn:               setting.OAuth2.DeviceCodeExpirationTime,
+		Interval:                setting.OAuth2.DeviceCodePollingInterval,
+	})
+}
+
+// renderGrantDevice shows the page to approve the authorization of the device with the given code
+func renderGrantDevice(ctx *context.Context, code *auth.OAuth2DeviceCode) {
+	app, err := auth.GetOAuth2ApplicationByID(ctx, code.ApplicationID)
+	if err != nil {
+		ctx.ServerError("GetOAuth2ApplicationByID", err)
+		return
+	}
+	owner, err := user_model.GetUserByID(app.UID)
+	if err != nil {
+		ctx.ServerError("GetUserByID", err)
+		return
+	}
+	ctx.Data["Application"] = app
+	ctx.Data["DeviceCode"] = code
+	ctx.Data["ApplicationUserLinkHTML"] = "<a href=\"" + html.EscapeString(owner.HTMLURL()) + "\">@" + html.EscapeString(owner.Name) + "</a>"
+	ctx.HTML(http.StatusOK, tplGrantDevice)
+}
+
+// GrantDevice shows the page where a user enters the code displayed by a device to authorize it
+func GrantDevice(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("auth.device_authorize_title")
+	userCode := ctx.FormTrim("user_code")
+	if userCode == "" {
+		ctx.HTML(http.StatusOK, tplGrantDevice)
+		return
+	}
+
+	code, err := auth.GetOAuth2DeviceCodeByUserCode(ctx, userCode)
+	if err != nil {
+		if auth.IsErrOAuth2DeviceCodeNotExist(err) {
+			ctx.Data["user_code"] = userCode
+			ctx.Data["Err_UserCode"] = true
+			ctx.RenderWithErr(ctx.Tr("auth.device_code_invalid"), tplGrantDevice, nil)
+			return
+		}
+		ctx.ServerError("GetOAuth2DeviceCodeByUserCode", err)
+		return
+	}
+	renderGrantDevice(ctx, code)
+}
+
+// GrantDevicePost manages the post request submitted when a user approves or denies the authorization of a device
+func GrantDevicePost(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("auth.device_authorize_title")
+	form := web.GetForm(ctx).(*forms.GrantDeviceForm)
+	code, err := auth.GetOAuth2DeviceCodeByUserCode(ctx, form.UserCode)
+	if err != nil {
+		if auth.IsErrOAuth2DeviceCodeNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("auth.device_code_invalid"))
+			ctx.Redirect(setting.AppSubURL + "/login/oauth/device")
+			return
+		}
+		ctx.ServerError("GetOAuth2DeviceCodeByUserCode", err)
+		return
+	}
+
+	if !form.Granted {
+		if err := code.Deny(ctx); err != nil {
+			ctx.ServerError("Deny", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("auth.device_authorize_denied"))
+		ctx.Redirect(setting.AppSubURL + "/login/oauth/device")
+		return
+	}
+
+	app, err := auth.GetOAuth2ApplicationByID(ctx, code.ApplicationID)
+	if err != nil {
+		ctx.ServerError("GetOAuth2ApplicationByID", err)
+		return
+	}
+	grant, err := app.GetGrantByUserID(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetGrantByUserID", err)
+		return
+	}
+	if grant == nil {
+		if grant, err = app.CreateGrant(ctx, ctx.Doer.ID, code.Scope); err != nil {
+			ctx.ServerError("CreateGrant", err)
+			return
+		}
+	}
+	if err := code.Approve(ctx, grant.ID); err != nil {
+		ctx.ServerError("Approve", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("auth.device_authorize_success", app.Name))
+	ctx.Redirect(setting.AppSubURL + "/login/oauth/device")
+}
+
+// handleDeviceCode issues the access token of a device once the user approved its authorization, see section 3.4 of RFC 8628
+func handleDeviceCode(ctx *context.Context, form forms.AccessTokenForm, serverKey, clientKey oauth2.JWTSigningKey) {
+	app, acErr := authenticateOAuthClient(ctx, form.ClientID, form.ClientSecret, false)
+	if acErr != nil {
+		handleAccessTokenError(ctx, *acErr)
+		return
+	}
+	code, err := auth.GetOAuth2DeviceCodeByDeviceCode(ctx, app.ID, form.DeviceCode)
+	if err != nil {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+			ErrorDescription: "invalid device code",
+		})
+		return
+	}
+
+	switch {
+	case code.IsExpired():
+		if err := code.Invalidate(ctx); err != nil {
+			ctx.ServerError("Invalidate", err)
+			return
+		}
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeExpiredToken,
+			ErrorDescription: "the device code has expired",
+		})
+		return
+	case code.IsDenied:
+		if err := code.Invalidate(ctx); err != nil {
+			ctx.ServerError("Invalidate", err)
+			return
+		}
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeAccessDenied,
+			ErrorDescription: "the user denied the authorization",
+		})
+		return
+	case !code.IsApproved():
+		tooFast, err := code.UpdatePolled(ctx, setting.OAuth2.DeviceCodePollingInterval)
+		if err != nil {
+			ctx.ServerError("UpdatePolled", err)
+			return
+		}
+		if tooFast {
+			handleAccessTokenError(ctx, AccessTokenError{
+				ErrorCode:        AccessTokenErrorCodeSlowDown,
+				ErrorDescription: fmt.Sprintf("poll at most every %d seconds", setting.OAuth2.DeviceCodePollingInterval),
+			})
+			return
+		}
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeAuthorizationPending,
+			ErrorDescription: "the user has not approved the authorization yet",
+		})
+		return
+	}
+
+	// remove the device code from database to deny duplicate usage
+	if err := code.Invalidate(ctx); err != nil {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidRequest,
+			ErrorDescription: "cannot proceed your request",
+		})
+		return
+	}
+	grant, err := auth.GetOAuth2GrantByID(ctx, code.GrantID)
+	if err != nil || grant == nil {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+			ErrorDescription: "grant does not exist",
+		})
+		return
+	}
+	resp, tokenErr := newAccessTokenResponse(ctx, grant, nil, serverKey, clientKey)
+	if tokenErr != nil {
+		handleAccessTokenError(ctx, *tokenErr)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,151 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/services/auth/source/oauth2"
+	"code.gitea.io/gitea/services/forms"
+)
+
+// handleTokenExchange exchanges an access token issued to the client for a token acting on behalf of the same user,
+// see RFC 8693. The new token is issued for another application of the same owner given as audience, so a service can
+// delegate a request to another service the user authorized. The scope of the new token is never wider than the one
+// of the subject token.
+func handleTokenExchange(ctx *context.Context, form forms.AccessTokenForm, serverKey, clientKey oauth2.JWTSigningKey) {
+	app, acErr := authenticateOAuthClient(ctx, form.ClientID, form.ClientSecret, true)
+	if acErr != nil {
+		handleAccessTokenError(ctx, *acErr)
+		return
+	}
+	if form.SubjectTokenType != TokenTypeIdentifierAccessToken {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidRequest,
+			ErrorDescription: "only access tokens can be exchanged",
+		})
+		return
+	}
+	if form.RequestedTokenType != "" && form.RequestedTokenType != TokenTypeIdentifierAccessToken {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidRequest,
+			ErrorDescription: "only access tokens can be requested",
+		})
+		return
+	}
+
+	token, err := oauth2.ParseToken(form.SubjectToken, serverKey)
+	if err != nil || token.Type != oauth2.TypeAccessToken {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+			ErrorDescription: "invalid subject token",
+		})
+		return
+	}
+	subjectGrant, err := auth.GetOAuth2GrantByID(ctx, token.GrantID)
+	if err != nil || subjectGrant == nil {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+			ErrorDescription: "grant does not exist",
+		})
+		return
+	}
+	// a client can only exchange the tokens issued to itself
+	if subjectGrant.ApplicationID != app.ID {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+			ErrorDescription: "the subject token was not issued to this client",
+		})
+		return
+	}
+
+	target := app
+	if form.Audience != "" && form.Audience != app.ClientID {
+		target, err = auth.GetOAuth2ApplicationByClientID(ctx, form.Audience)
+		if err != nil {
+			handleAccessTokenError(ctx, AccessTokenError{
+				ErrorCode:        AccessTokenErrorCodeInvalidTarget,
+				ErrorDescription: "unknown audience",
+			})
+			return
+		}
+		if target.UID != app.UID {
+			handleAccessTokenError(ctx, AccessTokenError{
+				ErrorCode:        AccessTokenErrorCodeInvalidTarget,
+				ErrorDescription: "the audience must be an application of the same owner",
+			})
+			return
+		}
+	}
+
+	// the user must have authorized the audience, the exchange doesn't authorize it on their behalf
+	grant := subjectGrant
+	if target.ID != app.ID {
+		grant, err = target.GetGrantByUserID(ctx, subjectGrant.UserID)
+		if err != nil {
+			ctx.ServerError("GetGrantByUserID", err)
+			return
+		}
+		if grant == nil {
+			handleAccessTokenError(ctx, AccessTokenError{
+				ErrorCode:        AccessTokenErrorCodeInvalidTarget,
+				ErrorDescription: "the user has not authorized the audience",
+			})
+			return
+		}
+	}
+
+	// the exchanged token can't be given more than the subject token, nor than the user authorized for the audience
+	subjectScope := tokenScope(token, subjectGrant)
+	var scopes []string
+	if form.Scope != "" {
+		for _, s := range strings.Fields(form.Scope) {
+			if !scopeContains(subjectScope, s) || !grant.ScopeContains(s) {
+				handleAccessTokenError(ctx, AccessTokenError{
+					ErrorCode:        AccessTokenErrorCodeInvalidScope,
+					ErrorDescription: "the requested scope exceeds the scope of the subject token or the one authorized for the audience",
+				})
+				return
+			}
+			scopes = append(scopes, s)
+		}
+	} else {
+		for _, s := range strings.Fields(subjectScope) {
+			if grant.ScopeContains(s) {
+				scopes = append(scopes, s)
+			}
+		}
+	}
+	scope := strings.Join(scopes, " ")
+
+	resp, tokenErr := newAccessTokenResponse(ctx, grant, &scope, serverKey, clientKey)
+	if tokenErr != nil {
+		handleAccessTokenError(ctx, *tokenErr)
+		return
+	}
+	resp.IssuedTokenType = TokenTypeIdentifierAccessToken
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// tokenScope returns the scope of a token, the one of its grant unless the token was issued by a token exchange
+func tokenScope(token *oauth2.Token, grant *auth.OAuth2Grant) string {
+	if token.Scope != nil {
+		return *token.Scope
+	}
+	return grant.Scope
+}
+
+// scopeContains returns whether the space separated scope contains s
+func scopeContains(scope, s string) bool {
+	for _, field := range strings.Fields(scope) {
+		if field == s {
+			return true
+		}
+	}
+	return false
+}
@@ -23,7 +23,7 @@ func createAndParseToken(t *testing.T, grant *auth.OAuth2Grant) *oauth2.OIDCToke
 	assert.NoError(t, err)
 	assert.NotNil(t, signingKey)
 
-	response, terr := newAccessTokenResponse(db.DefaultContext, grant, signingKey, signingKey)
+	response, terr := newAccessTokenResponse(db.DefaultContext, grant, nil, signingKey, signingKey)
 	assert.Nil(t, terr)
 	assert.NotNil(t, response)
 
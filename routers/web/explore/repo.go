@@ -153,6 +153,19 @@ func Repos(ctx *context.Context) {
 	ctx.Data["PageIsExplore"] = true
 	ctx.Data["PageIsExploreRepositories"] = true
 	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+	if setting.Service.Explore.EnableActivityFeed {
+		ctx.Data["FeedURL"] = setting.AppURL + "explore/activity"
+	}
+
+	// the pinned collections are shown on top of the first page of the repositories
+	if ctx.FormInt("page") <= 1 && ctx.FormTrim("q") == "" && ctx.Params("idx") == "" {
+		sections, err := loadCollectionSections(ctx, true)
+		if err != nil {
+			ctx.ServerError("loadCollectionSections", err)
+			return
+		}
+		ctx.Data["CollectionSections"] = sections
+	}
 
 	var ownerID int64
 	if ctx.Doer != nil && !ctx.Doer.IsAdmin {
@@ -0,0 +1,141 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package explore
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+const (
+	// tplExploreTrending explore trending repositories page template
+	tplExploreTrending base.TplName = "explore/trending"
+	// tplExploreCollection explore collection page template
+	tplExploreCollection base.TplName = "explore/collection"
+)
+
+// collectionPreviewSize is the number of repositories shown by the sections of the collections
+const collectionPreviewSize = 6
+
+// CollectionSection is a collection with the first of its repositories the doer can see
+type CollectionSection struct {
+	Collection *repo_model.ExploreCollection
+	Repos      repo_model.RepositoryList
+	Total      int64
+}
+
+// loadCollectionSections returns the sections of the collections which have repositories the doer can see
+func loadCollectionSections(ctx *context.Context, onlyPinned bool) ([]*CollectionSection, error) {
+	collections, err := repo_model.GetExploreCollections(ctx, onlyPinned)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make([]*CollectionSection, 0, len(collections))
+	for _, c := range collections {
+		repos, count, err := repo_model.GetExploreCollectionRepos(ctx, c, ctx.Doer, db.ListOptions{Page: 1, PageSize: collectionPreviewSize})
+		if err != nil {
+			return nil, err
+		}
+		if err := repos.LoadAttributes(); err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			sections = append(sections, &CollectionSection{Collection: c, Repos: repos, Total: count})
+		}
+	}
+	return sections, nil
+}
+
+func setExploreContext(ctx *context.Context) {
+	ctx.Data["UsersIsDisabled"] = setting.Service.Explore.DisableUsersPage
+	ctx.Data["Title"] = ctx.Tr("explore")
+	ctx.Data["PageIsExplore"] = true
+	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+}
+
+// Trending render the collections and the trending repositories
+func Trending(ctx *context.Context) {
+	setExploreContext(ctx)
+	ctx.Data["PageIsExploreTrending"] = true
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	if page == 1 {
+		sections, err := loadCollectionSections(ctx, false)
+		if err != nil {
+			ctx.ServerError("loadCollectionSections", err)
+			return
+		}
+		ctx.Data["CollectionSections"] = sections
+	}
+
+	repos, count, err := repo_model.FindTrendingRepos(ctx, ctx.Doer, db.ListOptions{Page: page, PageSize: setting.UI.ExplorePagingNum})
+	if err != nil {
+		ctx.ServerError("FindTrendingRepos", err)
+		return
+	}
+	if err := repos.LoadAttributes(); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	ctx.Data["Repos"] = repos
+	ctx.Data["Total"] = count
+
+	pager := context.NewPagination(int(count), setting.UI.ExplorePagingNum, page, 5)
+	pager.SetDefaultParams(ctx)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplExploreTrending)
+}
+
+// Collection render all the repositories of a collection
+func Collection(ctx *context.Context) {
+	setExploreContext(ctx)
+	ctx.Data["PageIsExploreTrending"] = true
+
+	c, err := repo_model.GetExploreCollectionByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, repo_model.ErrExploreCollectionNotExist) {
+			ctx.NotFound("GetExploreCollectionByID", err)
+		} else {
+			ctx.ServerError("GetExploreCollectionByID", err)
+		}
+		return
+	}
+	ctx.Data["Title"] = c.Name
+	ctx.Data["Collection"] = c
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+	repos, count, err := repo_model.GetExploreCollectionRepos(ctx, c, ctx.Doer, db.ListOptions{Page: page, PageSize: setting.UI.ExplorePagingNum})
+	if err != nil {
+		ctx.ServerError("GetExploreCollectionRepos", err)
+		return
+	}
+	if err := repos.LoadAttributes(); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	ctx.Data["Repos"] = repos
+	ctx.Data["Total"] = count
+
+	pager := context.NewPagination(int(count), setting.UI.ExplorePagingNum, page, 5)
+	pager.SetDefaultParams(ctx)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplExploreCollection)
+}
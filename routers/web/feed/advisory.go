@@ -0,0 +1,129 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"html"
+	"strings"
+	"time"
+
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+
+	"github.com/gorilla/feeds"
+)
+
+// ShowAdvisoriesFeedRSS shows the security advisories of a repository as RSS feed
+func ShowAdvisoriesFeedRSS(ctx *context.Context) {
+	showAdvisoriesFeed(ctx, "rss")
+}
+
+// ShowAdvisoriesFeedAtom shows the security advisories of a repository as Atom feed
+func ShowAdvisoriesFeedAtom(ctx *context.Context) {
+	showAdvisoriesFeed(ctx, "atom")
+}
+
+// showAdvisoriesFeed shows the published security advisories of a repository, the latest published first, as
+// RSS / Atom feed
+func showAdvisoriesFeed(ctx *context.Context, formatType string) {
+	repo := ctx.Repo.Repository
+	page := &feedPage{Page: getFeedPage(ctx), PageSize: getFeedPageSize(ctx)}
+	advisories, count, err := advisory_model.FindAdvisories(ctx, &advisory_model.FindAdvisoriesOptions{
+		ListOptions:      db.ListOptions{Page: page.Page, PageSize: page.PageSize},
+		RepoID:           repo.ID,
+		State:            advisory_model.StatePublished,
+		OrderByPublished: true,
+	})
+	if err != nil {
+		ctx.ServerError("FindAdvisories", err)
+		return
+	}
+	page.HasNext = int64(page.Page*page.PageSize) < count
+	for _, adv := range advisories {
+		adv.Repo = repo
+		if err := adv.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+	}
+
+	feed := &feeds.Feed{
+		Title:       ctx.Tr("repo.advisories.feed_of", repo.FullName()),
+		Link:        &feeds.Link{Href: repo.HTMLURL() + "/security/advisories"},
+		Description: repo.Description,
+		Created:     time.Now(),
+	}
+	meta := &feedItemsMeta{Categories: map[string][]string{}}
+	feed.Items, err = advisoriesToFeedItems(ctx, repo, advisories, meta)
+	if err != nil {
+		ctx.ServerError("convert feed", err)
+		return
+	}
+
+	writePagedFeed(ctx, feed, formatType, page, meta)
+}
+
+// advisoriesToFeedItems converts published advisories to feeds Item, their content starts with the severity, the
+// identifiers and the affected package of the vulnerability, they are categorized by the severity and the identifiers
+func advisoriesToFeedItems(ctx *context.Context, repo *repo_model.Repository, advisories []*advisory_model.Advisory, meta *feedItemsMeta) ([]*feeds.Item, error) {
+	items := make([]*feeds.Item, 0, len(advisories))
+	for _, adv := range advisories {
+		description, err := markdown.RenderString(&markup.RenderContext{
+			Ctx:       ctx,
+			URLPrefix: repo.Link(),
+			Type:      markdown.MarkupName,
+			Metas:     repo.ComposeMetas(),
+		}, adv.Description)
+		if err != nil {
+			return nil, err
+		}
+
+		var content strings.Builder
+		content.WriteString("<dl>")
+		writeField := func(key, value string) {
+			if value != "" {
+				content.WriteString("<dt>" + ctx.Tr(key) + "</dt><dd>" + html.EscapeString(value) + "</dd>")
+			}
+		}
+		writeField("repo.advisories.severity", ctx.Tr("repo.advisories.severity."+string(adv.Severity)))
+		writeField("repo.advisories.cve_id", adv.CVEID)
+		writeField("repo.advisories.cwe_ids", strings.Join(adv.CWEIDs, ", "))
+		writeField("repo.advisories.ecosystem", adv.Ecosystem)
+		writeField("repo.advisories.package_name", adv.PackageName)
+		writeField("repo.advisories.vulnerable_versions", adv.VulnerableVersions)
+		writeField("repo.advisories.patched_versions", adv.PatchedVersions)
+		content.WriteString("</dl>")
+		content.WriteString(description)
+
+		link := adv.HTMLURL()
+		item := &feeds.Item{
+			Title:       adv.Title,
+			Link:        &feeds.Link{Href: link},
+			Description: adv.Title,
+			Id:          link,
+			Created:     adv.PublishedUnix.AsTime(),
+			Content:     content.String(),
+		}
+		if adv.Publisher != nil {
+			item.Author = &feeds.Author{
+				Name:  adv.Publisher.DisplayName(),
+				Email: adv.Publisher.GetEmail(),
+			}
+			meta.addAuthor(link, adv.Publisher)
+		}
+
+		categories := append([]string{string(adv.Severity)}, adv.CWEIDs...)
+		if adv.CVEID != "" {
+			categories = append(categories, adv.CVEID)
+		}
+		meta.Categories[link] = categories
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"fmt"
+	"html"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/gorilla/feeds"
+)
+
+// ShowBranchFeed shows the latest commits of a branch as RSS / Atom feed, one item per commit
+func ShowBranchFeed(ctx *context.Context, repo *repo_model.Repository, formatType string) {
+	since, ok := getFeedSince(ctx)
+	if !ok {
+		return
+	}
+
+	page := &feedPage{Page: getFeedPage(ctx), PageSize: getFeedPageSize(ctx)}
+	commits, err := ctx.Repo.Commit.CommitsByRange(page.Page, page.PageSize)
+	if err != nil {
+		ctx.ServerError("CommitsByRange", err)
+		return
+	}
+	page.HasNext = len(commits) == page.PageSize
+	if since > 0 {
+		// the commits are listed newest first, the older ones and the following pages are before the requested time
+		for i, commit := range commits {
+			if commit.Committer.When.Unix() < since {
+				commits = commits[:i]
+				page.HasNext = false
+				break
+			}
+		}
+	}
+
+	feed := &feeds.Feed{
+		Title:       ctx.Tr("repo.commits.feed_of", ctx.Repo.BranchName, repo.FullName()),
+		Link:        &feeds.Link{Href: repo.HTMLURL() + "/commits/branch/" + util.PathEscapeSegments(ctx.Repo.BranchName)},
+		Description: repo.Description,
+		Created:     time.Now(),
+	}
+
+	feed.Items, err = commitsToFeedItems(ctx, repo, commits)
+	if err != nil {
+		ctx.ServerError("convert feed", err)
+		return
+	}
+
+	writePagedFeed(ctx, feed, formatType, page, nil)
+}
+
+// commitsToFeedItems converts commits to feeds Item, their content being the rendered commit message
+func commitsToFeedItems(ctx *context.Context, repo *repo_model.Repository, commits []*git.Commit) ([]*feeds.Item, error) {
+	renderCtx := &markup.RenderContext{
+		Ctx:       ctx,
+		URLPrefix: repo.Link(),
+		Metas:     repo.ComposeMetas(),
+	}
+
+	items := make([]*feeds.Item, 0, len(commits))
+	for _, commit := range commits {
+		message, err := markup.RenderCommitMessage(renderCtx, html.EscapeString(commit.Message()))
+		if err != nil {
+			return nil, err
+		}
+
+		sha := commit.ID.String()
+		link := repo.HTMLURL() + "/commit/" + sha
+		items = append(items, &feeds.Item{
+			Title:       commit.Summary(),
+			Link:        &feeds.Link{Href: link},
+			Description: sha,
+			Author: &feeds.Author{
+				Name:  commit.Author.Name,
+				Email: commit.Author.Email,
+			},
+			Id:      link,
+			Created: commit.Committer.When,
+			Content: fmt.Sprintf(`<p><a href="%s">%s</a></p><pre>%s</pre>`, html.EscapeString(link), sha, message),
+		})
+	}
+	return items, nil
+}
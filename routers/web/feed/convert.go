@@ -7,12 +7,18 @@ package feed
 import (
 	"fmt"
 	"html"
+	"mime"
 	"net/http"
 	"net/url"
+	"path"
 	"strconv"
 	"strings"
 
 	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/avatars"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/context"
 	"code.gitea.io/gitea/modules/markup"
 	"code.gitea.io/gitea/modules/markup/markdown"
@@ -23,6 +29,39 @@ import (
 	"github.com/gorilla/feeds"
 )
 
+// feedAvatarSize is the size of the avatars embedded in the content of the items
+const feedAvatarSize = avatars.DefaultAvatarPixelSize
+
+// toAbsoluteLink returns the absolute URL of a link, which may be relative to the instance
+func toAbsoluteLink(link string) string {
+	if strings.HasPrefix(link, "//") || strings.Contains(link, "://") {
+		return link
+	}
+	return setting.AppURL + strings.TrimPrefix(strings.TrimPrefix(link, setting.AppSubURL), "/")
+}
+
+// toUserAvatarLink returns the absolute link of the avatar of a user
+func toUserAvatarLink(u *user_model.User) string {
+	return toAbsoluteLink(u.AvatarLinkWithSize(feedAvatarSize * setting.Avatar.RenderedSizeFactor))
+}
+
+// avatarHTML returns the image of an avatar to embed in the content of an item, before the name of its user
+func avatarHTML(link, name string) string {
+	return fmt.Sprintf(`<img src="%s" alt="%s" width="%d" height="%d"> `, html.EscapeString(link), html.EscapeString(name), feedAvatarSize, feedAvatarSize)
+}
+
+// addAuthor records the profile and the avatar of the author of an item, readers show them along the item
+func (m *feedItemsMeta) addAuthor(id string, u *user_model.User) {
+	if m.Authors == nil {
+		m.Authors = make(map[string]*feedItemAuthor)
+	}
+	author := &feedItemAuthor{Avatar: toUserAvatarLink(u)}
+	if u.ID > 0 {
+		author.URI = u.HTMLURL()
+	}
+	m.Authors[id] = author
+}
+
 func toBranchLink(act *activities_model.Action) string {
 	return act.GetRepoLink() + "/src/branch/" + util.PathEscapeSegments(act.GetBranch())
 }
@@ -31,6 +70,11 @@ func toTagLink(act *activities_model.Action) string {
 	return act.GetRepoLink() + "/src/tag/" + util.PathEscapeSegments(act.GetTag())
 }
 
+// toRepoTagLink returns the absolute link of a tag of a repository, built like toTagLink
+func toRepoTagLink(repo *repo_model.Repository, tagName string) string {
+	return repo.HTMLURL() + "/src/tag/" + util.PathEscapeSegments(tagName)
+}
+
 func toIssueLink(act *activities_model.Action) string {
 	return act.GetRepoLink() + "/issues/" + url.PathEscape(act.GetIssueInfos()[0])
 }
@@ -39,14 +83,61 @@ func toPullLink(act *activities_model.Action) string {
 	return act.GetRepoLink() + "/pulls/" + url.PathEscape(act.GetIssueInfos()[0])
 }
 
+func toAdvisoryLink(act *activities_model.Action) string {
+	return act.GetRepoLink() + "/security/advisories/" + url.PathEscape(act.GetIssueInfos()[0])
+}
+
 func toSrcLink(act *activities_model.Action) string {
 	return act.GetRepoLink() + "/src/" + util.PathEscapeSegments(act.GetBranch())
 }
 
+func toCommitLink(act *activities_model.Action) string {
+	return act.GetRepoLink() + "/commit/" + url.PathEscape(act.GetIssueInfos()[0])
+}
+
+// toWorkflowRunLink returns the link to the run of a workflow run action, the commit if the run has no link
+func toWorkflowRunLink(act *activities_model.Action) string {
+	if infos := act.GetIssueInfos(); len(infos) > 1 && infos[1] != "" {
+		return infos[1]
+	}
+	return toCommitLink(act)
+}
+
 func toReleaseLink(act *activities_model.Action) string {
 	return act.GetRepoLink() + "/releases/tag/" + util.PathEscapeSegments(act.GetBranch())
 }
 
+// toAttachmentEnclosure returns the enclosure of a release asset, its MIME type is guessed from the file extension
+func toAttachmentEnclosure(attachment *repo_model.Attachment) *feeds.Enclosure {
+	mimeType := mime.TypeByExtension(path.Ext(attachment.Name))
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	return &feeds.Enclosure{
+		Url:    attachment.DownloadURL(),
+		Type:   mimeType,
+		Length: strconv.FormatInt(attachment.Size, 10),
+	}
+}
+
+// toReleaseEnclosure returns the enclosure of the published release of an action, nil if the release has no asset
+// or was deleted since. RSS only allows a single enclosure per item, so it is the first asset of the release.
+func toReleaseEnclosure(ctx *context.Context, act *activities_model.Action) (*feeds.Enclosure, error) {
+	rel, err := repo_model.GetRelease(act.RepoID, act.GetBranch())
+	if repo_model.IsErrReleaseNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := repo_model.GetReleaseAttachments(ctx, rel); err != nil {
+		return nil, err
+	}
+	if len(rel.Attachments) == 0 {
+		return nil, nil
+	}
+	return toAttachmentEnclosure(rel.Attachments[0]), nil
+}
+
 // renderMarkdown creates a minimal markdown render context from an action.
 // If rendering fails, the original markdown text is returned
 func renderMarkdown(ctx *context.Context, act *activities_model.Action, content string) string {
@@ -66,12 +157,14 @@ func renderMarkdown(ctx *context.Context, act *activities_model.Action, content
 	return markdown
 }
 
-// feedActionsToFeedItems convert gitea's Action feed to feeds Item
-func feedActionsToFeedItems(ctx *context.Context, actions activities_model.ActionList) (items []*feeds.Item, err error) {
+// feedActionsToFeedItems convert gitea's Action feed to feeds Item, the content of the items starts with the avatar of
+// their actor as on the dashboard
+func feedActionsToFeedItems(ctx *context.Context, actions activities_model.ActionList, meta *feedItemsMeta) (items []*feeds.Item, err error) {
 	for _, act := range actions {
 		act.LoadActUser()
 
 		var content, desc, title string
+		var enclosure *feeds.Enclosure
 
 		link := &feeds.Link{Href: act.GetCommentLink()}
 
@@ -175,6 +268,24 @@ func feedActionsToFeedItems(ctx *context.Context, actions activities_model.Actio
 				link.Href = releaseLink
 			}
 			title += ctx.TrHTMLEscapeArgs("action.publish_release", act.GetRepoLink(), releaseLink, act.ShortRepoPath(), act.Content)
+			if enclosure, err = toReleaseEnclosure(ctx, act); err != nil {
+				return nil, err
+			}
+		case activities_model.ActionPublishAdvisory:
+			advisoryLink := toAdvisoryLink(act)
+			link.Href = advisoryLink
+			title += ctx.TrHTMLEscapeArgs("action.publish_advisory", act.GetRepoLink(), advisoryLink, act.ShortRepoPath(), act.GetIssueInfos()[1])
+		case activities_model.ActionWorkflowRunSuccess, activities_model.ActionWorkflowRunFailure:
+			link.Href = toWorkflowRunLink(act)
+			key := "action.workflow_run_failure"
+			if act.OpType == activities_model.ActionWorkflowRunSuccess {
+				key = "action.workflow_run_success"
+			}
+			var workflow string
+			if infos := act.GetIssueInfos(); len(infos) > 2 {
+				workflow = infos[2]
+			}
+			title += ctx.TrHTMLEscapeArgs(key, act.GetRepoLink(), link.Href, workflow, toCommitLink(act), base.ShortSha(act.GetIssueInfos()[0]), act.ShortRepoPath())
 		case activities_model.ActionPullReviewDismissed:
 			pullLink := toPullLink(act)
 			title += ctx.TrHTMLEscapeArgs("action.review_dismissed", pullLink, act.GetIssueInfos()[0], act.ShortRepoPath(), act.GetIssueInfos()[1])
@@ -199,7 +310,8 @@ func feedActionsToFeedItems(ctx *context.Context, actions activities_model.Actio
 					if len(desc) != 0 {
 						desc += "\n\n"
 					}
-					desc += fmt.Sprintf("<a href=\"%s\">%s</a>\n%s",
+					desc += fmt.Sprintf("%s<a href=\"%s\">%s</a>\n%s",
+						avatarHTML(toAbsoluteLink(push.AvatarLink(commit.AuthorEmail)), commit.AuthorName),
 						html.EscapeString(fmt.Sprintf("%s/commit/%s", act.GetRepoLink(), commit.Sha1)),
 						commit.Sha1,
 						templates.RenderCommitMessage(ctx, commit.Message, repoLink, nil),
@@ -227,12 +339,19 @@ func feedActionsToFeedItems(ctx *context.Context, actions activities_model.Actio
 				desc = act.GetIssueTitle()
 			case activities_model.ActionPullReviewDismissed:
 				desc = ctx.Tr("action.review_dismissed_reason") + "\n\n" + act.GetIssueInfos()[2]
+			case activities_model.ActionStarRepo:
+				if act.Repo != nil {
+					desc = act.Repo.Description
+				}
 			}
 		}
 		if len(content) == 0 {
 			content = desc
 		}
+		content = avatarHTML(toUserAvatarLink(act.ActUser), act.ActUser.DisplayName()) + content
 
+		id := strconv.FormatInt(act.ID, 10)
+		meta.addAuthor(id, act.ActUser)
 		items = append(items, &feeds.Item{
 			Title:       title,
 			Link:        link,
@@ -241,14 +360,34 @@ func feedActionsToFeedItems(ctx *context.Context, actions activities_model.Actio
 				Name:  act.ActUser.DisplayName(),
 				Email: act.ActUser.GetEmail(),
 			},
-			Id:      strconv.FormatInt(act.ID, 10),
-			Created: act.CreatedUnix.AsTime(),
-			Content: content,
+			Id:        id,
+			Created:   act.CreatedUnix.AsTime(),
+			Content:   content,
+			Enclosure: enclosure,
 		})
 	}
 	return items, err
 }
 
+// feedActionsInReplyTo returns the absolute links of the issues and pull requests the comments of the actions reply
+// to, by the ids of their items. They are the ids of the opening entries of the issue feeds.
+func feedActionsInReplyTo(actions activities_model.ActionList) map[string]string {
+	inReplyTo := make(map[string]string)
+	for _, act := range actions {
+		var link string
+		switch act.OpType {
+		case activities_model.ActionCommentIssue:
+			link = toIssueLink(act)
+		case activities_model.ActionCommentPull:
+			link = toPullLink(act)
+		default:
+			continue
+		}
+		inReplyTo[strconv.FormatInt(act.ID, 10)] = setting.AppURL + strings.TrimPrefix(strings.TrimPrefix(link, setting.AppSubURL), "/")
+	}
+	return inReplyTo
+}
+
 // GetFeedType return if it is a feed request and altered name and feed type.
 func GetFeedType(name string, req *http.Request) (bool, string, string) {
 	if strings.HasSuffix(name, ".rss") ||
@@ -0,0 +1,72 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/gorilla/feeds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToAttachmentEnclosure(t *testing.T) {
+	defer func(appURL string) { setting.AppURL = appURL }(setting.AppURL)
+	setting.AppURL = "https://try.gitea.io/"
+
+	enclosure := toAttachmentEnclosure(&repo_model.Attachment{
+		UUID: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+		Name: "gitea.zip",
+		Size: 1024,
+	})
+	assert.Equal(t, &feeds.Enclosure{
+		Url:    "https://try.gitea.io/attachments/a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11",
+		Type:   "application/zip",
+		Length: "1024",
+	}, enclosure)
+
+	enclosure = toAttachmentEnclosure(&repo_model.Attachment{
+		UUID: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+		Name: "gitea-linux-amd64",
+	})
+	assert.Equal(t, "application/octet-stream", enclosure.Type)
+	assert.Equal(t, "0", enclosure.Length)
+}
+
+func TestFeedActionsInReplyTo(t *testing.T) {
+	defer func(appURL, appSubURL string) { setting.AppURL, setting.AppSubURL = appURL, appSubURL }(setting.AppURL, setting.AppSubURL)
+	setting.AppURL = "https://try.gitea.io/sub/"
+	setting.AppSubURL = "/sub"
+
+	repo := &repo_model.Repository{OwnerName: "user2", Name: "repo1"}
+	inReplyTo := feedActionsInReplyTo(activities_model.ActionList{
+		{ID: 1, OpType: activities_model.ActionCreateIssue, Repo: repo, Content: "1|title"},
+		{ID: 2, OpType: activities_model.ActionCommentIssue, Repo: repo, Content: "1|comment"},
+		{ID: 3, OpType: activities_model.ActionCommentPull, Repo: repo, Content: "2|comment"},
+	})
+	assert.Equal(t, map[string]string{
+		"2": "https://try.gitea.io/sub/user2/repo1/issues/1",
+		"3": "https://try.gitea.io/sub/user2/repo1/pulls/2",
+	}, inReplyTo)
+}
+
+func TestToAbsoluteLink(t *testing.T) {
+	defer func(appURL, appSubURL string) { setting.AppURL, setting.AppSubURL = appURL, appSubURL }(setting.AppURL, setting.AppSubURL)
+	setting.AppURL = "https://try.gitea.io/sub/"
+	setting.AppSubURL = "/sub"
+
+	assert.Equal(t, "https://try.gitea.io/sub/avatars/abc", toAbsoluteLink("/sub/avatars/abc"))
+	assert.Equal(t, "https://try.gitea.io/sub/assets/img/avatar_default.png", toAbsoluteLink("/sub/assets/img/avatar_default.png"))
+	assert.Equal(t, "https://secure.gravatar.com/avatar/abc?d=identicon", toAbsoluteLink("https://secure.gravatar.com/avatar/abc?d=identicon"))
+	assert.Equal(t, "//secure.gravatar.com/avatar/abc", toAbsoluteLink("//secure.gravatar.com/avatar/abc"))
+}
+
+func TestAvatarHTML(t *testing.T) {
+	assert.Equal(t, `<img src="https://try.gitea.io/avatars/abc?size=56&amp;x=1" alt="&lt;user&gt;" width="28" height="28"> `,
+		avatarHTML("https://try.gitea.io/avatars/abc?size=56&x=1", "<user>"))
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/gorilla/feeds"
+)
+
+// ShowExploreFeedRSS show the public activity of the whole instance as RSS feed
+func ShowExploreFeedRSS(ctx *context.Context) {
+	showExploreFeed(ctx, "rss")
+}
+
+// ShowExploreFeedAtom show the public activity of the whole instance as Atom feed
+func ShowExploreFeedAtom(ctx *context.Context) {
+	showExploreFeed(ctx, "atom")
+}
+
+// showExploreFeed show the public activity of the whole instance as RSS / Atom feed, it is the same for every viewer
+func showExploreFeed(ctx *context.Context, formatType string) {
+	if !setting.Service.Explore.EnableActivityFeed {
+		ctx.NotFound("EnableActivityFeed", nil)
+		return
+	}
+
+	opTypes, ok := getFeedActionTypes(ctx)
+	if !ok {
+		return
+	}
+
+	since, ok := getFeedSince(ctx)
+	if !ok {
+		return
+	}
+
+	page := &feedPage{Page: getFeedPage(ctx), PageSize: getFeedPageSize(ctx)}
+	actions, err := activities_model.GetFeeds(ctx, activities_model.GetFeedsOptions{
+		ListOptions:    db.ListOptions{Page: page.Page, PageSize: page.PageSize},
+		Instance:       true,
+		Actor:          nil,
+		IncludePrivate: false,
+		IncludeDeleted: false,
+		Date:           ctx.FormString("date"),
+		Since:          since,
+		OpTypes:        opTypes,
+	})
+	if err != nil {
+		ctx.ServerError("GetFeeds", err)
+		return
+	}
+
+	feed := &feeds.Feed{
+		Title:       ctx.Tr("home.feed_of", setting.AppName),
+		Link:        &feeds.Link{Href: setting.AppURL + "explore/repos"},
+		Description: ctx.Tr("explore.activity_feed_desc", setting.AppName),
+		Created:     time.Now(),
+	}
+
+	meta := &feedItemsMeta{InReplyTo: feedActionsInReplyTo(actions)}
+	feed.Items, err = feedActionsToFeedItems(ctx, actions, meta)
+	if err != nil {
+		ctx.ServerError("convert feed", err)
+		return
+	}
+
+	page.HasNext = len(actions) == page.PageSize
+	writePagedFeed(ctx, feed, formatType, page, meta)
+}
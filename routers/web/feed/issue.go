@@ -0,0 +1,317 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/gorilla/feeds"
+)
+
+// ShowIssueFeed shows the comments of an issue or a pull request as RSS / Atom feed
+func ShowIssueFeed(ctx *context.Context, issue *issues_model.Issue, formatType string) {
+	if err := issue.LoadPoster(); err != nil {
+		ctx.ServerError("LoadPoster", err)
+		return
+	}
+	since, ok := getFeedSince(ctx)
+	if !ok {
+		return
+	}
+	comments, err := issues_model.FindComments(ctx, &issues_model.FindCommentsOptions{IssueID: issue.ID})
+	if err != nil {
+		ctx.ServerError("FindComments", err)
+		return
+	}
+
+	// only the comments with a content since the requested time, oldest first
+	contentComments := make(issues_model.CommentList, 0, len(comments))
+	for _, comment := range comments {
+		if int64(comment.CreatedUnix) < since {
+			continue
+		}
+		if comment.Type == issues_model.CommentTypeComment || (comment.Type == issues_model.CommentTypeReview && comment.Content != "") {
+			comment.Issue = issue
+			contentComments = append(contentComments, comment)
+		}
+	}
+
+	// the items are the comments newest first followed by the opening post, the page holds the items from start to end
+	page := &feedPage{Page: getFeedPage(ctx), PageSize: getFeedPageSize(ctx)}
+	count := len(contentComments)
+	withOpening := int64(issue.CreatedUnix) >= since
+	total := count
+	if withOpening {
+		total++
+	}
+	start, end := (page.Page-1)*page.PageSize, page.Page*page.PageSize
+	page.HasNext = total > end
+	withOpening = withOpening && start <= count && count < end
+	contentComments = contentComments[util.Max(count-end, 0):util.Max(count-start, 0)]
+	if err := contentComments.LoadPosters(); err != nil {
+		ctx.ServerError("LoadPosters", err)
+		return
+	}
+
+	feed := &feeds.Feed{
+		Title:       fmt.Sprintf("%s#%d: %s", issue.Repo.FullName(), issue.Index, issue.Title),
+		Link:        &feeds.Link{Href: issue.HTMLURL()},
+		Description: issue.Title,
+		Created:     time.Now(),
+	}
+
+	feed.Items, err = issueCommentsToFeedItems(ctx, issue, contentComments, withOpening)
+	if err != nil {
+		ctx.ServerError("convert feed", err)
+		return
+	}
+
+	writePagedFeed(ctx, feed, formatType, page, nil)
+}
+
+// issueCommentsToFeedItems convert the comments of an issue, and its opening post if withOpening, to feeds Item,
+// newest first
+func issueCommentsToFeedItems(ctx *context.Context, issue *issues_model.Issue, comments issues_model.CommentList, withOpening bool) (items []*feeds.Item, err error) {
+	renderCtx := &markup.RenderContext{
+		Ctx:       ctx,
+		URLPrefix: issue.Repo.Link(),
+		Type:      markdown.MarkupName,
+		Metas:     issue.Repo.ComposeMetas(),
+	}
+	index := strconv.FormatInt(issue.Index, 10)
+
+	commentKey, openKey := "action.comment_issue", "action.create_issue"
+	if issue.IsPull {
+		commentKey, openKey = "action.comment_pull", "action.create_pull_request"
+	}
+
+	items = make([]*feeds.Item, 0, len(comments)+1)
+	for i := len(comments) - 1; i >= 0; i-- {
+		comment := comments[i]
+		content, err := markdown.RenderString(renderCtx, comment.Content)
+		if err != nil {
+			return nil, err
+		}
+		link := comment.HTMLURL()
+		items = append(items, &feeds.Item{
+			Title:       comment.Poster.DisplayName() + " " + ctx.TrHTMLEscapeArgs(commentKey, link, index, issue.Repo.FullName()),
+			Link:        &feeds.Link{Href: link},
+			Description: issue.Title,
+			Author: &feeds.Author{
+				Name:  comment.Poster.DisplayName(),
+				Email: comment.Poster.GetEmail(),
+			},
+			Id:      link,
+			Created: comment.CreatedUnix.AsTime(),
+			Updated: comment.UpdatedUnix.AsTime(),
+			Content: avatarHTML(toUserAvatarLink(comment.Poster), comment.Poster.DisplayName()) + content,
+		})
+	}
+
+	if !withOpening {
+		return items, nil
+	}
+
+	content, err := markdown.RenderString(renderCtx, issue.Content)
+	if err != nil {
+		return nil, err
+	}
+	link := issue.HTMLURL()
+	items = append(items, &feeds.Item{
+		Title:       issue.Poster.DisplayName() + " " + ctx.TrHTMLEscapeArgs(openKey, link, index, issue.Repo.FullName()),
+		Link:        &feeds.Link{Href: link},
+		Description: issue.Title,
+		Author: &feeds.Author{
+			Name:  issue.Poster.DisplayName(),
+			Email: issue.Poster.GetEmail(),
+		},
+		Id:      link,
+		Created: issue.CreatedUnix.AsTime(),
+		Content: avatarHTML(toUserAvatarLink(issue.Poster), issue.Poster.DisplayName()) + content,
+	})
+	return items, nil
+}
+
+// ShowRepoIssuesFeedRSS shows the issues of a repository as RSS feed
+func ShowRepoIssuesFeedRSS(ctx *context.Context) {
+	showRepoIssuesFeed(ctx, "rss")
+}
+
+// ShowRepoIssuesFeedAtom shows the issues of a repository as Atom feed
+func ShowRepoIssuesFeedAtom(ctx *context.Context) {
+	showRepoIssuesFeed(ctx, "atom")
+}
+
+// issueFeedEvent is an issue being opened, or being reopened by a comment
+type issueFeedEvent struct {
+	Issue   *issues_model.Issue
+	Reopen  *issues_model.Comment
+	Created timeutil.TimeStamp
+}
+
+// getFeedLabelNames returns the label names requested by the `labels` parameter of a feed, e.g. `?labels=bug,help-wanted`
+func getFeedLabelNames(ctx *context.Context) []string {
+	var labelNames []string
+	for _, value := range ctx.FormStrings("labels") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				labelNames = append(labelNames, name)
+			}
+		}
+	}
+	return labelNames
+}
+
+// showRepoIssuesFeed shows the issues of a repository being opened or reopened as RSS / Atom feed, only the issues
+// having at least one of the labels of the `labels` parameter if it is given. The items are categorized by the
+// labels of their issue.
+func showRepoIssuesFeed(ctx *context.Context, formatType string) {
+	repo := ctx.Repo.Repository
+	labelNames := getFeedLabelNames(ctx)
+
+	since, ok := getFeedSince(ctx)
+	if !ok {
+		return
+	}
+
+	// the events of the requested page are among the newest openings and reopenings up to this page, one more of each
+	// tells whether there is a next page
+	page := &feedPage{Page: getFeedPage(ctx), PageSize: getFeedPageSize(ctx)}
+	limit := page.Page*page.PageSize + 1
+	confidentialCond := issues_model.ConfidentialIssueCond(ctx.Doer)
+	issues, err := issues_model.Issues(&issues_model.IssuesOptions{
+		ConfidentialCond:   confidentialCond,
+		ListOptions:        db.ListOptions{Page: 1, PageSize: limit},
+		RepoID:             repo.ID,
+		IsPull:             util.OptionalBoolFalse,
+		IncludedLabelNames: labelNames,
+		SortType:           "newest",
+	})
+	if err != nil {
+		ctx.ServerError("Issues", err)
+		return
+	}
+	reopens, err := issues_model.FindReopenComments(ctx, repo.ID, false, labelNames, confidentialCond, limit)
+	if err != nil {
+		ctx.ServerError("FindReopenComments", err)
+		return
+	}
+
+	events := make([]*issueFeedEvent, 0, len(issues)+len(reopens))
+	for _, issue := range issues {
+		events = append(events, &issueFeedEvent{Issue: issue, Created: issue.CreatedUnix})
+	}
+	for _, comment := range reopens {
+		if comment.Issue != nil {
+			events = append(events, &issueFeedEvent{Issue: comment.Issue, Reopen: comment, Created: comment.CreatedUnix})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Created > events[j].Created
+	})
+	if since > 0 {
+		for i, event := range events {
+			if int64(event.Created) < since {
+				events = events[:i]
+				break
+			}
+		}
+	}
+	start, end := (page.Page-1)*page.PageSize, page.Page*page.PageSize
+	page.HasNext = len(events) > end
+	events = events[util.Min(start, len(events)):util.Min(end, len(events))]
+
+	title := ctx.Tr("repo.issues.feed_of", repo.FullName())
+	if len(labelNames) > 0 {
+		title = ctx.Tr("repo.issues.labels_feed_of", repo.FullName(), strings.Join(labelNames, ", "))
+	}
+	feed := &feeds.Feed{
+		Title:       title,
+		Link:        &feeds.Link{Href: repo.HTMLURL() + "/issues"},
+		Description: repo.Description,
+		Created:     time.Now(),
+	}
+
+	meta := &feedItemsMeta{InReplyTo: map[string]string{}, Categories: map[string][]string{}}
+	feed.Items, err = issueEventsToFeedItems(ctx, events, meta)
+	if err != nil {
+		ctx.ServerError("convert feed", err)
+		return
+	}
+
+	writePagedFeed(ctx, feed, formatType, page, meta)
+}
+
+// issueEventsToFeedItems convert the openings and reopenings of issues to feeds Item, the reopenings reply to their
+// issue, the items are categorized by the labels of their issue and their content starts with the avatar of their author
+func issueEventsToFeedItems(ctx *context.Context, events []*issueFeedEvent, meta *feedItemsMeta) (items []*feeds.Item, err error) {
+	items = make([]*feeds.Item, 0, len(events))
+	for _, event := range events {
+		issue := event.Issue
+		index := strconv.FormatInt(issue.Index, 10)
+
+		var item *feeds.Item
+		if event.Reopen != nil {
+			link := event.Reopen.HTMLURL()
+			item = &feeds.Item{
+				Title:       event.Reopen.Poster.DisplayName() + " " + ctx.TrHTMLEscapeArgs("action.reopen_issue", link, index, issue.Repo.FullName()),
+				Link:        &feeds.Link{Href: link},
+				Description: issue.Title,
+				Author: &feeds.Author{
+					Name:  event.Reopen.Poster.DisplayName(),
+					Email: event.Reopen.Poster.GetEmail(),
+				},
+				Id:      link,
+				Created: event.Reopen.CreatedUnix.AsTime(),
+				Content: avatarHTML(toUserAvatarLink(event.Reopen.Poster), event.Reopen.Poster.DisplayName()) + html.EscapeString(issue.Title),
+			}
+			meta.InReplyTo[link] = issue.HTMLURL()
+			meta.addAuthor(link, event.Reopen.Poster)
+		} else {
+			content, err := markdown.RenderString(&markup.RenderContext{
+				Ctx:       ctx,
+				URLPrefix: issue.Repo.Link(),
+				Type:      markdown.MarkupName,
+				Metas:     issue.Repo.ComposeMetas(),
+			}, issue.Content)
+			if err != nil {
+				return nil, err
+			}
+			link := issue.HTMLURL()
+			item = &feeds.Item{
+				Title:       issue.Poster.DisplayName() + " " + ctx.TrHTMLEscapeArgs("action.create_issue", link, index, issue.Repo.FullName()),
+				Link:        &feeds.Link{Href: link},
+				Description: issue.Title,
+				Author: &feeds.Author{
+					Name:  issue.Poster.DisplayName(),
+					Email: issue.Poster.GetEmail(),
+				},
+				Id:      link,
+				Created: issue.CreatedUnix.AsTime(),
+				Content: avatarHTML(toUserAvatarLink(issue.Poster), issue.Poster.DisplayName()) + content,
+			}
+			meta.addAuthor(link, issue.Poster)
+		}
+
+		for _, label := range issue.Labels {
+			meta.Categories[item.Id] = append(meta.Categories[item.Id], label.Name)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+)
+
+// opml is an OPML 2.0 document, see http://opml.org/spec2.opml
+type opml struct {
+	XMLName xml.Name      `xml:"opml"`
+	Version string        `xml:"version,attr"`
+	Head    opmlHead      `xml:"head"`
+	Outline []opmlOutline `xml:"body>outline"`
+}
+
+type opmlHead struct {
+	Title       string `xml:"title"`
+	DateCreated string `xml:"dateCreated"`
+	OwnerName   string `xml:"ownerName,omitempty"`
+}
+
+// opmlOutline is the outline of a feed subscription
+type opmlOutline struct {
+	Type        string `xml:"type,attr"`
+	Text        string `xml:"text,attr"`
+	Title       string `xml:"title,attr"`
+	XMLURL      string `xml:"xmlUrl,attr"`
+	HTMLURL     string `xml:"htmlUrl,attr"`
+	Description string `xml:"description,attr,omitempty"`
+}
+
+// ShowWatchingOPML exports the Atom feeds of the repositories the user watches as OPML outline, to import them into a
+// feed reader
+func ShowWatchingOPML(ctx *context.Context) {
+	repos, _, err := repo_model.GetWatchedRepos(ctx.Doer.ID, true, db.ListOptions{})
+	if err != nil {
+		ctx.ServerError("GetWatchedRepos", err)
+		return
+	}
+	sort.Slice(repos, func(i, j int) bool {
+		return strings.ToLower(repos[i].FullName()) < strings.ToLower(repos[j].FullName())
+	})
+
+	doc := &opml{
+		Version: "2.0",
+		Head: opmlHead{
+			Title:       ctx.Tr("home.subscriptions.opml_title", ctx.Doer.DisplayName()),
+			DateCreated: time.Now().Format(time.RFC1123Z),
+			OwnerName:   ctx.Doer.DisplayName(),
+		},
+		Outline: make([]opmlOutline, 0, len(repos)),
+	}
+	for _, repo := range repos {
+		// a user keeps watching the repositories they can't see anymore
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("GetUserRepoPermission", err)
+			return
+		}
+		if !perm.HasAccess() {
+			continue
+		}
+		doc.Outline = append(doc.Outline, opmlOutline{
+			Type:        "rss",
+			Text:        repo.FullName(),
+			Title:       repo.FullName(),
+			XMLURL:      repo.HTMLURL() + ".atom",
+			HTMLURL:     repo.HTMLURL(),
+			Description: repo.Description,
+		})
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "text/x-opml;charset=utf-8")
+	ctx.Resp.Header().Set("Content-Disposition", `attachment; filename="watching.opml"`)
+	ctx.Resp.WriteHeader(http.StatusOK)
+	if _, err := ctx.Resp.Write([]byte(xml.Header)); err != nil {
+		ctx.ServerError("Write", err)
+		return
+	}
+	encoder := xml.NewEncoder(ctx.Resp)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		ctx.ServerError("Render OPML failed", err)
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/gorilla/feeds"
+)
+
+// feedPage is the position of a document in a paged feed, see RFC 5005. The first page is the subscription document
+// with the newest entries, the following pages hold older and older entries.
+type feedPage struct {
+	Page     int
+	PageSize int
+	HasNext  bool
+}
+
+// getFeedPage returns the page requested by the `page` parameter of a feed
+func getFeedPage(ctx *context.Context) int {
+	if page := ctx.FormInt("page"); page > 1 {
+		return page
+	}
+	return 1
+}
+
+// getFeedPageSize returns the number of items requested by the `limit` parameter of a feed, FEED_PAGING_NUM by default
+// and at most FEED_MAX_PAGING_NUM
+func getFeedPageSize(ctx *context.Context) int {
+	limit := ctx.FormInt("limit")
+	if limit <= 0 {
+		return setting.UI.FeedPagingNum
+	}
+	if limit > setting.UI.FeedMaxPagingNum {
+		return setting.UI.FeedMaxPagingNum
+	}
+	return limit
+}
+
+// pageURL returns the absolute URL of a page of the requested feed, keeping the other parameters of the request
+func (p *feedPage) pageURL(ctx *context.Context, page int) string {
+	query := ctx.Req.URL.Query()
+	if page > 1 {
+		query.Set("page", strconv.Itoa(page))
+	} else {
+		query.Del("page")
+	}
+	link := setting.AppURL + strings.TrimPrefix(ctx.Req.URL.EscapedPath(), "/")
+	if len(query) > 0 {
+		link += "?" + query.Encode()
+	}
+	return link
+}
+
+// links returns the paging and archive links of a page, see sections 3 and 4 of RFC 5005
+func (p *feedPage) links(ctx *context.Context) []*feeds.AtomLink {
+	links := []*feeds.AtomLink{
+		{Href: p.pageURL(ctx, p.Page), Rel: "self"},
+		{Href: p.pageURL(ctx, 1), Rel: "first"},
+	}
+	if hub := setting.WebSubHubURL(); hub != "" && p.Page == 1 {
+		// the subscription document can be pushed by a WebSub hub, see section 4 of the WebSub specification
+		links = append(links, &feeds.AtomLink{Href: hub, Rel: "hub"})
+	}
+	if p.Page > 1 {
+		links = append(links,
+			&feeds.AtomLink{Href: p.pageURL(ctx, 1), Rel: "current"},
+			&feeds.AtomLink{Href: p.pageURL(ctx, p.Page-1), Rel: "previous"},
+		)
+	}
+	if p.Page > 2 {
+		links = append(links, &feeds.AtomLink{Href: p.pageURL(ctx, p.Page-1), Rel: "next-archive"})
+	}
+	if p.HasNext {
+		links = append(links,
+			&feeds.AtomLink{Href: p.pageURL(ctx, p.Page+1), Rel: "next"},
+			&feeds.AtomLink{Href: p.pageURL(ctx, p.Page+1), Rel: "prev-archive"},
+		)
+	}
+	return links
+}
+
+// atomInReplyTo is the thr:in-reply-to element of an Atom entry, see section 3 of RFC 4685
+type atomInReplyTo struct {
+	Ref  string `xml:"ref,attr"`
+	Href string `xml:"href,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// atomCategory is a category element of an Atom entry, see section 4.2.2 of RFC 4287
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// mediaThumbnail is a media:thumbnail element of an Atom entry or a RSS item, see the Media RSS specification
+type mediaThumbnail struct {
+	URL string `xml:"url,attr"`
+}
+
+// feedItemAuthor is the profile and the avatar of the author of an item
+type feedItemAuthor struct {
+	URI    string
+	Avatar string
+}
+
+// feedItemsMeta is the metadata of the items of a feed which feeds.Item can't hold, by the ids of the items
+type feedItemsMeta struct {
+	// InReplyTo maps the ids of the items to the links of the resources they reply to, e.g. a comment to its issue
+	InReplyTo map[string]string
+	// Categories maps the ids of the items to their categories, e.g. the labels of an issue
+	Categories map[string][]string
+	// Authors maps the ids of the items to the profile and avatar links of their authors
+	Authors map[string]*feedItemAuthor
+}
+
+// extendedAtomEntry is an Atom entry which can reply to the entry of another resource, have several categories and
+// the avatar of its author as thumbnail, its categories replace the single category of feeds.AtomEntry
+type extendedAtomEntry struct {
+	*feeds.AtomEntry
+	InReplyTo  *atomInReplyTo  `xml:"thr:in-reply-to"`
+	Categories []*atomCategory `xml:"category"`
+	Thumbnail  *mediaThumbnail `xml:"media:thumbnail"`
+}
+
+// pagedAtomFeed is an Atom feed with the paging links of RFC 5005, which replace the single link of feeds.AtomFeed,
+// and the extended entries, which replace the entries of feeds.AtomFeed
+type pagedAtomFeed struct {
+	ThreadNamespace string               `xml:"xmlns:thr,attr,omitempty"`
+	MediaNamespace  string               `xml:"xmlns:media,attr,omitempty"`
+	Links           []*feeds.AtomLink    `xml:"link"`
+	Entries         []*extendedAtomEntry `xml:"entry"`
+	*feeds.AtomFeed
+}
+
+// FeedXml implements feeds.XmlFeed
+func (f *pagedAtomFeed) FeedXml() interface{} { //nolint
+	return f
+}
+
+// rssAtomLink is an atom:link element of a RSS feed
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// pagedRssFeed is a RSS feed with the paging links of RFC 5005 as atom:link elements of its channel
+type pagedRssFeed struct {
+	XMLName          xml.Name `xml:"rss"`
+	Version          string   `xml:"version,attr"`
+	ContentNamespace string   `xml:"xmlns:content,attr"`
+	AtomNamespace    string   `xml:"xmlns:atom,attr"`
+	MediaNamespace   string   `xml:"xmlns:media,attr,omitempty"`
+	Channel          *pagedRssChannel
+}
+
+// extendedRssItem is a RSS item with several categories, which replace the single category of feeds.RssItem, and
+// the avatar of its author as thumbnail
+type extendedRssItem struct {
+	*feeds.RssItem
+	Categories []string        `xml:"category"`
+	Thumbnail  *mediaThumbnail `xml:"media:thumbnail"`
+}
+
+type pagedRssChannel struct {
+	Links []*rssAtomLink     `xml:"atom:link"`
+	Items []*extendedRssItem `xml:"item"`
+	*feeds.RssFeed
+}
+
+// FeedXml implements feeds.XmlFeed
+func (f *pagedRssFeed) FeedXml() interface{} { //nolint
+	return f
+}
+
+// mediaNamespace is the namespace of the Media RSS elements
+const mediaNamespace = "http://search.yahoo.com/mrss/"
+
+// toPagedXMLFeed converts a feed to an atom or rss document with the given paging links and the metadata of its items.
+// Only the atom entries reply to other resources and link to the profiles of their authors.
+func toPagedXMLFeed(feed *feeds.Feed, formatType string, links []*feeds.AtomLink, meta *feedItemsMeta) feeds.XmlFeed {
+	if meta == nil {
+		meta = &feedItemsMeta{}
+	}
+
+	if formatType == "atom" {
+		atomFeed := (&feeds.Atom{Feed: feed}).AtomFeed()
+		if atomFeed.Link != nil {
+			links = append([]*feeds.AtomLink{atomFeed.Link}, links...)
+		}
+		pagedFeed := &pagedAtomFeed{Links: links, Entries: make([]*extendedAtomEntry, 0, len(atomFeed.Entries)), AtomFeed: atomFeed}
+		for _, entry := range atomFeed.Entries {
+			extendedEntry := &extendedAtomEntry{AtomEntry: entry}
+			if ref, ok := meta.InReplyTo[entry.Id]; ok {
+				// smart readers group the entries replying to the same resource into a thread
+				extendedEntry.InReplyTo = &atomInReplyTo{Ref: ref, Href: ref, Type: "text/html"}
+				pagedFeed.ThreadNamespace = "http://purl.org/syndication/thread/1.0"
+			}
+			for _, category := range meta.Categories[entry.Id] {
+				extendedEntry.Categories = append(extendedEntry.Categories, &atomCategory{Term: category})
+			}
+			if author, ok := meta.Authors[entry.Id]; ok {
+				if entry.Author != nil {
+					entry.Author.Uri = author.URI
+				}
+				extendedEntry.Thumbnail = &mediaThumbnail{URL: author.Avatar}
+				pagedFeed.MediaNamespace = mediaNamespace
+			}
+			pagedFeed.Entries = append(pagedFeed.Entries, extendedEntry)
+		}
+		atomFeed.Entries = nil
+		return pagedFeed
+	}
+
+	rssLinks := make([]*rssAtomLink, 0, len(links))
+	for _, link := range links {
+		rssLinks = append(rssLinks, &rssAtomLink{Href: link.Href, Rel: link.Rel, Type: "application/rss+xml"})
+	}
+	rssFeed := (&feeds.Rss{Feed: feed}).RssFeed()
+	items := make([]*extendedRssItem, 0, len(rssFeed.Items))
+	var rssMediaNamespace string
+	for _, item := range rssFeed.Items {
+		extendedItem := &extendedRssItem{RssItem: item, Categories: meta.Categories[item.Guid]}
+		if author, ok := meta.Authors[item.Guid]; ok {
+			extendedItem.Thumbnail = &mediaThumbnail{URL: author.Avatar}
+			rssMediaNamespace = mediaNamespace
+		}
+		items = append(items, extendedItem)
+	}
+	rssFeed.Items = nil
+	return &pagedRssFeed{
+		Version:          "2.0",
+		ContentNamespace: "http://purl.org/rss/1.0/modules/content/",
+		AtomNamespace:    "http://www.w3.org/2005/Atom",
+		MediaNamespace:   rssMediaNamespace,
+		Channel:          &pagedRssChannel{Links: rssLinks, Items: items, RssFeed: rssFeed},
+	}
+}
+
+// writePagedFeed writes a page of a feed as atom or rss to ctx.Resp, with the links to its other pages and the
+// metadata of its items
+func writePagedFeed(ctx *context.Context, feed *feeds.Feed, formatType string, page *feedPage, meta *feedItemsMeta) {
+	if formatType == "atom" {
+		ctx.Resp.Header().Set("Content-Type", "application/atom+xml;charset=utf-8")
+	} else {
+		ctx.Resp.Header().Set("Content-Type", "application/rss+xml;charset=utf-8")
+	}
+	// the language is chosen by the `lang` parameter of the feed, so that the readers subscribe in their own language
+	ctx.Resp.Header().Set("Content-Language", ctx.Locale.Language())
+	ctx.Resp.WriteHeader(http.StatusOK)
+	if err := feeds.WriteXML(toPagedXMLFeed(feed, formatType, page.links(ctx), meta), ctx.Resp); err != nil {
+		ctx.ServerError("Render "+formatType+" failed", err)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/gorilla/feeds"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedPageLinks(t *testing.T) {
+	defer func(appURL string) { setting.AppURL = appURL }(setting.AppURL)
+	setting.AppURL = "https://try.gitea.io/"
+
+	rels := func(links []*feeds.AtomLink) map[string]string {
+		m := make(map[string]string, len(links))
+		for _, link := range links {
+			m[link.Rel] = link.Href
+		}
+		return m
+	}
+
+	ctx := test.MockContext(t, "user2.rss?types=release")
+	assert.Equal(t, map[string]string{
+		"self":         "https://try.gitea.io/user2.rss?types=release",
+		"first":        "https://try.gitea.io/user2.rss?types=release",
+		"next":         "https://try.gitea.io/user2.rss?page=2&types=release",
+		"prev-archive": "https://try.gitea.io/user2.rss?page=2&types=release",
+	}, rels((&feedPage{Page: 1, HasNext: true}).links(ctx)))
+
+	ctx = test.MockContext(t, "user2.rss?page=3")
+	assert.Equal(t, map[string]string{
+		"self":         "https://try.gitea.io/user2.rss?page=3",
+		"first":        "https://try.gitea.io/user2.rss",
+		"current":      "https://try.gitea.io/user2.rss",
+		"previous":     "https://try.gitea.io/user2.rss?page=2",
+		"next-archive": "https://try.gitea.io/user2.rss?page=2",
+	}, rels((&feedPage{Page: 3}).links(ctx)))
+
+	// only the subscription document advertises the WebSub hub
+	defer func(enabled bool) { setting.WebSub.Enabled = enabled }(setting.WebSub.Enabled)
+	setting.WebSub.Enabled = true
+	assert.Equal(t, "https://try.gitea.io/-/websub", rels((&feedPage{Page: 1}).links(ctx))["hub"])
+	assert.NotContains(t, rels((&feedPage{Page: 3}).links(ctx)), "hub")
+}
+
+func TestGetFeedPageSize(t *testing.T) {
+	defer func(pagingNum, maxPagingNum int) {
+		setting.UI.FeedPagingNum, setting.UI.FeedMaxPagingNum = pagingNum, maxPagingNum
+	}(setting.UI.FeedPagingNum, setting.UI.FeedMaxPagingNum)
+	setting.UI.FeedPagingNum = 20
+	setting.UI.FeedMaxPagingNum = 100
+
+	for limit, pageSize := range map[string]int{"": 20, "5": 5, "500": 100, "-1": 20} {
+		ctx := test.MockContext(t, "user2.rss")
+		ctx.Req.Form.Set("limit", limit)
+		assert.Equal(t, pageSize, getFeedPageSize(ctx), "limit=%s", limit)
+	}
+}
+
+func TestToPagedXMLFeed(t *testing.T) {
+	feed := &feeds.Feed{
+		Title:   "Feed of user2",
+		Link:    &feeds.Link{Href: "https://try.gitea.io/user2"},
+		Created: time.Unix(0, 0).UTC(),
+	}
+	links := []*feeds.AtomLink{{Href: "https://try.gitea.io/user2.rss?page=2", Rel: "next"}}
+
+	atom, err := feeds.ToXML(toPagedXMLFeed(feed, "atom", links, nil))
+	assert.NoError(t, err)
+	assert.Contains(t, atom, `<link href="https://try.gitea.io/user2"></link>`)
+	assert.Contains(t, atom, `<link href="https://try.gitea.io/user2.rss?page=2" rel="next"></link>`)
+	assert.NotContains(t, atom, `xmlns:thr`)
+
+	rss, err := feeds.ToXML(toPagedXMLFeed(feed, "rss", links, nil))
+	assert.NoError(t, err)
+	assert.Contains(t, rss, `xmlns:atom="http://www.w3.org/2005/Atom"`)
+	assert.Contains(t, rss, `<link>https://try.gitea.io/user2</link>`)
+	assert.Contains(t, rss, `<atom:link href="https://try.gitea.io/user2.rss?page=2" rel="next" type="application/rss+xml"></atom:link>`)
+}
+
+func TestToPagedXMLFeedInReplyTo(t *testing.T) {
+	feed := &feeds.Feed{
+		Title:   "Feed of user2",
+		Link:    &feeds.Link{Href: "https://try.gitea.io/user2"},
+		Created: time.Unix(0, 0).UTC(),
+		Items: []*feeds.Item{
+			{Title: "comment", Link: &feeds.Link{Href: "https://try.gitea.io/user2/repo1/issues/1#issuecomment-2"}, Id: "2"},
+			{Title: "issue", Link: &feeds.Link{Href: "https://try.gitea.io/user2/repo1/issues/1"}, Id: "1"},
+		},
+	}
+	meta := &feedItemsMeta{InReplyTo: map[string]string{"2": "https://try.gitea.io/user2/repo1/issues/1"}}
+
+	atom, err := feeds.ToXML(toPagedXMLFeed(feed, "atom", nil, meta))
+	assert.NoError(t, err)
+	assert.Contains(t, atom, `xmlns:thr="http://purl.org/syndication/thread/1.0"`)
+	assert.Equal(t, 2, strings.Count(atom, "<entry>"))
+	assert.Equal(t, 1, strings.Count(atom, "<thr:in-reply-to "))
+	assert.Contains(t, atom, `<id>2</id>`)
+	assert.Contains(t, atom, `<thr:in-reply-to ref="https://try.gitea.io/user2/repo1/issues/1" href="https://try.gitea.io/user2/repo1/issues/1" type="text/html"></thr:in-reply-to>`)
+
+	rss, err := feeds.ToXML(toPagedXMLFeed(feed, "rss", nil, meta))
+	assert.NoError(t, err)
+	assert.NotContains(t, rss, "in-reply-to")
+}
+
+func TestToPagedXMLFeedCategories(t *testing.T) {
+	feed := &feeds.Feed{
+		Title:   "Issues of user2/repo1",
+		Link:    &feeds.Link{Href: "https://try.gitea.io/user2/repo1/issues"},
+		Created: time.Unix(0, 0).UTC(),
+		Items: []*feeds.Item{
+			{Title: "issue 1", Link: &feeds.Link{Href: "https://try.gitea.io/user2/repo1/issues/1"}, Id: "1"},
+			{Title: "issue 2", Link: &feeds.Link{Href: "https://try.gitea.io/user2/repo1/issues/2"}, Id: "2"},
+		},
+	}
+	meta := &feedItemsMeta{Categories: map[string][]string{"1": {"bug", "help wanted"}}}
+
+	atom, err := feeds.ToXML(toPagedXMLFeed(feed, "atom", nil, meta))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(atom, "<entry>"))
+	assert.Equal(t, 2, strings.Count(atom, "<category "))
+	assert.Contains(t, atom, `<category term="bug"></category>`)
+	assert.Contains(t, atom, `<category term="help wanted"></category>`)
+
+	rss, err := feeds.ToXML(toPagedXMLFeed(feed, "rss", nil, meta))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(rss, "<item>"))
+	assert.Equal(t, 2, strings.Count(rss, "<category>"))
+	assert.Contains(t, rss, `<category>bug</category>`)
+	assert.Contains(t, rss, `<category>help wanted</category>`)
+}
+
+func TestToPagedXMLFeedAuthors(t *testing.T) {
+	feed := &feeds.Feed{
+		Title:   "Feed of user2",
+		Link:    &feeds.Link{Href: "https://try.gitea.io/user2"},
+		Created: time.Unix(0, 0).UTC(),
+		Items: []*feeds.Item{
+			{Title: "issue 1", Link: &feeds.Link{Href: "https://try.gitea.io/user2/repo1/issues/1"}, Id: "1", Author: &feeds.Author{Name: "user2"}},
+			{Title: "issue 2", Link: &feeds.Link{Href: "https://try.gitea.io/user2/repo1/issues/2"}, Id: "2", Author: &feeds.Author{Name: "ghost"}},
+		},
+	}
+	meta := &feedItemsMeta{Authors: map[string]*feedItemAuthor{
+		"1": {URI: "https://try.gitea.io/user2", Avatar: "https://try.gitea.io/avatars/ab53a2911ddf9b4817ac01ddcd3d975f"},
+	}}
+
+	atom, err := feeds.ToXML(toPagedXMLFeed(feed, "atom", nil, meta))
+	assert.NoError(t, err)
+	assert.Contains(t, atom, `xmlns:media="http://search.yahoo.com/mrss/"`)
+	assert.Contains(t, atom, `<uri>https://try.gitea.io/user2</uri>`)
+	assert.Equal(t, 1, strings.Count(atom, "<media:thumbnail "))
+	assert.Contains(t, atom, `<media:thumbnail url="https://try.gitea.io/avatars/ab53a2911ddf9b4817ac01ddcd3d975f"></media:thumbnail>`)
+
+	rss, err := feeds.ToXML(toPagedXMLFeed(feed, "rss", nil, meta))
+	assert.NoError(t, err)
+	assert.Contains(t, rss, `xmlns:media="http://search.yahoo.com/mrss/"`)
+	assert.Equal(t, 1, strings.Count(rss, "<media:thumbnail "))
+
+	// the namespace is only declared if an item has a thumbnail
+	rss, err = feeds.ToXML(toPagedXMLFeed(feed, "rss", nil, nil))
+	assert.NoError(t, err)
+	assert.NotContains(t, rss, "xmlns:media")
+}
@@ -9,6 +9,10 @@ import (
 	"time"
 
 	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/context"
 
 	"github.com/gorilla/feeds"
@@ -26,13 +30,37 @@ func ShowUserFeedAtom(ctx *context.Context) {
 
 // showUserFeed show user activity as RSS / Atom feed
 func showUserFeed(ctx *context.Context, formatType string) {
+	opTypes, ok := getFeedActionTypes(ctx)
+	if !ok {
+		return
+	}
+
+	var team *organization.Team
+	var repoIDs []int64
+	if ctx.ContextUser.IsOrganization() {
+		if team, repoIDs, ok = getOrgFeedFilters(ctx); !ok {
+			return
+		}
+	}
+
+	since, ok := getFeedSince(ctx)
+	if !ok {
+		return
+	}
+
+	page := &feedPage{Page: getFeedPage(ctx), PageSize: getFeedPageSize(ctx)}
 	actions, err := activities_model.GetFeeds(ctx, activities_model.GetFeedsOptions{
+		ListOptions:     db.ListOptions{Page: page.Page, PageSize: page.PageSize},
 		RequestedUser:   ctx.ContextUser,
+		RequestedTeam:   team,
 		Actor:           ctx.Doer,
 		IncludePrivate:  false,
 		OnlyPerformedBy: !ctx.ContextUser.IsOrganization(),
 		IncludeDeleted:  false,
 		Date:            ctx.FormString("date"),
+		Since:           since,
+		OpTypes:         opTypes,
+		RepoIDs:         repoIDs,
 	})
 	if err != nil {
 		ctx.ServerError("GetFeeds", err)
@@ -46,17 +74,160 @@ func showUserFeed(ctx *context.Context, formatType string) {
 		Created:     time.Now(),
 	}
 
-	feed.Items, err = feedActionsToFeedItems(ctx, actions)
+	meta := &feedItemsMeta{InReplyTo: feedActionsInReplyTo(actions)}
+	feed.Items, err = feedActionsToFeedItems(ctx, actions, meta)
+	if err != nil {
+		ctx.ServerError("convert feed", err)
+		return
+	}
+
+	page.HasNext = len(actions) == page.PageSize
+	writePagedFeed(ctx, feed, formatType, page, meta)
+}
+
+// ShowUserStarsFeedRSS show the repositories recently starred by a user as RSS feed
+func ShowUserStarsFeedRSS(ctx *context.Context) {
+	showUserStarsFeed(ctx, "rss")
+}
+
+// ShowUserStarsFeedAtom show the repositories recently starred by a user as Atom feed
+func ShowUserStarsFeedAtom(ctx *context.Context) {
+	showUserStarsFeed(ctx, "atom")
+}
+
+// showUserStarsFeed show the repositories recently starred by a user as RSS / Atom feed
+func showUserStarsFeed(ctx *context.Context, formatType string) {
+	if ctx.ContextUser.IsOrganization() {
+		ctx.NotFound("showUserStarsFeed", nil)
+		return
+	}
+
+	since, ok := getFeedSince(ctx)
+	if !ok {
+		return
+	}
+
+	page := &feedPage{Page: getFeedPage(ctx), PageSize: getFeedPageSize(ctx)}
+	actions, err := activities_model.GetFeeds(ctx, activities_model.GetFeedsOptions{
+		ListOptions:     db.ListOptions{Page: page.Page, PageSize: page.PageSize},
+		RequestedUser:   ctx.ContextUser,
+		Actor:           ctx.Doer,
+		IncludePrivate:  false,
+		OnlyPerformedBy: true,
+		IncludeDeleted:  false,
+		Since:           since,
+		OpTypes:         []activities_model.ActionType{activities_model.ActionStarRepo},
+	})
+	if err != nil {
+		ctx.ServerError("GetFeeds", err)
+		return
+	}
+
+	feed := &feeds.Feed{
+		Title:       ctx.Tr("home.stars_feed_of", ctx.ContextUser.DisplayName()),
+		Link:        &feeds.Link{Href: ctx.ContextUser.HTMLURL() + "?tab=stars"},
+		Description: ctx.ContextUser.Description,
+		Created:     time.Now(),
+	}
+
+	meta := &feedItemsMeta{}
+	feed.Items, err = feedActionsToFeedItems(ctx, actions, meta)
 	if err != nil {
 		ctx.ServerError("convert feed", err)
 		return
 	}
 
-	writeFeed(ctx, feed, formatType)
+	page.HasNext = len(actions) == page.PageSize
+	writePagedFeed(ctx, feed, formatType, page, meta)
+}
+
+// getFeedActionTypes returns the action types requested by the `types` parameter of a feed, e.g. `?types=release,tag`,
+// and responds with an error if one of them is unknown
+func getFeedActionTypes(ctx *context.Context) ([]activities_model.ActionType, bool) {
+	opTypes, err := activities_model.ParseActionTypeGroups(ctx.FormString("types"))
+	if err != nil {
+		if activities_model.IsErrUnknownActionTypeGroup(err) {
+			ctx.Error(http.StatusBadRequest, err.Error())
+		} else {
+			ctx.ServerError("ParseActionTypeGroups", err)
+		}
+		return nil, false
+	}
+	return opTypes, true
+}
+
+// getFeedSince returns the unix time requested by the `since` parameter of a feed, e.g. `?since=2022-10-01T00:00:00Z`,
+// and responds with an error if it isn't a RFC 3339 time
+func getFeedSince(ctx *context.Context) (int64, bool) {
+	_, since, err := context.GetQueryBeforeSince(ctx)
+	if err != nil {
+		ctx.Error(http.StatusBadRequest, err.Error())
+		return 0, false
+	}
+	return since, true
+}
+
+// getOrgFeedFilters returns the team and the repositories requested by the `team` and `repo` parameters of an
+// organization feed, e.g. `?team=backend&repo=api&repo=worker`, and responds with a 404 if one of them can't be seen
+func getOrgFeedFilters(ctx *context.Context) (*organization.Team, []int64, bool) {
+	var team *organization.Team
+	if teamName := ctx.FormTrim("team"); teamName != "" {
+		// the teams are only visible to the members of the organization
+		if ctx.Doer == nil {
+			ctx.NotFound("team", nil)
+			return nil, nil, false
+		}
+		if !ctx.Doer.IsAdmin {
+			isMember, err := organization.IsOrganizationMember(ctx, ctx.ContextUser.ID, ctx.Doer.ID)
+			if err != nil {
+				ctx.ServerError("IsOrganizationMember", err)
+				return nil, nil, false
+			}
+			if !isMember {
+				ctx.NotFound("team", nil)
+				return nil, nil, false
+			}
+		}
+		var err error
+		if team, err = organization.GetTeam(ctx, ctx.ContextUser.ID, teamName); err != nil {
+			if organization.IsErrTeamNotExist(err) {
+				ctx.NotFound("GetTeam", err)
+			} else {
+				ctx.ServerError("GetTeam", err)
+			}
+			return nil, nil, false
+		}
+	}
+
+	repoNames := ctx.FormStrings("repo")
+	repoIDs := make([]int64, 0, len(repoNames))
+	for _, name := range repoNames {
+		repo, err := repo_model.GetRepositoryByName(ctx.ContextUser.ID, name)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.NotFound("GetRepositoryByName", err)
+			} else {
+				ctx.ServerError("GetRepositoryByName", err)
+			}
+			return nil, nil, false
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("GetUserRepoPermission", err)
+			return nil, nil, false
+		}
+		if !perm.HasAccess() {
+			ctx.NotFound("GetRepositoryByName", nil)
+			return nil, nil, false
+		}
+		repoIDs = append(repoIDs, repo.ID)
+	}
+	return team, repoIDs, true
 }
 
 // writeFeed write a feeds.Feed as atom or rss to ctx.Resp
 func writeFeed(ctx *context.Context, feed *feeds.Feed, formatType string) {
+	ctx.Resp.Header().Set("Content-Language", ctx.Locale.Language())
 	ctx.Resp.WriteHeader(http.StatusOK)
 	if formatType == "atom" {
 		ctx.Resp.Header().Set("Content-Type", "application/atom+xml;charset=utf-8")
@@ -0,0 +1,67 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+
+	"github.com/gorilla/feeds"
+)
+
+// ShowReleasesFeedRSS shows the releases of a repository as RSS feed
+func ShowReleasesFeedRSS(ctx *context.Context) {
+	showReleasesFeed(ctx, "rss")
+}
+
+// ShowReleasesFeedAtom shows the releases of a repository as Atom feed
+func ShowReleasesFeedAtom(ctx *context.Context) {
+	showReleasesFeed(ctx, "atom")
+}
+
+// showReleasesFeed shows the published releases of a repository, with their full notes and assets, as RSS / Atom feed
+func showReleasesFeed(ctx *context.Context, formatType string) {
+	repo := ctx.Repo.Repository
+	since, ok := getFeedSince(ctx)
+	if !ok {
+		return
+	}
+
+	page := &feedPage{Page: getFeedPage(ctx), PageSize: getFeedPageSize(ctx)}
+	releases, err := repo_model.GetReleasesByRepoID(repo.ID, repo_model.FindReleasesOptions{
+		ListOptions: db.ListOptions{Page: page.Page, PageSize: page.PageSize},
+		Since:       since,
+	})
+	if err != nil {
+		ctx.ServerError("GetReleasesByRepoID", err)
+		return
+	}
+	for _, rel := range releases {
+		rel.Repo = repo
+		if err := rel.LoadAttributes(); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+	}
+
+	feed := &feeds.Feed{
+		Title:       ctx.Tr("repo.release.releases_feed_of", repo.FullName()),
+		Link:        &feeds.Link{Href: repo.HTMLURL() + "/releases"},
+		Description: repo.Description,
+		Created:     time.Now(),
+	}
+
+	feed.Items, err = releasesToFeedItems(ctx, releases)
+	if err != nil {
+		ctx.ServerError("convert feed", err)
+		return
+	}
+
+	page.HasNext = len(releases) == page.PageSize
+	writePagedFeed(ctx, feed, formatType, page, nil)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/context"
 
@@ -16,11 +17,25 @@ import (
 
 // ShowRepoFeed shows user activity on the repo as RSS / Atom feed
 func ShowRepoFeed(ctx *context.Context, repo *repo_model.Repository, formatType string) {
+	opTypes, ok := getFeedActionTypes(ctx)
+	if !ok {
+		return
+	}
+
+	since, ok := getFeedSince(ctx)
+	if !ok {
+		return
+	}
+
+	page := &feedPage{Page: getFeedPage(ctx), PageSize: getFeedPageSize(ctx)}
 	actions, err := activities_model.GetFeeds(ctx, activities_model.GetFeedsOptions{
+		ListOptions:    db.ListOptions{Page: page.Page, PageSize: page.PageSize},
 		RequestedRepo:  repo,
 		Actor:          ctx.Doer,
 		IncludePrivate: true,
 		Date:           ctx.FormString("date"),
+		Since:          since,
+		OpTypes:        opTypes,
 	})
 	if err != nil {
 		ctx.ServerError("GetFeeds", err)
@@ -34,11 +49,13 @@ func ShowRepoFeed(ctx *context.Context, repo *repo_model.Repository, formatType
 		Created:     time.Now(),
 	}
 
-	feed.Items, err = feedActionsToFeedItems(ctx, actions)
+	meta := &feedItemsMeta{InReplyTo: feedActionsInReplyTo(actions)}
+	feed.Items, err = feedActionsToFeedItems(ctx, actions, meta)
 	if err != nil {
 		ctx.ServerError("convert feed", err)
 		return
 	}
 
-	writeFeed(ctx, feed, formatType)
+	page.HasNext = len(actions) == page.PageSize
+	writePagedFeed(ctx, feed, formatType, page, meta)
 }
@@ -0,0 +1,196 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/context"
+	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/gorilla/feeds"
+)
+
+// ShowSavedSearchFeed shows the new matches of a search saved by the signed in user as RSS / Atom feed, the format
+// is chosen by the extension of the `id` parameter
+func ShowSavedSearchFeed(ctx *context.Context) {
+	isFeed, idStr, formatType := GetFeedType(ctx.Params(":id"), ctx.Req)
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if !isFeed || err != nil {
+		ctx.NotFound("ShowSavedSearchFeed", nil)
+		return
+	}
+
+	search, err := user_model.GetSavedSearch(ctx, ctx.Doer.ID, id)
+	if err != nil {
+		if user_model.IsErrSavedSearchNotExist(err) {
+			ctx.NotFound("GetSavedSearch", err)
+		} else {
+			ctx.ServerError("GetSavedSearch", err)
+		}
+		return
+	}
+
+	unitType := unit.TypeIssues
+	if search.Type == user_model.SavedSearchTypeCode {
+		if !setting.Indexer.RepoIndexerEnabled {
+			ctx.NotFound("ShowSavedSearchFeed", nil)
+			return
+		}
+		unitType = unit.TypeCode
+	}
+	repoIDs, ok := getSavedSearchRepoIDs(ctx, search, unitType)
+	if !ok {
+		return
+	}
+
+	// the matches are the ones which are new since the previous generation of the feed
+	generated := timeutil.TimeStampNow()
+	feed := &feeds.Feed{
+		Title:   ctx.Tr("settings.saved_searches.feed_of", search.Name),
+		Link:    &feeds.Link{Href: setting.AppURL + "user/settings/searches"},
+		Created: time.Now(),
+	}
+	meta := &feedItemsMeta{InReplyTo: map[string]string{}, Categories: map[string][]string{}}
+	if search.Type == user_model.SavedSearchTypeCode {
+		feed.Items, err = codeSearchToFeedItems(ctx, search, repoIDs)
+	} else {
+		feed.Items, err = issueSearchToFeedItems(ctx, search, repoIDs, meta)
+	}
+	if err != nil {
+		ctx.ServerError("convert feed", err)
+		return
+	}
+
+	if err := user_model.UpdateSavedSearchGenerated(ctx, search, generated); err != nil {
+		ctx.ServerError("UpdateSavedSearchGenerated", err)
+		return
+	}
+
+	writePagedFeed(ctx, feed, formatType, &feedPage{Page: 1}, meta)
+}
+
+// getSavedSearchRepoIDs returns the repositories a saved search searches, nil for an administrator searching all the
+// repositories. The feed is not found if the user can't read the unit of the repository of the search anymore.
+func getSavedSearchRepoIDs(ctx *context.Context, search *user_model.SavedSearch, unitType unit.Type) ([]int64, bool) {
+	if search.RepoID > 0 {
+		repo, err := repo_model.GetRepositoryByIDCtx(ctx, search.RepoID)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.NotFound("GetRepositoryByIDCtx", err)
+			} else {
+				ctx.ServerError("GetRepositoryByIDCtx", err)
+			}
+			return nil, false
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+		if err != nil {
+			ctx.ServerError("GetUserRepoPermission", err)
+			return nil, false
+		}
+		if !perm.CanRead(unitType) {
+			ctx.NotFound("ShowSavedSearchFeed", nil)
+			return nil, false
+		}
+		return []int64{repo.ID}, true
+	}
+
+	if ctx.Doer.IsAdmin {
+		return nil, true
+	}
+	repoIDs, err := repo_model.FindUserAccessibleRepoIDs(ctx.Doer, unitType)
+	if err != nil {
+		ctx.ServerError("FindUserAccessibleRepoIDs", err)
+		return nil, false
+	}
+	// an empty list would search all the repositories
+	if len(repoIDs) == 0 {
+		repoIDs = []int64{0}
+	}
+	return repoIDs, true
+}
+
+// issueSearchToFeedItems searches the issues of a saved search and converts the ones opened since the previous
+// generation of its feed to feeds Item
+func issueSearchToFeedItems(ctx *context.Context, search *user_model.SavedSearch, repoIDs []int64, meta *feedItemsMeta) ([]*feeds.Item, error) {
+	issueIDs, err := issue_indexer.SearchIssuesByKeyword(ctx, repoIDs, search.Keyword)
+	if err != nil {
+		return nil, fmt.Errorf("SearchIssuesByKeyword: %w", err)
+	}
+	if len(issueIDs) == 0 {
+		return []*feeds.Item{}, nil
+	}
+	issues, err := issues_model.Issues(&issues_model.IssuesOptions{
+		ConfidentialCond: issues_model.ConfidentialIssueCond(ctx.Doer),
+		IssueIDs:         issueIDs,
+		IsPull:           util.OptionalBoolFalse,
+		SortType:         "newest",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Issues: %w", err)
+	}
+
+	events := make([]*issueFeedEvent, 0, len(issues))
+	for _, issue := range issues {
+		if issue.CreatedUnix > search.LastGeneratedUnix {
+			events = append(events, &issueFeedEvent{Issue: issue, Created: issue.CreatedUnix})
+		}
+	}
+	return issueEventsToFeedItems(ctx, events, meta)
+}
+
+// codeSearchToFeedItems searches the code of a saved search and converts the matches in the files indexed since the
+// previous generation of its feed to feeds Item
+func codeSearchToFeedItems(ctx *context.Context, search *user_model.SavedSearch, repoIDs []int64) ([]*feeds.Item, error) {
+	_, results, _, err := code_indexer.PerformSearch(ctx, repoIDs, "", search.Keyword, 1, setting.UI.FeedMaxPagingNum, false)
+	if err != nil {
+		return nil, fmt.Errorf("PerformSearch: %w", err)
+	}
+
+	newResults := make([]*code_indexer.Result, 0, len(results))
+	loadRepoIDs := make([]int64, 0, len(results))
+	for _, result := range results {
+		if result.UpdatedUnix > search.LastGeneratedUnix {
+			newResults = append(newResults, result)
+			loadRepoIDs = append(loadRepoIDs, result.RepoID)
+		}
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(loadRepoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("GetRepositoriesMapByIDs: %w", err)
+	}
+
+	items := make([]*feeds.Item, 0, len(newResults))
+	for _, result := range newResults {
+		repo, ok := repos[result.RepoID]
+		if !ok {
+			continue
+		}
+		link := fmt.Sprintf("%s/src/commit/%s/%s", repo.HTMLURL(), result.CommitID, util.PathEscapeSegments(result.Filename))
+		if len(result.LineNumbers) > 0 {
+			link += "#L" + strconv.Itoa(result.LineNumbers[0])
+		}
+		items = append(items, &feeds.Item{
+			Title:       result.Filename + " - " + repo.FullName(),
+			Link:        &feeds.Link{Href: link},
+			Description: result.Filename,
+			Id:          link,
+			Created:     result.UpdatedUnix.AsTime(),
+			Content:     `<pre><code class="chroma">` + result.FormattedLines + `</code></pre>`,
+		})
+	}
+	return items, nil
+}
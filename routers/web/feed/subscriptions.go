@@ -0,0 +1,122 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/gorilla/feeds"
+)
+
+// ShowSubscriptionsFeedRSS show the releases of the repositories the user watches or stars as RSS feed
+func ShowSubscriptionsFeedRSS(ctx *context.Context) {
+	showSubscriptionsFeed(ctx, "rss")
+}
+
+// ShowSubscriptionsFeedAtom show the releases of the repositories the user watches or stars as Atom feed
+func ShowSubscriptionsFeedAtom(ctx *context.Context) {
+	showSubscriptionsFeed(ctx, "atom")
+}
+
+// showSubscriptionsFeed show the releases of the repositories the user watches or stars as RSS / Atom feed
+func showSubscriptionsFeed(ctx *context.Context, formatType string) {
+	if unit.TypeReleases.UnitGlobalDisabled() {
+		ctx.NotFound("showSubscriptionsFeed", nil)
+		return
+	}
+
+	releases, _, err := repo_model.FindSubscribedReleases(ctx, repo_model.FindSubscribedReleasesOptions{
+		ListOptions: db.ListOptions{PageSize: setting.UI.FeedPagingNum},
+		Doer:        ctx.Doer,
+		IncludeTags: ctx.FormBool("tags"),
+	})
+	if err != nil {
+		ctx.ServerError("FindSubscribedReleases", err)
+		return
+	}
+
+	feed := &feeds.Feed{
+		Title:   ctx.Tr("home.subscriptions.feed_title", ctx.Doer.DisplayName()),
+		Link:    &feeds.Link{Href: setting.AppURL + "subscriptions"},
+		Created: time.Now(),
+	}
+
+	feed.Items, err = releasesToFeedItems(ctx, releases)
+	if err != nil {
+		ctx.ServerError("convert feed", err)
+		return
+	}
+
+	writeFeed(ctx, feed, formatType)
+}
+
+// releasesToFeedItems convert the releases, with their attributes loaded, to feeds Item. The content is made of the
+// rendered release notes, the link to the tag and the list of the assets.
+func releasesToFeedItems(ctx *context.Context, releases []*repo_model.Release) (items []*feeds.Item, err error) {
+	for _, rel := range releases {
+		title := rel.Title
+		if rel.IsTag {
+			title = rel.TagName
+		}
+
+		var content strings.Builder
+		if rel.Note != "" {
+			note, err := markdown.RenderString(&markup.RenderContext{
+				Ctx:       ctx,
+				URLPrefix: rel.Repo.Link(),
+				Metas:     rel.Repo.ComposeMetas(),
+			}, rel.Note)
+			if err != nil {
+				return nil, err
+			}
+			content.WriteString(note)
+		}
+		tagLink := toRepoTagLink(rel.Repo, rel.TagName)
+		fmt.Fprintf(&content, `<p><a href="%s">%s</a></p>`, html.EscapeString(tagLink), html.EscapeString(rel.TagName))
+		if len(rel.Attachments) > 0 {
+			fmt.Fprintf(&content, "<p>%s</p><ul>", html.EscapeString(ctx.Tr("repo.release.downloads")))
+			for _, attachment := range rel.Attachments {
+				fmt.Fprintf(&content, `<li><a href="%s">%s</a></li>`, html.EscapeString(attachment.DownloadURL()), html.EscapeString(attachment.Name))
+			}
+			content.WriteString("</ul>")
+		}
+
+		author := &feeds.Author{Name: rel.OriginalAuthor}
+		if rel.OriginalAuthor == "" {
+			author = &feeds.Author{
+				Name:  rel.Publisher.DisplayName(),
+				Email: rel.Publisher.GetEmail(),
+			}
+		}
+
+		var enclosure *feeds.Enclosure
+		if len(rel.Attachments) > 0 {
+			enclosure = toAttachmentEnclosure(rel.Attachments[0])
+		}
+
+		items = append(items, &feeds.Item{
+			Title:       rel.Repo.FullName() + " " + title,
+			Link:        &feeds.Link{Href: rel.HTMLURL()},
+			Description: rel.TagName,
+			Author:      author,
+			Id:          rel.HTMLURL(),
+			Created:     rel.CreatedUnix.AsTime(),
+			Content:     content.String(),
+			Enclosure:   enclosure,
+		})
+	}
+	return items, err
+}
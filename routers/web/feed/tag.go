@@ -0,0 +1,93 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+
+	"github.com/gorilla/feeds"
+)
+
+// ShowTagsFeedRSS shows the tags of a repository as RSS feed
+func ShowTagsFeedRSS(ctx *context.Context) {
+	showTagsFeed(ctx, "rss")
+}
+
+// ShowTagsFeedAtom shows the tags of a repository as Atom feed
+func ShowTagsFeedAtom(ctx *context.Context) {
+	showTagsFeed(ctx, "atom")
+}
+
+// showTagsFeed shows the latest tags of a repository, including the ones of the published releases, as RSS / Atom feed
+func showTagsFeed(ctx *context.Context, formatType string) {
+	repo := ctx.Repo.Repository
+	since, ok := getFeedSince(ctx)
+	if !ok {
+		return
+	}
+
+	page := &feedPage{Page: getFeedPage(ctx), PageSize: getFeedPageSize(ctx)}
+	tags, err := repo_model.GetReleasesByRepoID(repo.ID, repo_model.FindReleasesOptions{
+		ListOptions: db.ListOptions{Page: page.Page, PageSize: page.PageSize},
+		Since:       since,
+		IncludeTags: true,
+	})
+	if err != nil {
+		ctx.ServerError("GetReleasesByRepoID", err)
+		return
+	}
+	for _, tag := range tags {
+		tag.Repo = repo
+		if err := tag.LoadAttributes(); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+	}
+
+	feed := &feeds.Feed{
+		Title:       ctx.Tr("repo.release.tags_feed_of", repo.FullName()),
+		Link:        &feeds.Link{Href: repo.HTMLURL() + "/tags"},
+		Description: repo.Description,
+		Created:     time.Now(),
+	}
+
+	for _, tag := range tags {
+		link := toRepoTagLink(repo, tag.TagName)
+		var content strings.Builder
+		commitLink := repo.HTMLURL() + "/commit/" + tag.Sha1
+		fmt.Fprintf(&content, `<p><a href="%s">%s</a></p>`, html.EscapeString(commitLink), html.EscapeString(tag.Sha1))
+		if !tag.IsTag {
+			fmt.Fprintf(&content, `<p><a href="%s">%s</a></p>`, html.EscapeString(tag.HTMLURL()), html.EscapeString(tag.Title))
+		}
+
+		author := &feeds.Author{Name: tag.OriginalAuthor}
+		if tag.OriginalAuthor == "" {
+			author = &feeds.Author{
+				Name:  tag.Publisher.DisplayName(),
+				Email: tag.Publisher.GetEmail(),
+			}
+		}
+
+		feed.Items = append(feed.Items, &feeds.Item{
+			Title:       repo.FullName() + " " + tag.TagName,
+			Link:        &feeds.Link{Href: link},
+			Description: tag.Sha1,
+			Author:      author,
+			Id:          link,
+			Created:     tag.CreatedUnix.AsTime(),
+			Content:     content.String(),
+		})
+	}
+
+	page.HasNext = len(tags) == page.PageSize
+	writePagedFeed(ctx, feed, formatType, page, nil)
+}
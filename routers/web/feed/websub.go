@@ -0,0 +1,35 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/context"
+	websub_service "code.gitea.io/gitea/services/websub"
+)
+
+// WebSubHub receives the subscription requests of the built-in WebSub hub, the intent of the subscriber is verified
+// asynchronously so the request is only accepted here, see section 5.1 of the WebSub specification
+func WebSubHub(ctx *context.Context) {
+	err := websub_service.HandleSubscriptionRequest(&websub_service.SubscriptionRequest{
+		Mode:         ctx.FormString("hub.mode"),
+		Topic:        ctx.FormString("hub.topic"),
+		Callback:     ctx.FormString("hub.callback"),
+		Secret:       ctx.FormString("hub.secret"),
+		LeaseSeconds: ctx.FormInt64("hub.lease_seconds"),
+	})
+	if err != nil {
+		if websub_service.IsErrInvalidSubscription(err) {
+			ctx.PlainText(http.StatusBadRequest, err.Error())
+		} else if websub_service.IsErrTooManyVerifications(err) {
+			ctx.PlainText(http.StatusTooManyRequests, err.Error())
+		} else {
+			ctx.ServerError("HandleSubscriptionRequest", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusAccepted)
+}
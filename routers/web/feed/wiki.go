@@ -0,0 +1,152 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package feed
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/git"
+	wiki_service "code.gitea.io/gitea/services/wiki"
+
+	"github.com/gorilla/feeds"
+)
+
+// ShowWikiFeedRSS shows the changes of the wiki of a repository as RSS feed
+func ShowWikiFeedRSS(ctx *context.Context) {
+	showWikiFeed(ctx, "rss")
+}
+
+// ShowWikiFeedAtom shows the changes of the wiki of a repository as Atom feed
+func ShowWikiFeedAtom(ctx *context.Context) {
+	showWikiFeed(ctx, "atom")
+}
+
+// showWikiFeed shows the latest commits of the wiki of a repository as RSS / Atom feed, one item per commit with a
+// summary of the pages it changed. The feed is empty until the wiki has a page.
+func showWikiFeed(ctx *context.Context, formatType string) {
+	repo := ctx.Repo.Repository
+	feed := &feeds.Feed{
+		Title:       ctx.Tr("repo.wiki.feed_of", repo.FullName()),
+		Link:        &feeds.Link{Href: repo.HTMLURL() + "/wiki"},
+		Description: repo.Description,
+		Created:     time.Now(),
+	}
+	page := &feedPage{Page: getFeedPage(ctx), PageSize: getFeedPageSize(ctx)}
+
+	if repo.HasWiki() {
+		wikiRepo, err := git.OpenRepository(ctx, repo.WikiPath())
+		if err != nil {
+			ctx.ServerError("OpenRepository", err)
+			return
+		}
+		defer wikiRepo.Close()
+
+		commit, err := wikiRepo.GetBranchCommit("master")
+		if err != nil && !git.IsErrNotExist(err) {
+			ctx.ServerError("GetBranchCommit", err)
+			return
+		}
+		if commit != nil {
+			commits, err := commit.CommitsByRange(page.Page, page.PageSize)
+			if err != nil {
+				ctx.ServerError("CommitsByRange", err)
+				return
+			}
+			page.HasNext = len(commits) == page.PageSize
+
+			feed.Items, err = wikiCommitsToFeedItems(ctx, repo, wikiRepo, commits)
+			if err != nil {
+				ctx.ServerError("convert feed", err)
+				return
+			}
+		}
+	}
+
+	writePagedFeed(ctx, feed, formatType, page, nil)
+}
+
+// wikiCommitsToFeedItems converts the commits of a wiki to feeds Item, their description summarizing the pages they
+// added, updated and deleted with the number of added and deleted lines
+func wikiCommitsToFeedItems(ctx *context.Context, repo *repo_model.Repository, wikiRepo *git.Repository, commits []*git.Commit) ([]*feeds.Item, error) {
+	items := make([]*feeds.Item, 0, len(commits))
+	for _, commit := range commits {
+		sha := commit.ID.String()
+		status, err := git.GetCommitFileStatus(ctx, wikiRepo.Path, sha)
+		if err != nil {
+			return nil, err
+		}
+		base := git.EmptyTreeSHA
+		if commit.ParentCount() > 0 {
+			parentID, err := commit.ParentID(0)
+			if err != nil {
+				return nil, err
+			}
+			base = parentID.String()
+		}
+		_, additions, deletions, err := git.GetDiffShortStat(ctx, wikiRepo.Path, base, sha)
+		if err != nil {
+			return nil, err
+		}
+
+		var summary []string
+		var content strings.Builder
+		content.WriteString("<ul>")
+		for _, change := range []struct {
+			Key       string
+			Files     []string
+			IsDeleted bool
+		}{
+			{"repo.wiki.feed.added", status.Added, false},
+			{"repo.wiki.feed.updated", status.Modified, false},
+			{"repo.wiki.feed.deleted", status.Removed, true},
+		} {
+			if len(change.Files) == 0 {
+				continue
+			}
+			names := make([]string, 0, len(change.Files))
+			links := make([]string, 0, len(change.Files))
+			for _, file := range change.Files {
+				name, err := wiki_service.FilenameToName(file)
+				isPage := err == nil
+				if !isPage {
+					// e.g. an image
+					name = file
+				}
+				names = append(names, name)
+				link := html.EscapeString(name)
+				if isPage && !change.IsDeleted {
+					link = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(repo.HTMLURL()+"/wiki/"+wiki_service.NameToSubURL(name)), link)
+				}
+				links = append(links, link)
+			}
+			summary = append(summary, ctx.Tr(change.Key, strings.Join(names, ", ")))
+			fmt.Fprintf(&content, "<li>%s</li>", ctx.Tr(change.Key, strings.Join(links, ", ")))
+		}
+		content.WriteString("</ul>")
+		stat := ctx.Tr("repo.wiki.feed.stat", additions, deletions)
+		summary = append(summary, stat)
+		fmt.Fprintf(&content, "<p>%s</p>", html.EscapeString(stat))
+
+		link := repo.HTMLURL() + "/wiki/commit/" + sha
+		items = append(items, &feeds.Item{
+			Title:       commit.Summary(),
+			Link:        &feeds.Link{Href: link},
+			Description: strings.Join(summary, "; "),
+			Author: &feeds.Author{
+				Name:  commit.Author.Name,
+				Email: commit.Author.Email,
+			},
+			Id:      link,
+			Created: commit.Committer.When,
+			Content: content.String(),
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"net/http"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/forms"
+	org_service "code.gitea.io/gitea/services/org"
+)
+
+const (
+	// tplSettingsBots template path for render bots settings
+	tplSettingsBots base.TplName = "org/settings/bots"
+	// tplSettingsBot template path for render the settings of a bot
+	tplSettingsBot base.TplName = "org/settings/bot"
+)
+
+// loadBotsData loads the bots of the organization
+func loadBotsData(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("org.settings.bots")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsBots"] = true
+
+	bots, err := organization.GetOrgBots(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetOrgBots", err)
+		return
+	}
+	ctx.Data["Bots"] = bots
+}
+
+// Bots render the bots of the organization
+func Bots(ctx *context.Context) {
+	loadBotsData(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplSettingsBots)
+}
+
+// BotsPost response for creating a bot
+func BotsPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.CreateOrgBotForm)
+	loadBotsData(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSettingsBots)
+		return
+	}
+
+	bot, err := org_service.CreateBot(ctx, ctx.Org.Organization, ctx.Doer, form.BotName, form.FullName)
+	if err != nil {
+		ctx.Data["Err_BotName"] = true
+		switch {
+		case user_model.IsErrUserAlreadyExist(err), user_model.IsErrEmailAlreadyUsed(err):
+			ctx.RenderWithErr(ctx.Tr("form.username_been_taken"), tplSettingsBots, form)
+		case db.IsErrNameReserved(err):
+			ctx.RenderWithErr(ctx.Tr("user.form.name_reserved", err.(db.ErrNameReserved).Name), tplSettingsBots, form)
+		case db.IsErrNamePatternNotAllowed(err):
+			ctx.RenderWithErr(ctx.Tr("user.form.name_pattern_not_allowed", err.(db.ErrNamePatternNotAllowed).Pattern), tplSettingsBots, form)
+		case db.IsErrNameCharsNotAllowed(err):
+			ctx.RenderWithErr(ctx.Tr("user.form.name_chars_not_allowed", err.(db.ErrNameCharsNotAllowed).Name), tplSettingsBots, form)
+		default:
+			ctx.ServerError("CreateBot", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("org.bots.add_success", bot.Name))
+	ctx.Redirect(ctx.Org.OrgLink + "/settings/bots/" + bot.Name)
+}
+
+// getBot returns the bot of the organization whose name is in the path, it writes a response on error
+func getBot(ctx *context.Context) *organization.OrgBot {
+	b, err := organization.GetOrgBotByName(ctx, ctx.Org.Organization.ID, ctx.Params(":botname"))
+	if err != nil {
+		if organization.IsErrOrgBotNotExist(err) {
+			ctx.NotFound("GetOrgBotByName", err)
+		} else {
+			ctx.ServerError("GetOrgBotByName", err)
+		}
+		return nil
+	}
+	return b
+}
+
+// Bot render the access tokens of a bot
+func Bot(ctx *context.Context) {
+	b := getBot(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = ctx.Tr("org.settings.bots")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsBots"] = true
+	ctx.Data["OrgBot"] = b
+
+	tokens, err := auth_model.ListAccessTokens(auth_model.ListAccessTokensOptions{UserID: b.BotID})
+	if err != nil {
+		ctx.ServerError("ListAccessTokens", err)
+		return
+	}
+	ctx.Data["Tokens"] = tokens
+
+	teams, err := organization.GetUserOrgTeams(ctx, ctx.Org.Organization.ID, b.BotID)
+	if err != nil {
+		ctx.ServerError("GetUserOrgTeams", err)
+		return
+	}
+	ctx.Data["Teams"] = teams
+
+	ctx.HTML(http.StatusOK, tplSettingsBot)
+}
+
+// BotTokenPost response for creating an access token of a bot
+func BotTokenPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.NewBotTokenForm)
+	b := getBot(ctx)
+	if ctx.Written() {
+		return
+	}
+	link := ctx.Org.OrgLink + "/settings/bots/" + b.Bot.Name
+
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(link)
+		return
+	}
+
+	scope := auth_model.AccessTokenScopeAll
+	if form.ReadOnly {
+		scope = auth_model.AccessTokenScopeRead
+	}
+	t, err := org_service.CreateBotToken(ctx, b, form.Name, scope)
+	if err != nil {
+		if auth_model.IsErrAccessTokenNameExist(err) {
+			ctx.Flash.Error(ctx.Tr("settings.generate_token_name_duplicate", form.Name))
+			ctx.Redirect(link)
+		} else {
+			ctx.ServerError("CreateBotToken", err)
+		}
+		return
+	}
+	log.Trace("Access token of bot %s/%s created by %s: %s", ctx.Org.Organization.Name, b.Bot.Name, ctx.Doer.Name, t.Name)
+
+	ctx.Flash.Success(ctx.Tr("settings.generate_token_success"))
+	ctx.Flash.Info(t.Token)
+	ctx.Redirect(link)
+}
+
+// DeleteBotToken response for deleting an access token of a bot
+func DeleteBotToken(ctx *context.Context) {
+	b := getBot(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := auth_model.DeleteAccessTokenByID(ctx.FormInt64("id"), b.BotID); err != nil {
+		ctx.Flash.Error("DeleteAccessTokenByID: " + err.Error())
+	} else {
+		ctx.Flash.Success(ctx.Tr("settings.delete_token_success"))
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"redirect": ctx.Org.OrgLink + "/settings/bots/" + b.Bot.Name,
+	})
+}
+
+// DeleteBot response for deleting a bot
+func DeleteBot(ctx *context.Context) {
+	b := getBot(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := org_service.DeleteBot(ctx, b); err != nil {
+		ctx.Flash.Error("DeleteBot: " + err.Error())
+	} else {
+		log.Trace("Bot deleted by %s: %s/%s", ctx.Doer.Name, ctx.Org.Organization.Name, b.Bot.Name)
+		ctx.Flash.Success(ctx.Tr("org.bots.delete_success"))
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"redirect": ctx.Org.OrgLink + "/settings/bots",
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"net/http"
+	"sort"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/forms"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// tplSettingsBranches template path for render the default branches settings
+const tplSettingsBranches base.TplName = "org/settings/branches"
+
+// defaultBranchCount is a name of default branch and the number of repositories of the organization having it
+type defaultBranchCount struct {
+	Name  string
+	Count int
+}
+
+// loadBranchesData loads the names of the default branches of the repositories of the organization
+func loadBranchesData(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("org.settings.branches")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsBranches"] = true
+
+	repos, err := organization.GetOrgRepositories(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetOrgRepositories", err)
+		return
+	}
+	counts := make(map[string]int)
+	for _, repo := range repos {
+		if !repo.IsEmpty {
+			counts[repo.DefaultBranch]++
+		}
+	}
+	defaultBranches := make([]*defaultBranchCount, 0, len(counts))
+	for name, count := range counts {
+		defaultBranches = append(defaultBranches, &defaultBranchCount{Name: name, Count: count})
+	}
+	sort.Slice(defaultBranches, func(i, j int) bool {
+		return defaultBranches[i].Name < defaultBranches[j].Name
+	})
+	ctx.Data["DefaultBranches"] = defaultBranches
+}
+
+// Branches render the form to rename the default branch of the repositories of the organization
+func Branches(ctx *context.Context) {
+	loadBranchesData(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplSettingsBranches)
+}
+
+// BranchesPost renames the default branch of the repositories of the organization and renders what it changed
+func BranchesPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.RenameOrgDefaultBranchesForm)
+	loadBranchesData(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSettingsBranches)
+		return
+	}
+
+	results, updatedWebhooks, err := repo_service.RenameOrgDefaultBranches(ctx, ctx.Doer, ctx.Org.Organization, form.OldName, form.NewName)
+	if err != nil {
+		ctx.ServerError("RenameOrgDefaultBranches", err)
+		return
+	}
+	// the default branches have changed
+	loadBranchesData(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Renamed"] = true
+	ctx.Data["Results"] = results
+	ctx.Data["UpdatedWebhooks"] = updatedWebhooks
+	ctx.HTML(http.StatusOK, tplSettingsBranches)
+}
@@ -0,0 +1,160 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/forms"
+	org_service "code.gitea.io/gitea/services/org"
+)
+
+// tplSettingsDomains template path for render domains settings
+const tplSettingsDomains base.TplName = "org/settings/domains"
+
+// loadDomainsData loads the domains of the organization and the teams their users can join
+func loadDomainsData(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("org.settings.domains")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsDomains"] = true
+
+	domains, err := organization.GetOrgDomains(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetOrgDomains", err)
+		return
+	}
+	ctx.Data["Domains"] = domains
+
+	teams, err := organization.FindOrgTeams(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("FindOrgTeams", err)
+		return
+	}
+	joinableTeams := make([]*organization.Team, 0, len(teams))
+	for _, t := range teams {
+		if !t.IsOwnerTeam() {
+			joinableTeams = append(joinableTeams, t)
+		}
+	}
+	ctx.Data["Teams"] = joinableTeams
+}
+
+// Domains render the domains of the organization
+func Domains(ctx *context.Context) {
+	loadDomainsData(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplSettingsDomains)
+}
+
+// DomainsPost response for claiming a domain
+func DomainsPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.AddOrgDomainForm)
+	loadDomainsData(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSettingsDomains)
+		return
+	}
+
+	if _, err := org_service.CreateDomain(ctx, ctx.Org.Organization, form.Domain, form.TeamID); err != nil {
+		switch {
+		case organization.IsErrOrgDomainInvalid(err):
+			ctx.Data["Err_Domain"] = true
+			ctx.RenderWithErr(ctx.Tr("org.domains.invalid"), tplSettingsDomains, form)
+		case organization.IsErrOrgDomainAlreadyExist(err):
+			ctx.Data["Err_Domain"] = true
+			ctx.RenderWithErr(ctx.Tr("org.domains.already_exist"), tplSettingsDomains, form)
+		case organization.IsErrTeamNotExist(err):
+			ctx.RenderWithErr(ctx.Tr("org.domains.team_invalid"), tplSettingsDomains, form)
+		default:
+			ctx.ServerError("CreateDomain", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("org.domains.add_success"))
+	ctx.Redirect(ctx.Org.OrgLink + "/settings/domains")
+}
+
+// getDomain returns the domain of the organization whose id is in the path, it writes a response on error
+func getDomain(ctx *context.Context) *organization.OrgDomain {
+	d, err := organization.GetOrgDomainByID(ctx, ctx.Org.Organization.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if organization.IsErrOrgDomainNotExist(err) {
+			ctx.NotFound("GetOrgDomainByID", err)
+		} else {
+			ctx.ServerError("GetOrgDomainByID", err)
+		}
+		return nil
+	}
+	return d
+}
+
+// DomainTeamPost response for changing the team joined by the users of a domain
+func DomainTeamPost(ctx *context.Context) {
+	d := getDomain(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := org_service.SetDomainTeam(ctx, d, ctx.FormInt64("team_id")); err != nil {
+		if organization.IsErrTeamNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("org.domains.team_invalid"))
+		} else {
+			ctx.ServerError("SetDomainTeam", err)
+			return
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("org.domains.update_success"))
+	}
+	ctx.Redirect(ctx.Org.OrgLink + "/settings/domains")
+}
+
+// VerifyDomainPost response for verifying the ownership of a domain
+func VerifyDomainPost(ctx *context.Context) {
+	d := getDomain(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := org_service.VerifyDomain(ctx, ctx.Org.Organization, d); err != nil {
+		if organization.IsErrOrgDomainNotVerified(err) {
+			ctx.Flash.Error(ctx.Tr("org.domains.verify_failed", d.Domain, err.(organization.ErrOrgDomainNotVerified).Reason))
+		} else {
+			ctx.ServerError("VerifyDomain", err)
+			return
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("org.domains.verify_success", d.Domain))
+	}
+	ctx.Redirect(ctx.Org.OrgLink + "/settings/domains")
+}
+
+// DeleteDomain response for removing a domain
+func DeleteDomain(ctx *context.Context) {
+	d := getDomain(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := organization.DeleteOrgDomain(ctx, d); err != nil {
+		ctx.Flash.Error("DeleteOrgDomain: " + err.Error())
+	} else {
+		ctx.Flash.Success(ctx.Tr("org.domains.delete_success"))
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"redirect": ctx.Org.OrgLink + "/settings/domains",
+	})
+}
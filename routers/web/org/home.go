@@ -16,6 +16,8 @@ import (
 	"code.gitea.io/gitea/modules/markup"
 	"code.gitea.io/gitea/modules/markup/markdown"
 	"code.gitea.io/gitea/modules/setting"
+	issue_service "code.gitea.io/gitea/services/issue"
+	user_service "code.gitea.io/gitea/services/user"
 )
 
 const (
@@ -129,7 +131,7 @@ func Home(ctx *context.Context) {
 			ctx.Error(http.StatusInternalServerError, "IsOrgMember")
 			return
 		}
-		opts.PublicOnly = !isMember && !ctx.Doer.IsAdmin
+		opts.PublicOnly = (!isMember || ctx.Org.IsGuest) && !ctx.Doer.IsAdmin
 	}
 
 	members, _, err := organization.FindOrgMembers(opts)
@@ -144,6 +146,27 @@ func Home(ctx *context.Context) {
 		return
 	}
 
+	if page == 1 && len(keyword) == 0 && len(language) == 0 {
+		ctx.Data["PinnedRepos"], err = user_service.GetVisiblePinnedRepositories(ctx, org.AsUser(), ctx.Doer)
+		if err != nil {
+			ctx.ServerError("GetVisiblePinnedRepositories", err)
+			return
+		}
+		pins, err := issue_service.GetVisibleOrgPinnedIssues(ctx, org.AsUser(), ctx.Doer)
+		if err != nil {
+			ctx.ServerError("GetVisibleOrgPinnedIssues", err)
+			return
+		}
+		ctx.Data["PinnedIssues"] = pins.Issues()
+	}
+	if !org.HideProfileProjects {
+		ctx.Data["OpenProjects"], err = user_service.GetVisibleOpenProjects(ctx, org.AsUser(), ctx.Doer)
+		if err != nil {
+			ctx.ServerError("GetVisibleOpenProjects", err)
+			return
+		}
+	}
+
 	ctx.Data["Owner"] = org
 	ctx.Data["Repos"] = repos
 	ctx.Data["Total"] = count
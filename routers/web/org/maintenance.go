@@ -0,0 +1,31 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+)
+
+// tplMaintenance template path for the maintenance reports page
+const tplMaintenance base.TplName = "org/maintenance"
+
+// Maintenance render the maintenance reports of the repositories of an organization
+func Maintenance(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("org.maintenance")
+	ctx.Data["PageIsOrgMaintenance"] = true
+
+	reports, err := repo_model.FindOrgMaintenanceReports(ctx, ctx.Org.Organization.ID, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("FindOrgMaintenanceReports", err)
+		return
+	}
+	ctx.Data["Reports"] = reports
+
+	ctx.HTML(http.StatusOK, tplMaintenance)
+}
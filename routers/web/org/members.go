@@ -43,7 +43,8 @@ func Members(ctx *context.Context) {
 			ctx.Error(http.StatusInternalServerError, "IsOrgMember")
 			return
 		}
-		opts.PublicOnly = !isMember && !ctx.Doer.IsAdmin
+		// guests only see the public members like non members
+		opts.PublicOnly = (!isMember || ctx.Org.IsGuest) && !ctx.Doer.IsAdmin
 	}
 	ctx.Data["PublicOnly"] = opts.PublicOnly
 
@@ -65,6 +66,7 @@ func Members(ctx *context.Context) {
 	ctx.Data["Members"] = members
 	ctx.Data["MembersIsPublicMember"] = membersIsPublic
 	ctx.Data["MembersIsUserOrgOwner"] = organization.IsUserOrgOwner(members, org.ID)
+	ctx.Data["MembersIsUserOrgGuest"] = organization.IsUserOrgGuest(members, org.ID)
 	ctx.Data["MembersTwoFaStatus"] = members.GetTwoFaStatus()
 
 	ctx.HTML(http.StatusOK, tplMembers)
@@ -93,6 +95,19 @@ func MembersAction(ctx *context.Context) {
 			return
 		}
 		err = organization.ChangeOrgUserStatus(org.ID, uid, true)
+	case "guest", "member":
+		if !ctx.Org.IsOwner {
+			ctx.Error(http.StatusNotFound)
+			return
+		}
+		err = organization.ChangeOrgUserGuest(org.ID, uid, ctx.Params(":action") == "guest")
+		if organization.IsErrOrgGuestOwner(err) {
+			ctx.Flash.Error(ctx.Tr("form.org_guest_owner"))
+			ctx.JSON(http.StatusOK, map[string]interface{}{
+				"redirect": ctx.Org.OrgLink + "/members",
+			})
+			return
+		}
 	case "remove":
 		if !ctx.Org.IsOwner {
 			ctx.Error(http.StatusNotFound)
@@ -0,0 +1,186 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models"
+	"code.gitea.io/gitea/models/organization"
+	unit_model "code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const (
+	// tplSettingsRoles template path for render roles settings
+	tplSettingsRoles base.TplName = "org/settings/roles"
+	// tplSettingsRoleNew template path for render role creation and edition
+	tplSettingsRoleNew base.TplName = "org/settings/role_new"
+)
+
+// Roles render the roles of the organization
+func Roles(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("org.settings.roles")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsRoles"] = true
+
+	roles, err := organization.GetOrgRoles(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetOrgRoles", err)
+		return
+	}
+	ctx.Data["Roles"] = roles
+
+	ctx.HTML(http.StatusOK, tplSettingsRoles)
+}
+
+func prepareRoleForm(ctx *context.Context, role *organization.Role) {
+	ctx.Data["Title"] = ctx.Tr("org.settings.roles")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsRoles"] = true
+	ctx.Data["Units"] = unit_model.Units
+	ctx.Data["Role"] = role
+}
+
+// NewRole render the page to create a role
+func NewRole(ctx *context.Context) {
+	prepareRoleForm(ctx, &organization.Role{OrgID: ctx.Org.Organization.ID, Units: []*organization.RoleUnit{}})
+	ctx.Data["PageIsOrgRolesNew"] = true
+	ctx.HTML(http.StatusOK, tplSettingsRoleNew)
+}
+
+// NewRolePost response for create new role
+func NewRolePost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.CreateRoleForm)
+	role := &organization.Role{
+		OrgID:       ctx.Org.Organization.ID,
+		Name:        form.RoleName,
+		Description: form.Description,
+		Units:       organization.NewRoleUnits(ctx.Org.Organization.ID, getUnitPerms(ctx.Req.Form)),
+	}
+	prepareRoleForm(ctx, role)
+	ctx.Data["PageIsOrgRolesNew"] = true
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSettingsRoleNew)
+		return
+	}
+
+	if len(role.Units) == 0 {
+		ctx.RenderWithErr(ctx.Tr("form.team_no_units_error"), tplSettingsRoleNew, &form)
+		return
+	}
+
+	if err := organization.NewRole(role); err != nil {
+		if organization.IsErrRoleAlreadyExist(err) {
+			ctx.Data["Err_RoleName"] = true
+			ctx.RenderWithErr(ctx.Tr("form.role_name_been_taken"), tplSettingsRoleNew, &form)
+		} else {
+			ctx.ServerError("NewRole", err)
+		}
+		return
+	}
+	log.Trace("Role created: %s/%s", ctx.Org.Organization.Name, role.Name)
+	ctx.Redirect(ctx.Org.OrgLink + "/settings/roles")
+}
+
+// getRole returns the role of the organization whose id is in the path, it writes a response on error
+func getRole(ctx *context.Context) *organization.Role {
+	role, err := organization.GetOrgRoleByID(ctx, ctx.Org.Organization.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if organization.IsErrRoleNotExist(err) {
+			ctx.NotFound("GetOrgRoleByID", err)
+		} else {
+			ctx.ServerError("GetOrgRoleByID", err)
+		}
+		return nil
+	}
+	if err := role.LoadUnits(ctx); err != nil {
+		ctx.ServerError("LoadUnits", err)
+		return nil
+	}
+	return role
+}
+
+// EditRole render the page to edit a role
+func EditRole(ctx *context.Context) {
+	role := getRole(ctx)
+	if ctx.Written() {
+		return
+	}
+	prepareRoleForm(ctx, role)
+	ctx.HTML(http.StatusOK, tplSettingsRoleNew)
+}
+
+// EditRolePost response for modify a role, which also updates the teams and collaborators having the role
+func EditRolePost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.CreateRoleForm)
+	role := getRole(ctx)
+	if ctx.Written() {
+		return
+	}
+	role.Name = form.RoleName
+	role.Description = form.Description
+	role.Units = organization.NewRoleUnits(role.OrgID, getUnitPerms(ctx.Req.Form))
+	prepareRoleForm(ctx, role)
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSettingsRoleNew)
+		return
+	}
+
+	if len(role.Units) == 0 {
+		ctx.RenderWithErr(ctx.Tr("form.team_no_units_error"), tplSettingsRoleNew, &form)
+		return
+	}
+
+	if err := models.UpdateRole(role); err != nil {
+		if organization.IsErrRoleAlreadyExist(err) {
+			ctx.Data["Err_RoleName"] = true
+			ctx.RenderWithErr(ctx.Tr("form.role_name_been_taken"), tplSettingsRoleNew, &form)
+		} else {
+			ctx.ServerError("UpdateRole", err)
+		}
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("org.roles.update_success"))
+	ctx.Redirect(ctx.Org.OrgLink + "/settings/roles")
+}
+
+// DeleteRole response for the delete role request
+func DeleteRole(ctx *context.Context) {
+	role := getRole(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := organization.DeleteRole(role); err != nil {
+		if organization.IsErrRoleInUse(err) {
+			ctx.Flash.Error(ctx.Tr("org.roles.delete_in_use"))
+		} else {
+			ctx.Flash.Error("DeleteRole: " + err.Error())
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("org.roles.delete_success"))
+	}
+
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"redirect": ctx.Org.OrgLink + "/settings/roles",
+	})
+}
+
+// loadOrgRoles loads the roles which can be assigned to the teams of the organization
+func loadOrgRoles(ctx *context.Context) {
+	roles, err := organization.GetOrgRoles(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetOrgRoles", err)
+		return
+	}
+	ctx.Data["Roles"] = roles
+}
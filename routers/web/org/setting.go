@@ -38,6 +38,12 @@ const (
 	tplSettingsHooks base.TplName = "org/settings/hooks"
 	// tplSettingsLabels template path for render labels settings
 	tplSettingsLabels base.TplName = "org/settings/labels"
+	// tplSettingsModeration template path for render moderation settings
+	tplSettingsModeration base.TplName = "org/settings/moderation"
+	// tplSettingsModerationReports template path for render the moderation queue
+	tplSettingsModerationReports base.TplName = "org/settings/moderation_reports"
+	// tplSettingsModerationLog template path for render the moderation audit trail
+	tplSettingsModerationLog base.TplName = "org/settings/moderation_log"
 )
 
 // Settings render the main settings page
@@ -47,6 +53,9 @@ func Settings(ctx *context.Context) {
 	ctx.Data["PageIsSettingsOptions"] = true
 	ctx.Data["CurrentVisibility"] = ctx.Org.Organization.Visibility
 	ctx.Data["RepoAdminChangeTeamAccess"] = ctx.Org.Organization.RepoAdminChangeTeamAccess
+	if !user_setting.PreparePinnedRepositories(ctx, ctx.Org.Organization.AsUser()) {
+		return
+	}
 	ctx.HTML(http.StatusOK, tplSettingsOptions)
 }
 
@@ -114,6 +123,8 @@ func SettingsPost(ctx *context.Context) {
 	org.Website = form.Website
 	org.Location = form.Location
 	org.RepoAdminChangeTeamAccess = form.RepoAdminChangeTeamAccess
+	org.HideProfilePackages = !form.ShowProfilePackages
+	org.HideProfileProjects = !form.ShowProfileProjects
 
 	visibilityChanged := form.Visibility != org.Visibility
 	org.Visibility = form.Visibility
@@ -122,6 +133,9 @@ func SettingsPost(ctx *context.Context) {
 		ctx.ServerError("UpdateUser", err)
 		return
 	}
+	if !user_setting.UpdatePinnedRepositories(ctx, org.AsUser(), form.PinnedRepositories, ctx.Org.OrgLink+"/settings") {
+		return
+	}
 
 	// update forks visibility
 	if visibilityChanged {
@@ -247,3 +261,51 @@ func Labels(ctx *context.Context) {
 	ctx.Data["LabelTemplates"] = repo_module.LabelTemplates
 	ctx.HTML(http.StatusOK, tplSettingsLabels)
 }
+
+// Moderation render the users blocked by the organization and the interaction limit of its repositories
+func Moderation(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.moderation")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsModeration"] = true
+	ctx.Data["ModerationLink"] = ctx.Org.OrgLink + "/settings/moderation"
+
+	if !user_setting.PrepareModeration(ctx, ctx.Org.Organization.AsUser()) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplSettingsModeration)
+}
+
+// ModerationPost response for blocking users and limiting interactions in the repositories of the organization
+func ModerationPost(ctx *context.Context) {
+	user_setting.HandleModerationPost(ctx, ctx.Org.Organization.AsUser(), ctx.Org.OrgLink+"/settings/moderation")
+}
+
+// ModerationReports render the reports about the content of the repositories of the organization
+func ModerationReports(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.moderation_reports")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsModerationReports"] = true
+	ctx.Data["ReportsLink"] = ctx.Org.OrgLink + "/settings/moderation/reports"
+
+	if !user_setting.PrepareModerationReports(ctx, ctx.Org.Organization.ID) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplSettingsModerationReports)
+}
+
+// ModerationReportPost response for taking an action on a report
+func ModerationReportPost(ctx *context.Context) {
+	user_setting.HandleModerationReportPost(ctx, ctx.Org.Organization.ID, ctx.Org.OrgLink+"/settings/moderation/reports")
+}
+
+// ModerationLog render the moderation audit trail of the repositories of the organization
+func ModerationLog(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.moderation_log")
+	ctx.Data["PageIsOrgSettings"] = true
+	ctx.Data["PageIsSettingsModerationLog"] = true
+
+	if !user_setting.PrepareModerationLog(ctx, ctx.Org.Organization.ID) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplSettingsModerationLog)
+}
@@ -14,6 +14,7 @@ import (
 
 	"code.gitea.io/gitea/models"
 	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
 	"code.gitea.io/gitea/models/perm"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -152,6 +153,10 @@ func TeamsAction(ctx *context.Context) {
 	if err != nil {
 		if organization.IsErrLastOrgOwner(err) {
 			ctx.Flash.Error(ctx.Tr("form.last_org_owner"))
+		} else if organization.IsErrOrgGuestOwner(err) {
+			ctx.Flash.Error(ctx.Tr("form.org_guest_owner"))
+		} else if organization.IsErrOrgBotForeign(err) {
+			ctx.Flash.Error(ctx.Tr("form.org_bot_foreign"))
 		} else {
 			log.Error("Action(%s): %v", ctx.Params(":action"), err)
 			ctx.JSON(http.StatusOK, map[string]interface{}{
@@ -219,6 +224,49 @@ func TeamsRepoAction(ctx *context.Context) {
 	ctx.Redirect(ctx.Org.OrgLink + "/teams/" + url.PathEscape(ctx.Org.Team.LowerName) + "/repositories")
 }
 
+// loadParentTeams loads the teams the team can be nested below: neither the owner team, the team itself nor its descendants
+func loadParentTeams(ctx *context.Context, t *organization.Team) {
+	teams, err := organization.FindOrgTeams(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("FindOrgTeams", err)
+		return
+	}
+	excluded := map[int64]bool{t.ID: true}
+	if t.ID > 0 {
+		descendantIDs, err := organization.GetTeamDescendantIDs(ctx, t.OrgID, t.ID)
+		if err != nil {
+			ctx.ServerError("GetTeamDescendantIDs", err)
+			return
+		}
+		for _, id := range descendantIDs {
+			excluded[id] = true
+		}
+	}
+	parents := make([]*organization.Team, 0, len(teams))
+	for _, team := range teams {
+		if !team.IsOwnerTeam() && !excluded[team.ID] {
+			parents = append(parents, team)
+		}
+	}
+	ctx.Data["ParentTeams"] = parents
+}
+
+// loadTeamHierarchy loads the parent and the children of the current team
+func loadTeamHierarchy(ctx *context.Context) {
+	parent, err := ctx.Org.Team.GetParent(ctx)
+	if err != nil {
+		ctx.ServerError("GetParent", err)
+		return
+	}
+	children, err := ctx.Org.Team.GetChildren(ctx)
+	if err != nil {
+		ctx.ServerError("GetChildren", err)
+		return
+	}
+	ctx.Data["ParentTeam"] = parent
+	ctx.Data["ChildTeams"] = children
+}
+
 // NewTeam render create new team page
 func NewTeam(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Org.Organization.FullName
@@ -226,6 +274,14 @@ func NewTeam(ctx *context.Context) {
 	ctx.Data["PageIsOrgTeamsNew"] = true
 	ctx.Data["Team"] = &organization.Team{}
 	ctx.Data["Units"] = unit_model.Units
+	loadParentTeams(ctx, &organization.Team{OrgID: ctx.Org.Organization.ID})
+	if ctx.Written() {
+		return
+	}
+	loadOrgRoles(ctx)
+	if ctx.Written() {
+		return
+	}
 	ctx.HTML(http.StatusOK, tplTeamNew)
 }
 
@@ -262,6 +318,9 @@ func NewTeamPost(ctx *context.Context) {
 		AccessMode:              p,
 		IncludesAllRepositories: includesAllRepositories,
 		CanCreateOrgRepo:        form.CanCreateOrgRepo,
+		AutoAssignReviewer:      form.AutoAssignReviewer,
+		ParentID:                form.ParentID,
+		RoleID:                  form.RoleID,
 	}
 
 	if t.AccessMode < perm.AccessModeAdmin {
@@ -281,13 +340,22 @@ func NewTeamPost(ctx *context.Context) {
 	ctx.Data["PageIsOrgTeamsNew"] = true
 	ctx.Data["Units"] = unit_model.Units
 	ctx.Data["Team"] = t
+	loadParentTeams(ctx, &organization.Team{OrgID: ctx.Org.Organization.ID})
+	if ctx.Written() {
+		return
+	}
+	loadOrgRoles(ctx)
+	if ctx.Written() {
+		return
+	}
 
 	if ctx.HasError() {
 		ctx.HTML(http.StatusOK, tplTeamNew)
 		return
 	}
 
-	if t.AccessMode < perm.AccessModeAdmin && len(unitPerms) == 0 {
+	// the units of a team having a role are the ones of the role
+	if t.AccessMode < perm.AccessModeAdmin && t.RoleID == 0 && len(unitPerms) == 0 {
 		ctx.RenderWithErr(ctx.Tr("form.team_no_units_error"), tplTeamNew, &form)
 		return
 	}
@@ -297,6 +365,14 @@ func NewTeamPost(ctx *context.Context) {
 		switch {
 		case organization.IsErrTeamAlreadyExist(err):
 			ctx.RenderWithErr(ctx.Tr("form.team_name_been_taken"), tplTeamNew, &form)
+		case organization.IsErrTeamParentInvalid(err):
+			ctx.Data["Err_TeamName"] = false
+			ctx.Data["Err_ParentID"] = true
+			ctx.RenderWithErr(ctx.Tr("form.team_parent_invalid"), tplTeamNew, &form)
+		case organization.IsErrRoleNotExist(err):
+			ctx.Data["Err_TeamName"] = false
+			ctx.Data["Err_RoleID"] = true
+			ctx.RenderWithErr(ctx.Tr("form.role_not_exist"), tplTeamNew, &form)
 		default:
 			ctx.ServerError("NewTeam", err)
 		}
@@ -315,7 +391,21 @@ func TeamMembers(ctx *context.Context) {
 		ctx.ServerError("GetMembers", err)
 		return
 	}
+	memberIDs := make([]int64, 0, len(ctx.Org.Team.Members))
+	for _, member := range ctx.Org.Team.Members {
+		memberIDs = append(memberIDs, member.ID)
+	}
+	openReviewRequests, err := issues_model.CountPendingReviewRequests(ctx, memberIDs)
+	if err != nil {
+		ctx.ServerError("CountPendingReviewRequests", err)
+		return
+	}
+	ctx.Data["OpenReviewRequests"] = openReviewRequests
 	ctx.Data["Units"] = unit_model.Units
+	loadTeamHierarchy(ctx)
+	if ctx.Written() {
+		return
+	}
 	ctx.HTML(http.StatusOK, tplTeamMembers)
 }
 
@@ -329,6 +419,10 @@ func TeamRepositories(ctx *context.Context) {
 		return
 	}
 	ctx.Data["Units"] = unit_model.Units
+	loadTeamHierarchy(ctx)
+	if ctx.Written() {
+		return
+	}
 	ctx.HTML(http.StatusOK, tplTeamRepositories)
 }
 
@@ -381,6 +475,14 @@ func EditTeam(ctx *context.Context) {
 	ctx.Data["team_name"] = ctx.Org.Team.Name
 	ctx.Data["desc"] = ctx.Org.Team.Description
 	ctx.Data["Units"] = unit_model.Units
+	loadParentTeams(ctx, ctx.Org.Team)
+	if ctx.Written() {
+		return
+	}
+	loadOrgRoles(ctx)
+	if ctx.Written() {
+		return
+	}
 	ctx.HTML(http.StatusOK, tplTeamNew)
 }
 
@@ -397,6 +499,14 @@ func EditTeamPost(ctx *context.Context) {
 	ctx.Data["PageIsOrgTeams"] = true
 	ctx.Data["Team"] = t
 	ctx.Data["Units"] = unit_model.Units
+	loadParentTeams(ctx, t)
+	if ctx.Written() {
+		return
+	}
+	loadOrgRoles(ctx)
+	if ctx.Written() {
+		return
+	}
 
 	if !t.IsOwnerTeam() {
 		// Validate permission level.
@@ -418,6 +528,11 @@ func EditTeamPost(ctx *context.Context) {
 			t.IncludesAllRepositories = includesAllRepositories
 		}
 		t.CanCreateOrgRepo = form.CanCreateOrgRepo
+		t.AutoAssignReviewer = form.AutoAssignReviewer
+		if t.RoleID != form.RoleID {
+			isAuthChanged = true
+			t.RoleID = form.RoleID
+		}
 	} else {
 		t.CanCreateOrgRepo = true
 	}
@@ -444,21 +559,39 @@ func EditTeamPost(ctx *context.Context) {
 		return
 	}
 
-	if t.AccessMode < perm.AccessModeAdmin && len(unitPerms) == 0 {
+	if t.AccessMode < perm.AccessModeAdmin && t.RoleID == 0 && len(unitPerms) == 0 {
 		ctx.RenderWithErr(ctx.Tr("form.team_no_units_error"), tplTeamNew, &form)
 		return
 	}
 
+	if err := organization.CheckTeamParent(ctx, t, form.ParentID); err != nil {
+		if organization.IsErrTeamParentInvalid(err) {
+			ctx.Data["Err_ParentID"] = true
+			ctx.RenderWithErr(ctx.Tr("form.team_parent_invalid"), tplTeamNew, &form)
+		} else {
+			ctx.ServerError("CheckTeamParent", err)
+		}
+		return
+	}
+
 	if err := models.UpdateTeam(t, isAuthChanged, isIncludeAllChanged); err != nil {
 		ctx.Data["Err_TeamName"] = true
 		switch {
 		case organization.IsErrTeamAlreadyExist(err):
 			ctx.RenderWithErr(ctx.Tr("form.team_name_been_taken"), tplTeamNew, &form)
+		case organization.IsErrRoleNotExist(err):
+			ctx.Data["Err_TeamName"] = false
+			ctx.Data["Err_RoleID"] = true
+			ctx.RenderWithErr(ctx.Tr("form.role_not_exist"), tplTeamNew, &form)
 		default:
 			ctx.ServerError("UpdateTeam", err)
 		}
 		return
 	}
+	if err := models.SetTeamParent(t, form.ParentID); err != nil {
+		ctx.ServerError("SetTeamParent", err)
+		return
+	}
 	ctx.Redirect(ctx.Org.OrgLink + "/teams/" + url.PathEscape(t.LowerName))
 }
 
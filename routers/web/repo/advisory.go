@@ -0,0 +1,330 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/modules/osv"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/web"
+	advisory_service "code.gitea.io/gitea/services/advisory"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const (
+	tplAdvisories   base.TplName = "repo/advisory/list"
+	tplAdvisoryNew  base.TplName = "repo/advisory/new"
+	tplAdvisoryView base.TplName = "repo/advisory/view"
+)
+
+// Advisories render the security advisories of a repository
+func Advisories(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.advisories")
+	ctx.Data["PageIsAdvisories"] = true
+
+	canManage := advisory_service.CanManage(ctx.Repo.Permission)
+	ctx.Data["CanManageAdvisories"] = canManage
+	ctx.Data["CanReportVulnerability"] = ctx.IsSigned && (canManage || ctx.Repo.Repository.EnablePrivateReporting)
+
+	state := advisory_model.State(ctx.FormTrim("state"))
+	ctx.Data["State"] = state
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	opts := &advisory_model.FindAdvisoriesOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.IssuePagingNum,
+		},
+		RepoID:             ctx.Repo.Repository.ID,
+		State:              state,
+		IncludeUnpublished: canManage,
+	}
+	if ctx.IsSigned {
+		opts.DoerID = ctx.Doer.ID
+	}
+	advisories, count, err := advisory_model.FindAdvisories(ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindAdvisories", err)
+		return
+	}
+	for _, adv := range advisories {
+		adv.Repo = ctx.Repo.Repository
+		if err := adv.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+	}
+	ctx.Data["Advisories"] = advisories
+
+	pager := context.NewPagination(int(count), setting.UI.IssuePagingNum, page, 5)
+	pager.AddParamString("state", string(state))
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplAdvisories)
+}
+
+// MustGetAdvisory loads the advisory of the request and checks that the doer may see it
+func MustGetAdvisory(ctx *context.Context) {
+	adv, err := advisory_model.GetAdvisoryByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, advisory_model.ErrAdvisoryNotExist) {
+			ctx.NotFound("GetAdvisoryByID", err)
+		} else {
+			ctx.ServerError("GetAdvisoryByID", err)
+		}
+		return
+	}
+	if !advisory_service.CanView(adv, ctx.Doer, ctx.Repo.Permission) {
+		ctx.NotFound("CanView", nil)
+		return
+	}
+	adv.Repo = ctx.Repo.Repository
+	if err := adv.LoadAttributes(ctx); err != nil {
+		ctx.ServerError("LoadAttributes", err)
+		return
+	}
+	ctx.Data["Advisory"] = adv
+	ctx.Data["CanManageAdvisories"] = advisory_service.CanManage(ctx.Repo.Permission)
+	ctx.Data["CanEditAdvisory"] = advisory_service.CanEdit(adv, ctx.Doer, ctx.Repo.Permission)
+}
+
+func getAdvisory(ctx *context.Context) *advisory_model.Advisory {
+	return ctx.Data["Advisory"].(*advisory_model.Advisory)
+}
+
+func mustManageAdvisories(ctx *context.Context) bool {
+	if !advisory_service.CanManage(ctx.Repo.Permission) {
+		ctx.NotFound("CanManage", nil)
+		return false
+	}
+	return true
+}
+
+// ViewAdvisory render a security advisory
+func ViewAdvisory(ctx *context.Context) {
+	adv := getAdvisory(ctx)
+	ctx.Data["Title"] = adv.Title
+	ctx.Data["PageIsAdvisories"] = true
+
+	var err error
+	ctx.Data["RenderedDescription"], err = markdown.RenderString(&markup.RenderContext{
+		Ctx:       ctx,
+		URLPrefix: ctx.Repo.RepoLink,
+		Metas:     ctx.Repo.Repository.ComposeMetas(),
+		GitRepo:   ctx.Repo.GitRepo,
+	}, adv.Description)
+	if err != nil {
+		ctx.ServerError("RenderString", err)
+		return
+	}
+	if setting.Advisories.OSVEnabled && adv.IsPublished() && !ctx.Repo.Repository.IsPrivate {
+		ctx.Data["OSVID"] = advisory_service.OSVID(adv)
+	}
+
+	ctx.HTML(http.StatusOK, tplAdvisoryView)
+}
+
+// NewAdvisory render the page to report a vulnerability or to create a draft advisory
+func NewAdvisory(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.advisories.new")
+	ctx.Data["PageIsAdvisories"] = true
+	ctx.Data["Severities"] = advisory_model.Severities
+
+	canManage := advisory_service.CanManage(ctx.Repo.Permission)
+	if !canManage && !ctx.Repo.Repository.EnablePrivateReporting {
+		ctx.NotFound("EnablePrivateReporting", nil)
+		return
+	}
+	ctx.Data["CanManageAdvisories"] = canManage
+	ctx.Data["severity"] = advisory_model.SeverityModerate
+
+	ctx.HTML(http.StatusOK, tplAdvisoryNew)
+}
+
+func applyAdvisoryForm(adv *advisory_model.Advisory, form *forms.AdvisoryForm) {
+	adv.Title = form.Title
+	adv.Description = form.Description
+	adv.Severity = advisory_model.Severity(form.Severity)
+	adv.CVEID = strings.TrimSpace(form.CVEID)
+	adv.CWEIDs = adv.CWEIDs[:0]
+	for _, cwe := range strings.Split(form.CWEIDs, ",") {
+		if cwe = strings.TrimSpace(cwe); cwe != "" {
+			adv.CWEIDs = append(adv.CWEIDs, cwe)
+		}
+	}
+	adv.Ecosystem = osv.NormalizeEcosystem(form.Ecosystem)
+	adv.PackageName = strings.TrimSpace(form.PackageName)
+	adv.VulnerableVersions = strings.TrimSpace(form.VulnerableVersions)
+	adv.PatchedVersions = strings.TrimSpace(form.PatchedVersions)
+}
+
+func renderAdvisoryError(ctx *context.Context, err error, tpl base.TplName, form *forms.AdvisoryForm) {
+	switch {
+	case errors.Is(err, advisory_model.ErrInvalidCVEID):
+		ctx.Data["Err_CVEID"] = true
+		ctx.RenderWithErr(ctx.Tr("repo.advisories.invalid_cve_id"), tpl, form)
+	case errors.Is(err, advisory_model.ErrInvalidCWEID):
+		ctx.Data["Err_CWEIDs"] = true
+		ctx.RenderWithErr(ctx.Tr("repo.advisories.invalid_cwe_id"), tpl, form)
+	default:
+		ctx.ServerError("Advisory", err)
+	}
+}
+
+// NewAdvisoryPost reports a vulnerability or creates a draft advisory
+func NewAdvisoryPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.AdvisoryForm)
+	ctx.Data["Title"] = ctx.Tr("repo.advisories.new")
+	ctx.Data["PageIsAdvisories"] = true
+	ctx.Data["Severities"] = advisory_model.Severities
+
+	canManage := advisory_service.CanManage(ctx.Repo.Permission)
+	if !canManage && !ctx.Repo.Repository.EnablePrivateReporting {
+		ctx.NotFound("EnablePrivateReporting", nil)
+		return
+	}
+	ctx.Data["CanManageAdvisories"] = canManage
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplAdvisoryNew)
+		return
+	}
+
+	adv := &advisory_model.Advisory{}
+	applyAdvisoryForm(adv, form)
+
+	var err error
+	if canManage {
+		err = advisory_service.CreateDraft(ctx, ctx.Repo.Repository, ctx.Doer, adv)
+	} else {
+		err = advisory_service.ReportVulnerability(ctx, ctx.Repo.Repository, ctx.Doer, adv)
+	}
+	if err != nil {
+		renderAdvisoryError(ctx, err, tplAdvisoryNew, form)
+		return
+	}
+
+	ctx.Redirect(adv.Link())
+}
+
+// EditAdvisory render the page to edit a security advisory
+func EditAdvisory(ctx *context.Context) {
+	adv := getAdvisory(ctx)
+	if !advisory_service.CanEdit(adv, ctx.Doer, ctx.Repo.Permission) {
+		ctx.NotFound("CanEdit", nil)
+		return
+	}
+	ctx.Data["Title"] = ctx.Tr("repo.advisories.edit")
+	ctx.Data["PageIsAdvisories"] = true
+	ctx.Data["PageIsEditAdvisory"] = true
+	ctx.Data["Severities"] = advisory_model.Severities
+
+	ctx.Data["title"] = adv.Title
+	ctx.Data["description"] = adv.Description
+	ctx.Data["severity"] = adv.Severity
+	ctx.Data["cve_id"] = adv.CVEID
+	ctx.Data["cwe_ids"] = strings.Join(adv.CWEIDs, ", ")
+	ctx.Data["ecosystem"] = adv.Ecosystem
+	ctx.Data["package_name"] = adv.PackageName
+	ctx.Data["vulnerable_versions"] = adv.VulnerableVersions
+	ctx.Data["patched_versions"] = adv.PatchedVersions
+
+	ctx.HTML(http.StatusOK, tplAdvisoryNew)
+}
+
+// EditAdvisoryPost updates a security advisory
+func EditAdvisoryPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.AdvisoryForm)
+	adv := getAdvisory(ctx)
+	if !advisory_service.CanEdit(adv, ctx.Doer, ctx.Repo.Permission) {
+		ctx.NotFound("CanEdit", nil)
+		return
+	}
+	ctx.Data["Title"] = ctx.Tr("repo.advisories.edit")
+	ctx.Data["PageIsAdvisories"] = true
+	ctx.Data["PageIsEditAdvisory"] = true
+	ctx.Data["Severities"] = advisory_model.Severities
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplAdvisoryNew)
+		return
+	}
+
+	applyAdvisoryForm(adv, form)
+	if err := advisory_model.UpdateAdvisory(ctx, adv, "title", "description", "severity", "cve_id", "cwe_ids",
+		"ecosystem", "package_name", "vulnerable_versions", "patched_versions"); err != nil {
+		renderAdvisoryError(ctx, err, tplAdvisoryNew, form)
+		return
+	}
+
+	ctx.Redirect(adv.Link())
+}
+
+func handleAdvisoryTransition(ctx *context.Context, adv *advisory_model.Advisory, err error, success string) {
+	if err != nil {
+		switch {
+		case errors.Is(err, advisory_service.ErrInvalidTransition):
+			ctx.Flash.Error(ctx.Tr("repo.advisories.invalid_transition"))
+		case errors.Is(err, advisory_service.ErrForkExists):
+			ctx.Flash.Error(ctx.Tr("repo.advisories.fork_exists"))
+		default:
+			ctx.ServerError("Advisory", err)
+			return
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr(success))
+	}
+	ctx.Redirect(adv.Link())
+}
+
+// AcceptAdvisory turns a vulnerability report into a draft advisory
+func AcceptAdvisory(ctx *context.Context) {
+	if !mustManageAdvisories(ctx) {
+		return
+	}
+	adv := getAdvisory(ctx)
+	handleAdvisoryTransition(ctx, adv, advisory_service.AcceptReport(ctx, adv), "repo.advisories.accept_success")
+}
+
+// PublishAdvisory publishes a draft advisory
+func PublishAdvisory(ctx *context.Context) {
+	if !mustManageAdvisories(ctx) {
+		return
+	}
+	adv := getAdvisory(ctx)
+	handleAdvisoryTransition(ctx, adv, advisory_service.Publish(ctx, ctx.Doer, adv), "repo.advisories.publish_success")
+}
+
+// CloseAdvisory closes a vulnerability report or a draft advisory
+func CloseAdvisory(ctx *context.Context) {
+	if !mustManageAdvisories(ctx) {
+		return
+	}
+	adv := getAdvisory(ctx)
+	handleAdvisoryTransition(ctx, adv, advisory_service.Close(ctx, ctx.Doer, adv), "repo.advisories.close_success")
+}
+
+// CreateAdvisoryFork creates the temporary private fork of an advisory
+func CreateAdvisoryFork(ctx *context.Context) {
+	if !mustManageAdvisories(ctx) {
+		return
+	}
+	adv := getAdvisory(ctx)
+	_, err := advisory_service.CreateFork(ctx, ctx.Doer, adv)
+	handleAdvisoryTransition(ctx, adv, err, "repo.advisories.fork_success")
+}
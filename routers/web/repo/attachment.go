@@ -51,6 +51,10 @@ func uploadAttachment(ctx *context.Context, repoID int64, allowedTypes string) {
 			ctx.Error(http.StatusBadRequest, err.Error())
 			return
 		}
+		if attachment.IsErrRepoAttachmentsSizeExceeded(err) {
+			ctx.Error(http.StatusRequestEntityTooLarge, ctx.Tr("repo.attachments_size_exceeded", setting.Attachment.RepoMaxSize))
+			return
+		}
 		ctx.Error(http.StatusInternalServerError, fmt.Sprintf("NewAttachment: %v", err))
 		return
 	}
@@ -145,8 +149,8 @@ func GetAttachment(ctx *context.Context) {
 	}
 	defer fr.Close()
 
-	if err = common.ServeData(ctx, attach.Name, attach.Size, fr); err != nil {
-		ctx.ServerError("ServeData", err)
+	if err = common.ServeDownload(ctx, repository, attach.Name, attach.Size, fr); err != nil {
+		ctx.ServerError("ServeDownload", err)
 		return
 	}
 }
@@ -7,6 +7,7 @@ package repo
 import (
 	"bytes"
 	"errors"
+	"strconv"
 	"strings"
 
 	"code.gitea.io/gitea/models"
@@ -124,6 +125,7 @@ func CherryPickPost(ctx *context.Context) {
 
 	// First lets try the simple plain read-tree -m approach
 	opts.Content = sha
+	var conflictErr error
 	if _, err := files.CherryPick(ctx, ctx.Repo.Repository, ctx.Doer, form.Revert, opts); err != nil {
 		if models.IsErrBranchAlreadyExists(err) {
 			// User has specified a branch that already exists
@@ -135,6 +137,9 @@ func CherryPickPost(ctx *context.Context) {
 			ctx.RenderWithErr(ctx.Tr("repo.editor.file_changed_while_editing", ctx.Repo.RepoLink+"/compare/"+form.LastCommit+"..."+ctx.Repo.CommitID), tplPatchFile, &form)
 			return
 		}
+		if models.IsErrCherryPickConflict(err) {
+			conflictErr = err
+		}
 		// Drop through to the apply technique
 
 		buf := &bytes.Buffer{}
@@ -171,6 +176,9 @@ func CherryPickPost(ctx *context.Context) {
 			} else if models.IsErrCommitIDDoesNotMatch(err) {
 				ctx.RenderWithErr(ctx.Tr("repo.editor.file_changed_while_editing", ctx.Repo.RepoLink+"/compare/"+form.LastCommit+"..."+ctx.Repo.CommitID), tplPatchFile, &form)
 				return
+			} else if conflictErr != nil {
+				ctx.RenderWithErr(ctx.Tr("repo.editor.cherry_pick_conflict", strings.Join(conflictErr.(models.ErrCherryPickConflict).ConflictedFiles, ", ")), tplCherryPick, &form)
+				return
 			} else {
 				ctx.RenderWithErr(ctx.Tr("repo.editor.fail_to_apply_patch", err), tplPatchFile, &form)
 				return
@@ -179,6 +187,16 @@ func CherryPickPost(ctx *context.Context) {
 	}
 
 	if form.CommitChoice == frmCommitChoiceNewBranch && ctx.Repo.Repository.UnitEnabled(unit.TypePullRequests) {
+		if !canCommit {
+			// the branch is protected, propose the commit in a pull request right away
+			pr, err := files.NewCherryPickPullRequest(ctx, ctx.Repo.Repository, ctx.Doer, ctx.Repo.BranchName, branchName, opts.LastCommitID, message)
+			if err != nil {
+				ctx.ServerError("NewCherryPickPullRequest", err)
+				return
+			}
+			ctx.Redirect(ctx.Repo.RepoLink + "/pulls/" + strconv.FormatInt(pr.Index, 10))
+			return
+		}
 		ctx.Redirect(ctx.Repo.RepoLink + "/compare/" + util.PathEscapeSegments(ctx.Repo.BranchName) + "..." + util.PathEscapeSegments(form.NewBranchName))
 	} else {
 		ctx.Redirect(ctx.Repo.RepoLink + "/src/branch/" + util.PathEscapeSegments(branchName))
@@ -23,6 +23,8 @@ import (
 	"code.gitea.io/gitea/modules/gitgraph"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/web/feed"
 	"code.gitea.io/gitea/services/gitdiff"
 )
 
@@ -45,6 +47,20 @@ func RefCommits(ctx *context.Context) {
 	}
 }
 
+// CommitsFeedType strips the ".rss" or ".atom" extension of the branch of "/commits/{branch}.atom" so that the commits
+// of the branch are shown as a feed. The paths of the files whose history is requested are left untouched.
+func CommitsFeedType(ctx *context.Context) {
+	path := ctx.Params("*")
+	for _, feedType := range []string{"rss", "atom"} {
+		branch := strings.TrimSuffix(path, "."+feedType)
+		if branch != path && ctx.Repo.GitRepo.IsBranchExist(branch) {
+			ctx.SetParams("*", branch)
+			ctx.Data["CommitsFeedType"] = feedType
+			return
+		}
+	}
+}
+
 // Commits render branch's commits
 func Commits(ctx *context.Context) {
 	ctx.Data["PageIsCommits"] = true
@@ -52,6 +68,13 @@ func Commits(ctx *context.Context) {
 		ctx.NotFound("Commit not found", nil)
 		return
 	}
+	if feedType, ok := ctx.Data["CommitsFeedType"].(string); ok {
+		feed.ShowBranchFeed(ctx, ctx.Repo.Repository, feedType)
+		return
+	}
+	if ctx.Repo.IsViewBranch {
+		ctx.Data["FeedURL"] = ctx.Repo.Repository.HTMLURL() + "/commits/branch/" + util.PathEscapeSegments(ctx.Repo.BranchName)
+	}
 	ctx.Data["PageIsViewCode"] = true
 
 	commitsCount, err := ctx.Repo.GetCommitsCount()
@@ -344,6 +367,10 @@ func Diff(ctx *context.Context) {
 	ctx.Data["CommitStatus"] = git_model.CalcCommitStatus(statuses)
 	ctx.Data["CommitStatuses"] = statuses
 
+	if setLatestDeployments(ctx, commitID); ctx.Written() {
+		return
+	}
+
 	verification := asymkey_model.ParseCommitWithSignature(commit)
 	ctx.Data["Verification"] = verification
 	ctx.Data["Author"] = user_model.ValidateCommitWithEmail(commit)
@@ -0,0 +1,49 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitsFeed(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	ctx := test.MockContext(t, "user2/repo1/commits/branch/master.atom")
+	ctx.SetParams("*", "master.atom")
+	test.LoadRepo(t, ctx, 1)
+	test.LoadGitRepo(t, ctx)
+	defer ctx.Repo.GitRepo.Close()
+
+	CommitsFeedType(ctx)
+	assert.Equal(t, "atom", ctx.Data["CommitsFeedType"])
+	assert.Equal(t, "master", ctx.Params("*"))
+
+	test.LoadRepoCommit(t, ctx)
+	ctx.Repo.BranchName = "master"
+	ctx.Repo.IsViewBranch = true
+	Commits(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Resp.Status())
+	assert.Equal(t, "application/atom+xml;charset=utf-8", ctx.Resp.Header().Get("Content-Type"))
+}
+
+func TestCommitsFeedTypeFileHistory(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	ctx := test.MockContext(t, "user2/repo1/commits/branch/master/docs/index.atom")
+	ctx.SetParams("*", "master/docs/index.atom")
+	test.LoadRepo(t, ctx, 1)
+	test.LoadGitRepo(t, ctx)
+	defer ctx.Repo.GitRepo.Close()
+
+	CommitsFeedType(ctx)
+	assert.Nil(t, ctx.Data["CommitsFeedType"])
+	assert.Equal(t, "master/docs/index.atom", ctx.Params("*"))
+}
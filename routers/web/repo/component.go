@@ -0,0 +1,35 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+)
+
+const tplComponentList base.TplName = "repo/issue/components"
+
+// Components render the page browsing the components of a repository with their open issues and pull requests
+func Components(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.components")
+	ctx.Data["PageIsIssueList"] = true
+	ctx.Data["PageIsComponents"] = true
+
+	components, err := issues_model.GetComponents(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetComponents", err)
+		return
+	}
+	if err := issues_model.LoadComponentIssueCounts(ctx, components); err != nil {
+		ctx.ServerError("LoadComponentIssueCounts", err)
+		return
+	}
+	ctx.Data["Components"] = components
+
+	ctx.HTML(http.StatusOK, tplComponentList)
+}
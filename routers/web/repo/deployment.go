@@ -0,0 +1,75 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	deployment_model "code.gitea.io/gitea/models/deployment"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+const (
+	tplDeployments base.TplName = "repo/deployments"
+)
+
+// Deployments render the deployment history of the environments of a repository
+func Deployments(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.deployments")
+
+	environments, err := deployment_model.GetLatestDeployments(ctx, ctx.Repo.Repository.ID, "")
+	if err != nil {
+		ctx.ServerError("GetLatestDeployments", err)
+		return
+	}
+	ctx.Data["Environments"] = environments
+
+	environment := ctx.FormTrim("environment")
+	ctx.Data["Environment"] = environment
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	deployments, count, err := deployment_model.FindDeployments(ctx, &deployment_model.FindDeploymentsOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.IssuePagingNum,
+		},
+		RepoID:      ctx.Repo.Repository.ID,
+		Environment: environment,
+	})
+	if err != nil {
+		ctx.ServerError("FindDeployments", err)
+		return
+	}
+	for _, d := range deployments {
+		if err := d.LoadCreator(ctx); err != nil {
+			ctx.ServerError("LoadCreator", err)
+			return
+		}
+	}
+	ctx.Data["Deployments"] = deployments
+
+	pager := context.NewPagination(int(count), setting.UI.IssuePagingNum, page, 5)
+	pager.AddParamString("environment", environment)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplDeployments)
+}
+
+// setLatestDeployments sets the latest deployment of every environment the commit has been deployed to
+func setLatestDeployments(ctx *context.Context, sha string) {
+	deployments, err := deployment_model.GetLatestDeployments(ctx, ctx.Repo.Repository.ID, sha)
+	if err != nil {
+		ctx.ServerError("GetLatestDeployments", err)
+		return
+	}
+	ctx.Data["LatestDeployments"] = deployments
+}
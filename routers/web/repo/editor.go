@@ -798,18 +798,11 @@ func RemoveUploadFileFromServer(ctx *context.Context) {
 // that doesn't already exist. If we exceed 1000 tries or an error is thrown, we just return "" so the user has to
 // type in the branch name themselves (will be an empty field)
 func GetUniquePatchBranchName(ctx *context.Context) string {
-	prefix := ctx.Doer.LowerName + "-patch-"
-	for i := 1; i <= 1000; i++ {
-		branchName := fmt.Sprintf("%s%d", prefix, i)
-		if _, err := ctx.Repo.GitRepo.GetBranch(branchName); err != nil {
-			if git.IsErrBranchNotExist(err) {
-				return branchName
-			}
-			log.Error("GetUniquePatchBranchName: %v", err)
-			return ""
-		}
+	branchName, err := files_service.GetUniquePatchBranchName(ctx.Repo.GitRepo, ctx.Doer)
+	if err != nil {
+		log.Error("GetUniquePatchBranchName: %v", err)
 	}
-	return ""
+	return branchName
 }
 
 // GetClosestParentWithFiles Recursively gets the path of parent in a tree that has files (used when file in a tree is
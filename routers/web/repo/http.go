@@ -10,6 +10,7 @@ import (
 	"compress/gzip"
 	gocontext "context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path"
@@ -27,11 +28,13 @@ import (
 	"code.gitea.io/gitea/modules/context"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/metrics"
 	repo_module "code.gitea.io/gitea/modules/repository"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
 	repo_service "code.gitea.io/gitea/services/repository"
+	wiki_service "code.gitea.io/gitea/services/wiki"
 )
 
 // httpBase implementation git smart HTTP protocol
@@ -181,6 +184,11 @@ func httpBase(ctx *context.Context) (h *serviceHandler) {
 			return
 		}
 
+		if !isPull && ctx.Data["ApiTokenScope"] == auth.AccessTokenScopeRead {
+			ctx.PlainText(http.StatusForbidden, "The access token is read-only.")
+			return
+		}
+
 		if repoExist {
 			p, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
 			if err != nil {
@@ -268,10 +276,20 @@ func httpBase(ctx *context.Context) (h *serviceHandler) {
 			ctx.ServerError("GetUnit(UnitTypeWiki) for "+repo.FullName(), err)
 			return
 		}
+		// the wikis of a standby are created by the first replication of their content
+		if receivePack && setting.Replication.Standby && ctx.Doer.IsAdmin {
+			if err := wiki_service.InitWiki(ctx, repo); err != nil {
+				ctx.ServerError("InitWiki", err)
+				return
+			}
+		}
 	}
 
 	environ = append(environ, repo_module.EnvRepoID+fmt.Sprintf("=%d", repo.ID))
 
+	// restrict the partial clone filters of upload-pack, the wiki shares the settings of its repository
+	environ = append(environ, git.PartialCloneConfigEnv(repo.PartialCloneConfig())...)
+
 	w := ctx.Resp
 	r := ctx.Req
 	cfg := &serviceConfig{
@@ -463,6 +481,10 @@ func serviceRPC(ctx gocontext.Context, h serviceHandler, service string) {
 		}
 	}
 
+	if service == "upload-pack" && setting.Metrics.Enabled {
+		reqBody = &uploadPackMetricsReader{ReadCloser: reqBody}
+	}
+
 	// set this for allow pre-receive and post-receive execute
 	h.environ = append(h.environ, "SSH_ORIGINAL_COMMAND="+service)
 
@@ -488,6 +510,53 @@ func serviceRPC(ctx gocontext.Context, h serviceHandler, service string) {
 	}
 }
 
+// uploadPackMetricsReader counts the partial clone filters and the object-info commands
+// of the upload-pack requests read through it
+type uploadPackMetricsReader struct {
+	io.ReadCloser
+	buf  []byte
+	done bool
+}
+
+func (r *uploadPackMetricsReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if !r.done && n > 0 {
+		r.buf = append(r.buf, p[:n]...)
+		r.scan()
+	}
+	return n, err
+}
+
+// scan reads the complete pkt-lines in the buffer until the first flush-pkt,
+// which ends the command and its arguments or the wanted objects
+func (r *uploadPackMetricsReader) scan() {
+	for len(r.buf) >= 4 {
+		length, err := strconv.ParseUint(string(r.buf[:4]), 16, 16)
+		if err != nil || length == 0 || length == 3 {
+			r.done = true
+			break
+		}
+		if length < 4 {
+			// delim-pkt or response-end-pkt
+			r.buf = r.buf[4:]
+			continue
+		}
+		if uint64(len(r.buf)) < length {
+			return
+		}
+		line := strings.TrimSuffix(string(r.buf[4:length]), "\n")
+		r.buf = r.buf[length:]
+		if line == "command=object-info" {
+			metrics.GitObjectInfoRequests.Inc()
+		} else if strings.HasPrefix(line, "filter ") {
+			metrics.GitUploadPackFilterRequests.WithLabelValues(git.PartialCloneFilterKind(strings.TrimPrefix(line, "filter "))).Inc()
+		}
+	}
+	if r.done {
+		r.buf = nil
+	}
+}
+
 // ServiceUploadPack implements Git Smart HTTP protocol
 func ServiceUploadPack(ctx *context.Context) {
 	h := httpBase(ctx)
@@ -5,8 +5,14 @@
 package repo
 
 import (
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
+	"code.gitea.io/gitea/modules/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,3 +47,28 @@ func TestContainsParentDirectorySeparator(t *testing.T) {
 		assert.EqualValues(t, tests[i].b, containsParentDirectorySeparator(tests[i].v))
 	}
 }
+
+func TestUploadPackMetricsReader(t *testing.T) {
+	read := func(body string) {
+		r := &uploadPackMetricsReader{ReadCloser: io.NopCloser(iotest.OneByteReader(strings.NewReader(body)))}
+		b, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, body, string(b))
+	}
+	blobNone := metrics.GitUploadPackFilterRequests.WithLabelValues("blob:none")
+	tree := metrics.GitUploadPackFilterRequests.WithLabelValues("tree")
+	blobNoneCount, treeCount, objectInfoCount := testutil.ToFloat64(blobNone), testutil.ToFloat64(tree), testutil.ToFloat64(metrics.GitObjectInfoRequests)
+
+	// protocol v2
+	read(string(packetWrite("command=fetch\n")) + "0001" + string(packetWrite("thin-pack\n")) + string(packetWrite("filter tree:1\n")) +
+		string(packetWrite("want 0000000000000000000000000000000000000001\n")) + "0000")
+	read(string(packetWrite("command=object-info\n")) + "0001" + string(packetWrite("size\n")) +
+		string(packetWrite("oid 0000000000000000000000000000000000000001\n")) + "0000")
+	// protocol v0, the haves come after the flush-pkt
+	read(string(packetWrite("want 0000000000000000000000000000000000000001 multi_ack_detailed side-band-64k\n")) +
+		string(packetWrite("filter blob:none\n")) + "0000" + string(packetWrite("filter blob:none\n")) + "0000")
+
+	assert.EqualValues(t, blobNoneCount+1, testutil.ToFloat64(blobNone))
+	assert.EqualValues(t, treeCount+1, testutil.ToFloat64(tree))
+	assert.EqualValues(t, objectInfoCount+1, testutil.ToFloat64(metrics.GitObjectInfoRequests))
+}
@@ -22,6 +22,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
 	"code.gitea.io/gitea/models/organization"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	project_model "code.gitea.io/gitea/models/project"
@@ -46,10 +47,14 @@ import (
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/utils"
+	"code.gitea.io/gitea/routers/web/feed"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
+	codescanning_service "code.gitea.io/gitea/services/codescanning"
 	comment_service "code.gitea.io/gitea/services/comments"
+	coverage_service "code.gitea.io/gitea/services/coverage"
 	"code.gitea.io/gitea/services/forms"
 	issue_service "code.gitea.io/gitea/services/issue"
+	moderation_service "code.gitea.io/gitea/services/moderation"
 	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
 )
@@ -104,6 +109,36 @@ func MustAllowUserComment(ctx *context.Context) {
 		ctx.Redirect(issue.HTMLURL())
 		return
 	}
+
+	if restriction := interactionRestriction(ctx); restriction != "" {
+		ctx.Flash.Error(restriction)
+		ctx.Redirect(issue.HTMLURL())
+	}
+}
+
+// interactionRestriction returns the message explaining why the signed in user cannot open issues or pull requests,
+// comment or react in the repository, or an empty string if they can
+func interactionRestriction(ctx *context.Context) string {
+	err := issue_service.CheckInteraction(ctx, ctx.Doer, ctx.Repo.Repository, ctx.Repo.Permission)
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, issue_service.ErrBlockedByOwner):
+		return ctx.Tr("repo.interaction.blocked")
+	case errors.Is(err, issue_service.ErrInteractionLimited):
+		return ctx.Tr("repo.interaction.limited")
+	}
+	ctx.ServerError("CheckInteraction", err)
+	return ""
+}
+
+// MustAllowInteraction checks that the signed in user is neither blocked by the owner of the repository
+// nor excluded by its interaction limit
+func MustAllowInteraction(ctx *context.Context) {
+	if restriction := interactionRestriction(ctx); restriction != "" {
+		ctx.Flash.Error(restriction)
+		ctx.Redirect(ctx.Repo.RepoLink)
+	}
 }
 
 // MustEnableIssues check if repository enable internal issues
@@ -176,6 +211,27 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption uti
 		}
 	}
 
+	selectFields := ctx.FormStrings("fields")
+	fieldValues, err := issues_model.ParseIssueFieldFilters(selectFields)
+	if err != nil {
+		log.Warn("Invalid issue field filters %v: %v", selectFields, err)
+		selectFields, fieldValues = nil, nil
+	}
+
+	componentID := ctx.FormInt64("component")
+	if componentID > 0 {
+		component, err := issues_model.GetComponentByID(ctx, repo.ID, componentID)
+		if err != nil {
+			if issues_model.IsErrComponentNotExist(err) {
+				ctx.NotFound("GetComponentByID", err)
+			} else {
+				ctx.ServerError("GetComponentByID", err)
+			}
+			return
+		}
+		ctx.Data["Component"] = component
+	}
+
 	keyword := strings.Trim(ctx.FormString("q"), " ")
 	if bytes.Contains([]byte(keyword), []byte{0x00}) {
 		keyword = ""
@@ -201,6 +257,7 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption uti
 		issueStats = &issues_model.IssueStats{}
 	} else {
 		issueStats, err = issues_model.GetIssueStats(&issues_model.IssueStatsOptions{
+			ConfidentialCond:  issues_model.ConfidentialIssueCond(ctx.Doer),
 			RepoID:            repo.ID,
 			Labels:            selectLabels,
 			MilestoneID:       milestoneID,
@@ -210,6 +267,8 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption uti
 			ReviewRequestedID: reviewRequestedID,
 			IsPull:            isPullOption,
 			IssueIDs:          issueIDs,
+			FieldValues:       fieldValues,
+			ComponentID:       componentID,
 		})
 		if err != nil {
 			ctx.ServerError("GetIssueStats", err)
@@ -246,6 +305,7 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption uti
 		issues = []*issues_model.Issue{}
 	} else {
 		issues, err = issues_model.Issues(&issues_model.IssuesOptions{
+			ConfidentialCond: issues_model.ConfidentialIssueCond(ctx.Doer),
 			ListOptions: db.ListOptions{
 				Page:     pager.Paginater.Current(),
 				PageSize: setting.UI.IssuePagingNum,
@@ -262,6 +322,8 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption uti
 			LabelIDs:          labelIDs,
 			SortType:          sortType,
 			IssueIDs:          issueIDs,
+			FieldValues:       fieldValues,
+			ComponentID:       componentID,
 		})
 		if err != nil {
 			ctx.ServerError("Issues", err)
@@ -295,6 +357,23 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption uti
 
 	ctx.Data["Issues"] = issues
 	ctx.Data["CommitLastStatus"] = lastStatus
+
+	pins, err := issues_model.GetPinnedIssues(ctx, repo.ID)
+	if err != nil {
+		ctx.ServerError("GetPinnedIssues", err)
+		return
+	}
+	if pins, err = pins.VisibleTo(ctx, ctx.Doer); err != nil {
+		ctx.ServerError("VisibleTo", err)
+		return
+	}
+	pinnedIssues := make(issues_model.IssueList, 0, len(pins))
+	for _, pin := range pins {
+		if pin.Issue.IsPull == isPullOption.IsTrue() {
+			pinnedIssues = append(pinnedIssues, pin.Issue)
+		}
+	}
+	ctx.Data["PinnedIssues"] = pinnedIssues
 	ctx.Data["CommitStatuses"] = commitStatuses
 
 	// Get assignees.
@@ -384,6 +463,7 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption uti
 	ctx.Data["MilestoneID"] = milestoneID
 	ctx.Data["AssigneeID"] = assigneeID
 	ctx.Data["PosterID"] = posterID
+	ctx.Data["ComponentID"] = componentID
 	ctx.Data["IsShowClosed"] = isShowClosed
 	ctx.Data["Keyword"] = keyword
 	if isShowClosed {
@@ -400,6 +480,10 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption uti
 	pager.AddParam(ctx, "milestone", "MilestoneID")
 	pager.AddParam(ctx, "assignee", "AssigneeID")
 	pager.AddParam(ctx, "poster", "PosterID")
+	pager.AddParam(ctx, "component", "ComponentID")
+	for _, field := range selectFields {
+		pager.AddParamString("fields", field)
+	}
 	ctx.Data["Page"] = pager
 }
 
@@ -421,6 +505,7 @@ func Issues(ctx *context.Context) {
 		ctx.Data["Title"] = ctx.Tr("repo.issues")
 		ctx.Data["PageIsIssueList"] = true
 		ctx.Data["NewIssueChooseTemplate"] = len(ctx.IssueTemplatesFromDefaultBranch()) > 0
+		ctx.Data["FeedURL"] = ctx.Repo.Repository.HTMLURL() + "/issues"
 	}
 
 	issues(ctx, ctx.FormInt64("milestone"), ctx.FormInt64("project"), util.OptionalBoolOf(isPullList))
@@ -440,6 +525,22 @@ func Issues(ctx *context.Context) {
 	}
 
 	ctx.Data["CanWriteIssuesOrPulls"] = ctx.Repo.CanWriteIssuesOrPulls(isPullList)
+	if !isPullList {
+		m, err := issues_model.GetIssueMirror(ctx, ctx.Repo.Repository.ID)
+		if err != nil {
+			ctx.ServerError("GetIssueMirror", err)
+			return
+		}
+		if m != nil {
+			ctx.Data["IssueMirror"] = m
+			ctx.Data["CanWriteIssuesOrPulls"] = false
+		}
+	}
+
+	prepareIssueFilters(ctx, isPullList)
+	if ctx.Written() {
+		return
+	}
 
 	ctx.HTML(http.StatusOK, tplIssues)
 }
@@ -748,6 +849,25 @@ func setTemplateIfExists(ctx *context.Context, ctxDataKey string, possibleFiles
 	templateCandidates = append(templateCandidates, possibleFiles...) // Append files to the end because they should be fallback
 
 	templateErrs := map[string]error{}
+	if setTemplateFromCommit(ctx, ctxDataKey, commit, templateCandidates, templateErrs) || len(templateErrs) > 0 {
+		return templateErrs
+	}
+
+	// the repository has no template of its own, use the ones of its defaults repository
+	defaultsCommit, defaultsGitRepo, err := ctx.Repo.GetDefaultsCommit(ctx)
+	if err != nil {
+		log.Error("GetDefaultsCommit: %v", err)
+		return templateErrs
+	}
+	if defaultsGitRepo != nil {
+		defer defaultsGitRepo.Close()
+		setTemplateFromCommit(ctx, ctxDataKey, defaultsCommit, templateCandidates, templateErrs)
+	}
+	return templateErrs
+}
+
+// setTemplateFromCommit sets the first valid template of the candidates found in the commit, and returns whether there is one
+func setTemplateFromCommit(ctx *context.Context, ctxDataKey string, commit *git.Commit, templateCandidates []string, templateErrs map[string]error) bool {
 	for _, filename := range templateCandidates {
 		if ok, _ := commit.HasFile(filename); !ok {
 			continue
@@ -788,9 +908,9 @@ func setTemplateIfExists(ctx *context.Context, ctxDataKey string, possibleFiles
 		ctx.Data["label_ids"] = strings.Join(labelIDs, ",")
 		ctx.Data["Reference"] = template.Ref
 		ctx.Data["RefEndName"] = git.RefEndName(template.Ref)
-		return templateErrs
+		return true
 	}
-	return templateErrs
+	return false
 }
 
 // NewIssue render creating issue page
@@ -1066,6 +1186,17 @@ func NewIssuePost(ctx *context.Context) {
 	content := form.Content
 	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
 		if template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
+			if err := issue_template.ValidateValues(template, ctx.Req.Form); err != nil {
+				if !issue_template.IsErrInvalidFieldValue(err) {
+					ctx.ServerError("ValidateValues", err)
+					return
+				}
+				fieldErr := err.(issue_template.ErrInvalidFieldValue)
+				ctx.Data["Fields"] = issue_template.FieldsWithValues(template, ctx.Req.Form)
+				ctx.Data["TemplateFile"] = template.FileName
+				ctx.RenderWithErr(ctx.Tr("repo.issues.form_field_invalid."+fieldErr.Reason, fieldErr.Label), tplIssueNew, form)
+				return
+			}
 			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
 		}
 	}
@@ -1079,6 +1210,8 @@ func NewIssuePost(ctx *context.Context) {
 		MilestoneID: milestoneID,
 		Content:     content,
 		Ref:         form.Ref,
+		// anyone can file a confidential issue, its poster keeps seeing it
+		IsConfidential: form.Confidential,
 	}
 
 	if err := issue_service.NewIssue(repo, issue, labelIDs, attachments, assigneeIDs); err != nil {
@@ -1103,6 +1236,12 @@ func NewIssuePost(ctx *context.Context) {
 	}
 
 	log.Trace("Issue created: %d/%d", repo.ID, issue.ID)
+	if held, err := moderation_model.IsContentHeld(ctx, repo.ID, issue.ID, 0); err != nil {
+		ctx.ServerError("IsContentHeld", err)
+		return
+	} else if held {
+		ctx.Flash.Info(ctx.Tr("repo.issues.held_for_review"))
+	}
 	if ctx.FormString("redirect_after_creation") == "project" {
 		ctx.Redirect(ctx.Repo.RepoLink + "/projects/" + strconv.FormatInt(form.ProjectID, 10))
 	} else {
@@ -1189,7 +1328,9 @@ func ViewIssue(ctx *context.Context) {
 		}
 	}
 
-	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	isFeed, index, showFeedType := feed.GetFeedType(ctx.Params(":index"), ctx.Req)
+	issueIndex, _ := strconv.ParseInt(index, 10, 64)
+	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, issueIndex)
 	if err != nil {
 		if issues_model.IsErrIssueNotExist(err) {
 			ctx.NotFound("GetIssueByIndex", err)
@@ -1201,6 +1342,13 @@ func ViewIssue(ctx *context.Context) {
 	if issue.Repo == nil {
 		issue.Repo = ctx.Repo.Repository
 	}
+	if hidden, err := issue.IsHiddenFrom(ctx, ctx.Doer); err != nil {
+		ctx.ServerError("IsHiddenFrom", err)
+		return
+	} else if hidden {
+		ctx.NotFound("IsHiddenFrom", nil)
+		return
+	}
 
 	// Make sure type and URL matches.
 	if ctx.Params(":type") == "issues" && issue.IsPull {
@@ -1227,6 +1375,12 @@ func ViewIssue(ctx *context.Context) {
 		ctx.Data["NewIssueChooseTemplate"] = len(ctx.IssueTemplatesFromDefaultBranch()) > 0
 	}
 
+	if isFeed {
+		feed.ShowIssueFeed(ctx, issue, showFeedType)
+		return
+	}
+	ctx.Data["FeedURL"] = issue.HTMLURL()
+
 	if issue.IsPull && !ctx.Repo.CanRead(unit.TypeIssues) {
 		ctx.Data["IssueType"] = "pulls"
 	} else if !issue.IsPull && !ctx.Repo.CanRead(unit.TypePullRequests) {
@@ -1250,6 +1404,15 @@ func ViewIssue(ctx *context.Context) {
 		return
 	}
 
+	canModerate := moderation_service.CanModerate(ctx.Doer, ctx.Repo.Permission)
+	issue.Comments, err = moderation_service.FilterComments(ctx, ctx.Doer, ctx.Repo.Repository.OwnerID, canModerate, issue.Comments)
+	if err != nil {
+		ctx.ServerError("FilterComments", err)
+		return
+	}
+	ctx.Data["CanModerate"] = canModerate
+	ctx.Data["ReportCategories"] = moderation_model.ReportCategories
+
 	ctx.Data["Title"] = fmt.Sprintf("#%d - %s", issue.Index, issue.Title)
 
 	iw := new(issues_model.IssueWatch)
@@ -1264,6 +1427,14 @@ func ViewIssue(ctx *context.Context) {
 	}
 	ctx.Data["IssueWatch"] = iw
 
+	if ctx.Doer != nil {
+		ctx.Data["HasVoted"], err = issues_model.HasVotedForIssue(ctx, ctx.Doer.ID, issue.ID)
+		if err != nil {
+			ctx.ServerError("HasVotedForIssue", err)
+			return
+		}
+	}
+
 	issue.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
 		URLPrefix: ctx.Repo.RepoLink,
 		Metas:     ctx.Repo.Repository.ComposeMetas(),
@@ -1660,19 +1831,38 @@ func ViewIssue(ctx *context.Context) {
 
 		ctx.Data["MergeStyle"] = mergeStyle
 
+		// the first line of the message generated by a template is the commit title, the rest is the commit body
 		defaultMergeMessage, err := pull_service.GetDefaultMergeMessage(ctx.Repo.GitRepo, pull, mergeStyle)
 		if err != nil {
 			ctx.ServerError("GetDefaultMergeMessage", err)
 			return
 		}
-		ctx.Data["DefaultMergeMessage"] = defaultMergeMessage
+		defaultMergeTitle, defaultMergeBody, _ := strings.Cut(defaultMergeMessage, "\n")
+		ctx.Data["DefaultMergeMessage"] = defaultMergeTitle
+		ctx.Data["DefaultMergeBody"] = strings.TrimSpace(defaultMergeBody)
 
 		defaultSquashMergeMessage, err := pull_service.GetDefaultMergeMessage(ctx.Repo.GitRepo, pull, repo_model.MergeStyleSquash)
 		if err != nil {
 			ctx.ServerError("GetDefaultSquashMergeMessage", err)
 			return
 		}
-		ctx.Data["DefaultSquashMergeMessage"] = defaultSquashMergeMessage
+		defaultSquashMergeTitle, defaultSquashMergeBody, _ := strings.Cut(defaultSquashMergeMessage, "\n")
+		ctx.Data["DefaultSquashMergeMessage"] = defaultSquashMergeTitle
+		ctx.Data["DefaultSquashMergeBody"] = strings.TrimSpace(defaultSquashMergeBody)
+
+		mergeTitleReadonly, err := pull_service.IsMergeMessageTemplateEnforced(ctx.Repo.GitRepo, pull, mergeStyle)
+		if err != nil {
+			ctx.ServerError("IsMergeMessageTemplateEnforced", err)
+			return
+		}
+		ctx.Data["MergeTitleReadonly"] = mergeTitleReadonly
+
+		squashMergeTitleReadonly, err := pull_service.IsMergeMessageTemplateEnforced(ctx.Repo.GitRepo, pull, repo_model.MergeStyleSquash)
+		if err != nil {
+			ctx.ServerError("IsMergeMessageTemplateEnforced", err)
+			return
+		}
+		ctx.Data["SquashMergeTitleReadonly"] = squashMergeTitleReadonly
 
 		if err = pull.LoadProtectedBranch(); err != nil {
 			ctx.ServerError("LoadProtectedBranch", err)
@@ -1688,6 +1878,21 @@ func ViewIssue(ctx *context.Context) {
 			ctx.Data["IsBlockedByRejection"] = issues_model.MergeBlockedByRejectedReview(ctx, pull.ProtectedBranch, pull)
 			ctx.Data["IsBlockedByOfficialReviewRequests"] = issues_model.MergeBlockedByOfficialReviewRequests(ctx, pull.ProtectedBranch, pull)
 			ctx.Data["IsBlockedByOutdatedBranch"] = issues_model.MergeBlockedByOutdatedBranch(pull.ProtectedBranch, pull)
+			newCodeScanningAlerts, err := codescanning_service.MergeBlockedByCodeScanningAlerts(ctx, pull)
+			if err != nil {
+				ctx.ServerError("MergeBlockedByCodeScanningAlerts", err)
+				return
+			}
+			ctx.Data["IsBlockedByCodeScanningAlerts"] = newCodeScanningAlerts > 0
+			ctx.Data["NewCodeScanningAlerts"] = newCodeScanningAlerts
+			blockedByDiffCoverage, diffCoverage, err := coverage_service.MergeBlockedByDiffCoverage(ctx, pull)
+			if err != nil {
+				ctx.ServerError("MergeBlockedByDiffCoverage", err)
+				return
+			}
+			ctx.Data["IsBlockedByDiffCoverage"] = blockedByDiffCoverage
+			ctx.Data["DiffCoverage"] = diffCoverage
+			ctx.Data["MinimumDiffCoverage"] = pull.ProtectedBranch.MinimumDiffCoverage
 			ctx.Data["GrantedApprovals"] = issues_model.GetGrantedApprovalsCount(ctx, pull.ProtectedBranch, pull)
 			ctx.Data["RequireSigned"] = pull.ProtectedBranch.RequireSignedCommits
 			ctx.Data["ChangedProtectedFiles"] = pull.ChangedProtectedFiles
@@ -1773,8 +1978,28 @@ func ViewIssue(ctx *context.Context) {
 	ctx.Data["HasIssuesOrPullsWritePermission"] = ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull)
 	ctx.Data["HasProjectsWritePermission"] = ctx.Repo.CanWrite(unit.TypeProjects)
 	ctx.Data["IsRepoAdmin"] = ctx.IsSigned && (ctx.Repo.IsAdmin() || ctx.Doer.IsAdmin)
+	if ctx.IsSigned {
+		ctx.Data["InteractionRestriction"] = interactionRestriction(ctx)
+		if ctx.Written() {
+			return
+		}
+	}
+	if prepareIssueMirror(ctx, issue); ctx.Written() {
+		return
+	}
 	ctx.Data["LockReasons"] = setting.Repository.Issue.LockReasons
 	ctx.Data["RefEndName"] = git.RefEndName(issue.Ref)
+	ctx.Data["IsPinned"], ctx.Data["IsAnnouncement"], err = issues_model.IsIssuePinned(ctx, issue)
+	if err != nil {
+		ctx.ServerError("IsIssuePinned", err)
+		return
+	}
+	if prepareIssueFields(ctx, issue); ctx.Written() {
+		return
+	}
+	if prepareSubIssues(ctx, issue); ctx.Written() {
+		return
+	}
 
 	var hiddenCommentTypes *big.Int
 	if ctx.IsSigned {
@@ -1815,6 +2040,12 @@ func checkIssueRights(ctx *context.Context, issue *issues_model.Issue) {
 	if issue.IsPull && !ctx.Repo.CanRead(unit.TypePullRequests) ||
 		!issue.IsPull && !ctx.Repo.CanRead(unit.TypeIssues) {
 		ctx.NotFound("IssueOrPullRequestUnitNotAllowed", nil)
+		return
+	}
+	if hidden, err := issue.IsHiddenFrom(ctx, ctx.Doer); err != nil {
+		ctx.ServerError("IsHiddenFrom", err)
+	} else if hidden {
+		ctx.NotFound("IsHiddenFrom", nil)
 	}
 }
 
@@ -1849,6 +2080,16 @@ func getActionIssues(ctx *context.Context) []*issues_model.Issue {
 			ctx.NotFound("IssueOrPullRequestUnitNotAllowed", nil)
 			return nil
 		}
+		if hidden, err := issue.IsHiddenFrom(ctx, ctx.Doer); err != nil {
+			ctx.ServerError("IsHiddenFrom", err)
+			return nil
+		} else if hidden {
+			ctx.NotFound("IsHiddenFrom", nil)
+			return nil
+		}
+		if checkNotMirroredIssue(ctx, issue); ctx.Written() {
+			return nil
+		}
 		if err = issue.LoadAttributes(ctx); err != nil {
 			ctx.ServerError("LoadAttributes", err)
 			return nil
@@ -1882,6 +2123,13 @@ func GetIssueInfo(ctx *context.Context) {
 			return
 		}
 	}
+	if hidden, err := issue.IsHiddenFrom(ctx, ctx.Doer); err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsHiddenFrom", err.Error())
+		return
+	} else if hidden {
+		ctx.Error(http.StatusNotFound)
+		return
+	}
 
 	ctx.JSON(http.StatusOK, convert.ToAPIIssue(issue))
 }
@@ -2022,16 +2270,9 @@ func UpdateIssueMilestone(ctx *context.Context) {
 	}
 
 	milestoneID := ctx.FormInt64("id")
-	for _, issue := range issues {
-		oldMilestoneID := issue.MilestoneID
-		if oldMilestoneID == milestoneID {
-			continue
-		}
-		issue.MilestoneID = milestoneID
-		if err := issue_service.ChangeMilestoneAssign(issue, ctx.Doer, oldMilestoneID); err != nil {
-			ctx.ServerError("ChangeMilestoneAssign", err)
-			return
-		}
+	if err := issue_service.BulkEditIssues(ctx, ctx.Doer, issues, &issue_service.BulkEditOptions{MilestoneID: &milestoneID}); err != nil {
+		ctx.ServerError("BulkEditIssues", err)
+		return
 	}
 
 	ctx.JSON(http.StatusOK, map[string]interface{}{
@@ -2332,6 +2573,7 @@ func SearchIssues(ctx *context.Context) {
 	// This would otherwise return all issues if no issues were found by the search.
 	if len(keyword) == 0 || len(issueIDs) > 0 || len(includedLabelNames) > 0 || len(includedMilestones) > 0 {
 		issuesOpt := &issues_model.IssuesOptions{
+			ConfidentialCond: issues_model.ConfidentialIssueCond(ctx.Doer),
 			ListOptions: db.ListOptions{
 				Page:     ctx.FormInt("page"),
 				PageSize: limit,
@@ -2511,6 +2753,7 @@ func ListIssues(ctx *context.Context) {
 	// This would otherwise return all issues if no issues were found by the search.
 	if len(keyword) == 0 || len(issueIDs) > 0 || len(labelIDs) > 0 {
 		issuesOpt := &issues_model.IssuesOptions{
+			ConfidentialCond:  issues_model.ConfidentialIssueCond(ctx.Doer),
 			ListOptions:       listOptions,
 			RepoID:            ctx.Repo.Repository.ID,
 			IsClosed:          isClosed,
@@ -2543,6 +2786,25 @@ func ListIssues(ctx *context.Context) {
 	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(issues))
 }
 
+// similarIssuesLimit is the number of similar issues suggested while a new issue is written
+const similarIssuesLimit = 5
+
+// SimilarIssues lists the issues similar to the title of a new issue
+func SimilarIssues(ctx *context.Context) {
+	title := strings.TrimSpace(ctx.FormString("title"))
+	if title == "" {
+		ctx.JSON(http.StatusOK, []*api.Issue{})
+		return
+	}
+
+	issues, err := issue_service.FindSimilarIssues(ctx, ctx.Repo.Repository, ctx.Doer, title, similarIssuesLimit)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(issues))
+}
+
 // UpdateIssueStatus change issue's status
 func UpdateIssueStatus(ctx *context.Context) {
 	issues := getActionIssues(ctx)
@@ -2564,19 +2826,15 @@ func UpdateIssueStatus(ctx *context.Context) {
 		ctx.ServerError("LoadRepositories", err)
 		return
 	}
-	for _, issue := range issues {
-		if issue.IsClosed != isClosed {
-			if err := issue_service.ChangeStatus(issue, ctx.Doer, isClosed); err != nil {
-				if issues_model.IsErrDependenciesLeft(err) {
-					ctx.JSON(http.StatusPreconditionFailed, map[string]interface{}{
-						"error": "cannot close this issue because it still has open dependencies",
-					})
-					return
-				}
-				ctx.ServerError("ChangeStatus", err)
-				return
-			}
+	if err := issue_service.BulkEditIssues(ctx, ctx.Doer, issues, &issue_service.BulkEditOptions{IsClosed: util.OptionalBoolOf(isClosed)}); err != nil {
+		if issues_model.IsErrDependenciesLeft(err) {
+			ctx.JSON(http.StatusPreconditionFailed, map[string]interface{}{
+				"error": "cannot close this issue because it still has open dependencies",
+			})
+			return
 		}
+		ctx.ServerError("BulkEditIssues", err)
+		return
 	}
 	ctx.JSON(http.StatusOK, map[string]interface{}{
 		"ok": true,
@@ -2709,6 +2967,10 @@ func NewComment(ctx *context.Context) {
 		ctx.ServerError("CreateIssueComment", err)
 		return
 	}
+	if comment.IsHidden {
+		// the comment was held for review by the spam detection
+		ctx.Flash.Info(ctx.Tr("repo.issues.comment_held_for_review"))
+	}
 
 	log.Trace("Comment created: %d/%d/%d", ctx.Repo.Repository.ID, issue.ID, comment.ID)
 }
@@ -2731,6 +2993,12 @@ func UpdateCommentContent(ctx *context.Context) {
 		return
 	}
 
+	// the writers who can't see the content of a hidden comment can't edit it either
+	if comment.IsHidden && ctx.Doer.ID != comment.PosterID && !moderation_service.CanModerate(ctx.Doer, ctx.Repo.Permission) {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
 	if comment.Type != issues_model.CommentTypeComment && comment.Type != issues_model.CommentTypeReview && comment.Type != issues_model.CommentTypeCode {
 		ctx.Error(http.StatusNoContent)
 		return
@@ -2846,6 +3114,12 @@ func ChangeIssueReaction(ctx *context.Context) {
 
 	switch ctx.Params(":action") {
 	case "react":
+		if interactionRestriction(ctx) != "" {
+			ctx.Error(http.StatusForbidden)
+			return
+		} else if ctx.Written() {
+			return
+		}
 		reaction, err := issues_model.CreateIssueReaction(ctx.Doer.ID, issue.ID, form.Content)
 		if err != nil {
 			if issues_model.IsErrForbiddenIssueReaction(err) {
@@ -2940,6 +3214,13 @@ func ChangeCommentReaction(ctx *context.Context) {
 		ctx.Error(http.StatusForbidden)
 		return
 	}
+	if hidden, err := comment.Issue.IsHiddenFrom(ctx, ctx.Doer); err != nil {
+		ctx.ServerError("IsHiddenFrom", err)
+		return
+	} else if hidden {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
 
 	if comment.Type != issues_model.CommentTypeComment && comment.Type != issues_model.CommentTypeCode && comment.Type != issues_model.CommentTypeReview {
 		ctx.Error(http.StatusNoContent)
@@ -2948,6 +3229,12 @@ func ChangeCommentReaction(ctx *context.Context) {
 
 	switch ctx.Params(":action") {
 	case "react":
+		if interactionRestriction(ctx) != "" {
+			ctx.Error(http.StatusForbidden)
+			return
+		} else if ctx.Written() {
+			return
+		}
 		reaction, err := issues_model.CreateCommentReaction(ctx.Doer.ID, comment.Issue.ID, comment.ID, form.Content)
 		if err != nil {
 			if issues_model.IsErrForbiddenIssueReaction(err) {
@@ -3057,6 +3344,14 @@ func GetCommentAttachments(ctx *context.Context) {
 		ctx.NotFoundOrServerError("GetCommentByID", issues_model.IsErrCommentNotExist, err)
 		return
 	}
+	if err := comment.LoadIssue(); err != nil {
+		ctx.NotFoundOrServerError("LoadIssue", issues_model.IsErrIssueNotExist, err)
+		return
+	}
+	checkIssueRights(ctx, comment.Issue)
+	if ctx.Written() {
+		return
+	}
 	attachments := make([]*api.Attachment, 0)
 	if comment.Type == issues_model.CommentTypeComment {
 		if err := comment.LoadAttachments(); err != nil {
@@ -0,0 +1,37 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"strconv"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+)
+
+// IssueConfidential marks or unmarks an issue confidential
+func IssueConfidential(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if issue.IsPull {
+		ctx.NotFound("IsPull", nil)
+		return
+	}
+
+	confidential, err := strconv.ParseBool(ctx.Req.PostForm.Get("confidential"))
+	if err != nil {
+		ctx.ServerError("confidential is not bool", err)
+		return
+	}
+
+	if err := issues_model.ChangeIssueConfidentiality(ctx.Doer, issue, confidential); err != nil {
+		ctx.ServerError("ChangeIssueConfidentiality", err)
+		return
+	}
+
+	ctx.Redirect(issue.HTMLURL())
+}
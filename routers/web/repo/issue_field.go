@@ -0,0 +1,62 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+)
+
+// prepareIssueFields sets the custom fields of the issues of the repository and the values of the issue for the
+// sidebar of the issue
+func prepareIssueFields(ctx *context.Context, issue *issues_model.Issue) {
+	fields, err := issues_model.GetApplicableIssueFields(ctx, ctx.Repo.Repository.ID, ctx.Repo.Repository.OwnerID)
+	if err != nil {
+		ctx.ServerError("GetApplicableIssueFields", err)
+		return
+	}
+	values, err := issues_model.GetIssueFieldValues(ctx, issue)
+	if err != nil {
+		ctx.ServerError("GetIssueFieldValues", err)
+		return
+	}
+	valueByField := make(map[int64]string, len(values))
+	for _, v := range values {
+		valueByField[v.FieldID] = v.Value
+	}
+	ctx.Data["IssueFields"] = fields
+	ctx.Data["IssueFieldValues"] = valueByField
+}
+
+// UpdateIssueFieldValue sets the value of a custom field of an issue, an empty value clears it
+func UpdateIssueFieldValue(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound("CanWriteIssuesOrPulls", nil)
+		return
+	}
+
+	field, err := issues_model.GetApplicableIssueFieldByID(ctx, ctx.Repo.Repository.ID, ctx.Repo.Repository.OwnerID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrIssueFieldNotExist(err) {
+			ctx.NotFound("GetApplicableIssueFieldByID", err)
+		} else {
+			ctx.ServerError("GetApplicableIssueFieldByID", err)
+		}
+		return
+	}
+	if err := issues_model.SetIssueFieldValue(ctx, issue, field, ctx.FormString("value")); err != nil {
+		if !issues_model.IsErrInvalidIssueFieldValue(err) {
+			ctx.ServerError("SetIssueFieldValue", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("repo.issues.fields.invalid_value", field.Name))
+	}
+
+	ctx.Redirect(issue.HTMLURL())
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// prepareIssueFilters sets the filters the doer saved for the issue or pull request list of the repository
+func prepareIssueFilters(ctx *context.Context, isPull bool) {
+	if !ctx.IsSigned {
+		return
+	}
+	filters, err := issues_model.GetIssueFilters(ctx, ctx.Doer.ID, ctx.Repo.Repository.ID, util.OptionalBoolOf(isPull))
+	if err != nil {
+		ctx.ServerError("GetIssueFilters", err)
+		return
+	}
+	ctx.Data["IssueFilters"] = filters
+}
+
+// issueListLink returns the link to the issue or pull request list of the repository of the request
+func issueListLink(ctx *context.Context) string {
+	if ctx.Params(":type") == "pulls" {
+		return ctx.Repo.RepoLink + "/pulls"
+	}
+	return ctx.Repo.RepoLink + "/issues"
+}
+
+// SaveIssueFilter saves the current filters of the issue or pull request list under a name
+func SaveIssueFilter(ctx *context.Context) {
+	f := &issues_model.IssueFilter{
+		UserID:      ctx.Doer.ID,
+		RepoID:      ctx.Repo.Repository.ID,
+		IsPull:      ctx.Params(":type") == "pulls",
+		Name:        ctx.FormString("name"),
+		MilestoneID: ctx.FormInt64("milestone"),
+		AssigneeID:  ctx.FormInt64("assignee"),
+		PosterID:    ctx.FormInt64("poster"),
+		IsClosed:    ctx.FormString("state") == "closed",
+		SortType:    ctx.FormString("sort"),
+		Keyword:     strings.TrimSpace(ctx.FormString("q")),
+		IsPinned:    ctx.FormBool("pinned"),
+	}
+	if !ctx.Repo.CanReadIssuesOrPulls(f.IsPull) {
+		ctx.NotFound("CanReadIssuesOrPulls", nil)
+		return
+	}
+	if labels := ctx.FormString("labels"); labels != "" && labels != "0" {
+		labelIDs, err := base.StringsToInt64s(strings.Split(labels, ","))
+		if err != nil {
+			ctx.ServerError("StringsToInt64s", err)
+			return
+		}
+		f.SetLabelIDs(labelIDs)
+	}
+
+	if err := issues_model.CreateIssueFilter(ctx, f); err != nil {
+		if err == issues_model.ErrTooManyIssueFilters {
+			ctx.Flash.Error(ctx.Tr("repo.issues.saved_filters.too_many", issues_model.MaxIssueFilters))
+		} else {
+			ctx.Flash.Error(ctx.Tr("repo.issues.saved_filters.invalid"))
+		}
+		ctx.Redirect(issueListLink(ctx))
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.issues.saved_filters.saved", f.Name))
+	ctx.Redirect(f.Link(ctx.Repo.RepoLink))
+}
+
+// getIssueFilter returns the filter of the request saved by the doer
+func getIssueFilter(ctx *context.Context) *issues_model.IssueFilter {
+	f, err := issues_model.GetIssueFilter(ctx, ctx.Doer.ID, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrIssueFilterNotExist(err) {
+			ctx.NotFound("GetIssueFilter", err)
+		} else {
+			ctx.ServerError("GetIssueFilter", err)
+		}
+		return nil
+	}
+	return f
+}
+
+// PinIssueFilter pins a saved filter to the issue or pull request list, or unpins it
+func PinIssueFilter(ctx *context.Context) {
+	f := getIssueFilter(ctx)
+	if ctx.Written() {
+		return
+	}
+	f.IsPinned = !f.IsPinned
+	if err := issues_model.UpdateIssueFilter(ctx, f); err != nil {
+		ctx.ServerError("UpdateIssueFilter", err)
+		return
+	}
+	ctx.Redirect(issueListLink(ctx))
+}
+
+// DeleteIssueFilter deletes a saved filter
+func DeleteIssueFilter(ctx *context.Context) {
+	f := getIssueFilter(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.DeleteIssueFilter(ctx, ctx.Doer.ID, ctx.Repo.Repository.ID, f.ID); err != nil {
+		ctx.ServerError("DeleteIssueFilter", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.issues.saved_filters.deleted", f.Name))
+	ctx.Redirect(issueListLink(ctx))
+}
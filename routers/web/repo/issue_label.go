@@ -198,20 +198,15 @@ func UpdateIssueLabel(ctx *context.Context) {
 			}
 		}
 
+		opts := &issue_service.BulkEditOptions{}
 		if action == "attach" {
-			for _, issue := range issues {
-				if err = issue_service.AddLabel(issue, ctx.Doer, label); err != nil {
-					ctx.ServerError("AddLabel", err)
-					return
-				}
-			}
+			opts.AddLabels = []*issues_model.Label{label}
 		} else {
-			for _, issue := range issues {
-				if err = issue_service.RemoveLabel(issue, ctx.Doer, label); err != nil {
-					ctx.ServerError("RemoveLabel", err)
-					return
-				}
-			}
+			opts.RemoveLabels = []*issues_model.Label{label}
+		}
+		if err = issue_service.BulkEditIssues(ctx, ctx.Doer, issues, opts); err != nil {
+			ctx.ServerError("BulkEditIssues", err)
+			return
 		}
 	default:
 		log.Warn("Unrecognized action: %s", action)
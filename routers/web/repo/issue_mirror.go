@@ -0,0 +1,93 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+)
+
+// MustNotBeIssueMirror forbids opening issues in a repository whose issues are mirrored from an external issue tracker
+func MustNotBeIssueMirror(ctx *context.Context) {
+	isMirror, err := issues_model.IsIssueMirror(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("IsIssueMirror", err)
+		return
+	}
+	if isMirror {
+		ctx.Flash.Error(ctx.Tr("repo.issues.mirror.read_only"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/issues")
+	}
+}
+
+// MustNotBeMirroredIssue forbids changing an issue mirrored from an external issue tracker, the pull requests and the
+// issues which don't exist are left to the handlers
+func MustNotBeMirroredIssue(ctx *context.Context) {
+	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if !issues_model.IsErrIssueNotExist(err) {
+			ctx.ServerError("GetIssueByIndex", err)
+		}
+		return
+	}
+	checkNotMirroredIssue(ctx, issue)
+}
+
+// MustNotBeMirroredComment forbids changing a comment of an issue mirrored from an external issue tracker
+func MustNotBeMirroredComment(ctx *context.Context) {
+	comment, err := issues_model.GetCommentByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if !issues_model.IsErrCommentNotExist(err) {
+			ctx.ServerError("GetCommentByID", err)
+		}
+		return
+	}
+	if err := comment.LoadIssueCtx(ctx); err != nil {
+		ctx.ServerError("LoadIssue", err)
+		return
+	}
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+		return
+	}
+	checkNotMirroredIssue(ctx, comment.Issue)
+}
+
+func checkNotMirroredIssue(ctx *context.Context, issue *issues_model.Issue) {
+	if issue.IsPull {
+		return
+	}
+	isMirror, err := issues_model.IsIssueMirror(ctx, issue.RepoID)
+	if err != nil {
+		ctx.ServerError("IsIssueMirror", err)
+	} else if isMirror {
+		ctx.Error(http.StatusForbidden, ctx.Tr("repo.issues.mirror.read_only"))
+	}
+}
+
+// prepareIssueMirror makes a mirrored issue read-only in its page and links it to its external issue
+func prepareIssueMirror(ctx *context.Context, issue *issues_model.Issue) {
+	if issue.IsPull {
+		return
+	}
+	m, err := issues_model.GetIssueMirror(ctx, issue.RepoID)
+	if err != nil {
+		ctx.ServerError("GetIssueMirror", err)
+		return
+	} else if m == nil {
+		return
+	}
+	if ctx.Data["IssueMirrorURL"], err = m.ExternalIssueURL(ctx, issue); err != nil {
+		ctx.ServerError("ExternalIssueURL", err)
+		return
+	}
+	ctx.Data["IsIssueMirror"] = true
+	ctx.Data["InteractionRestriction"] = ctx.Tr("repo.issues.mirror.read_only")
+	ctx.Data["IsIssuePoster"] = false
+	ctx.Data["HasIssuesOrPullsWritePermission"] = false
+	ctx.Data["HasProjectsWritePermission"] = false
+	ctx.Data["IsRepoAdmin"] = false
+}
@@ -0,0 +1,99 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/forms"
+	moderation_service "code.gitea.io/gitea/services/moderation"
+)
+
+// ReportContent reports an issue or one of its comments to the moderators
+func ReportContent(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ReportContentForm)
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	link := issue.HTMLURL()
+
+	var comment *issues_model.Comment
+	if form.CommentID > 0 {
+		var err error
+		comment, err = issues_model.GetCommentByID(ctx, form.CommentID)
+		if err != nil {
+			ctx.NotFoundOrServerError("GetCommentByID", issues_model.IsErrCommentNotExist, err)
+			return
+		}
+		if comment.IssueID != issue.ID {
+			ctx.NotFound("CompareCommentIssue", issues_model.ErrCommentNotExist{})
+			return
+		}
+		link += "#" + comment.HashTag()
+	}
+
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.Data["ErrorMsg"].(string))
+		ctx.Redirect(link)
+		return
+	}
+
+	_, err := moderation_service.ReportContent(ctx, ctx.Doer, issue, comment, moderation_model.ReportCategory(form.Category), form.Remark)
+	switch {
+	case err == nil:
+		ctx.Flash.Success(ctx.Tr("repo.issues.report.success"))
+	case errors.Is(err, moderation_model.ErrReportAlreadyExists):
+		ctx.Flash.Info(ctx.Tr("repo.issues.report.duplicate"))
+	case errors.Is(err, moderation_service.ErrCannotReportOwnContent):
+		ctx.Flash.Error(ctx.Tr("repo.issues.report.own_content"))
+	case errors.Is(err, moderation_model.ErrInvalidReportCategory), errors.Is(err, moderation_service.ErrCannotReportContent):
+		ctx.Flash.Error(ctx.Tr("repo.issues.report.invalid"))
+	default:
+		ctx.ServerError("ReportContent", err)
+		return
+	}
+	ctx.Redirect(link)
+}
+
+// SetCommentHidden hides a comment (action "hide") or shows it again (action "unhide"), only the moderators can do it
+func SetCommentHidden(ctx *context.Context) {
+	comment, err := issues_model.GetCommentByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetCommentByID", issues_model.IsErrCommentNotExist, err)
+		return
+	}
+	if err := comment.LoadIssueCtx(ctx); err != nil {
+		ctx.NotFoundOrServerError("LoadIssue", issues_model.IsErrIssueNotExist, err)
+		return
+	}
+	if comment.Issue.RepoID != ctx.Repo.Repository.ID {
+		ctx.NotFound("CompareRepoID", issues_model.ErrCommentNotExist{})
+		return
+	}
+	if !moderation_service.CanModerate(ctx.Doer, ctx.Repo.Permission) {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	hidden := ctx.Params(":action") == "hide"
+	if err := moderation_service.SetCommentHidden(ctx, ctx.Doer, comment, hidden, ""); err != nil {
+		if errors.Is(err, moderation_service.ErrCannotHideContent) {
+			ctx.Error(http.StatusBadRequest)
+			return
+		}
+		ctx.ServerError("SetCommentHidden", err)
+		return
+	}
+	comment.Issue.Repo = ctx.Repo.Repository
+	ctx.JSON(http.StatusOK, map[string]interface{}{
+		"redirect": comment.Issue.HTMLURL() + "#" + comment.HashTag(),
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"errors"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+)
+
+// PinIssue pins an issue or a pull request to the top of the issues of its repository, the announcement is also
+// shown on the home page of the repository
+func PinIssue(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound("CanWriteIssuesOrPulls", nil)
+		return
+	}
+
+	if err := issues_model.PinIssue(ctx, issue, ctx.FormBool("announcement")); err != nil {
+		if !errors.Is(err, issues_model.ErrTooManyPinnedIssues) {
+			ctx.ServerError("PinIssue", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("repo.issues.pin.too_many", issues_model.MaxPinnedIssues))
+	}
+
+	ctx.Redirect(issue.HTMLURL())
+}
+
+// UnpinIssue unpins an issue or a pull request from its repository
+func UnpinIssue(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.NotFound("CanWriteIssuesOrPulls", nil)
+		return
+	}
+
+	if err := issues_model.UnpinIssue(ctx, issue); err != nil {
+		ctx.ServerError("UnpinIssue", err)
+		return
+	}
+
+	ctx.Redirect(issue.HTMLURL())
+}
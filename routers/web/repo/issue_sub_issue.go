@@ -0,0 +1,103 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+)
+
+// prepareSubIssues sets the parent of the issue and the tree of its sub-issues for the sidebar of the issue
+func prepareSubIssues(ctx *context.Context, issue *issues_model.Issue) {
+	if issue.IsPull {
+		return
+	}
+	parent, err := issues_model.GetParentIssue(ctx, issue.ID)
+	if err != nil {
+		ctx.ServerError("GetParentIssue", err)
+		return
+	}
+	if parent != nil {
+		if hidden, err := parent.IsHiddenFrom(ctx, ctx.Doer); err != nil {
+			ctx.ServerError("IsHiddenFrom", err)
+			return
+		} else if hidden {
+			parent = nil
+		} else {
+			parent.Repo = ctx.Repo.Repository
+		}
+	}
+	tree, err := issues_model.GetSubIssueTree(ctx, issue)
+	if err != nil {
+		ctx.ServerError("GetSubIssueTree", err)
+		return
+	}
+	if tree, err = tree.VisibleTo(ctx, ctx.Doer); err != nil {
+		ctx.ServerError("VisibleTo", err)
+		return
+	}
+	ctx.Data["ParentIssue"] = parent
+	ctx.Data["SubIssueTree"] = tree
+	ctx.Data["SubIssueProgress"] = tree.Progress()
+}
+
+// getSubIssueAction returns the issue of the request and the issue of the form which is attached to or detached from
+// it, and responds with an error if the doer can't change the sub-issues
+func getSubIssueAction(ctx *context.Context) (*issues_model.Issue, *issues_model.Issue) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if issue.IsPull || !ctx.Repo.CanWriteIssuesOrPulls(false) {
+		ctx.NotFound("CanWriteIssuesOrPulls", nil)
+		return nil, nil
+	}
+	subIssue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.FormInt64("index"))
+	if err != nil {
+		if !issues_model.IsErrIssueNotExist(err) {
+			ctx.ServerError("GetIssueByIndex", err)
+			return nil, nil
+		}
+		ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.not_exist"))
+		ctx.Redirect(issue.HTMLURL())
+		return nil, nil
+	}
+	return issue, subIssue
+}
+
+// AddSubIssue attaches an issue of the repository as a sub-issue of the issue
+func AddSubIssue(ctx *context.Context) {
+	issue, subIssue := getSubIssueAction(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.AddSubIssue(ctx, ctx.Doer, issue, subIssue); err != nil {
+		switch {
+		case issues_model.IsErrSubIssueHasParent(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.has_parent", subIssue.Index))
+		case issues_model.IsErrCircularSubIssue(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.circular"))
+		case issues_model.IsErrInvalidSubIssue(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.not_exist"))
+		default:
+			ctx.ServerError("AddSubIssue", err)
+			return
+		}
+	}
+	ctx.Redirect(issue.HTMLURL())
+}
+
+// RemoveSubIssue detaches a sub-issue from the issue
+func RemoveSubIssue(ctx *context.Context) {
+	issue, subIssue := getSubIssueAction(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := issues_model.RemoveSubIssue(ctx, issue, subIssue); err != nil && !issues_model.IsErrSubIssueNotExist(err) {
+		ctx.ServerError("RemoveSubIssue", err)
+		return
+	}
+	ctx.Redirect(issue.HTMLURL())
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"strconv"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+)
+
+// IssueVote adds or removes the vote of the signed in user for an issue
+func IssueVote(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if !ctx.Repo.CanReadIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	vote, err := strconv.ParseBool(ctx.Req.PostForm.Get("vote"))
+	if err != nil {
+		ctx.ServerError("vote is not bool", err)
+		return
+	}
+
+	if vote {
+		err = issues_model.VoteForIssue(ctx, ctx.Doer.ID, issue)
+	} else {
+		err = issues_model.RemoveIssueVote(ctx, ctx.Doer.ID, issue)
+	}
+	if err != nil {
+		ctx.ServerError("VoteForIssue", err)
+		return
+	}
+
+	ctx.Redirect(issue.HTMLURL())
+}
@@ -42,6 +42,8 @@ import (
 	"code.gitea.io/gitea/routers/utils"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
 	"code.gitea.io/gitea/services/automerge"
+	codescanning_service "code.gitea.io/gitea/services/codescanning"
+	coverage_service "code.gitea.io/gitea/services/coverage"
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/gitdiff"
 	pull_service "code.gitea.io/gitea/services/pull"
@@ -404,6 +406,10 @@ func PrepareMergedViewPullInfo(ctx *context.Context, issue *issues_model.Issue)
 			ctx.Data["LatestCommitStatuses"] = commitStatuses
 			ctx.Data["LatestCommitStatus"] = git_model.CalcCommitStatus(commitStatuses)
 		}
+
+		if setLatestDeployments(ctx, sha); ctx.Written() {
+			return nil
+		}
 	}
 
 	return compareInfo
@@ -466,6 +472,10 @@ func PrepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git.C
 			ctx.Data["LatestCommitStatus"] = git_model.CalcCommitStatus(commitStatuses)
 		}
 
+		if setLatestDeployments(ctx, sha); ctx.Written() {
+			return nil
+		}
+
 		compareInfo, err := baseGitRepo.GetCompareInfo(pull.BaseRepo.RepoPath(),
 			pull.MergeBase, pull.GetGitRefName(), false, false)
 		if err != nil {
@@ -558,15 +568,12 @@ func PrepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git.C
 		ctx.Data["LatestCommitStatus"] = git_model.CalcCommitStatus(commitStatuses)
 	}
 
+	if setLatestDeployments(ctx, sha); ctx.Written() {
+		return nil
+	}
+
 	if pull.ProtectedBranch != nil && pull.ProtectedBranch.EnableStatusCheck {
-		ctx.Data["is_context_required"] = func(context string) bool {
-			for _, c := range pull.ProtectedBranch.StatusCheckContexts {
-				if c == context {
-					return true
-				}
-			}
-			return false
-		}
+		ctx.Data["is_context_required"] = pull.ProtectedBranch.IsStatusCheckRequired
 		ctx.Data["RequiredStatusCheckState"] = pull_service.MergeRequiredContextsCommitStatus(commitStatuses, pull.ProtectedBranch.StatusCheckContexts)
 	}
 
@@ -757,6 +764,22 @@ func ViewPullFiles(ctx *context.Context) {
 	ctx.Data["Diff"] = diff
 	ctx.Data["DiffNotAvailable"] = diff.NumFiles == 0
 
+	if codescanning_service.CanReadAlerts(ctx.Repo.Permission) {
+		if ctx.Data["CodeScanningAlerts"], err = codescanning_service.GetCommitAlertsByPath(ctx, ctx.Repo.Repository.ID, headCommitID); err != nil {
+			ctx.ServerError("GetCommitAlertsByPath", err)
+			return
+		}
+	}
+
+	diffCoverage, err := coverage_service.GetPullRequestDiffCoverage(ctx, pull)
+	if err != nil {
+		ctx.ServerError("GetPullRequestDiffCoverage", err)
+		return
+	}
+	if diffCoverage != nil {
+		ctx.Data["DiffFileCoverage"] = diffCoverage.FilesByPath()
+	}
+
 	baseCommit, err := ctx.Repo.GitRepo.GetCommit(startCommitID)
 	if err != nil {
 		ctx.ServerError("GetCommit", err)
@@ -986,6 +1009,16 @@ func MergePullRequest(ctx *context.Context) {
 		message += "\n\n" + form.MergeMessageField
 	}
 
+	if err := pull_service.CheckMergeMessage(ctx.Repo.GitRepo, pr, repo_model.MergeStyle(form.Do), message); err != nil {
+		if pull_service.IsErrMergeMessageNotMatchTemplate(err) {
+			ctx.Flash.Error(ctx.Tr("repo.pulls.merge_message_not_match_template", err.(pull_service.ErrMergeMessageNotMatchTemplate).Expected))
+			ctx.Redirect(issue.Link())
+			return
+		}
+		ctx.ServerError("CheckMergeMessage", err)
+		return
+	}
+
 	if form.MergeWhenChecksSucceed {
 		// delete all scheduled auto merges
 		_ = pull_model.DeleteScheduledAutoMerge(ctx, pr.ID)
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"code.gitea.io/gitea/models"
+	attestation_model "code.gitea.io/gitea/models/attestation"
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
@@ -83,9 +84,11 @@ func releasesOrTags(ctx *context.Context, isTagList bool) {
 	if isTagList {
 		ctx.Data["Title"] = ctx.Tr("repo.release.tags")
 		ctx.Data["PageIsTagList"] = true
+		ctx.Data["FeedURL"] = ctx.Repo.Repository.HTMLURL() + "/tags"
 	} else {
 		ctx.Data["Title"] = ctx.Tr("repo.release.releases")
 		ctx.Data["PageIsTagList"] = false
+		ctx.Data["FeedURL"] = ctx.Repo.Repository.HTMLURL() + "/releases"
 	}
 
 	listOptions := db.ListOptions{
@@ -387,6 +390,8 @@ func NewReleasePost(ctx *context.Context) {
 				ctx.RenderWithErr(ctx.Tr("repo.release.tag_name_invalid"), tplReleaseNew, &form)
 			case models.IsErrProtectedTagName(err):
 				ctx.RenderWithErr(ctx.Tr("repo.release.tag_name_protected"), tplReleaseNew, &form)
+			case attestation_model.IsErrAttestationRequired(err):
+				ctx.RenderWithErr(ctx.Tr("repo.release.attestation_required"), tplReleaseNew, &form)
 			default:
 				ctx.ServerError("CreateRelease", err)
 			}
@@ -409,6 +414,10 @@ func NewReleasePost(ctx *context.Context) {
 
 		if err = releaseservice.UpdateRelease(ctx.Doer, ctx.Repo.GitRepo, rel, attachmentUUIDs, nil, nil); err != nil {
 			ctx.Data["Err_TagName"] = true
+			if attestation_model.IsErrAttestationRequired(err) {
+				ctx.RenderWithErr(ctx.Tr("repo.release.attestation_required"), tplReleaseNew, &form)
+				return
+			}
 			ctx.ServerError("UpdateRelease", err)
 			return
 		}
@@ -509,6 +518,10 @@ func EditReleasePost(ctx *context.Context) {
 	rel.IsPrerelease = form.Prerelease
 	if err = releaseservice.UpdateRelease(ctx.Doer, ctx.Repo.GitRepo,
 		rel, addAttachmentUUIDs, delAttachmentUUIDs, editAttachments); err != nil {
+		if attestation_model.IsErrAttestationRequired(err) {
+			ctx.RenderWithErr(ctx.Tr("repo.release.attestation_required"), tplReleaseNew, form)
+			return
+		}
 		ctx.ServerError("UpdateRelease", err)
 		return
 	}
@@ -21,6 +21,7 @@ import (
 	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/context"
 	"code.gitea.io/gitea/modules/convert"
+	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/log"
 	repo_module "code.gitea.io/gitea/modules/repository"
 	"code.gitea.io/gitea/modules/setting"
@@ -271,6 +272,10 @@ func CreatePost(ctx *context.Context) {
 		})
 		if err == nil {
 			log.Trace("Repository created [%d]: %s/%s", repo.ID, ctxUser.Name, repo.Name)
+			if repo.IsPrivate && !form.Private && !setting.Repository.ForcePrivate {
+				// the repository was made private while it is held for review by the spam detection
+				ctx.Flash.Info(ctx.Tr("repo.held_for_review"))
+			}
 			ctx.Redirect(repo.Link())
 			return
 		}
@@ -288,9 +293,9 @@ func Action(ctx *context.Context) {
 	case "unwatch":
 		err = repo_model.WatchRepo(ctx, ctx.Doer.ID, ctx.Repo.Repository.ID, false)
 	case "star":
-		err = repo_model.StarRepo(ctx.Doer.ID, ctx.Repo.Repository.ID, true)
+		err = repo_service.StarRepo(ctx, ctx.Doer, ctx.Repo.Repository, true)
 	case "unstar":
-		err = repo_model.StarRepo(ctx.Doer.ID, ctx.Repo.Repository.ID, false)
+		err = repo_service.StarRepo(ctx, ctx.Doer, ctx.Repo.Repository, false)
 	case "accept_transfer":
 		err = acceptOrRejectRepoTransfer(ctx, true)
 	case "reject_transfer":
@@ -314,6 +319,37 @@ func Action(ctx *context.Context) {
 	ctx.RedirectToFirst(ctx.FormString("redirect_to"), ctx.Repo.RepoLink)
 }
 
+// SyncForkPost synchronizes the default branch of a fork with the default branch of its upstream repository
+func SyncForkPost(ctx *context.Context) {
+	result, err := repo_service.SyncFork(ctx, ctx.Doer, ctx.Repo.Repository, false)
+	if err != nil {
+		switch {
+		case repo_service.IsErrRepoNotFork(err):
+			ctx.NotFound("SyncFork", err)
+			return
+		case repo_service.IsErrForkSyncConflict(err):
+			ctx.Flash.Error(ctx.Tr("repo.sync_fork.conflict", strings.Join(err.(repo_service.ErrForkSyncConflict).Files, ", ")))
+		case git.IsErrPushOutOfDate(err), git.IsErrPushRejected(err):
+			ctx.Flash.Error(ctx.Tr("repo.sync_fork.rejected"))
+		default:
+			ctx.ServerError("SyncFork", err)
+			return
+		}
+		ctx.Redirect(ctx.Repo.RepoLink)
+		return
+	}
+
+	switch result.Status {
+	case repo_service.ForkSyncUpToDate:
+		ctx.Flash.Info(ctx.Tr("repo.sync_fork.up_to_date"))
+	case repo_service.ForkSyncFastForward:
+		ctx.Flash.Success(ctx.Tr("repo.sync_fork.fast_forwarded", result.Behind))
+	default:
+		ctx.Flash.Success(ctx.Tr("repo.sync_fork.merged", result.Behind))
+	}
+	ctx.Redirect(ctx.Repo.RepoLink)
+}
+
 func acceptOrRejectRepoTransfer(ctx *context.Context, accept bool) error {
 	repoTransfer, err := models.GetPendingRepositoryTransfer(ctx.Repo.Repository)
 	if err != nil {
@@ -0,0 +1,245 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	comment_service "code.gitea.io/gitea/services/comments"
+	"code.gitea.io/gitea/services/forms"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+const (
+	tplServiceDesk      base.TplName = "repo/service_desk/list"
+	tplServiceDeskIssue base.TplName = "repo/service_desk/view"
+)
+
+// ServiceDeskAssignment assigns the repository of a service desk to the context, unlike the repository assignment it
+// doesn't require any access to the repository, only that its service desk is enabled
+func ServiceDeskAssignment(ctx *context.Context) {
+	owner, err := user_model.GetUserByName(ctx, ctx.Params(":username"))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.NotFound("GetUserByName", err)
+		} else {
+			ctx.ServerError("GetUserByName", err)
+		}
+		return
+	}
+
+	repo, err := repo_model.GetRepositoryByName(owner.ID, ctx.Params(":reponame"))
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.NotFound("GetRepositoryByName", err)
+		} else {
+			ctx.ServerError("GetRepositoryByName", err)
+		}
+		return
+	}
+	repo.Owner = owner
+
+	if !repo.IsServiceDeskEnabled(ctx) {
+		ctx.NotFound("IsServiceDeskEnabled", nil)
+		return
+	}
+	if blocked, err := user_model.IsBlocked(ctx, owner.ID, ctx.Doer.ID); err != nil {
+		ctx.ServerError("IsBlocked", err)
+		return
+	} else if blocked {
+		ctx.NotFound("IsBlocked", nil)
+		return
+	}
+
+	ctx.Repo.Permission, err = access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetUserRepoPermission", err)
+		return
+	}
+	ctx.Repo.Owner = owner
+	ctx.Repo.Repository = repo
+	ctx.Repo.RepoLink = repo.Link()
+
+	ctx.Data["Title"] = ctx.Tr("repo.service_desk.title", repo.FullName())
+	ctx.Data["ServiceDeskLink"] = repo.Link() + "/service_desk"
+	ctx.Data["ServiceDeskRepo"] = repo
+	ctx.Data["CanReadIssues"] = ctx.Repo.Permission.CanRead(unit.TypeIssues)
+}
+
+// ServiceDesk render the issues the doer filed through the service desk of a repository and the form to file one
+func ServiceDesk(ctx *context.Context) {
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+
+	opts := &issues_model.IssuesOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.IssuePagingNum},
+		RepoID:      ctx.Repo.Repository.ID,
+		PosterID:    ctx.Doer.ID,
+		IsPull:      util.OptionalBoolFalse,
+		IsClosed:    util.OptionalBoolNone,
+		SortType:    "newest",
+	}
+	issues, err := issues_model.Issues(opts)
+	if err != nil {
+		ctx.ServerError("Issues", err)
+		return
+	}
+	count, err := issues_model.CountIssues(opts)
+	if err != nil {
+		ctx.ServerError("CountIssues", err)
+		return
+	}
+	ctx.Data["Issues"] = issues
+
+	pager := context.NewPagination(int(count), setting.UI.IssuePagingNum, page, 5)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplServiceDesk)
+}
+
+// ServiceDeskPost files an issue through the service desk of a repository
+func ServiceDeskPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ServiceDeskIssueForm)
+	if ctx.Repo.Repository.IsArchived {
+		ctx.Flash.Error(ctx.Tr("repo.service_desk.archived"))
+		ctx.Redirect(ctx.Data["ServiceDeskLink"].(string))
+		return
+	}
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(ctx.Data["ServiceDeskLink"].(string))
+		return
+	}
+
+	issue := &issues_model.Issue{
+		RepoID:   ctx.Repo.Repository.ID,
+		Repo:     ctx.Repo.Repository,
+		Title:    form.Title,
+		PosterID: ctx.Doer.ID,
+		Poster:   ctx.Doer,
+		Content:  form.Content,
+	}
+	if err := issue_service.NewIssue(ctx.Repo.Repository, issue, nil, nil, nil); err != nil {
+		ctx.ServerError("NewIssue", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.service_desk.create_success"))
+	ctx.Redirect(issue.ServiceDeskLink())
+}
+
+// ServiceDeskIssue render an issue the doer filed through the service desk of a repository with its comments
+func ServiceDeskIssue(ctx *context.Context) {
+	issue := getServiceDeskIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	var err error
+	issue.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
+		URLPrefix: ctx.Repo.RepoLink,
+		Metas:     ctx.Repo.Repository.ComposeMetas(),
+		Ctx:       ctx,
+	}, issue.Content)
+	if err != nil {
+		ctx.ServerError("RenderString", err)
+		return
+	}
+
+	comments, err := issues_model.FindComments(ctx, &issues_model.FindCommentsOptions{
+		IssueID: issue.ID,
+		Type:    issues_model.CommentTypeComment,
+	})
+	if err != nil {
+		ctx.ServerError("FindComments", err)
+		return
+	}
+	visible := make(issues_model.CommentList, 0, len(comments))
+	for _, comment := range comments {
+		// the comments hidden by the moderators are only shown to their posters
+		if comment.IsHidden && comment.PosterID != ctx.Doer.ID {
+			continue
+		}
+		comment.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
+			URLPrefix: ctx.Repo.RepoLink,
+			Metas:     ctx.Repo.Repository.ComposeMetas(),
+			Ctx:       ctx,
+		}, comment.Content)
+		if err != nil {
+			ctx.ServerError("RenderString", err)
+			return
+		}
+		visible = append(visible, comment)
+	}
+	if err := visible.LoadPosters(); err != nil {
+		ctx.ServerError("LoadPosters", err)
+		return
+	}
+
+	ctx.Data["Title"] = issue.Title
+	ctx.Data["Issue"] = issue
+	ctx.Data["Comments"] = visible
+	ctx.HTML(http.StatusOK, tplServiceDeskIssue)
+}
+
+// ServiceDeskCommentPost replies to an issue the doer filed through the service desk of a repository
+func ServiceDeskCommentPost(ctx *context.Context) {
+	issue := getServiceDeskIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.ServiceDeskCommentForm)
+	if issue.IsClosed || ctx.Repo.Repository.IsArchived {
+		ctx.Flash.Error(ctx.Tr("repo.service_desk.closed"))
+		ctx.Redirect(issue.ServiceDeskLink())
+		return
+	}
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(issue.ServiceDeskLink())
+		return
+	}
+
+	if _, err := comment_service.CreateIssueComment(ctx.Doer, ctx.Repo.Repository, issue, form.Content, nil); err != nil {
+		ctx.ServerError("CreateIssueComment", err)
+		return
+	}
+	ctx.Redirect(issue.ServiceDeskLink())
+}
+
+// getServiceDeskIssue returns the issue of the context, it must have been filed by the doer
+func getServiceDeskIssue(ctx *context.Context) *issues_model.Issue {
+	issue, err := issues_model.GetIssueByIndex(ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound("GetIssueByIndex", err)
+		} else {
+			ctx.ServerError("GetIssueByIndex", err)
+		}
+		return nil
+	}
+	if issue.IsPull || issue.PosterID != ctx.Doer.ID {
+		ctx.NotFound("GetIssueByIndex", nil)
+		return nil
+	}
+	issue.Repo = ctx.Repo.Repository
+	return issue
+}
@@ -44,6 +44,7 @@ import (
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	org_service "code.gitea.io/gitea/services/org"
+	release_service "code.gitea.io/gitea/services/release"
 	repo_service "code.gitea.io/gitea/services/repository"
 	wiki_service "code.gitea.io/gitea/services/wiki"
 )
@@ -57,6 +58,11 @@ const (
 	tplGithookEdit     base.TplName = "repo/settings/githook_edit"
 	tplDeployKeys      base.TplName = "repo/settings/deploy_keys"
 	tplProtectedBranch base.TplName = "repo/settings/protected_branch"
+	tplModeration      base.TplName = "repo/settings/moderation"
+	tplIssueSchedules  base.TplName = "repo/settings/issue_schedules"
+	tplComponents      base.TplName = "repo/settings/components"
+	tplIssueImport     base.TplName = "repo/settings/issue_import"
+	tplIssueMirror     base.TplName = "repo/settings/issue_mirror"
 )
 
 // SettingsCtxData is a middleware that sets all the general context data for the
@@ -75,6 +81,16 @@ func SettingsCtxData(ctx *context.Context) {
 	ctx.Data["SigningSettings"] = setting.Repository.Signing
 	ctx.Data["CodeIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
 
+	ctx.Data["PartialCloneEnabled"] = !setting.Git.DisablePartialClone
+	ctx.Data["PartialCloneInstanceFilters"] = repo_model.InstancePartialCloneFilters()
+	ctx.Data["PartialCloneInstanceTreeMaxDepth"] = setting.Git.PartialCloneTreeMaxDepth
+	allowedFilters, _ := ctx.Repo.Repository.PartialCloneConfig()
+	partialCloneAllowed := make(map[string]bool, len(allowedFilters))
+	for _, filter := range allowedFilters {
+		partialCloneAllowed[filter] = true
+	}
+	ctx.Data["PartialCloneAllowed"] = partialCloneAllowed
+
 	if ctx.Doer.IsAdmin {
 		if setting.Indexer.RepoIndexerEnabled {
 			status, err := repo_model.GetIndexerStatus(ctx, ctx.Repo.Repository, repo_model.RepoIndexerTypeCode)
@@ -97,6 +113,15 @@ func SettingsCtxData(ctx *context.Context) {
 		return
 	}
 	ctx.Data["PushMirrors"] = pushMirrors
+
+	if ctx.Repo.Repository.IsFork {
+		forkSync, err := repo_model.GetForkSync(ctx, ctx.Repo.Repository.ID)
+		if err != nil {
+			ctx.ServerError("GetForkSync", err)
+			return
+		}
+		ctx.Data["ForkSync"] = forkSync
+	}
 }
 
 // Settings show a repository's settings page
@@ -398,6 +423,11 @@ func SettingsPost(ctx *context.Context) {
 			repoChanged = true
 		}
 
+		if repo.EnablePrivateReporting != form.EnablePrivateReporting {
+			repo.EnablePrivateReporting = form.EnablePrivateReporting
+			repoChanged = true
+		}
+
 		if form.EnableWiki && form.EnableExternalWiki && !unit_model.TypeExternalWiki.UnitGlobalDisabled() {
 			if !validation.IsValidExternalURL(form.ExternalWikiURL) {
 				ctx.Flash.Error(ctx.Tr("repo.settings.external_wiki_url_error"))
@@ -452,6 +482,19 @@ func SettingsPost(ctx *context.Context) {
 			})
 			deleteUnitTypes = append(deleteUnitTypes, unit_model.TypeIssues)
 		} else if form.EnableIssues && !form.EnableExternalTracker && !unit_model.TypeIssues.UnitGlobalDisabled() {
+			stalePolicy := repo_model.StalePolicy{
+				StaleDays:             form.StaleDays,
+				StaleCloseDays:        form.StaleCloseDays,
+				StaleLabel:            form.StaleLabel,
+				StaleExemptLabels:     form.StaleExemptLabels,
+				StaleExemptMilestones: form.StaleExemptMilestones,
+				StaleIncludePulls:     form.StaleIncludePulls,
+			}
+			if err := stalePolicy.Validate(); err != nil {
+				ctx.Flash.Error(ctx.Tr("repo.settings.stale_policy_error", err.(repo_model.ErrInvalidStalePolicy).Reason))
+				ctx.Redirect(repo.Link() + "/settings")
+				return
+			}
 			units = append(units, repo_model.RepoUnit{
 				RepoID: repo.ID,
 				Type:   unit_model.TypeIssues,
@@ -459,6 +502,8 @@ func SettingsPost(ctx *context.Context) {
 					EnableTimetracker:                form.EnableTimetracker,
 					AllowOnlyContributorsToTrackTime: form.AllowOnlyContributorsToTrackTime,
 					EnableDependencies:               form.EnableIssueDependencies,
+					StalePolicy:                      stalePolicy,
+					EnableServiceDesk:                form.EnableServiceDesk,
 				},
 			})
 			deleteUnitTypes = append(deleteUnitTypes, unit_model.TypeExternalTracker)
@@ -504,6 +549,12 @@ func SettingsPost(ctx *context.Context) {
 					AllowRebaseUpdate:             form.PullsAllowRebaseUpdate,
 					DefaultDeleteBranchAfterMerge: form.DefaultDeleteBranchAfterMerge,
 					DefaultMergeStyle:             repo_model.MergeStyle(form.PullsDefaultMergeStyle),
+					ReviewReminderDays:            form.PullsReviewReminderDays,
+					ReviewEscalationDays:          form.PullsReviewEscalationDays,
+					ApprovalExpiryDays:            form.PullsApprovalExpiryDays,
+					DefaultMergeMessageTemplate:   strings.TrimSpace(form.PullsDefaultMergeMessageTemplate),
+					DefaultSquashMessageTemplate:  strings.TrimSpace(form.PullsDefaultSquashMessageTemplate),
+					EnforceMergeMessageTemplate:   form.PullsEnforceMergeMessageTemplate,
 				},
 			})
 		} else if !unit_model.TypePullRequests.UnitGlobalDisabled() {
@@ -544,6 +595,124 @@ func SettingsPost(ctx *context.Context) {
 		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
 		ctx.Redirect(ctx.Repo.RepoLink + "/settings")
 
+	case "commit_identity":
+		domains := make([]string, 0, 2)
+		for _, domain := range strings.Split(form.CommitEmailDomains, ",") {
+			domain = strings.ToLower(strings.TrimSpace(domain))
+			if domain == "" {
+				continue
+			}
+			if !organization.IsValidDomain(domain) {
+				ctx.Flash.Error(ctx.Tr("repo.settings.commit_identity.domains_error", domain))
+				ctx.Redirect(repo.Link() + "/settings")
+				return
+			}
+			domains = append(domains, domain)
+		}
+		repo.EnforceCommitIdentity = form.EnforceCommitIdentity
+		repo.CommitEmailDomains = strings.Join(domains, ",")
+
+		if err := repo_service.UpdateRepository(repo, false); err != nil {
+			ctx.ServerError("UpdateRepository", err)
+			return
+		}
+		log.Trace("Repository commit identity settings updated: %s/%s", ctx.Repo.Owner.Name, repo.Name)
+
+		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings")
+
+	case "partial_clone":
+		if setting.Git.DisablePartialClone {
+			ctx.NotFound("", nil)
+			return
+		}
+		if form.PartialCloneTreeMaxDepth < 0 {
+			ctx.Flash.Error(ctx.Tr("repo.settings.partial_clone.tree_max_depth_error"))
+			ctx.Redirect(repo.Link() + "/settings")
+			return
+		}
+
+		instanceFilters := repo_model.InstancePartialCloneFilters()
+		filters := make([]string, 0, len(instanceFilters))
+		for _, filter := range instanceFilters {
+			if util.IsStringInSlice(filter, form.PartialCloneFilters) {
+				filters = append(filters, filter)
+			}
+		}
+		switch {
+		case len(filters) == len(instanceFilters):
+			// follow the filters of the instance
+			repo.PartialCloneFilters = ""
+		case len(filters) == 0:
+			// not a filter, so that none of them is allowed
+			repo.PartialCloneFilters = "none"
+		default:
+			repo.PartialCloneFilters = strings.Join(filters, ",")
+		}
+		repo.PartialCloneTreeMaxDepth = form.PartialCloneTreeMaxDepth
+
+		if err := repo_service.UpdateRepository(repo, false); err != nil {
+			ctx.ServerError("UpdateRepository", err)
+			return
+		}
+		log.Trace("Repository partial clone settings updated: %s/%s", ctx.Repo.Owner.Name, repo.Name)
+
+		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings")
+
+	case "release_drafter":
+		releasesUnit, err := repo.GetUnit(unit_model.TypeReleases)
+		if err != nil {
+			ctx.NotFound("", nil)
+			return
+		}
+		tagName := strings.TrimSpace(form.ReleaseDraftTagName)
+		if tagName != "" && !git.IsValidRefPattern(tagName) {
+			ctx.Flash.Error(ctx.Tr("repo.settings.release_drafter.tag_name_error"))
+			ctx.Redirect(repo.Link() + "/settings")
+			return
+		}
+
+		releasesUnit.Config = &repo_model.ReleasesConfig{
+			EnableReleaseDrafter: form.EnableReleaseDrafter,
+			ReleaseDraftTagName:  tagName,
+			ReleaseDraftTemplate: form.ReleaseDraftTemplate,
+			ChangelogCategories:  form.ChangelogCategories,
+			RequireAttestations:  form.RequireAttestations,
+		}
+		if err := repo_model.UpdateRepoUnit(releasesUnit); err != nil {
+			ctx.ServerError("UpdateRepoUnit", err)
+			return
+		}
+		if err := release_service.UpdateReleaseDraft(ctx, repo, ctx.Doer); err != nil {
+			ctx.ServerError("UpdateReleaseDraft", err)
+			return
+		}
+		log.Trace("Repository release drafter settings updated: %s/%s", ctx.Repo.Owner.Name, repo.Name)
+
+		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings")
+
+	case "fork_sync":
+		if !repo.IsFork {
+			ctx.NotFound("", nil)
+			return
+		}
+		var err error
+		if form.EnableForkSync {
+			err = repo_model.EnableForkSync(ctx, repo.ID, ctx.Doer.ID)
+		} else {
+			err = repo_model.DisableForkSync(ctx, repo.ID)
+		}
+		if err != nil {
+			ctx.ServerError("UpdateForkSync", err)
+			return
+		}
+		log.Trace("Repository fork synchronization settings updated: %s/%s", ctx.Repo.Owner.Name, repo.Name)
+
+		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings")
+
 	case "admin":
 		if !ctx.Doer.IsAdmin {
 			ctx.Error(http.StatusForbidden)
@@ -876,6 +1045,15 @@ func Collaboration(ctx *context.Context) {
 	ctx.Data["Org"] = ctx.Repo.Repository.Owner
 	ctx.Data["Units"] = unit_model.Units
 
+	if ctx.Repo.Owner.IsOrganization() {
+		roles, err := organization.GetOrgRoles(ctx, ctx.Repo.Owner.ID)
+		if err != nil {
+			ctx.ServerError("GetOrgRoles", err)
+			return
+		}
+		ctx.Data["Roles"] = roles
+	}
+
 	ctx.HTML(http.StatusOK, tplCollaboration)
 }
 
@@ -930,8 +1108,17 @@ func CollaborationPost(ctx *context.Context) {
 	ctx.Redirect(setting.AppSubURL + ctx.Req.URL.EscapedPath())
 }
 
-// ChangeCollaborationAccessMode response for changing access of a collaboration
+// ChangeCollaborationAccessMode response for changing access of a collaboration,
+// the mode is either an access mode or "role-" followed by the id of a role of the organization
 func ChangeCollaborationAccessMode(ctx *context.Context) {
+	if mode := ctx.FormString("mode"); strings.HasPrefix(mode, "role-") {
+		roleID, _ := strconv.ParseInt(strings.TrimPrefix(mode, "role-"), 10, 64)
+		if err := models.SetCollaborationRole(ctx.Repo.Repository, ctx.FormInt64("uid"), roleID); err != nil {
+			log.Error("SetCollaborationRole: %v", err)
+		}
+		return
+	}
+
 	if err := repo_model.ChangeCollaborationAccessMode(
 		ctx.Repo.Repository,
 		ctx.FormInt64("uid"),
@@ -0,0 +1,220 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/forms"
+)
+
+// ComponentsSettings render the page to manage the components of a repository
+func ComponentsSettings(ctx *context.Context) {
+	if setComponentsContext(ctx) != nil {
+		return
+	}
+	ctx.Data["notify_owners"] = true
+
+	ctx.HTML(http.StatusOK, tplComponents)
+}
+
+// NewComponentPost handles the creation of a component
+func NewComponentPost(ctx *context.Context) {
+	if setComponentsContext(ctx) != nil {
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplComponents)
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.ComponentForm)
+	c := &issues_model.Component{RepoID: ctx.Repo.Repository.ID}
+	applyComponentForm(c, form)
+
+	if err := issues_model.CreateComponent(ctx, c); err != nil {
+		if !renderComponentError(ctx, err, form) {
+			ctx.ServerError("CreateComponent", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.components.create_success", c.Name))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/components")
+}
+
+// EditComponent render the page to edit a component
+func EditComponent(ctx *context.Context) {
+	if setComponentsContext(ctx) != nil {
+		return
+	}
+
+	c := selectComponentByContext(ctx)
+	if c == nil {
+		return
+	}
+	ctx.Data["PageIsEditComponent"] = true
+	ctx.Data["name"] = c.Name
+	ctx.Data["description"] = c.Description
+	ctx.Data["paths"] = strings.Join(c.Paths, "\n")
+	ctx.Data["owner_users"] = strings.Join(base.Int64sToStrings(c.OwnerUserIDs), ",")
+	ctx.Data["owner_teams"] = strings.Join(base.Int64sToStrings(c.OwnerTeamIDs), ",")
+	ctx.Data["labels"] = strings.Join(base.Int64sToStrings(c.LabelIDs), ",")
+	ctx.Data["notify_owners"] = c.NotifyOwners
+
+	ctx.HTML(http.StatusOK, tplComponents)
+}
+
+// EditComponentPost handles the update of a component
+func EditComponentPost(ctx *context.Context) {
+	if setComponentsContext(ctx) != nil {
+		return
+	}
+
+	ctx.Data["PageIsEditComponent"] = true
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplComponents)
+		return
+	}
+
+	c := selectComponentByContext(ctx)
+	if c == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.ComponentForm)
+	applyComponentForm(c, form)
+
+	if err := issues_model.UpdateComponent(ctx, c); err != nil {
+		if !renderComponentError(ctx, err, form) {
+			ctx.ServerError("UpdateComponent", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/components")
+}
+
+// DeleteComponentPost handles the deletion of a component
+func DeleteComponentPost(ctx *context.Context) {
+	c := selectComponentByContext(ctx)
+	if c == nil {
+		return
+	}
+
+	if err := issues_model.DeleteComponent(ctx, c.RepoID, c.ID); err != nil {
+		ctx.ServerError("DeleteComponent", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.components.delete_success", c.Name))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/components")
+}
+
+// applyComponentForm copies a submitted form to a component, the paths are given one per line
+func applyComponentForm(c *issues_model.Component, form *forms.ComponentForm) {
+	c.Name = form.Name
+	c.Description = form.Description
+	c.Paths = strings.Split(strings.ReplaceAll(form.Paths, "\r", ""), "\n")
+	c.OwnerUserIDs = splitComponentFormIDs(form.OwnerUsers)
+	c.OwnerTeamIDs = splitComponentFormIDs(form.OwnerTeams)
+	c.LabelIDs = splitComponentFormIDs(form.Labels)
+	c.NotifyOwners = form.NotifyOwners
+}
+
+func splitComponentFormIDs(s string) []int64 {
+	ids := make([]int64, 0, 2)
+	if strings.TrimSpace(s) != "" {
+		ids, _ = base.StringsToInt64s(strings.Split(s, ","))
+	}
+	return ids
+}
+
+// renderComponentError renders the errors of the creation or the update of a component caused by the form, it
+// returns false for the other errors
+func renderComponentError(ctx *context.Context, err error, form *forms.ComponentForm) bool {
+	switch {
+	case issues_model.IsErrComponentAlreadyExist(err):
+		ctx.Data["Err_Name"] = true
+		ctx.RenderWithErr(ctx.Tr("repo.settings.components.name_taken", form.Name), tplComponents, form)
+	case issues_model.IsErrInvalidComponentPath(err):
+		ctx.Data["Err_Paths"] = true
+		ctx.RenderWithErr(ctx.Tr("repo.settings.components.invalid_path", err.(issues_model.ErrInvalidComponentPath).Path), tplComponents, form)
+	default:
+		return false
+	}
+	return true
+}
+
+func setComponentsContext(ctx *context.Context) error {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.components")
+	ctx.Data["PageIsSettingsComponents"] = true
+
+	components, err := issues_model.GetComponents(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetComponents", err)
+		return err
+	}
+	ctx.Data["Components"] = components
+
+	users, err := repo_model.GetRepoAssignees(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.ServerError("GetRepoAssignees", err)
+		return err
+	}
+	ctx.Data["Users"] = users
+
+	labels, err := issues_model.GetLabelsByRepoID(ctx, ctx.Repo.Repository.ID, "", db.ListOptions{})
+	if err != nil {
+		ctx.ServerError("GetLabelsByRepoID", err)
+		return err
+	}
+	if ctx.Repo.Owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, ctx.Repo.Owner.ID, "", db.ListOptions{})
+		if err != nil {
+			ctx.ServerError("GetLabelsByOrgID", err)
+			return err
+		}
+		labels = append(labels, orgLabels...)
+
+		teams, err := organization.FindOrgTeams(ctx, ctx.Repo.Owner.ID)
+		if err != nil {
+			ctx.ServerError("FindOrgTeams", err)
+			return err
+		}
+		ctx.Data["Teams"] = teams
+	}
+	ctx.Data["Labels"] = labels
+	return nil
+}
+
+func selectComponentByContext(ctx *context.Context) *issues_model.Component {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.ParamsInt64(":id")
+	}
+
+	c, err := issues_model.GetComponentByID(ctx, ctx.Repo.Repository.ID, id)
+	if err != nil {
+		if issues_model.IsErrComponentNotExist(err) {
+			ctx.NotFound("GetComponentByID", err)
+		} else {
+			ctx.ServerError("GetComponentByID", err)
+		}
+		return nil
+	}
+	return c
+}
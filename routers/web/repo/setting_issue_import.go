@@ -0,0 +1,174 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/forms"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// maxIssueImportFileSize is the maximum size of an uploaded CSV file to import the issues from
+const maxIssueImportFileSize = 10 * 1024 * 1024
+
+// issueImportColumn is a column of an uploaded CSV file and the field of the issues it's mapped to
+type issueImportColumn struct {
+	Header string
+	Sample string
+	Field  issue_service.ImportField
+}
+
+// IssueImport render the page to export the issues of a repository and to import issues from a CSV file
+func IssueImport(ctx *context.Context) {
+	setIssueImportContext(ctx)
+	ctx.HTML(http.StatusOK, tplIssueImport)
+}
+
+// IssueImportUploadPost reads an uploaded CSV file and renders the mapping of its columns to the fields of the issues
+func IssueImportUploadPost(ctx *context.Context) {
+	setIssueImportContext(ctx)
+
+	form := web.GetForm(ctx).(*forms.IssueImportUploadForm)
+	if form.File == nil {
+		ctx.Flash.Error(ctx.Tr("repo.settings.issue_import.no_file"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_import")
+		return
+	}
+	if form.File.Size > maxIssueImportFileSize {
+		ctx.Flash.Error(ctx.Tr("repo.settings.issue_import.file_too_large"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_import")
+		return
+	}
+
+	r, err := form.File.Open()
+	if err != nil {
+		ctx.ServerError("Open", err)
+		return
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		ctx.ServerError("ReadAll", err)
+		return
+	}
+
+	renderIssueImportMapping(ctx, string(content), nil)
+}
+
+// IssueImportPost creates the issues of an uploaded CSV file with the submitted mapping of its columns
+func IssueImportPost(ctx *context.Context) {
+	setIssueImportContext(ctx)
+
+	form := web.GetForm(ctx).(*forms.IssueImportForm)
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_import")
+		return
+	}
+
+	mapping := make(issue_service.ImportMapping, len(form.Columns))
+	for i, column := range form.Columns {
+		if i < len(form.Fields) && form.Fields[i] != "" {
+			mapping[column] = issue_service.ImportField(form.Fields[i])
+		}
+	}
+
+	f, err := issue_service.ReadImportFile(strings.NewReader(form.Content))
+	if err != nil {
+		if issue_service.IsErrIssueImport(err) {
+			ctx.Flash.Error(ctx.Tr("repo.settings.issue_import.invalid_file", err.Error()))
+			ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_import")
+			return
+		}
+		ctx.ServerError("ReadImportFile", err)
+		return
+	}
+
+	issues, err := issue_service.ImportIssues(ctx, ctx.Repo.Repository, ctx.Doer, f, mapping)
+	if err != nil {
+		if issue_service.IsErrIssueImport(err) {
+			ctx.Flash.Error(ctx.Tr("repo.settings.issue_import.invalid_file", err.Error()), true)
+			renderIssueImportMapping(ctx, form.Content, mapping)
+			return
+		}
+		ctx.ServerError("ImportIssues", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.issue_import.success", len(issues)))
+	ctx.Redirect(ctx.Repo.RepoLink + "/issues")
+}
+
+// IssueExport exports all the issues of a repository as CSV
+func IssueExport(ctx *context.Context) {
+	format := issue_service.ExportFormatCSV
+	ctx.Resp.Header().Set("Content-Type", format.ContentType())
+	ctx.Resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-issues.%s"`, ctx.Repo.Repository.Name, format))
+	ctx.Resp.WriteHeader(http.StatusOK)
+	if err := issue_service.ExportIssues(ctx.Resp, format, &issues_model.IssuesOptions{
+		RepoID:   ctx.Repo.Repository.ID,
+		IsClosed: util.OptionalBoolNone,
+		IsPull:   util.OptionalBoolFalse,
+	}); err != nil {
+		log.Error("ExportIssues[%s]: %v", ctx.Repo.Repository.FullName(), err)
+	}
+}
+
+// renderIssueImportMapping renders the step mapping the columns of an uploaded CSV file to the fields of the issues,
+// the columns are mapped by their headers if mapping is nil
+func renderIssueImportMapping(ctx *context.Context, content string, mapping issue_service.ImportMapping) {
+	f, err := issue_service.ReadImportFile(strings.NewReader(content))
+	if err != nil {
+		if issue_service.IsErrIssueImport(err) {
+			ctx.Flash.Error(ctx.Tr("repo.settings.issue_import.invalid_file", err.Error()))
+			ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_import")
+			return
+		}
+		ctx.ServerError("ReadImportFile", err)
+		return
+	}
+	if len(f.Records) == 0 {
+		ctx.Flash.Error(ctx.Tr("repo.settings.issue_import.no_issues"))
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_import")
+		return
+	}
+	if mapping == nil {
+		mapping = issue_service.DefaultImportMapping(f.Headers)
+	}
+
+	columns := make([]*issueImportColumn, len(f.Headers))
+	for i, header := range f.Headers {
+		columns[i] = &issueImportColumn{
+			Header: header,
+			Field:  mapping[header],
+		}
+		if i < len(f.Records[0]) {
+			columns[i].Sample = f.Records[0][i]
+		}
+	}
+
+	ctx.Data["PageIsIssueImportMapping"] = true
+	ctx.Data["ImportContent"] = content
+	ctx.Data["ImportColumns"] = columns
+	ctx.Data["ImportFields"] = issue_service.ImportFields
+	ctx.Data["ImportCount"] = len(f.Records)
+	ctx.HTML(http.StatusOK, tplIssueImport)
+}
+
+func setIssueImportContext(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.issue_import")
+	ctx.Data["PageIsSettingsIssueImport"] = true
+	ctx.Data["MaxImportIssues"] = issue_service.MaxImportIssues
+}
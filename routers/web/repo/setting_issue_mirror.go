@@ -0,0 +1,116 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/forms"
+	"code.gitea.io/gitea/services/migrations"
+)
+
+// MustEnableIssueMirrors hides the issue mirror settings when the site administrator has disabled migrations
+func MustEnableIssueMirrors(ctx *context.Context) {
+	if setting.Repository.DisableMigrations {
+		ctx.NotFound("MustEnableIssueMirrors", nil)
+	}
+}
+
+// IssueMirrorSettings render the page to mirror the issue tracker of an external repository
+func IssueMirrorSettings(ctx *context.Context) {
+	if setIssueMirrorContext(ctx); ctx.Written() {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplIssueMirror)
+}
+
+// IssueMirrorSettingsPost sets up or changes the issue mirror of a repository
+func IssueMirrorSettingsPost(ctx *context.Context) {
+	m := setIssueMirrorContext(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.IssueMirrorForm)
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplIssueMirror)
+		return
+	}
+
+	token := form.AuthToken
+	// an empty token keeps the current one, as long as the external repository doesn't change
+	if token == "" && m != nil && m.RemoteAddress == form.RemoteAddress {
+		var err error
+		if token, err = m.AuthToken(); err != nil {
+			ctx.ServerError("AuthToken", err)
+			return
+		}
+	}
+
+	m, err := migrations.SetupIssueMirror(ctx, ctx.Doer, ctx.Repo.Repository, form.Service, form.RemoteAddress, token)
+	if err != nil {
+		switch {
+		case migrations.IsErrIssueMirrorServiceNotSupported(err):
+			ctx.Data["Err_Service"] = true
+			ctx.RenderWithErr(ctx.Tr("repo.settings.issue_mirror.service_not_supported"), tplIssueMirror, form)
+		case models.IsErrInvalidCloneAddr(err):
+			ctx.Data["Err_RemoteAddress"] = true
+			ctx.RenderWithErr(ctx.Tr("repo.settings.issue_mirror.address_not_allowed"), tplIssueMirror, form)
+		default:
+			ctx.ServerError("SetupIssueMirror", err)
+		}
+		return
+	}
+	migrations.StartIssueMirrorSync(m)
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.issue_mirror.saved"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_mirror")
+}
+
+// SyncIssueMirrorPost starts the synchronization of the issue mirror of a repository
+func SyncIssueMirrorPost(ctx *context.Context) {
+	m, err := issues_model.GetIssueMirror(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetIssueMirror", err)
+		return
+	} else if m == nil {
+		ctx.NotFound("GetIssueMirror", nil)
+		return
+	}
+	migrations.StartIssueMirrorSync(m)
+
+	ctx.Flash.Info(ctx.Tr("repo.settings.issue_mirror.sync_started"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_mirror")
+}
+
+// DeleteIssueMirrorPost stops mirroring the issues of a repository
+func DeleteIssueMirrorPost(ctx *context.Context) {
+	if err := issues_model.DeleteIssueMirror(ctx, ctx.Repo.Repository.ID); err != nil {
+		ctx.ServerError("DeleteIssueMirror", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.issue_mirror.deleted"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_mirror")
+}
+
+func setIssueMirrorContext(ctx *context.Context) *issues_model.IssueMirror {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.issue_mirror")
+	ctx.Data["PageIsSettingsIssueMirror"] = true
+	ctx.Data["IssueMirrorServices"] = issues_model.IssueMirrorServices
+
+	m, err := issues_model.GetIssueMirror(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetIssueMirror", err)
+		return nil
+	}
+	ctx.Data["IssueMirror"] = m
+	return m
+}
@@ -0,0 +1,208 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/forms"
+)
+
+// IssueSchedules render the page to manage the recurring issues of a repository
+func IssueSchedules(ctx *context.Context) {
+	if setIssueSchedulesContext(ctx) != nil {
+		return
+	}
+	ctx.Data["is_active"] = true
+
+	ctx.HTML(http.StatusOK, tplIssueSchedules)
+}
+
+// NewIssueSchedulePost handles the creation of a recurring issue
+func NewIssueSchedulePost(ctx *context.Context) {
+	if setIssueSchedulesContext(ctx) != nil {
+		return
+	}
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplIssueSchedules)
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.IssueScheduleForm)
+	s := &issues_model.IssueSchedule{
+		RepoID:    ctx.Repo.Repository.ID,
+		CreatorID: ctx.Doer.ID,
+	}
+	if !applyIssueScheduleForm(ctx, s, form) {
+		return
+	}
+
+	if err := issues_model.CreateIssueSchedule(ctx, s); err != nil {
+		ctx.ServerError("CreateIssueSchedule", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.issue_schedules.create_success", s.Title))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_schedules")
+}
+
+// EditIssueSchedule render the page to edit a recurring issue
+func EditIssueSchedule(ctx *context.Context) {
+	if setIssueSchedulesContext(ctx) != nil {
+		return
+	}
+
+	s := selectIssueScheduleByContext(ctx)
+	if s == nil {
+		return
+	}
+	ctx.Data["PageIsEditIssueSchedule"] = true
+	ctx.Data["title"] = s.Title
+	ctx.Data["content"] = s.Content
+	ctx.Data["template_file"] = s.TemplateFile
+	ctx.Data["schedule"] = s.Schedule
+	ctx.Data["assignees"] = strings.Join(base.Int64sToStrings(s.AssigneeIDs), ",")
+	ctx.Data["labels"] = strings.Join(base.Int64sToStrings(s.LabelIDs), ",")
+	ctx.Data["is_active"] = s.IsActive
+
+	ctx.HTML(http.StatusOK, tplIssueSchedules)
+}
+
+// EditIssueSchedulePost handles the update of a recurring issue
+func EditIssueSchedulePost(ctx *context.Context) {
+	if setIssueSchedulesContext(ctx) != nil {
+		return
+	}
+
+	ctx.Data["PageIsEditIssueSchedule"] = true
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplIssueSchedules)
+		return
+	}
+
+	s := selectIssueScheduleByContext(ctx)
+	if s == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.IssueScheduleForm)
+	if !applyIssueScheduleForm(ctx, s, form) {
+		return
+	}
+
+	if err := issues_model.UpdateIssueSchedule(ctx, s); err != nil {
+		ctx.ServerError("UpdateIssueSchedule", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_schedules")
+}
+
+// DeleteIssueSchedulePost handles the deletion of a recurring issue
+func DeleteIssueSchedulePost(ctx *context.Context) {
+	s := selectIssueScheduleByContext(ctx)
+	if s == nil {
+		return
+	}
+
+	if err := issues_model.DeleteIssueSchedule(ctx, s.RepoID, s.ID); err != nil {
+		ctx.ServerError("DeleteIssueSchedule", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.issue_schedules.delete_success", s.Title))
+	ctx.Redirect(ctx.Repo.RepoLink + "/settings/issue_schedules")
+}
+
+// applyIssueScheduleForm copies a submitted form to a recurring issue, it renders the error and returns false if the
+// schedule isn't valid
+func applyIssueScheduleForm(ctx *context.Context, s *issues_model.IssueSchedule, form *forms.IssueScheduleForm) bool {
+	schedule := strings.TrimSpace(form.Schedule)
+	if err := issues_model.ValidateIssueSchedule(schedule); err != nil {
+		ctx.Data["Err_Schedule"] = true
+		ctx.RenderWithErr(ctx.Tr("repo.settings.issue_schedules.invalid_schedule", err.Error()), tplIssueSchedules, form)
+		return false
+	}
+
+	s.Title = strings.TrimSpace(form.Title)
+	s.Content = form.Content
+	s.TemplateFile = form.TemplateFile
+	s.Schedule = schedule
+	s.AssigneeIDs = make([]int64, 0, 2)
+	if strings.TrimSpace(form.Assignees) != "" {
+		s.AssigneeIDs, _ = base.StringsToInt64s(strings.Split(form.Assignees, ","))
+	}
+	s.LabelIDs = make([]int64, 0, 2)
+	if strings.TrimSpace(form.Labels) != "" {
+		s.LabelIDs, _ = base.StringsToInt64s(strings.Split(form.Labels, ","))
+	}
+	s.IsActive = form.IsActive
+	return true
+}
+
+func setIssueSchedulesContext(ctx *context.Context) error {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.issue_schedules")
+	ctx.Data["PageIsSettingsIssueSchedules"] = true
+
+	schedules, err := issues_model.GetIssueSchedules(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetIssueSchedules", err)
+		return err
+	}
+	ctx.Data["IssueSchedules"] = schedules
+
+	assignees, err := repo_model.GetRepoAssignees(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.ServerError("GetRepoAssignees", err)
+		return err
+	}
+	ctx.Data["Assignees"] = assignees
+
+	labels, err := issues_model.GetLabelsByRepoID(ctx, ctx.Repo.Repository.ID, "", db.ListOptions{})
+	if err != nil {
+		ctx.ServerError("GetLabelsByRepoID", err)
+		return err
+	}
+	if ctx.Repo.Owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, ctx.Repo.Owner.ID, "", db.ListOptions{})
+		if err != nil {
+			ctx.ServerError("GetLabelsByOrgID", err)
+			return err
+		}
+		labels = append(labels, orgLabels...)
+	}
+	ctx.Data["Labels"] = labels
+
+	ctx.Data["IssueTemplates"] = ctx.IssueTemplatesFromDefaultBranch()
+	return nil
+}
+
+func selectIssueScheduleByContext(ctx *context.Context) *issues_model.IssueSchedule {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.ParamsInt64(":id")
+	}
+
+	s, err := issues_model.GetIssueScheduleByID(ctx, ctx.Repo.Repository.ID, id)
+	if err != nil {
+		if issues_model.IsErrIssueScheduleNotExist(err) {
+			ctx.NotFound("GetIssueScheduleByID", err)
+		} else {
+			ctx.ServerError("GetIssueScheduleByID", err)
+		}
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,39 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/context"
+	user_setting "code.gitea.io/gitea/routers/web/user/setting"
+)
+
+// Moderation render the interaction limit of the repository
+func Moderation(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.interaction_limit")
+	ctx.Data["PageIsSettingsModeration"] = true
+	ctx.Data["ModerationLink"] = ctx.Repo.RepoLink + "/settings/moderation"
+
+	if !user_setting.PrepareInteractionLimit(ctx, 0, ctx.Repo.Repository.ID) {
+		return
+	}
+	ownerLimit, err := repo_model.GetInteractionLimit(ctx, ctx.Repo.Repository.OwnerID, 0)
+	if err != nil {
+		ctx.ServerError("GetInteractionLimit", err)
+		return
+	}
+	ctx.Data["OwnerInteractionLimit"] = ownerLimit
+
+	ctx.HTML(http.StatusOK, tplModeration)
+}
+
+// ModerationPost response for limiting the interactions in the repository
+func ModerationPost(ctx *context.Context) {
+	if user_setting.UpdateInteractionLimit(ctx, 0, ctx.Repo.Repository.ID) {
+		ctx.Redirect(ctx.Repo.RepoLink + "/settings/moderation")
+	}
+}
@@ -5,11 +5,13 @@
 package repo
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"code.gitea.io/gitea/models"
 	git_model "code.gitea.io/gitea/models/git"
 	"code.gitea.io/gitea/models/organization"
 	"code.gitea.io/gitea/models/perm"
@@ -95,6 +97,36 @@ func ProtectedBranchPost(ctx *context.Context) {
 
 		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
 		ctx.Redirect(setting.AppSubURL + ctx.Req.URL.EscapedPath())
+	case "rename_default_branch":
+		from := repo.DefaultBranch
+		to := ctx.FormTrim("to")
+		if to == "" || len(to) > 100 || !git.IsValidRefPattern(to) {
+			ctx.Flash.Error(ctx.Tr("repo.settings.rename_branch_to") + ctx.Tr("form.git_ref_name_error"))
+			ctx.Redirect(setting.AppSubURL + ctx.Req.URL.EscapedPath())
+			return
+		}
+
+		result, err := repository.RenameDefaultBranch(ctx, ctx.Doer, repo, to)
+		if err != nil {
+			switch {
+			case errors.Is(err, repository.ErrDefaultBranchNotRenamable):
+				ctx.Flash.Error(ctx.Tr("repo.settings.rename_default_branch_not_renamable"))
+			case models.IsErrTagAlreadyExists(err):
+				ctx.Flash.Error(ctx.Tr("repo.branch.tag_collision", to))
+			case models.IsErrBranchAlreadyExists(err):
+				ctx.Flash.Error(ctx.Tr("repo.settings.rename_branch_failed_exist", to))
+			case models.IsErrBranchNameConflict(err):
+				ctx.Flash.Error(ctx.Tr("repo.branch.branch_name_conflict", to, err.(models.ErrBranchNameConflict).BranchName))
+			default:
+				ctx.ServerError("RenameDefaultBranch", err)
+				return
+			}
+			ctx.Redirect(setting.AppSubURL + ctx.Req.URL.EscapedPath())
+			return
+		}
+
+		ctx.Flash.Success(ctx.Tr("repo.settings.rename_default_branch_success", from, to, result.RetargetedPullRequests, result.UpdatedWebhooks))
+		ctx.Redirect(setting.AppSubURL + ctx.Req.URL.EscapedPath())
 	default:
 		ctx.NotFound("", nil)
 	}
@@ -136,7 +168,12 @@ func SettingsProtectedBranch(c *context.Context) {
 	c.Data["merge_whitelist_users"] = strings.Join(base.Int64sToStrings(protectBranch.MergeWhitelistUserIDs), ",")
 	c.Data["approvals_whitelist_users"] = strings.Join(base.Int64sToStrings(protectBranch.ApprovalsWhitelistUserIDs), ",")
 	contexts, _ := git_model.FindRepoRecentCommitStatusContexts(c.Repo.Repository.ID, 7*24*time.Hour) // Find last week status check contexts
+	statusCheckPatterns := make([]string, 0, len(protectBranch.StatusCheckContexts))
 	for _, ctx := range protectBranch.StatusCheckContexts {
+		if git_model.IsStatusCheckPattern(ctx) {
+			statusCheckPatterns = append(statusCheckPatterns, ctx)
+			continue
+		}
 		var found bool
 		for i := range contexts {
 			if contexts[i] == ctx {
@@ -150,6 +187,7 @@ func SettingsProtectedBranch(c *context.Context) {
 	}
 
 	c.Data["branch_status_check_contexts"] = contexts
+	c.Data["status_check_patterns"] = strings.Join(statusCheckPatterns, "\n")
 	c.Data["is_context_required"] = func(context string) bool {
 		for _, c := range protectBranch.StatusCheckContexts {
 			if c == context {
@@ -239,7 +277,20 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 
 		protectBranch.EnableStatusCheck = f.EnableStatusCheck
 		if f.EnableStatusCheck {
-			protectBranch.StatusCheckContexts = f.StatusCheckContexts
+			statusCheckContexts := f.StatusCheckContexts
+			for _, pattern := range strings.Split(f.StatusCheckPatterns, "\n") {
+				pattern = strings.TrimSpace(pattern)
+				if pattern == "" || util.IsStringInSlice(pattern, statusCheckContexts) {
+					continue
+				}
+				if _, err := git_model.CompileStatusCheck(pattern); err != nil {
+					ctx.Flash.Error(ctx.Tr("repo.settings.protect_status_check_patterns_invalid", pattern))
+					ctx.Redirect(fmt.Sprintf("%s/settings/branches/%s", ctx.Repo.RepoLink, util.PathEscapeSegments(branch)))
+					return
+				}
+				statusCheckContexts = append(statusCheckContexts, pattern)
+			}
+			protectBranch.StatusCheckContexts = statusCheckContexts
 		} else {
 			protectBranch.StatusCheckContexts = nil
 		}
@@ -257,10 +308,14 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 		protectBranch.BlockOnRejectedReviews = f.BlockOnRejectedReviews
 		protectBranch.BlockOnOfficialReviewRequests = f.BlockOnOfficialReviewRequests
 		protectBranch.DismissStaleApprovals = f.DismissStaleApprovals
+		protectBranch.IgnoreLastCommittersApprovals = f.IgnoreLastCommittersApprovals
+		protectBranch.DismissPusherApprovals = f.DismissPusherApprovals
 		protectBranch.RequireSignedCommits = f.RequireSignedCommits
 		protectBranch.ProtectedFilePatterns = f.ProtectedFilePatterns
 		protectBranch.UnprotectedFilePatterns = f.UnprotectedFilePatterns
 		protectBranch.BlockOnOutdatedBranch = f.BlockOnOutdatedBranch
+		protectBranch.BlockOnCodeScanningAlerts = f.BlockOnCodeScanningAlerts
+		protectBranch.MinimumDiffCoverage = f.MinimumDiffCoverage
 
 		err = git_model.UpdateProtectBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
 			UserIDs:          whitelistUsers,
@@ -23,6 +23,7 @@ import (
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	unit_model "code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
@@ -40,6 +41,7 @@ import (
 	"code.gitea.io/gitea/modules/typesniffer"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/web/feed"
+	repo_service "code.gitea.io/gitea/services/repository"
 )
 
 const (
@@ -48,6 +50,7 @@ const (
 	tplRepoViewList base.TplName = "repo/view_list"
 	tplWatchers     base.TplName = "repo/watchers"
 	tplForks        base.TplName = "repo/forks"
+	tplForkNetwork  base.TplName = "repo/fork_network"
 	tplMigrating    base.TplName = "repo/migrate/migrating"
 )
 
@@ -125,6 +128,8 @@ func renderDirectory(ctx *context.Context, treeLink string) {
 	if ctx.Repo.CanWrite(unit_model.TypeCode) && ctx.Repo.IsViewBranch {
 		ctx.Data["CanAddFile"] = !ctx.Repo.Repository.IsArchived
 		ctx.Data["CanUploadFile"] = setting.Repository.Upload.Enabled && !ctx.Repo.Repository.IsArchived
+		ctx.Data["CanSyncFork"] = ctx.Repo.Repository.IsFork && !ctx.Repo.Repository.IsArchived &&
+			ctx.Repo.TreePath == "" && ctx.Repo.BranchName == ctx.Repo.Repository.DefaultBranch
 	}
 
 	readmeFile, readmeTreelink := findReadmeFile(ctx, entries, treeLink)
@@ -560,7 +565,7 @@ func renderFile(ctx *context.Context, entry *git.TreeEntry, treeLink, rawLink st
 					language = ""
 				}
 			}
-			fileContent, err := highlight.File(blob.Name(), language, buf)
+			fileContent, _, err := highlight.File(blob.Name(), language, buf)
 			if err != nil {
 				log.Error("highlight.File failed, fallback to plain text: %v", err)
 				fileContent = highlight.PlainText(buf)
@@ -887,6 +892,25 @@ func renderRepoTopics(ctx *context.Context) {
 	ctx.Data["Topics"] = topics
 }
 
+func renderRepoAnnouncement(ctx *context.Context) {
+	announcement, err := issues_model.GetAnnouncement(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetAnnouncement", err)
+		return
+	}
+	if announcement == nil || !ctx.Repo.CanReadIssuesOrPulls(announcement.IsPull) {
+		return
+	}
+	if hidden, err := announcement.IsHiddenFrom(ctx, ctx.Doer); err != nil {
+		ctx.ServerError("IsHiddenFrom", err)
+		return
+	} else if hidden {
+		return
+	}
+	announcement.Repo = ctx.Repo.Repository
+	ctx.Data["Announcement"] = announcement
+}
+
 func renderCode(ctx *context.Context) {
 	ctx.Data["PageIsViewCode"] = true
 
@@ -941,6 +965,13 @@ func renderCode(ctx *context.Context) {
 		return
 	}
 
+	if len(ctx.Repo.TreePath) == 0 {
+		renderRepoAnnouncement(ctx)
+		if ctx.Written() {
+			return
+		}
+	}
+
 	// Get current entry user currently looking at.
 	entry, err := ctx.Repo.Commit.GetTreeEntryByPath(ctx.Repo.TreePath)
 	if err != nil {
@@ -1058,3 +1089,74 @@ func Forks(ctx *context.Context) {
 
 	ctx.HTML(http.StatusOK, tplForks)
 }
+
+// ForkNetworkItem is a repository of the fork network page
+type ForkNetworkItem struct {
+	*repo_service.ForkNetworkRepo
+	IsRoot     bool
+	Divergence *git.DivergeObject
+}
+
+// ForkNetwork renders the fork network of a repository, and the repositories of the network containing a commit
+func ForkNetwork(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.fork_network")
+	ctx.Data["PageIsForkNetwork"] = true
+
+	root, network, err := repo_service.GetForkNetwork(ctx, ctx.Repo.Repository, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetForkNetwork", err)
+		return
+	}
+
+	commitID := strings.TrimSpace(ctx.FormString("commit"))
+	ctx.Data["Commit"] = commitID
+	if commitID != "" {
+		if !git.IsValidSHAPattern(commitID) {
+			ctx.Data["CommitIsInvalid"] = true
+		} else {
+			forks, err := repo_service.FindForksContainingCommit(ctx, network, commitID)
+			if err != nil {
+				ctx.ServerError("FindForksContainingCommit", err)
+				return
+			}
+			for _, fork := range forks {
+				if err := fork.Repo.GetOwner(ctx); err != nil {
+					ctx.ServerError("GetOwner", err)
+					return
+				}
+			}
+			ctx.Data["ForksContainingCommit"] = forks
+		}
+	}
+
+	page := ctx.FormInt("page")
+	if page <= 0 {
+		page = 1
+	}
+	pager := context.NewPagination(len(network), setting.ItemsPerPage, page, 5)
+	if commitID != "" {
+		pager.AddParamString("commit", commitID)
+	}
+	ctx.Data["Page"] = pager
+
+	// the divergences are only computed for the displayed repositories
+	pageNetwork := util.PaginateSlice(network, pager.Paginater.Current(), setting.ItemsPerPage).([]*repo_service.ForkNetworkRepo)
+	items := make([]*ForkNetworkItem, 0, len(pageNetwork))
+	for _, networkRepo := range pageNetwork {
+		if err := networkRepo.Repo.GetOwner(ctx); err != nil {
+			ctx.ServerError("GetOwner", err)
+			return
+		}
+		item := &ForkNetworkItem{ForkNetworkRepo: networkRepo, IsRoot: networkRepo.Repo.ID == root.ID}
+		if !item.IsRoot {
+			if item.Divergence, err = repo_service.GetForkDivergence(ctx, root, networkRepo.Repo); err != nil {
+				ctx.ServerError("GetForkDivergence", err)
+				return
+			}
+		}
+		items = append(items, item)
+	}
+	ctx.Data["ForkNetwork"] = items
+
+	ctx.HTML(http.StatusOK, tplForkNetwork)
+}
@@ -181,6 +181,7 @@ func ParseHookEvent(form forms.WebhookForm) *webhook.HookEvent {
 			Wiki:                 form.Wiki,
 			Repository:           form.Repository,
 			Package:              form.Package,
+			Deployment:           form.Deployment,
 		},
 		BranchFilter: form.BranchFilter,
 	}
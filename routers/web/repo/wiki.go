@@ -474,6 +474,7 @@ func Wiki(ctx *context.Context) {
 		return
 	}
 
+	ctx.Data["FeedURL"] = ctx.Repo.Repository.HTMLURL() + "/wiki"
 	if !ctx.Repo.Repository.HasWiki() {
 		ctx.Data["Title"] = ctx.Tr("repo.wiki")
 		ctx.HTML(http.StatusOK, tplWikiStart)
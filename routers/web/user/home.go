@@ -387,12 +387,13 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 
 	isPullList := unitType == unit.TypePullRequests
 	opts := &issues_model.IssuesOptions{
-		IsPull:     util.OptionalBoolOf(isPullList),
-		SortType:   sortType,
-		IsArchived: util.OptionalBoolFalse,
-		Org:        org,
-		Team:       team,
-		User:       ctx.Doer,
+		ConfidentialCond: issues_model.ConfidentialIssueCond(ctx.Doer),
+		IsPull:           util.OptionalBoolOf(isPullList),
+		SortType:         sortType,
+		IsArchived:       util.OptionalBoolFalse,
+		Org:              org,
+		Team:             team,
+		User:             ctx.Doer,
 	}
 
 	// Search all repositories which
@@ -551,16 +552,17 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	var issueStats *issues_model.IssueStats
 	if !forceEmpty {
 		statsOpts := issues_model.UserIssueStatsOptions{
-			UserID:     ctx.Doer.ID,
-			FilterMode: filterMode,
-			IsPull:     isPullList,
-			IsClosed:   isShowClosed,
-			IssueIDs:   issueIDsFromSearch,
-			IsArchived: util.OptionalBoolFalse,
-			LabelIDs:   opts.LabelIDs,
-			Org:        org,
-			Team:       team,
-			RepoCond:   opts.RepoCond,
+			ConfidentialCond: issues_model.ConfidentialIssueCond(ctx.Doer),
+			UserID:           ctx.Doer.ID,
+			FilterMode:       filterMode,
+			IsPull:           isPullList,
+			IsClosed:         isShowClosed,
+			IssueIDs:         issueIDsFromSearch,
+			IsArchived:       util.OptionalBoolFalse,
+			LabelIDs:         opts.LabelIDs,
+			Org:              org,
+			Team:             team,
+			RepoCond:         opts.RepoCond,
 		}
 
 		issueStats, err = issues_model.GetUserIssueStats(statsOpts)
@@ -13,7 +13,6 @@ import (
 	activities_model "code.gitea.io/gitea/models/activities"
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/organization"
-	project_model "code.gitea.io/gitea/models/project"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/context"
@@ -23,6 +22,7 @@ import (
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/web/feed"
 	"code.gitea.io/gitea/routers/web/org"
+	user_service "code.gitea.io/gitea/services/user"
 )
 
 // Profile render user's profile page
@@ -112,7 +112,16 @@ func Profile(ctx *context.Context) {
 	}
 	ctx.Data["Badges"] = badges
 
+	ctx.Data["UserStatus"], err = user_model.GetUserStatus(ctx, ctx.ContextUser.ID)
+	if err != nil {
+		ctx.ServerError("GetUserStatus", err)
+		return
+	}
+
 	tab := ctx.FormString("tab")
+	if (tab == "activity" && ctx.ContextUser.HideProfileActivity) || (tab == "projects" && ctx.ContextUser.HideProfileProjects) {
+		tab = ""
+	}
 	ctx.Data["TabName"] = tab
 
 	page := ctx.FormInt("page")
@@ -203,6 +212,7 @@ func Profile(ctx *context.Context) {
 		}
 	case "stars":
 		ctx.Data["PageIsProfileStarList"] = true
+		ctx.Data["FeedURL"] = ctx.ContextUser.HTMLURL() + "/stars"
 		repos, count, err = repo_model.SearchRepository(&repo_model.SearchRepoOptions{
 			ListOptions: db.ListOptions{
 				PageSize: setting.UI.User.RepoPagingNum,
@@ -225,13 +235,9 @@ func Profile(ctx *context.Context) {
 
 		total = int(count)
 	case "projects":
-		ctx.Data["OpenProjects"], _, err = project_model.GetProjects(ctx, project_model.SearchOptions{
-			Page:     -1,
-			IsClosed: util.OptionalBoolFalse,
-			Type:     project_model.TypeIndividual,
-		})
+		ctx.Data["OpenProjects"], err = user_service.GetVisibleOpenProjects(ctx, ctx.ContextUser, ctx.Doer)
 		if err != nil {
-			ctx.ServerError("GetProjects", err)
+			ctx.ServerError("GetVisibleOpenProjects", err)
 			return
 		}
 	case "watching":
@@ -257,6 +263,14 @@ func Profile(ctx *context.Context) {
 
 		total = int(count)
 	default:
+		if page == 1 && len(keyword) == 0 && len(language) == 0 {
+			ctx.Data["PinnedRepos"], err = user_service.GetVisiblePinnedRepositories(ctx, ctx.ContextUser, ctx.Doer)
+			if err != nil {
+				ctx.ServerError("GetVisiblePinnedRepositories", err)
+				return
+			}
+		}
+
 		repos, count, err = repo_model.SearchRepository(&repo_model.SearchRepoOptions{
 			ListOptions: db.ListOptions{
 				PageSize: setting.UI.User.RepoPagingNum,
@@ -289,7 +303,7 @@ func Profile(ctx *context.Context) {
 		pager.AddParam(ctx, "language", "Language")
 	}
 	ctx.Data["Page"] = pager
-	ctx.Data["IsPackageEnabled"] = setting.Packages.Enabled
+	ctx.Data["IsPackageEnabled"] = setting.Packages.Enabled && !ctx.ContextUser.HideProfilePackages
 
 	ctx.Data["ShowUserEmail"] = len(ctx.ContextUser.Email) > 0 && ctx.IsSigned && (!ctx.ContextUser.KeepEmailPrivate || ctx.ContextUser.ID == ctx.Doer.ID)
 
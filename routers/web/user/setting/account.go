@@ -8,6 +8,7 @@ package setting
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"code.gitea.io/gitea/models"
@@ -249,8 +250,25 @@ func DeleteAccount(ctx *context.Context) {
 		return
 	}
 
-	if err := user.DeleteUser(ctx, ctx.Doer, false); err != nil {
+	var successor *user_model.User
+	if successorName := strings.TrimSpace(ctx.FormString("successor")); len(successorName) > 0 {
+		var err error
+		if successor, err = user_model.GetUserByName(ctx, successorName); err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.Flash.Error(ctx.Tr("settings.delete_successor_not_exist", successorName))
+				ctx.Redirect(setting.AppSubURL + "/user/settings/account")
+			} else {
+				ctx.ServerError("GetUserByName", err)
+			}
+			return
+		}
+	}
+
+	if err := user.ScheduleUserDeletion(ctx, ctx.Doer, successor); err != nil {
 		switch {
+		case errors.Is(err, user.ErrSuccessorIsSelf), errors.Is(err, user.ErrSuccessorInvalid):
+			ctx.Flash.Error(ctx.Tr("settings.delete_successor_invalid"))
+			ctx.Redirect(setting.AppSubURL + "/user/settings/account")
 		case models.IsErrUserOwnRepos(err):
 			ctx.Flash.Error(ctx.Tr("form.still_own_repo"))
 			ctx.Redirect(setting.AppSubURL + "/user/settings/account")
@@ -261,14 +279,30 @@ func DeleteAccount(ctx *context.Context) {
 			ctx.Flash.Error(ctx.Tr("form.still_own_packages"))
 			ctx.Redirect(setting.AppSubURL + "/user/settings/account")
 		default:
-			ctx.ServerError("DeleteUser", err)
+			ctx.ServerError("ScheduleUserDeletion", err)
 		}
-	} else {
+	} else if setting.Service.UserDeleteGracePeriod <= 0 {
 		log.Trace("Account deleted: %s", ctx.Doer.Name)
 		ctx.Redirect(setting.AppSubURL + "/")
+	} else {
+		log.Trace("Account deletion scheduled: %s", ctx.Doer.Name)
+		ctx.Flash.Success(ctx.Tr("settings.delete_account_scheduled", setting.Service.UserDeleteGracePeriod.String()))
+		ctx.Redirect(setting.AppSubURL + "/user/settings/account")
 	}
 }
 
+// CancelAccountDeletion cancels the scheduled deletion of the account
+func CancelAccountDeletion(ctx *context.Context) {
+	if err := user.CancelUserDeletion(ctx, ctx.Doer); err != nil {
+		ctx.ServerError("CancelUserDeletion", err)
+		return
+	}
+	log.Trace("Account deletion canceled: %s", ctx.Doer.Name)
+
+	ctx.Flash.Success(ctx.Tr("settings.delete_account_canceled"))
+	ctx.Redirect(setting.AppSubURL + "/user/settings/account")
+}
+
 func loadAccountData(ctx *context.Context) {
 	emlist, err := user_model.GetEmailAddresses(ctx.Doer.ID)
 	if err != nil {
@@ -292,6 +326,25 @@ func loadAccountData(ctx *context.Context) {
 	ctx.Data["ActivationsPending"] = pendingActivation
 	ctx.Data["CanAddEmails"] = !pendingActivation || !setting.Service.RegisterEmailConfirm
 
+	deletion, err := user_model.GetScheduledDeletion(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetScheduledDeletion", err)
+		return
+	}
+	if deletion != nil {
+		ctx.Data["ScheduledDeletion"] = deletion
+		if deletion.SuccessorID > 0 {
+			successor, err := user_model.GetUserByIDCtx(ctx, deletion.SuccessorID)
+			if err != nil && !user_model.IsErrUserNotExist(err) {
+				ctx.ServerError("GetUserByID", err)
+				return
+			}
+			ctx.Data["DeletionSuccessor"] = successor
+		}
+	}
+	ctx.Data["UserDeleteGracePeriod"] = setting.Service.UserDeleteGracePeriod.String()
+	ctx.Data["UserDeleteScheduled"] = setting.Service.UserDeleteGracePeriod > 0
+
 	if setting.Service.UserDeleteWithCommentsMaxTime != 0 {
 		ctx.Data["UserDeleteWithCommentsMaxTime"] = setting.Service.UserDeleteWithCommentsMaxTime.String()
 		ctx.Data["UserDeleteWithComments"] = ctx.Doer.CreatedUnix.AsTime().Add(setting.Service.UserDeleteWithCommentsMaxTime).After(time.Now())
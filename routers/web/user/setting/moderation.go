@@ -0,0 +1,158 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+	moderation_service "code.gitea.io/gitea/services/moderation"
+)
+
+const tplSettingsModeration base.TplName = "user/settings/moderation"
+
+// Moderation render the users blocked by the signed in user and the interaction limit of their repositories
+func Moderation(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.moderation")
+	ctx.Data["PageIsSettingsModeration"] = true
+	ctx.Data["ModerationLink"] = setting.AppSubURL + "/user/settings/moderation"
+
+	if !PrepareModeration(ctx, ctx.Doer) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplSettingsModeration)
+}
+
+// ModerationPost response for blocking users and limiting interactions
+func ModerationPost(ctx *context.Context) {
+	HandleModerationPost(ctx, ctx.Doer, setting.AppSubURL+"/user/settings/moderation")
+}
+
+// PrepareModeration loads the users blocked by the owner and the interaction limit of its repositories
+func PrepareModeration(ctx *context.Context, owner *user_model.User) bool {
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	pageSize := setting.UI.User.RepoPagingNum
+
+	users, count, err := user_model.GetBlockedUsers(ctx, owner.ID, db.ListOptions{Page: page, PageSize: pageSize})
+	if err != nil {
+		ctx.ServerError("GetBlockedUsers", err)
+		return false
+	}
+	ctx.Data["BlockedUsers"] = users
+	ctx.Data["Page"] = context.NewPagination(int(count), pageSize, page, 5)
+
+	limitedUsers, _, err := moderation_model.GetShadowLimitedUsers(ctx, owner.ID, db.ListOptions{})
+	if err != nil {
+		ctx.ServerError("GetShadowLimitedUsers", err)
+		return false
+	}
+	ctx.Data["ShadowLimitedUsers"] = limitedUsers
+
+	return PrepareInteractionLimit(ctx, owner.ID, 0)
+}
+
+// PrepareInteractionLimit loads the active interaction limit of the owner (repoID = 0) or of the repository (ownerID = 0)
+func PrepareInteractionLimit(ctx *context.Context, ownerID, repoID int64) bool {
+	limit, err := repo_model.GetInteractionLimit(ctx, ownerID, repoID)
+	if err != nil {
+		ctx.ServerError("GetInteractionLimit", err)
+		return false
+	}
+	ctx.Data["InteractionLimit"] = limit
+	ctx.Data["InteractionLimitTypes"] = repo_model.InteractionLimitTypes
+	ctx.Data["InteractionLimitDurations"] = repo_model.InteractionLimitDurationNames
+	return true
+}
+
+// HandleModerationPost blocks or unblocks a user for the owner, or changes the interaction limit of its repositories
+func HandleModerationPost(ctx *context.Context, owner *user_model.User, link string) {
+	switch ctx.FormString("action") {
+	case "block":
+		blockee, err := user_model.GetUserByName(ctx, ctx.FormString("blockee"))
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.Flash.Error(ctx.Tr("form.user_not_exist"))
+				break
+			}
+			ctx.ServerError("GetUserByName", err)
+			return
+		}
+		if blockee.IsOrganization() {
+			ctx.Flash.Error(ctx.Tr("settings.blocked_users.block_org"))
+			break
+		}
+		if err := user_model.BlockUser(ctx, owner.ID, blockee.ID); err != nil {
+			if errors.Is(err, user_model.ErrCannotBlockSelf) {
+				ctx.Flash.Error(ctx.Tr("settings.blocked_users.block_self"))
+				break
+			}
+			ctx.ServerError("BlockUser", err)
+			return
+		}
+		ctx.Flash.Success(ctx.Tr("settings.blocked_users.block_success", blockee.Name))
+	case "unblock":
+		blockee, err := user_model.GetUserByIDCtx(ctx, ctx.FormInt64("id"))
+		if err != nil {
+			ctx.ServerError("GetUserByID", err)
+			return
+		}
+		if err := user_model.UnblockUser(ctx, owner.ID, blockee.ID); err != nil {
+			ctx.ServerError("UnblockUser", err)
+			return
+		}
+		ctx.Flash.Success(ctx.Tr("settings.blocked_users.unblock_success", blockee.Name))
+	case "remove_shadow_limit":
+		limited, err := user_model.GetUserByIDCtx(ctx, ctx.FormInt64("id"))
+		if err != nil {
+			ctx.ServerError("GetUserByID", err)
+			return
+		}
+		if err := moderation_service.RemoveShadowLimit(ctx, ctx.Doer, owner.ID, limited.ID, ""); err != nil {
+			ctx.ServerError("RemoveShadowLimit", err)
+			return
+		}
+		ctx.Flash.Success(ctx.Tr("settings.shadow_limited_users.remove_success", limited.Name))
+	default:
+		if !UpdateInteractionLimit(ctx, owner.ID, 0) {
+			return
+		}
+	}
+	ctx.Redirect(link)
+}
+
+// UpdateInteractionLimit sets (action "limit") or removes (action "unlimit") the interaction limit
+// of the owner (repoID = 0) or of the repository (ownerID = 0), the result is reported as flash message
+func UpdateInteractionLimit(ctx *context.Context, ownerID, repoID int64) bool {
+	if ctx.FormString("action") == "unlimit" {
+		if err := repo_model.RemoveInteractionLimit(ctx, ownerID, repoID); err != nil {
+			ctx.ServerError("RemoveInteractionLimit", err)
+			return false
+		}
+		ctx.Flash.Success(ctx.Tr("repo.interaction_limit.remove_success"))
+		return true
+	}
+
+	limit := repo_model.InteractionLimitType(ctx.FormString("limit"))
+	if _, err := repo_model.SetInteractionLimit(ctx, ownerID, repoID, limit, ctx.FormString("duration")); err != nil {
+		if errors.Is(err, repo_model.ErrInvalidInteractionLimit) || errors.Is(err, repo_model.ErrInvalidInteractionLimitDuration) {
+			ctx.Flash.Error(ctx.Tr("repo.interaction_limit.invalid"))
+			return true
+		}
+		ctx.ServerError("SetInteractionLimit", err)
+		return false
+	}
+	ctx.Flash.Success(ctx.Tr("repo.interaction_limit.set_success"))
+	return true
+}
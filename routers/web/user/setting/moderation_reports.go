@@ -0,0 +1,148 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+	moderation_service "code.gitea.io/gitea/services/moderation"
+)
+
+const (
+	tplSettingsModerationReports base.TplName = "user/settings/moderation_reports"
+	tplSettingsModerationLog     base.TplName = "user/settings/moderation_log"
+)
+
+// ModerationReports render the reports about the content of the repositories of the signed in user
+func ModerationReports(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.moderation_reports")
+	ctx.Data["PageIsSettingsModerationReports"] = true
+	ctx.Data["ReportsLink"] = setting.AppSubURL + "/user/settings/moderation/reports"
+
+	if !PrepareModerationReports(ctx, ctx.Doer.ID) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplSettingsModerationReports)
+}
+
+// ModerationReportPost response for taking an action on a report
+func ModerationReportPost(ctx *context.Context) {
+	HandleModerationReportPost(ctx, ctx.Doer.ID, setting.AppSubURL+"/user/settings/moderation/reports")
+}
+
+// ModerationLog render the moderation audit trail of the repositories of the signed in user
+func ModerationLog(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.moderation_log")
+	ctx.Data["PageIsSettingsModerationLog"] = true
+
+	if !PrepareModerationLog(ctx, ctx.Doer.ID) {
+		return
+	}
+	ctx.HTML(http.StatusOK, tplSettingsModerationLog)
+}
+
+// PrepareModerationReports loads the reports with the requested status about the content of the repositories
+// of the owner, or of all the repositories when ownerID is 0
+func PrepareModerationReports(ctx *context.Context, ownerID int64) bool {
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	status := moderation_model.ReportStatus(ctx.FormString("status"))
+	if !status.IsValid() {
+		status = moderation_model.ReportStatusOpen
+	}
+
+	reports, count, err := moderation_model.FindReports(ctx, &moderation_model.FindReportsOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.IssuePagingNum},
+		OwnerID:     ownerID,
+		Status:      status,
+	})
+	if err != nil {
+		ctx.ServerError("FindReports", err)
+		return false
+	}
+	for _, report := range reports {
+		if err := report.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return false
+		}
+	}
+	ctx.Data["Reports"] = reports
+	ctx.Data["ReportStatus"] = status
+	ctx.Data["ReportStatuses"] = moderation_model.ReportStatuses
+	ctx.Data["ReportActions"] = moderation_model.ReportActions
+
+	pager := context.NewPagination(int(count), setting.UI.IssuePagingNum, page, 5)
+	pager.AddParamString("status", string(status))
+	ctx.Data["Page"] = pager
+	return true
+}
+
+// HandleModerationReportPost takes the requested action on a report about the content of the repositories of the owner,
+// or of any repository when ownerID is 0. The result is reported as flash message.
+func HandleModerationReportPost(ctx *context.Context, ownerID int64, link string) {
+	report, err := moderation_model.GetReportByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if errors.Is(err, moderation_model.ErrReportNotExist) {
+			ctx.NotFound("GetReportByID", err)
+			return
+		}
+		ctx.ServerError("GetReportByID", err)
+		return
+	}
+	if ownerID > 0 && report.OwnerID != ownerID {
+		ctx.NotFound("GetReportByID", moderation_model.ErrReportNotExist)
+		return
+	}
+
+	action := moderation_model.ActionType(ctx.FormString("action"))
+	if err := moderation_service.HandleReport(ctx, ctx.Doer, report, action, ctx.FormString("reason")); err != nil {
+		switch {
+		case errors.Is(err, moderation_service.ErrReportClosed):
+			ctx.Flash.Error(ctx.Tr("settings.moderation.report_closed"))
+		case errors.Is(err, moderation_service.ErrInvalidAction), errors.Is(err, moderation_service.ErrCannotHideContent):
+			ctx.Flash.Error(ctx.Tr("settings.moderation.invalid_action"))
+		default:
+			ctx.ServerError("HandleReport", err)
+			return
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr("settings.moderation.action_success"))
+	}
+	ctx.Redirect(link)
+}
+
+// PrepareModerationLog loads a page of the moderation audit trail of the owner, or of the whole instance when ownerID is 0
+func PrepareModerationLog(ctx *context.Context, ownerID int64) bool {
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+
+	logs, count, err := moderation_model.FindLogs(ctx, &moderation_model.FindLogsOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.IssuePagingNum},
+		OwnerID:     ownerID,
+	})
+	if err != nil {
+		ctx.ServerError("FindLogs", err)
+		return false
+	}
+	for _, l := range logs {
+		if err := l.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return false
+		}
+	}
+	ctx.Data["ModerationLogs"] = logs
+	ctx.Data["Page"] = context.NewPagination(int(count), setting.UI.IssuePagingNum, page, 5)
+	return true
+}
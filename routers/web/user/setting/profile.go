@@ -14,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/models/organization"
@@ -23,6 +24,7 @@ import (
 	"code.gitea.io/gitea/modules/context"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/translation"
 	"code.gitea.io/gitea/modules/typesniffer"
 	"code.gitea.io/gitea/modules/util"
@@ -46,6 +48,12 @@ func Profile(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Tr("settings")
 	ctx.Data["PageIsSettingsProfile"] = true
 	ctx.Data["AllowedUserVisibilityModes"] = setting.Service.AllowedUserVisibilityModesSlice.ToVisibleTypeSlice()
+	if !PreparePinnedRepositories(ctx, ctx.Doer) {
+		return
+	}
+	if !prepareUserStatus(ctx) {
+		return
+	}
 
 	ctx.HTML(http.StatusOK, tplSettingsProfile)
 }
@@ -100,6 +108,65 @@ func HandleUsernameChange(ctx *context.Context, user *user_model.User, newName s
 	return nil
 }
 
+func prepareUserStatus(ctx *context.Context) bool {
+	status, err := user_model.GetUserStatus(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetUserStatus", err)
+		return false
+	}
+	ctx.Data["UserStatus"] = status
+	return true
+}
+
+// endOfDay parses a date of a settings form and returns the end of that day
+func endOfDay(date string) (timeutil.TimeStamp, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return 0, err
+	}
+	return timeutil.TimeStamp(time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 59, 0, day.Location()).Unix()), nil
+}
+
+// PreparePinnedRepositories sets the names of the repositories pinned by the owner for the settings form
+func PreparePinnedRepositories(ctx *context.Context, owner *user_model.User) bool {
+	repos, err := repo_model.GetPinnedRepositories(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetPinnedRepositories", err)
+		return false
+	}
+	names := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		names = append(names, repo.Name)
+	}
+	ctx.Data["PinnedRepositories"] = strings.Join(names, ", ")
+	return true
+}
+
+// UpdatePinnedRepositories pins the comma separated repositories of the owner to their profile page,
+// invalid repositories are reported as flash error and redirect to the settings page
+func UpdatePinnedRepositories(ctx *context.Context, owner *user_model.User, names, settingsLink string) bool {
+	pinned := make([]string, 0, repo_model.MaxPinnedRepositories)
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			pinned = append(pinned, name)
+		}
+	}
+	if err := user_service.PinRepositories(ctx, owner, pinned); err != nil {
+		switch {
+		case repo_model.IsErrRepoNotExist(err):
+			ctx.Flash.Error(ctx.Tr("settings.pinned_repositories_not_exist", err.(repo_model.ErrRepoNotExist).Name))
+		case errors.Is(err, repo_model.ErrTooManyPinnedRepositories):
+			ctx.Flash.Error(ctx.Tr("settings.pinned_repositories_too_many", repo_model.MaxPinnedRepositories))
+		default:
+			ctx.ServerError("PinRepositories", err)
+			return false
+		}
+		ctx.Redirect(settingsLink)
+		return false
+	}
+	return true
+}
+
 // ProfilePost response for change user's profile
 func ProfilePost(ctx *context.Context) {
 	form := web.GetForm(ctx).(*forms.UpdateProfileForm)
@@ -128,6 +195,42 @@ func ProfilePost(ctx *context.Context) {
 	ctx.Doer.Description = form.Description
 	ctx.Doer.KeepActivityPrivate = form.KeepActivityPrivate
 	ctx.Doer.Visibility = form.Visibility
+	ctx.Doer.HideProfileActivity = !form.ShowProfileActivity
+	ctx.Doer.HideProfilePackages = !form.ShowProfilePackages
+	ctx.Doer.HideProfileProjects = !form.ShowProfileProjects
+	ctx.Doer.ReviewCapacity = form.ReviewCapacity
+	ctx.Doer.OutOfOffice = form.OutOfOffice
+	ctx.Doer.OutOfOfficeUntil = 0
+	if len(form.OutOfOfficeUntil) > 0 {
+		until, err := endOfDay(form.OutOfOfficeUntil)
+		if err != nil {
+			ctx.Data["Err_OutOfOfficeUntil"] = true
+			ctx.RenderWithErr(ctx.Tr("settings.out_of_office_until_invalid"), tplSettingsProfile, &form)
+			return
+		}
+		ctx.Doer.OutOfOfficeUntil = until
+	}
+
+	status := &user_model.Status{
+		UID:     ctx.Doer.ID,
+		Emoji:   form.StatusEmoji,
+		Message: form.StatusMessage,
+		Busy:    form.StatusBusy,
+	}
+	if len(form.StatusExpiresAt) > 0 {
+		expires, err := endOfDay(form.StatusExpiresAt)
+		if err != nil {
+			ctx.Data["Err_StatusExpiresAt"] = true
+			ctx.RenderWithErr(ctx.Tr("settings.status_expires_at_invalid"), tplSettingsProfile, &form)
+			return
+		}
+		status.ExpiresUnix = expires
+	}
+	if err := status.Validate(); err != nil {
+		ctx.Data["Err_StatusEmoji"] = true
+		ctx.RenderWithErr(ctx.Tr("settings.status_emoji_invalid"), tplSettingsProfile, &form)
+		return
+	}
 	if err := user_model.UpdateUserSetting(ctx.Doer); err != nil {
 		if _, ok := err.(user_model.ErrEmailAlreadyUsed); ok {
 			ctx.Flash.Error(ctx.Tr("form.email_been_used"))
@@ -137,6 +240,13 @@ func ProfilePost(ctx *context.Context) {
 		ctx.ServerError("UpdateUser", err)
 		return
 	}
+	if err := user_model.SetUserStatus(ctx, status); err != nil {
+		ctx.ServerError("SetUserStatus", err)
+		return
+	}
+	if !UpdatePinnedRepositories(ctx, ctx.Doer, form.PinnedRepositories, setting.AppSubURL+"/user/settings") {
+		return
+	}
 
 	// Update the language to the one we just set
 	middleware.SetLocaleCookie(ctx.Resp, ctx.Doer.Language, 0)
@@ -0,0 +1,123 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+const tplSettingsSavedSearches base.TplName = "user/settings/searches"
+
+// SavedSearches render the searches saved by the signed in user with the links of their feeds
+func SavedSearches(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.saved_searches")
+	ctx.Data["PageIsSettingsSavedSearches"] = true
+	ctx.Data["SavedSearchTypes"] = user_model.SavedSearchTypes
+	ctx.Data["IsRepoIndexerEnabled"] = setting.Indexer.RepoIndexerEnabled
+
+	searches, err := user_model.GetSavedSearches(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetSavedSearches", err)
+		return
+	}
+	repoIDs := make([]int64, 0, len(searches))
+	for _, search := range searches {
+		if search.RepoID > 0 {
+			repoIDs = append(repoIDs, search.RepoID)
+		}
+	}
+	repos, err := repo_model.GetRepositoriesMapByIDs(repoIDs)
+	if err != nil {
+		ctx.ServerError("GetRepositoriesMapByIDs", err)
+		return
+	}
+	ctx.Data["SavedSearches"] = searches
+	ctx.Data["SavedSearchRepos"] = repos
+
+	ctx.HTML(http.StatusOK, tplSettingsSavedSearches)
+}
+
+// SavedSearchesPost response for saving (action "save") or deleting (action "delete") a search
+func SavedSearchesPost(ctx *context.Context) {
+	link := setting.AppSubURL + "/user/settings/searches"
+
+	switch ctx.FormString("action") {
+	case "save":
+		search := &user_model.SavedSearch{
+			UserID:  ctx.Doer.ID,
+			Name:    ctx.FormTrim("name"),
+			Type:    user_model.SavedSearchType(ctx.FormString("type")),
+			Keyword: ctx.FormTrim("q"),
+		}
+		if search.Name == "" || search.Keyword == "" || !search.Type.IsValid() ||
+			(search.Type == user_model.SavedSearchTypeCode && !setting.Indexer.RepoIndexerEnabled) {
+			ctx.Flash.Error(ctx.Tr("settings.saved_searches.invalid"))
+			break
+		}
+
+		unitType := unit.TypeIssues
+		if search.Type == user_model.SavedSearchTypeCode {
+			unitType = unit.TypeCode
+		}
+		if repoName := ctx.FormTrim("repo"); repoName != "" {
+			repo, ok := getSavedSearchRepo(ctx, repoName, unitType)
+			if ctx.Written() {
+				return
+			} else if !ok {
+				ctx.Flash.Error(ctx.Tr("settings.saved_searches.repo_not_exist", repoName))
+				break
+			}
+			search.RepoID = repo.ID
+		}
+
+		if err := user_model.CreateSavedSearch(ctx, search); err != nil {
+			if errors.Is(err, user_model.ErrTooManySavedSearches) {
+				ctx.Flash.Error(ctx.Tr("settings.saved_searches.too_many", user_model.MaxSavedSearches))
+				break
+			}
+			ctx.ServerError("CreateSavedSearch", err)
+			return
+		}
+		ctx.Flash.Success(ctx.Tr("settings.saved_searches.save_success", search.Name))
+	case "delete":
+		if err := user_model.DeleteSavedSearch(ctx, ctx.Doer.ID, ctx.FormInt64("id")); err != nil {
+			ctx.ServerError("DeleteSavedSearch", err)
+			return
+		}
+		ctx.Flash.Success(ctx.Tr("settings.saved_searches.delete_success"))
+	}
+	ctx.Redirect(link)
+}
+
+// getSavedSearchRepo returns the repository named "owner/name" if the signed in user can read its unit
+func getSavedSearchRepo(ctx *context.Context, fullName string, unitType unit.Type) (*repo_model.Repository, bool) {
+	ownerName, repoName, found := strings.Cut(fullName, "/")
+	if !found {
+		return nil, false
+	}
+	repo, err := repo_model.GetRepositoryByOwnerAndNameCtx(ctx, ownerName, repoName)
+	if err != nil {
+		if !repo_model.IsErrRepoNotExist(err) {
+			ctx.ServerError("GetRepositoryByOwnerAndNameCtx", err)
+		}
+		return nil, false
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+	if err != nil {
+		ctx.ServerError("GetUserRepoPermission", err)
+		return nil, false
+	}
+	return repo, perm.CanRead(unitType)
+}
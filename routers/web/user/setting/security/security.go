@@ -6,14 +6,19 @@
 package security
 
 import (
+	"errors"
 	"net/http"
 
 	auth_model "code.gitea.io/gitea/models/auth"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/web"
+	auth_service "code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/auth/source/oauth2"
+	"code.gitea.io/gitea/services/forms"
 )
 
 const (
@@ -54,6 +59,47 @@ func DeleteAccountLink(ctx *context.Context) {
 	})
 }
 
+// LinkPasswordSourcePost links an account of a password based authentication source after checking its credentials
+func LinkPasswordSourcePost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.LinkPasswordSourceForm)
+	redirectTo := setting.AppSubURL + "/user/settings/security"
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(redirectTo)
+		return
+	}
+
+	source, err := auth_model.GetSourceByID(form.SourceID)
+	if err != nil {
+		if auth_model.IsErrSourceNotExist(err) {
+			ctx.NotFound("GetSourceByID", err)
+		} else {
+			ctx.ServerError("GetSourceByID", err)
+		}
+		return
+	}
+
+	if err := auth_service.LinkPasswordSource(ctx.Doer, source, form.LoginName, form.Password); err != nil {
+		switch {
+		case user_model.IsErrUserNotExist(err):
+			ctx.Flash.Error(ctx.Tr("settings.link_password_source_failed"))
+		case user_model.IsErrExternalLoginUserAlreadyExist(err):
+			ctx.Flash.Error(ctx.Tr("settings.link_password_source_taken"))
+		case errors.Is(err, auth_service.ErrLinkPrimarySource), errors.Is(err, oauth2.ErrAuthSourceNotActived):
+			ctx.Flash.Error(ctx.Tr("settings.link_password_source_unavailable"))
+		default:
+			ctx.ServerError("LinkPasswordSource", err)
+			return
+		}
+		ctx.Redirect(redirectTo)
+		return
+	}
+	log.Trace("Account of authentication source %s linked by user %s", source.Name, ctx.Doer.Name)
+
+	ctx.Flash.Success(ctx.Tr("settings.link_password_source_success", source.Name))
+	ctx.Redirect(redirectTo)
+}
+
 func loadSecurityData(ctx *context.Context) {
 	enrolled, err := auth_model.HasTwoFactorByUID(ctx.Doer.ID)
 	if err != nil {
@@ -113,6 +159,13 @@ func loadSecurityData(ctx *context.Context) {
 		ctx.ServerError("GetActiveOAuth2Providers", err)
 		return
 	}
+	linkableSources, err := auth_service.LinkablePasswordSources(ctx.Doer)
+	if err != nil {
+		ctx.ServerError("LinkablePasswordSources", err)
+		return
+	}
+	ctx.Data["LinkablePasswordSources"] = linkableSources
+
 	ctx.Data["OrderedOAuth2Names"] = orderedOAuth2Names
 	ctx.Data["OAuth2Providers"] = oauth2Providers
 
@@ -0,0 +1,63 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+const tplSubscriptions base.TplName = "user/dashboard/subscriptions"
+
+// Subscriptions render the recent releases of the repositories the user watches or stars
+func Subscriptions(ctx *context.Context) {
+	if unit.TypeReleases.UnitGlobalDisabled() {
+		ctx.NotFound("Subscriptions", nil)
+		return
+	}
+
+	ctxUser := getDashboardContextUser(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Data["Title"] = ctxUser.DisplayName() + " - " + ctx.Tr("subscriptions")
+	ctx.Data["PageIsSubscriptionsDashboard"] = true
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	showTags := ctx.FormBool("tags")
+
+	releases, count, err := repo_model.FindSubscribedReleases(ctx, repo_model.FindSubscribedReleasesOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.FeedPagingNum,
+		},
+		Doer:        ctx.Doer,
+		IncludeTags: showTags,
+	})
+	if err != nil {
+		ctx.ServerError("FindSubscribedReleases", err)
+		return
+	}
+	ctx.Data["Releases"] = releases
+	ctx.Data["ShowTags"] = showTags
+
+	pager := context.NewPagination(int(count), setting.UI.FeedPagingNum, page, 5)
+	if showTags {
+		pager.AddParamString("tags", "true")
+	}
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplSubscriptions)
+}
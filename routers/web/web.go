@@ -77,6 +77,8 @@ func CorsHandler() func(next http.Handler) http.Handler {
 	}
 }
 
+// The SignedURL plugin only handles signed downloads, which are made as their signer whoever is signed in.
+//
 // The OAuth2 plugin is expected to be executed first, as it must ignore the user id stored
 // in the session (if there is a user id stored in session other plugins might return the user
 // object for that id).
@@ -85,6 +87,7 @@ func CorsHandler() func(next http.Handler) http.Handler {
 // for users that have already signed in.
 func buildAuthGroup() *auth_service.Group {
 	group := auth_service.NewGroup(
+		&auth_service.SignedURL{},
 		&auth_service.OAuth2{}, // FIXME: this should be removed and only applied in download and oauth related routers
 		&auth_service.Basic{},  // FIXME: this should be removed and only applied in download and git/lfs routers
 		&auth_service.Session{},
@@ -172,7 +175,7 @@ func Routes(ctx gocontext.Context) *web.Route {
 	// prometheus metrics endpoint - do not need to go through contexter
 	if setting.Metrics.Enabled {
 		c := metrics.NewCollector()
-		prometheus.MustRegister(c)
+		prometheus.MustRegister(c, metrics.GitUploadPackFilterRequests, metrics.GitObjectInfoRequests)
 
 		routes.Get("/metrics", append(common, Metrics)...)
 	}
@@ -319,11 +322,15 @@ func RegisterRoutes(m *web.Route) {
 		})
 		m.Get("/repos", explore.Repos)
 		m.Get("/repos/sitemap-{idx}.xml", explore.Repos)
+		m.Get("/trending", explore.Trending)
+		m.Get("/collections/{id}", explore.Collection)
 		m.Get("/users", explore.Users)
 		m.Get("/users/sitemap-{idx}.xml", explore.Users)
 		m.Get("/organizations", explore.Organizations)
 		m.Get("/code", explore.Code)
 		m.Get("/topics/search", explore.TopicSearch)
+		m.Get("/activity.rss", feed.ShowExploreFeedRSS)
+		m.Get("/activity.atom", feed.ShowExploreFeedAtom)
 	}, ignExploreSignIn)
 	m.Group("/issues", func() {
 		m.Get("", user.Issues)
@@ -332,6 +339,14 @@ func RegisterRoutes(m *web.Route) {
 
 	m.Get("/pulls", reqSignIn, user.Pulls)
 	m.Get("/milestones", reqSignIn, reqMilestonesDashboardPageEnabled, user.Milestones)
+	m.Group("/subscriptions", func() {
+		m.Get("", user.Subscriptions)
+		m.Get(".rss", feed.ShowSubscriptionsFeedRSS)
+		m.Get(".atom", feed.ShowSubscriptionsFeedAtom)
+	}, reqSignIn)
+	if setting.WebSub.Enabled {
+		m.Post("/-/websub", ignSignInAndCsrf, feed.WebSubHub)
+	}
 
 	// ***** START: User *****
 	m.Group("/user", func() {
@@ -382,6 +397,9 @@ func RegisterRoutes(m *web.Route) {
 	m.Post("/login/oauth/access_token", CorsHandler(), bindIgnErr(forms.AccessTokenForm{}), ignSignInAndCsrf, auth.AccessTokenOAuth)
 	m.Get("/login/oauth/keys", ignSignInAndCsrf, auth.OIDCKeys)
 	m.Post("/login/oauth/introspect", CorsHandler(), bindIgnErr(forms.IntrospectTokenForm{}), ignSignInAndCsrf, auth.IntrospectOAuth)
+	m.Post("/login/oauth/device_authorization", CorsHandler(), bindIgnErr(forms.DeviceAuthorizationForm{}), ignSignInAndCsrf, auth.DeviceAuthorizationOAuth)
+	m.Combo("/login/oauth/device", reqSignIn).Get(auth.GrantDevice).
+		Post(bindIgnErr(forms.GrantDeviceForm{}), auth.GrantDevicePost)
 
 	m.Group("/user/settings", func() {
 		m.Get("", user_setting.Profile)
@@ -395,6 +413,7 @@ func RegisterRoutes(m *web.Route) {
 			m.Post("/email", bindIgnErr(forms.AddEmailForm{}), user_setting.EmailPost)
 			m.Post("/email/delete", user_setting.DeleteEmail)
 			m.Post("/delete", user_setting.DeleteAccount)
+			m.Post("/delete/cancel", user_setting.CancelAccountDeletion)
 		})
 		m.Group("/appearance", func() {
 			m.Get("", user_setting.Appearance)
@@ -420,7 +439,8 @@ func RegisterRoutes(m *web.Route) {
 				m.Post("/delete", security.DeleteOpenID)
 				m.Post("/toggle_visibility", security.ToggleOpenIDVisibility)
 			}, openIDSignInEnabled)
-			m.Post("/account_link", linkAccountEnabled, security.DeleteAccountLink)
+			m.Post("/account_link", security.DeleteAccountLink)
+			m.Post("/account_link/password", bindIgnErr(forms.LinkPasswordSourceForm{}), security.LinkPasswordSourcePost)
 		})
 		m.Group("/applications/oauth2", func() {
 			m.Get("/{id}", user_setting.OAuth2ApplicationShow)
@@ -437,6 +457,11 @@ func RegisterRoutes(m *web.Route) {
 			Post(bindIgnErr(forms.AddKeyForm{}), user_setting.KeysPost)
 		m.Post("/keys/delete", user_setting.DeleteKey)
 		m.Get("/organization", user_setting.Organization)
+		m.Combo("/moderation").Get(user_setting.Moderation).Post(user_setting.ModerationPost)
+		m.Get("/moderation/reports", user_setting.ModerationReports)
+		m.Post("/moderation/reports/{id}", user_setting.ModerationReportPost)
+		m.Get("/moderation/log", user_setting.ModerationLog)
+		m.Combo("/searches").Get(user_setting.SavedSearches).Post(user_setting.SavedSearchesPost)
 		m.Get("/repos", user_setting.Repos)
 		m.Post("/repos/unadopted", user_setting.AdoptOrDeleteRepository)
 	}, reqSignIn, func(ctx *context.Context) {
@@ -457,6 +482,8 @@ func RegisterRoutes(m *web.Route) {
 		m.Post("/logout", auth.SignOut)
 		m.Get("/task/{task}", reqSignIn, user.TaskStatus)
 		m.Get("/stopwatches", reqSignIn, user.GetStopwatches)
+		m.Get("/watching.opml", reqSignIn, feed.ShowWatchingOPML)
+		m.Get("/searches/{id}", reqSignIn, feed.ShowSavedSearchFeed)
 		m.Get("/search", ignExploreSignIn, user.Search)
 		m.Group("/oauth2", func() {
 			m.Get("/{provider}", auth.SignInOAuth)
@@ -495,6 +522,7 @@ func RegisterRoutes(m *web.Route) {
 			m.Combo("/new").Get(admin.NewUser).Post(bindIgnErr(forms.AdminCreateUserForm{}), admin.NewUserPost)
 			m.Combo("/{userid}").Get(admin.EditUser).Post(bindIgnErr(forms.AdminEditUserForm{}), admin.EditUserPost)
 			m.Post("/{userid}/delete", admin.DeleteUser)
+			m.Post("/{userid}/merge", admin.MergeUserPost)
 			m.Post("/{userid}/avatar", bindIgnErr(forms.AvatarForm{}), admin.AvatarPost)
 			m.Post("/{userid}/avatar/delete", admin.DeleteAvatar)
 		})
@@ -564,6 +592,32 @@ func RegisterRoutes(m *web.Route) {
 			m.Post("/{authid}/delete", admin.DeleteAuthSource)
 		})
 
+		m.Group("/badges", func() {
+			m.Get("", admin.Badges)
+			m.Post("/new", bindIgnErr(forms.AdminBadgeForm{}), admin.NewBadgePost)
+			m.Combo("/{badgeid}").Get(admin.EditBadge).Post(bindIgnErr(forms.AdminBadgeForm{}), admin.EditBadgePost)
+			m.Post("/{badgeid}/delete", admin.DeleteBadge)
+			m.Post("/{badgeid}/users", admin.AddBadgeUserPost)
+			m.Post("/{badgeid}/users/delete", admin.RemoveBadgeUser)
+		})
+
+		m.Group("/collections", func() {
+			m.Get("", admin.ExploreCollections)
+			m.Post("/new", bindIgnErr(forms.AdminExploreCollectionForm{}), admin.NewExploreCollectionPost)
+			m.Combo("/{collectionid}").Get(admin.EditExploreCollection).Post(bindIgnErr(forms.AdminExploreCollectionForm{}), admin.EditExploreCollectionPost)
+			m.Post("/{collectionid}/delete", admin.DeleteExploreCollection)
+			m.Post("/{collectionid}/repos", admin.AddExploreCollectionRepoPost)
+			m.Post("/{collectionid}/repos/delete", admin.RemoveExploreCollectionRepo)
+		})
+
+		m.Group("/moderation", func() {
+			m.Get("/reports", admin.ModerationReports)
+			m.Post("/reports/{id}", admin.ModerationReportPost)
+			m.Get("/holds", admin.ModerationHolds)
+			m.Post("/holds/{id}", admin.ModerationHoldPost)
+			m.Get("/log", admin.ModerationLog)
+		})
+
 		m.Group("/notices", func() {
 			m.Get("", admin.Notices)
 			m.Post("/delete", admin.DeleteNotices)
@@ -582,6 +636,8 @@ func RegisterRoutes(m *web.Route) {
 			m.Get(".gpg", user.ShowGPGKeys)
 			m.Get(".rss", feed.ShowUserFeedRSS)
 			m.Get(".atom", feed.ShowUserFeedAtom)
+			m.Get("/stars.rss", feed.ShowUserStarsFeedRSS)
+			m.Get("/stars.atom", feed.ShowUserStarsFeedAtom)
 			m.Get("", user.Profile)
 		}, context_service.UserAssignmentWeb())
 		m.Get("/attachments/{uuid}", repo.GetAttachment)
@@ -640,6 +696,7 @@ func RegisterRoutes(m *web.Route) {
 			m.Get("/milestones/{team}", reqMilestonesDashboardPageEnabled, user.Milestones)
 			m.Post("/members/action/{action}", org.MembersAction)
 			m.Get("/teams", org.Teams)
+			m.Get("/maintenance", org.Maintenance)
 		}, context.OrgAssignment(true, false, true))
 
 		m.Group("/{org}", func() {
@@ -701,6 +758,35 @@ func RegisterRoutes(m *web.Route) {
 					m.Post("/initialize", bindIgnErr(forms.InitializeLabelsForm{}), org.InitializeLabels)
 				})
 
+				m.Group("/roles", func() {
+					m.Get("", org.Roles)
+					m.Combo("/new").Get(org.NewRole).Post(bindIgnErr(forms.CreateRoleForm{}), org.NewRolePost)
+					m.Combo("/{id}").Get(org.EditRole).Post(bindIgnErr(forms.CreateRoleForm{}), org.EditRolePost)
+					m.Post("/{id}/delete", org.DeleteRole)
+				})
+
+				m.Group("/domains", func() {
+					m.Combo("").Get(org.Domains).Post(bindIgnErr(forms.AddOrgDomainForm{}), org.DomainsPost)
+					m.Post("/{id}", org.DomainTeamPost)
+					m.Post("/{id}/verify", org.VerifyDomainPost)
+					m.Post("/{id}/delete", org.DeleteDomain)
+				})
+
+				m.Group("/bots", func() {
+					m.Combo("").Get(org.Bots).Post(bindIgnErr(forms.CreateOrgBotForm{}), org.BotsPost)
+					m.Get("/{botname}", org.Bot)
+					m.Post("/{botname}/tokens", bindIgnErr(forms.NewBotTokenForm{}), org.BotTokenPost)
+					m.Post("/{botname}/tokens/delete", org.DeleteBotToken)
+					m.Post("/{botname}/delete", org.DeleteBot)
+				})
+
+				m.Combo("/branches").Get(org.Branches).Post(bindIgnErr(forms.RenameOrgDefaultBranchesForm{}), org.BranchesPost)
+
+				m.Combo("/moderation").Get(org.Moderation).Post(org.ModerationPost)
+				m.Get("/moderation/reports", org.ModerationReports)
+				m.Post("/moderation/reports/{id}", org.ModerationReportPost)
+				m.Get("/moderation/log", org.ModerationLog)
+
 				m.Route("/delete", "GET,POST", org.SettingsDelete)
 			})
 		}, context.OrgAssignment(true, true))
@@ -752,6 +838,8 @@ func RegisterRoutes(m *web.Route) {
 			m.Post("/avatar", bindIgnErr(forms.AvatarForm{}), repo.SettingsAvatar)
 			m.Post("/avatar/delete", repo.SettingsDeleteAvatar)
 
+			m.Combo("/moderation").Get(repo.Moderation).Post(repo.ModerationPost)
+
 			m.Group("/collaboration", func() {
 				m.Combo("").Get(repo.Collaboration).Post(repo.CollaborationPost)
 				m.Post("/access_mode", repo.ChangeCollaborationAccessMode)
@@ -777,6 +865,36 @@ func RegisterRoutes(m *web.Route) {
 				m.Post("/{id}", bindIgnErr(forms.ProtectTagForm{}), context.RepoMustNotBeArchived(), repo.EditProtectedTagPost)
 			})
 
+			m.Group("/issue_schedules", func() {
+				m.Get("", repo.IssueSchedules)
+				m.Post("", bindIgnErr(forms.IssueScheduleForm{}), context.RepoMustNotBeArchived(), repo.NewIssueSchedulePost)
+				m.Post("/delete", repo.DeleteIssueSchedulePost)
+				m.Get("/{id}", repo.EditIssueSchedule)
+				m.Post("/{id}", bindIgnErr(forms.IssueScheduleForm{}), context.RepoMustNotBeArchived(), repo.EditIssueSchedulePost)
+			}, repo.MustEnableIssues)
+
+			m.Group("/issue_import", func() {
+				m.Get("", repo.IssueImport)
+				m.Post("", bindIgnErr(forms.IssueImportUploadForm{}), context.RepoMustNotBeArchived(), repo.IssueImportUploadPost)
+				m.Post("/confirm", bindIgnErr(forms.IssueImportForm{}), context.RepoMustNotBeArchived(), repo.MustNotBeIssueMirror, repo.IssueImportPost)
+				m.Get("/export", repo.IssueExport)
+			}, repo.MustEnableIssues)
+
+			m.Group("/issue_mirror", func() {
+				m.Get("", repo.IssueMirrorSettings)
+				m.Post("", bindIgnErr(forms.IssueMirrorForm{}), context.RepoMustNotBeArchived(), repo.IssueMirrorSettingsPost)
+				m.Post("/sync", context.RepoMustNotBeArchived(), repo.SyncIssueMirrorPost)
+				m.Post("/delete", repo.DeleteIssueMirrorPost)
+			}, repo.MustEnableIssues, repo.MustEnableIssueMirrors)
+
+			m.Group("/components", func() {
+				m.Get("", repo.ComponentsSettings)
+				m.Post("", bindIgnErr(forms.ComponentForm{}), context.RepoMustNotBeArchived(), repo.NewComponentPost)
+				m.Post("/delete", repo.DeleteComponentPost)
+				m.Get("/{id}", repo.EditComponent)
+				m.Post("/{id}", bindIgnErr(forms.ComponentForm{}), context.RepoMustNotBeArchived(), repo.EditComponentPost)
+			})
+
 			m.Group("/hooks/git", func() {
 				m.Get("", repo.GitHooks)
 				m.Combo("/{name}").Get(repo.GitHooksEdit).
@@ -843,6 +961,14 @@ func RegisterRoutes(m *web.Route) {
 
 	m.Post("/{username}/{reponame}/action/{action}", reqSignIn, context.RepoAssignment, context.UnitTypes(), repo.Action)
 
+	// The service desk doesn't require any access to the repository
+	m.Group("/{username}/{reponame}/service_desk", func() {
+		m.Combo("").Get(repo.ServiceDesk).
+			Post(bindIgnErr(forms.ServiceDeskIssueForm{}), repo.ServiceDeskPost)
+		m.Get("/{index}", repo.ServiceDeskIssue)
+		m.Post("/{index}/comments", bindIgnErr(forms.ServiceDeskCommentForm{}), repo.ServiceDeskCommentPost)
+	}, reqSignIn, repo.ServiceDeskAssignment)
+
 	// Grouping for those endpoints not requiring authentication
 	m.Group("/{username}/{reponame}", func() {
 		m.Group("/milestone", func() {
@@ -857,7 +983,7 @@ func RegisterRoutes(m *web.Route) {
 		m.Get("/compare", repo.MustBeNotEmpty, reqRepoCodeReader, repo.SetEditorconfigIfExists, ignSignIn, repo.SetDiffViewStyle, repo.SetWhitespaceBehavior, repo.CompareDiff)
 		m.Combo("/compare/*", repo.MustBeNotEmpty, reqRepoCodeReader, repo.SetEditorconfigIfExists).
 			Get(ignSignIn, repo.SetDiffViewStyle, repo.SetWhitespaceBehavior, repo.CompareDiff).
-			Post(reqSignIn, context.RepoMustNotBeArchived(), reqRepoPullsReader, repo.MustAllowPulls, bindIgnErr(forms.CreateIssueForm{}), repo.SetWhitespaceBehavior, repo.CompareAndPullRequestPost)
+			Post(reqSignIn, context.RepoMustNotBeArchived(), reqRepoPullsReader, repo.MustAllowPulls, repo.MustAllowInteraction, bindIgnErr(forms.CreateIssueForm{}), repo.SetWhitespaceBehavior, repo.CompareAndPullRequestPost)
 		m.Group("/{type:issues|pulls}", func() {
 			m.Group("/{index}", func() {
 				m.Get("/info", repo.GetIssueInfo)
@@ -872,8 +998,9 @@ func RegisterRoutes(m *web.Route) {
 				m.Combo("").Get(context.RepoRef(), repo.NewIssue).
 					Post(bindIgnErr(forms.CreateIssueForm{}), repo.NewIssuePost)
 				m.Get("/choose", context.RepoRef(), repo.NewIssueChooseTemplate)
-			})
+			}, repo.MustAllowInteraction, repo.MustNotBeIssueMirror)
 			m.Get("/search", repo.ListIssues)
+			m.Get("/similar", repo.SimilarIssues)
 		}, context.RepoMustNotBeArchived(), reqRepoIssueReader)
 		// FIXME: should use different URLs but mostly same logic for comments of issue and pull request.
 		// So they can apply their own enable/disable logic on routers.
@@ -882,13 +1009,19 @@ func RegisterRoutes(m *web.Route) {
 				m.Post("/title", repo.UpdateIssueTitle)
 				m.Post("/content", repo.UpdateIssueContent)
 				m.Post("/deadline", bindIgnErr(structs.EditDeadlineOption{}), repo.UpdateIssueDeadline)
+				m.Post("/fields/{id}", repo.UpdateIssueFieldValue)
 				m.Post("/watch", repo.IssueWatch)
+				m.Post("/vote", repo.MustAllowUserComment, repo.IssueVote)
 				m.Post("/ref", repo.UpdateIssueRef)
 				m.Post("/viewed-files", repo.UpdateViewedFiles)
 				m.Group("/dependency", func() {
 					m.Post("/add", repo.AddDependency)
 					m.Post("/delete", repo.RemoveDependency)
 				})
+				m.Group("/sub_issues", func() {
+					m.Post("/add", repo.AddSubIssue)
+					m.Post("/remove", repo.RemoveSubIssue)
+				})
 				m.Combo("/comments").Post(repo.MustAllowUserComment, bindIgnErr(forms.CreateCommentForm{}), repo.NewComment)
 				m.Group("/times", func() {
 					m.Post("/add", bindIgnErr(forms.AddTimeManuallyForm{}), repo.AddTimeManually)
@@ -901,14 +1034,18 @@ func RegisterRoutes(m *web.Route) {
 				m.Post("/reactions/{action}", bindIgnErr(forms.ReactionForm{}), repo.ChangeIssueReaction)
 				m.Post("/lock", reqRepoIssueWriter, bindIgnErr(forms.IssueLockForm{}), repo.LockIssue)
 				m.Post("/unlock", reqRepoIssueWriter, repo.UnlockIssue)
+				m.Post("/confidential", reqRepoIssueWriter, repo.IssueConfidential)
+				m.Post("/pin", reqRepoIssuesOrPullsWriter, repo.PinIssue)
+				m.Post("/unpin", reqRepoIssuesOrPullsWriter, repo.UnpinIssue)
 				m.Post("/delete", reqRepoAdmin, repo.DeleteIssue)
-			}, context.RepoMustNotBeArchived())
+			}, context.RepoMustNotBeArchived(), repo.MustNotBeMirroredIssue)
 			m.Group("/{index}", func() {
 				m.Get("/attachments", repo.GetIssueAttachments)
 				m.Get("/attachments/{uuid}", repo.GetAttachment)
 			})
 			m.Group("/{index}", func() {
 				m.Post("/content-history/soft-delete", repo.SoftDeleteContentHistory)
+				m.Post("/report", bindIgnErr(forms.ReportContentForm{}), repo.ReportContent)
 			})
 
 			m.Post("/labels", reqRepoIssuesOrPullsWriter, repo.UpdateIssueLabel)
@@ -922,11 +1059,17 @@ func RegisterRoutes(m *web.Route) {
 			m.Post("/attachments", repo.UploadIssueAttachment)
 			m.Post("/attachments/remove", repo.DeleteAttachment)
 		}, context.RepoMustNotBeArchived())
+		m.Group("/{type:issues|pulls}/filters", func() {
+			m.Post("", repo.SaveIssueFilter)
+			m.Post("/{id}/pin", repo.PinIssueFilter)
+			m.Post("/{id}/delete", repo.DeleteIssueFilter)
+		}, reqRepoIssuesOrPullsReader)
 		m.Group("/comments/{id}", func() {
 			m.Post("", repo.UpdateCommentContent)
 			m.Post("/delete", repo.DeleteComment)
 			m.Post("/reactions/{action}", bindIgnErr(forms.ReactionForm{}), repo.ChangeCommentReaction)
-		}, context.RepoMustNotBeArchived())
+			m.Post("/{action:hide|unhide}", repo.SetCommentHidden)
+		}, context.RepoMustNotBeArchived(), repo.MustNotBeMirroredComment)
 		m.Group("/comments/{id}", func() {
 			m.Get("/attachments", repo.GetCommentAttachments)
 		})
@@ -981,17 +1124,22 @@ func RegisterRoutes(m *web.Route) {
 			m.Post("/delete", repo.DeleteBranchPost)
 			m.Post("/restore", repo.RestoreBranchPost)
 		}, context.RepoMustNotBeArchived(), reqRepoCodeWriter, repo.MustBeNotEmpty)
+		m.Post("/sync_fork", context.RepoMustNotBeArchived(), reqRepoCodeWriter, repo.MustBeNotEmpty, repo.SyncForkPost)
 	}, reqSignIn, context.RepoAssignment, context.UnitTypes())
 
 	// Releases
 	m.Group("/{username}/{reponame}", func() {
 		m.Get("/tags", repo.TagsList, repo.MustBeNotEmpty,
 			reqRepoCodeReader, context.RepoRefByType(context.RepoRefTag))
+		m.Get("/tags.rss", repo.MustBeNotEmpty, reqRepoCodeReader, feed.ShowTagsFeedRSS)
+		m.Get("/tags.atom", repo.MustBeNotEmpty, reqRepoCodeReader, feed.ShowTagsFeedAtom)
 		m.Group("/releases", func() {
 			m.Get("/", repo.Releases)
 			m.Get("/tag/*", repo.SingleRelease)
 			m.Get("/latest", repo.LatestRelease)
 		}, repo.MustBeNotEmpty, reqRepoReleaseReader, context.RepoRefByType(context.RepoRefTag, true))
+		m.Get("/releases.rss", repo.MustBeNotEmpty, reqRepoReleaseReader, feed.ShowReleasesFeedRSS)
+		m.Get("/releases.atom", repo.MustBeNotEmpty, reqRepoReleaseReader, feed.ShowReleasesFeedAtom)
 		m.Get("/releases/attachments/{uuid}", repo.GetAttachment, repo.MustBeNotEmpty, reqRepoReleaseReader)
 		m.Group("/releases", func() {
 			m.Get("/new", repo.NewRelease)
@@ -1033,6 +1181,8 @@ func RegisterRoutes(m *web.Route) {
 
 	m.Group("/{username}/{reponame}", func() {
 		m.Group("", func() {
+			m.Get("/issues.rss", reqRepoIssueReader, feed.ShowRepoIssuesFeedRSS)
+			m.Get("/issues.atom", reqRepoIssueReader, feed.ShowRepoIssuesFeedAtom)
 			m.Get("/{type:issues|pulls}", repo.Issues)
 			m.Get("/{type:issues|pulls}/{index}", repo.ViewIssue)
 			m.Group("/{type:issues|pulls}/{index}/content-history", func() {
@@ -1042,6 +1192,7 @@ func RegisterRoutes(m *web.Route) {
 			})
 			m.Get("/labels", reqRepoIssuesOrPullsReader, repo.RetrieveLabels, repo.Labels)
 			m.Get("/milestones", reqRepoIssuesOrPullsReader, repo.Milestones)
+			m.Get("/components", reqRepoIssuesOrPullsReader, repo.Components)
 		}, context.RepoRef())
 
 		if setting.Packages.Enabled {
@@ -1098,6 +1249,8 @@ func RegisterRoutes(m *web.Route) {
 		m.Group("/wiki", func() {
 			m.Get("/raw/*", repo.WikiRaw)
 		}, repo.MustEnableWiki)
+		m.Get("/wiki.rss", repo.MustEnableWiki, feed.ShowWikiFeedRSS)
+		m.Get("/wiki.atom", repo.MustEnableWiki, feed.ShowWikiFeedAtom)
 
 		m.Group("/activity", func() {
 			m.Get("", repo.Activity)
@@ -1190,7 +1343,7 @@ func RegisterRoutes(m *web.Route) {
 			m.Get("/commit/*", context.RepoRefByType(context.RepoRefCommit), repo.RefCommits)
 			// "/*" route is deprecated, and kept for backward compatibility
 			m.Get("/*", context.RepoRefByType(context.RepoRefLegacy), repo.RefCommits)
-		}, repo.MustBeNotEmpty, reqRepoCodeReader)
+		}, repo.MustBeNotEmpty, reqRepoCodeReader, repo.CommitsFeedType)
 
 		m.Group("/blame", func() {
 			m.Get("/branch/*", context.RepoRefByType(context.RepoRefBranch), repo.RefBlame)
@@ -1214,7 +1367,27 @@ func RegisterRoutes(m *web.Route) {
 
 		m.Group("", func() {
 			m.Get("/forks", repo.Forks)
+			m.Get("/forks/network", repo.ForkNetwork)
+			m.Get("/deployments", repo.Deployments)
+		}, context.RepoRef(), reqRepoCodeReader)
+		m.Group("/security/advisories", func() {
+			m.Get("", repo.Advisories)
+			m.Combo("/new", reqSignIn).Get(repo.NewAdvisory).
+				Post(bindIgnErr(forms.AdvisoryForm{}), repo.NewAdvisoryPost)
+			m.Group("/{id}", func() {
+				m.Get("", repo.ViewAdvisory)
+				m.Group("", func() {
+					m.Combo("/edit").Get(repo.EditAdvisory).
+						Post(bindIgnErr(forms.AdvisoryForm{}), repo.EditAdvisoryPost)
+					m.Post("/accept", repo.AcceptAdvisory)
+					m.Post("/publish", repo.PublishAdvisory)
+					m.Post("/close", repo.CloseAdvisory)
+					m.Post("/fork", repo.CreateAdvisoryFork)
+				}, reqSignIn)
+			}, repo.MustGetAdvisory)
 		}, context.RepoRef(), reqRepoCodeReader)
+		m.Get("/security.rss", reqRepoCodeReader, feed.ShowAdvisoriesFeedRSS)
+		m.Get("/security.atom", reqRepoCodeReader, feed.ShowAdvisoriesFeedAtom)
 		m.Get("/commit/{sha:([a-f0-9]{7,40})}.{ext:patch|diff}",
 			repo.MustBeNotEmpty, reqRepoCodeReader, repo.RawDiff)
 	}, ignSignIn, context.RepoAssignment, context.UnitTypes())
@@ -0,0 +1,176 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package advisory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/notification"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/timeutil"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+var (
+	// ErrPrivateReportingDisabled indicates that the repository does not accept private vulnerability reports
+	ErrPrivateReportingDisabled = errors.New("Private vulnerability reporting is disabled")
+	// ErrInvalidTransition indicates that the advisory can not be moved to the requested state
+	ErrInvalidTransition = errors.New("Advisory can not change to this state")
+	// ErrForkExists indicates that the advisory already has a temporary private fork
+	ErrForkExists = errors.New("Advisory already has a temporary private fork")
+)
+
+// CanManage returns true if the permission allows to triage, edit and publish the advisories of the repository
+func CanManage(perm access_model.Permission) bool {
+	return perm.IsAdmin() || perm.CanWrite(unit.TypeCode)
+}
+
+// CanView returns true if the doer may read the advisory: published advisories are public to the readers
+// of the code, drafts are only visible to the collaborators and to the reporter
+func CanView(adv *advisory_model.Advisory, doer *user_model.User, perm access_model.Permission) bool {
+	if !perm.CanRead(unit.TypeCode) {
+		return false
+	}
+	if adv.IsPublished() || CanManage(perm) {
+		return true
+	}
+	return doer != nil && doer.ID == adv.ReporterID
+}
+
+// CanEdit returns true if the doer may change the content of the advisory
+func CanEdit(adv *advisory_model.Advisory, doer *user_model.User, perm access_model.Permission) bool {
+	if CanManage(perm) {
+		return adv.State != advisory_model.StateClosed
+	}
+	return doer != nil && doer.ID == adv.ReporterID && adv.State == advisory_model.StateTriage
+}
+
+// ReportVulnerability privately reports a vulnerability to the maintainers of the repository
+func ReportVulnerability(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, adv *advisory_model.Advisory) error {
+	if !repo.EnablePrivateReporting {
+		return ErrPrivateReportingDisabled
+	}
+	adv.RepoID = repo.ID
+	adv.Repo = repo
+	adv.ReporterID = doer.ID
+	adv.Reporter = doer
+	adv.State = advisory_model.StateTriage
+	return advisory_model.CreateAdvisory(ctx, adv)
+}
+
+// CreateDraft creates a draft advisory which is only visible to the collaborators
+func CreateDraft(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, adv *advisory_model.Advisory) error {
+	adv.RepoID = repo.ID
+	adv.Repo = repo
+	adv.ReporterID = doer.ID
+	adv.Reporter = doer
+	adv.State = advisory_model.StateDraft
+	return advisory_model.CreateAdvisory(ctx, adv)
+}
+
+// AcceptReport turns a reported vulnerability into a draft advisory
+func AcceptReport(ctx context.Context, adv *advisory_model.Advisory) error {
+	if adv.State != advisory_model.StateTriage {
+		return ErrInvalidTransition
+	}
+	adv.State = advisory_model.StateDraft
+	return advisory_model.UpdateAdvisory(ctx, adv, "state")
+}
+
+// Publish discloses the advisory, removes its temporary fork and notifies the watchers of the repository
+func Publish(ctx context.Context, doer *user_model.User, adv *advisory_model.Advisory) error {
+	if adv.State != advisory_model.StateDraft {
+		return ErrInvalidTransition
+	}
+	adv.State = advisory_model.StatePublished
+	adv.PublisherID = doer.ID
+	adv.Publisher = doer
+	adv.PublishedUnix = timeutil.TimeStampNow()
+	if err := advisory_model.UpdateAdvisory(ctx, adv, "state", "publisher_id", "published_unix"); err != nil {
+		return err
+	}
+
+	if err := deleteFork(ctx, doer, adv); err != nil {
+		log.Error("deleteFork[%d]: %v", adv.ID, err)
+	}
+
+	notification.NotifyPublishAdvisory(doer, adv)
+	return nil
+}
+
+// Close rejects a report or withdraws a draft advisory and removes its temporary fork
+func Close(ctx context.Context, doer *user_model.User, adv *advisory_model.Advisory) error {
+	if !adv.IsOpen() {
+		return ErrInvalidTransition
+	}
+	adv.State = advisory_model.StateClosed
+	if err := advisory_model.UpdateAdvisory(ctx, adv, "state"); err != nil {
+		return err
+	}
+	return deleteFork(ctx, doer, adv)
+}
+
+// CreateFork creates a temporary private fork of the repository to prepare the fix of the advisory.
+// The reporter is added as a collaborator of the fork.
+func CreateFork(ctx context.Context, doer *user_model.User, adv *advisory_model.Advisory) (*repo_model.Repository, error) {
+	if !adv.IsOpen() {
+		return nil, ErrInvalidTransition
+	}
+	if err := adv.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+	if adv.Fork != nil {
+		return nil, ErrForkExists
+	}
+	if err := adv.Repo.GetOwner(ctx); err != nil {
+		return nil, err
+	}
+
+	fork, err := repo_service.ForkRepository(ctx, doer, adv.Repo.Owner, repo_service.ForkRepoOptions{
+		BaseRepo:    adv.Repo,
+		Name:        fmt.Sprintf("%s-advisory-%d", adv.Repo.Name, adv.ID),
+		Description: fmt.Sprintf("Temporary private fork for the security advisory %q", adv.Title),
+		Temporary:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	adv.ForkID = fork.ID
+	adv.Fork = fork
+	if err := advisory_model.UpdateAdvisory(ctx, adv, "fork_id"); err != nil {
+		return nil, err
+	}
+
+	if adv.ReporterID != doer.ID && adv.Reporter != nil && adv.Reporter.ID > 0 {
+		if err := repo_module.AddCollaborator(fork, adv.Reporter); err != nil {
+			return nil, err
+		}
+	}
+	return fork, nil
+}
+
+func deleteFork(ctx context.Context, doer *user_model.User, adv *advisory_model.Advisory) error {
+	if err := adv.LoadAttributes(ctx); err != nil {
+		return err
+	}
+	if adv.Fork == nil {
+		return nil
+	}
+	if err := repo_service.DeleteRepository(ctx, doer, adv.Fork, false); err != nil {
+		return err
+	}
+	adv.ForkID = 0
+	adv.Fork = nil
+	return advisory_model.UpdateAdvisory(ctx, adv, "fork_id")
+}
@@ -0,0 +1,65 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package advisory
+
+import (
+	"testing"
+
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	"code.gitea.io/gitea/models/db"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdvisoryWorkflow(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	reporter := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	report := &advisory_model.Advisory{Title: "XSS in the wiki", Severity: advisory_model.SeverityHigh}
+	assert.ErrorIs(t, ReportVulnerability(db.DefaultContext, repo, reporter, report), ErrPrivateReportingDisabled)
+
+	repo.EnablePrivateReporting = true
+	assert.NoError(t, ReportVulnerability(db.DefaultContext, repo, reporter, report))
+	assert.Equal(t, advisory_model.StateTriage, report.State)
+
+	reporterPerm, err := access_model.GetUserRepoPermission(db.DefaultContext, repo, reporter)
+	assert.NoError(t, err)
+	ownerPerm, err := access_model.GetUserRepoPermission(db.DefaultContext, repo, owner)
+	assert.NoError(t, err)
+	assert.True(t, CanView(report, reporter, reporterPerm))
+	assert.True(t, CanEdit(report, reporter, reporterPerm))
+	assert.True(t, CanView(report, owner, ownerPerm))
+	assert.False(t, CanView(report, nil, reporterPerm))
+
+	assert.ErrorIs(t, Publish(db.DefaultContext, owner, report), ErrInvalidTransition)
+	assert.NoError(t, AcceptReport(db.DefaultContext, report))
+	assert.Equal(t, advisory_model.StateDraft, report.State)
+	// reporters can not change accepted reports anymore
+	assert.False(t, CanEdit(report, reporter, reporterPerm))
+
+	fork, err := CreateFork(db.DefaultContext, owner, report)
+	assert.NoError(t, err)
+	assert.True(t, fork.IsPrivate)
+	assert.Equal(t, repo.OwnerID, fork.OwnerID)
+	_, err = CreateFork(db.DefaultContext, owner, report)
+	assert.ErrorIs(t, err, ErrForkExists)
+
+	assert.NoError(t, Publish(db.DefaultContext, owner, report))
+	report = unittest.AssertExistsAndLoadBean(t, &advisory_model.Advisory{ID: report.ID})
+	assert.Equal(t, advisory_model.StatePublished, report.State)
+	assert.EqualValues(t, 0, report.ForkID)
+	assert.NotZero(t, report.PublishedUnix)
+	unittest.AssertNotExistsBean(t, &repo_model.Repository{ID: fork.ID})
+	assert.True(t, CanView(report, nil, reporterPerm))
+
+	assert.ErrorIs(t, Close(db.DefaultContext, owner, report), ErrInvalidTransition)
+}
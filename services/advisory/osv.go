@@ -0,0 +1,111 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package advisory
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	"code.gitea.io/gitea/modules/osv"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// OSVID returns the id of the advisory in the OSV database of the instance
+func OSVID(adv *advisory_model.Advisory) string {
+	return fmt.Sprintf("%s-%d", setting.Advisories.IDPrefix, adv.ID)
+}
+
+// ToOSV converts a published advisory into an OSV vulnerability, the repository of the advisory must be loaded
+func ToOSV(adv *advisory_model.Advisory) *osv.Vulnerability {
+	published := adv.PublishedUnix.AsTime().UTC()
+	vuln := &osv.Vulnerability{
+		SchemaVersion: osv.SchemaVersion,
+		ID:            OSVID(adv),
+		Modified:      adv.UpdatedUnix.AsTime().UTC(),
+		Published:     &published,
+		Summary:       adv.Title,
+		Details:       adv.Description,
+		References: []*osv.Reference{
+			{Type: osv.ReferenceTypeAdvisory, URL: adv.HTMLURL()},
+			{Type: osv.ReferenceTypePackage, URL: adv.Repo.HTMLURL()},
+		},
+		DatabaseSpecific: map[string]interface{}{
+			"severity": strings.ToUpper(string(adv.Severity)),
+			"cwe_ids":  adv.CWEIDs,
+		},
+	}
+	if adv.CVEID != "" {
+		vuln.Aliases = []string{adv.CVEID}
+	}
+	if adv.PackageName != "" {
+		ranges, versions := osv.ParseVersions(adv.VulnerableVersions, adv.PatchedVersions)
+		vuln.Affected = []*osv.Affected{{
+			Package: osv.Package{
+				Ecosystem: osv.NormalizeEcosystem(adv.Ecosystem),
+				Name:      adv.PackageName,
+			},
+			Ranges:   ranges,
+			Versions: versions,
+		}}
+	}
+	return vuln
+}
+
+func findVulnerabilities(ctx context.Context, opts *advisory_model.FindAdvisoriesOptions) ([]*osv.Vulnerability, error) {
+	opts.State = advisory_model.StatePublished
+	opts.PublicOnly = true
+	advisories, _, err := advisory_model.FindAdvisories(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	vulns := make([]*osv.Vulnerability, 0, len(advisories))
+	for _, adv := range advisories {
+		if err := adv.LoadAttributes(ctx); err != nil {
+			return nil, err
+		}
+		vulns = append(vulns, ToOSV(adv))
+	}
+	return vulns, nil
+}
+
+// GetVulnerability returns the published advisory of a public repository with the given OSV id
+func GetVulnerability(ctx context.Context, id string) (*osv.Vulnerability, error) {
+	advID, err := strconv.ParseInt(strings.TrimPrefix(id, setting.Advisories.IDPrefix+"-"), 10, 64)
+	if err != nil || !strings.HasPrefix(id, setting.Advisories.IDPrefix+"-") {
+		return nil, advisory_model.ErrAdvisoryNotExist
+	}
+	vulns, err := findVulnerabilities(ctx, &advisory_model.FindAdvisoriesOptions{ID: advID})
+	if err != nil {
+		return nil, err
+	} else if len(vulns) == 0 {
+		return nil, advisory_model.ErrAdvisoryNotExist
+	}
+	return vulns[0], nil
+}
+
+// ListVulnerabilities returns the published advisories of public repositories affecting packages of the ecosystem
+func ListVulnerabilities(ctx context.Context, ecosystem string) ([]*osv.Vulnerability, error) {
+	return findVulnerabilities(ctx, &advisory_model.FindAdvisoriesOptions{Ecosystem: osv.NormalizeEcosystem(ecosystem)})
+}
+
+// QueryVulnerabilities returns the published advisories of public repositories affecting the version of the package.
+// All the advisories of the package are returned if no version is given.
+func QueryVulnerabilities(ctx context.Context, ecosystem, name, version string) ([]*osv.Vulnerability, error) {
+	ecosystem = osv.NormalizeEcosystem(ecosystem)
+	vulns, err := findVulnerabilities(ctx, &advisory_model.FindAdvisoriesOptions{Ecosystem: ecosystem, PackageName: name})
+	if err != nil || version == "" {
+		return vulns, err
+	}
+	result := make([]*osv.Vulnerability, 0, len(vulns))
+	for _, vuln := range vulns {
+		if vuln.Affects(ecosystem, name, version) {
+			result = append(result, vuln)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package advisory
+
+import (
+	"fmt"
+	"testing"
+
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/osv"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryVulnerabilities(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	adv := &advisory_model.Advisory{
+		Title:              "Prototype pollution",
+		Severity:           advisory_model.SeverityCritical,
+		CVEID:              "CVE-2022-1234",
+		Ecosystem:          "npm",
+		PackageName:        "left-pad",
+		VulnerableVersions: "< 1.3.0",
+		PatchedVersions:    "1.3.0",
+	}
+	assert.NoError(t, CreateDraft(db.DefaultContext, repo, owner, adv))
+
+	// drafts are not distributed
+	vulns, err := QueryVulnerabilities(db.DefaultContext, "npm", "left-pad", "")
+	assert.NoError(t, err)
+	assert.Empty(t, vulns)
+
+	assert.NoError(t, Publish(db.DefaultContext, owner, adv))
+
+	vuln, err := GetVulnerability(db.DefaultContext, OSVID(adv))
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("GITEA-%d", adv.ID), vuln.ID)
+	assert.Equal(t, []string{"CVE-2022-1234"}, vuln.Aliases)
+	assert.Len(t, vuln.Affected, 1)
+	assert.Equal(t, osv.Package{Ecosystem: "npm", Name: "left-pad"}, vuln.Affected[0].Package)
+
+	_, err = GetVulnerability(db.DefaultContext, "GHSA-1")
+	assert.ErrorIs(t, err, advisory_model.ErrAdvisoryNotExist)
+
+	vulns, err = QueryVulnerabilities(db.DefaultContext, "npm", "left-pad", "1.2.0")
+	assert.NoError(t, err)
+	assert.Len(t, vulns, 1)
+	vulns, err = QueryVulnerabilities(db.DefaultContext, "npm", "left-pad", "1.3.0")
+	assert.NoError(t, err)
+	assert.Empty(t, vulns)
+
+	vulns, err = ListVulnerabilities(db.DefaultContext, "NPM")
+	assert.NoError(t, err)
+	assert.Len(t, vulns, 1)
+	vulns, err = ListVulnerabilities(db.DefaultContext, "PyPI")
+	assert.NoError(t, err)
+	assert.Empty(t, vulns)
+}
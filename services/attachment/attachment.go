@@ -12,6 +12,8 @@ import (
 
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/storage"
 	"code.gitea.io/gitea/modules/upload"
 	"code.gitea.io/gitea/modules/util"
@@ -19,11 +21,47 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrRepoAttachmentsSizeExceeded represents a "RepoAttachmentsSizeExceeded" kind of error.
+type ErrRepoAttachmentsSizeExceeded struct {
+	RepoID  int64
+	MaxSize int64
+}
+
+// IsErrRepoAttachmentsSizeExceeded checks if an error is a ErrRepoAttachmentsSizeExceeded.
+func IsErrRepoAttachmentsSizeExceeded(err error) bool {
+	_, ok := err.(ErrRepoAttachmentsSizeExceeded)
+	return ok
+}
+
+func (err ErrRepoAttachmentsSizeExceeded) Error() string {
+	return fmt.Sprintf("the attachments of the repository exceed the maximum size [repo_id: %d, max_size: %d MiB]", err.RepoID, err.MaxSize)
+}
+
+// checkRepoAttachmentsSize checks the attachments of the repository don't exceed the maximum size with the new
+// attachment of the given size
+func checkRepoAttachmentsSize(ctx context.Context, repoID, size int64) error {
+	if setting.Attachment.RepoMaxSize <= 0 {
+		return nil
+	}
+	total, err := repo_model.GetRepoAttachmentsSize(ctx, repoID)
+	if err != nil {
+		return err
+	}
+	if total+size > setting.Attachment.RepoMaxSize*1024*1024 {
+		return ErrRepoAttachmentsSizeExceeded{RepoID: repoID, MaxSize: setting.Attachment.RepoMaxSize}
+	}
+	return nil
+}
+
 // NewAttachment creates a new attachment object, but do not verify.
 func NewAttachment(attach *repo_model.Attachment, file io.Reader) (*repo_model.Attachment, error) {
 	if attach.RepoID == 0 {
 		return nil, fmt.Errorf("attachment %s should belong to a repository", attach.Name)
 	}
+	// fail early when the repository is already full
+	if err := checkRepoAttachmentsSize(db.DefaultContext, attach.RepoID, 0); err != nil {
+		return nil, err
+	}
 
 	err := db.WithTx(func(ctx context.Context) error {
 		attach.UUID = uuid.New().String()
@@ -33,6 +71,13 @@ func NewAttachment(attach *repo_model.Attachment, file io.Reader) (*repo_model.A
 		}
 		attach.Size = size
 
+		if err := checkRepoAttachmentsSize(ctx, attach.RepoID, size); err != nil {
+			if err := storage.Attachments.Delete(attach.RelativePath()); err != nil {
+				log.Error("Delete attachment %s failed: %v", attach.UUID, err)
+			}
+			return err
+		}
+
 		return db.Insert(ctx, attach)
 	})
 
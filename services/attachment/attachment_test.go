@@ -7,12 +7,14 @@ package attachment
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -45,3 +47,22 @@ func TestUploadAttachment(t *testing.T) {
 	assert.EqualValues(t, user.ID, attachment.UploaderID)
 	assert.Equal(t, int64(0), attachment.DownloadCount)
 }
+
+func TestUploadAttachmentRepoMaxSize(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	defer func(maxSize int64) { setting.Attachment.RepoMaxSize = maxSize }(setting.Attachment.RepoMaxSize)
+	setting.Attachment.RepoMaxSize = 1
+
+	attach, err := NewAttachment(&repo_model.Attachment{RepoID: 1, UploaderID: 1, Name: "small.txt"}, strings.NewReader("small"))
+	assert.NoError(t, err)
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: attach.ID})
+
+	_, err = NewAttachment(&repo_model.Attachment{RepoID: 1, UploaderID: 1, Name: "large.txt"}, strings.NewReader(strings.Repeat("a", 1024*1024)))
+	assert.True(t, IsErrRepoAttachmentsSizeExceeded(err))
+	unittest.AssertNotExistsBean(t, &repo_model.Attachment{RepoID: 1, Name: "large.txt"})
+
+	// the other repositories are not affected
+	_, err = NewAttachment(&repo_model.Attachment{RepoID: 2, UploaderID: 1, Name: "large.txt"}, strings.NewReader(strings.Repeat("a", 1024*1024)))
+	assert.NoError(t, err)
+}
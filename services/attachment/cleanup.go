@@ -0,0 +1,88 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// cleanupBatchSize is the number of attachments deleted at once by the cleanup
+const cleanupBatchSize = 100
+
+// deleteAttachmentsByBatch deletes with their files the attachments returned by find until there is none left
+func deleteAttachmentsByBatch(ctx context.Context, find func() ([]*repo_model.Attachment, error)) (int, error) {
+	deleted := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return deleted, fmt.Errorf("aborted after deleting %d attachments", deleted)
+		default:
+		}
+
+		attachments, err := find()
+		if err != nil {
+			return deleted, err
+		}
+		if len(attachments) == 0 {
+			return deleted, nil
+		}
+		n, err := repo_model.DeleteAttachments(ctx, attachments, false)
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+		for _, a := range attachments {
+			// the attachment is removed from the database, so a missing file must not stop the cleanup
+			if err := storage.Attachments.Delete(a.RelativePath()); err != nil {
+				log.Warn("Delete attachment %s failed: %v", a.UUID, err)
+			}
+		}
+	}
+}
+
+// DeleteOrphanedAttachments deletes the attachments created before the given duration which are linked
+// neither to an existing issue nor to an existing release, including the uploads never linked to anything
+func DeleteOrphanedAttachments(ctx context.Context, olderThan time.Duration) error {
+	createdBefore := timeutil.TimeStamp(time.Now().Add(-olderThan).Unix())
+	deleted, err := deleteAttachmentsByBatch(ctx, func() ([]*repo_model.Attachment, error) {
+		return repo_model.FindOrphanedAttachments(ctx, createdBefore, cleanupBatchSize)
+	})
+	log.Trace("Deleted %d orphaned attachments", deleted)
+	return err
+}
+
+// DeleteExpiredAttachments deletes the attachments of the issues and pull requests closed for longer
+// than the maximum age of the attachments on closed issues, if set
+func DeleteExpiredAttachments(ctx context.Context) error {
+	if setting.Attachment.MaxAgeOnClosedIssues <= 0 {
+		return nil
+	}
+	closedBefore := timeutil.TimeStamp(time.Now().Add(-setting.Attachment.MaxAgeOnClosedIssues).Unix())
+	deleted, err := deleteAttachmentsByBatch(ctx, func() ([]*repo_model.Attachment, error) {
+		return repo_model.FindAttachmentsOfClosedIssues(ctx, closedBefore, cleanupBatchSize)
+	})
+	log.Trace("Deleted %d attachments of closed issues", deleted)
+	return err
+}
+
+// Cleanup deletes the orphaned attachments created before the given duration and the expired attachments
+// of the closed issues
+func Cleanup(ctx context.Context, olderThan time.Duration) error {
+	if err := DeleteOrphanedAttachments(ctx, olderThan); err != nil {
+		return fmt.Errorf("DeleteOrphanedAttachments: %w", err)
+	}
+	if err := DeleteExpiredAttachments(ctx); err != nil {
+		return fmt.Errorf("DeleteExpiredAttachments: %w", err)
+	}
+	return nil
+}
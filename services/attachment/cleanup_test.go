@@ -0,0 +1,65 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package attachment
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteOrphanedAttachments(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	old, err := NewAttachment(&repo_model.Attachment{RepoID: 1, UploaderID: 1, Name: "old.txt"}, strings.NewReader("old"))
+	assert.NoError(t, err)
+	_, err = db.GetEngine(db.DefaultContext).Exec("UPDATE `attachment` SET created_unix = ? WHERE id = ?", 946684800, old.ID)
+	assert.NoError(t, err)
+	recent, err := NewAttachment(&repo_model.Attachment{RepoID: 1, UploaderID: 1, Name: "recent.txt"}, strings.NewReader("recent"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, DeleteOrphanedAttachments(db.DefaultContext, time.Hour))
+	// the upload never linked to an issue or a release
+	unittest.AssertNotExistsBean(t, &repo_model.Attachment{ID: old.ID})
+	// the upload which may still be linked
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: recent.ID})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: 1})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: 9})
+
+	// the attachments of a deleted release
+	assert.NoError(t, repo_model.DeleteReleaseByID(2))
+	assert.NoError(t, DeleteOrphanedAttachments(db.DefaultContext, time.Hour))
+	unittest.AssertNotExistsBean(t, &repo_model.Attachment{ID: 11})
+}
+
+func TestDeleteExpiredAttachments(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	defer func(maxAge time.Duration) { setting.Attachment.MaxAgeOnClosedIssues = maxAge }(setting.Attachment.MaxAgeOnClosedIssues)
+
+	setting.Attachment.MaxAgeOnClosedIssues = 0
+	assert.NoError(t, DeleteExpiredAttachments(db.DefaultContext))
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: 2})
+
+	setting.Attachment.MaxAgeOnClosedIssues = time.Hour
+	_, err := db.GetEngine(db.DefaultContext).In("id", 4, 5).Cols("closed_unix").Update(&issues_model.Issue{ClosedUnix: 946684830})
+	assert.NoError(t, err)
+	assert.NoError(t, DeleteExpiredAttachments(db.DefaultContext))
+	// the attachments of the closed issues 4 and 5 and of their comments
+	for _, id := range []int64{2, 5, 6, 7} {
+		unittest.AssertNotExistsBean(t, &repo_model.Attachment{ID: id})
+	}
+	for _, id := range []int64{1, 3, 4, 9} {
+		unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: id})
+	}
+}
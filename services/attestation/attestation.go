@@ -0,0 +1,129 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package attestation
+
+import (
+	"context"
+
+	attestation_model "code.gitea.io/gitea/models/attestation"
+	packages_model "code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/intoto"
+)
+
+// CreateRepoAttestation stores an in-toto attestation of a commit or a tag of a repository
+func CreateRepoAttestation(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, subjectType attestation_model.SubjectType, subject, content string) (*attestation_model.Attestation, error) {
+	statement, err := intoto.Parse([]byte(content))
+	if err != nil {
+		return nil, err
+	}
+	a := &attestation_model.Attestation{
+		OwnerID:       repo.OwnerID,
+		RepoID:        repo.ID,
+		SubjectType:   subjectType,
+		Subject:       subject,
+		PredicateType: statement.PredicateType,
+		Content:       content,
+		UploaderID:    doer.ID,
+		Digests:       statement.Digests(),
+	}
+	return a, attestation_model.CreateAttestation(ctx, a)
+}
+
+// CreatePackageAttestation stores an in-toto attestation of a package version
+func CreatePackageAttestation(ctx context.Context, pd *packages_model.PackageDescriptor, doer *user_model.User, content string) (*attestation_model.Attestation, error) {
+	statement, err := intoto.Parse([]byte(content))
+	if err != nil {
+		return nil, err
+	}
+	a := &attestation_model.Attestation{
+		OwnerID:       pd.Owner.ID,
+		PackageID:     pd.Package.ID,
+		SubjectType:   attestation_model.SubjectTypePackage,
+		Subject:       pd.Version.LowerVersion,
+		PredicateType: statement.PredicateType,
+		Content:       content,
+		UploaderID:    doer.ID,
+		Digests:       statement.Digests(),
+	}
+	return a, attestation_model.CreateAttestation(ctx, a)
+}
+
+// RequiresAttestations returns whether a repository requires an attestation to publish a release or to tag a
+// container image linked to it
+func RequiresAttestations(ctx context.Context, repo *repo_model.Repository) (bool, error) {
+	releasesUnit, err := repo.GetUnitCtx(ctx, unit.TypeReleases)
+	if err != nil {
+		if repo_model.IsErrUnitTypeNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return releasesUnit.ReleasesConfig().RequireAttestations, nil
+}
+
+// CheckReleaseAttestation returns an ErrAttestationRequired if the repository of a release to publish requires an
+// attestation and neither its tag nor the commit it points to has one
+func CheckReleaseAttestation(ctx context.Context, gitRepo *git.Repository, rel *repo_model.Release) error {
+	if rel.IsDraft || rel.IsTag {
+		return nil
+	}
+	if err := rel.LoadAttributes(); err != nil {
+		return err
+	}
+	if required, err := RequiresAttestations(ctx, rel.Repo); err != nil || !required {
+		return err
+	}
+
+	var commitSHA string
+	if gitRepo.IsTagExist(rel.TagName) {
+		commit, err := gitRepo.GetTagCommit(rel.TagName)
+		if err != nil {
+			return err
+		}
+		commitSHA = commit.ID.String()
+	} else if commit, err := gitRepo.GetCommit(rel.Target); err == nil {
+		commitSHA = commit.ID.String()
+	} else if !git.IsErrNotExist(err) {
+		return err
+	}
+
+	has, err := attestation_model.HasReleaseAttestation(ctx, rel.RepoID, rel.TagName, commitSHA)
+	if err != nil {
+		return err
+	} else if !has {
+		return attestation_model.ErrAttestationRequired{Subject: rel.TagName}
+	}
+	return nil
+}
+
+// CheckPackageAttestation returns an ErrAttestationRequired if a package is linked to a repository requiring
+// attestations and no attestation of one of its versions has a subject with a digest
+func CheckPackageAttestation(ctx context.Context, p *packages_model.Package, digest string) error {
+	if p.RepoID == 0 {
+		return nil
+	}
+	repo, err := repo_model.GetRepositoryByIDCtx(ctx, p.RepoID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if required, err := RequiresAttestations(ctx, repo); err != nil || !required {
+		return err
+	}
+
+	has, err := attestation_model.HasPackageAttestation(ctx, p.ID, digest)
+	if err != nil {
+		return err
+	} else if !has {
+		return attestation_model.ErrAttestationRequired{Subject: digest}
+	}
+	return nil
+}
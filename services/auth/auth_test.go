@@ -9,7 +9,10 @@ import (
 	"net/http"
 	"testing"
 
+	auth_model "code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_isGitRawOrLFSPath(t *testing.T) {
@@ -129,3 +132,35 @@ func Test_isGitRawOrLFSPath(t *testing.T) {
 	}
 	setting.LFS.StartServer = origLFSStartServer
 }
+
+func Test_checkAccessTokenScope(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		scope  auth_model.AccessTokenScope
+
+		want error
+	}{
+		{"POST", "/user/settings", auth_model.AccessTokenScopeAll, nil},
+		{"GET", "/api/v1/user", auth_model.AccessTokenScopeRead, nil},
+		{"HEAD", "/user2/repo1/info/lfs/objects/oid", auth_model.AccessTokenScopeRead, nil},
+		{"POST", "/user2/repo1.git/git-upload-pack", auth_model.AccessTokenScopeRead, nil},
+		{"POST", "/user2/repo1.git/info/lfs/objects/batch", auth_model.AccessTokenScopeRead, nil},
+		{"POST", "/login/oauth/introspect", auth_model.AccessTokenScopeRead, nil},
+		{"POST", "/user/settings", auth_model.AccessTokenScopeRead, ErrAccessTokenReadOnly},
+		{"POST", "/user2/repo1/issues/1/title", auth_model.AccessTokenScopeRead, ErrAccessTokenReadOnly},
+		{"POST", "/api/v1/user/repos", auth_model.AccessTokenScopeRead, ErrAccessTokenReadOnly},
+		{"DELETE", "/api/v1/repos/user2/repo1", auth_model.AccessTokenScopeRead, ErrAccessTokenReadOnly},
+		{"POST", "/user2/repo1.git/git-receive-pack", auth_model.AccessTokenScopeRead, ErrAccessTokenReadOnly},
+		{"PUT", "/user2/repo1.git/info/lfs/objects/oid/6", auth_model.AccessTokenScopeRead, ErrAccessTokenReadOnly},
+		{"POST", "/user2/repo1.git/info/lfs/verify", auth_model.AccessTokenScopeRead, ErrAccessTokenReadOnly},
+		{"GET", "/user2/repo1.rss", auth_model.AccessTokenScopeFeed, nil},
+		{"GET", "/api/v1/user", auth_model.AccessTokenScopeFeed, ErrAccessTokenScope},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, "http://localhost"+tt.path, nil)
+			assert.Equal(t, tt.want, checkAccessTokenScope(req, tt.scope))
+		})
+	}
+}
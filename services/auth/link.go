@@ -0,0 +1,96 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"errors"
+	"strings"
+
+	"code.gitea.io/gitea/models/auth"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/services/auth/source/oauth2"
+	"code.gitea.io/gitea/services/auth/source/smtp"
+)
+
+// ErrLinkPrimarySource is returned when a user tries to link the source they already sign in with
+var ErrLinkPrimarySource = errors.New("the account already signs in with this authentication source")
+
+// IsLinkablePasswordSource returns true if accounts of the source can be linked to existing users with a login name and password
+func IsLinkablePasswordSource(source *auth.Source) bool {
+	return source.IsLDAP() || source.IsDLDAP() || source.IsSMTP() || source.IsPAM()
+}
+
+// LinkablePasswordSources returns the active sources whose accounts the user can link to their account
+func LinkablePasswordSources(user *user_model.User) ([]*auth.Source, error) {
+	sources, err := auth.AllActiveSources()
+	if err != nil {
+		return nil, err
+	}
+	linkable := make([]*auth.Source, 0, len(sources))
+	for _, source := range sources {
+		if source.ID != user.LoginSource && IsLinkablePasswordSource(source) {
+			linkable = append(linkable, source)
+		}
+	}
+	return linkable, nil
+}
+
+// authenticateLinked checks the credentials of the external account against the source.
+// The user is copied so that the source only sees the external login name and never creates a new user.
+func authenticateLinked(source *auth.Source, user *user_model.User, loginName, password string) error {
+	authenticator, ok := source.Cfg.(PasswordAuthenticator)
+	if !ok || !IsLinkablePasswordSource(source) {
+		return smtp.ErrUnsupportedLoginType
+	}
+	linked := *user
+	linked.LoginName = loginName
+	_, err := authenticator.Authenticate(&linked, loginName, password)
+	return err
+}
+
+// LinkPasswordSource verifies the login name and password against the source and links the external account
+// to the user, who can then sign in with either of them
+func LinkPasswordSource(user *user_model.User, source *auth.Source, loginName, password string) error {
+	loginName = strings.TrimSpace(loginName)
+	if !source.IsActive {
+		return oauth2.ErrAuthSourceNotActived
+	}
+	if source.ID == user.LoginSource {
+		return ErrLinkPrimarySource
+	}
+	if len(loginName) == 0 {
+		return user_model.ErrUserNotExist{Name: loginName}
+	}
+
+	if err := authenticateLinked(source, user, loginName, password); err != nil {
+		return err
+	}
+
+	return user_model.LinkExternalToUser(user, &user_model.ExternalLoginUser{
+		ExternalID:    loginName,
+		UserID:        user.ID,
+		LoginSourceID: source.ID,
+		Name:          loginName,
+	})
+}
+
+// signInLinked authenticates the user who linked the external account of the source with this login name,
+// it returns nil if no user linked such an account
+func signInLinked(source *auth.Source, loginName, password string) (*user_model.User, error) {
+	link := &user_model.ExternalLoginUser{ExternalID: strings.TrimSpace(loginName), LoginSourceID: source.ID}
+	has, err := user_model.GetExternalLogin(link)
+	if err != nil || !has {
+		return nil, err
+	}
+
+	user, err := user_model.GetUserByID(link.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authenticateLinked(source, user, link.ExternalID, password); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -6,6 +6,7 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -14,7 +15,6 @@ import (
 	"code.gitea.io/gitea/models/db"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
-	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/web/middleware"
 	"code.gitea.io/gitea/services/auth/source/oauth2"
 )
@@ -60,7 +60,7 @@ func (o *OAuth2) Name() string {
 }
 
 // userIDFromToken returns the user id corresponding to the OAuth token.
-func (o *OAuth2) userIDFromToken(req *http.Request, store DataStore) int64 {
+func (o *OAuth2) userIDFromToken(req *http.Request, w http.ResponseWriter, store DataStore) int64 {
 	_ = req.ParseForm()
 
 	// Check access token.
@@ -90,18 +90,14 @@ func (o *OAuth2) userIDFromToken(req *http.Request, store DataStore) int64 {
 		}
 		return uid
 	}
-	t, err := auth_model.GetAccessTokenBySHA(tokenSHA)
+	t, err := VerifyAccessToken(req, store, tokenSHA)
 	if err != nil {
-		if !auth_model.IsErrAccessTokenNotExist(err) && !auth_model.IsErrAccessTokenEmpty(err) {
+		if !auth_model.IsErrAccessTokenNotExist(err) && !auth_model.IsErrAccessTokenEmpty(err) && !errors.Is(err, ErrAccessTokenScope) && !errors.Is(err, ErrAccessTokenReadOnly) {
 			log.Error("GetAccessTokenBySHA: %v", err)
 		}
+		WriteAccessTokenError(w, err)
 		return 0
 	}
-	t.UpdatedUnix = timeutil.TimeStampNow()
-	if err = auth_model.UpdateAccessToken(t); err != nil {
-		log.Error("UpdateAccessToken: %v", err)
-	}
-	store.GetData()["IsApiToken"] = true
 	return t.UID
 }
 
@@ -114,11 +110,11 @@ func (o *OAuth2) Verify(req *http.Request, w http.ResponseWriter, store DataStor
 		return nil
 	}
 
-	if !middleware.IsAPIPath(req) && !isAttachmentDownload(req) && !isAuthenticatedTokenRequest(req) {
+	if !middleware.IsAPIPath(req) && !isAttachmentDownload(req) && !isAuthenticatedTokenRequest(req) && !middleware.IsFeedRequest(req) {
 		return nil
 	}
 
-	id := o.userIDFromToken(req, store)
+	id := o.userIDFromToken(req, w, store)
 	if id <= 0 {
 		return nil
 	}
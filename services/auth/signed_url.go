@@ -0,0 +1,107 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// Ensure the struct implements the interface.
+var (
+	_ Method = &SignedURL{}
+	_ Named  = &SignedURL{}
+)
+
+// SignedURLMethodName is the constant name of the signed URL authentication method
+const SignedURLMethodName = "signed_url"
+
+// signedURLPathRe matches the downloads which can be accessed through a signed URL:
+// raw files, archives and attachments such as release assets
+var signedURLPathRe = regexp.MustCompile(`^/(?:attachments/|[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+/(?:raw/|media/|archive/))`)
+
+// SignedURL implements the Auth interface and authenticates the GET requests of downloads
+// carrying a signature minted by a user, the request is made as that user until the URL expires.
+type SignedURL struct{}
+
+// Name represents the name of auth method
+func (s *SignedURL) Name() string {
+	return SignedURLMethodName
+}
+
+// signature returns the signature of the path for the user, it changes with the user's salt and
+// password hash so that changing the password revokes the signed URLs of the user
+func signature(path string, u *user_model.User, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(setting.SecretKey+u.Rands+u.Passwd))
+	_, _ = mac.Write([]byte(strconv.FormatInt(u.ID, 10) + ":" + strconv.FormatInt(expires, 10) + ":" + path))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignURL returns the query string granting the user's access to the path, relative to the
+// application sub URL and escaped, until expires
+func SignURL(path string, u *user_model.User, expires time.Time) string {
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+	query.Set("signer", strconv.FormatInt(u.ID, 10))
+	query.Set("signature", signature(path, u, expires.Unix()))
+	return query.Encode()
+}
+
+// IsSignedURLPath returns true if the path, relative to the application sub URL, can be signed
+func IsSignedURLPath(path string) bool {
+	return signedURLPathRe.MatchString(path)
+}
+
+// Verify checks the signature and the expiry of the request and returns the user who signed it.
+// Returns nil if the request isn't signed or the signature is invalid.
+func (s *SignedURL) Verify(req *http.Request, w http.ResponseWriter, store DataStore, sess SessionStore) *user_model.User {
+	if req.Method != "GET" && req.Method != "HEAD" {
+		return nil
+	}
+	query := req.URL.Query()
+	sig := query.Get("signature")
+	if sig == "" || !IsSignedURLPath(req.URL.Path) {
+		return nil
+	}
+
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		log.Trace("SignedURL: expired or invalid expiry for %s", req.URL.Path)
+		return nil
+	}
+	signerID, err := strconv.ParseInt(query.Get("signer"), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	u, err := user_model.GetUserByID(signerID)
+	if err != nil {
+		if !user_model.IsErrUserNotExist(err) {
+			log.Error("GetUserByID: %v", err)
+		}
+		return nil
+	}
+	if !u.IsActive || u.ProhibitLogin {
+		return nil
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(signature(req.URL.EscapedPath(), u, expires))) {
+		log.Trace("SignedURL: invalid signature for %s", req.URL.Path)
+		return nil
+	}
+
+	log.Trace("SignedURL: valid signature of user[%d] for %s", u.ID, req.URL.Path)
+	return u
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSignedURLPath(t *testing.T) {
+	assert.True(t, IsSignedURLPath("/owner/repo/raw/branch/main/README.md"))
+	assert.True(t, IsSignedURLPath("/owner/repo/media/tag/v1.0/logo.png"))
+	assert.True(t, IsSignedURLPath("/owner/repo/archive/main.zip"))
+	assert.True(t, IsSignedURLPath("/attachments/a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"))
+	assert.False(t, IsSignedURLPath("/owner/repo/settings"))
+	assert.False(t, IsSignedURLPath("/owner/repo/releases/download/v1.0/asset.zip"))
+	assert.False(t, IsSignedURLPath("/api/v1/repos/owner/repo/raw/README.md"))
+}
+
+func TestSignURL(t *testing.T) {
+	u := &user_model.User{ID: 2, Rands: "salt"}
+	expires := time.Unix(1666224000, 0)
+	path := "/owner/repo/raw/branch/main/README.md"
+
+	query, err := url.ParseQuery(SignURL(path, u, expires))
+	assert.NoError(t, err)
+	assert.Equal(t, "1666224000", query.Get("expires"))
+	assert.Equal(t, "2", query.Get("signer"))
+	sig := query.Get("signature")
+	assert.Equal(t, signature(path, u, expires.Unix()), sig)
+
+	assert.NotEqual(t, sig, signature("/owner/repo/raw/branch/main/LICENSE", u, expires.Unix()))
+	assert.NotEqual(t, sig, signature(path, u, expires.Unix()+1))
+	assert.NotEqual(t, sig, signature(path, &user_model.User{ID: 3, Rands: "salt"}, expires.Unix()))
+	// changing the salt of the user revokes the signature
+	assert.NotEqual(t, sig, signature(path, &user_model.User{ID: 2, Rands: "new salt"}, expires.Unix()))
+	assert.Len(t, sig, 64)
+
+	// so does changing the password of the user
+	assert.NoError(t, u.SetPassword("password"))
+	sig = signature(path, u, expires.Unix())
+	assert.NoError(t, u.SetPassword("password"))
+	assert.NotEqual(t, sig, signature(path, u, expires.Unix()))
+}
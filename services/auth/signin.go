@@ -56,6 +56,10 @@ func UserSignIn(username, password string) (*user_model.User, *auth.Source, erro
 		}
 
 		if hasUser {
+			if user.IsBot() {
+				return nil, nil, user_model.ErrUserProhibitLogin{UID: user.ID, Name: user.Name}
+			}
+
 			source, err := auth.GetSourceByID(user.LoginSource)
 			if err != nil {
 				return nil, nil, err
@@ -101,7 +105,11 @@ func UserSignIn(username, password string) (*user_model.User, *auth.Source, erro
 			continue
 		}
 
-		authUser, err := authenticator.Authenticate(nil, username, password)
+		// an account of the source linked to an existing user signs in as this user
+		authUser, err := signInLinked(source, username, password)
+		if err == nil && authUser == nil {
+			authUser, err = authenticator.Authenticate(nil, username, password)
+		}
 
 		if err == nil {
 			if !authUser.ProhibitLogin {
@@ -33,6 +33,9 @@ type Source struct {
 	RestrictedGroup    string
 	SkipLocalTwoFA     bool `json:",omitempty"`
 
+	GroupTeamMap       string `json:",omitempty"` // Map group claim values to organization teams
+	GroupTeamMapStrict bool   `json:",omitempty"` // Remove users from all teams of the mapped organizations which their groups don't map to
+
 	// reference to the authSource
 	authSource *auth.Source
 }
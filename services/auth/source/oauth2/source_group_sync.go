@@ -0,0 +1,131 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package oauth2
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	unit_model "code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+)
+
+// mapGroupsToTeams parses the group team map, which maps group claim values to organizations teams
+func (source *Source) mapGroupsToTeams() map[string]map[string][]string {
+	groupsToTeams := make(map[string]map[string][]string)
+	if err := json.Unmarshal([]byte(source.GroupTeamMap), &groupsToTeams); err != nil {
+		log.Error("Failed to unmarshall OAuth2 group team map: %v", err)
+	}
+	return groupsToTeams
+}
+
+// SyncGroupsToTeams maps the groups of the user to organization team memberships.
+// The user is added to the teams mapped to their groups and removed from the teams mapped to other groups,
+// in strict mode the user is also removed from all other teams of the mapped organizations.
+// Mapped teams which don't exist yet are created, but the organizations must exist.
+func (source *Source) SyncGroupsToTeams(ctx context.Context, user *user_model.User, groups []string) {
+	if source.GroupTeamMap == "" {
+		return
+	}
+
+	userGroups := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		userGroups[group] = true
+	}
+	// organization name -> team name -> whether the user belongs to the team
+	mappedTeams := make(map[string]map[string]bool)
+	for group, memberships := range source.mapGroupsToTeams() {
+		for orgName, teamNames := range memberships {
+			if _, ok := mappedTeams[orgName]; !ok {
+				mappedTeams[orgName] = make(map[string]bool)
+			}
+			for _, teamName := range teamNames {
+				mappedTeams[orgName][teamName] = mappedTeams[orgName][teamName] || userGroups[group]
+			}
+		}
+	}
+
+	for orgName, teamNames := range mappedTeams {
+		org, err := organization.GetOrgByName(orgName)
+		if err != nil {
+			// organization must be created before OAuth2 group sync
+			log.Warn("OAuth2 group sync: Could not find organisation %s: %v", orgName, err)
+			continue
+		}
+
+		granted := make(map[int64]bool)
+		mapped := make(map[string]bool, len(teamNames))
+		for teamName, isGranted := range teamNames {
+			mapped[strings.ToLower(teamName)] = true
+			if !isGranted {
+				continue
+			}
+			team, err := getOrCreateSyncedTeam(org, teamName)
+			if err != nil {
+				log.Error("OAuth2 group sync: Could not get team %s of %s: %v", teamName, org.Name, err)
+				continue
+			}
+			granted[team.ID] = true
+			if isMember, err := organization.IsTeamMember(ctx, org.ID, team.ID, user.ID); err != nil || isMember {
+				continue
+			}
+			log.Trace("OAuth2 group sync: adding user [%s] to team [%s] of [%s]", user.Name, team.Name, org.Name)
+			if err := models.AddTeamMember(team, user.ID); err != nil {
+				log.Error("OAuth2 group sync: Could not add user to team: %v", err)
+			}
+		}
+
+		teams, err := organization.FindOrgTeams(ctx, org.ID)
+		if err != nil {
+			log.Error("OAuth2 group sync: Could not find teams of %s: %v", org.Name, err)
+			continue
+		}
+		for _, team := range teams {
+			if granted[team.ID] || (!mapped[team.LowerName] && !source.GroupTeamMapStrict) {
+				continue
+			}
+			if isMember, err := organization.IsTeamMember(ctx, org.ID, team.ID, user.ID); err != nil || !isMember {
+				continue
+			}
+			log.Trace("OAuth2 group sync: removing user [%s] from team [%s] of [%s]", user.Name, team.Name, org.Name)
+			if err := models.RemoveTeamMember(team, user.ID); err != nil {
+				log.Error("OAuth2 group sync: Could not remove user from team: %v", err)
+			}
+		}
+	}
+}
+
+// getOrCreateSyncedTeam returns the team of the organization, which is created with read access to
+// the default units of all repositories if it does not exist yet
+func getOrCreateSyncedTeam(org *organization.Organization, teamName string) (*organization.Team, error) {
+	team, err := org.GetTeam(teamName)
+	if err == nil || !organization.IsErrTeamNotExist(err) {
+		return team, err
+	}
+
+	team = &organization.Team{
+		OrgID:      org.ID,
+		Name:       teamName,
+		AccessMode: perm.AccessModeRead,
+		Units:      make([]*organization.TeamUnit, 0, len(unit_model.DefaultRepoUnits)),
+	}
+	for _, tp := range unit_model.DefaultRepoUnits {
+		team.Units = append(team.Units, &organization.TeamUnit{
+			OrgID:      org.ID,
+			Type:       tp,
+			AccessMode: perm.AccessModeRead,
+		})
+	}
+	if err := models.NewTeam(team); err != nil {
+		return nil, err
+	}
+	log.Trace("OAuth2 group sync: created team [%s] of [%s]", team.Name, org.Name)
+	return team, nil
+}
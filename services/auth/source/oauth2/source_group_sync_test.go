@@ -0,0 +1,51 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package oauth2
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncGroupsToTeams(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	source := &Source{
+		GroupTeamMap: `{"dev": {"user3": ["team1"]}, "ops": {"user3": ["ops"], "missing-org": ["team"]}}`,
+	}
+
+	assertMember := func(teamID int64, expected bool) {
+		isMember, err := organization.IsTeamMember(db.DefaultContext, 3, teamID, user.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, isMember)
+	}
+
+	// the user left "dev" and joined "ops", whose team is created on demand
+	source.SyncGroupsToTeams(db.DefaultContext, user, []string{"ops"})
+	assertMember(2, false)
+	ops := unittest.AssertExistsAndLoadBean(t, &organization.Team{OrgID: 3, LowerName: "ops"})
+	assert.Equal(t, perm.AccessModeRead, ops.AccessMode)
+	assertMember(ops.ID, true)
+
+	// teams which are not mapped are kept unless in strict mode
+	assert.NoError(t, models.AddTeamMember(unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 7}), user.ID))
+	source.SyncGroupsToTeams(db.DefaultContext, user, []string{"dev"})
+	assertMember(2, true)
+	assertMember(ops.ID, false)
+	assertMember(7, true)
+
+	source.GroupTeamMapStrict = true
+	source.SyncGroupsToTeams(db.DefaultContext, user, []string{"dev"})
+	assertMember(2, true)
+	assertMember(7, false)
+}
@@ -37,6 +37,8 @@ type Token struct {
 	GrantID int64     `json:"gnt"`
 	Type    TokenType `json:"tt"`
 	Counter int64     `json:"cnt,omitempty"`
+	// Scope narrows the scope of the grant for the tokens issued by a token exchange, it is nil for the other tokens
+	Scope *string `json:"scp,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -0,0 +1,84 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/web/middleware"
+)
+
+// ErrAccessTokenScope is returned when the scope of an access token doesn't allow a request
+var ErrAccessTokenScope = errors.New("the scope of the access token doesn't allow this request")
+
+// ErrAccessTokenReadOnly is returned when a read-only access token authenticates a request which may change something
+var ErrAccessTokenReadOnly = errors.New("the access token is read-only")
+
+var (
+	gitUploadPackPathRe = regexp.MustCompile(`^/[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+/git-upload-pack$`)
+	lfsBatchPathRe      = regexp.MustCompile(`^/[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+/info/lfs/objects/batch$`)
+)
+
+// isReadOnlyRequest checks if the request can't change anything: its method is safe, or it fetches with git, asks
+// for LFS objects, whose handler refuses the uploads of the read-only tokens, or introspects an OAuth2 token
+func isReadOnlyRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return gitUploadPackPathRe.MatchString(req.URL.Path) || lfsBatchPathRe.MatchString(req.URL.Path) ||
+		req.URL.Path == "/login/oauth/introspect"
+}
+
+// checkAccessTokenScope returns ErrAccessTokenScope when an access token of the scope can't authenticate the
+// request, and ErrAccessTokenReadOnly when the token is read-only and the request may change something
+func checkAccessTokenScope(req *http.Request, scope auth_model.AccessTokenScope) error {
+	switch scope {
+	case auth_model.AccessTokenScopeFeed:
+		if !middleware.IsFeedRequest(req) {
+			return ErrAccessTokenScope
+		}
+	case auth_model.AccessTokenScopeRead:
+		if !isReadOnlyRequest(req) {
+			return ErrAccessTokenReadOnly
+		}
+	}
+	return nil
+}
+
+// WriteAccessTokenError responds 403 when an access token was refused because it is read-only, so that the request
+// fails instead of going on anonymously. The requests whose token was refused for another reason go on anonymously.
+func WriteAccessTokenError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrAccessTokenReadOnly) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+	}
+}
+
+// VerifyAccessToken returns the access token matching tokenSHA when its scope allows the request, or
+// ErrAccessTokenScope or ErrAccessTokenReadOnly when it doesn't. Every authentication method looking up access tokens must use it: it marks the
+// request as authenticated by a token, gives the scope of the token to the routes and records the use of the token.
+func VerifyAccessToken(req *http.Request, store DataStore, tokenSHA string) (*auth_model.AccessToken, error) {
+	token, err := auth_model.GetAccessTokenBySHA(tokenSHA)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAccessTokenScope(req, token.Scope); err != nil {
+		return nil, err
+	}
+
+	token.UpdatedUnix = timeutil.TimeStampNow()
+	if err := auth_model.UpdateAccessToken(token); err != nil {
+		log.Error("UpdateAccessToken: %v", err)
+	}
+
+	store.GetData()["IsApiToken"] = true
+	store.GetData()["ApiTokenScope"] = token.Scope
+	return token, nil
+}
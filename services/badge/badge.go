@@ -0,0 +1,103 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package badge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// Achievement is a badge awarded automatically to the users whose activity matches it
+type Achievement struct {
+	Slug        string
+	Description string
+	// Earners returns the ids of the users who have earned the achievement
+	Earners func(ctx context.Context) ([]int64, error)
+}
+
+func ago(d time.Duration) timeutil.TimeStamp {
+	return timeutil.TimeStamp(time.Now().Add(-d).Unix())
+}
+
+// Achievements lists the achievements known by Gitea
+var Achievements = []*Achievement{
+	{
+		Slug:        "first-pull-merged",
+		Description: "Got a first pull request merged",
+		Earners: func(ctx context.Context) ([]int64, error) {
+			return issues_model.GetUserIDsByMergedPulls(ctx, 1)
+		},
+	},
+	{
+		Slug:        "hundred-reviews",
+		Description: "Submitted 100 reviews",
+		Earners: func(ctx context.Context) ([]int64, error) {
+			return issues_model.GetUserIDsBySubmittedReviews(ctx, 100)
+		},
+	},
+	{
+		Slug:        "long-running-maintainer",
+		Description: "Has been merging pull requests for more than a year",
+		Earners: func(ctx context.Context) ([]int64, error) {
+			return issues_model.GetUserIDsByMergingPeriod(ctx, ago(365*24*time.Hour), ago(90*24*time.Hour))
+		},
+	},
+}
+
+// ensureBadge returns the badge of the achievement, creating it on first use
+func ensureBadge(ctx context.Context, a *Achievement) (*user_model.Badge, error) {
+	b, err := user_model.GetBadgeBySlug(ctx, a.Slug)
+	if err == nil {
+		return b, nil
+	} else if !errors.Is(err, user_model.ErrBadgeNotExist) {
+		return nil, err
+	}
+	b = &user_model.Badge{Slug: a.Slug, Description: a.Description}
+	return b, user_model.CreateBadge(ctx, b)
+}
+
+// AwardAchievements gives the achievement badges to the users who have earned them
+func AwardAchievements(ctx context.Context) error {
+	for _, a := range Achievements {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("aborted awarding achievements before %s", a.Slug)
+		default:
+		}
+
+		b, err := ensureBadge(ctx, a)
+		if err != nil {
+			return err
+		}
+		earners, err := a.Earners(ctx)
+		if err != nil {
+			return err
+		}
+		awarded, err := user_model.GetBadgeUserIDs(ctx, b)
+		if err != nil {
+			return err
+		}
+		has := make(map[int64]bool, len(awarded))
+		for _, id := range awarded {
+			has[id] = true
+		}
+		for _, id := range earners {
+			if has[id] {
+				continue
+			}
+			if err := user_model.AddUserBadge(ctx, &user_model.User{ID: id}, b); err != nil {
+				log.Error("AddUserBadge[%s, %d]: %v", a.Slug, id, err)
+			}
+		}
+	}
+	return nil
+}
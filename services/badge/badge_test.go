@@ -0,0 +1,42 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package badge
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwardAchievements(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	assert.NoError(t, AwardAchievements(db.DefaultContext))
+	// awarding again does not give the badges twice
+	assert.NoError(t, AwardAchievements(db.DefaultContext))
+
+	for _, a := range Achievements {
+		b, err := user_model.GetBadgeBySlug(db.DefaultContext, a.Slug)
+		assert.NoError(t, err)
+		assert.Equal(t, a.Description, b.Description)
+	}
+
+	// the poster of the merged pull request of the fixtures
+	b, err := user_model.GetBadgeBySlug(db.DefaultContext, "first-pull-merged")
+	assert.NoError(t, err)
+	ids, err := user_model.GetBadgeUserIDs(db.DefaultContext, b)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, ids)
+
+	b, err = user_model.GetBadgeBySlug(db.DefaultContext, "long-running-maintainer")
+	assert.NoError(t, err)
+	ids, err = user_model.GetBadgeUserIDs(db.DefaultContext, b)
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+}
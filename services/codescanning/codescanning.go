@@ -0,0 +1,229 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package codescanning
+
+import (
+	"context"
+	"fmt"
+
+	codescanning_model "code.gitea.io/gitea/models/codescanning"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/sarif"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// CanReadAlerts returns true if the permission allows to read the code scanning alerts of the repository. The alerts
+// may reveal vulnerabilities which aren't fixed yet, so like the draft advisories they are only shown to the writers of
+// the code and the admins.
+func CanReadAlerts(perm access_model.Permission) bool {
+	return perm.IsAdmin() || perm.CanWrite(unit.TypeCode)
+}
+
+// UploadReport stores the findings of a SARIF report for a commit of the repository. Every run of the
+// report becomes an analysis, findings already known by their fingerprint are attached to the existing alert.
+// Alerts of a tool which are missing from a report for the default branch are marked as fixed.
+func UploadReport(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, commitSHA, ref string, report *sarif.Report) ([]*codescanning_model.Analysis, error) {
+	ctx, committer, err := db.TxContext()
+	if err != nil {
+		return nil, err
+	}
+	defer committer.Close()
+
+	type runKey struct{ tool, category string }
+	var order []runKey
+	byRun := make(map[runKey][]*sarif.Finding)
+	for _, run := range report.Runs {
+		key := runKey{run.Tool.Driver.Name, ""}
+		if run.AutomationDetails != nil {
+			key.category = run.AutomationDetails.ID
+		}
+		if _, ok := byRun[key]; !ok {
+			order = append(order, key)
+			byRun[key] = nil
+		}
+	}
+	for _, finding := range report.Findings() {
+		key := runKey{finding.ToolName, finding.Category}
+		byRun[key] = append(byRun[key], finding)
+	}
+
+	isDefaultBranch := ref == git.BranchPrefix+repo.DefaultBranch
+	analyses := make([]*codescanning_model.Analysis, 0, len(order))
+	for _, key := range order {
+		findings := byRun[key]
+		analysis := &codescanning_model.Analysis{
+			RepoID:     repo.ID,
+			CommitSHA:  commitSHA,
+			Ref:        ref,
+			ToolName:   key.tool,
+			Category:   key.category,
+			NumResults: len(findings),
+			UploaderID: doer.ID,
+		}
+		if len(findings) > 0 {
+			analysis.ToolVersion = findings[0].ToolVersion
+		}
+		if err := codescanning_model.InsertAnalysis(ctx, analysis); err != nil {
+			return nil, err
+		}
+
+		seen := make(map[string]*codescanning_model.Alert, len(findings))
+		seenIDs := make([]int64, 0, len(findings))
+		instances := make([]*codescanning_model.AlertInstance, 0, len(findings))
+		for _, finding := range findings {
+			alert, ok := seen[finding.Fingerprint]
+			if !ok {
+				if alert, err = syncAlert(ctx, repo.ID, commitSHA, finding); err != nil {
+					return nil, err
+				}
+				seen[finding.Fingerprint] = alert
+				seenIDs = append(seenIDs, alert.ID)
+			}
+			instances = append(instances, &codescanning_model.AlertInstance{
+				AlertID:    alert.ID,
+				AnalysisID: analysis.ID,
+				RepoID:     repo.ID,
+				CommitSHA:  commitSHA,
+				Path:       finding.Path,
+				StartLine:  finding.StartLine,
+				EndLine:    finding.EndLine,
+				Message:    finding.Message,
+			})
+		}
+		if err := codescanning_model.InsertAlertInstances(ctx, instances); err != nil {
+			return nil, err
+		}
+
+		if isDefaultBranch {
+			if err := codescanning_model.FixMissingAlerts(ctx, repo.ID, key.tool, key.category, seenIDs); err != nil {
+				return nil, err
+			}
+		}
+		analyses = append(analyses, analysis)
+	}
+
+	return analyses, committer.Commit()
+}
+
+// syncAlert creates the alert of a finding or updates it with the latest location, reopening it if it had been fixed
+func syncAlert(ctx context.Context, repoID int64, commitSHA string, finding *sarif.Finding) (*codescanning_model.Alert, error) {
+	alert, err := codescanning_model.GetAlertByFingerprint(ctx, repoID, finding.Fingerprint)
+	if err == codescanning_model.ErrAlertNotExist {
+		alert = &codescanning_model.Alert{
+			RepoID:       repoID,
+			Fingerprint:  finding.Fingerprint,
+			ToolName:     finding.ToolName,
+			Category:     finding.Category,
+			FirstSeenSHA: commitSHA,
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	alert.RuleID = finding.RuleID
+	alert.RuleDescription = finding.RuleDescription
+	alert.Severity = finding.Severity
+	alert.Message = finding.Message
+	alert.Path = finding.Path
+	alert.StartLine = finding.StartLine
+	alert.EndLine = finding.EndLine
+	alert.LastSeenSHA = commitSHA
+
+	if alert.ID == 0 {
+		return alert, codescanning_model.InsertAlert(ctx, alert)
+	}
+
+	cols := []string{"rule_id", "rule_description", "severity", "message", "path", "start_line", "end_line", "last_seen_sha"}
+	if alert.State == codescanning_model.AlertStateFixed {
+		alert.State = codescanning_model.AlertStateOpen
+		alert.FixedUnix = 0
+		cols = append(cols, "state", "fixed_unix")
+	}
+	return alert, codescanning_model.UpdateAlertCols(ctx, alert, cols...)
+}
+
+// DismissAlert dismisses an alert so that it neither annotates diffs nor blocks merges anymore
+func DismissAlert(ctx context.Context, alert *codescanning_model.Alert, doer *user_model.User, reason, comment string) error {
+	alert.State = codescanning_model.AlertStateDismissed
+	alert.DismissedByID = doer.ID
+	alert.DismissedBy = doer
+	alert.DismissedReason = reason
+	alert.DismissedComment = comment
+	alert.DismissedUnix = timeutil.TimeStampNow()
+	return codescanning_model.UpdateAlertCols(ctx, alert, "state", "dismissed_by_id", "dismissed_reason", "dismissed_comment", "dismissed_unix")
+}
+
+// ReopenAlert reopens a dismissed alert
+func ReopenAlert(ctx context.Context, alert *codescanning_model.Alert) error {
+	if alert.State != codescanning_model.AlertStateDismissed {
+		return nil
+	}
+	alert.State = codescanning_model.AlertStateOpen
+	alert.DismissedByID = 0
+	alert.DismissedBy = nil
+	alert.DismissedReason = ""
+	alert.DismissedComment = ""
+	alert.DismissedUnix = 0
+	return codescanning_model.UpdateAlertCols(ctx, alert, "state", "dismissed_by_id", "dismissed_reason", "dismissed_comment", "dismissed_unix")
+}
+
+// GetCommitAlertsByPath returns the occurrences of not dismissed alerts in the analyses of a commit, keyed by file path
+func GetCommitAlertsByPath(ctx context.Context, repoID int64, commitSHA string) (map[string][]*codescanning_model.AlertInstance, error) {
+	instances, err := codescanning_model.FindCommitAlertInstances(ctx, repoID, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+	byPath := make(map[string][]*codescanning_model.AlertInstance)
+	for _, instance := range instances {
+		byPath[instance.Path] = append(byPath[instance.Path], instance)
+	}
+	return byPath, nil
+}
+
+// CountPullRequestNewAlerts returns the number of not dismissed alerts found in the head commit of the pull request
+// which are not present in its merge base, or in the latest analyzed commit of the base branch if the merge base was not analyzed
+func CountPullRequestNewAlerts(ctx context.Context, pr *issues_model.PullRequest) (int64, error) {
+	if err := pr.LoadBaseRepoCtx(ctx); err != nil {
+		return 0, err
+	}
+
+	gitRepo, closer, err := git.RepositoryFromContextOrOpen(ctx, pr.BaseRepo.RepoPath())
+	if err != nil {
+		return 0, err
+	}
+	defer closer.Close()
+
+	headSHA, err := gitRepo.GetRefCommitID(pr.GetGitRefName())
+	if err != nil {
+		return 0, fmt.Errorf("GetRefCommitID: %w", err)
+	}
+
+	baseSHA := pr.MergeBase
+	has, err := codescanning_model.HasAnalysisForCommit(ctx, pr.BaseRepoID, baseSHA)
+	if err != nil {
+		return 0, err
+	}
+	if !has {
+		if baseSHA, err = codescanning_model.GetLatestAnalyzedCommit(ctx, pr.BaseRepoID, git.BranchPrefix+pr.BaseBranch); err != nil {
+			return 0, err
+		}
+	}
+
+	return codescanning_model.CountNewAlerts(ctx, pr.BaseRepoID, headSHA, baseSHA)
+}
+
+// MergeBlockedByCodeScanningAlerts returns the number of new alerts if merging the pull request is blocked by them
+func MergeBlockedByCodeScanningAlerts(ctx context.Context, pr *issues_model.PullRequest) (int64, error) {
+	if pr.ProtectedBranch == nil || !pr.ProtectedBranch.BlockOnCodeScanningAlerts {
+		return 0, nil
+	}
+	return CountPullRequestNewAlerts(ctx, pr)
+}
@@ -11,6 +11,7 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/notification"
 	"code.gitea.io/gitea/modules/timeutil"
+	spam_service "code.gitea.io/gitea/services/spam"
 )
 
 // CreateIssueComment creates a plain issue comment.
@@ -27,6 +28,11 @@ func CreateIssueComment(doer *user_model.User, repo *repo_model.Repository, issu
 		return nil, err
 	}
 
+	// nothing is announced about the comments held for review
+	if held, err := spam_service.CheckComment(db.DefaultContext, doer, repo, comment); err != nil || held {
+		return comment, err
+	}
+
 	mentions, err := issues_model.FindAndUpdateIssueMentions(db.DefaultContext, issue, doer, comment.Content)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,256 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package component
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/notification"
+	"code.gitea.io/gitea/modules/notification/base"
+	issue_service "code.gitea.io/gitea/services/issue"
+)
+
+// Init registers the notifier linking the issues and the pull requests to the components they touch
+func Init() error {
+	notification.RegisterNotifier(&componentNotifier{})
+	return nil
+}
+
+type componentNotifier struct {
+	base.NullNotifier
+}
+
+var _ base.Notifier = &componentNotifier{}
+
+// NotifyNewIssue links a new issue to the components whose files it quotes
+func (*componentNotifier) NotifyNewIssue(issue *issues_model.Issue, _ []*user_model.User) {
+	if issue.IsPull {
+		return
+	}
+	if err := TouchComponents(db.DefaultContext, issue, IssueFiles(issue)); err != nil {
+		log.Error("TouchComponents[%d]: %v", issue.ID, err)
+	}
+}
+
+// NotifyIssueChangeContent links an edited issue to the components whose files it quotes
+func (*componentNotifier) NotifyIssueChangeContent(_ *user_model.User, issue *issues_model.Issue, _ string) {
+	if issue.IsPull {
+		return
+	}
+	if err := TouchComponents(db.DefaultContext, issue, IssueFiles(issue)); err != nil {
+		log.Error("TouchComponents[%d]: %v", issue.ID, err)
+	}
+}
+
+// NotifyNewPullRequest links a new pull request to the components whose files it changes
+func (*componentNotifier) NotifyNewPullRequest(pr *issues_model.PullRequest, _ []*user_model.User) {
+	touchPullRequestComponents(pr)
+}
+
+// NotifyPullRequestSynchronized links an updated pull request to the components whose files it changes
+func (*componentNotifier) NotifyPullRequestSynchronized(_ *user_model.User, pr *issues_model.PullRequest) {
+	touchPullRequestComponents(pr)
+}
+
+func touchPullRequestComponents(pr *issues_model.PullRequest) {
+	ctx := db.DefaultContext
+	if err := pr.LoadIssueCtx(ctx); err != nil {
+		log.Error("LoadIssue[%d]: %v", pr.ID, err)
+		return
+	}
+	files, err := PullRequestFiles(ctx, pr)
+	if err != nil {
+		log.Error("PullRequestFiles[%d]: %v", pr.ID, err)
+		return
+	}
+	if err := TouchComponents(ctx, pr.Issue, files); err != nil {
+		log.Error("TouchComponents[%d]: %v", pr.Issue.ID, err)
+	}
+}
+
+// codeSpanPattern matches the paths quoted as code in markdown, e.g. `services/auth/main.go`
+var codeSpanPattern = regexp.MustCompile("`([^`\\s]+)`")
+
+// IssueFiles returns the paths quoted as code in the title and the content of an issue. A quoted directory also
+// stands for the files it contains.
+func IssueFiles(issue *issues_model.Issue) []string {
+	files := make([]string, 0, 5)
+	for _, m := range codeSpanPattern.FindAllStringSubmatch(issue.Title+"\n"+issue.Content, -1) {
+		p := strings.Trim(m[1], "/")
+		if p == "" || strings.HasPrefix(p, ".") && !strings.HasPrefix(p, ".gitea") && !strings.HasPrefix(p, ".github") {
+			continue
+		}
+		files = append(files, p, p+"/")
+	}
+	return files
+}
+
+// PullRequestFiles returns the files changed by a pull request since its merge base
+func PullRequestFiles(ctx context.Context, pr *issues_model.PullRequest) ([]string, error) {
+	if err := pr.LoadBaseRepoCtx(ctx); err != nil {
+		return nil, err
+	}
+	if pr.MergeBase == "" {
+		return nil, nil
+	}
+	gitRepo, err := git.OpenRepository(ctx, pr.BaseRepo.RepoPath())
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	headCommitID, err := gitRepo.GetRefCommitID(pr.GetGitRefName())
+	if err != nil {
+		return nil, err
+	}
+	changed, err := gitRepo.GetFilesChangedBetween(pr.MergeBase, headCommitID)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(changed))
+	for _, file := range changed {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// TouchComponents links an issue or a pull request to the components of its repository some files belong to. The
+// components it didn't touch yet add their labels to it and subscribe their owners if they notify them.
+func TouchComponents(ctx context.Context, issue *issues_model.Issue, files []string) error {
+	if len(files) == 0 {
+		return nil
+	}
+	components, err := issues_model.GetComponents(ctx, issue.RepoID)
+	if err != nil || len(components) == 0 {
+		return err
+	}
+	added, err := issues_model.AddIssueComponents(ctx, issue.ID, issues_model.MatchComponents(components, files))
+	if err != nil || len(added) == 0 {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	doer := user_model.NewGhostUser()
+	if err := addComponentLabels(ctx, issue, doer, added); err != nil {
+		return err
+	}
+	return notifyComponentOwners(ctx, issue, added)
+}
+
+func addComponentLabels(ctx context.Context, issue *issues_model.Issue, doer *user_model.User, components []*issues_model.Component) error {
+	labelIDs := make([]int64, 0, 5)
+	for _, c := range components {
+		labelIDs = append(labelIDs, c.LabelIDs...)
+	}
+	if len(labelIDs) == 0 {
+		return nil
+	}
+	if err := issue.LoadLabels(ctx); err != nil {
+		return err
+	}
+	has := make(map[int64]bool, len(issue.Labels))
+	for _, l := range issue.Labels {
+		has[l.ID] = true
+	}
+
+	all, err := issues_model.GetLabelsByIDs(labelIDs)
+	if err != nil {
+		return err
+	}
+	labels := make([]*issues_model.Label, 0, len(all))
+	for _, l := range all {
+		// the labels which have been deleted or moved are skipped
+		if has[l.ID] || l.RepoID != issue.RepoID && l.OrgID != issue.Repo.OwnerID {
+			continue
+		}
+		has[l.ID] = true
+		labels = append(labels, l)
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return issue_service.AddLabels(issue, doer, labels)
+}
+
+// ComponentOwnerIDs returns the IDs of the owners of a component: its owner users and the members of its owner teams
+func ComponentOwnerIDs(ctx context.Context, c *issues_model.Component) ([]int64, error) {
+	ids := make([]int64, 0, len(c.OwnerUserIDs))
+	seen := make(map[int64]bool)
+	for _, id := range c.OwnerUserIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, teamID := range c.OwnerTeamIDs {
+		members, err := organization.GetTeamMembers(ctx, &organization.SearchMembersOptions{TeamID: teamID})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			if !seen[m.ID] {
+				seen[m.ID] = true
+				ids = append(ids, m.ID)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// notifyComponentOwners subscribes the owners of components who can read an issue to it and notifies them of it
+func notifyComponentOwners(ctx context.Context, issue *issues_model.Issue, components []*issues_model.Component) error {
+	notified := map[int64]bool{issue.PosterID: true}
+	for _, c := range components {
+		if !c.NotifyOwners {
+			continue
+		}
+		ownerIDs, err := ComponentOwnerIDs(ctx, c)
+		if err != nil {
+			return err
+		}
+		for _, id := range ownerIDs {
+			if notified[id] {
+				continue
+			}
+			notified[id] = true
+
+			owner, err := user_model.GetUserByIDCtx(ctx, id)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					continue
+				}
+				return err
+			}
+			perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, owner)
+			if err != nil {
+				return err
+			}
+			if !perm.CanReadIssuesOrPulls(issue.IsPull) {
+				continue
+			}
+			if err := issues_model.CreateOrUpdateIssueWatch(owner.ID, issue.ID, true); err != nil {
+				return err
+			}
+			if err := activities_model.CreateOrUpdateIssueNotifications(issue.ID, 0, issue.PosterID, owner.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
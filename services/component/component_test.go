@@ -0,0 +1,47 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package component
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueFiles(t *testing.T) {
+	issue := &issues_model.Issue{
+		Title:   "Crash in `services/auth`",
+		Content: "The panic comes from `services/auth/main.go`, see `go test ./...` and `.env`.",
+	}
+	assert.Equal(t, []string{"services/auth", "services/auth/", "services/auth/main.go", "services/auth/main.go/"}, IssueFiles(issue))
+}
+
+func TestTouchComponents(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// the pull request 3 of user1 changes a file of the backend owned by user2
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 3})
+	assert.NoError(t, TouchComponents(db.DefaultContext, issue, []string{"services/auth/main.go", "README.md"}))
+
+	components, err := issues_model.GetIssueComponents(db.DefaultContext, issue.ID)
+	assert.NoError(t, err)
+	assert.Len(t, components, 2)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: issue.ID, LabelID: 1})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueWatch{IssueID: issue.ID, UserID: 2, IsWatching: true})
+	// the owner of the docs isn't notified
+	unittest.AssertNotExistsBean(t, &issues_model.IssueWatch{IssueID: issue.ID, UserID: 4})
+
+	// the labels of the components already touched aren't added again
+	label := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	assert.NoError(t, issues_model.DeleteIssueLabel(db.DefaultContext, issue, label, doer))
+	assert.NoError(t, TouchComponents(db.DefaultContext, issue, []string{"services/auth/main.go"}))
+	unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: issue.ID, LabelID: 1})
+}
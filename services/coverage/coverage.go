@@ -0,0 +1,315 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package coverage
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	coverage_model "code.gitea.io/gitea/models/coverage"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/coverage"
+	"code.gitea.io/gitea/modules/git"
+)
+
+// UploadReport stores a coverage report for a commit, replacing a previous report with the same flag
+func UploadReport(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, commitSHA, flag, format string, report *coverage.Report) (*coverage_model.Report, error) {
+	r := &coverage_model.Report{
+		RepoID:     repo.ID,
+		CommitSHA:  commitSHA,
+		Flag:       flag,
+		Format:     format,
+		UploaderID: doer.ID,
+	}
+	files := make([]*coverage_model.File, 0, len(report.Files))
+	for _, f := range report.Files {
+		file := &coverage_model.File{
+			Path:           f.Path,
+			CoveredLines:   f.CoveredLines(),
+			UncoveredLines: f.UncoveredLines(),
+		}
+		r.LinesCovered += int64(len(file.CoveredLines))
+		r.LinesValid += int64(len(file.CoveredLines) + len(file.UncoveredLines))
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	return r, coverage_model.InsertReport(ctx, r, files)
+}
+
+// FileCoverage represents the merged line coverage of a file over all reports of a commit
+type FileCoverage struct {
+	Path  string
+	Lines map[int]bool // instrumented line -> covered
+}
+
+// LinesCovered returns the number of covered lines
+func (f *FileCoverage) LinesCovered() (n int64) {
+	for _, covered := range f.Lines {
+		if covered {
+			n++
+		}
+	}
+	return n
+}
+
+// LinesValid returns the number of instrumented lines
+func (f *FileCoverage) LinesValid() int64 {
+	return int64(len(f.Lines))
+}
+
+// Percentage returns the percentage of covered lines
+func (f *FileCoverage) Percentage() float64 {
+	return percentage(f.LinesCovered(), f.LinesValid())
+}
+
+// CommitCoverage represents the merged line coverage of all reports of a commit
+type CommitCoverage struct {
+	CommitSHA string
+	Files     map[string]*FileCoverage
+}
+
+// File returns the coverage of the file at the repository path. Reports generated with absolute
+// paths are matched by their suffix.
+func (c *CommitCoverage) File(path string) *FileCoverage {
+	if f, ok := c.Files[path]; ok {
+		return f
+	}
+	for p, f := range c.Files {
+		if strings.HasSuffix(p, "/"+path) {
+			return f
+		}
+	}
+	return nil
+}
+
+// LinesCovered returns the number of covered lines of all files
+func (c *CommitCoverage) LinesCovered() (n int64) {
+	for _, f := range c.Files {
+		n += f.LinesCovered()
+	}
+	return n
+}
+
+// LinesValid returns the number of instrumented lines of all files
+func (c *CommitCoverage) LinesValid() (n int64) {
+	for _, f := range c.Files {
+		n += f.LinesValid()
+	}
+	return n
+}
+
+// Percentage returns the percentage of covered lines of all files
+func (c *CommitCoverage) Percentage() float64 {
+	return percentage(c.LinesCovered(), c.LinesValid())
+}
+
+// GetCommitCoverage returns the merged coverage of all reports of a commit, or nil if none was uploaded
+func GetCommitCoverage(ctx context.Context, repoID int64, commitSHA string) (*CommitCoverage, error) {
+	files, err := coverage_model.GetCommitFiles(ctx, repoID, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		reports, err := coverage_model.GetCommitReports(ctx, repoID, commitSHA)
+		if err != nil || len(reports) == 0 {
+			return nil, err
+		}
+	}
+
+	c := &CommitCoverage{CommitSHA: commitSHA, Files: make(map[string]*FileCoverage, len(files))}
+	for _, file := range files {
+		f, ok := c.Files[file.Path]
+		if !ok {
+			f = &FileCoverage{Path: file.Path, Lines: make(map[int]bool)}
+			c.Files[file.Path] = f
+		}
+		for _, line := range file.UncoveredLines {
+			if _, ok := f.Lines[line]; !ok {
+				f.Lines[line] = false
+			}
+		}
+		for _, line := range file.CoveredLines {
+			f.Lines[line] = true
+		}
+	}
+	return c, nil
+}
+
+// DiffFileCoverage represents the coverage of the lines a pull request adds to a file
+type DiffFileCoverage struct {
+	Path         string
+	LinesCovered int64
+	LinesValid   int64
+	File         *FileCoverage
+}
+
+// Percentage returns the percentage of covered added lines
+func (f *DiffFileCoverage) Percentage() float64 {
+	return percentage(f.LinesCovered, f.LinesValid)
+}
+
+// FilePercentage returns the percentage of covered lines of the whole file
+func (f *DiffFileCoverage) FilePercentage() float64 {
+	if f.File == nil {
+		return 0
+	}
+	return f.File.Percentage()
+}
+
+// DiffCoverage represents the coverage of the lines added by a pull request
+type DiffCoverage struct {
+	HeadSHA      string
+	BaseSHA      string
+	LinesCovered int64
+	LinesValid   int64
+	Files        []*DiffFileCoverage
+}
+
+// Percentage returns the percentage of covered added lines, a diff without instrumented lines is fully covered
+func (c *DiffCoverage) Percentage() float64 {
+	return percentage(c.LinesCovered, c.LinesValid)
+}
+
+// FilesByPath returns the coverage of the files keyed by their path
+func (c *DiffCoverage) FilesByPath() map[string]*DiffFileCoverage {
+	files := make(map[string]*DiffFileCoverage, len(c.Files))
+	for _, f := range c.Files {
+		files[f.Path] = f
+	}
+	return files
+}
+
+func percentage(covered, valid int64) float64 {
+	if valid == 0 {
+		return 100
+	}
+	return float64(covered) * 100 / float64(valid)
+}
+
+// GetPullRequestDiffCoverage returns the coverage of the lines added by the pull request,
+// or nil if no coverage was uploaded for its head commit
+func GetPullRequestDiffCoverage(ctx context.Context, pr *issues_model.PullRequest) (*DiffCoverage, error) {
+	if err := pr.LoadBaseRepoCtx(ctx); err != nil {
+		return nil, err
+	}
+
+	gitRepo, closer, err := git.RepositoryFromContextOrOpen(ctx, pr.BaseRepo.RepoPath())
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	headSHA, err := gitRepo.GetRefCommitID(pr.GetGitRefName())
+	if err != nil {
+		return nil, fmt.Errorf("GetRefCommitID: %w", err)
+	}
+
+	commitCoverage, err := GetCommitCoverage(ctx, pr.BaseRepoID, headSHA)
+	if err != nil || commitCoverage == nil {
+		return nil, err
+	}
+
+	stdout, _, err := git.NewCommand(ctx, "diff", "-U0", "--no-color", "--no-ext-diff", pr.MergeBase, headSHA).
+		RunStdString(&git.RunOpts{Dir: pr.BaseRepo.RepoPath()})
+	if err != nil {
+		return nil, fmt.Errorf("git diff: %w", err)
+	}
+	added, err := parseAddedLines(strings.NewReader(stdout))
+	if err != nil {
+		return nil, err
+	}
+
+	diffCoverage := &DiffCoverage{HeadSHA: headSHA, BaseSHA: pr.MergeBase}
+	for _, path := range sortedKeys(added) {
+		f := &DiffFileCoverage{Path: path, File: commitCoverage.File(path)}
+		if f.File != nil {
+			for _, line := range added[path] {
+				if covered, ok := f.File.Lines[line]; ok {
+					f.LinesValid++
+					if covered {
+						f.LinesCovered++
+					}
+				}
+			}
+		}
+		diffCoverage.LinesCovered += f.LinesCovered
+		diffCoverage.LinesValid += f.LinesValid
+		diffCoverage.Files = append(diffCoverage.Files, f)
+	}
+	return diffCoverage, nil
+}
+
+// MergeBlockedByDiffCoverage returns true if the branch protection requires a minimum diff coverage
+// which the pull request does not reach or for which no coverage has been uploaded yet
+func MergeBlockedByDiffCoverage(ctx context.Context, pr *issues_model.PullRequest) (bool, *DiffCoverage, error) {
+	if pr.ProtectedBranch == nil || pr.ProtectedBranch.MinimumDiffCoverage <= 0 {
+		return false, nil, nil
+	}
+	diffCoverage, err := GetPullRequestDiffCoverage(ctx, pr)
+	if err != nil {
+		return false, nil, err
+	}
+	if diffCoverage == nil {
+		return true, nil, nil
+	}
+	return diffCoverage.Percentage() < float64(pr.ProtectedBranch.MinimumDiffCoverage), diffCoverage, nil
+}
+
+// parseAddedLines returns the line numbers added to every file of a diff generated with -U0
+func parseAddedLines(r io.Reader) (map[string][]int, error) {
+	added := make(map[string][]int)
+	var file string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			file = ""
+			if name := line[4:]; strings.HasPrefix(name, "b/") {
+				file = name[2:]
+			}
+		case strings.HasPrefix(line, "@@ ") && file != "":
+			// @@ -l,s +l,s @@
+			fields := strings.Fields(line)
+			if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+				continue
+			}
+			start, count := fields[2][1:], "1"
+			if i := strings.IndexByte(start, ','); i >= 0 {
+				start, count = start[:i], start[i+1:]
+			}
+			s, err := strconv.Atoi(start)
+			if err != nil {
+				return nil, fmt.Errorf("invalid hunk header %q", line)
+			}
+			c, err := strconv.Atoi(count)
+			if err != nil {
+				return nil, fmt.Errorf("invalid hunk header %q", line)
+			}
+			for i := 0; i < c; i++ {
+				added[file] = append(added[file], s+i)
+			}
+		}
+	}
+	return added, scanner.Err()
+}
+
+func sortedKeys(m map[string][]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package coverage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAddedLines(t *testing.T) {
+	added, err := parseAddedLines(strings.NewReader(`diff --git a/main.go b/main.go
+index 1234567..89abcde 100644
+--- a/main.go
++++ b/main.go
+@@ -3 +3,2 @@ package main
+-	old()
++	newA()
++	newB()
+@@ -10,2 +11,0 @@ func main() {
+-	removed()
+-	removed()
+@@ -20,0 +21 @@ func other() {
++	added()
+diff --git a/deleted.go b/deleted.go
+deleted file mode 100644
+--- a/deleted.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package deleted
+-
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]int{"main.go": {3, 4, 21}}, added)
+}
+
+func TestDiffCoveragePercentage(t *testing.T) {
+	assert.EqualValues(t, 100, (&DiffCoverage{}).Percentage())
+	assert.EqualValues(t, 50, (&DiffCoverage{LinesCovered: 1, LinesValid: 2}).Percentage())
+
+	c := &CommitCoverage{Files: map[string]*FileCoverage{
+		"/build/src/main.go": {Path: "/build/src/main.go", Lines: map[int]bool{3: true, 4: false}},
+	}}
+	f := c.File("main.go")
+	if assert.NotNil(t, f) {
+		assert.EqualValues(t, 1, f.LinesCovered())
+		assert.EqualValues(t, 2, f.LinesValid())
+	}
+	assert.Nil(t, c.File("other.go"))
+}
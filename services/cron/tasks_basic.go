@@ -13,12 +13,18 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/models/webhook"
 	"code.gitea.io/gitea/modules/setting"
+	attachment_service "code.gitea.io/gitea/services/attachment"
 	"code.gitea.io/gitea/services/auth"
+	issue_service "code.gitea.io/gitea/services/issue"
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	packages_service "code.gitea.io/gitea/services/packages"
+	pull_service "code.gitea.io/gitea/services/pull"
+	replication_service "code.gitea.io/gitea/services/replication"
 	repo_service "code.gitea.io/gitea/services/repository"
 	archiver_service "code.gitea.io/gitea/services/repository/archiver"
+	user_service "code.gitea.io/gitea/services/user"
+	websub_service "code.gitea.io/gitea/services/websub"
 )
 
 func registerUpdateMirrorTask() {
@@ -155,6 +161,110 @@ func registerCleanupPackages() {
 	})
 }
 
+func registerCleanupAttachments() {
+	RegisterTaskFatal("cleanup_attachments", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@midnight",
+		},
+		OlderThan: 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		realConfig := config.(*OlderThanConfig)
+		return attachment_service.Cleanup(ctx, realConfig.OlderThan)
+	})
+}
+
+func registerProcessReviewPolicies() {
+	RegisterTaskFatal("process_review_policies", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 6h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return pull_service.ProcessReviewPolicies(ctx)
+	})
+}
+
+func registerProcessStalePolicies() {
+	RegisterTaskFatal("process_stale_issues", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return issue_service.ProcessStalePolicies(ctx)
+	})
+}
+
+func registerCreateScheduledIssues() {
+	RegisterTaskFatal("create_scheduled_issues", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 5m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return issue_service.CreateScheduledIssues(ctx)
+	})
+}
+
+func registerDeleteScheduledUsers() {
+	RegisterTaskFatal("delete_scheduled_accounts", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return user_service.DeleteScheduledUsers(ctx)
+	})
+}
+
+func registerSyncForks() {
+	RegisterTaskFatal("sync_forks", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return repo_service.SyncScheduledForks(ctx)
+	})
+}
+
+func registerSyncIssueMirrors() {
+	RegisterTaskFatal("sync_issue_mirrors", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return migrations.SyncIssueMirrors(ctx)
+	})
+}
+
+func registerPublishWebSubFeeds() {
+	RegisterTaskFatal("publish_websub_feeds", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return websub_service.PublishNewActions(ctx)
+	})
+}
+
+func registerReplicateRepositories() {
+	RegisterTaskFatal("replicate_repositories", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return replication_service.ReplicateAllRepositories(ctx)
+	})
+}
+
+func registerCheckReplicationLag() {
+	RegisterTaskFatal("check_replication_lag", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 10m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return replication_service.CheckReplicationLag(ctx)
+	})
+}
+
 func initBasicTasks() {
 	if setting.Mirror.Enabled {
 		registerUpdateMirrorTask()
@@ -166,9 +276,23 @@ func initBasicTasks() {
 	registerDeletedBranchesCleanup()
 	if !setting.Repository.DisableMigrations {
 		registerUpdateMigrationPosterID()
+		registerSyncIssueMirrors()
 	}
 	registerCleanupHookTaskTable()
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
 	}
+	registerCleanupAttachments()
+	registerProcessReviewPolicies()
+	registerProcessStalePolicies()
+	registerCreateScheduledIssues()
+	registerDeleteScheduledUsers()
+	registerSyncForks()
+	if setting.WebSub.Enabled {
+		registerPublishWebSubFeeds()
+	}
+	if setting.Replication.Enabled {
+		registerReplicateRepositories()
+		registerCheckReplicationLag()
+	}
 }
@@ -6,15 +6,22 @@ package cron
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	activities_model "code.gitea.io/gitea/models/activities"
 	"code.gitea.io/gitea/models/admin"
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
 	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/updatechecker"
+	badge_service "code.gitea.io/gitea/services/badge"
+	dependency_service "code.gitea.io/gitea/services/dependency"
+	org_service "code.gitea.io/gitea/services/org"
 	repo_service "code.gitea.io/gitea/services/repository"
 	archiver_service "code.gitea.io/gitea/services/repository/archiver"
 	user_service "code.gitea.io/gitea/services/user"
@@ -138,6 +145,20 @@ func registerDeleteOldActions() {
 	})
 }
 
+func registerDeleteOldContentHistories() {
+	RegisterTaskFatal("delete_old_content_histories", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@every 168h",
+		},
+		OlderThan: 365 * 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		olderThanConfig := config.(*OlderThanConfig)
+		return issues_model.DeleteOldIssueContentHistories(ctx, olderThanConfig.OlderThan)
+	})
+}
+
 func registerUpdateGiteaChecker() {
 	type UpdateCheckerConfig struct {
 		BaseConfig
@@ -170,6 +191,121 @@ func registerDeleteOldSystemNotices() {
 	})
 }
 
+func registerUpdateDependencies() {
+	type UpdateDependenciesConfig struct {
+		BaseConfig
+		Username     string
+		NpmRegistry  string
+		PyPIRegistry string `ini:"PYPI_REGISTRY"`
+	}
+	RegisterTaskFatal("update_dependencies", &UpdateDependenciesConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@midnight",
+		},
+		NpmRegistry:  "https://registry.npmjs.org",
+		PyPIRegistry: "https://pypi.org",
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		updateConfig := config.(*UpdateDependenciesConfig)
+		if updateConfig.Username == "" {
+			return fmt.Errorf("no user configured to propose dependency updates")
+		}
+		doer, err := user_model.GetUserByName(ctx, updateConfig.Username)
+		if err != nil {
+			return err
+		}
+		return dependency_service.UpdateDependencies(ctx, &dependency_service.Options{
+			Doer:         doer,
+			NpmRegistry:  updateConfig.NpmRegistry,
+			PyPIRegistry: updateConfig.PyPIRegistry,
+		})
+	})
+}
+
+func registerAwardAchievements() {
+	RegisterTaskFatal("award_achievements", &BaseConfig{
+		Enabled:    false,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return badge_service.AwardAchievements(ctx)
+	})
+}
+
+func registerUpdateOrgInsights() {
+	type UpdateOrgInsightsConfig struct {
+		BaseConfig
+		BackfillDays int64
+	}
+	RegisterTaskFatal("update_org_insights", &UpdateOrgInsightsConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@midnight",
+		},
+		BackfillDays: 90,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		return org_service.UpdateAllOrgInsights(ctx, config.(*UpdateOrgInsightsConfig).BackfillDays)
+	})
+}
+
+func registerUpdateLanguageTrends() {
+	RegisterTaskFatal("update_language_trends", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return repo_model.UpdateLanguageTrends(ctx, repo_model.LanguageStatsDayStart(timeutil.TimeStampNow()))
+	})
+}
+
+func registerUpdateMaintenanceReports() {
+	type UpdateMaintenanceReportsConfig struct {
+		BaseConfig
+		StaleMonths int
+	}
+	RegisterTaskFatal("update_maintenance_reports", &UpdateMaintenanceReportsConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@midnight",
+		},
+		StaleMonths: 6,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		return repo_service.UpdateMaintenanceReports(ctx, config.(*UpdateMaintenanceReportsConfig).StaleMonths)
+	})
+}
+
+func registerUpdateTrendingRepos() {
+	type UpdateTrendingReposConfig struct {
+		BaseConfig
+		WindowDays     int64
+		StarWeight     float64
+		ForkWeight     float64
+		ActivityWeight float64
+	}
+	RegisterTaskFatal("update_trending_repos", &UpdateTrendingReposConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@every 1h",
+		},
+		WindowDays:     7,
+		StarWeight:     3,
+		ForkWeight:     2,
+		ActivityWeight: 1,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		trendingConfig := config.(*UpdateTrendingReposConfig)
+		return repo_model.UpdateTrendingRepos(ctx, &repo_model.TrendingOptions{
+			WindowDays:     trendingConfig.WindowDays,
+			StarWeight:     trendingConfig.StarWeight,
+			ForkWeight:     trendingConfig.ForkWeight,
+			ActivityWeight: trendingConfig.ActivityWeight,
+		})
+	})
+}
+
 func initExtendedTasks() {
 	registerDeleteInactiveUsers()
 	registerDeleteRepositoryArchives()
@@ -181,6 +317,13 @@ func initExtendedTasks() {
 	registerDeleteMissingRepositories()
 	registerRemoveRandomAvatars()
 	registerDeleteOldActions()
+	registerDeleteOldContentHistories()
 	registerUpdateGiteaChecker()
 	registerDeleteOldSystemNotices()
+	registerUpdateDependencies()
+	registerAwardAchievements()
+	registerUpdateOrgInsights()
+	registerUpdateLanguageTrends()
+	registerUpdateMaintenanceReports()
+	registerUpdateTrendingRepos()
 }
@@ -0,0 +1,444 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dependency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/dependency"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/osv"
+	"code.gitea.io/gitea/modules/setting"
+	advisory_service "code.gitea.io/gitea/services/advisory"
+	"code.gitea.io/gitea/services/automerge"
+	issue_service "code.gitea.io/gitea/services/issue"
+	pull_service "code.gitea.io/gitea/services/pull"
+	files_service "code.gitea.io/gitea/services/repository/files"
+
+	"xorm.io/builder"
+)
+
+// BranchPrefix is the prefix of the branches of the update pull requests
+const BranchPrefix = "dependency-updates/"
+
+// Options configures how dependency updates are proposed
+type Options struct {
+	// Doer is the user committing the updates and opening the pull requests
+	Doer *user_model.User
+	// NpmRegistry and PyPIRegistry are the upstream registries, packages of the repository owner's
+	// package registry are always looked up first
+	NpmRegistry  string
+	PyPIRegistry string
+}
+
+// UpdateDependencies proposes dependency updates for all repositories containing a dependency update configuration
+func UpdateDependencies(ctx context.Context, opts *Options) error {
+	return db.Iterate(
+		ctx,
+		new(repo_model.Repository),
+		builder.Eq{"is_empty": false, "is_archived": false, "is_mirror": false},
+		func(idx int, bean interface{}) error {
+			repo := bean.(*repo_model.Repository)
+			select {
+			case <-ctx.Done():
+				return db.ErrCancelledf("before dependency updates of %s", repo.FullName())
+			default:
+			}
+			if err := UpdateRepoDependencies(ctx, repo, opts); err != nil {
+				log.Error("UpdateRepoDependencies[%s]: %v", repo.FullName(), err)
+			}
+			return nil
+		},
+	)
+}
+
+// readConfig returns the dependency update configuration of the commit or nil if there is none
+func readConfig(commit *git.Commit) (*dependency.Config, error) {
+	for _, configPath := range dependency.ConfigPaths {
+		content, err := commit.GetFileContent(configPath, 0)
+		if err != nil {
+			if git.IsErrNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		cfg, err := dependency.ParseConfig([]byte(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", configPath, err)
+		}
+		return cfg, nil
+	}
+	return nil, nil
+}
+
+// update represents the update of a dependency to a newer version
+type update struct {
+	Dependency   *dependency.Dependency
+	Version      string
+	Releases     []string
+	ChangelogURL string
+	// Fixes are the advisories of the instance affecting the current version but not the new one
+	Fixes []*osv.Vulnerability
+}
+
+// updateGroup bundles the updates proposed in one pull request
+type updateGroup struct {
+	Name    string
+	Grouped bool
+	Updates []*update
+}
+
+var branchNameReplacer = strings.NewReplacer("@", "", "/", "-", " ", "-", ":", "-", "~", "-", "^", "-", "..", "-")
+
+func (g *updateGroup) branch() string {
+	return BranchPrefix + strings.Trim(branchNameReplacer.Replace(g.Name), "-.")
+}
+
+func (g *updateGroup) title() string {
+	if !g.Grouped && len(g.Updates) == 1 {
+		return fmt.Sprintf("Update %s to %s", g.Updates[0].Dependency.Name, g.Updates[0].Version)
+	}
+	return fmt.Sprintf("Update %s dependencies", g.Name)
+}
+
+func (g *updateGroup) body() string {
+	var sb strings.Builder
+	sb.WriteString("This pull request updates the following dependencies:\n\n")
+	sb.WriteString("| Package | Manifest | Change | Changelog |\n")
+	sb.WriteString("|---|---|---|---|\n")
+	for _, u := range g.Updates {
+		changelog := "-"
+		if u.ChangelogURL != "" {
+			changelog = fmt.Sprintf("[%s](%s)", u.Dependency.Name, u.ChangelogURL)
+		}
+		fmt.Fprintf(&sb, "| `%s` | `%s` | `%s` → `%s` | %s |\n", u.Dependency.Name, u.Dependency.Manifest, u.Dependency.Constraint, u.Dependency.WithVersion(u.Version), changelog)
+	}
+	for _, u := range g.Updates {
+		if len(u.Releases) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n<details>\n<summary>Releases of %s</summary>\n\n", u.Dependency.Name)
+		for _, release := range u.Releases {
+			fmt.Fprintf(&sb, "- %s\n", release)
+		}
+		sb.WriteString("\n</details>\n")
+	}
+	fixes := false
+	for _, u := range g.Updates {
+		for _, vuln := range u.Fixes {
+			if !fixes {
+				sb.WriteString("\nThis pull request fixes the following vulnerabilities:\n\n")
+				fixes = true
+			}
+			link := vuln.ID
+			if len(vuln.References) > 0 {
+				link = fmt.Sprintf("[%s](%s)", vuln.ID, vuln.References[0].URL)
+			}
+			fmt.Fprintf(&sb, "- %s: %s (`%s`)\n", link, vuln.Summary, u.Dependency.Name)
+		}
+	}
+	sb.WriteString("\nClose this pull request to ignore these updates, delete its branch afterwards to receive them again.\n")
+	return sb.String()
+}
+
+// UpdateRepoDependencies proposes the updates of the outdated dependencies of a repository as pull requests
+func UpdateRepoDependencies(ctx context.Context, repo *repo_model.Repository, opts *Options) error {
+	gitRepo, closer, err := git.RepositoryFromContextOrOpen(ctx, repo.RepoPath())
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+
+	commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	cfg, err := readConfig(commit)
+	if err != nil || cfg == nil || !cfg.IsScheduled(time.Now()) {
+		return err
+	}
+
+	if err := repo.GetOwner(ctx); err != nil {
+		return err
+	}
+	groups, err := findUpdates(ctx, commit, cfg, newRegistries(repo.Owner, opts))
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		if err := proposeUpdates(ctx, repo, gitRepo, commit, cfg, opts.Doer, group); err != nil {
+			log.Error("proposeUpdates[%s, %s]: %v", repo.FullName(), group.Name, err)
+		}
+	}
+	return nil
+}
+
+// findUpdates looks up the latest versions of the dependencies of the manifests and groups the outdated ones
+func findUpdates(ctx context.Context, commit *git.Commit, cfg *dependency.Config, registries *registries) ([]*updateGroup, error) {
+	manifests := cfg.Manifests
+	if len(manifests) == 0 {
+		manifests = dependency.DefaultManifests()
+	}
+
+	groups := make(map[string]*updateGroup)
+	for _, manifest := range manifests {
+		content, err := commit.GetFileContent(manifest, 0)
+		if err != nil {
+			if git.IsErrNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		deps, err := dependency.ParseManifest(manifest, []byte(content))
+		if err != nil {
+			log.Warn("ParseManifest[%s]: %v", manifest, err)
+			continue
+		}
+		for _, dep := range deps {
+			if cfg.IsIgnored(dep.Name) {
+				continue
+			}
+			info, err := registries.Lookup(ctx, dep)
+			if err != nil {
+				if !errors.Is(err, errPackageNotFound) {
+					log.Warn("Lookup[%s]: %v", dep.Name, err)
+				}
+				continue
+			}
+			latest := dependency.LatestVersion(info.Versions)
+			if !dep.IsOutdatedBy(latest) {
+				continue
+			}
+
+			name, grouped := cfg.GroupName(dep.Name), true
+			if name == "" {
+				name, grouped = dep.Name, false
+			}
+			group, ok := groups[name]
+			if !ok {
+				group = &updateGroup{Name: name, Grouped: grouped}
+				groups[name] = group
+			}
+			group.Updates = append(group.Updates, &update{
+				Dependency:   dep,
+				Version:      latest,
+				Releases:     dependency.VersionsBetween(info.Versions, dep.Version, latest),
+				ChangelogURL: info.ChangelogURL,
+				Fixes:        findFixes(ctx, dep, latest),
+			})
+		}
+	}
+
+	result := make([]*updateGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// findFixes returns the published advisories of the instance which affect the current version of the dependency
+// but not the new version
+func findFixes(ctx context.Context, dep *dependency.Dependency, newVersion string) []*osv.Vulnerability {
+	if !setting.Advisories.OSVEnabled || dep.Version == "" {
+		return nil
+	}
+	vulns, err := advisory_service.QueryVulnerabilities(ctx, string(dep.Ecosystem), dep.Name, dep.Version)
+	if err != nil {
+		log.Warn("QueryVulnerabilities[%s]: %v", dep.Name, err)
+		return nil
+	}
+	ecosystem := osv.NormalizeEcosystem(string(dep.Ecosystem))
+	fixes := make([]*osv.Vulnerability, 0, len(vulns))
+	for _, vuln := range vulns {
+		if !vuln.Affects(ecosystem, dep.Name, newVersion) {
+			fixes = append(fixes, vuln)
+		}
+	}
+	return fixes
+}
+
+// commitUpdates applies the updates to the manifests of the commit and commits the changed manifests to the new branch,
+// updates which have already been applied are skipped
+func commitUpdates(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, commit *git.Commit, oldBranch, newBranch string, group *updateGroup) (bool, error) {
+	byManifest := make(map[string][]*update)
+	manifests := make([]string, 0, 2)
+	for _, u := range group.Updates {
+		if _, ok := byManifest[u.Dependency.Manifest]; !ok {
+			manifests = append(manifests, u.Dependency.Manifest)
+		}
+		byManifest[u.Dependency.Manifest] = append(byManifest[u.Dependency.Manifest], u)
+	}
+
+	changed := false
+	for _, manifest := range manifests {
+		content, err := commit.GetFileContent(manifest, 0)
+		if err != nil {
+			return changed, err
+		}
+		deps, err := dependency.ParseManifest(manifest, []byte(content))
+		if err != nil {
+			return changed, err
+		}
+		byName := make(map[string]*dependency.Dependency, len(deps))
+		for _, dep := range deps {
+			byName[dep.Name] = dep
+		}
+
+		changes := make(map[*dependency.Dependency]string)
+		names := make([]string, 0, len(byManifest[manifest]))
+		for _, u := range byManifest[manifest] {
+			if dep, ok := byName[u.Dependency.Name]; ok && dep.IsOutdatedBy(u.Version) {
+				changes[dep] = u.Version
+				names = append(names, fmt.Sprintf("%s to %s", dep.Name, u.Version))
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		updated, err := dependency.UpdateManifest([]byte(content), changes)
+		if err != nil {
+			return changed, err
+		}
+
+		if _, err := files_service.CreateOrUpdateRepoFile(ctx, repo, doer, &files_service.UpdateRepoFileOptions{
+			OldBranch: oldBranch,
+			NewBranch: newBranch,
+			TreePath:  manifest,
+			Message:   fmt.Sprintf("Update %s in %s", strings.Join(names, ", "), manifest),
+			Content:   string(updated),
+		}); err != nil {
+			return changed, err
+		}
+		changed = true
+		oldBranch = newBranch
+	}
+	return changed, nil
+}
+
+// proposeUpdates opens a pull request for the update group or refreshes the open one. Groups whose
+// pull request has been closed are skipped as long as the branch exists.
+func proposeUpdates(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, commit *git.Commit, cfg *dependency.Config, doer *user_model.User, group *updateGroup) error {
+	branch := group.branch()
+	if gitRepo.IsBranchExist(branch) {
+		pr, err := issues_model.GetUnmergedPullRequest(repo.ID, repo.ID, branch, repo.DefaultBranch, issues_model.PullRequestFlowGithub)
+		if err != nil {
+			if issues_model.IsErrPullRequestNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		branchCommit, err := gitRepo.GetBranchCommit(branch)
+		if err != nil {
+			return err
+		}
+		changed, err := commitUpdates(ctx, repo, doer, branchCommit, branch, branch, group)
+		if err != nil {
+			return err
+		}
+		if changed {
+			if err := pr.LoadIssueCtx(ctx); err != nil {
+				return err
+			}
+			if err := issue_service.ChangeTitle(pr.Issue, doer, group.title()); err != nil {
+				return err
+			}
+			if err := issue_service.ChangeContent(pr.Issue, doer, group.body()); err != nil {
+				return err
+			}
+		}
+		return scheduleAutoMerge(ctx, repo, cfg, doer, pr)
+	}
+
+	if _, err := commitUpdates(ctx, repo, doer, commit, repo.DefaultBranch, branch, group); err != nil {
+		return err
+	}
+
+	var labelIDs []int64
+	if len(cfg.Labels) > 0 {
+		var err error
+		if labelIDs, err = issues_model.GetLabelIDsInRepoByNames(repo.ID, cfg.Labels); err != nil {
+			return err
+		}
+	}
+
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Title:    group.title(),
+		PosterID: doer.ID,
+		Poster:   doer,
+		IsPull:   true,
+		Content:  group.body(),
+	}
+	pr := &issues_model.PullRequest{
+		HeadRepoID: repo.ID,
+		BaseRepoID: repo.ID,
+		HeadBranch: branch,
+		BaseBranch: repo.DefaultBranch,
+		HeadRepo:   repo,
+		BaseRepo:   repo,
+		MergeBase:  commit.ID.String(),
+		Type:       issues_model.PullRequestGitea,
+	}
+	if err := pull_service.NewPullRequest(ctx, repo, issue, labelIDs, nil, pr, nil); err != nil {
+		return err
+	}
+	return scheduleAutoMerge(ctx, repo, cfg, doer, pr)
+}
+
+// scheduleAutoMerge schedules the pull request to be merged once its status checks succeeded.
+// Pull requests whose checks already succeeded are merged right away if they are mergeable.
+func scheduleAutoMerge(ctx context.Context, repo *repo_model.Repository, cfg *dependency.Config, doer *user_model.User, pr *issues_model.PullRequest) error {
+	if !cfg.Automerge {
+		return nil
+	}
+	if scheduled, _, err := pull_model.GetScheduledMergeByPullID(ctx, pr.ID); err != nil || scheduled {
+		return err
+	}
+
+	style := repo_model.MergeStyle(cfg.MergeStyle)
+	if style == "" {
+		style = repo_model.MergeStyleMerge
+	}
+	scheduled, err := automerge.ScheduleAutoMerge(ctx, doer, pr, style, "")
+	if err != nil || scheduled {
+		return err
+	}
+
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err != nil {
+		return err
+	}
+	if err := pull_service.CheckPullMergable(ctx, doer, &perm, pr, false, false); err != nil {
+		// e.g. the mergeability check of a new pull request is still running, retried with the next run
+		log.Debug("dependency update pull request %d is not mergeable yet: %v", pr.ID, err)
+		return nil
+	}
+	gitRepo, closer, err := git.RepositoryFromContextOrOpen(ctx, repo.RepoPath())
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+	message, err := pull_service.GetDefaultMergeMessage(gitRepo, pr, style)
+	if err != nil {
+		return err
+	}
+	return pull_service.Merge(ctx, pr, doer, gitRepo, style, "", message)
+}
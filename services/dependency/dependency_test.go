@@ -0,0 +1,65 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dependency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.gitea.io/gitea/modules/dependency"
+	"code.gitea.io/gitea/modules/osv"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpstreamRegistries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/@vue%2Fcompiler-sfc":
+			_, _ = w.Write([]byte(`{"versions": {"3.2.37": {}, "3.2.38": {}}, "repository": {"url": "git+https://github.com/vuejs/core.git"}}`))
+		case "/pypi/requests/json":
+			_, _ = w.Write([]byte(`{"info": {"home_page": "https://requests.readthedocs.io", "project_urls": {"Changelog": "https://example.com/HISTORY.md"}}, "releases": {"2.28.0": [], "2.28.1": []}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	info, err := (&npmRegistry{url: server.URL}).Lookup(context.Background(), "@vue/compiler-sfc")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"3.2.37", "3.2.38"}, info.Versions)
+	assert.Equal(t, "https://github.com/vuejs/core/releases", info.ChangelogURL)
+
+	info, err = (&pypiRegistry{url: server.URL + "/"}).Lookup(context.Background(), "requests")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"2.28.0", "2.28.1"}, info.Versions)
+	assert.Equal(t, "https://example.com/HISTORY.md", info.ChangelogURL)
+
+	_, err = (&npmRegistry{url: server.URL}).Lookup(context.Background(), "unknown")
+	assert.ErrorIs(t, err, errPackageNotFound)
+}
+
+func TestUpdateGroup(t *testing.T) {
+	dep := &dependency.Dependency{Manifest: "package.json", Ecosystem: dependency.EcosystemNpm, Name: "@vue/compiler-sfc", Constraint: "^3.2.37", Version: "3.2.37"}
+	group := &updateGroup{Name: dep.Name, Updates: []*update{{Dependency: dep, Version: "3.2.38", Releases: []string{"3.2.38"}}}}
+	assert.Equal(t, "dependency-updates/vue-compiler-sfc", group.branch())
+	assert.Equal(t, "Update @vue/compiler-sfc to 3.2.38", group.title())
+	assert.Contains(t, group.body(), "| `@vue/compiler-sfc` | `package.json` | `^3.2.37` → `^3.2.38` | - |")
+
+	assert.NotContains(t, group.body(), "vulnerabilities")
+
+	group.Updates[0].Fixes = []*osv.Vulnerability{{
+		ID:         "GITEA-1",
+		Summary:    "Prototype pollution",
+		References: []*osv.Reference{{Type: osv.ReferenceTypeAdvisory, URL: "https://try.gitea.io/user2/repo1/security/advisories/1"}},
+	}}
+	assert.Contains(t, group.body(), "- [GITEA-1](https://try.gitea.io/user2/repo1/security/advisories/1): Prototype pollution (`@vue/compiler-sfc`)")
+
+	group = &updateGroup{Name: "frontend", Grouped: true, Updates: group.Updates}
+	assert.Equal(t, "dependency-updates/frontend", group.branch())
+	assert.Equal(t, "Update frontend dependencies", group.title())
+}
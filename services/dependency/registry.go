@@ -0,0 +1,204 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package dependency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/dependency"
+	"code.gitea.io/gitea/modules/proxy"
+)
+
+var packageTypes = map[dependency.Ecosystem]packages_model.Type{
+	dependency.EcosystemNpm:  packages_model.TypeNpm,
+	dependency.EcosystemPyPI: packages_model.TypePyPI,
+}
+
+// errPackageNotFound is returned by registries which do not know a package
+var errPackageNotFound = errors.New("package not found")
+
+// PackageInfo holds the published versions of a package
+type PackageInfo struct {
+	Versions []string
+	// ChangelogURL links to the changelog, the releases or the homepage of the package
+	ChangelogURL string
+}
+
+// Registry looks up the published versions of packages
+type Registry interface {
+	Lookup(ctx context.Context, name string) (*PackageInfo, error)
+}
+
+var httpClient = &http.Client{
+	Timeout: 30 * time.Second,
+	Transport: &http.Transport{
+		Proxy: proxy.Proxy(),
+	},
+}
+
+func getJSON(ctx context.Context, link string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return errPackageNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, link)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// npmRegistry looks up packages using the npm registry API
+type npmRegistry struct {
+	url string
+}
+
+func (r *npmRegistry) Lookup(ctx context.Context, name string) (*PackageInfo, error) {
+	var metadata struct {
+		Versions   map[string]json.RawMessage `json:"versions"`
+		Homepage   string                     `json:"homepage"`
+		Repository struct {
+			URL string `json:"url"`
+		} `json:"repository"`
+	}
+	// scoped packages keep their @ but escape the slash
+	if err := getJSON(ctx, strings.TrimSuffix(r.url, "/")+"/"+strings.Replace(url.PathEscape(name), "%40", "@", 1), &metadata); err != nil {
+		return nil, err
+	}
+	info := &PackageInfo{Versions: make([]string, 0, len(metadata.Versions))}
+	for v := range metadata.Versions {
+		info.Versions = append(info.Versions, v)
+	}
+	if link := repositoryWebLink(metadata.Repository.URL); link != "" {
+		info.ChangelogURL = link + "/releases"
+	} else {
+		info.ChangelogURL = metadata.Homepage
+	}
+	return info, nil
+}
+
+// repositoryWebLink converts the repository URL of a package manifest, e.g. git+https://host/owner/repo.git, to a web link
+func repositoryWebLink(repoURL string) string {
+	link := strings.TrimSuffix(strings.TrimPrefix(repoURL, "git+"), ".git")
+	if u, err := url.Parse(link); err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return ""
+	}
+	return link
+}
+
+// pypiRegistry looks up packages using the PyPI JSON API
+type pypiRegistry struct {
+	url string
+}
+
+func (r *pypiRegistry) Lookup(ctx context.Context, name string) (*PackageInfo, error) {
+	var metadata struct {
+		Info struct {
+			HomePage    string            `json:"home_page"`
+			ProjectURLs map[string]string `json:"project_urls"`
+		} `json:"info"`
+		Releases map[string]json.RawMessage `json:"releases"`
+	}
+	if err := getJSON(ctx, fmt.Sprintf("%s/pypi/%s/json", strings.TrimSuffix(r.url, "/"), url.PathEscape(name)), &metadata); err != nil {
+		return nil, err
+	}
+	info := &PackageInfo{Versions: make([]string, 0, len(metadata.Releases))}
+	for v := range metadata.Releases {
+		info.Versions = append(info.Versions, v)
+	}
+	info.ChangelogURL = metadata.Info.HomePage
+	for key, link := range metadata.Info.ProjectURLs {
+		if strings.EqualFold(key, "changelog") || strings.EqualFold(key, "changes") || strings.EqualFold(key, "release notes") {
+			info.ChangelogURL = link
+			break
+		}
+	}
+	return info, nil
+}
+
+// pypiNormalizer normalizes package names, https://peps.python.org/pep-0503/#normalized-names
+var pypiNormalizer = strings.NewReplacer(".", "-", "_", "-")
+
+// giteaRegistry looks up the packages published in the package registry of a Gitea user or organization
+type giteaRegistry struct {
+	owner       *user_model.User
+	packageType packages_model.Type
+}
+
+func (r *giteaRegistry) Lookup(ctx context.Context, name string) (*PackageInfo, error) {
+	if r.packageType == packages_model.TypePyPI {
+		name = pypiNormalizer.Replace(strings.ToLower(name))
+	}
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, r.owner.ID, r.packageType, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, errPackageNotFound
+	}
+	info := &PackageInfo{
+		Versions:     make([]string, 0, len(pvs)),
+		ChangelogURL: fmt.Sprintf("%s/-/packages/%s/%s", r.owner.HTMLURL(), string(r.packageType), url.PathEscape(strings.ToLower(name))),
+	}
+	for _, pv := range pvs {
+		info.Versions = append(info.Versions, pv.Version)
+	}
+	return info, nil
+}
+
+// registries resolves packages of a repository, packages published in the package registry of
+// the repository owner take precedence over the upstream registries
+type registries struct {
+	gitea    map[dependency.Ecosystem]Registry
+	upstream map[dependency.Ecosystem]Registry
+}
+
+func newRegistries(owner *user_model.User, opts *Options) *registries {
+	r := &registries{
+		gitea:    make(map[dependency.Ecosystem]Registry, len(packageTypes)),
+		upstream: make(map[dependency.Ecosystem]Registry, 2),
+	}
+	for ecosystem, packageType := range packageTypes {
+		r.gitea[ecosystem] = &giteaRegistry{owner: owner, packageType: packageType}
+	}
+	if opts.NpmRegistry != "" {
+		r.upstream[dependency.EcosystemNpm] = &npmRegistry{url: opts.NpmRegistry}
+	}
+	if opts.PyPIRegistry != "" {
+		r.upstream[dependency.EcosystemPyPI] = &pypiRegistry{url: opts.PyPIRegistry}
+	}
+	return r
+}
+
+func (r *registries) Lookup(ctx context.Context, dep *dependency.Dependency) (*PackageInfo, error) {
+	if registry, ok := r.gitea[dep.Ecosystem]; ok {
+		info, err := registry.Lookup(ctx, dep.Name)
+		if err == nil || !errors.Is(err, errPackageNotFound) {
+			return info, err
+		}
+	}
+	registry, ok := r.upstream[dep.Ecosystem]
+	if !ok {
+		return nil, errPackageNotFound
+	}
+	return registry.Lookup(ctx, dep.Name)
+}
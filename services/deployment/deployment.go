@@ -0,0 +1,78 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	deployment_model "code.gitea.io/gitea/models/deployment"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/notification"
+)
+
+// DefaultEnvironment is the environment of deployments created without one
+const DefaultEnvironment = "production"
+
+// ErrInvalidRef is returned when the ref of a new deployment can not be resolved to a commit
+type ErrInvalidRef struct {
+	Ref string
+}
+
+func (err ErrInvalidRef) Error() string {
+	return fmt.Sprintf("ref does not resolve to a commit: %s", err.Ref)
+}
+
+// IsErrInvalidRef checks if an error is an ErrInvalidRef
+func IsErrInvalidRef(err error) bool {
+	_, ok := err.(ErrInvalidRef)
+	return ok
+}
+
+// CreateDeployment creates a pending deployment of the commit the ref points to
+func CreateDeployment(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, doer *user_model.User, ref, environment, description string) (*deployment_model.Deployment, error) {
+	if strings.HasPrefix(ref, "-") {
+		return nil, ErrInvalidRef{Ref: ref}
+	}
+	commit, err := gitRepo.GetCommit(ref)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return nil, ErrInvalidRef{Ref: ref}
+		}
+		return nil, err
+	}
+	if environment == "" {
+		environment = DefaultEnvironment
+	}
+
+	d := &deployment_model.Deployment{
+		RepoID:      repo.ID,
+		Environment: environment,
+		Ref:         ref,
+		SHA:         commit.ID.String(),
+		Description: description,
+		CreatorID:   doer.ID,
+		Creator:     doer,
+	}
+	if err := deployment_model.CreateDeployment(ctx, d); err != nil {
+		return nil, err
+	}
+	notification.NotifyDeploymentCreate(doer, repo, d)
+	return d, nil
+}
+
+// CreateStatus adds a state transition to a deployment
+func CreateStatus(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, d *deployment_model.Deployment, status *deployment_model.Status) error {
+	status.CreatorID = doer.ID
+	status.Creator = doer
+	if err := deployment_model.CreateStatus(ctx, d, status); err != nil {
+		return err
+	}
+	notification.NotifyDeploymentStatus(doer, repo, d, status)
+	return nil
+}
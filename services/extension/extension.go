@@ -0,0 +1,189 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package extension sends the server-side events to the external processors configured by the site administrator.
+// The processors subscribed to a decision event can reject the action, the other events are only notified to them.
+package extension
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/notification"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// Event is a server-side event sent to the extension processors
+type Event string
+
+const (
+	// EventPreReceive is the decision on a ref update pushed to a repository
+	EventPreReceive Event = "pre_receive"
+	// EventUserRegister is the decision on the registration of a new account
+	EventUserRegister Event = "user_register"
+	// EventIssueOpened notifies that an issue or a pull request was opened
+	EventIssueOpened Event = "issue_opened"
+	// EventIssueClosed notifies that an issue or a pull request was closed
+	EventIssueClosed Event = "issue_closed"
+	// EventIssueReopened notifies that an issue or a pull request was reopened
+	EventIssueReopened Event = "issue_reopened"
+	// EventIssueDeleted notifies that an issue was deleted
+	EventIssueDeleted Event = "issue_deleted"
+)
+
+var events = []Event{
+	EventPreReceive,
+	EventUserRegister,
+	EventIssueOpened,
+	EventIssueClosed,
+	EventIssueReopened,
+	EventIssueDeleted,
+}
+
+// IsDecision returns whether the processors subscribed to the event decide whether the action happens
+func (e Event) IsDecision() bool {
+	return e == EventPreReceive || e == EventUserRegister
+}
+
+func isValidEvent(event string) bool {
+	for _, e := range events {
+		if string(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// request is the content POSTed to the processors
+type request struct {
+	Event Event       `json:"event"`
+	Data  interface{} `json:"data"`
+}
+
+// decision is the answer of a processor to a decision event
+type decision struct {
+	Allow   bool   `json:"allow"`
+	Message string `json:"message"`
+}
+
+var client = &http.Client{
+	Transport: &http.Transport{
+		Proxy: proxy.Proxy(),
+	},
+}
+
+// Init checks the events of the extension processors and starts sending them the events they subscribed to
+func Init() error {
+	hasIssueEvents := false
+	for _, p := range setting.ExtensionProcessors {
+		for _, event := range p.Events {
+			if !isValidEvent(event) {
+				return fmt.Errorf("extension.%s has an unknown event %q", p.Name, event)
+			}
+			if !Event(event).IsDecision() {
+				hasIssueEvents = true
+			}
+		}
+	}
+	if hasIssueEvents {
+		notification.RegisterNotifier(&extensionNotifier{})
+	}
+	return nil
+}
+
+// HasProcessors returns whether at least one processor subscribed to the event
+func HasProcessors(event Event) bool {
+	for _, p := range setting.ExtensionProcessors {
+		if p.HasEvent(string(event)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Decide asks the processors subscribed to a decision event whether the action can happen, in the order of their
+// configuration. The first processor rejecting the action stops the decision and its message is returned. When a
+// processor fails to answer in time, its failure policy decides.
+func Decide(ctx context.Context, event Event, data interface{}) (allowed bool, message string) {
+	for _, p := range setting.ExtensionProcessors {
+		if !p.HasEvent(string(event)) {
+			continue
+		}
+		var d decision
+		if err := send(ctx, p, event, data, &d); err != nil {
+			log.Error("Extension processor %s failed to decide on %s: %v", p.Name, event, err)
+			if p.FailurePolicy == setting.ExtensionFailureDeny {
+				return false, fmt.Sprintf("extension %s is unavailable", p.Name)
+			}
+			continue
+		}
+		if !d.Allow {
+			if d.Message == "" {
+				d.Message = fmt.Sprintf("rejected by extension %s", p.Name)
+			}
+			return false, d.Message
+		}
+	}
+	return true, ""
+}
+
+// Notify sends an event to the processors which subscribed to it in the background, their failures are only logged
+func Notify(event Event, data interface{}) {
+	for _, p := range setting.ExtensionProcessors {
+		if !p.HasEvent(string(event)) {
+			continue
+		}
+		go func(p *setting.ExtensionProcessor) {
+			if err := send(graceful.GetManager().ShutdownContext(), p, event, data, nil); err != nil {
+				log.Error("Extension processor %s failed to receive %s: %v", p.Name, event, err)
+			}
+		}(p)
+	}
+}
+
+// send POSTs an event to a processor and decodes its answer in result when it isn't nil
+func send(ctx context.Context, p *setting.ExtensionProcessor, event Event, data interface{}, result interface{}) error {
+	body, err := json.Marshal(&request{Event: event, Data: data})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Gitea "+setting.AppVer)
+	req.Header.Set("X-Gitea-Event", string(event))
+	if p.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.Token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("processor answered with status %d", resp.StatusCode)
+	}
+	if result == nil {
+		return nil
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, result)
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package extension
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecide(t *testing.T) {
+	var received []*request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		var req request
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		received = append(received, &req)
+
+		switch r.URL.Path {
+		case "/allow":
+			_, _ = w.Write([]byte(`{"allow": true}`))
+		case "/deny":
+			_, _ = w.Write([]byte(`{"allow": false, "message": "no pushes on fridays"}`))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"allow": true}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	processor := func(path string, policy string, events ...string) *setting.ExtensionProcessor {
+		return &setting.ExtensionProcessor{
+			Name:          path,
+			URL:           server.URL + "/" + path,
+			Token:         "secret",
+			Events:        events,
+			Timeout:       50 * time.Millisecond,
+			FailurePolicy: policy,
+		}
+	}
+	defer func(processors []*setting.ExtensionProcessor) {
+		setting.ExtensionProcessors = processors
+	}(setting.ExtensionProcessors)
+
+	data := &PreReceiveData{Repository: &Repository{ID: 1, FullName: "user2/repo1"}, Ref: "refs/heads/master"}
+
+	// the processors which didn't subscribe to the event aren't asked
+	setting.ExtensionProcessors = []*setting.ExtensionProcessor{
+		processor("allow", setting.ExtensionFailureAllow, string(EventPreReceive)),
+		processor("deny", setting.ExtensionFailureAllow, string(EventUserRegister)),
+	}
+	assert.True(t, HasProcessors(EventPreReceive))
+	assert.False(t, HasProcessors(EventIssueOpened))
+	allowed, _ := Decide(context.Background(), EventPreReceive, data)
+	assert.True(t, allowed)
+	if assert.Len(t, received, 1) {
+		assert.Equal(t, EventPreReceive, received[0].Event)
+		assert.Equal(t, "refs/heads/master", received[0].Data.(map[string]interface{})["ref"])
+	}
+
+	allowed, message := Decide(context.Background(), EventUserRegister, &UserRegisterData{User: &User{Name: "user"}})
+	assert.False(t, allowed)
+	assert.Equal(t, "no pushes on fridays", message)
+
+	// the failures follow the failure policy of the processor
+	setting.ExtensionProcessors = []*setting.ExtensionProcessor{
+		processor("error", setting.ExtensionFailureAllow, string(EventPreReceive)),
+		processor("slow", setting.ExtensionFailureAllow, string(EventPreReceive)),
+	}
+	allowed, _ = Decide(context.Background(), EventPreReceive, data)
+	assert.True(t, allowed)
+
+	setting.ExtensionProcessors[1].FailurePolicy = setting.ExtensionFailureDeny
+	allowed, message = Decide(context.Background(), EventPreReceive, data)
+	assert.False(t, allowed)
+	assert.Equal(t, "extension slow is unavailable", message)
+}
+
+func TestInit(t *testing.T) {
+	defer func(processors []*setting.ExtensionProcessor) {
+		setting.ExtensionProcessors = processors
+	}(setting.ExtensionProcessors)
+
+	setting.ExtensionProcessors = []*setting.ExtensionProcessor{
+		{Name: "policy", URL: "http://localhost/", Events: []string{string(EventPreReceive), "repository_created"}},
+	}
+	assert.Error(t, Init())
+
+	setting.ExtensionProcessors[0].Events = []string{string(EventPreReceive)}
+	assert.NoError(t, Init())
+}
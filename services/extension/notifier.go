@@ -0,0 +1,55 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package extension
+
+import (
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/notification/base"
+)
+
+type extensionNotifier struct {
+	base.NullNotifier
+}
+
+var _ base.Notifier = &extensionNotifier{}
+
+func notifyIssue(event Event, doer *user_model.User, issue *issues_model.Issue) {
+	if !HasProcessors(event) {
+		return
+	}
+	data, err := newIssueData(db.DefaultContext, doer, issue)
+	if err != nil {
+		log.Error("Unable to prepare the %s event of issue %d: %v", event, issue.ID, err)
+		return
+	}
+	Notify(event, data)
+}
+
+func (*extensionNotifier) NotifyNewIssue(issue *issues_model.Issue, _ []*user_model.User) {
+	notifyIssue(EventIssueOpened, issue.Poster, issue)
+}
+
+func (*extensionNotifier) NotifyNewPullRequest(pr *issues_model.PullRequest, _ []*user_model.User) {
+	if err := pr.LoadIssue(); err != nil {
+		log.Error("LoadIssue[%d]: %v", pr.ID, err)
+		return
+	}
+	notifyIssue(EventIssueOpened, pr.Issue.Poster, pr.Issue)
+}
+
+func (*extensionNotifier) NotifyIssueChangeStatus(doer *user_model.User, issue *issues_model.Issue, _ *issues_model.Comment, isClosed bool) {
+	if isClosed {
+		notifyIssue(EventIssueClosed, doer, issue)
+	} else {
+		notifyIssue(EventIssueReopened, doer, issue)
+	}
+}
+
+func (*extensionNotifier) NotifyDeleteIssue(doer *user_model.User, issue *issues_model.Issue) {
+	notifyIssue(EventIssueDeleted, doer, issue)
+}
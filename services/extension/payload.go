@@ -0,0 +1,111 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package extension
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+)
+
+// User is a user in the events sent to the processors
+type User struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+	IsAdmin  bool   `json:"is_admin"`
+}
+
+// Repository is a repository in the events sent to the processors
+type Repository struct {
+	ID        int64  `json:"id"`
+	FullName  string `json:"full_name"`
+	HTMLURL   string `json:"html_url"`
+	IsPrivate bool   `json:"private"`
+}
+
+// Issue is an issue or a pull request in the events sent to the processors
+type Issue struct {
+	ID      int64  `json:"id"`
+	Index   int64  `json:"number"`
+	Title   string `json:"title"`
+	IsPull  bool   `json:"is_pull"`
+	HTMLURL string `json:"html_url"`
+	Poster  *User  `json:"poster"`
+}
+
+// PreReceiveData is the data of the pre_receive events
+type PreReceiveData struct {
+	Repository *Repository `json:"repository"`
+	Pusher     *User       `json:"pusher"`
+	Ref        string      `json:"ref"`
+	Before     string      `json:"before"`
+	After      string      `json:"after"`
+}
+
+// UserRegisterData is the data of the user_register events, the user doesn't have an ID yet
+type UserRegisterData struct {
+	User *User `json:"user"`
+	// Provider is the name of the OAuth2 authentication source the user signs up with, if any
+	Provider string `json:"provider"`
+}
+
+// IssueData is the data of the issue_* events
+type IssueData struct {
+	Repository *Repository `json:"repository"`
+	Issue      *Issue      `json:"issue"`
+	Sender     *User       `json:"sender"`
+}
+
+// NewUser returns the user in the events sent to the processors
+func NewUser(u *user_model.User) *User {
+	if u == nil {
+		return nil
+	}
+	return &User{
+		ID:       u.ID,
+		Name:     u.Name,
+		FullName: u.FullName,
+		Email:    u.Email,
+		IsAdmin:  u.IsAdmin,
+	}
+}
+
+// NewRepository returns the repository in the events sent to the processors
+func NewRepository(repo *repo_model.Repository) *Repository {
+	return &Repository{
+		ID:        repo.ID,
+		FullName:  repo.FullName(),
+		HTMLURL:   repo.HTMLURL(),
+		IsPrivate: repo.IsPrivate,
+	}
+}
+
+func newIssueData(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) (*IssueData, error) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	if err := issue.LoadPoster(); err != nil {
+		return nil, err
+	}
+	if doer == nil {
+		doer = issue.Poster
+	}
+	return &IssueData{
+		Repository: NewRepository(issue.Repo),
+		Issue: &Issue{
+			ID:      issue.ID,
+			Index:   issue.Index,
+			Title:   issue.Title,
+			IsPull:  issue.IsPull,
+			HTMLURL: issue.HTMLURL(),
+			Poster:  NewUser(issue.Poster),
+		},
+		Sender: NewUser(doer),
+	}, nil
+}
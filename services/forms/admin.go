@@ -60,6 +60,33 @@ func (f *AdminEditUserForm) Validate(req *http.Request, errs binding.Errors) bin
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// AdminBadgeForm form for admin to create or edit a badge
+type AdminBadgeForm struct {
+	Description string `binding:"Required;MaxSize(255)"`
+	ImageURL    string `binding:"OmitEmpty;ValidUrl;MaxSize(255)"`
+}
+
+// Validate validates form fields
+func (f *AdminBadgeForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// AdminExploreCollectionForm form for admin to create or edit a collection of the explore pages
+type AdminExploreCollectionForm struct {
+	Name        string `binding:"Required;MaxSize(255)"`
+	Description string
+	Topic       string `binding:"MaxSize(50)"`
+	IsPinned    bool
+	Position    int
+}
+
+// Validate validates form fields
+func (f *AdminExploreCollectionForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // AdminDashboardForm form for admin dashboard operations
 type AdminDashboardForm struct {
 	Op   string `binding:"required"`
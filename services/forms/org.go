@@ -45,6 +45,9 @@ type UpdateOrgSettingForm struct {
 	Visibility                structs.VisibleType
 	MaxRepoCreation           int
 	RepoAdminChangeTeamAccess bool
+	PinnedRepositories        string
+	ShowProfilePackages       bool
+	ShowProfileProjects       bool
 }
 
 // Validate validates the fields
@@ -62,11 +65,14 @@ func (f *UpdateOrgSettingForm) Validate(req *http.Request, errs binding.Errors)
 
 // CreateTeamForm form for creating team
 type CreateTeamForm struct {
-	TeamName         string `binding:"Required;AlphaDashDot;MaxSize(30)"`
-	Description      string `binding:"MaxSize(255)"`
-	Permission       string
-	RepoAccess       string
-	CanCreateOrgRepo bool
+	TeamName           string `binding:"Required;AlphaDashDot;MaxSize(30)"`
+	Description        string `binding:"MaxSize(255)"`
+	Permission         string
+	RepoAccess         string
+	CanCreateOrgRepo   bool
+	AutoAssignReviewer bool
+	ParentID           int64
+	RoleID             int64
 }
 
 // Validate validates the fields
@@ -74,3 +80,63 @@ func (f *CreateTeamForm) Validate(req *http.Request, errs binding.Errors) bindin
 	ctx := context.GetContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// CreateRoleForm form for creating or editing a role
+type CreateRoleForm struct {
+	RoleName    string `binding:"Required;AlphaDashDot;MaxSize(30)"`
+	Description string `binding:"MaxSize(255)"`
+}
+
+// Validate validates the fields
+func (f *CreateRoleForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// AddOrgDomainForm form for claiming a domain
+type AddOrgDomainForm struct {
+	Domain string `binding:"Required;MaxSize(253)"`
+	TeamID int64
+}
+
+// Validate validates the fields
+func (f *AddOrgDomainForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// CreateOrgBotForm form for creating a bot account of an organization
+type CreateOrgBotForm struct {
+	BotName  string `binding:"Required;AlphaDashDot;MaxSize(40)" locale:"org.bots.name"`
+	FullName string `binding:"MaxSize(100)"`
+}
+
+// Validate validates the fields
+func (f *CreateOrgBotForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// NewBotTokenForm form for creating an access token of a bot
+type NewBotTokenForm struct {
+	Name     string `binding:"Required;MaxSize(255)"`
+	ReadOnly bool
+}
+
+// Validate validates the fields
+func (f *NewBotTokenForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// RenameOrgDefaultBranchesForm form for renaming the default branch of the repositories of an organization
+type RenameOrgDefaultBranchesForm struct {
+	OldName string `binding:"Required;GitRefName;MaxSize(100)" locale:"org.branches.old_name"`
+	NewName string `binding:"Required;GitRefName;MaxSize(100)" locale:"org.branches.new_name"`
+}
+
+// Validate validates the fields
+func (f *RenameOrgDefaultBranchesForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
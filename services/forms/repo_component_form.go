@@ -0,0 +1,31 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package forms
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/web/middleware"
+
+	"gitea.com/go-chi/binding"
+)
+
+// ComponentForm form for creating and editing the components of a repository
+type ComponentForm struct {
+	Name         string `binding:"Required;MaxSize(100)"`
+	Description  string
+	Paths        string `binding:"Required"`
+	OwnerUsers   string
+	OwnerTeams   string
+	Labels       string
+	NotifyOwners bool
+}
+
+// Validate validates the fields
+func (f *ComponentForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -145,6 +145,7 @@ type RepoSettingForm struct {
 	TrackerIssueStyle                     string
 	ExternalTrackerRegexpPattern          string
 	EnableCloseIssuesViaCommitInAnyBranch bool
+	EnablePrivateReporting                bool
 	EnableProjects                        bool
 	EnablePackages                        bool
 	EnablePulls                           bool
@@ -158,14 +159,45 @@ type RepoSettingForm struct {
 	EnableAutodetectManualMerge           bool
 	PullsAllowRebaseUpdate                bool
 	DefaultDeleteBranchAfterMerge         bool
+	PullsReviewReminderDays               int `binding:"Range(0,365)"`
+	PullsReviewEscalationDays             int `binding:"Range(0,365)"`
+	PullsApprovalExpiryDays               int `binding:"Range(0,365)"`
+	PullsDefaultMergeMessageTemplate      string
+	PullsDefaultSquashMessageTemplate     string
+	PullsEnforceMergeMessageTemplate      bool
 	EnableTimetracker                     bool
 	AllowOnlyContributorsToTrackTime      bool
 	EnableIssueDependencies               bool
+	StaleDays                             int
+	StaleCloseDays                        int
+	StaleLabel                            string
+	StaleExemptLabels                     string
+	StaleExemptMilestones                 bool
+	StaleIncludePulls                     bool
+	EnableServiceDesk                     bool
 	IsArchived                            bool
 
 	// Signing Settings
 	TrustModel string
 
+	// Commit identity settings
+	EnforceCommitIdentity bool
+	CommitEmailDomains    string
+
+	// Partial clone settings
+	PartialCloneFilters      []string
+	PartialCloneTreeMaxDepth int
+
+	// Release drafter settings
+	EnableReleaseDrafter bool
+	ReleaseDraftTagName  string `binding:"MaxSize(255)"`
+	ReleaseDraftTemplate string
+	ChangelogCategories  string
+	RequireAttestations  bool
+
+	// Fork synchronization settings
+	EnableForkSync bool
+
 	// Admin settings
 	EnableHealthCheck  bool
 	RequestReindexType string
@@ -196,6 +228,7 @@ type ProtectBranchForm struct {
 	MergeWhitelistTeams           string
 	EnableStatusCheck             bool
 	StatusCheckContexts           []string
+	StatusCheckPatterns           string
 	RequiredApprovals             int64
 	EnableApprovalsWhitelist      bool
 	ApprovalsWhitelistUsers       string
@@ -203,7 +236,11 @@ type ProtectBranchForm struct {
 	BlockOnRejectedReviews        bool
 	BlockOnOfficialReviewRequests bool
 	BlockOnOutdatedBranch         bool
+	BlockOnCodeScanningAlerts     bool
+	MinimumDiffCoverage           int64 `binding:"Range(0,100)"`
 	DismissStaleApprovals         bool
+	IgnoreLastCommittersApprovals int64 `binding:"Range(0,100)"`
+	DismissPusherApprovals        bool
 	RequireSignedCommits          bool
 	ProtectedFilePatterns         string
 	UnprotectedFilePatterns       string
@@ -245,6 +282,7 @@ type WebhookForm struct {
 	Wiki                 bool
 	Repository           bool
 	Package              bool
+	Deployment           bool
 	Active               bool
 	BranchFilter         string `binding:"GlobPattern"`
 }
@@ -439,6 +477,7 @@ type CreateIssueForm struct {
 	Content             string
 	Files               []string
 	AllowMaintainerEdit bool
+	Confidential        bool
 }
 
 // Validate validates the fields
@@ -498,6 +537,20 @@ func (i IssueLockForm) HasValidReason() bool {
 	return false
 }
 
+// ReportContentForm form for reporting an issue or a comment to the moderators
+type ReportContentForm struct {
+	// CommentID is 0 to report the content of the issue
+	CommentID int64
+	Category  string `binding:"Required"`
+	Remark    string `binding:"MaxSize(1000)"`
+}
+
+// Validate validates the fields
+func (f *ReportContentForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // __________                   __               __
 // \______   \_______  ____    |__| ____   _____/  |_  ______
 //  |     ___/\_  __ \/  _ \   |  |/ __ \_/ ___\   __\/  ___/
@@ -716,6 +769,25 @@ func (f *EditReleaseForm) Validate(req *http.Request, errs binding.Errors) bindi
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// AdvisoryForm form for reporting or editing a security advisory
+type AdvisoryForm struct {
+	Title              string `binding:"Required;MaxSize(255)"`
+	Description        string
+	Severity           string `binding:"Required;In(low,moderate,high,critical)"`
+	CVEID              string `form:"cve_id" binding:"MaxSize(32)"`
+	CWEIDs             string `form:"cwe_ids"`
+	Ecosystem          string `binding:"MaxSize(32)"`
+	PackageName        string `binding:"MaxSize(255)"`
+	VulnerableVersions string `binding:"MaxSize(255)"`
+	PatchedVersions    string `binding:"MaxSize(255)"`
+}
+
+// Validate validates the fields
+func (f *AdvisoryForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 //  __      __.__ __   .__
 // /  \    /  \__|  | _|__|
 // \   \/\/   /  |  |/ /  |
@@ -0,0 +1,40 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package forms
+
+import (
+	"mime/multipart"
+	"net/http"
+
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/web/middleware"
+
+	"gitea.com/go-chi/binding"
+)
+
+// IssueImportUploadForm form for uploading the CSV file to import the issues from
+type IssueImportUploadForm struct {
+	File *multipart.FileHeader
+}
+
+// Validate validates the fields
+func (f *IssueImportUploadForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// IssueImportForm form for importing the issues of an uploaded CSV file, the columns being mapped to the fields
+// of the issues
+type IssueImportForm struct {
+	Content string `binding:"Required"`
+	Columns []string
+	Fields  []string
+}
+
+// Validate validates the fields
+func (f *IssueImportForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
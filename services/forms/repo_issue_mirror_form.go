@@ -0,0 +1,28 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package forms
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web/middleware"
+
+	"gitea.com/go-chi/binding"
+)
+
+// IssueMirrorForm form for mirroring the issue tracker of an external repository
+type IssueMirrorForm struct {
+	Service       structs.GitServiceType
+	RemoteAddress string `binding:"Required;ValidUrl;MaxSize(2048)"`
+	AuthToken     string
+}
+
+// Validate validates the fields
+func (f *IssueMirrorForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
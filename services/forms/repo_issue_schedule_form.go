@@ -0,0 +1,31 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package forms
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/web/middleware"
+
+	"gitea.com/go-chi/binding"
+)
+
+// IssueScheduleForm form for creating and editing the recurring issues of a repository
+type IssueScheduleForm struct {
+	Title        string `binding:"Required;MaxSize(255)"`
+	Content      string
+	TemplateFile string
+	Schedule     string `binding:"Required;MaxSize(100)"`
+	Assignees    string
+	Labels       string
+	IsActive     bool
+}
+
+// Validate validates the fields
+func (f *IssueScheduleForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
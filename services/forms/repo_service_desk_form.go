@@ -0,0 +1,37 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package forms
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/context"
+	"code.gitea.io/gitea/modules/web/middleware"
+
+	"gitea.com/go-chi/binding"
+)
+
+// ServiceDeskIssueForm form for filing an issue through the service desk of a repository
+type ServiceDeskIssueForm struct {
+	Title   string `binding:"Required;MaxSize(255)"`
+	Content string
+}
+
+// Validate validates the fields
+func (f *ServiceDeskIssueForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// ServiceDeskCommentForm form for replying to an issue filed through the service desk of a repository
+type ServiceDeskCommentForm struct {
+	Content string `binding:"Required"`
+}
+
+// Validate validates the fields
+func (f *ServiceDeskCommentForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -214,6 +214,16 @@ type AccessTokenForm struct {
 
 	// PKCE support
 	CodeVerifier string `json:"code_verifier"`
+
+	// Device authorization grant support, see RFC 8628
+	DeviceCode string `json:"device_code"`
+
+	// Token exchange support, see RFC 8693
+	SubjectToken       string `json:"subject_token"`
+	SubjectTokenType   string `json:"subject_token_type"`
+	RequestedTokenType string `json:"requested_token_type"`
+	Audience           string `json:"audience"`
+	Scope              string `json:"scope"`
 }
 
 // Validate validates the fields
@@ -222,6 +232,31 @@ func (f *AccessTokenForm) Validate(req *http.Request, errs binding.Errors) bindi
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// DeviceAuthorizationForm for requesting the authorization of a device, see RFC 8628
+type DeviceAuthorizationForm struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Scope        string `json:"scope"`
+}
+
+// Validate validates the fields
+func (f *DeviceAuthorizationForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// GrantDeviceForm form for approving or denying the authorization of a device
+type GrantDeviceForm struct {
+	UserCode string `binding:"Required"`
+	Granted  bool
+}
+
+// Validate validates the fields
+func (f *GrantDeviceForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // IntrospectTokenForm for introspecting tokens
 type IntrospectTokenForm struct {
 	Token string `json:"token"`
@@ -250,6 +285,17 @@ type UpdateProfileForm struct {
 	Description         string `binding:"MaxSize(255)"`
 	Visibility          structs.VisibleType
 	KeepActivityPrivate bool
+	ReviewCapacity      int `binding:"Range(0,1000)"`
+	OutOfOffice         bool
+	OutOfOfficeUntil    string
+	PinnedRepositories  string
+	ShowProfileActivity bool
+	ShowProfilePackages bool
+	ShowProfileProjects bool
+	StatusEmoji         string `binding:"MaxSize(64)"`
+	StatusMessage       string `binding:"MaxSize(80)"`
+	StatusBusy          bool
+	StatusExpiresAt     string
 }
 
 // Validate validates the fields
@@ -349,6 +395,19 @@ func (f *AddOpenIDForm) Validate(req *http.Request, errs binding.Errors) binding
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// LinkPasswordSourceForm is for linking an account of a password based authentication source
+type LinkPasswordSourceForm struct {
+	SourceID  int64  `binding:"Required"`
+	LoginName string `binding:"Required;MaxSize(254)"`
+	Password  string `binding:"Required;MaxSize(255)"`
+}
+
+// Validate validates the fields
+func (f *LinkPasswordSourceForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // AddKeyForm form for adding SSH/GPG key
 type AddKeyForm struct {
 	Type        string `binding:"OmitEmpty"`
@@ -368,7 +427,8 @@ func (f *AddKeyForm) Validate(req *http.Request, errs binding.Errors) binding.Er
 
 // NewAccessTokenForm form for creating access token
 type NewAccessTokenForm struct {
-	Name string `binding:"Required;MaxSize(255)"`
+	Name  string `binding:"Required;MaxSize(255)"`
+	Scope string `binding:"In(,read,feed)"`
 }
 
 // Validate validates the fields
@@ -192,7 +192,10 @@ func IsValidTeamReviewRequest(ctx context.Context, reviewer *organization.Team,
 
 	if isAdd {
 		if issue.Repo.IsPrivate {
-			hasTeam := organization.HasTeamRepo(ctx, reviewer.OrgID, reviewer.ID, issue.RepoID)
+			hasTeam, err := organization.HasInheritedTeamRepo(ctx, reviewer.OrgID, reviewer.ID, issue.RepoID)
+			if err != nil {
+				return err
+			}
 
 			if !hasTeam {
 				return issues_model.ErrNotValidReviewRequest{
@@ -245,20 +248,44 @@ func TeamReviewRequest(issue *issues_model.Issue, doer *user_model.User, reviewe
 		return
 	}
 
+	// hand the review to the least loaded member instead of notifying the whole team
+	if reviewer.AutoAssignReviewer {
+		var member *user_model.User
+		if member, err = PickLeastLoadedReviewer(db.DefaultContext, reviewer, issue); err != nil {
+			return
+		}
+		if member != nil {
+			_, err = ReviewRequest(issue, doer, member, true)
+			return comment, err
+		}
+	}
+
 	// notify all user in this team
 	if err = comment.LoadIssue(); err != nil {
 		return
 	}
 
+	// the review request targets the whole subtree of the team
 	members, err := organization.GetTeamMembers(db.DefaultContext, &organization.SearchMembersOptions{
-		TeamID: reviewer.ID,
+		TeamID:          reviewer.ID,
+		OrgID:           reviewer.OrgID,
+		IncludeSubteams: true,
 	})
 	if err != nil {
 		return
 	}
+	memberIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		memberIDs = append(memberIDs, member.ID)
+	}
+	statuses, err := user_model.GetUserStatuses(db.DefaultContext, memberIDs)
+	if err != nil {
+		return
+	}
 
 	for _, member := range members {
-		if member.ID == comment.Issue.PosterID {
+		// busy members decline the review requests of their teams
+		if member.ID == comment.Issue.PosterID || (statuses[member.ID] != nil && statuses[member.ID].Busy) {
 			continue
 		}
 		comment.AssigneeID = member.ID
@@ -0,0 +1,152 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/notification"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// BulkEditOptions are the changes applied to several issues at once. The labels, the milestone and the assignees are
+// expected to be valid for the issues.
+type BulkEditOptions struct {
+	AddLabels       []*issues_model.Label
+	RemoveLabels    []*issues_model.Label
+	MilestoneID     *int64 // 0 removes the milestone
+	AddAssignees    []*user_model.User
+	RemoveAssignees []*user_model.User
+	IsClosed        util.OptionalBool
+}
+
+// BulkEditIssues applies the same changes to several issues or pull requests in one transaction: if one of the
+// changes fails, e.g. an issue can't be closed because of its dependencies, none of them is applied. The
+// notifications are only sent once all the changes have been committed.
+func BulkEditIssues(ctx context.Context, doer *user_model.User, issues []*issues_model.Issue, opts *BulkEditOptions) error {
+	notifications := make([]func(), 0, len(issues))
+	if err := db.WithTx(func(ctx context.Context) error {
+		for _, issue := range issues {
+			notify, err := bulkEditIssue(ctx, doer, issue, opts)
+			if err != nil {
+				return err
+			}
+			notifications = append(notifications, notify...)
+		}
+		return nil
+	}, ctx); err != nil {
+		return err
+	}
+
+	for _, notify := range notifications {
+		notify()
+	}
+	return nil
+}
+
+// bulkEditIssue applies the changes to an issue and returns the notifications of the changes actually made
+func bulkEditIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, opts *BulkEditOptions) ([]func(), error) {
+	var notifications []func()
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	removedLabels := make([]*issues_model.Label, 0, len(opts.RemoveLabels))
+	for _, label := range opts.RemoveLabels {
+		if !issues_model.HasIssueLabel(ctx, issue.ID, label.ID) {
+			continue
+		}
+		if err := issues_model.DeleteIssueLabel(ctx, issue, label, doer); err != nil {
+			return nil, err
+		}
+		removedLabels = append(removedLabels, label)
+	}
+	addedLabels := make([]*issues_model.Label, 0, len(opts.AddLabels))
+	for _, label := range opts.AddLabels {
+		// skip the labels already added and the labels of other repositories
+		if issues_model.HasIssueLabel(ctx, issue.ID, label.ID) ||
+			label.RepoID != issue.RepoID && label.OrgID != issue.Repo.OwnerID {
+			continue
+		}
+		addedLabels = append(addedLabels, label)
+	}
+	if len(addedLabels) > 0 {
+		if err := issues_model.NewIssueLabelsCtx(ctx, issue, addedLabels, doer); err != nil {
+			return nil, err
+		}
+	}
+	if len(addedLabels) > 0 || len(removedLabels) > 0 {
+		notifications = append(notifications, func() {
+			notification.NotifyIssueChangeLabels(doer, issue, addedLabels, removedLabels)
+		})
+	}
+
+	if opts.MilestoneID != nil && *opts.MilestoneID != issue.MilestoneID {
+		oldMilestoneID := issue.MilestoneID
+		issue.MilestoneID = *opts.MilestoneID
+		if err := changeMilestoneAssign(ctx, doer, issue, oldMilestoneID); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, func() {
+			notification.NotifyIssueChangeMilestone(doer, issue, oldMilestoneID)
+		})
+	}
+
+	if len(opts.AddAssignees) > 0 || len(opts.RemoveAssignees) > 0 {
+		if err := issue.LoadAssignees(ctx); err != nil {
+			return nil, err
+		}
+		assigned := make(map[int64]bool, len(issue.Assignees))
+		for _, assignee := range issue.Assignees {
+			assigned[assignee.ID] = true
+		}
+		toggle := func(assignee *user_model.User) error {
+			removed, comment, err := issues_model.ToggleIssueAssigneeCtx(ctx, issue, doer, assignee.ID)
+			if err != nil {
+				return err
+			}
+			assigned[assignee.ID] = !removed
+			notifications = append(notifications, func() {
+				notification.NotifyIssueChangeAssignee(doer, issue, assignee, removed, comment)
+			})
+			return nil
+		}
+		for _, assignee := range opts.RemoveAssignees {
+			if assigned[assignee.ID] {
+				if err := toggle(assignee); err != nil {
+					return nil, err
+				}
+			}
+		}
+		for _, assignee := range opts.AddAssignees {
+			if !assigned[assignee.ID] {
+				if err := toggle(assignee); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if !opts.IsClosed.IsNone() && opts.IsClosed.IsTrue() != issue.IsClosed {
+		isClosed := opts.IsClosed.IsTrue()
+		comment, err := issues_model.ChangeIssueStatus(ctx, issue, doer, isClosed)
+		if err != nil {
+			return nil, err
+		}
+		if isClosed {
+			if err := issues_model.FinishIssueStopwatchIfPossible(ctx, doer, issue); err != nil {
+				return nil, err
+			}
+		}
+		notifications = append(notifications, func() {
+			notification.NotifyIssueChangeStatus(doer, issue, comment, isClosed)
+		})
+	}
+
+	return notifications, nil
+}
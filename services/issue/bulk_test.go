@@ -0,0 +1,71 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkEditIssues(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user1 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue3 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 3})
+	label1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 1})
+	label2 := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 2})
+	milestoneID := int64(1)
+	labelComments := unittest.GetCount(t, &issues_model.Comment{IssueID: 1, Type: issues_model.CommentTypeLabel})
+
+	assert.NoError(t, BulkEditIssues(db.DefaultContext, doer, []*issues_model.Issue{issue1, issue3}, &BulkEditOptions{
+		AddLabels:       []*issues_model.Label{label2},
+		RemoveLabels:    []*issues_model.Label{label1},
+		MilestoneID:     &milestoneID,
+		AddAssignees:    []*user_model.User{doer},
+		RemoveAssignees: []*user_model.User{user1},
+		IsClosed:        util.OptionalBoolTrue,
+	}))
+	for _, id := range []int64{1, 3} {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: id})
+		assert.True(t, issue.IsClosed)
+		assert.EqualValues(t, 1, issue.MilestoneID)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: id, LabelID: 2})
+		unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: id, LabelID: 1})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: id, AssigneeID: 2})
+		unittest.AssertNotExistsBean(t, &issues_model.IssueAssignees{IssueID: id, AssigneeID: 1})
+	}
+	// only the changes actually made are commented
+	unittest.AssertCount(t, &issues_model.Comment{IssueID: 1, Type: issues_model.CommentTypeLabel}, labelComments+2)
+	unittest.AssertCount(t, &issues_model.Comment{IssueID: 3, Type: issues_model.CommentTypeLabel}, 1)
+}
+
+func TestBulkEditIssuesRollback(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue3 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 3})
+	label2 := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 2})
+
+	// the assignee doesn't exist, so nothing is changed
+	err := BulkEditIssues(db.DefaultContext, doer, []*issues_model.Issue{issue1, issue3}, &BulkEditOptions{
+		AddLabels:    []*issues_model.Label{label2},
+		AddAssignees: []*user_model.User{{ID: unittest.NonexistentID}},
+		IsClosed:     util.OptionalBoolTrue,
+	})
+	assert.Error(t, err)
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1}).IsClosed)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: 2})
+	unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 3, LabelID: 2})
+}
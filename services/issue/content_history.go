@@ -0,0 +1,86 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"context"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// ContentHistoryDiffs returns the diff of each revision of a page of an edit history, the latest first, from its
+// previous revision which isn't deleted. The diffs of the deleted revisions are empty.
+func ContentHistoryDiffs(ctx context.Context, histories []*issues_model.ContentHistory) ([]string, error) {
+	diffs := make([]string, len(histories))
+	var prevContent string
+	if len(histories) > 0 {
+		// the previous revision of the last one of the page is on the next page
+		prevHistory, err := issues_model.GetPrevIssueContentHistory(ctx, histories[len(histories)-1])
+		if err != nil {
+			return nil, err
+		}
+		if prevHistory != nil {
+			prevContent = prevHistory.ContentText
+		}
+	}
+	for i := len(histories) - 1; i >= 0; i-- {
+		if histories[i].IsDeleted {
+			continue
+		}
+		diffs[i] = ContentDiff(prevContent, histories[i].ContentText)
+		prevContent = histories[i].ContentText
+	}
+	return diffs, nil
+}
+
+// ContentDiff returns the line diff between two contents, each line is prefixed by "+" if it was added, "-" if it
+// was removed or " " if it is unchanged
+func ContentDiff(oldContent, newContent string) string {
+	// each distinct line is diffed as a single rune, skipping the surrogates which aren't valid runes
+	lines := map[rune]string{}
+	lineRunes := map[string]rune{}
+	toRunes := func(content string) []rune {
+		var runes []rune
+		for _, line := range strings.SplitAfter(content, "\n") {
+			if line == "" {
+				continue
+			}
+			r, ok := lineRunes[line]
+			if !ok {
+				r = rune(len(lines))
+				if r >= 0xD800 {
+					r += 0x800
+				}
+				lineRunes[line] = r
+				lines[r] = line
+			}
+			runes = append(runes, r)
+		}
+		return runes
+	}
+	oldRunes, newRunes := toRunes(oldContent), toRunes(newContent)
+
+	var diff strings.Builder
+	for _, d := range diffmatchpatch.New().DiffMainRunes(oldRunes, newRunes, false) {
+		prefix := " "
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			prefix = "+"
+		case diffmatchpatch.DiffDelete:
+			prefix = "-"
+		}
+		for _, r := range d.Text {
+			diff.WriteString(prefix)
+			diff.WriteString(lines[r])
+			if !strings.HasSuffix(lines[r], "\n") {
+				diff.WriteString("\n")
+			}
+		}
+	}
+	return diff.String()
+}
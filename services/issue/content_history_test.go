@@ -0,0 +1,43 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentDiff(t *testing.T) {
+	assert.Equal(t, "+a\n+b\n", ContentDiff("", "a\nb"))
+	assert.Equal(t, " a\n-b\n+c\n d\n", ContentDiff("a\nb\nd\n", "a\nc\nd\n"))
+	assert.Empty(t, ContentDiff("", ""))
+}
+
+func TestContentHistoryDiffs(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	now := timeutil.TimeStampNow()
+	for i, content := range []string{"a", "a\nb", "a\nc", "c"} {
+		assert.NoError(t, issues_model.SaveIssueContentHistory(db.DefaultContext, 1, 1, 0, now.Add(int64(i)), content, i == 0))
+	}
+	histories, count, err := issues_model.FindIssueContentHistories(db.DefaultContext, 1, 0, db.ListOptions{Page: 1, PageSize: 2})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 4, count)
+	if assert.Len(t, histories, 2) {
+		assert.NoError(t, issues_model.SoftDeleteIssueContentHistory(db.DefaultContext, histories[1].ID))
+		histories[1].IsDeleted = true
+	}
+
+	// the deleted revision is skipped, the previous revision of the page is on the next page
+	diffs, err := ContentHistoryDiffs(db.DefaultContext, histories)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"-a\n-b\n+c\n", ""}, diffs)
+}
@@ -0,0 +1,195 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ExportFormat is the format of an issue export
+type ExportFormat string
+
+const (
+	// ExportFormatCSV exports the issues as CSV with a header line
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatJSONLines exports the issues as JSON Lines, one JSON object per issue
+	ExportFormatJSONLines ExportFormat = "jsonl"
+)
+
+// IsValid returns true if the export format is supported
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormatCSV || f == ExportFormatJSONLines
+}
+
+// ContentType returns the content type of the export format
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatJSONLines {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// exportBatchSize is the number of issues loaded at once while exporting
+const exportBatchSize = 50
+
+var exportColumns = []string{
+	"index", "type", "title", "state", "author", "assignees", "labels", "milestone",
+	"created_at", "updated_at", "closed_at", "deadline", "comments", "time_spent", "url",
+}
+
+// exportedIssue is an issue or a pull request as exported
+type exportedIssue struct {
+	Index     int64      `json:"index"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	State     string     `json:"state"`
+	Author    string     `json:"author"`
+	Assignees []string   `json:"assignees"`
+	Labels    []string   `json:"labels"`
+	Milestone string     `json:"milestone"`
+	Created   time.Time  `json:"created_at"`
+	Updated   time.Time  `json:"updated_at"`
+	Closed    *time.Time `json:"closed_at"`
+	Deadline  *time.Time `json:"deadline"`
+	Comments  int        `json:"comments"`
+	// TimeSpent is the total tracked time, in seconds
+	TimeSpent int64  `json:"time_spent"`
+	URL       string `json:"url"`
+}
+
+func optionalTime(t timeutil.TimeStamp) *time.Time {
+	if t == 0 {
+		return nil
+	}
+	tt := t.AsTime()
+	return &tt
+}
+
+func toExportedIssue(issue *issues_model.Issue) *exportedIssue {
+	e := &exportedIssue{
+		Index:     issue.Index,
+		Type:      "issue",
+		Title:     issue.Title,
+		State:     string(issue.State()),
+		Assignees: make([]string, 0, len(issue.Assignees)),
+		Labels:    make([]string, 0, len(issue.Labels)),
+		Created:   issue.CreatedUnix.AsTime(),
+		Updated:   issue.UpdatedUnix.AsTime(),
+		Deadline:  optionalTime(issue.DeadlineUnix),
+		Comments:  issue.NumComments,
+		TimeSpent: issue.TotalTrackedTime,
+		URL:       issue.HTMLURL(),
+	}
+	if issue.IsClosed {
+		e.Closed = optionalTime(issue.ClosedUnix)
+	}
+	if issue.IsPull {
+		e.Type = "pull"
+		if issue.PullRequest != nil && issue.PullRequest.HasMerged {
+			e.State = "merged"
+		}
+	}
+	if issue.Poster != nil {
+		e.Author = issue.Poster.Name
+	}
+	for _, assignee := range issue.Assignees {
+		e.Assignees = append(e.Assignees, assignee.Name)
+	}
+	for _, label := range issue.Labels {
+		e.Labels = append(e.Labels, label.Name)
+	}
+	if issue.Milestone != nil {
+		e.Milestone = issue.Milestone.Name
+	}
+	return e
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (e *exportedIssue) csvRecord() []string {
+	return []string{
+		strconv.FormatInt(e.Index, 10),
+		e.Type,
+		e.Title,
+		e.State,
+		e.Author,
+		strings.Join(e.Assignees, ","),
+		strings.Join(e.Labels, ","),
+		e.Milestone,
+		formatExportTime(&e.Created),
+		formatExportTime(&e.Updated),
+		formatExportTime(e.Closed),
+		formatExportTime(e.Deadline),
+		strconv.Itoa(e.Comments),
+		strconv.FormatInt(e.TimeSpent, 10),
+		e.URL,
+	}
+}
+
+// ExportIssues writes all the issues matching the options to w in the given format, oldest first.
+// The issues are loaded by batches and the pagination and the sort of the options are ignored,
+// nil options export no issue.
+func ExportIssues(w io.Writer, format ExportFormat, opts *issues_model.IssuesOptions) error {
+	var csvWriter *csv.Writer
+	var encoder json.Encoder
+	if format == ExportFormatJSONLines {
+		encoder = json.NewEncoder(w)
+	} else {
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(exportColumns); err != nil {
+			return err
+		}
+		defer csvWriter.Flush()
+	}
+	if opts == nil {
+		return nil
+	}
+
+	batchOpts := *opts
+	batchOpts.SortType = "oldest"
+	batchOpts.PageSize = exportBatchSize
+	for page := 1; ; page++ {
+		batchOpts.Page = page
+		issues, err := issues_model.Issues(&batchOpts)
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range issues {
+			e := toExportedIssue(issue)
+			if csvWriter != nil {
+				err = csvWriter.Write(e.csvRecord())
+			} else {
+				err = encoder.Encode(e)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if csvWriter != nil {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return err
+			}
+		}
+
+		if len(issues) < exportBatchSize {
+			return nil
+		}
+	}
+}
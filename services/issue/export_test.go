@@ -0,0 +1,51 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportIssues(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	opts := &issues_model.IssuesOptions{RepoID: 1, IsPull: util.OptionalBoolFalse, IsClosed: util.OptionalBoolNone}
+	count, err := issues_model.CountIssues(opts)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, ExportIssues(&buf, ExportFormatCSV, opts))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, int(count)+1) {
+		assert.Equal(t, strings.Join(exportColumns, ","), lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "1,issue,issue1,open,user1,user1,label1,,2000-01-01T00:00:00Z,"), lines[1])
+	}
+
+	buf.Reset()
+	assert.NoError(t, ExportIssues(&buf, ExportFormatJSONLines, opts))
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, int(count)) {
+		var first exportedIssue
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		assert.EqualValues(t, 1, first.Index)
+		assert.Equal(t, "issue", first.Type)
+		assert.Equal(t, []string{"user1"}, first.Assignees)
+		assert.Equal(t, []string{"label1"}, first.Labels)
+		assert.Nil(t, first.Closed)
+	}
+
+	buf.Reset()
+	assert.NoError(t, ExportIssues(&buf, ExportFormatCSV, nil))
+	assert.Equal(t, strings.Join(exportColumns, ",")+"\n", buf.String())
+}
@@ -0,0 +1,383 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	csv_module "code.gitea.io/gitea/modules/csv"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ImportField is a field of the issues a column of an imported CSV file can be mapped to
+type ImportField string
+
+const (
+	// ImportFieldTitle is the title of the issue, the only required field
+	ImportFieldTitle ImportField = "title"
+	// ImportFieldContent is the description of the issue
+	ImportFieldContent ImportField = "content"
+	// ImportFieldState is "open" or "closed", the issues are open by default
+	ImportFieldState ImportField = "state"
+	// ImportFieldLabels are the comma separated names of labels of the repository or of its organization
+	ImportFieldLabels ImportField = "labels"
+	// ImportFieldAssignees are the comma separated names of users who can be assigned to the issues
+	ImportFieldAssignees ImportField = "assignees"
+	// ImportFieldMilestone is the name of a milestone of the repository
+	ImportFieldMilestone ImportField = "milestone"
+	// ImportFieldDeadline is a date written as RFC 3339 or as 2006-01-02
+	ImportFieldDeadline ImportField = "deadline"
+)
+
+// ImportFields are the fields the columns of an imported CSV file can be mapped to
+var ImportFields = []ImportField{
+	ImportFieldTitle, ImportFieldContent, ImportFieldState, ImportFieldLabels,
+	ImportFieldAssignees, ImportFieldMilestone, ImportFieldDeadline,
+}
+
+// IsValid returns true if the field can be imported
+func (f ImportField) IsValid() bool {
+	for _, field := range ImportFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxImportIssues is the maximum number of issues imported from a file at once
+const MaxImportIssues = 1000
+
+// ImportMapping maps the headers of the columns of an imported CSV file to the fields of the issues, the columns
+// which aren't mapped are ignored
+type ImportMapping map[string]ImportField
+
+// DefaultImportMapping maps the columns named like a field, e.g. the ones of an exported file
+func DefaultImportMapping(headers []string) ImportMapping {
+	mapping := make(ImportMapping, len(headers))
+	for _, header := range headers {
+		switch field := ImportField(strings.ToLower(strings.TrimSpace(header))); field {
+		case "body", "description":
+			mapping[header] = ImportFieldContent
+		default:
+			if field.IsValid() {
+				mapping[header] = field
+			}
+		}
+	}
+	return mapping
+}
+
+// ErrIssueImport represents an invalid imported CSV file, Row is the number of the invalid row as in a spreadsheet
+type ErrIssueImport struct {
+	Row     int
+	Message string
+}
+
+// IsErrIssueImport checks if an error is a ErrIssueImport.
+func IsErrIssueImport(err error) bool {
+	_, ok := err.(ErrIssueImport)
+	return ok
+}
+
+func (err ErrIssueImport) Error() string {
+	if err.Row == 0 {
+		return fmt.Sprintf("invalid import: %s", err.Message)
+	}
+	return fmt.Sprintf("invalid import at row %d: %s", err.Row, err.Message)
+}
+
+// ImportFile is a parsed CSV file to import, its first row being the headers of the columns
+type ImportFile struct {
+	Headers []string
+	Records [][]string
+}
+
+// ReadImportFile parses a CSV file to import, the delimiter is guessed from its content
+func ReadImportFile(r io.Reader) (*ImportFile, error) {
+	reader, err := csv_module.CreateReaderAndDetermineDelimiter(nil, r)
+	if err != nil {
+		return nil, err
+	}
+	reader.FieldsPerRecord = -1
+
+	f := &ImportFile{}
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, ErrIssueImport{Row: row, Message: err.Error()}
+		}
+		if f.Headers == nil {
+			f.Headers = record
+			continue
+		}
+		if len(f.Records) == MaxImportIssues {
+			return nil, ErrIssueImport{Message: fmt.Sprintf("more than %d issues", MaxImportIssues)}
+		}
+		f.Records = append(f.Records, record)
+	}
+	if len(f.Headers) == 0 {
+		return nil, ErrIssueImport{Message: "the file is empty"}
+	}
+	return f, nil
+}
+
+// errInvalidImportValue is an invalid value of a row of an imported file
+type errInvalidImportValue string
+
+func (err errInvalidImportValue) Error() string {
+	return string(err)
+}
+
+func invalidImportValue(format string, args ...interface{}) error {
+	return errInvalidImportValue(fmt.Sprintf(format, args...))
+}
+
+// importedIssue is an issue to create with the values of a row of an imported file
+type importedIssue struct {
+	issue       *issues_model.Issue
+	labelIDs    []int64
+	assigneeIDs []int64
+	isClosed    bool
+}
+
+// importResolver resolves the names of the labels, the assignees and the milestones of a repository once
+type importResolver struct {
+	ctx        context.Context
+	repo       *repo_model.Repository
+	labels     map[string]int64
+	assignees  map[string]int64
+	milestones map[string]int64
+}
+
+func newImportResolver(ctx context.Context, repo *repo_model.Repository) (*importResolver, error) {
+	r := &importResolver{
+		ctx:        ctx,
+		repo:       repo,
+		labels:     make(map[string]int64),
+		assignees:  make(map[string]int64),
+		milestones: make(map[string]int64),
+	}
+	labels, err := issues_model.GetLabelsByRepoID(ctx, repo.ID, "", db.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.GetOwner(ctx); err != nil {
+		return nil, err
+	}
+	if repo.Owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, repo.OwnerID, "", db.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, orgLabels...)
+	}
+	for _, l := range labels {
+		// the labels of the repository win over the ones of its organization with the same name
+		if _, ok := r.labels[strings.ToLower(l.Name)]; !ok {
+			r.labels[strings.ToLower(l.Name)] = l.ID
+		}
+	}
+	return r, nil
+}
+
+func splitImportList(value string) []string {
+	names := make([]string, 0, 2)
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (r *importResolver) labelIDs(value string) ([]int64, error) {
+	ids := make([]int64, 0, 2)
+	for _, name := range splitImportList(value) {
+		id, ok := r.labels[strings.ToLower(name)]
+		if !ok {
+			return nil, invalidImportValue("label does not exist: %s", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (r *importResolver) assigneeIDs(value string) ([]int64, error) {
+	ids := make([]int64, 0, 2)
+	for _, name := range splitImportList(value) {
+		id, ok := r.assignees[strings.ToLower(name)]
+		if !ok {
+			u, err := user_model.GetUserByName(r.ctx, name)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					return nil, invalidImportValue("user does not exist: %s", name)
+				}
+				return nil, err
+			}
+			if valid, err := access_model.CanBeAssigned(r.ctx, u, r.repo, false); err != nil {
+				return nil, err
+			} else if !valid {
+				return nil, invalidImportValue("user can't be assigned: %s", name)
+			}
+			id = u.ID
+			r.assignees[strings.ToLower(name)] = id
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (r *importResolver) milestoneID(name string) (int64, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return 0, nil
+	}
+	if id, ok := r.milestones[name]; ok {
+		return id, nil
+	}
+	m, err := issues_model.GetMilestoneByRepoIDANDName(r.repo.ID, name)
+	if err != nil {
+		if issues_model.IsErrMilestoneNotExist(err) {
+			return 0, invalidImportValue("milestone does not exist: %s", name)
+		}
+		return 0, err
+	}
+	r.milestones[name] = m.ID
+	return m.ID, nil
+}
+
+func parseImportDeadline(value string) (timeutil.TimeStamp, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.ParseInLocation("2006-01-02", value, time.Local); err != nil {
+			return 0, invalidImportValue("invalid deadline: %s", value)
+		}
+		// the deadlines set from the UI are at the end of the day
+		t = t.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+	}
+	return timeutil.TimeStamp(t.Unix()), nil
+}
+
+// prepareImportedIssue converts a row of an imported file to an issue, it returns an error describing the invalid
+// value if the row isn't valid
+func (r *importResolver) prepareImportedIssue(doer *user_model.User, values map[ImportField]string) (*importedIssue, error) {
+	var err error
+	imported := &importedIssue{
+		issue: &issues_model.Issue{
+			RepoID:   r.repo.ID,
+			Repo:     r.repo,
+			Title:    strings.TrimSpace(values[ImportFieldTitle]),
+			PosterID: doer.ID,
+			Poster:   doer,
+			Content:  values[ImportFieldContent],
+		},
+	}
+	if imported.issue.Title == "" {
+		return nil, invalidImportValue("the title is empty")
+	}
+	if len(imported.issue.Title) > 255 {
+		return nil, invalidImportValue("the title is longer than 255 characters")
+	}
+
+	switch state := strings.ToLower(strings.TrimSpace(values[ImportFieldState])); state {
+	case "", "open":
+	case "closed":
+		imported.isClosed = true
+	default:
+		return nil, invalidImportValue("invalid state: %s", state)
+	}
+
+	if imported.labelIDs, err = r.labelIDs(values[ImportFieldLabels]); err != nil {
+		return nil, err
+	}
+	if imported.assigneeIDs, err = r.assigneeIDs(values[ImportFieldAssignees]); err != nil {
+		return nil, err
+	}
+	if imported.issue.MilestoneID, err = r.milestoneID(values[ImportFieldMilestone]); err != nil {
+		return nil, err
+	}
+	if imported.issue.DeadlineUnix, err = parseImportDeadline(values[ImportFieldDeadline]); err != nil {
+		return nil, err
+	}
+	return imported, nil
+}
+
+// ImportIssues creates an issue from each row of an imported CSV file, the columns being mapped to the fields of the
+// issues. All the rows are checked before any issue is created, an ErrIssueImport is returned for the first invalid
+// row.
+func ImportIssues(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, f *ImportFile, mapping ImportMapping) ([]*issues_model.Issue, error) {
+	columns := make(map[ImportField]int, len(mapping))
+	for i, header := range f.Headers {
+		field, ok := mapping[header]
+		if !ok || field == "" {
+			continue
+		}
+		if !field.IsValid() {
+			return nil, ErrIssueImport{Message: fmt.Sprintf("unknown field: %s", field)}
+		}
+		if _, ok := columns[field]; ok {
+			return nil, ErrIssueImport{Message: fmt.Sprintf("several columns are mapped to the field %s", field)}
+		}
+		columns[field] = i
+	}
+	if _, ok := columns[ImportFieldTitle]; !ok {
+		return nil, ErrIssueImport{Message: "no column is mapped to the title"}
+	}
+
+	r, err := newImportResolver(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	prepared := make([]*importedIssue, 0, len(f.Records))
+	for i, record := range f.Records {
+		values := make(map[ImportField]string, len(columns))
+		for field, column := range columns {
+			if column < len(record) {
+				values[field] = record[column]
+			}
+		}
+		imported, err := r.prepareImportedIssue(doer, values)
+		if err != nil {
+			var invalid errInvalidImportValue
+			if errors.As(err, &invalid) {
+				// the headers are the first row
+				return nil, ErrIssueImport{Row: i + 2, Message: invalid.Error()}
+			}
+			return nil, err
+		}
+		prepared = append(prepared, imported)
+	}
+
+	issues := make([]*issues_model.Issue, 0, len(prepared))
+	for _, imported := range prepared {
+		if err := NewIssue(repo, imported.issue, imported.labelIDs, nil, imported.assigneeIDs); err != nil {
+			return issues, err
+		}
+		if imported.isClosed {
+			if err := ChangeStatus(imported.issue, doer, true); err != nil {
+				return issues, err
+			}
+		}
+		issues = append(issues, imported.issue)
+	}
+	return issues, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultImportMapping(t *testing.T) {
+	assert.Equal(t, ImportMapping{
+		"title":     ImportFieldTitle,
+		"state":     ImportFieldState,
+		"assignees": ImportFieldAssignees,
+		"labels":    ImportFieldLabels,
+		"milestone": ImportFieldMilestone,
+		"deadline":  ImportFieldDeadline,
+	}, DefaultImportMapping(exportColumns))
+	assert.Equal(t, ImportMapping{"Body": ImportFieldContent, " Title ": ImportFieldTitle}, DefaultImportMapping([]string{"Body", " Title ", "Votes"}))
+}
+
+func TestImportIssues(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	mapping := ImportMapping{
+		"Summary": ImportFieldTitle,
+		"Body":    ImportFieldContent,
+		"Status":  ImportFieldState,
+		"Tags":    ImportFieldLabels,
+		"Owner":   ImportFieldAssignees,
+		"Release": ImportFieldMilestone,
+		"Due":     ImportFieldDeadline,
+	}
+
+	f, err := ReadImportFile(strings.NewReader(`Summary,Body,Status,Tags,Owner,Release,Due,Votes
+Imported one,"first
+line",closed,"label1, Label2",user2,milestone1,2022-10-01,3
+Imported two,,open,label3,,,,1
+`))
+	assert.NoError(t, err)
+	assert.Len(t, f.Records, 2)
+
+	// the label 3 belongs to another repository, no issue is created
+	count := unittest.GetCount(t, &issues_model.Issue{RepoID: repo.ID})
+	_, err = ImportIssues(db.DefaultContext, repo, doer, f, mapping)
+	assert.EqualError(t, err, "invalid import at row 3: label does not exist: label3")
+	assert.EqualValues(t, count, unittest.GetCount(t, &issues_model.Issue{RepoID: repo.ID}))
+
+	f.Records[1][3] = ""
+	issues, err := ImportIssues(db.DefaultContext, repo, doer, f, mapping)
+	assert.NoError(t, err)
+	if assert.Len(t, issues, 2) {
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issues[0].ID})
+		assert.Equal(t, "Imported one", issue.Title)
+		assert.Equal(t, "first\nline", issue.Content)
+		assert.True(t, issue.IsClosed)
+		assert.EqualValues(t, 1, issue.MilestoneID)
+		assert.NotZero(t, issue.DeadlineUnix)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: issue.ID, LabelID: 1})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: issue.ID, LabelID: 2})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: issue.ID, AssigneeID: 2})
+
+		issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issues[1].ID})
+		assert.Equal(t, "Imported two", issue.Title)
+		assert.False(t, issue.IsClosed)
+	}
+
+	_, err = ImportIssues(db.DefaultContext, repo, doer, f, ImportMapping{"Body": ImportFieldContent})
+	assert.True(t, IsErrIssueImport(err))
+	_, err = ImportIssues(db.DefaultContext, repo, doer, f, ImportMapping{"Summary": ImportFieldTitle, "Body": ImportFieldTitle})
+	assert.True(t, IsErrIssueImport(err))
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	perm_model "code.gitea.io/gitea/models/perm"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+var (
+	// ErrBlockedByOwner is returned when the owner of the repository has blocked the user
+	ErrBlockedByOwner = errors.New("user is blocked by the repository owner")
+	// ErrInteractionLimited is returned when an interaction limit of the repository excludes the user
+	ErrInteractionLimited = errors.New("interactions with the repository are limited")
+)
+
+// existingUserAge is the age an account needs to interact while the existing users limit applies
+const existingUserAge = 24 * time.Hour
+
+func isCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, perm access_model.Permission) (bool, error) {
+	if perm.IsAdmin() || perm.CanAccessAny(perm_model.AccessModeWrite, unit.TypeCode, unit.TypeIssues, unit.TypePullRequests) {
+		return true, nil
+	}
+	return repo_model.IsCollaborator(ctx, repo.ID, doer.ID)
+}
+
+// CheckInteraction returns an error if the doer may not open issues or pull requests, comment or react in the repository
+// because the owner of the repository has blocked them or because of an interaction limit.
+// Site administrators and collaborators are never restricted.
+func CheckInteraction(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, perm access_model.Permission) error {
+	if doer == nil || doer.IsAdmin || perm.IsAdmin() {
+		return nil
+	}
+
+	blocked, err := user_model.IsBlocked(ctx, repo.OwnerID, doer.ID)
+	if err != nil {
+		return err
+	} else if blocked {
+		return ErrBlockedByOwner
+	}
+
+	limit, err := repo_model.GetEffectiveInteractionLimit(ctx, repo)
+	if err != nil || limit == nil {
+		return err
+	}
+	if collaborator, err := isCollaborator(ctx, doer, repo, perm); err != nil || collaborator {
+		return err
+	}
+
+	switch limit.Type {
+	case repo_model.InteractionLimitExistingUsers:
+		if doer.CreatedUnix < timeutil.TimeStamp(time.Now().Add(-existingUserAge).Unix()) {
+			return nil
+		}
+	case repo_model.InteractionLimitContributorsOnly:
+		if contributed, err := issues_model.HasMergedPullRequest(ctx, repo.ID, doer.ID); err != nil || contributed {
+			return err
+		}
+	}
+	return ErrInteractionLimited
+}
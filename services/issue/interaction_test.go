@@ -0,0 +1,48 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckInteraction(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	perm, err := access_model.GetUserRepoPermission(db.DefaultContext, repo, doer)
+	assert.NoError(t, err)
+
+	assert.NoError(t, CheckInteraction(db.DefaultContext, doer, repo, perm))
+
+	assert.NoError(t, user_model.BlockUser(db.DefaultContext, repo.OwnerID, doer.ID))
+	assert.ErrorIs(t, CheckInteraction(db.DefaultContext, doer, repo, perm), ErrBlockedByOwner)
+	assert.NoError(t, user_model.UnblockUser(db.DefaultContext, repo.OwnerID, doer.ID))
+
+	// the account of user 4 is old enough but they never had a pull request merged
+	_, err = repo_model.SetInteractionLimit(db.DefaultContext, repo.OwnerID, 0, repo_model.InteractionLimitExistingUsers, "one_day")
+	assert.NoError(t, err)
+	assert.NoError(t, CheckInteraction(db.DefaultContext, doer, repo, perm))
+
+	// the limit of the repository takes precedence over the one of its owner
+	_, err = repo_model.SetInteractionLimit(db.DefaultContext, 0, repo.ID, repo_model.InteractionLimitContributorsOnly, "three_days")
+	assert.NoError(t, err)
+	assert.ErrorIs(t, CheckInteraction(db.DefaultContext, doer, repo, perm), ErrInteractionLimited)
+
+	// administrators are never restricted
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	assert.NoError(t, CheckInteraction(db.DefaultContext, admin, repo, access_model.Permission{}))
+
+	assert.NoError(t, repo_model.RemoveInteractionLimit(db.DefaultContext, 0, repo.ID))
+	assert.NoError(t, CheckInteraction(db.DefaultContext, doer, repo, perm))
+}
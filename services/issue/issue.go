@@ -11,6 +11,7 @@ import (
 	admin_model "code.gitea.io/gitea/models/admin"
 	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	project_model "code.gitea.io/gitea/models/project"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -19,6 +20,7 @@ import (
 	"code.gitea.io/gitea/modules/notification"
 	"code.gitea.io/gitea/modules/storage"
 	"code.gitea.io/gitea/modules/util"
+	spam_service "code.gitea.io/gitea/services/spam"
 )
 
 // NewIssue creates new issue with labels for repository.
@@ -27,6 +29,11 @@ func NewIssue(repo *repo_model.Repository, issue *issues_model.Issue, labelIDs [
 		return err
 	}
 
+	// nothing is announced about the issues held for review
+	if held, err := spam_service.CheckIssue(db.DefaultContext, issue); err != nil || held {
+		return err
+	}
+
 	for _, assigneeID := range assigneeIDs {
 		if err := AddAssigneeIfNotAssigned(issue, issue.Poster, assigneeID); err != nil {
 			return err
@@ -242,21 +249,34 @@ func deleteIssue(issue *issues_model.Issue) error {
 		&issues_model.ContentHistory{},
 		&issues_model.Comment{},
 		&issues_model.IssueLabel{},
+		&issues_model.IssueFieldValue{},
+		&issues_model.IssueComponent{},
 		&issues_model.IssueDependency{},
+		&issues_model.SubIssue{},
 		&issues_model.IssueAssignees{},
 		&issues_model.IssueUser{},
 		&activities_model.Notification{},
 		&issues_model.Reaction{},
 		&issues_model.IssueWatch{},
+		&issues_model.IssueVote{},
 		&issues_model.Stopwatch{},
 		&issues_model.TrackedTime{},
 		&project_model.ProjectIssue{},
 		&repo_model.Attachment{},
 		&issues_model.PullRequest{},
+		&issues_model.PinnedIssue{},
+		&moderation_model.Report{},
 	); err != nil {
 		return err
 	}
 
+	// the sub-issues of this issue have no parent anymore
+	if _, err := db.DeleteByBean(ctx, &issues_model.SubIssue{
+		ParentID: issue.ID,
+	}); err != nil {
+		return err
+	}
+
 	// References to this issue in other issues
 	if _, err := db.DeleteByBean(ctx, &issues_model.Comment{
 		RefIssueID: issue.ID,
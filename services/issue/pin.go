@@ -0,0 +1,33 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	user_model "code.gitea.io/gitea/models/user"
+)
+
+// GetVisibleOrgPinnedIssues returns the issues and pull requests pinned to the organization which the doer can read
+// and which aren't hidden from them
+func GetVisibleOrgPinnedIssues(ctx context.Context, org, doer *user_model.User) (issues_model.PinnedIssueList, error) {
+	pins, err := issues_model.GetOrgPinnedIssues(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	visible := make(issues_model.PinnedIssueList, 0, len(pins))
+	for _, pin := range pins {
+		perm, err := access_model.GetUserRepoPermission(ctx, pin.Issue.Repo, doer)
+		if err != nil {
+			return nil, err
+		}
+		if perm.CanReadIssuesOrPulls(pin.Issue.IsPull) {
+			visible = append(visible, pin)
+		}
+	}
+	return visible.VisibleTo(ctx, doer)
+}
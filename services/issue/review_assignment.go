@@ -0,0 +1,95 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"context"
+	"sort"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+)
+
+// ReviewerWorkload represents the open review requests of a team member
+type ReviewerWorkload struct {
+	User         *user_model.User
+	OpenRequests int64
+	// Busy is true if the member has set a busy status
+	Busy bool
+}
+
+// Available returns true if the member can be assigned another review
+func (w *ReviewerWorkload) Available() bool {
+	if !w.User.IsActive || w.User.ProhibitLogin || w.User.IsOutOfOffice() || w.Busy {
+		return false
+	}
+	return w.User.ReviewCapacity <= 0 || w.OpenRequests < int64(w.User.ReviewCapacity)
+}
+
+// GetTeamReviewerWorkloads returns the workload of every member of the team and its descendants, least loaded first
+func GetTeamReviewerWorkloads(ctx context.Context, team *organization.Team) ([]*ReviewerWorkload, error) {
+	members, err := organization.GetTeamMembers(ctx, &organization.SearchMembersOptions{
+		TeamID:          team.ID,
+		OrgID:           team.OrgID,
+		IncludeSubteams: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.ID)
+	}
+	counts, err := issues_model.CountPendingReviewRequests(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := user_model.GetUserStatuses(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := make([]*ReviewerWorkload, 0, len(members))
+	for _, member := range members {
+		workloads = append(workloads, &ReviewerWorkload{
+			User:         member,
+			OpenRequests: counts[member.ID],
+			Busy:         statuses[member.ID] != nil && statuses[member.ID].Busy,
+		})
+	}
+	sort.SliceStable(workloads, func(i, j int) bool {
+		return workloads[i].OpenRequests < workloads[j].OpenRequests
+	})
+	return workloads, nil
+}
+
+// PickLeastLoadedReviewer returns the available team member with the fewest open review requests
+// who is neither the poster of the pull request nor already one of its reviewers, or nil if there is none
+func PickLeastLoadedReviewer(ctx context.Context, team *organization.Team, issue *issues_model.Issue) (*user_model.User, error) {
+	workloads, err := GetTeamReviewerWorkloads(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := issues_model.GetReviewersByIssueID(issue.ID)
+	if err != nil {
+		return nil, err
+	}
+	excluded := map[int64]bool{issue.PosterID: true}
+	for _, review := range reviews {
+		if review.ReviewerID != 0 {
+			excluded[review.ReviewerID] = true
+		}
+	}
+
+	for _, workload := range workloads {
+		if !excluded[workload.User.ID] && workload.Available() {
+			return workload.User, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewerWorkloadBusy(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	team := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 2})
+
+	assert.NoError(t, user_model.SetUserStatus(db.DefaultContext, &user_model.Status{UID: 2, Message: "Focusing", Busy: true}))
+
+	workloads, err := GetTeamReviewerWorkloads(db.DefaultContext, team)
+	assert.NoError(t, err)
+	assert.Len(t, workloads, 2)
+	for _, workload := range workloads {
+		assert.Equal(t, workload.User.ID == 2, workload.Busy)
+		assert.Equal(t, workload.User.ID != 2, workload.Available())
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	issue_template "code.gitea.io/gitea/modules/issue/template"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// scheduledIssuesBatchSize is the maximum number of recurring issues created by a run of CreateScheduledIssues,
+// the remaining ones are created by the next runs
+const scheduledIssuesBatchSize = 100
+
+// CreateScheduledIssues creates the issues of the recurring issues of the repositories whose next run has come.
+// A run which has been missed, e.g. because the instance was down, creates a single issue.
+func CreateScheduledIssues(ctx context.Context) error {
+	schedules, err := issues_model.FindDueIssueSchedules(ctx, timeutil.TimeStampNow(), scheduledIssuesBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, s := range schedules {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("aborted scheduled issues before schedule %d", s.ID)
+		default:
+		}
+
+		if _, err := CreateScheduledIssue(ctx, s); err != nil {
+			log.Error("CreateScheduledIssue[%d]: %v", s.ID, err)
+		}
+	}
+	return nil
+}
+
+// CreateScheduledIssue creates the issue of a recurring issue and schedules its next run, it returns nil if the
+// recurring issue can't run anymore and has been deactivated
+func CreateScheduledIssue(ctx context.Context, s *issues_model.IssueSchedule) (*issues_model.Issue, error) {
+	runAt := time.Now()
+
+	repo, creator, err := loadScheduleRepoAndCreator(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil || creator == nil {
+		log.Info("Scheduled issues: deactivated schedule %d of repository %d", s.ID, s.RepoID)
+		return nil, issues_model.DeactivateIssueSchedule(ctx, s)
+	}
+
+	content, err := scheduledIssueContent(ctx, repo, s)
+	if err != nil {
+		return nil, err
+	}
+
+	assigneeIDs := make([]int64, 0, len(s.AssigneeIDs))
+	for _, id := range s.AssigneeIDs {
+		assignee, err := user_model.GetUserByIDCtx(ctx, id)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		// the assignees who have lost their access to the repository are skipped
+		if valid, err := access_model.CanBeAssigned(ctx, assignee, repo, false); err != nil {
+			return nil, err
+		} else if valid {
+			assigneeIDs = append(assigneeIDs, id)
+		}
+	}
+
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		Title:    s.Title,
+		PosterID: creator.ID,
+		Poster:   creator,
+		Content:  content,
+	}
+	if err := NewIssue(repo, issue, s.LabelIDs, nil, assigneeIDs); err != nil {
+		return nil, err
+	}
+	log.Info("Scheduled issues: created %s#%d from schedule %d", repo.FullName(), issue.Index, s.ID)
+	return issue, issues_model.UpdateIssueScheduleRun(ctx, s, issue.ID, runAt)
+}
+
+// loadScheduleRepoAndCreator returns the repository and the creator of a recurring issue, or nil if the recurring
+// issue can't run anymore: the repository is archived or has no issues, or the creator can't read them anymore
+func loadScheduleRepoAndCreator(ctx context.Context, s *issues_model.IssueSchedule) (*repo_model.Repository, *user_model.User, error) {
+	repo, err := repo_model.GetRepositoryByIDCtx(ctx, s.RepoID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if repo.IsArchived || !repo.UnitEnabledCtx(ctx, unit.TypeIssues) {
+		return nil, nil, nil
+	}
+
+	creator, err := user_model.GetUserByIDCtx(ctx, s.CreatorID)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	if !creator.IsActive || creator.ProhibitLogin {
+		return nil, nil, nil
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, creator)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !perm.CanRead(unit.TypeIssues) {
+		return nil, nil, nil
+	}
+	return repo, creator, nil
+}
+
+// scheduledIssueContent returns the content of the issue of a recurring issue, the one of its template if it has one
+// which still exists in the default branch of the repository
+func scheduledIssueContent(ctx context.Context, repo *repo_model.Repository, s *issues_model.IssueSchedule) (string, error) {
+	if s.TemplateFile == "" || repo.IsEmpty {
+		return s.Content, nil
+	}
+
+	gitRepo, err := git.OpenRepository(ctx, repo.RepoPath())
+	if err != nil {
+		return "", err
+	}
+	defer gitRepo.Close()
+
+	template, err := issue_template.UnmarshalFromRepo(gitRepo, repo.DefaultBranch, s.TemplateFile)
+	if err != nil {
+		log.Warn("Scheduled issues: template %q of schedule %d can't be used: %v", s.TemplateFile, s.ID, err)
+		return s.Content, nil
+	}
+	if template.Type() == api.IssueTemplateTypeYaml {
+		return issue_template.RenderToMarkdown(template, url.Values{}), nil
+	}
+	return template.Content, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateScheduledIssues(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// the schedule 2 is inactive
+	assert.NoError(t, CreateScheduledIssues(db.DefaultContext))
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, Title: "Release checklist"})
+	assert.EqualValues(t, 2, issue.PosterID)
+	assert.Equal(t, "- [ ] tag the release", issue.Content)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: issue.ID, LabelID: 1})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: issue.ID, AssigneeID: 2})
+	unittest.AssertNotExistsBean(t, &issues_model.Issue{RepoID: 1, Title: "Monthly review"})
+
+	s := unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSchedule{ID: 1})
+	assert.Equal(t, issue.ID, s.LastIssueID)
+	assert.Greater(t, int64(s.NextRunUnix), time.Now().Unix())
+
+	// a run isn't repeated until the next one has come
+	assert.NoError(t, CreateScheduledIssues(db.DefaultContext))
+	unittest.AssertCount(t, &issues_model.Issue{RepoID: 1, Title: "Release checklist"}, 1)
+}
+
+func TestCreateScheduledIssue_Deactivated(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// the creator of the schedule doesn't exist anymore
+	s := &issues_model.IssueSchedule{RepoID: 1, CreatorID: unittest.NonexistentID, Title: "orphan", Schedule: "@daily", IsActive: true}
+	assert.NoError(t, issues_model.CreateIssueSchedule(db.DefaultContext, s))
+
+	issue, err := CreateScheduledIssue(db.DefaultContext, s)
+	assert.NoError(t, err)
+	assert.Nil(t, issue)
+	s = unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSchedule{ID: s.ID})
+	assert.False(t, s.IsActive)
+}
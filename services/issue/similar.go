@@ -0,0 +1,51 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"context"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+)
+
+// FindSimilarIssues returns the open and closed issues of a repository which are similar to the title of a new issue,
+// the most similar first, to surface the possible duplicates before the issue is created. The confidential issues the
+// doer can't see are left out.
+func FindSimilarIssues(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, title string, limit int) (issues_model.IssueList, error) {
+	ids, err := issue_indexer.SearchSimilarIssues(ctx, []int64{repo.ID}, title)
+	if err != nil || len(ids) == 0 {
+		return issues_model.IssueList{}, err
+	}
+
+	found, err := issues_model.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*issues_model.Issue, len(found))
+	for _, issue := range found {
+		byID[issue.ID] = issue
+	}
+
+	issues := make(issues_model.IssueList, 0, limit)
+	for _, id := range ids {
+		if len(issues) == limit {
+			break
+		}
+		issue := byID[id]
+		if issue == nil || issue.IsPull {
+			continue
+		}
+		issue.Repo = repo
+		if hidden, err := issue.IsHiddenFrom(ctx, doer); err != nil {
+			return nil, err
+		} else if !hidden {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
@@ -0,0 +1,279 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/notification"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/comments"
+)
+
+// staleLabelColor is the color of the stale label when it is created by the stale policy
+const staleLabelColor = "#ededed"
+
+func daysAgo(days int) timeutil.TimeStamp {
+	return timeutil.TimeStamp(time.Now().Add(-time.Duration(days) * 24 * time.Hour).Unix())
+}
+
+// ProcessStalePolicies applies the stale policies of the repositories: the open issues without activity are
+// labelled as stale, the stale issues with new activity are unlabelled and the ones which stayed inactive are closed.
+// Every action is commented by the ghost user so the participants are notified and the issue keeps a trace of it.
+func ProcessStalePolicies(ctx context.Context) error {
+	units, err := repo_model.FindRepoUnitsByType(ctx, unit.TypeIssues)
+	if err != nil {
+		return err
+	}
+	for _, u := range units {
+		cfg := u.IssuesConfig()
+		if cfg.StaleDays <= 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("aborted stale policies before repository %d", u.RepoID)
+		default:
+		}
+
+		repo, err := repo_model.GetRepositoryByIDCtx(ctx, u.RepoID)
+		if err != nil {
+			log.Error("GetRepositoryByID[%d]: %v", u.RepoID, err)
+			continue
+		}
+		if repo.IsArchived {
+			continue
+		}
+		if err := processStalePolicy(ctx, repo, cfg); err != nil {
+			log.Error("processStalePolicy[%s]: %v", repo.FullName(), err)
+		}
+	}
+	return nil
+}
+
+// stalePolicy is the stale policy of a repository resolved for a run
+type stalePolicy struct {
+	cfg            *repo_model.IssuesConfig
+	repo           *repo_model.Repository
+	doer           *user_model.User
+	label          *issues_model.Label
+	exemptLabelIDs map[int64]bool
+}
+
+func processStalePolicy(ctx context.Context, repo *repo_model.Repository, cfg *repo_model.IssuesConfig) error {
+	isPull := util.OptionalBoolFalse
+	if cfg.StaleIncludePulls {
+		isPull = util.OptionalBoolNone
+	}
+	// the issues mirrored from an external issue tracker are read-only, only the pull requests are left
+	isMirror, err := issues_model.IsIssueMirror(ctx, repo.ID)
+	if err != nil {
+		return err
+	} else if isMirror {
+		if !cfg.StaleIncludePulls {
+			return nil
+		}
+		isPull = util.OptionalBoolTrue
+	}
+
+	label, err := getOrCreateStaleLabel(ctx, repo, cfg.GetStaleLabel())
+	if err != nil {
+		return err
+	}
+	p := &stalePolicy{
+		cfg:            cfg,
+		repo:           repo,
+		doer:           user_model.NewGhostUser(),
+		label:          label,
+		exemptLabelIDs: make(map[int64]bool),
+	}
+	for _, name := range cfg.GetStaleExemptLabels() {
+		l, err := issues_model.GetLabelInRepoByName(ctx, repo.ID, name)
+		if err != nil {
+			if issues_model.IsErrRepoLabelNotExist(err) {
+				continue
+			}
+			return err
+		}
+		p.exemptLabelIDs[l.ID] = true
+	}
+
+	// the stale issues are handled first so the ones marked during this run aren't closed right away
+	staleIssues, err := issues_model.Issues(&issues_model.IssuesOptions{
+		RepoID:   repo.ID,
+		IsClosed: util.OptionalBoolFalse,
+		IsPull:   isPull,
+		LabelIDs: []int64{label.ID},
+	})
+	if err != nil {
+		return err
+	}
+	for _, issue := range staleIssues {
+		if err := p.processStaleIssue(ctx, issue); err != nil {
+			log.Error("processStaleIssue[%d]: %v", issue.ID, err)
+		}
+	}
+
+	inactiveIssues, err := issues_model.Issues(&issues_model.IssuesOptions{
+		RepoID:            repo.ID,
+		IsClosed:          util.OptionalBoolFalse,
+		IsPull:            isPull,
+		UpdatedBeforeUnix: int64(daysAgo(cfg.StaleDays)),
+	})
+	if err != nil {
+		return err
+	}
+	for _, issue := range inactiveIssues {
+		if err := p.markStale(ctx, issue); err != nil {
+			log.Error("markStale[%d]: %v", issue.ID, err)
+		}
+	}
+	return nil
+}
+
+func getOrCreateStaleLabel(ctx context.Context, repo *repo_model.Repository, name string) (*issues_model.Label, error) {
+	label, err := issues_model.GetLabelInRepoByName(ctx, repo.ID, name)
+	if err == nil || !issues_model.IsErrRepoLabelNotExist(err) {
+		return label, err
+	}
+	label = &issues_model.Label{
+		RepoID:      repo.ID,
+		Name:        name,
+		Description: "Issues and pull requests without recent activity",
+		Color:       staleLabelColor,
+	}
+	return label, issues_model.NewLabel(ctx, label)
+}
+
+// isExempt returns whether an issue is exempted from the stale policy by its labels or its milestone
+func (p *stalePolicy) isExempt(ctx context.Context, issue *issues_model.Issue) (bool, error) {
+	if p.cfg.StaleExemptMilestones && issue.MilestoneID > 0 {
+		return true, nil
+	}
+	if err := issue.LoadLabels(ctx); err != nil {
+		return false, err
+	}
+	for _, l := range issue.Labels {
+		if p.exemptLabelIDs[l.ID] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *stalePolicy) issueType(issue *issues_model.Issue) string {
+	if issue.IsPull {
+		return "pull request"
+	}
+	return "issue"
+}
+
+func (p *stalePolicy) markStale(ctx context.Context, issue *issues_model.Issue) error {
+	if exempt, err := p.isExempt(ctx, issue); err != nil || exempt {
+		return err
+	}
+	for _, l := range issue.Labels {
+		if l.ID == p.label.ID {
+			return nil
+		}
+	}
+
+	issue.Repo = p.repo
+	if err := AddLabel(issue, p.doer, p.label); err != nil {
+		return err
+	}
+	message := fmt.Sprintf("This %s has been automatically marked as stale because it has not had any activity in the last %d days.",
+		p.issueType(issue), p.cfg.StaleDays)
+	if p.cfg.StaleCloseDays > 0 {
+		message += fmt.Sprintf(" It will be closed in %d days if no further activity occurs.", p.cfg.StaleCloseDays)
+	}
+	if _, err := comments.CreateIssueComment(p.doer, p.repo, issue, message, nil); err != nil {
+		return err
+	}
+	log.Info("Stale policy: marked %s#%d as stale", p.repo.FullName(), issue.Index)
+	return nil
+}
+
+// staleSince returns when the stale label was last added to an issue, or its creation time if that is unknown
+func (p *stalePolicy) staleSince(ctx context.Context, issue *issues_model.Issue) (timeutil.TimeStamp, error) {
+	labelComments, err := issues_model.FindComments(ctx, &issues_model.FindCommentsOptions{
+		IssueID: issue.ID,
+		Type:    issues_model.CommentTypeLabel,
+	})
+	if err != nil {
+		return 0, err
+	}
+	since := issue.CreatedUnix
+	for _, c := range labelComments {
+		if c.LabelID == p.label.ID && c.Content == "1" && c.CreatedUnix > since {
+			since = c.CreatedUnix
+		}
+	}
+	return since, nil
+}
+
+// hasActivitySince returns whether anyone but the stale policy commented on or changed an issue since the given time
+func (p *stalePolicy) hasActivitySince(ctx context.Context, issue *issues_model.Issue, since timeutil.TimeStamp) (bool, error) {
+	activities, err := issues_model.FindComments(ctx, &issues_model.FindCommentsOptions{
+		IssueID: issue.ID,
+		Since:   int64(since),
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, c := range activities {
+		if c.PosterID != p.doer.ID && c.CreatedUnix > since {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *stalePolicy) processStaleIssue(ctx context.Context, issue *issues_model.Issue) error {
+	if exempt, err := p.isExempt(ctx, issue); err != nil || exempt {
+		return err
+	}
+	since, err := p.staleSince(ctx, issue)
+	if err != nil {
+		return err
+	}
+	issue.Repo = p.repo
+
+	active, err := p.hasActivitySince(ctx, issue, since)
+	if err != nil {
+		return err
+	}
+	if active {
+		if err := issues_model.DeleteIssueLabel(ctx, issue, p.label, p.doer); err != nil {
+			return err
+		}
+		notification.NotifyIssueChangeLabels(p.doer, issue, nil, []*issues_model.Label{p.label})
+		log.Info("Stale policy: %s#%d is not stale anymore", p.repo.FullName(), issue.Index)
+		return nil
+	}
+
+	if p.cfg.StaleCloseDays <= 0 || since >= daysAgo(p.cfg.StaleCloseDays) {
+		return nil
+	}
+	message := fmt.Sprintf("This %s has been automatically closed because it has been stale for %d days with no activity.",
+		p.issueType(issue), p.cfg.StaleCloseDays)
+	if _, err := comments.CreateIssueComment(p.doer, p.repo, issue, message, nil); err != nil {
+		return err
+	}
+	if err := ChangeStatus(issue, p.doer, true); err != nil {
+		return err
+	}
+	log.Info("Stale policy: closed stale %s#%d", p.repo.FullName(), issue.Index)
+	return nil
+}
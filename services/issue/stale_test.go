@@ -0,0 +1,86 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package issue
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/comments"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessStalePolicies(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	issuesUnit, err := repo.GetUnit(unit.TypeIssues)
+	assert.NoError(t, err)
+	issuesUnit.Config = &repo_model.IssuesConfig{StalePolicy: repo_model.StalePolicy{
+		StaleDays:             30,
+		StaleCloseDays:        7,
+		StaleExemptLabels:     "label2, unknown",
+		StaleExemptMilestones: true,
+		StaleIncludePulls:     true,
+	}}
+	assert.NoError(t, repo_model.UpdateRepoUnit(issuesUnit))
+
+	// the open issue 1 and pull request 11 are inactive, the pull requests 2 and 3 have a milestone
+	assert.NoError(t, ProcessStalePolicies(db.DefaultContext))
+	label := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{RepoID: repo.ID, Name: repo_model.DefaultStaleLabel})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: label.ID})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: 11, LabelID: label.ID})
+	unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 2, LabelID: label.ID})
+	unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 3, LabelID: label.ID})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 1, PosterID: -1, Type: issues_model.CommentTypeComment})
+
+	// both stayed stale for more than a week, but pull request 11 has a new comment since
+	_, err = db.GetEngine(db.DefaultContext).Exec("UPDATE comment SET created_unix = ?, updated_unix = ? WHERE poster_id = ?", daysAgo(8), daysAgo(8), -1)
+	assert.NoError(t, err)
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	pull := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 11})
+	_, err = comments.CreateIssueComment(doer, repo, pull, "still relevant", nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, ProcessStalePolicies(db.DefaultContext))
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	assert.True(t, issue.IsClosed)
+	pull = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 11})
+	assert.False(t, pull.IsClosed)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 11, LabelID: label.ID})
+}
+
+func TestProcessStalePoliciesIssueMirror(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	assert.NoError(t, issues_model.SaveIssueMirror(db.DefaultContext, &issues_model.IssueMirror{
+		RepoID:        repo.ID,
+		DoerID:        2,
+		ServiceType:   structs.GithubService,
+		RemoteAddress: "https://github.com/owner/repo/",
+	}))
+	issuesUnit, err := repo.GetUnit(unit.TypeIssues)
+	assert.NoError(t, err)
+	issuesUnit.Config = &repo_model.IssuesConfig{StalePolicy: repo_model.StalePolicy{StaleDays: 30}}
+	assert.NoError(t, repo_model.UpdateRepoUnit(issuesUnit))
+
+	// the mirrored issues are read-only
+	assert.NoError(t, ProcessStalePolicies(db.DefaultContext))
+	unittest.AssertNotExistsBean(t, &issues_model.Label{RepoID: repo.ID, Name: repo_model.DefaultStaleLabel})
+
+	// but the pull requests aren't mirrored
+	issuesUnit.Config = &repo_model.IssuesConfig{StalePolicy: repo_model.StalePolicy{StaleDays: 30, StaleIncludePulls: true}}
+	assert.NoError(t, repo_model.UpdateRepoUnit(issuesUnit))
+	assert.NoError(t, ProcessStalePolicies(db.DefaultContext))
+	label := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{RepoID: repo.ID, Name: repo_model.DefaultStaleLabel})
+	unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: label.ID})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: 11, LabelID: label.ID})
+}
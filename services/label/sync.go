@@ -0,0 +1,171 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package label
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	repo_module "code.gitea.io/gitea/modules/repository"
+)
+
+// SetLabel is a label of a label set
+type SetLabel struct {
+	// SourceID is the organization label this label comes from, 0 for the labels of a label template
+	SourceID    int64
+	Name        string
+	Color       string
+	Description string
+}
+
+// GetOrgLabelSet returns the labels of an organization as a label set
+func GetOrgLabelSet(ctx context.Context, orgID int64) ([]*SetLabel, error) {
+	labels, err := issues_model.GetLabelsByOrgID(ctx, orgID, "", db.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	set := make([]*SetLabel, 0, len(labels))
+	for _, label := range labels {
+		set = append(set, &SetLabel{
+			SourceID:    label.ID,
+			Name:        label.Name,
+			Color:       label.Color,
+			Description: label.Description,
+		})
+	}
+	return set, nil
+}
+
+// GetTemplateLabelSet returns the labels of a label template of the instance as a label set
+func GetTemplateLabelSet(name string) ([]*SetLabel, error) {
+	list, err := repo_module.GetLabelTemplateFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make([]*SetLabel, 0, len(list))
+	for _, label := range list {
+		set = append(set, &SetLabel{
+			Name:        label[0],
+			Color:       label[1],
+			Description: label[2],
+		})
+	}
+	return set, nil
+}
+
+// LabelChange is a change of a repository label needed to synchronize it with a label set
+type LabelChange struct {
+	// Label is the label after the change, its ID is 0 if the label is created by a dry run
+	Label *issues_model.Label
+	// OldName is the name of the label before the change, empty for a created label
+	OldName string
+	IsNew   bool
+}
+
+// SyncResult is the result of the synchronization of the labels of a repository with a label set
+type SyncResult struct {
+	Repo    *repo_model.Repository
+	Changes []*LabelChange
+}
+
+// HasDrift returns whether the labels of the repository drifted from the label set
+func (r *SyncResult) HasDrift() bool {
+	return len(r.Changes) > 0
+}
+
+// SyncLabels synchronizes the labels of a repository with a label set: the missing labels are created,
+// the labels which were renamed or recolored in the set are updated, and the other labels of the repository
+// are left untouched. With dryRun, the changes are only detected and not applied.
+func SyncLabels(ctx context.Context, repo *repo_model.Repository, set []*SetLabel, dryRun bool) (*SyncResult, error) {
+	result := &SyncResult{Repo: repo}
+
+	labels, err := issues_model.GetLabelsByRepoID(ctx, repo.ID, "", db.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make(map[int64]bool, len(labels))
+	for _, setLabel := range set {
+		color := normalizeColor(setLabel.Color)
+
+		// a label already synchronized is found by its source, which allows renaming it, others by name
+		label := findLabel(labels, matched, func(l *issues_model.Label) bool {
+			return setLabel.SourceID > 0 && l.SyncedFromID == setLabel.SourceID
+		})
+		if label == nil {
+			label = findLabel(labels, matched, func(l *issues_model.Label) bool {
+				return strings.EqualFold(l.Name, setLabel.Name)
+			})
+		}
+
+		if label == nil {
+			result.Changes = append(result.Changes, &LabelChange{
+				Label: &issues_model.Label{
+					RepoID:       repo.ID,
+					Name:         setLabel.Name,
+					Color:        color,
+					Description:  setLabel.Description,
+					SyncedFromID: setLabel.SourceID,
+				},
+				IsNew: true,
+			})
+			continue
+		}
+		matched[label.ID] = true
+
+		if label.Name == setLabel.Name && label.Color == color && label.Description == setLabel.Description && label.SyncedFromID == setLabel.SourceID {
+			continue
+		}
+		change := &LabelChange{Label: label, OldName: label.Name}
+		label.Name = setLabel.Name
+		label.Color = color
+		label.Description = setLabel.Description
+		label.SyncedFromID = setLabel.SourceID
+		result.Changes = append(result.Changes, change)
+	}
+
+	if dryRun || !result.HasDrift() {
+		return result, nil
+	}
+
+	return result, db.WithTx(func(ctx context.Context) error {
+		for _, change := range result.Changes {
+			if change.IsNew {
+				if err := issues_model.NewLabel(ctx, change.Label); err != nil {
+					return err
+				}
+			} else if err := issues_model.UpdateSyncedLabel(ctx, change.Label); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, ctx)
+}
+
+func findLabel(labels []*issues_model.Label, matched map[int64]bool, cond func(*issues_model.Label) bool) *issues_model.Label {
+	for _, label := range labels {
+		if !matched[label.ID] && cond(label) {
+			return label
+		}
+	}
+	return nil
+}
+
+// normalizeColor returns the color the way it's stored for a label, e.g. "#00aabb"
+func normalizeColor(color string) string {
+	color = strings.ToLower(strings.TrimSpace(color))
+	if !strings.HasPrefix(color, "#") {
+		color = "#" + color
+	}
+	if len(color) == 4 {
+		color = string([]byte{'#', color[1], color[1], color[2], color[2], color[3], color[3]})
+	}
+	return color
+}
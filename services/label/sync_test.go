@@ -0,0 +1,86 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package label
+
+import (
+	"path/filepath"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m, &unittest.TestOptions{
+		GiteaRootPath: filepath.Join("..", ".."),
+	})
+}
+
+func TestSyncLabels(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+	set, err := GetOrgLabelSet(db.DefaultContext, repo.OwnerID)
+	assert.NoError(t, err)
+	assert.Len(t, set, 2)
+
+	// the drift is detected without changing anything
+	result, err := SyncLabels(db.DefaultContext, repo, set, true)
+	assert.NoError(t, err)
+	assert.True(t, result.HasDrift())
+	if assert.Len(t, result.Changes, 2) {
+		assert.True(t, result.Changes[0].IsNew)
+		assert.Equal(t, "orglabel3", result.Changes[0].Label.Name)
+	}
+	unittest.AssertNotExistsBean(t, &issues_model.Label{RepoID: repo.ID, Name: "orglabel3"})
+
+	result, err = SyncLabels(db.DefaultContext, repo, set, false)
+	assert.NoError(t, err)
+	assert.Len(t, result.Changes, 2)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Label{RepoID: repo.ID, Name: "orglabel3", Color: "#abcdef", SyncedFromID: 3})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Label{RepoID: repo.ID, Name: "orglabel4", Color: "#000000", SyncedFromID: 4})
+
+	// the labels renamed and recolored in the set are updated
+	set[0].Name = "renamed"
+	set[1].Color = "#FFF"
+	result, err = SyncLabels(db.DefaultContext, repo, set, false)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Changes, 2) {
+		assert.False(t, result.Changes[0].IsNew)
+		assert.Equal(t, "orglabel3", result.Changes[0].OldName)
+	}
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Label{RepoID: repo.ID, Name: "renamed", SyncedFromID: 3})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Label{RepoID: repo.ID, Name: "orglabel4", Color: "#ffffff", SyncedFromID: 4})
+	unittest.AssertNotExistsBean(t, &issues_model.Label{RepoID: repo.ID, Name: "orglabel3"})
+
+	result, err = SyncLabels(db.DefaultContext, repo, set, false)
+	assert.NoError(t, err)
+	assert.False(t, result.HasDrift())
+}
+
+func TestSyncLabelsByName(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	// the existing labels are matched by name
+	set := []*SetLabel{
+		{Name: "Label1", Color: "#abcdef"},
+		{Name: "label2", Color: "#000000", Description: "described"},
+	}
+	result, err := SyncLabels(db.DefaultContext, repo, set, false)
+	assert.NoError(t, err)
+	if assert.Len(t, result.Changes, 2) {
+		assert.EqualValues(t, 1, result.Changes[0].Label.ID)
+		assert.Equal(t, "label1", result.Changes[0].OldName)
+		assert.EqualValues(t, 2, result.Changes[1].Label.ID)
+	}
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 1, Name: "Label1"})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Label{ID: 2, Description: "described"})
+	unittest.AssertCount(t, &issues_model.Label{RepoID: repo.ID}, 2)
+}
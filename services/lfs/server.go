@@ -18,6 +18,7 @@ import (
 	"strconv"
 	"strings"
 
+	auth_model "code.gitea.io/gitea/models/auth"
 	git_model "code.gitea.io/gitea/models/git"
 	"code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
@@ -416,6 +417,11 @@ func getAuthenticatedRepository(ctx *context.Context, rc *requestContext, requir
 		return nil
 	}
 
+	if requireWrite && ctx.Data["ApiTokenScope"] == auth_model.AccessTokenScopeRead {
+		writeStatusMessage(ctx, http.StatusForbidden, "The access token is read-only.")
+		return nil
+	}
+
 	if !authenticate(ctx, repository, rc.Authorization, false, requireWrite) {
 		requireAuth(ctx)
 		return nil
@@ -225,6 +225,9 @@ func composeIssueCommentMessages(ctx *mailCommentContext, lang string, recipient
 	} else {
 		link = ctx.Issue.HTMLURL()
 	}
+	if ctx.ServiceDesk {
+		link = ctx.Issue.ServiceDeskHTMLURL()
+	}
 
 	reviewType := issues_model.ReviewTypeComment
 	if ctx.Comment != nil && ctx.Comment.Review != nil {
@@ -412,7 +415,8 @@ func SendIssueAssignedMail(issue *issues_model.Issue, doer *user_model.User, con
 			// don't send emails to inactive users
 			continue
 		}
-		langMap[user.Language] = append(langMap[user.Language], user)
+		lang := translation.ResolveLanguage(user.Language)
+		langMap[lang] = append(langMap[lang], user)
 	}
 
 	for lang, tos := range langMap {
@@ -0,0 +1,104 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/markup"
+	"code.gitea.io/gitea/modules/markup/markdown"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/translation"
+)
+
+const (
+	tplPublishedAdvisoryMail base.TplName = "advisory"
+)
+
+// MailPublishedAdvisory sends the published security advisory to all the repo watchers.
+func MailPublishedAdvisory(ctx context.Context, doer *user_model.User, adv *advisory_model.Advisory) {
+	if setting.MailService == nil {
+		// No mail service configured
+		return
+	}
+
+	watcherIDList, err := repo_model.GetRepoWatchersIDs(ctx, adv.RepoID)
+	if err != nil {
+		log.Error("GetRepoWatchersIDs(%d): %v", adv.RepoID, err)
+		return
+	}
+
+	recipients, err := user_model.GetMaileableUsersByIDs(watcherIDList, false)
+	if err != nil {
+		log.Error("user_model.GetMaileableUsersByIDs: %v", err)
+		return
+	}
+
+	langMap := make(map[string][]string)
+	for _, user := range recipients {
+		if user.ID != doer.ID {
+			lang := translation.ResolveLanguage(user.Language)
+			langMap[lang] = append(langMap[lang], user.Email)
+		}
+	}
+
+	renderedDescription, err := markdown.RenderString(&markup.RenderContext{
+		Ctx:       ctx,
+		URLPrefix: adv.Repo.Link(),
+		Metas:     adv.Repo.ComposeMetas(),
+	}, adv.Description)
+	if err != nil {
+		log.Error("markdown.RenderString(%d): %v", adv.RepoID, err)
+		return
+	}
+
+	for lang, tos := range langMap {
+		mailPublishedAdvisory(lang, tos, doer, adv, renderedDescription)
+	}
+}
+
+func mailPublishedAdvisory(lang string, tos []string, doer *user_model.User, adv *advisory_model.Advisory, renderedDescription string) {
+	locale := translation.NewLocale(lang)
+
+	subject := locale.Tr("mail.advisory.published.subject", adv.Title, adv.Repo.FullName())
+	mailMeta := map[string]interface{}{
+		"Advisory":            adv,
+		"RenderedDescription": renderedDescription,
+		"Doer":                doer,
+		"Subject":             subject,
+		"Language":            locale.Language(),
+		"Link":                adv.HTMLURL(),
+		// helper
+		"locale":    locale,
+		"Str2html":  templates.Str2html,
+		"DotEscape": templates.DotEscape,
+	}
+
+	var mailBody bytes.Buffer
+
+	if err := bodyTemplates.ExecuteTemplate(&mailBody, string(tplPublishedAdvisoryMail), mailMeta); err != nil {
+		log.Error("ExecuteTemplate [%s]: %v", string(tplPublishedAdvisoryMail)+"/body", err)
+		return
+	}
+
+	msgs := make([]*Message, 0, len(tos))
+	advURL := fmt.Sprintf("<%s>", adv.HTMLURL())
+	for _, to := range tos {
+		msg := NewMessageFrom([]string{to}, doer.DisplayName(), setting.MailService.FromEmail, subject, mailBody.String())
+		msg.Info = subject
+		msg.SetHeader("Message-ID", advURL)
+		msgs = append(msgs, msg)
+	}
+
+	SendAsyncs(msgs)
+}
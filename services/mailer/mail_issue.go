@@ -16,6 +16,7 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/translation"
 )
 
 func fallbackMailSubject(issue *issues_model.Issue) string {
@@ -29,6 +30,8 @@ type mailCommentContext struct {
 	ActionType activities_model.ActionType
 	Content    string
 	Comment    *issues_model.Comment
+	// ServiceDesk links the issue in the service desk of its repository, for a poster without access to the repository
+	ServiceDesk bool
 }
 
 const (
@@ -128,6 +131,7 @@ func mailIssueCommentBatch(ctx *mailCommentContext, users []*user_model.User, vi
 	}
 
 	langMap := make(map[string][]*user_model.User)
+	var serviceDeskPoster *user_model.User
 	for _, user := range users {
 		if !user.IsActive {
 			// Exclude deactivated users
@@ -151,10 +155,20 @@ func mailIssueCommentBatch(ctx *mailCommentContext, users []*user_model.User, vi
 
 		// test if this user is allowed to see the issue/pull
 		if !access_model.CheckRepoUnitUser(ctx, ctx.Issue.Repo, user, checkUnit) {
+			// the poster of an issue filed through the service desk follows it there
+			if user.ID == ctx.Issue.PosterID && !ctx.Issue.IsPull && ctx.Issue.Repo.IsServiceDeskEnabled(ctx) {
+				serviceDeskPoster = user
+			}
+			continue
+		}
+		if hidden, err := ctx.Issue.IsHiddenFrom(ctx, user); err != nil {
+			return err
+		} else if hidden {
 			continue
 		}
 
-		langMap[user.Language] = append(langMap[user.Language], user)
+		lang := translation.ResolveLanguage(user.Language)
+		langMap[lang] = append(langMap[lang], user)
 	}
 
 	for lang, receivers := range langMap {
@@ -171,6 +185,16 @@ func mailIssueCommentBatch(ctx *mailCommentContext, users []*user_model.User, vi
 		}
 	}
 
+	if serviceDeskPoster != nil {
+		serviceDeskCtx := *ctx
+		serviceDeskCtx.ServiceDesk = true
+		msgs, err := composeIssueCommentMessages(&serviceDeskCtx, translation.ResolveLanguage(serviceDeskPoster.Language), []*user_model.User{serviceDeskPoster}, fromMention, "issue comments")
+		if err != nil {
+			return err
+		}
+		SendAsyncs(msgs)
+	}
+
 	return nil
 }
 
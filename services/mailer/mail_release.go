@@ -45,7 +45,8 @@ func MailNewRelease(ctx context.Context, rel *repo_model.Release) {
 	langMap := make(map[string][]string)
 	for _, user := range recipients {
 		if user.ID != rel.PublisherID {
-			langMap[user.Language] = append(langMap[user.Language], user.Email)
+			lang := translation.ResolveLanguage(user.Language)
+			langMap[lang] = append(langMap[lang], user.Email)
 		}
 	}
 
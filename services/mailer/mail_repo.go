@@ -36,7 +36,8 @@ func SendRepoTransferNotifyMail(doer, newOwner *user_model.User, repo *repo_mode
 				// don't send emails to inactive users
 				continue
 			}
-			langMap[user.Language] = append(langMap[user.Language], user.Email)
+			lang := translation.ResolveLanguage(user.Language)
+			langMap[lang] = append(langMap[lang], user.Email)
 		}
 
 		for lang, tos := range langMap {
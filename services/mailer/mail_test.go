@@ -122,6 +122,24 @@ func TestComposeIssueMessage(t *testing.T) {
 	assert.Equal(t, "<user2/repo1/issues/1@localhost>", messageID[0], "Message-ID header doesn't match")
 }
 
+func TestComposeServiceDeskMessage(t *testing.T) {
+	doer, _, issue, comment := prepareMailerTest(t)
+
+	subjectTemplates = texttmpl.Must(texttmpl.New("issue/comment").Parse(subjectTpl))
+	bodyTemplates = template.Must(template.New("issue/comment").Parse(bodyTpl))
+
+	recipients := []*user_model.User{{Name: "Test", Email: "test@gitea.com"}}
+	msgs, err := composeIssueCommentMessages(&mailCommentContext{
+		Context: context.TODO(), // TODO: use a correct context
+		Issue:   issue, Doer: doer, ActionType: activities_model.ActionCommentIssue,
+		Content: "test body", Comment: comment, ServiceDesk: true,
+	}, "en-US", recipients, false, "issue comment")
+	assert.NoError(t, err)
+	if assert.Len(t, msgs, 1) {
+		assert.Contains(t, msgs[0].Body, `href="`+setting.AppURL+`user2/repo1/service_desk/1"`)
+	}
+}
+
 func TestTemplateSelection(t *testing.T) {
 	doer, repo, issue, comment := prepareMailerTest(t)
 	recipients := []*user_model.User{{Name: "Test", Email: "test@gitea.com"}}
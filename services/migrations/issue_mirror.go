@@ -0,0 +1,350 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"code.gitea.io/gitea/models"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/foreignreference"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/graceful"
+	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
+	"code.gitea.io/gitea/modules/log"
+	base "code.gitea.io/gitea/modules/migration"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+const issueMirrorPageSize = 50
+
+// ErrIssueMirrorServiceNotSupported represents a "IssueMirrorServiceNotSupported" kind of error.
+type ErrIssueMirrorServiceNotSupported struct {
+	ServiceType structs.GitServiceType
+}
+
+// IsErrIssueMirrorServiceNotSupported checks if an error is an ErrIssueMirrorServiceNotSupported.
+func IsErrIssueMirrorServiceNotSupported(err error) bool {
+	_, ok := err.(ErrIssueMirrorServiceNotSupported)
+	return ok
+}
+
+func (err ErrIssueMirrorServiceNotSupported) Error() string {
+	return fmt.Sprintf("the issues of this service can't be mirrored [service: %s]", err.ServiceType.Name())
+}
+
+// SetupIssueMirror mirrors the issues of the external repository at the remote address into a repository, as the doer.
+// The issues are only synchronized later, by SyncIssueMirror or the cron task.
+func SetupIssueMirror(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, serviceType structs.GitServiceType, remoteAddress, authToken string) (*issues_model.IssueMirror, error) {
+	if !issues_model.IsIssueMirrorService(serviceType) {
+		return nil, ErrIssueMirrorServiceNotSupported{ServiceType: serviceType}
+	}
+	if err := IsMigrateURLAllowed(remoteAddress, doer); err != nil {
+		return nil, err
+	}
+
+	m := &issues_model.IssueMirror{
+		RepoID:        repo.ID,
+		DoerID:        doer.ID,
+		ServiceType:   serviceType,
+		RemoteAddress: remoteAddress,
+	}
+	if err := m.SetAuthToken(authToken); err != nil {
+		return nil, err
+	}
+	return m, issues_model.SaveIssueMirror(ctx, m)
+}
+
+// SyncIssueMirror synchronizes the issues, comments and labels of a repository with those of its external repository.
+// Issues are never deleted, but the comments deleted from the external issues are deleted from the mirrored ones.
+func SyncIssueMirror(ctx context.Context, m *issues_model.IssueMirror) error {
+	repo, err := repo_model.GetRepositoryByIDCtx(ctx, m.RepoID)
+	if err != nil {
+		return err
+	}
+	doer, err := user_model.GetUserByIDCtx(ctx, m.DoerID)
+	if err != nil {
+		if !user_model.IsErrUserNotExist(err) {
+			return err
+		}
+		doer = user_model.NewGhostUser()
+	}
+	if err := IsMigrateURLAllowed(m.RemoteAddress, doer); err != nil {
+		return err
+	}
+	token, err := m.AuthToken()
+	if err != nil {
+		return err
+	}
+
+	downloader, err := newDownloader(ctx, repo.OwnerName, base.MigrateOptions{
+		CloneAddr:      m.RemoteAddress,
+		AuthToken:      token,
+		GitServiceType: m.ServiceType,
+		Issues:         true,
+		Comments:       true,
+		Labels:         true,
+		RepoName:       repo.Name,
+	})
+	if err != nil {
+		return err
+	}
+	synced, err := syncIssueMirror(ctx, m, repo, doer, downloader)
+	if err != nil {
+		return err
+	}
+	for _, issue := range synced {
+		if err := issue.LoadDiscussComments(); err != nil {
+			return err
+		}
+		issue_indexer.UpdateIssueIndexer(issue)
+	}
+	return nil
+}
+
+// SyncIssueMirrors synchronizes all the issue mirrors, recording the result of each synchronization
+func SyncIssueMirrors(ctx context.Context) error {
+	mirrors, err := issues_model.FindIssueMirrors(ctx)
+	if err != nil {
+		return err
+	}
+	for _, m := range mirrors {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("aborted before syncing the issues of repository %d", m.RepoID)
+		default:
+		}
+
+		if err := syncAndRecordIssueMirror(ctx, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StartIssueMirrorSync synchronizes an issue mirror in the background, its result is recorded like the scheduled ones
+func StartIssueMirrorSync(m *issues_model.IssueMirror) {
+	go func() {
+		ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().ShutdownContext(),
+			fmt.Sprintf("IssueMirror: sync the issues of repository %d", m.RepoID))
+		defer finished()
+
+		if err := syncAndRecordIssueMirror(ctx, m); err != nil {
+			log.Error("StartIssueMirrorSync: unable to record the sync of repository %d: %v", m.RepoID, err)
+		}
+	}()
+}
+
+func syncAndRecordIssueMirror(ctx context.Context, m *issues_model.IssueMirror) error {
+	m.LastError = ""
+	if err := SyncIssueMirror(ctx, m); err != nil {
+		log.Warn("SyncIssueMirror: unable to sync the issues of repository %d: %v", m.RepoID, err)
+		m.LastError = err.Error()
+	}
+	m.LastSyncUnix = timeutil.TimeStampNow()
+	return issues_model.UpdateIssueMirrorResult(ctx, m)
+}
+
+// syncIssueMirror synchronizes the issues of a repository with those of the downloader, and returns the issues which
+// have been created or updated
+func syncIssueMirror(ctx context.Context, m *issues_model.IssueMirror, repo *repo_model.Repository, doer *user_model.User, downloader base.Downloader) ([]*issues_model.Issue, error) {
+	// the uploader is only used to map the external users to the local ones
+	uploader := NewGiteaLocalUploader(ctx, doer, repo.OwnerName, repo.Name)
+	uploader.gitServiceType = m.ServiceType
+
+	labels, err := syncIssueMirrorLabels(ctx, repo, downloader)
+	if err != nil {
+		return nil, err
+	}
+
+	var synced []*issues_model.Issue
+	for page := 1; ; page++ {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("aborted while syncing the issues of repository %d", repo.ID)
+		default:
+		}
+
+		issues, isEnd, err := downloader.GetIssues(page, issueMirrorPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			is, err := syncMirroredIssue(ctx, uploader, repo, labels, downloader, issue)
+			if err != nil {
+				return nil, fmt.Errorf("sync issue #%d: %w", issue.Number, err)
+			}
+			if is != nil {
+				synced = append(synced, is)
+			}
+		}
+		if isEnd || len(issues) == 0 {
+			break
+		}
+	}
+
+	return synced, models.UpdateRepoStats(ctx, repo.ID)
+}
+
+// syncIssueMirrorLabels creates the labels of the external repository missing from the repository, and returns all the
+// labels of the repository by their names
+func syncIssueMirrorLabels(ctx context.Context, repo *repo_model.Repository, downloader base.Downloader) (map[string]*issues_model.Label, error) {
+	existing, err := issues_model.GetLabelsByRepoID(ctx, repo.ID, "", db.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[string]*issues_model.Label, len(existing))
+	for _, label := range existing {
+		labels[label.Name] = label
+	}
+
+	remoteLabels, err := downloader.GetLabels()
+	if err != nil {
+		return nil, err
+	}
+	for _, label := range remoteLabels {
+		if _, ok := labels[label.Name]; ok {
+			continue
+		}
+		color := "#" + label.Color
+		if !issues_model.LabelColorPattern.MatchString(color) {
+			color = "#ffffff"
+		}
+		lb := &issues_model.Label{
+			RepoID:      repo.ID,
+			Name:        label.Name,
+			Description: label.Description,
+			Color:       color,
+		}
+		if err := issues_model.NewLabel(ctx, lb); err != nil {
+			return nil, err
+		}
+		labels[lb.Name] = lb
+	}
+	return labels, nil
+}
+
+// syncMirroredIssue creates or updates the mirror of an external issue, and returns it if it has changed
+func syncMirroredIssue(ctx context.Context, uploader *GiteaLocalUploader, repo *repo_model.Repository, labels map[string]*issues_model.Label, downloader base.Downloader, issue *base.Issue) (*issues_model.Issue, error) {
+	foreignIndex := strconv.FormatInt(issue.Number, 10)
+
+	if issue.Created.IsZero() {
+		issue.Created = time.Now()
+	}
+	if issue.Updated.IsZero() {
+		issue.Updated = issue.Created
+	}
+
+	is, err := issues_model.GetIssueByForeignIndex(ctx, repo.ID, issue.Number)
+	if err != nil && !foreignreference.IsErrLocalIndexNotExist(err) && !issues_model.IsErrIssueNotExist(err) {
+		return nil, err
+	}
+	isNew := err != nil
+	if isNew {
+		is = &issues_model.Issue{
+			RepoID:      repo.ID,
+			Repo:        repo,
+			Index:       issue.Number,
+			CreatedUnix: timeutil.TimeStamp(issue.Created.Unix()),
+		}
+	} else if is.UpdatedUnix >= timeutil.TimeStamp(issue.Updated.Unix()) {
+		return nil, nil
+	}
+
+	is.Title = issue.Title
+	is.Content = issue.Content
+	is.IsClosed = issue.State == "closed"
+	is.IsLocked = issue.IsLocked
+	is.UpdatedUnix = timeutil.TimeStamp(issue.Updated.Unix())
+	is.ClosedUnix = 0
+	if issue.Closed != nil {
+		is.ClosedUnix = timeutil.TimeStamp(issue.Closed.Unix())
+	}
+	is.Labels = make([]*issues_model.Label, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		if lb, ok := labels[label.Name]; ok {
+			is.Labels = append(is.Labels, lb)
+		}
+	}
+	if err := uploader.remapUser(issue, is); err != nil {
+		return nil, err
+	}
+
+	if isNew {
+		err = issues_model.InsertMirroredIssue(ctx, is, foreignIndex)
+	} else {
+		err = issues_model.UpdateMirroredIssue(ctx, is)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return is, syncMirroredComments(ctx, uploader, is, downloader, issue)
+}
+
+// syncMirroredComments creates, updates and deletes the comments of a mirrored issue to match the external ones
+func syncMirroredComments(ctx context.Context, uploader *GiteaLocalUploader, is *issues_model.Issue, downloader base.Downloader, issue *base.Issue) error {
+	mirrored, err := issues_model.GetMirroredComments(ctx, is)
+	if err != nil {
+		return err
+	}
+
+	// the downloaders return all the comments of an issue at once
+	comments, _, err := downloader.GetComments(issue)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		foreignIndex := strconv.FormatInt(comment.Index, 10)
+		if comment.Created.IsZero() {
+			comment.Created = time.Unix(int64(is.CreatedUnix), 0)
+		}
+		if comment.Updated.IsZero() {
+			comment.Updated = comment.Created
+		}
+
+		c, ok := mirrored[foreignIndex]
+		if ok {
+			delete(mirrored, foreignIndex)
+			if c.UpdatedUnix >= timeutil.TimeStamp(comment.Updated.Unix()) {
+				continue
+			}
+			c.Content = comment.Content
+			c.UpdatedUnix = timeutil.TimeStamp(comment.Updated.Unix())
+			if err := issues_model.UpdateMirroredComment(ctx, c); err != nil {
+				return err
+			}
+			continue
+		}
+
+		c = &issues_model.Comment{
+			IssueID:     is.ID,
+			Type:        issues_model.CommentTypeComment,
+			Content:     comment.Content,
+			CreatedUnix: timeutil.TimeStamp(comment.Created.Unix()),
+			UpdatedUnix: timeutil.TimeStamp(comment.Updated.Unix()),
+		}
+		if err := uploader.remapUser(comment, c); err != nil {
+			return err
+		}
+		if err := issues_model.InsertMirroredComment(ctx, is.RepoID, c, foreignIndex); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range mirrored {
+		if err := issues_model.DeleteMirroredComment(ctx, is.RepoID, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package migrations
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/foreignreference"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	base "code.gitea.io/gitea/modules/migration"
+	"code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type issueMirrorDownloader struct {
+	base.NullDownloader
+	labels   []*base.Label
+	issues   []*base.Issue
+	comments map[int64][]*base.Comment
+}
+
+func (d *issueMirrorDownloader) GetLabels() ([]*base.Label, error) {
+	return d.labels, nil
+}
+
+func (d *issueMirrorDownloader) GetIssues(page, perPage int) ([]*base.Issue, bool, error) {
+	if page > 1 {
+		return nil, true, nil
+	}
+	return d.issues, true, nil
+}
+
+func (d *issueMirrorDownloader) GetComments(commentable base.Commentable) ([]*base.Comment, bool, error) {
+	return d.comments[commentable.GetLocalIndex()], true, nil
+}
+
+func TestSyncIssueMirror(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	m := &issues_model.IssueMirror{RepoID: repo.ID, DoerID: doer.ID, ServiceType: structs.GithubService, RemoteAddress: "https://github.com/owner/repo"}
+	assert.NoError(t, issues_model.SaveIssueMirror(db.DefaultContext, m))
+
+	created := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	downloader := &issueMirrorDownloader{
+		labels: []*base.Label{{Name: "mirrored-label", Color: "00ff00"}},
+		issues: []*base.Issue{
+			{Number: 1, Title: "taken", PosterID: 99, PosterName: "octocat", State: "open", Created: created, Updated: created},
+			{
+				Number: 2000, Title: "free", PosterID: 99, PosterName: "octocat", State: "open", Created: created, Updated: created,
+				Labels: []*base.Label{{Name: "mirrored-label"}},
+			},
+		},
+		comments: map[int64][]*base.Comment{
+			2000: {
+				{Index: 10, PosterID: 99, PosterName: "octocat", Content: "first", Created: created},
+				{Index: 11, PosterID: 99, PosterName: "octocat", Content: "second", Created: created},
+			},
+		},
+	}
+
+	synced, err := syncIssueMirror(db.DefaultContext, m, repo, doer, downloader)
+	assert.NoError(t, err)
+	assert.Len(t, synced, 2)
+
+	label := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{RepoID: repo.ID, Name: "mirrored-label", Color: "#00ff00"})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Index: 2000})
+	assert.Equal(t, "free", issue.Title)
+	assert.Equal(t, "octocat", issue.OriginalAuthor)
+	assert.Equal(t, doer.ID, issue.PosterID)
+	assert.EqualValues(t, 2, issue.NumComments)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: issue.ID, LabelID: label.ID})
+	// the local issue #1 keeps its number
+	taken, err := issues_model.GetIssueByForeignIndex(db.DefaultContext, repo.ID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "taken", taken.Title)
+	assert.NotEqualValues(t, 1, taken.Index)
+
+	// an unchanged issue is skipped
+	synced, err = syncIssueMirror(db.DefaultContext, m, repo, doer, downloader)
+	assert.NoError(t, err)
+	assert.Empty(t, synced)
+
+	updated := created.Add(time.Hour)
+	downloader.issues = downloader.issues[1:]
+	downloader.issues[0].Title = "closed"
+	downloader.issues[0].State = "closed"
+	downloader.issues[0].Closed = &updated
+	downloader.issues[0].Updated = updated
+	downloader.issues[0].Labels = nil
+	downloader.comments[2000] = []*base.Comment{
+		{Index: 10, PosterID: 99, PosterName: "octocat", Content: "edited", Created: created, Updated: updated},
+	}
+	synced, err = syncIssueMirror(db.DefaultContext, m, repo, doer, downloader)
+	assert.NoError(t, err)
+	assert.Len(t, synced, 1)
+
+	issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID})
+	assert.Equal(t, "closed", issue.Title)
+	assert.True(t, issue.IsClosed)
+	assert.EqualValues(t, 1, issue.NumComments)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: issue.ID, LabelID: label.ID})
+	comments, err := issues_model.GetMirroredComments(db.DefaultContext, issue)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "edited", comments["10"].Content)
+	unittest.AssertNotExistsBean(t, &foreignreference.ForeignReference{RepoID: repo.ID, ForeignIndex: "11", Type: foreignreference.TypeComment})
+}
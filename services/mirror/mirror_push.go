@@ -149,7 +149,7 @@ func runPushSync(ctx context.Context, m *repo_model.PushMirror) error {
 
 			endpoint := lfs.DetermineEndpoint(remoteURL.String(), "")
 			lfsClient := lfs.NewClient(endpoint, nil)
-			if err := pushAllLFSObjects(ctx, gitRepo, lfsClient); err != nil {
+			if err := PushAllLFSObjects(ctx, gitRepo, lfsClient); err != nil {
 				return util.SanitizeErrorCredentialURLs(err)
 			}
 		}
@@ -191,7 +191,8 @@ func runPushSync(ctx context.Context, m *repo_model.PushMirror) error {
 	return nil
 }
 
-func pushAllLFSObjects(ctx context.Context, gitRepo *git.Repository, lfsClient lfs.Client) error {
+// PushAllLFSObjects uploads the LFS objects referenced by a repository with an LFS client
+func PushAllLFSObjects(ctx context.Context, gitRepo *git.Repository, lfsClient lfs.Client) error {
 	contentStore := lfs.NewContentStore()
 
 	pointerChan := make(chan lfs.PointerBlob)
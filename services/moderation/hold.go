@@ -0,0 +1,126 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package moderation
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/notification"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	comment_service "code.gitea.io/gitea/services/comments"
+	issue_service "code.gitea.io/gitea/services/issue"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+func insertHoldLog(ctx context.Context, doer *user_model.User, hold *moderation_model.Hold, action moderation_model.ActionType, reason string) error {
+	return moderation_model.InsertLog(ctx, &moderation_model.Log{
+		ModeratorID:  doer.ID,
+		OwnerID:      hold.OwnerID,
+		RepoID:       hold.RepoID,
+		Action:       action,
+		TargetUserID: hold.PosterID,
+		IssueID:      hold.IssueID,
+		CommentID:    hold.CommentID,
+		Reason:       reason,
+	})
+}
+
+// ApproveHold publishes content held by the spam detection: the issue is reopened, the comment is shown again or
+// the repository is made public again, and the notifications which were held back are sent
+func ApproveHold(ctx context.Context, doer *user_model.User, hold *moderation_model.Hold, reason string) error {
+	if !hold.IsHeld() {
+		return moderation_model.ErrHoldClosed
+	}
+	if err := hold.LoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	if err := db.WithTx(func(ctx context.Context) error {
+		if err := moderation_model.CloseHold(ctx, hold, doer.ID, moderation_model.HoldStatusApproved); err != nil {
+			return err
+		}
+		switch {
+		case hold.Type == moderation_model.HoldTypeIssue && hold.Issue != nil:
+			if err := issues_model.SetIssueHeld(ctx, hold.Issue, false); err != nil {
+				return err
+			}
+		case hold.Type == moderation_model.HoldTypeComment && hold.Comment != nil:
+			if err := setCommentHidden(ctx, hold.Comment, false); err != nil {
+				return err
+			}
+		case hold.Type == moderation_model.HoldTypeRepo && hold.Repo != nil:
+			hold.Repo.IsPrivate = false
+			if err := repo_module.UpdateRepository(ctx, hold.Repo, true); err != nil {
+				return err
+			}
+		}
+		return insertHoldLog(ctx, doer, hold, moderation_model.ActionApproveHold, reason)
+	}, ctx); err != nil {
+		return err
+	}
+
+	switch {
+	case hold.Type == moderation_model.HoldTypeIssue && hold.Issue != nil:
+		issue := hold.Issue
+		if err := issue.LoadPoster(); err != nil {
+			return err
+		}
+		mentions, err := issues_model.FindAndUpdateIssueMentions(ctx, issue, issue.Poster, issue.Content)
+		if err != nil {
+			return err
+		}
+		notification.NotifyNewIssue(issue, mentions)
+	case hold.Type == moderation_model.HoldTypeComment && hold.Comment != nil && hold.Issue != nil:
+		comment := hold.Comment
+		if err := comment.LoadPoster(); err != nil {
+			return err
+		}
+		mentions, err := issues_model.FindAndUpdateIssueMentions(ctx, hold.Issue, comment.Poster, comment.Content)
+		if err != nil {
+			return err
+		}
+		notification.NotifyCreateIssueComment(comment.Poster, hold.Repo, hold.Issue, comment, mentions)
+	}
+	return nil
+}
+
+// RejectHold deletes content held by the spam detection
+func RejectHold(ctx context.Context, doer *user_model.User, hold *moderation_model.Hold, reason string) error {
+	if !hold.IsHeld() {
+		return moderation_model.ErrHoldClosed
+	}
+	if err := hold.LoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	if err := db.WithTx(func(ctx context.Context) error {
+		if err := moderation_model.CloseHold(ctx, hold, doer.ID, moderation_model.HoldStatusRejected); err != nil {
+			return err
+		}
+		return insertHoldLog(ctx, doer, hold, moderation_model.ActionRejectHold, reason)
+	}, ctx); err != nil {
+		return err
+	}
+
+	var err error
+	switch {
+	case hold.Type == moderation_model.HoldTypeIssue && hold.Issue != nil:
+		// pull requests are never held, so the git repository isn't needed
+		err = issue_service.DeleteIssue(doer, nil, hold.Issue)
+	case hold.Type == moderation_model.HoldTypeComment && hold.Comment != nil:
+		err = comment_service.DeleteComment(doer, hold.Comment)
+	case hold.Type == moderation_model.HoldTypeRepo && hold.Repo != nil:
+		err = repo_service.DeleteRepository(ctx, doer, hold.Repo, true)
+	}
+	if err != nil {
+		log.Error("Failed to delete the rejected %s of hold %d: %v", hold.Type, hold.ID, err)
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package moderation
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func holdComment(t *testing.T, commentID int64) *moderation_model.Hold {
+	comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: commentID})
+	comment.IsHidden = true
+	assert.NoError(t, issues_model.UpdateCommentCols(db.DefaultContext, comment, "is_hidden"))
+	hold := &moderation_model.Hold{
+		Type:      moderation_model.HoldTypeComment,
+		PosterID:  comment.PosterID,
+		OwnerID:   2,
+		RepoID:    1,
+		IssueID:   comment.IssueID,
+		CommentID: comment.ID,
+		Score:     100,
+	}
+	assert.NoError(t, moderation_model.CreateHold(db.DefaultContext, hold))
+	return hold
+}
+
+func TestApproveHold(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	hold := holdComment(t, 2)
+	assert.NoError(t, ApproveHold(db.DefaultContext, admin, hold, "not spam"))
+	comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2})
+	assert.False(t, comment.IsHidden)
+	unittest.AssertExistsAndLoadBean(t, &moderation_model.Hold{ID: hold.ID, Status: moderation_model.HoldStatusApproved, ReviewerID: 1})
+	unittest.AssertExistsAndLoadBean(t, &moderation_model.Log{CommentID: 2, Action: moderation_model.ActionApproveHold, Reason: "not spam"})
+
+	assert.ErrorIs(t, ApproveHold(db.DefaultContext, admin, hold, ""), moderation_model.ErrHoldClosed)
+	assert.ErrorIs(t, RejectHold(db.DefaultContext, admin, hold, ""), moderation_model.ErrHoldClosed)
+}
+
+func TestRejectHold(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+
+	hold := holdComment(t, 2)
+	assert.NoError(t, RejectHold(db.DefaultContext, admin, hold, "spam"))
+	unittest.AssertNotExistsBean(t, &issues_model.Comment{ID: 2})
+	unittest.AssertExistsAndLoadBean(t, &moderation_model.Hold{ID: hold.ID, Status: moderation_model.HoldStatusRejected, ReviewerID: 1})
+	unittest.AssertExistsAndLoadBean(t, &moderation_model.Log{CommentID: 2, Action: moderation_model.ActionRejectHold})
+}
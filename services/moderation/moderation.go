@@ -0,0 +1,232 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package moderation
+
+import (
+	"context"
+	"errors"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/models/organization"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	user_model "code.gitea.io/gitea/models/user"
+)
+
+var (
+	// ErrCannotReportOwnContent is returned when a user reports their own issue or comment
+	ErrCannotReportOwnContent = errors.New("cannot report your own content")
+	// ErrReportClosed is returned when an action is taken on a report which has already been handled
+	ErrReportClosed = errors.New("report is already closed")
+	// ErrInvalidAction is returned when the action can't be taken on a report
+	ErrInvalidAction = errors.New("invalid moderation action")
+	// ErrCannotReportContent is returned when reporting something else than an issue, a pull request or a comment
+	ErrCannotReportContent = errors.New("only issues, pull requests and comments can be reported")
+	// ErrCannotHideContent is returned when hiding something else than a comment
+	ErrCannotHideContent = errors.New("only comments can be hidden")
+)
+
+// CanModerate returns true if the doer can moderate the issues and comments of a repository, which are
+// the site administrators and the administrators of the repository, including the owners of its organization
+func CanModerate(doer *user_model.User, perm access_model.Permission) bool {
+	return doer != nil && (doer.IsAdmin || perm.IsAdmin())
+}
+
+// CanModerateOwner returns true if the doer can moderate all the repositories of the owner
+func CanModerateOwner(doer, owner *user_model.User) (bool, error) {
+	if doer == nil {
+		return false, nil
+	}
+	if doer.IsAdmin || doer.ID == owner.ID {
+		return true, nil
+	}
+	if owner.IsOrganization() {
+		return organization.OrgFromUser(owner).IsOwnedBy(doer.ID)
+	}
+	return false, nil
+}
+
+// ReportContent reports an issue or a comment to the moderators, the content of the issue is reported when comment is nil
+func ReportContent(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, comment *issues_model.Comment, category moderation_model.ReportCategory, remark string) (*moderation_model.Report, error) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	report := &moderation_model.Report{
+		ReporterID: doer.ID,
+		OwnerID:    issue.Repo.OwnerID,
+		RepoID:     issue.RepoID,
+		IssueID:    issue.ID,
+		PosterID:   issue.PosterID,
+		Category:   category,
+		Remark:     remark,
+	}
+	if comment != nil {
+		if comment.Type != issues_model.CommentTypeComment {
+			return nil, ErrCannotReportContent
+		}
+		report.CommentID = comment.ID
+		report.PosterID = comment.PosterID
+	}
+	if report.PosterID == doer.ID {
+		return nil, ErrCannotReportOwnContent
+	}
+	return report, moderation_model.CreateReport(ctx, report)
+}
+
+// HandleReport takes an action on an open report. The other open reports about the same content are closed
+// with it and the action is recorded in the audit trail.
+func HandleReport(ctx context.Context, doer *user_model.User, report *moderation_model.Report, action moderation_model.ActionType, reason string) error {
+	if !report.IsOpen() {
+		return ErrReportClosed
+	}
+	if !action.IsReportAction() {
+		return ErrInvalidAction
+	}
+	if err := report.LoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	status := moderation_model.ReportStatusResolved
+	switch action {
+	case moderation_model.ActionHideComment:
+		if report.Comment == nil {
+			return ErrCannotHideContent
+		}
+	case moderation_model.ActionLockIssue:
+		if err := issues_model.LockIssue(&issues_model.IssueLockOptions{
+			Doer:  doer,
+			Issue: report.Issue,
+		}); err != nil {
+			return err
+		}
+	case moderation_model.ActionDismissReport:
+		status = moderation_model.ReportStatusDismissed
+	}
+
+	return db.WithTx(func(ctx context.Context) error {
+		switch action {
+		case moderation_model.ActionHideComment:
+			if err := setCommentHidden(ctx, report.Comment, true); err != nil {
+				return err
+			}
+		case moderation_model.ActionShadowLimit:
+			if _, err := moderation_model.AddShadowLimit(ctx, report.OwnerID, report.PosterID); err != nil {
+				return err
+			}
+		}
+		if err := moderation_model.CloseReports(ctx, report.IssueID, report.CommentID, doer.ID, status); err != nil {
+			return err
+		}
+		return moderation_model.InsertLog(ctx, &moderation_model.Log{
+			ModeratorID:  doer.ID,
+			OwnerID:      report.OwnerID,
+			RepoID:       report.RepoID,
+			Action:       action,
+			TargetUserID: report.PosterID,
+			IssueID:      report.IssueID,
+			CommentID:    report.CommentID,
+			ReportID:     report.ID,
+			Reason:       reason,
+		})
+	}, ctx)
+}
+
+func setCommentHidden(ctx context.Context, comment *issues_model.Comment, hidden bool) error {
+	comment.IsHidden = hidden
+	return issues_model.UpdateCommentCols(ctx, comment, "is_hidden")
+}
+
+// SetCommentHidden hides or shows again a comment without a report, hiding a comment resolves the open reports about it
+func SetCommentHidden(ctx context.Context, doer *user_model.User, comment *issues_model.Comment, hidden bool, reason string) error {
+	if comment.Type != issues_model.CommentTypeComment {
+		return ErrCannotHideContent
+	}
+	if comment.IsHidden == hidden {
+		return nil
+	}
+	if err := comment.LoadIssueCtx(ctx); err != nil {
+		return err
+	}
+	if err := comment.Issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	return db.WithTx(func(ctx context.Context) error {
+		if err := setCommentHidden(ctx, comment, hidden); err != nil {
+			return err
+		}
+		action := moderation_model.ActionUnhideComment
+		if hidden {
+			action = moderation_model.ActionHideComment
+			if err := moderation_model.CloseReports(ctx, comment.IssueID, comment.ID, doer.ID, moderation_model.ReportStatusResolved); err != nil {
+				return err
+			}
+		}
+		return moderation_model.InsertLog(ctx, &moderation_model.Log{
+			ModeratorID:  doer.ID,
+			OwnerID:      comment.Issue.Repo.OwnerID,
+			RepoID:       comment.Issue.RepoID,
+			Action:       action,
+			TargetUserID: comment.PosterID,
+			IssueID:      comment.IssueID,
+			CommentID:    comment.ID,
+			Reason:       reason,
+		})
+	}, ctx)
+}
+
+// RemoveShadowLimit lifts the shadow limit of a user in the repositories of the owner and records it in the audit trail
+func RemoveShadowLimit(ctx context.Context, doer *user_model.User, ownerID, userID int64, reason string) error {
+	return db.WithTx(func(ctx context.Context) error {
+		removed, err := moderation_model.RemoveShadowLimit(ctx, ownerID, userID)
+		if err != nil || !removed {
+			return err
+		}
+		return moderation_model.InsertLog(ctx, &moderation_model.Log{
+			ModeratorID:  doer.ID,
+			OwnerID:      ownerID,
+			Action:       moderation_model.ActionRemoveShadowLimit,
+			TargetUserID: userID,
+			Reason:       reason,
+		})
+	}, ctx)
+}
+
+// FilterComments prepares the comments of an issue for the doer: the comments of the users shadow limited by the owner
+// of the repository are removed unless the doer posted them, and the content of the hidden comments is cleared.
+// The moderators see every comment.
+func FilterComments(ctx context.Context, doer *user_model.User, ownerID int64, canModerate bool, comments issues_model.CommentList) (issues_model.CommentList, error) {
+	if canModerate {
+		return comments, nil
+	}
+	limitedIDs, err := moderation_model.GetShadowLimitedUserIDs(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	limited := make(map[int64]struct{}, len(limitedIDs))
+	for _, id := range limitedIDs {
+		limited[id] = struct{}{}
+	}
+
+	filtered := comments[:0]
+	for _, comment := range comments {
+		isPoster := doer != nil && doer.ID == comment.PosterID
+		if isPoster {
+			filtered = append(filtered, comment)
+			continue
+		}
+		if _, ok := limited[comment.PosterID]; ok {
+			continue
+		}
+		if comment.IsHidden {
+			comment.Content = ""
+			comment.RenderedContent = ""
+			comment.Attachments = nil
+		}
+		filtered = append(filtered, comment)
+	}
+	return filtered, nil
+}
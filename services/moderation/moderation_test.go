@@ -0,0 +1,147 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package moderation
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportContent(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2})
+	user3 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	_, err := ReportContent(db.DefaultContext, user3, issue, comment, moderation_model.ReportCategorySpam, "")
+	assert.ErrorIs(t, err, ErrCannotReportOwnContent)
+
+	label := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 1})
+	_, err = ReportContent(db.DefaultContext, user4, issue, label, moderation_model.ReportCategorySpam, "")
+	assert.ErrorIs(t, err, ErrCannotReportContent)
+
+	report, err := ReportContent(db.DefaultContext, user4, issue, comment, moderation_model.ReportCategoryAbuse, "insults")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, report.OwnerID)
+	assert.EqualValues(t, 3, report.PosterID)
+	assert.EqualValues(t, 2, report.CommentID)
+
+	report, err = ReportContent(db.DefaultContext, user4, issue, nil, moderation_model.ReportCategoryOffTopic, "")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, report.PosterID)
+	assert.EqualValues(t, 0, report.CommentID)
+}
+
+func TestHandleReport(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2})
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	report, err := ReportContent(db.DefaultContext, user4, issue, comment, moderation_model.ReportCategorySpam, "")
+	assert.NoError(t, err)
+	other, err := ReportContent(db.DefaultContext, user5, issue, comment, moderation_model.ReportCategorySpam, "")
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, HandleReport(db.DefaultContext, admin, report, moderation_model.ActionUnhideComment, ""), ErrInvalidAction)
+	assert.NoError(t, HandleReport(db.DefaultContext, admin, report, moderation_model.ActionHideComment, "spam link"))
+
+	comment = unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2})
+	assert.True(t, comment.IsHidden)
+	other = unittest.AssertExistsAndLoadBean(t, &moderation_model.Report{ID: other.ID})
+	assert.Equal(t, moderation_model.ReportStatusResolved, other.Status)
+	assert.EqualValues(t, admin.ID, other.ResolverID)
+	unittest.AssertExistsAndLoadBean(t, &moderation_model.Log{
+		ModeratorID:  admin.ID,
+		OwnerID:      2,
+		Action:       moderation_model.ActionHideComment,
+		TargetUserID: 3,
+		ReportID:     report.ID,
+		Reason:       "spam link",
+	})
+	assert.ErrorIs(t, HandleReport(db.DefaultContext, admin, other, moderation_model.ActionDismissReport, ""), ErrReportClosed)
+
+	// the issue content can't be hidden, but the issue can be locked and its poster shadow limited
+	report, err = ReportContent(db.DefaultContext, user4, issue, nil, moderation_model.ReportCategoryAbuse, "")
+	assert.NoError(t, err)
+	assert.ErrorIs(t, HandleReport(db.DefaultContext, admin, report, moderation_model.ActionHideComment, ""), ErrCannotHideContent)
+	assert.NoError(t, HandleReport(db.DefaultContext, admin, report, moderation_model.ActionLockIssue, ""))
+	issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	assert.True(t, issue.IsLocked)
+
+	report, err = ReportContent(db.DefaultContext, user4, issue, nil, moderation_model.ReportCategoryAbuse, "")
+	assert.NoError(t, err)
+	assert.NoError(t, HandleReport(db.DefaultContext, admin, report, moderation_model.ActionShadowLimit, ""))
+	limited, err := moderation_model.IsShadowLimited(db.DefaultContext, 2, 1)
+	assert.NoError(t, err)
+	assert.True(t, limited)
+
+	assert.NoError(t, RemoveShadowLimit(db.DefaultContext, admin, 2, 1, "appeal"))
+	limited, err = moderation_model.IsShadowLimited(db.DefaultContext, 2, 1)
+	assert.NoError(t, err)
+	assert.False(t, limited)
+	unittest.AssertExistsAndLoadBean(t, &moderation_model.Log{Action: moderation_model.ActionRemoveShadowLimit, TargetUserID: 1})
+}
+
+func TestFilterComments(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user3 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	hidden := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2})
+	assert.NoError(t, SetCommentHidden(db.DefaultContext, admin, hidden, true, ""))
+	_, err := moderation_model.AddShadowLimit(db.DefaultContext, 2, 5)
+	assert.NoError(t, err)
+
+	load := func() issues_model.CommentList {
+		return issues_model.CommentList{
+			unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2}),
+			unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 3}),
+		}
+	}
+
+	// the moderators see everything
+	comments, err := FilterComments(db.DefaultContext, admin, 2, true, load())
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 2) {
+		assert.Equal(t, "good work!", comments[0].Content)
+	}
+
+	// the posters see their own comments
+	comments, err = FilterComments(db.DefaultContext, user3, 2, false, load())
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	comments, err = FilterComments(db.DefaultContext, user5, 2, false, load())
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 2) {
+		assert.Empty(t, comments[0].Content)
+		assert.Equal(t, "meh...", comments[1].Content)
+	}
+
+	// the others don't see the content of the hidden comment nor the comments of the shadow limited user
+	for _, doer := range []*user_model.User{user4, nil} {
+		comments, err = FilterComments(db.DefaultContext, doer, 2, false, load())
+		assert.NoError(t, err)
+		if assert.Len(t, comments, 1) {
+			assert.True(t, comments[0].IsHidden)
+			assert.Empty(t, comments[0].Content)
+		}
+	}
+}
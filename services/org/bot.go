@@ -0,0 +1,83 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models"
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	user_service "code.gitea.io/gitea/services/user"
+)
+
+// CreateBot creates a bot account owned by the organization. The bot can't sign in nor own repositories,
+// it acts through the access tokens created by the owners of the organization with the permissions of its teams.
+func CreateBot(ctx context.Context, org *organization.Organization, doer *user_model.User, name, fullName string) (*user_model.User, error) {
+	emailNotifications := user_model.EmailNotificationsDisabled
+	maxRepoCreation := 0
+	bot := &user_model.User{
+		Name:     name,
+		FullName: fullName,
+		Email:    fmt.Sprintf("%s@%s", name, setting.Service.NoReplyAddress),
+		Type:     user_model.UserTypeBot,
+	}
+	if err := user_model.CreateUser(bot, &user_model.CreateUserOverwriteOptions{
+		KeepEmailPrivate:             util.OptionalBoolTrue,
+		Visibility:                   &org.Visibility,
+		AllowCreateOrganization:      util.OptionalBoolFalse,
+		EmailNotificationsPreference: &emailNotifications,
+		MaxRepoCreation:              &maxRepoCreation,
+		IsRestricted:                 util.OptionalBoolFalse,
+		IsActive:                     util.OptionalBoolTrue,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := organization.CreateOrgBot(ctx, &organization.OrgBot{
+		OrgID:     org.ID,
+		BotID:     bot.ID,
+		CreatorID: doer.ID,
+	}); err != nil {
+		if err := user_service.DeleteUser(ctx, bot, true); err != nil {
+			log.Error("DeleteUser[%s]: %v", bot.Name, err)
+		}
+		return nil, err
+	}
+	log.Trace("Bot created by %s: %s/%s", doer.Name, org.Name, bot.Name)
+	return bot, nil
+}
+
+// DeleteBot deletes a bot account of an organization with its tokens and memberships,
+// its comments and other contributions are kept like for a deleted user
+func DeleteBot(ctx context.Context, b *organization.OrgBot) error {
+	if err := models.RemoveOrgUser(b.OrgID, b.BotID); err != nil {
+		return err
+	}
+	return user_service.DeleteUser(ctx, b.Bot, false)
+}
+
+// CreateBotToken creates an access token of a bot with the given scope
+func CreateBotToken(ctx context.Context, b *organization.OrgBot, name string, scope auth_model.AccessTokenScope) (*auth_model.AccessToken, error) {
+	if !scope.IsValid() {
+		return nil, fmt.Errorf("invalid access token scope %q", scope)
+	}
+	t := &auth_model.AccessToken{
+		UID:   b.BotID,
+		Name:  name,
+		Scope: scope,
+	}
+	if exist, err := auth_model.AccessTokenByNameExists(t); err != nil {
+		return nil, err
+	} else if exist {
+		return nil, auth_model.ErrAccessTokenNameExist{Name: name}
+	}
+	return t, auth_model.NewAccessToken(t)
+}
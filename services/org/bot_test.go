@@ -0,0 +1,62 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models"
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBots(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	defer func(address string) { setting.Service.NoReplyAddress = address }(setting.Service.NoReplyAddress)
+	setting.Service.NoReplyAddress = "noreply.example.org"
+	org3 := unittest.AssertExistsAndLoadBean(t, &organization.Organization{ID: 3})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	bot, err := CreateBot(db.DefaultContext, org3, doer, "ci-bot", "CI")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, bot.IsBot())
+	assert.True(t, bot.IsActive)
+	assert.Equal(t, 0, bot.MaxRepoCreation)
+
+	bots, err := organization.GetOrgBots(db.DefaultContext, org3.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, bots, 1) {
+		assert.Equal(t, bot.ID, bots[0].BotID)
+		assert.Equal(t, doer.ID, bots[0].Creator.ID)
+	}
+	_, err = organization.GetOrgBotByName(db.DefaultContext, 6, "ci-bot")
+	assert.True(t, organization.IsErrOrgBotNotExist(err))
+
+	// a bot only joins the teams of its organization
+	team2 := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 2})
+	team3 := unittest.AssertExistsAndLoadBean(t, &organization.Team{ID: 3})
+	assert.NoError(t, models.AddTeamMember(team2, bot.ID))
+	assert.True(t, organization.IsErrOrgBotForeign(models.AddTeamMember(team3, bot.ID)))
+
+	token, err := CreateBotToken(db.DefaultContext, bots[0], "deploy", auth_model.AccessTokenScopeRead)
+	assert.NoError(t, err)
+	unittest.AssertExistsAndLoadBean(t, &auth_model.AccessToken{ID: token.ID, UID: bot.ID, Scope: auth_model.AccessTokenScopeRead})
+	_, err = CreateBotToken(db.DefaultContext, bots[0], "deploy", auth_model.AccessTokenScopeAll)
+	assert.True(t, auth_model.IsErrAccessTokenNameExist(err))
+
+	assert.NoError(t, DeleteBot(db.DefaultContext, bots[0]))
+	unittest.AssertNotExistsBean(t, &user_model.User{ID: bot.ID})
+	unittest.AssertNotExistsBean(t, &organization.OrgBot{BotID: bot.ID})
+	unittest.AssertNotExistsBean(t, &organization.TeamUser{UID: bot.ID})
+	unittest.AssertNotExistsBean(t, &auth_model.AccessToken{ID: token.ID})
+}
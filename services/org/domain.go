@@ -0,0 +1,135 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"context"
+	"net"
+
+	"code.gitea.io/gitea/models"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// lookupTXT resolves the TXT records of a name, it is replaced in tests
+var lookupTXT = net.LookupTXT
+
+// checkDomainTeam returns an ErrTeamNotExist if the team can't be joined by the users of a domain of the organization,
+// no team at all means the users don't join the organization. The owners team can't be joined automatically.
+func checkDomainTeam(ctx context.Context, orgID, teamID int64) error {
+	if teamID == 0 {
+		return nil
+	}
+	team, err := organization.GetTeamByID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if team.OrgID != orgID || team.IsOwnerTeam() {
+		return organization.ErrTeamNotExist{OrgID: orgID, TeamID: teamID}
+	}
+	return nil
+}
+
+// CreateDomain claims the domain for the organization, once verified the users
+// having a verified email address on the domain join the given team.
+func CreateDomain(ctx context.Context, org *organization.Organization, domain string, teamID int64) (*organization.OrgDomain, error) {
+	if err := checkDomainTeam(ctx, org.ID, teamID); err != nil {
+		return nil, err
+	}
+	d := &organization.OrgDomain{
+		OrgID:  org.ID,
+		Domain: domain,
+		TeamID: teamID,
+	}
+	if err := organization.CreateOrgDomain(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// SetDomainTeam changes the team the users of the domain join
+func SetDomainTeam(ctx context.Context, d *organization.OrgDomain, teamID int64) error {
+	if err := checkDomainTeam(ctx, d.OrgID, teamID); err != nil {
+		return err
+	}
+	d.TeamID = teamID
+	return organization.UpdateOrgDomainCols(ctx, d, "team_id")
+}
+
+// VerifyDomain checks the DNS TXT record proving the ownership of the domain by the organization,
+// a domain can only be verified by one organization.
+func VerifyDomain(ctx context.Context, org *organization.Organization, d *organization.OrgDomain) error {
+	if d.IsVerified {
+		return nil
+	}
+
+	taken, err := organization.IsDomainVerifiedByOtherOrg(ctx, org.ID, d.Domain)
+	if err != nil {
+		return err
+	} else if taken {
+		return organization.ErrOrgDomainNotVerified{Domain: d.Domain, Reason: "verified by another organization"}
+	}
+
+	records, err := lookupTXT(d.ChallengeName(org.Name))
+	if err != nil {
+		log.Debug("LookupTXT(%s): %v", d.ChallengeName(org.Name), err)
+		return organization.ErrOrgDomainNotVerified{Domain: d.Domain, Reason: "no TXT record found"}
+	}
+	for _, record := range records {
+		if record == d.Token {
+			d.IsVerified = true
+			d.VerifiedUnix = timeutil.TimeStampNow()
+			return organization.UpdateOrgDomainCols(ctx, d, "is_verified", "verified_unix")
+		}
+	}
+	return organization.ErrOrgDomainNotVerified{Domain: d.Domain, Reason: "TXT record does not match"}
+}
+
+// JoinVerifiedDomainOrgs adds the user to the teams of the verified domains of its activated email addresses,
+// it is called when the user is created or activated and when an email address is activated.
+func JoinVerifiedDomainOrgs(ctx context.Context, u *user_model.User) error {
+	if u.IsOrganization() || !u.IsActive {
+		return nil
+	}
+
+	emails, err := user_model.GetEmailAddresses(u.ID)
+	if err != nil {
+		return err
+	}
+	domains := make([]string, 0, len(emails))
+	for _, email := range emails {
+		if email.IsActivated {
+			if domain := organization.EmailDomain(email.Email); domain != "" {
+				domains = append(domains, domain)
+			}
+		}
+	}
+
+	orgDomains, err := organization.FindVerifiedOrgDomains(ctx, domains)
+	if err != nil {
+		return err
+	}
+	for _, d := range orgDomains {
+		if blocked, err := user_model.IsBlocked(ctx, d.OrgID, u.ID); err != nil {
+			return err
+		} else if blocked {
+			continue
+		}
+		team, err := organization.GetTeamByID(ctx, d.TeamID)
+		if err != nil {
+			if organization.IsErrTeamNotExist(err) {
+				continue
+			}
+			return err
+		}
+		if err := models.AddTeamMember(team, u.ID); err != nil {
+			return err
+		}
+		log.Trace("User %s joined team %d of organization %d through the domain %s", u.Name, team.ID, d.OrgID, d.Domain)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"errors"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyDomain(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	records := map[string][]string{}
+	defer func(old func(string) ([]string, error)) { lookupTXT = old }(lookupTXT)
+	lookupTXT = func(name string) ([]string, error) {
+		if r, ok := records[name]; ok {
+			return r, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	org3 := unittest.AssertExistsAndLoadBean(t, &organization.Organization{ID: 3})
+	org6 := unittest.AssertExistsAndLoadBean(t, &organization.Organization{ID: 6})
+
+	// the owners team can't be joined through a domain, nor a team of another organization
+	_, err := CreateDomain(db.DefaultContext, org3, "example.com", 1)
+	assert.True(t, organization.IsErrTeamNotExist(err))
+	_, err = CreateDomain(db.DefaultContext, org3, "example.com", 3)
+	assert.True(t, organization.IsErrTeamNotExist(err))
+
+	d, err := CreateDomain(db.DefaultContext, org3, "Example.com", 2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "example.com", d.Domain)
+	assert.Equal(t, "_gitea-challenge-user3.example.com", d.ChallengeName(org3.Name))
+
+	err = VerifyDomain(db.DefaultContext, org3, d)
+	assert.True(t, organization.IsErrOrgDomainNotVerified(err))
+
+	records[d.ChallengeName(org3.Name)] = []string{"something else"}
+	err = VerifyDomain(db.DefaultContext, org3, d)
+	assert.True(t, organization.IsErrOrgDomainNotVerified(err))
+
+	records[d.ChallengeName(org3.Name)] = []string{"something else", d.Token}
+	assert.NoError(t, VerifyDomain(db.DefaultContext, org3, d))
+	unittest.AssertExistsAndLoadBean(t, &organization.OrgDomain{ID: d.ID, IsVerified: true})
+
+	// another organization can claim the domain but not verify it
+	d6, err := CreateDomain(db.DefaultContext, org6, "example.com", 0)
+	assert.NoError(t, err)
+	records[d6.ChallengeName(org6.Name)] = []string{d6.Token}
+	err = VerifyDomain(db.DefaultContext, org6, d6)
+	assert.True(t, organization.IsErrOrgDomainNotVerified(err))
+}
+
+func TestJoinVerifiedDomainOrgs(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	org3 := unittest.AssertExistsAndLoadBean(t, &organization.Organization{ID: 3})
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	d, err := CreateDomain(db.DefaultContext, org3, "example.com", 2)
+	assert.NoError(t, err)
+
+	// unverified domains are ignored
+	assert.NoError(t, JoinVerifiedDomainOrgs(db.DefaultContext, user5))
+	unittest.AssertNotExistsBean(t, &organization.TeamUser{TeamID: 2, UID: 5})
+
+	d.IsVerified = true
+	assert.NoError(t, organization.UpdateOrgDomainCols(db.DefaultContext, d, "is_verified"))
+	assert.NoError(t, JoinVerifiedDomainOrgs(db.DefaultContext, user5))
+	unittest.AssertExistsAndLoadBean(t, &organization.TeamUser{TeamID: 2, UID: 5})
+	unittest.AssertExistsAndLoadBean(t, &organization.OrgUser{OrgID: 3, UID: 5})
+
+	// joining again is a no-op
+	assert.NoError(t, JoinVerifiedDomainOrgs(db.DefaultContext, user5))
+
+	unittest.CheckConsistencyFor(t, &organization.Team{ID: 2}, &user_model.User{ID: 3})
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"context"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// UpdateOrgInsights computes the insights of the organization for the days which are over and not computed yet,
+// going back at most backfillDays days.
+func UpdateOrgInsights(ctx context.Context, org *organization.Organization, backfillDays int64) error {
+	today := activities_model.InsightDayStart(timeutil.TimeStampNow())
+	day := today - timeutil.TimeStamp(backfillDays*activities_model.OrgInsightDay)
+
+	latest, err := activities_model.GetLatestOrgInsightDay(ctx, org.ID)
+	if err != nil {
+		return err
+	}
+	if latest >= day {
+		day = latest + activities_model.OrgInsightDay
+	}
+
+	for ; day < today; day += activities_model.OrgInsightDay {
+		select {
+		case <-ctx.Done():
+			return db.ErrCancelledf("before insights of %s for %s", org.Name, day.FormatInLocation("2006-01-02", time.UTC))
+		default:
+		}
+		in, err := activities_model.CountOrgInsight(ctx, org.ID, day)
+		if err != nil {
+			return err
+		}
+		if err := activities_model.SaveOrgInsight(ctx, in); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateAllOrgInsights computes the missing insights of all organizations
+func UpdateAllOrgInsights(ctx context.Context, backfillDays int64) error {
+	return db.Iterate(
+		ctx,
+		new(organization.Organization),
+		builder.Eq{"type": user_model.UserTypeOrganization},
+		func(idx int, bean interface{}) error {
+			org := bean.(*organization.Organization)
+			if err := UpdateOrgInsights(ctx, org, backfillDays); err != nil {
+				if db.IsErrCancelled(err) {
+					return err
+				}
+				log.Error("UpdateOrgInsights[%s]: %v", org.Name, err)
+			}
+			return nil
+		},
+	)
+}
@@ -0,0 +1,37 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"testing"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateOrgInsights(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	org := unittest.AssertExistsAndLoadBean(t, &organization.Organization{ID: 3})
+	today := activities_model.InsightDayStart(timeutil.TimeStampNow())
+
+	assert.NoError(t, UpdateOrgInsights(db.DefaultContext, org, 3))
+	assert.EqualValues(t, 3, unittest.GetCount(t, &activities_model.OrgInsight{OrgID: 3}))
+	latest, err := activities_model.GetLatestOrgInsightDay(db.DefaultContext, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, today-activities_model.OrgInsightDay, latest)
+
+	// the days already computed are not computed again
+	assert.NoError(t, UpdateOrgInsights(db.DefaultContext, org, 5))
+	assert.EqualValues(t, 3, unittest.GetCount(t, &activities_model.OrgInsight{OrgID: 3}))
+
+	assert.NoError(t, UpdateAllOrgInsights(db.DefaultContext, 1))
+	assert.EqualValues(t, 1, unittest.GetCount(t, &activities_model.OrgInsight{OrgID: 6}))
+}
@@ -8,13 +8,18 @@ import (
 	"fmt"
 
 	"code.gitea.io/gitea/models"
+	activities_model "code.gitea.io/gitea/models/activities"
 	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
 	"code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/storage"
 	"code.gitea.io/gitea/modules/util"
+	user_service "code.gitea.io/gitea/services/user"
 )
 
 // DeleteOrganization completely and permanently deletes everything of organization.
@@ -40,14 +45,39 @@ func DeleteOrganization(org *organization.Organization) error {
 		return models.ErrUserOwnPackages{UID: org.ID}
 	}
 
+	bots, err := organization.GetOrgBots(ctx, org.ID)
+	if err != nil {
+		return fmt.Errorf("GetOrgBots: %v", err)
+	}
+
 	if err := organization.DeleteOrganization(ctx, org); err != nil {
 		return fmt.Errorf("DeleteOrganization: %v", err)
 	}
 
+	if err := activities_model.DeleteOrgInsights(ctx, org.ID); err != nil {
+		return fmt.Errorf("DeleteOrgInsights: %v", err)
+	}
+
+	if err := db.DeleteBeans(ctx,
+		&issues_model.IssueField{OrgID: org.ID},
+		&issues_model.PinnedIssue{OrgID: org.ID},
+		&moderation_model.ShadowLimit{OwnerID: org.ID},
+		&moderation_model.Log{OwnerID: org.ID},
+	); err != nil {
+		return fmt.Errorf("deleteBeans: %v", err)
+	}
+
 	if err := commiter.Commit(); err != nil {
 		return err
 	}
 
+	// the bots can only be deleted once they are not members of the organization anymore
+	for _, b := range bots {
+		if err := user_service.DeleteUser(db.DefaultContext, b.Bot, false); err != nil {
+			log.Error("DeleteUser[%s]: %v", b.Bot.Name, err)
+		}
+	}
+
 	// FIXME: system notice
 	// Note: There are something just cannot be roll back,
 	//	so just keep error logs of those operations.
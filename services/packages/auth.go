@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	auth_model "code.gitea.io/gitea/models/auth"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
 
@@ -19,9 +20,11 @@ import (
 type packageClaims struct {
 	jwt.RegisteredClaims
 	UserID int64
+	// Scope is the scope of the access token the user authenticated with, so a read-only token stays read-only
+	Scope auth_model.AccessTokenScope `json:",omitempty"`
 }
 
-func CreateAuthorizationToken(u *user_model.User) (string, error) {
+func CreateAuthorizationToken(u *user_model.User, scope auth_model.AccessTokenScope) (string, error) {
 	now := time.Now()
 
 	claims := packageClaims{
@@ -30,6 +33,7 @@ func CreateAuthorizationToken(u *user_model.User) (string, error) {
 			NotBefore: jwt.NewNumericDate(now),
 		},
 		UserID: u.ID,
+		Scope:  scope,
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
@@ -41,10 +45,10 @@ func CreateAuthorizationToken(u *user_model.User) (string, error) {
 	return tokenString, nil
 }
 
-func ParseAuthorizationToken(req *http.Request) (int64, error) {
+func ParseAuthorizationToken(req *http.Request) (int64, auth_model.AccessTokenScope, error) {
 	parts := strings.SplitN(req.Header.Get("Authorization"), " ", 2)
 	if len(parts) != 2 {
-		return 0, fmt.Errorf("no token")
+		return 0, "", fmt.Errorf("no token")
 	}
 
 	token, err := jwt.ParseWithClaims(parts[1], &packageClaims{}, func(t *jwt.Token) (interface{}, error) {
@@ -54,13 +58,13 @@ func ParseAuthorizationToken(req *http.Request) (int64, error) {
 		return []byte(setting.SecretKey), nil
 	})
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
 	c, ok := token.Claims.(*packageClaims)
 	if !token.Valid || !ok {
-		return 0, fmt.Errorf("invalid token claim")
+		return 0, "", fmt.Errorf("invalid token claim")
 	}
 
-	return c.UserID, nil
+	return c.UserID, c.Scope, nil
 }
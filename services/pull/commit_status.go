@@ -14,10 +14,12 @@ import (
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/structs"
 
+	"github.com/gobwas/glob"
 	"github.com/pkg/errors"
 )
 
-// MergeRequiredContextsCommitStatus returns a commit status state for given required contexts
+// MergeRequiredContextsCommitStatus returns a commit status state for given required contexts,
+// which are the required status checks of a protected branch
 func MergeRequiredContextsCommitStatus(commitStatuses []*git_model.CommitStatus, requiredContexts []string) structs.CommitStatusState {
 	if len(requiredContexts) == 0 {
 		status := git_model.CalcCommitStatus(commitStatuses)
@@ -28,28 +30,44 @@ func MergeRequiredContextsCommitStatus(commitStatuses []*git_model.CommitStatus,
 	}
 
 	returnedStatus := structs.CommitStatusSuccess
-	for _, ctx := range requiredContexts {
+	for _, check := range requiredContexts {
+		targetStatus := requiredStatusCheckState(commitStatuses, check)
+		if targetStatus.NoBetterThan(returnedStatus) {
+			returnedStatus = targetStatus
+		}
+	}
+	return returnedStatus
+}
+
+// requiredStatusCheckState returns the state of a required status check, the best state of its alternatives.
+// An alternative has the worst state of the statuses whose context it matches, so that all the jobs of a
+// matrix build have to pass, or is pending when there is none yet.
+func requiredStatusCheckState(commitStatuses []*git_model.CommitStatus, check string) structs.CommitStatusState {
+	alternatives, err := git_model.CompileStatusCheck(check)
+	if err != nil || len(alternatives) == 0 {
+		// a context name which isn't a valid pattern only matches itself
+		alternatives = []glob.Glob{glob.MustCompile(glob.QuoteMeta(check))}
+	}
+
+	var bestStatus structs.CommitStatusState
+	for _, g := range alternatives {
 		var targetStatus structs.CommitStatusState
 		for _, commitStatus := range commitStatuses {
-			if commitStatus.Context == ctx {
+			if commitStatus.Context != check && !g.Match(commitStatus.Context) {
+				continue
+			}
+			if targetStatus == "" || commitStatus.State.NoBetterThan(targetStatus) {
 				targetStatus = commitStatus.State
-				break
 			}
 		}
-
 		if targetStatus == "" {
 			targetStatus = structs.CommitStatusPending
-			commitStatuses = append(commitStatuses, &git_model.CommitStatus{
-				State:       targetStatus,
-				Context:     ctx,
-				Description: "Pending",
-			})
 		}
-		if targetStatus.NoBetterThan(returnedStatus) {
-			returnedStatus = targetStatus
+		if bestStatus == "" || !targetStatus.NoBetterThan(bestStatus) {
+			bestStatus = targetStatus
 		}
 	}
-	return returnedStatus
+	return bestStatus
 }
 
 // IsCommitStatusContextSuccess returns true if all required status check contexts succeed.
@@ -63,23 +81,7 @@ func IsCommitStatusContextSuccess(commitStatuses []*git_model.CommitStatus, requ
 		return true
 	}
 
-	for _, ctx := range requiredContexts {
-		var found bool
-		for _, commitStatus := range commitStatuses {
-			if commitStatus.Context == ctx {
-				if commitStatus.State != structs.CommitStatusSuccess {
-					return false
-				}
-
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
-	}
-	return true
+	return MergeRequiredContextsCommitStatus(commitStatuses, requiredContexts).IsSuccess()
 }
 
 // IsPullCommitStatusPass returns if all required status checks PASS
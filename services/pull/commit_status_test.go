@@ -0,0 +1,49 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package pull
+
+import (
+	"testing"
+
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeRequiredContextsCommitStatus(t *testing.T) {
+	commitStatuses := []*git_model.CommitStatus{
+		{Context: "ci/build-linux", State: structs.CommitStatusSuccess},
+		{Context: "ci/build-macos", State: structs.CommitStatusSuccess},
+		{Context: "ci/build-windows", State: structs.CommitStatusFailure},
+		{Context: "ci/test-linux", State: structs.CommitStatusSuccess},
+		{Context: "lint", State: structs.CommitStatusSuccess},
+		{Context: "docs", State: structs.CommitStatusPending},
+	}
+
+	cases := []struct {
+		requiredContexts []string
+		expected         structs.CommitStatusState
+	}{
+		{nil, structs.CommitStatusFailure},
+		{[]string{"lint"}, structs.CommitStatusSuccess},
+		{[]string{"lint", "docs"}, structs.CommitStatusPending},
+		{[]string{"missing"}, structs.CommitStatusPending},
+		// all the statuses matching a pattern must pass
+		{[]string{"ci/test-*"}, structs.CommitStatusSuccess},
+		{[]string{"ci/build-*"}, structs.CommitStatusFailure},
+		{[]string{"ci/build-{linux,macos}"}, structs.CommitStatusSuccess},
+		// and at least one of them must be reported
+		{[]string{"ci/deploy-*"}, structs.CommitStatusPending},
+		// an any-of group passes when one of its alternatives passes
+		{[]string{"ci/build-windows | ci/test-*"}, structs.CommitStatusSuccess},
+		{[]string{"ci/build-windows|docs"}, structs.CommitStatusPending},
+		{[]string{"ci/build-windows | missing", "lint"}, structs.CommitStatusPending},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, MergeRequiredContextsCommitStatus(commitStatuses, c.requiredContexts), "%v", c.requiredContexts)
+		assert.Equal(t, c.expected == structs.CommitStatusSuccess, IsCommitStatusContextSuccess(commitStatuses, c.requiredContexts), "%v", c.requiredContexts)
+	}
+}
@@ -35,9 +35,59 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/timeutil"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
+	codescanning_service "code.gitea.io/gitea/services/codescanning"
+	coverage_service "code.gitea.io/gitea/services/coverage"
 	issue_service "code.gitea.io/gitea/services/issue"
 )
 
+// ErrMergeMessageNotMatchTemplate represents an error if the commit title of a merge does not match the title
+// enforced by the merge message template of the repository
+type ErrMergeMessageNotMatchTemplate struct {
+	Expected string
+	Actual   string
+}
+
+// IsErrMergeMessageNotMatchTemplate checks if an error is a ErrMergeMessageNotMatchTemplate.
+func IsErrMergeMessageNotMatchTemplate(err error) bool {
+	_, ok := err.(ErrMergeMessageNotMatchTemplate)
+	return ok
+}
+
+func (err ErrMergeMessageNotMatchTemplate) Error() string {
+	return fmt.Sprintf("merge commit title does not match the template [expected: %q, actual: %q]", err.Expected, err.Actual)
+}
+
+// getMergeMessageTemplate returns the template of the merge message of the given merge style: the one configured
+// in the repository settings first, then the one of the default branch, or an empty string if there is none
+func getMergeMessageTemplate(baseGitRepo *git.Repository, pr *issues_model.PullRequest, mergeStyle repo_model.MergeStyle) (string, error) {
+	if mergeStyle == "" {
+		return "", nil
+	}
+
+	prUnit, err := pr.BaseRepo.GetUnit(unit.TypePullRequests)
+	if err != nil && !repo_model.IsErrUnitTypeNotExist(err) {
+		return "", err
+	} else if err == nil {
+		if tmpl := prUnit.PullRequestsConfig().GetMergeMessageTemplate(mergeStyle); tmpl != "" {
+			return tmpl, nil
+		}
+	}
+
+	templateFilepath := fmt.Sprintf(".gitea/default_merge_message/%s_TEMPLATE.md", strings.ToUpper(string(mergeStyle)))
+	commit, err := baseGitRepo.GetBranchCommit(pr.BaseRepo.DefaultBranch)
+	if err != nil {
+		return "", err
+	}
+	templateContent, err := commit.GetFileContent(templateFilepath, setting.Repository.PullRequest.DefaultMergeMessageSize)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return templateContent, nil
+}
+
 // GetDefaultMergeMessage returns default message used when merging pull request
 func GetDefaultMergeMessage(baseGitRepo *git.Repository, pr *issues_model.PullRequest, mergeStyle repo_model.MergeStyle) (string, error) {
 	if err := pr.LoadHeadRepo(); err != nil {
@@ -53,6 +103,9 @@ func GetDefaultMergeMessage(baseGitRepo *git.Repository, pr *issues_model.PullRe
 	if err := pr.LoadIssue(); err != nil {
 		return "", err
 	}
+	if err := pr.Issue.LoadPoster(); err != nil {
+		return "", err
+	}
 
 	isExternalTracker := pr.BaseRepo.UnitEnabled(unit.TypeExternalTracker)
 	issueReference := "#"
@@ -60,58 +113,57 @@ func GetDefaultMergeMessage(baseGitRepo *git.Repository, pr *issues_model.PullRe
 		issueReference = "!"
 	}
 
-	if mergeStyle != "" {
-		templateFilepath := fmt.Sprintf(".gitea/default_merge_message/%s_TEMPLATE.md", strings.ToUpper(string(mergeStyle)))
-		commit, err := baseGitRepo.GetBranchCommit(pr.BaseRepo.DefaultBranch)
-		if err != nil {
-			return "", err
-		}
-		templateContent, err := commit.GetFileContent(templateFilepath, setting.Repository.PullRequest.DefaultMergeMessageSize)
-		if err != nil {
-			if !git.IsErrNotExist(err) {
-				return "", err
-			}
-		} else {
-			vars := map[string]string{
-				"BaseRepoOwnerName":      pr.BaseRepo.OwnerName,
-				"BaseRepoName":           pr.BaseRepo.Name,
-				"BaseBranch":             pr.BaseBranch,
-				"HeadRepoOwnerName":      "",
-				"HeadRepoName":           "",
-				"HeadBranch":             pr.HeadBranch,
-				"PullRequestTitle":       pr.Issue.Title,
-				"PullRequestDescription": pr.Issue.Content,
-				"PullRequestPosterName":  pr.Issue.Poster.Name,
-				"PullRequestIndex":       strconv.FormatInt(pr.Index, 10),
-				"PullRequestReference":   fmt.Sprintf("%s%d", issueReference, pr.Index),
-			}
-			if pr.HeadRepo != nil {
-				vars["HeadRepoOwnerName"] = pr.HeadRepo.OwnerName
-				vars["HeadRepoName"] = pr.HeadRepo.Name
+	templateContent, err := getMergeMessageTemplate(baseGitRepo, pr, mergeStyle)
+	if err != nil {
+		return "", err
+	}
+	if templateContent != "" {
+		vars := map[string]string{
+			"BaseRepoOwnerName":      pr.BaseRepo.OwnerName,
+			"BaseRepoName":           pr.BaseRepo.Name,
+			"BaseBranch":             pr.BaseBranch,
+			"HeadRepoOwnerName":      "",
+			"HeadRepoName":           "",
+			"HeadBranch":             pr.HeadBranch,
+			"PullRequestTitle":       pr.Issue.Title,
+			"PullRequestDescription": pr.Issue.Content,
+			"PullRequestPosterName":  pr.Issue.Poster.Name,
+			"PullRequestIndex":       strconv.FormatInt(pr.Index, 10),
+			"PullRequestReference":   fmt.Sprintf("%s%d", issueReference, pr.Index),
+		}
+		if pr.HeadRepo != nil {
+			vars["HeadRepoOwnerName"] = pr.HeadRepo.OwnerName
+			vars["HeadRepoName"] = pr.HeadRepo.Name
+		}
+		refs, err := pr.ResolveCrossReferences(baseGitRepo.Ctx)
+		if err == nil {
+			closeIssueIndexes := make([]string, 0, len(refs))
+			closeWord := "close"
+			if len(setting.Repository.PullRequest.CloseKeywords) > 0 {
+				closeWord = setting.Repository.PullRequest.CloseKeywords[0]
 			}
-			refs, err := pr.ResolveCrossReferences(baseGitRepo.Ctx)
-			if err == nil {
-				closeIssueIndexes := make([]string, 0, len(refs))
-				closeWord := "close"
-				if len(setting.Repository.PullRequest.CloseKeywords) > 0 {
-					closeWord = setting.Repository.PullRequest.CloseKeywords[0]
-				}
-				for _, ref := range refs {
-					if ref.RefAction == references.XRefActionCloses {
-						closeIssueIndexes = append(closeIssueIndexes, fmt.Sprintf("%s %s%d", closeWord, issueReference, ref.Issue.Index))
-					}
-				}
-				if len(closeIssueIndexes) > 0 {
-					vars["ClosingIssues"] = strings.Join(closeIssueIndexes, ", ")
-				} else {
-					vars["ClosingIssues"] = ""
+			for _, ref := range refs {
+				if ref.RefAction == references.XRefActionCloses {
+					closeIssueIndexes = append(closeIssueIndexes, fmt.Sprintf("%s %s%d", closeWord, issueReference, ref.Issue.Index))
 				}
 			}
-
-			return os.Expand(templateContent, func(s string) string {
-				return vars[s]
-			}), nil
+			if len(closeIssueIndexes) > 0 {
+				vars["ClosingIssues"] = strings.Join(closeIssueIndexes, ", ")
+			} else {
+				vars["ClosingIssues"] = ""
+			}
 		}
+
+		return os.Expand(templateContent, func(s string) string {
+			// the co-authors and the approvers need to walk the commits and the reviews, only do it when used
+			switch s {
+			case "PullRequestCoAuthors":
+				return getPullRequestCoAuthors(baseGitRepo.Ctx, pr)
+			case "PullRequestApprovers":
+				return strings.TrimSuffix(pr.GetApprovers(), "\n")
+			}
+			return vars[s]
+		}), nil
 	}
 
 	// Squash merge has a different from other styles.
@@ -130,6 +182,63 @@ func GetDefaultMergeMessage(baseGitRepo *git.Repository, pr *issues_model.PullRe
 	return fmt.Sprintf("Merge pull request '%s' (%s%d) from %s:%s into %s", pr.Issue.Title, issueReference, pr.Issue.Index, pr.HeadRepo.FullName(), pr.HeadBranch, pr.BaseBranch), nil
 }
 
+// getPullRequestCoAuthors returns the Co-authored-by trailers of the authors of the commits of a pull request
+func getPullRequestCoAuthors(ctx context.Context, pr *issues_model.PullRequest) string {
+	lines := strings.Split(GetSquashMergeCommitMessages(ctx, pr), "\n")
+	coAuthors := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.HasPrefix(line, "Co-authored-by: ") {
+			coAuthors = append(coAuthors, line)
+		}
+	}
+	return strings.Join(coAuthors, "\n")
+}
+
+// IsMergeMessageTemplateEnforced returns whether the title of the message of a merge with the given merge style
+// has to be the one generated by the merge message template of the repository
+func IsMergeMessageTemplateEnforced(baseGitRepo *git.Repository, pr *issues_model.PullRequest, mergeStyle repo_model.MergeStyle) (bool, error) {
+	if mergeStyle == repo_model.MergeStyleRebase || mergeStyle == repo_model.MergeStyleManuallyMerged {
+		return false, nil
+	}
+	if err := pr.LoadBaseRepo(); err != nil {
+		return false, err
+	}
+	prUnit, err := pr.BaseRepo.GetUnit(unit.TypePullRequests)
+	if err != nil {
+		return false, err
+	}
+	if !prUnit.PullRequestsConfig().EnforceMergeMessageTemplate {
+		return false, nil
+	}
+
+	templateContent, err := getMergeMessageTemplate(baseGitRepo, pr, mergeStyle)
+	if err != nil {
+		return false, err
+	}
+	return templateContent != "", nil
+}
+
+// CheckMergeMessage checks the message of a merge against the merge message template of the repository:
+// when the repository enforces its template, the commit title has to be the one of the template, only
+// the rest of the message can be edited
+func CheckMergeMessage(baseGitRepo *git.Repository, pr *issues_model.PullRequest, mergeStyle repo_model.MergeStyle, message string) error {
+	if enforced, err := IsMergeMessageTemplateEnforced(baseGitRepo, pr, mergeStyle); err != nil || !enforced {
+		return err
+	}
+	defaultMessage, err := GetDefaultMergeMessage(baseGitRepo, pr, mergeStyle)
+	if err != nil {
+		return err
+	}
+
+	expected, _, _ := strings.Cut(strings.TrimSpace(defaultMessage), "\n")
+	actual, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	expected, actual = strings.TrimSpace(expected), strings.TrimSpace(actual)
+	if expected != actual {
+		return ErrMergeMessageNotMatchTemplate{Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
 // Merge merges pull request to base repository.
 // Caller should check PR is ready to be merged (review and status checks)
 func Merge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, baseGitRepo *git.Repository, mergeStyle repo_model.MergeStyle, expectedHeadCommitID, message string) error {
@@ -255,104 +364,11 @@ func rawMerge(ctx context.Context, pr *issues_model.PullRequest, doer *user_mode
 		}
 	}
 
-	var outbuf, errbuf strings.Builder
-
-	// Enable sparse-checkout
-	sparseCheckoutList, err := getDiffTree(ctx, tmpBasePath, baseBranch, trackingBranch)
-	if err != nil {
-		log.Error("getDiffTree(%s, %s, %s): %v", tmpBasePath, baseBranch, trackingBranch, err)
-		return "", fmt.Errorf("getDiffTree: %v", err)
-	}
-
-	infoPath := filepath.Join(tmpBasePath, ".git", "info")
-	if err := os.MkdirAll(infoPath, 0o700); err != nil {
-		log.Error("Unable to create .git/info in %s: %v", tmpBasePath, err)
-		return "", fmt.Errorf("Unable to create .git/info in tmpBasePath: %v", err)
-	}
-
-	sparseCheckoutListPath := filepath.Join(infoPath, "sparse-checkout")
-	if err := os.WriteFile(sparseCheckoutListPath, []byte(sparseCheckoutList), 0o600); err != nil {
-		log.Error("Unable to write .git/info/sparse-checkout file in %s: %v", tmpBasePath, err)
-		return "", fmt.Errorf("Unable to write .git/info/sparse-checkout file in tmpBasePath: %v", err)
-	}
-
-	gitConfigCommand := func() *git.Command {
-		return git.NewCommand(ctx, "config", "--local")
-	}
-
-	// Switch off LFS process (set required, clean and smudge here also)
-	if err := gitConfigCommand().AddArguments("filter.lfs.process", "").
-		Run(&git.RunOpts{
-			Dir:    tmpBasePath,
-			Stdout: &outbuf,
-			Stderr: &errbuf,
-		}); err != nil {
-		log.Error("git config [filter.lfs.process -> <> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
-		return "", fmt.Errorf("git config [filter.lfs.process -> <> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
-	}
-	outbuf.Reset()
-	errbuf.Reset()
-
-	if err := gitConfigCommand().AddArguments("filter.lfs.required", "false").
-		Run(&git.RunOpts{
-			Dir:    tmpBasePath,
-			Stdout: &outbuf,
-			Stderr: &errbuf,
-		}); err != nil {
-		log.Error("git config [filter.lfs.required -> <false> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
-		return "", fmt.Errorf("git config [filter.lfs.required -> <false> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
-	}
-	outbuf.Reset()
-	errbuf.Reset()
-
-	if err := gitConfigCommand().AddArguments("filter.lfs.clean", "").
-		Run(&git.RunOpts{
-			Dir:    tmpBasePath,
-			Stdout: &outbuf,
-			Stderr: &errbuf,
-		}); err != nil {
-		log.Error("git config [filter.lfs.clean -> <> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
-		return "", fmt.Errorf("git config [filter.lfs.clean -> <> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
-	}
-	outbuf.Reset()
-	errbuf.Reset()
-
-	if err := gitConfigCommand().AddArguments("filter.lfs.smudge", "").
-		Run(&git.RunOpts{
-			Dir:    tmpBasePath,
-			Stdout: &outbuf,
-			Stderr: &errbuf,
-		}); err != nil {
-		log.Error("git config [filter.lfs.smudge -> <> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
-		return "", fmt.Errorf("git config [filter.lfs.smudge -> <> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
-	}
-	outbuf.Reset()
-	errbuf.Reset()
-
-	if err := gitConfigCommand().AddArguments("core.sparseCheckout", "true").
-		Run(&git.RunOpts{
-			Dir:    tmpBasePath,
-			Stdout: &outbuf,
-			Stderr: &errbuf,
-		}); err != nil {
-		log.Error("git config [core.sparseCheckout -> true ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
-		return "", fmt.Errorf("git config [core.sparsecheckout -> true]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+	if err := prepareMergeWorkTree(ctx, tmpBasePath, baseBranch, trackingBranch); err != nil {
+		return "", err
 	}
-	outbuf.Reset()
-	errbuf.Reset()
 
-	// Read base branch index
-	if err := git.NewCommand(ctx, "read-tree", "HEAD").
-		Run(&git.RunOpts{
-			Dir:    tmpBasePath,
-			Stdout: &outbuf,
-			Stderr: &errbuf,
-		}); err != nil {
-		log.Error("git read-tree HEAD: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
-		return "", fmt.Errorf("Unable to read base branch in to the index: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
-	}
-	outbuf.Reset()
-	errbuf.Reset()
+	var outbuf, errbuf strings.Builder
 
 	sig := doer.NewGitSig()
 	committer := sig
@@ -627,6 +643,108 @@ func rawMerge(ctx context.Context, pr *issues_model.PullRequest, doer *user_mode
 	return mergeCommitID, nil
 }
 
+// prepareMergeWorkTree sets up the sparse checkout of the files changed between the base and the tracking branches of a
+// temporary repository, with LFS switched off, and reads the base branch into the index
+func prepareMergeWorkTree(ctx context.Context, tmpBasePath, baseBranch, trackingBranch string) error {
+	var outbuf, errbuf strings.Builder
+
+	// Enable sparse-checkout
+	sparseCheckoutList, err := getDiffTree(ctx, tmpBasePath, baseBranch, trackingBranch)
+	if err != nil {
+		log.Error("getDiffTree(%s, %s, %s): %v", tmpBasePath, baseBranch, trackingBranch, err)
+		return fmt.Errorf("getDiffTree: %v", err)
+	}
+
+	infoPath := filepath.Join(tmpBasePath, ".git", "info")
+	if err := os.MkdirAll(infoPath, 0o700); err != nil {
+		log.Error("Unable to create .git/info in %s: %v", tmpBasePath, err)
+		return fmt.Errorf("Unable to create .git/info in tmpBasePath: %v", err)
+	}
+
+	sparseCheckoutListPath := filepath.Join(infoPath, "sparse-checkout")
+	if err := os.WriteFile(sparseCheckoutListPath, []byte(sparseCheckoutList), 0o600); err != nil {
+		log.Error("Unable to write .git/info/sparse-checkout file in %s: %v", tmpBasePath, err)
+		return fmt.Errorf("Unable to write .git/info/sparse-checkout file in tmpBasePath: %v", err)
+	}
+
+	gitConfigCommand := func() *git.Command {
+		return git.NewCommand(ctx, "config", "--local")
+	}
+
+	// Switch off LFS process (set required, clean and smudge here also)
+	if err := gitConfigCommand().AddArguments("filter.lfs.process", "").
+		Run(&git.RunOpts{
+			Dir:    tmpBasePath,
+			Stdout: &outbuf,
+			Stderr: &errbuf,
+		}); err != nil {
+		log.Error("git config [filter.lfs.process -> <> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+		return fmt.Errorf("git config [filter.lfs.process -> <> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+	}
+	outbuf.Reset()
+	errbuf.Reset()
+
+	if err := gitConfigCommand().AddArguments("filter.lfs.required", "false").
+		Run(&git.RunOpts{
+			Dir:    tmpBasePath,
+			Stdout: &outbuf,
+			Stderr: &errbuf,
+		}); err != nil {
+		log.Error("git config [filter.lfs.required -> <false> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+		return fmt.Errorf("git config [filter.lfs.required -> <false> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+	}
+	outbuf.Reset()
+	errbuf.Reset()
+
+	if err := gitConfigCommand().AddArguments("filter.lfs.clean", "").
+		Run(&git.RunOpts{
+			Dir:    tmpBasePath,
+			Stdout: &outbuf,
+			Stderr: &errbuf,
+		}); err != nil {
+		log.Error("git config [filter.lfs.clean -> <> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+		return fmt.Errorf("git config [filter.lfs.clean -> <> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+	}
+	outbuf.Reset()
+	errbuf.Reset()
+
+	if err := gitConfigCommand().AddArguments("filter.lfs.smudge", "").
+		Run(&git.RunOpts{
+			Dir:    tmpBasePath,
+			Stdout: &outbuf,
+			Stderr: &errbuf,
+		}); err != nil {
+		log.Error("git config [filter.lfs.smudge -> <> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+		return fmt.Errorf("git config [filter.lfs.smudge -> <> ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+	}
+	outbuf.Reset()
+	errbuf.Reset()
+
+	if err := gitConfigCommand().AddArguments("core.sparseCheckout", "true").
+		Run(&git.RunOpts{
+			Dir:    tmpBasePath,
+			Stdout: &outbuf,
+			Stderr: &errbuf,
+		}); err != nil {
+		log.Error("git config [core.sparseCheckout -> true ]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+		return fmt.Errorf("git config [core.sparsecheckout -> true]: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+	}
+	outbuf.Reset()
+	errbuf.Reset()
+
+	// Read base branch index
+	if err := git.NewCommand(ctx, "read-tree", "HEAD").
+		Run(&git.RunOpts{
+			Dir:    tmpBasePath,
+			Stdout: &outbuf,
+			Stderr: &errbuf,
+		}); err != nil {
+		log.Error("git read-tree HEAD: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+		return fmt.Errorf("Unable to read base branch in to the index: %v\n%s\n%s", err, outbuf.String(), errbuf.String())
+	}
+	return nil
+}
+
 func commitAndSignNoAuthor(ctx context.Context, pr *issues_model.PullRequest, message, signArg, tmpBasePath string, env []string) error {
 	var outbuf, errbuf strings.Builder
 	if signArg == "" {
@@ -801,6 +919,26 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 		}
 	}
 
+	newAlerts, err := codescanning_service.MergeBlockedByCodeScanningAlerts(ctx, pr)
+	if err != nil {
+		return err
+	}
+	if newAlerts > 0 {
+		return models.ErrDisallowedToMerge{
+			Reason: "There are new code scanning alerts",
+		}
+	}
+
+	blockedByCoverage, _, err := coverage_service.MergeBlockedByDiffCoverage(ctx, pr)
+	if err != nil {
+		return err
+	}
+	if blockedByCoverage {
+		return models.ErrDisallowedToMerge{
+			Reason: "The test coverage of the added lines is below the required minimum",
+		}
+	}
+
 	if skipProtectedFilesCheck {
 		return nil
 	}
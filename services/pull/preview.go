@@ -0,0 +1,206 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	repo_module "code.gitea.io/gitea/modules/repository"
+)
+
+// MergePreview is what merging a pull request with a merge style would produce
+type MergePreview struct {
+	Style repo_model.MergeStyle
+	// Mergeable is false if the merge conflicts or the branches have no common history
+	Mergeable bool
+	// CommitCount is the number of commits the merge adds to the base branch
+	CommitCount int
+	// ConflictedFiles are the files which conflict
+	ConflictedFiles []string
+	// ConflictCommitSHA is the commit of the head branch which fails to be rebased
+	ConflictCommitSHA string
+}
+
+// PreviewMerge merges a pull request with each of the merge styles in a temporary repository without pushing the
+// result, to report the commits it would add to the base branch and its conflicts. The manually merged style is skipped.
+func PreviewMerge(ctx context.Context, pr *issues_model.PullRequest, styles []repo_model.MergeStyle) ([]*MergePreview, error) {
+	tmpBasePath, err := createTemporaryRepo(ctx, pr)
+	if err != nil {
+		log.Error("CreateTemporaryPath: %v", err)
+		return nil, err
+	}
+	defer func() {
+		if err := repo_module.RemoveTemporaryPath(tmpBasePath); err != nil {
+			log.Error("PreviewMerge: RemoveTemporaryPath: %s", err)
+		}
+	}()
+
+	baseBranch := "base"
+	trackingBranch := "tracking"
+	stagingBranch := "staging"
+
+	if err := prepareMergeWorkTree(ctx, tmpBasePath, baseBranch, trackingBranch); err != nil {
+		return nil, err
+	}
+
+	var merge, rebase *MergePreview
+	for _, style := range styles {
+		switch style {
+		case repo_model.MergeStyleMerge, repo_model.MergeStyleSquash:
+			merge = &MergePreview{}
+		case repo_model.MergeStyleRebase, repo_model.MergeStyleRebaseMerge:
+			rebase = &MergePreview{}
+		}
+	}
+	// the merge is tried on the base branch before the rebase moves to the staging branch
+	if merge != nil {
+		if merge.CommitCount, err = countCommits(ctx, tmpBasePath, baseBranch, trackingBranch); err != nil {
+			return nil, err
+		}
+		if err := previewMerge(ctx, pr, merge, tmpBasePath, trackingBranch); err != nil {
+			return nil, err
+		}
+	}
+	if rebase != nil {
+		if err := previewRebase(ctx, pr, rebase, tmpBasePath, baseBranch, trackingBranch, stagingBranch); err != nil {
+			return nil, err
+		}
+	}
+
+	previews := make([]*MergePreview, 0, len(styles))
+	for _, style := range styles {
+		var result *MergePreview
+		switch style {
+		case repo_model.MergeStyleMerge, repo_model.MergeStyleSquash:
+			result = merge
+		case repo_model.MergeStyleRebase, repo_model.MergeStyleRebaseMerge:
+			result = rebase
+		default:
+			continue
+		}
+
+		preview := &MergePreview{
+			Style:             style,
+			Mergeable:         result.Mergeable,
+			ConflictedFiles:   result.ConflictedFiles,
+			ConflictCommitSHA: result.ConflictCommitSHA,
+		}
+		if preview.Mergeable {
+			switch style {
+			case repo_model.MergeStyleMerge, repo_model.MergeStyleRebaseMerge:
+				// the commits of the head branch and the merge commit
+				preview.CommitCount = result.CommitCount + 1
+			case repo_model.MergeStyleRebase:
+				preview.CommitCount = result.CommitCount
+			case repo_model.MergeStyleSquash:
+				preview.CommitCount = 1
+			}
+		}
+		previews = append(previews, preview)
+	}
+	return previews, nil
+}
+
+// previewMerge merges the tracking branch into the base branch then aborts the merge, the preview is not mergeable if
+// it conflicts
+func previewMerge(ctx context.Context, pr *issues_model.PullRequest, preview *MergePreview, tmpBasePath, trackingBranch string) error {
+	cmd := git.NewCommand(ctx, "merge", "--no-ff", "--no-commit", trackingBranch)
+	err := runMergeCommand(pr, repo_model.MergeStyleMerge, cmd, tmpBasePath)
+	switch {
+	case err == nil:
+		preview.Mergeable = true
+	case models.IsErrMergeConflicts(err):
+		if preview.ConflictedFiles, err = getUnmergedFiles(ctx, tmpBasePath); err != nil {
+			return err
+		}
+	case models.IsErrMergeUnrelatedHistories(err):
+		return nil
+	default:
+		return err
+	}
+
+	if err := git.NewCommand(ctx, "merge", "--abort").Run(&git.RunOpts{Dir: tmpBasePath}); err != nil {
+		return fmt.Errorf("git merge --abort: %w", err)
+	}
+	return nil
+}
+
+// previewRebase rebases the tracking branch on the base branch as the staging branch, the preview is not mergeable if
+// a commit fails to apply
+func previewRebase(ctx context.Context, pr *issues_model.PullRequest, preview *MergePreview, tmpBasePath, baseBranch, trackingBranch, stagingBranch string) error {
+	var outbuf, errbuf strings.Builder
+	if err := git.NewCommand(ctx, "checkout", "-b", stagingBranch, trackingBranch).
+		Run(&git.RunOpts{
+			Dir:    tmpBasePath,
+			Stdout: &outbuf,
+			Stderr: &errbuf,
+		}); err != nil {
+		return fmt.Errorf("git checkout staging [%s:%s -> %s:%s]: %v\n%s\n%s", pr.HeadRepo.FullName(), pr.HeadBranch, pr.BaseRepo.FullName(), pr.BaseBranch, err, outbuf.String(), errbuf.String())
+	}
+	outbuf.Reset()
+	errbuf.Reset()
+
+	if err := git.NewCommand(ctx, "rebase", baseBranch).
+		Run(&git.RunOpts{
+			Dir:    tmpBasePath,
+			Stdout: &outbuf,
+			Stderr: &errbuf,
+		}); err != nil {
+		// Rebase will leave a REBASE_HEAD file in .git if there is a conflict
+		if _, statErr := os.Stat(filepath.Join(tmpBasePath, ".git", "REBASE_HEAD")); statErr != nil {
+			return fmt.Errorf("git rebase staging on to base [%s:%s -> %s:%s]: %v\n%s\n%s", pr.HeadRepo.FullName(), pr.HeadBranch, pr.BaseRepo.FullName(), pr.BaseBranch, err, outbuf.String(), errbuf.String())
+		}
+		for _, failingCommitPath := range []string{
+			filepath.Join(tmpBasePath, ".git", "rebase-apply", "original-commit"), // Git < 2.26
+			filepath.Join(tmpBasePath, ".git", "rebase-merge", "stopped-sha"),     // Git >= 2.26
+		} {
+			if commitSHA, err := os.ReadFile(failingCommitPath); err == nil {
+				preview.ConflictCommitSHA = strings.TrimSpace(string(commitSHA))
+				break
+			}
+		}
+		preview.ConflictedFiles, err = getUnmergedFiles(ctx, tmpBasePath)
+		return err
+	}
+
+	preview.Mergeable = true
+	var err error
+	preview.CommitCount, err = countCommits(ctx, tmpBasePath, baseBranch, stagingBranch)
+	return err
+}
+
+// countCommits returns the number of commits of the head which aren't in the base
+func countCommits(ctx context.Context, tmpBasePath, base, head string) (int, error) {
+	stdout, _, err := git.NewCommand(ctx, "rev-list", "--count", base+".."+head).RunStdString(&git.RunOpts{Dir: tmpBasePath})
+	if err != nil {
+		return 0, fmt.Errorf("git rev-list --count %s..%s: %w", base, head, err)
+	}
+	return strconv.Atoi(strings.TrimSpace(stdout))
+}
+
+// getUnmergedFiles returns the files left unmerged by a conflicting merge or rebase
+func getUnmergedFiles(ctx context.Context, tmpBasePath string) ([]string, error) {
+	stdout, _, err := git.NewCommand(ctx, "diff", "--name-only", "--diff-filter=U", "-z").RunStdString(&git.RunOpts{Dir: tmpBasePath})
+	if err != nil {
+		return nil, fmt.Errorf("git diff --diff-filter=U: %w", err)
+	}
+	files := make([]string, 0, strings.Count(stdout, "\x00"))
+	for _, file := range strings.Split(stdout, "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package pull
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreviewMerge(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	// branch2 has 2 commits ahead of master
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	previews, err := PreviewMerge(db.DefaultContext, pr, []repo_model.MergeStyle{
+		repo_model.MergeStyleRebase,
+		repo_model.MergeStyleMerge,
+		repo_model.MergeStyleManuallyMerged,
+		repo_model.MergeStyleSquash,
+		repo_model.MergeStyleRebaseMerge,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*MergePreview{
+		{Style: repo_model.MergeStyleRebase, Mergeable: true, CommitCount: 2},
+		{Style: repo_model.MergeStyleMerge, Mergeable: true, CommitCount: 3},
+		{Style: repo_model.MergeStyleSquash, Mergeable: true, CommitCount: 1},
+		{Style: repo_model.MergeStyleRebaseMerge, Mergeable: true, CommitCount: 3},
+	}, previews)
+}
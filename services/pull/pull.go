@@ -288,6 +288,9 @@ func AddTestPullRequestTask(doer *user_model.User, repoID int64, branch string,
 						if err := issues_model.MarkReviewsAsNotStale(pr.IssueID, newCommitID); err != nil {
 							log.Error("MarkReviewsAsNotStale: %v", err)
 						}
+						if err := dismissPusherApprovals(ctx, pr, doer); err != nil {
+							log.Error("dismissPusherApprovals: %v", err)
+						}
 						divergence, err := GetDiverging(ctx, pr)
 						if err != nil {
 							log.Error("GetDiverging: %v", err)
@@ -89,3 +89,41 @@ func TestPullRequest_GetDefaultMergeMessage_ExternalTracker(t *testing.T) {
 
 	assert.Equal(t, "Merge pull request 'issue3' (#3) from user2/repo2:branch2 into master", mergeMessage)
 }
+
+func TestPullRequest_GetDefaultMergeMessage_Template(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+
+	assert.NoError(t, pr.LoadBaseRepo())
+	gitRepo, err := git.OpenRepository(git.DefaultContext, pr.BaseRepo.RepoPath())
+	assert.NoError(t, err)
+	defer gitRepo.Close()
+
+	prUnit, err := pr.BaseRepo.GetUnit(unit.TypePullRequests)
+	assert.NoError(t, err)
+	prConfig := prUnit.PullRequestsConfig()
+	prConfig.DefaultMergeMessageTemplate = "Merge ${PullRequestReference} from ${HeadBranch}\n\n${PullRequestTitle} by ${PullRequestPosterName}"
+	prConfig.DefaultSquashMessageTemplate = "${PullRequestTitle} (${PullRequestReference})"
+
+	mergeMessage, err := GetDefaultMergeMessage(gitRepo, pr, repo_model.MergeStyleMerge)
+	assert.NoError(t, err)
+	assert.Equal(t, "Merge #3 from branch2\n\nissue3 by user1", mergeMessage)
+
+	mergeMessage, err = GetDefaultMergeMessage(gitRepo, pr, repo_model.MergeStyleRebaseMerge)
+	assert.NoError(t, err)
+	assert.Equal(t, "Merge #3 from branch2\n\nissue3 by user1", mergeMessage)
+
+	mergeMessage, err = GetDefaultMergeMessage(gitRepo, pr, repo_model.MergeStyleSquash)
+	assert.NoError(t, err)
+	assert.Equal(t, "issue3 (#3)", mergeMessage)
+
+	// the title can be changed as long as the template is not enforced
+	assert.NoError(t, CheckMergeMessage(gitRepo, pr, repo_model.MergeStyleMerge, "Another title"))
+
+	prConfig.EnforceMergeMessageTemplate = true
+	assert.NoError(t, CheckMergeMessage(gitRepo, pr, repo_model.MergeStyleMerge, "Merge #3 from branch2\n\nAnother body"))
+	assert.NoError(t, CheckMergeMessage(gitRepo, pr, repo_model.MergeStyleRebase, "Another title"))
+	err = CheckMergeMessage(gitRepo, pr, repo_model.MergeStyleSquash, "Another title\n\nissue3 (#3)")
+	assert.True(t, IsErrMergeMessageNotMatchTemplate(err))
+	assert.Equal(t, "issue3 (#3)", err.(ErrMergeMessageNotMatchTemplate).Expected)
+}
@@ -271,6 +271,29 @@ func SubmitReview(ctx context.Context, doer *user_model.User, gitRepo *git.Repos
 	return review, comm, nil
 }
 
+// dismissPusherApprovals dismisses the approvals of the user who pushed to the pull request
+// if the protected branch doesn't let the pushers approve their own changes
+func dismissPusherApprovals(ctx context.Context, pr *issues_model.PullRequest, pusher *user_model.User) error {
+	if pusher == nil {
+		return nil
+	}
+	if err := pr.LoadProtectedBranchCtx(ctx); err != nil {
+		return err
+	}
+	if pr.ProtectedBranch == nil || !pr.ProtectedBranch.DismissPusherApprovals {
+		return nil
+	}
+
+	dismissed, err := issues_model.DismissApprovalsByReviewer(ctx, pr.IssueID, pusher.ID)
+	if err != nil {
+		return err
+	}
+	if dismissed > 0 {
+		log.Trace("Dismissed %d approvals of pusher[%d] on PR[%d]", dismissed, pusher.ID, pr.ID)
+	}
+	return nil
+}
+
 // DismissReview dismissing stale review by repo admin
 func DismissReview(ctx context.Context, reviewID, repoID int64, message string, doer *user_model.User, isDismiss, dismissPriors bool) (comment *issues_model.Comment, err error) {
 	review, err := issues_model.GetReviewByID(ctx, reviewID)
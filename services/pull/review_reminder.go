@@ -0,0 +1,214 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/notification"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+const day = 24 * time.Hour
+
+// reviewPolicies caches the pull request config of the repositories visited during a run
+type reviewPolicies map[int64]*repo_model.PullRequestsConfig
+
+func (p reviewPolicies) get(ctx context.Context, issue *issues_model.Issue) *repo_model.PullRequestsConfig {
+	if cfg, ok := p[issue.RepoID]; ok {
+		return cfg
+	}
+	var cfg *repo_model.PullRequestsConfig
+	if err := issue.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo[%d]: %v", issue.RepoID, err)
+	} else if prUnit, err := issue.Repo.GetUnitCtx(ctx, unit.TypePullRequests); err == nil {
+		cfg = prUnit.PullRequestsConfig()
+	}
+	p[issue.RepoID] = cfg
+	return cfg
+}
+
+func daysAgo(days int) timeutil.TimeStamp {
+	return timeutil.TimeStamp(time.Now().Add(-time.Duration(days) * day).Unix())
+}
+
+// ProcessReviewPolicies reminds requested reviewers, escalates long pending review requests
+// and dismisses expired approvals according to the review policies of the repositories
+func ProcessReviewPolicies(ctx context.Context) error {
+	if err := remindPendingReviewRequests(ctx); err != nil {
+		return err
+	}
+	return dismissExpiredApprovals(ctx)
+}
+
+func remindPendingReviewRequests(ctx context.Context) error {
+	// no policy can act on requests younger than a day
+	reviews, err := issues_model.FindPendingReviewRequests(ctx, daysAgo(1))
+	if err != nil {
+		return err
+	}
+
+	policies := make(reviewPolicies)
+	for _, review := range reviews {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("aborted review reminders before review request %d", review.ID)
+		default:
+		}
+
+		if err := review.LoadAttributes(ctx); err != nil {
+			log.Error("LoadAttributes[%d]: %v", review.ID, err)
+			continue
+		}
+		cfg := policies.get(ctx, review.Issue)
+		if cfg == nil {
+			continue
+		}
+
+		if cfg.ReviewEscalationDays > 0 && review.EscalatedUnix == 0 && review.CreatedUnix < daysAgo(cfg.ReviewEscalationDays) {
+			if err := escalateReviewRequest(ctx, review); err != nil {
+				log.Error("escalateReviewRequest[%d]: %v", review.ID, err)
+			}
+			continue
+		}
+
+		if cfg.ReviewReminderDays > 0 && review.CreatedUnix < daysAgo(cfg.ReviewReminderDays) &&
+			(review.RemindedUnix == 0 || review.RemindedUnix < daysAgo(cfg.ReviewReminderDays)) {
+			if err := remindReviewRequest(ctx, review); err != nil {
+				log.Error("remindReviewRequest[%d]: %v", review.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// requestedReviewers returns the users a review request is waiting for
+func requestedReviewers(ctx context.Context, review *issues_model.Review) ([]*user_model.User, error) {
+	if review.ReviewerTeam != nil {
+		if err := review.ReviewerTeam.GetMembersCtx(ctx); err != nil {
+			return nil, err
+		}
+		return review.ReviewerTeam.Members, nil
+	}
+	if review.Reviewer != nil {
+		return []*user_model.User{review.Reviewer}, nil
+	}
+	return nil, nil
+}
+
+func remindReviewRequest(ctx context.Context, review *issues_model.Review) error {
+	reviewers, err := requestedReviewers(ctx, review)
+	if err != nil {
+		return err
+	}
+	if err := review.Issue.LoadPoster(); err != nil {
+		return err
+	}
+	for _, reviewer := range reviewers {
+		notification.NotifyPullReviewReminder(review.Issue.Poster, review.Issue, reviewer, false)
+	}
+	return issues_model.SetReviewReminded(ctx, review)
+}
+
+// escalationReceivers returns the users a pending review request is escalated to: the teams of the
+// requested reviewer which have access to the repository, or the owners of the organization for team requests
+func escalationReceivers(ctx context.Context, review *issues_model.Review) ([]*user_model.User, error) {
+	repo := review.Issue.Repo
+	if err := repo.GetOwner(ctx); err != nil {
+		return nil, err
+	}
+	if !repo.Owner.IsOrganization() {
+		if review.Reviewer != nil && review.Reviewer.ID == repo.OwnerID {
+			return nil, nil
+		}
+		return []*user_model.User{repo.Owner}, nil
+	}
+
+	var teams []*organization.Team
+	if review.ReviewerTeam != nil {
+		owners, err := organization.GetOwnerTeam(ctx, repo.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, owners)
+	} else if review.Reviewer != nil {
+		var err error
+		if teams, err = organization.GetUserRepoTeams(ctx, repo.OwnerID, review.Reviewer.ID, repo.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[int64]bool)
+	if review.Reviewer != nil {
+		seen[review.Reviewer.ID] = true
+	}
+	receivers := make([]*user_model.User, 0, 10)
+	for _, team := range teams {
+		if err := team.GetMembersCtx(ctx); err != nil {
+			return nil, err
+		}
+		for _, member := range team.Members {
+			if !seen[member.ID] {
+				seen[member.ID] = true
+				receivers = append(receivers, member)
+			}
+		}
+	}
+	return receivers, nil
+}
+
+func escalateReviewRequest(ctx context.Context, review *issues_model.Review) error {
+	receivers, err := escalationReceivers(ctx, review)
+	if err != nil {
+		return err
+	}
+	if err := review.Issue.LoadPoster(); err != nil {
+		return err
+	}
+	for _, receiver := range receivers {
+		notification.NotifyPullReviewReminder(review.Issue.Poster, review.Issue, receiver, true)
+	}
+	return issues_model.SetReviewEscalated(ctx, review)
+}
+
+func dismissExpiredApprovals(ctx context.Context) error {
+	reviews, err := issues_model.FindApprovalsBefore(ctx, daysAgo(1))
+	if err != nil {
+		return err
+	}
+
+	policies := make(reviewPolicies)
+	doer := user_model.NewGhostUser()
+	for _, review := range reviews {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("aborted approval expiry before review %d", review.ID)
+		default:
+		}
+
+		if err := review.LoadAttributes(ctx); err != nil {
+			log.Error("LoadAttributes[%d]: %v", review.ID, err)
+			continue
+		}
+		cfg := policies.get(ctx, review.Issue)
+		if cfg == nil || cfg.ApprovalExpiryDays <= 0 || review.UpdatedUnix >= daysAgo(cfg.ApprovalExpiryDays) {
+			continue
+		}
+
+		message := fmt.Sprintf("This approval was dismissed automatically because it is older than %d days.", cfg.ApprovalExpiryDays)
+		if _, err := DismissReview(ctx, review.ID, review.Issue.RepoID, message, doer, true, false); err != nil {
+			log.Error("DismissReview[%d]: %v", review.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package release
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/notification"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// maxReleaseBuildLogSize is the size of the log kept for a release build, the beginning of longer logs is dropped
+const maxReleaseBuildLogSize = 4 << 20
+
+// ClaimReleaseBuild claims a pushed tag for building the assets of its release. The release is turned into a draft,
+// created if the tag has none, which is published once the build succeeds. A tag whose build failed can be claimed
+// again, the assets uploaded by the failed build are then deleted.
+func ClaimReleaseBuild(ctx context.Context, doer *user_model.User, gitRepo *git.Repository, repo *repo_model.Repository, tagName string) (*repo_model.ReleaseBuild, error) {
+	commit, err := gitRepo.GetTagCommit(tagName)
+	if err != nil {
+		return nil, err
+	}
+	numCommits, err := commit.CommitsCount()
+	if err != nil {
+		return nil, err
+	}
+
+	build, err := repo_model.GetReleaseBuildByTag(ctx, repo.ID, tagName)
+	if err != nil && !repo_model.IsErrReleaseBuildNotExist(err) {
+		return nil, err
+	}
+	if build != nil && build.Status != repo_model.ReleaseBuildStatusFailure {
+		return nil, repo_model.ErrReleaseBuildAlreadyClaimed{TagName: tagName}
+	}
+
+	rel, err := repo_model.GetRelease(repo.ID, tagName)
+	if err != nil && !repo_model.IsErrReleaseNotExist(err) {
+		return nil, err
+	}
+	if rel != nil && !rel.IsTag && !rel.IsDraft {
+		return nil, repo_model.ErrReleaseAlreadyExist{TagName: tagName}
+	}
+
+	if err := db.WithTx(func(ctx context.Context) error {
+		if rel == nil {
+			rel = &repo_model.Release{
+				RepoID:       repo.ID,
+				PublisherID:  doer.ID,
+				TagName:      tagName,
+				LowerTagName: strings.ToLower(tagName),
+				Target:       commit.ID.String(),
+				Title:        tagName,
+				CreatedUnix:  timeutil.TimeStampNow(),
+			}
+		}
+		if rel.IsTag {
+			rel.Title = tagName
+			rel.PublisherID = doer.ID
+			rel.IsTag = false
+		}
+		rel.IsDraft = true
+		rel.Sha1 = commit.ID.String()
+		rel.NumCommits = numCommits
+		if rel.ID == 0 {
+			if err := db.Insert(ctx, rel); err != nil {
+				return err
+			}
+		} else if err := repo_model.UpdateRelease(ctx, rel); err != nil {
+			return err
+		}
+
+		if build == nil {
+			build = &repo_model.ReleaseBuild{
+				RepoID:       repo.ID,
+				TagName:      tagName,
+				LowerTagName: strings.ToLower(tagName),
+			}
+		} else {
+			// the assets of the failed build must not be published with the new one
+			if err := repo_model.GetReleaseAttachments(ctx, rel); err != nil {
+				return err
+			}
+			if _, err := repo_model.DeleteAttachments(ctx, rel.Attachments, true); err != nil {
+				return err
+			}
+		}
+		build.ReleaseID = rel.ID
+		build.BuilderID = doer.ID
+		build.Status = repo_model.ReleaseBuildStatusPending
+		build.Description = ""
+		build.Log = ""
+		if build.ID == 0 {
+			return db.Insert(ctx, build)
+		}
+		return repo_model.UpdateReleaseBuildCols(ctx, build, "release_id", "builder_id", "status", "description", "log")
+	}, ctx); err != nil {
+		return nil, err
+	}
+
+	build.Release = rel
+	build.Builder = doer
+	return build, nil
+}
+
+// AppendReleaseBuildLog appends the content to the log of a running release build
+func AppendReleaseBuildLog(ctx context.Context, build *repo_model.ReleaseBuild, content string) error {
+	return db.WithTx(func(ctx context.Context) error {
+		current, err := repo_model.GetReleaseBuildByID(ctx, build.RepoID, build.ID)
+		if err != nil {
+			return err
+		}
+		if current.Status.IsFinished() {
+			return repo_model.ErrReleaseBuildFinished{ID: build.ID}
+		}
+		build.Log = current.Log + content
+		if len(build.Log) > maxReleaseBuildLogSize {
+			build.Log = build.Log[len(build.Log)-maxReleaseBuildLogSize:]
+		}
+		return repo_model.UpdateReleaseBuildCols(ctx, build, "log")
+	}, ctx)
+}
+
+// UpdateReleaseBuildStatus updates the status of a release build. When the build succeeds, its release is published
+// with all the uploaded assets at once. When it fails, the release stays a draft.
+func UpdateReleaseBuildStatus(ctx context.Context, build *repo_model.ReleaseBuild, status repo_model.ReleaseBuildStatus, description string) error {
+	if build.Status.IsFinished() {
+		return repo_model.ErrReleaseBuildFinished{ID: build.ID}
+	}
+	if err := build.LoadRelease(ctx); err != nil {
+		return err
+	}
+	rel := build.Release
+
+	if err := db.WithTx(func(ctx context.Context) error {
+		if err := repo_model.UpdateReleaseBuildStatus(ctx, build, status, description); err != nil {
+			return err
+		}
+		if status != repo_model.ReleaseBuildStatusSuccess {
+			return nil
+		}
+		rel.IsDraft = false
+		// the release appears when it is published, not when its build was claimed
+		rel.CreatedUnix = timeutil.TimeStampNow()
+		return repo_model.UpdateRelease(ctx, rel)
+	}, ctx); err != nil {
+		return err
+	}
+
+	if status == repo_model.ReleaseBuildStatusSuccess {
+		if err := rel.LoadAttributes(); err != nil {
+			return err
+		}
+		notification.NotifyNewRelease(rel)
+	}
+	return nil
+}
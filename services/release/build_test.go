@@ -0,0 +1,99 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package release
+
+import (
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/services/attachment"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseBuild(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	gitRepo, err := git.OpenRepository(git.DefaultContext, repo.RepoPath())
+	assert.NoError(t, err)
+	defer gitRepo.Close()
+
+	// the release of the tag is already published
+	_, err = ClaimReleaseBuild(db.DefaultContext, user, gitRepo, repo, "v1.1")
+	assert.True(t, repo_model.IsErrReleaseAlreadyExist(err))
+	_, err = ClaimReleaseBuild(db.DefaultContext, user, gitRepo, repo, "unknown")
+	assert.True(t, git.IsErrNotExist(err))
+
+	assert.NoError(t, CreateNewTag(db.DefaultContext, user, repo, "master", "v2.0-build", ""))
+	build, err := ClaimReleaseBuild(db.DefaultContext, user, gitRepo, repo, "v2.0-build")
+	assert.NoError(t, err)
+	assert.Equal(t, repo_model.ReleaseBuildStatusPending, build.Status)
+	rel := unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: build.ReleaseID})
+	assert.True(t, rel.IsDraft)
+	assert.False(t, rel.IsTag)
+	assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", rel.Sha1)
+
+	_, err = ClaimReleaseBuild(db.DefaultContext, user, gitRepo, repo, "v2.0-build")
+	assert.True(t, repo_model.IsErrReleaseBuildAlreadyClaimed(err))
+
+	assert.NoError(t, UpdateReleaseBuildStatus(db.DefaultContext, build, repo_model.ReleaseBuildStatusRunning, "building"))
+	assert.NoError(t, AppendReleaseBuildLog(db.DefaultContext, build, "compiling\n"))
+	assert.NoError(t, AppendReleaseBuildLog(db.DefaultContext, build, "packaging\n"))
+	build = unittest.AssertExistsAndLoadBean(t, &repo_model.ReleaseBuild{ID: build.ID})
+	assert.Equal(t, "compiling\npackaging\n", build.Log)
+	assert.Equal(t, "building", build.Description)
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: build.ReleaseID, IsDraft: true})
+
+	// the release is published when the build succeeds
+	assert.NoError(t, UpdateReleaseBuildStatus(db.DefaultContext, build, repo_model.ReleaseBuildStatusSuccess, ""))
+	rel = unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: build.ReleaseID})
+	assert.False(t, rel.IsDraft)
+	assert.True(t, repo_model.IsErrReleaseBuildFinished(AppendReleaseBuildLog(db.DefaultContext, build, "late\n")))
+	assert.True(t, repo_model.IsErrReleaseBuildFinished(UpdateReleaseBuildStatus(db.DefaultContext, build, repo_model.ReleaseBuildStatusFailure, "")))
+	_, err = ClaimReleaseBuild(db.DefaultContext, user, gitRepo, repo, "v2.0-build")
+	assert.True(t, repo_model.IsErrReleaseBuildAlreadyClaimed(err))
+}
+
+func TestReleaseBuildFailure(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	gitRepo, err := git.OpenRepository(git.DefaultContext, repo.RepoPath())
+	assert.NoError(t, err)
+	defer gitRepo.Close()
+
+	assert.NoError(t, CreateNewTag(db.DefaultContext, user, repo, "master", "v2.1-build", ""))
+	build, err := ClaimReleaseBuild(db.DefaultContext, user, gitRepo, repo, "v2.1-build")
+	assert.NoError(t, err)
+
+	attach, err := attachment.NewAttachment(&repo_model.Attachment{
+		RepoID:     repo.ID,
+		ReleaseID:  build.ReleaseID,
+		UploaderID: user.ID,
+		Name:       "gitea-linux-amd64",
+	}, strings.NewReader("binary"))
+	assert.NoError(t, err)
+
+	// the release stays a draft when the build fails
+	assert.NoError(t, UpdateReleaseBuildStatus(db.DefaultContext, build, repo_model.ReleaseBuildStatusFailure, "tests failed"))
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: build.ReleaseID, IsDraft: true})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Attachment{ID: attach.ID})
+
+	// claiming the tag again starts over
+	reclaimed, err := ClaimReleaseBuild(db.DefaultContext, user, gitRepo, repo, "v2.1-build")
+	assert.NoError(t, err)
+	assert.Equal(t, build.ID, reclaimed.ID)
+	assert.Equal(t, build.ReleaseID, reclaimed.ReleaseID)
+	assert.Equal(t, repo_model.ReleaseBuildStatusPending, reclaimed.Status)
+	unittest.AssertNotExistsBean(t, &repo_model.Attachment{ID: attach.ID})
+}
@@ -0,0 +1,356 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package release
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/notification"
+	"code.gitea.io/gitea/modules/notification/base"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// DefaultReleaseDraftTemplate is the note of the drafted release when no template is configured
+const DefaultReleaseDraftTemplate = "## What's Changed\n\n$CHANGES\n\n**Contributors:** $CONTRIBUTORS"
+
+// Init registers the notifier updating the drafted releases
+func Init() error {
+	notification.RegisterNotifier(&drafterNotifier{})
+	return nil
+}
+
+type drafterNotifier struct {
+	base.NullNotifier
+}
+
+var _ base.Notifier = &drafterNotifier{}
+
+// NotifyMergePullRequest adds the merged pull request to the drafted release
+func (*drafterNotifier) NotifyMergePullRequest(pr *issues_model.PullRequest, doer *user_model.User) {
+	if err := pr.LoadBaseRepo(); err != nil {
+		log.Error("LoadBaseRepo[%d]: %v", pr.ID, err)
+		return
+	}
+	if pr.BaseBranch != pr.BaseRepo.DefaultBranch {
+		return
+	}
+	if err := UpdateReleaseDraft(db.DefaultContext, pr.BaseRepo, doer); err != nil {
+		log.Error("UpdateReleaseDraft[%s]: %v", pr.BaseRepo.FullName(), err)
+	}
+}
+
+// NotifyNewRelease removes the published changes from the drafted release
+func (*drafterNotifier) NotifyNewRelease(rel *repo_model.Release) {
+	if rel.IsDraft || rel.IsPrerelease || rel.IsTag {
+		return
+	}
+	if err := rel.LoadAttributes(); err != nil {
+		log.Error("LoadAttributes[%d]: %v", rel.ID, err)
+		return
+	}
+	if err := UpdateReleaseDraft(db.DefaultContext, rel.Repo, rel.Publisher); err != nil {
+		log.Error("UpdateReleaseDraft[%s]: %v", rel.Repo.FullName(), err)
+	}
+}
+
+// changelogCategory is a section of the changelog with the pull requests having one of its labels
+type changelogCategory struct {
+	Title  string
+	Labels []string
+	Pulls  []*issues_model.PullRequest
+}
+
+// parseChangelogCategories parses the changelog categories, one per line written as "Title: label1, label2"
+func parseChangelogCategories(s string) []*changelogCategory {
+	categories := make([]*changelogCategory, 0, 5)
+	for _, line := range strings.Split(s, "\n") {
+		title, labels, ok := strings.Cut(line, ":")
+		title = strings.TrimSpace(title)
+		if !ok || title == "" {
+			continue
+		}
+		category := &changelogCategory{Title: title}
+		for _, label := range strings.Split(labels, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				category.Labels = append(category.Labels, strings.ToLower(label))
+			}
+		}
+		categories = append(categories, category)
+	}
+	return categories
+}
+
+func (c *changelogCategory) matches(issue *issues_model.Issue) bool {
+	for _, label := range issue.Labels {
+		for _, name := range c.Labels {
+			if strings.ToLower(label.Name) == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeChangelogEntries(sb *strings.Builder, pulls []*issues_model.PullRequest) {
+	for _, pr := range pulls {
+		fmt.Fprintf(sb, "- %s (#%d)", pr.Issue.Title, pr.Issue.Index)
+		if pr.Issue.Poster != nil && !pr.Issue.Poster.IsGhost() {
+			fmt.Fprintf(sb, " @%s", pr.Issue.Poster.Name)
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// generateChangelog returns the changelog of the pull requests, grouped by category if categories are configured.
+// A pull request belongs to the first category matching one of its labels.
+func generateChangelog(cfg *repo_model.ReleasesConfig, pulls []*issues_model.PullRequest) string {
+	categories := parseChangelogCategories(cfg.ChangelogCategories)
+	if len(categories) == 0 {
+		var sb strings.Builder
+		writeChangelogEntries(&sb, pulls)
+		return strings.TrimSpace(sb.String())
+	}
+
+	others := &changelogCategory{Title: "Other Changes"}
+	for _, pr := range pulls {
+		category := others
+		for _, c := range categories {
+			if c.matches(pr.Issue) {
+				category = c
+				break
+			}
+		}
+		category.Pulls = append(category.Pulls, pr)
+	}
+
+	var sb strings.Builder
+	for _, c := range append(categories, others) {
+		if len(c.Pulls) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "### %s\n\n", c.Title)
+		writeChangelogEntries(&sb, c.Pulls)
+		sb.WriteString("\n")
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// GenerateReleaseDraftNote returns the note of the drafted release with the changelog of the pull requests,
+// which must have their issues loaded with their labels and posters
+func GenerateReleaseDraftNote(cfg *repo_model.ReleasesConfig, pulls []*issues_model.PullRequest, previousTag string) string {
+	contributors := make([]string, 0, len(pulls))
+	seen := make(map[int64]bool, len(pulls))
+	for _, pr := range pulls {
+		if poster := pr.Issue.Poster; poster != nil && !poster.IsGhost() && !seen[poster.ID] {
+			seen[poster.ID] = true
+			contributors = append(contributors, "@"+poster.Name)
+		}
+	}
+
+	template := cfg.ReleaseDraftTemplate
+	if strings.TrimSpace(template) == "" {
+		template = DefaultReleaseDraftTemplate
+	}
+	expansions := map[string]string{
+		"CHANGES":      generateChangelog(cfg, pulls),
+		"CONTRIBUTORS": strings.Join(contributors, ", "),
+		"PREVIOUS_TAG": previousTag,
+	}
+	return os.Expand(template, func(key string) string {
+		if value, ok := expansions[key]; ok {
+			return value
+		}
+		return "$" + key
+	})
+}
+
+// getReleaseDrafterConfig returns the releases config of the repository if the release drafter is enabled
+func getReleaseDrafterConfig(ctx context.Context, repo *repo_model.Repository) (*repo_model.ReleasesConfig, error) {
+	releasesUnit, err := repo.GetUnitCtx(ctx, unit.TypeReleases)
+	if repo_model.IsErrUnitTypeNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	cfg := releasesUnit.ReleasesConfig()
+	if !cfg.EnableReleaseDrafter {
+		return nil, nil
+	}
+	return cfg, nil
+}
+
+// UpdateReleaseDraft drafts the next release of the repository with the changelog of the pull requests merged
+// into the default branch since the latest release, if the release drafter of the repository is enabled.
+// The drafted release is deleted when there is no change.
+func UpdateReleaseDraft(ctx context.Context, repo *repo_model.Repository, doer *user_model.User) error {
+	cfg, err := getReleaseDrafterConfig(ctx, repo)
+	if err != nil || cfg == nil {
+		return err
+	}
+
+	var since timeutil.TimeStamp
+	var previousTag string
+	latest, err := repo_model.GetLatestReleaseByRepoID(repo.ID)
+	if err == nil {
+		since = latest.CreatedUnix
+		previousTag = latest.TagName
+	} else if !repo_model.IsErrReleaseNotExist(err) {
+		return err
+	}
+
+	pulls, err := issues_model.GetPullRequestsMergedSince(ctx, repo.ID, repo.DefaultBranch, since)
+	if err != nil {
+		return err
+	}
+	if err := pulls.LoadAttributes(); err != nil {
+		return err
+	}
+	issues := make(issues_model.IssueList, 0, len(pulls))
+	for _, pr := range pulls {
+		issues = append(issues, pr.Issue)
+	}
+	if err := issues.LoadAttributes(); err != nil {
+		return err
+	}
+
+	tagName := cfg.GetReleaseDraftTagName()
+	draft, err := repo_model.GetRelease(repo.ID, tagName)
+	if err != nil && !repo_model.IsErrReleaseNotExist(err) {
+		return err
+	}
+
+	if draft != nil {
+		if !draft.IsDraft {
+			log.Warn("The tag %s of the release drafter of %s is used by a published release", tagName, repo.FullName())
+			return nil
+		}
+		if len(pulls) == 0 {
+			return deleteReleaseDraft(ctx, draft)
+		}
+		draft.Note = GenerateReleaseDraftNote(cfg, pulls, previousTag)
+		return repo_model.UpdateRelease(ctx, draft)
+	}
+
+	if len(pulls) == 0 {
+		return nil
+	}
+	return db.Insert(ctx, &repo_model.Release{
+		RepoID:       repo.ID,
+		PublisherID:  doer.ID,
+		TagName:      tagName,
+		LowerTagName: strings.ToLower(tagName),
+		Target:       repo.DefaultBranch,
+		Title:        tagName,
+		Note:         GenerateReleaseDraftNote(cfg, pulls, previousTag),
+		IsDraft:      true,
+		CreatedUnix:  timeutil.TimeStampNow(),
+	})
+}
+
+// deleteReleaseDraft deletes the drafted release and its attachments, it has no tag to delete
+func deleteReleaseDraft(ctx context.Context, draft *repo_model.Release) error {
+	if err := repo_model.GetReleaseAttachments(ctx, draft); err != nil {
+		return err
+	}
+	if _, err := repo_model.DeleteAttachments(ctx, draft.Attachments, true); err != nil {
+		return err
+	}
+	return repo_model.DeleteReleaseByID(draft.ID)
+}
+
+// PublishReleaseDraftOptions are the options to publish the drafted release of a repository
+type PublishReleaseDraftOptions struct {
+	TagName      string
+	Target       string
+	Title        string
+	IsPrerelease bool
+}
+
+// GetReleaseDraft returns the drafted release of the repository
+func GetReleaseDraft(ctx context.Context, repo *repo_model.Repository) (*repo_model.Release, error) {
+	cfg, err := getReleaseDrafterConfig(ctx, repo)
+	if err != nil {
+		return nil, err
+	} else if cfg == nil {
+		return nil, repo_model.ErrReleaseNotExist{TagName: repo_model.DefaultReleaseDraftTagName}
+	}
+
+	draft, err := repo_model.GetRelease(repo.ID, cfg.GetReleaseDraftTagName())
+	if err != nil {
+		return nil, err
+	} else if !draft.IsDraft {
+		return nil, repo_model.ErrReleaseNotExist{TagName: draft.TagName}
+	}
+	return draft, nil
+}
+
+// PublishReleaseDraft publishes the drafted release of the repository with the tag, which is created if it doesn't
+// exist. If the tag has already been pushed, its release takes the note and the attachments of the drafted release.
+func PublishReleaseDraft(ctx context.Context, doer *user_model.User, gitRepo *git.Repository, repo *repo_model.Repository, opts PublishReleaseDraftOptions) (*repo_model.Release, error) {
+	draft, err := GetReleaseDraft(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = opts.TagName
+	}
+
+	rel, err := repo_model.GetRelease(repo.ID, opts.TagName)
+	if err != nil && !repo_model.IsErrReleaseNotExist(err) {
+		return nil, err
+	}
+	if rel != nil {
+		if !rel.IsTag {
+			return nil, repo_model.ErrReleaseAlreadyExist{TagName: opts.TagName}
+		}
+		rel.Title = title
+		rel.Note = draft.Note
+		rel.IsTag = false
+		rel.IsDraft = false
+		rel.IsPrerelease = opts.IsPrerelease
+		rel.PublisherID = doer.ID
+		rel.Publisher = doer
+		rel.Repo = repo
+		if err := repo_model.MoveReleaseAttachments(ctx, draft.ID, rel.ID); err != nil {
+			return nil, err
+		}
+		if err := UpdateRelease(doer, gitRepo, rel, nil, nil, nil); err != nil {
+			return nil, err
+		}
+		if err := repo_model.DeleteReleaseByID(draft.ID); err != nil {
+			return nil, err
+		}
+		return rel, nil
+	}
+
+	draft.TagName = opts.TagName
+	draft.Title = title
+	if opts.Target != "" {
+		draft.Target = opts.Target
+	}
+	draft.IsDraft = false
+	draft.IsPrerelease = opts.IsPrerelease
+	draft.PublisherID = doer.ID
+	draft.Publisher = doer
+	draft.Repo = repo
+	// the changes of the next draft are merged after the publication, not after the creation of the draft
+	draft.CreatedUnix = timeutil.TimeStampNow()
+	if err := UpdateRelease(doer, gitRepo, draft, nil, nil, nil); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
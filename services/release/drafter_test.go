@@ -0,0 +1,75 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package release
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateReleaseDraftNote(t *testing.T) {
+	alice := &user_model.User{ID: 1, Name: "alice"}
+	bob := &user_model.User{ID: 2, Name: "bob"}
+	pulls := []*issues_model.PullRequest{
+		{Issue: &issues_model.Issue{Index: 1, Title: "Add a feature", Poster: alice, Labels: []*issues_model.Label{{Name: "Feature"}}}},
+		{Issue: &issues_model.Issue{Index: 2, Title: "Fix a bug", Poster: bob, Labels: []*issues_model.Label{{Name: "bug"}}}},
+		{Issue: &issues_model.Issue{Index: 3, Title: "Update the docs", Poster: alice}},
+	}
+
+	cfg := &repo_model.ReleasesConfig{ReleaseDraftTemplate: "Since $PREVIOUS_TAG:\n$CHANGES\nby $CONTRIBUTORS $UNKNOWN"}
+	assert.Equal(t, "Since v1.0:\n- Add a feature (#1) @alice\n- Fix a bug (#2) @bob\n- Update the docs (#3) @alice\nby @alice, @bob $UNKNOWN",
+		GenerateReleaseDraftNote(cfg, pulls, "v1.0"))
+
+	cfg = &repo_model.ReleasesConfig{ChangelogCategories: "Features: feature, enhancement\nBug Fixes: bug\ninvalid"}
+	assert.Equal(t, "## What's Changed\n\n"+
+		"### Features\n\n- Add a feature (#1) @alice\n\n"+
+		"### Bug Fixes\n\n- Fix a bug (#2) @bob\n\n"+
+		"### Other Changes\n\n- Update the docs (#3) @alice\n\n"+
+		"**Contributors:** @alice, @bob",
+		GenerateReleaseDraftNote(cfg, pulls, ""))
+}
+
+func TestUpdateReleaseDraft(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	// the merged pull request of the fixtures is merged after the latest release
+	_, err := db.GetEngine(db.DefaultContext).ID(1).Cols("merged_unix").NoAutoTime().Update(&issues_model.PullRequest{MergedUnix: 946684900})
+	assert.NoError(t, err)
+
+	// the release drafter is disabled
+	assert.NoError(t, UpdateReleaseDraft(db.DefaultContext, repo, doer))
+	unittest.AssertNotExistsBean(t, &repo_model.Release{RepoID: repo.ID, LowerTagName: repo_model.DefaultReleaseDraftTagName})
+	_, err = GetReleaseDraft(db.DefaultContext, repo)
+	assert.True(t, repo_model.IsErrReleaseNotExist(err))
+
+	releasesUnit, err := repo.GetUnit(unit.TypeReleases)
+	assert.NoError(t, err)
+	releasesUnit.Config = &repo_model.ReleasesConfig{EnableReleaseDrafter: true, ReleaseDraftTemplate: "$CHANGES"}
+	assert.NoError(t, repo_model.UpdateRepoUnit(releasesUnit))
+
+	assert.NoError(t, UpdateReleaseDraft(db.DefaultContext, repo, doer))
+	draft, err := GetReleaseDraft(db.DefaultContext, repo)
+	assert.NoError(t, err)
+	assert.True(t, draft.IsDraft)
+	assert.Equal(t, repo.DefaultBranch, draft.Target)
+	assert.Equal(t, "- issue2 (#2) @user1", draft.Note)
+
+	// the draft is deleted when there is no change since the latest release
+	_, err = db.GetEngine(db.DefaultContext).ID(1).Cols("merged_unix").NoAutoTime().Update(&issues_model.PullRequest{MergedUnix: 946684700})
+	assert.NoError(t, err)
+	assert.NoError(t, UpdateReleaseDraft(db.DefaultContext, repo, doer))
+	unittest.AssertNotExistsBean(t, &repo_model.Release{ID: draft.ID})
+}
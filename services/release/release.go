@@ -21,6 +21,7 @@ import (
 	"code.gitea.io/gitea/modules/repository"
 	"code.gitea.io/gitea/modules/storage"
 	"code.gitea.io/gitea/modules/timeutil"
+	attestation_service "code.gitea.io/gitea/services/attestation"
 )
 
 func createTag(gitRepo *git.Repository, rel *repo_model.Release, msg string) (bool, error) {
@@ -123,6 +124,10 @@ func CreateRelease(gitRepo *git.Repository, rel *repo_model.Release, attachmentU
 		}
 	}
 
+	if err = attestation_service.CheckReleaseAttestation(gitRepo.Ctx, gitRepo, rel); err != nil {
+		return err
+	}
+
 	if _, err = createTag(gitRepo, rel, msg); err != nil {
 		return err
 	}
@@ -189,6 +194,16 @@ func UpdateRelease(doer *user_model.User, gitRepo *git.Repository, rel *repo_mod
 	if rel.ID == 0 {
 		return errors.New("UpdateRelease only accepts an exist release")
 	}
+	// only the releases being published must have an attestation, not the ones already published
+	oldRel, err := repo_model.GetReleaseByID(gitRepo.Ctx, rel.ID)
+	if err != nil {
+		return err
+	}
+	if oldRel.IsDraft || oldRel.IsTag {
+		if err = attestation_service.CheckReleaseAttestation(gitRepo.Ctx, gitRepo, rel); err != nil {
+			return err
+		}
+	}
 	isCreated, err := createTag(gitRepo, rel, "")
 	if err != nil {
 		return err
@@ -345,6 +360,10 @@ func DeleteReleaseByID(ctx context.Context, id int64, doer *user_model.User, del
 		return fmt.Errorf("DeleteAttachments: %v", err)
 	}
 
+	if err := repo_model.DeleteReleaseBuildsByReleaseID(ctx, rel.ID); err != nil {
+		return fmt.Errorf("DeleteReleaseBuildsByReleaseID: %v", err)
+	}
+
 	for i := range rel.Attachments {
 		attachment := rel.Attachments[i]
 		if err := storage.Attachments.Delete(attachment.RelativePath()); err != nil {
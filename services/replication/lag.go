@@ -0,0 +1,78 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+func pushToQueue(repoID int64) error {
+	if err := replicationQueue.Push(&task{Type: taskSync, RepoID: repoID}); err != nil && err != queue.ErrAlreadyInQueue {
+		return err
+	}
+	return nil
+}
+
+// ReplicateAllRepositories queues the replication of all the repositories, which also replicates the changes of
+// their metadata, the repositories which have never been replicated are pending
+func ReplicateAllRepositories(ctx context.Context) error {
+	if !setting.Replication.Enabled {
+		return nil
+	}
+	return db.IterateObjects(ctx, func(repo *repo_model.Repository) error {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("aborted before the replication of %s", repo.FullName())
+		default:
+		}
+
+		r, err := repo_model.GetRepoReplication(ctx, repo.ID)
+		if err != nil {
+			return err
+		}
+		if r.ID == 0 {
+			if err := repo_model.MarkRepoReplicationPending(ctx, repo.ID); err != nil {
+				return err
+			}
+		}
+		return pushToQueue(repo.ID)
+	})
+}
+
+// CheckReplicationLag queues again the replications which are pending or failing and returns an error when the
+// oldest change which is not replicated yet is older than the replication.MAX_LAG setting
+func CheckReplicationLag(ctx context.Context) error {
+	if !setting.Replication.Enabled {
+		return nil
+	}
+	for _, opts := range []repo_model.FindRepoReplicationsOptions{{OnlyPending: true}, {OnlyFailing: true}} {
+		replications, _, err := repo_model.FindRepoReplications(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, r := range replications {
+			if err := pushToQueue(r.RepoID); err != nil {
+				return err
+			}
+		}
+	}
+
+	stats, err := repo_model.GetReplicationStats(ctx)
+	if err != nil {
+		return err
+	}
+	if lag := time.Duration(stats.Lag(timeutil.TimeStampNow())) * time.Second; lag > setting.Replication.MaxLag {
+		return fmt.Errorf("the replication of %d repositories to the standby lags by %s, %d replications are failing", stats.PendingRepos, lag, stats.FailingRepos)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package replication
+
+import (
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/notification/base"
+	"code.gitea.io/gitea/modules/repository"
+)
+
+type replicationNotifier struct {
+	base.NullNotifier
+}
+
+var _ base.Notifier = &replicationNotifier{}
+
+func (*replicationNotifier) NotifyCreateRepository(_, _ *user_model.User, repo *repo_model.Repository) {
+	ReplicateRepository(db.DefaultContext, repo)
+}
+
+func (*replicationNotifier) NotifyMigrateRepository(_, _ *user_model.User, repo *repo_model.Repository) {
+	ReplicateRepository(db.DefaultContext, repo)
+}
+
+func (*replicationNotifier) NotifyForkRepository(_ *user_model.User, _, repo *repo_model.Repository) {
+	ReplicateRepository(db.DefaultContext, repo)
+}
+
+func (*replicationNotifier) NotifyRenameRepository(_ *user_model.User, repo *repo_model.Repository, oldRepoName string) {
+	if err := repo_model.MarkRepoReplicationPending(db.DefaultContext, repo.ID); err != nil {
+		log.Error("MarkRepoReplicationPending[%d]: %v", repo.ID, err)
+	}
+	addToQueue(&task{Type: taskRename, RepoID: repo.ID, OwnerName: repo.OwnerName, Name: oldRepoName})
+}
+
+func (*replicationNotifier) NotifyTransferRepository(_ *user_model.User, repo *repo_model.Repository, oldOwnerName string) {
+	if err := repo_model.MarkRepoReplicationPending(db.DefaultContext, repo.ID); err != nil {
+		log.Error("MarkRepoReplicationPending[%d]: %v", repo.ID, err)
+	}
+	addToQueue(&task{Type: taskTransfer, RepoID: repo.ID, OwnerName: oldOwnerName, Name: repo.Name})
+}
+
+func (*replicationNotifier) NotifyDeleteRepository(_ *user_model.User, repo *repo_model.Repository) {
+	addToQueue(&task{Type: taskDelete, RepoID: repo.ID, OwnerName: repo.OwnerName, Name: repo.Name})
+}
+
+func (*replicationNotifier) NotifyPushCommits(_ *user_model.User, repo *repo_model.Repository, _ *repository.PushUpdateOptions, _ *repository.PushCommits) {
+	ReplicateRepository(db.DefaultContext, repo)
+}
+
+func (*replicationNotifier) NotifyCreateRef(_ *user_model.User, repo *repo_model.Repository, _, _, _ string) {
+	ReplicateRepository(db.DefaultContext, repo)
+}
+
+func (*replicationNotifier) NotifyDeleteRef(_ *user_model.User, repo *repo_model.Repository, _, _ string) {
+	ReplicateRepository(db.DefaultContext, repo)
+}
+
+func (*replicationNotifier) NotifySyncPushCommits(_ *user_model.User, repo *repo_model.Repository, _ *repository.PushUpdateOptions, _ *repository.PushCommits) {
+	ReplicateRepository(db.DefaultContext, repo)
+}
+
+func (*replicationNotifier) NotifySyncCreateRef(_ *user_model.User, repo *repo_model.Repository, _, _, _ string) {
+	ReplicateRepository(db.DefaultContext, repo)
+}
+
+func (*replicationNotifier) NotifySyncDeleteRef(_ *user_model.User, repo *repo_model.Repository, _, _ string) {
+	ReplicateRepository(db.DefaultContext, repo)
+}
+
+func (*replicationNotifier) NotifyNewWikiPage(_ *user_model.User, repo *repo_model.Repository, _, _ string) {
+	ReplicateRepository(db.DefaultContext, repo)
+}
+
+func (*replicationNotifier) NotifyEditWikiPage(_ *user_model.User, repo *repo_model.Repository, _, _ string) {
+	ReplicateRepository(db.DefaultContext, repo)
+}
+
+func (*replicationNotifier) NotifyDeleteWikiPage(_ *user_model.User, repo *repo_model.Repository, _ string) {
+	ReplicateRepository(db.DefaultContext, repo)
+}
@@ -0,0 +1,319 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package replication
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/lfs"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/notification"
+	pwd "code.gitea.io/gitea/modules/password"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	mirror_service "code.gitea.io/gitea/services/mirror"
+
+	gitea_sdk "code.gitea.io/sdk/gitea"
+)
+
+var (
+	replicationQueue queue.UniqueQueue
+	// standbyTransport is used by the API, git and LFS requests to the standby instance
+	standbyTransport *http.Transport
+)
+
+type taskType int
+
+const (
+	// taskSync pushes the refs, the LFS objects and the metadata of a repository
+	taskSync taskType = iota
+	// taskRename renames a repository before its sync
+	taskRename
+	// taskTransfer transfers a repository to its new owner before its sync
+	taskTransfer
+	// taskDelete deletes a repository
+	taskDelete
+)
+
+// task is a replication of a repository to the standby, OwnerName and Name are its names on the standby before the
+// task when it renames, transfers or deletes it
+type task struct {
+	Type      taskType
+	RepoID    int64
+	OwnerName string
+	Name      string
+}
+
+// Init starts the replication of the repositories to the standby instance
+func Init() error {
+	if !setting.Replication.Enabled {
+		return nil
+	}
+
+	standbyTransport = &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: setting.Replication.SkipTLSVerify},
+		Proxy:           proxy.Proxy(),
+	}
+
+	replicationQueue = queue.CreateUniqueQueue("replication", handle, new(task))
+	if replicationQueue == nil {
+		return fmt.Errorf("Unable to create replication Queue")
+	}
+	go graceful.GetManager().RunWithShutdownFns(replicationQueue.Run)
+
+	notification.RegisterNotifier(&replicationNotifier{})
+	return nil
+}
+
+func handle(data ...queue.Data) []queue.Data {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, datum := range data {
+		t := datum.(*task)
+		if err := t.run(ctx); err != nil {
+			log.Error("Replication of repository %d to the standby: %v", t.RepoID, err)
+		}
+	}
+	return nil
+}
+
+func addToQueue(t *task) {
+	go func() {
+		if err := replicationQueue.Push(t); err != nil && err != queue.ErrAlreadyInQueue {
+			log.Error("Unable to push the replication of repository %d to the queue: %v", t.RepoID, err)
+		}
+	}()
+}
+
+// ReplicateRepository records a change of a repository and queues its replication
+func ReplicateRepository(ctx context.Context, repo *repo_model.Repository) {
+	if !setting.Replication.Enabled {
+		return
+	}
+	if err := repo_model.MarkRepoReplicationPending(ctx, repo.ID); err != nil {
+		log.Error("MarkRepoReplicationPending[%d]: %v", repo.ID, err)
+	}
+	addToQueue(&task{Type: taskSync, RepoID: repo.ID})
+}
+
+func newStandbyClient(ctx context.Context) (*gitea_sdk.Client, error) {
+	return gitea_sdk.NewClient(
+		setting.Replication.StandbyURL,
+		gitea_sdk.SetToken(setting.Replication.StandbyToken),
+		gitea_sdk.SetContext(ctx),
+		gitea_sdk.SetHTTPClient(&http.Client{Transport: standbyTransport}),
+	)
+}
+
+// standbyRemoteURL returns the URL of a git repository on the standby, including the credentials of the replication
+func standbyRemoteURL(ownerName, repoName string) (*url.URL, error) {
+	u, err := url.Parse(setting.Replication.StandbyURL + url.PathEscape(ownerName) + "/" + url.PathEscape(repoName) + ".git")
+	if err != nil {
+		return nil, err
+	}
+	u.User = url.UserPassword(setting.Replication.StandbyToken, "x-oauth-basic")
+	return u, nil
+}
+
+func isNotFound(resp *gitea_sdk.Response) bool {
+	return resp != nil && resp.StatusCode == http.StatusNotFound
+}
+
+func (t *task) run(ctx context.Context) error {
+	client, err := newStandbyClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if t.Type == taskDelete {
+		resp, err := client.DeleteRepo(t.OwnerName, t.Name)
+		if err != nil && !isNotFound(resp) {
+			return fmt.Errorf("delete %s/%s: %w", t.OwnerName, t.Name, err)
+		}
+		return nil
+	}
+
+	repo, err := repo_model.GetRepositoryByIDCtx(ctx, t.RepoID)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			// the repository has been deleted in the meantime, its deletion is queued too
+			return nil
+		}
+		return err
+	}
+	if err := repo.GetOwner(ctx); err != nil {
+		return err
+	}
+
+	switch t.Type {
+	case taskRename:
+		name := repo.Name
+		_, resp, err := client.EditRepo(t.OwnerName, t.Name, gitea_sdk.EditRepoOption{Name: &name})
+		if err != nil && !isNotFound(resp) {
+			return fmt.Errorf("rename %s/%s: %w", t.OwnerName, t.Name, err)
+		}
+	case taskTransfer:
+		if err := ensureOwner(client, repo.Owner); err != nil {
+			return err
+		}
+		_, resp, err := client.TransferRepo(t.OwnerName, t.Name, gitea_sdk.TransferRepoOption{NewOwner: repo.OwnerName})
+		if err != nil && !isNotFound(resp) {
+			return fmt.Errorf("transfer %s/%s: %w", t.OwnerName, t.Name, err)
+		}
+	}
+
+	return syncRepository(ctx, client, repo)
+}
+
+// syncRepository replicates a repository and records the result
+func syncRepository(ctx context.Context, client *gitea_sdk.Client, repo *repo_model.Repository) error {
+	ctx, _, finished := process.GetManager().AddContext(ctx, fmt.Sprintf("Replicating %s to the standby", repo.FullName()))
+	defer finished()
+
+	startedUnix := timeutil.TimeStampNow()
+	var syncErr string
+	if err := replicate(ctx, client, repo); err != nil {
+		log.Error("Replication of %-v to the standby: %v", repo, err)
+		syncErr = util.SanitizeCredentialURLs(err.Error())
+	}
+	return repo_model.MarkRepoReplicationSynced(ctx, repo.ID, startedUnix, syncErr)
+}
+
+func replicate(ctx context.Context, client *gitea_sdk.Client, repo *repo_model.Repository) error {
+	if repo.Status != repo_model.RepositoryReady {
+		return errors.New("the repository is not ready")
+	}
+
+	if err := ensureOwner(client, repo.Owner); err != nil {
+		return err
+	}
+	_, resp, err := client.GetRepo(repo.OwnerName, repo.Name)
+	if isNotFound(resp) {
+		_, _, err = client.AdminCreateRepo(repo.OwnerName, gitea_sdk.CreateRepoOption{
+			Name:        repo.Name,
+			Description: repo.Description,
+			Private:     repo.IsPrivate,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("create the repository: %w", err)
+	}
+
+	if !repo.IsEmpty {
+		if err := pushToStandby(ctx, repo.RepoPath(), repo.OwnerName, repo.Name); err != nil {
+			return err
+		}
+	}
+	if repo.HasWiki() {
+		if err := pushToStandby(ctx, repo.WikiPath(), repo.OwnerName, repo.Name+".wiki"); err != nil {
+			return err
+		}
+	}
+
+	opts := gitea_sdk.EditRepoOption{
+		Description: &repo.Description,
+		Website:     &repo.Website,
+		Private:     &repo.IsPrivate,
+		Template:    &repo.IsTemplate,
+	}
+	if !repo.IsEmpty {
+		opts.DefaultBranch = &repo.DefaultBranch
+	}
+	if _, _, err := client.EditRepo(repo.OwnerName, repo.Name, opts); err != nil {
+		return fmt.Errorf("edit the repository: %w", err)
+	}
+	topics := repo.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	if _, err := client.SetRepoTopics(repo.OwnerName, repo.Name, topics); err != nil {
+		return fmt.Errorf("set the topics: %w", err)
+	}
+	return nil
+}
+
+// ensureOwner creates the owner of a repository on the standby if it doesn't exist yet, the users get a random
+// password which they must change
+func ensureOwner(client *gitea_sdk.Client, owner *user_model.User) error {
+	_, resp, err := client.GetUserInfo(owner.Name)
+	if err == nil || !isNotFound(resp) {
+		return err
+	}
+
+	visibility := gitea_sdk.VisibleType(owner.Visibility.String())
+	if owner.IsOrganization() {
+		_, _, err = client.CreateOrg(gitea_sdk.CreateOrgOption{
+			Name:        owner.Name,
+			FullName:    owner.FullName,
+			Description: owner.Description,
+			Website:     owner.Website,
+			Location:    owner.Location,
+			Visibility:  visibility,
+		})
+	} else {
+		var password string
+		if password, err = pwd.Generate(32); err != nil {
+			return err
+		}
+		mustChangePassword := true
+		_, _, err = client.AdminCreateUser(gitea_sdk.CreateUserOption{
+			Username:           owner.Name,
+			FullName:           owner.FullName,
+			Email:              owner.Email,
+			Password:           password,
+			MustChangePassword: &mustChangePassword,
+			Visibility:         &visibility,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("create the owner %s: %w", owner.Name, err)
+	}
+	return nil
+}
+
+// pushToStandby pushes all the refs and the LFS objects of a git repository to the standby, the refs which don't
+// exist anymore are deleted
+func pushToStandby(ctx context.Context, repoPath, ownerName, repoName string) error {
+	remoteURL, err := standbyRemoteURL(ownerName, repoName)
+	if err != nil {
+		return err
+	}
+
+	if setting.LFS.StartServer {
+		gitRepo, err := git.OpenRepository(ctx, repoPath)
+		if err != nil {
+			return err
+		}
+		defer gitRepo.Close()
+
+		endpoint := lfs.DetermineEndpoint(remoteURL.String(), "")
+		if err := mirror_service.PushAllLFSObjects(ctx, gitRepo, lfs.NewClient(endpoint, standbyTransport)); err != nil {
+			return util.SanitizeErrorCredentialURLs(err)
+		}
+	}
+
+	if err := git.Push(ctx, repoPath, git.PushOptions{
+		Remote:  remoteURL.String(),
+		Force:   true,
+		Mirror:  true,
+		Timeout: time.Duration(setting.Git.Timeout.Mirror) * time.Second,
+	}); err != nil {
+		return util.SanitizeErrorCredentialURLs(err)
+	}
+	return nil
+}
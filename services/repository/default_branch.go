@@ -0,0 +1,131 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/gitea/models"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+)
+
+// ErrDefaultBranchNotRenamable is returned when the default branch of an empty, mirrored or archived repository is renamed
+var ErrDefaultBranchNotRenamable = errors.New("the default branch of an empty, mirrored or archived repository cannot be renamed")
+
+// DefaultBranchRename is what renaming the default branch of a repository changed
+type DefaultBranchRename struct {
+	Repo    *repo_model.Repository
+	OldName string
+	NewName string
+	// RetargetedPullRequests is the number of open pull requests whose base branch is now the renamed branch
+	RetargetedPullRequests int
+	// UpdatedBranchProtection is true if the protection of the branch moved to the renamed branch
+	UpdatedBranchProtection bool
+	// UpdatedWebhooks is the number of webhooks of the repository whose branch filter now names the renamed branch
+	UpdatedWebhooks int
+	// Err is the reason why the default branch of the repository couldn't be renamed by an organization-wide rename
+	Err error
+}
+
+// RenameDefaultBranch renames the default branch of a repository, retargets the open pull requests, moves the branch
+// protection and updates the branch filters of the webhooks of the repository. The old name becomes a redirect to the
+// new name, so that the clones fetching the old name keep getting the default branch, and pushing to it is rejected.
+func RenameDefaultBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, to string) (*DefaultBranchRename, error) {
+	if repo.IsEmpty || repo.IsMirror || repo.IsArchived {
+		return nil, ErrDefaultBranchNotRenamable
+	}
+	from := repo.DefaultBranch
+	if err := checkBranchName(ctx, repo, to); err != nil {
+		return nil, err
+	}
+
+	gitRepo, err := git.OpenRepository(ctx, repo.RepoPath())
+	if err != nil {
+		return nil, fmt.Errorf("OpenRepository: %w", err)
+	}
+	defer gitRepo.Close()
+
+	result := &DefaultBranchRename{Repo: repo, OldName: from, NewName: to}
+	pulls, err := issues_model.GetUnmergedPullRequestsByBaseInfo(repo.ID, from)
+	if err != nil {
+		return nil, fmt.Errorf("GetUnmergedPullRequestsByBaseInfo: %w", err)
+	}
+	result.RetargetedPullRequests = len(pulls)
+	protectedBranch, err := git_model.GetProtectedBranchBy(ctx, repo.ID, from)
+	if err != nil {
+		return nil, fmt.Errorf("GetProtectedBranchBy: %w", err)
+	}
+	result.UpdatedBranchProtection = protectedBranch != nil
+	// the redirects of the previous renames are lost once their target is renamed, they must be read before
+	redirects, err := gitRepo.GetBranchRedirects()
+	if err != nil {
+		return nil, fmt.Errorf("GetBranchRedirects: %w", err)
+	}
+
+	msg, err := RenameBranch(repo, doer, gitRepo, from, to)
+	if err != nil {
+		return nil, err
+	}
+	switch msg {
+	case "target_exist":
+		return nil, models.ErrBranchAlreadyExists{BranchName: to}
+	case "from_not_exist":
+		return nil, git.ErrBranchNotExist{Name: from}
+	}
+
+	for name, target := range redirects {
+		if target == from {
+			if err := gitRepo.CreateBranchRedirect(name, to); err != nil {
+				return nil, fmt.Errorf("CreateBranchRedirect: %w", err)
+			}
+		}
+	}
+	if err := gitRepo.CreateBranchRedirect(from, to); err != nil {
+		return nil, fmt.Errorf("CreateBranchRedirect: %w", err)
+	}
+
+	if result.UpdatedWebhooks, err = webhook_model.RenameBranchInFilters(ctx, &webhook_model.ListWebhookOptions{RepoID: repo.ID}, from, to); err != nil {
+		return nil, fmt.Errorf("RenameBranchInFilters: %w", err)
+	}
+	return result, nil
+}
+
+// RenameOrgDefaultBranches renames the default branch of the repositories of an organization whose default branch is
+// named from, as RenameDefaultBranch does, and updates the branch filters of the webhooks of the organization. The
+// repositories whose default branch couldn't be renamed have the reason in their result.
+func RenameOrgDefaultBranches(ctx context.Context, doer *user_model.User, org *organization.Organization, from, to string) ([]*DefaultBranchRename, int, error) {
+	repos, err := organization.GetOrgRepositories(ctx, org.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("GetOrgRepositories: %w", err)
+	}
+
+	results := make([]*DefaultBranchRename, 0, len(repos))
+	for _, repo := range repos {
+		if repo.DefaultBranch != from {
+			continue
+		}
+		result, err := RenameDefaultBranch(ctx, doer, repo, to)
+		if err != nil {
+			log.Error("RenameDefaultBranch of %-v: %v", repo, err)
+			result = &DefaultBranchRename{Repo: repo, OldName: from, NewName: to, Err: err}
+		}
+		results = append(results, result)
+	}
+
+	updatedWebhooks, err := webhook_model.RenameBranchInFilters(ctx, &webhook_model.ListWebhookOptions{OrgID: org.ID}, from, to)
+	if err != nil {
+		return results, 0, fmt.Errorf("RenameBranchInFilters: %w", err)
+	}
+	return results, updatedWebhooks, nil
+}
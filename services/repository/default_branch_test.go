@@ -0,0 +1,77 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repository
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models"
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameDefaultBranch(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	assert.NoError(t, git_model.UpdateProtectBranch(db.DefaultContext, repo, &git_model.ProtectedBranch{RepoID: repo.ID, BranchName: "master"}, git_model.WhitelistOptions{}))
+
+	gitRepo, err := git.OpenRepository(git.DefaultContext, repo.RepoPath())
+	assert.NoError(t, err)
+	defer gitRepo.Close()
+	// a redirect left by a previous rename
+	assert.NoError(t, gitRepo.CreateBranchRedirect("trunk", "master"))
+
+	_, err = RenameDefaultBranch(db.DefaultContext, doer, repo, "develop")
+	assert.True(t, models.IsErrBranchAlreadyExists(err))
+
+	result, err := RenameDefaultBranch(db.DefaultContext, doer, repo, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, "master", result.OldName)
+	assert.Equal(t, "main", result.NewName)
+	assert.Equal(t, 1, result.RetargetedPullRequests)
+	assert.True(t, result.UpdatedBranchProtection)
+
+	repo = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	assert.Equal(t, "main", repo.DefaultBranch)
+	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 2})
+	assert.Equal(t, "main", pr.BaseBranch)
+	unittest.AssertExistsAndLoadBean(t, &git_model.ProtectedBranch{RepoID: repo.ID, BranchName: "main"})
+
+	redirects, err := gitRepo.GetBranchRedirects()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"master": "main", "trunk": "main"}, redirects)
+
+	// the redirect can't be renamed as the default branch
+	_, err = RenameDefaultBranch(db.DefaultContext, doer, repo, "master")
+	assert.True(t, models.IsErrBranchAlreadyExists(err))
+}
+
+func TestRenameOrgDefaultBranches(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	org := unittest.AssertExistsAndLoadBean(t, &organization.Organization{ID: 3})
+
+	results, updatedWebhooks, err := RenameOrgDefaultBranches(db.DefaultContext, doer, org, "master", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, updatedWebhooks)
+	for _, result := range results {
+		assert.Equal(t, "master", result.OldName)
+		if result.Err != nil {
+			continue
+		}
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: result.Repo.ID})
+		assert.Equal(t, "main", repo.DefaultBranch)
+	}
+	assert.NotEmpty(t, results)
+}
@@ -10,7 +10,9 @@ import (
 	"strings"
 
 	"code.gitea.io/gitea/models"
+	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/log"
@@ -78,14 +80,18 @@ func CherryPick(ctx context.Context, repo *repo_model.Repository, doer *user_mod
 	}
 
 	description := fmt.Sprintf("CherryPick %s onto %s", right, opts.OldBranch)
-	conflict, _, err := pull.AttemptThreeWayMerge(ctx,
+	conflict, conflictedFiles, err := pull.AttemptThreeWayMerge(ctx,
 		t.basePath, t.gitRepo, base, opts.LastCommitID, right, description)
 	if err != nil {
 		return nil, fmt.Errorf("failed to three-way merge %s onto %s: %v", right, opts.OldBranch, err)
 	}
 
 	if conflict {
-		return nil, fmt.Errorf("failed to merge due to conflicts")
+		return nil, models.ErrCherryPickConflict{
+			SHA:             commit.ID.String(),
+			Branch:          opts.OldBranch,
+			ConflictedFiles: conflictedFiles,
+		}
 	}
 
 	treeHash, err := t.WriteTree()
@@ -124,3 +130,126 @@ func CherryPick(ctx context.Context, repo *repo_model.Repository, doer *user_mod
 
 	return fileResponse, nil
 }
+
+// CherryPickCommitOptions holds the options to cherry-pick or revert a commit onto a branch
+type CherryPickCommitOptions struct {
+	SHA       string
+	Revert    bool
+	Branch    string
+	NewBranch string
+	Message   string
+	Author    *IdentityOptions
+	Committer *IdentityOptions
+	Dates     *CommitDateOptions
+	Signoff   bool
+}
+
+// CherryPickCommitResult holds the commit created by CherryPickCommit, the branch it was pushed to and the pull
+// request opened to merge it if the target branch is protected
+type CherryPickCommitResult struct {
+	*structs.FileResponse
+	Branch      string
+	PullRequest *issues_model.PullRequest
+}
+
+// GetUniquePatchBranchName returns a name for a new branch of the doer which doesn't exist yet in the repository
+func GetUniquePatchBranchName(gitRepo *git.Repository, doer *user_model.User) (string, error) {
+	prefix := doer.LowerName + "-patch-"
+	for i := 1; i <= 1000; i++ {
+		branchName := fmt.Sprintf("%s%d", prefix, i)
+		if _, err := gitRepo.GetBranch(branchName); err != nil {
+			if git.IsErrBranchNotExist(err) {
+				return branchName, nil
+			}
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no free branch name with the prefix %s", prefix)
+}
+
+// NewCherryPickPullRequest opens a pull request to merge the branch holding a cherry-picked or reverted commit into
+// the branch it was created from, the commit message is used for the title and the content of the pull request
+func NewCherryPickPullRequest(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, baseBranch, headBranch, mergeBase, message string) (*issues_model.PullRequest, error) {
+	title, content, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	pullIssue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		Title:    title,
+		PosterID: doer.ID,
+		Poster:   doer,
+		IsPull:   true,
+		Content:  strings.TrimSpace(content),
+	}
+	pr := &issues_model.PullRequest{
+		HeadRepoID: repo.ID,
+		BaseRepoID: repo.ID,
+		HeadBranch: headBranch,
+		BaseBranch: baseBranch,
+		HeadRepo:   repo,
+		BaseRepo:   repo,
+		MergeBase:  mergeBase,
+		Type:       issues_model.PullRequestGitea,
+	}
+	if err := pull.NewPullRequest(ctx, repo, pullIssue, nil, nil, pr, nil); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// CherryPickCommit cherry-picks or reverts a commit onto a branch, or onto a new branch created from it. If no new
+// branch is given and the doer cannot commit to the branch because it is protected, the commit is pushed to a new
+// branch instead and a pull request is opened to merge it into the protected branch. It returns
+// models.ErrCherryPickConflict if the commit cannot be applied cleanly.
+func CherryPickCommit(ctx context.Context, repo *repo_model.Repository, gitRepo *git.Repository, doer *user_model.User, opts *CherryPickCommitOptions) (*CherryPickCommitResult, error) {
+	if opts.Branch == "" {
+		opts.Branch = repo.DefaultBranch
+	}
+	commit, err := gitRepo.GetCommit(opts.SHA)
+	if err != nil {
+		return nil, err
+	}
+
+	message := strings.TrimSpace(opts.Message)
+	if message == "" {
+		if opts.Revert {
+			message = fmt.Sprintf("Revert \"%s\"\n\nThis reverts commit %s.", commit.Summary(), commit.ID)
+		} else {
+			message = strings.TrimSpace(commit.Message())
+		}
+	}
+
+	patchOpts := &ApplyDiffPatchOptions{
+		OldBranch: opts.Branch,
+		NewBranch: opts.NewBranch,
+		Message:   message,
+		Content:   commit.ID.String(),
+		Author:    opts.Author,
+		Committer: opts.Committer,
+		Dates:     opts.Dates,
+		Signoff:   opts.Signoff,
+	}
+	fileResponse, err := CherryPick(ctx, repo, doer, opts.Revert, patchOpts)
+	openPull := false
+	if models.IsErrUserCannotCommit(err) && opts.NewBranch == "" && repo.UnitEnabledCtx(ctx, unit.TypePullRequests) {
+		// the branch is protected, propose the commit in a pull request instead
+		if patchOpts.NewBranch, err = GetUniquePatchBranchName(gitRepo, doer); err != nil {
+			return nil, err
+		}
+		openPull = true
+		fileResponse, err = CherryPick(ctx, repo, doer, opts.Revert, patchOpts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CherryPickCommitResult{
+		FileResponse: fileResponse,
+		Branch:       patchOpts.NewBranch,
+	}
+	if openPull {
+		if result.PullRequest, err = NewCherryPickPullRequest(ctx, repo, doer, opts.Branch, patchOpts.NewBranch, patchOpts.LastCommitID, message); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
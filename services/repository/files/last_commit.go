@@ -0,0 +1,77 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package files
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// GetLastCommits returns the last commit which changed each of the paths of the tree of a commit, the root of the tree
+// being the empty path. The paths are grouped by directory so that the history is walked once per directory, like the
+// tree view does, and the results are kept in the last commit cache of the repository when it is set.
+func GetLastCommits(ctx context.Context, repo *repo_model.Repository, commit *git.Commit, paths []string) ([]*api.LastCommit, error) {
+	cleaned := make([]string, 0, len(paths))
+	seen := make(map[string]bool, len(paths))
+	dirs := make([]string, 0, len(paths))
+	entriesByDir := make(map[string]git.Entries)
+	for _, p := range paths {
+		p = strings.TrimPrefix(path.Clean("/"+p), "/")
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		cleaned = append(cleaned, p)
+		if p == "" {
+			continue
+		}
+
+		entry, err := commit.GetTreeEntryByPath(p)
+		if err != nil {
+			return nil, err
+		}
+		dir := path.Dir("/" + p)[1:]
+		if _, ok := entriesByDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		entriesByDir[dir] = append(entriesByDir[dir], entry)
+	}
+
+	commits := map[string]*git.Commit{"": commit}
+	for _, dir := range dirs {
+		infos, _, err := entriesByDir[dir].GetCommitsInfo(ctx, commit, dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			commits[path.Join(dir, info.Entry.Name())] = info.Commit
+		}
+	}
+
+	// many paths usually share the same last commit
+	responses := make(map[string]*api.FileCommitResponse)
+	lastCommits := make([]*api.LastCommit, 0, len(cleaned))
+	for _, p := range cleaned {
+		lastCommit := &api.LastCommit{Path: p}
+		if c := commits[p]; c != nil {
+			resp, ok := responses[c.ID.String()]
+			if !ok {
+				var err error
+				if resp, err = GetFileCommitResponse(repo, c); err != nil {
+					return nil, err
+				}
+				responses[c.ID.String()] = resp
+			}
+			lastCommit.Commit = resp
+		}
+		lastCommits = append(lastCommits, lastCommit)
+	}
+	return lastCommits, nil
+}
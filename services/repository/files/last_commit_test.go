@@ -0,0 +1,38 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package files
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLastCommits(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+	ctx := test.MockContext(t, "user2/repo1")
+	test.LoadRepo(t, ctx, 1)
+	test.LoadRepoCommit(t, ctx)
+	test.LoadGitRepo(t, ctx)
+	defer ctx.Repo.GitRepo.Close()
+
+	lastCommits, err := GetLastCommits(ctx, ctx.Repo.Repository, ctx.Repo.Commit, []string{"README.md", "/", "/README.md"})
+	assert.NoError(t, err)
+	if assert.Len(t, lastCommits, 2) {
+		assert.Equal(t, "README.md", lastCommits[0].Path)
+		assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", lastCommits[0].Commit.SHA)
+		assert.Equal(t, "", lastCommits[1].Path)
+		assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", lastCommits[1].Commit.SHA)
+		// the paths changed by the same commit share its response
+		assert.Same(t, lastCommits[0].Commit, lastCommits[1].Commit)
+	}
+
+	_, err = GetLastCommits(ctx, ctx.Repo.Repository, ctx.Repo.Commit, []string{"README.md", "unknown.md"})
+	assert.True(t, git.IsErrNotExist(err))
+}
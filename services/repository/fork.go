@@ -44,6 +44,8 @@ type ForkRepoOptions struct {
 	BaseRepo    *repo_model.Repository
 	Name        string
 	Description string
+	// Temporary forks are always private and may coexist with other forks of the same owner
+	Temporary bool
 }
 
 // ForkRepository forks a repository
@@ -52,7 +54,7 @@ func ForkRepository(ctx context.Context, doer, owner *user_model.User, opts Fork
 	if err != nil {
 		return nil, err
 	}
-	if forkedRepo != nil {
+	if forkedRepo != nil && !opts.Temporary {
 		return nil, ErrForkAlreadyExist{
 			Uname:    owner.Name,
 			RepoName: opts.BaseRepo.FullName(),
@@ -68,7 +70,7 @@ func ForkRepository(ctx context.Context, doer, owner *user_model.User, opts Fork
 		LowerName:     strings.ToLower(opts.Name),
 		Description:   opts.Description,
 		DefaultBranch: opts.BaseRepo.DefaultBranch,
-		IsPrivate:     opts.BaseRepo.IsPrivate || opts.BaseRepo.Owner.Visibility == structs.VisibleTypePrivate,
+		IsPrivate:     opts.Temporary || opts.BaseRepo.IsPrivate || opts.BaseRepo.Owner.Visibility == structs.VisibleTypePrivate,
 		IsEmpty:       opts.BaseRepo.IsEmpty,
 		IsFork:        true,
 		ForkID:        opts.BaseRepo.ID,
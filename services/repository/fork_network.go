@@ -0,0 +1,132 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/git"
+)
+
+// ForkNetworkRepo is a repository of a fork network
+type ForkNetworkRepo struct {
+	Repo *repo_model.Repository
+	// Parent is the repository the repository was forked from, nil for the root or if the doer can't access it
+	Parent *repo_model.Repository
+}
+
+// GetForkNetwork returns the repositories of the fork network of a repository the doer can access,
+// the root of the network first
+func GetForkNetwork(ctx context.Context, repo *repo_model.Repository, doer *user_model.User) (*repo_model.Repository, []*ForkNetworkRepo, error) {
+	root, err := repo_model.GetForkNetworkRoot(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	forks, err := repo_model.GetForkNetwork(ctx, root, doer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	network := make([]*ForkNetworkRepo, 0, len(forks)+1)
+	perm, err := access_model.GetUserRepoPermission(ctx, root, doer)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[int64]*repo_model.Repository, len(forks)+1)
+	if perm.HasAccess() {
+		network = append(network, &ForkNetworkRepo{Repo: root})
+		byID[root.ID] = root
+	}
+	for _, fork := range forks {
+		byID[fork.ID] = fork
+	}
+	for _, fork := range forks {
+		network = append(network, &ForkNetworkRepo{Repo: fork, Parent: byID[fork.ForkID]})
+	}
+	return root, network, nil
+}
+
+func getDefaultBranchCommitID(ctx context.Context, repo *repo_model.Repository) (string, error) {
+	gitRepo, closer, err := git.RepositoryFromContextOrOpen(ctx, repo.RepoPath())
+	if err != nil {
+		return "", err
+	}
+	defer closer.Close()
+
+	commitID, err := gitRepo.GetBranchCommitID(repo.DefaultBranch)
+	if err != nil && git.IsErrNotExist(err) {
+		return "", nil
+	}
+	return commitID, err
+}
+
+// GetForkDivergence returns the number of commits the default branch of a fork is ahead or behind the default branch
+// of the root of its fork network, or nil if one of them is empty. The divergence is computed when requested and
+// cached until one of the branches changes.
+func GetForkDivergence(ctx context.Context, root, fork *repo_model.Repository) (*git.DivergeObject, error) {
+	if root.IsEmpty || fork.IsEmpty {
+		return nil, nil
+	}
+
+	rootCommitID, err := getDefaultBranchCommitID(ctx, root)
+	if err != nil || rootCommitID == "" {
+		return nil, err
+	}
+	forkCommitID, err := getDefaultBranchCommitID(ctx, fork)
+	if err != nil || forkCommitID == "" {
+		return nil, err
+	}
+
+	// the commit IDs identify the divergence, whatever the repositories are
+	divergence := &git.DivergeObject{}
+	value, err := cache.GetString(fmt.Sprintf("fork_divergence_%s_%s", rootCommitID, forkCommitID), func() (string, error) {
+		computed, err := git.GetDivergingCommitsBetweenRepos(ctx, root.RepoPath(), rootCommitID, fork.RepoPath(), forkCommitID)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d,%d", computed.Ahead, computed.Behind), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Sscanf(value, "%d,%d", &divergence.Ahead, &divergence.Behind); err != nil {
+		return nil, err
+	}
+	return divergence, nil
+}
+
+// ForkContainingCommit is a repository of a fork network containing a commit
+type ForkContainingCommit struct {
+	Repo *repo_model.Repository
+	// Branches are the branches of the repository containing the commit
+	Branches []string
+}
+
+// FindForksContainingCommit returns the repositories of a fork network having a branch which contains a commit
+func FindForksContainingCommit(ctx context.Context, network []*ForkNetworkRepo, commitID string) ([]*ForkContainingCommit, error) {
+	if !git.IsValidSHAPattern(commitID) {
+		return nil, fmt.Errorf("invalid commit ID: %s", commitID)
+	}
+
+	forks := make([]*ForkContainingCommit, 0, len(network))
+	for _, networkRepo := range network {
+		if networkRepo.Repo.IsEmpty {
+			continue
+		}
+		branches, err := git.GetBranchesContainingCommit(ctx, networkRepo.Repo.RepoPath(), commitID)
+		if err != nil {
+			return nil, err
+		}
+		if len(branches) > 0 {
+			forks = append(forks, &ForkContainingCommit{Repo: networkRepo.Repo, Branches: branches})
+		}
+	}
+	return forks, nil
+}
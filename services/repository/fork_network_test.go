@@ -0,0 +1,56 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repository
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/git"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetForkNetwork(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 29})
+
+	root, network, err := GetForkNetwork(db.DefaultContext, repo, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 27, root.ID)
+	if assert.Len(t, network, 2) {
+		assert.EqualValues(t, 27, network[0].Repo.ID)
+		assert.Nil(t, network[0].Parent)
+		assert.EqualValues(t, 29, network[1].Repo.ID)
+		assert.EqualValues(t, 27, network[1].Parent.ID)
+	}
+}
+
+func TestFindForksContainingCommit(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	root, network, err := GetForkNetwork(db.DefaultContext, repo, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, repo.ID, root.ID)
+	assert.Len(t, network, 1)
+
+	forks, err := FindForksContainingCommit(git.DefaultContext, network, "65f1bf27bc3bf70f64657658635e66094edbcb4d")
+	assert.NoError(t, err)
+	if assert.Len(t, forks, 1) {
+		assert.EqualValues(t, repo.ID, forks[0].Repo.ID)
+		assert.Contains(t, forks[0].Branches, "master")
+	}
+
+	forks, err = FindForksContainingCommit(git.DefaultContext, network, "0000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	assert.Empty(t, forks)
+
+	divergence, err := GetForkDivergence(git.DefaultContext, root, repo)
+	assert.NoError(t, err)
+	assert.Equal(t, &git.DivergeObject{}, divergence)
+}
@@ -0,0 +1,242 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// ErrRepoNotFork represents a "RepoNotFork" kind of error.
+type ErrRepoNotFork struct {
+	RepoID int64
+}
+
+// IsErrRepoNotFork checks if an error is an ErrRepoNotFork.
+func IsErrRepoNotFork(err error) bool {
+	_, ok := err.(ErrRepoNotFork)
+	return ok
+}
+
+func (err ErrRepoNotFork) Error() string {
+	return fmt.Sprintf("repository is not a fork [id: %d]", err.RepoID)
+}
+
+// ErrForkSyncConflict represents a "ForkSyncConflict" kind of error.
+type ErrForkSyncConflict struct {
+	Files []string
+}
+
+// IsErrForkSyncConflict checks if an error is an ErrForkSyncConflict.
+func IsErrForkSyncConflict(err error) bool {
+	_, ok := err.(ErrForkSyncConflict)
+	return ok
+}
+
+func (err ErrForkSyncConflict) Error() string {
+	return fmt.Sprintf("merging the upstream repository conflicts [files: %s]", strings.Join(err.Files, ", "))
+}
+
+// ForkSyncStatus is the way the default branch of a fork is synchronized with its upstream repository
+type ForkSyncStatus string
+
+const (
+	// ForkSyncUpToDate means the fork already contains all the commits of its upstream repository
+	ForkSyncUpToDate ForkSyncStatus = "up_to_date"
+	// ForkSyncFastForward means the fork has no commit of its own and is fast-forwarded to its upstream repository
+	ForkSyncFastForward ForkSyncStatus = "fast_forward"
+	// ForkSyncMerge means the upstream repository is merged into the fork with a merge commit
+	ForkSyncMerge ForkSyncStatus = "merge"
+)
+
+// ForkSyncResult is the result of the synchronization of the default branch of a fork with its upstream repository
+type ForkSyncResult struct {
+	Status ForkSyncStatus
+	// Ahead and Behind are the numbers of commits the fork was ahead and behind its upstream repository before the synchronization
+	Ahead  int
+	Behind int
+	// CommitID is the commit of the default branch of the fork after the synchronization, or before it for a dry run
+	CommitID string
+}
+
+// SyncFork synchronizes the default branch of a fork with the default branch of its upstream repository, as the doer.
+// The fork is fast-forwarded if it has no commit of its own, otherwise the upstream branch is merged into it. The
+// branch of the fork is never force-pushed: if the merge conflicts, an ErrForkSyncConflict is returned and the fork is
+// left unchanged. A dry run only checks how the fork would be synchronized.
+func SyncFork(ctx context.Context, doer *user_model.User, fork *repo_model.Repository, dryRun bool) (*ForkSyncResult, error) {
+	if !fork.IsFork {
+		return nil, ErrRepoNotFork{RepoID: fork.ID}
+	}
+	upstream, err := repo_model.GetRepositoryByIDCtx(ctx, fork.ForkID)
+	if err != nil {
+		return nil, err
+	}
+
+	forkCommitID, err := getDefaultBranchCommitID(ctx, fork)
+	if err != nil {
+		return nil, err
+	}
+	result := &ForkSyncResult{Status: ForkSyncUpToDate, CommitID: forkCommitID}
+	if upstream.IsEmpty {
+		return result, nil
+	}
+	upstreamCommitID, err := getDefaultBranchCommitID(ctx, upstream)
+	if err != nil || upstreamCommitID == "" {
+		return result, err
+	}
+
+	if forkCommitID != "" {
+		divergence, err := git.GetDivergingCommitsBetweenRepos(ctx, upstream.RepoPath(), upstreamCommitID, fork.RepoPath(), forkCommitID)
+		if err != nil {
+			return nil, err
+		}
+		result.Ahead, result.Behind = divergence.Ahead, divergence.Behind
+		if result.Behind == 0 {
+			return result, nil
+		}
+	}
+
+	if result.Ahead == 0 {
+		result.Status = ForkSyncFastForward
+		if dryRun {
+			return result, nil
+		}
+		if err := git.Push(ctx, upstream.RepoPath(), git.PushOptions{
+			Remote: fork.RepoPath(),
+			Branch: upstreamCommitID + ":" + git.BranchPrefix + fork.DefaultBranch,
+			Env:    repo_module.PushingEnvironment(doer, fork),
+		}); err != nil {
+			return nil, err
+		}
+		result.CommitID = upstreamCommitID
+		return result, nil
+	}
+
+	result.Status = ForkSyncMerge
+	commitID, err := mergeUpstream(ctx, doer, upstream, fork, upstreamCommitID, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	if !dryRun {
+		result.CommitID = commitID
+	}
+	return result, nil
+}
+
+// mergeUpstream merges a commit of the default branch of the upstream repository into the default branch of the fork
+// in a temporary repository, and pushes the merge commit to the fork unless it is a dry run
+func mergeUpstream(ctx context.Context, doer *user_model.User, upstream, fork *repo_model.Repository, upstreamCommitID string, dryRun bool) (string, error) {
+	tmpBasePath, err := repo_module.CreateTemporaryPath("sync-fork")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := repo_module.RemoveTemporaryPath(tmpBasePath); err != nil {
+			log.Error("SyncFork: RemoveTemporaryPath: %v", err)
+		}
+	}()
+
+	if _, _, err := git.NewCommand(ctx, "clone", "-s", "--no-tags", "-b", fork.DefaultBranch, fork.RepoPath(), tmpBasePath).RunStdString(nil); err != nil {
+		return "", fmt.Errorf("clone %s: %v", fork.FullName(), err)
+	}
+	if _, _, err := git.NewCommand(ctx, "fetch", "--no-tags", upstream.RepoPath(), git.BranchPrefix+upstream.DefaultBranch).RunStdString(&git.RunOpts{Dir: tmpBasePath}); err != nil {
+		return "", fmt.Errorf("fetch %s: %v", upstream.FullName(), err)
+	}
+
+	sig := doer.NewGitSig()
+	env := append(os.Environ(),
+		"GIT_AUTHOR_NAME="+sig.Name,
+		"GIT_AUTHOR_EMAIL="+sig.Email,
+		"GIT_COMMITTER_NAME="+sig.Name,
+		"GIT_COMMITTER_EMAIL="+sig.Email,
+	)
+	message := fmt.Sprintf("Merge branch '%s' of %s into %s", upstream.DefaultBranch, upstream.FullName(), fork.DefaultBranch)
+	if _, _, err := git.NewCommand(ctx, "merge", "--no-ff", "--no-edit", "--no-gpg-sign", "-m", message, upstreamCommitID).RunStdString(&git.RunOpts{Dir: tmpBasePath, Env: env}); err != nil {
+		stdout, _, diffErr := git.NewCommand(ctx, "diff", "--name-only", "--diff-filter=U").RunStdString(&git.RunOpts{Dir: tmpBasePath})
+		if diffErr == nil && len(strings.TrimSpace(stdout)) > 0 {
+			return "", ErrForkSyncConflict{Files: strings.Fields(stdout)}
+		}
+		return "", fmt.Errorf("merge %s: %v", upstream.FullName(), err)
+	}
+	if dryRun {
+		return "", nil
+	}
+
+	stdout, _, err := git.NewCommand(ctx, "rev-parse", "HEAD").RunStdString(&git.RunOpts{Dir: tmpBasePath})
+	if err != nil {
+		return "", err
+	}
+	commitID := strings.TrimSpace(stdout)
+	// the branch is not forced: the push fails if the fork changed in the meantime
+	if err := git.Push(ctx, tmpBasePath, git.PushOptions{
+		Remote: fork.RepoPath(),
+		Branch: commitID + ":" + git.BranchPrefix + fork.DefaultBranch,
+		Env:    repo_module.PushingEnvironment(doer, fork),
+	}); err != nil {
+		return "", err
+	}
+	return commitID, nil
+}
+
+// SyncScheduledForks synchronizes the forks having a scheduled synchronization, as the users who enabled it.
+// The failures, such as conflicts, are recorded on the schedules and don't stop the synchronization of the other forks.
+func SyncScheduledForks(ctx context.Context) error {
+	syncs, err := repo_model.FindForkSyncs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range syncs {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("aborted before syncing fork %d", s.RepoID)
+		default:
+		}
+
+		s.LastError = ""
+		if err := syncScheduledFork(ctx, s); err != nil {
+			log.Warn("SyncScheduledForks: unable to sync repository %d: %v", s.RepoID, err)
+			s.LastError = err.Error()
+		}
+		s.LastSyncUnix = timeutil.TimeStampNow()
+		if err := repo_model.UpdateForkSyncResult(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func syncScheduledFork(ctx context.Context, s *repo_model.ForkSync) error {
+	fork, err := repo_model.GetRepositoryByIDCtx(ctx, s.RepoID)
+	if err != nil {
+		return err
+	}
+	if fork.IsArchived {
+		return nil
+	}
+	doer, err := user_model.GetUserByIDCtx(ctx, s.DoerID)
+	if err != nil {
+		return err
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, fork, doer)
+	if err != nil {
+		return err
+	}
+	if !doer.IsActive || doer.ProhibitLogin || !perm.CanWrite(unit.TypeCode) {
+		return fmt.Errorf("%s is not allowed to push to %s anymore", doer.Name, fork.FullName())
+	}
+	_, err = SyncFork(ctx, doer, fork, false)
+	return err
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repository
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// commitFile commits a file to the branch of a bare repository without running its hooks
+func commitFile(t *testing.T, repoPath, branch, filename, content string) string {
+	indexFile := t.TempDir() + "/index"
+	env := append(os.Environ(),
+		"GIT_INDEX_FILE="+indexFile,
+		"GIT_AUTHOR_NAME=user2", "GIT_AUTHOR_EMAIL=user2@example.com",
+		"GIT_COMMITTER_NAME=user2", "GIT_COMMITTER_EMAIL=user2@example.com",
+	)
+	run := func(stdin string, args ...string) string {
+		opts := &git.RunOpts{Dir: repoPath, Env: env}
+		if stdin != "" {
+			opts.Stdin = strings.NewReader(stdin)
+		}
+		stdout, _, err := git.NewCommand(git.DefaultContext, args...).RunStdString(opts)
+		assert.NoError(t, err)
+		return strings.TrimSpace(stdout)
+	}
+
+	run("", "read-tree", branch)
+	blob := run(content, "hash-object", "-w", "--stdin")
+	run("", "update-index", "--add", "--cacheinfo", "100644,"+blob+","+filename)
+	tree := run("", "write-tree")
+	commitID := run("", "commit-tree", tree, "-p", branch, "-m", "Update "+filename)
+	run("", "update-ref", git.BranchPrefix+branch, commitID)
+	return commitID
+}
+
+func TestSyncFork(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	upstream := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 10})
+	fork := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 11})
+
+	_, err := SyncFork(db.DefaultContext, doer, upstream, true)
+	assert.True(t, IsErrRepoNotFork(err))
+
+	fork.IsFork = true
+	const initialCommitID = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+	t.Cleanup(func() {
+		for _, repoPath := range []string{upstream.RepoPath(), fork.RepoPath()} {
+			_, _, err := git.NewCommand(git.DefaultContext, "update-ref", git.BranchPrefix+"master", initialCommitID).RunStdString(&git.RunOpts{Dir: repoPath})
+			assert.NoError(t, err)
+		}
+	})
+
+	result, err := SyncFork(db.DefaultContext, doer, fork, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &ForkSyncResult{Status: ForkSyncUpToDate, CommitID: initialCommitID}, result)
+
+	commitFile(t, upstream.RepoPath(), "master", "README.md", "upstream\n")
+	result, err = SyncFork(db.DefaultContext, doer, fork, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &ForkSyncResult{Status: ForkSyncFastForward, Behind: 1, CommitID: initialCommitID}, result)
+
+	commitFile(t, fork.RepoPath(), "master", "FORK.md", "fork\n")
+	result, err = SyncFork(db.DefaultContext, doer, fork, true)
+	assert.NoError(t, err)
+	assert.Equal(t, ForkSyncMerge, result.Status)
+	assert.Equal(t, 1, result.Ahead)
+	assert.Equal(t, 1, result.Behind)
+
+	forkCommitID := commitFile(t, fork.RepoPath(), "master", "README.md", "fork\n")
+	_, err = SyncFork(db.DefaultContext, doer, fork, true)
+	if assert.True(t, IsErrForkSyncConflict(err)) {
+		assert.Equal(t, []string{"README.md"}, err.(ErrForkSyncConflict).Files)
+	}
+
+	// the fork is left unchanged
+	stdout, _, err := git.NewCommand(git.DefaultContext, "rev-parse", "master").RunStdString(&git.RunOpts{Dir: fork.RepoPath()})
+	assert.NoError(t, err)
+	assert.Equal(t, forkCommitID, strings.TrimSpace(stdout))
+}
+
+func TestEnableForkSync(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	assert.NoError(t, repo_model.EnableForkSync(db.DefaultContext, 11, 2))
+	assert.NoError(t, repo_model.EnableForkSync(db.DefaultContext, 11, 13))
+	s, err := repo_model.GetForkSync(db.DefaultContext, 11)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 13, s.DoerID)
+
+	// the repository isn't a fork in the fixtures, its synchronization fails
+	assert.NoError(t, SyncScheduledForks(db.DefaultContext))
+	s = unittest.AssertExistsAndLoadBean(t, &repo_model.ForkSync{RepoID: 11})
+	assert.NotZero(t, s.LastSyncUnix)
+	assert.NotEmpty(t, s.LastError)
+
+	assert.NoError(t, repo_model.DisableForkSync(db.DefaultContext, 11))
+	unittest.AssertNotExistsBean(t, &repo_model.ForkSync{RepoID: 11})
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repository
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// UpdateMaintenanceReports computes the maintenance reports of all the repositories indexed by the expertise indexer,
+// the directories and the dependency manifests which have not been changed for staleMonths months are stale
+func UpdateMaintenanceReports(ctx context.Context, staleMonths int) error {
+	staleBefore := timeutil.TimeStamp(timeutil.TimeStampNow().AsTime().AddDate(0, -staleMonths, 0).Unix())
+	return db.Iterate(
+		ctx,
+		new(repo_model.RepoIndexerStatus),
+		builder.Eq{"indexer_type": repo_model.RepoIndexerTypeExpertise},
+		func(idx int, bean interface{}) error {
+			status := bean.(*repo_model.RepoIndexerStatus)
+			select {
+			case <-ctx.Done():
+				return db.ErrCancelledf("before maintenance report of repository %d", status.RepoID)
+			default:
+			}
+			report, err := repo_model.ComputeMaintenanceReport(ctx, status.RepoID, status.CommitSha, staleBefore)
+			if err != nil {
+				log.Error("ComputeMaintenanceReport[%d]: %v", status.RepoID, err)
+				return nil
+			}
+			if err := repo_model.SaveMaintenanceReport(ctx, report); err != nil {
+				log.Error("SaveMaintenanceReport[%d]: %v", status.RepoID, err)
+			}
+			return nil
+		},
+	)
+}
@@ -22,6 +22,7 @@ import (
 	repo_module "code.gitea.io/gitea/modules/repository"
 	"code.gitea.io/gitea/modules/setting"
 	pull_service "code.gitea.io/gitea/services/pull"
+	spam_service "code.gitea.io/gitea/services/spam"
 )
 
 // CreateRepository creates a repository for the user/organization.
@@ -32,6 +33,10 @@ func CreateRepository(doer, owner *user_model.User, opts repo_module.CreateRepoO
 		return nil, err
 	}
 
+	if _, err := spam_service.CheckRepository(db.DefaultContext, doer, repo); err != nil {
+		log.Error("CheckRepository: %v", err)
+	}
+
 	notification.NotifyCreateRepository(doer, owner, repo)
 
 	return repo, nil
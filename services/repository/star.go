@@ -0,0 +1,26 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repository
+
+import (
+	"context"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/notification"
+)
+
+// StarRepo stars or unstars a repository as the doer, the change is notified if the repository wasn't already
+// starred or unstarred
+func StarRepo(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, star bool) error {
+	if repo_model.IsStaring(ctx, doer.ID, repo.ID) == star {
+		return nil
+	}
+	if err := repo_model.StarRepo(doer.ID, repo.ID, star); err != nil {
+		return err
+	}
+	notification.NotifyStarRepository(doer, repo, star)
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package spam
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+type classifierUser struct {
+	ID       int64     `json:"id"`
+	Name     string    `json:"name"`
+	FullName string    `json:"full_name"`
+	Email    string    `json:"email"`
+	Website  string    `json:"website"`
+	Created  time.Time `json:"created"`
+}
+
+// classifierRequest is the content POSTed to the external classifier
+type classifierRequest struct {
+	Type       string         `json:"type"`
+	User       classifierUser `json:"user"`
+	Repository string         `json:"repository"`
+	Title      string         `json:"title"`
+	Content    string         `json:"content"`
+}
+
+// classifierResponse is the answer of the external classifier
+type classifierResponse struct {
+	Score  int    `json:"score"`
+	Reason string `json:"reason"`
+}
+
+// checkClassifier asks the external classifier to score the content, when one is configured
+func checkClassifier(ctx context.Context, content *Content) (int, string, error) {
+	if setting.Spam.ClassifierURL == "" {
+		return 0, "", nil
+	}
+
+	payload := classifierRequest{
+		Type: string(content.Type),
+		User: classifierUser{
+			ID:       content.Poster.ID,
+			Name:     content.Poster.Name,
+			FullName: content.Poster.FullName,
+			Email:    content.Poster.Email,
+			Website:  content.Poster.Website,
+			Created:  content.Poster.CreatedUnix.AsTime(),
+		},
+		Title:   content.Title,
+		Content: content.Body,
+	}
+	if content.Repo != nil {
+		payload.Repository = content.Repo.FullName()
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, setting.Spam.ClassifierTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, setting.Spam.ClassifierURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Gitea "+setting.AppVer)
+	if setting.Spam.ClassifierToken != "" {
+		req.Header.Set("Authorization", "Bearer "+setting.Spam.ClassifierToken)
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy: proxy.Proxy(),
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, "", fmt.Errorf("classifier answered with status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return 0, "", err
+	}
+	var result classifierResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return 0, "", err
+	}
+	if result.Score <= 0 {
+		return 0, "", nil
+	}
+	reason := "classifier"
+	if result.Reason != "" {
+		reason += ": " + result.Reason
+	}
+	return result.Score, reason, nil
+}
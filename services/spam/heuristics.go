@@ -0,0 +1,79 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package spam
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// Scores of the built-in checks
+const (
+	tooManyLinksScore    = 50
+	blockedWordScore     = 100
+	repeatedContentScore = 50
+)
+
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://`)
+
+func init() {
+	RegisterChecker("links", CheckerFunc(checkLinks))
+	RegisterChecker("blocked_words", CheckerFunc(checkBlockedWords))
+	RegisterChecker("repeated_content", CheckerFunc(checkRepeatedContent))
+	RegisterChecker("classifier", CheckerFunc(checkClassifier))
+}
+
+// checkLinks scores the content with more links than allowed
+func checkLinks(_ context.Context, content *Content) (int, string, error) {
+	links := len(linkPattern.FindAllStringIndex(content.Title+"\n"+content.Body, -1))
+	if links <= setting.Spam.MaxLinks {
+		return 0, "", nil
+	}
+	return tooManyLinksScore, fmt.Sprintf("%d links", links), nil
+}
+
+// checkBlockedWords scores each blocked word found in the content
+func checkBlockedWords(_ context.Context, content *Content) (int, string, error) {
+	text := strings.ToLower(content.Title + "\n" + content.Body)
+	var found []string
+	for _, word := range setting.Spam.BlockedWords {
+		if strings.Contains(text, word) {
+			found = append(found, word)
+		}
+	}
+	if len(found) == 0 {
+		return 0, "", nil
+	}
+	return blockedWordScore * len(found), "blocked words: " + strings.Join(found, ", "), nil
+}
+
+// checkRepeatedContent scores the issues and comments whose content was already posted by the same user within a day
+func checkRepeatedContent(ctx context.Context, content *Content) (int, string, error) {
+	if strings.TrimSpace(content.Body) == "" {
+		return 0, "", nil
+	}
+	since := timeutil.TimeStamp(time.Now().Add(-24 * time.Hour).Unix())
+
+	var count int64
+	var err error
+	switch content.Type {
+	case moderation_model.HoldTypeIssue:
+		count, err = issues_model.CountPosterIssuesWithContent(ctx, content.Poster.ID, content.ID, content.Body, since)
+	case moderation_model.HoldTypeComment:
+		count, err = issues_model.CountPosterCommentsWithContent(ctx, content.Poster.ID, content.ID, content.Body, since)
+	}
+	if err != nil || count == 0 {
+		return 0, "", err
+	}
+	return repeatedContentScore, fmt.Sprintf("same content posted %d times within a day", count+1), nil
+}
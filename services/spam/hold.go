@@ -0,0 +1,119 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package spam
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// check scores the content and returns the hold to create, or nil if the content doesn't need to be held
+func check(ctx context.Context, content *Content) *moderation_model.Hold {
+	score, reasons := Score(ctx, content)
+	if score < setting.Spam.HoldScore {
+		return nil
+	}
+	return &moderation_model.Hold{
+		Type:     content.Type,
+		PosterID: content.Poster.ID,
+		OwnerID:  content.Repo.OwnerID,
+		RepoID:   content.Repo.ID,
+		Score:    score,
+		Reasons:  strings.Join(reasons, "\n"),
+	}
+}
+
+// CheckIssue scores a new issue of a new account, a suspicious issue is closed and locked until a site administrator
+// reviews it. It returns true if the issue is held, in which case no notification must be sent about it.
+func CheckIssue(ctx context.Context, issue *issues_model.Issue) (bool, error) {
+	if issue.IsPull || !NeedsCheck(issue.Poster) {
+		return false, nil
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return false, err
+	}
+	hold := check(ctx, &Content{
+		Type:   moderation_model.HoldTypeIssue,
+		ID:     issue.ID,
+		Poster: issue.Poster,
+		Repo:   issue.Repo,
+		Title:  issue.Title,
+		Body:   issue.Content,
+	})
+	if hold == nil {
+		return false, nil
+	}
+	hold.IssueID = issue.ID
+
+	return true, db.WithTx(func(ctx context.Context) error {
+		if err := issues_model.SetIssueHeld(ctx, issue, true); err != nil {
+			return err
+		}
+		return moderation_model.CreateHold(ctx, hold)
+	}, ctx)
+}
+
+// CheckComment scores a new comment of a new account, a suspicious comment is hidden until a site administrator
+// reviews it. It returns true if the comment is held, in which case no notification must be sent about it.
+func CheckComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, comment *issues_model.Comment) (bool, error) {
+	if comment.Type != issues_model.CommentTypeComment || !NeedsCheck(doer) {
+		return false, nil
+	}
+	hold := check(ctx, &Content{
+		Type:   moderation_model.HoldTypeComment,
+		ID:     comment.ID,
+		Poster: doer,
+		Repo:   repo,
+		Body:   comment.Content,
+	})
+	if hold == nil {
+		return false, nil
+	}
+	hold.IssueID = comment.IssueID
+	hold.CommentID = comment.ID
+
+	return true, db.WithTx(func(ctx context.Context) error {
+		comment.IsHidden = true
+		if err := issues_model.UpdateCommentCols(ctx, comment, "is_hidden"); err != nil {
+			return err
+		}
+		return moderation_model.CreateHold(ctx, hold)
+	}, ctx)
+}
+
+// CheckRepository scores a new public repository created by a new account, a suspicious repository is made private
+// until a site administrator reviews it. It returns true if the repository is held.
+func CheckRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) (bool, error) {
+	if repo.IsPrivate || !NeedsCheck(doer) {
+		return false, nil
+	}
+	hold := check(ctx, &Content{
+		Type:   moderation_model.HoldTypeRepo,
+		ID:     repo.ID,
+		Poster: doer,
+		Repo:   repo,
+		Title:  repo.Name,
+		Body:   strings.TrimSpace(repo.Description + "\n" + repo.Website),
+	})
+	if hold == nil {
+		return false, nil
+	}
+
+	return true, db.WithTx(func(ctx context.Context) error {
+		repo.IsPrivate = true
+		if err := repo_module.UpdateRepository(ctx, repo, true); err != nil {
+			return err
+		}
+		return moderation_model.CreateHold(ctx, hold)
+	}, ctx)
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package spam
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// Content is new content submitted to the spam checkers
+type Content struct {
+	Type moderation_model.HoldType
+	// ID is the id of the issue, the comment or the repository
+	ID     int64
+	Poster *user_model.User
+	Repo   *repo_model.Repository
+	// Title is the title of the issue or the name of the repository, it is empty for comments
+	Title string
+	// Body is the content of the issue or the comment, or the description of the repository
+	Body string
+}
+
+// Checker scores new content, the higher the score the more likely the content is spam.
+// The reason is shown to the site administrators when the content is held.
+type Checker interface {
+	Check(ctx context.Context, content *Content) (score int, reason string, err error)
+}
+
+// CheckerFunc is an adapter to use an ordinary function as Checker
+type CheckerFunc func(ctx context.Context, content *Content) (int, string, error)
+
+// Check calls f(ctx, content)
+func (f CheckerFunc) Check(ctx context.Context, content *Content) (int, string, error) {
+	return f(ctx, content)
+}
+
+type namedChecker struct {
+	name    string
+	checker Checker
+}
+
+var (
+	checkersMu sync.RWMutex
+	checkers   []namedChecker
+)
+
+// RegisterChecker registers a spam checker under a name, registering a name again replaces the checker
+func RegisterChecker(name string, checker Checker) {
+	checkersMu.Lock()
+	defer checkersMu.Unlock()
+	for i := range checkers {
+		if checkers[i].name == name {
+			checkers[i].checker = checker
+			return
+		}
+	}
+	checkers = append(checkers, namedChecker{name: name, checker: checker})
+}
+
+// UnregisterChecker removes a spam checker
+func UnregisterChecker(name string) {
+	checkersMu.Lock()
+	defer checkersMu.Unlock()
+	for i := range checkers {
+		if checkers[i].name == name {
+			checkers = append(checkers[:i], checkers[i+1:]...)
+			return
+		}
+	}
+}
+
+// NeedsCheck returns true if the content posted by the user must be checked: the spam detection only checks
+// the accounts younger than the configured age, and never the site administrators
+func NeedsCheck(u *user_model.User) bool {
+	if !setting.Spam.Enabled || u == nil || u.IsAdmin || u.IsBot() {
+		return false
+	}
+	return time.Since(u.CreatedUnix.AsTime()) < setting.Spam.NewAccountAge
+}
+
+// Score sums the scores of all the registered checkers, a failing checker is logged and ignored
+func Score(ctx context.Context, content *Content) (score int, reasons []string) {
+	checkersMu.RLock()
+	defer checkersMu.RUnlock()
+	for _, c := range checkers {
+		s, reason, err := c.checker.Check(ctx, content)
+		if err != nil {
+			log.Warn("Spam checker %s failed on %s %d: %v", c.name, content.Type, content.ID, err)
+			continue
+		}
+		if s == 0 {
+			continue
+		}
+		score += s
+		if reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+	return score, reasons
+}
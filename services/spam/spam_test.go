@@ -0,0 +1,220 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package spam
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	moderation_model "code.gitea.io/gitea/models/moderation"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func enableSpamDetection(t *testing.T) {
+	old := setting.Spam
+	t.Cleanup(func() {
+		setting.Spam = old
+	})
+	setting.Spam.Enabled = true
+	// the accounts of the fixtures are old
+	setting.Spam.NewAccountAge = 100 * 365 * 24 * time.Hour
+	setting.Spam.HoldScore = 100
+	setting.Spam.MaxLinks = 1
+	setting.Spam.BlockedWords = []string{"casino"}
+	setting.Spam.ClassifierURL = ""
+}
+
+func TestNeedsCheck(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	enableSpamDetection(t)
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	assert.False(t, NeedsCheck(admin))
+	assert.True(t, NeedsCheck(user))
+
+	setting.Spam.NewAccountAge = time.Hour
+	assert.False(t, NeedsCheck(user))
+}
+
+func TestScore(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	enableSpamDetection(t)
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	score, reasons := Score(db.DefaultContext, &Content{
+		Type:   moderation_model.HoldTypeComment,
+		Poster: user,
+		Repo:   repo,
+		Body:   "see https://example.com",
+	})
+	assert.Zero(t, score)
+	assert.Empty(t, reasons)
+
+	score, reasons = Score(db.DefaultContext, &Content{
+		Type:   moderation_model.HoldTypeComment,
+		Poster: user,
+		Repo:   repo,
+		Body:   "best Casino at https://example.com and http://example.org",
+	})
+	assert.Equal(t, blockedWordScore+tooManyLinksScore, score)
+	assert.Equal(t, []string{"2 links", "blocked words: casino"}, reasons)
+
+	// user3 already posted this comment, but long ago
+	user3 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2})
+	score, _ = Score(db.DefaultContext, &Content{
+		Type:   moderation_model.HoldTypeComment,
+		ID:     100,
+		Poster: user3,
+		Repo:   repo,
+		Body:   comment.Content,
+	})
+	assert.Zero(t, score)
+	_, err := db.GetEngine(db.DefaultContext).Exec("UPDATE comment SET created_unix = ? WHERE id = ?", time.Now().Unix(), comment.ID)
+	assert.NoError(t, err)
+	score, reasons = Score(db.DefaultContext, &Content{
+		Type:   moderation_model.HoldTypeComment,
+		ID:     100,
+		Poster: user3,
+		Repo:   repo,
+		Body:   comment.Content,
+	})
+	assert.Equal(t, repeatedContentScore, score)
+	assert.Equal(t, []string{"same content posted 2 times within a day"}, reasons)
+}
+
+func TestRegisterChecker(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	enableSpamDetection(t)
+
+	RegisterChecker("test", CheckerFunc(func(_ context.Context, content *Content) (int, string, error) {
+		return 42, "test checker", nil
+	}))
+	score, reasons := Score(db.DefaultContext, &Content{Type: moderation_model.HoldTypeRepo, Title: "repo"})
+	assert.Equal(t, 42, score)
+	assert.Equal(t, []string{"test checker"}, reasons)
+
+	UnregisterChecker("test")
+	score, _ = Score(db.DefaultContext, &Content{Type: moderation_model.HoldTypeRepo, Title: "repo"})
+	assert.Zero(t, score)
+}
+
+func TestClassifier(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	enableSpamDetection(t)
+
+	var received classifierRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		_, _ = w.Write([]byte(`{"score": 80, "reason": "looks like an advertisement"}`))
+	}))
+	defer server.Close()
+	setting.Spam.ClassifierURL = server.URL
+	setting.Spam.ClassifierToken = "secret"
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	score, reasons := Score(db.DefaultContext, &Content{
+		Type:   moderation_model.HoldTypeIssue,
+		ID:     1,
+		Poster: user,
+		Repo:   repo,
+		Title:  "Cheap watches",
+		Body:   "buy now",
+	})
+	assert.Equal(t, 80, score)
+	assert.Equal(t, []string{"classifier: looks like an advertisement"}, reasons)
+	assert.Equal(t, "issue", received.Type)
+	assert.Equal(t, "user2", received.User.Name)
+	assert.Equal(t, "user2/repo1", received.Repository)
+	assert.Equal(t, "Cheap watches", received.Title)
+
+	// a failing classifier is ignored
+	server.Close()
+	score, _ = Score(db.DefaultContext, &Content{Type: moderation_model.HoldTypeIssue, Poster: user, Repo: repo, Body: "buy now"})
+	assert.Zero(t, score)
+}
+
+func TestCheckComment(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	enableSpamDetection(t)
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2})
+
+	held, err := CheckComment(db.DefaultContext, user, repo, comment)
+	assert.NoError(t, err)
+	assert.False(t, held)
+
+	comment.Content = "visit our casino"
+	held, err = CheckComment(db.DefaultContext, user, repo, comment)
+	assert.NoError(t, err)
+	assert.True(t, held)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2, IsHidden: true})
+	hold := unittest.AssertExistsAndLoadBean(t, &moderation_model.Hold{CommentID: 2})
+	assert.Equal(t, moderation_model.HoldTypeComment, hold.Type)
+	assert.Equal(t, moderation_model.HoldStatusHeld, hold.Status)
+	assert.EqualValues(t, 1, hold.IssueID)
+	assert.EqualValues(t, 3, hold.PosterID)
+	assert.EqualValues(t, 2, hold.OwnerID)
+	assert.Equal(t, blockedWordScore, hold.Score)
+}
+
+func TestCheckIssue(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	enableSpamDetection(t)
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 4})
+	assert.NoError(t, issue.LoadPoster())
+	issue.Title = "casino"
+
+	held, err := CheckIssue(db.DefaultContext, issue)
+	assert.NoError(t, err)
+	assert.True(t, held)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 4, IsClosed: true, IsLocked: true})
+	unittest.AssertExistsAndLoadBean(t, &moderation_model.Hold{IssueID: 4, Type: moderation_model.HoldTypeIssue})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: issue.RepoID})
+	assert.EqualValues(t, 1, repo.NumClosedIssues)
+
+	held, err = moderation_model.IsContentHeld(db.DefaultContext, issue.RepoID, issue.ID, 0)
+	assert.NoError(t, err)
+	assert.True(t, held)
+}
+
+func TestCheckRepository(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	enableSpamDetection(t)
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	repo.Description = "casino"
+
+	held, err := CheckRepository(db.DefaultContext, user, repo)
+	assert.NoError(t, err)
+	assert.True(t, held)
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1, IsPrivate: true})
+	unittest.AssertExistsAndLoadBean(t, &moderation_model.Hold{RepoID: 1, Type: moderation_model.HoldTypeRepo})
+
+	// private repositories are not checked
+	held, err = CheckRepository(db.DefaultContext, user, repo)
+	assert.NoError(t, err)
+	assert.False(t, held)
+}
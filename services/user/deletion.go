@@ -0,0 +1,188 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/gitea/models"
+	admin_model "code.gitea.io/gitea/models/admin"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	packages_model "code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+var (
+	// ErrSuccessorIsSelf is returned when a user designates themselves as the successor of their account
+	ErrSuccessorIsSelf = errors.New("a user cannot be their own successor")
+	// ErrSuccessorInvalid is returned when the successor is an organization or cannot sign in
+	ErrSuccessorInvalid = errors.New("the successor must be an active user")
+)
+
+// ScheduleUserDeletion schedules the deletion of the user after the configured grace period.
+// The repositories and organizations owned by the user are handed over to the successor, if given,
+// before the account is deleted. Without a grace period the account is deleted immediately.
+func ScheduleUserDeletion(ctx context.Context, u, successor *user_model.User) error {
+	successorID := int64(0)
+	if successor != nil {
+		if successor.ID == u.ID {
+			return ErrSuccessorIsSelf
+		}
+		if successor.IsOrganization() || !successor.IsActive || successor.ProhibitLogin {
+			return ErrSuccessorInvalid
+		}
+		successorID = successor.ID
+
+		// packages cannot be handed over
+		if ownsPackages, err := packages_model.HasOwnerPackages(ctx, u.ID); err != nil {
+			return fmt.Errorf("HasOwnerPackages: %v", err)
+		} else if ownsPackages {
+			return models.ErrUserOwnPackages{UID: u.ID}
+		}
+	} else if err := checkDeletable(ctx, u); err != nil {
+		return err
+	}
+
+	if setting.Service.UserDeleteGracePeriod <= 0 {
+		return purgeUser(ctx, u, successor)
+	}
+
+	return user_model.ScheduleDeletion(ctx, u.ID, successorID, timeutil.TimeStampNow().AddDuration(setting.Service.UserDeleteGracePeriod))
+}
+
+// CancelUserDeletion cancels the scheduled deletion of the user
+func CancelUserDeletion(ctx context.Context, u *user_model.User) error {
+	return user_model.CancelScheduledDeletion(ctx, u.ID)
+}
+
+// DeleteScheduledUsers deletes the users whose grace period has ended.
+// A deletion that fails is kept so that it is retried on the next run.
+func DeleteScheduledUsers(ctx context.Context) error {
+	deletions, err := user_model.GetDueScheduledDeletions(ctx, timeutil.TimeStampNow())
+	if err != nil {
+		return err
+	}
+
+	for _, deletion := range deletions {
+		select {
+		case <-ctx.Done():
+			return db.ErrCancelledf("Before delete scheduled user %d", deletion.UserID)
+		default:
+		}
+
+		u, err := user_model.GetUserByIDCtx(ctx, deletion.UserID)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				if err := user_model.CancelScheduledDeletion(ctx, deletion.UserID); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+
+		var successor *user_model.User
+		if deletion.SuccessorID > 0 {
+			if successor, err = user_model.GetUserByIDCtx(ctx, deletion.SuccessorID); err != nil {
+				log.Error("Unable to get successor %d of user %s: %v", deletion.SuccessorID, u.Name, err)
+				_ = admin_model.CreateNotice(ctx, admin_model.NoticeTask, fmt.Sprintf("delete scheduled user '%s': successor %d: %v", u.Name, deletion.SuccessorID, err))
+				continue
+			}
+		}
+
+		if err := purgeUser(ctx, u, successor); err != nil {
+			log.Error("Unable to delete scheduled user %s: %v", u.Name, err)
+			_ = admin_model.CreateNotice(ctx, admin_model.NoticeTask, fmt.Sprintf("delete scheduled user '%s': %v", u.Name, err))
+		}
+	}
+	return nil
+}
+
+// purgeUser hands over the repositories and organizations of the user to the successor, if any, and deletes the user
+func purgeUser(ctx context.Context, u, successor *user_model.User) error {
+	if successor != nil {
+		if err := handOverRepositories(ctx, u, successor); err != nil {
+			return err
+		}
+		if err := handOverOrganizations(ctx, u, successor); err != nil {
+			return err
+		}
+	}
+
+	if err := DeleteUser(ctx, u, false); err != nil {
+		return err
+	}
+	if successor != nil {
+		log.Info("Account %s deleted, repositories and organizations handed over to %s", u.Name, successor.Name)
+	} else {
+		log.Info("Account %s deleted", u.Name)
+	}
+	return nil
+}
+
+// handOverRepositories transfers all repositories owned by the user to the successor
+func handOverRepositories(ctx context.Context, u, successor *user_model.User) error {
+	for {
+		repos, _, err := repo_model.GetUserRepositories(&repo_model.SearchRepoOptions{
+			ListOptions: db.ListOptions{
+				PageSize: repo_model.RepositoryListDefaultPageSize,
+				Page:     1,
+			},
+			Private: true,
+			OwnerID: u.ID,
+			Actor:   u,
+		})
+		if err != nil {
+			return fmt.Errorf("GetUserRepositories: %v", err)
+		}
+		if len(repos) == 0 {
+			return nil
+		}
+		for _, repo := range repos {
+			if err := repo_service.TransferOwnership(u, successor, repo, nil); err != nil {
+				return fmt.Errorf("TransferOwnership of %s to %s: %w", repo.FullName(), successor.Name, err)
+			}
+		}
+	}
+}
+
+// handOverOrganizations makes the successor an owner of every organization owned by the user
+// and removes the user from all organizations
+func handOverOrganizations(ctx context.Context, u, successor *user_model.User) error {
+	orgs, err := organization.FindOrgs(organization.FindOrgOptions{
+		UserID:         u.ID,
+		IncludePrivate: true,
+	})
+	if err != nil {
+		return fmt.Errorf("FindOrgs: %v", err)
+	}
+	for _, org := range orgs {
+		isOwner, err := organization.IsOrganizationOwner(ctx, org.ID, u.ID)
+		if err != nil {
+			return err
+		}
+		if isOwner {
+			ownerTeam, err := org.GetOwnerTeam()
+			if err != nil {
+				return err
+			}
+			if err := models.AddTeamMember(ownerTeam, successor.ID); err != nil {
+				return fmt.Errorf("AddTeamMember of %s to %s: %w", successor.Name, org.Name, err)
+			}
+		}
+		if err := models.RemoveOrgUser(org.ID, u.ID); err != nil {
+			return fmt.Errorf("RemoveOrgUser of %s from %s: %w", u.Name, org.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"code.gitea.io/gitea/models"
+	admin_model "code.gitea.io/gitea/models/admin"
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+)
+
+var (
+	// ErrMergeSameUser is returned when a user is merged into itself
+	ErrMergeSameUser = errors.New("cannot merge a user into itself")
+	// ErrMergeOrganization is returned when an organization takes part in a merge
+	ErrMergeOrganization = errors.New("organizations cannot be merged")
+)
+
+// MergeUser moves the issues, comments, reviews, releases, keys, tokens, emails and linked accounts of the source user
+// to the target user and deletes the source user, whose name then redirects to the target.
+// Like for a deletion the source must not own repositories or packages nor belong to organizations anymore.
+// The merge is recorded as a system notice.
+func MergeUser(ctx context.Context, doer, source, target *user_model.User) error {
+	if source.ID == target.ID {
+		return ErrMergeSameUser
+	}
+	if source.IsOrganization() || target.IsOrganization() {
+		return ErrMergeOrganization
+	}
+	if err := checkDeletable(ctx, source); err != nil {
+		return err
+	}
+
+	var counts map[string]int64
+	if err := db.WithTx(func(ctx context.Context) error {
+		var err error
+		counts, err = models.MergeUserContent(ctx, source, target)
+		return err
+	}, ctx); err != nil {
+		return err
+	}
+
+	if err := DeleteUser(ctx, source, false); err != nil {
+		return fmt.Errorf("DeleteUser: %v", err)
+	}
+	if err := user_model.NewUserRedirect(ctx, target.ID, source.Name, target.Name); err != nil {
+		return err
+	}
+
+	tables := make([]string, 0, len(counts))
+	for table, count := range counts {
+		if count > 0 {
+			tables = append(tables, fmt.Sprintf("%s: %d", table, count))
+		}
+	}
+	sort.Strings(tables)
+	desc := fmt.Sprintf("user '%s' (id %d) merged into '%s' (id %d) by '%s', reassigned %s",
+		source.Name, source.ID, target.Name, target.ID, doer.Name, strings.Join(tables, ", "))
+	if len(tables) == 0 {
+		desc += "nothing"
+	}
+	log.Info("%s", desc)
+	return admin_model.CreateNotice(ctx, admin_model.NoticeTask, desc)
+}
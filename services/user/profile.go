@@ -0,0 +1,71 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"context"
+
+	access_model "code.gitea.io/gitea/models/perm/access"
+	project_model "code.gitea.io/gitea/models/project"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// PinRepositories pins the repositories of the owner with the given names to the profile page of the owner,
+// the repositories are shown in the given order and the previously pinned repositories are unpinned
+func PinRepositories(ctx context.Context, owner *user_model.User, names []string) error {
+	if len(names) > repo_model.MaxPinnedRepositories {
+		return repo_model.ErrTooManyPinnedRepositories
+	}
+	repoIDs := make([]int64, 0, len(names))
+	for _, name := range names {
+		repo, err := repo_model.GetRepositoryByName(owner.ID, name)
+		if err != nil {
+			return err
+		}
+		repoIDs = append(repoIDs, repo.ID)
+	}
+	return repo_model.SetPinnedRepositories(ctx, owner.ID, repoIDs)
+}
+
+// GetVisiblePinnedRepositories returns the repositories pinned by the owner which the doer has access to
+func GetVisiblePinnedRepositories(ctx context.Context, owner, doer *user_model.User) (repo_model.RepositoryList, error) {
+	repos, err := repo_model.GetPinnedRepositories(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+	visible := make(repo_model.RepositoryList, 0, len(repos))
+	for _, repo := range repos {
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+		if err != nil {
+			return nil, err
+		}
+		if perm.HasAccess() {
+			visible = append(visible, repo)
+		}
+	}
+	return visible, visible.LoadAttributes()
+}
+
+// GetVisibleOpenProjects returns the open projects of the repositories of the owner which the doer has access to
+func GetVisibleOpenProjects(ctx context.Context, owner, doer *user_model.User) ([]*project_model.Project, error) {
+	projects, _, err := project_model.GetProjects(ctx, project_model.SearchOptions{
+		Page:     -1,
+		IsClosed: util.OptionalBoolFalse,
+		SortType: "recentupdate",
+		OwnerID:  owner.ID,
+		Actor:    doer,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		if err := project.LoadRepo(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return projects, nil
+}
@@ -7,9 +7,13 @@ package user
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"code.gitea.io/gitea/models"
+	admin_model "code.gitea.io/gitea/models/admin"
+	auth_model "code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
@@ -124,3 +128,88 @@ func TestCreateUser_Issue5882(t *testing.T) {
 		assert.NoError(t, DeleteUser(db.DefaultContext, v.user, false))
 	}
 }
+
+func TestPinRepositories(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	assert.True(t, repo_model.IsErrRepoNotExist(PinRepositories(db.DefaultContext, owner, []string{"repo1", "repo3"})))
+	assert.NoError(t, PinRepositories(db.DefaultContext, owner, []string{"repo2", "repo1"}))
+
+	// the private repository is only visible to the owner
+	repos, err := GetVisiblePinnedRepositories(db.DefaultContext, owner, owner)
+	assert.NoError(t, err)
+	assert.Len(t, repos, 2)
+	repos, err = GetVisiblePinnedRepositories(db.DefaultContext, owner, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, repos, 1) {
+		assert.Equal(t, "repo1", repos[0].Name)
+	}
+}
+
+func TestMergeUser(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	source := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	target := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 8})
+
+	assert.ErrorIs(t, MergeUser(db.DefaultContext, doer, source, source), ErrMergeSameUser)
+	org := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	assert.ErrorIs(t, MergeUser(db.DefaultContext, doer, source, org), ErrMergeOrganization)
+
+	// user 2 still owns repositories
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	assert.True(t, models.IsErrUserOwnRepos(MergeUser(db.DefaultContext, doer, owner, target)))
+
+	assert.NoError(t, MergeUser(db.DefaultContext, doer, source, target))
+	unittest.AssertNotExistsBean(t, &user_model.User{ID: 1})
+	unittest.AssertNotExistsBean(t, &issues_model.Issue{PosterID: 1})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, PosterID: 8})
+	unittest.AssertExistsAndLoadBean(t, &auth_model.AccessToken{ID: 1, UID: 8})
+	email := unittest.AssertExistsAndLoadBean(t, &user_model.EmailAddress{LowerEmail: "user1@example.com"})
+	assert.EqualValues(t, 8, email.UID)
+	assert.False(t, email.IsPrimary)
+
+	redirectID, err := user_model.LookupUserRedirect("user1")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 8, redirectID)
+	unittest.AssertExistsIf(t, true, &admin_model.Notice{Type: admin_model.NoticeTask})
+}
+
+func TestScheduleUserDeletion(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	defer func(gracePeriod time.Duration) {
+		setting.Service.UserDeleteGracePeriod = gracePeriod
+	}(setting.Service.UserDeleteGracePeriod)
+	setting.Service.UserDeleteGracePeriod = time.Hour
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+	successor := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	assert.ErrorIs(t, ScheduleUserDeletion(db.DefaultContext, user, user), ErrSuccessorIsSelf)
+	org := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	assert.ErrorIs(t, ScheduleUserDeletion(db.DefaultContext, user, org), ErrSuccessorInvalid)
+	// without a successor the user must not own anything
+	assert.True(t, models.IsErrUserOwnRepos(ScheduleUserDeletion(db.DefaultContext, user, nil)))
+
+	assert.NoError(t, ScheduleUserDeletion(db.DefaultContext, user, successor))
+	deletion := unittest.AssertExistsAndLoadBean(t, &user_model.ScheduledDeletion{UserID: 5, SuccessorID: 4})
+
+	// the grace period has not ended yet
+	assert.NoError(t, DeleteScheduledUsers(db.DefaultContext))
+	unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	assert.NoError(t, CancelUserDeletion(db.DefaultContext, user))
+	unittest.AssertNotExistsBean(t, &user_model.ScheduledDeletion{UserID: 5})
+
+	assert.NoError(t, user_model.ScheduleDeletion(db.DefaultContext, 5, 4, deletion.CreatedUnix))
+	assert.NoError(t, DeleteScheduledUsers(db.DefaultContext))
+	unittest.AssertNotExistsBean(t, &user_model.User{ID: 5})
+	unittest.AssertNotExistsBean(t, &user_model.ScheduledDeletion{UserID: 5})
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 4, OwnerID: 4})
+	for _, orgID := range []int64{6, 7} {
+		isOwner, err := organization.IsOrganizationOwner(db.DefaultContext, orgID, 4)
+		assert.NoError(t, err)
+		assert.True(t, isOwner)
+	}
+}
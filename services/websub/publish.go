@@ -0,0 +1,176 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package websub
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/models/appstate"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+const (
+	// lastActionKey is the app state key of the last action whose feeds were published
+	lastActionKey = "websub.last_action_id"
+	// publishBatchSize is the maximum number of actions handled by one run of the publisher
+	publishBatchSize = 1000
+)
+
+// feedURLs returns the URLs of the activity feeds showing an action, without their extension
+func feedURLs(ctx context.Context, actions []*activities_model.Action) ([]string, error) {
+	seen := make(map[string]bool)
+	users := make(map[int64]*user_model.User)
+	repos := make(map[int64]*repo_model.Repository)
+	for _, act := range actions {
+		if setting.Service.Explore.EnableActivityFeed && !act.IsPrivate {
+			seen[setting.AppURL+"explore/activity"] = true
+		}
+
+		repo, ok := repos[act.RepoID]
+		if !ok {
+			r, err := repo_model.GetRepositoryByIDCtx(ctx, act.RepoID)
+			if err != nil && !repo_model.IsErrRepoNotExist(err) {
+				return nil, err
+			}
+			repo, repos[act.RepoID] = r, r
+		}
+		if repo != nil {
+			seen[repo.HTMLURL()] = true
+		}
+
+		// the profile feed of a user only shows its own actions, the one of an organization all its actions
+		if act.UserID != act.ActUserID && (repo == nil || act.UserID != repo.OwnerID) {
+			continue
+		}
+		u, ok := users[act.UserID]
+		if !ok {
+			usr, err := user_model.GetUserByIDCtx(ctx, act.UserID)
+			if err != nil && !user_model.IsErrUserNotExist(err) {
+				return nil, err
+			}
+			u, users[act.UserID] = usr, usr
+		}
+		if u != nil && (act.UserID == act.ActUserID || u.IsOrganization()) {
+			seen[u.HTMLURL()] = true
+		}
+	}
+
+	urls := make([]string, 0, len(seen)*2)
+	for u := range seen {
+		urls = append(urls, u+".rss", u+".atom")
+	}
+	return urls, nil
+}
+
+// PublishNewActions distributes the activity feeds changed by the actions recorded since its last run
+// to their WebSub subscribers
+func PublishNewActions(ctx context.Context) error {
+	content, err := appstate.GetAppStateContent(lastActionKey)
+	if err != nil {
+		return err
+	}
+	if content == "" {
+		// nothing was published yet, start from now on
+		maxID, err := activities_model.GetMaxActionID(ctx)
+		if err != nil {
+			return err
+		}
+		return appstate.SaveAppStateContent(lastActionKey, strconv.FormatInt(maxID, 10))
+	}
+	lastID, err := strconv.ParseInt(content, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid app state %s: %w", lastActionKey, err)
+	}
+
+	if err := activities_model.DeleteExpiredWebSubSubscriptions(ctx); err != nil {
+		return err
+	}
+
+	actions, err := activities_model.FindPublicActionsAfter(ctx, lastID, publishBatchSize)
+	if err != nil || len(actions) == 0 {
+		return err
+	}
+	urls, err := feedURLs(ctx, actions)
+	if err != nil {
+		return err
+	}
+	subs, err := activities_model.FindWebSubSubscriptions(ctx, urls...)
+	if err != nil {
+		return err
+	}
+	for _, sub := range subs {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("aborted WebSub distribution before subscription %d", sub.ID)
+		default:
+		}
+		if err := distribute(ctx, sub); err != nil {
+			log.Warn("WebSub distribution of %s to %s: %v", sub.Topic, sub.Callback, err)
+		}
+	}
+
+	return appstate.SaveAppStateContent(lastActionKey, strconv.FormatInt(actions[len(actions)-1].ID, 10))
+}
+
+// distribute fetches the topic of a subscription and sends it to its callback, see section 7 of the WebSub specification
+func distribute(ctx context.Context, sub *activities_model.WebSubSubscription) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, setting.LocalURL+strings.TrimPrefix(sub.Topic, setting.AppURL), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := localHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// the feed is gone or became private
+		return fmt.Errorf("fetch topic: status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, sub.Callback, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", resp.Header.Get("Content-Type"))
+	req.Header.Add("Link", fmt.Sprintf(`<%s>; rel="hub"`, setting.WebSubHubURL()))
+	req.Header.Add("Link", fmt.Sprintf(`<%s>; rel="self"`, sub.Topic))
+	if sub.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(sub.Secret))
+		_, _ = mac.Write(body)
+		req.Header.Set("X-Hub-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err = subscriberHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusGone {
+		// the subscriber asked to stop the deliveries
+		return activities_model.DeleteWebSubSubscription(ctx, sub.Topic, sub.Callback)
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package websub
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	activities_model "code.gitea.io/gitea/models/activities"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/hostmatcher"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// maxSecretLength is the maximum length of the secret of a subscription, see section 5.1 of the WebSub specification
+const maxSecretLength = 200
+
+var (
+	// subscriberHTTPClient sends the verifications of intent and the content distributions to the subscribers
+	subscriberHTTPClient *http.Client
+	// localHTTPClient fetches the content of the topics from Gitea itself
+	localHTTPClient *http.Client
+	// verificationQueue sends the verifications of intent of the subscription requests
+	verificationQueue queue.Queue
+
+	// pendingCallbacks are the callbacks whose verification of intent is queued, a callback is verified for one
+	// request at a time
+	pendingCallbacks   = make(map[string]struct{})
+	pendingCallbacksMu sync.Mutex
+)
+
+// Init initializes the HTTP clients of the built-in WebSub hub, the subscribers are restricted to the hosts
+// allowed for webhooks
+func Init() error {
+	if !setting.WebSub.Enabled {
+		return nil
+	}
+
+	timeout := time.Duration(setting.Webhook.DeliverTimeout) * time.Second
+	allowedHostListValue := setting.Webhook.AllowedHostList
+	if allowedHostListValue == "" {
+		allowedHostListValue = hostmatcher.MatchBuiltinExternal
+	}
+	allowedHostMatcher := hostmatcher.ParseHostMatchList("webhook.ALLOWED_HOST_LIST", allowedHostListValue)
+
+	subscriberHTTPClient = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: setting.Webhook.SkipTLSVerify},
+			Proxy:           proxy.Proxy(),
+			DialContext:     hostmatcher.NewDialContext("websub", allowedHostMatcher, nil),
+		},
+	}
+	localHTTPClient = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	verificationQueue = queue.CreateQueue("websub_verification", handle, &SubscriptionRequest{})
+	if verificationQueue == nil {
+		return fmt.Errorf("Unable to create websub_verification Queue")
+	}
+	go graceful.GetManager().RunWithShutdownFns(verificationQueue.Run)
+	return nil
+}
+
+func handle(data ...queue.Data) []queue.Data {
+	ctx := graceful.GetManager().ShutdownContext()
+	for _, datum := range data {
+		r := datum.(*SubscriptionRequest)
+		if err := verifyAndApply(ctx, r); err != nil {
+			log.Warn("WebSub %s of %s to %s: %v", r.Mode, r.Callback, r.Topic, err)
+		}
+		releaseCallback(r.Callback)
+	}
+	return nil
+}
+
+// IsValidTopic returns whether a topic is an activity feed of this instance
+func IsValidTopic(topic string) bool {
+	if !strings.HasPrefix(topic, setting.AppURL) {
+		return false
+	}
+	u, err := url.Parse(topic)
+	if err != nil {
+		return false
+	}
+	return strings.HasSuffix(u.Path, ".rss") || strings.HasSuffix(u.Path, ".atom")
+}
+
+// ErrInvalidSubscription represents an invalid subscription request
+type ErrInvalidSubscription struct {
+	Reason string
+}
+
+// IsErrInvalidSubscription checks if an error is a ErrInvalidSubscription
+func IsErrInvalidSubscription(err error) bool {
+	_, ok := err.(ErrInvalidSubscription)
+	return ok
+}
+
+func (err ErrInvalidSubscription) Error() string {
+	return fmt.Sprintf("invalid subscription request: %s", err.Reason)
+}
+
+// ErrTooManyVerifications represents a subscription request refused because too many verifications are pending
+type ErrTooManyVerifications struct {
+	Reason string
+}
+
+// IsErrTooManyVerifications checks if an error is a ErrTooManyVerifications
+func IsErrTooManyVerifications(err error) bool {
+	_, ok := err.(ErrTooManyVerifications)
+	return ok
+}
+
+func (err ErrTooManyVerifications) Error() string {
+	return fmt.Sprintf("too many pending verifications: %s", err.Reason)
+}
+
+// SubscriptionRequest is a subscription or unsubscription request received by the hub
+type SubscriptionRequest struct {
+	Mode         string
+	Topic        string
+	Callback     string
+	Secret       string
+	LeaseSeconds int64
+}
+
+// Validate checks a request and fills its lease with the default of the hub if it is not set
+func (r *SubscriptionRequest) Validate() error {
+	if r.Mode != "subscribe" && r.Mode != "unsubscribe" {
+		return ErrInvalidSubscription{Reason: "hub.mode must be subscribe or unsubscribe"}
+	}
+	if !IsValidTopic(r.Topic) {
+		return ErrInvalidSubscription{Reason: "hub.topic is not a feed of this instance"}
+	}
+	if u, err := url.Parse(r.Callback); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidSubscription{Reason: "hub.callback must be an absolute http or https URL"}
+	}
+	if len(r.Secret) > maxSecretLength {
+		return ErrInvalidSubscription{Reason: fmt.Sprintf("hub.secret must be shorter than %d bytes", maxSecretLength)}
+	}
+	if r.LeaseSeconds <= 0 {
+		r.LeaseSeconds = setting.WebSub.LeaseSeconds
+	} else if r.LeaseSeconds > setting.WebSub.MaxLeaseSeconds {
+		r.LeaseSeconds = setting.WebSub.MaxLeaseSeconds
+	}
+	return nil
+}
+
+// HandleSubscriptionRequest validates a request and queues the verification of the intent of the subscriber, the
+// subscription only changes once the subscriber confirmed it. It returns ErrTooManyVerifications while the callback
+// is already being verified or when MAX_PENDING_VERIFICATIONS requests are waiting to be verified.
+func HandleSubscriptionRequest(r *SubscriptionRequest) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if err := reserveCallback(r.Callback); err != nil {
+		return err
+	}
+	if err := verificationQueue.Push(r); err != nil {
+		releaseCallback(r.Callback)
+		return err
+	}
+	return nil
+}
+
+// reserveCallback marks a callback as being verified, unless it already is or too many callbacks are
+func reserveCallback(callback string) error {
+	pendingCallbacksMu.Lock()
+	defer pendingCallbacksMu.Unlock()
+	if _, ok := pendingCallbacks[callback]; ok {
+		return ErrTooManyVerifications{Reason: "the callback is already being verified"}
+	}
+	if len(pendingCallbacks) >= setting.WebSub.MaxPendingVerifications {
+		return ErrTooManyVerifications{Reason: "the hub is busy verifying other subscribers"}
+	}
+	pendingCallbacks[callback] = struct{}{}
+	return nil
+}
+
+// releaseCallback marks a callback as verified
+func releaseCallback(callback string) {
+	pendingCallbacksMu.Lock()
+	defer pendingCallbacksMu.Unlock()
+	delete(pendingCallbacks, callback)
+}
+
+// verifyAndApply verifies the intent of the subscriber, see section 5.3 of the WebSub specification, then applies
+// the request
+func verifyAndApply(ctx context.Context, r *SubscriptionRequest) error {
+	challenge, err := util.CryptoRandomString(32)
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(r.Callback)
+	if err != nil {
+		return err
+	}
+	query := u.Query()
+	query.Set("hub.mode", r.Mode)
+	query.Set("hub.topic", r.Topic)
+	query.Set("hub.challenge", challenge)
+	if r.Mode == "subscribe" {
+		query.Set("hub.lease_seconds", strconv.FormatInt(r.LeaseSeconds, 10))
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := subscriberHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(len(challenge)+1)))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 || string(body) != challenge {
+		return fmt.Errorf("the subscriber did not confirm the request: status %d", resp.StatusCode)
+	}
+
+	if r.Mode == "unsubscribe" {
+		return activities_model.DeleteWebSubSubscription(ctx, r.Topic, r.Callback)
+	}
+	return activities_model.UpsertWebSubSubscription(ctx, &activities_model.WebSubSubscription{
+		Topic:       r.Topic,
+		Callback:    r.Callback,
+		Secret:      r.Secret,
+		ExpiresUnix: timeutil.TimeStampNow().Add(r.LeaseSeconds),
+	})
+}
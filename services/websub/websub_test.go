@@ -0,0 +1,35 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package websub
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReserveCallback(t *testing.T) {
+	defer func(max int) {
+		setting.WebSub.MaxPendingVerifications = max
+	}(setting.WebSub.MaxPendingVerifications)
+	setting.WebSub.MaxPendingVerifications = 2
+
+	assert.NoError(t, reserveCallback("https://reader.example.com/a"))
+	// a callback is verified for one request at a time
+	assert.True(t, IsErrTooManyVerifications(reserveCallback("https://reader.example.com/a")))
+	assert.NoError(t, reserveCallback("https://reader.example.com/b"))
+	// the hub verifies at most MAX_PENDING_VERIFICATIONS callbacks
+	assert.True(t, IsErrTooManyVerifications(reserveCallback("https://reader.example.com/c")))
+
+	releaseCallback("https://reader.example.com/a")
+	assert.NoError(t, reserveCallback("https://reader.example.com/c"))
+	assert.True(t, IsErrTooManyVerifications(reserveCallback("https://reader.example.com/a")))
+
+	releaseCallback("https://reader.example.com/b")
+	releaseCallback("https://reader.example.com/c")
+	assert.Empty(t, pendingCallbacks)
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIAdminDownloadUsage(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// an anonymous and a signed in download of a raw file
+	resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/raw/branch/master/README.md"), http.StatusOK)
+	size := int64(resp.Body.Len())
+	session := loginUser(t, "user2")
+	session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/raw/branch/master/README.md"), http.StatusOK)
+
+	adminSession := loginUser(t, "user1")
+	token := getTokenForLoggedInUser(t, adminSession)
+	req := NewRequest(t, "GET", "/api/v1/admin/downloads/usage?token="+token)
+	resp = adminSession.MakeRequest(t, req, http.StatusOK)
+	var usages []*api.RepoDownloadUsage
+	DecodeJSON(t, resp, &usages)
+	if assert.Len(t, usages, 1) {
+		assert.Equal(t, "user2/repo1", usages[0].RepoFullName)
+		assert.EqualValues(t, 2, usages[0].Downloads)
+		assert.Equal(t, 2*size, usages[0].Size)
+	}
+
+	req = NewRequest(t, "GET", "/api/v1/admin/downloads/usage/owners?token="+token)
+	resp = adminSession.MakeRequest(t, req, http.StatusOK)
+	var ownerUsages []*api.OwnerDownloadUsage
+	DecodeJSON(t, resp, &ownerUsages)
+	if assert.Len(t, ownerUsages, 1) {
+		assert.Equal(t, "user2", ownerUsages[0].OwnerName)
+		assert.Equal(t, 2*size, ownerUsages[0].Size)
+	}
+
+	// only the administrators can list the usages
+	token = getTokenForLoggedInUser(t, session)
+	session.MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/downloads/usage?token="+token), http.StatusForbidden)
+}
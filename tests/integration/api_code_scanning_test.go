@@ -0,0 +1,32 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPICodeScanningAlertsPermission(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the owner of the public repo1 can read its alerts
+	token := getTokenForLoggedInUser(t, loginUser(t, "user2"))
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/code-scanning/alerts?token="+token), http.StatusOK)
+	var alerts []*api.CodeScanningAlert
+	DecodeJSON(t, resp, &alerts)
+	assert.Empty(t, alerts)
+
+	// the readers of the code can't, as for the draft advisories
+	readerToken := getTokenForLoggedInUser(t, loginUser(t, "user5"))
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/code-scanning/alerts?token="+readerToken), http.StatusForbidden)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/code-scanning/alerts/1?token="+readerToken), http.StatusForbidden)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/code-scanning/alerts"), http.StatusUnauthorized)
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIHighlight(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/highlight", &api.HighlightOption{
+		Filename: "main.go",
+		Content:  "package main\n\nfunc main() {}\n",
+	})
+	resp := MakeRequest(t, req, http.StatusOK)
+	var result api.HighlightResult
+	DecodeJSON(t, resp, &result)
+	assert.Equal(t, "Go", result.Language)
+	if assert.Len(t, result.Lines, 3) {
+		assert.Contains(t, result.Lines[0], `<span class="kn">package</span>`)
+	}
+
+	// the language overrides the file name
+	req = NewRequestWithJSON(t, "POST", "/api/v1/highlight", &api.HighlightOption{
+		Filename: "main.go",
+		Language: "python",
+		Content:  "print(1)\n",
+	})
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &result)
+	assert.Equal(t, "Python", result.Language)
+
+	req = NewRequestWithJSON(t, "POST", "/api/v1/highlight", &api.HighlightOption{})
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &result)
+	assert.Empty(t, result.Lines)
+}
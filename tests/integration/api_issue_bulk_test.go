@@ -0,0 +1,74 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIEditIssues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session)
+	link := "/api/v1/repos/user2/repo1/issues?token=" + token
+
+	milestone := int64(1)
+	closed := "closed"
+	req := NewRequestWithJSON(t, "PATCH", link, &api.EditIssuesOption{
+		Indices:      []int64{1, 3},
+		AddLabels:    []int64{2},
+		RemoveLabels: []int64{1},
+		Milestone:    &milestone,
+		AddAssignees: []string{"user2"},
+		State:        &closed,
+	})
+	resp := MakeRequest(t, req, http.StatusOK)
+	var issues []*api.Issue
+	DecodeJSON(t, resp, &issues)
+	assert.Len(t, issues, 2)
+	for _, issue := range issues {
+		assert.Equal(t, api.StateClosed, issue.State)
+		if assert.NotNil(t, issue.Milestone) {
+			assert.EqualValues(t, 1, issue.Milestone.ID)
+		}
+		if assert.Len(t, issue.Labels, 1) {
+			assert.EqualValues(t, 2, issue.Labels[0].ID)
+		}
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: issue.ID, AssigneeID: 2})
+	}
+
+	// the issues are all validated before any of them is changed
+	open := "open"
+	req = NewRequestWithJSON(t, "PATCH", link, &api.EditIssuesOption{
+		Indices: []int64{1, 3},
+		State:   &open,
+		// the labels of other repositories can't be added
+		AddLabels: []int64{3},
+	})
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+	req = NewRequestWithJSON(t, "PATCH", link, &api.EditIssuesOption{
+		Indices: []int64{1, 1000},
+		State:   &open,
+	})
+	MakeRequest(t, req, http.StatusNotFound)
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1}).IsClosed)
+
+	// the readers can't edit the issues
+	token4 := getTokenForLoggedInUser(t, loginUser(t, "user4"))
+	req = NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues?token="+token4, &api.EditIssuesOption{
+		Indices: []int64{1},
+		State:   &open,
+	})
+	MakeRequest(t, req, http.StatusForbidden)
+}
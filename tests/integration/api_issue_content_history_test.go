@@ -0,0 +1,51 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIIssueContentHistory(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2")
+	body := "new content"
+	req := NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues/1?token="+token, &api.EditIssueOption{Body: &body})
+	MakeRequest(t, req, http.StatusCreated)
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1/content_history"), http.StatusOK)
+	assert.Equal(t, "2", resp.Header().Get("X-Total-Count"))
+	var histories []*api.ContentHistory
+	DecodeJSON(t, resp, &histories)
+	if assert.Len(t, histories, 2) {
+		assert.Equal(t, "user2", histories[0].Editor.UserName)
+		assert.Equal(t, "new content", histories[0].Content)
+		assert.Equal(t, "-content for the first issue\n+new content\n", histories[0].Diff)
+		assert.True(t, histories[1].IsFirstCreated)
+		assert.Equal(t, "content for the first issue", histories[1].Content)
+	}
+
+	req = NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues/comments/2?token="+token, &api.EditIssueCommentOption{Body: "better work!"})
+	MakeRequest(t, req, http.StatusOK)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/comments/2/content_history?limit=1"), http.StatusOK)
+	assert.Equal(t, "2", resp.Header().Get("X-Total-Count"))
+	histories = nil
+	DecodeJSON(t, resp, &histories)
+	if assert.Len(t, histories, 1) {
+		assert.Equal(t, "better work!", histories[0].Content)
+		assert.Equal(t, "-good work!\n+better work!\n", histories[0].Diff)
+	}
+
+	// the comment must belong to the repository
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo2/issues/comments/2/content_history?token="+token), http.StatusNotFound)
+}
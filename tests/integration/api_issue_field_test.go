@@ -0,0 +1,131 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRepoIssueFields(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2")
+	urlStr := "/api/v1/repos/user2/repo1/issue_fields"
+
+	req := NewRequestWithJSON(t, "POST", urlStr+"?token="+token, &api.CreateIssueFieldOption{
+		Name:    "Priority",
+		Type:    "enum",
+		Options: []string{"low", "high"},
+	})
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var field api.IssueField
+	DecodeJSON(t, resp, &field)
+	assert.Equal(t, "Priority", field.Name)
+	assert.Equal(t, []string{"low", "high"}, field.Options)
+	assert.False(t, field.IsOrgField)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueField{ID: field.ID, RepoID: 1})
+
+	// the names are unique and an enum field needs options
+	req = NewRequestWithJSON(t, "POST", urlStr+"?token="+token, &api.CreateIssueFieldOption{Name: "priority", Type: "text"})
+	MakeRequest(t, req, http.StatusConflict)
+	req = NewRequestWithJSON(t, "POST", urlStr+"?token="+token, &api.CreateIssueFieldOption{Name: "Size", Type: "enum"})
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	// only the writers of the issues can manage the fields
+	req = NewRequestWithJSON(t, "POST", urlStr+"?token="+getUserToken(t, "user4"), &api.CreateIssueFieldOption{Name: "Size", Type: "text"})
+	MakeRequest(t, req, http.StatusForbidden)
+
+	newName := "Importance"
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("%s/%d?token=%s", urlStr, field.ID, token), &api.EditIssueFieldOption{Name: &newName})
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &field)
+	assert.Equal(t, "Importance", field.Name)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", urlStr), http.StatusOK)
+	var fields []*api.IssueField
+	DecodeJSON(t, resp, &fields)
+	assert.Len(t, fields, 3)
+
+	MakeRequest(t, NewRequest(t, "DELETE", fmt.Sprintf("%s/%d?token=%s", urlStr, field.ID, token)), http.StatusNoContent)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueField{ID: field.ID})
+	MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/%d", urlStr, field.ID)), http.StatusNotFound)
+}
+
+func TestAPIOrgIssueFields(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2")
+	req := NewRequestWithJSON(t, "POST", "/api/v1/orgs/user3/issue_fields?token="+token, &api.CreateIssueFieldOption{
+		Name: "Estimate",
+		Type: "number",
+	})
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var field api.IssueField
+	DecodeJSON(t, resp, &field)
+	assert.True(t, field.IsOrgField)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueField{ID: field.ID, OrgID: 3})
+
+	// the fields of an organization apply to the issues of its repositories
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user3/repo3/issue_fields?token="+token), http.StatusOK)
+	var fields []*api.IssueField
+	DecodeJSON(t, resp, &fields)
+	assert.Len(t, fields, 2)
+
+	req = NewRequestWithJSON(t, "PUT", fmt.Sprintf("/api/v1/repos/user3/repo3/issues/1/fields/%d?token=%s", field.ID, token), &api.SetIssueFieldValueOption{Value: "1.50"})
+	resp = MakeRequest(t, req, http.StatusOK)
+	var values []*api.IssueFieldValue
+	DecodeJSON(t, resp, &values)
+	if assert.Len(t, values, 1) {
+		assert.Equal(t, "1.5", values[0].Value)
+	}
+
+	// a field of the organization can't be edited through one of its repositories
+	MakeRequest(t, NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/repos/user3/repo3/issue_fields/%d?token=%s", field.ID, token)), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/orgs/user3/issue_fields/%d?token=%s", field.ID, token)), http.StatusNoContent)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueFieldValue{FieldID: field.ID})
+}
+
+func TestAPIIssueFieldValues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2")
+	urlStr := "/api/v1/repos/user2/repo1/issues/2/fields"
+
+	req := NewRequestWithJSON(t, "PUT", urlStr+"/2?token="+token, &api.SetIssueFieldValueOption{Value: "api"})
+	MakeRequest(t, req, http.StatusOK)
+	req = NewRequestWithJSON(t, "PUT", urlStr+"/2?token="+token, &api.SetIssueFieldValueOption{Value: "docs"})
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+	req = NewRequestWithJSON(t, "PUT", urlStr+"/3?token="+token, &api.SetIssueFieldValueOption{Value: "2022-10-01"})
+	MakeRequest(t, req, http.StatusNotFound)
+
+	resp := MakeRequest(t, NewRequest(t, "GET", urlStr), http.StatusOK)
+	var values []*api.IssueFieldValue
+	DecodeJSON(t, resp, &values)
+	if assert.Len(t, values, 2) {
+		assert.Equal(t, "api", values[0].Value)
+		assert.Equal(t, "5", values[1].Value)
+	}
+
+	MakeRequest(t, NewRequest(t, "DELETE", urlStr+"/1?token="+token), http.StatusOK)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueFieldValue{IssueID: 2, FieldID: 1})
+
+	// filter the issues by the values of their fields
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues?state=all&fields=1:3"), http.StatusOK)
+	var issues []*api.Issue
+	DecodeJSON(t, resp, &issues)
+	if assert.Len(t, issues, 1) {
+		assert.EqualValues(t, 1, issues[0].Index)
+	}
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues?fields=invalid"), http.StatusUnprocessableEntity)
+}
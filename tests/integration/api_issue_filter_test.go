@@ -0,0 +1,98 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIIssueFilters(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2")
+	urlStr := "/api/v1/repos/user2/repo1/issue_filters"
+
+	req := NewRequestWithJSON(t, "POST", urlStr+"?token="+token, &api.CreateIssueFilterOption{
+		Name:   "Open pulls of user2",
+		Type:   "pulls",
+		Labels: []int64{1, -2},
+		Poster: 2,
+		Sort:   "recentupdate",
+		Pinned: true,
+	})
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var filter api.IssueFilter
+	DecodeJSON(t, resp, &filter)
+	assert.Equal(t, "pulls", filter.Type)
+	assert.Equal(t, []int64{1, -2}, filter.Labels)
+	assert.Equal(t, "open", filter.State)
+	assert.Contains(t, filter.HTMLURL, "/user2/repo1/pulls?")
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueFilter{ID: filter.ID, UserID: 2, RepoID: 1, IsPull: true})
+
+	req = NewRequestWithJSON(t, "POST", urlStr+"?token="+token, &api.CreateIssueFilterOption{Name: "Unknown", Sort: "unknown"})
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	closed := "closed"
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("%s/%d?token=%s", urlStr, filter.ID, token), &api.EditIssueFilterOption{State: &closed})
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &filter)
+	assert.Equal(t, "closed", filter.State)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", urlStr+"?type=pulls&token="+token), http.StatusOK)
+	var filters []*api.IssueFilter
+	DecodeJSON(t, resp, &filters)
+	if assert.Len(t, filters, 1) {
+		assert.Equal(t, filter.ID, filters[0].ID)
+	}
+	resp = MakeRequest(t, NewRequest(t, "GET", urlStr+"?token="+token), http.StatusOK)
+	DecodeJSON(t, resp, &filters)
+	assert.Len(t, filters, 2)
+
+	// the filters are private to the user who saved them
+	user4Token := getUserToken(t, "user4")
+	MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/%d?token=%s", urlStr, filter.ID, user4Token)), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "DELETE", fmt.Sprintf("%s/%d?token=%s", urlStr, filter.ID, user4Token)), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", urlStr), http.StatusUnauthorized)
+
+	MakeRequest(t, NewRequest(t, "DELETE", fmt.Sprintf("%s/%d?token=%s", urlStr, filter.ID, token)), http.StatusNoContent)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueFilter{ID: filter.ID})
+}
+
+func TestSaveIssueFilter(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	req := NewRequestWithValues(t, "POST", "/user2/repo1/issues/filters", map[string]string{
+		"_csrf":  GetCSRF(t, session, "/user2/repo1/issues"),
+		"name":   "Milestone 1",
+		"labels": "1",
+		"state":  "open",
+		"sort":   "oldest",
+		"pinned": "true",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	filter := unittest.AssertExistsAndLoadBean(t, &issues_model.IssueFilter{UserID: 2, RepoID: 1, Name: "Milestone 1"})
+	assert.True(t, filter.IsPinned)
+	assert.Equal(t, "1", filter.Labels)
+
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues"), http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	assert.EqualValues(t, 2, htmlDoc.Find(".saved-issue-filters > a.item").Length())
+
+	req = NewRequestWithValues(t, "POST", fmt.Sprintf("/user2/repo1/issues/filters/%d/delete", filter.ID), map[string]string{
+		"_csrf": GetCSRF(t, session, "/user2/repo1/issues"),
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueFilter{ID: filter.ID})
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIImportIssues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	session := loginUser(t, user2.Name)
+	token := getTokenForLoggedInUser(t, session)
+	link := fmt.Sprintf("/api/v1/repos/%s/%s/issues/import?token=%s", user2.Name, repo1.Name, token)
+
+	content := "Summary;labels;Votes\nImported issue;label1;3\n"
+	req := NewRequestWithJSON(t, "POST", link, &api.ImportIssuesOption{Content: content})
+	session.MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", link, &api.ImportIssuesOption{
+		Content: content,
+		Mapping: map[string]string{"Summary": "title", "Votes": "votes"},
+	})
+	session.MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", link, &api.ImportIssuesOption{
+		Content: content,
+		Mapping: map[string]string{"Summary": "title", "labels": ""},
+	})
+	resp := session.MakeRequest(t, req, http.StatusCreated)
+	var apiIssues []*api.Issue
+	DecodeJSON(t, resp, &apiIssues)
+	if assert.Len(t, apiIssues, 1) {
+		assert.Equal(t, "Imported issue", apiIssues[0].Title)
+		assert.Empty(t, apiIssues[0].Labels)
+		assert.Equal(t, user2.ID, apiIssues[0].Poster.ID)
+	}
+}
+
+func TestIssueImportAndExport(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	req := NewRequest(t, "GET", "/user2/repo1/settings/issue_import/export")
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	assert.True(t, strings.HasPrefix(resp.Body.String(), "index,type,title,state,"))
+	assert.Contains(t, resp.Body.String(), "issue1")
+
+	csrf := GetCSRF(t, session, "/user2/repo1/settings/issue_import")
+	req = NewRequestWithValues(t, "POST", "/user2/repo1/settings/issue_import/confirm", map[string]string{
+		"_csrf":   csrf,
+		"content": "Name\nImported from the settings\n",
+		"columns": "Name",
+		"fields":  "title",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, Title: "Imported from the settings"})
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIPinIssue(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2")
+	for _, index := range []int64{1, 2, 3} {
+		req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/user2/repo1/issues/%d/pin?token=%s", index, token), &api.PinIssueOption{IsAnnouncement: index == 2})
+		MakeRequest(t, req, http.StatusNoContent)
+	}
+	// a repository can't pin more issues
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/4/pin?token="+token, &api.PinIssueOption{})
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	// the last issue is moved to the top
+	MakeRequest(t, NewRequest(t, "PATCH", "/api/v1/repos/user2/repo1/issues/3/pin/1?token="+token), http.StatusNoContent)
+	MakeRequest(t, NewRequest(t, "PATCH", "/api/v1/repos/user2/repo1/issues/4/pin/1?token="+token), http.StatusNotFound)
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/pinned"), http.StatusOK)
+	var pins []*api.PinnedIssue
+	DecodeJSON(t, resp, &pins)
+	if assert.Len(t, pins, 3) {
+		assert.EqualValues(t, 3, pins[0].Issue.Index)
+		assert.EqualValues(t, 1, pins[1].Issue.Index)
+		assert.EqualValues(t, 2, pins[2].Issue.Index)
+		assert.True(t, pins[2].IsAnnouncement)
+		assert.Equal(t, 1, pins[0].Position)
+	}
+
+	// only writers can pin
+	req = NewRequest(t, "DELETE", "/api/v1/repos/user2/repo1/issues/3/pin?token="+getUserToken(t, "user4"))
+	MakeRequest(t, req, http.StatusForbidden)
+	MakeRequest(t, NewRequest(t, "DELETE", "/api/v1/repos/user2/repo1/issues/3/pin?token="+token), http.StatusNoContent)
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/pinned"), http.StatusOK)
+	pins = nil
+	DecodeJSON(t, resp, &pins)
+	assert.Len(t, pins, 2)
+}
+
+func TestAPIOrgPinnedIssues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2")
+	req := NewRequestWithJSON(t, "PUT", "/api/v1/orgs/user3/pinned_issues?token="+token, &api.EditOrgPinnedIssuesOption{
+		Issues: []*api.PinnedIssueRef{{Repo: "repo3", Index: 2}, {Repo: "repo3", Index: 1}},
+	})
+	resp := MakeRequest(t, req, http.StatusOK)
+	var pins []*api.PinnedIssue
+	DecodeJSON(t, resp, &pins)
+	if assert.Len(t, pins, 2) {
+		assert.EqualValues(t, 12, pins[0].Issue.ID)
+		assert.EqualValues(t, 6, pins[1].Issue.ID)
+	}
+
+	// the issues of the private repository are hidden from anonymous users
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/user3/pinned_issues"), http.StatusOK)
+	pins = nil
+	DecodeJSON(t, resp, &pins)
+	assert.Empty(t, pins)
+
+	req = NewRequestWithJSON(t, "PUT", "/api/v1/orgs/user3/pinned_issues?token="+token, &api.EditOrgPinnedIssuesOption{
+		Issues: []*api.PinnedIssueRef{{Repo: "unknown", Index: 1}},
+	})
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+	req = NewRequestWithJSON(t, "PUT", "/api/v1/orgs/user3/pinned_issues?token="+getUserToken(t, "user4"), &api.EditOrgPinnedIssuesOption{})
+	MakeRequest(t, req, http.StatusForbidden)
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIListSimilarIssues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	urlStr := "/api/v1/repos/user2/repo1/issues/similar"
+	MakeRequest(t, NewRequest(t, "GET", urlStr), http.StatusUnprocessableEntity)
+
+	// closed issues are suggested too
+	resp := MakeRequest(t, NewRequest(t, "GET", urlStr+"?title=Problem%20with%20issue5"), http.StatusOK)
+	var issues []*api.Issue
+	DecodeJSON(t, resp, &issues)
+	if assert.NotEmpty(t, issues) {
+		assert.EqualValues(t, 4, issues[0].Index)
+		assert.Equal(t, api.StateClosed, issues[0].State)
+	}
+
+	// pull requests are not
+	resp = MakeRequest(t, NewRequest(t, "GET", urlStr+"?title=pull5"), http.StatusOK)
+	DecodeJSON(t, resp, &issues)
+	assert.Empty(t, issues)
+
+	// the web endpoint used while writing a new issue
+	session := loginUser(t, "user2")
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/similar?title=issue1"), http.StatusOK)
+	DecodeJSON(t, resp, &issues)
+	if assert.NotEmpty(t, issues) {
+		assert.EqualValues(t, 1, issues[0].Index)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPISubIssues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2")
+	urlStr := "/api/v1/repos/user2/repo1/issues/1/sub_issues"
+
+	// issue #4 is a sub-issue of issue #1
+	resp := MakeRequest(t, NewRequest(t, "GET", urlStr), http.StatusOK)
+	var subIssues []*api.Issue
+	DecodeJSON(t, resp, &subIssues)
+	if assert.Len(t, subIssues, 1) {
+		assert.EqualValues(t, 4, subIssues[0].Index)
+	}
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/4/parent"), http.StatusOK)
+	var parent api.Issue
+	DecodeJSON(t, resp, &parent)
+	assert.EqualValues(t, 1, parent.Index)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1/parent"), http.StatusNotFound)
+
+	// an issue cannot be a sub-issue of its own sub-issue, nor have two parents, and pull requests are not sub-issues
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/4/sub_issues?token="+token, &api.AddSubIssueOption{Index: 1})
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+	req = NewRequestWithJSON(t, "POST", urlStr+"?token="+token, &api.AddSubIssueOption{Index: 4})
+	MakeRequest(t, req, http.StatusConflict)
+	req = NewRequestWithJSON(t, "POST", urlStr+"?token="+token, &api.AddSubIssueOption{Index: 2})
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	// only the writers of the issues can attach sub-issues
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/4/sub_issues?token="+getUserToken(t, "user4"), &api.AddSubIssueOption{Index: 1})
+	MakeRequest(t, req, http.StatusForbidden)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", urlStr+"/progress"), http.StatusOK)
+	var progress api.SubIssueProgress
+	DecodeJSON(t, resp, &progress)
+	assert.Equal(t, api.SubIssueProgress{Total: 1, Closed: 1, Percent: 100}, progress)
+
+	MakeRequest(t, NewRequest(t, "DELETE", urlStr+"/4?token="+token), http.StatusNoContent)
+	unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: 5})
+	MakeRequest(t, NewRequest(t, "DELETE", urlStr+"/4?token="+token), http.StatusNotFound)
+
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/4/sub_issues?token="+token, &api.AddSubIssueOption{Index: 1})
+	resp = MakeRequest(t, req, http.StatusCreated)
+	var subIssue api.Issue
+	DecodeJSON(t, resp, &subIssue)
+	assert.EqualValues(t, 1, subIssue.Index)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.SubIssue{IssueID: 1, ParentID: 5})
+}
+
+func TestViewSubIssues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1"), http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	assert.EqualValues(t, 1, htmlDoc.Find(".sub-issues .item").Length())
+
+	req := NewRequestWithValues(t, "POST", "/user2/repo1/issues/1/sub_issues/remove", map[string]string{
+		"_csrf": GetCSRF(t, session, "/user2/repo1/issues/1"),
+		"index": "4",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: 5})
+}
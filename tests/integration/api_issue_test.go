@@ -71,6 +71,28 @@ func TestAPIListIssues(t *testing.T) {
 	if assert.Len(t, apiIssues, 1) {
 		assert.EqualValues(t, 1, apiIssues[0].ID)
 	}
+
+	// test reaction filters and sorts
+	req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/%s/%s/issues/2/reactions?token=%s", owner.Name, repo.Name, token),
+		&api.EditReactionOption{Reaction: "+1"})
+	session.MakeRequest(t, req, http.StatusCreated)
+
+	link.RawQuery = url.Values{"token": {token}, "state": {"all"}, "min_reactions": {"1"}, "sort": {"mostreactions"}}.Encode()
+	resp = session.MakeRequest(t, NewRequest(t, "GET", link.String()), http.StatusOK)
+	DecodeJSON(t, resp, &apiIssues)
+	if assert.Len(t, apiIssues, 2) {
+		assert.EqualValues(t, 1, apiIssues[0].ID)
+		assert.EqualValues(t, 3, apiIssues[0].Reactions)
+		assert.EqualValues(t, 2, apiIssues[1].ID)
+		assert.EqualValues(t, 1, apiIssues[1].ThumbsUp)
+	}
+
+	link.RawQuery = url.Values{"token": {token}, "state": {"all"}, "min_thumbs_up": {"1"}}.Encode()
+	resp = session.MakeRequest(t, NewRequest(t, "GET", link.String()), http.StatusOK)
+	DecodeJSON(t, resp, &apiIssues)
+	if assert.Len(t, apiIssues, 1) {
+		assert.EqualValues(t, 2, apiIssues[0].ID)
+	}
 }
 
 func TestAPICreateIssue(t *testing.T) {
@@ -0,0 +1,97 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIModerationReports(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// comment 2 is posted by user3 on the first issue of user2/repo1
+	reporterToken := getTokenForLoggedInUser(t, loginUser(t, "user5"))
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/comments/2/reports?token="+reporterToken, &api.CreateModerationReportOption{
+		Category: "spam",
+		Remark:   "advertisement",
+	})
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var report api.ModerationReport
+	DecodeJSON(t, resp, &report)
+	assert.EqualValues(t, 2, report.CommentID)
+	assert.EqualValues(t, 1, report.IssueIndex)
+	assert.Equal(t, "user3", report.ReportedUser.UserName)
+	assert.Equal(t, "open", report.Status)
+
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/comments/2/reports?token="+reporterToken, &api.CreateModerationReportOption{
+		Category: "abuse",
+	})
+	MakeRequest(t, req, http.StatusConflict)
+
+	posterToken := getTokenForLoggedInUser(t, loginUser(t, "user3"))
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/comments/2/reports?token="+posterToken, &api.CreateModerationReportOption{
+		Category: "other",
+	})
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	// only the moderators can see the reports
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/moderation/reports?token="+reporterToken)
+	MakeRequest(t, req, http.StatusForbidden)
+
+	moderatorToken := getTokenForLoggedInUser(t, loginUser(t, "user2"))
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/moderation/reports?status=open&token="+moderatorToken)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var reports []*api.ModerationReport
+	DecodeJSON(t, resp, &reports)
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, report.ID, reports[0].ID)
+	}
+
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/moderation/reports/"+strconv.FormatInt(report.ID, 10)+"/actions?token="+moderatorToken, &api.ModerationActionOption{
+		Action: "hide_comment",
+		Reason: "spam",
+	})
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &report)
+	assert.Equal(t, "resolved", report.Status)
+	assert.Equal(t, "user2", report.Resolver.UserName)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{ID: 2, IsHidden: true})
+
+	// the report can't be handled twice
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/moderation/reports/"+strconv.FormatInt(report.ID, 10)+"/actions?token="+moderatorToken, &api.ModerationActionOption{
+		Action: "dismiss_report",
+	})
+	MakeRequest(t, req, http.StatusConflict)
+
+	// the content of the hidden comment is only returned to the moderators and the poster
+	var comment api.Comment
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/comments/2?token="+reporterToken)
+	DecodeJSON(t, MakeRequest(t, req, http.StatusOK), &comment)
+	assert.True(t, comment.IsHidden)
+	assert.Empty(t, comment.Body)
+
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/comments/2?token="+posterToken)
+	DecodeJSON(t, MakeRequest(t, req, http.StatusOK), &comment)
+	assert.NotEmpty(t, comment.Body)
+
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/moderation/log?token="+moderatorToken)
+	resp = MakeRequest(t, req, http.StatusOK)
+	var entries []*api.ModerationLogEntry
+	DecodeJSON(t, resp, &entries)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "hide_comment", entries[0].Action)
+		assert.Equal(t, report.ID, entries[0].ReportID)
+		assert.Equal(t, "user3", entries[0].TargetUser.UserName)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIOrgRoles(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// user2 owns org3
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session)
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/orgs/user3/roles?token="+token, &api.CreateRoleOption{
+		Name:     "triage",
+		UnitsMap: map[string]string{"repo.code": "read", "repo.issues": "write"},
+	})
+	resp := session.MakeRequest(t, req, http.StatusCreated)
+	var apiRole api.Role
+	DecodeJSON(t, resp, &apiRole)
+	assert.Equal(t, "triage", apiRole.Name)
+	assert.Equal(t, map[string]string{"repo.code": "read", "repo.issues": "write"}, apiRole.UnitsMap)
+
+	// a role must grant access to a unit
+	req = NewRequestWithJSON(t, "POST", "/api/v1/orgs/user3/roles?token="+token, &api.CreateRoleOption{
+		Name:     "nothing",
+		UnitsMap: map[string]string{"repo.code": "none"},
+	})
+	session.MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequest(t, "GET", "/api/v1/orgs/user3/roles?token="+token)
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	var apiRoles []*api.Role
+	DecodeJSON(t, resp, &apiRoles)
+	assert.Len(t, apiRoles, 1)
+
+	// assign the role to a team
+	req = NewRequestWithJSON(t, "POST", "/api/v1/orgs/user3/teams?token="+token, &api.CreateTeamOption{
+		Name:   "triagers",
+		RoleID: apiRole.ID,
+	})
+	resp = session.MakeRequest(t, req, http.StatusCreated)
+	var apiTeam api.Team
+	DecodeJSON(t, resp, &apiTeam)
+	assert.Equal(t, apiRole.ID, apiTeam.RoleID)
+	assert.Equal(t, "write", apiTeam.UnitsMap["repo.issues"])
+
+	// changing the role changes the permissions of the team
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/orgs/user3/roles/%d?token=%s", apiRole.ID, token), &api.EditRoleOption{
+		UnitsMap: map[string]string{"repo.code": "read", "repo.releases": "write"},
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+	unittest.AssertExistsAndLoadBean(t, &organization.TeamUnit{TeamID: apiTeam.ID, Type: unit.TypeReleases, AccessMode: perm.AccessModeWrite})
+	unittest.AssertNotExistsBean(t, &organization.TeamUnit{TeamID: apiTeam.ID, Type: unit.TypeIssues})
+
+	// a role assigned to a team cannot be deleted
+	req = NewRequestf(t, "DELETE", "/api/v1/orgs/user3/roles/%d?token=%s", apiRole.ID, token)
+	session.MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestf(t, "DELETE", "/api/v1/teams/%d?token=%s", apiTeam.ID, token)
+	session.MakeRequest(t, req, http.StatusNoContent)
+	req = NewRequestf(t, "DELETE", "/api/v1/orgs/user3/roles/%d?token=%s", apiRole.ID, token)
+	session.MakeRequest(t, req, http.StatusNoContent)
+	unittest.AssertNotExistsBean(t, &organization.Role{ID: apiRole.ID})
+
+	// members who don't own the organization cannot create roles
+	session = loginUser(t, "user4")
+	token = getTokenForLoggedInUser(t, session)
+	req = NewRequestWithJSON(t, "POST", "/api/v1/orgs/user3/roles?token="+token, &api.CreateRoleOption{
+		Name:     "triage",
+		UnitsMap: map[string]string{"repo.issues": "write"},
+	})
+	session.MakeRequest(t, req, http.StatusForbidden)
+}
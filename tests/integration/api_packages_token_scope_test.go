@@ -0,0 +1,80 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageReadTokenScope(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	readToken := &auth_model.AccessToken{UID: user.ID, Name: "read-packages", Scope: auth_model.AccessTokenScopeRead}
+	assert.NoError(t, auth_model.NewAccessToken(readToken))
+	fullToken := &auth_model.AccessToken{UID: user.ID, Name: "all-packages"}
+	assert.NoError(t, auth_model.NewAccessToken(fullToken))
+	feedToken := &auth_model.AccessToken{UID: user.ID, Name: "feeds", Scope: auth_model.AccessTokenScopeFeed}
+	assert.NoError(t, auth_model.NewAccessToken(feedToken))
+
+	url := fmt.Sprintf("/api/packages/%s/generic/scoped/1.0.0/file.bin", user.Name)
+	content := []byte{1, 2, 3}
+
+	t.Run("ReadTokenUpload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", url, bytes.NewReader(content))
+		req.SetBasicAuth(user.Name, readToken.Token)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/nuget/", user.Name), bytes.NewReader(content))
+		req.Header.Set("X-NuGet-ApiKey", readToken.Token)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("FullTokenUpload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", url, bytes.NewReader(content))
+		req.SetBasicAuth(user.Name, fullToken.Token)
+		MakeRequest(t, req, http.StatusCreated)
+	})
+
+	t.Run("ReadTokenDownloadAndDelete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", url)
+		req.SetBasicAuth(user.Name, readToken.Token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "DELETE", url)
+		req.SetBasicAuth(user.Name, readToken.Token)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("FeedToken", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// a feed token doesn't authenticate anything but the feeds
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/nuget/", user.Name), bytes.NewReader(content))
+		req.Header.Set("X-NuGet-ApiKey", feedToken.Token)
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "PUT", url, bytes.NewReader(content))
+		req.SetBasicAuth(user.Name, feedToken.Token)
+		MakeRequest(t, req, http.StatusUnauthorized)
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIPullMergePreview(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		session := loginUser(t, "user1")
+		testRepoFork(t, session, "user2", "repo1", "user1", "repo1")
+		testEditFile(t, session, "user1", "repo1", "master", "README.md", "Hello, World (Edited)\n")
+
+		resp := testPullCreate(t, session, "user1", "repo1", "master", "This is a pull title")
+		elem := strings.Split(test.RedirectURL(resp), "/")
+		assert.EqualValues(t, "pulls", elem[3])
+
+		req := NewRequestf(t, "GET", "/api/v1/repos/%s/%s/pulls/%s/merge/preview", elem[1], elem[2], elem[4])
+		resp = MakeRequest(t, req, http.StatusOK)
+		var preview api.PullRequestMergePreview
+		DecodeJSON(t, resp, &preview)
+
+		assert.NotEmpty(t, preview.MergeBase)
+		assert.NotEqual(t, preview.BaseCommit, preview.HeadCommit)
+		commitCounts := make(map[string]int, len(preview.Styles))
+		for _, style := range preview.Styles {
+			assert.True(t, style.Mergeable, style.Style)
+			assert.Empty(t, style.ConflictedFiles, style.Style)
+			commitCounts[style.Style] = style.CommitCount
+		}
+		assert.Equal(t, map[string]int{"merge": 2, "rebase": 1, "rebase-merge": 2, "squash": 1}, commitCounts)
+		if assert.Len(t, preview.Files, 1) {
+			assert.Equal(t, "README.md", preview.Files[0].Filename)
+			assert.Equal(t, "modified", preview.Files[0].Status)
+		}
+		assert.False(t, preview.FilesTruncated)
+	})
+}
+
+func TestAPIPullMergePreviewConflict(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		session := loginUser(t, "user1")
+		testRepoFork(t, session, "user2", "repo1", "user1", "repo1")
+		testEditFileToNewBranch(t, session, "user1", "repo1", "master", "conflict", "README.md", "Hello, World (Edited Once)\n")
+		testEditFileToNewBranch(t, session, "user1", "repo1", "master", "base", "README.md", "Hello, World (Edited Twice)\n")
+
+		token := getTokenForLoggedInUser(t, session)
+		req := NewRequestWithJSON(t, http.MethodPost, fmt.Sprintf("/api/v1/repos/%s/%s/pulls?token=%s", "user1", "repo1", token), &api.CreatePullRequestOption{
+			Head:  "conflict",
+			Base:  "base",
+			Title: "create a conflicting pr",
+		})
+		resp := session.MakeRequest(t, req, http.StatusCreated)
+		var pr api.PullRequest
+		DecodeJSON(t, resp, &pr)
+
+		req = NewRequestf(t, "GET", "/api/v1/repos/user1/repo1/pulls/%d/merge/preview?token=%s", pr.Index, token)
+		resp = session.MakeRequest(t, req, http.StatusOK)
+		var preview api.PullRequestMergePreview
+		DecodeJSON(t, resp, &preview)
+
+		assert.NotEmpty(t, preview.Styles)
+		for _, style := range preview.Styles {
+			assert.False(t, style.Mergeable, style.Style)
+			assert.Zero(t, style.CommitCount, style.Style)
+			assert.Equal(t, []string{"README.md"}, style.ConflictedFiles, style.Style)
+			if style.Style == "rebase" || style.Style == "rebase-merge" {
+				assert.Equal(t, pr.Head.Sha, style.ConflictCommit)
+			}
+		}
+
+		// a closed pull request has nothing to preview
+		closed := "closed"
+		req = NewRequestWithJSON(t, http.MethodPatch, fmt.Sprintf("/api/v1/repos/user1/repo1/pulls/%d?token=%s", pr.Index, token), &api.EditPullRequestOption{State: &closed})
+		session.MakeRequest(t, req, http.StatusCreated)
+		req = NewRequestf(t, "GET", "/api/v1/repos/user1/repo1/pulls/%d/merge/preview?token=%s", pr.Index, token)
+		session.MakeRequest(t, req, http.StatusConflict)
+	})
+}
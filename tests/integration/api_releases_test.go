@@ -231,3 +231,55 @@ func TestAPIDeleteReleaseByTagName(t *testing.T) {
 	req = NewRequestf(t, http.MethodDelete, fmt.Sprintf("/api/v1/repos/%s/%s/tags/release-tag?token=%s", owner.Name, repo.Name, token))
 	_ = session.MakeRequest(t, req, http.StatusNoContent)
 }
+
+func TestAPIReleaseBuild(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	session := loginUser(t, owner.LowerName)
+	token := getTokenForLoggedInUser(t, session)
+
+	gitRepo, err := git.OpenRepository(git.DefaultContext, repo.RepoPath())
+	assert.NoError(t, err)
+	defer gitRepo.Close()
+	assert.NoError(t, gitRepo.CreateTag("v0.0.2", "master"))
+
+	buildsURL := fmt.Sprintf("/api/v1/repos/%s/%s/releases/builds", owner.Name, repo.Name)
+	req := NewRequestWithJSON(t, "POST", buildsURL+"?token="+token, &api.CreateReleaseBuildOption{TagName: "v0.0.2"})
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var build api.ReleaseBuild
+	DecodeJSON(t, resp, &build)
+	assert.Equal(t, "v0.0.2", build.TagName)
+	assert.Equal(t, "pending", build.Status)
+	assert.Equal(t, owner.ID, build.Builder.ID)
+
+	req = NewRequestWithJSON(t, "POST", buildsURL+"?token="+token, &api.CreateReleaseBuildOption{TagName: "v0.0.2"})
+	MakeRequest(t, req, http.StatusConflict)
+	req = NewRequestWithJSON(t, "POST", buildsURL+"?token="+token, &api.CreateReleaseBuildOption{TagName: "v1.1"})
+	MakeRequest(t, req, http.StatusConflict)
+
+	// the release isn't published before the build succeeds
+	unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: build.ReleaseID, IsDraft: true})
+
+	buildURL := fmt.Sprintf("%s/%d", buildsURL, build.ID)
+	req = NewRequestWithJSON(t, "POST", buildURL+"/log?token="+token, &api.AppendReleaseBuildLogOption{Content: "building\n"})
+	MakeRequest(t, req, http.StatusNoContent)
+	resp = MakeRequest(t, NewRequest(t, "GET", buildURL+"/log?token="+token), http.StatusOK)
+	assert.Equal(t, "building\n", resp.Body.String())
+
+	req = NewRequestWithJSON(t, "PATCH", buildURL+"?token="+token, &api.EditReleaseBuildOption{Status: "success"})
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &build)
+	assert.Equal(t, "success", build.Status)
+
+	releaseURL := fmt.Sprintf("/api/v1/repos/%s/%s/releases/tags/v0.0.2", owner.Name, repo.Name)
+	resp = MakeRequest(t, NewRequest(t, "GET", releaseURL), http.StatusOK)
+	var release api.Release
+	DecodeJSON(t, resp, &release)
+	assert.Equal(t, build.ReleaseID, release.ID)
+	assert.False(t, release.IsDraft)
+
+	req = NewRequestWithJSON(t, "POST", buildURL+"/log?token="+token, &api.AppendReleaseBuildLogOption{Content: "late\n"})
+	MakeRequest(t, req, http.StatusConflict)
+}
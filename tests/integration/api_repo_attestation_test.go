@@ -0,0 +1,118 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	attestation_model "code.gitea.io/gitea/models/attestation"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testProvenance = `{"_type": "https://in-toto.io/Statement/v0.1",
+"subject": [{"name": "repo1.tar.gz", "digest": {"sha256": "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"}}],
+"predicateType": "https://slsa.dev/provenance/v0.2", "predicate": {}}`
+
+func TestAPIRepoAttestations(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	session := loginUser(t, user2.Name)
+	token := getTokenForLoggedInUser(t, session)
+	link := fmt.Sprintf("/api/v1/repos/%s/%s/attestations", user2.Name, repo1.Name)
+
+	req := NewRequestWithJSON(t, "POST", link+"?token="+token, &api.CreateAttestationOption{
+		SubjectType: "commit",
+		Subject:     "master",
+		Content:     `{"predicateType": "https://slsa.dev/provenance/v0.2"}`,
+	})
+	session.MakeRequest(t, req, http.StatusUnprocessableEntity)
+	req = NewRequestWithJSON(t, "POST", link+"?token="+token, &api.CreateAttestationOption{
+		SubjectType: "commit",
+		Subject:     "0000000000000000000000000000000000000000",
+		Content:     testProvenance,
+	})
+	session.MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", link+"?token="+token, &api.CreateAttestationOption{
+		SubjectType: "commit",
+		Subject:     "master",
+		Content:     testProvenance,
+	})
+	resp := session.MakeRequest(t, req, http.StatusCreated)
+	var apiAttestation api.Attestation
+	DecodeJSON(t, resp, &apiAttestation)
+	assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", apiAttestation.Subject)
+	assert.Equal(t, "https://slsa.dev/provenance/v0.2", apiAttestation.PredicateType)
+	assert.Equal(t, []string{"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"}, apiAttestation.Digests)
+	assert.Equal(t, user2.ID, apiAttestation.Uploader.ID)
+
+	req = NewRequest(t, "GET", link+"?subject_type=commit&token="+token)
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	var apiAttestations []*api.Attestation
+	DecodeJSON(t, resp, &apiAttestations)
+	if assert.Len(t, apiAttestations, 1) {
+		assert.Equal(t, apiAttestation.ID, apiAttestations[0].ID)
+	}
+
+	req = NewRequest(t, "DELETE", fmt.Sprintf("%s/%d?token=%s", link, apiAttestation.ID, token))
+	session.MakeRequest(t, req, http.StatusNoContent)
+	unittest.AssertNotExistsBean(t, &attestation_model.Attestation{ID: apiAttestation.ID})
+
+	// the attestations of other repositories can't be read
+	req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/repo2/attestations/1?token=%s", user2.Name, token))
+	session.MakeRequest(t, req, http.StatusNotFound)
+}
+
+func TestAPIReleaseRequireAttestations(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	releasesUnit := unittest.AssertExistsAndLoadBean(t, &repo_model.RepoUnit{RepoID: repo1.ID, Type: unit.TypeReleases})
+	releasesUnit.ReleasesConfig().RequireAttestations = true
+	assert.NoError(t, repo_model.UpdateRepoUnit(releasesUnit))
+
+	session := loginUser(t, user2.Name)
+	token := getTokenForLoggedInUser(t, session)
+	link := fmt.Sprintf("/api/v1/repos/%s/%s", user2.Name, repo1.Name)
+
+	release := &api.CreateReleaseOption{TagName: "v9.0", Title: "v9.0", Target: "master"}
+	req := NewRequestWithJSON(t, "POST", link+"/releases?token="+token, release)
+	session.MakeRequest(t, req, http.StatusUnprocessableEntity)
+	unittest.AssertNotExistsBean(t, &repo_model.Release{RepoID: repo1.ID, TagName: "v9.0"})
+
+	// a draft doesn't need an attestation until it is published
+	release.IsDraft = true
+	req = NewRequestWithJSON(t, "POST", link+"/releases?token="+token, release)
+	resp := session.MakeRequest(t, req, http.StatusCreated)
+	var apiRelease api.Release
+	DecodeJSON(t, resp, &apiRelease)
+
+	isDraft := false
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("%s/releases/%d?token=%s", link, apiRelease.ID, token), &api.EditReleaseOption{IsDraft: &isDraft})
+	session.MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", link+"/attestations?token="+token, &api.CreateAttestationOption{
+		SubjectType: "tag",
+		Subject:     "v9.0",
+		Content:     testProvenance,
+	})
+	session.MakeRequest(t, req, http.StatusCreated)
+
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("%s/releases/%d?token=%s", link, apiRelease.ID, token), &api.EditReleaseOption{IsDraft: &isDraft})
+	session.MakeRequest(t, req, http.StatusOK)
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &repo_model.Release{ID: apiRelease.ID}).IsDraft)
+}
@@ -0,0 +1,94 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	api "code.gitea.io/gitea/modules/structs"
+	files_service "code.gitea.io/gitea/services/repository/files"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPICherryPickCommit(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+		ctx := NewAPITestContext(t, user2.Name, repo1.Name)
+
+		commitInNewBranch := func(treePath, branch, content string) string {
+			resp, err := files_service.CreateOrUpdateRepoFile(git.DefaultContext, repo1, user2, &files_service.UpdateRepoFileOptions{
+				OldBranch: "master",
+				NewBranch: branch,
+				TreePath:  treePath,
+				Content:   content,
+				IsNewFile: true,
+			})
+			assert.NoError(t, err)
+			return resp.Commit.SHA
+		}
+		cherryPick := func(sha, op string, opts api.CherryPickCommitOption, expectedStatus int) *api.CherryPickResponse {
+			urlStr := fmt.Sprintf("/api/v1/repos/%s/%s/git/commits/%s/%s?token=%s", user2.Name, repo1.Name, sha, op, ctx.Token)
+			resp := MakeRequest(t, NewRequestWithJSON(t, "POST", urlStr, &opts), expectedStatus)
+			var result api.CherryPickResponse
+			DecodeJSON(t, resp, &result)
+			return &result
+		}
+
+		t.Run("CherryPickAndRevert", func(t *testing.T) {
+			sha := commitInNewBranch("cherry.txt", "cherry", "cherry")
+
+			result := cherryPick(sha, "cherry-pick", api.CherryPickCommitOption{}, http.StatusCreated)
+			assert.Equal(t, "master", result.Branch)
+			assert.NotNil(t, result.Commit)
+			assert.Nil(t, result.PullRequest)
+			assert.Empty(t, result.ConflictedFiles)
+			MakeRequest(t, NewRequestf(t, "GET", "/api/v1/repos/%s/%s/contents/cherry.txt?ref=master", user2.Name, repo1.Name), http.StatusOK)
+
+			result = cherryPick(result.Commit.SHA, "revert", api.CherryPickCommitOption{
+				FileOptions: api.FileOptions{NewBranchName: "revert-cherry"},
+			}, http.StatusCreated)
+			assert.Equal(t, "revert-cherry", result.Branch)
+			assert.Contains(t, result.Commit.Message, "Revert")
+		})
+
+		t.Run("Conflict", func(t *testing.T) {
+			sha := commitInNewBranch("conflict.txt", "conflict", "theirs")
+			_, err := createFileInBranch(user2, repo1, "conflict.txt", "master", "ours")
+			assert.NoError(t, err)
+
+			result := cherryPick(sha, "cherry-pick", api.CherryPickCommitOption{}, http.StatusConflict)
+			assert.Equal(t, "master", result.Branch)
+			assert.Nil(t, result.Commit)
+			assert.Equal(t, []string{"conflict.txt"}, result.ConflictedFiles)
+		})
+
+		t.Run("ProtectedBranch", func(t *testing.T) {
+			sha := commitInNewBranch("protected.txt", "protected-cherry", "protected")
+			doProtectBranch(ctx, "master", "", "")(t)
+
+			result := cherryPick(sha, "cherry-pick", api.CherryPickCommitOption{}, http.StatusCreated)
+			assert.Equal(t, "user2-patch-1", result.Branch)
+			if assert.NotNil(t, result.PullRequest) {
+				assert.Equal(t, "master", result.PullRequest.Base.Name)
+				assert.Equal(t, "user2-patch-1", result.PullRequest.Head.Name)
+			}
+			unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: repo1.ID, HeadBranch: "user2-patch-1", BaseBranch: "master"})
+		})
+
+		t.Run("NotFound", func(t *testing.T) {
+			cherryPick("0000000000000000000000000000000000000000", "cherry-pick", api.CherryPickCommitOption{}, http.StatusNotFound)
+		})
+	})
+}
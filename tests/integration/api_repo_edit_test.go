@@ -10,11 +10,14 @@ import (
 	"net/url"
 	"testing"
 
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	unit_model "code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -344,3 +347,85 @@ func TestAPIRepoEdit(t *testing.T) {
 		session.MakeRequest(t, req, http.StatusForbidden)
 	})
 }
+
+func TestAPIRepoEditStalePolicy(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	session := loginUser(t, user2.Name)
+	token := getTokenForLoggedInUser(t, session)
+	url := fmt.Sprintf("/api/v1/repos/%s/repo1?token=%s", user2.Name, token)
+
+	hasIssues := true
+	policy := &api.StalePolicy{
+		StaleDays:        60,
+		StaleCloseDays:   14,
+		StaleLabel:       "inactive",
+		ExemptLabels:     []string{"bug", "pinned"},
+		ExemptMilestones: true,
+	}
+	req := NewRequestWithJSON(t, "PATCH", url, &api.EditRepoOption{
+		HasIssues:       &hasIssues,
+		InternalTracker: &api.InternalTracker{EnableTimeTracker: true, StalePolicy: policy},
+	})
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	var repo api.Repository
+	DecodeJSON(t, resp, &repo)
+	assert.Equal(t, policy, repo.InternalTracker.StalePolicy)
+
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	issuesUnit, err := repo1.GetUnit(unit_model.TypeIssues)
+	assert.NoError(t, err)
+	assert.Equal(t, 60, issuesUnit.IssuesConfig().StaleDays)
+	assert.Equal(t, "bug,pinned", issuesUnit.IssuesConfig().StaleExemptLabels)
+
+	// the stale policy is kept when omitted
+	req = NewRequestWithJSON(t, "PATCH", url, &api.EditRepoOption{
+		HasIssues:       &hasIssues,
+		InternalTracker: &api.InternalTracker{EnableTimeTracker: false},
+	})
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &repo)
+	assert.False(t, repo.InternalTracker.EnableTimeTracker)
+	assert.Equal(t, policy, repo.InternalTracker.StalePolicy)
+
+	policy.StaleDays = -1
+	req = NewRequestWithJSON(t, "PATCH", url, &api.EditRepoOption{
+		HasIssues:       &hasIssues,
+		InternalTracker: &api.InternalTracker{StalePolicy: policy},
+	})
+	session.MakeRequest(t, req, http.StatusUnprocessableEntity)
+}
+
+func TestAPIRepoStalePolicyIssueMirror(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	// the issues of user2/repo1 are mirrored from an external issue tracker
+	assert.NoError(t, issues_model.SaveIssueMirror(db.DefaultContext, &issues_model.IssueMirror{
+		RepoID:        1,
+		DoerID:        2,
+		ServiceType:   api.GithubService,
+		RemoteAddress: "https://github.com/owner/repo/",
+	}))
+
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session)
+	adminToken := getTokenForLoggedInUser(t, loginUser(t, "user1"))
+	hasIssues := true
+	setPolicy := func(policy *api.StalePolicy) {
+		req := NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1?token="+token, &api.EditRepoOption{
+			HasIssues:       &hasIssues,
+			InternalTracker: &api.InternalTracker{StalePolicy: policy},
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+		MakeRequest(t, NewRequest(t, "POST", "/api/v1/admin/cron/process_stale_issues?token="+adminToken), http.StatusNoContent)
+	}
+
+	// the inactive issue1 is mirrored, so it is left to its external tracker
+	setPolicy(&api.StalePolicy{StaleDays: 30})
+	unittest.AssertNotExistsBean(t, &issues_model.Label{RepoID: 1, Name: repo_model.DefaultStaleLabel})
+
+	// the inactive pull request 11 isn't mirrored
+	setPolicy(&api.StalePolicy{StaleDays: 30, IncludePulls: true})
+	label := unittest.AssertExistsAndLoadBean(t, &issues_model.Label{RepoID: 1, Name: repo_model.DefaultStaleLabel})
+	unittest.AssertNotExistsBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: label.ID})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: 11, LabelID: label.ID})
+}
@@ -0,0 +1,65 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRepoExpertise(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the expertise is not found while the indexer is disabled
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/expertise"), http.StatusNotFound)
+
+	setting.Indexer.ExpertiseIndexerEnabled = true
+	defer func() {
+		setting.Indexer.ExpertiseIndexerEnabled = false
+	}()
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/expertise?limit=2"), http.StatusOK)
+	var expertise api.RepoExpertise
+	DecodeJSON(t, resp, &expertise)
+	assert.Equal(t, "", expertise.Path)
+	assert.Equal(t, 2, expertise.BusFactor)
+	assert.Equal(t, 3, expertise.Authors)
+	if assert.Len(t, expertise.Experts, 2) {
+		assert.Equal(t, "user2", expertise.Experts[0].User.UserName)
+		assert.EqualValues(t, 30, expertise.Experts[0].Score)
+		assert.Equal(t, "user4", expertise.Experts[1].User.UserName)
+	}
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/expertise?path=/docs/guide.md"), http.StatusOK)
+	DecodeJSON(t, resp, &expertise)
+	assert.Equal(t, "docs/guide.md", expertise.Path)
+	assert.Equal(t, 1, expertise.BusFactor)
+	assert.Len(t, expertise.Experts, 1)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/expertise/bus_factor"), http.StatusOK)
+	var busFactors []*api.DirectoryBusFactor
+	DecodeJSON(t, resp, &busFactors)
+	if assert.Len(t, busFactors, 2) {
+		assert.Equal(t, "", busFactors[0].Path)
+		assert.Equal(t, 2, busFactors[0].BusFactor)
+		assert.Equal(t, "docs", busFactors[1].Path)
+		assert.Equal(t, 1, busFactors[1].BusFactor)
+		assert.Equal(t, "user4", busFactors[1].TopExpert.User.UserName)
+	}
+
+	// pull #3 adds a file to the root directory, user2 cannot be requested to review it
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/pulls/3/suggested_reviewers"), http.StatusOK)
+	var reviewers []*api.Expert
+	DecodeJSON(t, resp, &reviewers)
+	if assert.Len(t, reviewers, 1) {
+		assert.Equal(t, "user4", reviewers[0].User.UserName)
+	}
+}
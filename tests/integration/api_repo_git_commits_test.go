@@ -8,8 +8,10 @@ import (
 	"net/http"
 	"testing"
 
+	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/tests"
 
@@ -52,6 +54,41 @@ func TestAPIReposGitCommits(t *testing.T) {
 	}
 }
 
+func TestAPIReposGitCommitVerification(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	session := loginUser(t, user.Name)
+	token := getTokenForLoggedInUser(t, session)
+
+	req := NewRequestf(t, "GET", "/api/v1/repos/%s/repo1/git/commits/12345/verification?token="+token, user.Name)
+	session.MakeRequest(t, req, http.StatusNotFound)
+
+	sha := "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+	req = NewRequestf(t, "GET", "/api/v1/repos/%s/repo1/git/commits/%s/verification?token="+token, user.Name, sha)
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	var verification api.CommitSignatureVerification
+	DecodeJSON(t, resp, &verification)
+	assert.Equal(t, sha, verification.SHA)
+	assert.False(t, verification.Verified)
+	assert.Equal(t, "gpg.error.not_signed_commit", verification.Reason)
+	assert.Equal(t, "ethantkoenig@gmail.com", verification.Committer.Email)
+	assert.Nil(t, verification.Signer)
+	assert.Empty(t, verification.KeyChain)
+
+	// a raw commit object is verified as if it had been pushed to the repository
+	raw, _, err := git.NewCommand(git.DefaultContext, "cat-file", "commit", sha).RunStdString(&git.RunOpts{Dir: repo.RepoPath()})
+	assert.NoError(t, err)
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/git/verification?token="+token, &api.VerifyCommitSignatureOption{Commit: raw})
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	var payloadVerification api.CommitSignatureVerification
+	DecodeJSON(t, resp, &payloadVerification)
+	assert.Equal(t, verification, payloadVerification)
+
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/git/verification?token="+token, &api.VerifyCommitSignatureOption{})
+	session.MakeRequest(t, req, http.StatusUnprocessableEntity)
+}
+
 func TestAPIReposGitCommitList(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
@@ -0,0 +1,46 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRepoGrep(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/glob/grep?q=loren+ipsum$"), http.StatusOK)
+	var result api.GrepResponse
+	DecodeJSON(t, resp, &result)
+	assert.Equal(t, "ef6b814b610d8e7717aa0f71fbe5842bcf814697", result.SHA)
+	assert.False(t, result.Truncated)
+	if assert.Len(t, result.Matches, 2) {
+		assert.Equal(t, "a.txt", result.Matches[0].Path)
+		assert.Equal(t, 1, result.Matches[0].LineNumber)
+		assert.Equal(t, "file1 loren ipsum", result.Matches[0].Line)
+		assert.Equal(t, "aaa.doc", result.Matches[1].Path)
+	}
+
+	// the paths and the limit bound the search
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/glob/grep?q=FILE[0-9]&ignore_case=true&path=x&limit=2"), http.StatusOK)
+	result = api.GrepResponse{}
+	DecodeJSON(t, resp, &result)
+	assert.True(t, result.Truncated)
+	if assert.Len(t, result.Matches, 2) {
+		assert.Equal(t, "x/b.txt", result.Matches[0].Path)
+		assert.Equal(t, "x/y/a.txt", result.Matches[1].Path)
+	}
+
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/glob/grep"), http.StatusUnprocessableEntity)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/glob/grep?q=("), http.StatusUnprocessableEntity)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/glob/grep?q=file&context=100"), http.StatusUnprocessableEntity)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/glob/grep?q=file&ref=unknown"), http.StatusNotFound)
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIGetLastCommits(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	const sha = "ef6b814b610d8e7717aa0f71fbe5842bcf814697"
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/glob/last_commits?path=a.txt&path=x/y&path=x/y/z/a.txt"), http.StatusOK)
+	var lastCommits []*api.LastCommit
+	DecodeJSON(t, resp, &lastCommits)
+	if assert.Len(t, lastCommits, 3) {
+		assert.Equal(t, "a.txt", lastCommits[0].Path)
+		assert.Equal(t, "x/y", lastCommits[1].Path)
+		assert.Equal(t, "x/y/z/a.txt", lastCommits[2].Path)
+		for _, lastCommit := range lastCommits {
+			assert.Equal(t, sha, lastCommit.Commit.SHA)
+		}
+	}
+
+	// the response of the same commit and paths can be revalidated
+	etag := resp.Header().Get("Etag")
+	assert.NotEmpty(t, etag)
+	req := NewRequest(t, "GET", "/api/v1/repos/user2/glob/last_commits?ref="+sha+"&path=a.txt&path=x/y&path=x/y/z/a.txt")
+	req.Header.Set("If-None-Match", etag)
+	MakeRequest(t, req, http.StatusNotModified)
+
+	// the entries of a directory are listed page by page
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/glob/last_commits?dir=x&limit=1"), http.StatusOK)
+	assert.Equal(t, "2", resp.Header().Get("X-Total-Count"))
+	lastCommits = nil
+	DecodeJSON(t, resp, &lastCommits)
+	if assert.Len(t, lastCommits, 1) {
+		assert.Equal(t, "x/b.txt", lastCommits[0].Path)
+	}
+
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/glob/last_commits?path=unknown.txt"), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/glob/last_commits?dir=unknown"), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/glob/last_commits?ref=unknown&path=a.txt"), http.StatusNotFound)
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRepoMaintenanceReport(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/insights/maintenance"), http.StatusOK)
+	var report api.MaintenanceReport
+	DecodeJSON(t, resp, &report)
+	assert.Equal(t, "user2/repo1", report.Repository.FullName)
+	assert.EqualValues(t, 1, report.NumSingleAuthorFiles)
+	if assert.Len(t, report.SingleAuthorFiles, 1) {
+		assert.Equal(t, "docs/guide.md", report.SingleAuthorFiles[0].Path)
+		assert.Equal(t, "user4@example.com", report.SingleAuthorFiles[0].Email)
+	}
+	assert.Empty(t, report.StaleDirectories)
+
+	// repo16 has no report
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo16/insights/maintenance?token="+token), http.StatusNotFound)
+}
+
+func TestAPIOrgMaintenanceReports(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// repo3 of the organization user3 is private
+	assert.NoError(t, repo_model.SaveMaintenanceReport(db.DefaultContext, &repo_model.MaintenanceReport{
+		RepoID:              3,
+		NumStaleDirectories: 1,
+		StaleDirectories:    []*repo_model.MaintenanceReportEntry{{Path: "docs", LastCommitUnix: 946684800}},
+	}))
+
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session)
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/user3/insights/maintenance?token="+token), http.StatusOK)
+	var reports []*api.MaintenanceReport
+	DecodeJSON(t, resp, &reports)
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, "user3/repo3", reports[0].Repository.FullName)
+		assert.EqualValues(t, 1, reports[0].NumStaleDirectories)
+		assert.Equal(t, "docs", reports[0].StaleDirectories[0].Path)
+	}
+
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/org/user3/maintenance"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "/user3/repo3")
+	assert.Contains(t, resp.Body.String(), "/docs\">docs</a>")
+
+	// the reports need a token
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/user3/insights/maintenance"), http.StatusUnauthorized)
+}
@@ -0,0 +1,28 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRepoRenderFile(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	for _, query := range []string{"", "?ref=master", "?ref=65f1bf27bc3bf70f64657658635e66094edbcb4d"} {
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/render/README.md"+query)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "text/html; charset=utf-8", resp.Header().Get("Content-Type"))
+		assert.Contains(t, resp.Body.String(), `<h1 id="user-content-repo1">repo1</h1>`)
+	}
+
+	req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/render/missing.md")
+	MakeRequest(t, req, http.StatusNotFound)
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+)
+
+func TestAPIRepoSignedURLPasswordChange(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// user2/repo2 is private
+	token := getTokenForLoggedInUser(t, loginUser(t, "user2"))
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo2/signed_urls?token="+token, &api.CreateSignedURLOption{
+		Type: "raw",
+		Path: "README.md",
+	})
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var signedURL api.SignedURL
+	DecodeJSON(t, resp, &signedURL)
+	link := "/" + strings.TrimPrefix(signedURL.URL, setting.AppURL)
+
+	MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+
+	// changing the password of the signer revokes the URL
+	adminToken := getTokenForLoggedInUser(t, loginUser(t, "user1"))
+	req = NewRequestWithJSON(t, "PATCH", "/api/v1/admin/users/user2?token="+adminToken, &api.EditUserOption{
+		LoginName: "user2",
+		Password:  "new-password-123",
+	})
+	MakeRequest(t, req, http.StatusOK)
+
+	MakeRequest(t, NewRequest(t, "GET", link), http.StatusNotFound)
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIGitignoreTemplates(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/gitignore/templates"), http.StatusOK)
+	var names []string
+	DecodeJSON(t, resp, &names)
+	assert.Contains(t, names, "Go")
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/gitignore/templates/Go"), http.StatusOK)
+	var template api.GitignoreTemplateInfo
+	DecodeJSON(t, resp, &template)
+	assert.Equal(t, "Go", template.Name)
+	assert.Contains(t, template.Source, "*.exe")
+
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/gitignore/templates/unknown"), http.StatusNotFound)
+}
+
+func TestAPILicenseTemplates(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/licenses"), http.StatusOK)
+	var names []string
+	DecodeJSON(t, resp, &names)
+	assert.Contains(t, names, "MIT")
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/licenses/MIT?year=2020&author=Gitea"), http.StatusOK)
+	var template api.LicenseTemplateInfo
+	DecodeJSON(t, resp, &template)
+	assert.Equal(t, "MIT", template.Name)
+	assert.Contains(t, template.Body, "Copyright (c) 2020 Gitea")
+
+	// the year is the current one by default
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/licenses/MIT"), http.StatusOK)
+	DecodeJSON(t, resp, &template)
+	assert.Contains(t, template.Body, "Copyright (c) "+strconv.Itoa(time.Now().Year())+" <copyright holders>")
+
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/licenses/unknown"), http.StatusNotFound)
+}
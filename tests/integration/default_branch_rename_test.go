@@ -0,0 +1,74 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRenameDefaultBranch(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		session := loginUser(t, "user2")
+		token := getTokenForLoggedInUser(t, session)
+
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/default_branch/rename?token="+token, &api.RenameDefaultBranchOption{NewName: "develop"})
+		MakeRequest(t, req, http.StatusConflict)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/default_branch/rename?token="+token, &api.RenameDefaultBranchOption{NewName: "main"})
+		resp := MakeRequest(t, req, http.StatusOK)
+		var result api.DefaultBranchRename
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, "user2/repo1", result.Repository)
+		assert.Equal(t, "master", result.OldName)
+		assert.Equal(t, "main", result.NewName)
+		assert.Equal(t, 1, result.RetargetedPullRequests)
+		assert.Empty(t, result.Error)
+
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+		assert.Equal(t, "main", repo.DefaultBranch)
+
+		// the old name redirects to the renamed branch when fetched
+		session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/raw/branch/master/README.md"), http.StatusOK)
+
+		u.Path = "user2/repo1.git"
+		u.User = url.UserPassword("user2", userPassword)
+		dstPath := t.TempDir()
+		t.Run("Clone", doGitClone(dstPath, u))
+		_, err := generateCommitWithNewData(littleSize, dstPath, "user2@example.com", "User Two", "rename-")
+		assert.NoError(t, err)
+		t.Run("PushToRedirectRejected", doGitPushTestRepositoryFail(dstPath, "origin", "HEAD:master"))
+		t.Run("PushToRenamedBranch", doGitPushTestRepository(dstPath, "origin", "HEAD:main"))
+	})
+}
+
+func TestOrgRenameDefaultBranches(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	session := loginUser(t, "user2")
+
+	req := NewRequest(t, "GET", "/org/user3/settings/branches")
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+
+	req = NewRequestWithValues(t, "POST", "/org/user3/settings/branches", map[string]string{
+		"_csrf":    htmlDoc.GetCSRF(),
+		"old_name": "master",
+		"new_name": "main",
+	})
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	assert.Positive(t, htmlDoc.doc.Find(".settings.branches table tbody tr").Length())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	assert.Equal(t, "main", repo.DefaultBranch)
+}
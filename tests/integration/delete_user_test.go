@@ -9,13 +9,18 @@ import (
 	"net/http"
 	"testing"
 
+	"code.gitea.io/gitea/models/db"
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	user_service "code.gitea.io/gitea/services/user"
 	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func assertUserDeleted(t *testing.T, userID int64) {
@@ -41,10 +46,39 @@ func TestUserDeleteAccount(t *testing.T) {
 	})
 	session.MakeRequest(t, req, http.StatusSeeOther)
 
+	// the account is only deleted once the grace period has ended
+	unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 8})
+	unittest.AssertExistsAndLoadBean(t, &user_model.ScheduledDeletion{UserID: 8})
+
+	assert.NoError(t, user_model.ScheduleDeletion(db.DefaultContext, 8, 0, timeutil.TimeStampNow()))
+	assert.NoError(t, user_service.DeleteScheduledUsers(db.DefaultContext))
+
 	assertUserDeleted(t, 8)
 	unittest.CheckConsistencyFor(t, &user_model.User{})
 }
 
+func TestUserCancelAccountDeletion(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user8")
+	csrf := GetCSRF(t, session, "/user/settings/account")
+	urlStr := fmt.Sprintf("/user/settings/account/delete?password=%s", userPassword)
+	req := NewRequestWithValues(t, "POST", urlStr, map[string]string{
+		"_csrf": csrf,
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertExistsAndLoadBean(t, &user_model.ScheduledDeletion{UserID: 8})
+
+	csrf = GetCSRF(t, session, "/user/settings/account")
+	req = NewRequestWithValues(t, "POST", "/user/settings/account/delete/cancel", map[string]string{
+		"_csrf": csrf,
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+
+	unittest.AssertNotExistsBean(t, &user_model.ScheduledDeletion{UserID: 8})
+	unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 8})
+}
+
 func TestUserDeleteAccountStillOwnRepos(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
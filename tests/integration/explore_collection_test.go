@@ -0,0 +1,117 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIExplore(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/explore/trending"), http.StatusOK)
+	var repos []*api.Repository
+	DecodeJSON(t, resp, &repos)
+	if assert.Len(t, repos, 2) {
+		assert.EqualValues(t, 1, repos[0].ID)
+		assert.EqualValues(t, 10, repos[1].ID)
+	}
+	assert.Equal(t, "2", resp.Header().Get("X-Total-Count"))
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/explore/collections?pinned=true"), http.StatusOK)
+	var collections []*api.ExploreCollection
+	DecodeJSON(t, resp, &collections)
+	if assert.Len(t, collections, 1) {
+		assert.Equal(t, "Featured", collections[0].Name)
+		assert.True(t, collections[0].Pinned)
+	}
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/explore/collections/2"), http.StatusOK)
+	var collection api.ExploreCollection
+	DecodeJSON(t, resp, &collection)
+	assert.Equal(t, "golang", collection.Topic)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/explore/collections/1/repos"), http.StatusOK)
+	DecodeJSON(t, resp, &repos)
+	if assert.Len(t, repos, 2) {
+		assert.EqualValues(t, 10, repos[0].ID)
+		assert.EqualValues(t, 1, repos[1].ID)
+	}
+
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/explore/collections/99"), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/explore/collections/99/repos"), http.StatusNotFound)
+}
+
+func TestExploreTrending(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/explore/trending"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "Featured")
+	assert.Contains(t, resp.Body.String(), "/user2/repo1")
+
+	// only the pinned collections are shown on the repositories page
+	resp = MakeRequest(t, NewRequest(t, "GET", "/explore/repos"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "/explore/collections/1")
+	assert.NotContains(t, resp.Body.String(), "/explore/collections/2")
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/explore/collections/2"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "/user2/repo1")
+	MakeRequest(t, NewRequest(t, "GET", "/explore/collections/99"), http.StatusNotFound)
+}
+
+func TestAdminExploreCollections(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user1")
+	csrf := GetCSRF(t, session, "/admin/collections")
+	req := NewRequestWithValues(t, "POST", "/admin/collections/new", map[string]string{
+		"_csrf":     csrf,
+		"name":      "Databases",
+		"topic":     "Database",
+		"is_pinned": "on",
+		"position":  "2",
+	})
+	resp := session.MakeRequest(t, req, http.StatusSeeOther)
+	c := unittest.AssertExistsAndLoadBean(t, &repo_model.ExploreCollection{Name: "Databases"})
+	assert.Equal(t, "database", c.Topic)
+	assert.True(t, c.IsPinned)
+	assert.Equal(t, 2, c.Position)
+	link := test.RedirectURL(resp)
+
+	req = NewRequestWithValues(t, "POST", link+"/repos", map[string]string{
+		"_csrf":     csrf,
+		"repo_name": "user2/repo1",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertExistsAndLoadBean(t, &repo_model.ExploreCollectionRepo{CollectionID: c.ID, RepoID: 1})
+
+	resp = session.MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "user2/repo1")
+
+	req = NewRequestWithValues(t, "POST", link+"/repos/delete", map[string]string{
+		"_csrf": csrf,
+		"id":    "1",
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+	unittest.AssertNotExistsBean(t, &repo_model.ExploreCollectionRepo{CollectionID: c.ID, RepoID: 1})
+
+	req = NewRequestWithValues(t, "POST", link+"/delete", map[string]string{
+		"_csrf": csrf,
+	})
+	session.MakeRequest(t, req, http.StatusOK)
+	unittest.AssertNotExistsBean(t, &repo_model.ExploreCollection{ID: c.ID})
+
+	// the other users can't manage the collections
+	loginUser(t, "user2").MakeRequest(t, NewRequest(t, "GET", "/admin/collections"), http.StatusForbidden)
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExploreActivityFeed(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the feed is disabled by default
+	MakeRequest(t, NewRequest(t, "GET", "/explore/activity.rss"), http.StatusNotFound)
+
+	setting.Service.Explore.EnableActivityFeed = true
+	defer func() {
+		setting.Service.Explore.EnableActivityFeed = false
+	}()
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/explore/activity.rss"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/rss+xml")
+	body := resp.Body.String()
+	assert.Contains(t, body, "/user10/repo8")
+	assert.NotContains(t, body, "/user10/repo7", "the actions of private repositories are left out")
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/explore/activity.atom?types=release"), http.StatusOK)
+	assert.NotContains(t, resp.Body.String(), "/user10/repo8")
+	MakeRequest(t, NewRequest(t, "GET", "/explore/activity.atom?types=unknown"), http.StatusBadRequest)
+
+	// the feed is the same for every viewer
+	session := loginUser(t, "user10")
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/explore/activity.rss"), http.StatusOK)
+	assert.NotContains(t, resp.Body.String(), "/user10/repo7")
+}
+
+func TestExploreActivityFeedLimitSince(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	setting.Service.Explore.EnableActivityFeed = true
+	defer func() {
+		setting.Service.Explore.EnableActivityFeed = false
+	}()
+
+	// the public actions are 7 on user10/repo8 and 3 on user11/repo9, the newest first
+	resp := MakeRequest(t, NewRequest(t, "GET", "/explore/activity.atom?limit=1"), http.StatusOK)
+	body := resp.Body.String()
+	assert.Contains(t, body, "/user10/repo8")
+	assert.NotContains(t, body, "/user11/repo9")
+	assert.Contains(t, body, `rel="next"`, "the next page keeps the limit")
+	assert.Contains(t, body, "limit=1&amp;page=2")
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/explore/activity.atom?since=2020-10-18T08:00:00Z"), http.StatusOK)
+	body = resp.Body.String()
+	assert.Contains(t, body, "/user10/repo8")
+	assert.NotContains(t, body, "/user11/repo9")
+
+	MakeRequest(t, NewRequest(t, "GET", "/explore/activity.atom?since=yesterday"), http.StatusBadRequest)
+}
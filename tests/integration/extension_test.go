@@ -0,0 +1,75 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtensionUserRegister(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	setting.Service.EnableCaptcha = false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Event string `json:"event"`
+			Data  struct {
+				User struct {
+					Email string `json:"email"`
+				} `json:"user"`
+			} `json:"data"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "user_register", req.Event)
+		if strings.HasSuffix(req.Data.User.Email, "@blocked.example.com") {
+			_, _ = w.Write([]byte(`{"allow": false, "message": "this domain is blocked"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"allow": true}`))
+	}))
+	defer server.Close()
+
+	defer func(processors []*setting.ExtensionProcessor) {
+		setting.ExtensionProcessors = processors
+	}(setting.ExtensionProcessors)
+	setting.ExtensionProcessors = []*setting.ExtensionProcessor{{
+		Name:          "registration",
+		URL:           server.URL,
+		Events:        []string{"user_register"},
+		Timeout:       5 * time.Second,
+		FailurePolicy: setting.ExtensionFailureDeny,
+	}}
+
+	req := NewRequestWithValues(t, "POST", "/user/sign_up", map[string]string{
+		"user_name": "blockedUser",
+		"email":     "blockedUser@blocked.example.com",
+		"password":  "examplePassword!1",
+		"retype":    "examplePassword!1",
+	})
+	resp := MakeRequest(t, req, http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "this domain is blocked")
+	unittest.AssertNotExistsBean(t, &user_model.User{Name: "blockedUser"})
+
+	req = NewRequestWithValues(t, "POST", "/user/sign_up", map[string]string{
+		"user_name": "allowedUser",
+		"email":     "allowedUser@example.com",
+		"password":  "examplePassword!1",
+		"retype":    "examplePassword!1",
+	})
+	MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "allowedUser"})
+}
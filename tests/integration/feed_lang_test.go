@@ -0,0 +1,37 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedLanguage(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/user2.rss"), http.StatusOK)
+	assert.Equal(t, "en-US", resp.Header().Get("Content-Language"))
+	assert.Contains(t, resp.Body.String(), "Feed of")
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2.rss?lang=de-DE"), http.StatusOK)
+	assert.Equal(t, "de-DE", resp.Header().Get("Content-Language"))
+	assert.Contains(t, resp.Body.String(), "Feed von")
+	assert.Contains(t, resp.Body.String(), "lang=de-DE", "the paging links keep the language")
+
+	// the language of a feed doesn't change the language of the session
+	session := loginUser(t, "user2")
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2.atom?lang=fr-FR"), http.StatusOK)
+	assert.Equal(t, "fr-FR", resp.Header().Get("Content-Language"))
+	if ck := session.GetCookie("lang"); ck != nil {
+		assert.NotEqual(t, "fr-FR", ck.Value)
+	}
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2.atom"), http.StatusOK)
+	assert.Equal(t, "en-US", resp.Header().Get("Content-Language"))
+}
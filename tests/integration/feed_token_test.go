@@ -0,0 +1,53 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedToken(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	req := NewRequest(t, "GET", "/user/settings/applications")
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	doc := NewHTMLParser(t, resp.Body)
+	req = NewRequestWithValues(t, "POST", "/user/settings/applications", map[string]string{
+		"_csrf": doc.GetCSRF(),
+		"name":  "feed-reader",
+		"scope": "feed",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	req = NewRequest(t, "GET", "/user/settings/applications")
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	token := NewHTMLParser(t, resp.Body).doc.Find(".ui.info p").Text()
+	unittest.AssertExistsAndLoadBean(t, &auth_model.AccessToken{UID: 2, Name: "feed-reader", Scope: auth_model.AccessTokenScopeFeed})
+
+	// user2/repo2 is private
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo2.rss"), http.StatusNotFound)
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo2.rss?token="+token), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/rss+xml")
+
+	req = NewRequest(t, "GET", "/user2/repo2.atom")
+	req.SetBasicAuth("user2", token)
+	MakeRequest(t, req, http.StatusOK)
+
+	// the token can't be used for anything but the feeds, nor can a password be used for the feeds
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/user?token="+token), http.StatusUnauthorized)
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo2/src/branch/master/README.md?token="+token), http.StatusNotFound)
+	// the history of a file whose name looks like a feed isn't a feed
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo2/commits/branch/master/docs/x.atom?token="+token), http.StatusNotFound)
+	req = NewRequest(t, "GET", "/user2/repo2.rss")
+	req.SetBasicAuth("user2", userPassword)
+	MakeRequest(t, req, http.StatusNotFound)
+}
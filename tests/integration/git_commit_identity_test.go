@@ -0,0 +1,47 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/url"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/git"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitCommitIdentityPolicy(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerName: "user2", Name: "repo1"})
+		repo.EnforceCommitIdentity = true
+		repo.CommitEmailDomains = "example.org"
+		assert.NoError(t, repo_model.UpdateRepositoryCols(db.DefaultContext, repo, "enforce_commit_identity", "commit_email_domains"))
+
+		u.Path = "user2/repo1.git"
+		u.User = url.UserPassword("user2", userPassword)
+		dstPath := t.TempDir()
+		t.Run("Clone", doGitClone(dstPath, u))
+
+		// user2-2@example.com is an unverified address of user2
+		for _, email := range []string{"user5@example.com", "user2-2@example.com"} {
+			_, err := generateCommitWithNewData(littleSize, dstPath, email, "User Two", "identity-")
+			assert.NoError(t, err)
+			t.Run("PushRejected", doGitPushTestRepositoryFail(dstPath, "origin", "master"))
+			t.Run("PushRejectedToNewBranch", doGitPushTestRepositoryFail(dstPath, "origin", "master:identity"))
+			_, _, err = git.NewCommand(git.DefaultContext, "reset", "--hard", "origin/master").RunStdString(&git.RunOpts{Dir: dstPath})
+			assert.NoError(t, err)
+		}
+
+		for _, email := range []string{"user2@example.com", "colleague@example.org"} {
+			_, err := generateCommitWithNewData(littleSize, dstPath, email, "User Two", "identity-")
+			assert.NoError(t, err)
+			t.Run("PushAccepted", doGitPushTestRepository(dstPath, "origin", "master"))
+		}
+	})
+}
@@ -7,6 +7,7 @@ package integration
 import (
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"testing"
@@ -204,6 +205,21 @@ func TestGPGGit(t *testing.T) {
 				}
 				assert.Equal(t, "gitea@fake.local", branch.Commit.Verification.Signer.Email)
 			}))
+			t.Run("CheckMasterBranchSignatureVerification", func(t *testing.T) {
+				req := NewRequestf(t, "GET", "/api/v1/repos/%s/%s/git/commits/master/verification?token=%s", testCtx.Username, testCtx.Reponame, testCtx.Token)
+				resp := testCtx.Session.MakeRequest(t, req, http.StatusOK)
+				var verification api.CommitSignatureVerification
+				DecodeJSON(t, resp, &verification)
+				assert.True(t, verification.Verified)
+				assert.Equal(t, "trusted", verification.TrustStatus)
+				// the signing key of the instance is not owned by a user
+				assert.Nil(t, verification.Signer)
+				assert.Equal(t, "gitea@fake.local", verification.SignerIdentity.Email)
+				if assert.Len(t, verification.KeyChain, 1) {
+					assert.Equal(t, "gpg", verification.KeyChain[0].Type)
+					assert.NotEmpty(t, verification.KeyChain[0].KeyID)
+				}
+			})
 		})
 	}, false)
 	setting.Repository.Signing.CRUDActions = []string{"never"}
@@ -0,0 +1,100 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfidentialIssue(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// user2 owns the public repo1, user5 has no access to it besides reading
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session)
+	confidential := true
+	req := NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues/1?token="+token, &api.EditIssueOption{
+		Confidential: &confidential,
+	})
+	resp := session.MakeRequest(t, req, http.StatusCreated)
+	var apiIssue api.Issue
+	DecodeJSON(t, resp, &apiIssue)
+	assert.True(t, apiIssue.IsConfidential)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, IsConfidential: true})
+
+	session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1"), http.StatusOK)
+	session.MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1?token="+token), http.StatusOK)
+
+	other := loginUser(t, "user5")
+	otherToken := getTokenForLoggedInUser(t, other)
+	other.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1"), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1"), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1?token="+otherToken), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1/comments?token="+otherToken), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/comments/2?token="+otherToken), http.StatusNotFound)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues?state=all&type=issues&token="+otherToken), http.StatusOK)
+	var apiIssues []*api.Issue
+	DecodeJSON(t, resp, &apiIssues)
+	for _, issue := range apiIssues {
+		assert.NotEqualValues(t, 1, issue.Index)
+	}
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/comments?token="+otherToken), http.StatusOK)
+	var apiComments []*api.Comment
+	DecodeJSON(t, resp, &apiComments)
+	for _, comment := range apiComments {
+		assert.NotContains(t, []int64{2, 3}, comment.ID)
+	}
+
+	// neither is it shown as announcement, pinned issue or sub-issue
+	session.MakeRequest(t, NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/1/pin?token="+token, &api.PinIssueOption{
+		IsAnnouncement: true,
+	}), http.StatusNoContent)
+	session.MakeRequest(t, NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/4/sub_issues?token="+token, &api.AddSubIssueOption{
+		Index: 1,
+	}), http.StatusCreated)
+
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1"), http.StatusOK)
+	assert.EqualValues(t, 1, NewHTMLParser(t, resp.Body).Find(".repo-announcement").Length())
+	resp = other.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1"), http.StatusOK)
+	assert.EqualValues(t, 0, NewHTMLParser(t, resp.Body).Find(".repo-announcement").Length())
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/pinned?token="+otherToken), http.StatusOK)
+	var apiPins []*api.PinnedIssue
+	DecodeJSON(t, resp, &apiPins)
+	assert.Empty(t, apiPins)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/4/sub_issues?token="+otherToken), http.StatusOK)
+	DecodeJSON(t, resp, &apiIssues)
+	assert.Empty(t, apiIssues)
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/4/sub_issues/progress?token="+otherToken), http.StatusOK)
+	var apiProgress api.SubIssueProgress
+	DecodeJSON(t, resp, &apiProgress)
+	assert.Zero(t, apiProgress.Total)
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/4/sub_issues?token="+token), http.StatusOK)
+	DecodeJSON(t, resp, &apiIssues)
+	assert.Len(t, apiIssues, 1)
+
+	// the poster of a confidential issue keeps seeing it
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues?token="+otherToken, &api.CreateIssueOption{
+		Title:        "A security issue",
+		Confidential: true,
+	})
+	resp = MakeRequest(t, req, http.StatusCreated)
+	DecodeJSON(t, resp, &apiIssue)
+	assert.True(t, apiIssue.IsConfidential)
+	other.MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/user2/repo1/issues/%d", apiIssue.Index)), http.StatusOK)
+	MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/user2/repo1/issues/%d", apiIssue.Index)), http.StatusNotFound)
+}
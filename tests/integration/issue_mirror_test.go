@@ -0,0 +1,101 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueMirrorReadOnly(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the mirror is saved directly so that the test doesn't reach the external service
+	assert.NoError(t, issues_model.SaveIssueMirror(db.DefaultContext, &issues_model.IssueMirror{
+		RepoID:        1,
+		DoerID:        2,
+		ServiceType:   api.GithubService,
+		RemoteAddress: "https://github.com/owner/repo",
+	}))
+
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session)
+
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issue_mirror?token="+token), http.StatusOK)
+	var apiMirror api.IssueMirror
+	DecodeJSON(t, resp, &apiMirror)
+	assert.Equal(t, "github", apiMirror.Service)
+	assert.Equal(t, "https://github.com/owner/repo", apiMirror.RemoteAddress)
+	assert.Nil(t, apiMirror.LastSync)
+
+	// the issues can be read but neither opened nor changed
+	session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1"), http.StatusOK)
+	session.MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1?token="+token), http.StatusOK)
+	session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/new"), http.StatusSeeOther)
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues?token="+token, &api.CreateIssueOption{
+		Title: "local issue",
+	})
+	session.MakeRequest(t, req, http.StatusForbidden)
+	title := "changed locally"
+	req = NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues/1?token="+token, &api.EditIssueOption{
+		Title: title,
+	})
+	session.MakeRequest(t, req, http.StatusForbidden)
+	req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/1/comments?token="+token, &api.CreateIssueCommentOption{
+		Body: "local comment",
+	})
+	session.MakeRequest(t, req, http.StatusForbidden)
+	req = NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues/comments/2?token="+token, &api.EditIssueCommentOption{
+		Body: "changed locally",
+	})
+	session.MakeRequest(t, req, http.StatusForbidden)
+	req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/1/title", map[string]string{
+		"_csrf": GetCSRF(t, session, "/user2/repo1/issues/1"),
+		"title": title,
+	})
+	session.MakeRequest(t, req, http.StatusForbidden)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1}, "name <> '"+title+"'")
+
+	// the issues can be changed again once they aren't mirrored anymore
+	session.MakeRequest(t, NewRequest(t, "DELETE", "/api/v1/repos/user2/repo1/issue_mirror?token="+token), http.StatusNoContent)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueMirror{RepoID: 1})
+	req = NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues/1?token="+token, &api.EditIssueOption{
+		Title: title,
+	})
+	session.MakeRequest(t, req, http.StatusCreated)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, Title: title})
+}
+
+func TestAPISetIssueMirrorValidation(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session)
+
+	// OneDev has a downloader but its issues can't be mirrored
+	req := NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/repo1/issue_mirror?token="+token, &api.SetIssueMirrorOption{
+		Service:       "onedev",
+		RemoteAddress: "https://code.onedev.io/projects/onedev-server",
+	})
+	session.MakeRequest(t, req, http.StatusUnprocessableEntity)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueMirror{RepoID: 1})
+
+	// only the administrators of the repository can mirror its issues
+	other := loginUser(t, "user5")
+	otherToken := getTokenForLoggedInUser(t, other)
+	req = NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/repo1/issue_mirror?token="+otherToken, &api.SetIssueMirrorOption{
+		Service:       "github",
+		RemoteAddress: "https://github.com/owner/repo",
+	})
+	other.MakeRequest(t, req, http.StatusForbidden)
+}
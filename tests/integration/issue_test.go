@@ -557,3 +557,65 @@ func TestUpdateIssueDeadline(t *testing.T) {
 
 	assert.EqualValues(t, "2022-04-06", apiIssue.Deadline.Format("2006-01-02"))
 }
+
+func TestNewIssueFromForm(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	_, err := createFileInBranch(user2, repo1, ".gitea/ISSUE_TEMPLATE/bug.yaml", repo1.DefaultBranch, `name: Bug
+about: Report a bug
+body:
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      required: true
+      regex: "v[0-9]+"
+  - type: dropdown
+    id: os
+    attributes:
+      label: OS
+      options:
+        - Linux
+        - Windows
+    validations:
+      required: true
+`)
+	assert.NoError(t, err)
+
+	session := loginUser(t, user2.Name)
+	req := NewRequest(t, "GET", "/user2/repo1/issues/new?template=.gitea/ISSUE_TEMPLATE/bug.yaml")
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	assert.EqualValues(t, 1, htmlDoc.doc.Find(`input[name="form-field-version"]`).Length())
+
+	values := map[string]string{
+		"_csrf":              htmlDoc.GetCSRF(),
+		"title":              "issue from a form",
+		"template-file":      ".gitea/ISSUE_TEMPLATE/bug.yaml",
+		"form-field-version": "v1",
+	}
+
+	// the form is shown again with the submitted values when a field is invalid
+	req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/new", values)
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	assert.Contains(t, htmlDoc.doc.Find(".flash-error").Text(), `"OS" is required.`)
+	val, _ := htmlDoc.doc.Find(`input[name="form-field-version"]`).Attr("value")
+	assert.Equal(t, "v1", val)
+
+	values["form-field-version"] = "1.0"
+	values["form-field-os"] = "1"
+	req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/new", values)
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	assert.Contains(t, htmlDoc.doc.Find(".flash-error").Text(), `"Version" does not have the expected format.`)
+
+	values["form-field-version"] = "v1"
+	req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/new", values)
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo1.ID, Title: "issue from a form"})
+	assert.Contains(t, issue.Content, "Windows")
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIIssueVotes(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user5")
+	token := getTokenForLoggedInUser(t, session)
+	urlStr := "/api/v1/repos/user2/repo1/issues/4/votes?token=" + token
+
+	req := NewRequest(t, "POST", urlStr)
+	resp := session.MakeRequest(t, req, http.StatusCreated)
+	var apiIssue api.Issue
+	DecodeJSON(t, resp, &apiIssue)
+	assert.Equal(t, 1, apiIssue.Votes)
+	session.MakeRequest(t, req, http.StatusOK)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5, NumVotes: 1})
+
+	// the issue with the most votes first
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues?state=all&type=issues&sort=mostvotes"), http.StatusOK)
+	var apiIssues []*api.Issue
+	DecodeJSON(t, resp, &apiIssues)
+	if assert.Len(t, apiIssues, 2) {
+		assert.EqualValues(t, 1, apiIssues[0].Index)
+		assert.Equal(t, 2, apiIssues[0].Votes)
+		assert.EqualValues(t, 4, apiIssues[1].Index)
+	}
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1/votes"), http.StatusOK)
+	var voters []*api.User
+	DecodeJSON(t, resp, &voters)
+	if assert.Len(t, voters, 2) {
+		assert.Equal(t, "user4", voters[0].UserName)
+	}
+	assert.Equal(t, "2", resp.Header().Get("X-Total-Count"))
+
+	resp = session.MakeRequest(t, NewRequest(t, "DELETE", urlStr), http.StatusOK)
+	DecodeJSON(t, resp, &apiIssue)
+	assert.Equal(t, 0, apiIssue.Votes)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueVote{IssueID: 5})
+
+	MakeRequest(t, NewRequest(t, "POST", "/api/v1/repos/user2/repo1/issues/4/votes"), http.StatusUnauthorized)
+}
+
+func TestIssueVote(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user5")
+	req := NewRequestWithValues(t, "POST", "/user2/repo1/issues/4/vote", map[string]string{
+		"_csrf": GetCSRF(t, session, "/user2/repo1/issues/4"),
+		"vote":  "1",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueVote{IssueID: 5, UserID: 5})
+
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/4"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "1 Votes")
+
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues?state=all&sort=mostvotes"), http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	links := htmlDoc.doc.Find(".issue.list a.title").Map(func(_ int, s *goquery.Selection) string {
+		return s.AttrOr("href", "")
+	})
+	if assert.Len(t, links, 2) {
+		assert.Equal(t, "/user2/repo1/issues/1", links[0])
+	}
+
+	req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/4/vote", map[string]string{
+		"_csrf": GetCSRF(t, session, "/user2/repo1/issues/4"),
+		"vote":  "0",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueVote{IssueID: 5, UserID: 5})
+}
@@ -0,0 +1,173 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/routers/web/auth"
+	"code.gitea.io/gitea/services/auth/source/oauth2"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testOAuthClientID     = "da7da3ba-9a13-4167-856f-3899de0b0138"
+	testOAuthClientSecret = "4MK8Na6R55smdCY0WuCCumZ6hjRPnGY5saWVRHHjJiA="
+)
+
+func TestDeviceAuthorizationGrant(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the device only knows the client id
+	req := NewRequestWithValues(t, "POST", "/login/oauth/device_authorization", map[string]string{
+		"client_id": testOAuthClientID,
+		"scope":     "openid",
+	})
+	resp := MakeRequest(t, req, http.StatusOK)
+	device := new(auth.DeviceAuthorizationResponse)
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), device))
+	assert.NotEmpty(t, device.DeviceCode)
+	assert.Len(t, device.UserCode, 9)
+
+	poll := func(expectedStatus int) *auth.AccessTokenResponse {
+		req := NewRequestWithValues(t, "POST", "/login/oauth/access_token", map[string]string{
+			"grant_type":  auth.GrantTypeDeviceCode,
+			"client_id":   testOAuthClientID,
+			"device_code": device.DeviceCode,
+		})
+		resp := MakeRequest(t, req, expectedStatus)
+		token := new(auth.AccessTokenResponse)
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), token))
+		return token
+	}
+
+	req = NewRequestWithValues(t, "POST", "/login/oauth/access_token", map[string]string{
+		"grant_type":  auth.GrantTypeDeviceCode,
+		"client_id":   testOAuthClientID,
+		"device_code": device.DeviceCode,
+	})
+	resp = MakeRequest(t, req, http.StatusBadRequest)
+	assert.Contains(t, resp.Body.String(), "authorization_pending")
+
+	// the user enters the code and approves the device
+	session := loginUser(t, "user4")
+	req = NewRequest(t, "GET", device.VerificationURIComplete)
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	htmlDoc.AssertElement(t, "#authorize-device", true)
+	req = NewRequestWithValues(t, "POST", "/login/oauth/device", map[string]string{
+		"_csrf":     htmlDoc.GetCSRF(),
+		"user_code": device.UserCode,
+		"granted":   "true",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+
+	token := poll(http.StatusOK)
+	assert.NotEmpty(t, token.AccessToken)
+	assert.NotEmpty(t, token.RefreshToken)
+
+	// the device code can only be used once
+	poll(http.StatusBadRequest)
+}
+
+func TestTokenExchange(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	req := NewRequestWithValues(t, "POST", "/login/oauth/access_token", map[string]string{
+		"grant_type":    "authorization_code",
+		"client_id":     testOAuthClientID,
+		"client_secret": testOAuthClientSecret,
+		"redirect_uri":  "a",
+		"code":          "authcode",
+		"code_verifier": "N1Zo9-8Rfwhkt68r1r29ty8YwIraXR8eh_1Qwxg7yQXsonBt",
+	})
+	resp := MakeRequest(t, req, http.StatusOK)
+	subject := new(auth.AccessTokenResponse)
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), subject))
+
+	// another service of the same owner
+	target, err := auth_model.CreateOAuth2Application(db.DefaultContext, auth_model.CreateOAuth2ApplicationOptions{
+		Name:         "Backend",
+		UserID:       1,
+		RedirectURIs: []string{"b"},
+	})
+	assert.NoError(t, err)
+
+	exchange := func(values map[string]string, expectedStatus int) *auth.AccessTokenResponse {
+		params := map[string]string{
+			"grant_type":         auth.GrantTypeTokenExchange,
+			"client_id":          testOAuthClientID,
+			"client_secret":      testOAuthClientSecret,
+			"subject_token":      subject.AccessToken,
+			"subject_token_type": auth.TokenTypeIdentifierAccessToken,
+		}
+		for k, v := range values {
+			params[k] = v
+		}
+		resp := MakeRequest(t, NewRequestWithValues(t, "POST", "/login/oauth/access_token", params), expectedStatus)
+		token := new(auth.AccessTokenResponse)
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), token))
+		return token
+	}
+
+	// the scope of the issued tokens, the subject token has the scope "openid profile" of its grant
+	scopeOf := func(jwt string) string {
+		token, err := oauth2.ParseToken(jwt, oauth2.DefaultSigningKey)
+		assert.NoError(t, err)
+		if assert.NotNil(t, token.Scope) {
+			return *token.Scope
+		}
+		return ""
+	}
+
+	// the audience must have been authorized by the user
+	exchange(map[string]string{"audience": target.ClientID}, http.StatusBadRequest)
+	grant, err := target.GetGrantByUserID(db.DefaultContext, 1)
+	assert.NoError(t, err)
+	assert.Nil(t, grant)
+	_, err = target.CreateGrant(db.DefaultContext, 1, "openid email")
+	assert.NoError(t, err)
+
+	token := exchange(map[string]string{"audience": target.ClientID}, http.StatusOK)
+	assert.NotEmpty(t, token.AccessToken)
+	assert.Equal(t, auth.TokenTypeIdentifierAccessToken, token.IssuedTokenType)
+	assert.Equal(t, "openid", scopeOf(token.AccessToken))
+
+	// the scope is narrowed for the same client too, and stays narrowed when the token is refreshed
+	token = exchange(map[string]string{"scope": "openid"}, http.StatusOK)
+	assert.Equal(t, "openid", scopeOf(token.AccessToken))
+	req = NewRequestWithValues(t, "POST", "/login/oauth/access_token", map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     testOAuthClientID,
+		"client_secret": testOAuthClientSecret,
+		"refresh_token": token.RefreshToken,
+	})
+	resp = MakeRequest(t, req, http.StatusOK)
+	refreshed := new(auth.AccessTokenResponse)
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), refreshed))
+	assert.Equal(t, "openid", scopeOf(refreshed.AccessToken))
+
+	// the scope of the subject token and the one authorized for the audience can't be exceeded
+	exchange(map[string]string{"scope": "openid email"}, http.StatusBadRequest)
+	exchange(map[string]string{"audience": target.ClientID, "scope": "openid email"}, http.StatusBadRequest)
+	exchange(map[string]string{"audience": target.ClientID, "scope": "openid profile"}, http.StatusBadRequest)
+	// the client must authenticate
+	exchange(map[string]string{"client_secret": ""}, http.StatusBadRequest)
+	// refresh tokens can't be exchanged
+	exchange(map[string]string{"subject_token": subject.RefreshToken}, http.StatusBadRequest)
+	// the audience must belong to the same owner
+	other, err := auth_model.CreateOAuth2Application(db.DefaultContext, auth_model.CreateOAuth2ApplicationOptions{
+		Name:   "Other",
+		UserID: 2,
+	})
+	assert.NoError(t, err)
+	exchange(map[string]string{"audience": other.ClientID}, http.StatusBadRequest)
+}
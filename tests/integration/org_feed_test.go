@@ -0,0 +1,30 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/tests"
+)
+
+func TestOrgFeedFilters(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the teams and the private repositories of user3 are only visible to its members
+	MakeRequest(t, NewRequest(t, "GET", "/user3.rss?team=owners"), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", "/user3.rss?repo=repo3"), http.StatusNotFound)
+
+	session := loginUser(t, "user2")
+	session.MakeRequest(t, NewRequest(t, "GET", "/user3.rss?team=owners"), http.StatusOK)
+	session.MakeRequest(t, NewRequest(t, "GET", "/user3.atom?team=team1&repo=repo3&repo=repo5"), http.StatusOK)
+	session.MakeRequest(t, NewRequest(t, "GET", "/user3.rss?team=unknown"), http.StatusNotFound)
+	session.MakeRequest(t, NewRequest(t, "GET", "/user3.rss?repo=unknown"), http.StatusNotFound)
+
+	// a user who isn't a member can't filter by team
+	session = loginUser(t, "user5")
+	session.MakeRequest(t, NewRequest(t, "GET", "/user3.rss?team=owners"), http.StatusNotFound)
+}
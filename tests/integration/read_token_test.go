@@ -0,0 +1,71 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/lfs"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadTokenWrites(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	setting.LFS.StartServer = true
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	readToken := &auth_model.AccessToken{UID: user.ID, Name: "read-only", Scope: auth_model.AccessTokenScopeRead}
+	assert.NoError(t, auth_model.NewAccessToken(readToken))
+
+	content := []byte("read-only")
+	p := lfs.Pointer{Oid: "8f2a8d2ee9eebb7cc0e3d84ed9c2fd72abbff4a62b8ef1af3bf15ca1e1b44b89", Size: int64(len(content))}
+	newBatchRequest := func(operation string) *http.Request {
+		req := NewRequestWithJSON(t, "POST", "/user2/repo1.git/info/lfs/objects/batch", &lfs.BatchRequest{
+			Operation: operation,
+			Objects:   []lfs.Pointer{p},
+		})
+		req.Header.Set("Accept", lfs.MediaType)
+		req.Header.Set("Content-Type", lfs.MediaType)
+		req.SetBasicAuth(user.Name, readToken.Token)
+		return req
+	}
+
+	t.Run("WebWrites", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", "/user2/repo1.git/info/lfs/objects/"+p.Oid+"/9", bytes.NewReader(content))
+		req.SetBasicAuth(user.Name, readToken.Token)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithBody(t, "POST", "/user2/repo1.git/git-receive-pack", bytes.NewReader(nil))
+		req.SetBasicAuth(user.Name, readToken.Token)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		MakeRequest(t, newBatchRequest("upload"), http.StatusForbidden)
+	})
+
+	t.Run("APIWrites", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "POST", "/api/v1/user/repos?token="+readToken.Token, map[string]string{"name": "read-only"})
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("Reads", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		MakeRequest(t, newBatchRequest("download"), http.StatusOK)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/user?token="+readToken.Token), http.StatusOK)
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIAdminReplicationStatus(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer func() {
+		setting.Replication.Enabled = false
+	}()
+	setting.Replication.Enabled = true
+
+	assert.NoError(t, repo_model.MarkRepoReplicationPending(db.DefaultContext, 1))
+
+	token := getUserToken(t, "user1")
+	resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/replication?token="+token), http.StatusOK)
+	var status api.ReplicationStatus
+	DecodeJSON(t, resp, &status)
+	assert.True(t, status.Enabled)
+	assert.False(t, status.Standby)
+	assert.EqualValues(t, 1, status.PendingRepos)
+	assert.EqualValues(t, 0, status.FailingRepos)
+	assert.False(t, status.Lagging)
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/replication/repos?state=pending&token="+token), http.StatusOK)
+	var replications []*api.RepoReplication
+	DecodeJSON(t, resp, &replications)
+	if assert.Len(t, replications, 1) {
+		assert.Equal(t, "user2/repo1", replications[0].RepoFullName)
+		assert.NotNil(t, replications[0].PendingSince)
+		assert.Nil(t, replications[0].SyncedAt)
+	}
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/replication/repos?state=failing&token="+token), http.StatusOK)
+	DecodeJSON(t, resp, &replications)
+	assert.Empty(t, replications)
+
+	// only the administrators can get the state of the replication
+	MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/replication?token="+getUserToken(t, "user2")), http.StatusForbidden)
+}
+
+func TestReplicationStandbyPush(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		defer func() {
+			setting.Replication.Standby = false
+		}()
+		setting.Replication.Standby = true
+
+		u.Path = "user2/repo1.git"
+		u.User = url.UserPassword("user2", userPassword)
+		dstPath := t.TempDir()
+		t.Run("Clone", doGitClone(dstPath, u))
+		_, err := generateCommitWithNewData(littleSize, dstPath, "user2@example.com", "User Two", "standby-")
+		assert.NoError(t, err)
+
+		// only the replication of the primary instance, done by an administrator, can push to a standby
+		t.Run("PushRejected", doGitPushTestRepositoryFail(dstPath, "origin", "HEAD:master"))
+		u.User = url.UserPassword("user1", userPassword)
+		t.Run("AddAdminRemote", doGitAddRemote(dstPath, "admin", u))
+		t.Run("ForcePushAccepted", doGitPushTestRepository(dstPath, "--force", "admin", "HEAD:master"))
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoBranchFeed(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	commitLink := func(sha string) string {
+		return setting.AppURL + "user2/repo16/commit/" + sha
+	}
+
+	// user2/repo16 is private, its master branch has three commits
+	session := loginUser(t, "user2")
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo16/commits/branch/master.rss"), http.StatusNotFound)
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo16/commits/branch/master.rss"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/rss+xml")
+	assert.Equal(t, 3, strings.Count(resp.Body.String(), "<item>"))
+
+	// the feed is paged by the limit parameter and starts at the since parameter
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo16/commits/branch/master.atom?limit=2"), http.StatusOK)
+	body := resp.Body.String()
+	assert.Equal(t, 2, strings.Count(body, "<entry>"))
+	assert.Contains(t, body, "<id>"+commitLink("69554a64c1e6030f051e5c3f94bfbd773cd6a324")+"</id>")
+	assert.Contains(t, body, "<id>"+commitLink("27566bd5738fc8b4e3fef3c5e72cce608537bd95")+"</id>")
+	assert.Contains(t, body, "limit=2&amp;page=2")
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo16/commits/branch/master.atom?limit=2&page=2"), http.StatusOK)
+	body = resp.Body.String()
+	assert.Equal(t, 1, strings.Count(body, "<entry>"))
+	assert.Contains(t, body, "<id>"+commitLink("5099b81332712fe655e34e8dd63574f503f61811")+"</id>")
+
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo16/commits/branch/master.atom?since=2017-08-06T17:57:00Z"), http.StatusOK)
+	body = resp.Body.String()
+	assert.Equal(t, 2, strings.Count(body, "<entry>"))
+	assert.NotContains(t, body, "5099b81332712fe655e34e8dd63574f503f61811")
+	session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo16/commits/branch/master.atom?since=yesterday"), http.StatusBadRequest)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"path"
+	"strings"
 	"testing"
 
 	"code.gitea.io/gitea/modules/json"
@@ -115,3 +116,25 @@ func TestRepoCommitsWithStatusFailure(t *testing.T) {
 func TestRepoCommitsWithStatusWarning(t *testing.T) {
 	doTestRepoCommitWithStatus(t, "warning", "gitea-exclamation", "yellow")
 }
+
+func TestRepoCommitStatusFeed(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	sha := "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+	ctx := NewAPITestContext(t, "user2", "repo1")
+	t.Run("CreatePendingStatus", doAPICreateCommitStatus(ctx, sha, api.CommitStatusPending))
+	t.Run("CreateSuccessStatus", doAPICreateCommitStatus(ctx, sha, api.CommitStatusSuccess))
+
+	// only the finished runs are in the feed
+	session := loginUser(t, "user2")
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1.rss?types=workflow"), http.StatusOK)
+	body := resp.Body.String()
+	assert.Equal(t, 1, strings.Count(body, "<item>"))
+	assert.Contains(t, body, "testci")
+	assert.Contains(t, body, "succeeded on commit")
+	assert.Contains(t, body, "<link>http://test.ci/</link>")
+
+	t.Run("CreateFailureStatus", doAPICreateCommitStatus(ctx, sha, api.CommitStatusFailure))
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1.rss?types=workflow"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "failed on commit")
+}
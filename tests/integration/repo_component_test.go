@@ -0,0 +1,146 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/unittest"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoComponents(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	link := "/user2/repo1/settings/components"
+	req := NewRequest(t, "GET", link)
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	assert.EqualValues(t, 2, htmlDoc.doc.Find("table tbody tr").Length())
+
+	values := map[string]string{
+		"_csrf":         htmlDoc.GetCSRF(),
+		"name":          "Web",
+		"paths":         "web_src/**\r\n[invalid",
+		"owner_users":   "2",
+		"labels":        "2",
+		"notify_owners": "on",
+	}
+	req = NewRequestWithValues(t, "POST", link, values)
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	assert.Contains(t, htmlDoc.doc.Find(".flash-error").Text(), `The path "[invalid" is not a valid glob pattern.`)
+
+	values["paths"] = "web_src/**\r\ntemplates/**"
+	req = NewRequestWithValues(t, "POST", link, values)
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	c := unittest.AssertExistsAndLoadBean(t, &issues_model.Component{RepoID: 1, LowerName: "web"})
+	assert.Equal(t, []string{"web_src/**", "templates/**"}, c.Paths)
+	assert.Equal(t, []int64{2}, c.LabelIDs)
+	assert.True(t, c.NotifyOwners)
+
+	// the names are unique
+	values["name"] = "backend"
+	req = NewRequestWithValues(t, "POST", fmt.Sprintf("%s/%d", link, c.ID), values)
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	assert.Contains(t, htmlDoc.doc.Find(".flash-error").Text(), `The component "backend" already exists.`)
+
+	// the issues of the components can be browsed
+	req = NewRequest(t, "GET", "/user2/repo1/components")
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	assert.EqualValues(t, 1, htmlDoc.doc.Find(`a[href="/user2/repo1/issues?component=1"]`).Length())
+	req = NewRequest(t, "GET", "/user2/repo1/issues?component=1")
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	assert.EqualValues(t, 1, htmlDoc.doc.Find(".issue.list > li.item").Length())
+	assert.Contains(t, htmlDoc.doc.Find(".ui.info.message").Text(), `"Backend"`)
+	req = NewRequest(t, "GET", "/user2/repo1/issues?component=123")
+	session.MakeRequest(t, req, http.StatusNotFound)
+
+	req = NewRequestWithValues(t, "POST", link+"/delete", map[string]string{
+		"_csrf": values["_csrf"],
+		"id":    strconv.FormatInt(c.ID, 10),
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertNotExistsBean(t, &issues_model.Component{ID: c.ID})
+}
+
+func TestAPIRepoComponents(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session)
+	link := "/api/v1/repos/user2/repo1/components"
+
+	req := NewRequest(t, "GET", link)
+	resp := MakeRequest(t, req, http.StatusOK)
+	var components []*api.Component
+	DecodeJSON(t, resp, &components)
+	if assert.Len(t, components, 2) {
+		assert.Equal(t, "Backend", components[0].Name)
+		assert.Equal(t, 1, components[0].OpenIssues)
+		assert.Equal(t, 1, components[0].OpenPulls)
+	}
+
+	req = NewRequestWithJSON(t, "POST", link+"?token="+token, &api.CreateComponentOption{
+		Name:  "Web",
+		Paths: []string{"web_src/**"},
+	})
+	resp = MakeRequest(t, req, http.StatusCreated)
+	var component api.Component
+	DecodeJSON(t, resp, &component)
+	assert.Equal(t, "Web", component.Name)
+	assert.True(t, component.NotifyOwners)
+
+	req = NewRequestWithJSON(t, "POST", link+"?token="+token, &api.CreateComponentOption{
+		Name:  "web",
+		Paths: []string{"web_src/**"},
+	})
+	MakeRequest(t, req, http.StatusConflict)
+
+	notify := false
+	req = NewRequestWithJSON(t, "PATCH", link+"/web?token="+token, &api.EditComponentOption{
+		Paths:        []string{"[invalid"},
+		NotifyOwners: &notify,
+	})
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+	req = NewRequestWithJSON(t, "PATCH", link+"/web?token="+token, &api.EditComponentOption{
+		LabelIDs:     []int64{1},
+		NotifyOwners: &notify,
+	})
+	resp = MakeRequest(t, req, http.StatusOK)
+	DecodeJSON(t, resp, &component)
+	assert.Equal(t, []int64{1}, component.LabelIDs)
+	assert.False(t, component.NotifyOwners)
+
+	// only the admins of the repository manage its components
+	token4 := getTokenForLoggedInUser(t, loginUser(t, "user4"))
+	req = NewRequest(t, "DELETE", fmt.Sprintf("%s/%d?token=%s", link, component.ID, token4))
+	MakeRequest(t, req, http.StatusForbidden)
+	req = NewRequest(t, "DELETE", fmt.Sprintf("%s/%d?token=%s", link, component.ID, token))
+	MakeRequest(t, req, http.StatusNoContent)
+	unittest.AssertNotExistsBean(t, &issues_model.Component{ID: component.ID})
+
+	// the issues can be filtered by component
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues?state=all&type=issues&component=docs")
+	resp = MakeRequest(t, req, http.StatusOK)
+	var issues []*api.Issue
+	DecodeJSON(t, resp, &issues)
+	if assert.Len(t, issues, 1) {
+		assert.EqualValues(t, 5, issues[0].ID)
+	}
+	req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues?component=unknown")
+	MakeRequest(t, req, http.StatusNotFound)
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	issue_service "code.gitea.io/gitea/services/issue"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoIssueSchedules(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	_, err := createFileInBranch(user2, repo1, ".gitea/ISSUE_TEMPLATE/release.md", repo1.DefaultBranch, `---
+name: Release
+about: Release checklist
+---
+- [ ] write the changelog
+`)
+	assert.NoError(t, err)
+
+	session := loginUser(t, user2.Name)
+	link := "/user2/repo1/settings/issue_schedules"
+	req := NewRequest(t, "GET", link)
+	resp := session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	assert.EqualValues(t, 2, htmlDoc.doc.Find("table tbody tr").Length())
+	assert.EqualValues(t, 1, htmlDoc.doc.Find(`.menu .item[data-value=".gitea/ISSUE_TEMPLATE/release.md"]`).Length())
+
+	values := map[string]string{
+		"_csrf":         htmlDoc.GetCSRF(),
+		"title":         "Weekly release",
+		"template_file": ".gitea/ISSUE_TEMPLATE/release.md",
+		"schedule":      "every monday",
+		"assignees":     "2",
+		"labels":        "1,2",
+		"is_active":     "on",
+	}
+	req = NewRequestWithValues(t, "POST", link, values)
+	resp = session.MakeRequest(t, req, http.StatusOK)
+	htmlDoc = NewHTMLParser(t, resp.Body)
+	assert.Contains(t, htmlDoc.doc.Find(".flash-error").Text(), "The schedule is invalid")
+	unittest.AssertNotExistsBean(t, &issues_model.IssueSchedule{Title: "Weekly release"})
+
+	values["schedule"] = "0 9 * * 1"
+	req = NewRequestWithValues(t, "POST", link, values)
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	s := unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSchedule{RepoID: repo1.ID, Title: "Weekly release"})
+	assert.EqualValues(t, user2.ID, s.CreatorID)
+	assert.Equal(t, []int64{1, 2}, s.LabelIDs)
+	assert.True(t, s.IsActive)
+
+	// the content comes from the template of the default branch
+	issue, err := issue_service.CreateScheduledIssue(db.DefaultContext, s)
+	assert.NoError(t, err)
+	if assert.NotNil(t, issue) {
+		assert.Equal(t, "Weekly release", issue.Title)
+		assert.Contains(t, issue.Content, "- [ ] write the changelog")
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: issue.ID, AssigneeID: 2})
+	}
+
+	values["title"] = "Biweekly release"
+	delete(values, "is_active")
+	req = NewRequestWithValues(t, "POST", fmt.Sprintf("%s/%d", link, s.ID), values)
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	s = unittest.AssertExistsAndLoadBean(t, &issues_model.IssueSchedule{ID: s.ID})
+	assert.Equal(t, "Biweekly release", s.Title)
+	assert.False(t, s.IsActive)
+
+	// the schedules of other repositories can't be changed
+	req = NewRequestWithValues(t, "POST", fmt.Sprintf("/user2/repo2/settings/issue_schedules/%d", s.ID), values)
+	session.MakeRequest(t, req, http.StatusNotFound)
+
+	req = NewRequestWithValues(t, "POST", link+"/delete", map[string]string{
+		"_csrf": values["_csrf"],
+		"id":    strconv.FormatInt(s.ID, 10),
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertNotExistsBean(t, &issues_model.IssueSchedule{ID: s.ID})
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoIssuesFeed(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues.atom"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/atom+xml")
+	body := resp.Body.String()
+	assert.Contains(t, body, "/user2/repo1/issues/1</id>")
+	assert.Contains(t, body, "/user2/repo1/issues/4</id>")
+	assert.Contains(t, body, `<category term="label1"></category>`)
+	// the items link to the profiles of their authors and show their avatars
+	assert.Contains(t, body, "<uri>"+setting.AppURL+"user1</uri>")
+	assert.Contains(t, body, "<media:thumbnail url=")
+	// pull requests aren't issues
+	assert.NotContains(t, body, "/user2/repo1/issues/2</id>")
+
+	// only the issues with one of the labels
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues.atom?labels=label1,unknown"), http.StatusOK)
+	body = resp.Body.String()
+	assert.Contains(t, body, "/user2/repo1/issues/1</id>")
+	assert.NotContains(t, body, "/user2/repo1/issues/4</id>")
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues.rss?labels=label2"), http.StatusOK)
+	body = resp.Body.String()
+	assert.Contains(t, body, "<category>label2</category>")
+	assert.NotContains(t, body, "<category>label1</category>")
+
+	// a reopened issue is a new item replying to the issue
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session)
+	for _, state := range []string{"closed", "open"} {
+		req := NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues/1?token="+token, map[string]string{"state": state})
+		MakeRequest(t, req, http.StatusCreated)
+	}
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues.atom?labels=label1"), http.StatusOK)
+	body = resp.Body.String()
+	assert.Equal(t, 1, strings.Count(body, "<thr:in-reply-to "))
+	assert.Contains(t, body, "/user2/repo1/issues/1#issuecomment-")
+
+	// neither the opening nor the reopening of a confidential issue is shown to anonymous readers
+	req := NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues/1?token="+token, map[string]bool{"confidential": true})
+	MakeRequest(t, req, http.StatusCreated)
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues.atom"), http.StatusOK)
+	body = resp.Body.String()
+	assert.NotContains(t, body, "/user2/repo1/issues/1</id>")
+	assert.NotContains(t, body, "/user2/repo1/issues/1#issuecomment-")
+	assert.Zero(t, strings.Count(body, "<thr:in-reply-to "))
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues.rss"), http.StatusOK)
+	assert.NotContains(t, resp.Body.String(), "/user2/repo1/issues/1#issuecomment-")
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues.atom"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "/user2/repo1/issues/1#issuecomment-")
+
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo2/issues.atom"), http.StatusNotFound)
+}
+
+func TestIssueFeed(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the opening post of issue1 and its two comments, the label change isn't an item, newest first
+	resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1.rss"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/rss+xml")
+	body := resp.Body.String()
+	assert.Equal(t, 3, strings.Count(body, "<item>"))
+	comment3 := strings.Index(body, "<link>"+setting.AppURL+"user2/repo1/issues/1#issuecomment-3</link>")
+	comment2 := strings.Index(body, "<link>"+setting.AppURL+"user2/repo1/issues/1#issuecomment-2</link>")
+	opening := strings.Index(body, "<link>"+setting.AppURL+"user2/repo1/issues/1</link>")
+	assert.True(t, comment3 >= 0 && comment2 > comment3 && opening > comment2, "items in the wrong order")
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1.atom"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/atom+xml")
+	body = resp.Body.String()
+	assert.Equal(t, 3, strings.Count(body, "<entry>"))
+	assert.Contains(t, body, "/user2/repo1/issues/1#issuecomment-2</id>")
+	assert.Contains(t, body, "/user2/repo1/issues/1#issuecomment-3</id>")
+
+	// the feed is paged by the limit parameter, the opening post being the oldest item
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1.atom?limit=2"), http.StatusOK)
+	body = resp.Body.String()
+	assert.Equal(t, 2, strings.Count(body, "<entry>"))
+	assert.Contains(t, body, "/user2/repo1/issues/1#issuecomment-3</id>")
+	assert.Contains(t, body, "/user2/repo1/issues/1#issuecomment-2</id>")
+	assert.Contains(t, body, "limit=2&amp;page=2")
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1.atom?limit=2&page=2"), http.StatusOK)
+	body = resp.Body.String()
+	assert.Equal(t, 1, strings.Count(body, "<entry>"))
+	assert.Contains(t, body, "/user2/repo1/issues/1</id>")
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1.rss?since=2099-01-01T00:00:00Z"), http.StatusOK)
+	assert.Zero(t, strings.Count(resp.Body.String(), "<item>"))
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1.rss?since=yesterday"), http.StatusBadRequest)
+
+	// the feed of a confidential issue is hidden like the issue
+	session := loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session)
+	req := NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues/1?token="+token, map[string]bool{"confidential": true})
+	MakeRequest(t, req, http.StatusCreated)
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1.rss"), http.StatusNotFound)
+	loginUser(t, "user5").MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1.atom"), http.StatusNotFound)
+	session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/1.rss"), http.StatusOK)
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoReleasesFeed(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	releaseLink := func(tag string) string {
+		return setting.AppURL + "user2/repo1/releases/tag/" + tag
+	}
+
+	// the release v1.1 and the pre-release v1.0, neither the draft nor the plain tag
+	resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/releases.rss"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/rss+xml")
+	body := resp.Body.String()
+	assert.Equal(t, 2, strings.Count(body, "<item>"))
+	assert.Contains(t, body, "<link>"+releaseLink("v1.1")+"</link>")
+	assert.Contains(t, body, "<link>"+releaseLink("v1.0")+"</link>")
+	assert.Contains(t, body, "some text for a pre release")
+	assert.NotContains(t, body, "draft-release")
+	assert.NotContains(t, body, "delete-tag")
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/releases.atom"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/atom+xml")
+	body = resp.Body.String()
+	assert.Equal(t, 2, strings.Count(body, "<entry>"))
+	assert.Contains(t, body, "<id>"+releaseLink("v1.1")+"</id>")
+	assert.Contains(t, body, "<id>"+releaseLink("v1.0")+"</id>")
+	assert.NotContains(t, body, "draft-release")
+
+	// the drafts stay out of the feed of their writers too
+	session := loginUser(t, "user2")
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/releases.atom"), http.StatusOK)
+	assert.NotContains(t, resp.Body.String(), "draft-release")
+
+	// the feed is paged by the limit parameter and starts at the since parameter
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/releases.atom?limit=1"), http.StatusOK)
+	body = resp.Body.String()
+	assert.Equal(t, 1, strings.Count(body, "<entry>"))
+	assert.Contains(t, body, "limit=1&amp;page=2")
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/releases.atom?limit=1&page=2"), http.StatusOK)
+	assert.Equal(t, 1, strings.Count(resp.Body.String(), "<entry>"))
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/releases.rss?since=2000-01-01T00:00:00Z"), http.StatusOK)
+	assert.Equal(t, 2, strings.Count(resp.Body.String(), "<item>"))
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/releases.rss?since=2000-01-02T00:00:00Z"), http.StatusOK)
+	assert.Zero(t, strings.Count(resp.Body.String(), "<item>"))
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/releases.rss?since=yesterday"), http.StatusBadRequest)
+
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo2/releases.rss"), http.StatusNotFound)
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	advisory_model "code.gitea.io/gitea/models/advisory"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	advisory_service "code.gitea.io/gitea/services/advisory"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoSecurityFeed(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	newAdvisory := func(title string) *advisory_model.Advisory {
+		adv := &advisory_model.Advisory{
+			Title:       title,
+			Description: "Upgrade to **1.2.4**",
+			Severity:    advisory_model.SeverityHigh,
+			CVEID:       "CVE-2022-12345",
+			CWEIDs:      []string{"CWE-79"},
+		}
+		assert.NoError(t, advisory_service.CreateDraft(db.DefaultContext, repo, doer, adv))
+		return adv
+	}
+	published := newAdvisory("Cross site scripting in the renderer")
+	assert.NoError(t, advisory_service.Publish(db.DefaultContext, doer, published))
+	newAdvisory("Unpublished vulnerability")
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/security.atom"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/atom+xml")
+	body := resp.Body.String()
+	assert.Contains(t, body, "<title>Cross site scripting in the renderer</title>")
+	assert.Contains(t, body, published.HTMLURL())
+	assert.Contains(t, body, `<category term="CVE-2022-12345"`)
+	assert.Contains(t, body, "&lt;strong&gt;1.2.4&lt;/strong&gt;")
+	assert.NotContains(t, body, "Unpublished vulnerability")
+
+	// the advisories of a private repository are only readable by its readers
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo2/security.rss"), http.StatusNotFound)
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoTagsFeed(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	tagLink := func(tag string) string {
+		return setting.AppURL + "user2/repo1/src/tag/" + tag
+	}
+
+	// the tags of the releases v1.1 and v1.0 and the plain tag, not the tag of the draft
+	resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/tags.rss"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/rss+xml")
+	body := resp.Body.String()
+	assert.Equal(t, 3, strings.Count(body, "<item>"))
+	for _, tag := range []string{"v1.1", "v1.0", "delete-tag"} {
+		assert.Contains(t, body, "<link>"+tagLink(tag)+"</link>")
+	}
+	assert.NotContains(t, body, "draft-release")
+	// the items link to the tagged commit, and to the release of the tag if there is one
+	assert.Contains(t, body, setting.AppURL+"user2/repo1/commit/65f1bf27bc3bf70f64657658635e66094edbcb4d")
+	assert.Contains(t, body, setting.AppURL+"user2/repo1/releases/tag/v1.1")
+	assert.NotContains(t, body, setting.AppURL+"user2/repo1/releases/tag/delete-tag")
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/tags.atom"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/atom+xml")
+	body = resp.Body.String()
+	assert.Equal(t, 3, strings.Count(body, "<entry>"))
+	for _, tag := range []string{"v1.1", "v1.0", "delete-tag"} {
+		assert.Contains(t, body, "<id>"+tagLink(tag)+"</id>")
+	}
+	assert.NotContains(t, body, "draft-release")
+
+	// the feed is paged by the limit parameter and starts at the since parameter
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/tags.atom?limit=2"), http.StatusOK)
+	body = resp.Body.String()
+	assert.Equal(t, 2, strings.Count(body, "<entry>"))
+	assert.Contains(t, body, "limit=2&amp;page=2")
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/tags.atom?limit=2&page=2"), http.StatusOK)
+	body = resp.Body.String()
+	assert.Equal(t, 1, strings.Count(body, "<entry>"))
+	assert.NotContains(t, body, "page=3")
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/tags.rss?since=2000-01-02T00:00:00Z"), http.StatusOK)
+	assert.Zero(t, strings.Count(resp.Body.String(), "<item>"))
+
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo2/tags.rss"), http.StatusNotFound)
+}
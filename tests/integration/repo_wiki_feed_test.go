@@ -0,0 +1,37 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoWikiFeed(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/wiki.atom"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/atom+xml")
+	body := resp.Body.String()
+	assert.Contains(t, body, "<title>add unescaped file</title>")
+	assert.Contains(t, body, "/user2/repo1/wiki/commit/0dca5bd9b5d7ef937710e056f575e86c0184ba85")
+	assert.Contains(t, body, "Added Unescaped File; 3 additions, 0 deletions")
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/wiki.rss?limit=2"), http.StatusOK)
+	body = resp.Body.String()
+	assert.Equal(t, 2, strings.Count(body, "<item>"))
+	assert.Contains(t, body, `rel="next"`)
+
+	// a repository without wiki pages has an empty feed
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user5/repo4/wiki.atom"), http.StatusOK)
+	assert.NotContains(t, resp.Body.String(), "<entry>")
+
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo2/wiki.atom"), http.StatusNotFound)
+}
@@ -0,0 +1,62 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDesk(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the private repo2 of user2, user4 has no access to it
+	issuesUnit := unittest.AssertExistsAndLoadBean(t, &repo_model.RepoUnit{RepoID: 2, Type: unit.TypeIssues})
+	issuesUnit.IssuesConfig().EnableServiceDesk = true
+	assert.NoError(t, repo_model.UpdateRepoUnit(issuesUnit))
+
+	session := loginUser(t, "user4")
+	session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo2/issues"), http.StatusNotFound)
+	session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/service_desk"), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", "/user2/repo2/service_desk"), http.StatusSeeOther)
+
+	csrf := GetCSRF(t, session, "/user2/repo2/service_desk")
+	req := NewRequestWithValues(t, "POST", "/user2/repo2/service_desk", map[string]string{
+		"_csrf":   csrf,
+		"title":   "The printer is on fire",
+		"content": "Again.",
+	})
+	resp := session.MakeRequest(t, req, http.StatusSeeOther)
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 2, PosterID: 4, Title: "The printer is on fire"})
+	link := test.RedirectURL(resp)
+	assert.Equal(t, issue.ServiceDeskLink(), link)
+
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo2/service_desk"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "The printer is on fire")
+	assert.NotContains(t, resp.Body.String(), "issue4")
+
+	req = NewRequestWithValues(t, "POST", link+"/comments", map[string]string{
+		"_csrf":   csrf,
+		"content": "It's out now.",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: issue.ID, PosterID: 4, Content: "It's out now."})
+
+	resp = session.MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "It&#39;s out now.")
+
+	// the other issues aren't visible, neither to the other users
+	session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo2/service_desk/1"), http.StatusNotFound)
+	loginUser(t, "user5").MakeRequest(t, NewRequest(t, "GET", link), http.StatusNotFound)
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearchFeed(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	csrf := GetCSRF(t, session, "/user/settings/searches")
+	req := NewRequestWithValues(t, "POST", "/user/settings/searches", map[string]string{
+		"_csrf":  csrf,
+		"action": "save",
+		"name":   "First issues",
+		"type":   "issue",
+		"q":      "first",
+		"repo":   "user2/repo1",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	search := unittest.AssertExistsAndLoadBean(t, &user_model.SavedSearch{UserID: 2, Name: "First issues"})
+	assert.Equal(t, user_model.SavedSearchTypeIssue, search.Type)
+	assert.EqualValues(t, 1, search.RepoID)
+
+	// a repository which can't be read can't be searched
+	session4 := loginUser(t, "user4")
+	req = NewRequestWithValues(t, "POST", "/user/settings/searches", map[string]string{
+		"_csrf":  GetCSRF(t, session4, "/user/settings/searches"),
+		"action": "save",
+		"name":   "Private",
+		"type":   "issue",
+		"q":      "first",
+		"repo":   "user2/repo2",
+	})
+	session4.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertNotExistsBean(t, &user_model.SavedSearch{Name: "Private"})
+
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/settings/searches"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), fmt.Sprintf("/user/searches/%d.atom", search.ID))
+
+	// the first generation lists all the matches, the next ones only the new matches
+	feedURL := fmt.Sprintf("/user/searches/%d.atom", search.ID)
+	resp = session.MakeRequest(t, NewRequest(t, "GET", feedURL), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/atom+xml")
+	assert.Contains(t, resp.Body.String(), "/user2/repo1/issues/1</id>")
+	resp = session.MakeRequest(t, NewRequest(t, "GET", feedURL), http.StatusOK)
+	assert.NotContains(t, resp.Body.String(), "/user2/repo1/issues/1</id>")
+	search = unittest.AssertExistsAndLoadBean(t, &user_model.SavedSearch{ID: search.ID})
+	assert.NotZero(t, search.LastGeneratedUnix)
+
+	// the feed of a search is only readable by its owner
+	session4.MakeRequest(t, NewRequest(t, "GET", feedURL), http.StatusNotFound)
+	MakeRequest(t, NewRequest(t, "GET", feedURL), http.StatusSeeOther)
+
+	req = NewRequestWithValues(t, "POST", "/user/settings/searches", map[string]string{
+		"_csrf":  csrf,
+		"action": "delete",
+		"id":     fmt.Sprint(search.ID),
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertNotExistsBean(t, &user_model.SavedSearch{ID: search.ID})
+	session.MakeRequest(t, NewRequest(t, "GET", feedURL), http.StatusNotFound)
+}
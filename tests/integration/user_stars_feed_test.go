@@ -0,0 +1,38 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserStarsFeed(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user4")
+	MakeRequest(t, NewRequest(t, "PUT", "/api/v1/user/starred/user2/repo1?token="+token), http.StatusNoContent)
+	MakeRequest(t, NewRequest(t, "PUT", "/api/v1/user/starred/user2/repo2?token="+token), http.StatusNotFound)
+
+	resp := MakeRequest(t, NewRequest(t, "GET", "/user4/stars.atom"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/atom+xml")
+	body := resp.Body.String()
+	assert.Contains(t, body, "/user2/repo1")
+	assert.NotContains(t, body, "/user10/repo8")
+
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user4/stars.rss"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), "/user2/repo1")
+
+	// an unstarred repository leaves the feed
+	MakeRequest(t, NewRequest(t, "DELETE", "/api/v1/user/starred/user2/repo1?token="+token), http.StatusNoContent)
+	resp = MakeRequest(t, NewRequest(t, "GET", "/user4/stars.atom"), http.StatusOK)
+	assert.NotContains(t, resp.Body.String(), "/user2/repo1")
+
+	MakeRequest(t, NewRequest(t, "GET", "/user3/stars.atom"), http.StatusNotFound)
+}
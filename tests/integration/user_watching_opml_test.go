@@ -0,0 +1,36 @@
+// Copyright 2022 The Gitea Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserWatchingOPML(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user4")
+	resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/watching.opml"), http.StatusOK)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "text/x-opml")
+	body := resp.Body.String()
+	assert.Contains(t, body, `<opml version="2.0">`)
+	assert.Contains(t, body, `xmlUrl="`+setting.AppURL+`user2/repo1.atom"`)
+	assert.Contains(t, body, `htmlUrl="`+setting.AppURL+`user2/repo1"`)
+
+	// the private repositories the user watches are exported too
+	session = loginUser(t, "user2")
+	token := getTokenForLoggedInUser(t, session)
+	MakeRequest(t, NewRequest(t, "PUT", "/api/v1/repos/user2/repo2/subscription?token="+token), http.StatusOK)
+	resp = session.MakeRequest(t, NewRequest(t, "GET", "/user/watching.opml"), http.StatusOK)
+	assert.Contains(t, resp.Body.String(), `xmlUrl="`+setting.AppURL+`user2/repo2.atom"`)
+
+	MakeRequest(t, NewRequest(t, "GET", "/user/watching.opml"), http.StatusSeeOther)
+}
@@ -345,6 +345,37 @@ export function initRepoIssueWipTitle() {
   });
 }
 
+export function initRepoIssueSimilar() {
+  const $similar = $('#similar-issues');
+  if (!$similar.length) return;
+
+  const $list = $similar.find('.list');
+  let timer = null;
+  let lastTitle = '';
+  $('#issue_title').on('input', function () {
+    clearTimeout(timer);
+    timer = setTimeout(async () => {
+      const title = $(this).val().trim();
+      if (title === lastTitle) return;
+      lastTitle = title;
+      if (title.length < 3) {
+        $similar.addClass('hide');
+        return;
+      }
+
+      const issues = await $.get($similar.data('url'), {title});
+      // the title has changed while the issues were searched
+      if (title !== lastTitle) return;
+      $list.empty();
+      for (const issue of issues) {
+        const state = issue.state === 'closed' ? $similar.data('closed') : $similar.data('open');
+        $list.append(`<li><a href="${htmlEscape(issue.html_url)}" target="_blank">#${issue.number} ${htmlEscape(issue.title)}</a> <span class="ui mini basic label">${htmlEscape(state)}</span></li>`);
+      }
+      $similar.toggleClass('hide', issues.length === 0);
+    }, 500);
+  });
+}
+
 export async function updateIssuesMeta(url, action, issueIds, elementId) {
   return $.ajax({
     type: 'POST',
@@ -36,6 +36,7 @@ import {
   initRepoIssueReferenceRepositorySearch,
   initRepoIssueTimeTracking,
   initRepoIssueWipTitle,
+  initRepoIssueSimilar,
   initRepoPullRequestMergeInstruction,
   initRepoPullRequestAllowMaintainerEdit,
   initRepoPullRequestReview,
@@ -166,6 +167,7 @@ $(document).ready(() => {
   initRepoIssueReferenceRepositorySearch();
   initRepoIssueTimeTracking();
   initRepoIssueWipTitle();
+  initRepoIssueSimilar();
   initRepoMigration();
   initRepoMigrationStatusChecker();
   initRepoProject();